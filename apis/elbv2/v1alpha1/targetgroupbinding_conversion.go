@@ -0,0 +1,182 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// annotationConversionData preserves fields of the Hub version that cannot be represented by v1alpha1,
+// so that they survive round trips through v1alpha1.
+const annotationConversionData = "elbv2.k8s.aws/v1beta1-conversion-data"
+
+// hubOnlySpecFields are the fields of Hub version spec that don't exist in v1alpha1.
+type hubOnlySpecFields struct {
+	IPAddressType          *v1beta1.TargetGroupIPAddressType `json:"ipAddressType,omitempty"`
+	VpcID                  string                            `json:"vpcID,omitempty"`
+	IAMRoleARNToAssume     string                            `json:"iamRoleARNToAssume,omitempty"`
+	NodeSelector           *metav1.LabelSelector             `json:"nodeSelector,omitempty"`
+	MaxTargets             *int64                            `json:"maxTargets,omitempty"`
+	TargetOverflowStrategy *v1beta1.TargetOverflowStrategy   `json:"targetOverflowStrategy,omitempty"`
+	ZoneBalancing          *v1beta1.TargetZoneBalancing      `json:"zoneBalancing,omitempty"`
+	TargetGroupAttributes  []v1beta1.TargetGroupAttribute    `json:"targetGroupAttributes,omitempty"`
+}
+
+// ConvertTo converts this TargetGroupBinding to the Hub version (v1beta1).
+func (src *TargetGroupBinding) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.TargetGroupBinding)
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	dst.Spec = v1beta1.TargetGroupBindingSpec{
+		TargetGroupARN: src.Spec.TargetGroupARN,
+		TargetType:     (*v1beta1.TargetType)(src.Spec.TargetType),
+		ServiceRef: v1beta1.ServiceReference{
+			Name: src.Spec.ServiceRef.Name,
+			Port: src.Spec.ServiceRef.Port,
+		},
+		Networking: convertNetworkingToHub(src.Spec.Networking),
+	}
+	dst.Status = v1beta1.TargetGroupBindingStatus{
+		ObservedGeneration: src.Status.ObservedGeneration,
+	}
+
+	rawConversionData, exists := dst.Annotations[annotationConversionData]
+	if !exists {
+		return nil
+	}
+	delete(dst.Annotations, annotationConversionData)
+	var fields hubOnlySpecFields
+	if err := json.Unmarshal([]byte(rawConversionData), &fields); err != nil {
+		return errors.Wrapf(err, "failed to parse annotation %v", annotationConversionData)
+	}
+	dst.Spec.IPAddressType = fields.IPAddressType
+	dst.Spec.VpcID = fields.VpcID
+	dst.Spec.IAMRoleARNToAssume = fields.IAMRoleARNToAssume
+	dst.Spec.NodeSelector = fields.NodeSelector
+	dst.Spec.MaxTargets = fields.MaxTargets
+	dst.Spec.TargetOverflowStrategy = fields.TargetOverflowStrategy
+	dst.Spec.ZoneBalancing = fields.ZoneBalancing
+	dst.Spec.TargetGroupAttributes = fields.TargetGroupAttributes
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *TargetGroupBinding) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.TargetGroupBinding)
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	dst.Spec = TargetGroupBindingSpec{
+		TargetGroupARN: src.Spec.TargetGroupARN,
+		TargetType:     (*TargetType)(src.Spec.TargetType),
+		ServiceRef: ServiceReference{
+			Name: src.Spec.ServiceRef.Name,
+			Port: src.Spec.ServiceRef.Port,
+		},
+		Networking: convertNetworkingFromHub(src.Spec.Networking),
+	}
+	dst.Status = TargetGroupBindingStatus{
+		ObservedGeneration: src.Status.ObservedGeneration,
+	}
+
+	fields := hubOnlySpecFields{
+		IPAddressType:          src.Spec.IPAddressType,
+		VpcID:                  src.Spec.VpcID,
+		IAMRoleARNToAssume:     src.Spec.IAMRoleARNToAssume,
+		NodeSelector:           src.Spec.NodeSelector.DeepCopy(),
+		MaxTargets:             src.Spec.MaxTargets,
+		TargetOverflowStrategy: src.Spec.TargetOverflowStrategy,
+		ZoneBalancing:          src.Spec.ZoneBalancing.DeepCopy(),
+		TargetGroupAttributes:  src.Spec.DeepCopy().TargetGroupAttributes,
+	}
+	if equality.Semantic.DeepEqual(fields, hubOnlySpecFields{}) {
+		return nil
+	}
+	rawConversionData, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	if dst.Annotations == nil {
+		dst.Annotations = make(map[string]string)
+	}
+	dst.Annotations[annotationConversionData] = string(rawConversionData)
+	return nil
+}
+
+func convertNetworkingToHub(networking *TargetGroupBindingNetworking) *v1beta1.TargetGroupBindingNetworking {
+	if networking == nil {
+		return nil
+	}
+	hubNetworking := &v1beta1.TargetGroupBindingNetworking{}
+	for _, rule := range networking.Ingress {
+		hubRule := v1beta1.NetworkingIngressRule{
+			From:  make([]v1beta1.NetworkingPeer, 0, len(rule.From)),
+			Ports: make([]v1beta1.NetworkingPort, 0, len(rule.Ports)),
+		}
+		for _, peer := range rule.From {
+			hubPeer := v1beta1.NetworkingPeer{}
+			if peer.IPBlock != nil {
+				hubPeer.IPBlock = &v1beta1.IPBlock{CIDR: peer.IPBlock.CIDR}
+			}
+			if peer.SecurityGroup != nil {
+				hubPeer.SecurityGroup = &v1beta1.SecurityGroup{GroupID: peer.SecurityGroup.GroupID}
+			}
+			hubRule.From = append(hubRule.From, hubPeer)
+		}
+		for _, port := range rule.Ports {
+			hubRule.Ports = append(hubRule.Ports, v1beta1.NetworkingPort{
+				Protocol: (*v1beta1.NetworkingProtocol)(port.Protocol),
+				Port:     port.Port,
+			})
+		}
+		hubNetworking.Ingress = append(hubNetworking.Ingress, hubRule)
+	}
+	return hubNetworking
+}
+
+func convertNetworkingFromHub(hubNetworking *v1beta1.TargetGroupBindingNetworking) *TargetGroupBindingNetworking {
+	if hubNetworking == nil {
+		return nil
+	}
+	networking := &TargetGroupBindingNetworking{}
+	for _, hubRule := range hubNetworking.Ingress {
+		rule := NetworkingIngressRule{
+			From:  make([]NetworkingPeer, 0, len(hubRule.From)),
+			Ports: make([]NetworkingPort, 0, len(hubRule.Ports)),
+		}
+		for _, hubPeer := range hubRule.From {
+			peer := NetworkingPeer{}
+			if hubPeer.IPBlock != nil {
+				peer.IPBlock = &IPBlock{CIDR: hubPeer.IPBlock.CIDR}
+			}
+			if hubPeer.SecurityGroup != nil {
+				peer.SecurityGroup = &SecurityGroup{GroupID: hubPeer.SecurityGroup.GroupID}
+			}
+			rule.From = append(rule.From, peer)
+		}
+		for _, hubPort := range hubRule.Ports {
+			rule.Ports = append(rule.Ports, NetworkingPort{
+				Protocol: (*NetworkingProtocol)(hubPort.Protocol),
+				Port:     hubPort.Port,
+			})
+		}
+		networking.Ingress = append(networking.Ingress, rule)
+	}
+	return networking
+}
@@ -0,0 +1,69 @@
+package v1alpha1
+
+import (
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"testing"
+)
+
+func TestTargetGroupBinding_ConversionRoundTrip(t *testing.T) {
+	ipAddressTypeIPv6 := v1beta1.TargetGroupIPAddressTypeIPv6
+	port80 := intstr.FromInt(80)
+	tests := []struct {
+		name            string
+		hub             *v1beta1.TargetGroupBinding
+		wantAnnotations map[string]string
+	}{
+		{
+			name: "without v1beta1 only fields",
+			hub: &v1beta1.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "tgb-1"},
+				Spec: v1beta1.TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+					ServiceRef:     v1beta1.ServiceReference{Name: "svc-1", Port: port80},
+					Networking: &v1beta1.TargetGroupBindingNetworking{
+						Ingress: []v1beta1.NetworkingIngressRule{
+							{
+								From:  []v1beta1.NetworkingPeer{{SecurityGroup: &v1beta1.SecurityGroup{GroupID: "sg-1"}}},
+								Ports: []v1beta1.NetworkingPort{},
+							},
+						},
+					},
+				},
+			},
+			wantAnnotations: nil,
+		},
+		{
+			name: "v1beta1 only fields are preserved by annotation",
+			hub: &v1beta1.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "tgb-1", Annotations: map[string]string{"k": "v"}},
+				Spec: v1beta1.TargetGroupBindingSpec{
+					TargetGroupARN:     "tg-1",
+					ServiceRef:         v1beta1.ServiceReference{Name: "svc-1", Port: port80},
+					IPAddressType:      &ipAddressTypeIPv6,
+					VpcID:              "vpc-0123456789abcdef0",
+					IAMRoleARNToAssume: "arn:aws:iam::123456789012:role/role-1",
+				},
+			},
+			wantAnnotations: map[string]string{
+				"k":                                     "v",
+				"elbv2.k8s.aws/v1beta1-conversion-data": `{"ipAddressType":"ipv6","vpcID":"vpc-0123456789abcdef0","iamRoleARNToAssume":"arn:aws:iam::123456789012:role/role-1"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tgb := &TargetGroupBinding{}
+			err := tgb.ConvertFrom(tt.hub)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantAnnotations, tgb.Annotations)
+
+			got := &v1beta1.TargetGroupBinding{}
+			err = tgb.ConvertTo(got)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.hub, got)
+		})
+	}
+}
@@ -0,0 +1,167 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Attribute defines a key/value pair of AWS resource attribute.
+type Attribute struct {
+	// The key of the attribute.
+	Key string `json:"key"`
+
+	// The value of the attribute.
+	Value string `json:"value"`
+}
+
+// SubnetSelector selects subnets by tags.
+type SubnetSelector struct {
+	// Tags selects subnets that have all the tags, a tag matches if its value is any of the values.
+	// Subnets with the tag key match regardless of value if the values are empty.
+	Tags map[string][]string `json:"tags"`
+}
+
+// ListenerDefaultActionType is the type of listener default action.
+// +kubebuilder:validation:Enum=fixed-response;redirect
+type ListenerDefaultActionType string
+
+const (
+	ListenerDefaultActionTypeFixedResponse ListenerDefaultActionType = "fixed-response"
+	ListenerDefaultActionTypeRedirect      ListenerDefaultActionType = "redirect"
+)
+
+// FixedResponseActionConfig defines an action that returns a custom HTTP response.
+type FixedResponseActionConfig struct {
+	// The content type.
+	// +optional
+	ContentType *string `json:"contentType,omitempty"`
+
+	// The message.
+	// +kubebuilder:validation:MaxLength=1024
+	// +optional
+	MessageBody *string `json:"messageBody,omitempty"`
+
+	// The HTTP response code.
+	// +kubebuilder:validation:Pattern=`^[245]\d\d$`
+	StatusCode string `json:"statusCode"`
+}
+
+// RedirectActionConfig defines an action that redirects requests.
+type RedirectActionConfig struct {
+	// The hostname.
+	// +optional
+	Host *string `json:"host,omitempty"`
+
+	// The absolute path, starting with the leading "/".
+	// +optional
+	Path *string `json:"path,omitempty"`
+
+	// The port.
+	// +optional
+	Port *string `json:"port,omitempty"`
+
+	// The protocol.
+	// +optional
+	Protocol *string `json:"protocol,omitempty"`
+
+	// The query parameters.
+	// +optional
+	Query *string `json:"query,omitempty"`
+
+	// The HTTP redirect code.
+	// +kubebuilder:validation:Enum=HTTP_301;HTTP_302
+	StatusCode string `json:"statusCode"`
+}
+
+// ListenerDefaultAction defines the action of listeners for requests that match no rule.
+type ListenerDefaultAction struct {
+	// The type of action.
+	Type ListenerDefaultActionType `json:"type"`
+
+	// FixedResponseConfig is required for fixed-response action.
+	// +optional
+	FixedResponseConfig *FixedResponseActionConfig `json:"fixedResponseConfig,omitempty"`
+
+	// RedirectConfig is required for redirect action.
+	// +optional
+	RedirectConfig *RedirectActionConfig `json:"redirectConfig,omitempty"`
+}
+
+// IngressClassParamsSpec defines the desired state of IngressClassParams
+type IngressClassParamsSpec struct {
+	// LoadBalancerAttributes define the custom attributes to LoadBalancers for all Ingress that belongs to IngressClass with this IngressClassParams.
+	// They take precedence over attributes specified on Ingresses.
+	// +optional
+	LoadBalancerAttributes []Attribute `json:"loadBalancerAttributes,omitempty"`
+
+	// Subnets selects the subnets for LoadBalancers of all Ingress that belongs to IngressClass with this IngressClassParams.
+	// It takes precedence over subnets specified on Ingresses.
+	// +optional
+	Subnets *SubnetSelector `json:"subnets,omitempty"`
+
+	// DefaultAction defines the default action of listeners for all IngressGroups that belong to IngressClass with this IngressClassParams,
+	// which applies when no Ingress within the IngressGroup specifies a default backend.
+	// It takes precedence over the default action specified on Ingresses.
+	// +optional
+	DefaultAction *ListenerDefaultAction `json:"defaultAction,omitempty"`
+
+	// TargetType defines the default TargetType of TargetGroups for all Ingress that belongs to IngressClass with this IngressClassParams.
+	// It takes precedence over the controller's default-target-type, while the target-type annotation on Ingresses or Services takes precedence over it.
+	// +kubebuilder:validation:Enum=instance;ip
+	// +optional
+	TargetType *TargetType `json:"targetType,omitempty"`
+
+	// Region defines the AWS region to provision LoadBalancers in for all Ingress that belongs to IngressClass with this IngressClassParams.
+	// It defaults to the controller's region, and VpcID must be specified together with it.
+	// +optional
+	Region *string `json:"region,omitempty"`
+
+	// VpcID defines the VPC in Region to provision LoadBalancers in for all Ingress that belongs to IngressClass with this IngressClassParams.
+	// +optional
+	VpcID *string `json:"vpcID,omitempty"`
+
+	// FeatureGates overrides the feature gates of the controller for all Ingress that belongs to IngressClass with this IngressClassParams.
+	// Only features that apply per IngressClass can be overridden.
+	// +optional
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// IngressClassParams is the Schema for the IngressClassParams API
+type IngressClassParams struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec IngressClassParamsSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// IngressClassParamsList contains a list of IngressClassParams
+type IngressClassParamsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IngressClassParams `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&IngressClassParams{}, &IngressClassParamsList{})
+}
@@ -0,0 +1,108 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ListenerRuleConditions defines the conditions of listener rule, all specified conditions must be satisfied.
+type ListenerRuleConditions struct {
+	// HostHeaders matches the host header of requests, at least one of them must match.
+	// +optional
+	HostHeaders []string `json:"hostHeaders,omitempty"`
+
+	// PathPatterns matches the path of requests, at least one of them must match.
+	// +optional
+	PathPatterns []string `json:"pathPatterns,omitempty"`
+
+	// HTTPRequestMethods matches the HTTP method of requests, at least one of them must match.
+	// +optional
+	HTTPRequestMethods []string `json:"httpRequestMethods,omitempty"`
+
+	// SourceIPs matches the source IP CIDRs of requests, at least one of them must match.
+	// +optional
+	SourceIPs []string `json:"sourceIPs,omitempty"`
+}
+
+// TargetGroupBindingReference defines reference to a TargetGroupBinding in same namespace.
+type TargetGroupBindingReference struct {
+	// Name is the name of the TargetGroupBinding.
+	Name string `json:"name"`
+}
+
+// ListenerRuleBindingSpec defines the desired state of ListenerRuleBinding
+type ListenerRuleBindingSpec struct {
+	// listenerARN is the Amazon Resource Name (ARN) for the listener to attach rule to.
+	// +kubebuilder:validation:MinLength=1
+	ListenerARN string `json:"listenerARN"`
+
+	// priority is the priority of rule on listener, it must be unique among rules on listener.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=50000
+	Priority int64 `json:"priority"`
+
+	// conditions of the rule, at least one condition must be specified.
+	Conditions ListenerRuleConditions `json:"conditions"`
+
+	// targetGroupBindingRef is a reference to the TargetGroupBinding whose TargetGroup the rule forwards to.
+	TargetGroupBindingRef TargetGroupBindingReference `json:"targetGroupBindingRef"`
+}
+
+// ListenerRuleBindingStatus defines the observed state of ListenerRuleBinding
+type ListenerRuleBindingStatus struct {
+	// The generation observed by the ListenerRuleBinding controller.
+	// +optional
+	ObservedGeneration *int64 `json:"observedGeneration,omitempty"`
+
+	// The ARN of listener that rule is attached to.
+	// +optional
+	ListenerARN *string `json:"listenerARN,omitempty"`
+
+	// The ARN of rule created for ListenerRuleBinding.
+	// +optional
+	RuleARN *string `json:"ruleARN,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:categories=all
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="PRIORITY",type="integer",JSONPath=".spec.priority",description="The priority of listener rule"
+// +kubebuilder:printcolumn:name="TARGET-GROUP-BINDING",type="string",JSONPath=".spec.targetGroupBindingRef.name",description="The TargetGroupBinding's name"
+// +kubebuilder:printcolumn:name="LISTENER-ARN",type="string",JSONPath=".spec.listenerARN",description="The AWS Listener's Amazon Resource Name",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// ListenerRuleBinding is the Schema for the ListenerRuleBinding API
+type ListenerRuleBinding struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ListenerRuleBindingSpec   `json:"spec,omitempty"`
+	Status ListenerRuleBindingStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ListenerRuleBindingList contains a list of ListenerRuleBinding
+type ListenerRuleBindingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ListenerRuleBinding `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ListenerRuleBinding{}, &ListenerRuleBindingList{})
+}
@@ -0,0 +1,73 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// InventoryOwner is an Ingress or Service that AWS resources are provisioned for.
+type InventoryOwner struct {
+	// Namespace of the Ingress or Service.
+	Namespace string `json:"namespace"`
+
+	// Name of the Ingress or Service.
+	Name string `json:"name"`
+}
+
+// InventoryResource is an AWS resource owned by the controller.
+type InventoryResource struct {
+	// Type is the CloudFormation type of the AWS resource, like AWS::ElasticLoadBalancingV2::LoadBalancer.
+	Type string `json:"type"`
+
+	// ID is the Amazon Resource Name (ARN) of the AWS resource, or its ID for resources without ARN like SecurityGroups.
+	ID string `json:"id"`
+}
+
+// LoadBalancerInventoryStatus defines the observed state of LoadBalancerInventory
+type LoadBalancerInventoryStatus struct {
+	// OwnerKind is the kind of objects that AWS resources are provisioned for, either Ingress or Service.
+	OwnerKind string `json:"ownerKind"`
+
+	// StackID is the ID of IngressGroup or ServiceGroup that AWS resources are provisioned for, which is the value of the stack tag on AWS resources.
+	StackID string `json:"stackID"`
+
+	// Owners are the Ingresses or Services that AWS resources are provisioned for.
+	// +optional
+	Owners []InventoryOwner `json:"owners,omitempty"`
+
+	// Resources are the AWS resources owned by the controller, ordered by type and ID.
+	// +optional
+	Resources []InventoryResource `json:"resources,omitempty"`
+
+	// LastSyncTime is the time AWS resources were last reconciled successfully.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="KIND",type="string",JSONPath=".status.ownerKind",description="The kind of objects that AWS resources are provisioned for"
+// +kubebuilder:printcolumn:name="STACK",type="string",JSONPath=".status.stackID",description="The ID of IngressGroup or ServiceGroup"
+// +kubebuilder:printcolumn:name="LAST-SYNC",type="date",JSONPath=".status.lastSyncTime"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// LoadBalancerInventory is the Schema for the LoadBalancerInventory API
+// It's maintained by the controller for each IngressGroup or ServiceGroup, and lists the AWS resources owned by the controller.
+type LoadBalancerInventory struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status LoadBalancerInventoryStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// LoadBalancerInventoryList contains a list of LoadBalancerInventory
+type LoadBalancerInventoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LoadBalancerInventory `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&LoadBalancerInventory{}, &LoadBalancerInventoryList{})
+}
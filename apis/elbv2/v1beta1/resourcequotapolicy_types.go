@@ -0,0 +1,65 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ResourceQuotaLimits defines the maximum number of AWS resources per namespace, resources without a limit are unlimited.
+type ResourceQuotaLimits struct {
+	// ApplicationLoadBalancers is the maximum number of ALBs.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ApplicationLoadBalancers *int64 `json:"applicationLoadBalancers,omitempty"`
+
+	// NetworkLoadBalancers is the maximum number of NLBs.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	NetworkLoadBalancers *int64 `json:"networkLoadBalancers,omitempty"`
+
+	// ElasticIPs is the maximum number of Elastic IPs allocated for NLBs.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ElasticIPs *int64 `json:"elasticIPs,omitempty"`
+
+	// TargetGroups is the maximum number of TargetGroups.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TargetGroups *int64 `json:"targetGroups,omitempty"`
+}
+
+// ResourceQuotaPolicySpec defines the desired state of ResourceQuotaPolicy
+type ResourceQuotaPolicySpec struct {
+	// NamespaceSelector selects the namespaces this policy applies to, each selected namespace is limited separately.
+	// If unspecified, all namespaces are selected.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Hard is the maximum number of AWS resources the controller provisions for Ingresses and Services in each selected namespace.
+	// The lowest limit applies if multiple policies select a namespace.
+	Hard ResourceQuotaLimits `json:"hard"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// ResourceQuotaPolicy is the Schema for the ResourceQuotaPolicy API
+type ResourceQuotaPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ResourceQuotaPolicySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ResourceQuotaPolicyList contains a list of ResourceQuotaPolicy
+type ResourceQuotaPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ResourceQuotaPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ResourceQuotaPolicy{}, &ResourceQuotaPolicyList{})
+}
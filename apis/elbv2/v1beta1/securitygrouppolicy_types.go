@@ -0,0 +1,120 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:validation:Enum=Ingress;Service
+// SecurityGroupPolicyTargetKind is the kind of objects that SecurityGroupPolicy applies to.
+type SecurityGroupPolicyTargetKind string
+
+const (
+	// SecurityGroupPolicyTargetKindIngress targets the LoadBalancer of an Ingress.
+	SecurityGroupPolicyTargetKindIngress SecurityGroupPolicyTargetKind = "Ingress"
+
+	// SecurityGroupPolicyTargetKindService targets the LoadBalancer of a Service.
+	SecurityGroupPolicyTargetKindService SecurityGroupPolicyTargetKind = "Service"
+)
+
+// SecurityGroupPolicyTargetReference defines reference to an Ingress or Service in same namespace.
+type SecurityGroupPolicyTargetReference struct {
+	// Kind is the kind of the target, either Ingress or Service.
+	Kind SecurityGroupPolicyTargetKind `json:"kind"`
+
+	// Name is the name of the target.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// PrefixList defines reference to an AWS EC2 managed PrefixList.
+type PrefixList struct {
+	// PrefixListID is the ID of the managed PrefixList.
+	PrefixListID string `json:"prefixListID"`
+}
+
+// SecurityGroupPolicyPeer defines the source peer for inbound rules.
+type SecurityGroupPolicyPeer struct {
+	// IPBlock defines an IPBlock peer.
+	// If specified, none of the other fields can be set.
+	// +optional
+	IPBlock *IPBlock `json:"ipBlock,omitempty"`
+
+	// SecurityGroup defines a SecurityGroup peer.
+	// If specified, none of the other fields can be set.
+	// +optional
+	SecurityGroup *SecurityGroup `json:"securityGroup,omitempty"`
+
+	// PrefixList defines a PrefixList peer.
+	// If specified, none of the other fields can be set.
+	// +optional
+	PrefixList *PrefixList `json:"prefixList,omitempty"`
+}
+
+// SecurityGroupPolicyPort defines the port range and protocol for inbound rules.
+type SecurityGroupPolicyPort struct {
+	// The protocol which traffic must match.
+	// If protocol is unspecified, it defaults to TCP.
+	// +optional
+	Protocol *NetworkingProtocol `json:"protocol,omitempty"`
+
+	// The port which traffic must match.
+	// If port is unspecified, it matches all listener ports of the LoadBalancer with protocol.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port *int64 `json:"port,omitempty"`
+
+	// EndPort is the last port of the port range starting at port, it requires port to be specified.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	EndPort *int64 `json:"endPort,omitempty"`
+}
+
+// SecurityGroupPolicyIngressRule defines traffic allowed into the LoadBalancer.
+type SecurityGroupPolicyIngressRule struct {
+	// List of peers which should be able to access the LoadBalancer.
+	// +kubebuilder:validation:MinItems=1
+	From []SecurityGroupPolicyPeer `json:"from"`
+
+	// List of ports which should be made accessible on the LoadBalancer.
+	// If ports is unspecified, all listener ports of the LoadBalancer are accessible over TCP.
+	// +optional
+	Ports []SecurityGroupPolicyPort `json:"ports,omitempty"`
+}
+
+// SecurityGroupPolicySpec defines the desired state of SecurityGroupPolicy
+type SecurityGroupPolicySpec struct {
+	// targetRef is a reference to the Ingress or Service whose managed SecurityGroup the rules are appended to.
+	TargetRef SecurityGroupPolicyTargetReference `json:"targetRef"`
+
+	// ingress is the list of inbound rules appended to the managed SecurityGroup.
+	// +kubebuilder:validation:MinItems=1
+	Ingress []SecurityGroupPolicyIngressRule `json:"ingress"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="KIND",type="string",JSONPath=".spec.targetRef.kind",description="The kind of target"
+// +kubebuilder:printcolumn:name="TARGET",type="string",JSONPath=".spec.targetRef.name",description="The name of target"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// SecurityGroupPolicy is the Schema for the SecurityGroupPolicy API
+// It appends inbound rules to the managed SecurityGroup of the LoadBalancer of an Ingress or Service.
+type SecurityGroupPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec SecurityGroupPolicySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SecurityGroupPolicyList contains a list of SecurityGroupPolicy
+type SecurityGroupPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SecurityGroupPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SecurityGroupPolicy{}, &SecurityGroupPolicyList{})
+}
@@ -17,20 +17,32 @@ limitations under the License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
-// +kubebuilder:validation:Enum=instance;ip
+// +kubebuilder:validation:Enum=instance;ip;lambda
 // TargetType is the targetType of your ELBV2 TargetGroup.
 //
 // * with `instance` TargetType, nodes with nodePort for your service will be registered as targets
 // * with `ip` TargetType, Pods with containerPort for your service will be registered as targets
+// * with `lambda` TargetType, no targets will be registered, the Lambda function of your TargetGroup is managed outside the controller
 type TargetType string
 
 const (
 	TargetTypeInstance TargetType = "instance"
 	TargetTypeIP       TargetType = "ip"
+	TargetTypeLambda   TargetType = "lambda"
+)
+
+// +kubebuilder:validation:Enum=ipv4;ipv6
+// TargetGroupIPAddressType is the IP address type of your ELBV2 TargetGroup.
+type TargetGroupIPAddressType string
+
+const (
+	TargetGroupIPAddressTypeIPv4 TargetGroupIPAddressType = "ipv4"
+	TargetGroupIPAddressTypeIPv6 TargetGroupIPAddressType = "ipv6"
 )
 
 // ServiceReference defines reference to a Kubernetes Service and its ServicePort.
@@ -87,7 +99,7 @@ type NetworkingPort struct {
 	Protocol *NetworkingProtocol `json:"protocol,omitempty"`
 
 	// The port which traffic must match.
-	// When NodePort endpoints(instance TargetType) is used, this must be a numerical port.
+	// When NodePort endpoints(instance TargetType) is used, this can be either numerical port or named port that resolves to the nodePort.
 	// When Port endpoints(ip TargetType) is used, this can be either numerical or named port on pods.
 	// if port is unspecified, it defaults to all ports.
 	// +optional
@@ -112,6 +124,35 @@ type TargetGroupBindingNetworking struct {
 	Ingress []NetworkingIngressRule `json:"ingress,omitempty"`
 }
 
+// +kubebuilder:validation:Enum=Fail;Truncate
+// TargetOverflowStrategy defines how endpoints are handled once targets of TargetGroup reached the max targets.
+//
+// * with `Fail` strategy, no new targets are registered and the TargetGroupBinding fails to reconcile
+// * with `Truncate` strategy, new targets are registered up to the max targets and the rest endpoints are dropped
+type TargetOverflowStrategy string
+
+const (
+	TargetOverflowStrategyFail     TargetOverflowStrategy = "Fail"
+	TargetOverflowStrategyTruncate TargetOverflowStrategy = "Truncate"
+)
+
+// TargetZoneBalancing defines how targets are balanced across availability zones.
+type TargetZoneBalancing struct {
+	// maxSkew is the max difference of targets between availability zones,
+	// new targets that would skew availability zones beyond it are not registered until other availability zones catch up.
+	// +kubebuilder:validation:Minimum=0
+	MaxSkew int64 `json:"maxSkew"`
+}
+
+// TargetGroupAttribute defines an attribute of TargetGroup.
+type TargetGroupAttribute struct {
+	// key is the name of the attribute.
+	Key string `json:"key"`
+
+	// value is the value of the attribute.
+	Value string `json:"value"`
+}
+
 // TargetGroupBindingSpec defines the desired state of TargetGroupBinding
 type TargetGroupBindingSpec struct {
 	// targetGroupARN is the Amazon Resource Name (ARN) for the TargetGroup.
@@ -122,11 +163,102 @@ type TargetGroupBindingSpec struct {
 	TargetType *TargetType `json:"targetType,omitempty"`
 
 	// serviceRef is a reference to a Kubernetes Service and ServicePort.
+	// It's required for instance and ip TargetType.
+	// +optional
 	ServiceRef ServiceReference `json:"serviceRef"`
 
+	// ipAddressType is the IP address type of TargetGroup. If unspecified, it will be automatically inferred.
+	// Pod addresses of the same family are registered for ip TargetType.
+	// +optional
+	IPAddressType *TargetGroupIPAddressType `json:"ipAddressType,omitempty"`
+
 	// networking defines the networking rules to allow ELBV2 LoadBalancer to access targets in TargetGroup.
+	// If unspecified, the rules are inferred from the SecurityGroups or subnets of LoadBalancers that TargetGroup is attached to.
 	// +optional
 	Networking *TargetGroupBindingNetworking `json:"networking,omitempty"`
+
+	// vpcID is the VPC of the TargetGroup. If unspecified, it defaults to the VPC of the controller.
+	// It should be specified when the TargetGroup lives in a VPC peered with the cluster's VPC.
+	// +kubebuilder:validation:Pattern=`^vpc-[0-9a-f]{8}(?:[0-9a-f]{9})?$`
+	// +optional
+	VpcID string `json:"vpcID,omitempty"`
+
+	// iamRoleARNToAssume is the ARN of IAM role to assume when reconciling targets of the TargetGroup.
+	// It's required when the TargetGroup lives in a different AWS account than the controller.
+	// +optional
+	IAMRoleARNToAssume string `json:"iamRoleARNToAssume,omitempty"`
+
+	// nodeSelector selects the nodes to register as targets for instance TargetType, in addition to the nodes excluded by the controller.
+	// If unspecified, all nodes that aren't excluded are registered.
+	// +optional
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+
+	// maxTargets is the max number of targets to register into TargetGroup.
+	// If unspecified, it's limited by the quota of targets per TargetGroup only.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxTargets *int64 `json:"maxTargets,omitempty"`
+
+	// targetOverflowStrategy defines how endpoints are handled once targets reached the max targets. Defaults to Fail.
+	// Targets that are already registered are always kept, and new targets are selected by the order of their IDs for Truncate.
+	// +optional
+	TargetOverflowStrategy *TargetOverflowStrategy `json:"targetOverflowStrategy,omitempty"`
+
+	// zoneBalancing enforces targets are registered evenly across availability zones for ip TargetType.
+	// +optional
+	ZoneBalancing *TargetZoneBalancing `json:"zoneBalancing,omitempty"`
+
+	// targetGroupAttributes are the attributes to configure on TargetGroup, attributes that aren't specified are kept as is.
+	// Stickiness attributes are modified together whenever the stickiness type changes, e.g. between lb_cookie and app_cookie.
+	// +optional
+	TargetGroupAttributes []TargetGroupAttribute `json:"targetGroupAttributes,omitempty"`
+}
+
+// TargetGroupBindingConditionType is the type of TargetGroupBinding conditions.
+type TargetGroupBindingConditionType string
+
+const (
+	// TargetGroupBindingConditionTargetsOverflowed is true when endpoints are not registered since targets reached the max targets.
+	TargetGroupBindingConditionTargetsOverflowed TargetGroupBindingConditionType = "TargetsOverflowed"
+	// TargetGroupBindingConditionTargetsHealthy is true when all targets of TargetGroup are healthy.
+	TargetGroupBindingConditionTargetsHealthy TargetGroupBindingConditionType = "TargetsHealthy"
+	// TargetGroupBindingConditionReconciled is true when the last reconcile succeeded, its reason is the error class otherwise.
+	TargetGroupBindingConditionReconciled TargetGroupBindingConditionType = "Reconciled"
+	// TargetGroupBindingConditionReconcilePaused is true when reconcile is paused after consecutive terminal failures, its reason is the terminal reason.
+	TargetGroupBindingConditionReconcilePaused TargetGroupBindingConditionType = "ReconcilePaused"
+	// TargetGroupBindingConditionDriftDetected is true when AWS resources drifted from desired state while reconcile is paused by the elbv2.k8s.aws/reconcile annotation,
+	// its message describes the drift.
+	TargetGroupBindingConditionDriftDetected TargetGroupBindingConditionType = "DriftDetected"
+)
+
+// TargetGroupBindingCondition describes the state of TargetGroupBinding at a certain point.
+type TargetGroupBindingCondition struct {
+	// type of the condition.
+	Type TargetGroupBindingConditionType `json:"type"`
+
+	// status of the condition, one of True, False, Unknown.
+	Status corev1.ConditionStatus `json:"status"`
+
+	// last time the condition transitioned from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// unique, one-word, CamelCase reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// human-readable message indicating details about last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// TargetHealthSummary describes the aggregate health of targets in TargetGroup.
+type TargetHealthSummary struct {
+	// number of healthy targets.
+	HealthyTargets int32 `json:"healthyTargets"`
+
+	// number of targets, excluding targets being deregistered.
+	TotalTargets int32 `json:"totalTargets"`
 }
 
 // TargetGroupBindingStatus defines the observed state of TargetGroupBinding
@@ -134,6 +266,14 @@ type TargetGroupBindingStatus struct {
 	// The generation observed by the TargetGroupBinding controller.
 	// +optional
 	ObservedGeneration *int64 `json:"observedGeneration,omitempty"`
+
+	// conditions of TargetGroupBinding.
+	// +optional
+	Conditions []TargetGroupBindingCondition `json:"conditions,omitempty"`
+
+	// targetHealth is the aggregate health of targets, which is only reported when target health polling is enabled.
+	// +optional
+	TargetHealth *TargetHealthSummary `json:"targetHealth,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -21,56 +21,811 @@ limitations under the License.
 package v1beta1
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Attribute) DeepCopyInto(out *Attribute) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Attribute.
+func (in *Attribute) DeepCopy() *Attribute {
+	if in == nil {
+		return nil
+	}
+	out := new(Attribute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FixedResponseActionConfig) DeepCopyInto(out *FixedResponseActionConfig) {
+	*out = *in
+	if in.ContentType != nil {
+		in, out := &in.ContentType, &out.ContentType
+		*out = new(string)
+		**out = **in
+	}
+	if in.MessageBody != nil {
+		in, out := &in.MessageBody, &out.MessageBody
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FixedResponseActionConfig.
+func (in *FixedResponseActionConfig) DeepCopy() *FixedResponseActionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FixedResponseActionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IPBlock) DeepCopyInto(out *IPBlock) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPBlock.
-func (in *IPBlock) DeepCopy() *IPBlock {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPBlock.
+func (in *IPBlock) DeepCopy() *IPBlock {
+	if in == nil {
+		return nil
+	}
+	out := new(IPBlock)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressClassParams) DeepCopyInto(out *IngressClassParams) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressClassParams.
+func (in *IngressClassParams) DeepCopy() *IngressClassParams {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressClassParams)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IngressClassParams) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressClassParamsList) DeepCopyInto(out *IngressClassParamsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]IngressClassParams, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressClassParamsList.
+func (in *IngressClassParamsList) DeepCopy() *IngressClassParamsList {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressClassParamsList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IngressClassParamsList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressClassParamsSpec) DeepCopyInto(out *IngressClassParamsSpec) {
+	*out = *in
+	if in.LoadBalancerAttributes != nil {
+		in, out := &in.LoadBalancerAttributes, &out.LoadBalancerAttributes
+		*out = make([]Attribute, len(*in))
+		copy(*out, *in)
+	}
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = new(SubnetSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DefaultAction != nil {
+		in, out := &in.DefaultAction, &out.DefaultAction
+		*out = new(ListenerDefaultAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TargetType != nil {
+		in, out := &in.TargetType, &out.TargetType
+		*out = new(TargetType)
+		**out = **in
+	}
+	if in.Region != nil {
+		in, out := &in.Region, &out.Region
+		*out = new(string)
+		**out = **in
+	}
+	if in.VpcID != nil {
+		in, out := &in.VpcID, &out.VpcID
+		*out = new(string)
+		**out = **in
+	}
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressClassParamsSpec.
+func (in *IngressClassParamsSpec) DeepCopy() *IngressClassParamsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressClassParamsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryOwner) DeepCopyInto(out *InventoryOwner) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryOwner.
+func (in *InventoryOwner) DeepCopy() *InventoryOwner {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryOwner)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryResource) DeepCopyInto(out *InventoryResource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryResource.
+func (in *InventoryResource) DeepCopy() *InventoryResource {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ListenerDefaultAction) DeepCopyInto(out *ListenerDefaultAction) {
+	*out = *in
+	if in.FixedResponseConfig != nil {
+		in, out := &in.FixedResponseConfig, &out.FixedResponseConfig
+		*out = new(FixedResponseActionConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RedirectConfig != nil {
+		in, out := &in.RedirectConfig, &out.RedirectConfig
+		*out = new(RedirectActionConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListenerDefaultAction.
+func (in *ListenerDefaultAction) DeepCopy() *ListenerDefaultAction {
+	if in == nil {
+		return nil
+	}
+	out := new(ListenerDefaultAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ListenerRuleBinding) DeepCopyInto(out *ListenerRuleBinding) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListenerRuleBinding.
+func (in *ListenerRuleBinding) DeepCopy() *ListenerRuleBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(ListenerRuleBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ListenerRuleBinding) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ListenerRuleBindingList) DeepCopyInto(out *ListenerRuleBindingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ListenerRuleBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListenerRuleBindingList.
+func (in *ListenerRuleBindingList) DeepCopy() *ListenerRuleBindingList {
+	if in == nil {
+		return nil
+	}
+	out := new(ListenerRuleBindingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ListenerRuleBindingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ListenerRuleBindingSpec) DeepCopyInto(out *ListenerRuleBindingSpec) {
+	*out = *in
+	in.Conditions.DeepCopyInto(&out.Conditions)
+	out.TargetGroupBindingRef = in.TargetGroupBindingRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListenerRuleBindingSpec.
+func (in *ListenerRuleBindingSpec) DeepCopy() *ListenerRuleBindingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ListenerRuleBindingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ListenerRuleBindingStatus) DeepCopyInto(out *ListenerRuleBindingStatus) {
+	*out = *in
+	if in.ObservedGeneration != nil {
+		in, out := &in.ObservedGeneration, &out.ObservedGeneration
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ListenerARN != nil {
+		in, out := &in.ListenerARN, &out.ListenerARN
+		*out = new(string)
+		**out = **in
+	}
+	if in.RuleARN != nil {
+		in, out := &in.RuleARN, &out.RuleARN
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListenerRuleBindingStatus.
+func (in *ListenerRuleBindingStatus) DeepCopy() *ListenerRuleBindingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ListenerRuleBindingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ListenerRuleConditions) DeepCopyInto(out *ListenerRuleConditions) {
+	*out = *in
+	if in.HostHeaders != nil {
+		in, out := &in.HostHeaders, &out.HostHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PathPatterns != nil {
+		in, out := &in.PathPatterns, &out.PathPatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HTTPRequestMethods != nil {
+		in, out := &in.HTTPRequestMethods, &out.HTTPRequestMethods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SourceIPs != nil {
+		in, out := &in.SourceIPs, &out.SourceIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListenerRuleConditions.
+func (in *ListenerRuleConditions) DeepCopy() *ListenerRuleConditions {
+	if in == nil {
+		return nil
+	}
+	out := new(ListenerRuleConditions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerInventory) DeepCopyInto(out *LoadBalancerInventory) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerInventory.
+func (in *LoadBalancerInventory) DeepCopy() *LoadBalancerInventory {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerInventory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LoadBalancerInventory) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerInventoryList) DeepCopyInto(out *LoadBalancerInventoryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]LoadBalancerInventory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerInventoryList.
+func (in *LoadBalancerInventoryList) DeepCopy() *LoadBalancerInventoryList {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerInventoryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LoadBalancerInventoryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerInventoryStatus) DeepCopyInto(out *LoadBalancerInventoryStatus) {
+	*out = *in
+	if in.Owners != nil {
+		in, out := &in.Owners, &out.Owners
+		*out = make([]InventoryOwner, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]InventoryResource, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerInventoryStatus.
+func (in *LoadBalancerInventoryStatus) DeepCopy() *LoadBalancerInventoryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerInventoryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkingIngressRule) DeepCopyInto(out *NetworkingIngressRule) {
+	*out = *in
+	if in.From != nil {
+		in, out := &in.From, &out.From
+		*out = make([]NetworkingPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]NetworkingPort, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkingIngressRule.
+func (in *NetworkingIngressRule) DeepCopy() *NetworkingIngressRule {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkingIngressRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkingPeer) DeepCopyInto(out *NetworkingPeer) {
+	*out = *in
+	if in.IPBlock != nil {
+		in, out := &in.IPBlock, &out.IPBlock
+		*out = new(IPBlock)
+		**out = **in
+	}
+	if in.SecurityGroup != nil {
+		in, out := &in.SecurityGroup, &out.SecurityGroup
+		*out = new(SecurityGroup)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkingPeer.
+func (in *NetworkingPeer) DeepCopy() *NetworkingPeer {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkingPeer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkingPort) DeepCopyInto(out *NetworkingPort) {
+	*out = *in
+	if in.Protocol != nil {
+		in, out := &in.Protocol, &out.Protocol
+		*out = new(NetworkingProtocol)
+		**out = **in
+	}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkingPort.
+func (in *NetworkingPort) DeepCopy() *NetworkingPort {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkingPort)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrefixList) DeepCopyInto(out *PrefixList) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrefixList.
+func (in *PrefixList) DeepCopy() *PrefixList {
+	if in == nil {
+		return nil
+	}
+	out := new(PrefixList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedirectActionConfig) DeepCopyInto(out *RedirectActionConfig) {
+	*out = *in
+	if in.Host != nil {
+		in, out := &in.Host, &out.Host
+		*out = new(string)
+		**out = **in
+	}
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(string)
+		**out = **in
+	}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(string)
+		**out = **in
+	}
+	if in.Protocol != nil {
+		in, out := &in.Protocol, &out.Protocol
+		*out = new(string)
+		**out = **in
+	}
+	if in.Query != nil {
+		in, out := &in.Query, &out.Query
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedirectActionConfig.
+func (in *RedirectActionConfig) DeepCopy() *RedirectActionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RedirectActionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceQuotaLimits) DeepCopyInto(out *ResourceQuotaLimits) {
+	*out = *in
+	if in.ApplicationLoadBalancers != nil {
+		in, out := &in.ApplicationLoadBalancers, &out.ApplicationLoadBalancers
+		*out = new(int64)
+		**out = **in
+	}
+	if in.NetworkLoadBalancers != nil {
+		in, out := &in.NetworkLoadBalancers, &out.NetworkLoadBalancers
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ElasticIPs != nil {
+		in, out := &in.ElasticIPs, &out.ElasticIPs
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TargetGroups != nil {
+		in, out := &in.TargetGroups, &out.TargetGroups
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceQuotaLimits.
+func (in *ResourceQuotaLimits) DeepCopy() *ResourceQuotaLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceQuotaLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceQuotaPolicy) DeepCopyInto(out *ResourceQuotaPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceQuotaPolicy.
+func (in *ResourceQuotaPolicy) DeepCopy() *ResourceQuotaPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(IPBlock)
+	out := new(ResourceQuotaPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ResourceQuotaPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkingIngressRule) DeepCopyInto(out *NetworkingIngressRule) {
+func (in *ResourceQuotaPolicyList) DeepCopyInto(out *ResourceQuotaPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ResourceQuotaPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceQuotaPolicyList.
+func (in *ResourceQuotaPolicyList) DeepCopy() *ResourceQuotaPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceQuotaPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ResourceQuotaPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceQuotaPolicySpec) DeepCopyInto(out *ResourceQuotaPolicySpec) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Hard.DeepCopyInto(&out.Hard)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceQuotaPolicySpec.
+func (in *ResourceQuotaPolicySpec) DeepCopy() *ResourceQuotaPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceQuotaPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroup) DeepCopyInto(out *SecurityGroup) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroup.
+func (in *SecurityGroup) DeepCopy() *SecurityGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupPolicy) DeepCopyInto(out *SecurityGroupPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupPolicy.
+func (in *SecurityGroupPolicy) DeepCopy() *SecurityGroupPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SecurityGroupPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupPolicyIngressRule) DeepCopyInto(out *SecurityGroupPolicyIngressRule) {
 	*out = *in
 	if in.From != nil {
 		in, out := &in.From, &out.From
-		*out = make([]NetworkingPeer, len(*in))
+		*out = make([]SecurityGroupPolicyPeer, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.Ports != nil {
 		in, out := &in.Ports, &out.Ports
-		*out = make([]NetworkingPort, len(*in))
+		*out = make([]SecurityGroupPolicyPort, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkingIngressRule.
-func (in *NetworkingIngressRule) DeepCopy() *NetworkingIngressRule {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupPolicyIngressRule.
+func (in *SecurityGroupPolicyIngressRule) DeepCopy() *SecurityGroupPolicyIngressRule {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkingIngressRule)
+	out := new(SecurityGroupPolicyIngressRule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkingPeer) DeepCopyInto(out *NetworkingPeer) {
+func (in *SecurityGroupPolicyList) DeepCopyInto(out *SecurityGroupPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SecurityGroupPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupPolicyList.
+func (in *SecurityGroupPolicyList) DeepCopy() *SecurityGroupPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SecurityGroupPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupPolicyPeer) DeepCopyInto(out *SecurityGroupPolicyPeer) {
 	*out = *in
 	if in.IPBlock != nil {
 		in, out := &in.IPBlock, &out.IPBlock
@@ -82,20 +837,25 @@ func (in *NetworkingPeer) DeepCopyInto(out *NetworkingPeer) {
 		*out = new(SecurityGroup)
 		**out = **in
 	}
+	if in.PrefixList != nil {
+		in, out := &in.PrefixList, &out.PrefixList
+		*out = new(PrefixList)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkingPeer.
-func (in *NetworkingPeer) DeepCopy() *NetworkingPeer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupPolicyPeer.
+func (in *SecurityGroupPolicyPeer) DeepCopy() *SecurityGroupPolicyPeer {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkingPeer)
+	out := new(SecurityGroupPolicyPeer)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkingPort) DeepCopyInto(out *NetworkingPort) {
+func (in *SecurityGroupPolicyPort) DeepCopyInto(out *SecurityGroupPolicyPort) {
 	*out = *in
 	if in.Protocol != nil {
 		in, out := &in.Protocol, &out.Protocol
@@ -104,32 +864,60 @@ func (in *NetworkingPort) DeepCopyInto(out *NetworkingPort) {
 	}
 	if in.Port != nil {
 		in, out := &in.Port, &out.Port
-		*out = new(intstr.IntOrString)
+		*out = new(int64)
+		**out = **in
+	}
+	if in.EndPort != nil {
+		in, out := &in.EndPort, &out.EndPort
+		*out = new(int64)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkingPort.
-func (in *NetworkingPort) DeepCopy() *NetworkingPort {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupPolicyPort.
+func (in *SecurityGroupPolicyPort) DeepCopy() *SecurityGroupPolicyPort {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkingPort)
+	out := new(SecurityGroupPolicyPort)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecurityGroup) DeepCopyInto(out *SecurityGroup) {
+func (in *SecurityGroupPolicySpec) DeepCopyInto(out *SecurityGroupPolicySpec) {
 	*out = *in
+	out.TargetRef = in.TargetRef
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = make([]SecurityGroupPolicyIngressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroup.
-func (in *SecurityGroup) DeepCopy() *SecurityGroup {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupPolicySpec.
+func (in *SecurityGroupPolicySpec) DeepCopy() *SecurityGroupPolicySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SecurityGroup)
+	out := new(SecurityGroupPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupPolicyTargetReference) DeepCopyInto(out *SecurityGroupPolicyTargetReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupPolicyTargetReference.
+func (in *SecurityGroupPolicyTargetReference) DeepCopy() *SecurityGroupPolicyTargetReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupPolicyTargetReference)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -150,6 +938,51 @@ func (in *ServiceReference) DeepCopy() *ServiceReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubnetSelector) DeepCopyInto(out *SubnetSelector) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetSelector.
+func (in *SubnetSelector) DeepCopy() *SubnetSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(SubnetSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetGroupAttribute) DeepCopyInto(out *TargetGroupAttribute) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetGroupAttribute.
+func (in *TargetGroupAttribute) DeepCopy() *TargetGroupAttribute {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetGroupAttribute)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TargetGroupBinding) DeepCopyInto(out *TargetGroupBinding) {
 	*out = *in
@@ -177,6 +1010,22 @@ func (in *TargetGroupBinding) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetGroupBindingCondition) DeepCopyInto(out *TargetGroupBindingCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetGroupBindingCondition.
+func (in *TargetGroupBindingCondition) DeepCopy() *TargetGroupBindingCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetGroupBindingCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TargetGroupBindingList) DeepCopyInto(out *TargetGroupBindingList) {
 	*out = *in
@@ -231,6 +1080,21 @@ func (in *TargetGroupBindingNetworking) DeepCopy() *TargetGroupBindingNetworking
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetGroupBindingReference) DeepCopyInto(out *TargetGroupBindingReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetGroupBindingReference.
+func (in *TargetGroupBindingReference) DeepCopy() *TargetGroupBindingReference {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetGroupBindingReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TargetGroupBindingSpec) DeepCopyInto(out *TargetGroupBindingSpec) {
 	*out = *in
@@ -240,11 +1104,41 @@ func (in *TargetGroupBindingSpec) DeepCopyInto(out *TargetGroupBindingSpec) {
 		**out = **in
 	}
 	out.ServiceRef = in.ServiceRef
+	if in.IPAddressType != nil {
+		in, out := &in.IPAddressType, &out.IPAddressType
+		*out = new(TargetGroupIPAddressType)
+		**out = **in
+	}
 	if in.Networking != nil {
 		in, out := &in.Networking, &out.Networking
 		*out = new(TargetGroupBindingNetworking)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxTargets != nil {
+		in, out := &in.MaxTargets, &out.MaxTargets
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TargetOverflowStrategy != nil {
+		in, out := &in.TargetOverflowStrategy, &out.TargetOverflowStrategy
+		*out = new(TargetOverflowStrategy)
+		**out = **in
+	}
+	if in.ZoneBalancing != nil {
+		in, out := &in.ZoneBalancing, &out.ZoneBalancing
+		*out = new(TargetZoneBalancing)
+		**out = **in
+	}
+	if in.TargetGroupAttributes != nil {
+		in, out := &in.TargetGroupAttributes, &out.TargetGroupAttributes
+		*out = make([]TargetGroupAttribute, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetGroupBindingSpec.
@@ -265,6 +1159,18 @@ func (in *TargetGroupBindingStatus) DeepCopyInto(out *TargetGroupBindingStatus)
 		*out = new(int64)
 		**out = **in
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]TargetGroupBindingCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TargetHealth != nil {
+		in, out := &in.TargetHealth, &out.TargetHealth
+		*out = new(TargetHealthSummary)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetGroupBindingStatus.
@@ -276,3 +1182,33 @@ func (in *TargetGroupBindingStatus) DeepCopy() *TargetGroupBindingStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetHealthSummary) DeepCopyInto(out *TargetHealthSummary) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetHealthSummary.
+func (in *TargetHealthSummary) DeepCopy() *TargetHealthSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetHealthSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetZoneBalancing) DeepCopyInto(out *TargetZoneBalancing) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetZoneBalancing.
+func (in *TargetZoneBalancing) DeepCopy() *TargetZoneBalancing {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetZoneBalancing)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,252 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ConvertTo converts this TargetGroupBinding to the Hub version (v1beta1).
+// allowFrom peers are converted into an ingress rule without ports, which allows peers on all ports with TCP.
+func (src *TargetGroupBinding) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.TargetGroupBinding)
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	dst.Spec = v1beta1.TargetGroupBindingSpec{
+		TargetGroupARN: src.Spec.TargetGroupARN,
+		TargetType:     (*v1beta1.TargetType)(src.Spec.TargetType),
+		ServiceRef: v1beta1.ServiceReference{
+			Name: src.Spec.ServiceRef.Name,
+			Port: src.Spec.ServiceRef.Port,
+		},
+		IPAddressType:          (*v1beta1.TargetGroupIPAddressType)(src.Spec.IPAddressType),
+		Networking:             convertNetworkingToHub(src.Spec.Networking),
+		VpcID:                  src.Spec.VpcID,
+		IAMRoleARNToAssume:     src.Spec.IAMRoleARNToAssume,
+		NodeSelector:           src.Spec.NodeSelector.DeepCopy(),
+		MaxTargets:             src.Spec.MaxTargets,
+		TargetOverflowStrategy: (*v1beta1.TargetOverflowStrategy)(src.Spec.TargetOverflowStrategy),
+		ZoneBalancing:          convertZoneBalancingToHub(src.Spec.ZoneBalancing),
+		TargetGroupAttributes:  convertTargetGroupAttributesToHub(src.Spec.TargetGroupAttributes),
+	}
+	dst.Status = v1beta1.TargetGroupBindingStatus{
+		ObservedGeneration: src.Status.ObservedGeneration,
+		Conditions:         convertConditionsToHub(src.Status.Conditions),
+		TargetHealth:       (*v1beta1.TargetHealthSummary)(src.Status.TargetHealth),
+	}
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+// peers of ingress rules without ports are converted into allowFrom peers.
+func (dst *TargetGroupBinding) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.TargetGroupBinding)
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	dst.Spec = TargetGroupBindingSpec{
+		TargetGroupARN: src.Spec.TargetGroupARN,
+		TargetType:     (*TargetType)(src.Spec.TargetType),
+		ServiceRef: ServiceReference{
+			Name: src.Spec.ServiceRef.Name,
+			Port: src.Spec.ServiceRef.Port,
+		},
+		IPAddressType:          (*TargetGroupIPAddressType)(src.Spec.IPAddressType),
+		Networking:             convertNetworkingFromHub(src.Spec.Networking),
+		VpcID:                  src.Spec.VpcID,
+		IAMRoleARNToAssume:     src.Spec.IAMRoleARNToAssume,
+		NodeSelector:           src.Spec.NodeSelector.DeepCopy(),
+		MaxTargets:             src.Spec.MaxTargets,
+		TargetOverflowStrategy: (*TargetOverflowStrategy)(src.Spec.TargetOverflowStrategy),
+		ZoneBalancing:          convertZoneBalancingFromHub(src.Spec.ZoneBalancing),
+		TargetGroupAttributes:  convertTargetGroupAttributesFromHub(src.Spec.TargetGroupAttributes),
+	}
+	dst.Status = TargetGroupBindingStatus{
+		ObservedGeneration: src.Status.ObservedGeneration,
+		Conditions:         convertConditionsFromHub(src.Status.Conditions),
+		TargetHealth:       (*TargetHealthSummary)(src.Status.TargetHealth),
+	}
+	return nil
+}
+
+func convertNetworkingToHub(networking *TargetGroupBindingNetworking) *v1beta1.TargetGroupBindingNetworking {
+	if networking == nil {
+		return nil
+	}
+	hubNetworking := &v1beta1.TargetGroupBindingNetworking{}
+	if len(networking.AllowFrom) != 0 {
+		hubNetworking.Ingress = append(hubNetworking.Ingress, v1beta1.NetworkingIngressRule{
+			From:  convertPeersToHub(networking.AllowFrom),
+			Ports: []v1beta1.NetworkingPort{},
+		})
+	}
+	for _, rule := range networking.Ingress {
+		hubNetworking.Ingress = append(hubNetworking.Ingress, v1beta1.NetworkingIngressRule{
+			From:  convertPeersToHub(rule.From),
+			Ports: convertPortsToHub(rule.Ports),
+		})
+	}
+	return hubNetworking
+}
+
+func convertNetworkingFromHub(hubNetworking *v1beta1.TargetGroupBindingNetworking) *TargetGroupBindingNetworking {
+	if hubNetworking == nil {
+		return nil
+	}
+	networking := &TargetGroupBindingNetworking{}
+	for _, hubRule := range hubNetworking.Ingress {
+		if len(hubRule.Ports) == 0 {
+			networking.AllowFrom = append(networking.AllowFrom, convertPeersFromHub(hubRule.From)...)
+			continue
+		}
+		networking.Ingress = append(networking.Ingress, NetworkingIngressRule{
+			From:  convertPeersFromHub(hubRule.From),
+			Ports: convertPortsFromHub(hubRule.Ports),
+		})
+	}
+	return networking
+}
+
+func convertPeersToHub(peers []NetworkingPeer) []v1beta1.NetworkingPeer {
+	hubPeers := make([]v1beta1.NetworkingPeer, 0, len(peers))
+	for _, peer := range peers {
+		hubPeer := v1beta1.NetworkingPeer{}
+		if peer.IPBlock != nil {
+			hubPeer.IPBlock = &v1beta1.IPBlock{CIDR: peer.IPBlock.CIDR}
+		}
+		if peer.SecurityGroup != nil {
+			hubPeer.SecurityGroup = &v1beta1.SecurityGroup{GroupID: peer.SecurityGroup.GroupID}
+		}
+		hubPeers = append(hubPeers, hubPeer)
+	}
+	return hubPeers
+}
+
+func convertPeersFromHub(hubPeers []v1beta1.NetworkingPeer) []NetworkingPeer {
+	peers := make([]NetworkingPeer, 0, len(hubPeers))
+	for _, hubPeer := range hubPeers {
+		peer := NetworkingPeer{}
+		if hubPeer.IPBlock != nil {
+			peer.IPBlock = &IPBlock{CIDR: hubPeer.IPBlock.CIDR}
+		}
+		if hubPeer.SecurityGroup != nil {
+			peer.SecurityGroup = &SecurityGroup{GroupID: hubPeer.SecurityGroup.GroupID}
+		}
+		peers = append(peers, peer)
+	}
+	return peers
+}
+
+func convertPortsToHub(ports []NetworkingPort) []v1beta1.NetworkingPort {
+	hubPorts := make([]v1beta1.NetworkingPort, 0, len(ports))
+	for _, port := range ports {
+		hubPorts = append(hubPorts, v1beta1.NetworkingPort{
+			Protocol: (*v1beta1.NetworkingProtocol)(port.Protocol),
+			Port:     port.Port,
+		})
+	}
+	return hubPorts
+}
+
+func convertPortsFromHub(hubPorts []v1beta1.NetworkingPort) []NetworkingPort {
+	ports := make([]NetworkingPort, 0, len(hubPorts))
+	for _, hubPort := range hubPorts {
+		ports = append(ports, NetworkingPort{
+			Protocol: (*NetworkingProtocol)(hubPort.Protocol),
+			Port:     hubPort.Port,
+		})
+	}
+	return ports
+}
+
+func convertConditionsToHub(conditions []TargetGroupBindingCondition) []v1beta1.TargetGroupBindingCondition {
+	if conditions == nil {
+		return nil
+	}
+	hubConditions := make([]v1beta1.TargetGroupBindingCondition, 0, len(conditions))
+	for _, condition := range conditions {
+		hubConditions = append(hubConditions, v1beta1.TargetGroupBindingCondition{
+			Type:               v1beta1.TargetGroupBindingConditionType(condition.Type),
+			Status:             condition.Status,
+			LastTransitionTime: condition.LastTransitionTime,
+			Reason:             condition.Reason,
+			Message:            condition.Message,
+		})
+	}
+	return hubConditions
+}
+
+func convertConditionsFromHub(hubConditions []v1beta1.TargetGroupBindingCondition) []TargetGroupBindingCondition {
+	if hubConditions == nil {
+		return nil
+	}
+	conditions := make([]TargetGroupBindingCondition, 0, len(hubConditions))
+	for _, hubCondition := range hubConditions {
+		conditions = append(conditions, TargetGroupBindingCondition{
+			Type:               TargetGroupBindingConditionType(hubCondition.Type),
+			Status:             hubCondition.Status,
+			LastTransitionTime: hubCondition.LastTransitionTime,
+			Reason:             hubCondition.Reason,
+			Message:            hubCondition.Message,
+		})
+	}
+	return conditions
+}
+
+func convertZoneBalancingToHub(zoneBalancing *TargetZoneBalancing) *v1beta1.TargetZoneBalancing {
+	if zoneBalancing == nil {
+		return nil
+	}
+	return &v1beta1.TargetZoneBalancing{
+		MaxSkew: zoneBalancing.MaxSkew,
+	}
+}
+
+func convertZoneBalancingFromHub(hubZoneBalancing *v1beta1.TargetZoneBalancing) *TargetZoneBalancing {
+	if hubZoneBalancing == nil {
+		return nil
+	}
+	return &TargetZoneBalancing{
+		MaxSkew: hubZoneBalancing.MaxSkew,
+	}
+}
+
+func convertTargetGroupAttributesToHub(attributes []TargetGroupAttribute) []v1beta1.TargetGroupAttribute {
+	if attributes == nil {
+		return nil
+	}
+	hubAttributes := make([]v1beta1.TargetGroupAttribute, 0, len(attributes))
+	for _, attribute := range attributes {
+		hubAttributes = append(hubAttributes, v1beta1.TargetGroupAttribute{
+			Key:   attribute.Key,
+			Value: attribute.Value,
+		})
+	}
+	return hubAttributes
+}
+
+func convertTargetGroupAttributesFromHub(hubAttributes []v1beta1.TargetGroupAttribute) []TargetGroupAttribute {
+	if hubAttributes == nil {
+		return nil
+	}
+	attributes := make([]TargetGroupAttribute, 0, len(hubAttributes))
+	for _, hubAttribute := range hubAttributes {
+		attributes = append(attributes, TargetGroupAttribute{
+			Key:   hubAttribute.Key,
+			Value: hubAttribute.Value,
+		})
+	}
+	return attributes
+}
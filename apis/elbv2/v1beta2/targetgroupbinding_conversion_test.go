@@ -0,0 +1,274 @@
+package v1beta2
+
+import (
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"testing"
+)
+
+func TestTargetGroupBinding_ConvertTo(t *testing.T) {
+	targetTypeIP := TargetTypeIP
+	hubTargetTypeIP := v1beta1.TargetTypeIP
+	protocolUDP := NetworkingProtocolUDP
+	hubProtocolUDP := v1beta1.NetworkingProtocolUDP
+	port80 := intstr.FromInt(80)
+	maxTargets := int64(10)
+	overflowStrategyTruncate := TargetOverflowStrategyTruncate
+	hubOverflowStrategyTruncate := v1beta1.TargetOverflowStrategyTruncate
+	tests := []struct {
+		name string
+		src  *TargetGroupBinding
+		want *v1beta1.TargetGroupBinding
+	}{
+		{
+			name: "without networking",
+			src: &TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "tgb-1"},
+				Spec: TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+					TargetType:     &targetTypeIP,
+					ServiceRef:     ServiceReference{Name: "svc-1", Port: port80},
+					VpcID:          "vpc-0123456789abcdef0",
+				},
+			},
+			want: &v1beta1.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "tgb-1"},
+				Spec: v1beta1.TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+					TargetType:     &hubTargetTypeIP,
+					ServiceRef:     v1beta1.ServiceReference{Name: "svc-1", Port: port80},
+					VpcID:          "vpc-0123456789abcdef0",
+				},
+			},
+		},
+		{
+			name: "with maxTargets and conditions",
+			src: &TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "tgb-1"},
+				Spec: TargetGroupBindingSpec{
+					TargetGroupARN:         "tg-1",
+					ServiceRef:             ServiceReference{Name: "svc-1", Port: port80},
+					MaxTargets:             &maxTargets,
+					TargetOverflowStrategy: &overflowStrategyTruncate,
+				},
+				Status: TargetGroupBindingStatus{
+					Conditions: []TargetGroupBindingCondition{
+						{
+							Type:    TargetGroupBindingConditionTargetsOverflowed,
+							Status:  corev1.ConditionTrue,
+							Reason:  "MaxTargetsReached",
+							Message: "1 of 11 targets are not registered",
+						},
+					},
+				},
+			},
+			want: &v1beta1.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "tgb-1"},
+				Spec: v1beta1.TargetGroupBindingSpec{
+					TargetGroupARN:         "tg-1",
+					ServiceRef:             v1beta1.ServiceReference{Name: "svc-1", Port: port80},
+					MaxTargets:             &maxTargets,
+					TargetOverflowStrategy: &hubOverflowStrategyTruncate,
+				},
+				Status: v1beta1.TargetGroupBindingStatus{
+					Conditions: []v1beta1.TargetGroupBindingCondition{
+						{
+							Type:    v1beta1.TargetGroupBindingConditionTargetsOverflowed,
+							Status:  corev1.ConditionTrue,
+							Reason:  "MaxTargetsReached",
+							Message: "1 of 11 targets are not registered",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "with targetHealth",
+			src: &TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "tgb-1"},
+				Spec: TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+					ServiceRef:     ServiceReference{Name: "svc-1", Port: port80},
+				},
+				Status: TargetGroupBindingStatus{
+					TargetHealth: &TargetHealthSummary{
+						HealthyTargets: 2,
+						TotalTargets:   3,
+					},
+				},
+			},
+			want: &v1beta1.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "tgb-1"},
+				Spec: v1beta1.TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+					ServiceRef:     v1beta1.ServiceReference{Name: "svc-1", Port: port80},
+				},
+				Status: v1beta1.TargetGroupBindingStatus{
+					TargetHealth: &v1beta1.TargetHealthSummary{
+						HealthyTargets: 2,
+						TotalTargets:   3,
+					},
+				},
+			},
+		},
+		{
+			name: "with targetGroupAttributes",
+			src: &TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "tgb-1"},
+				Spec: TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+					ServiceRef:     ServiceReference{Name: "svc-1", Port: port80},
+					TargetGroupAttributes: []TargetGroupAttribute{
+						{Key: "stickiness.type", Value: "app_cookie"},
+					},
+				},
+			},
+			want: &v1beta1.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "tgb-1"},
+				Spec: v1beta1.TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+					ServiceRef:     v1beta1.ServiceReference{Name: "svc-1", Port: port80},
+					TargetGroupAttributes: []v1beta1.TargetGroupAttribute{
+						{Key: "stickiness.type", Value: "app_cookie"},
+					},
+				},
+			},
+		},
+		{
+			name: "allowFrom is converted into ingress rule without ports",
+			src: &TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "tgb-1"},
+				Spec: TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+					ServiceRef:     ServiceReference{Name: "svc-1", Port: port80},
+					Networking: &TargetGroupBindingNetworking{
+						AllowFrom: []NetworkingPeer{
+							{SecurityGroup: &SecurityGroup{GroupID: "sg-1"}},
+						},
+						Ingress: []NetworkingIngressRule{
+							{
+								From:  []NetworkingPeer{{IPBlock: &IPBlock{CIDR: "10.0.0.0/16"}}},
+								Ports: []NetworkingPort{{Protocol: &protocolUDP, Port: &port80}},
+							},
+						},
+					},
+				},
+			},
+			want: &v1beta1.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "tgb-1"},
+				Spec: v1beta1.TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+					ServiceRef:     v1beta1.ServiceReference{Name: "svc-1", Port: port80},
+					Networking: &v1beta1.TargetGroupBindingNetworking{
+						Ingress: []v1beta1.NetworkingIngressRule{
+							{
+								From:  []v1beta1.NetworkingPeer{{SecurityGroup: &v1beta1.SecurityGroup{GroupID: "sg-1"}}},
+								Ports: []v1beta1.NetworkingPort{},
+							},
+							{
+								From:  []v1beta1.NetworkingPeer{{IPBlock: &v1beta1.IPBlock{CIDR: "10.0.0.0/16"}}},
+								Ports: []v1beta1.NetworkingPort{{Protocol: &hubProtocolUDP, Port: &port80}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := &v1beta1.TargetGroupBinding{}
+			err := tt.src.ConvertTo(got)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTargetGroupBinding_ConvertFrom(t *testing.T) {
+	protocolTCP := NetworkingProtocolTCP
+	hubProtocolTCP := v1beta1.NetworkingProtocolTCP
+	port80 := intstr.FromInt(80)
+	tests := []struct {
+		name string
+		src  *v1beta1.TargetGroupBinding
+		want *TargetGroupBinding
+	}{
+		{
+			name: "ingress rules without ports are converted into allowFrom",
+			src: &v1beta1.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "tgb-1"},
+				Spec: v1beta1.TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+					ServiceRef:     v1beta1.ServiceReference{Name: "svc-1", Port: port80},
+					Networking: &v1beta1.TargetGroupBindingNetworking{
+						Ingress: []v1beta1.NetworkingIngressRule{
+							{
+								From: []v1beta1.NetworkingPeer{{SecurityGroup: &v1beta1.SecurityGroup{GroupID: "sg-1"}}},
+							},
+							{
+								From:  []v1beta1.NetworkingPeer{{IPBlock: &v1beta1.IPBlock{CIDR: "10.0.0.0/16"}}},
+								Ports: []v1beta1.NetworkingPort{{Protocol: &hubProtocolTCP, Port: &port80}},
+							},
+							{
+								From:  []v1beta1.NetworkingPeer{{SecurityGroup: &v1beta1.SecurityGroup{GroupID: "sg-2"}}},
+								Ports: []v1beta1.NetworkingPort{},
+							},
+						},
+					},
+				},
+				Status: v1beta1.TargetGroupBindingStatus{ObservedGeneration: func(v int64) *int64 { return &v }(3)},
+			},
+			want: &TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "tgb-1"},
+				Spec: TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+					ServiceRef:     ServiceReference{Name: "svc-1", Port: port80},
+					Networking: &TargetGroupBindingNetworking{
+						AllowFrom: []NetworkingPeer{
+							{SecurityGroup: &SecurityGroup{GroupID: "sg-1"}},
+							{SecurityGroup: &SecurityGroup{GroupID: "sg-2"}},
+						},
+						Ingress: []NetworkingIngressRule{
+							{
+								From:  []NetworkingPeer{{IPBlock: &IPBlock{CIDR: "10.0.0.0/16"}}},
+								Ports: []NetworkingPort{{Protocol: &protocolTCP, Port: &port80}},
+							},
+						},
+					},
+				},
+				Status: TargetGroupBindingStatus{ObservedGeneration: func(v int64) *int64 { return &v }(3)},
+			},
+		},
+		{
+			name: "empty networking",
+			src: &v1beta1.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "tgb-1"},
+				Spec: v1beta1.TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+					ServiceRef:     v1beta1.ServiceReference{Name: "svc-1", Port: port80},
+					Networking:     &v1beta1.TargetGroupBindingNetworking{},
+				},
+			},
+			want: &TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "tgb-1"},
+				Spec: TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+					ServiceRef:     ServiceReference{Name: "svc-1", Port: port80},
+					Networking:     &TargetGroupBindingNetworking{},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := &TargetGroupBinding{}
+			err := got.ConvertFrom(tt.src)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
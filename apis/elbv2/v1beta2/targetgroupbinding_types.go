@@ -0,0 +1,314 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// +kubebuilder:validation:Enum=instance;ip;lambda
+// TargetType is the targetType of your ELBV2 TargetGroup.
+//
+// * with `instance` TargetType, nodes with nodePort for your service will be registered as targets
+// * with `ip` TargetType, Pods with containerPort for your service will be registered as targets
+// * with `lambda` TargetType, no targets will be registered, the Lambda function of your TargetGroup is managed outside the controller
+type TargetType string
+
+const (
+	TargetTypeInstance TargetType = "instance"
+	TargetTypeIP       TargetType = "ip"
+	TargetTypeLambda   TargetType = "lambda"
+)
+
+// +kubebuilder:validation:Enum=ipv4;ipv6
+// TargetGroupIPAddressType is the IP address type of your ELBV2 TargetGroup.
+type TargetGroupIPAddressType string
+
+const (
+	TargetGroupIPAddressTypeIPv4 TargetGroupIPAddressType = "ipv4"
+	TargetGroupIPAddressTypeIPv6 TargetGroupIPAddressType = "ipv6"
+)
+
+// ServiceReference defines reference to a Kubernetes Service and its ServicePort.
+type ServiceReference struct {
+	// Name is the name of the Service.
+	Name string `json:"name"`
+
+	// Port is the port of the ServicePort.
+	Port intstr.IntOrString `json:"port"`
+}
+
+// IPBlock defines source/destination IPBlock in networking rules.
+type IPBlock struct {
+	// CIDR is the network CIDR.
+	// Both IPV4 or IPV6 CIDR are accepted.
+	CIDR string `json:"cidr"`
+}
+
+// SecurityGroup defines reference to an AWS EC2 SecurityGroup.
+type SecurityGroup struct {
+	// GroupID is the EC2 SecurityGroupID.
+	GroupID string `json:"groupID"`
+}
+
+// NetworkingPeer defines the source/destination peer for networking rules.
+type NetworkingPeer struct {
+	// IPBlock defines an IPBlock peer.
+	// If specified, none of the other fields can be set.
+	// +optional
+	IPBlock *IPBlock `json:"ipBlock,omitempty"`
+
+	// SecurityGroup defines a SecurityGroup peer.
+	// If specified, none of the other fields can be set.
+	// +optional
+	SecurityGroup *SecurityGroup `json:"securityGroup,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=TCP;UDP
+// NetworkingProtocol defines the protocol for networking rules.
+type NetworkingProtocol string
+
+const (
+	// NetworkingProtocolTCP is the TCP protocol.
+	NetworkingProtocolTCP NetworkingProtocol = "TCP"
+
+	// NetworkingProtocolUDP is the UDP protocol.
+	NetworkingProtocolUDP NetworkingProtocol = "UDP"
+)
+
+// NetworkingPort defines the port and protocol for networking rules.
+type NetworkingPort struct {
+	// The protocol which traffic must match.
+	// If protocol is unspecified, it defaults to TCP.
+	Protocol *NetworkingProtocol `json:"protocol,omitempty"`
+
+	// The port which traffic must match.
+	// When NodePort endpoints(instance TargetType) is used, this can be either numerical port or named port that resolves to the nodePort.
+	// When Port endpoints(ip TargetType) is used, this can be either numerical or named port on pods.
+	// if port is unspecified, it defaults to all ports.
+	// +optional
+	Port *intstr.IntOrString `json:"port,omitempty"`
+}
+
+// NetworkingIngressRule defines a particular set of traffic that is allowed to access TargetGroup's targets.
+type NetworkingIngressRule struct {
+	// List of peers which should be able to access the targets in TargetGroup.
+	// At least one NetworkingPeer should be specified.
+	// +kubebuilder:validation:MinItems=1
+	From []NetworkingPeer `json:"from"`
+
+	// List of ports which should be made accessible on the targets in TargetGroup.
+	// At least one NetworkingPort should be specified, use allowFrom to allow peers on all ports instead.
+	// +kubebuilder:validation:MinItems=1
+	Ports []NetworkingPort `json:"ports"`
+}
+
+// TargetGroupBindingNetworking defines the networking rules to allow ELBV2 LoadBalancer to access targets in TargetGroup.
+type TargetGroupBindingNetworking struct {
+	// List of peers which should be able to access the targets in TargetGroup on all ports with TCP.
+	// It's the common case of allowing the SecurityGroup of ELBV2 LoadBalancer to access targets.
+	// +optional
+	AllowFrom []NetworkingPeer `json:"allowFrom,omitempty"`
+
+	// List of ingress rules to allow ELBV2 LoadBalancer to access targets in TargetGroup on specific ports.
+	// +optional
+	Ingress []NetworkingIngressRule `json:"ingress,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Fail;Truncate
+// TargetOverflowStrategy defines how endpoints are handled once targets of TargetGroup reached the max targets.
+//
+// * with `Fail` strategy, no new targets are registered and the TargetGroupBinding fails to reconcile
+// * with `Truncate` strategy, new targets are registered up to the max targets and the rest endpoints are dropped
+type TargetOverflowStrategy string
+
+const (
+	TargetOverflowStrategyFail     TargetOverflowStrategy = "Fail"
+	TargetOverflowStrategyTruncate TargetOverflowStrategy = "Truncate"
+)
+
+// TargetZoneBalancing defines how targets are balanced across availability zones.
+type TargetZoneBalancing struct {
+	// maxSkew is the max difference of targets between availability zones,
+	// new targets that would skew availability zones beyond it are not registered until other availability zones catch up.
+	// +kubebuilder:validation:Minimum=0
+	MaxSkew int64 `json:"maxSkew"`
+}
+
+// TargetGroupAttribute defines an attribute of TargetGroup.
+type TargetGroupAttribute struct {
+	// key is the name of the attribute.
+	Key string `json:"key"`
+
+	// value is the value of the attribute.
+	Value string `json:"value"`
+}
+
+// TargetGroupBindingSpec defines the desired state of TargetGroupBinding
+type TargetGroupBindingSpec struct {
+	// targetGroupARN is the Amazon Resource Name (ARN) for the TargetGroup.
+	TargetGroupARN string `json:"targetGroupARN"`
+
+	// targetType is the TargetType of TargetGroup. If unspecified, it will be automatically inferred.
+	// +optional
+	TargetType *TargetType `json:"targetType,omitempty"`
+
+	// serviceRef is a reference to a Kubernetes Service and ServicePort.
+	// It's required for instance and ip TargetType.
+	// +optional
+	ServiceRef ServiceReference `json:"serviceRef"`
+
+	// ipAddressType is the IP address type of TargetGroup. If unspecified, it will be automatically inferred.
+	// Pod addresses of the same family are registered for ip TargetType.
+	// +optional
+	IPAddressType *TargetGroupIPAddressType `json:"ipAddressType,omitempty"`
+
+	// networking defines the networking rules to allow ELBV2 LoadBalancer to access targets in TargetGroup.
+	// If unspecified, the rules are inferred from the SecurityGroups or subnets of LoadBalancers that TargetGroup is attached to.
+	// +optional
+	Networking *TargetGroupBindingNetworking `json:"networking,omitempty"`
+
+	// vpcID is the VPC of the TargetGroup. If unspecified, it defaults to the VPC of the controller.
+	// It should be specified when the TargetGroup lives in a VPC peered with the cluster's VPC.
+	// +kubebuilder:validation:Pattern=`^vpc-[0-9a-f]{8}(?:[0-9a-f]{9})?$`
+	// +optional
+	VpcID string `json:"vpcID,omitempty"`
+
+	// iamRoleARNToAssume is the ARN of IAM role to assume when reconciling targets of the TargetGroup.
+	// It's required when the TargetGroup lives in a different AWS account than the controller.
+	// +optional
+	IAMRoleARNToAssume string `json:"iamRoleARNToAssume,omitempty"`
+
+	// nodeSelector selects the nodes to register as targets for instance TargetType, in addition to the nodes excluded by the controller.
+	// If unspecified, all nodes that aren't excluded are registered.
+	// +optional
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+
+	// maxTargets is the max number of targets to register into TargetGroup.
+	// If unspecified, it's limited by the quota of targets per TargetGroup only.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxTargets *int64 `json:"maxTargets,omitempty"`
+
+	// targetOverflowStrategy defines how endpoints are handled once targets reached the max targets. Defaults to Fail.
+	// Targets that are already registered are always kept, and new targets are selected by the order of their IDs for Truncate.
+	// +optional
+	TargetOverflowStrategy *TargetOverflowStrategy `json:"targetOverflowStrategy,omitempty"`
+
+	// zoneBalancing enforces targets are registered evenly across availability zones for ip TargetType.
+	// +optional
+	ZoneBalancing *TargetZoneBalancing `json:"zoneBalancing,omitempty"`
+
+	// targetGroupAttributes are the attributes to configure on TargetGroup, attributes that aren't specified are kept as is.
+	// Stickiness attributes are modified together whenever the stickiness type changes, e.g. between lb_cookie and app_cookie.
+	// +optional
+	TargetGroupAttributes []TargetGroupAttribute `json:"targetGroupAttributes,omitempty"`
+}
+
+// TargetGroupBindingConditionType is the type of TargetGroupBinding conditions.
+type TargetGroupBindingConditionType string
+
+const (
+	// TargetGroupBindingConditionTargetsOverflowed is true when endpoints are not registered since targets reached the max targets.
+	TargetGroupBindingConditionTargetsOverflowed TargetGroupBindingConditionType = "TargetsOverflowed"
+	// TargetGroupBindingConditionTargetsHealthy is true when all targets of TargetGroup are healthy.
+	TargetGroupBindingConditionTargetsHealthy TargetGroupBindingConditionType = "TargetsHealthy"
+	// TargetGroupBindingConditionReconciled is true when the last reconcile succeeded, its reason is the error class otherwise.
+	TargetGroupBindingConditionReconciled TargetGroupBindingConditionType = "Reconciled"
+	// TargetGroupBindingConditionReconcilePaused is true when reconcile is paused after consecutive terminal failures, its reason is the terminal reason.
+	TargetGroupBindingConditionReconcilePaused TargetGroupBindingConditionType = "ReconcilePaused"
+	// TargetGroupBindingConditionDriftDetected is true when AWS resources drifted from desired state while reconcile is paused by the elbv2.k8s.aws/reconcile annotation,
+	// its message describes the drift.
+	TargetGroupBindingConditionDriftDetected TargetGroupBindingConditionType = "DriftDetected"
+)
+
+// TargetGroupBindingCondition describes the state of TargetGroupBinding at a certain point.
+type TargetGroupBindingCondition struct {
+	// type of the condition.
+	Type TargetGroupBindingConditionType `json:"type"`
+
+	// status of the condition, one of True, False, Unknown.
+	Status corev1.ConditionStatus `json:"status"`
+
+	// last time the condition transitioned from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// unique, one-word, CamelCase reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// human-readable message indicating details about last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// TargetHealthSummary describes the aggregate health of targets in TargetGroup.
+type TargetHealthSummary struct {
+	// number of healthy targets.
+	HealthyTargets int32 `json:"healthyTargets"`
+
+	// number of targets, excluding targets being deregistered.
+	TotalTargets int32 `json:"totalTargets"`
+}
+
+// TargetGroupBindingStatus defines the observed state of TargetGroupBinding
+type TargetGroupBindingStatus struct {
+	// The generation observed by the TargetGroupBinding controller.
+	// +optional
+	ObservedGeneration *int64 `json:"observedGeneration,omitempty"`
+
+	// conditions of TargetGroupBinding.
+	// +optional
+	Conditions []TargetGroupBindingCondition `json:"conditions,omitempty"`
+
+	// targetHealth is the aggregate health of targets, which is only reported when target health polling is enabled.
+	// +optional
+	TargetHealth *TargetHealthSummary `json:"targetHealth,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:categories=all
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="SERVICE-NAME",type="string",JSONPath=".spec.serviceRef.name",description="The Kubernetes Service's name"
+// +kubebuilder:printcolumn:name="SERVICE-PORT",type="string",JSONPath=".spec.serviceRef.port",description="The Kubernetes Service's port"
+// +kubebuilder:printcolumn:name="TARGET-TYPE",type="string",JSONPath=".spec.targetType",description="The AWS TargetGroup's TargetType"
+// +kubebuilder:printcolumn:name="ARN",type="string",JSONPath=".spec.targetGroupARN",description="The AWS TargetGroup's Amazon Resource Name",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// TargetGroupBinding is the Schema for the TargetGroupBinding API
+type TargetGroupBinding struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TargetGroupBindingSpec   `json:"spec,omitempty"`
+	Status TargetGroupBindingStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TargetGroupBindingList contains a list of TargetGroupBinding
+type TargetGroupBindingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TargetGroupBinding `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TargetGroupBinding{}, &TargetGroupBindingList{})
+}
@@ -0,0 +1,150 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// offline-model-builder builds the model of Ingresses and Services from manifests without calling AWS APIs,
+// so that annotations can be validated in CI pipelines before the manifests are applied.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/spf13/pflag"
+	"io"
+	"k8s.io/apimachinery/pkg/runtime"
+	"os"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/offline"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"strings"
+)
+
+const (
+	flagClusterName              = "cluster-name"
+	flagVPCID                    = "vpc-id"
+	flagIngressClass             = "ingress-class"
+	flagSubnets                  = "subnets"
+	flagDefaultTargetType        = "default-target-type"
+	flagEnableIPAM               = "enable-ipam"
+	flagEnableVPCEndpointService = "enable-vpc-endpoint-service"
+	flagFormat                   = "format"
+
+	formatJSON = "json"
+)
+
+func main() {
+	var cfg offline.Config
+	var defaultTargetType string
+	var format string
+	fs := pflag.NewFlagSet("offline-model-builder", pflag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: offline-model-builder [flags] [manifest files...]\n\n"+
+			"Builds the model of Ingresses and Services in manifests without calling AWS APIs, manifests are read from stdin if no files or \"-\" is specified.\n\n")
+		fs.PrintDefaults()
+	}
+	fs.StringVar(&cfg.ClusterName, flagClusterName, "cluster", "Kubernetes cluster name")
+	fs.StringVar(&cfg.VPCID, flagVPCID, "vpc-offline", "ID of the VPC of the cluster")
+	fs.StringVar(&cfg.IngressClass, flagIngressClass, "",
+		"Name of the IngressClass that Ingresses are matched by, the Ingresses without IngressClass are matched if empty")
+	fs.StringSliceVar(&cfg.DiscoveredSubnetIDs, flagSubnets, []string{"subnet-offline-1", "subnet-offline-2"},
+		"IDs of the subnets that are used when subnets would be discovered")
+	fs.StringVar(&defaultTargetType, flagDefaultTargetType, string(elbv2model.TargetTypeInstance),
+		"Default target type of target groups for ingresses without target-type annotation or IngressClassParams targetType, one of instance or ip")
+	fs.BoolVar(&cfg.IPAMEnabled, flagEnableIPAM, false, "Enable IPAM addon for services with IPAM pool")
+	fs.BoolVar(&cfg.VPCEndpointServiceEnabled, flagEnableVPCEndpointService, false, "Enable VPC endpoint service addon for services with VPC endpoint service")
+	fs.StringVar(&format, flagFormat, formatJSON, "Output format of models, one of json, terraform or cloudformation")
+	_ = fs.Parse(os.Args[1:])
+	cfg.DefaultTargetType = elbv2model.TargetType(defaultTargetType)
+
+	if err := run(cfg, format, fs.Args(), os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(cfg offline.Config, format string, files []string, stdout io.Writer, stderr io.Writer) error {
+	scheme := offline.NewScheme()
+	objects, err := loadManifests(scheme, files)
+	if err != nil {
+		return err
+	}
+	builder := offline.NewDefaultModelBuilder(scheme, cfg, &log.NullLogger{})
+	results, err := builder.Build(context.Background(), objects)
+	if err != nil {
+		return err
+	}
+
+	stackMarshaller := deploy.NewDefaultStackMarshaller()
+	stackExporter := deploy.NewDefaultStackExporter(cfg.VPCID)
+	failures := 0
+	for _, result := range results {
+		for _, event := range result.Events {
+			fmt.Fprintf(stderr, "%v %v: %v\n", result.Kind, result.ID, event)
+		}
+		if result.Err != nil {
+			fmt.Fprintf(stderr, "%v %v: failed to build model: %v\n", result.Kind, result.ID, result.Err)
+			failures++
+			continue
+		}
+		var payload string
+		if format == formatJSON {
+			payload, err = stackMarshaller.Marshal(result.Stack)
+			if err == nil {
+				var indented bytes.Buffer
+				if err = json.Indent(&indented, []byte(payload), "", "  "); err == nil {
+					payload = indented.String()
+				}
+			}
+		} else {
+			payload, err = stackExporter.Export(result.Stack, deploy.StackExportFormat(format))
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "---\n# %v %v\n%v", result.Kind, result.ID, strings.TrimSuffix(payload, "\n")+"\n")
+	}
+	if failures > 0 {
+		return fmt.Errorf("failed to build model for %v of %v Ingress groups and Services", failures, len(results))
+	}
+	return nil
+}
+
+// loadManifests loads objects from manifest files, or stdin if no files or "-" is specified.
+func loadManifests(scheme *runtime.Scheme, files []string) ([]runtime.Object, error) {
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+	var objects []runtime.Object
+	for _, file := range files {
+		reader := io.Reader(os.Stdin)
+		if file != "-" {
+			f, err := os.Open(file)
+			if err != nil {
+				return nil, err
+			}
+			defer f.Close()
+			reader = f
+		}
+		fileObjects, err := offline.LoadObjects(scheme, reader)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", file, err)
+		}
+		objects = append(objects, fileObjects...)
+	}
+	return objects, nil
+}
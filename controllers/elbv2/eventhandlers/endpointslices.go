@@ -0,0 +1,91 @@
+package eventhandlers
+
+import (
+	"context"
+	"github.com/go-logr/logr"
+	discovery "k8s.io/api/discovery/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/targetgroupbinding"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// NewEnqueueRequestsForEndpointSliceEvent constructs new enqueueRequestsForEndpointSliceEvent.
+func NewEnqueueRequestsForEndpointSliceEvent(k8sClient client.Client, logger logr.Logger) handler.EventHandler {
+	return &enqueueRequestsForEndpointSliceEvent{
+		k8sClient: k8sClient,
+		logger:    logger,
+	}
+}
+
+var _ handler.EventHandler = (*enqueueRequestsForEndpointSliceEvent)(nil)
+
+type enqueueRequestsForEndpointSliceEvent struct {
+	k8sClient client.Client
+	logger    logr.Logger
+}
+
+// Create is called in response to an create event - e.g. Pod Creation.
+func (h *enqueueRequestsForEndpointSliceEvent) Create(e event.CreateEvent, queue workqueue.RateLimitingInterface) {
+	epSliceNew := e.Object.(*discovery.EndpointSlice)
+	h.enqueueImpactedTargetGroupBindings(queue, epSliceNew)
+}
+
+// Update is called in response to an update event -  e.g. Pod Updated.
+func (h *enqueueRequestsForEndpointSliceEvent) Update(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+	epSliceOld := e.ObjectOld.(*discovery.EndpointSlice)
+	epSliceNew := e.ObjectNew.(*discovery.EndpointSlice)
+	if !equality.Semantic.DeepEqual(epSliceOld.Ports, epSliceNew.Ports) ||
+		!equality.Semantic.DeepEqual(epSliceOld.Endpoints, epSliceNew.Endpoints) {
+		h.enqueueImpactedTargetGroupBindings(queue, epSliceNew)
+	}
+}
+
+// Delete is called in response to a delete event - e.g. Pod Deleted.
+func (h *enqueueRequestsForEndpointSliceEvent) Delete(e event.DeleteEvent, queue workqueue.RateLimitingInterface) {
+	epSliceOld := e.Object.(*discovery.EndpointSlice)
+	h.enqueueImpactedTargetGroupBindings(queue, epSliceOld)
+}
+
+// Generic is called in response to an event of an unknown type or a synthetic event triggered as a cron or
+// external trigger request - e.g. reconcile AutoScaling, or a WebHook.
+func (h *enqueueRequestsForEndpointSliceEvent) Generic(event.GenericEvent, workqueue.RateLimitingInterface) {
+}
+
+func (h *enqueueRequestsForEndpointSliceEvent) enqueueImpactedTargetGroupBindings(queue workqueue.RateLimitingInterface, epSlice *discovery.EndpointSlice) {
+	svcName, ok := epSlice.Labels[discovery.LabelServiceName]
+	if !ok || len(svcName) == 0 {
+		return
+	}
+	tgbList := &elbv2api.TargetGroupBindingList{}
+	if err := h.k8sClient.List(context.Background(), tgbList,
+		client.InNamespace(epSlice.Namespace),
+		client.MatchingFields{targetgroupbinding.IndexKeyServiceRefName: svcName}); err != nil {
+		h.logger.Error(err, "failed to fetch targetGroupBindings")
+		return
+	}
+
+	epSliceKey := k8s.NamespacedName(epSlice)
+	for _, tgb := range tgbList.Items {
+		if tgb.Spec.TargetType == nil || (*tgb.Spec.TargetType) != elbv2api.TargetTypeIP {
+			continue
+		}
+
+		h.logger.V(1).Info("enqueue targetGroupBinding for endpointSlice event",
+			"endpointSlice", epSliceKey,
+			"targetGroupBinding", k8s.NamespacedName(&tgb),
+		)
+		queue.Add(reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: tgb.Namespace,
+				Name:      tgb.Name,
+			},
+		})
+	}
+}
@@ -5,7 +5,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
-	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
@@ -19,7 +19,7 @@ import (
 	"testing"
 )
 
-func Test_enqueueRequestsForEndpointsEvent_enqueueImpactedTargetGroupBindings(t *testing.T) {
+func Test_enqueueRequestsForEndpointSliceEvent_enqueueImpactedTargetGroupBindings(t *testing.T) {
 	instanceTargetType := elbv2api.TargetTypeInstance
 	ipTargetType := elbv2api.TargetTypeIP
 
@@ -32,7 +32,7 @@ func Test_enqueueRequestsForEndpointsEvent_enqueueImpactedTargetGroupBindings(t
 		tgbListCalls []tgbListCall
 	}
 	type args struct {
-		eps *corev1.Endpoints
+		epSlice *discovery.EndpointSlice
 	}
 	tests := []struct {
 		name         string
@@ -41,7 +41,7 @@ func Test_enqueueRequestsForEndpointsEvent_enqueueImpactedTargetGroupBindings(t
 		wantRequests []ctrl.Request
 	}{
 		{
-			name: "service event should enqueue impacted ip TargetType TGBs",
+			name: "endpointSlice event should enqueue impacted ip TargetType TGBs",
 			fields: fields{
 				tgbListCalls: []tgbListCall{
 					{
@@ -82,10 +82,13 @@ func Test_enqueueRequestsForEndpointsEvent_enqueueImpactedTargetGroupBindings(t
 				},
 			},
 			args: args{
-				eps: &corev1.Endpoints{
+				epSlice: &discovery.EndpointSlice{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "awesome-ns",
-						Name:      "awesome-svc",
+						Name:      "awesome-svc-x8ks2",
+						Labels: map[string]string{
+							discovery.LabelServiceName: "awesome-svc",
+						},
 					},
 				},
 			},
@@ -99,7 +102,7 @@ func Test_enqueueRequestsForEndpointsEvent_enqueueImpactedTargetGroupBindings(t
 			},
 		},
 		{
-			name: "service event should enqueue impacted ip TargetType TGBs - ignore nil TargetType",
+			name: "endpointSlice event should enqueue impacted ip TargetType TGBs - ignore nil TargetType",
 			fields: fields{
 				tgbListCalls: []tgbListCall{
 					{
@@ -131,10 +134,13 @@ func Test_enqueueRequestsForEndpointsEvent_enqueueImpactedTargetGroupBindings(t
 				},
 			},
 			args: args{
-				eps: &corev1.Endpoints{
+				epSlice: &discovery.EndpointSlice{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "awesome-ns",
-						Name:      "awesome-svc",
+						Name:      "awesome-svc-x8ks2",
+						Labels: map[string]string{
+							discovery.LabelServiceName: "awesome-svc",
+						},
 					},
 				},
 			},
@@ -144,6 +150,18 @@ func Test_enqueueRequestsForEndpointsEvent_enqueueImpactedTargetGroupBindings(t
 				},
 			},
 		},
+		{
+			name: "endpointSlice event without service name label should be ignored",
+			args: args{
+				epSlice: &discovery.EndpointSlice{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "awesome-slice",
+					},
+				},
+			},
+			wantRequests: nil,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -166,12 +184,12 @@ func Test_enqueueRequestsForEndpointsEvent_enqueueImpactedTargetGroupBindings(t
 				)
 			}
 
-			h := &enqueueRequestsForEndpointsEvent{
+			h := &enqueueRequestsForEndpointSliceEvent{
 				k8sClient: k8sClient,
 				logger:    &log.NullLogger{},
 			}
 			queue := controllertest.Queue{Interface: workqueue.New()}
-			h.enqueueImpactedTargetGroupBindings(queue, tt.args.eps)
+			h.enqueueImpactedTargetGroupBindings(queue, tt.args.epSlice)
 			gotRequests := testutils.ExtractCTRLRequestsFromQueue(queue)
 			assert.True(t, cmp.Equal(tt.wantRequests, gotRequests),
 				"diff", cmp.Diff(tt.wantRequests, gotRequests))
@@ -17,16 +17,21 @@ import (
 )
 
 // NewEnqueueRequestsForNodeEvent constructs new enqueueRequestsForNodeEvent.
-func NewEnqueueRequestsForNodeEvent(k8sClient client.Client, logger logr.Logger) handler.EventHandler {
+// nodes with a taint of any of excludedTaintKeys are not traffic proxies, neither are draining nodes if excludeDrainingNodes is set.
+func NewEnqueueRequestsForNodeEvent(k8sClient client.Client, excludedTaintKeys []string, excludeDrainingNodes bool, logger logr.Logger) handler.EventHandler {
 	return &enqueueRequestsForNodeEvent{
-		k8sClient: k8sClient,
-		logger:    logger,
+		k8sClient:            k8sClient,
+		excludedTaintKeys:    excludedTaintKeys,
+		excludeDrainingNodes: excludeDrainingNodes,
+		logger:               logger,
 	}
 }
 
 type enqueueRequestsForNodeEvent struct {
-	k8sClient client.Client
-	logger    logr.Logger
+	k8sClient            client.Client
+	excludedTaintKeys    []string
+	excludeDrainingNodes bool
+	logger               logr.Logger
 }
 
 // Create is called in response to an create event - e.g. Pod Creation.
@@ -57,16 +62,23 @@ func (h *enqueueRequestsForNodeEvent) Generic(e event.GenericEvent, queue workqu
 // enqueueImpactedEndpointBindings will enqueue all impacted TargetGroupBindings for node events.
 func (h *enqueueRequestsForNodeEvent) enqueueImpactedTargetGroupBindings(queue workqueue.RateLimitingInterface, nodeOld *corev1.Node, nodeNew *corev1.Node) {
 	var nodeKey types.NamespacedName
-	nodeOldIsReady := false
-	nodeNewIsReady := false
+	nodeOldIsEligible := false
+	nodeNewIsEligible := false
+	nodeOldIsDraining := false
+	nodeNewIsDraining := false
 	if nodeOld != nil {
 		nodeKey = k8s.NamespacedName(nodeOld)
-		nodeOldIsReady = k8s.IsNodeReady(nodeOld)
+		nodeOldIsDraining = h.excludeDrainingNodes && k8s.IsNodeDraining(nodeOld)
+		nodeOldIsEligible = k8s.IsNodeReady(nodeOld) && !backend.IsNodeExcludedByTaints(nodeOld, h.excludedTaintKeys) && !nodeOldIsDraining
 	}
 	if nodeNew != nil {
 		nodeKey = k8s.NamespacedName(nodeNew)
-		nodeNewIsReady = k8s.IsNodeReady(nodeNew)
+		nodeNewIsDraining = h.excludeDrainingNodes && k8s.IsNodeDraining(nodeNew)
+		nodeNewIsEligible = k8s.IsNodeReady(nodeNew) && !backend.IsNodeExcludedByTaints(nodeNew, h.excludedTaintKeys) && !nodeNewIsDraining
 	}
+	// pods on the node are deregistered or registered again once the node starts or stops draining.
+	// note: draining of deleted nodes is irrelevant, since their pods are removed from endpoints anyway.
+	nodeDrainingChanged := nodeOld != nil && nodeNew != nil && nodeOldIsDraining != nodeNewIsDraining
 
 	tgbList := &elbv2api.TargetGroupBindingList{}
 	if err := h.k8sClient.List(context.Background(), tgbList); err != nil {
@@ -75,32 +87,44 @@ func (h *enqueueRequestsForNodeEvent) enqueueImpactedTargetGroupBindings(queue w
 	}
 
 	for _, tgb := range tgbList.Items {
+		if tgb.Spec.TargetType != nil && (*tgb.Spec.TargetType) == elbv2api.TargetTypeIP && nodeDrainingChanged {
+			h.enqueueTargetGroupBinding(queue, nodeKey, &tgb)
+			continue
+		}
 		if tgb.Spec.TargetType == nil || (*tgb.Spec.TargetType) != elbv2api.TargetTypeInstance {
 			continue
 		}
 
-		nodeSelector := backend.GetTrafficProxyNodeSelector(&tgb)
+		nodeSelector, err := backend.GetTrafficProxyNodeSelector(&tgb)
+		if err != nil {
+			h.logger.Error(err, "failed to build node selector", "targetGroupBinding", k8s.NamespacedName(&tgb))
+			continue
+		}
 
 		nodeOldIsTrafficProxy := false
 		nodeNewIsTrafficProxy := false
 		if nodeOld != nil {
-			nodeOldIsTrafficProxy = nodeOldIsReady && nodeSelector.Matches(labels.Set(nodeOld.Labels))
+			nodeOldIsTrafficProxy = nodeOldIsEligible && nodeSelector.Matches(labels.Set(nodeOld.Labels))
 		}
 		if nodeNew != nil {
-			nodeNewIsTrafficProxy = nodeNewIsReady && nodeSelector.Matches(labels.Set(nodeNew.Labels))
+			nodeNewIsTrafficProxy = nodeNewIsEligible && nodeSelector.Matches(labels.Set(nodeNew.Labels))
 		}
 
 		if nodeOldIsTrafficProxy != nodeNewIsTrafficProxy {
-			h.logger.V(1).Info("enqueue targetGroupBinding for node event",
-				"node", nodeKey,
-				"targetGroupBinding", k8s.NamespacedName(&tgb),
-			)
-			queue.Add(reconcile.Request{
-				NamespacedName: types.NamespacedName{
-					Namespace: tgb.Namespace,
-					Name:      tgb.Name,
-				},
-			})
+			h.enqueueTargetGroupBinding(queue, nodeKey, &tgb)
 		}
 	}
 }
+
+func (h *enqueueRequestsForNodeEvent) enqueueTargetGroupBinding(queue workqueue.RateLimitingInterface, nodeKey types.NamespacedName, tgb *elbv2api.TargetGroupBinding) {
+	h.logger.V(1).Info("enqueue targetGroupBinding for node event",
+		"node", nodeKey,
+		"targetGroupBinding", k8s.NamespacedName(tgb),
+	)
+	queue.Add(reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Namespace: tgb.Namespace,
+			Name:      tgb.Name,
+		},
+	})
+}
@@ -0,0 +1,79 @@
+package eventhandlers
+
+import (
+	"context"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/listenerrulebinding"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// NewEnqueueRequestsForTargetGroupBindingEvent constructs new enqueueRequestsForTargetGroupBindingEvent.
+func NewEnqueueRequestsForTargetGroupBindingEvent(k8sClient client.Client, logger logr.Logger) handler.EventHandler {
+	return &enqueueRequestsForTargetGroupBindingEvent{
+		k8sClient: k8sClient,
+		logger:    logger,
+	}
+}
+
+type enqueueRequestsForTargetGroupBindingEvent struct {
+	k8sClient client.Client
+	logger    logr.Logger
+}
+
+// Create is called in response to an create event - e.g. Pod Creation.
+func (h *enqueueRequestsForTargetGroupBindingEvent) Create(e event.CreateEvent, queue workqueue.RateLimitingInterface) {
+	tgbNew := e.Object.(*elbv2api.TargetGroupBinding)
+	h.enqueueImpactedListenerRuleBindings(queue, tgbNew)
+}
+
+// Update is called in response to an update event -  e.g. Pod Updated.
+func (h *enqueueRequestsForTargetGroupBindingEvent) Update(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+	tgbOld := e.ObjectOld.(*elbv2api.TargetGroupBinding)
+	tgbNew := e.ObjectNew.(*elbv2api.TargetGroupBinding)
+	if tgbOld.Spec.TargetGroupARN != tgbNew.Spec.TargetGroupARN {
+		h.enqueueImpactedListenerRuleBindings(queue, tgbNew)
+	}
+}
+
+// Delete is called in response to a delete event - e.g. Pod Deleted.
+func (h *enqueueRequestsForTargetGroupBindingEvent) Delete(e event.DeleteEvent, queue workqueue.RateLimitingInterface) {
+	// nothing to do here, the listener rule keeps forwarding to TargetGroup until ListenerRuleBinding is deleted.
+}
+
+// Generic is called in response to an event of an unknown type or a synthetic event triggered as a cron or
+// external trigger request - e.g. reconcile AutoScaling, or a WebHook.
+func (h *enqueueRequestsForTargetGroupBindingEvent) Generic(e event.GenericEvent, queue workqueue.RateLimitingInterface) {
+	// nothing to do here
+}
+
+// enqueueImpactedListenerRuleBindings will enqueue all impacted ListenerRuleBindings for targetGroupBinding events.
+func (h *enqueueRequestsForTargetGroupBindingEvent) enqueueImpactedListenerRuleBindings(queue workqueue.RateLimitingInterface, tgb *elbv2api.TargetGroupBinding) {
+	lrbList := &elbv2api.ListenerRuleBindingList{}
+	if err := h.k8sClient.List(context.Background(), lrbList,
+		client.InNamespace(tgb.Namespace),
+		client.MatchingFields{listenerrulebinding.IndexKeyTargetGroupBindingRefName: tgb.Name}); err != nil {
+		h.logger.Error(err, "failed to fetch listenerRuleBindings")
+		return
+	}
+
+	tgbKey := k8s.NamespacedName(tgb)
+	for _, lrb := range lrbList.Items {
+		h.logger.V(1).Info("enqueue listenerRuleBinding for targetGroupBinding event",
+			"targetGroupBinding", tgbKey,
+			"listenerRuleBinding", k8s.NamespacedName(&lrb),
+		)
+		queue.Add(reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: lrb.Namespace,
+				Name:      lrb.Name,
+			},
+		})
+	}
+}
@@ -0,0 +1,175 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/aws-load-balancer-controller/controllers/elbv2/eventhandlers"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/audit"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/listenerrulebinding"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/tracing"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+)
+
+const (
+	listenerRuleBindingFinalizer      = "elbv2.k8s.aws/listener-rule"
+	listenerRuleBindingControllerName = "listenerRuleBinding"
+)
+
+// NewListenerRuleBindingReconciler constructs new listenerRuleBindingReconciler
+func NewListenerRuleBindingReconciler(k8sClient client.Client, eventRecorder record.EventRecorder, finalizerManager k8s.FinalizerManager,
+	lrbResourceManager listenerrulebinding.ResourceManager, namespaceMatcher k8s.NamespaceMatcher, config config.ControllerConfig,
+	logger logr.Logger) *listenerRuleBindingReconciler {
+
+	return &listenerRuleBindingReconciler{
+		k8sClient:          k8sClient,
+		eventRecorder:      eventRecorder,
+		finalizerManager:   finalizerManager,
+		lrbResourceManager: lrbResourceManager,
+		namespaceMatcher:   namespaceMatcher,
+		mutationRecorder:   audit.NewDefaultMutationRecorder(eventRecorder, config.MutationAuditConfig, logger),
+		logger:             logger,
+	}
+}
+
+// listenerRuleBindingReconciler reconciles a ListenerRuleBinding object
+type listenerRuleBindingReconciler struct {
+	k8sClient          client.Client
+	eventRecorder      record.EventRecorder
+	finalizerManager   k8s.FinalizerManager
+	lrbResourceManager listenerrulebinding.ResourceManager
+	namespaceMatcher   k8s.NamespaceMatcher
+	mutationRecorder   audit.MutationRecorder
+	logger             logr.Logger
+}
+
+// +kubebuilder:rbac:groups=elbv2.k8s.aws,resources=listenerrulebindings,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=elbv2.k8s.aws,resources=listenerrulebindings/status,verbs=update;patch
+
+func (r *listenerRuleBindingReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx, span := tracing.StartSpan(context.Background(), "ReconcileListenerRuleBinding", tracing.AttributeReconcileRequest.String(req.String()))
+	err := r.reconcile(ctx, req)
+	tracing.EndSpan(span, err)
+	return runtime.HandleReconcileError(err, r.logger)
+}
+
+func (r *listenerRuleBindingReconciler) reconcile(ctx context.Context, req ctrl.Request) error {
+	matchesNamespace, err := r.namespaceMatcher.Matches(ctx, req.Namespace)
+	if err != nil {
+		return err
+	}
+	// ListenerRuleBindings in namespaces sharded to other controllers are left untouched.
+	if !matchesNamespace {
+		return nil
+	}
+	lrb := &elbv2api.ListenerRuleBinding{}
+	if err := r.k8sClient.Get(ctx, req.NamespacedName, lrb); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	ctx = audit.ContextWithMutationRecorder(ctx, r.mutationRecorder, lrb)
+	if !lrb.DeletionTimestamp.IsZero() {
+		return r.cleanupListenerRuleBinding(ctx, lrb)
+	}
+	return r.reconcileListenerRuleBinding(ctx, lrb)
+}
+
+func (r *listenerRuleBindingReconciler) reconcileListenerRuleBinding(ctx context.Context, lrb *elbv2api.ListenerRuleBinding) error {
+	if err := r.finalizerManager.AddFinalizers(ctx, lrb, listenerRuleBindingFinalizer); err != nil {
+		r.eventRecorder.Event(lrb, corev1.EventTypeWarning, k8s.ListenerRuleBindingEventReasonFailedAddFinalizer, fmt.Sprintf("Failed add finalizer due to %v", err))
+		return err
+	}
+	lrbOld := lrb.DeepCopy()
+	reconcileErr := r.lrbResourceManager.Reconcile(ctx, lrb)
+	if reconcileErr != nil {
+		r.eventRecorder.Event(lrb, corev1.EventTypeWarning, k8s.ListenerRuleBindingEventReasonFailedReconcile, fmt.Sprintf("Failed reconcile due to %v", reconcileErr))
+	} else {
+		lrb.Status.ObservedGeneration = aws.Int64(lrb.Generation)
+	}
+	// the status is updated even if reconcile failed, since it tracks the listener rule created so far.
+	if err := r.updateListenerRuleBindingStatus(ctx, lrbOld, lrb); err != nil {
+		r.eventRecorder.Event(lrb, corev1.EventTypeWarning, k8s.ListenerRuleBindingEventReasonFailedUpdateStatus, fmt.Sprintf("Failed update status due to %v", err))
+		return err
+	}
+	if reconcileErr != nil {
+		return reconcileErr
+	}
+
+	r.eventRecorder.Event(lrb, corev1.EventTypeNormal, k8s.ListenerRuleBindingEventReasonSuccessfullyReconciled, "Successfully reconciled")
+	return nil
+}
+
+func (r *listenerRuleBindingReconciler) cleanupListenerRuleBinding(ctx context.Context, lrb *elbv2api.ListenerRuleBinding) error {
+	if k8s.HasFinalizer(lrb, listenerRuleBindingFinalizer) {
+		if err := r.lrbResourceManager.Cleanup(ctx, lrb); err != nil {
+			r.eventRecorder.Event(lrb, corev1.EventTypeWarning, k8s.ListenerRuleBindingEventReasonFailedCleanup, fmt.Sprintf("Failed cleanup due to %v", err))
+			return err
+		}
+		if err := r.finalizerManager.RemoveFinalizers(ctx, lrb, listenerRuleBindingFinalizer); err != nil {
+			r.eventRecorder.Event(lrb, corev1.EventTypeWarning, k8s.ListenerRuleBindingEventReasonFailedRemoveFinalizer, fmt.Sprintf("Failed remove finalizer due to %v", err))
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *listenerRuleBindingReconciler) updateListenerRuleBindingStatus(ctx context.Context, lrbOld *elbv2api.ListenerRuleBinding, lrb *elbv2api.ListenerRuleBinding) error {
+	if equality.Semantic.DeepEqual(lrbOld.Status, lrb.Status) {
+		return nil
+	}
+	if err := r.k8sClient.Status().Patch(ctx, lrb, client.MergeFrom(lrbOld)); err != nil {
+		return errors.Wrapf(err, "failed to update listenerRuleBinding status: %v", k8s.NamespacedName(lrb))
+	}
+	return nil
+}
+
+func (r *listenerRuleBindingReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+	if err := r.setupIndexes(ctx, mgr.GetFieldIndexer()); err != nil {
+		return err
+	}
+
+	tgbEventHandler := eventhandlers.NewEnqueueRequestsForTargetGroupBindingEvent(r.k8sClient,
+		r.logger.WithName("eventHandlers").WithName("targetGroupBinding"))
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&elbv2api.ListenerRuleBinding{}).
+		Named(listenerRuleBindingControllerName).
+		Watches(&source.Kind{Type: &elbv2api.TargetGroupBinding{}}, tgbEventHandler).
+		Complete(r)
+}
+
+func (r *listenerRuleBindingReconciler) setupIndexes(ctx context.Context, fieldIndexer client.FieldIndexer) error {
+	if err := fieldIndexer.IndexField(ctx, &elbv2api.ListenerRuleBinding{},
+		listenerrulebinding.IndexKeyTargetGroupBindingRefName, listenerrulebinding.IndexFuncTargetGroupBindingRefName); err != nil {
+		return err
+	}
+	return nil
+}
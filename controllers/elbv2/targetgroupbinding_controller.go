@@ -22,14 +22,23 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/aws-load-balancer-controller/controllers/elbv2/eventhandlers"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/audit"
+	awspkg "sigs.k8s.io/aws-load-balancer-controller/pkg/aws"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/quota"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/targetgroupbinding"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/tracing"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
 	"sigs.k8s.io/controller-runtime/pkg/source"
+	"time"
 
 	"github.com/go-logr/logr"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -45,17 +54,30 @@ const (
 
 // NewTargetGroupBindingReconciler constructs new targetGroupBindingReconciler
 func NewTargetGroupBindingReconciler(k8sClient client.Client, eventRecorder record.EventRecorder, finalizerManager k8s.FinalizerManager,
-	tgbResourceManager targetgroupbinding.ResourceManager, config config.ControllerConfig,
-	logger logr.Logger) *targetGroupBindingReconciler {
+	tgbResourceManager targetgroupbinding.ResourceManager, namespaceMatcher k8s.NamespaceMatcher, deadLetterQueue runtime.DeadLetterQueue,
+	errorClassCollector runtime.ErrorClassCollector, reconcileIntrospector runtime.ReconcileIntrospector, config config.ControllerConfig, logger logr.Logger) *targetGroupBindingReconciler {
 
 	return &targetGroupBindingReconciler{
 		k8sClient:          k8sClient,
 		eventRecorder:      eventRecorder,
 		finalizerManager:   finalizerManager,
 		tgbResourceManager: tgbResourceManager,
+		namespaceMatcher:   namespaceMatcher,
+		mutationRecorder:   audit.NewDefaultMutationRecorder(eventRecorder, config.MutationAuditConfig, logger),
 		logger:             logger,
 
-		maxConcurrentReconciles: config.TargetGroupBindingMaxConcurrentReconciles,
+		healthChecker:         runtime.NewDefaultReconcileHealthChecker(controllerName, metrics.Registry, config.ReconcileStallTimeout),
+		reconcileStallTimeout: config.ReconcileStallTimeout,
+		deadLetterQueue:       deadLetterQueue,
+		errorClassCollector:   errorClassCollector,
+		reconcileIntrospector: reconcileIntrospector,
+		rateLimiter:           runtime.NewReconcileRateLimiter(config.ReconcileBackoffConfig.BaseDelay, config.ReconcileBackoffConfig.MaxDelay),
+
+		maxConcurrentReconciles:     config.TargetGroupBindingMaxConcurrentReconciles,
+		targetNodeExcludedTaintKeys: config.TargetNodeExcludedTaintKeys,
+		excludeDrainingNodes:        config.EnableDrainingNodeDeregistration,
+		targetHealthPollPeriod:      config.TargetHealthPollPeriod,
+		slowReconcileThreshold:      config.SlowReconcileThreshold,
 	}
 }
 
@@ -65,9 +87,22 @@ type targetGroupBindingReconciler struct {
 	eventRecorder      record.EventRecorder
 	finalizerManager   k8s.FinalizerManager
 	tgbResourceManager targetgroupbinding.ResourceManager
+	namespaceMatcher   k8s.NamespaceMatcher
+	mutationRecorder   audit.MutationRecorder
 	logger             logr.Logger
 
-	maxConcurrentReconciles int
+	healthChecker         runtime.ReconcileHealthChecker
+	reconcileStallTimeout time.Duration
+	deadLetterQueue       runtime.DeadLetterQueue
+	errorClassCollector   runtime.ErrorClassCollector
+	reconcileIntrospector runtime.ReconcileIntrospector
+	rateLimiter           ratelimiter.RateLimiter
+
+	maxConcurrentReconciles     int
+	targetNodeExcludedTaintKeys []string
+	excludeDrainingNodes        bool
+	targetHealthPollPeriod      time.Duration
+	slowReconcileThreshold      time.Duration
 }
 
 // +kubebuilder:rbac:groups=elbv2.k8s.aws,resources=targetgroupbindings,verbs=get;list;watch;update;patch;create;delete
@@ -75,48 +110,141 @@ type targetGroupBindingReconciler struct {
 // +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=pods/status,verbs=update;patch
 // +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
-// +kubebuilder:rbac:groups="",resources=endpoints,verbs=get;list;watch
+// +kubebuilder:rbac:groups="discovery.k8s.io",resources=endpointslices,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *targetGroupBindingReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
-	return runtime.HandleReconcileError(r.reconcile(req), r.logger)
+	ctx, span := tracing.StartSpan(context.Background(), "ReconcileTargetGroupBinding", tracing.AttributeReconcileRequest.String(req.String()))
+	var err error
+	finishReconcile := r.reconcileIntrospector.StartReconcile(controllerName, req)
+	defer func() {
+		finishReconcile(err)
+	}()
+	err = r.reconcile(ctx, req)
+	tracing.EndSpan(span, err)
+	r.healthChecker.ObserveReconcile(err)
+	if errorClass := r.errorClassCollector.ObserveReconcile(controllerName, err); errorClass != "" {
+		r.reportErrorClass(req, errorClass, err)
+	}
+	if reason, deadLettered := r.deadLetterQueue.ObserveReconcile(controllerName, req, err); deadLettered {
+		return ctrl.Result{}, r.pauseReconcile(req, reason, err)
+	}
+	return runtime.HandleReconcileError(err, r.logger)
+}
+
+// pauseReconcile reports the TargetGroupBinding of dead-lettered request as paused via its ReconcilePaused condition, it's no longer retried until changed.
+func (r *targetGroupBindingReconciler) pauseReconcile(req ctrl.Request, reason string, reconcileErr error) error {
+	ctx := context.Background()
+	tgb := &elbv2api.TargetGroupBinding{}
+	if err := r.k8sClient.Get(ctx, req.NamespacedName, tgb); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	r.logger.Info("paused reconcile due to terminal error", "targetGroupBinding", req.NamespacedName, "reason", reason, "error", reconcileErr.Error())
+	r.eventRecorder.Event(tgb, corev1.EventTypeWarning, k8s.TargetGroupBindingEventReasonReconcilePaused, fmt.Sprintf("Paused reconcile until changed due to %v", reconcileErr))
+	return targetgroupbinding.UpdateReconcilePausedCondition(ctx, r.k8sClient, tgb, reason, reconcileErr)
 }
 
-func (r *targetGroupBindingReconciler) reconcile(req ctrl.Request) error {
+// reportErrorClass reports the class of reconcile error via the Reconciled condition of TargetGroupBinding for request.
+func (r *targetGroupBindingReconciler) reportErrorClass(req ctrl.Request, errorClass runtime.ErrorClass, reconcileErr error) {
 	ctx := context.Background()
 	tgb := &elbv2api.TargetGroupBinding{}
 	if err := r.k8sClient.Get(ctx, req.NamespacedName, tgb); err != nil {
+		return
+	}
+	if err := targetgroupbinding.UpdateReconciledCondition(ctx, r.k8sClient, tgb, errorClass, reconcileErr); err != nil {
+		r.logger.Error(err, "failed to report error class", "targetGroupBinding", req.NamespacedName, "errorClass", errorClass)
+	}
+}
+
+func (r *targetGroupBindingReconciler) reconcile(ctx context.Context, req ctrl.Request) error {
+	stageTimer := runtime.NewStageTimer()
+	ctx = runtime.ContextWithStageTimer(ctx, stageTimer)
+	matchesNamespace, err := r.namespaceMatcher.Matches(ctx, req.Namespace)
+	if err != nil {
+		return err
+	}
+	// TargetGroupBindings in namespaces sharded to other controllers are left untouched.
+	if !matchesNamespace {
+		return nil
+	}
+	tgb := &elbv2api.TargetGroupBinding{}
+	if err := r.k8sClient.Get(ctx, req.NamespacedName, tgb); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.deadLetterQueue.Forget(controllerName, req)
+		}
 		return client.IgnoreNotFound(err)
 	}
+	defer r.reportSlowReconcile(tgb, stageTimer)
 
+	ctx = audit.ContextWithMutationRecorder(ctx, r.mutationRecorder, tgb)
+	if tgb.Annotations[k8s.AnnotationReconcile] == k8s.ReconcileModePaused {
+		ctx = awspkg.ContextWithMutationsFrozen(ctx)
+	}
 	if !tgb.DeletionTimestamp.IsZero() {
 		return r.cleanupTargetGroupBinding(ctx, tgb)
 	}
 	return r.reconcileTargetGroupBinding(ctx, tgb)
 }
 
+// reportSlowReconcile emits an event with the per-stage timing breakdown on TargetGroupBinding if its reconcile took longer than slowReconcileThreshold.
+func (r *targetGroupBindingReconciler) reportSlowReconcile(tgb *elbv2api.TargetGroupBinding, stageTimer *runtime.StageTimer) {
+	message, slow := runtime.SlowReconcileMessage(stageTimer, r.slowReconcileThreshold)
+	if !slow {
+		return
+	}
+	r.logger.Info("slow reconcile", "targetGroupBinding", k8s.NamespacedName(tgb), "timing", message)
+	r.eventRecorder.Event(tgb, corev1.EventTypeWarning, k8s.TargetGroupBindingEventReasonSlowReconcile, message)
+}
+
 func (r *targetGroupBindingReconciler) reconcileTargetGroupBinding(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
 	if err := r.finalizerManager.AddFinalizers(ctx, tgb, targetGroupBindingFinalizer); err != nil {
 		r.eventRecorder.Event(tgb, corev1.EventTypeWarning, k8s.TargetGroupBindingEventReasonFailedAddFinalizer, fmt.Sprintf("Failed add finalizer due to %v", err))
 		return err
 	}
 	if err := r.tgbResourceManager.Reconcile(ctx, tgb); err != nil {
+		var quotaErr *quota.ExceededError
+		var mutationsFrozenErr *awspkg.MutationsFrozenError
+		if errors.As(err, &mutationsFrozenErr) {
+			return r.reportDrift(ctx, tgb, mutationsFrozenErr)
+		}
+		if errors.As(err, &quotaErr) {
+			r.eventRecorder.Event(tgb, corev1.EventTypeWarning, k8s.TargetGroupBindingEventReasonQuotaExceeded, fmt.Sprintf("Failed register targets due to %v", err))
+		}
 		return err
 	}
 	if err := r.updateTargetGroupBindingStatus(ctx, tgb); err != nil {
 		r.eventRecorder.Event(tgb, corev1.EventTypeWarning, k8s.TargetGroupBindingEventReasonFailedUpdateStatus, fmt.Sprintf("Failed update status due to %v", err))
 		return err
 	}
+	if err := targetgroupbinding.UpdateReconcilePausedCondition(ctx, r.k8sClient, tgb, "", nil); err != nil {
+		r.eventRecorder.Event(tgb, corev1.EventTypeWarning, k8s.TargetGroupBindingEventReasonFailedUpdateStatus, fmt.Sprintf("Failed update status due to %v", err))
+		return err
+	}
+	if err := targetgroupbinding.UpdateReconciledCondition(ctx, r.k8sClient, tgb, "", nil); err != nil {
+		r.eventRecorder.Event(tgb, corev1.EventTypeWarning, k8s.TargetGroupBindingEventReasonFailedUpdateStatus, fmt.Sprintf("Failed update status due to %v", err))
+		return err
+	}
+	if err := targetgroupbinding.UpdateDriftDetectedCondition(ctx, r.k8sClient, tgb, ""); err != nil {
+		r.eventRecorder.Event(tgb, corev1.EventTypeWarning, k8s.TargetGroupBindingEventReasonFailedUpdateStatus, fmt.Sprintf("Failed update status due to %v", err))
+		return err
+	}
 
 	r.eventRecorder.Event(tgb, corev1.EventTypeNormal, k8s.TargetGroupBindingEventReasonSuccessfullyReconciled, "Successfully reconciled")
+	if r.targetHealthPollPeriod > 0 {
+		return runtime.NewRequeueNeededAfter("poll target health", r.targetHealthPollPeriod)
+	}
 	return nil
 }
 
 func (r *targetGroupBindingReconciler) cleanupTargetGroupBinding(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
 	if k8s.HasFinalizer(tgb, targetGroupBindingFinalizer) {
 		if err := r.tgbResourceManager.Cleanup(ctx, tgb); err != nil {
+			var mutationsFrozenErr *awspkg.MutationsFrozenError
+			if errors.As(err, &mutationsFrozenErr) {
+				return r.reportDrift(ctx, tgb, mutationsFrozenErr)
+			}
 			r.eventRecorder.Event(tgb, corev1.EventTypeWarning, k8s.TargetGroupBindingEventReasonFailedCleanup, fmt.Sprintf("Failed cleanup due to %v", err))
 			return err
 		}
@@ -128,6 +256,16 @@ func (r *targetGroupBindingReconciler) cleanupTargetGroupBinding(ctx context.Con
 	return nil
 }
 
+// reportDrift reports the drift on TargetGroupBinding while its reconcile is paused.
+func (r *targetGroupBindingReconciler) reportDrift(ctx context.Context, tgb *elbv2api.TargetGroupBinding, mutationsFrozenErr *awspkg.MutationsFrozenError) error {
+	r.eventRecorder.Event(tgb, corev1.EventTypeWarning, k8s.TargetGroupBindingEventReasonDriftDetected, fmt.Sprintf("Reconcile paused, drift detected: %v", mutationsFrozenErr.Drift()))
+	if err := targetgroupbinding.UpdateDriftDetectedCondition(ctx, r.k8sClient, tgb, mutationsFrozenErr.Drift()); err != nil {
+		r.eventRecorder.Event(tgb, corev1.EventTypeWarning, k8s.TargetGroupBindingEventReasonFailedUpdateStatus, fmt.Sprintf("Failed update status due to %v", err))
+		return err
+	}
+	return nil
+}
+
 func (r *targetGroupBindingReconciler) updateTargetGroupBindingStatus(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
 	if aws.Int64Value(tgb.Status.ObservedGeneration) == tgb.Generation {
 		return nil
@@ -144,20 +282,25 @@ func (r *targetGroupBindingReconciler) SetupWithManager(ctx context.Context, mgr
 	if err := r.setupIndexes(ctx, mgr.GetFieldIndexer()); err != nil {
 		return err
 	}
+	if r.reconcileStallTimeout > 0 {
+		if err := runtime.AddReconcileHealthChecker(mgr, metrics.Registry, controllerName, r.healthChecker); err != nil {
+			return err
+		}
+	}
 
 	svcEventHandler := eventhandlers.NewEnqueueRequestsForServiceEvent(r.k8sClient,
 		r.logger.WithName("eventHandlers").WithName("service"))
-	epsEventsHandler := eventhandlers.NewEnqueueRequestsForEndpointsEvent(r.k8sClient,
-		r.logger.WithName("eventHandlers").WithName("endpoints"))
-	nodeEventsHandler := eventhandlers.NewEnqueueRequestsForNodeEvent(r.k8sClient,
+	epSlicesEventsHandler := eventhandlers.NewEnqueueRequestsForEndpointSliceEvent(r.k8sClient,
+		r.logger.WithName("eventHandlers").WithName("endpointSlices"))
+	nodeEventsHandler := eventhandlers.NewEnqueueRequestsForNodeEvent(r.k8sClient, r.targetNodeExcludedTaintKeys, r.excludeDrainingNodes,
 		r.logger.WithName("eventHandlers").WithName("node"))
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&elbv2api.TargetGroupBinding{}).
 		Named(controllerName).
 		Watches(&source.Kind{Type: &corev1.Service{}}, svcEventHandler).
-		Watches(&source.Kind{Type: &corev1.Endpoints{}}, epsEventsHandler).
+		Watches(&source.Kind{Type: &discovery.EndpointSlice{}}, epSlicesEventsHandler).
 		Watches(&source.Kind{Type: &corev1.Node{}}, nodeEventsHandler).
-		WithOptions(controller.Options{MaxConcurrentReconciles: r.maxConcurrentReconciles}).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.maxConcurrentReconciles, RateLimiter: r.rateLimiter}).
 		Complete(r)
 }
 
@@ -0,0 +1,89 @@
+package eventhandlers
+
+import (
+	"context"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/ingress"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+)
+
+// NewEnqueueRequestsForConfigMapEvent constructs new enqueueRequestsForConfigMapEvent.
+func NewEnqueueRequestsForConfigMapEvent(ingEventChan chan<- event.GenericEvent,
+	k8sClient client.Client, eventRecorder record.EventRecorder, logger logr.Logger) *enqueueRequestsForConfigMapEvent {
+	return &enqueueRequestsForConfigMapEvent{
+		ingEventChan:  ingEventChan,
+		k8sClient:     k8sClient,
+		eventRecorder: eventRecorder,
+		logger:        logger,
+	}
+}
+
+var _ handler.EventHandler = (*enqueueRequestsForConfigMapEvent)(nil)
+
+type enqueueRequestsForConfigMapEvent struct {
+	ingEventChan  chan<- event.GenericEvent
+	k8sClient     client.Client
+	eventRecorder record.EventRecorder
+	logger        logr.Logger
+}
+
+func (h *enqueueRequestsForConfigMapEvent) Create(e event.CreateEvent, _ workqueue.RateLimitingInterface) {
+	h.enqueueImpactedIngresses(e.Meta)
+}
+
+func (h *enqueueRequestsForConfigMapEvent) Update(e event.UpdateEvent, _ workqueue.RateLimitingInterface) {
+	configMapOld := e.ObjectOld.(*corev1.ConfigMap)
+	configMapNew := e.ObjectNew.(*corev1.ConfigMap)
+
+	// we only care below update event:
+	//	1. ConfigMap data updates
+	//	2. ConfigMap deletions
+	if equality.Semantic.DeepEqual(configMapOld.Data, configMapNew.Data) &&
+		equality.Semantic.DeepEqual(configMapOld.DeletionTimestamp.IsZero(), configMapNew.DeletionTimestamp.IsZero()) {
+		return
+	}
+
+	h.enqueueImpactedIngresses(e.MetaNew)
+}
+
+func (h *enqueueRequestsForConfigMapEvent) Delete(e event.DeleteEvent, _ workqueue.RateLimitingInterface) {
+	h.enqueueImpactedIngresses(e.Meta)
+}
+
+func (h *enqueueRequestsForConfigMapEvent) Generic(e event.GenericEvent, _ workqueue.RateLimitingInterface) {
+	// we don't have any generic event for configMaps.
+}
+
+func (h *enqueueRequestsForConfigMapEvent) enqueueImpactedIngresses(configMap metav1.Object) {
+	configMapKey := k8s.NamespacedName(configMap)
+
+	ingList := &networking.IngressList{}
+	if err := h.k8sClient.List(context.Background(), ingList,
+		client.InNamespace(configMap.GetNamespace()),
+		client.MatchingFields{ingress.IndexKeyConfigMapRefName: configMap.GetName()}); err != nil {
+		h.logger.Error(err, "failed to fetch ingresses")
+		return
+	}
+	for index := range ingList.Items {
+		ing := &ingList.Items[index]
+		meta, _ := meta.Accessor(ing)
+
+		h.logger.V(1).Info("enqueue ingress for configMap event",
+			"configMap", configMapKey,
+			"ingress", k8s.NamespacedName(ing))
+		h.ingEventChan <- event.GenericEvent{
+			Meta:   meta,
+			Object: ing,
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package eventhandlers
+
+import (
+	"context"
+	"github.com/go-logr/logr"
+	networking "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+)
+
+// NewEnqueueRequestsForIngressClassEvent constructs new enqueueRequestsForIngressClassEvent.
+func NewEnqueueRequestsForIngressClassEvent(ingEventChan chan<- event.GenericEvent, k8sClient client.Client,
+	logger logr.Logger) *enqueueRequestsForIngressClassEvent {
+	return &enqueueRequestsForIngressClassEvent{
+		ingEventChan: ingEventChan,
+		k8sClient:    k8sClient,
+		logger:       logger,
+	}
+}
+
+var _ handler.EventHandler = (*enqueueRequestsForIngressClassEvent)(nil)
+
+// enqueueRequestsForIngressClassEvent enqueues the Ingresses of IngressClasses that changed,
+// so that changes of class-level settings like IngressClassParams are picked up right away.
+type enqueueRequestsForIngressClassEvent struct {
+	ingEventChan chan<- event.GenericEvent
+	k8sClient    client.Client
+	logger       logr.Logger
+}
+
+func (h *enqueueRequestsForIngressClassEvent) Create(e event.CreateEvent, _ workqueue.RateLimitingInterface) {
+	enqueueIngressesOfClasses(h.ingEventChan, h.k8sClient, sets.NewString(e.Meta.GetName()), h.logger)
+}
+
+func (h *enqueueRequestsForIngressClassEvent) Update(e event.UpdateEvent, _ workqueue.RateLimitingInterface) {
+	ingClassOld := e.ObjectOld.(*networking.IngressClass)
+	ingClassNew := e.ObjectNew.(*networking.IngressClass)
+	if equality.Semantic.DeepEqual(ingClassOld.Spec, ingClassNew.Spec) {
+		return
+	}
+	enqueueIngressesOfClasses(h.ingEventChan, h.k8sClient, sets.NewString(ingClassNew.Name), h.logger)
+}
+
+func (h *enqueueRequestsForIngressClassEvent) Delete(e event.DeleteEvent, _ workqueue.RateLimitingInterface) {
+	enqueueIngressesOfClasses(h.ingEventChan, h.k8sClient, sets.NewString(e.Meta.GetName()), h.logger)
+}
+
+func (h *enqueueRequestsForIngressClassEvent) Generic(_ event.GenericEvent, _ workqueue.RateLimitingInterface) {
+	// we don't have any generic event for ingressClasses.
+}
+
+// enqueueIngressesOfClasses enqueues the Ingresses whose IngressClass is any of ingClassNames.
+func enqueueIngressesOfClasses(ingEventChan chan<- event.GenericEvent, k8sClient client.Client, ingClassNames sets.String, logger logr.Logger) {
+	if len(ingClassNames) == 0 {
+		return
+	}
+	ingList := &networking.IngressList{}
+	if err := k8sClient.List(context.Background(), ingList); err != nil {
+		logger.Error(err, "failed to fetch ingresses")
+		return
+	}
+	for index := range ingList.Items {
+		ing := &ingList.Items[index]
+		if ing.Spec.IngressClassName == nil || !ingClassNames.Has(*ing.Spec.IngressClassName) {
+			continue
+		}
+		meta, _ := meta.Accessor(ing)
+
+		logger.V(1).Info("enqueue ingress for ingressClass event",
+			"ingressClass", *ing.Spec.IngressClassName,
+			"ingress", k8s.NamespacedName(ing))
+		ingEventChan <- event.GenericEvent{
+			Meta:   meta,
+			Object: ing,
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package eventhandlers
+
+import (
+	"context"
+	"github.com/go-logr/logr"
+	networking "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/workqueue"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/ingress"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+)
+
+// NewEnqueueRequestsForIngressClassParamsEvent constructs new enqueueRequestsForIngressClassParamsEvent.
+func NewEnqueueRequestsForIngressClassParamsEvent(ingEventChan chan<- event.GenericEvent, k8sClient client.Client,
+	logger logr.Logger) *enqueueRequestsForIngressClassParamsEvent {
+	return &enqueueRequestsForIngressClassParamsEvent{
+		ingEventChan: ingEventChan,
+		k8sClient:    k8sClient,
+		logger:       logger,
+	}
+}
+
+var _ handler.EventHandler = (*enqueueRequestsForIngressClassParamsEvent)(nil)
+
+// enqueueRequestsForIngressClassParamsEvent enqueues the Ingresses of IngressClasses referencing IngressClassParams that changed.
+type enqueueRequestsForIngressClassParamsEvent struct {
+	ingEventChan chan<- event.GenericEvent
+	k8sClient    client.Client
+	logger       logr.Logger
+}
+
+func (h *enqueueRequestsForIngressClassParamsEvent) Create(e event.CreateEvent, _ workqueue.RateLimitingInterface) {
+	h.enqueueImpactedIngresses(e.Meta.GetName())
+}
+
+func (h *enqueueRequestsForIngressClassParamsEvent) Update(e event.UpdateEvent, _ workqueue.RateLimitingInterface) {
+	paramsOld := e.ObjectOld.(*elbv2api.IngressClassParams)
+	paramsNew := e.ObjectNew.(*elbv2api.IngressClassParams)
+	if equality.Semantic.DeepEqual(paramsOld.Spec, paramsNew.Spec) {
+		return
+	}
+	h.enqueueImpactedIngresses(paramsNew.Name)
+}
+
+func (h *enqueueRequestsForIngressClassParamsEvent) Delete(e event.DeleteEvent, _ workqueue.RateLimitingInterface) {
+	h.enqueueImpactedIngresses(e.Meta.GetName())
+}
+
+func (h *enqueueRequestsForIngressClassParamsEvent) Generic(_ event.GenericEvent, _ workqueue.RateLimitingInterface) {
+	// we don't have any generic event for ingressClassParams.
+}
+
+func (h *enqueueRequestsForIngressClassParamsEvent) enqueueImpactedIngresses(paramsName string) {
+	ingClassList := &networking.IngressClassList{}
+	if err := h.k8sClient.List(context.Background(), ingClassList); err != nil {
+		h.logger.Error(err, "failed to fetch ingressClasses")
+		return
+	}
+	ingClassNames := sets.NewString()
+	for _, ingClass := range ingClassList.Items {
+		params := ingClass.Spec.Parameters
+		if params == nil || params.APIGroup == nil || *params.APIGroup != elbv2api.GroupVersion.Group ||
+			params.Kind != ingress.IngressClassParamsKind || params.Name != paramsName {
+			continue
+		}
+		ingClassNames.Insert(ingClass.Name)
+	}
+	enqueueIngressesOfClasses(h.ingEventChan, h.k8sClient, ingClassNames, h.logger)
+}
@@ -0,0 +1,74 @@
+package eventhandlers
+
+import (
+	"context"
+	"github.com/go-logr/logr"
+	networking "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+)
+
+// NewEnqueueRequestsForNamespaceEvent constructs new enqueueRequestsForNamespaceEvent.
+func NewEnqueueRequestsForNamespaceEvent(ingEventChan chan<- event.GenericEvent, k8sClient client.Client,
+	logger logr.Logger) *enqueueRequestsForNamespaceEvent {
+	return &enqueueRequestsForNamespaceEvent{
+		ingEventChan: ingEventChan,
+		k8sClient:    k8sClient,
+		logger:       logger,
+	}
+}
+
+var _ handler.EventHandler = (*enqueueRequestsForNamespaceEvent)(nil)
+
+// enqueueRequestsForNamespaceEvent enqueues the Ingresses in namespaces whose labels changed,
+// so that namespaces moved to this controller's shard are picked up right away.
+type enqueueRequestsForNamespaceEvent struct {
+	ingEventChan chan<- event.GenericEvent
+	k8sClient    client.Client
+	logger       logr.Logger
+}
+
+func (h *enqueueRequestsForNamespaceEvent) Create(_ event.CreateEvent, _ workqueue.RateLimitingInterface) {
+	// Ingresses in new namespaces are enqueued by their own create events.
+}
+
+func (h *enqueueRequestsForNamespaceEvent) Update(e event.UpdateEvent, _ workqueue.RateLimitingInterface) {
+	if equality.Semantic.DeepEqual(e.MetaOld.GetLabels(), e.MetaNew.GetLabels()) {
+		return
+	}
+	h.enqueueImpactedIngresses(e.MetaNew)
+}
+
+func (h *enqueueRequestsForNamespaceEvent) Delete(_ event.DeleteEvent, _ workqueue.RateLimitingInterface) {
+	// Ingresses are deleted together with their namespace, which are enqueued by their own delete events.
+}
+
+func (h *enqueueRequestsForNamespaceEvent) Generic(_ event.GenericEvent, _ workqueue.RateLimitingInterface) {
+	// we don't have any generic event for namespaces.
+}
+
+func (h *enqueueRequestsForNamespaceEvent) enqueueImpactedIngresses(ns metav1.Object) {
+	ingList := &networking.IngressList{}
+	if err := h.k8sClient.List(context.Background(), ingList, client.InNamespace(ns.GetName())); err != nil {
+		h.logger.Error(err, "failed to fetch ingresses")
+		return
+	}
+	for index := range ingList.Items {
+		ing := &ingList.Items[index]
+		meta, _ := meta.Accessor(ing)
+
+		h.logger.V(1).Info("enqueue ingress for namespace event",
+			"namespace", ns.GetName(),
+			"ingress", k8s.NamespacedName(ing))
+		h.ingEventChan <- event.GenericEvent{
+			Meta:   meta,
+			Object: ing,
+		}
+	}
+}
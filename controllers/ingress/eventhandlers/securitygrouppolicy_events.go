@@ -0,0 +1,86 @@
+package eventhandlers
+
+import (
+	"context"
+	"github.com/go-logr/logr"
+	networking "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+)
+
+// NewEnqueueRequestsForSecurityGroupPolicyEvent constructs new enqueueRequestsForSecurityGroupPolicyEvent.
+func NewEnqueueRequestsForSecurityGroupPolicyEvent(ingEventChan chan<- event.GenericEvent,
+	k8sClient client.Client, logger logr.Logger) *enqueueRequestsForSecurityGroupPolicyEvent {
+	return &enqueueRequestsForSecurityGroupPolicyEvent{
+		ingEventChan: ingEventChan,
+		k8sClient:    k8sClient,
+		logger:       logger,
+	}
+}
+
+var _ handler.EventHandler = (*enqueueRequestsForSecurityGroupPolicyEvent)(nil)
+
+type enqueueRequestsForSecurityGroupPolicyEvent struct {
+	ingEventChan chan<- event.GenericEvent
+	k8sClient    client.Client
+	logger       logr.Logger
+}
+
+func (h *enqueueRequestsForSecurityGroupPolicyEvent) Create(e event.CreateEvent, _ workqueue.RateLimitingInterface) {
+	h.enqueueImpactedIngress(e.Object.(*elbv2api.SecurityGroupPolicy))
+}
+
+func (h *enqueueRequestsForSecurityGroupPolicyEvent) Update(e event.UpdateEvent, _ workqueue.RateLimitingInterface) {
+	policyOld := e.ObjectOld.(*elbv2api.SecurityGroupPolicy)
+	policyNew := e.ObjectNew.(*elbv2api.SecurityGroupPolicy)
+
+	// we only care about spec updates.
+	if equality.Semantic.DeepEqual(policyOld.Spec, policyNew.Spec) {
+		return
+	}
+
+	// the Ingress targeted by old policy is enqueued as well, so that rules are revoked when the policy is retargeted.
+	if policyOld.Spec.TargetRef != policyNew.Spec.TargetRef {
+		h.enqueueImpactedIngress(policyOld)
+	}
+	h.enqueueImpactedIngress(policyNew)
+}
+
+func (h *enqueueRequestsForSecurityGroupPolicyEvent) Delete(e event.DeleteEvent, _ workqueue.RateLimitingInterface) {
+	h.enqueueImpactedIngress(e.Object.(*elbv2api.SecurityGroupPolicy))
+}
+
+func (h *enqueueRequestsForSecurityGroupPolicyEvent) Generic(e event.GenericEvent, _ workqueue.RateLimitingInterface) {
+	// we don't have any generic event for securityGroupPolicies.
+}
+
+func (h *enqueueRequestsForSecurityGroupPolicyEvent) enqueueImpactedIngress(policy *elbv2api.SecurityGroupPolicy) {
+	if policy.Spec.TargetRef.Kind != elbv2api.SecurityGroupPolicyTargetKindIngress {
+		return
+	}
+	ingKey := types.NamespacedName{Namespace: policy.Namespace, Name: policy.Spec.TargetRef.Name}
+	ing := &networking.Ingress{}
+	if err := h.k8sClient.Get(context.Background(), ingKey, ing); err != nil {
+		if !apierrors.IsNotFound(err) {
+			h.logger.Error(err, "failed to fetch ingress", "ingress", ingKey)
+		}
+		return
+	}
+	meta, _ := meta.Accessor(ing)
+
+	h.logger.V(1).Info("enqueue ingress for securityGroupPolicy event",
+		"securityGroupPolicy", k8s.NamespacedName(policy),
+		"ingress", ingKey)
+	h.ingEventChan <- event.GenericEvent{
+		Meta:   meta,
+		Object: ing,
+	}
+}
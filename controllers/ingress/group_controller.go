@@ -8,102 +8,306 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1beta1"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/controllers/ingress/eventhandlers"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/audit"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy"
+	elbv2deploy "sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
+	deploymetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/metrics"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/quota"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/ingress"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	networkingpkg "sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/tracing"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
 	"sigs.k8s.io/controller-runtime/pkg/source"
+	"sync"
+	"time"
 )
 
 const (
-	ingressTagPrefix        = "ingress.k8s.aws"
-	ingressAnnotationPrefix = "alb.ingress.kubernetes.io"
+	ingressTagPrefix        = tracking.IngressTagPrefix
+	ingressAnnotationPrefix = annotations.AnnotationPrefixIngress
 	controllerName          = "ingress"
 )
 
 // NewGroupReconciler constructs new GroupReconciler
 func NewGroupReconciler(cloud aws.Cloud, k8sClient client.Client, eventRecorder record.EventRecorder,
 	finalizerManager k8s.FinalizerManager, networkingSGManager networkingpkg.SecurityGroupManager,
-	networkingSGReconciler networkingpkg.SecurityGroupReconciler, subnetsResolver networkingpkg.SubnetsResolver,
+	networkingSGReconciler networkingpkg.SecurityGroupReconciler, sgDivergenceReporter networkingpkg.SecurityGroupDivergenceReporter, subnetsResolver networkingpkg.SubnetsResolver,
+	deployMetricsCollector deploymetrics.Collector, namespaceMatcher k8s.NamespaceMatcher, deadLetterQueue runtime.DeadLetterQueue, errorClassCollector runtime.ErrorClassCollector,
+	reconcileIntrospector runtime.ReconcileIntrospector, deployTracker runtime.InFlightDeployTracker, resumeMarkerStore runtime.ResumeMarkerStore, lbWarmPool elbv2deploy.LoadBalancerWarmPool, namespaceQuotaChecker quota.NamespaceQuotaChecker, dynamicConfigProvider config.DynamicConfigProvider,
 	config config.ControllerConfig, logger logr.Logger) *groupReconciler {
 
 	annotationParser := annotations.NewSuffixAnnotationParser(ingressAnnotationPrefix)
 	authConfigBuilder := ingress.NewDefaultAuthConfigBuilder(annotationParser)
 	enhancedBackendBuilder := ingress.NewDefaultEnhancedBackendBuilder(annotationParser)
 	referenceIndexer := ingress.NewDefaultReferenceIndexer(enhancedBackendBuilder, authConfigBuilder, logger)
+	sgResolver := networkingpkg.NewDefaultSecurityGroupResolver(cloud.EC2(), cloud.VpcID(), logger)
+	defaultTargetType := elbv2model.TargetType(config.IngressConfig.DefaultTargetType)
 	modelBuilder := ingress.NewDefaultModelBuilder(k8sClient, eventRecorder,
-		cloud.EC2(), cloud.ACM(),
-		annotationParser, subnetsResolver,
-		authConfigBuilder, enhancedBackendBuilder,
-		cloud.VpcID(), config.ClusterName, logger)
+		cloud.ACM(), annotationParser,
+		subnetsResolver, sgResolver,
+		authConfigBuilder, enhancedBackendBuilder, quota.NewDefaultProvider(cloud.ServiceQuotas(), logger), dynamicConfigProvider,
+		cloud.VpcID(), config.ClusterName, "", defaultTargetType, logger)
 	stackMarshaller := deploy.NewDefaultStackMarshaller()
+	var stackDeployerOpts []deploy.StackDeployerOption
+	if config.IngressConfig.EnableLegacyResourceAdoption {
+		stackDeployerOpts = append(stackDeployerOpts, deploy.WithLegacyResourceAdoption())
+	}
+	// the warm pool only holds ALBs in the controller's own account and region.
+	defaultStackDeployerOpts := append([]deploy.StackDeployerOption{deploy.WithLoadBalancerWarmPool(lbWarmPool)}, stackDeployerOpts...)
 	stackDeployer := deploy.NewDefaultStackDeployer(cloud, k8sClient, networkingSGManager, networkingSGReconciler,
-		config, ingressTagPrefix, logger)
+		config, dynamicConfigProvider, ingressTagPrefix, deployMetricsCollector, logger, defaultStackDeployerOpts...)
+	logBucketValidator := elbv2deploy.NewDefaultLogBucketValidator(cloud.S3(), cloud.Region(), logger)
 	ingressConfig := config.IngressConfig
-	groupLoader := ingress.NewDefaultGroupLoader(k8sClient, eventRecorder, annotationParser, ingressConfig.IngressClass)
+	groupLoader := ingress.NewDefaultGroupLoader(k8sClient, eventRecorder, annotationParser, namespaceMatcher, ingressConfig.IngressClass)
 	groupFinalizerManager := ingress.NewDefaultFinalizerManager(finalizerManager)
+	iamRoleResolver := ingress.NewDefaultIAMRoleResolver(k8sClient, annotationParser)
+	regionResolver := ingress.NewDefaultRegionResolver(ingress.NewDefaultClassParamsLoader(k8sClient))
+	mutationRecorder := audit.NewDefaultMutationRecorder(eventRecorder, config.MutationAuditConfig, logger)
+	provisionedResourcesExporter := deploy.NewDefaultProvisionedResourcesExporter(k8sClient, config.ProvisionedResourcesConfigMap, logger)
+	inventoryManager := deploy.NewDefaultInventoryManager(k8sClient, logger)
+	var stackExportHandler deploy.StackExportHandler
+	if config.EnableStackExportEndpoint {
+		stackExportHandler = deploy.NewDefaultStackExportHandler(deploy.NewDefaultStackExporter(cloud.VpcID()), logger)
+	}
+
+	// builds the components for IngressGroups that provision AWS resources with an assumed IAM role or in another region.
+	customComponentsBuilder := func(roleARN string, region ingress.Region) groupDeployComponents {
+		roleCloud := cloud
+		if roleARN != "" {
+			roleCloud = roleCloud.AssumeRole(roleARN)
+		}
+		if region.Name != "" {
+			roleCloud = roleCloud.ForRegion(region.Name, region.VpcID)
+		}
+		roleSGManager := networkingpkg.NewDefaultSecurityGroupManager(roleCloud.EC2(), logger)
+		roleSGReconciler := networkingpkg.NewDefaultSecurityGroupReconciler(roleSGManager, sgDivergenceReporter, logger)
+		roleSubnetsResolver := networkingpkg.NewDefaultSubnetsResolver(roleCloud.EC2(), roleCloud.VpcID(), config.ClusterName, logger)
+		roleSGResolver := networkingpkg.NewDefaultSecurityGroupResolver(roleCloud.EC2(), roleCloud.VpcID(), logger)
+		return groupDeployComponents{
+			modelBuilder: ingress.NewDefaultModelBuilder(k8sClient, eventRecorder,
+				roleCloud.ACM(), annotationParser,
+				roleSubnetsResolver, roleSGResolver,
+				authConfigBuilder, enhancedBackendBuilder, quota.NewDefaultProvider(roleCloud.ServiceQuotas(), logger), dynamicConfigProvider,
+				roleCloud.VpcID(), config.ClusterName, roleARN, defaultTargetType, logger),
+			stackDeployer: deploy.NewDefaultStackDeployer(roleCloud, k8sClient, roleSGManager, roleSGReconciler,
+				config, dynamicConfigProvider, ingressTagPrefix, deployMetricsCollector, logger, stackDeployerOpts...),
+			logBucketValidator: elbv2deploy.NewDefaultLogBucketValidator(roleCloud.S3(), roleCloud.Region(), logger),
+		}
+	}
 
 	return &groupReconciler{
 		k8sClient:        k8sClient,
 		eventRecorder:    eventRecorder,
+		annotationParser: annotationParser,
 		referenceIndexer: referenceIndexer,
 		modelBuilder:     modelBuilder,
 		stackMarshaller:  stackMarshaller,
 		stackDeployer:    stackDeployer,
 
 		groupLoader:           groupLoader,
+		namespaceMatcher:      namespaceMatcher,
 		groupFinalizerManager: groupFinalizerManager,
+		iamRoleResolver:       iamRoleResolver,
+		regionResolver:        regionResolver,
+		logBucketValidator:    logBucketValidator,
+		mutationRecorder:      mutationRecorder,
 		logger:                logger,
 
+		provisionedResourcesExporter: provisionedResourcesExporter,
+		inventoryManager:             inventoryManager,
+		stackExportHandler:           stackExportHandler,
+
+		customComponentsBuilder: customComponentsBuilder,
+		customComponents:        make(map[groupDeployComponentsKey]groupDeployComponents),
+
+		healthChecker:         runtime.NewDefaultReconcileHealthChecker(controllerName, metrics.Registry, config.ReconcileStallTimeout),
+		reconcileStallTimeout: config.ReconcileStallTimeout,
+		deadLetterQueue:       deadLetterQueue,
+		errorClassCollector:   errorClassCollector,
+		reconcileIntrospector: reconcileIntrospector,
+		deployTracker:         deployTracker,
+		resumeMarkerStore:     resumeMarkerStore,
+		namespaceQuotaChecker: namespaceQuotaChecker,
+		timerQueue:            runtime.NewDefaultTimerQueue(),
+		rateLimiter:           runtime.NewReconcileRateLimiter(config.ReconcileBackoffConfig.BaseDelay, config.ReconcileBackoffConfig.MaxDelay),
+
 		maxConcurrentReconciles: config.IngressConfig.MaxConcurrentReconciles,
+		certTagsResyncPeriod:    config.IngressConfig.CertTagsResyncPeriod,
+		driftSyncPeriod:         config.DriftSyncPeriod,
+		slowReconcileThreshold:  config.SlowReconcileThreshold,
+		costRates:               deploy.DefaultCostRates.WithOverrides(config.CostRates),
+
+		deployMetricsCollector: deployMetricsCollector,
+		dynamicConfigProvider:  dynamicConfigProvider,
 	}
 }
 
+// groupDeployComponents are the components to build and deploy model stack for IngressGroups.
+type groupDeployComponents struct {
+	modelBuilder       ingress.ModelBuilder
+	stackDeployer      deploy.StackDeployer
+	logBucketValidator elbv2deploy.LogBucketValidator
+}
+
+// groupDeployComponentsKey identifies the IAM role and region that components provision AWS resources with.
+type groupDeployComponentsKey struct {
+	roleARN string
+	region  ingress.Region
+}
+
 // GroupReconciler reconciles a IngressGroup
 type groupReconciler struct {
 	k8sClient        client.Client
 	eventRecorder    record.EventRecorder
+	annotationParser annotations.Parser
 	referenceIndexer ingress.ReferenceIndexer
 	modelBuilder     ingress.ModelBuilder
 	stackMarshaller  deploy.StackMarshaller
 	stackDeployer    deploy.StackDeployer
 
 	groupLoader           ingress.GroupLoader
+	namespaceMatcher      k8s.NamespaceMatcher
 	groupFinalizerManager ingress.FinalizerManager
+	iamRoleResolver       ingress.IAMRoleResolver
+	regionResolver        ingress.RegionResolver
+	logBucketValidator    elbv2deploy.LogBucketValidator
+	mutationRecorder      audit.MutationRecorder
 	logger                logr.Logger
 
+	provisionedResourcesExporter deploy.ProvisionedResourcesExporter
+	inventoryManager             deploy.InventoryManager
+	// stackExportHandler is nil unless the stack export endpoint is enabled.
+	stackExportHandler deploy.StackExportHandler
+
+	// customComponents caches the components for IngressGroups using assumed IAM roles or other regions by role ARN and region.
+	customComponentsBuilder func(roleARN string, region ingress.Region) groupDeployComponents
+	customComponents        map[groupDeployComponentsKey]groupDeployComponents
+	customComponentsMutex   sync.Mutex
+
+	healthChecker         runtime.ReconcileHealthChecker
+	reconcileStallTimeout time.Duration
+	deadLetterQueue       runtime.DeadLetterQueue
+	errorClassCollector   runtime.ErrorClassCollector
+	reconcileIntrospector runtime.ReconcileIntrospector
+	deployTracker         runtime.InFlightDeployTracker
+	resumeMarkerStore     runtime.ResumeMarkerStore
+	namespaceQuotaChecker quota.NamespaceQuotaChecker
+	timerQueue            runtime.TimerQueue
+	rateLimiter           ratelimiter.RateLimiter
+
 	maxConcurrentReconciles int
+	certTagsResyncPeriod    time.Duration
+	driftSyncPeriod         time.Duration
+	slowReconcileThreshold  time.Duration
+	costRates               deploy.CostRates
+
+	deployMetricsCollector deploymetrics.Collector
+	dynamicConfigProvider  config.DynamicConfigProvider
 }
 
-// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;update;patch;delete
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses/status,verbs=update;patch
-// +kubebuilder:rbac:groups=extensions,resources=ingresses,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingressclasses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=elbv2.k8s.aws,resources=ingressclassparams,verbs=get;list;watch
+// +kubebuilder:rbac:groups=elbv2.k8s.aws,resources=resourcequotapolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=elbv2.k8s.aws,resources=securitygrouppolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=elbv2.k8s.aws,resources=loadbalancerinventories,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups=extensions,resources=ingresses,verbs=get;list;watch;update;patch;delete
 // +kubebuilder:rbac:groups=extensions,resources=ingresses/status,verbs=update;patch
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;patch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile
 func (r *groupReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
-	return runtime.HandleReconcileError(r.reconcile(req), r.logger)
+	ctx, span := tracing.StartSpan(context.Background(), "ReconcileIngressGroup", tracing.AttributeReconcileRequest.String(req.String()))
+	var err error
+	finishReconcile := r.reconcileIntrospector.StartReconcile(controllerName, req)
+	defer func() {
+		finishReconcile(err)
+	}()
+	err = r.reconcile(ctx, req)
+	tracing.EndSpan(span, err)
+	r.healthChecker.ObserveReconcile(err)
+	if errorClass := r.errorClassCollector.ObserveReconcile(controllerName, err); errorClass != "" {
+		r.reportErrorClass(req, errorClass)
+	}
+	if reason, deadLettered := r.deadLetterQueue.ObserveReconcile(controllerName, req, err); deadLettered {
+		return ctrl.Result{}, r.pauseReconcile(req, reason, err)
+	}
+	return runtime.HandleReconcileError(err, r.logger)
+}
+
+// pauseReconcile reports the members of IngressGroup for dead-lettered request as paused via events, it's no longer retried until changed.
+func (r *groupReconciler) pauseReconcile(req ctrl.Request, reason string, reconcileErr error) error {
+	ctx := context.Background()
+	ingGroupID := ingress.DecodeGroupIDFromReconcileRequest(req)
+	ingGroup, err := r.groupLoader.Load(ctx, ingGroupID)
+	if err != nil {
+		return err
+	}
+	r.logger.Info("paused reconcile due to terminal error", "ingressGroup", ingGroupID, "reason", reason, "error", reconcileErr.Error())
+	r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonReconcilePaused, fmt.Sprintf("Paused reconcile until changed due to %v", reconcileErr))
+	return nil
 }
 
-func (r *groupReconciler) reconcile(req ctrl.Request) error {
+// matchesShard checks whether the IngressGroup is sharded to this controller,
+// explicit IngressGroups are sharded by their names while implicit ones are sharded by their namespaces.
+func (r *groupReconciler) matchesShard(ctx context.Context, ingGroupID ingress.GroupID) (bool, error) {
+	if ingGroupID.IsExplicit() {
+		return r.namespaceMatcher.MatchesExplicitGroup(ingGroupID.Name), nil
+	}
+	return r.namespaceMatcher.Matches(ctx, ingGroupID.Namespace)
+}
+
+// reportErrorClass reports the class of reconcile error via events on the members of IngressGroup for request.
+func (r *groupReconciler) reportErrorClass(req ctrl.Request, errorClass runtime.ErrorClass) {
 	ctx := context.Background()
 	ingGroupID := ingress.DecodeGroupIDFromReconcileRequest(req)
 	ingGroup, err := r.groupLoader.Load(ctx, ingGroupID)
+	if err != nil {
+		return
+	}
+	r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonFailedReconcile, fmt.Sprintf("Failed reconcile with error class %v", errorClass))
+}
+
+func (r *groupReconciler) reconcile(ctx context.Context, req ctrl.Request) error {
+	stageTimer := runtime.NewStageTimer()
+	ctx = runtime.ContextWithStageTimer(ctx, stageTimer)
+	ingGroupID := ingress.DecodeGroupIDFromReconcileRequest(req)
+	matchesShard, err := r.matchesShard(ctx, ingGroupID)
+	if err != nil {
+		return err
+	}
+	// IngressGroups sharded to other controllers are left untouched.
+	if !matchesShard {
+		return nil
+	}
+	ingGroup, err := r.groupLoader.Load(ctx, ingGroupID)
+	if err != nil {
+		return err
+	}
+	defer r.reportSlowReconcile(ctx, ingGroup, stageTimer)
+	ingGroup, err = r.expireIngressGroupMembers(ctx, req, ingGroup)
 	if err != nil {
 		return err
 	}
@@ -113,65 +317,385 @@ func (r *groupReconciler) reconcile(req ctrl.Request) error {
 		return err
 	}
 
-	_, lb, err := r.buildAndDeployModel(ctx, ingGroup)
+	protectedIng, err := ingress.FindUnconfirmedDeletionProtectedIngress(r.annotationParser, ingGroup)
+	if err != nil {
+		return err
+	}
+	if protectedIng != nil {
+		// the IngressGroup is reconciled again once the deletion is confirmed via annotation.
+		r.eventRecorder.Event(protectedIng, corev1.EventTypeWarning, k8s.IngressEventReasonDeletionProtected,
+			fmt.Sprintf("LoadBalancer deletion is protected, confirm it with annotation %v/%v: %v",
+				ingressAnnotationPrefix, annotations.IngressSuffixDeletionConfirmation, protectedIng.Name))
+		return nil
+	}
+
+	driftSyncPeriod, err := r.resolveDriftSyncPeriod(ingGroup)
 	if err != nil {
+		r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonFailedBuildModel, fmt.Sprintf("Failed build model due to %v", err))
 		return err
 	}
+	if driftSyncPeriod > 0 {
+		// drifts on SecurityGroup rules are only observable when bypassing the cache.
+		ctx = networkingpkg.ContextWithReloadIgnoringCache(ctx)
+	}
+	if r.isIngressGroupReconcilePaused(ingGroup) {
+		ctx = aws.ContextWithMutationsFrozen(ctx)
+	}
+	stack, lb, err := r.buildAndDeployModel(ctx, ingGroup)
+	if err != nil {
+		// drifts are reported via DriftDetected events, since Ingresses have no status conditions.
+		var mutationsFrozenErr *aws.MutationsFrozenError
+		if !errors.As(err, &mutationsFrozenErr) {
+			return err
+		}
+		if driftSyncPeriod > 0 {
+			return deploy.NewDriftSyncRequeue(driftSyncPeriod)
+		}
+		return nil
+	}
 
+	costEstimate, err := r.buildCostEstimate(ingGroup, stack)
+	if err != nil {
+		return err
+	}
 	if len(ingGroup.Members) > 0 && lb != nil {
 		lbDNS, err := lb.DNSName().Resolve(ctx)
 		if err != nil {
 			return err
 		}
-		if err := r.updateIngressGroupStatus(ctx, ingGroup, lbDNS); err != nil {
+		provisionedResources, err := deploy.BuildProvisionedResourcesPayload(stack)
+		if err != nil {
+			return err
+		}
+		if err := r.updateIngressGroupStatus(ctx, ingGroup, lbDNS, provisionedResources, costEstimate); err != nil {
 			r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonFailedUpdateStatus, fmt.Sprintf("Failed update status due to %v", err))
 			return err
 		}
+		unhealthyTargets, err := deploy.BuildUnhealthyTargetsMessage(ctx, r.k8sClient, stack)
+		if err != nil {
+			return err
+		}
+		if unhealthyTargets != "" {
+			r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonUnhealthyTargets, unhealthyTargets)
+		}
+	}
+	if err := r.syncIngressGroupInventory(ctx, ingGroup, stack); err != nil {
+		return err
 	}
 
 	if len(ingGroup.InactiveMembers) > 0 {
+		if err := r.unexportIngressGroupProvisionedResources(ctx, ingGroup); err != nil {
+			return err
+		}
 		if err := r.groupFinalizerManager.RemoveGroupFinalizer(ctx, ingGroupID, ingGroup.InactiveMembers...); err != nil {
 			r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonFailedRemoveFinalizer, fmt.Sprintf("Failed remove finalizer due to %v", err))
 			return err
 		}
 	}
+	if len(ingGroup.Members) == 0 {
+		r.deadLetterQueue.Forget(controllerName, req)
+	}
 
 	r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeNormal, k8s.IngressEventReasonSuccessfullyReconciled, "Successfully reconciled")
+	weightRampRequeueAfter, err := ingress.BuildWeightRampRequeueAfter(ctx, r.k8sClient, r.annotationParser, ingGroup, stack)
+	if err != nil {
+		return err
+	}
+	if weightRampRequeueAfter > 0 {
+		// weights of new TargetGroups are increased in stages, which must be applied even without changes to Ingresses.
+		return runtime.NewRequeueNeededAfter("ramp target group weights", weightRampRequeueAfter)
+	}
+	replacementRequeueAfter, err := deploy.BuildLoadBalancerReplacementRequeueAfter(stack, time.Now())
+	if err != nil {
+		return err
+	}
+	if replacementRequeueAfter > 0 {
+		// blue/green replacement of LoadBalancer progresses in phases, which must be applied even without changes to Ingresses.
+		return runtime.NewRequeueNeededAfter("replace load balancer", replacementRequeueAfter)
+	}
+	if r.certTagsResyncPeriod > 0 && r.isIngressGroupUsingCertTags(ingGroup) &&
+		(driftSyncPeriod == 0 || r.certTagsResyncPeriod <= driftSyncPeriod) {
+		return runtime.NewRequeueNeededAfter("discover certificates by tags", r.certTagsResyncPeriod)
+	}
+	if driftSyncPeriod > 0 && len(ingGroup.Members) > 0 {
+		return deploy.NewDriftSyncRequeue(driftSyncPeriod)
+	}
 	return nil
 }
 
+// expireIngressGroupMembers moves the members of IngressGroup whose TTL expired into inactive members, so that their AWS resources are torn down.
+// expired members are deleted if requested, and the IngressGroup is reconciled again once other members expire.
+func (r *groupReconciler) expireIngressGroupMembers(ctx context.Context, req ctrl.Request, ingGroup ingress.Group) (ingress.Group, error) {
+	now := time.Now()
+	activeMembers := make([]*networking.Ingress, 0, len(ingGroup.Members))
+	for _, ing := range ingGroup.Members {
+		expiry, err := deploy.ResolveExpiry(r.annotationParser, annotations.AnnotationPrefixIngress, annotations.IngressSuffixTTL, annotations.IngressSuffixTTLDeleteObject, ing)
+		if err != nil {
+			r.eventRecorder.Event(ing, corev1.EventTypeWarning, k8s.IngressEventReasonFailedBuildModel, fmt.Sprintf("Failed build model due to %v", err))
+			return ingress.Group{}, err
+		}
+		if expiry == nil || !expiry.Expired(now) {
+			if expiry != nil {
+				r.timerQueue.AddAt(req, expiry.ExpiresAt)
+			}
+			activeMembers = append(activeMembers, ing)
+			continue
+		}
+		if _, err := deploy.DeleteExpiredObject(ctx, r.k8sClient, ing, *expiry); err != nil {
+			return ingress.Group{}, err
+		}
+		// expired members that already released the group finalizer have their AWS resources torn down.
+		if !ingress.HasGroupFinalizer(ingGroup.ID, ing) {
+			continue
+		}
+		r.logger.Info("ingress expired", "ingress", k8s.NamespacedName(ing), "deleteObject", expiry.DeleteObject)
+		r.eventRecorder.Event(ing, corev1.EventTypeNormal, k8s.IngressEventReasonExpired, "TTL expired, tearing down AWS resources")
+		ingGroup.InactiveMembers = append(ingGroup.InactiveMembers, ing)
+	}
+	ingGroup.Members = activeMembers
+	return ingGroup, nil
+}
+
+// isIngressGroupReconcilePaused checks whether any member of IngressGroup pauses reconcile, including members being deleted.
+// members share the same LoadBalancer, so the mutations of AWS resources are frozen for the whole IngressGroup.
+func (r *groupReconciler) isIngressGroupReconcilePaused(ingGroup ingress.Group) bool {
+	for _, members := range [][]*networking.Ingress{ingGroup.Members, ingGroup.InactiveMembers} {
+		for _, ing := range members {
+			reconcileMode := ""
+			if exists := r.annotationParser.ParseStringAnnotation(annotations.IngressSuffixReconcile, &reconcileMode, ing.Annotations); exists &&
+				reconcileMode == k8s.ReconcileModePaused {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// resolveDriftSyncPeriod resolves the period at which IngressGroup is forcibly re-synchronized,
+// the shortest drift sync period among members is used.
+func (r *groupReconciler) resolveDriftSyncPeriod(ingGroup ingress.Group) (time.Duration, error) {
+	memberAnnotations := make([]map[string]string, 0, len(ingGroup.Members))
+	for _, ing := range ingGroup.Members {
+		memberAnnotations = append(memberAnnotations, ing.Annotations)
+	}
+	return deploy.ResolveDriftSyncPeriod(r.annotationParser, annotations.IngressSuffixDriftSyncPeriod, r.driftSyncPeriod, memberAnnotations...)
+}
+
+// isIngressGroupUsingCertTags checks whether any member of IngressGroup discovers certificates by tags,
+// such IngressGroups need to be reconciled periodically since certificate tag changes are not observable.
+func (r *groupReconciler) isIngressGroupUsingCertTags(ingGroup ingress.Group) bool {
+	for _, ing := range ingGroup.Members {
+		rawCertTags := ""
+		if exists := r.annotationParser.ParseStringAnnotation(annotations.IngressSuffixCertificateTags, &rawCertTags, ing.Annotations); exists {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *groupReconciler) buildAndDeployModel(ctx context.Context, ingGroup ingress.Group) (core.Stack, *elbv2model.LoadBalancer, error) {
-	stack, lb, err := r.modelBuilder.Build(ctx, ingGroup)
+	components, err := r.buildGroupDeployComponents(ctx, ingGroup)
 	if err != nil {
 		r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonFailedBuildModel, fmt.Sprintf("Failed build model due to %v", err))
 		return nil, nil, err
 	}
+	var stack core.Stack
+	var lb *elbv2model.LoadBalancer
+	err = runtime.TimeStage(ctx, "BuildModel", func(ctx context.Context) error {
+		var err error
+		stack, lb, err = components.modelBuilder.Build(ctx, ingGroup)
+		return err
+	})
+	if err != nil {
+		var ambiguousSGNameErr *networkingpkg.AmbiguousSecurityGroupNameError
+		if errors.As(err, &ambiguousSGNameErr) {
+			r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonAmbiguousSecurityGroup, fmt.Sprintf("Failed build model due to %v", err))
+		} else {
+			r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonFailedBuildModel, fmt.Sprintf("Failed build model due to %v", err))
+		}
+		return nil, nil, err
+	}
 	stackJSON, err := r.stackMarshaller.Marshal(stack)
 	if err != nil {
 		r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonFailedBuildModel, fmt.Sprintf("Failed build model due to %v", err))
 		return nil, nil, err
 	}
 	r.logger.Info("successfully built model", "model", stackJSON)
+	r.validateLogBucket(ctx, ingGroup, components.logBucketValidator, lb)
 
-	if err := r.stackDeployer.Deploy(ctx, stack); err != nil {
-		r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonFailedDeployModel, fmt.Sprintf("Failed deploy model due to %v", err))
+	members := make([]k8sruntime.Object, 0, len(ingGroup.Members))
+	namespaces := make([]string, 0, len(ingGroup.Members))
+	deployed := false
+	for _, ing := range ingGroup.Members {
+		members = append(members, ing)
+		namespaces = append(namespaces, ing.Namespace)
+		if len(ing.Status.LoadBalancer.Ingress) != 0 {
+			deployed = true
+		}
+	}
+	if err := r.namespaceQuotaChecker.Reserve(ctx, stack, namespaces, deployed); err != nil {
+		var namespaceQuotaErr *quota.NamespaceQuotaExceededError
+		if errors.As(err, &namespaceQuotaErr) {
+			r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonNamespaceQuotaExceeded, fmt.Sprintf("Failed deploy model due to %v", err))
+		} else {
+			r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonFailedDeployModel, fmt.Sprintf("Failed deploy model due to %v", err))
+		}
+		return nil, nil, err
+	}
+	deployCtx := audit.ContextWithMutationRecorder(ctx, r.mutationRecorder, members...)
+	// stacks are deployed as a whole even if the controller is shutting down, so that AWS resources aren't left half-deployed.
+	finishDeploy, err := r.deployTracker.TrackDeploy(controllerName, ingress.EncodeGroupIDToReconcileRequest(ingGroup.ID))
+	if err != nil {
+		// deployments refused during shutdown are left to the next leader, instead of being reported as failures.
+		if errors.Is(err, runtime.ErrShuttingDown) {
+			return nil, nil, runtime.NewRequeueNeeded(err.Error())
+		}
+		return nil, nil, err
+	}
+	err = components.stackDeployer.Deploy(deployCtx, stack)
+	finishDeploy()
+	if err != nil {
+		var quotaErr *quota.ExceededError
+		var mutationsFrozenErr *aws.MutationsFrozenError
+		var replacementRequiredErr *elbv2deploy.LoadBalancerReplacementRequiredError
+		if errors.As(err, &mutationsFrozenErr) {
+			r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonDriftDetected, fmt.Sprintf("Reconcile paused, drift detected: %v", mutationsFrozenErr.Drift()))
+		} else if errors.As(err, &quotaErr) {
+			r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonQuotaExceeded, fmt.Sprintf("Failed deploy model due to %v", err))
+		} else if errors.As(err, &replacementRequiredErr) {
+			r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonLoadBalancerReplacementRequired,
+				fmt.Sprintf("Failed deploy model, loadBalancer %v requires replacement: %v", replacementRequiredErr.LoadBalancerARN, replacementRequiredErr))
+		} else {
+			r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonFailedDeployModel, fmt.Sprintf("Failed deploy model due to %v", err))
+		}
 		return nil, nil, err
 	}
 	r.logger.Info("successfully deployed model", "ingressGroup", ingGroup.ID)
+	if r.stackExportHandler != nil {
+		if lb != nil {
+			r.stackExportHandler.Record(stack)
+		} else {
+			r.stackExportHandler.Forget(stack.StackID())
+		}
+	}
 	return stack, lb, err
 }
 
+// reportSlowReconcile emits events with the per-stage timing breakdown on members of IngressGroup if its reconcile took longer than slowReconcileThreshold.
+func (r *groupReconciler) reportSlowReconcile(ctx context.Context, ingGroup ingress.Group, stageTimer *runtime.StageTimer) {
+	message, slow := runtime.SlowReconcileMessage(stageTimer, r.slowReconcileThreshold)
+	if !slow {
+		return
+	}
+	r.logger.Info("slow reconcile", "ingressGroup", ingGroup.ID, "timing", message)
+	r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonSlowReconcile, message)
+}
+
+// validateLogBucket runs the pre-flight check for log delivery of LoadBalancer, and emits warning events on failure.
+// it doesn't block deployment since the check is best-effort.
+func (r *groupReconciler) validateLogBucket(ctx context.Context, ingGroup ingress.Group, logBucketValidator elbv2deploy.LogBucketValidator, lb *elbv2model.LoadBalancer) {
+	if lb == nil {
+		return
+	}
+	if err := logBucketValidator.Validate(ctx, lb); err != nil {
+		r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonInvalidLogBucket, fmt.Sprintf("Log delivery will fail due to %v", err))
+	}
+}
+
+// buildGroupDeployComponents returns the components to build and deploy model stack for IngressGroup,
+// based on the IAM role and region resolved for IngressGroup.
+func (r *groupReconciler) buildGroupDeployComponents(ctx context.Context, ingGroup ingress.Group) (groupDeployComponents, error) {
+	roleARN, err := r.iamRoleResolver.Resolve(ctx, ingGroup)
+	if err != nil {
+		return groupDeployComponents{}, err
+	}
+	region, err := r.regionResolver.Resolve(ctx, ingGroup)
+	if err != nil {
+		return groupDeployComponents{}, err
+	}
+	if roleARN == "" && region.Name == "" {
+		return groupDeployComponents{
+			modelBuilder:       r.modelBuilder,
+			stackDeployer:      r.stackDeployer,
+			logBucketValidator: r.logBucketValidator,
+		}, nil
+	}
+
+	componentsKey := groupDeployComponentsKey{roleARN: roleARN, region: region}
+	r.customComponentsMutex.Lock()
+	defer r.customComponentsMutex.Unlock()
+	if components, ok := r.customComponents[componentsKey]; ok {
+		return components, nil
+	}
+	components := r.customComponentsBuilder(roleARN, region)
+	r.customComponents[componentsKey] = components
+	return components, nil
+}
+
 func (r *groupReconciler) recordIngressGroupEvent(_ context.Context, ingGroup ingress.Group, eventType string, reason string, message string) {
 	for _, ing := range ingGroup.Members {
 		r.eventRecorder.Event(ing, eventType, reason, message)
 	}
 }
 
-func (r *groupReconciler) updateIngressGroupStatus(ctx context.Context, ingGroup ingress.Group, lbDNS string) error {
+// buildCostEstimate builds the cost estimate reported on members of IngressGroup, and observes the cost in metrics attributed to their namespaces.
+// it's empty unless cost estimate is enabled.
+func (r *groupReconciler) buildCostEstimate(ingGroup ingress.Group, stack core.Stack) (string, error) {
+	if !r.dynamicConfigProvider.Get().FeatureGates.Enabled(config.FeatureCostEstimate) {
+		return "", nil
+	}
+	costEstimate, err := deploy.BuildCostEstimate(stack, r.costRates)
+	if err != nil {
+		return "", err
+	}
+	memberNamespaces := make([]string, 0, len(ingGroup.Members))
+	for _, ing := range ingGroup.Members {
+		memberNamespaces = append(memberNamespaces, ing.Namespace)
+	}
+	r.deployMetricsCollector.ObserveCostEstimate(stack.StackID().String(), deploy.SplitCostEstimateByNamespace(costEstimate, memberNamespaces))
+	return deploy.BuildCostEstimatePayload(costEstimate)
+}
+
+// syncIngressGroupInventory maintains the LoadBalancerInventory of IngressGroup if enabled, it's deleted once IngressGroup has no members.
+func (r *groupReconciler) syncIngressGroupInventory(ctx context.Context, ingGroup ingress.Group, stack core.Stack) error {
+	if len(ingGroup.Members) == 0 {
+		return r.inventoryManager.Delete(ctx, deploy.InventoryOwnerKindIngress, stack.StackID())
+	}
+	if !r.dynamicConfigProvider.Get().FeatureGates.Enabled(config.FeatureLoadBalancerInventory) {
+		return nil
+	}
+	owners := make([]types.NamespacedName, 0, len(ingGroup.Members))
+	for _, ing := range ingGroup.Members {
+		owners = append(owners, k8s.NamespacedName(ing))
+	}
+	return r.inventoryManager.Sync(ctx, deploy.InventoryOwnerKindIngress, stack, owners)
+}
+
+func (r *groupReconciler) updateIngressGroupStatus(ctx context.Context, ingGroup ingress.Group, lbDNS string, provisionedResources string, costEstimate string) error {
 	for _, ing := range ingGroup.Members {
 		if err := r.updateIngressStatus(ctx, lbDNS, ing); err != nil {
 			return err
 		}
+		changed, err := r.provisionedResourcesExporter.Export(ctx, ing.Namespace, deploy.ProvisionedResourcesKeyPrefixIngress+ing.Name, provisionedResources)
+		if err != nil {
+			return err
+		}
+		if changed {
+			r.eventRecorder.Event(ing, corev1.EventTypeNormal, k8s.IngressEventReasonProvisionedResources, fmt.Sprintf("Provisioned AWS resources: %v", provisionedResources))
+		}
+		if costEstimate != "" {
+			r.eventRecorder.Event(ing, corev1.EventTypeNormal, k8s.IngressEventReasonCostEstimate, fmt.Sprintf("Estimated monthly cost: %v", costEstimate))
+		}
+	}
+	return nil
+}
+
+// unexportIngressGroupProvisionedResources removes the exported AWS resources for inactive members of IngressGroup.
+func (r *groupReconciler) unexportIngressGroupProvisionedResources(ctx context.Context, ingGroup ingress.Group) error {
+	for _, ing := range ingGroup.InactiveMembers {
+		if err := r.provisionedResourcesExporter.Unexport(ctx, ing.Namespace, deploy.ProvisionedResourcesKeyPrefixIngress+ing.Name); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -197,6 +721,7 @@ func (r *groupReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager
 	c, err := controller.New(controllerName, mgr, controller.Options{
 		MaxConcurrentReconciles: r.maxConcurrentReconciles,
 		Reconciler:              r,
+		RateLimiter:             r.rateLimiter,
 	})
 	if err != nil {
 		return err
@@ -207,6 +732,16 @@ func (r *groupReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager
 	if err := r.setupWatches(ctx, c); err != nil {
 		return err
 	}
+	if r.reconcileStallTimeout > 0 {
+		if err := runtime.AddReconcileHealthChecker(mgr, metrics.Registry, controllerName, r.healthChecker); err != nil {
+			return err
+		}
+	}
+	if r.stackExportHandler != nil {
+		if err := mgr.AddMetricsExtraHandler("/debug/stack-export/ingress", r.stackExportHandler); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -225,6 +760,13 @@ func (r *groupReconciler) setupIndexes(ctx context.Context, fieldIndexer client.
 	); err != nil {
 		return err
 	}
+	if err := fieldIndexer.IndexField(ctx, &networking.Ingress{}, ingress.IndexKeyConfigMapRefName,
+		func(obj k8sruntime.Object) []string {
+			return r.referenceIndexer.BuildConfigMapRefIndexes(context.Background(), obj.(*networking.Ingress))
+		},
+	); err != nil {
+		return err
+	}
 	if err := fieldIndexer.IndexField(ctx, &corev1.Service{}, ingress.IndexKeySecretRefName,
 		func(obj k8sruntime.Object) []string {
 			return r.referenceIndexer.BuildSecretRefIndexes(context.Background(), obj.(*corev1.Service))
@@ -244,7 +786,22 @@ func (r *groupReconciler) setupWatches(_ context.Context, c controller.Controlle
 		r.logger.WithName("eventHandlers").WithName("service"))
 	secretEventHandler := eventhandlers.NewEnqueueRequestsForSecretEvent(ingEventChan, svcEventChan, r.k8sClient, r.eventRecorder,
 		r.logger.WithName("eventHandlers").WithName("secret"))
+	configMapEventHandler := eventhandlers.NewEnqueueRequestsForConfigMapEvent(ingEventChan, r.k8sClient, r.eventRecorder,
+		r.logger.WithName("eventHandlers").WithName("configMap"))
+	sgPolicyEventHandler := eventhandlers.NewEnqueueRequestsForSecurityGroupPolicyEvent(ingEventChan, r.k8sClient,
+		r.logger.WithName("eventHandlers").WithName("securityGroupPolicy"))
+	namespaceEventHandler := eventhandlers.NewEnqueueRequestsForNamespaceEvent(ingEventChan, r.k8sClient,
+		r.logger.WithName("eventHandlers").WithName("namespace"))
+	ingClassEventHandler := eventhandlers.NewEnqueueRequestsForIngressClassEvent(ingEventChan, r.k8sClient,
+		r.logger.WithName("eventHandlers").WithName("ingressClass"))
+	ingClassParamsEventHandler := eventhandlers.NewEnqueueRequestsForIngressClassParamsEvent(ingEventChan, r.k8sClient,
+		r.logger.WithName("eventHandlers").WithName("ingressClassParams"))
 
+	// requests with deployments interrupted by previous leader's shutdown are enqueued ahead of others.
+	resumeSource := runtime.NewResumeSource(controllerName, r.resumeMarkerStore, r.logger.WithName("resume"))
+	if err := c.Watch(resumeSource, &handler.Funcs{}); err != nil {
+		return err
+	}
 	if err := c.Watch(&source.Channel{Source: ingEventChan}, ingEventHandler); err != nil {
 		return err
 	}
@@ -260,5 +817,24 @@ func (r *groupReconciler) setupWatches(_ context.Context, c controller.Controlle
 	if err := c.Watch(&source.Kind{Type: &corev1.Secret{}}, secretEventHandler); err != nil {
 		return err
 	}
+	if err := c.Watch(&source.Kind{Type: &corev1.ConfigMap{}}, configMapEventHandler); err != nil {
+		return err
+	}
+	if err := c.Watch(&source.Kind{Type: &elbv2api.SecurityGroupPolicy{}}, sgPolicyEventHandler); err != nil {
+		return err
+	}
+	if err := c.Watch(&source.Kind{Type: &corev1.Namespace{}}, namespaceEventHandler); err != nil {
+		return err
+	}
+	if err := c.Watch(&source.Kind{Type: &networking.IngressClass{}}, ingClassEventHandler); err != nil {
+		return err
+	}
+	if err := c.Watch(&source.Kind{Type: &elbv2api.IngressClassParams{}}, ingClassParamsEventHandler); err != nil {
+		return err
+	}
+	// the timer queue enqueues reconcile requests directly.
+	if err := c.Watch(r.timerQueue, &handler.Funcs{}); err != nil {
+		return err
+	}
 	return nil
 }
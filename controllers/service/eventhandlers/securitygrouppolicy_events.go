@@ -0,0 +1,86 @@
+package eventhandlers
+
+import (
+	"context"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+)
+
+// NewEnqueueRequestsForSecurityGroupPolicyEvent constructs new enqueueRequestsForSecurityGroupPolicyEvent.
+func NewEnqueueRequestsForSecurityGroupPolicyEvent(svcEventChan chan<- event.GenericEvent,
+	k8sClient client.Client, logger logr.Logger) *enqueueRequestsForSecurityGroupPolicyEvent {
+	return &enqueueRequestsForSecurityGroupPolicyEvent{
+		svcEventChan: svcEventChan,
+		k8sClient:    k8sClient,
+		logger:       logger,
+	}
+}
+
+var _ handler.EventHandler = (*enqueueRequestsForSecurityGroupPolicyEvent)(nil)
+
+type enqueueRequestsForSecurityGroupPolicyEvent struct {
+	svcEventChan chan<- event.GenericEvent
+	k8sClient    client.Client
+	logger       logr.Logger
+}
+
+func (h *enqueueRequestsForSecurityGroupPolicyEvent) Create(e event.CreateEvent, _ workqueue.RateLimitingInterface) {
+	h.enqueueImpactedService(e.Object.(*elbv2api.SecurityGroupPolicy))
+}
+
+func (h *enqueueRequestsForSecurityGroupPolicyEvent) Update(e event.UpdateEvent, _ workqueue.RateLimitingInterface) {
+	policyOld := e.ObjectOld.(*elbv2api.SecurityGroupPolicy)
+	policyNew := e.ObjectNew.(*elbv2api.SecurityGroupPolicy)
+
+	// we only care about spec updates.
+	if equality.Semantic.DeepEqual(policyOld.Spec, policyNew.Spec) {
+		return
+	}
+
+	// the Service targeted by old policy is enqueued as well, so that rules are revoked when the policy is retargeted.
+	if policyOld.Spec.TargetRef != policyNew.Spec.TargetRef {
+		h.enqueueImpactedService(policyOld)
+	}
+	h.enqueueImpactedService(policyNew)
+}
+
+func (h *enqueueRequestsForSecurityGroupPolicyEvent) Delete(e event.DeleteEvent, _ workqueue.RateLimitingInterface) {
+	h.enqueueImpactedService(e.Object.(*elbv2api.SecurityGroupPolicy))
+}
+
+func (h *enqueueRequestsForSecurityGroupPolicyEvent) Generic(e event.GenericEvent, _ workqueue.RateLimitingInterface) {
+	// we don't have any generic event for securityGroupPolicies.
+}
+
+func (h *enqueueRequestsForSecurityGroupPolicyEvent) enqueueImpactedService(policy *elbv2api.SecurityGroupPolicy) {
+	if policy.Spec.TargetRef.Kind != elbv2api.SecurityGroupPolicyTargetKindService {
+		return
+	}
+	svcKey := types.NamespacedName{Namespace: policy.Namespace, Name: policy.Spec.TargetRef.Name}
+	svc := &corev1.Service{}
+	if err := h.k8sClient.Get(context.Background(), svcKey, svc); err != nil {
+		if !apierrors.IsNotFound(err) {
+			h.logger.Error(err, "failed to fetch service", "service", svcKey)
+		}
+		return
+	}
+	meta, _ := meta.Accessor(svc)
+
+	h.logger.V(1).Info("enqueue service for securityGroupPolicy event",
+		"securityGroupPolicy", k8s.NamespacedName(policy),
+		"service", svcKey)
+	h.svcEventChan <- event.GenericEvent{
+		Meta:   meta,
+		Object: svc,
+	}
+}
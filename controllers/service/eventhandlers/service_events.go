@@ -1,39 +1,39 @@
 package eventhandlers
 
 import (
+	"context"
+	"fmt"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
-	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/service"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
-	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
-const loadBalancerTypeNLBIP = "nlb-ip"
-
 // NewEnqueueRequestForServiceEvent constructs new enqueueRequestsForServiceEvent.
-func NewEnqueueRequestForServiceEvent(eventRecorder record.EventRecorder, annotationParser annotations.Parser, logger logr.Logger) *enqueueRequestsForServiceEvent {
+func NewEnqueueRequestForServiceEvent(groupLoader service.GroupLoader, eventRecorder record.EventRecorder, logger logr.Logger) *enqueueRequestsForServiceEvent {
 	return &enqueueRequestsForServiceEvent{
-		eventRecorder:    eventRecorder,
-		annotationParser: annotationParser,
-		logger:           logger,
+		groupLoader:   groupLoader,
+		eventRecorder: eventRecorder,
+		logger:        logger,
 	}
 }
 
 var _ handler.EventHandler = (*enqueueRequestsForServiceEvent)(nil)
 
 type enqueueRequestsForServiceEvent struct {
-	eventRecorder    record.EventRecorder
-	annotationParser annotations.Parser
-	logger           logr.Logger
+	groupLoader   service.GroupLoader
+	eventRecorder record.EventRecorder
+	logger        logr.Logger
 }
 
 func (h *enqueueRequestsForServiceEvent) Create(e event.CreateEvent, queue workqueue.RateLimitingInterface) {
-	h.enqueueManagedService(queue, e.Object.(*corev1.Service))
+	h.enqueueIfBelongsToGroup(queue, e.Object.(*corev1.Service))
 }
 
 func (h *enqueueRequestsForServiceEvent) Update(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
@@ -46,7 +46,8 @@ func (h *enqueueRequestsForServiceEvent) Update(e event.UpdateEvent, queue workq
 		return
 	}
 
-	h.enqueueManagedService(queue, newSvc)
+	// the group of old Service is enqueued as well, so that Services leaving a group are released by it.
+	h.enqueueIfBelongsToGroup(queue, oldSvc, newSvc)
 }
 
 func (h *enqueueRequestsForServiceEvent) Delete(e event.DeleteEvent, queue workqueue.RateLimitingInterface) {
@@ -56,26 +57,33 @@ func (h *enqueueRequestsForServiceEvent) Delete(e event.DeleteEvent, queue workq
 }
 
 func (h *enqueueRequestsForServiceEvent) Generic(e event.GenericEvent, queue workqueue.RateLimitingInterface) {
+	h.enqueueIfBelongsToGroup(queue, e.Object.(*corev1.Service))
 }
 
-func (h *enqueueRequestsForServiceEvent) isServiceSupported(service *corev1.Service) bool {
-	lbType := ""
-	_ = h.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixLoadBalancerType, &lbType, service.Annotations)
-	if lbType == loadBalancerTypeNLBIP {
-		return true
+func (h *enqueueRequestsForServiceEvent) enqueueIfBelongsToGroup(queue workqueue.RateLimitingInterface, svcList ...*corev1.Service) {
+	sourceSvcKeyByGroupID := make(map[service.GroupID]types.NamespacedName)
+
+	for _, svc := range svcList {
+		groupID, err := h.groupLoader.FindGroupID(context.Background(), svc)
+		if err != nil {
+			h.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedLoadGroupID, fmt.Sprintf("failed load groupID due to %v", err))
+			continue
+		}
+
+		svcKey := k8s.NamespacedName(svc)
+		if groupID == nil {
+			h.logger.V(1).Info("ignoring service", "service", svcKey)
+			continue
+		}
+
+		sourceSvcKeyByGroupID[*groupID] = svcKey
 	}
-	return false
-}
 
-func (h *enqueueRequestsForServiceEvent) enqueueManagedService(queue workqueue.RateLimitingInterface, service *corev1.Service) {
-	// Check if the svc needs to be handled
-	if !h.isServiceSupported(service) {
-		return
+	for groupID, sourceSvcKey := range sourceSvcKeyByGroupID {
+		h.logger.V(1).Info("enqueue serviceGroup for service event",
+			"service", sourceSvcKey,
+			"serviceGroup", groupID,
+		)
+		queue.Add(service.EncodeGroupIDToReconcileRequest(groupID))
 	}
-	queue.Add(reconcile.Request{
-		NamespacedName: types.NamespacedName{
-			Namespace: service.Namespace,
-			Name:      service.Name,
-		},
-	})
 }
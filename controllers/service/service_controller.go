@@ -6,27 +6,41 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/controllers/service/eventhandlers"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/audit"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy"
+	elbv2deploy "sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
+	deploymetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/metrics"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/quota"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/securitygrouppolicy"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/service"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/tracing"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
 	"sigs.k8s.io/controller-runtime/pkg/source"
+	"time"
 )
 
 const (
-	serviceFinalizer        = "service.k8s.aws/resources"
-	serviceTagPrefix        = "service.k8s.aws"
+	serviceTagPrefix        = tracking.ServiceTagPrefix
 	serviceAnnotationPrefix = "service.beta.kubernetes.io"
 	controllerName          = "service"
 )
@@ -34,112 +48,445 @@ const (
 func NewServiceReconciler(cloud aws.Cloud, k8sClient client.Client, eventRecorder record.EventRecorder,
 	finalizerManager k8s.FinalizerManager, networkingSGManager networking.SecurityGroupManager,
 	networkingSGReconciler networking.SecurityGroupReconciler, subnetsResolver networking.SubnetsResolver,
-	config config.ControllerConfig, logger logr.Logger) *serviceReconciler {
+	deployMetricsCollector deploymetrics.Collector, namespaceMatcher k8s.NamespaceMatcher, deadLetterQueue runtime.DeadLetterQueue, errorClassCollector runtime.ErrorClassCollector,
+	reconcileIntrospector runtime.ReconcileIntrospector, deployTracker runtime.InFlightDeployTracker, resumeMarkerStore runtime.ResumeMarkerStore, namespaceQuotaChecker quota.NamespaceQuotaChecker, dynamicConfigProvider config.DynamicConfigProvider, config config.ControllerConfig, logger logr.Logger) *serviceReconciler {
 
 	annotationParser := annotations.NewSuffixAnnotationParser(serviceAnnotationPrefix)
-	modelBuilder := service.NewDefaultModelBuilder(annotationParser, subnetsResolver, config.ClusterName)
+	groupLoader := service.NewDefaultGroupLoader(k8sClient, annotationParser, namespaceMatcher)
+	groupFinalizerManager := service.NewDefaultFinalizerManager(finalizerManager)
+	sgPolicyPermissionBuilder := securitygrouppolicy.NewDefaultPermissionBuilder(k8sClient, eventRecorder)
+	modelBuilder := service.NewDefaultModelBuilder(annotationParser, subnetsResolver, sgPolicyPermissionBuilder, eventRecorder, quota.NewDefaultProvider(cloud.ServiceQuotas(), logger), dynamicConfigProvider, config.ClusterName, config.AddonsConfig.IPAMEnabled, config.AddonsConfig.VPCEndpointServiceEnabled)
 	stackMarshaller := deploy.NewDefaultStackMarshaller()
-	stackDeployer := deploy.NewDefaultStackDeployer(cloud, k8sClient, networkingSGManager, networkingSGReconciler, config, serviceTagPrefix, logger)
+	stackDeployer := deploy.NewDefaultStackDeployer(cloud, k8sClient, networkingSGManager, networkingSGReconciler, config, dynamicConfigProvider, serviceTagPrefix, deployMetricsCollector, logger)
+	logBucketValidator := elbv2deploy.NewDefaultLogBucketValidator(cloud.S3(), cloud.Region(), logger)
+	mutationRecorder := audit.NewDefaultMutationRecorder(eventRecorder, config.MutationAuditConfig, logger)
+	provisionedResourcesExporter := deploy.NewDefaultProvisionedResourcesExporter(k8sClient, config.ProvisionedResourcesConfigMap, logger)
+	inventoryManager := deploy.NewDefaultInventoryManager(k8sClient, logger)
+	var stackExportHandler deploy.StackExportHandler
+	if config.EnableStackExportEndpoint {
+		stackExportHandler = deploy.NewDefaultStackExportHandler(deploy.NewDefaultStackExporter(cloud.VpcID()), logger)
+	}
 	return &serviceReconciler{
-		k8sClient:        k8sClient,
-		eventRecorder:    eventRecorder,
-		finalizerManager: finalizerManager,
-		annotationParser: annotationParser,
+		k8sClient:             k8sClient,
+		eventRecorder:         eventRecorder,
+		groupLoader:           groupLoader,
+		groupFinalizerManager: groupFinalizerManager,
+		annotationParser:      annotationParser,
+		namespaceMatcher:      namespaceMatcher,
+
+		modelBuilder:       modelBuilder,
+		stackMarshaller:    stackMarshaller,
+		stackDeployer:      stackDeployer,
+		logBucketValidator: logBucketValidator,
+		mutationRecorder:   mutationRecorder,
+		logger:             logger,
+
+		provisionedResourcesExporter: provisionedResourcesExporter,
+		inventoryManager:             inventoryManager,
+		stackExportHandler:           stackExportHandler,
 
-		modelBuilder:    modelBuilder,
-		stackMarshaller: stackMarshaller,
-		stackDeployer:   stackDeployer,
-		logger:          logger,
+		healthChecker:         runtime.NewDefaultReconcileHealthChecker(controllerName, metrics.Registry, config.ReconcileStallTimeout),
+		reconcileStallTimeout: config.ReconcileStallTimeout,
+		deadLetterQueue:       deadLetterQueue,
+		errorClassCollector:   errorClassCollector,
+		reconcileIntrospector: reconcileIntrospector,
+		deployTracker:         deployTracker,
+		resumeMarkerStore:     resumeMarkerStore,
+		namespaceQuotaChecker: namespaceQuotaChecker,
+		timerQueue:            runtime.NewDefaultTimerQueue(),
+		rateLimiter:           runtime.NewReconcileRateLimiter(config.ReconcileBackoffConfig.BaseDelay, config.ReconcileBackoffConfig.MaxDelay),
 
 		maxConcurrentReconciles: config.ServiceMaxConcurrentReconciles,
+		driftSyncPeriod:         config.DriftSyncPeriod,
+		slowReconcileThreshold:  config.SlowReconcileThreshold,
+		costRates:               deploy.DefaultCostRates.WithOverrides(config.CostRates),
+
+		deployMetricsCollector: deployMetricsCollector,
+		dynamicConfigProvider:  dynamicConfigProvider,
 	}
 }
 
 type serviceReconciler struct {
-	k8sClient        client.Client
-	eventRecorder    record.EventRecorder
-	finalizerManager k8s.FinalizerManager
-	annotationParser annotations.Parser
+	k8sClient             client.Client
+	eventRecorder         record.EventRecorder
+	groupLoader           service.GroupLoader
+	groupFinalizerManager service.FinalizerManager
+	annotationParser      annotations.Parser
+	namespaceMatcher      k8s.NamespaceMatcher
+
+	modelBuilder       service.ModelBuilder
+	stackMarshaller    deploy.StackMarshaller
+	stackDeployer      deploy.StackDeployer
+	logBucketValidator elbv2deploy.LogBucketValidator
+	mutationRecorder   audit.MutationRecorder
+	logger             logr.Logger
 
-	modelBuilder    service.ModelBuilder
-	stackMarshaller deploy.StackMarshaller
-	stackDeployer   deploy.StackDeployer
-	logger          logr.Logger
+	provisionedResourcesExporter deploy.ProvisionedResourcesExporter
+	inventoryManager             deploy.InventoryManager
+	// stackExportHandler is nil unless the stack export endpoint is enabled.
+	stackExportHandler deploy.StackExportHandler
+
+	healthChecker         runtime.ReconcileHealthChecker
+	reconcileStallTimeout time.Duration
+	deadLetterQueue       runtime.DeadLetterQueue
+	errorClassCollector   runtime.ErrorClassCollector
+	reconcileIntrospector runtime.ReconcileIntrospector
+	deployTracker         runtime.InFlightDeployTracker
+	resumeMarkerStore     runtime.ResumeMarkerStore
+	namespaceQuotaChecker quota.NamespaceQuotaChecker
+	timerQueue            runtime.TimerQueue
+	rateLimiter           ratelimiter.RateLimiter
 
 	maxConcurrentReconciles int
+	driftSyncPeriod         time.Duration
+	slowReconcileThreshold  time.Duration
+	costRates               deploy.CostRates
+
+	deployMetricsCollector deploymetrics.Collector
+	dynamicConfigProvider  config.DynamicConfigProvider
 }
 
-// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=services/status,verbs=update;patch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;patch
+// +kubebuilder:rbac:groups=elbv2.k8s.aws,resources=resourcequotapolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=elbv2.k8s.aws,resources=securitygrouppolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=elbv2.k8s.aws,resources=loadbalancerinventories,verbs=get;list;watch;create;update;delete
 
 func (r *serviceReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
-	return runtime.HandleReconcileError(r.reconcile(req), r.logger)
+	ctx, span := tracing.StartSpan(context.Background(), "ReconcileService", tracing.AttributeReconcileRequest.String(req.String()))
+	var err error
+	finishReconcile := r.reconcileIntrospector.StartReconcile(controllerName, req)
+	defer func() {
+		finishReconcile(err)
+	}()
+	err = r.reconcile(ctx, req)
+	tracing.EndSpan(span, err)
+	r.healthChecker.ObserveReconcile(err)
+	if errorClass := r.errorClassCollector.ObserveReconcile(controllerName, err); errorClass != "" {
+		r.reportErrorClass(req, errorClass)
+	}
+	if reason, deadLettered := r.deadLetterQueue.ObserveReconcile(controllerName, req, err); deadLettered {
+		return ctrl.Result{}, r.pauseReconcile(req, reason, err)
+	}
+	return runtime.HandleReconcileError(err, r.logger)
 }
 
-func (r *serviceReconciler) reconcile(req ctrl.Request) error {
+// pauseReconcile reports the members of ServiceGroup for dead-lettered request as paused via events, it's no longer retried until changed.
+func (r *serviceReconciler) pauseReconcile(req ctrl.Request, reason string, reconcileErr error) error {
 	ctx := context.Background()
-	svc := &corev1.Service{}
-	if err := r.k8sClient.Get(ctx, req.NamespacedName, svc); err != nil {
-		return client.IgnoreNotFound(err)
+	svcGroupID := service.DecodeGroupIDFromReconcileRequest(req)
+	svcGroup, err := r.groupLoader.Load(ctx, svcGroupID)
+	if err != nil {
+		return err
+	}
+	r.logger.Info("paused reconcile due to terminal error", "serviceGroup", svcGroupID, "reason", reason, "error", reconcileErr.Error())
+	r.recordServiceGroupEvent(svcGroup, corev1.EventTypeWarning, k8s.ServiceEventReasonReconcilePaused, fmt.Sprintf("Paused reconcile until changed due to %v", reconcileErr))
+	return nil
+}
+
+// reportErrorClass reports the class of reconcile error via events on the members of ServiceGroup for request.
+func (r *serviceReconciler) reportErrorClass(req ctrl.Request, errorClass runtime.ErrorClass) {
+	ctx := context.Background()
+	svcGroupID := service.DecodeGroupIDFromReconcileRequest(req)
+	svcGroup, err := r.groupLoader.Load(ctx, svcGroupID)
+	if err != nil {
+		return
+	}
+	r.recordServiceGroupEvent(svcGroup, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedReconcile, fmt.Sprintf("Failed reconcile with error class %v", errorClass))
+}
+
+func (r *serviceReconciler) reconcile(ctx context.Context, req ctrl.Request) error {
+	stageTimer := runtime.NewStageTimer()
+	ctx = runtime.ContextWithStageTimer(ctx, stageTimer)
+	svcGroupID := service.DecodeGroupIDFromReconcileRequest(req)
+	matchesShard, err := r.matchesShard(ctx, svcGroupID)
+	if err != nil {
+		return err
+	}
+	// ServiceGroups sharded to other controllers are left untouched.
+	if !matchesShard {
+		return nil
+	}
+	svcGroup, err := r.groupLoader.Load(ctx, svcGroupID)
+	if err != nil {
+		return err
+	}
+	if len(svcGroup.Members) == 0 && len(svcGroup.InactiveMembers) == 0 {
+		r.deadLetterQueue.Forget(controllerName, req)
+		return nil
+	}
+	defer r.reportSlowReconcile(svcGroup, stageTimer)
+	svcGroup, err = r.expireServiceGroupMembers(ctx, req, svcGroup)
+	if err != nil {
+		return err
+	}
+	if r.isServiceGroupReconcilePaused(svcGroup) {
+		ctx = aws.ContextWithMutationsFrozen(ctx)
+	}
+	return r.reconcileLoadBalancerResources(ctx, svcGroup)
+}
+
+// matchesShard checks whether the ServiceGroup is sharded to this controller,
+// explicit ServiceGroups are sharded by their names while implicit ones are sharded by their namespaces.
+func (r *serviceReconciler) matchesShard(ctx context.Context, svcGroupID service.GroupID) (bool, error) {
+	if svcGroupID.IsExplicit() {
+		return r.namespaceMatcher.MatchesExplicitGroup(svcGroupID.Name), nil
+	}
+	return r.namespaceMatcher.Matches(ctx, svcGroupID.Namespace)
+}
+
+// expireServiceGroupMembers moves the members of ServiceGroup whose TTL expired into inactive members, so that their AWS resources are torn down.
+// expired members are deleted if requested, and the ServiceGroup is reconciled again once other members expire.
+func (r *serviceReconciler) expireServiceGroupMembers(ctx context.Context, req ctrl.Request, svcGroup service.Group) (service.Group, error) {
+	now := time.Now()
+	activeMembers := make([]*corev1.Service, 0, len(svcGroup.Members))
+	for _, svc := range svcGroup.Members {
+		expiry, err := deploy.ResolveExpiry(r.annotationParser, annotations.AnnotationPrefixService, annotations.SvcLBSuffixTTL, annotations.SvcLBSuffixTTLDeleteObject, svc)
+		if err != nil {
+			r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedBuildModel, fmt.Sprintf("Failed build model due to %v", err))
+			return service.Group{}, err
+		}
+		if expiry == nil || !expiry.Expired(now) {
+			if expiry != nil {
+				r.timerQueue.AddAt(req, expiry.ExpiresAt)
+			}
+			activeMembers = append(activeMembers, svc)
+			continue
+		}
+		if _, err := deploy.DeleteExpiredObject(ctx, r.k8sClient, svc, *expiry); err != nil {
+			return service.Group{}, err
+		}
+		// expired members that already released the group finalizer have their AWS resources torn down.
+		if !service.HasGroupFinalizer(svcGroup.ID, svc) {
+			continue
+		}
+		r.logger.Info("service expired", "service", k8s.NamespacedName(svc), "deleteObject", expiry.DeleteObject)
+		r.eventRecorder.Event(svc, corev1.EventTypeNormal, k8s.ServiceEventReasonExpired, "TTL expired, tearing down AWS resources")
+		svcGroup.InactiveMembers = append(svcGroup.InactiveMembers, svc)
+	}
+	svcGroup.Members = activeMembers
+	return svcGroup, nil
+}
+
+// isServiceGroupReconcilePaused checks whether any member of ServiceGroup pauses reconcile, including members being deleted.
+// members share the same LoadBalancer, so the mutations of AWS resources are frozen for the whole ServiceGroup.
+func (r *serviceReconciler) isServiceGroupReconcilePaused(svcGroup service.Group) bool {
+	for _, members := range [][]*corev1.Service{svcGroup.Members, svcGroup.InactiveMembers} {
+		for _, svc := range members {
+			reconcileMode := ""
+			if exists := r.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixReconcile, &reconcileMode, svc.Annotations); exists &&
+				reconcileMode == k8s.ReconcileModePaused {
+				return true
+			}
+		}
 	}
-	if !svc.DeletionTimestamp.IsZero() {
-		return r.cleanupLoadBalancerResources(ctx, svc)
+	return false
+}
+
+// resolveDriftSyncPeriod resolves the period at which ServiceGroup is forcibly re-synchronized,
+// the shortest drift sync period among members is used.
+func (r *serviceReconciler) resolveDriftSyncPeriod(svcGroup service.Group) (time.Duration, error) {
+	memberAnnotations := make([]map[string]string, 0, len(svcGroup.Members))
+	for _, svc := range svcGroup.Members {
+		memberAnnotations = append(memberAnnotations, svc.Annotations)
 	}
-	return r.reconcileLoadBalancerResources(ctx, svc)
+	return deploy.ResolveDriftSyncPeriod(r.annotationParser, annotations.SvcLBSuffixDriftSyncPeriod, r.driftSyncPeriod, memberAnnotations...)
 }
 
-func (r *serviceReconciler) buildAndDeployModel(ctx context.Context, svc *corev1.Service) (core.Stack, *elbv2model.LoadBalancer, error) {
-	stack, lb, err := r.modelBuilder.Build(ctx, svc)
+// reportSlowReconcile emits events with the per-stage timing breakdown on members of ServiceGroup if its reconcile took longer than slowReconcileThreshold.
+func (r *serviceReconciler) reportSlowReconcile(svcGroup service.Group, stageTimer *runtime.StageTimer) {
+	message, slow := runtime.SlowReconcileMessage(stageTimer, r.slowReconcileThreshold)
+	if !slow {
+		return
+	}
+	r.logger.Info("slow reconcile", "serviceGroup", svcGroup.ID, "timing", message)
+	r.recordServiceGroupEvent(svcGroup, corev1.EventTypeWarning, k8s.ServiceEventReasonSlowReconcile, message)
+}
+
+func (r *serviceReconciler) buildAndDeployModel(ctx context.Context, svcGroup service.Group) (core.Stack, *elbv2model.LoadBalancer, error) {
+	var stack core.Stack
+	var lb *elbv2model.LoadBalancer
+	err := runtime.TimeStage(ctx, "BuildModel", func(ctx context.Context) error {
+		var err error
+		stack, lb, err = r.modelBuilder.Build(ctx, svcGroup)
+		return err
+	})
 	if err != nil {
-		r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedBuildModel, fmt.Sprintf("Failed build model due to %v", err))
+		r.recordServiceGroupEvent(svcGroup, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedBuildModel, fmt.Sprintf("Failed build model due to %v", err))
 		return nil, nil, err
 	}
 	stackJSON, err := r.stackMarshaller.Marshal(stack)
 	if err != nil {
-		r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedBuildModel, fmt.Sprintf("Failed build model due to %v", err))
+		r.recordServiceGroupEvent(svcGroup, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedBuildModel, fmt.Sprintf("Failed build model due to %v", err))
 		return nil, nil, err
 	}
 	r.logger.Info("successfully built model", "model", stackJSON)
+	if lb != nil {
+		if err := r.logBucketValidator.Validate(ctx, lb); err != nil {
+			r.recordServiceGroupEvent(svcGroup, corev1.EventTypeWarning, k8s.ServiceEventReasonInvalidLogBucket, fmt.Sprintf("Log delivery will fail due to %v", err))
+		}
+	}
 
-	if err = r.stackDeployer.Deploy(ctx, stack); err != nil {
-		r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedDeployModel, fmt.Sprintf("Failed deploy model due to %v", err))
+	members := make([]k8sruntime.Object, 0, len(svcGroup.Members)+len(svcGroup.InactiveMembers))
+	namespaces := make([]string, 0, len(svcGroup.Members))
+	deployed := false
+	for _, svc := range svcGroup.Members {
+		members = append(members, svc)
+		namespaces = append(namespaces, svc.Namespace)
+		if len(svc.Status.LoadBalancer.Ingress) != 0 {
+			deployed = true
+		}
+	}
+	for _, svc := range svcGroup.InactiveMembers {
+		members = append(members, svc)
+	}
+	if err := r.namespaceQuotaChecker.Reserve(ctx, stack, namespaces, deployed); err != nil {
+		var namespaceQuotaErr *quota.NamespaceQuotaExceededError
+		if errors.As(err, &namespaceQuotaErr) {
+			r.recordServiceGroupEvent(svcGroup, corev1.EventTypeWarning, k8s.ServiceEventReasonNamespaceQuotaExceeded, fmt.Sprintf("Failed deploy model due to %v", err))
+		} else {
+			r.recordServiceGroupEvent(svcGroup, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedDeployModel, fmt.Sprintf("Failed deploy model due to %v", err))
+		}
 		return nil, nil, err
 	}
-	r.logger.Info("successfully deployed model", "service", k8s.NamespacedName(svc))
+	deployCtx := audit.ContextWithMutationRecorder(ctx, r.mutationRecorder, members...)
+	// stacks are deployed as a whole even if the controller is shutting down, so that AWS resources aren't left half-deployed.
+	finishDeploy, err := r.deployTracker.TrackDeploy(controllerName, service.EncodeGroupIDToReconcileRequest(svcGroup.ID))
+	if err != nil {
+		// deployments refused during shutdown are left to the next leader, instead of being reported as failures.
+		if errors.Is(err, runtime.ErrShuttingDown) {
+			return nil, nil, runtime.NewRequeueNeeded(err.Error())
+		}
+		return nil, nil, err
+	}
+	err = r.stackDeployer.Deploy(deployCtx, stack)
+	finishDeploy()
+	if err != nil {
+		var quotaErr *quota.ExceededError
+		var mutationsFrozenErr *aws.MutationsFrozenError
+		var replacementRequiredErr *elbv2deploy.LoadBalancerReplacementRequiredError
+		if errors.As(err, &mutationsFrozenErr) {
+			r.recordServiceGroupEvent(svcGroup, corev1.EventTypeWarning, k8s.ServiceEventReasonDriftDetected, fmt.Sprintf("Reconcile paused, drift detected: %v", mutationsFrozenErr.Drift()))
+		} else if errors.As(err, &quotaErr) {
+			r.recordServiceGroupEvent(svcGroup, corev1.EventTypeWarning, k8s.ServiceEventReasonQuotaExceeded, fmt.Sprintf("Failed deploy model due to %v", err))
+		} else if errors.As(err, &replacementRequiredErr) {
+			r.recordServiceGroupEvent(svcGroup, corev1.EventTypeWarning, k8s.ServiceEventReasonLoadBalancerReplacementRequired,
+				fmt.Sprintf("Failed deploy model, loadBalancer %v requires replacement: %v", replacementRequiredErr.LoadBalancerARN, replacementRequiredErr))
+		} else {
+			r.recordServiceGroupEvent(svcGroup, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedDeployModel, fmt.Sprintf("Failed deploy model due to %v", err))
+		}
+		return nil, nil, err
+	}
+	r.logger.Info("successfully deployed model", "serviceGroup", svcGroup.ID)
+	if r.stackExportHandler != nil {
+		if lb != nil {
+			r.stackExportHandler.Record(stack)
+		} else {
+			r.stackExportHandler.Forget(stack.StackID())
+		}
+	}
 
 	return stack, lb, nil
 }
 
-func (r *serviceReconciler) reconcileLoadBalancerResources(ctx context.Context, svc *corev1.Service) error {
-	if err := r.finalizerManager.AddFinalizers(ctx, svc, serviceFinalizer); err != nil {
-		r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedAddFinalizer, fmt.Sprintf("Failed add finalizer due to %v", err))
+func (r *serviceReconciler) reconcileLoadBalancerResources(ctx context.Context, svcGroup service.Group) error {
+	if err := r.groupFinalizerManager.AddGroupFinalizer(ctx, svcGroup.ID, svcGroup.Members...); err != nil {
+		r.recordServiceGroupEvent(svcGroup, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedAddFinalizer, fmt.Sprintf("Failed add finalizer due to %v", err))
 		return err
 	}
-	_, lb, err := r.buildAndDeployModel(ctx, svc)
+	driftSyncPeriod, err := r.resolveDriftSyncPeriod(svcGroup)
 	if err != nil {
+		r.recordServiceGroupEvent(svcGroup, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedBuildModel, fmt.Sprintf("Failed build model due to %v", err))
 		return err
 	}
-	lbDNS, err := lb.DNSName().Resolve(ctx)
+	if driftSyncPeriod > 0 {
+		// drifts on SecurityGroup rules are only observable when bypassing the cache.
+		ctx = networking.ContextWithReloadIgnoringCache(ctx)
+	}
+	stack, lb, err := r.buildAndDeployModel(ctx, svcGroup)
+	if err != nil {
+		// drifts are reported via DriftDetected events, since Services have no status conditions.
+		var mutationsFrozenErr *aws.MutationsFrozenError
+		if !errors.As(err, &mutationsFrozenErr) {
+			return err
+		}
+		if driftSyncPeriod > 0 && len(svcGroup.Members) > 0 {
+			return deploy.NewDriftSyncRequeue(driftSyncPeriod)
+		}
+		return nil
+	}
+	costEstimate, err := r.buildCostEstimate(svcGroup, stack)
 	if err != nil {
 		return err
 	}
-
-	if err = r.updateServiceStatus(ctx, lbDNS, svc); err != nil {
-		r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedUpdateStatus, fmt.Sprintf("Failed update status due to %v", err))
+	if len(svcGroup.Members) > 0 && lb != nil {
+		lbDNS, err := lb.DNSName().Resolve(ctx)
+		if err != nil {
+			return err
+		}
+		provisionedResources, err := deploy.BuildProvisionedResourcesPayload(stack)
+		if err != nil {
+			return err
+		}
+		if err := r.updateServiceGroupStatus(ctx, svcGroup, lbDNS, provisionedResources, costEstimate); err != nil {
+			r.recordServiceGroupEvent(svcGroup, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedUpdateStatus, fmt.Sprintf("Failed update status due to %v", err))
+			return err
+		}
+		unhealthyTargets, err := deploy.BuildUnhealthyTargetsMessage(ctx, r.k8sClient, stack)
+		if err != nil {
+			return err
+		}
+		if unhealthyTargets != "" {
+			r.recordServiceGroupEvent(svcGroup, corev1.EventTypeWarning, k8s.ServiceEventReasonUnhealthyTargets, unhealthyTargets)
+		}
+	}
+	if err := r.syncServiceGroupInventory(ctx, svcGroup, stack); err != nil {
+		return err
+	}
+	if err := r.cleanupInactiveMembers(ctx, svcGroup); err != nil {
 		return err
 	}
-	r.eventRecorder.Event(svc, corev1.EventTypeNormal, k8s.ServiceEventReasonSuccessfullyReconciled, "Successfully reconciled")
+	for _, svc := range svcGroup.Members {
+		r.eventRecorder.Event(svc, corev1.EventTypeNormal, k8s.ServiceEventReasonSuccessfullyReconciled, "Successfully reconciled")
+	}
+	replacementRequeueAfter, err := deploy.BuildLoadBalancerReplacementRequeueAfter(stack, time.Now())
+	if err != nil {
+		return err
+	}
+	if replacementRequeueAfter > 0 {
+		// blue/green replacement of LoadBalancer progresses in phases, which must be applied even without changes to Services.
+		return runtime.NewRequeueNeededAfter("replace load balancer", replacementRequeueAfter)
+	}
+	if driftSyncPeriod > 0 && len(svcGroup.Members) > 0 {
+		return deploy.NewDriftSyncRequeue(driftSyncPeriod)
+	}
 	return nil
 }
 
-func (r *serviceReconciler) cleanupLoadBalancerResources(ctx context.Context, svc *corev1.Service) error {
-	if k8s.HasFinalizer(svc, serviceFinalizer) {
-		_, _, err := r.buildAndDeployModel(ctx, svc)
-		if err != nil {
+// syncServiceGroupInventory maintains the LoadBalancerInventory of ServiceGroup if enabled, it's deleted once ServiceGroup has no members.
+func (r *serviceReconciler) syncServiceGroupInventory(ctx context.Context, svcGroup service.Group, stack core.Stack) error {
+	if len(svcGroup.Members) == 0 {
+		return r.inventoryManager.Delete(ctx, deploy.InventoryOwnerKindService, stack.StackID())
+	}
+	if !r.dynamicConfigProvider.Get().FeatureGates.Enabled(config.FeatureLoadBalancerInventory) {
+		return nil
+	}
+	owners := make([]types.NamespacedName, 0, len(svcGroup.Members))
+	for _, svc := range svcGroup.Members {
+		owners = append(owners, k8s.NamespacedName(svc))
+	}
+	return r.inventoryManager.Sync(ctx, deploy.InventoryOwnerKindService, stack, owners)
+}
+
+// cleanupInactiveMembers releases the inactive members of ServiceGroup, once their listeners are removed from the LoadBalancer.
+func (r *serviceReconciler) cleanupInactiveMembers(ctx context.Context, svcGroup service.Group) error {
+	for _, svc := range svcGroup.InactiveMembers {
+		if err := r.provisionedResourcesExporter.Unexport(ctx, svc.Namespace, deploy.ProvisionedResourcesKeyPrefixService+svc.Name); err != nil {
 			return err
 		}
-		if err := r.finalizerManager.RemoveFinalizers(ctx, svc, serviceFinalizer); err != nil {
+		if err := r.groupFinalizerManager.RemoveGroupFinalizer(ctx, svcGroup.ID, svc); err != nil {
 			r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedRemoveFinalizer, fmt.Sprintf("Failed remove finalizer due to %v", err))
 			return err
 		}
@@ -147,6 +494,52 @@ func (r *serviceReconciler) cleanupLoadBalancerResources(ctx context.Context, sv
 	return nil
 }
 
+// buildCostEstimate builds the cost estimate reported on members of ServiceGroup, and observes the cost in metrics attributed to their namespaces.
+// it's empty unless cost estimate is enabled.
+func (r *serviceReconciler) buildCostEstimate(svcGroup service.Group, stack core.Stack) (string, error) {
+	if !r.dynamicConfigProvider.Get().FeatureGates.Enabled(config.FeatureCostEstimate) {
+		return "", nil
+	}
+	costEstimate, err := deploy.BuildCostEstimate(stack, r.costRates)
+	if err != nil {
+		return "", err
+	}
+	memberNamespaces := make([]string, 0, len(svcGroup.Members))
+	for _, svc := range svcGroup.Members {
+		memberNamespaces = append(memberNamespaces, svc.Namespace)
+	}
+	r.deployMetricsCollector.ObserveCostEstimate(stack.StackID().String(), deploy.SplitCostEstimateByNamespace(costEstimate, memberNamespaces))
+	return deploy.BuildCostEstimatePayload(costEstimate)
+}
+
+func (r *serviceReconciler) updateServiceGroupStatus(ctx context.Context, svcGroup service.Group, lbDNS string, provisionedResources string, costEstimate string) error {
+	for _, svc := range svcGroup.Members {
+		if err := r.updateServiceStatus(ctx, lbDNS, svc); err != nil {
+			return err
+		}
+		changed, err := r.provisionedResourcesExporter.Export(ctx, svc.Namespace, deploy.ProvisionedResourcesKeyPrefixService+svc.Name, provisionedResources)
+		if err != nil {
+			return err
+		}
+		if changed {
+			r.eventRecorder.Event(svc, corev1.EventTypeNormal, k8s.ServiceEventReasonProvisionedResources, fmt.Sprintf("Provisioned AWS resources: %v", provisionedResources))
+		}
+		if costEstimate != "" {
+			r.eventRecorder.Event(svc, corev1.EventTypeNormal, k8s.ServiceEventReasonCostEstimate, fmt.Sprintf("Estimated monthly cost: %v", costEstimate))
+		}
+	}
+	return nil
+}
+
+// recordServiceGroupEvent records the event on members of ServiceGroup, including members being deleted.
+func (r *serviceReconciler) recordServiceGroupEvent(svcGroup service.Group, eventType string, reason string, message string) {
+	for _, members := range [][]*corev1.Service{svcGroup.Members, svcGroup.InactiveMembers} {
+		for _, svc := range members {
+			r.eventRecorder.Event(svc, eventType, reason, message)
+		}
+	}
+}
+
 func (r *serviceReconciler) updateServiceStatus(ctx context.Context, lbDNS string, svc *corev1.Service) error {
 	if len(svc.Status.LoadBalancer.Ingress) != 1 ||
 		svc.Status.LoadBalancer.Ingress[0].IP != "" ||
@@ -168,6 +561,7 @@ func (r *serviceReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 	c, err := controller.New(controllerName, mgr, controller.Options{
 		MaxConcurrentReconciles: r.maxConcurrentReconciles,
 		Reconciler:              r,
+		RateLimiter:             r.rateLimiter,
 	})
 	if err != nil {
 		return err
@@ -175,14 +569,47 @@ func (r *serviceReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 	if err := r.setupWatches(ctx, c); err != nil {
 		return err
 	}
+	if r.reconcileStallTimeout > 0 {
+		if err := runtime.AddReconcileHealthChecker(mgr, metrics.Registry, controllerName, r.healthChecker); err != nil {
+			return err
+		}
+	}
+	if r.stackExportHandler != nil {
+		if err := mgr.AddMetricsExtraHandler("/debug/stack-export/service", r.stackExportHandler); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
 func (r *serviceReconciler) setupWatches(_ context.Context, c controller.Controller) error {
-	svcEventHandler := eventhandlers.NewEnqueueRequestForServiceEvent(r.eventRecorder, r.annotationParser,
+	svcEventChan := make(chan event.GenericEvent)
+	svcEventHandler := eventhandlers.NewEnqueueRequestForServiceEvent(r.groupLoader, r.eventRecorder,
 		r.logger.WithName("eventHandlers").WithName("service"))
+	sgPolicyEventHandler := eventhandlers.NewEnqueueRequestsForSecurityGroupPolicyEvent(svcEventChan, r.k8sClient,
+		r.logger.WithName("eventHandlers").WithName("securityGroupPolicy"))
+	namespaceEventHandler := eventhandlers.NewEnqueueRequestsForNamespaceEvent(svcEventChan, r.k8sClient,
+		r.logger.WithName("eventHandlers").WithName("namespace"))
+	// requests with deployments interrupted by previous leader's shutdown are enqueued ahead of others.
+	resumeSource := runtime.NewResumeSource(controllerName, r.resumeMarkerStore, r.logger.WithName("resume"))
+	if err := c.Watch(resumeSource, &handler.Funcs{}); err != nil {
+		return err
+	}
+	if err := c.Watch(&source.Channel{Source: svcEventChan}, svcEventHandler); err != nil {
+		return err
+	}
 	if err := c.Watch(&source.Kind{Type: &corev1.Service{}}, svcEventHandler); err != nil {
 		return err
 	}
+	if err := c.Watch(&source.Kind{Type: &elbv2api.SecurityGroupPolicy{}}, sgPolicyEventHandler); err != nil {
+		return err
+	}
+	if err := c.Watch(&source.Kind{Type: &corev1.Namespace{}}, namespaceEventHandler); err != nil {
+		return err
+	}
+	// the timer queue enqueues reconcile requests directly.
+	if err := c.Watch(r.timerQueue, &handler.Funcs{}); err != nil {
+		return err
+	}
 	return nil
 }
@@ -21,33 +21,54 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/spf13/pflag"
 	zapraw "go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/labels"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	"os"
+	elbv2v1alpha1api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1alpha1"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	elbv2v1beta2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta2"
 	elbv2controller "sigs.k8s.io/aws-load-balancer-controller/controllers/elbv2"
 	"sigs.k8s.io/aws-load-balancer-controller/controllers/ingress"
 	"sigs.k8s.io/aws-load-balancer-controller/controllers/service"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/permissions"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/throttle"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	elbv2deploy "sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
+	deploymetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/metrics"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/quota"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/gc"
+	ingresspkg "sigs.k8s.io/aws-load-balancer-controller/pkg/ingress"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/inject"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/listenerrulebinding"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
+	servicepkg "sigs.k8s.io/aws-load-balancer-controller/pkg/service"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/targetgroupbinding"
+	tgbmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/targetgroupbinding/metrics"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/tracing"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/version"
 	corewebhook "sigs.k8s.io/aws-load-balancer-controller/webhooks/core"
 	elbv2webhook "sigs.k8s.io/aws-load-balancer-controller/webhooks/elbv2"
+	networkingwebhook "sigs.k8s.io/aws-load-balancer-controller/webhooks/networking"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"time"
 	// +kubebuilder:scaffold:imports
 )
 
+// the timeout to flush pending spans on shutdown.
+const tracingShutdownTimeout = 5 * time.Second
+
 var (
 	scheme   = k8sruntime.NewScheme()
 	setupLog = ctrl.Log.WithName("setup")
@@ -57,6 +78,9 @@ func init() {
 	_ = clientgoscheme.AddToScheme(scheme)
 
 	_ = elbv2api.AddToScheme(scheme)
+	// served versions other than the storage version must be registered for conversion webhook.
+	_ = elbv2v1alpha1api.AddToScheme(scheme)
+	_ = elbv2v1beta2api.AddToScheme(scheme)
 	// +kubebuilder:scaffold:scheme
 }
 
@@ -73,59 +97,196 @@ func main() {
 		os.Exit(1)
 	}
 	ctrl.SetLogger(getLoggerWithLogLevel(controllerCFG.LogLevel))
+	shutdownTracing, err := tracing.Setup(context.Background(), controllerCFG.TracingConfig)
+	if err != nil {
+		setupLog.Error(err, "unable to initialize tracing")
+		os.Exit(1)
+	}
+	// pending spans are flushed before exiting, so that spans leading to a failure aren't lost.
+	exit := func(code int) {
+		flushTracing(shutdownTracing)
+		os.Exit(code)
+	}
 
 	cloud, err := aws.NewCloud(controllerCFG.AWSConfig, metrics.Registry)
 	if err != nil {
 		setupLog.Error(err, "unable to initialize AWS cloud")
-		os.Exit(1)
+		exit(1)
 	}
 	restCFG, err := config.BuildRestConfig(controllerCFG.RuntimeConfig)
 	if err != nil {
 		setupLog.Error(err, "unable to build REST config")
-		os.Exit(1)
+		exit(1)
 	}
 	rtOpts := config.BuildRuntimeOptions(controllerCFG.RuntimeConfig, scheme)
 	mgr, err := ctrl.NewManager(restCFG, rtOpts)
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
-		os.Exit(1)
+		exit(1)
 	}
 	clientSet, err := kubernetes.NewForConfig(mgr.GetConfig())
 	if err != nil {
 		setupLog.Error(err, "unable to obtain clientSet")
-		os.Exit(1)
+		exit(1)
 	}
 	podInfoRepo := k8s.NewDefaultPodInfoRepo(clientSet.CoreV1().RESTClient(), rtOpts.Namespace, ctrl.Log)
+	featureGates := controllerCFG.ResolveFeatureGates()
+	var dynamicConfigProvider config.DynamicConfigProvider = config.NewStaticDynamicConfigProvider(config.DynamicConfig{FeatureGates: featureGates})
+	var configMapDynamicConfigProvider interface {
+		Start(stopChan <-chan struct{}) error
+		WaitForCacheSync(stopChan <-chan struct{}) error
+	}
+	if controllerCFG.DynamicConfigConfigMap != "" {
+		// the flag is validated while loading controller config.
+		configMapKey, _ := config.ParseDynamicConfigConfigMapKey(controllerCFG.DynamicConfigConfigMap)
+		provider := config.NewConfigMapDynamicConfigProvider(clientSet.CoreV1().RESTClient(), configMapKey, featureGates, ctrl.Log.WithName("dynamic-config"))
+		dynamicConfigProvider = provider
+		configMapDynamicConfigProvider = provider
+	}
 	finalizerManager := k8s.NewDefaultFinalizerManager(mgr.GetClient(), ctrl.Log)
 	podENIResolver := networking.NewDefaultPodENIInfoResolver(cloud.EC2(), cloud.VpcID(), ctrl.Log)
 	nodeENIResolver := networking.NewDefaultNodeENIInfoResolver(cloud.EC2(), ctrl.Log)
 	sgManager := networking.NewDefaultSecurityGroupManager(cloud.EC2(), ctrl.Log)
-	sgReconciler := networking.NewDefaultSecurityGroupReconciler(sgManager, ctrl.Log)
+	var sgDivergenceReporter networking.SecurityGroupDivergenceReporter
+	if controllerCFG.SGRuleReconcileMode == config.SGRuleReconcileModeAdditive {
+		reporter, err := networking.NewDefaultSecurityGroupDivergenceReporter(metrics.Registry, ctrl.Log.WithName("sg-divergence-reporter"))
+		if err != nil {
+			setupLog.Error(err, "unable to initialize securityGroup divergence reporter")
+			exit(1)
+		}
+		sgDivergenceReporter = reporter
+	}
+	sgReconciler := networking.NewDefaultSecurityGroupReconciler(sgManager, sgDivergenceReporter, ctrl.Log)
+	deployMetricsCollector, err := deploymetrics.NewCollector(metrics.Registry)
+	if err != nil {
+		setupLog.Error(err, "unable to initialize deploy metrics collector")
+		exit(1)
+	}
 	subnetResolver := networking.NewDefaultSubnetsResolver(cloud.EC2(), cloud.VpcID(), controllerCFG.ClusterName, ctrl.Log.WithName("subnets-resolver"))
-	tgbResManager := targetgroupbinding.NewDefaultResourceManager(mgr.GetClient(), cloud.ELBV2(),
-		podInfoRepo, podENIResolver, nodeENIResolver, sgManager, sgReconciler, cloud.VpcID(), controllerCFG.ClusterName, ctrl.Log)
+	tgbMetricsCollector, err := tgbmetrics.NewCollector(metrics.Registry)
+	if err != nil {
+		setupLog.Error(err, "unable to initialize targetGroupBinding metrics collector")
+		exit(1)
+	}
+	tgbResManager := targetgroupbinding.NewDefaultResourceManager(mgr.GetClient(), cloud,
+		podInfoRepo, podENIResolver, nodeENIResolver, sgManager, sgReconciler, cloud.VpcID(), controllerCFG.ClusterName,
+		controllerCFG.PodWebhookConfig.PodReadinessGateMaxWait, controllerCFG.EnableTGBNetworkingInference,
+		controllerCFG.TargetNodeExcludedTaintKeys, controllerCFG.EnableDrainingNodeDeregistration,
+		controllerCFG.TargetHealthPollPeriod, tgbMetricsCollector, ctrl.Log)
+
+	watchNamespaceSelector, err := config.BuildWatchNamespaceSelector(controllerCFG.RuntimeConfig)
+	if err != nil {
+		setupLog.Error(err, "unable to build watch namespace selector")
+		exit(1)
+	}
+	namespaceMatcher := k8s.NewShardedNamespaceMatcher(mgr.GetClient(), watchNamespaceSelector,
+		controllerCFG.RuntimeConfig.ShardCount, controllerCFG.RuntimeConfig.ShardIndex)
+	deadLetterQueue, err := runtime.NewDefaultDeadLetterQueue(controllerCFG.ReconcileBackoffConfig.MaxTerminalFailures, metrics.Registry)
+	if err != nil {
+		setupLog.Error(err, "unable to initialize reconcile dead letter queue")
+		exit(1)
+	}
+	errorClassCollector, err := runtime.NewDefaultErrorClassCollector(metrics.Registry)
+	if err != nil {
+		setupLog.Error(err, "unable to initialize reconcile error class collector")
+		exit(1)
+	}
+	reconcileIntrospector := runtime.NewDefaultReconcileIntrospector(metrics.Registry)
+	if controllerCFG.RuntimeConfig.DebugBindAddress != "" {
+		debugServer := runtime.NewDebugServer(controllerCFG.RuntimeConfig.DebugBindAddress, reconcileIntrospector, ctrl.Log.WithName("debug-server"))
+		if err := mgr.Add(debugServer); err != nil {
+			setupLog.Error(err, "unable to add debug server")
+			exit(1)
+		}
+	}
+	warmPoolLogger := ctrl.Log.WithName("alb-warm-pool")
+	// the warm pool is started even if disabled, so that spare ALBs left from when it was enabled are deleted.
+	// spare ALBs are discovered via ELBV2 DescribeTags, since Resource Groups Tagging API misses recently created or claimed ALBs.
+	warmPool := elbv2deploy.NewDefaultLoadBalancerWarmPool(cloud.ELBV2(),
+		elbv2deploy.NewDefaultTaggingManager(cloud.ELBV2(), nil, warmPoolLogger),
+		subnetResolver, controllerCFG.ClusterName, controllerCFG.ALBWarmPoolConfig, warmPoolLogger)
+	if err := mgr.Add(warmPool); err != nil {
+		setupLog.Error(err, "unable to add alb warm pool")
+		exit(1)
+	}
+	var lbWarmPool elbv2deploy.LoadBalancerWarmPool
+	if controllerCFG.ALBWarmPoolConfig.Size > 0 {
+		lbWarmPool = warmPool
+	}
 
+	deployTracker := runtime.NewDefaultInFlightDeployTracker()
+	var resumeMarkersConfigMapKey types.NamespacedName
+	if controllerCFG.ResumeMarkersConfigMap != "" {
+		resumeMarkersConfigMapKey, _ = config.ParseResumeMarkersConfigMapKey(controllerCFG.ResumeMarkersConfigMap)
+	}
+	resumeMarkerStore := runtime.NewConfigMapResumeMarkerStore(mgr.GetClient(), mgr.GetAPIReader(), resumeMarkersConfigMapKey)
+
+	// Ingresses and Services share the usage of namespaces limited by ResourceQuotaPolicies.
+	namespaceQuotaChecker := quota.NewDefaultNamespaceQuotaChecker(mgr.GetClient())
 	ingGroupReconciler := ingress.NewGroupReconciler(cloud, mgr.GetClient(), mgr.GetEventRecorderFor("ingress"),
-		finalizerManager, sgManager, sgReconciler, subnetResolver,
-		controllerCFG, ctrl.Log.WithName("controllers").WithName("ingress"))
+		finalizerManager, sgManager, sgReconciler, sgDivergenceReporter, subnetResolver, deployMetricsCollector,
+		namespaceMatcher, deadLetterQueue, errorClassCollector, reconcileIntrospector, deployTracker, resumeMarkerStore, lbWarmPool, namespaceQuotaChecker, dynamicConfigProvider, controllerCFG, ctrl.Log.WithName("controllers").WithName("ingress"))
 	svcReconciler := service.NewServiceReconciler(cloud, mgr.GetClient(), mgr.GetEventRecorderFor("service"),
-		finalizerManager, sgManager, sgReconciler, subnetResolver,
-		controllerCFG, ctrl.Log.WithName("controllers").WithName("service"))
+		finalizerManager, sgManager, sgReconciler, subnetResolver, deployMetricsCollector,
+		namespaceMatcher, deadLetterQueue, errorClassCollector, reconcileIntrospector, deployTracker, resumeMarkerStore, namespaceQuotaChecker, dynamicConfigProvider, controllerCFG, ctrl.Log.WithName("controllers").WithName("service"))
 	tgbReconciler := elbv2controller.NewTargetGroupBindingReconciler(mgr.GetClient(), mgr.GetEventRecorderFor("targetGroupBinding"),
-		finalizerManager, tgbResManager,
+		finalizerManager, tgbResManager, namespaceMatcher, deadLetterQueue, errorClassCollector, reconcileIntrospector,
 		controllerCFG, ctrl.Log.WithName("controllers").WithName("targetGroupBinding"))
 	ctx := context.Background()
 	if err = ingGroupReconciler.SetupWithManager(ctx, mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Ingress")
-		os.Exit(1)
+		exit(1)
 	}
 	if err = svcReconciler.SetupWithManager(ctx, mgr); err != nil {
 		setupLog.Error(err, "Unable to create controller", "controller", "Service")
-		os.Exit(1)
+		exit(1)
 	}
 	if err := tgbReconciler.SetupWithManager(ctx, mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "TargetGroupBinding")
-		os.Exit(1)
+		exit(1)
+	}
+	if controllerCFG.EnableListenerRuleBinding {
+		lrbResManager := listenerrulebinding.NewDefaultResourceManager(mgr.GetClient(), cloud.ELBV2(),
+			controllerCFG.ClusterName, controllerCFG.ListenerRuleBindingAllowedListeners, ctrl.Log.WithName("listenerRuleBindings"))
+		lrbReconciler := elbv2controller.NewListenerRuleBindingReconciler(mgr.GetClient(), mgr.GetEventRecorderFor("listenerRuleBinding"),
+			finalizerManager, lrbResManager, namespaceMatcher,
+			controllerCFG, ctrl.Log.WithName("controllers").WithName("listenerRuleBinding"))
+		if err := lrbReconciler.SetupWithManager(ctx, mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ListenerRuleBinding")
+			exit(1)
+		}
+	}
+	if controllerCFG.OrphanGCConfig.Interval > 0 {
+		// the garbage collector resolves owners of AWS resources among all namespaces, so resources of other shards are never orphaned.
+		ingGroupLoader := ingresspkg.NewDefaultGroupLoader(mgr.GetClient(), mgr.GetEventRecorderFor("ingress"),
+			annotations.NewSuffixAnnotationParser(annotations.AnnotationPrefixIngress),
+			k8s.NewDefaultNamespaceMatcher(mgr.GetClient(), labels.Everything()), controllerCFG.IngressConfig.IngressClass)
+		svcGroupLoader := servicepkg.NewDefaultGroupLoader(mgr.GetClient(),
+			annotations.NewSuffixAnnotationParser(annotations.AnnotationPrefixService),
+			k8s.NewDefaultNamespaceMatcher(mgr.GetClient(), labels.Everything()))
+		var rgtClient services.RGT
+		if controllerCFG.EnableRGTAPI {
+			rgtClient = cloud.RGT()
+		}
+		orphanCollector := gc.NewDefaultOrphanCollector(cloud.ELBV2(), cloud.EC2(), rgtClient, mgr.GetClient(), ingGroupLoader, svcGroupLoader,
+			sgManager, sgReconciler, dynamicConfigProvider, cloud.VpcID(), controllerCFG.ClusterName, controllerCFG.OrphanGCConfig, ctrl.Log.WithName("orphan-gc"))
+		if err := mgr.Add(orphanCollector); err != nil {
+			setupLog.Error(err, "unable to add orphan garbage collector")
+			exit(1)
+		}
+	}
+
+	if controllerCFG.EnableIAMPermissionsCheck {
+		permissionsChecker := permissions.NewDefaultChecker(cloud.IAM(), cloud.STS(), permissions.RequiredActions(controllerCFG),
+			ctrl.Log.WithName("iam-permissions"))
+		if err := mgr.Add(permissionsChecker); err != nil {
+			setupLog.Error(err, "unable to add IAM permissions checker")
+			exit(1)
+		}
+		if err := mgr.AddReadyzCheck("iam-permissions", permissionsChecker.Readyz); err != nil {
+			setupLog.Error(err, "unable to add IAM permissions readiness check")
+			exit(1)
+		}
 	}
 
 	// Add liveness probe
@@ -133,31 +294,63 @@ func main() {
 	setupLog.Info("adding health check for controller")
 	if err != nil {
 		setupLog.Error(err, "unable add a health check")
-		os.Exit(1)
+		exit(1)
 	}
 
 	podReadinessGateInjector := inject.NewPodReadinessGate(controllerCFG.PodWebhookConfig,
 		mgr.GetClient(), ctrl.Log.WithName("pod-readiness-gate-injector"))
 	corewebhook.NewPodMutator(podReadinessGateInjector).SetupWithManager(mgr)
+	corewebhook.NewPodValidator(mgr.GetClient(), controllerCFG.PodWebhookConfig, ctrl.Log).SetupWithManager(mgr)
+	corewebhook.NewServiceValidator(mgr.GetClient(), ctrl.Log).SetupWithManager(mgr)
 	elbv2webhook.NewTargetGroupBindingMutator(cloud.ELBV2(), ctrl.Log).SetupWithManager(mgr)
-	elbv2webhook.NewTargetGroupBindingValidator(ctrl.Log).SetupWithManager(mgr)
+	elbv2webhook.NewTargetGroupBindingValidator(mgr.GetClient(), controllerCFG.TargetGroupBindingAllowedIAMRoleARNs, ctrl.Log).SetupWithManager(mgr)
+	elbv2webhook.NewIngressClassParamsValidator(mgr.GetClient(), ctrl.Log).SetupWithManager(mgr)
+	elbv2webhook.NewSecurityGroupPolicyValidator(ctrl.Log).SetupWithManager(mgr)
+	elbv2webhook.SetupConversionWithManager(mgr)
+	networkingwebhook.NewIngressValidator(mgr.GetClient(), controllerCFG.IngressConfig,
+		mgr.GetEventRecorderFor("ingress"), ctrl.Log).SetupWithManager(mgr)
 	//+kubebuilder:scaffold:builder
 
-	stopChan := ctrl.SetupSignalHandler()
+	// in-flight deployments are drained before the manager and its dependencies stop, so that stacks aren't abandoned half-deployed.
+	stopChan := runtime.GracefulStopChan(ctrl.SetupSignalHandler(), deployTracker, resumeMarkerStore,
+		controllerCFG.GracefulShutdownTimeout, ctrl.Log.WithName("graceful-shutdown"))
 	go func() {
 		setupLog.Info("starting podInfo repo")
 		if err := podInfoRepo.Start(stopChan); err != nil {
 			setupLog.Error(err, "problem running podInfo repo")
-			os.Exit(1)
+			exit(1)
 		}
 	}()
 	if err := podInfoRepo.WaitForCacheSync(stopChan); err != nil {
 		setupLog.Error(err, "problem wait for podInfo repo sync")
-		os.Exit(1)
+		exit(1)
+	}
+	if configMapDynamicConfigProvider != nil {
+		go func() {
+			setupLog.Info("starting dynamic config provider")
+			if err := configMapDynamicConfigProvider.Start(stopChan); err != nil {
+				setupLog.Error(err, "problem running dynamic config provider")
+				exit(1)
+			}
+		}()
+		if err := configMapDynamicConfigProvider.WaitForCacheSync(stopChan); err != nil {
+			setupLog.Error(err, "problem wait for dynamic config provider sync")
+			exit(1)
+		}
 	}
 	if err := mgr.Start(stopChan); err != nil {
 		setupLog.Error(err, "problem running manager")
-		os.Exit(1)
+		exit(1)
+	}
+	flushTracing(shutdownTracing)
+}
+
+// flushTracing flushes pending spans and stops exporting spans.
+func flushTracing(shutdownTracing tracing.ShutdownFunc) {
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	defer cancel()
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		setupLog.Error(err, "problem flushing traces")
 	}
 }
 
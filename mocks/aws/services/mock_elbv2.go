@@ -10,6 +10,7 @@ import (
 	elbv2 "github.com/aws/aws-sdk-go/service/elbv2"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
+	services "sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
 )
 
 // MockELBV2 is a mock of ELBV2 interface
@@ -135,6 +136,56 @@ func (mr *MockELBV2MockRecorder) AddTagsWithContext(arg0, arg1 interface{}, arg2
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTagsWithContext", reflect.TypeOf((*MockELBV2)(nil).AddTagsWithContext), varargs...)
 }
 
+// AddTrustStoreRevocations mocks base method
+func (m *MockELBV2) AddTrustStoreRevocations(arg0 *elbv2.AddTrustStoreRevocationsInput) (*elbv2.AddTrustStoreRevocationsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTrustStoreRevocations", arg0)
+	ret0, _ := ret[0].(*elbv2.AddTrustStoreRevocationsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTrustStoreRevocations indicates an expected call of AddTrustStoreRevocations
+func (mr *MockELBV2MockRecorder) AddTrustStoreRevocations(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTrustStoreRevocations", reflect.TypeOf((*MockELBV2)(nil).AddTrustStoreRevocations), arg0)
+}
+
+// AddTrustStoreRevocationsRequest mocks base method
+func (m *MockELBV2) AddTrustStoreRevocationsRequest(arg0 *elbv2.AddTrustStoreRevocationsInput) (*request.Request, *elbv2.AddTrustStoreRevocationsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTrustStoreRevocationsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*elbv2.AddTrustStoreRevocationsOutput)
+	return ret0, ret1
+}
+
+// AddTrustStoreRevocationsRequest indicates an expected call of AddTrustStoreRevocationsRequest
+func (mr *MockELBV2MockRecorder) AddTrustStoreRevocationsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTrustStoreRevocationsRequest", reflect.TypeOf((*MockELBV2)(nil).AddTrustStoreRevocationsRequest), arg0)
+}
+
+// AddTrustStoreRevocationsWithContext mocks base method
+func (m *MockELBV2) AddTrustStoreRevocationsWithContext(arg0 context.Context, arg1 *elbv2.AddTrustStoreRevocationsInput, arg2 ...request.Option) (*elbv2.AddTrustStoreRevocationsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddTrustStoreRevocationsWithContext", varargs...)
+	ret0, _ := ret[0].(*elbv2.AddTrustStoreRevocationsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTrustStoreRevocationsWithContext indicates an expected call of AddTrustStoreRevocationsWithContext
+func (mr *MockELBV2MockRecorder) AddTrustStoreRevocationsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTrustStoreRevocationsWithContext", reflect.TypeOf((*MockELBV2)(nil).AddTrustStoreRevocationsWithContext), varargs...)
+}
+
 // CreateListener mocks base method
 func (m *MockELBV2) CreateListener(arg0 *elbv2.CreateListenerInput) (*elbv2.CreateListenerOutput, error) {
 	m.ctrl.T.Helper()
@@ -335,6 +386,56 @@ func (mr *MockELBV2MockRecorder) CreateTargetGroupWithContext(arg0, arg1 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTargetGroupWithContext", reflect.TypeOf((*MockELBV2)(nil).CreateTargetGroupWithContext), varargs...)
 }
 
+// CreateTrustStore mocks base method
+func (m *MockELBV2) CreateTrustStore(arg0 *elbv2.CreateTrustStoreInput) (*elbv2.CreateTrustStoreOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTrustStore", arg0)
+	ret0, _ := ret[0].(*elbv2.CreateTrustStoreOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTrustStore indicates an expected call of CreateTrustStore
+func (mr *MockELBV2MockRecorder) CreateTrustStore(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTrustStore", reflect.TypeOf((*MockELBV2)(nil).CreateTrustStore), arg0)
+}
+
+// CreateTrustStoreRequest mocks base method
+func (m *MockELBV2) CreateTrustStoreRequest(arg0 *elbv2.CreateTrustStoreInput) (*request.Request, *elbv2.CreateTrustStoreOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTrustStoreRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*elbv2.CreateTrustStoreOutput)
+	return ret0, ret1
+}
+
+// CreateTrustStoreRequest indicates an expected call of CreateTrustStoreRequest
+func (mr *MockELBV2MockRecorder) CreateTrustStoreRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTrustStoreRequest", reflect.TypeOf((*MockELBV2)(nil).CreateTrustStoreRequest), arg0)
+}
+
+// CreateTrustStoreWithContext mocks base method
+func (m *MockELBV2) CreateTrustStoreWithContext(arg0 context.Context, arg1 *elbv2.CreateTrustStoreInput, arg2 ...request.Option) (*elbv2.CreateTrustStoreOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateTrustStoreWithContext", varargs...)
+	ret0, _ := ret[0].(*elbv2.CreateTrustStoreOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTrustStoreWithContext indicates an expected call of CreateTrustStoreWithContext
+func (mr *MockELBV2MockRecorder) CreateTrustStoreWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTrustStoreWithContext", reflect.TypeOf((*MockELBV2)(nil).CreateTrustStoreWithContext), varargs...)
+}
+
 // DeleteListener mocks base method
 func (m *MockELBV2) DeleteListener(arg0 *elbv2.DeleteListenerInput) (*elbv2.DeleteListenerOutput, error) {
 	m.ctrl.T.Helper()
@@ -485,6 +586,56 @@ func (mr *MockELBV2MockRecorder) DeleteRuleWithContext(arg0, arg1 interface{}, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRuleWithContext", reflect.TypeOf((*MockELBV2)(nil).DeleteRuleWithContext), varargs...)
 }
 
+// DeleteSharedTrustStoreAssociation mocks base method
+func (m *MockELBV2) DeleteSharedTrustStoreAssociation(arg0 *elbv2.DeleteSharedTrustStoreAssociationInput) (*elbv2.DeleteSharedTrustStoreAssociationOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSharedTrustStoreAssociation", arg0)
+	ret0, _ := ret[0].(*elbv2.DeleteSharedTrustStoreAssociationOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSharedTrustStoreAssociation indicates an expected call of DeleteSharedTrustStoreAssociation
+func (mr *MockELBV2MockRecorder) DeleteSharedTrustStoreAssociation(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSharedTrustStoreAssociation", reflect.TypeOf((*MockELBV2)(nil).DeleteSharedTrustStoreAssociation), arg0)
+}
+
+// DeleteSharedTrustStoreAssociationRequest mocks base method
+func (m *MockELBV2) DeleteSharedTrustStoreAssociationRequest(arg0 *elbv2.DeleteSharedTrustStoreAssociationInput) (*request.Request, *elbv2.DeleteSharedTrustStoreAssociationOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSharedTrustStoreAssociationRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*elbv2.DeleteSharedTrustStoreAssociationOutput)
+	return ret0, ret1
+}
+
+// DeleteSharedTrustStoreAssociationRequest indicates an expected call of DeleteSharedTrustStoreAssociationRequest
+func (mr *MockELBV2MockRecorder) DeleteSharedTrustStoreAssociationRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSharedTrustStoreAssociationRequest", reflect.TypeOf((*MockELBV2)(nil).DeleteSharedTrustStoreAssociationRequest), arg0)
+}
+
+// DeleteSharedTrustStoreAssociationWithContext mocks base method
+func (m *MockELBV2) DeleteSharedTrustStoreAssociationWithContext(arg0 context.Context, arg1 *elbv2.DeleteSharedTrustStoreAssociationInput, arg2 ...request.Option) (*elbv2.DeleteSharedTrustStoreAssociationOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteSharedTrustStoreAssociationWithContext", varargs...)
+	ret0, _ := ret[0].(*elbv2.DeleteSharedTrustStoreAssociationOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSharedTrustStoreAssociationWithContext indicates an expected call of DeleteSharedTrustStoreAssociationWithContext
+func (mr *MockELBV2MockRecorder) DeleteSharedTrustStoreAssociationWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSharedTrustStoreAssociationWithContext", reflect.TypeOf((*MockELBV2)(nil).DeleteSharedTrustStoreAssociationWithContext), varargs...)
+}
+
 // DeleteTargetGroup mocks base method
 func (m *MockELBV2) DeleteTargetGroup(arg0 *elbv2.DeleteTargetGroupInput) (*elbv2.DeleteTargetGroupOutput, error) {
 	m.ctrl.T.Helper()
@@ -535,6 +686,56 @@ func (mr *MockELBV2MockRecorder) DeleteTargetGroupWithContext(arg0, arg1 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTargetGroupWithContext", reflect.TypeOf((*MockELBV2)(nil).DeleteTargetGroupWithContext), varargs...)
 }
 
+// DeleteTrustStore mocks base method
+func (m *MockELBV2) DeleteTrustStore(arg0 *elbv2.DeleteTrustStoreInput) (*elbv2.DeleteTrustStoreOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTrustStore", arg0)
+	ret0, _ := ret[0].(*elbv2.DeleteTrustStoreOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTrustStore indicates an expected call of DeleteTrustStore
+func (mr *MockELBV2MockRecorder) DeleteTrustStore(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTrustStore", reflect.TypeOf((*MockELBV2)(nil).DeleteTrustStore), arg0)
+}
+
+// DeleteTrustStoreRequest mocks base method
+func (m *MockELBV2) DeleteTrustStoreRequest(arg0 *elbv2.DeleteTrustStoreInput) (*request.Request, *elbv2.DeleteTrustStoreOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTrustStoreRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*elbv2.DeleteTrustStoreOutput)
+	return ret0, ret1
+}
+
+// DeleteTrustStoreRequest indicates an expected call of DeleteTrustStoreRequest
+func (mr *MockELBV2MockRecorder) DeleteTrustStoreRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTrustStoreRequest", reflect.TypeOf((*MockELBV2)(nil).DeleteTrustStoreRequest), arg0)
+}
+
+// DeleteTrustStoreWithContext mocks base method
+func (m *MockELBV2) DeleteTrustStoreWithContext(arg0 context.Context, arg1 *elbv2.DeleteTrustStoreInput, arg2 ...request.Option) (*elbv2.DeleteTrustStoreOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteTrustStoreWithContext", varargs...)
+	ret0, _ := ret[0].(*elbv2.DeleteTrustStoreOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTrustStoreWithContext indicates an expected call of DeleteTrustStoreWithContext
+func (mr *MockELBV2MockRecorder) DeleteTrustStoreWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTrustStoreWithContext", reflect.TypeOf((*MockELBV2)(nil).DeleteTrustStoreWithContext), varargs...)
+}
+
 // DeregisterTargets mocks base method
 func (m *MockELBV2) DeregisterTargets(arg0 *elbv2.DeregisterTargetsInput) (*elbv2.DeregisterTargetsOutput, error) {
 	m.ctrl.T.Helper()
@@ -635,6 +836,21 @@ func (mr *MockELBV2MockRecorder) DescribeAccountLimitsWithContext(arg0, arg1 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeAccountLimitsWithContext", reflect.TypeOf((*MockELBV2)(nil).DescribeAccountLimitsWithContext), varargs...)
 }
 
+// DescribeListenerAttributesWithContext mocks base method
+func (m *MockELBV2) DescribeListenerAttributesWithContext(arg0 context.Context, arg1 *services.DescribeListenerAttributesInput) (*services.DescribeListenerAttributesOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeListenerAttributesWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*services.DescribeListenerAttributesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeListenerAttributesWithContext indicates an expected call of DescribeListenerAttributesWithContext
+func (mr *MockELBV2MockRecorder) DescribeListenerAttributesWithContext(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeListenerAttributesWithContext", reflect.TypeOf((*MockELBV2)(nil).DescribeListenerAttributesWithContext), arg0, arg1)
+}
+
 // DescribeListenerCertificates mocks base method
 func (m *MockELBV2) DescribeListenerCertificates(arg0 *elbv2.DescribeListenerCertificatesInput) (*elbv2.DescribeListenerCertificatesOutput, error) {
 	m.ctrl.T.Helper()
@@ -1309,6 +1525,405 @@ func (mr *MockELBV2MockRecorder) DescribeTargetHealthWithContext(arg0, arg1 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTargetHealthWithContext", reflect.TypeOf((*MockELBV2)(nil).DescribeTargetHealthWithContext), varargs...)
 }
 
+// DescribeTrustStoreAssociations mocks base method
+func (m *MockELBV2) DescribeTrustStoreAssociations(arg0 *elbv2.DescribeTrustStoreAssociationsInput) (*elbv2.DescribeTrustStoreAssociationsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeTrustStoreAssociations", arg0)
+	ret0, _ := ret[0].(*elbv2.DescribeTrustStoreAssociationsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeTrustStoreAssociations indicates an expected call of DescribeTrustStoreAssociations
+func (mr *MockELBV2MockRecorder) DescribeTrustStoreAssociations(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTrustStoreAssociations", reflect.TypeOf((*MockELBV2)(nil).DescribeTrustStoreAssociations), arg0)
+}
+
+// DescribeTrustStoreAssociationsPages mocks base method
+func (m *MockELBV2) DescribeTrustStoreAssociationsPages(arg0 *elbv2.DescribeTrustStoreAssociationsInput, arg1 func(*elbv2.DescribeTrustStoreAssociationsOutput, bool) bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeTrustStoreAssociationsPages", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DescribeTrustStoreAssociationsPages indicates an expected call of DescribeTrustStoreAssociationsPages
+func (mr *MockELBV2MockRecorder) DescribeTrustStoreAssociationsPages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTrustStoreAssociationsPages", reflect.TypeOf((*MockELBV2)(nil).DescribeTrustStoreAssociationsPages), arg0, arg1)
+}
+
+// DescribeTrustStoreAssociationsPagesWithContext mocks base method
+func (m *MockELBV2) DescribeTrustStoreAssociationsPagesWithContext(arg0 context.Context, arg1 *elbv2.DescribeTrustStoreAssociationsInput, arg2 func(*elbv2.DescribeTrustStoreAssociationsOutput, bool) bool, arg3 ...request.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeTrustStoreAssociationsPagesWithContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DescribeTrustStoreAssociationsPagesWithContext indicates an expected call of DescribeTrustStoreAssociationsPagesWithContext
+func (mr *MockELBV2MockRecorder) DescribeTrustStoreAssociationsPagesWithContext(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTrustStoreAssociationsPagesWithContext", reflect.TypeOf((*MockELBV2)(nil).DescribeTrustStoreAssociationsPagesWithContext), varargs...)
+}
+
+// DescribeTrustStoreAssociationsRequest mocks base method
+func (m *MockELBV2) DescribeTrustStoreAssociationsRequest(arg0 *elbv2.DescribeTrustStoreAssociationsInput) (*request.Request, *elbv2.DescribeTrustStoreAssociationsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeTrustStoreAssociationsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*elbv2.DescribeTrustStoreAssociationsOutput)
+	return ret0, ret1
+}
+
+// DescribeTrustStoreAssociationsRequest indicates an expected call of DescribeTrustStoreAssociationsRequest
+func (mr *MockELBV2MockRecorder) DescribeTrustStoreAssociationsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTrustStoreAssociationsRequest", reflect.TypeOf((*MockELBV2)(nil).DescribeTrustStoreAssociationsRequest), arg0)
+}
+
+// DescribeTrustStoreAssociationsWithContext mocks base method
+func (m *MockELBV2) DescribeTrustStoreAssociationsWithContext(arg0 context.Context, arg1 *elbv2.DescribeTrustStoreAssociationsInput, arg2 ...request.Option) (*elbv2.DescribeTrustStoreAssociationsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeTrustStoreAssociationsWithContext", varargs...)
+	ret0, _ := ret[0].(*elbv2.DescribeTrustStoreAssociationsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeTrustStoreAssociationsWithContext indicates an expected call of DescribeTrustStoreAssociationsWithContext
+func (mr *MockELBV2MockRecorder) DescribeTrustStoreAssociationsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTrustStoreAssociationsWithContext", reflect.TypeOf((*MockELBV2)(nil).DescribeTrustStoreAssociationsWithContext), varargs...)
+}
+
+// DescribeTrustStoreRevocations mocks base method
+func (m *MockELBV2) DescribeTrustStoreRevocations(arg0 *elbv2.DescribeTrustStoreRevocationsInput) (*elbv2.DescribeTrustStoreRevocationsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeTrustStoreRevocations", arg0)
+	ret0, _ := ret[0].(*elbv2.DescribeTrustStoreRevocationsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeTrustStoreRevocations indicates an expected call of DescribeTrustStoreRevocations
+func (mr *MockELBV2MockRecorder) DescribeTrustStoreRevocations(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTrustStoreRevocations", reflect.TypeOf((*MockELBV2)(nil).DescribeTrustStoreRevocations), arg0)
+}
+
+// DescribeTrustStoreRevocationsPages mocks base method
+func (m *MockELBV2) DescribeTrustStoreRevocationsPages(arg0 *elbv2.DescribeTrustStoreRevocationsInput, arg1 func(*elbv2.DescribeTrustStoreRevocationsOutput, bool) bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeTrustStoreRevocationsPages", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DescribeTrustStoreRevocationsPages indicates an expected call of DescribeTrustStoreRevocationsPages
+func (mr *MockELBV2MockRecorder) DescribeTrustStoreRevocationsPages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTrustStoreRevocationsPages", reflect.TypeOf((*MockELBV2)(nil).DescribeTrustStoreRevocationsPages), arg0, arg1)
+}
+
+// DescribeTrustStoreRevocationsPagesWithContext mocks base method
+func (m *MockELBV2) DescribeTrustStoreRevocationsPagesWithContext(arg0 context.Context, arg1 *elbv2.DescribeTrustStoreRevocationsInput, arg2 func(*elbv2.DescribeTrustStoreRevocationsOutput, bool) bool, arg3 ...request.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeTrustStoreRevocationsPagesWithContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DescribeTrustStoreRevocationsPagesWithContext indicates an expected call of DescribeTrustStoreRevocationsPagesWithContext
+func (mr *MockELBV2MockRecorder) DescribeTrustStoreRevocationsPagesWithContext(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTrustStoreRevocationsPagesWithContext", reflect.TypeOf((*MockELBV2)(nil).DescribeTrustStoreRevocationsPagesWithContext), varargs...)
+}
+
+// DescribeTrustStoreRevocationsRequest mocks base method
+func (m *MockELBV2) DescribeTrustStoreRevocationsRequest(arg0 *elbv2.DescribeTrustStoreRevocationsInput) (*request.Request, *elbv2.DescribeTrustStoreRevocationsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeTrustStoreRevocationsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*elbv2.DescribeTrustStoreRevocationsOutput)
+	return ret0, ret1
+}
+
+// DescribeTrustStoreRevocationsRequest indicates an expected call of DescribeTrustStoreRevocationsRequest
+func (mr *MockELBV2MockRecorder) DescribeTrustStoreRevocationsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTrustStoreRevocationsRequest", reflect.TypeOf((*MockELBV2)(nil).DescribeTrustStoreRevocationsRequest), arg0)
+}
+
+// DescribeTrustStoreRevocationsWithContext mocks base method
+func (m *MockELBV2) DescribeTrustStoreRevocationsWithContext(arg0 context.Context, arg1 *elbv2.DescribeTrustStoreRevocationsInput, arg2 ...request.Option) (*elbv2.DescribeTrustStoreRevocationsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeTrustStoreRevocationsWithContext", varargs...)
+	ret0, _ := ret[0].(*elbv2.DescribeTrustStoreRevocationsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeTrustStoreRevocationsWithContext indicates an expected call of DescribeTrustStoreRevocationsWithContext
+func (mr *MockELBV2MockRecorder) DescribeTrustStoreRevocationsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTrustStoreRevocationsWithContext", reflect.TypeOf((*MockELBV2)(nil).DescribeTrustStoreRevocationsWithContext), varargs...)
+}
+
+// DescribeTrustStores mocks base method
+func (m *MockELBV2) DescribeTrustStores(arg0 *elbv2.DescribeTrustStoresInput) (*elbv2.DescribeTrustStoresOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeTrustStores", arg0)
+	ret0, _ := ret[0].(*elbv2.DescribeTrustStoresOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeTrustStores indicates an expected call of DescribeTrustStores
+func (mr *MockELBV2MockRecorder) DescribeTrustStores(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTrustStores", reflect.TypeOf((*MockELBV2)(nil).DescribeTrustStores), arg0)
+}
+
+// DescribeTrustStoresPages mocks base method
+func (m *MockELBV2) DescribeTrustStoresPages(arg0 *elbv2.DescribeTrustStoresInput, arg1 func(*elbv2.DescribeTrustStoresOutput, bool) bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeTrustStoresPages", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DescribeTrustStoresPages indicates an expected call of DescribeTrustStoresPages
+func (mr *MockELBV2MockRecorder) DescribeTrustStoresPages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTrustStoresPages", reflect.TypeOf((*MockELBV2)(nil).DescribeTrustStoresPages), arg0, arg1)
+}
+
+// DescribeTrustStoresPagesWithContext mocks base method
+func (m *MockELBV2) DescribeTrustStoresPagesWithContext(arg0 context.Context, arg1 *elbv2.DescribeTrustStoresInput, arg2 func(*elbv2.DescribeTrustStoresOutput, bool) bool, arg3 ...request.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeTrustStoresPagesWithContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DescribeTrustStoresPagesWithContext indicates an expected call of DescribeTrustStoresPagesWithContext
+func (mr *MockELBV2MockRecorder) DescribeTrustStoresPagesWithContext(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTrustStoresPagesWithContext", reflect.TypeOf((*MockELBV2)(nil).DescribeTrustStoresPagesWithContext), varargs...)
+}
+
+// DescribeTrustStoresRequest mocks base method
+func (m *MockELBV2) DescribeTrustStoresRequest(arg0 *elbv2.DescribeTrustStoresInput) (*request.Request, *elbv2.DescribeTrustStoresOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeTrustStoresRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*elbv2.DescribeTrustStoresOutput)
+	return ret0, ret1
+}
+
+// DescribeTrustStoresRequest indicates an expected call of DescribeTrustStoresRequest
+func (mr *MockELBV2MockRecorder) DescribeTrustStoresRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTrustStoresRequest", reflect.TypeOf((*MockELBV2)(nil).DescribeTrustStoresRequest), arg0)
+}
+
+// DescribeTrustStoresWithContext mocks base method
+func (m *MockELBV2) DescribeTrustStoresWithContext(arg0 context.Context, arg1 *elbv2.DescribeTrustStoresInput, arg2 ...request.Option) (*elbv2.DescribeTrustStoresOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeTrustStoresWithContext", varargs...)
+	ret0, _ := ret[0].(*elbv2.DescribeTrustStoresOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeTrustStoresWithContext indicates an expected call of DescribeTrustStoresWithContext
+func (mr *MockELBV2MockRecorder) DescribeTrustStoresWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTrustStoresWithContext", reflect.TypeOf((*MockELBV2)(nil).DescribeTrustStoresWithContext), varargs...)
+}
+
+// GetResourcePolicy mocks base method
+func (m *MockELBV2) GetResourcePolicy(arg0 *elbv2.GetResourcePolicyInput) (*elbv2.GetResourcePolicyOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResourcePolicy", arg0)
+	ret0, _ := ret[0].(*elbv2.GetResourcePolicyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResourcePolicy indicates an expected call of GetResourcePolicy
+func (mr *MockELBV2MockRecorder) GetResourcePolicy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResourcePolicy", reflect.TypeOf((*MockELBV2)(nil).GetResourcePolicy), arg0)
+}
+
+// GetResourcePolicyRequest mocks base method
+func (m *MockELBV2) GetResourcePolicyRequest(arg0 *elbv2.GetResourcePolicyInput) (*request.Request, *elbv2.GetResourcePolicyOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResourcePolicyRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*elbv2.GetResourcePolicyOutput)
+	return ret0, ret1
+}
+
+// GetResourcePolicyRequest indicates an expected call of GetResourcePolicyRequest
+func (mr *MockELBV2MockRecorder) GetResourcePolicyRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResourcePolicyRequest", reflect.TypeOf((*MockELBV2)(nil).GetResourcePolicyRequest), arg0)
+}
+
+// GetResourcePolicyWithContext mocks base method
+func (m *MockELBV2) GetResourcePolicyWithContext(arg0 context.Context, arg1 *elbv2.GetResourcePolicyInput, arg2 ...request.Option) (*elbv2.GetResourcePolicyOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetResourcePolicyWithContext", varargs...)
+	ret0, _ := ret[0].(*elbv2.GetResourcePolicyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResourcePolicyWithContext indicates an expected call of GetResourcePolicyWithContext
+func (mr *MockELBV2MockRecorder) GetResourcePolicyWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResourcePolicyWithContext", reflect.TypeOf((*MockELBV2)(nil).GetResourcePolicyWithContext), varargs...)
+}
+
+// GetTrustStoreCaCertificatesBundle mocks base method
+func (m *MockELBV2) GetTrustStoreCaCertificatesBundle(arg0 *elbv2.GetTrustStoreCaCertificatesBundleInput) (*elbv2.GetTrustStoreCaCertificatesBundleOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrustStoreCaCertificatesBundle", arg0)
+	ret0, _ := ret[0].(*elbv2.GetTrustStoreCaCertificatesBundleOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrustStoreCaCertificatesBundle indicates an expected call of GetTrustStoreCaCertificatesBundle
+func (mr *MockELBV2MockRecorder) GetTrustStoreCaCertificatesBundle(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrustStoreCaCertificatesBundle", reflect.TypeOf((*MockELBV2)(nil).GetTrustStoreCaCertificatesBundle), arg0)
+}
+
+// GetTrustStoreCaCertificatesBundleRequest mocks base method
+func (m *MockELBV2) GetTrustStoreCaCertificatesBundleRequest(arg0 *elbv2.GetTrustStoreCaCertificatesBundleInput) (*request.Request, *elbv2.GetTrustStoreCaCertificatesBundleOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrustStoreCaCertificatesBundleRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*elbv2.GetTrustStoreCaCertificatesBundleOutput)
+	return ret0, ret1
+}
+
+// GetTrustStoreCaCertificatesBundleRequest indicates an expected call of GetTrustStoreCaCertificatesBundleRequest
+func (mr *MockELBV2MockRecorder) GetTrustStoreCaCertificatesBundleRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrustStoreCaCertificatesBundleRequest", reflect.TypeOf((*MockELBV2)(nil).GetTrustStoreCaCertificatesBundleRequest), arg0)
+}
+
+// GetTrustStoreCaCertificatesBundleWithContext mocks base method
+func (m *MockELBV2) GetTrustStoreCaCertificatesBundleWithContext(arg0 context.Context, arg1 *elbv2.GetTrustStoreCaCertificatesBundleInput, arg2 ...request.Option) (*elbv2.GetTrustStoreCaCertificatesBundleOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetTrustStoreCaCertificatesBundleWithContext", varargs...)
+	ret0, _ := ret[0].(*elbv2.GetTrustStoreCaCertificatesBundleOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrustStoreCaCertificatesBundleWithContext indicates an expected call of GetTrustStoreCaCertificatesBundleWithContext
+func (mr *MockELBV2MockRecorder) GetTrustStoreCaCertificatesBundleWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrustStoreCaCertificatesBundleWithContext", reflect.TypeOf((*MockELBV2)(nil).GetTrustStoreCaCertificatesBundleWithContext), varargs...)
+}
+
+// GetTrustStoreRevocationContent mocks base method
+func (m *MockELBV2) GetTrustStoreRevocationContent(arg0 *elbv2.GetTrustStoreRevocationContentInput) (*elbv2.GetTrustStoreRevocationContentOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrustStoreRevocationContent", arg0)
+	ret0, _ := ret[0].(*elbv2.GetTrustStoreRevocationContentOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrustStoreRevocationContent indicates an expected call of GetTrustStoreRevocationContent
+func (mr *MockELBV2MockRecorder) GetTrustStoreRevocationContent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrustStoreRevocationContent", reflect.TypeOf((*MockELBV2)(nil).GetTrustStoreRevocationContent), arg0)
+}
+
+// GetTrustStoreRevocationContentRequest mocks base method
+func (m *MockELBV2) GetTrustStoreRevocationContentRequest(arg0 *elbv2.GetTrustStoreRevocationContentInput) (*request.Request, *elbv2.GetTrustStoreRevocationContentOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrustStoreRevocationContentRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*elbv2.GetTrustStoreRevocationContentOutput)
+	return ret0, ret1
+}
+
+// GetTrustStoreRevocationContentRequest indicates an expected call of GetTrustStoreRevocationContentRequest
+func (mr *MockELBV2MockRecorder) GetTrustStoreRevocationContentRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrustStoreRevocationContentRequest", reflect.TypeOf((*MockELBV2)(nil).GetTrustStoreRevocationContentRequest), arg0)
+}
+
+// GetTrustStoreRevocationContentWithContext mocks base method
+func (m *MockELBV2) GetTrustStoreRevocationContentWithContext(arg0 context.Context, arg1 *elbv2.GetTrustStoreRevocationContentInput, arg2 ...request.Option) (*elbv2.GetTrustStoreRevocationContentOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetTrustStoreRevocationContentWithContext", varargs...)
+	ret0, _ := ret[0].(*elbv2.GetTrustStoreRevocationContentOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrustStoreRevocationContentWithContext indicates an expected call of GetTrustStoreRevocationContentWithContext
+func (mr *MockELBV2MockRecorder) GetTrustStoreRevocationContentWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrustStoreRevocationContentWithContext", reflect.TypeOf((*MockELBV2)(nil).GetTrustStoreRevocationContentWithContext), varargs...)
+}
+
 // ModifyListener mocks base method
 func (m *MockELBV2) ModifyListener(arg0 *elbv2.ModifyListenerInput) (*elbv2.ModifyListenerOutput, error) {
 	m.ctrl.T.Helper()
@@ -1324,6 +1939,21 @@ func (mr *MockELBV2MockRecorder) ModifyListener(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyListener", reflect.TypeOf((*MockELBV2)(nil).ModifyListener), arg0)
 }
 
+// ModifyListenerAttributesWithContext mocks base method
+func (m *MockELBV2) ModifyListenerAttributesWithContext(arg0 context.Context, arg1 *services.ModifyListenerAttributesInput) (*services.ModifyListenerAttributesOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModifyListenerAttributesWithContext", arg0, arg1)
+	ret0, _ := ret[0].(*services.ModifyListenerAttributesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModifyListenerAttributesWithContext indicates an expected call of ModifyListenerAttributesWithContext
+func (mr *MockELBV2MockRecorder) ModifyListenerAttributesWithContext(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyListenerAttributesWithContext", reflect.TypeOf((*MockELBV2)(nil).ModifyListenerAttributesWithContext), arg0, arg1)
+}
+
 // ModifyListenerRequest mocks base method
 func (m *MockELBV2) ModifyListenerRequest(arg0 *elbv2.ModifyListenerInput) (*request.Request, *elbv2.ModifyListenerOutput) {
 	m.ctrl.T.Helper()
@@ -1559,6 +2189,56 @@ func (mr *MockELBV2MockRecorder) ModifyTargetGroupWithContext(arg0, arg1 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyTargetGroupWithContext", reflect.TypeOf((*MockELBV2)(nil).ModifyTargetGroupWithContext), varargs...)
 }
 
+// ModifyTrustStore mocks base method
+func (m *MockELBV2) ModifyTrustStore(arg0 *elbv2.ModifyTrustStoreInput) (*elbv2.ModifyTrustStoreOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModifyTrustStore", arg0)
+	ret0, _ := ret[0].(*elbv2.ModifyTrustStoreOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModifyTrustStore indicates an expected call of ModifyTrustStore
+func (mr *MockELBV2MockRecorder) ModifyTrustStore(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyTrustStore", reflect.TypeOf((*MockELBV2)(nil).ModifyTrustStore), arg0)
+}
+
+// ModifyTrustStoreRequest mocks base method
+func (m *MockELBV2) ModifyTrustStoreRequest(arg0 *elbv2.ModifyTrustStoreInput) (*request.Request, *elbv2.ModifyTrustStoreOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModifyTrustStoreRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*elbv2.ModifyTrustStoreOutput)
+	return ret0, ret1
+}
+
+// ModifyTrustStoreRequest indicates an expected call of ModifyTrustStoreRequest
+func (mr *MockELBV2MockRecorder) ModifyTrustStoreRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyTrustStoreRequest", reflect.TypeOf((*MockELBV2)(nil).ModifyTrustStoreRequest), arg0)
+}
+
+// ModifyTrustStoreWithContext mocks base method
+func (m *MockELBV2) ModifyTrustStoreWithContext(arg0 context.Context, arg1 *elbv2.ModifyTrustStoreInput, arg2 ...request.Option) (*elbv2.ModifyTrustStoreOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ModifyTrustStoreWithContext", varargs...)
+	ret0, _ := ret[0].(*elbv2.ModifyTrustStoreOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModifyTrustStoreWithContext indicates an expected call of ModifyTrustStoreWithContext
+func (mr *MockELBV2MockRecorder) ModifyTrustStoreWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyTrustStoreWithContext", reflect.TypeOf((*MockELBV2)(nil).ModifyTrustStoreWithContext), varargs...)
+}
+
 // RegisterTargets mocks base method
 func (m *MockELBV2) RegisterTargets(arg0 *elbv2.RegisterTargetsInput) (*elbv2.RegisterTargetsOutput, error) {
 	m.ctrl.T.Helper()
@@ -1709,6 +2389,56 @@ func (mr *MockELBV2MockRecorder) RemoveTagsWithContext(arg0, arg1 interface{}, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTagsWithContext", reflect.TypeOf((*MockELBV2)(nil).RemoveTagsWithContext), varargs...)
 }
 
+// RemoveTrustStoreRevocations mocks base method
+func (m *MockELBV2) RemoveTrustStoreRevocations(arg0 *elbv2.RemoveTrustStoreRevocationsInput) (*elbv2.RemoveTrustStoreRevocationsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTrustStoreRevocations", arg0)
+	ret0, _ := ret[0].(*elbv2.RemoveTrustStoreRevocationsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveTrustStoreRevocations indicates an expected call of RemoveTrustStoreRevocations
+func (mr *MockELBV2MockRecorder) RemoveTrustStoreRevocations(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTrustStoreRevocations", reflect.TypeOf((*MockELBV2)(nil).RemoveTrustStoreRevocations), arg0)
+}
+
+// RemoveTrustStoreRevocationsRequest mocks base method
+func (m *MockELBV2) RemoveTrustStoreRevocationsRequest(arg0 *elbv2.RemoveTrustStoreRevocationsInput) (*request.Request, *elbv2.RemoveTrustStoreRevocationsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTrustStoreRevocationsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*elbv2.RemoveTrustStoreRevocationsOutput)
+	return ret0, ret1
+}
+
+// RemoveTrustStoreRevocationsRequest indicates an expected call of RemoveTrustStoreRevocationsRequest
+func (mr *MockELBV2MockRecorder) RemoveTrustStoreRevocationsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTrustStoreRevocationsRequest", reflect.TypeOf((*MockELBV2)(nil).RemoveTrustStoreRevocationsRequest), arg0)
+}
+
+// RemoveTrustStoreRevocationsWithContext mocks base method
+func (m *MockELBV2) RemoveTrustStoreRevocationsWithContext(arg0 context.Context, arg1 *elbv2.RemoveTrustStoreRevocationsInput, arg2 ...request.Option) (*elbv2.RemoveTrustStoreRevocationsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveTrustStoreRevocationsWithContext", varargs...)
+	ret0, _ := ret[0].(*elbv2.RemoveTrustStoreRevocationsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveTrustStoreRevocationsWithContext indicates an expected call of RemoveTrustStoreRevocationsWithContext
+func (mr *MockELBV2MockRecorder) RemoveTrustStoreRevocationsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTrustStoreRevocationsWithContext", reflect.TypeOf((*MockELBV2)(nil).RemoveTrustStoreRevocationsWithContext), varargs...)
+}
+
 // SetIpAddressType mocks base method
 func (m *MockELBV2) SetIpAddressType(arg0 *elbv2.SetIpAddressTypeInput) (*elbv2.SetIpAddressTypeOutput, error) {
 	m.ctrl.T.Helper()
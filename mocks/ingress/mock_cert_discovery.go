@@ -47,3 +47,18 @@ func (mr *MockCertDiscoveryMockRecorder) Discover(arg0, arg1 interface{}) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Discover", reflect.TypeOf((*MockCertDiscovery)(nil).Discover), arg0, arg1)
 }
+
+// DiscoverByTags mocks base method
+func (m *MockCertDiscovery) DiscoverByTags(arg0 context.Context, arg1 map[string]string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscoverByTags", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiscoverByTags indicates an expected call of DiscoverByTags
+func (mr *MockCertDiscoveryMockRecorder) DiscoverByTags(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscoverByTags", reflect.TypeOf((*MockCertDiscovery)(nil).DiscoverByTags), arg0, arg1)
+}
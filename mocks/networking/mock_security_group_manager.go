@@ -84,6 +84,37 @@ func (mr *MockSecurityGroupManagerMockRecorder) FetchSGInfosByRequest(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchSGInfosByRequest", reflect.TypeOf((*MockSecurityGroupManager)(nil).FetchSGInfosByRequest), arg0, arg1)
 }
 
+// FetchSGIngressRules mocks base method
+func (m *MockSecurityGroupManager) FetchSGIngressRules(arg0 context.Context, arg1 string) ([]networking.SecurityGroupRuleInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchSGIngressRules", arg0, arg1)
+	ret0, _ := ret[0].([]networking.SecurityGroupRuleInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchSGIngressRules indicates an expected call of FetchSGIngressRules
+func (mr *MockSecurityGroupManagerMockRecorder) FetchSGIngressRules(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchSGIngressRules", reflect.TypeOf((*MockSecurityGroupManager)(nil).FetchSGIngressRules), arg0, arg1)
+}
+
+// InvalidateSGInfos mocks base method
+func (m *MockSecurityGroupManager) InvalidateSGInfos(arg0 ...string) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "InvalidateSGInfos", varargs...)
+}
+
+// InvalidateSGInfos indicates an expected call of InvalidateSGInfos
+func (mr *MockSecurityGroupManagerMockRecorder) InvalidateSGInfos(arg0 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateSGInfos", reflect.TypeOf((*MockSecurityGroupManager)(nil).InvalidateSGInfos), arg0...)
+}
+
 // RevokeSGIngress mocks base method
 func (m *MockSecurityGroupManager) RevokeSGIngress(arg0 context.Context, arg1 string, arg2 []networking.IPPermissionInfo) error {
 	m.ctrl.T.Helper()
@@ -97,3 +128,17 @@ func (mr *MockSecurityGroupManagerMockRecorder) RevokeSGIngress(arg0, arg1, arg2
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSGIngress", reflect.TypeOf((*MockSecurityGroupManager)(nil).RevokeSGIngress), arg0, arg1, arg2)
 }
+
+// TagSGRules mocks base method
+func (m *MockSecurityGroupManager) TagSGRules(arg0 context.Context, arg1 string, arg2 []string, arg3 map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TagSGRules", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TagSGRules indicates an expected call of TagSGRules
+func (mr *MockSecurityGroupManagerMockRecorder) TagSGRules(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagSGRules", reflect.TypeOf((*MockSecurityGroupManager)(nil).TagSGRules), arg0, arg1, arg2, arg3)
+}
@@ -4,28 +4,63 @@ const (
 	// IngressClass
 	IngressClass = "kubernetes.io/ingress.class"
 
+	// AnnotationPrefixIngress is the prefix for Ingress annotations
+	AnnotationPrefixIngress = "alb.ingress.kubernetes.io"
+
+	// AnnotationPrefixService is the prefix for Service annotations
+	AnnotationPrefixService = "service.beta.kubernetes.io"
+
 	// Ingress annotation suffixes
 	IngressSuffixGroupName                    = "group.name"
 	IngressSuffixGroupOrder                   = "group.order"
+	IngressSuffixGroupDefaultAction           = "group.default-action"
 	IngressSuffixTags                         = "tags"
 	IngressSuffixIPAddressType                = "ip-address-type"
 	IngressSuffixScheme                       = "scheme"
 	IngressSuffixSubnets                      = "subnets"
+	IngressSuffixSubnetLocale                 = "subnet-locale"
+	IngressSuffixSubnetTags                   = "subnet-tags"
+	IngressSuffixExcludedSubnets              = "excluded-subnets"
 	IngressSuffixCustomerOwnedIPv4Pool        = "customer-owned-ipv4-pool"
 	IngressSuffixLoadBalancerAttributes       = "load-balancer-attributes"
+	IngressSuffixLoadBalancerARN              = "load-balancer-arn"
 	IngressSuffixWAFv2ACLARN                  = "wafv2-acl-arn"
 	IngressSuffixWAFACLID                     = "waf-acl-id"
 	IngressSuffixWebACLID                     = "web-acl-id" // deprecated, use "waf-acl-id" instead.
 	IngressSuffixShieldAdvancedProtection     = "shield-advanced-protection"
+	IngressSuffixZonalShiftAwayFrom           = "zonal-shift.away-from"
+	IngressSuffixZonalShiftExpiresIn          = "zonal-shift.expires-in"
+	IngressSuffixZonalShiftComment            = "zonal-shift.comment"
 	IngressSuffixSecurityGroups               = "security-groups"
 	IngressSuffixListenPorts                  = "listen-ports"
 	IngressSuffixInboundCIDRs                 = "inbound-cidrs"
 	IngressSuffixCertificateARN               = "certificate-arn"
+	IngressSuffixCertificateTags              = "certificate-tags"
+	IngressSuffixDefaultCertificateARN        = "default-certificate-arn"
 	IngressSuffixSSLPolicy                    = "ssl-policy"
 	IngressSuffixTargetType                   = "target-type"
 	IngressSuffixBackendProtocol              = "backend-protocol"
 	IngressSuffixBackendProtocolVersion       = "backend-protocol-version"
 	IngressSuffixTargetGroupAttributes        = "target-group-attributes"
+	IngressSuffixTargetGroupCrossZone         = "target-group-cross-zone-load-balancing"
+	IngressSuffixCodeDeployBlueGreen          = "codedeploy-blue-green"
+	IngressSuffixCodeDeployActiveColor        = "codedeploy-active-color"
+	IngressSuffixCodeDeployPausedUntil        = "codedeploy-paused-until"
+	IngressSuffixTargetGroupAnomalyMitigation = "target-group-anomaly-mitigation"
+	IngressSuffixTargetGroupSlowStart         = "target-group-slow-start-duration-seconds"
+	IngressSuffixTargetGroupWeightRamp        = "target-group-weight-ramp"
+
+	IngressSuffixTargetGroupStickinessAppCookieName            = "target-group-stickiness.app-cookie.name"
+	IngressSuffixTargetGroupStickinessAppCookieDurationSeconds = "target-group-stickiness.app-cookie.duration-seconds"
+
+	IngressSuffixLoadBalancerAttributesOwner       = "load-balancer-attributes.owner"
+	IngressSuffixLoadBalancerAttributesMergePolicy = "load-balancer-attributes.merge-policy"
+
+	IngressSuffixTargetGroupHealthDNSFailoverMinHealthyTargetsCount                = "target-group-health.dns-failover.minimum-healthy-targets.count"
+	IngressSuffixTargetGroupHealthDNSFailoverMinHealthyTargetsPercentage           = "target-group-health.dns-failover.minimum-healthy-targets.percentage"
+	IngressSuffixTargetGroupHealthUnhealthyStateRoutingMinHealthyTargetsCount      = "target-group-health.unhealthy-state-routing.minimum-healthy-targets.count"
+	IngressSuffixTargetGroupHealthUnhealthyStateRoutingMinHealthyTargetsPercentage = "target-group-health.unhealthy-state-routing.minimum-healthy-targets.percentage"
+
 	IngressSuffixHealthCheckPort              = "healthcheck-port"
 	IngressSuffixHealthCheckProtocol          = "healthcheck-protocol"
 	IngressSuffixHealthCheckPath              = "healthcheck-path"
@@ -41,6 +76,20 @@ const (
 	IngressSuffixAuthScope                    = "auth-scope"
 	IngressSuffixAuthSessionCookie            = "auth-session-cookie"
 	IngressSuffixAuthSessionTimeout           = "auth-session-timeout"
+	IngressSuffixDriftSyncPeriod              = "drift-sync-period"
+	IngressSuffixWildcardHostMatchApex        = "wildcard-host-match-apex"
+	IngressSuffixReconcile                    = "reconcile"
+	IngressSuffixDeletionProtection           = "deletion-protection"
+	IngressSuffixDeletionConfirmation         = "deletion-confirmation"
+	IngressSuffixManageRoute53Records         = "manage-route53-records"
+	IngressSuffixTTL                          = "ttl"
+	IngressSuffixTTLDeleteObject              = "ttl-delete-object"
+	IngressSuffixReplacementStrategy          = "replacement-strategy"
+	IngressSuffixReplacementDrainWindow       = "replacement-drain-window"
+
+	// IngressClass annotation suffixes
+	IngressClassSuffixIAMRoleARN     = "iam-role-arn"
+	IngressClassSuffixGroupAllowlist = "group.allowlist"
 
 	// NLB annotation suffixes
 	// prefixes service.beta.kubernetes.io, service.kubernetes.io
@@ -49,10 +98,12 @@ const (
 	SvcLBSuffixInternal                      = "aws-load-balancer-internal"
 	SvcLBSuffixIPAddressType                 = "aws-load-balancer-ip-address-type"
 	SvcLBSuffixProxyProtocol                 = "aws-load-balancer-proxy-protocol"
+	SvcLBSuffixProxyProtocolTLVs             = "aws-load-balancer-proxy-protocol-tlvs"
 	SvcLBSuffixAccessLogEnabled              = "aws-load-balancer-access-log-enabled"
 	SvcLBSuffixAccessLogS3BucketName         = "aws-load-balancer-access-log-s3-bucket-name"
 	SvcLBSuffixAccessLogS3BucketPrefix       = "aws-load-balancer-access-log-s3-bucket-prefix"
 	SvcLBSuffixCrossZoneLoadBalancingEnabled = "aws-load-balancer-cross-zone-load-balancing-enabled"
+	SvcLBSuffixDNSRecordClientRoutingPolicy  = "aws-load-balancer-dns-record-client-routing-policy"
 	SvcLBSuffixSSLCertificate                = "aws-load-balancer-ssl-cert"
 	SvcLBSuffixSSLPorts                      = "aws-load-balancer-ssl-ports"
 	SvcLBSuffixSSLNegotiationPolicy          = "aws-load-balancer-ssl-negotiation-policy"
@@ -66,7 +117,35 @@ const (
 	SvcLBSuffixHCPort                        = "aws-load-balancer-healthcheck-port"
 	SvcLBSuffixHCPath                        = "aws-load-balancer-healthcheck-path"
 	SvcLBSuffixEIPAllocations                = "aws-load-balancer-eip-allocations"
+	SvcLBSuffixEIPPool                       = "aws-load-balancer-eip-pool"
+	SvcLBSuffixEIPPoolAllocate               = "aws-load-balancer-eip-pool-allocate"
+	SvcLBSuffixIPAMPool                      = "aws-load-balancer-ipam-pool"
+	SvcLBSuffixVPCEndpointService            = "aws-load-balancer-vpc-endpoint-service"
 	SvcLBSuffixTargetGroupAttributes         = "aws-load-balancer-target-group-attributes"
+	SvcLBSuffixListenerAttributes            = "aws-load-balancer-listener-attributes"
+	SvcLBSuffixTargetGroupCrossZone          = "aws-load-balancer-target-group-cross-zone-load-balancing"
+	SvcLBSuffixTargetNodeLabels              = "aws-load-balancer-target-node-labels"
 	SvcLBSuffixSubnets                       = "aws-load-balancer-subnets"
+	SvcLBSuffixSubnetTags                    = "aws-load-balancer-subnet-tags"
+	SvcLBSuffixExcludedSubnets               = "aws-load-balancer-excluded-subnets"
 	SvcLBSuffixALPNPolicy                    = "aws-load-balancer-alpn-policy"
+	SvcLBSuffixManageSecurityGroup           = "aws-load-balancer-manage-security-group"
+	SvcLBSuffixDriftSyncPeriod               = "aws-load-balancer-drift-sync-period"
+	SvcLBSuffixZonalShiftAwayFrom            = "aws-load-balancer-zonal-shift-away-from"
+	SvcLBSuffixZonalShiftExpiresIn           = "aws-load-balancer-zonal-shift-expires-in"
+	SvcLBSuffixZonalShiftComment             = "aws-load-balancer-zonal-shift-comment"
+	SvcLBSuffixReconcile                     = "aws-load-balancer-reconcile"
+	SvcLBSuffixGroupName                     = "aws-load-balancer-group-name"
+	SvcLBSuffixTTL                           = "aws-load-balancer-ttl"
+	SvcLBSuffixTTLDeleteObject               = "aws-load-balancer-ttl-delete-object"
+	SvcLBSuffixReplacementStrategy           = "aws-load-balancer-replacement-strategy"
+	SvcLBSuffixReplacementDrainWindow        = "aws-load-balancer-replacement-drain-window"
+
+	SvcLBSuffixVPCEndpointServiceAcceptanceRequired = "aws-load-balancer-vpc-endpoint-service-acceptance-required"
+	SvcLBSuffixVPCEndpointServiceAllowedPrincipals  = "aws-load-balancer-vpc-endpoint-service-allowed-principals"
+
+	SvcLBSuffixTargetGroupHealthDNSFailoverMinHealthyTargetsCount                = "aws-load-balancer-target-group-health-dns-failover-minimum-healthy-targets-count"
+	SvcLBSuffixTargetGroupHealthDNSFailoverMinHealthyTargetsPercentage           = "aws-load-balancer-target-group-health-dns-failover-minimum-healthy-targets-percentage"
+	SvcLBSuffixTargetGroupHealthUnhealthyStateRoutingMinHealthyTargetsCount      = "aws-load-balancer-target-group-health-unhealthy-state-routing-minimum-healthy-targets-count"
+	SvcLBSuffixTargetGroupHealthUnhealthyStateRoutingMinHealthyTargetsPercentage = "aws-load-balancer-target-group-health-unhealthy-state-routing-minimum-healthy-targets-percentage"
 )
@@ -133,19 +133,9 @@ func (p *suffixAnnotationParser) ParseStringMapAnnotation(annotation string, val
 	if !exists {
 		return false, nil
 	}
-	rawKVPairs := splitCommaSeparatedString(raw)
-	keyValues := make(map[string]string)
-	for _, kvPair := range rawKVPairs {
-		parts := strings.SplitN(kvPair, "=", 2)
-		if len(parts) != 2 {
-			return false, errors.Errorf("failed to parse stringMap annotation, %v: %v", matchedKey, raw)
-		}
-		key := parts[0]
-		value := parts[1]
-		if len(key) == 0 {
-			return false, errors.Errorf("failed to parse stringMap annotation, %v: %v", matchedKey, raw)
-		}
-		keyValues[key] = value
+	keyValues, err := splitCommaSeparatedKeyValues(raw)
+	if err != nil {
+		return false, errors.Errorf("failed to parse stringMap annotation, %v: %v", matchedKey, raw)
 	}
 	if value != nil {
 		*value = keyValues
@@ -194,3 +184,19 @@ func splitCommaSeparatedString(commaSeparatedString string) []string {
 	}
 	return result
 }
+
+// splitCommaSeparatedKeyValues splits comma separated key=value pairs into a map.
+func splitCommaSeparatedKeyValues(commaSeparatedKeyValues string) (map[string]string, error) {
+	keyValues := make(map[string]string)
+	for _, kvPair := range splitCommaSeparatedString(commaSeparatedKeyValues) {
+		parts := strings.SplitN(kvPair, "=", 2)
+		if len(parts) != 2 {
+			return nil, errors.Errorf("expect key=value pair: %v", kvPair)
+		}
+		if len(parts[0]) == 0 {
+			return nil, errors.Errorf("expect non-empty key: %v", kvPair)
+		}
+		keyValues[parts[0]] = parts[1]
+	}
+	return keyValues, nil
+}
@@ -0,0 +1,219 @@
+package annotations
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ValueValidator validates the raw value of an annotation.
+type ValueValidator func(value string) error
+
+// Validator validates annotations have values of their expected formats, so that invalid annotations can be rejected at admission time.
+type Validator interface {
+	// Validate validates annotations, each invalid annotation is reported as an error on its key under fldPath.
+	Validate(annotations map[string]string, fldPath *field.Path) field.ErrorList
+}
+
+// NewSuffixAnnotationValidator returns new suffixAnnotationValidator for annotations with specified prefixes.
+// validatorsBySuffix validates annotations by exact suffix, while validatorsBySuffixPrefix validates annotations whose suffix is templated, like "actions.${name}".
+func NewSuffixAnnotationValidator(annotationPrefixes []string, validatorsBySuffix map[string]ValueValidator, validatorsBySuffixPrefix map[string]ValueValidator) *suffixAnnotationValidator {
+	return &suffixAnnotationValidator{
+		annotationPrefixes:       annotationPrefixes,
+		validatorsBySuffix:       validatorsBySuffix,
+		validatorsBySuffixPrefix: validatorsBySuffixPrefix,
+	}
+}
+
+var _ Validator = &suffixAnnotationValidator{}
+
+// suffixAnnotationValidator is an Validator implementation that identify annotation by configurable prefixes and suffix.
+// annotations with unknown suffix are left to be ignored.
+type suffixAnnotationValidator struct {
+	annotationPrefixes       []string
+	validatorsBySuffix       map[string]ValueValidator
+	validatorsBySuffixPrefix map[string]ValueValidator
+}
+
+func (v *suffixAnnotationValidator) Validate(annotations map[string]string, fldPath *field.Path) field.ErrorList {
+	keys := make([]string, 0, len(annotations))
+	for key := range annotations {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var allErrs field.ErrorList
+	for _, key := range keys {
+		validator := v.findValueValidator(key)
+		if validator == nil {
+			continue
+		}
+		value := annotations[key]
+		if err := validator(value); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(key), value, err.Error()))
+		}
+	}
+	return allErrs
+}
+
+// findValueValidator finds the validator for annotation key, it returns nil if the key is unknown.
+func (v *suffixAnnotationValidator) findValueValidator(key string) ValueValidator {
+	for _, prefix := range v.annotationPrefixes {
+		if !strings.HasPrefix(key, prefix+"/") {
+			continue
+		}
+		suffix := strings.TrimPrefix(key, prefix+"/")
+		if validator, ok := v.validatorsBySuffix[suffix]; ok {
+			return validator
+		}
+		for suffixPrefix, validator := range v.validatorsBySuffixPrefix {
+			if strings.HasPrefix(suffix, suffixPrefix) {
+				return validator
+			}
+		}
+	}
+	return nil
+}
+
+// ChangedAnnotations returns the annotations that are added or changed compared to oldAnnotations.
+// Validators only check changed annotations upon update, so that objects carrying annotations invalid before validation was introduced can still be updated.
+func ChangedAnnotations(annotations map[string]string, oldAnnotations map[string]string) map[string]string {
+	changedAnnotations := make(map[string]string)
+	for key, value := range annotations {
+		if oldValue, exists := oldAnnotations[key]; !exists || oldValue != value {
+			changedAnnotations[key] = value
+		}
+	}
+	return changedAnnotations
+}
+
+// ValidateBool validates value is a boolean.
+func ValidateBool(value string) error {
+	if _, err := strconv.ParseBool(value); err != nil {
+		return errors.New("must be true or false")
+	}
+	return nil
+}
+
+// ValidateInt64InRange returns ValueValidator that validates value is an integer within [min, max].
+func ValidateInt64InRange(min int64, max int64) ValueValidator {
+	return func(value string) error {
+		i, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || i < min || i > max {
+			return errors.Errorf("must be an integer within [%v, %v]", min, max)
+		}
+		return nil
+	}
+}
+
+// ValidateDurationAtLeast returns ValueValidator that validates value is a duration no shorter than min.
+func ValidateDurationAtLeast(min time.Duration) ValueValidator {
+	return func(value string) error {
+		d, err := time.ParseDuration(value)
+		if err != nil || d < min {
+			return errors.Errorf("must be a duration of at least %v like 5m", min)
+		}
+		return nil
+	}
+}
+
+// ValidateOneOf returns ValueValidator that validates value is one of allowed values.
+func ValidateOneOf(allowedValues ...string) ValueValidator {
+	return func(value string) error {
+		for _, allowedValue := range allowedValues {
+			if value == allowedValue {
+				return nil
+			}
+		}
+		return errors.Errorf("must be one of [%v]", strings.Join(allowedValues, ", "))
+	}
+}
+
+// ValidateStringSlice returns ValueValidator that validates value is a comma separated list,
+// and each item of the list is valid by itemValidator.
+func ValidateStringSlice(itemValidator ValueValidator) ValueValidator {
+	return func(value string) error {
+		items := splitCommaSeparatedString(value)
+		if len(items) == 0 {
+			return errors.New("must be a non-empty comma separated list")
+		}
+		for index, item := range items {
+			if err := itemValidator(item); err != nil {
+				return errors.Errorf("item %v %q %v", index, item, err.Error())
+			}
+		}
+		return nil
+	}
+}
+
+// ValidateNonEmpty validates value is non-empty.
+func ValidateNonEmpty(value string) error {
+	if len(strings.TrimSpace(value)) == 0 {
+		return errors.New("must be non-empty")
+	}
+	return nil
+}
+
+// ValidateCIDR validates value is an IPv4 or IPv6 CIDR.
+func ValidateCIDR(value string) error {
+	if _, _, err := net.ParseCIDR(value); err != nil {
+		return errors.New("must be a valid IPv4 or IPv6 CIDR like 10.0.0.0/16")
+	}
+	return nil
+}
+
+// ValidateStringMap validates value is comma separated key=value pairs.
+func ValidateStringMap(value string) error {
+	if _, err := splitCommaSeparatedKeyValues(value); err != nil {
+		return errors.Wrap(err, "must be comma separated key=value pairs")
+	}
+	return nil
+}
+
+// ValidateStringMapWith returns ValueValidator that validates value is comma separated key=value pairs,
+// and the pairs are valid by mapValidator.
+func ValidateStringMapWith(mapValidator func(keyValues map[string]string) error) ValueValidator {
+	return func(value string) error {
+		keyValues, err := splitCommaSeparatedKeyValues(value)
+		if err != nil {
+			return errors.Wrap(err, "must be comma separated key=value pairs")
+		}
+		return mapValidator(keyValues)
+	}
+}
+
+// ValidateJSON returns ValueValidator that validates value is json of the object from newObj,
+// and the decoded object is valid by objValidator if it's not nil.
+func ValidateJSON(newObj func() interface{}, objValidator func(obj interface{}) error) ValueValidator {
+	return func(value string) error {
+		obj := newObj()
+		if err := json.Unmarshal([]byte(value), obj); err != nil {
+			return errors.Errorf("must be valid json: %v", describeJSONError(err))
+		}
+		if objValidator != nil {
+			return objValidator(obj)
+		}
+		return nil
+	}
+}
+
+// describeJSONError describes json decode errors with the position or field they occurred at.
+func describeJSONError(err error) string {
+	switch jsonErr := err.(type) {
+	case *json.SyntaxError:
+		return fmt.Sprintf("%v at offset %v", jsonErr.Error(), jsonErr.Offset)
+	case *json.UnmarshalTypeError:
+		if jsonErr.Field != "" {
+			return fmt.Sprintf("cannot use %v as %v for field %v", jsonErr.Value, jsonErr.Type.String(), jsonErr.Field)
+		}
+		return fmt.Sprintf("cannot use %v as %v", jsonErr.Value, jsonErr.Type.String())
+	default:
+		return err.Error()
+	}
+}
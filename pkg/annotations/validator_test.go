@@ -0,0 +1,100 @@
+package annotations
+
+import (
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"testing"
+)
+
+func Test_suffixAnnotationValidator_Validate(t *testing.T) {
+	type jsonObj struct {
+		Port int64 `json:"port"`
+	}
+	v := NewSuffixAnnotationValidator([]string{"pfx.io", "alt.pfx.io"},
+		map[string]ValueValidator{
+			"enabled": ValidateBool,
+			"count":   ValidateInt64InRange(1, 10),
+			"mode":    ValidateOneOf("a", "b"),
+			"cidrs":   ValidateStringSlice(ValidateCIDR),
+			"tags":    ValidateStringMap,
+		},
+		map[string]ValueValidator{
+			"config.": ValidateJSON(func() interface{} {
+				return &jsonObj{}
+			}, nil),
+		},
+	)
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantErrs    []string
+	}{
+		{
+			name: "valid annotations",
+			annotations: map[string]string{
+				"pfx.io/enabled":     "true",
+				"alt.pfx.io/count":   "10",
+				"pfx.io/mode":        "b",
+				"pfx.io/cidrs":       "10.0.0.0/16,2001:db8::/32",
+				"pfx.io/tags":        "k1=v1, k2=",
+				"pfx.io/config.svc1": `{"port": 80}`,
+			},
+		},
+		{
+			name: "unknown annotations are ignored",
+			annotations: map[string]string{
+				"pfx.io/unknown":     "value",
+				"other.io/enabled":   "yes",
+				"pfx.io.sub/enabled": "yes",
+			},
+		},
+		{
+			name: "invalid annotations",
+			annotations: map[string]string{
+				"pfx.io/enabled":     "yes",
+				"alt.pfx.io/count":   "11",
+				"pfx.io/mode":        "c",
+				"pfx.io/cidrs":       "10.0.0.0/16,10.0.0.1",
+				"pfx.io/tags":        "=v1",
+				"pfx.io/config.svc1": `{"port": "80"}`,
+				"pfx.io/config.svc2": `{"port": 80`,
+			},
+			wantErrs: []string{
+				`metadata.annotations[alt.pfx.io/count]: Invalid value: "11": must be an integer within [1, 10]`,
+				`metadata.annotations[pfx.io/cidrs]: Invalid value: "10.0.0.0/16,10.0.0.1": item 1 "10.0.0.1" must be a valid IPv4 or IPv6 CIDR like 10.0.0.0/16`,
+				`metadata.annotations[pfx.io/config.svc1]: Invalid value: "{\"port\": \"80\"}": must be valid json: cannot use string as int64 for field port`,
+				`metadata.annotations[pfx.io/config.svc2]: Invalid value: "{\"port\": 80": must be valid json: unexpected end of JSON input at offset 11`,
+				`metadata.annotations[pfx.io/enabled]: Invalid value: "yes": must be true or false`,
+				`metadata.annotations[pfx.io/mode]: Invalid value: "c": must be one of [a, b]`,
+				`metadata.annotations[pfx.io/tags]: Invalid value: "=v1": must be comma separated key=value pairs: expect non-empty key: =v1`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := v.Validate(tt.annotations, field.NewPath("metadata", "annotations"))
+			var gotErrs []string
+			for _, err := range errs {
+				gotErrs = append(gotErrs, err.Error())
+			}
+			assert.Equal(t, tt.wantErrs, gotErrs)
+		})
+	}
+}
+
+func Test_ChangedAnnotations(t *testing.T) {
+	annotations := map[string]string{
+		"pfx.io/unchanged": "a",
+		"pfx.io/changed":   "b",
+		"pfx.io/added":     "c",
+	}
+	oldAnnotations := map[string]string{
+		"pfx.io/unchanged": "a",
+		"pfx.io/changed":   "x",
+		"pfx.io/removed":   "y",
+	}
+	assert.Equal(t, map[string]string{
+		"pfx.io/changed": "b",
+		"pfx.io/added":   "c",
+	}, ChangedAnnotations(annotations, oldAnnotations))
+}
@@ -0,0 +1,195 @@
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"sort"
+	"strings"
+)
+
+const (
+	sdkHandlerRecordAPICall = "recordAPICallAudit"
+
+	apiCallRecordEventVersion = "1.08"
+	apiCallRecordEventType    = "AwsApiCall"
+	apiCallRecordTimeFormat   = "2006-01-02T15:04:05Z"
+
+	resourceTypeSecurityGroup = "AWS::EC2::SecurityGroup"
+)
+
+// prefixes of read-only AWS API operations, which are not audited.
+var readOnlyOperationPrefixes = []string{"Describe", "List", "Get"}
+
+// APICallRecord is the audit record of a mutating AWS API call, its fields follow the CloudTrail record format.
+type APICallRecord struct {
+	EventVersion        string                     `json:"eventVersion"`
+	EventTime           string                     `json:"eventTime"`
+	EventSource         string                     `json:"eventSource"`
+	EventName           string                     `json:"eventName"`
+	EventType           string                     `json:"eventType"`
+	AWSRegion           string                     `json:"awsRegion"`
+	UserAgent           string                     `json:"userAgent,omitempty"`
+	RequestID           string                     `json:"requestID,omitempty"`
+	ErrorCode           string                     `json:"errorCode,omitempty"`
+	ErrorMessage        string                     `json:"errorMessage,omitempty"`
+	ReadOnly            bool                       `json:"readOnly"`
+	Resources           []APICallResource          `json:"resources,omitempty"`
+	AdditionalEventData APICallAdditionalEventData `json:"additionalEventData"`
+}
+
+// APICallResource is an AWS resource accessed by an AWS API call.
+type APICallResource struct {
+	ARN  string `json:"ARN,omitempty"`
+	Type string `json:"type,omitempty"`
+	ID   string `json:"resourceID,omitempty"`
+}
+
+// APICallAdditionalEventData is the data of AWS API call that CloudTrail doesn't record.
+type APICallAdditionalEventData struct {
+	// the hash of request parameters, parameters are not recorded since they can contain secrets like OIDC client secrets.
+	RequestParametersHash string `json:"requestParametersHash"`
+	// the Kubernetes objects whose reconcile made the call, in the form of Kind/namespace/name.
+	ReconcileTrigger []string `json:"reconcileTrigger,omitempty"`
+	RetryCount       int      `json:"retryCount"`
+}
+
+// NewAPICallRecorder constructs new apiCallRecorder that writes records into sink.
+func NewAPICallRecorder(sink APICallSink) *apiCallRecorder {
+	return &apiCallRecorder{
+		sink: sink,
+	}
+}
+
+// apiCallRecorder records mutating AWS API calls made by the controller.
+type apiCallRecorder struct {
+	sink APICallSink
+}
+
+// InjectHandlers injects the SDK handlers that record AWS API calls once they complete.
+func (r *apiCallRecorder) InjectHandlers(handlers *request.Handlers) {
+	handlers.Complete.PushBackNamed(request.NamedHandler{
+		Name: sdkHandlerRecordAPICall,
+		Fn:   r.recordAPICall,
+	})
+}
+
+func (r *apiCallRecorder) recordAPICall(req *request.Request) {
+	if req.Operation == nil || IsReadOnlyOperation(req.Operation.Name) {
+		return
+	}
+	r.sink.Write(buildAPICallRecord(req))
+}
+
+// buildAPICallRecord builds the audit record for AWS API call.
+func buildAPICallRecord(req *request.Request) APICallRecord {
+	record := APICallRecord{
+		EventVersion: apiCallRecordEventVersion,
+		EventTime:    req.Time.UTC().Format(apiCallRecordTimeFormat),
+		EventSource:  fmt.Sprintf("%v.amazonaws.com", req.ClientInfo.SigningName),
+		EventName:    req.Operation.Name,
+		EventType:    apiCallRecordEventType,
+		AWSRegion:    aws.StringValue(req.Config.Region),
+		RequestID:    req.RequestID,
+		ReadOnly:     false,
+		AdditionalEventData: APICallAdditionalEventData{
+			RequestParametersHash: hashRequestParameters(req.Params),
+			ReconcileTrigger:      reconcileTriggerFromContext(req.Context()),
+			RetryCount:            req.RetryCount,
+		},
+	}
+	if req.HTTPRequest != nil {
+		record.UserAgent = req.HTTPRequest.Header.Get("User-Agent")
+	}
+	if req.Error != nil {
+		record.ErrorCode = "internal"
+		if awsErr, ok := req.Error.(awserr.Error); ok {
+			record.ErrorCode = awsErr.Code()
+		}
+		record.ErrorMessage = req.Error.Error()
+	}
+	record.Resources = extractAPICallResources(req.Params, req.Data)
+	return record
+}
+
+// IsReadOnlyOperation checks whether AWS API operation is read-only by its name.
+func IsReadOnlyOperation(operation string) bool {
+	for _, prefix := range readOnlyOperationPrefixes {
+		if strings.HasPrefix(operation, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// hashRequestParameters computes the sha256 hash of JSON representation of request parameters.
+func hashRequestParameters(params interface{}) string {
+	payload, err := json.Marshal(params)
+	if err != nil {
+		return ""
+	}
+	hash := sha256.Sum256(payload)
+	return "sha256:" + hex.EncodeToString(hash[:])
+}
+
+// extractAPICallResources extracts the ARNs and SecurityGroup IDs from request parameters and response data of AWS API call.
+func extractAPICallResources(objs ...interface{}) []APICallResource {
+	arns := make(map[string]struct{})
+	securityGroupIDs := make(map[string]struct{})
+	for _, obj := range objs {
+		payload, err := json.Marshal(obj)
+		if err != nil {
+			continue
+		}
+		var value interface{}
+		if err := json.Unmarshal(payload, &value); err != nil {
+			continue
+		}
+		collectResourceIdentifiers(value, "", arns, securityGroupIDs)
+	}
+
+	resources := make([]APICallResource, 0, len(arns)+len(securityGroupIDs))
+	for _, arn := range sortedKeys(arns) {
+		resources = append(resources, APICallResource{ARN: arn})
+	}
+	for _, sgID := range sortedKeys(securityGroupIDs) {
+		resources = append(resources, APICallResource{Type: resourceTypeSecurityGroup, ID: sgID})
+	}
+	if len(resources) == 0 {
+		return nil
+	}
+	return resources
+}
+
+// collectResourceIdentifiers walks value decoded from JSON, and collects ARNs and SecurityGroup IDs within it.
+func collectResourceIdentifiers(value interface{}, key string, arns map[string]struct{}, securityGroupIDs map[string]struct{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for childKey, childValue := range v {
+			collectResourceIdentifiers(childValue, childKey, arns, securityGroupIDs)
+		}
+	case []interface{}:
+		for _, childValue := range v {
+			collectResourceIdentifiers(childValue, key, arns, securityGroupIDs)
+		}
+	case string:
+		if strings.HasPrefix(v, "arn:") {
+			arns[v] = struct{}{}
+		} else if (key == "GroupId" || key == "GroupIds" || key == "SecurityGroups") && strings.HasPrefix(v, "sg-") {
+			securityGroupIDs[v] = struct{}{}
+		}
+	}
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
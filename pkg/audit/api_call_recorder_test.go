@@ -0,0 +1,138 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+// memoryAPICallSink keeps records in memory.
+type memoryAPICallSink struct {
+	records []APICallRecord
+}
+
+func (s *memoryAPICallSink) Write(record APICallRecord) {
+	s.records = append(s.records, record)
+}
+
+func Test_apiCallRecorder_recordAPICall(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "svc-1",
+		},
+	}
+	buildRequest := func(ctx context.Context, signingName string, operation string, params interface{}, data interface{}, err error) *request.Request {
+		req := request.New(aws.Config{Region: aws.String("us-west-2")}, metadata.ClientInfo{SigningName: signingName}, request.Handlers{},
+			nil, &request.Operation{Name: operation}, params, data)
+		req.SetContext(ctx)
+		req.Time = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+		req.RequestID = "request-id"
+		req.HTTPRequest.Header.Set("User-Agent", "elbv2.k8s.aws/v2.4.0")
+		req.Error = err
+		return req
+	}
+	tests := []struct {
+		name        string
+		req         *request.Request
+		wantRecords []APICallRecord
+	}{
+		{
+			name: "read-only call is not recorded",
+			req: buildRequest(context.Background(), "elasticloadbalancing", "DescribeTargetHealth",
+				&elbv2sdk.DescribeTargetHealthInput{TargetGroupArn: aws.String("arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/tg-1/abc")}, nil, nil),
+			wantRecords: nil,
+		},
+		{
+			name: "mutating call within reconcile",
+			req: buildRequest(ContextWithMutationRecorder(context.Background(), nil, svc), "elasticloadbalancing", "RegisterTargets",
+				&elbv2sdk.RegisterTargetsInput{
+					TargetGroupArn: aws.String("arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/tg-1/abc"),
+					Targets:        []*elbv2sdk.TargetDescription{{Id: aws.String("192.168.1.1"), Port: aws.Int64(8080)}},
+				}, &elbv2sdk.RegisterTargetsOutput{}, nil),
+			wantRecords: []APICallRecord{
+				{
+					EventVersion: "1.08",
+					EventTime:    "2026-01-02T03:04:05Z",
+					EventSource:  "elasticloadbalancing.amazonaws.com",
+					EventName:    "RegisterTargets",
+					EventType:    "AwsApiCall",
+					AWSRegion:    "us-west-2",
+					UserAgent:    "elbv2.k8s.aws/v2.4.0",
+					RequestID:    "request-id",
+					Resources: []APICallResource{
+						{ARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/tg-1/abc"},
+					},
+					AdditionalEventData: APICallAdditionalEventData{
+						RequestParametersHash: "sha256:e60c22af9e28fddca29e74bd2a909ff6d1097bc93b8bbcf4e7e6534a820c83aa",
+						ReconcileTrigger:      []string{"Service/awesome-ns/svc-1"},
+					},
+				},
+			},
+		},
+		{
+			name: "failed mutating call on SecurityGroup",
+			req: buildRequest(context.Background(), "ec2", "AuthorizeSecurityGroupIngress",
+				&ec2sdk.AuthorizeSecurityGroupIngressInput{GroupId: aws.String("sg-1")}, &ec2sdk.AuthorizeSecurityGroupIngressOutput{},
+				awserr.New("InvalidPermission.Duplicate", "the permission already exists", errors.New("duplicate"))),
+			wantRecords: []APICallRecord{
+				{
+					EventVersion: "1.08",
+					EventTime:    "2026-01-02T03:04:05Z",
+					EventSource:  "ec2.amazonaws.com",
+					EventName:    "AuthorizeSecurityGroupIngress",
+					EventType:    "AwsApiCall",
+					AWSRegion:    "us-west-2",
+					UserAgent:    "elbv2.k8s.aws/v2.4.0",
+					RequestID:    "request-id",
+					ErrorCode:    "InvalidPermission.Duplicate",
+					ErrorMessage: "InvalidPermission.Duplicate: the permission already exists\ncaused by: duplicate",
+					Resources: []APICallResource{
+						{Type: "AWS::EC2::SecurityGroup", ID: "sg-1"},
+					},
+					AdditionalEventData: APICallAdditionalEventData{
+						RequestParametersHash: "sha256:0872cc04890c6a2e528b2c15baaa5ffa67700fb011d1effce12b7bf790faa822",
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := &memoryAPICallSink{}
+			recorder := NewAPICallRecorder(sink)
+			recorder.recordAPICall(tt.req)
+			assert.Equal(t, tt.wantRecords, sink.records)
+		})
+	}
+}
+
+func TestIsReadOnlyOperation(t *testing.T) {
+	tests := []struct {
+		operation string
+		want      bool
+	}{
+		{operation: "DescribeLoadBalancers", want: true},
+		{operation: "ListTagsForResource", want: true},
+		{operation: "GetWebACLForResource", want: true},
+		{operation: "CreateLoadBalancer", want: false},
+		{operation: "ModifyTargetGroupAttributes", want: false},
+		{operation: "AddTags", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.operation, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsReadOnlyOperation(tt.operation))
+		})
+	}
+}
+
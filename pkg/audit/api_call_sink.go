@@ -0,0 +1,141 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	// the max number of records buffered by webhook sink, new records are dropped once the buffer is full.
+	defaultWebhookSinkBufferSize = 1000
+	// the timeout to deliver each record to webhook.
+	defaultWebhookSinkTimeout = 10 * time.Second
+)
+
+// APICallSink writes audit records of AWS API calls.
+type APICallSink interface {
+	// Write writes the record, it must not block the AWS API call for long.
+	Write(record APICallRecord)
+}
+
+// NewAPICallSink constructs the APICallSink for target, which is either a file URL like file:///var/log/audit.json,
+// or a webhook URL like https://audit.example.com/records.
+func NewAPICallSink(target string, logger logr.Logger) (APICallSink, error) {
+	targetURL, err := url.Parse(target)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid audit sink: %v", target)
+	}
+	switch targetURL.Scheme {
+	case "file":
+		if targetURL.Path == "" {
+			return nil, errors.Errorf("invalid audit sink: %v, file path must be specified", target)
+		}
+		return NewFileAPICallSink(targetURL.Path, logger)
+	case "http", "https":
+		return NewWebhookAPICallSink(target, logger), nil
+	default:
+		return nil, errors.Errorf("invalid audit sink: %v, scheme must be one of [file, http, https]", target)
+	}
+}
+
+// NewFileAPICallSink constructs new fileAPICallSink that appends records to file at path.
+func NewFileAPICallSink(path string, logger logr.Logger) (*fileAPICallSink, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open audit sink file: %v", path)
+	}
+	return &fileAPICallSink{
+		file:   file,
+		logger: logger,
+	}, nil
+}
+
+var _ APICallSink = &fileAPICallSink{}
+
+// fileAPICallSink writes records into a file as JSON lines.
+type fileAPICallSink struct {
+	file      *os.File
+	fileMutex sync.Mutex
+	logger    logr.Logger
+}
+
+func (s *fileAPICallSink) Write(record APICallRecord) {
+	payload, err := json.Marshal(record)
+	if err != nil {
+		s.logger.Error(err, "failed to encode audit record", "eventName", record.EventName)
+		return
+	}
+	s.fileMutex.Lock()
+	defer s.fileMutex.Unlock()
+	if _, err := s.file.Write(append(payload, '\n')); err != nil {
+		s.logger.Error(err, "failed to write audit record", "eventName", record.EventName)
+	}
+}
+
+// NewWebhookAPICallSink constructs new webhookAPICallSink that posts records to url.
+func NewWebhookAPICallSink(url string, logger logr.Logger) *webhookAPICallSink {
+	s := &webhookAPICallSink{
+		url:        url,
+		httpClient: &http.Client{Timeout: defaultWebhookSinkTimeout},
+		records:    make(chan APICallRecord, defaultWebhookSinkBufferSize),
+		logger:     logger,
+	}
+	go s.deliverRecords()
+	return s
+}
+
+var _ APICallSink = &webhookAPICallSink{}
+
+// webhookAPICallSink posts each record as JSON to a webhook.
+// records are delivered asynchronously in order, so that AWS API calls are not blocked by the webhook.
+type webhookAPICallSink struct {
+	url        string
+	httpClient *http.Client
+	records    chan APICallRecord
+	logger     logr.Logger
+}
+
+func (s *webhookAPICallSink) Write(record APICallRecord) {
+	select {
+	case s.records <- record:
+	default:
+		s.logger.Info("dropped audit record since webhook is falling behind", "eventName", record.EventName)
+	}
+}
+
+func (s *webhookAPICallSink) deliverRecords() {
+	for record := range s.records {
+		if err := s.deliverRecord(record); err != nil {
+			s.logger.Error(err, "failed to deliver audit record", "eventName", record.EventName)
+		}
+	}
+}
+
+func (s *webhookAPICallSink) deliverRecord(record APICallRecord) error {
+	payload, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, s.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("webhook responded with status code %v", resp.StatusCode)
+	}
+	return nil
+}
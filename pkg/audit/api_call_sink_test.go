@@ -0,0 +1,93 @@
+package audit
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_NewAPICallSink(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  string
+		wantErr string
+	}{
+		{
+			name:   "webhook sink",
+			target: "https://audit.example.com/records",
+		},
+		{
+			name:    "file sink without path",
+			target:  "file://",
+			wantErr: "invalid audit sink: file://, file path must be specified",
+		},
+		{
+			name:    "unknown scheme",
+			target:  "s3://my-bucket/audit",
+			wantErr: "invalid audit sink: s3://my-bucket/audit, scheme must be one of [file, http, https]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAPICallSink(tt.target, &log.NullLogger{})
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_fileAPICallSink_Write(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.json")
+	sink, err := NewAPICallSink("file://"+path, &log.NullLogger{})
+	assert.NoError(t, err)
+
+	sink.Write(APICallRecord{EventName: "CreateLoadBalancer"})
+	sink.Write(APICallRecord{EventName: "DeleteLoadBalancer"})
+
+	content, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	var eventNames []string
+	for _, line := range lines {
+		var record APICallRecord
+		assert.NoError(t, json.Unmarshal([]byte(line), &record))
+		eventNames = append(eventNames, record.EventName)
+	}
+	assert.Equal(t, []string{"CreateLoadBalancer", "DeleteLoadBalancer"}, eventNames)
+}
+
+func Test_webhookAPICallSink_Write(t *testing.T) {
+	records := make(chan APICallRecord, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var record APICallRecord
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&record))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		records <- record
+	}))
+	defer server.Close()
+
+	sink := NewWebhookAPICallSink(server.URL, &log.NullLogger{})
+	sink.Write(APICallRecord{EventName: "CreateLoadBalancer"})
+	sink.Write(APICallRecord{EventName: "DeleteLoadBalancer"})
+
+	var eventNames []string
+	for i := 0; i < 2; i++ {
+		select {
+		case record := <-records:
+			eventNames = append(eventNames, record.EventName)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for audit records")
+		}
+	}
+	assert.Equal(t, []string{"CreateLoadBalancer", "DeleteLoadBalancer"}, eventNames)
+}
@@ -0,0 +1,29 @@
+package audit
+
+import (
+	"github.com/spf13/pflag"
+)
+
+const (
+	flagEnableMutationEvents      = "enable-mutation-events"
+	flagEnableMutationAuditLog    = "enable-mutation-audit-log"
+	defaultEnableMutationEvents   = true
+	defaultEnableMutationAuditLog = false
+)
+
+// Config contains the configurations for auditing mutations of AWS resources
+type Config struct {
+	// Emit Kubernetes events for each mutation of listener rules, security group rules and target group attributes.
+	EnableEvents bool
+
+	// Emit structured logs for each mutation of listener rules, security group rules and target group attributes.
+	EnableAuditLog bool
+}
+
+// BindFlags binds the command line flags to the fields in the config object
+func (cfg *Config) BindFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&cfg.EnableEvents, flagEnableMutationEvents, defaultEnableMutationEvents,
+		"Emit Kubernetes events with a diff for each change made to listener rules, security group rules and target group attributes")
+	fs.BoolVar(&cfg.EnableAuditLog, flagEnableMutationAuditLog, defaultEnableMutationAuditLog,
+		"Emit structured audit logs with a diff for each change made to listener rules, security group rules and target group attributes")
+}
@@ -0,0 +1,67 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"reflect"
+)
+
+type mutationRecorderContextKey struct{}
+
+// mutationRecorderContext is the recorder and the Kubernetes objects carried by context.
+type mutationRecorderContext struct {
+	recorder MutationRecorder
+	objs     []runtime.Object
+}
+
+// ContextWithMutationRecorder returns a context that records AWS resource mutations made with it against objs.
+func ContextWithMutationRecorder(ctx context.Context, recorder MutationRecorder, objs ...runtime.Object) context.Context {
+	return context.WithValue(ctx, mutationRecorderContextKey{}, mutationRecorderContext{
+		recorder: recorder,
+		objs:     objs,
+	})
+}
+
+// RecordMutation records the mutation with the recorder carried by ctx, it's a no-op if there is none.
+func RecordMutation(ctx context.Context, mutation Mutation) {
+	recorderCtx, ok := ctx.Value(mutationRecorderContextKey{}).(mutationRecorderContext)
+	if !ok || recorderCtx.recorder == nil {
+		return
+	}
+	recorderCtx.recorder.Record(recorderCtx.objs, mutation)
+}
+
+// RecordDivergence records the divergence with the recorder carried by ctx, it's a no-op if there is none.
+func RecordDivergence(ctx context.Context, divergence Divergence) {
+	recorderCtx, ok := ctx.Value(mutationRecorderContextKey{}).(mutationRecorderContext)
+	if !ok || recorderCtx.recorder == nil {
+		return
+	}
+	recorderCtx.recorder.RecordDivergence(recorderCtx.objs, divergence)
+}
+
+// reconcileTriggerFromContext returns the Kubernetes objects carried by ctx in the form of Kind/namespace/name.
+func reconcileTriggerFromContext(ctx context.Context) []string {
+	if ctx == nil {
+		return nil
+	}
+	recorderCtx, ok := ctx.Value(mutationRecorderContextKey{}).(mutationRecorderContext)
+	if !ok {
+		return nil
+	}
+	triggers := make([]string, 0, len(recorderCtx.objs))
+	for _, obj := range recorderCtx.objs {
+		metaObj, err := meta.Accessor(obj)
+		if err != nil {
+			continue
+		}
+		kind := obj.GetObjectKind().GroupVersionKind().Kind
+		if kind == "" {
+			kind = reflect.Indirect(reflect.ValueOf(obj)).Type().Name()
+		}
+		triggers = append(triggers, fmt.Sprintf("%v/%v/%v", kind, metaObj.GetNamespace(), metaObj.GetName()))
+	}
+	return triggers
+}
@@ -0,0 +1,111 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// maxDiffLength is the max length of diff, longer diffs are truncated to keep events within API limits.
+	maxDiffLength = 512
+	noneValue     = "<none>"
+)
+
+// ComputeDiff computes a compact diff between the JSON representations of before and after.
+// Top level fields are compared individually, and each changed field is reported as `field: before -> after`.
+// A nil before or after denotes a created or deleted resource, and null values are omitted.
+func ComputeDiff(before interface{}, after interface{}) string {
+	beforeFields := toJSONFields(before)
+	afterFields := toJSONFields(after)
+	fieldSet := make(map[string]struct{}, len(beforeFields)+len(afterFields))
+	for field := range beforeFields {
+		fieldSet[field] = struct{}{}
+	}
+	for field := range afterFields {
+		fieldSet[field] = struct{}{}
+	}
+	fields := make([]string, 0, len(fieldSet))
+	for field := range fieldSet {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var changes []string
+	for _, field := range fields {
+		beforeValue, beforeExists := beforeFields[field]
+		afterValue, afterExists := afterFields[field]
+		if beforeExists && afterExists && beforeValue == afterValue {
+			continue
+		}
+		if !beforeExists {
+			beforeValue = noneValue
+		}
+		if !afterExists {
+			afterValue = noneValue
+		}
+		changes = append(changes, fmt.Sprintf("%v: %v -> %v", field, beforeValue, afterValue))
+	}
+	diff := strings.Join(changes, "; ")
+	if len(diff) > maxDiffLength {
+		diff = diff[:maxDiffLength-3] + "..."
+	}
+	return diff
+}
+
+// toJSONFields returns the compact JSON representation of each top level field of obj.
+func toJSONFields(obj interface{}) map[string]string {
+	if obj == nil {
+		return nil
+	}
+	payload, err := json.Marshal(obj)
+	if err != nil {
+		return nil
+	}
+	var rawFields map[string]interface{}
+	if err := json.Unmarshal(payload, &rawFields); err != nil {
+		return nil
+	}
+	fields := make(map[string]string, len(rawFields))
+	for field, rawValue := range rawFields {
+		value := omitNullValues(rawValue)
+		if value == nil {
+			continue
+		}
+		valuePayload, err := json.Marshal(value)
+		if err != nil {
+			continue
+		}
+		fields[field] = string(valuePayload)
+	}
+	return fields
+}
+
+// omitNullValues removes null values from decoded JSON, which are common with AWS SDK structs.
+func omitNullValues(value interface{}) interface{} {
+	switch typedValue := value.(type) {
+	case map[string]interface{}:
+		for key, elem := range typedValue {
+			if elem := omitNullValues(elem); elem != nil {
+				typedValue[key] = elem
+			} else {
+				delete(typedValue, key)
+			}
+		}
+		if len(typedValue) == 0 {
+			return nil
+		}
+		return typedValue
+	case []interface{}:
+		elems := make([]interface{}, 0, len(typedValue))
+		for _, elem := range typedValue {
+			if elem := omitNullValues(elem); elem != nil {
+				elems = append(elems, elem)
+			}
+		}
+		return elems
+	default:
+		return value
+	}
+}
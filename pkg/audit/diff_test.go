@@ -0,0 +1,71 @@
+package audit
+
+import (
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func Test_ComputeDiff(t *testing.T) {
+	tests := []struct {
+		name   string
+		before interface{}
+		after  interface{}
+		want   string
+	}{
+		{
+			name:   "modified fields",
+			before: map[string]string{"deregistration_delay.timeout_seconds": "300", "slow_start.duration_seconds": "0"},
+			after:  map[string]string{"deregistration_delay.timeout_seconds": "60", "slow_start.duration_seconds": "0"},
+			want:   `deregistration_delay.timeout_seconds: "300" -> "60"`,
+		},
+		{
+			name:   "created resource",
+			before: nil,
+			after: map[string]interface{}{
+				"priority": awssdk.Int64(1),
+				"actions": []*elbv2sdk.Action{
+					{
+						Type:           awssdk.String("forward"),
+						TargetGroupArn: awssdk.String("tg-arn"),
+					},
+				},
+			},
+			want: `actions: <none> -> [{"TargetGroupArn":"tg-arn","Type":"forward"}]; priority: <none> -> 1`,
+		},
+		{
+			name: "deleted resource",
+			before: map[string]interface{}{
+				"ingress": []string{"IpProtocol: tcp, FromPort: 80, ToPort: 80, IpRange: 10.0.0.0/16"},
+			},
+			after: nil,
+			want:  `ingress: ["IpProtocol: tcp, FromPort: 80, ToPort: 80, IpRange: 10.0.0.0/16"] -> <none>`,
+		},
+		{
+			name:   "null fields are omitted",
+			before: map[string]interface{}{"priority": (*int64)(nil), "conditions": []string{"a"}},
+			after:  map[string]interface{}{"priority": (*int64)(nil), "conditions": []string{"b"}},
+			want:   `conditions: ["a"] -> ["b"]`,
+		},
+		{
+			name:   "unchanged",
+			before: map[string]string{"key": "value"},
+			after:  map[string]string{"key": "value"},
+			want:   "",
+		},
+		{
+			name:   "long diff is truncated",
+			before: nil,
+			after:  map[string]string{"key": strings.Repeat("a", 1024)},
+			want:   `key: <none> -> "` + strings.Repeat("a", maxDiffLength-len(`key: <none> -> "`)-3) + "...",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ComputeDiff(tt.before, tt.after)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
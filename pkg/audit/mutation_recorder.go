@@ -0,0 +1,126 @@
+package audit
+
+import (
+	"fmt"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+)
+
+const (
+	OperationCreate = "Create"
+	OperationModify = "Modify"
+	OperationDelete = "Delete"
+)
+
+const (
+	ResourceKindListenerRule         = "ListenerRule"
+	ResourceKindTargetGroupAttribute = "TargetGroupAttributes"
+	ResourceKindListenerAttribute    = "ListenerAttributes"
+	ResourceKindSecurityGroupRule    = "SecurityGroupRule"
+)
+
+// Mutation describes a change the controller made to an AWS resource.
+type Mutation struct {
+	// Kind of the mutated AWS resource.
+	ResourceKind string
+	// Identifier of the mutated AWS resource, e.g. ARN or security group ID.
+	ResourceID string
+	// The operation performed, one of Create, Modify or Delete.
+	Operation string
+	// Compact diff of the resource settings before and after the mutation.
+	Diff string
+}
+
+// Divergence describes a difference between an AWS resource and its desired state that the controller retains instead of reverting.
+type Divergence struct {
+	// Kind of the diverged AWS resource.
+	ResourceKind string
+	// Identifier of the diverged AWS resource, e.g. ARN or security group ID.
+	ResourceID string
+	// Compact diff of the resource settings retained beyond the desired state.
+	Diff string
+}
+
+// MutationRecorder records mutations of AWS resources made on behalf of Kubernetes objects.
+type MutationRecorder interface {
+	// Record records the mutation against objs.
+	Record(objs []runtime.Object, mutation Mutation)
+
+	// RecordDivergence records the retained divergence against objs.
+	RecordDivergence(objs []runtime.Object, divergence Divergence)
+}
+
+// NewDefaultMutationRecorder constructs new defaultMutationRecorder.
+func NewDefaultMutationRecorder(eventRecorder record.EventRecorder, cfg Config, logger logr.Logger) *defaultMutationRecorder {
+	return &defaultMutationRecorder{
+		eventRecorder: eventRecorder,
+		cfg:           cfg,
+		logger:        logger,
+	}
+}
+
+var _ MutationRecorder = &defaultMutationRecorder{}
+
+// default implementation for MutationRecorder, which emits Kubernetes events and optionally audit logs.
+type defaultMutationRecorder struct {
+	eventRecorder record.EventRecorder
+	cfg           Config
+	logger        logr.Logger
+}
+
+func (r *defaultMutationRecorder) Record(objs []runtime.Object, mutation Mutation) {
+	if r.cfg.EnableEvents {
+		message := fmt.Sprintf("%v %v %v", mutation.Operation, mutation.ResourceKind, mutation.ResourceID)
+		if len(mutation.Diff) != 0 {
+			message = fmt.Sprintf("%v: %v", message, mutation.Diff)
+		}
+		for _, obj := range objs {
+			r.eventRecorder.Event(obj, corev1.EventTypeNormal, k8s.EventReasonAWSResourceMutated, message)
+		}
+	}
+	if r.cfg.EnableAuditLog {
+		r.logger.Info("mutated AWS resource",
+			"operation", mutation.Operation,
+			"resourceKind", mutation.ResourceKind,
+			"resourceID", mutation.ResourceID,
+			"diff", mutation.Diff,
+			"objects", objectKeys(objs))
+	}
+}
+
+func (r *defaultMutationRecorder) RecordDivergence(objs []runtime.Object, divergence Divergence) {
+	if r.cfg.EnableEvents {
+		message := fmt.Sprintf("Retained divergence of %v %v", divergence.ResourceKind, divergence.ResourceID)
+		if len(divergence.Diff) != 0 {
+			message = fmt.Sprintf("%v: %v", message, divergence.Diff)
+		}
+		for _, obj := range objs {
+			r.eventRecorder.Event(obj, corev1.EventTypeWarning, k8s.EventReasonAWSResourceDiverged, message)
+		}
+	}
+	if r.cfg.EnableAuditLog {
+		r.logger.Info("retained divergence of AWS resource",
+			"resourceKind", divergence.ResourceKind,
+			"resourceID", divergence.ResourceID,
+			"diff", divergence.Diff,
+			"objects", objectKeys(objs))
+	}
+}
+
+// objectKeys returns the namespaced names of objs.
+func objectKeys(objs []runtime.Object) []string {
+	keys := make([]string, 0, len(objs))
+	for _, obj := range objs {
+		metaObj, err := meta.Accessor(obj)
+		if err != nil {
+			continue
+		}
+		keys = append(keys, types.NamespacedName{Namespace: metaObj.GetNamespace(), Name: metaObj.GetName()}.String())
+	}
+	return keys
+}
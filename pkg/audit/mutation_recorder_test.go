@@ -0,0 +1,141 @@
+package audit
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_RecordMutation(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "svc-1",
+		},
+	}
+	mutation := Mutation{
+		ResourceKind: ResourceKindTargetGroupAttribute,
+		ResourceID:   "tg-arn",
+		Operation:    OperationModify,
+		Diff:         `deregistration_delay.timeout_seconds: "300" -> "60"`,
+	}
+	tests := []struct {
+		name         string
+		cfg          Config
+		withRecorder bool
+		mutation     Mutation
+		wantEvents   []string
+	}{
+		{
+			name:         "events enabled",
+			cfg:          Config{EnableEvents: true},
+			withRecorder: true,
+			mutation:     mutation,
+			wantEvents: []string{
+				`Normal AWSResourceMutated Modify TargetGroupAttributes tg-arn: deregistration_delay.timeout_seconds: "300" -> "60"`,
+			},
+		},
+		{
+			name:         "events enabled without diff",
+			cfg:          Config{EnableEvents: true},
+			withRecorder: true,
+			mutation: Mutation{
+				ResourceKind: ResourceKindListenerRule,
+				ResourceID:   "rule-arn",
+				Operation:    OperationDelete,
+			},
+			wantEvents: []string{
+				`Normal AWSResourceMutated Delete ListenerRule rule-arn`,
+			},
+		},
+		{
+			name:         "events disabled",
+			cfg:          Config{EnableEvents: false, EnableAuditLog: true},
+			withRecorder: true,
+			mutation:     mutation,
+		},
+		{
+			name:         "context without recorder",
+			cfg:          Config{EnableEvents: true},
+			withRecorder: false,
+			mutation:     mutation,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventRecorder := record.NewFakeRecorder(10)
+			ctx := context.Background()
+			if tt.withRecorder {
+				recorder := NewDefaultMutationRecorder(eventRecorder, tt.cfg, &log.NullLogger{})
+				ctx = ContextWithMutationRecorder(ctx, recorder, svc)
+			}
+			RecordMutation(ctx, tt.mutation)
+			close(eventRecorder.Events)
+			var gotEvents []string
+			for event := range eventRecorder.Events {
+				gotEvents = append(gotEvents, event)
+			}
+			assert.Equal(t, tt.wantEvents, gotEvents)
+		})
+	}
+}
+
+func Test_RecordDivergence(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "svc-1",
+		},
+	}
+	divergence := Divergence{
+		ResourceKind: ResourceKindSecurityGroupRule,
+		ResourceID:   "sg-1",
+		Diff:         `ingress: [] -> ["IpProtocol: tcp, FromPort: 22, ToPort: 22, IpRange: 0.0.0.0/0"]`,
+	}
+	tests := []struct {
+		name         string
+		cfg          Config
+		withRecorder bool
+		wantEvents   []string
+	}{
+		{
+			name:         "events enabled",
+			cfg:          Config{EnableEvents: true},
+			withRecorder: true,
+			wantEvents: []string{
+				`Warning AWSResourceDiverged Retained divergence of SecurityGroupRule sg-1: ingress: [] -> ["IpProtocol: tcp, FromPort: 22, ToPort: 22, IpRange: 0.0.0.0/0"]`,
+			},
+		},
+		{
+			name:         "events disabled",
+			cfg:          Config{EnableEvents: false, EnableAuditLog: true},
+			withRecorder: true,
+		},
+		{
+			name:         "context without recorder",
+			cfg:          Config{EnableEvents: true},
+			withRecorder: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventRecorder := record.NewFakeRecorder(10)
+			ctx := context.Background()
+			if tt.withRecorder {
+				recorder := NewDefaultMutationRecorder(eventRecorder, tt.cfg, &log.NullLogger{})
+				ctx = ContextWithMutationRecorder(ctx, recorder, svc)
+			}
+			RecordDivergence(ctx, divergence)
+			close(eventRecorder.Events)
+			var gotEvents []string
+			for event := range eventRecorder.Events {
+				gotEvents = append(gotEvents, event)
+			}
+			assert.Equal(t, tt.wantEvents, gotEvents)
+		})
+	}
+}
@@ -2,13 +2,34 @@ package aws
 
 import (
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
+	"os"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/audit"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/faultinjection"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/metrics"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/throttle"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/tracing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sort"
+	"sync"
+)
+
+const (
+	// the session name of IAM role assumed by the controller.
+	assumeRoleSessionName = "aws-load-balancer-controller"
+	// the environment variable that disables EC2Metadata for AWS SDK.
+	envEC2MetadataDisabled = "AWS_EC2_METADATA_DISABLED"
+	// IMDSv2 responses are dropped before reaching pods without host network if the hop limit of instance metadata options is 1.
+	imdsHopLimitHint = "pods without host network require an instance metadata hop limit of at least 2 on IMDSv2-only instances"
 )
 
 type Cloud interface {
@@ -30,44 +51,106 @@ type Cloud interface {
 	// Shield provides API to AWS Shield
 	Shield() services.Shield
 
+	// ARCZonalShift provides API to AWS Route 53 ARC zonal shift
+	ARCZonalShift() services.ARCZonalShift
+
+	// Route53 provides API to AWS Route 53
+	Route53() services.Route53
+
 	// RGT provides API to AWS RGT
 	RGT() services.RGT
 
+	// ServiceQuotas provides API to AWS Service Quotas
+	ServiceQuotas() services.ServiceQuotas
+
+	// S3 provides API to AWS S3
+	S3() services.S3
+
+	// IAM provides API to AWS IAM
+	IAM() services.IAM
+
+	// STS provides API to AWS STS
+	STS() services.STS
+
 	// Region for the kubernetes cluster
 	Region() string
 
 	// VPC ID for the the kubernetes cluster
 	VpcID() string
+
+	// AssumeRole returns a Cloud that operates with credentials of the specified IAM role.
+	// The IAM role is always assumed with the controller's own credentials.
+	AssumeRole(roleARN string) Cloud
+
+	// ForRegion returns a Cloud that operates in the specified region and VPC with the same credentials.
+	// It returns the Cloud itself if region and vpcID are its own.
+	ForRegion(region string, vpcID string) Cloud
 }
 
 // NewCloud constructs new Cloud implementation.
 func NewCloud(cfg CloudConfig, metricsRegisterer prometheus.Registerer) (Cloud, error) {
-	metadataSess := session.Must(session.NewSession(aws.NewConfig()))
-	metadata := services.NewEC2Metadata(metadataSess)
-	if len(cfg.Region) == 0 {
-		region, err := metadata.Region()
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to introspect region from EC2Metadata, specify --aws-region instead if EC2Metadata is unavailable")
+	if cfg.IMDSDisabled {
+		// without other credential sources, AWS SDK falls back to the instance profile credentials from EC2Metadata,
+		// which only fails after timeouts where EC2Metadata is blocked for pods.
+		if err := os.Setenv(envEC2MetadataDisabled, "true"); err != nil {
+			return nil, errors.Wrap(err, "failed to disable EC2Metadata")
 		}
-		cfg.Region = region
 	}
+	if len(cfg.Region) == 0 || len(cfg.VpcID) == 0 {
+		metadataSess := session.Must(session.NewSession(aws.NewConfig()))
+		metadata := services.NewEC2Metadata(metadataSess)
+		if len(cfg.Region) == 0 {
+			region, err := metadata.Region()
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to introspect region from EC2Metadata, specify --aws-region instead if EC2Metadata is unavailable, %v", imdsHopLimitHint)
+			}
+			cfg.Region = region
+		}
 
-	if len(cfg.VpcID) == 0 {
-		vpcId, err := metadata.VpcID()
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to introspect vpcID from EC2Metadata, specify --aws-vpc-id instead if EC2Metadata is unavailable")
+		if len(cfg.VpcID) == 0 {
+			vpcId, err := metadata.VpcID()
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to introspect vpcID from EC2Metadata, specify --aws-vpc-id instead if EC2Metadata is unavailable, %v", imdsHopLimitHint)
+			}
+			cfg.VpcID = vpcId
 		}
-		cfg.VpcID = vpcId
 	}
 
-	awsCFG := aws.NewConfig().WithRegion(cfg.Region).WithSTSRegionalEndpoint(endpoints.RegionalSTSEndpoint).WithMaxRetries(cfg.MaxRetries)
+	stsRegionalEndpoint := endpoints.RegionalSTSEndpoint
+	if cfg.STSRegionalEndpoints == STSRegionalEndpointsLegacy {
+		stsRegionalEndpoint = endpoints.LegacySTSEndpoint
+	}
+	awsCFG := aws.NewConfig().WithRegion(cfg.Region).WithSTSRegionalEndpoint(stsRegionalEndpoint).WithMaxRetries(cfg.MaxRetries)
+	if len(cfg.Endpoints) != 0 {
+		awsCFG = awsCFG.WithEndpointResolver(buildEndpointResolver(cfg.Endpoints))
+	}
+	if cfg.UseFIPSEndpoint {
+		awsCFG.UseFIPSEndpoint = endpoints.FIPSEndpointStateEnabled
+	}
+	if cfg.UseDualStackEndpoint {
+		awsCFG.UseDualStackEndpoint = endpoints.DualStackEndpointStateEnabled
+	}
+	awsCFG = request.WithRetryer(awsCFG, client.DefaultRetryer{
+		NumMaxRetries:    cfg.MaxRetries,
+		MinThrottleDelay: cfg.ThrottleMinRetryDelay,
+		MaxThrottleDelay: cfg.ThrottleMaxRetryDelay,
+	})
 	sess := session.Must(session.NewSession(awsCFG))
 	injectUserAgent(&sess.Handlers)
+	injectMutationFreezer(&sess.Handlers)
 
 	if cfg.ThrottleConfig != nil {
 		throttler := throttle.NewThrottler(cfg.ThrottleConfig)
+		if cfg.AdaptiveThrottle {
+			throttler = throttler.WithAdaptiveThrottle()
+		}
 		throttler.InjectHandlers(&sess.Handlers)
 	}
+	if !cfg.FaultInjection.IsEmpty() || cfg.FaultInjectionSecurityGroupVisibleDelay > 0 {
+		ctrl.Log.WithName("aws-fault-injection").Info("injecting faults into AWS API calls, it's only meant for test environments",
+			"faults", cfg.FaultInjection.String(), "securityGroupVisibleDelay", cfg.FaultInjectionSecurityGroupVisibleDelay)
+		faultinjection.NewInjector(&cfg.FaultInjection, cfg.FaultInjectionSecurityGroupVisibleDelay).InjectHandlers(&sess.Handlers)
+	}
 	if metricsRegisterer != nil {
 		metricsCollector, err := metrics.NewCollector(metricsRegisterer)
 		if err != nil {
@@ -75,32 +158,99 @@ func NewCloud(cfg CloudConfig, metricsRegisterer prometheus.Registerer) (Cloud,
 		}
 		metricsCollector.InjectHandlers(&sess.Handlers)
 	}
+	if len(cfg.APIAuditSink) != 0 {
+		auditSink, err := audit.NewAPICallSink(cfg.APIAuditSink, ctrl.Log.WithName("aws-api-audit"))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to initialize AWS API call audit")
+		}
+		audit.NewAPICallRecorder(auditSink).InjectHandlers(&sess.Handlers)
+	}
+	tracing.NewSDKTracer().InjectHandlers(&sess.Handlers)
+	if len(cfg.AssumeRoleARN) != 0 {
+		// the copied session shares handlers(userAgent, mutationFreezer, throttler, faultInjector, metrics, audit, tracing) with the session of controller's own credentials.
+		sess = sess.Copy(&aws.Config{Credentials: buildAssumeRoleCredentials(sess, cfg)})
+	}
+
+	return newDefaultCloud(cfg, sess), nil
+}
 
+// buildAssumeRoleCredentials builds the credentials of IAM role assumed by the controller, with external ID and session tags.
+// session tags are transitive, so that they're kept for IAM roles assumed subsequently with these credentials.
+func buildAssumeRoleCredentials(sess *session.Session, cfg CloudConfig) *credentials.Credentials {
+	return stscreds.NewCredentials(sess, cfg.AssumeRoleARN, func(p *stscreds.AssumeRoleProvider) {
+		p.RoleSessionName = assumeRoleSessionName
+		if len(cfg.AssumeRoleExternalID) != 0 {
+			p.ExternalID = aws.String(cfg.AssumeRoleExternalID)
+		}
+		tagKeys := make([]string, 0, len(cfg.AssumeRoleSessionTags))
+		for tagKey := range cfg.AssumeRoleSessionTags {
+			tagKeys = append(tagKeys, tagKey)
+		}
+		sort.Strings(tagKeys)
+		for _, tagKey := range tagKeys {
+			p.Tags = append(p.Tags, &sts.Tag{
+				Key:   aws.String(tagKey),
+				Value: aws.String(cfg.AssumeRoleSessionTags[tagKey]),
+			})
+			p.TransitiveTagKeys = append(p.TransitiveTagKeys, aws.String(tagKey))
+		}
+	})
+}
+
+func newDefaultCloud(cfg CloudConfig, sess *session.Session) *defaultCloud {
 	return &defaultCloud{
-		cfg:         cfg,
-		ec2:         services.NewEC2(sess),
-		elbv2:       services.NewELBV2(sess),
-		acm:         services.NewACM(sess),
-		wafv2:       services.NewWAFv2(sess),
-		wafRegional: services.NewWAFRegional(sess, cfg.Region),
-		shield:      services.NewShield(sess),
-		rgt:         services.NewRGT(sess),
-	}, nil
+		cfg:               cfg,
+		sess:              sess,
+		ec2:               services.NewEC2(sess),
+		elbv2:             services.NewELBV2(sess),
+		acm:               services.NewACM(sess),
+		wafv2:             services.NewWAFv2(sess),
+		wafRegional:       services.NewWAFRegional(sess, cfg.Region),
+		shield:            services.NewShield(sess),
+		arcZonalShift:     services.NewARCZonalShift(sess),
+		route53:           services.NewRoute53(sess),
+		rgt:               services.NewRGT(sess),
+		serviceQuotas:     services.NewServiceQuotas(sess),
+		s3:                services.NewS3(sess),
+		iam:               services.NewIAM(sess),
+		sts:               services.NewSTS(sess),
+		assumedRoleClouds: make(map[string]*defaultCloud),
+		regionalClouds:    make(map[string]*defaultCloud),
+	}
 }
 
 var _ Cloud = &defaultCloud{}
 
 type defaultCloud struct {
-	cfg CloudConfig
+	cfg  CloudConfig
+	sess *session.Session
 
 	ec2   services.EC2
 	elbv2 services.ELBV2
 
-	acm         services.ACM
-	wafv2       services.WAFv2
-	wafRegional services.WAFRegional
-	shield      services.Shield
-	rgt         services.RGT
+	acm           services.ACM
+	wafv2         services.WAFv2
+	wafRegional   services.WAFRegional
+	shield        services.Shield
+	arcZonalShift services.ARCZonalShift
+	route53       services.Route53
+	rgt           services.RGT
+	serviceQuotas services.ServiceQuotas
+	s3            services.S3
+	iam           services.IAM
+	sts           services.STS
+
+	// parent is the Cloud with controller's own credentials, it's nil for the root Cloud.
+	parent *defaultCloud
+	// assumedRoleClouds caches the Clouds for assumed IAM roles by role ARN.
+	assumedRoleClouds      map[string]*defaultCloud
+	assumedRoleCloudsMutex sync.Mutex
+
+	// regionalParent is the Cloud in controller's own region with the same credentials, it's nil for Clouds in controller's own region.
+	regionalParent *defaultCloud
+	// regionalClouds caches the Clouds for other regions by region and VPC ID.
+	regionalClouds      map[string]*defaultCloud
+	regionalCloudsMutex sync.Mutex
 }
 
 func (c *defaultCloud) EC2() services.EC2 {
@@ -127,10 +277,34 @@ func (c *defaultCloud) Shield() services.Shield {
 	return c.shield
 }
 
+func (c *defaultCloud) ARCZonalShift() services.ARCZonalShift {
+	return c.arcZonalShift
+}
+
+func (c *defaultCloud) Route53() services.Route53 {
+	return c.route53
+}
+
 func (c *defaultCloud) RGT() services.RGT {
 	return c.rgt
 }
 
+func (c *defaultCloud) ServiceQuotas() services.ServiceQuotas {
+	return c.serviceQuotas
+}
+
+func (c *defaultCloud) S3() services.S3 {
+	return c.s3
+}
+
+func (c *defaultCloud) IAM() services.IAM {
+	return c.iam
+}
+
+func (c *defaultCloud) STS() services.STS {
+	return c.sts
+}
+
 func (c *defaultCloud) Region() string {
 	return c.cfg.Region
 }
@@ -138,3 +312,51 @@ func (c *defaultCloud) Region() string {
 func (c *defaultCloud) VpcID() string {
 	return c.cfg.VpcID
 }
+
+func (c *defaultCloud) AssumeRole(roleARN string) Cloud {
+	if c.regionalParent != nil {
+		return c.regionalParent.AssumeRole(roleARN).ForRegion(c.cfg.Region, c.cfg.VpcID)
+	}
+	if c.parent != nil {
+		return c.parent.AssumeRole(roleARN)
+	}
+
+	c.assumedRoleCloudsMutex.Lock()
+	defer c.assumedRoleCloudsMutex.Unlock()
+	if assumedRoleCloud, ok := c.assumedRoleClouds[roleARN]; ok {
+		return assumedRoleCloud
+	}
+	// the copied session shares handlers(userAgent, mutationFreezer, throttler, metrics, audit) with the root session.
+	creds := stscreds.NewCredentials(c.sess, roleARN)
+	assumedRoleSess := c.sess.Copy(&aws.Config{Credentials: creds})
+	assumedRoleCloud := newDefaultCloud(c.cfg, assumedRoleSess)
+	assumedRoleCloud.parent = c
+	c.assumedRoleClouds[roleARN] = assumedRoleCloud
+	return assumedRoleCloud
+}
+
+func (c *defaultCloud) ForRegion(region string, vpcID string) Cloud {
+	if c.regionalParent != nil {
+		return c.regionalParent.ForRegion(region, vpcID)
+	}
+	if region == c.cfg.Region && vpcID == c.cfg.VpcID {
+		return c
+	}
+
+	c.regionalCloudsMutex.Lock()
+	defer c.regionalCloudsMutex.Unlock()
+	regionalCloudKey := region + "/" + vpcID
+	if regionalCloud, ok := c.regionalClouds[regionalCloudKey]; ok {
+		return regionalCloud
+	}
+	regionalCFG := c.cfg
+	regionalCFG.Region = region
+	regionalCFG.VpcID = vpcID
+	// the copied session shares credentials and handlers(userAgent, mutationFreezer, throttler, metrics, audit) with the session of controller's own region.
+	regionalSess := c.sess.Copy(&aws.Config{Region: aws.String(region)})
+	regionalCloud := newDefaultCloud(regionalCFG, regionalSess)
+	regionalCloud.parent = c.parent
+	regionalCloud.regionalParent = c
+	c.regionalClouds[regionalCloudKey] = regionalCloud
+	return regionalCloud
+}
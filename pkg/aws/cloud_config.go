@@ -1,8 +1,12 @@
 package aws
 
 import (
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/pkg/errors"
 	"github.com/spf13/pflag"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/faultinjection"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/throttle"
+	"time"
 )
 
 const (
@@ -13,6 +17,39 @@ const (
 	defaultVpcID         = ""
 	defaultRegion        = ""
 	defaultAPIMaxRetries = 10
+
+	flagAWSAPIAdaptiveThrottle      = "aws-api-adaptive-throttle"
+	flagAWSAPIThrottleMinRetryDelay = "aws-api-throttle-min-retry-delay"
+	flagAWSAPIThrottleMaxRetryDelay = "aws-api-throttle-max-retry-delay"
+	defaultAPIAdaptiveThrottle      = false
+	defaultAPIThrottleMinRetryDelay = client.DefaultRetryerMinThrottleDelay
+	defaultAPIThrottleMaxRetryDelay = client.DefaultRetryerMaxThrottleDelay
+
+	flagAWSAPIEndpoints            = "aws-api-endpoints"
+	flagAWSUseFIPSEndpoint         = "aws-use-fips-endpoint"
+	flagAWSUseDualStackEndpoint    = "aws-use-dualstack-endpoint"
+	defaultAWSUseFIPSEndpoint      = false
+	defaultAWSUseDualStackEndpoint = false
+
+	flagAWSAssumeRoleARN         = "aws-assume-role-arn"
+	flagAWSAssumeRoleExternalID  = "aws-assume-role-external-id"
+	flagAWSAssumeRoleSessionTags = "aws-assume-role-session-tags"
+	// the maximum number of session tags per AssumeRole request.
+	maxAssumeRoleSessionTags = 50
+
+	flagAWSAPIAuditSink = "aws-api-audit-sink"
+
+	flagAWSSTSRegionalEndpoints    = "aws-sts-regional-endpoints"
+	flagAWSIMDSDisabled            = "aws-imds-disabled"
+	defaultAWSSTSRegionalEndpoints = STSRegionalEndpointsRegional
+
+	// STSRegionalEndpointsRegional calls STS via the endpoint of controller's region.
+	STSRegionalEndpointsRegional = "regional"
+	// STSRegionalEndpointsLegacy calls STS via the global endpoint for regions launched before 2019, like the AWS_STS_REGIONAL_ENDPOINTS=legacy of AWS SDKs.
+	STSRegionalEndpointsLegacy = "legacy"
+
+	flagAWSAPIFaultInjection                          = "aws-api-fault-injection"
+	flagAWSAPIFaultInjectionSecurityGroupVisibleDelay = "aws-api-fault-injection-security-group-visible-delay"
 )
 
 type CloudConfig struct {
@@ -27,6 +64,48 @@ type CloudConfig struct {
 
 	// Max retries configuration for AWS APIs
 	MaxRetries int
+
+	// Whether throttle settings for AWS APIs adapt to throttling responses from AWS
+	AdaptiveThrottle bool
+
+	// Minimum delay before retrying AWS API calls that got throttled, actual delay is jittered exponential backoff
+	ThrottleMinRetryDelay time.Duration
+
+	// Maximum delay before retrying AWS API calls that got throttled
+	ThrottleMaxRetryDelay time.Duration
+
+	// Custom endpoint URLs for AWS APIs, keyed by endpoint ID of AWS services, e.g. "ec2" or "elasticloadbalancing"
+	Endpoints map[string]string
+
+	// Whether FIPS endpoints are resolved for AWS APIs without custom endpoints
+	UseFIPSEndpoint bool
+
+	// Whether dual-stack endpoints are resolved for AWS APIs without custom endpoints
+	UseDualStackEndpoint bool
+
+	// IAM role assumed by the controller to call AWS APIs, the controller's own credentials are used if empty
+	AssumeRoleARN string
+
+	// External ID to assume AssumeRoleARN with
+	AssumeRoleExternalID string
+
+	// Session tags to assume AssumeRoleARN with, they are transitive through roles assumed subsequently
+	AssumeRoleSessionTags map[string]string
+
+	// Whether STS is called via regional or legacy global endpoints, either regional or legacy
+	STSRegionalEndpoints string
+
+	// Whether the EC2 instance metadata service is never called, region and VPC ID must be specified explicitly then
+	IMDSDisabled bool
+
+	// Sink to write audit records of mutating AWS API calls, either a file URL or a webhook URL, auditing is disabled if empty
+	APIAuditSink string
+
+	// Faults injected into AWS API calls to exercise retry paths of the controller, only meant for test environments
+	FaultInjection faultinjection.ServiceOperationsFaultConfig
+
+	// Delay before security groups created by the controller are visible to other EC2 API calls, simulated by fault injection
+	FaultInjectionSecurityGroupVisibleDelay time.Duration
 }
 
 func (cfg *CloudConfig) BindFlags(fs *pflag.FlagSet) {
@@ -34,4 +113,38 @@ func (cfg *CloudConfig) BindFlags(fs *pflag.FlagSet) {
 	fs.Var(cfg.ThrottleConfig, flagAWSAPIThrottle, "throttle settings for AWS APIs, format: serviceID1:operationRegex1=rate:burst,serviceID2:operationRegex2=rate:burst")
 	fs.StringVar(&cfg.VpcID, flagAWSVpcID, defaultVpcID, "AWS VPC ID for the Kubernetes cluster")
 	fs.IntVar(&cfg.MaxRetries, flagAWSMaxRetries, defaultAPIMaxRetries, "Maximum retries for AWS APIs")
+	fs.BoolVar(&cfg.AdaptiveThrottle, flagAWSAPIAdaptiveThrottle, defaultAPIAdaptiveThrottle, "Reduce the throttle rate for AWS APIs when AWS throttles them, and recover it when calls succeed")
+	fs.DurationVar(&cfg.ThrottleMinRetryDelay, flagAWSAPIThrottleMinRetryDelay, defaultAPIThrottleMinRetryDelay, "Minimum delay before retrying AWS API calls that got throttled")
+	fs.DurationVar(&cfg.ThrottleMaxRetryDelay, flagAWSAPIThrottleMaxRetryDelay, defaultAPIThrottleMaxRetryDelay, "Maximum delay before retrying AWS API calls that got throttled")
+	fs.StringToStringVar(&cfg.Endpoints, flagAWSAPIEndpoints, nil, "custom endpoint URLs for AWS APIs, format: serviceID1=URL1,serviceID2=URL2")
+	fs.BoolVar(&cfg.UseFIPSEndpoint, flagAWSUseFIPSEndpoint, defaultAWSUseFIPSEndpoint, "Use FIPS endpoints for AWS APIs without custom endpoints")
+	fs.BoolVar(&cfg.UseDualStackEndpoint, flagAWSUseDualStackEndpoint, defaultAWSUseDualStackEndpoint, "Use dual-stack endpoints for AWS APIs without custom endpoints")
+	fs.StringVar(&cfg.AssumeRoleARN, flagAWSAssumeRoleARN, "", "IAM role assumed by the controller to call AWS APIs")
+	fs.StringVar(&cfg.AssumeRoleExternalID, flagAWSAssumeRoleExternalID, "", "External ID to assume the IAM role with")
+	fs.StringToStringVar(&cfg.AssumeRoleSessionTags, flagAWSAssumeRoleSessionTags, nil, "Session tags to assume the IAM role with, format: key1=value1,key2=value2")
+	fs.StringVar(&cfg.STSRegionalEndpoints, flagAWSSTSRegionalEndpoints, defaultAWSSTSRegionalEndpoints, "Whether STS is called via the endpoint of controller's region or the legacy global endpoint, either regional or legacy")
+	fs.BoolVar(&cfg.IMDSDisabled, flagAWSIMDSDisabled, false, "Never call the EC2 instance metadata service, which requires aws-region and aws-vpc-id, for clusters where it's blocked for pods")
+	fs.StringVar(&cfg.APIAuditSink, flagAWSAPIAuditSink, "", "Sink to write CloudTrail compatible audit records of mutating AWS API calls, format: file:///path/to/file or https://webhook-url")
+	fs.Var(&cfg.FaultInjection, flagAWSAPIFaultInjection, "[testing only] faults injected into AWS API calls, format: serviceID1:operationRegex1=errorCode:failures,serviceID2:operationRegex2=errorCode:failures")
+	fs.DurationVar(&cfg.FaultInjectionSecurityGroupVisibleDelay, flagAWSAPIFaultInjectionSecurityGroupVisibleDelay, 0, "[testing only] simulated delay before created security groups are visible to other EC2 API calls")
+}
+
+// Validate the cloud configuration
+func (cfg *CloudConfig) Validate() error {
+	if err := validateEndpoints(cfg.Endpoints); err != nil {
+		return errors.Wrapf(err, "invalid %v", flagAWSAPIEndpoints)
+	}
+	if cfg.AssumeRoleARN == "" && (cfg.AssumeRoleExternalID != "" || len(cfg.AssumeRoleSessionTags) != 0) {
+		return errors.Errorf("%v must be specified with %v or %v", flagAWSAssumeRoleARN, flagAWSAssumeRoleExternalID, flagAWSAssumeRoleSessionTags)
+	}
+	if len(cfg.AssumeRoleSessionTags) > maxAssumeRoleSessionTags {
+		return errors.Errorf("%v must have at most %v tags", flagAWSAssumeRoleSessionTags, maxAssumeRoleSessionTags)
+	}
+	if cfg.STSRegionalEndpoints != "" && cfg.STSRegionalEndpoints != STSRegionalEndpointsRegional && cfg.STSRegionalEndpoints != STSRegionalEndpointsLegacy {
+		return errors.Errorf("%v must be one of %v or %v", flagAWSSTSRegionalEndpoints, STSRegionalEndpointsRegional, STSRegionalEndpointsLegacy)
+	}
+	if cfg.IMDSDisabled && (len(cfg.Region) == 0 || len(cfg.VpcID) == 0) {
+		return errors.Errorf("%v and %v must be specified with %v", flagAWSRegion, flagAWSVpcID, flagAWSIMDSDisabled)
+	}
+	return nil
 }
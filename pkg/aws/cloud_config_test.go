@@ -0,0 +1,101 @@
+package aws
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCloudConfig_Validate(t *testing.T) {
+	manySessionTags := make(map[string]string)
+	for i := 0; i < 51; i++ {
+		manySessionTags[fmt.Sprintf("key-%d", i)] = "value"
+	}
+	tests := []struct {
+		name    string
+		cfg     CloudConfig
+		wantErr error
+	}{
+		{
+			name: "default configuration",
+			cfg:  CloudConfig{},
+		},
+		{
+			name: "assume role with external ID and session tags",
+			cfg: CloudConfig{
+				AssumeRoleARN:         "arn:aws:iam::123456789012:role/aws-load-balancer-controller",
+				AssumeRoleExternalID:  "my-external-id",
+				AssumeRoleSessionTags: map[string]string{"cluster": "my-cluster"},
+			},
+		},
+		{
+			name: "external ID without assume role",
+			cfg: CloudConfig{
+				AssumeRoleExternalID: "my-external-id",
+			},
+			wantErr: errors.New("aws-assume-role-arn must be specified with aws-assume-role-external-id or aws-assume-role-session-tags"),
+		},
+		{
+			name: "session tags without assume role",
+			cfg: CloudConfig{
+				AssumeRoleSessionTags: map[string]string{"cluster": "my-cluster"},
+			},
+			wantErr: errors.New("aws-assume-role-arn must be specified with aws-assume-role-external-id or aws-assume-role-session-tags"),
+		},
+		{
+			name: "too many session tags",
+			cfg: CloudConfig{
+				AssumeRoleARN:         "arn:aws:iam::123456789012:role/aws-load-balancer-controller",
+				AssumeRoleSessionTags: manySessionTags,
+			},
+			wantErr: errors.New("aws-assume-role-session-tags must have at most 50 tags"),
+		},
+		{
+			name: "legacy STS endpoints",
+			cfg: CloudConfig{
+				STSRegionalEndpoints: "legacy",
+			},
+		},
+		{
+			name: "invalid STS endpoints",
+			cfg: CloudConfig{
+				STSRegionalEndpoints: "global",
+			},
+			wantErr: errors.New("aws-sts-regional-endpoints must be one of regional or legacy"),
+		},
+		{
+			name: "IMDS disabled with region and VPC ID",
+			cfg: CloudConfig{
+				Region:       "us-west-2",
+				VpcID:        "vpc-1",
+				IMDSDisabled: true,
+			},
+		},
+		{
+			name: "IMDS disabled without VPC ID",
+			cfg: CloudConfig{
+				Region:       "us-west-2",
+				IMDSDisabled: true,
+			},
+			wantErr: errors.New("aws-region and aws-vpc-id must be specified with aws-imds-disabled"),
+		},
+		{
+			name: "invalid endpoints",
+			cfg: CloudConfig{
+				Endpoints: map[string]string{"lambda": "https://lambda.us-west-2.amazonaws.com"},
+			},
+			wantErr: errors.New("invalid aws-api-endpoints: unsupported service lambda, must be one of [acm arc-zonal-shift ec2 elasticloadbalancing s3 servicequotas shield sts tagging waf-regional wafv2]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
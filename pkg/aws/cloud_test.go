@@ -0,0 +1,114 @@
+package aws
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newTestDefaultCloud() *defaultCloud {
+	sess := session.Must(session.NewSession(&aws.Config{
+		Region:      aws.String("us-west-2"),
+		Credentials: credentials.NewStaticCredentials("AKID", "SECRET", ""),
+	}))
+	return newDefaultCloud(CloudConfig{Region: "us-west-2", VpcID: "vpc-1"}, sess)
+}
+
+func Test_defaultCloud_ForRegion(t *testing.T) {
+	tests := []struct {
+		name       string
+		region     string
+		vpcID      string
+		wantRegion string
+		wantVpcID  string
+		wantSelf   bool
+	}{
+		{
+			name:       "same region and VPC",
+			region:     "us-west-2",
+			vpcID:      "vpc-1",
+			wantRegion: "us-west-2",
+			wantVpcID:  "vpc-1",
+			wantSelf:   true,
+		},
+		{
+			name:       "other region",
+			region:     "eu-west-1",
+			vpcID:      "vpc-2",
+			wantRegion: "eu-west-1",
+			wantVpcID:  "vpc-2",
+		},
+		{
+			name:       "other VPC in same region",
+			region:     "us-west-2",
+			vpcID:      "vpc-3",
+			wantRegion: "us-west-2",
+			wantVpcID:  "vpc-3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestDefaultCloud()
+			got := c.ForRegion(tt.region, tt.vpcID)
+			assert.Equal(t, tt.wantRegion, got.Region())
+			assert.Equal(t, tt.wantVpcID, got.VpcID())
+			assert.Equal(t, tt.wantRegion, aws.StringValue(got.(*defaultCloud).sess.Config.Region))
+			if tt.wantSelf {
+				assert.Same(t, c, got)
+			} else {
+				assert.NotSame(t, c, got)
+			}
+			assert.Same(t, got, c.ForRegion(tt.region, tt.vpcID))
+		})
+	}
+}
+
+func Test_defaultCloud_ForRegion_fromRegionalCloud(t *testing.T) {
+	c := newTestDefaultCloud()
+	regionalCloud := c.ForRegion("eu-west-1", "vpc-2")
+
+	assert.Same(t, c, regionalCloud.ForRegion("us-west-2", "vpc-1"))
+	assert.Same(t, c.ForRegion("ap-south-1", "vpc-3"), regionalCloud.ForRegion("ap-south-1", "vpc-3"))
+}
+
+func Test_defaultCloud_AssumeRole_fromRegionalCloud(t *testing.T) {
+	c := newTestDefaultCloud()
+	roleARN := "arn:aws:iam::123456789012:role/my-role"
+	regionalCloud := c.ForRegion("eu-west-1", "vpc-2")
+
+	got := regionalCloud.AssumeRole(roleARN)
+	assert.Equal(t, "eu-west-1", got.Region())
+	assert.Equal(t, "vpc-2", got.VpcID())
+	assert.Same(t, c.AssumeRole(roleARN).ForRegion("eu-west-1", "vpc-2"), got)
+	assert.Same(t, c.AssumeRole(roleARN), got.ForRegion("us-west-2", "vpc-1"))
+	assert.Same(t, c.AssumeRole(roleARN), got.AssumeRole(roleARN).ForRegion("us-west-2", "vpc-1"))
+}
+
+func TestNewCloud_STSRegionalEndpoints(t *testing.T) {
+	tests := []struct {
+		name                 string
+		stsRegionalEndpoints string
+		want                 endpoints.STSRegionalEndpoint
+	}{
+		{
+			name: "regional by default",
+			want: endpoints.RegionalSTSEndpoint,
+		},
+		{
+			name:                 "legacy",
+			stsRegionalEndpoints: STSRegionalEndpointsLegacy,
+			want:                 endpoints.LegacySTSEndpoint,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// region and VPC ID are specified explicitly, so that EC2Metadata isn't called.
+			c, err := NewCloud(CloudConfig{Region: "us-east-1", VpcID: "vpc-1", STSRegionalEndpoints: tt.stsRegionalEndpoints}, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, c.(*defaultCloud).sess.Config.STSRegionalEndpoint)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package aws
+
+import (
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/aws/aws-sdk-go/service/arczonalshift"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/aws/aws-sdk-go/service/shield"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/wafregional"
+	"github.com/aws/aws-sdk-go/service/wafv2"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"net/url"
+)
+
+// supportedEndpointServiceIDs are the endpoint IDs of AWS services whose endpoints can be customized.
+var supportedEndpointServiceIDs = sets.NewString(
+	ec2.EndpointsID,
+	elbv2.EndpointsID,
+	acm.EndpointsID,
+	wafregional.EndpointsID,
+	wafv2.EndpointsID,
+	shield.EndpointsID,
+	arczonalshift.EndpointsID,
+	resourcegroupstaggingapi.EndpointsID,
+	s3.EndpointsID,
+	servicequotas.EndpointsID,
+	sts.EndpointsID,
+)
+
+// validateEndpoints validates custom endpoint URLs keyed by endpoint ID of AWS services.
+func validateEndpoints(endpointURLByServiceID map[string]string) error {
+	for serviceID, endpointURL := range endpointURLByServiceID {
+		if !supportedEndpointServiceIDs.Has(serviceID) {
+			return errors.Errorf("unsupported service %v, must be one of %v", serviceID, supportedEndpointServiceIDs.List())
+		}
+		parsedURL, err := url.Parse(endpointURL)
+		if err != nil {
+			return errors.Wrapf(err, "invalid endpoint for service %v", serviceID)
+		}
+		if (parsedURL.Scheme != "https" && parsedURL.Scheme != "http") || parsedURL.Host == "" {
+			return errors.Errorf("invalid endpoint for service %v: %v, must be an absolute http or https URL", serviceID, endpointURL)
+		}
+	}
+	return nil
+}
+
+// buildEndpointResolver builds an endpoints.Resolver that resolves custom endpoint URLs keyed by endpoint ID of AWS services,
+// endpoints of other services are resolved by the SDK's default resolver.
+func buildEndpointResolver(endpointURLByServiceID map[string]string) endpoints.Resolver {
+	defaultResolver := endpoints.DefaultResolver()
+	return endpoints.ResolverFunc(func(service, region string, opts ...func(*endpoints.Options)) (endpoints.ResolvedEndpoint, error) {
+		if endpointURL, ok := endpointURLByServiceID[service]; ok {
+			return endpoints.ResolvedEndpoint{
+				URL:           endpointURL,
+				SigningRegion: region,
+			}, nil
+		}
+		return defaultResolver.EndpointFor(service, region, opts...)
+	})
+}
@@ -0,0 +1,93 @@
+package aws
+
+import (
+	"errors"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_validateEndpoints(t *testing.T) {
+	tests := []struct {
+		name                   string
+		endpointURLByServiceID map[string]string
+		wantErr                error
+	}{
+		{
+			name:                   "no custom endpoints",
+			endpointURLByServiceID: nil,
+		},
+		{
+			name: "valid custom endpoints",
+			endpointURLByServiceID: map[string]string{
+				"ec2":                  "https://vpce-0123456789abcdef0-a1b2c3d4.ec2.us-west-2.vpce.amazonaws.com",
+				"elasticloadbalancing": "https://elasticloadbalancing-fips.us-gov-west-1.amazonaws.com",
+			},
+		},
+		{
+			name: "unsupported service",
+			endpointURLByServiceID: map[string]string{
+				"lambda": "https://lambda.us-west-2.amazonaws.com",
+			},
+			wantErr: errors.New("unsupported service lambda, must be one of [acm arc-zonal-shift ec2 elasticloadbalancing s3 servicequotas shield sts tagging waf-regional wafv2]"),
+		},
+		{
+			name: "endpoint without scheme",
+			endpointURLByServiceID: map[string]string{
+				"ec2": "ec2.us-west-2.amazonaws.com",
+			},
+			wantErr: errors.New("invalid endpoint for service ec2: ec2.us-west-2.amazonaws.com, must be an absolute http or https URL"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateEndpoints(tt.endpointURLByServiceID)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_buildEndpointResolver(t *testing.T) {
+	tests := []struct {
+		name    string
+		service string
+		opts    []func(*endpoints.Options)
+		want    string
+	}{
+		{
+			name:    "service with custom endpoint",
+			service: "ec2",
+			want:    "https://vpce-0123456789abcdef0-a1b2c3d4.ec2.us-west-2.vpce.amazonaws.com",
+		},
+		{
+			name:    "service without custom endpoint",
+			service: "elasticloadbalancing",
+			want:    "https://elasticloadbalancing.us-west-2.amazonaws.com",
+		},
+		{
+			name:    "service without custom endpoint resolves FIPS endpoint",
+			service: "elasticloadbalancing",
+			opts: []func(*endpoints.Options){
+				func(o *endpoints.Options) {
+					o.UseFIPSEndpoint = endpoints.FIPSEndpointStateEnabled
+				},
+			},
+			want: "https://elasticloadbalancing-fips.us-west-2.amazonaws.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := buildEndpointResolver(map[string]string{
+				"ec2": "https://vpce-0123456789abcdef0-a1b2c3d4.ec2.us-west-2.vpce.amazonaws.com",
+			})
+			got, err := resolver.EndpointFor(tt.service, "us-west-2", tt.opts...)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.URL)
+			assert.Equal(t, "us-west-2", got.SigningRegion)
+		})
+	}
+}
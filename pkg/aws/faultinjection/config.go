@@ -0,0 +1,107 @@
+package faultinjection
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type faultConfig struct {
+	operationPtn *regexp.Regexp
+	errorCode    string
+	// the number of consecutive failures of each distinct request before it's sent to AWS.
+	failures int
+}
+
+var _ pflag.Value = &ServiceOperationsFaultConfig{}
+
+// ServiceOperationsFaultConfig is faultConfig for each service's operations.
+// It supports to be configured using flags with format like "${serviceID}:${operationRegex}=${errorCode}:${failures}"
+// e.g. "EC2:DeleteSecurityGroup=DependencyViolation:3,Elastic Load Balancing v2:Describe.*=Throttling:2"
+// Note: it's meant to exercise retry paths of the controller in test environments only.
+type ServiceOperationsFaultConfig struct {
+	// service:operationRegex:config
+	value map[string][]faultConfig
+}
+
+// IsEmpty checks whether no faults are configured.
+func (c *ServiceOperationsFaultConfig) IsEmpty() bool {
+	return c == nil || len(c.value) == 0
+}
+
+func (c *ServiceOperationsFaultConfig) String() string {
+	if c == nil {
+		return ""
+	}
+
+	var configs []string
+	var serviceIDs []string
+	for serviceID := range c.value {
+		serviceIDs = append(serviceIDs, serviceID)
+	}
+	sort.Strings(serviceIDs)
+	for _, serviceID := range serviceIDs {
+		for _, operationsFaultConfig := range c.value[serviceID] {
+			configs = append(configs, fmt.Sprintf("%s:%s=%s:%d",
+				serviceID,
+				operationsFaultConfig.operationPtn.String(),
+				operationsFaultConfig.errorCode,
+				operationsFaultConfig.failures,
+			))
+		}
+	}
+	return strings.Join(configs, ",")
+}
+
+func (c *ServiceOperationsFaultConfig) Set(val string) error {
+	valueOverride := make(map[string][]faultConfig)
+	configPairs := strings.Split(val, ",")
+	for _, pair := range configPairs {
+		kv := strings.Split(pair, "=")
+		if len(kv) != 2 {
+			return errors.Errorf("%s must be formatted as serviceID:operationRegex=errorCode:failures", pair)
+		}
+		serviceIDOperationRegexPair := strings.Split(kv[0], ":")
+		if len(serviceIDOperationRegexPair) != 2 {
+			return errors.Errorf("%s must be formatted as serviceID:operationRegex", kv[0])
+		}
+		errorCodeFailuresPair := strings.Split(kv[1], ":")
+		if len(errorCodeFailuresPair) != 2 {
+			return errors.Errorf("%s must be formatted as errorCode:failures", kv[1])
+		}
+		serviceID := serviceIDOperationRegexPair[0]
+		operationPtn, err := regexp.Compile(serviceIDOperationRegexPair[1])
+		if err != nil {
+			return errors.Errorf("%s must be valid regex expression for operation", serviceIDOperationRegexPair[1])
+		}
+		errorCode := errorCodeFailuresPair[0]
+		if len(errorCode) == 0 {
+			return errors.Errorf("%s must have non-empty errorCode", kv[1])
+		}
+		failures, err := strconv.Atoi(errorCodeFailuresPair[1])
+		if err != nil || failures < 1 {
+			return errors.Errorf("%s must be positive integer as failures for requests", errorCodeFailuresPair[1])
+		}
+		valueOverride[serviceID] = append(valueOverride[serviceID], faultConfig{
+			operationPtn: operationPtn,
+			errorCode:    errorCode,
+			failures:     failures,
+		})
+	}
+
+	if c.value == nil {
+		c.value = make(map[string][]faultConfig)
+	}
+	for k, v := range valueOverride {
+		c.value[k] = v
+	}
+	return nil
+}
+
+func (c *ServiceOperationsFaultConfig) Type() string {
+	return "serviceOperationsFaultConfig"
+}
@@ -0,0 +1,112 @@
+package faultinjection
+
+import (
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+)
+
+func TestServiceOperationsFaultConfig_String(t *testing.T) {
+	tests := []struct {
+		name  string
+		value map[string][]faultConfig
+		want  string
+	}{
+		{
+			name: "non-empty value",
+			value: map[string][]faultConfig{
+				ec2.ServiceID: {
+					{
+						operationPtn: regexp.MustCompile("DeleteSecurityGroup"),
+						errorCode:    "DependencyViolation",
+						failures:     3,
+					},
+				},
+				elbv2.ServiceID: {
+					{
+						operationPtn: regexp.MustCompile("^Describe"),
+						errorCode:    "Throttling",
+						failures:     2,
+					},
+				},
+			},
+			want: "EC2:DeleteSecurityGroup=DependencyViolation:3,Elastic Load Balancing v2:^Describe=Throttling:2",
+		},
+		{
+			name:  "nil value",
+			value: nil,
+			want:  "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &ServiceOperationsFaultConfig{
+				value: tt.value,
+			}
+			assert.Equal(t, tt.want, c.String())
+		})
+	}
+}
+
+func TestServiceOperationsFaultConfig_Set(t *testing.T) {
+	tests := []struct {
+		name    string
+		val     string
+		want    map[string][]faultConfig
+		wantErr error
+	}{
+		{
+			name: "multiple faults",
+			val:  "EC2:DeleteSecurityGroup=DependencyViolation:3,EC2:Describe.*=RequestLimitExceeded:1",
+			want: map[string][]faultConfig{
+				ec2.ServiceID: {
+					{
+						operationPtn: regexp.MustCompile("DeleteSecurityGroup"),
+						errorCode:    "DependencyViolation",
+						failures:     3,
+					},
+					{
+						operationPtn: regexp.MustCompile("Describe.*"),
+						errorCode:    "RequestLimitExceeded",
+						failures:     1,
+					},
+				},
+			},
+		},
+		{
+			name:    "missing failures",
+			val:     "EC2:DeleteSecurityGroup=DependencyViolation",
+			wantErr: errors.New("DependencyViolation must be formatted as errorCode:failures"),
+		},
+		{
+			name:    "non-positive failures",
+			val:     "EC2:DeleteSecurityGroup=DependencyViolation:0",
+			wantErr: errors.New("0 must be positive integer as failures for requests"),
+		},
+		{
+			name:    "invalid operation regex",
+			val:     "EC2:Delete(=DependencyViolation:1",
+			wantErr: errors.New("Delete( must be valid regex expression for operation"),
+		},
+		{
+			name:    "empty error code",
+			val:     "EC2:Delete.*=:1",
+			wantErr: errors.New(":1 must have non-empty errorCode"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &ServiceOperationsFaultConfig{}
+			err := c.Set(tt.val)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, c.value)
+			}
+		})
+	}
+}
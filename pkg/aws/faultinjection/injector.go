@@ -0,0 +1,132 @@
+package faultinjection
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	sdkHandlerInjectFaults               = "injectFaults"
+	sdkHandlerTrackCreatedSecurityGroups = "trackCreatedSecurityGroups"
+
+	// the error code of EC2 for security groups that don't exist or are not visible yet.
+	errCodeSecurityGroupNotFound = "InvalidGroup.NotFound"
+	// the message of injected errors, it helps telling injected faults from real ones in logs.
+	injectedFaultMessage = "fault injected by aws-load-balancer-controller"
+)
+
+type injector struct {
+	config                    *ServiceOperationsFaultConfig
+	securityGroupVisibleDelay time.Duration
+
+	mutex sync.Mutex
+	// failuresByRequest tracks the consecutive failures injected for each distinct request.
+	failuresByRequest map[string]int
+	// visibleTimeBySecurityGroupID tracks the time when recently created security groups become visible.
+	visibleTimeBySecurityGroupID map[string]time.Time
+}
+
+// NewInjector constructs new fault injector instance.
+// Requests to operations matching config fail with the configured error code for the configured times before they're sent to AWS.
+// Requests referencing security groups created within securityGroupVisibleDelay fail with InvalidGroup.NotFound,
+// to simulate the eventual consistency of EC2.
+func NewInjector(config *ServiceOperationsFaultConfig, securityGroupVisibleDelay time.Duration) *injector {
+	return &injector{
+		config:                       config,
+		securityGroupVisibleDelay:    securityGroupVisibleDelay,
+		failuresByRequest:            make(map[string]int),
+		visibleTimeBySecurityGroupID: make(map[string]time.Time),
+	}
+}
+
+func (i *injector) InjectHandlers(handlers *request.Handlers) {
+	// the send chain stops once a fault is injected, so that the failed request isn't sent to AWS.
+	handlers.Send.AfterEachFn = request.HandlerListStopOnError
+	handlers.Send.PushFrontNamed(request.NamedHandler{
+		Name: sdkHandlerInjectFaults,
+		Fn:   i.beforeSend,
+	})
+	if i.securityGroupVisibleDelay > 0 {
+		handlers.Complete.PushBackNamed(request.NamedHandler{
+			Name: sdkHandlerTrackCreatedSecurityGroups,
+			Fn:   i.afterComplete,
+		})
+	}
+}
+
+// beforeSend is added to the Send chain; called before each request attempt is sent.
+// injected faults are returned the way AWS responds with errors, so they're retried by SDK if retryable.
+func (i *injector) beforeSend(r *request.Request) {
+	if r.Operation == nil {
+		return
+	}
+	errorCode := i.findFault(r, time.Now())
+	if errorCode == "" {
+		return
+	}
+	r.HTTPResponse = &http.Response{
+		StatusCode: http.StatusBadRequest,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(strings.NewReader("")),
+	}
+	r.Error = awserr.NewRequestFailure(awserr.New(errorCode, injectedFaultMessage, nil), http.StatusBadRequest, "")
+}
+
+// afterComplete is added to the Complete chain; called after each request completes.
+func (i *injector) afterComplete(r *request.Request) {
+	if r.Error != nil || r.Operation == nil || r.ClientInfo.ServiceID != ec2.ServiceID || r.Operation.Name != "CreateSecurityGroup" {
+		return
+	}
+	output, ok := r.Data.(*ec2.CreateSecurityGroupOutput)
+	if !ok || output.GroupId == nil {
+		return
+	}
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	i.visibleTimeBySecurityGroupID[*output.GroupId] = time.Now().Add(i.securityGroupVisibleDelay)
+}
+
+// findFault finds the error code to fail request with, it returns empty string if request shouldn't fail.
+func (i *injector) findFault(r *request.Request, now time.Time) string {
+	params := awsutil.Prettify(r.Params)
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	if r.ClientInfo.ServiceID == ec2.ServiceID {
+		for sgID, visibleTime := range i.visibleTimeBySecurityGroupID {
+			if !now.Before(visibleTime) {
+				delete(i.visibleTimeBySecurityGroupID, sgID)
+				continue
+			}
+			if strings.Contains(params, fmt.Sprintf("%q", sgID)) {
+				return errCodeSecurityGroupNotFound
+			}
+		}
+	}
+	if i.config.IsEmpty() {
+		return ""
+	}
+	for _, operationsFaultConfig := range i.config.value[r.ClientInfo.ServiceID] {
+		if !operationsFaultConfig.operationPtn.MatchString(r.Operation.Name) {
+			continue
+		}
+		// the failures are counted per distinct request, so that retries of a request eventually succeed,
+		// and the next identical request starts failing again.
+		requestKey := fmt.Sprintf("%s:%s:%s", r.ClientInfo.ServiceID, r.Operation.Name, params)
+		if i.failuresByRequest[requestKey] < operationsFaultConfig.failures {
+			i.failuresByRequest[requestKey]++
+			return operationsFaultConfig.errorCode
+		}
+		delete(i.failuresByRequest, requestKey)
+		return ""
+	}
+	return ""
+}
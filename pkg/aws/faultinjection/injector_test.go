@@ -0,0 +1,117 @@
+package faultinjection
+
+import (
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_injector_InjectHandlers(t *testing.T) {
+	handlers := request.Handlers{}
+	NewInjector(&ServiceOperationsFaultConfig{}, 0).InjectHandlers(&handlers)
+	assert.Equal(t, 1, handlers.Send.Len())
+	assert.Equal(t, 0, handlers.Complete.Len())
+
+	handlers = request.Handlers{}
+	NewInjector(&ServiceOperationsFaultConfig{}, time.Second).InjectHandlers(&handlers)
+	assert.Equal(t, 1, handlers.Send.Len())
+	assert.Equal(t, 1, handlers.Complete.Len())
+}
+
+func Test_injector_beforeSend(t *testing.T) {
+	config := &ServiceOperationsFaultConfig{}
+	assert.NoError(t, config.Set("EC2:DeleteSecurityGroup=DependencyViolation:2"))
+	i := NewInjector(config, 0)
+	newRequest := func(sgID string) *request.Request {
+		return &request.Request{
+			ClientInfo: metadata.ClientInfo{ServiceID: ec2.ServiceID},
+			Operation:  &request.Operation{Name: "DeleteSecurityGroup"},
+			Params:     &ec2.DeleteSecurityGroupInput{GroupId: awssdk.String(sgID)},
+		}
+	}
+
+	var errCodes []string
+	for _, sgID := range []string{"sg-1", "sg-1", "sg-2", "sg-1", "sg-1", "sg-1"} {
+		r := newRequest(sgID)
+		i.beforeSend(r)
+		if r.Error == nil {
+			errCodes = append(errCodes, "")
+			continue
+		}
+		awsErr, ok := r.Error.(awserr.RequestFailure)
+		assert.True(t, ok)
+		assert.Equal(t, 400, awsErr.StatusCode())
+		assert.Equal(t, 400, r.HTTPResponse.StatusCode)
+		errCodes = append(errCodes, awsErr.Code())
+	}
+	// each distinct request fails twice then succeeds, and identical requests afterwards fail again.
+	assert.Equal(t, []string{"DependencyViolation", "DependencyViolation", "DependencyViolation", "", "DependencyViolation", "DependencyViolation"}, errCodes)
+}
+
+func Test_injector_findFault_securityGroupVisibleDelay(t *testing.T) {
+	i := NewInjector(&ServiceOperationsFaultConfig{}, time.Minute)
+	i.afterComplete(&request.Request{
+		ClientInfo: metadata.ClientInfo{ServiceID: ec2.ServiceID},
+		Operation:  &request.Operation{Name: "CreateSecurityGroup"},
+		Data:       &ec2.CreateSecurityGroupOutput{GroupId: awssdk.String("sg-1")},
+	})
+	now := time.Now()
+	tests := []struct {
+		name string
+		r    *request.Request
+		now  time.Time
+		want string
+	}{
+		{
+			name: "request referencing created security group",
+			r: &request.Request{
+				ClientInfo: metadata.ClientInfo{ServiceID: ec2.ServiceID},
+				Operation:  &request.Operation{Name: "DescribeSecurityGroups"},
+				Params:     &ec2.DescribeSecurityGroupsInput{GroupIds: awssdk.StringSlice([]string{"sg-1"})},
+			},
+			now:  now,
+			want: "InvalidGroup.NotFound",
+		},
+		{
+			name: "request referencing other security group",
+			r: &request.Request{
+				ClientInfo: metadata.ClientInfo{ServiceID: ec2.ServiceID},
+				Operation:  &request.Operation{Name: "DescribeSecurityGroups"},
+				Params:     &ec2.DescribeSecurityGroupsInput{GroupIds: awssdk.StringSlice([]string{"sg-12"})},
+			},
+			now:  now,
+			want: "",
+		},
+		{
+			name: "request of other service",
+			r: &request.Request{
+				ClientInfo: metadata.ClientInfo{ServiceID: elbv2.ServiceID},
+				Operation:  &request.Operation{Name: "SetSecurityGroups"},
+				Params:     &elbv2.SetSecurityGroupsInput{SecurityGroups: awssdk.StringSlice([]string{"sg-1"})},
+			},
+			now:  now,
+			want: "",
+		},
+		{
+			name: "request after security group is visible",
+			r: &request.Request{
+				ClientInfo: metadata.ClientInfo{ServiceID: ec2.ServiceID},
+				Operation:  &request.Operation{Name: "DescribeSecurityGroups"},
+				Params:     &ec2.DescribeSecurityGroupsInput{GroupIds: awssdk.StringSlice([]string{"sg-1"})},
+			},
+			now:  now.Add(2 * time.Minute),
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, i.findFault(tt.r, tt.now))
+		})
+	}
+}
@@ -55,6 +55,12 @@ func (c *collector) collectAPIRequestMetric(r *request.Request) {
 		labelService:   service,
 		labelOperation: operation,
 	}).Observe(duration.Seconds())
+	if request.IsErrorThrottle(r.Error) {
+		c.instruments.apiThrottledRequestsTotal.With(map[string]string{
+			labelService:   service,
+			labelOperation: operation,
+		}).Inc()
+	}
 }
 
 func (c *collector) collectAPICallMetric(r *request.Request) {
@@ -78,6 +84,13 @@ func (c *collector) collectAPICallMetric(r *request.Request) {
 		labelService:   service,
 		labelOperation: operation,
 	}).Observe(float64(r.RetryCount))
+	if resourceKind, ok := ResourceKindFromContext(r.Context()); ok {
+		c.instruments.apiCallsByResourceKindTotal.With(map[string]string{
+			labelService:      service,
+			labelOperation:    operation,
+			labelResourceKind: resourceKind,
+		}).Inc()
+	}
 }
 
 // statusCodeForRequest returns the http status code for request.
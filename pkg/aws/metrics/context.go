@@ -0,0 +1,16 @@
+package metrics
+
+import "context"
+
+type resourceKindContextKey struct{}
+
+// ContextWithResourceKind returns a context that attributes SDK API calls made with it to resources of specified kind.
+func ContextWithResourceKind(ctx context.Context, resourceKind string) context.Context {
+	return context.WithValue(ctx, resourceKindContextKey{}, resourceKind)
+}
+
+// ResourceKindFromContext returns the resource kind that SDK API calls made with ctx are attributed to.
+func ResourceKindFromContext(ctx context.Context) (string, bool) {
+	resourceKind, ok := ctx.Value(resourceKindContextKey{}).(string)
+	return resourceKind, ok
+}
@@ -13,6 +13,9 @@ const (
 
 	metricAPIRequestsTotal          = "api_requests_total"
 	metricAPIRequestDurationSeconds = "api_request_duration_seconds"
+	metricAPIThrottledRequestsTotal = "api_throttled_requests_total"
+
+	metricAPICallsByResourceKindTotal = "api_calls_by_resource_kind_total"
 )
 
 const (
@@ -20,14 +23,19 @@ const (
 	labelOperation  = "operation"
 	labelStatusCode = "status_code"
 	labelErrorCode  = "error_code"
+
+	labelResourceKind = "resource_kind"
 )
 
 type instruments struct {
-	apiCallsTotal            *prometheus.CounterVec
-	apiCallDurationSeconds   *prometheus.HistogramVec
-	apiCallRetries           *prometheus.HistogramVec
-	apiRequestsTotal         *prometheus.CounterVec
-	apiRequestDurationSecond *prometheus.HistogramVec
+	apiCallsTotal             *prometheus.CounterVec
+	apiCallDurationSeconds    *prometheus.HistogramVec
+	apiCallRetries            *prometheus.HistogramVec
+	apiRequestsTotal          *prometheus.CounterVec
+	apiRequestDurationSecond  *prometheus.HistogramVec
+	apiThrottledRequestsTotal *prometheus.CounterVec
+
+	apiCallsByResourceKindTotal *prometheus.CounterVec
 }
 
 // newInstruments allocates and register new metrics to registerer
@@ -59,6 +67,17 @@ func newInstruments(registerer prometheus.Registerer) (*instruments, error) {
 		Name:      metricAPIRequestDurationSeconds,
 		Help:      "Latency of an individual HTTP request to the service endpoint",
 	}, []string{labelService, labelOperation})
+	apiThrottledRequestsTotal := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricSubsystemAWS,
+		Name:      metricAPIThrottledRequestsTotal,
+		Help:      "Total number of HTTP requests that were throttled by AWS services",
+	}, []string{labelService, labelOperation})
+
+	apiCallsByResourceKindTotal := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricSubsystemAWS,
+		Name:      metricAPICallsByResourceKindTotal,
+		Help:      "Total number of SDK API calls made while deploying resources of each kind",
+	}, []string{labelService, labelOperation, labelResourceKind})
 
 	if err := registerer.Register(apiCallsTotal); err != nil {
 		return nil, err
@@ -75,11 +94,19 @@ func newInstruments(registerer prometheus.Registerer) (*instruments, error) {
 	if err := registerer.Register(apiRequestDurationSecond); err != nil {
 		return nil, err
 	}
+	if err := registerer.Register(apiThrottledRequestsTotal); err != nil {
+		return nil, err
+	}
+	if err := registerer.Register(apiCallsByResourceKindTotal); err != nil {
+		return nil, err
+	}
 	return &instruments{
-		apiCallsTotal:            apiCallsTotal,
-		apiCallDurationSeconds:   apiCallDurationSeconds,
-		apiCallRetries:           apiCallRetries,
-		apiRequestsTotal:         apiRequestsTotal,
-		apiRequestDurationSecond: apiRequestDurationSecond,
+		apiCallsTotal:               apiCallsTotal,
+		apiCallDurationSeconds:      apiCallDurationSeconds,
+		apiCallRetries:              apiCallRetries,
+		apiRequestsTotal:            apiRequestsTotal,
+		apiRequestDurationSecond:    apiRequestDurationSecond,
+		apiThrottledRequestsTotal:   apiThrottledRequestsTotal,
+		apiCallsByResourceKindTotal: apiCallsByResourceKindTotal,
 	}, nil
 }
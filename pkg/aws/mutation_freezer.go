@@ -0,0 +1,71 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/audit"
+)
+
+const (
+	sdkHandlerFreezeMutations = "freezeMutations"
+
+	// STS calls are made to refresh credentials of assumed IAM roles, they don't mutate AWS resources.
+	signingNameSTS = "sts"
+)
+
+type mutationsFrozenContextKey struct{}
+
+// MutationsFrozenError is the error when an AWS API call that mutates AWS resources is made with context whose mutations are frozen.
+// It means the AWS resources have drifted from desired state.
+type MutationsFrozenError struct {
+	// Service is the signing name of AWS service, e.g. elasticloadbalancing.
+	Service string
+	// Operation is the name of AWS API operation, e.g. ModifyListener.
+	Operation string
+}
+
+// Drift describes the drift as the mutating AWS API call that is prevented.
+func (e *MutationsFrozenError) Drift() string {
+	return fmt.Sprintf("%v:%v", e.Service, e.Operation)
+}
+
+func (e *MutationsFrozenError) Error() string {
+	return fmt.Sprintf("mutations are frozen, AWS resources have drifted and require %v", e.Drift())
+}
+
+// ContextWithMutationsFrozen returns a context that prevents AWS API calls made with it from mutating AWS resources.
+func ContextWithMutationsFrozen(ctx context.Context) context.Context {
+	return context.WithValue(ctx, mutationsFrozenContextKey{}, true)
+}
+
+// isMutationsFrozen checks whether mutations of AWS resources are frozen for ctx.
+func isMutationsFrozen(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	frozen, _ := ctx.Value(mutationsFrozenContextKey{}).(bool)
+	return frozen
+}
+
+// injectMutationFreezer injects the SDK handler that fails mutating AWS API calls made with context whose mutations are frozen.
+// the call fails before being sent, so it's not retried.
+func injectMutationFreezer(handlers *request.Handlers) {
+	handlers.Validate.PushFrontNamed(request.NamedHandler{
+		Name: sdkHandlerFreezeMutations,
+		Fn:   freezeMutations,
+	})
+}
+
+func freezeMutations(req *request.Request) {
+	if req.Operation == nil || audit.IsReadOnlyOperation(req.Operation.Name) || req.ClientInfo.SigningName == signingNameSTS {
+		return
+	}
+	if !isMutationsFrozen(req.Context()) {
+		return
+	}
+	req.Error = &MutationsFrozenError{
+		Service:   req.ClientInfo.SigningName,
+		Operation: req.Operation.Name,
+	}
+}
@@ -0,0 +1,69 @@
+package aws
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func Test_freezeMutations(t *testing.T) {
+	tests := []struct {
+		name        string
+		frozen      bool
+		signingName string
+		operation   string
+		wantErr     error
+	}{
+		{
+			name:        "mutating call with frozen context",
+			frozen:      true,
+			signingName: "elasticloadbalancing",
+			operation:   "ModifyListener",
+			wantErr: &MutationsFrozenError{
+				Service:   "elasticloadbalancing",
+				Operation: "ModifyListener",
+			},
+		},
+		{
+			name:        "read-only call with frozen context",
+			frozen:      true,
+			signingName: "elasticloadbalancing",
+			operation:   "DescribeListeners",
+		},
+		{
+			name:        "sts call with frozen context",
+			frozen:      true,
+			signingName: "sts",
+			operation:   "AssumeRole",
+		},
+		{
+			name:        "mutating call without frozen context",
+			frozen:      false,
+			signingName: "ec2",
+			operation:   "AuthorizeSecurityGroupIngress",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.frozen {
+				ctx = ContextWithMutationsFrozen(ctx)
+			}
+			req := &request.Request{
+				ClientInfo:  metadata.ClientInfo{SigningName: tt.signingName},
+				Operation:   &request.Operation{Name: tt.operation},
+				HTTPRequest: &http.Request{},
+			}
+			req.SetContext(ctx)
+			freezeMutations(req)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, req.Error)
+			} else {
+				assert.NoError(t, req.Error)
+			}
+		})
+	}
+}
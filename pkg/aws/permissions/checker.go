@@ -0,0 +1,211 @@
+package permissions
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"net/http"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// the interval to re-check permissions until all required actions are allowed.
+	defaultCheckInterval = 1 * time.Minute
+	// the maximum number of actions per SimulatePrincipalPolicy request.
+	simulateActionsChunkSize = 100
+
+	errCodeAccessDenied = "AccessDenied"
+)
+
+// Checker verifies the controller's AWS credentials are allowed to perform the IAM actions it requires.
+// It runs on every replica regardless of leader election, so that a misconfigured replica never becomes ready.
+type Checker interface {
+	manager.Runnable
+	manager.LeaderElectionRunnable
+
+	// Check simulates the required actions against the IAM policies of controller's principal, and returns the missing ones.
+	Check(ctx context.Context) ([]string, error)
+
+	// Readyz fails until all required actions are verified as allowed.
+	Readyz(req *http.Request) error
+}
+
+// NewDefaultChecker constructs new defaultChecker.
+func NewDefaultChecker(iamClient services.IAM, stsClient services.STS, requiredActions []string, logger logr.Logger) *defaultChecker {
+	return &defaultChecker{
+		iamClient:       iamClient,
+		stsClient:       stsClient,
+		requiredActions: requiredActions,
+		checkInterval:   defaultCheckInterval,
+		logger:          logger,
+	}
+}
+
+var _ Checker = &defaultChecker{}
+
+// default implementation for Checker.
+type defaultChecker struct {
+	iamClient       services.IAM
+	stsClient       services.STS
+	requiredActions []string
+	checkInterval   time.Duration
+	logger          logr.Logger
+
+	mutex          sync.RWMutex
+	checked        bool
+	missingActions []string
+	checkErr       error
+}
+
+// Start checks permissions periodically until all required actions are allowed, or stopped.
+func (c *defaultChecker) Start(stop <-chan struct{}) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-stop
+		cancel()
+	}()
+	wait.Until(func() {
+		if c.isSatisfied() {
+			return
+		}
+		missingActions, err := c.Check(ctx)
+		c.mutex.Lock()
+		c.checkErr = err
+		if err == nil {
+			c.checked = true
+			c.missingActions = missingActions
+		}
+		c.mutex.Unlock()
+		if err != nil {
+			c.logger.Error(err, "failed to check IAM permissions")
+			return
+		}
+		if len(missingActions) != 0 {
+			c.logger.Info("missing IAM permissions", "actions", missingActions)
+			return
+		}
+		c.logger.Info("verified IAM permissions", "actions", len(c.requiredActions))
+	}, c.checkInterval, stop)
+	return nil
+}
+
+func (c *defaultChecker) NeedLeaderElection() bool {
+	return false
+}
+
+func (c *defaultChecker) Check(ctx context.Context) ([]string, error) {
+	principalARN, err := c.resolvePrincipalARN(ctx)
+	if err != nil {
+		if isAccessDenied(err) {
+			return []string{"iam:GetRole"}, nil
+		}
+		return nil, err
+	}
+	missingActions := sets.NewString()
+	for start := 0; start < len(c.requiredActions); start += simulateActionsChunkSize {
+		end := start + simulateActionsChunkSize
+		if end > len(c.requiredActions) {
+			end = len(c.requiredActions)
+		}
+		results, err := c.iamClient.SimulatePrincipalPolicyAsList(ctx, &iam.SimulatePrincipalPolicyInput{
+			PolicySourceArn: awssdk.String(principalARN),
+			ActionNames:     awssdk.StringSlice(c.requiredActions[start:end]),
+		})
+		if err != nil {
+			if isAccessDenied(err) {
+				return []string{"iam:SimulatePrincipalPolicy"}, nil
+			}
+			return nil, errors.Wrapf(err, "failed to simulate IAM policies of %v", principalARN)
+		}
+		for _, result := range results {
+			if !isActionAllowed(result) {
+				missingActions.Insert(awssdk.StringValue(result.EvalActionName))
+			}
+		}
+	}
+	return missingActions.List(), nil
+}
+
+func (c *defaultChecker) Readyz(_ *http.Request) error {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	if !c.checked {
+		if c.checkErr != nil {
+			return errors.Wrap(c.checkErr, "IAM permissions not verified")
+		}
+		return errors.New("IAM permissions not verified yet")
+	}
+	if len(c.missingActions) != 0 {
+		return errors.Errorf("missing IAM permissions: %v", strings.Join(c.missingActions, ", "))
+	}
+	return nil
+}
+
+// isSatisfied returns whether all required actions have been verified as allowed.
+func (c *defaultChecker) isSatisfied() bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.checked && len(c.missingActions) == 0
+}
+
+// resolvePrincipalARN resolves the ARN of IAM user or role behind controller's credentials.
+// Assumed-role sessions are resolved into their role, since policies cannot be simulated against sessions.
+func (c *defaultChecker) resolvePrincipalARN(ctx context.Context) (string, error) {
+	resp, err := c.stsClient.GetCallerIdentityWithContext(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get caller identity")
+	}
+	callerARN := awssdk.StringValue(resp.Arn)
+	parsedARN, err := arn.Parse(callerARN)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to parse caller ARN: %v", callerARN)
+	}
+	if parsedARN.Service != sts.ServiceName || !strings.HasPrefix(parsedARN.Resource, "assumed-role/") {
+		return callerARN, nil
+	}
+	// resource of an assumed-role session is in the format of assumed-role/<role name>/<session name>.
+	resourceParts := strings.Split(parsedARN.Resource, "/")
+	if len(resourceParts) < 3 {
+		return "", errors.Errorf("invalid assumed-role ARN: %v", callerARN)
+	}
+	roleResp, err := c.iamClient.GetRoleWithContext(ctx, &iam.GetRoleInput{
+		RoleName: awssdk.String(resourceParts[1]),
+	})
+	if err != nil {
+		if isAccessDenied(err) {
+			return "", err
+		}
+		return "", errors.Wrapf(err, "failed to get role: %v", resourceParts[1])
+	}
+	return awssdk.StringValue(roleResp.Role.Arn), nil
+}
+
+// isActionAllowed returns whether the action in simulation result is allowed.
+// Actions denied only for lack of context values are allowed by conditional statements, and are considered allowed.
+func isActionAllowed(result *iam.EvaluationResult) bool {
+	decision := awssdk.StringValue(result.EvalDecision)
+	if decision == iam.PolicyEvaluationDecisionTypeAllowed {
+		return true
+	}
+	return decision == iam.PolicyEvaluationDecisionTypeImplicitDeny && len(result.MissingContextValues) != 0
+}
+
+func isAccessDenied(err error) bool {
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		return awsErr.Code() == errCodeAccessDenied
+	}
+	return false
+}
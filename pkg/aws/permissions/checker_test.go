@@ -0,0 +1,226 @@
+package permissions
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_defaultChecker_Check(t *testing.T) {
+	type getCallerIdentityCall struct {
+		resp *sts.GetCallerIdentityOutput
+		err  error
+	}
+	type getRoleCall struct {
+		req  *iam.GetRoleInput
+		resp *iam.GetRoleOutput
+		err  error
+	}
+	type simulatePrincipalPolicyAsListCall struct {
+		req  *iam.SimulatePrincipalPolicyInput
+		resp []*iam.EvaluationResult
+		err  error
+	}
+	type fields struct {
+		getCallerIdentityCalls             []getCallerIdentityCall
+		getRoleCalls                       []getRoleCall
+		simulatePrincipalPolicyAsListCalls []simulatePrincipalPolicyAsListCall
+	}
+	requiredActions := []string{"ec2:DescribeVpcs", "elasticloadbalancing:CreateLoadBalancer", "wafv2:AssociateWebACL"}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "all actions allowed for assumed role",
+			fields: fields{
+				getCallerIdentityCalls: []getCallerIdentityCall{
+					{
+						resp: &sts.GetCallerIdentityOutput{Arn: awssdk.String("arn:aws:sts::123456789012:assumed-role/lb-controller/session")},
+					},
+				},
+				getRoleCalls: []getRoleCall{
+					{
+						req: &iam.GetRoleInput{RoleName: awssdk.String("lb-controller")},
+						resp: &iam.GetRoleOutput{
+							Role: &iam.Role{Arn: awssdk.String("arn:aws:iam::123456789012:role/k8s/lb-controller")},
+						},
+					},
+				},
+				simulatePrincipalPolicyAsListCalls: []simulatePrincipalPolicyAsListCall{
+					{
+						req: &iam.SimulatePrincipalPolicyInput{
+							PolicySourceArn: awssdk.String("arn:aws:iam::123456789012:role/k8s/lb-controller"),
+							ActionNames:     awssdk.StringSlice(requiredActions),
+						},
+						resp: []*iam.EvaluationResult{
+							{EvalActionName: awssdk.String("ec2:DescribeVpcs"), EvalDecision: awssdk.String("allowed")},
+							{EvalActionName: awssdk.String("elasticloadbalancing:CreateLoadBalancer"), EvalDecision: awssdk.String("allowed")},
+							{EvalActionName: awssdk.String("wafv2:AssociateWebACL"), EvalDecision: awssdk.String("allowed")},
+						},
+					},
+				},
+			},
+			want: []string{},
+		},
+		{
+			name: "missing actions for IAM user",
+			fields: fields{
+				getCallerIdentityCalls: []getCallerIdentityCall{
+					{
+						resp: &sts.GetCallerIdentityOutput{Arn: awssdk.String("arn:aws:iam::123456789012:user/lb-controller")},
+					},
+				},
+				simulatePrincipalPolicyAsListCalls: []simulatePrincipalPolicyAsListCall{
+					{
+						req: &iam.SimulatePrincipalPolicyInput{
+							PolicySourceArn: awssdk.String("arn:aws:iam::123456789012:user/lb-controller"),
+							ActionNames:     awssdk.StringSlice(requiredActions),
+						},
+						resp: []*iam.EvaluationResult{
+							{EvalActionName: awssdk.String("ec2:DescribeVpcs"), EvalDecision: awssdk.String("allowed")},
+							{
+								EvalActionName:       awssdk.String("elasticloadbalancing:CreateLoadBalancer"),
+								EvalDecision:         awssdk.String("implicitDeny"),
+								MissingContextValues: awssdk.StringSlice([]string{"aws:RequestTag/elbv2.k8s.aws/cluster"}),
+							},
+							{EvalActionName: awssdk.String("wafv2:AssociateWebACL"), EvalDecision: awssdk.String("explicitDeny")},
+						},
+					},
+				},
+			},
+			want: []string{"wafv2:AssociateWebACL"},
+		},
+		{
+			name: "simulation denied",
+			fields: fields{
+				getCallerIdentityCalls: []getCallerIdentityCall{
+					{
+						resp: &sts.GetCallerIdentityOutput{Arn: awssdk.String("arn:aws:iam::123456789012:user/lb-controller")},
+					},
+				},
+				simulatePrincipalPolicyAsListCalls: []simulatePrincipalPolicyAsListCall{
+					{
+						req: &iam.SimulatePrincipalPolicyInput{
+							PolicySourceArn: awssdk.String("arn:aws:iam::123456789012:user/lb-controller"),
+							ActionNames:     awssdk.StringSlice(requiredActions),
+						},
+						err: awserr.New("AccessDenied", "not authorized", nil),
+					},
+				},
+			},
+			want: []string{"iam:SimulatePrincipalPolicy"},
+		},
+		{
+			name: "get role denied",
+			fields: fields{
+				getCallerIdentityCalls: []getCallerIdentityCall{
+					{
+						resp: &sts.GetCallerIdentityOutput{Arn: awssdk.String("arn:aws:sts::123456789012:assumed-role/lb-controller/session")},
+					},
+				},
+				getRoleCalls: []getRoleCall{
+					{
+						req: &iam.GetRoleInput{RoleName: awssdk.String("lb-controller")},
+						err: awserr.New("AccessDenied", "not authorized", nil),
+					},
+				},
+			},
+			want: []string{"iam:GetRole"},
+		},
+		{
+			name: "get caller identity fails",
+			fields: fields{
+				getCallerIdentityCalls: []getCallerIdentityCall{
+					{
+						err: errors.New("some error"),
+					},
+				},
+			},
+			wantErr: errors.New("failed to get caller identity: some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			iamClient := mock_services.NewMockIAM(ctrl)
+			stsClient := mock_services.NewMockSTS(ctrl)
+			for _, call := range tt.fields.getCallerIdentityCalls {
+				stsClient.EXPECT().GetCallerIdentityWithContext(ctx, &sts.GetCallerIdentityInput{}).Return(call.resp, call.err)
+			}
+			for _, call := range tt.fields.getRoleCalls {
+				iamClient.EXPECT().GetRoleWithContext(ctx, call.req).Return(call.resp, call.err)
+			}
+			for _, call := range tt.fields.simulatePrincipalPolicyAsListCalls {
+				iamClient.EXPECT().SimulatePrincipalPolicyAsList(ctx, call.req).Return(call.resp, call.err)
+			}
+
+			checker := NewDefaultChecker(iamClient, stsClient, requiredActions, &log.NullLogger{})
+			got, err := checker.Check(ctx)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultChecker_Readyz(t *testing.T) {
+	tests := []struct {
+		name           string
+		checked        bool
+		missingActions []string
+		checkErr       error
+		wantErr        error
+	}{
+		{
+			name:    "not checked yet",
+			wantErr: errors.New("IAM permissions not verified yet"),
+		},
+		{
+			name:     "check failed",
+			checkErr: errors.New("some error"),
+			wantErr:  errors.New("IAM permissions not verified: some error"),
+		},
+		{
+			name:           "missing actions",
+			checked:        true,
+			missingActions: []string{"tag:GetResources", "wafv2:AssociateWebACL"},
+			wantErr:        errors.New("missing IAM permissions: tag:GetResources, wafv2:AssociateWebACL"),
+		},
+		{
+			name:    "all actions allowed",
+			checked: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := &defaultChecker{
+				checked:        tt.checked,
+				missingActions: tt.missingActions,
+				checkErr:       tt.checkErr,
+			}
+			err := checker.Readyz(nil)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
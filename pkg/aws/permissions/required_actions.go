@@ -0,0 +1,101 @@
+package permissions
+
+import (
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+)
+
+// coreActions are the IAM actions required regardless of enabled features, as granted by docs/install/iam_policy.json.
+var coreActions = []string{
+	"iam:CreateServiceLinkedRole",
+	"ec2:DescribeAccountAttributes",
+	"ec2:DescribeAddresses",
+	"ec2:DescribeAvailabilityZones",
+	"ec2:DescribeInternetGateways",
+	"ec2:DescribeVpcs",
+	"ec2:DescribeSubnets",
+	"ec2:DescribeSecurityGroups",
+	"ec2:DescribeSecurityGroupRules",
+	"ec2:DescribeInstances",
+	"ec2:DescribeNetworkInterfaces",
+	"ec2:DescribeTags",
+	"ec2:AllocateAddress",
+	"ec2:ReleaseAddress",
+	"ec2:CreateSecurityGroup",
+	"ec2:DeleteSecurityGroup",
+	"ec2:AuthorizeSecurityGroupIngress",
+	"ec2:RevokeSecurityGroupIngress",
+	"ec2:CreateTags",
+	"ec2:DeleteTags",
+	"elasticloadbalancing:DescribeLoadBalancers",
+	"elasticloadbalancing:DescribeLoadBalancerAttributes",
+	"elasticloadbalancing:DescribeListeners",
+	"elasticloadbalancing:DescribeListenerAttributes",
+	"elasticloadbalancing:DescribeListenerCertificates",
+	"elasticloadbalancing:DescribeSSLPolicies",
+	"elasticloadbalancing:DescribeRules",
+	"elasticloadbalancing:DescribeTargetGroups",
+	"elasticloadbalancing:DescribeTargetGroupAttributes",
+	"elasticloadbalancing:DescribeTargetHealth",
+	"elasticloadbalancing:DescribeTags",
+	"elasticloadbalancing:CreateLoadBalancer",
+	"elasticloadbalancing:DeleteLoadBalancer",
+	"elasticloadbalancing:ModifyLoadBalancerAttributes",
+	"elasticloadbalancing:SetIpAddressType",
+	"elasticloadbalancing:SetSecurityGroups",
+	"elasticloadbalancing:SetSubnets",
+	"elasticloadbalancing:CreateListener",
+	"elasticloadbalancing:DeleteListener",
+	"elasticloadbalancing:ModifyListener",
+	"elasticloadbalancing:ModifyListenerAttributes",
+	"elasticloadbalancing:AddListenerCertificates",
+	"elasticloadbalancing:RemoveListenerCertificates",
+	"elasticloadbalancing:CreateRule",
+	"elasticloadbalancing:DeleteRule",
+	"elasticloadbalancing:ModifyRule",
+	"elasticloadbalancing:SetRulePriorities",
+	"elasticloadbalancing:CreateTargetGroup",
+	"elasticloadbalancing:DeleteTargetGroup",
+	"elasticloadbalancing:ModifyTargetGroup",
+	"elasticloadbalancing:ModifyTargetGroupAttributes",
+	"elasticloadbalancing:RegisterTargets",
+	"elasticloadbalancing:DeregisterTargets",
+	"elasticloadbalancing:AddTags",
+	"elasticloadbalancing:RemoveTags",
+	"acm:ListCertificates",
+	"acm:DescribeCertificate",
+	"servicequotas:ListServiceQuotas",
+	"servicequotas:ListAWSDefaultServiceQuotas",
+}
+
+// RequiredActions returns the IAM actions required by the controller with features enabled by cfg.
+func RequiredActions(cfg config.ControllerConfig) []string {
+	actions := sets.NewString(coreActions...)
+	if cfg.AddonsConfig.WAFEnabled {
+		actions.Insert("waf-regional:GetWebACL", "waf-regional:GetWebACLForResource", "waf-regional:AssociateWebACL", "waf-regional:DisassociateWebACL")
+	}
+	if cfg.AddonsConfig.WAFV2Enabled {
+		actions.Insert("wafv2:GetWebACL", "wafv2:GetWebACLForResource", "wafv2:AssociateWebACL", "wafv2:DisassociateWebACL")
+	}
+	if cfg.AddonsConfig.ShieldEnabled {
+		actions.Insert("shield:GetSubscriptionState", "shield:DescribeProtection", "shield:CreateProtection", "shield:DeleteProtection")
+	}
+	if cfg.AddonsConfig.ZonalShiftEnabled {
+		actions.Insert("arc-zonal-shift:ListZonalShifts", "arc-zonal-shift:StartZonalShift", "arc-zonal-shift:CancelZonalShift")
+	}
+	if cfg.AddonsConfig.IPAMEnabled {
+		actions.Insert("ec2:DescribeIpamPools", "ec2:GetIpamPoolAllocations", "ec2:AllocateIpamPoolCidr", "ec2:ReleaseIpamPoolAllocation")
+	}
+	if cfg.AddonsConfig.VPCEndpointServiceEnabled {
+		actions.Insert("ec2:DescribeVpcEndpointServiceConfigurations", "ec2:DescribeVpcEndpointServicePermissions", "ec2:DescribeVpcEndpointConnections",
+			"ec2:CreateVpcEndpointServiceConfiguration", "ec2:ModifyVpcEndpointServiceConfiguration", "ec2:ModifyVpcEndpointServicePermissions",
+			"ec2:RejectVpcEndpointConnections", "ec2:DeleteVpcEndpointServiceConfigurations")
+	}
+	if cfg.AddonsConfig.Route53Enabled {
+		actions.Insert("route53:ListHostedZones", "route53:ListResourceRecordSets", "route53:ChangeResourceRecordSets")
+	}
+	if cfg.EnableRGTAPI {
+		actions.Insert("tag:GetResources")
+	}
+	return actions.List()
+}
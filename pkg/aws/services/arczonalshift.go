@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/arczonalshift"
+	"github.com/aws/aws-sdk-go/service/arczonalshift/arczonalshiftiface"
+)
+
+type ARCZonalShift interface {
+	arczonalshiftiface.ARCZonalShiftAPI
+
+	// wrapper to ListZonalShiftsPagesWithContext API, which aggregates paged results into list.
+	ListZonalShiftsAsList(ctx context.Context, input *arczonalshift.ListZonalShiftsInput) ([]*arczonalshift.ZonalShiftSummary, error)
+}
+
+// NewARCZonalShift constructs new ARCZonalShift implementation.
+func NewARCZonalShift(session *session.Session) ARCZonalShift {
+	return &defaultARCZonalShift{
+		ARCZonalShiftAPI: arczonalshift.New(session),
+	}
+}
+
+// default implementation for ARCZonalShift.
+type defaultARCZonalShift struct {
+	arczonalshiftiface.ARCZonalShiftAPI
+}
+
+func (c *defaultARCZonalShift) ListZonalShiftsAsList(ctx context.Context, input *arczonalshift.ListZonalShiftsInput) ([]*arczonalshift.ZonalShiftSummary, error) {
+	var result []*arczonalshift.ZonalShiftSummary
+	if err := c.ListZonalShiftsPagesWithContext(ctx, input, func(output *arczonalshift.ListZonalShiftsOutput, _ bool) bool {
+		result = append(result, output.Items...)
+		return true
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
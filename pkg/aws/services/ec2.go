@@ -19,8 +19,26 @@ type EC2 interface {
 	// wrapper to DescribeSecurityGroupsPagesWithContext API, which aggregates paged results into list.
 	DescribeSecurityGroupsAsList(ctx context.Context, input *ec2.DescribeSecurityGroupsInput) ([]*ec2.SecurityGroup, error)
 
+	// wrapper to DescribeSecurityGroupRulesPagesWithContext API, which aggregates paged results into list.
+	DescribeSecurityGroupRulesAsList(ctx context.Context, input *ec2.DescribeSecurityGroupRulesInput) ([]*ec2.SecurityGroupRule, error)
+
 	// wrapper to DescribeSubnetsPagesWithContext API, which aggregates paged results into list.
 	DescribeSubnetsAsList(ctx context.Context, input *ec2.DescribeSubnetsInput) ([]*ec2.Subnet, error)
+
+	// wrapper to DescribeIpamPoolsPagesWithContext API, which aggregates paged results into list.
+	DescribeIpamPoolsAsList(ctx context.Context, input *ec2.DescribeIpamPoolsInput) ([]*ec2.IpamPool, error)
+
+	// wrapper to GetIpamPoolAllocationsPagesWithContext API, which aggregates paged results into list.
+	GetIpamPoolAllocationsAsList(ctx context.Context, input *ec2.GetIpamPoolAllocationsInput) ([]*ec2.IpamPoolAllocation, error)
+
+	// wrapper to DescribeVpcEndpointServiceConfigurationsPagesWithContext API, which aggregates paged results into list.
+	DescribeVpcEndpointServiceConfigurationsAsList(ctx context.Context, input *ec2.DescribeVpcEndpointServiceConfigurationsInput) ([]*ec2.ServiceConfiguration, error)
+
+	// wrapper to DescribeVpcEndpointServicePermissionsPagesWithContext API, which aggregates paged results into list.
+	DescribeVpcEndpointServicePermissionsAsList(ctx context.Context, input *ec2.DescribeVpcEndpointServicePermissionsInput) ([]*ec2.AllowedPrincipal, error)
+
+	// wrapper to DescribeVpcEndpointConnectionsPagesWithContext API, which aggregates paged results into list.
+	DescribeVpcEndpointConnectionsAsList(ctx context.Context, input *ec2.DescribeVpcEndpointConnectionsInput) ([]*ec2.VpcEndpointConnection, error)
 }
 
 // NewEC2 constructs new EC2 implementation.
@@ -69,6 +87,17 @@ func (c *defaultEC2) DescribeSecurityGroupsAsList(ctx context.Context, input *ec
 	return result, nil
 }
 
+func (c *defaultEC2) DescribeSecurityGroupRulesAsList(ctx context.Context, input *ec2.DescribeSecurityGroupRulesInput) ([]*ec2.SecurityGroupRule, error) {
+	var result []*ec2.SecurityGroupRule
+	if err := c.DescribeSecurityGroupRulesPagesWithContext(ctx, input, func(output *ec2.DescribeSecurityGroupRulesOutput, _ bool) bool {
+		result = append(result, output.SecurityGroupRules...)
+		return true
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *defaultEC2) DescribeSubnetsAsList(ctx context.Context, input *ec2.DescribeSubnetsInput) ([]*ec2.Subnet, error) {
 	var result []*ec2.Subnet
 	if err := c.DescribeSubnetsPagesWithContext(ctx, input, func(output *ec2.DescribeSubnetsOutput, _ bool) bool {
@@ -79,3 +108,58 @@ func (c *defaultEC2) DescribeSubnetsAsList(ctx context.Context, input *ec2.Descr
 	}
 	return result, nil
 }
+
+func (c *defaultEC2) DescribeIpamPoolsAsList(ctx context.Context, input *ec2.DescribeIpamPoolsInput) ([]*ec2.IpamPool, error) {
+	var result []*ec2.IpamPool
+	if err := c.DescribeIpamPoolsPagesWithContext(ctx, input, func(output *ec2.DescribeIpamPoolsOutput, _ bool) bool {
+		result = append(result, output.IpamPools...)
+		return true
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *defaultEC2) GetIpamPoolAllocationsAsList(ctx context.Context, input *ec2.GetIpamPoolAllocationsInput) ([]*ec2.IpamPoolAllocation, error) {
+	var result []*ec2.IpamPoolAllocation
+	if err := c.GetIpamPoolAllocationsPagesWithContext(ctx, input, func(output *ec2.GetIpamPoolAllocationsOutput, _ bool) bool {
+		result = append(result, output.IpamPoolAllocations...)
+		return true
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *defaultEC2) DescribeVpcEndpointServiceConfigurationsAsList(ctx context.Context, input *ec2.DescribeVpcEndpointServiceConfigurationsInput) ([]*ec2.ServiceConfiguration, error) {
+	var result []*ec2.ServiceConfiguration
+	if err := c.DescribeVpcEndpointServiceConfigurationsPagesWithContext(ctx, input, func(output *ec2.DescribeVpcEndpointServiceConfigurationsOutput, _ bool) bool {
+		result = append(result, output.ServiceConfigurations...)
+		return true
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *defaultEC2) DescribeVpcEndpointServicePermissionsAsList(ctx context.Context, input *ec2.DescribeVpcEndpointServicePermissionsInput) ([]*ec2.AllowedPrincipal, error) {
+	var result []*ec2.AllowedPrincipal
+	if err := c.DescribeVpcEndpointServicePermissionsPagesWithContext(ctx, input, func(output *ec2.DescribeVpcEndpointServicePermissionsOutput, _ bool) bool {
+		result = append(result, output.AllowedPrincipals...)
+		return true
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *defaultEC2) DescribeVpcEndpointConnectionsAsList(ctx context.Context, input *ec2.DescribeVpcEndpointConnectionsInput) ([]*ec2.VpcEndpointConnection, error) {
+	var result []*ec2.VpcEndpointConnection
+	if err := c.DescribeVpcEndpointConnectionsPagesWithContext(ctx, input, func(output *ec2.DescribeVpcEndpointConnectionsOutput, _ bool) bool {
+		result = append(result, output.VpcEndpointConnections...)
+		return true
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
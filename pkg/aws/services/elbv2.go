@@ -25,18 +25,28 @@ type ELBV2 interface {
 
 	// wrapper to DescribeRulesWithContext API, which aggregates paged results into list.
 	DescribeRulesAsList(ctx context.Context, input *elbv2.DescribeRulesInput) ([]*elbv2.Rule, error)
+
+	// DescribeListenerAttributesWithContext describes the attributes of listener.
+	DescribeListenerAttributesWithContext(ctx context.Context, input *DescribeListenerAttributesInput) (*DescribeListenerAttributesOutput, error)
+
+	// ModifyListenerAttributesWithContext modifies the specified attributes of listener.
+	ModifyListenerAttributesWithContext(ctx context.Context, input *ModifyListenerAttributesInput) (*ModifyListenerAttributesOutput, error)
 }
 
 // NewELBV2 constructs new ELBV2 implementation.
 func NewELBV2(session *session.Session) ELBV2 {
+	client := elbv2.New(session)
 	return &defaultELBV2{
-		ELBV2API: elbv2.New(session),
+		ELBV2API: client,
+		client:   client,
 	}
 }
 
 // default implementation for ELBV2.
 type defaultELBV2 struct {
 	elbv2iface.ELBV2API
+	// client is used to make requests for APIs not available in elbv2iface.ELBV2API.
+	client *elbv2.ELBV2
 }
 
 func (c *defaultELBV2) DescribeLoadBalancersAsList(ctx context.Context, input *elbv2.DescribeLoadBalancersInput) ([]*elbv2.LoadBalancer, error) {
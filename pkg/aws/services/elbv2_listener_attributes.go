@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+// the listener attributes APIs postdate the aws-sdk-go in use, so their shapes are defined here.
+// the struct tags follow the generated shapes of aws-sdk-go, which drive the query protocol (un)marshalling.
+
+const (
+	opDescribeListenerAttributes = "DescribeListenerAttributes"
+	opModifyListenerAttributes   = "ModifyListenerAttributes"
+)
+
+// ListenerAttribute is an attribute of listener, e.g. tcp.idle_timeout.seconds.
+type ListenerAttribute struct {
+	_ struct{} `type:"structure"`
+
+	// The name of the attribute.
+	Key *string `type:"string"`
+
+	// The value of the attribute.
+	Value *string `type:"string"`
+}
+
+// DescribeListenerAttributesInput is the input of DescribeListenerAttributes API.
+type DescribeListenerAttributesInput struct {
+	_ struct{} `type:"structure"`
+
+	// The Amazon Resource Name (ARN) of the listener.
+	ListenerArn *string `type:"string" required:"true"`
+}
+
+// DescribeListenerAttributesOutput is the output of DescribeListenerAttributes API.
+type DescribeListenerAttributesOutput struct {
+	_ struct{} `type:"structure"`
+
+	// Information about the listener attributes.
+	Attributes []*ListenerAttribute `type:"list"`
+}
+
+// ModifyListenerAttributesInput is the input of ModifyListenerAttributes API.
+type ModifyListenerAttributesInput struct {
+	_ struct{} `type:"structure"`
+
+	// The listener attributes.
+	Attributes []*ListenerAttribute `type:"list" required:"true"`
+
+	// The Amazon Resource Name (ARN) of the listener.
+	ListenerArn *string `type:"string" required:"true"`
+}
+
+// ModifyListenerAttributesOutput is the output of ModifyListenerAttributes API.
+type ModifyListenerAttributesOutput struct {
+	_ struct{} `type:"structure"`
+
+	// Information about the listener attributes.
+	Attributes []*ListenerAttribute `type:"list"`
+}
+
+func (c *defaultELBV2) DescribeListenerAttributesWithContext(ctx context.Context, input *DescribeListenerAttributesInput) (*DescribeListenerAttributesOutput, error) {
+	output := &DescribeListenerAttributesOutput{}
+	req := c.client.NewRequest(&request.Operation{
+		Name:       opDescribeListenerAttributes,
+		HTTPMethod: "POST",
+		HTTPPath:   "/",
+	}, input, output)
+	req.SetContext(ctx)
+	return output, req.Send()
+}
+
+func (c *defaultELBV2) ModifyListenerAttributesWithContext(ctx context.Context, input *ModifyListenerAttributesInput) (*ModifyListenerAttributesOutput, error) {
+	output := &ModifyListenerAttributesOutput{}
+	req := c.client.NewRequest(&request.Operation{
+		Name:       opModifyListenerAttributes,
+		HTTPMethod: "POST",
+		HTTPPath:   "/",
+	}, input, output)
+	req.SetContext(ctx)
+	return output, req.Send()
+}
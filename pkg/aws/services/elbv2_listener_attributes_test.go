@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func newTestELBV2(t *testing.T, responseBody string, requestForms *[]url.Values) ELBV2 {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		form, err := url.ParseQuery(string(body))
+		assert.NoError(t, err)
+		*requestForms = append(*requestForms, form)
+		w.Header().Set("Content-Type", "text/xml")
+		w.Write([]byte(responseBody))
+	}))
+	t.Cleanup(server.Close)
+	sess := session.Must(session.NewSession(&awssdk.Config{
+		Region:      awssdk.String("us-west-2"),
+		Endpoint:    awssdk.String(server.URL),
+		Credentials: credentials.NewStaticCredentials("AKID", "SECRET", ""),
+		MaxRetries:  awssdk.Int(0),
+	}))
+	return NewELBV2(sess)
+}
+
+func Test_defaultELBV2_DescribeListenerAttributesWithContext(t *testing.T) {
+	responseBody := `<DescribeListenerAttributesResponse xmlns="http://elasticloadbalancing.amazonaws.com/doc/2015-12-01/">
+  <DescribeListenerAttributesResult>
+    <Attributes>
+      <member>
+        <Key>tcp.idle_timeout.seconds</Key>
+        <Value>350</Value>
+      </member>
+    </Attributes>
+  </DescribeListenerAttributesResult>
+  <ResponseMetadata>
+    <RequestId>request-id</RequestId>
+  </ResponseMetadata>
+</DescribeListenerAttributesResponse>`
+	var requestForms []url.Values
+	elbv2Client := newTestELBV2(t, responseBody, &requestForms)
+
+	got, err := elbv2Client.DescribeListenerAttributesWithContext(context.Background(), &DescribeListenerAttributesInput{
+		ListenerArn: awssdk.String("my-listener"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []*ListenerAttribute{
+		{
+			Key:   awssdk.String("tcp.idle_timeout.seconds"),
+			Value: awssdk.String("350"),
+		},
+	}, got.Attributes)
+	assert.Equal(t, []url.Values{
+		{
+			"Action":      []string{"DescribeListenerAttributes"},
+			"Version":     []string{"2015-12-01"},
+			"ListenerArn": []string{"my-listener"},
+		},
+	}, requestForms)
+}
+
+func Test_defaultELBV2_ModifyListenerAttributesWithContext(t *testing.T) {
+	responseBody := `<ModifyListenerAttributesResponse xmlns="http://elasticloadbalancing.amazonaws.com/doc/2015-12-01/">
+  <ModifyListenerAttributesResult>
+    <Attributes>
+      <member>
+        <Key>tcp.idle_timeout.seconds</Key>
+        <Value>600</Value>
+      </member>
+    </Attributes>
+  </ModifyListenerAttributesResult>
+  <ResponseMetadata>
+    <RequestId>request-id</RequestId>
+  </ResponseMetadata>
+</ModifyListenerAttributesResponse>`
+	var requestForms []url.Values
+	elbv2Client := newTestELBV2(t, responseBody, &requestForms)
+
+	got, err := elbv2Client.ModifyListenerAttributesWithContext(context.Background(), &ModifyListenerAttributesInput{
+		ListenerArn: awssdk.String("my-listener"),
+		Attributes: []*ListenerAttribute{
+			{
+				Key:   awssdk.String("tcp.idle_timeout.seconds"),
+				Value: awssdk.String("600"),
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []*ListenerAttribute{
+		{
+			Key:   awssdk.String("tcp.idle_timeout.seconds"),
+			Value: awssdk.String("600"),
+		},
+	}, got.Attributes)
+	assert.Equal(t, []url.Values{
+		{
+			"Action":                    []string{"ModifyListenerAttributes"},
+			"Version":                   []string{"2015-12-01"},
+			"ListenerArn":               []string{"my-listener"},
+			"Attributes.member.1.Key":   []string{"tcp.idle_timeout.seconds"},
+			"Attributes.member.1.Value": []string{"600"},
+		},
+	}, requestForms)
+}
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+)
+
+type IAM interface {
+	iamiface.IAMAPI
+
+	// wrapper to SimulatePrincipalPolicyPagesWithContext API, which aggregates paged results into list.
+	SimulatePrincipalPolicyAsList(ctx context.Context, input *iam.SimulatePrincipalPolicyInput) ([]*iam.EvaluationResult, error)
+}
+
+// NewIAM constructs new IAM implementation.
+func NewIAM(session *session.Session) IAM {
+	return &defaultIAM{
+		IAMAPI: iam.New(session),
+	}
+}
+
+// default implementation for IAM.
+type defaultIAM struct {
+	iamiface.IAMAPI
+}
+
+func (c *defaultIAM) SimulatePrincipalPolicyAsList(ctx context.Context, input *iam.SimulatePrincipalPolicyInput) ([]*iam.EvaluationResult, error) {
+	var result []*iam.EvaluationResult
+	if err := c.SimulatePrincipalPolicyPagesWithContext(ctx, input, func(output *iam.SimulatePolicyResponse, _ bool) bool {
+		result = append(result, output.EvaluationResults...)
+		return true
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
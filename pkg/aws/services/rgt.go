@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
 	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
@@ -8,6 +9,9 @@ import (
 
 type RGT interface {
 	resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI
+
+	// wrapper to GetResourcesPagesWithContext API, which aggregates paged results into list.
+	GetResourcesAsList(ctx context.Context, input *resourcegroupstaggingapi.GetResourcesInput) ([]*resourcegroupstaggingapi.ResourceTagMapping, error)
 }
 
 // NewRGT constructs new RGT implementation.
@@ -20,3 +24,14 @@ func NewRGT(session *session.Session) RGT {
 type defaultRGT struct {
 	resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI
 }
+
+func (c *defaultRGT) GetResourcesAsList(ctx context.Context, input *resourcegroupstaggingapi.GetResourcesInput) ([]*resourcegroupstaggingapi.ResourceTagMapping, error) {
+	var result []*resourcegroupstaggingapi.ResourceTagMapping
+	if err := c.GetResourcesPagesWithContext(ctx, input, func(output *resourcegroupstaggingapi.GetResourcesOutput, _ bool) bool {
+		result = append(result, output.ResourceTagMappingList...)
+		return true
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
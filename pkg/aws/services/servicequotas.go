@@ -0,0 +1,52 @@
+package services
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/aws/aws-sdk-go/service/servicequotas/servicequotasiface"
+)
+
+type ServiceQuotas interface {
+	servicequotasiface.ServiceQuotasAPI
+
+	// wrapper to ListServiceQuotasPagesWithContext API, which aggregates paged results into list.
+	ListServiceQuotasAsList(ctx context.Context, input *servicequotas.ListServiceQuotasInput) ([]*servicequotas.ServiceQuota, error)
+
+	// wrapper to ListAWSDefaultServiceQuotasPagesWithContext API, which aggregates paged results into list.
+	ListAWSDefaultServiceQuotasAsList(ctx context.Context, input *servicequotas.ListAWSDefaultServiceQuotasInput) ([]*servicequotas.ServiceQuota, error)
+}
+
+// NewServiceQuotas constructs new ServiceQuotas implementation.
+func NewServiceQuotas(session *session.Session) ServiceQuotas {
+	return &defaultServiceQuotas{
+		ServiceQuotasAPI: servicequotas.New(session),
+	}
+}
+
+// default implementation for ServiceQuotas.
+type defaultServiceQuotas struct {
+	servicequotasiface.ServiceQuotasAPI
+}
+
+func (c *defaultServiceQuotas) ListServiceQuotasAsList(ctx context.Context, input *servicequotas.ListServiceQuotasInput) ([]*servicequotas.ServiceQuota, error) {
+	var result []*servicequotas.ServiceQuota
+	if err := c.ListServiceQuotasPagesWithContext(ctx, input, func(output *servicequotas.ListServiceQuotasOutput, _ bool) bool {
+		result = append(result, output.Quotas...)
+		return true
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *defaultServiceQuotas) ListAWSDefaultServiceQuotasAsList(ctx context.Context, input *servicequotas.ListAWSDefaultServiceQuotasInput) ([]*servicequotas.ServiceQuota, error) {
+	var result []*servicequotas.ServiceQuota
+	if err := c.ListAWSDefaultServiceQuotasPagesWithContext(ctx, input, func(output *servicequotas.ListAWSDefaultServiceQuotasOutput, _ bool) bool {
+		result = append(result, output.Quotas...)
+		return true
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
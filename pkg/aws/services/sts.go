@@ -0,0 +1,23 @@
+package services
+
+import (
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+)
+
+type STS interface {
+	stsiface.STSAPI
+}
+
+// NewSTS constructs new STS implementation.
+func NewSTS(session *session.Session) STS {
+	return &defaultSTS{
+		STSAPI: sts.New(session),
+	}
+}
+
+// default implementation for STS.
+type defaultSTS struct {
+	stsiface.STSAPI
+}
@@ -1,6 +1,8 @@
 package throttle
 
 import (
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/aws/aws-sdk-go/service/wafregional"
 	"github.com/aws/aws-sdk-go/service/wafv2"
 	"golang.org/x/time/rate"
@@ -11,6 +13,20 @@ import (
 func NewDefaultServiceOperationsThrottleConfig() *ServiceOperationsThrottleConfig {
 	return &ServiceOperationsThrottleConfig{
 		value: map[string][]throttleConfig{
+			ec2.ServiceID: {
+				{
+					operationPtn: regexp.MustCompile("^DescribeSecurityGroups|DescribeNetworkInterfaces|DescribeInstances"),
+					r:            rate.Limit(20),
+					burst:        40,
+				},
+			},
+			elbv2.ServiceID: {
+				{
+					operationPtn: regexp.MustCompile("^Describe.*"),
+					r:            rate.Limit(10),
+					burst:        20,
+				},
+			},
 			wafregional.ServiceID: {
 				{
 					operationPtn: regexp.MustCompile("^AssociateWebACL|DisassociateWebACL"),
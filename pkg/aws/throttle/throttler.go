@@ -4,17 +4,33 @@ import (
 	"github.com/aws/aws-sdk-go/aws/request"
 	"golang.org/x/time/rate"
 	"regexp"
+	"sync"
 )
 
-const sdkHandlerRequestThrottle = "requestThrottle"
+const (
+	sdkHandlerRequestThrottle = "requestThrottle"
+	sdkHandlerAdaptThrottle   = "adaptThrottle"
+
+	// adaptive throttle halves the rate when requests are throttled by AWS.
+	adaptiveRateDecreaseFactor = 0.5
+	// adaptive throttle recovers 5% of the configured rate when requests succeed.
+	adaptiveRateIncreaseFactor = 0.05
+	// adaptive throttle never reduces the rate below 1/16 of the configured rate.
+	adaptiveRateMinFactor = 1.0 / 16
+)
 
 type conditionLimiter struct {
 	condition Condition
 	limiter   *rate.Limiter
+	// maxLimit is the configured rate of limiter, adaptive throttle never exceeds it.
+	maxLimit rate.Limit
 }
 
 type throttler struct {
 	conditionLimiters []conditionLimiter
+	adaptive          bool
+	// adaptMutex serializes adapting the rate of limiters, since concurrent requests read and update it.
+	adaptMutex sync.Mutex
 }
 
 // NewThrottler constructs new request throttler instance.
@@ -37,10 +53,18 @@ func (t *throttler) WithConditionThrottle(condition Condition, r rate.Limit, bur
 	t.conditionLimiters = append(t.conditionLimiters, conditionLimiter{
 		condition: condition,
 		limiter:   limiter,
+		maxLimit:  r,
 	})
 	return t
 }
 
+// WithAdaptiveThrottle makes throttles adapt to AWS throttling: the rate of matching requests is decreased
+// multiplicatively when any of them is throttled by AWS, and recovered additively when they succeed.
+func (t *throttler) WithAdaptiveThrottle() *throttler {
+	t.adaptive = true
+	return t
+}
+
 func (t *throttler) WithServiceThrottle(serviceID string, r rate.Limit, burst int) *throttler {
 	return t.WithConditionThrottle(matchService(serviceID), r, burst)
 }
@@ -58,6 +82,12 @@ func (t *throttler) InjectHandlers(handlers *request.Handlers) {
 		Name: sdkHandlerRequestThrottle,
 		Fn:   t.beforeSign,
 	})
+	if t.adaptive {
+		handlers.CompleteAttempt.PushBackNamed(request.NamedHandler{
+			Name: sdkHandlerAdaptThrottle,
+			Fn:   t.afterAttempt,
+		})
+	}
 }
 
 // beforeSign is added to the Sign chain; called before each request
@@ -68,3 +98,34 @@ func (t *throttler) beforeSign(r *request.Request) {
 		}
 	}
 }
+
+// afterAttempt is added to the CompleteAttempt chain; called after each request attempt
+func (t *throttler) afterAttempt(r *request.Request) {
+	isThrottled := request.IsErrorThrottle(r.Error)
+	if !isThrottled && r.Error != nil {
+		return
+	}
+	t.adaptMutex.Lock()
+	defer t.adaptMutex.Unlock()
+	for _, conditionLimiter := range t.conditionLimiters {
+		if !conditionLimiter.condition(r) {
+			continue
+		}
+		currentLimit := conditionLimiter.limiter.Limit()
+		var newLimit rate.Limit
+		if isThrottled {
+			newLimit = currentLimit * adaptiveRateDecreaseFactor
+			if minLimit := conditionLimiter.maxLimit * adaptiveRateMinFactor; newLimit < minLimit {
+				newLimit = minLimit
+			}
+		} else {
+			newLimit = currentLimit + conditionLimiter.maxLimit*adaptiveRateIncreaseFactor
+			if newLimit > conditionLimiter.maxLimit {
+				newLimit = conditionLimiter.maxLimit
+			}
+		}
+		if newLimit != currentLimit {
+			conditionLimiter.limiter.SetLimit(newLimit)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package throttle
 
 import (
 	"context"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/time/rate"
@@ -17,6 +18,92 @@ func Test_throttler_InjectHandlers(t *testing.T) {
 	handlers := request.Handlers{}
 	throttler.InjectHandlers(&handlers)
 	assert.Equal(t, 1, handlers.Sign.Len())
+	assert.Equal(t, 0, handlers.CompleteAttempt.Len())
+}
+
+func Test_throttler_InjectHandlers_adaptive(t *testing.T) {
+	throttler := (&throttler{}).WithAdaptiveThrottle()
+	handlers := request.Handlers{}
+	throttler.InjectHandlers(&handlers)
+	assert.Equal(t, 1, handlers.Sign.Len())
+	assert.Equal(t, 1, handlers.CompleteAttempt.Len())
+}
+
+func Test_throttler_afterAttempt(t *testing.T) {
+	tests := []struct {
+		name         string
+		currentLimit rate.Limit
+		maxLimit     rate.Limit
+		matches      bool
+		err          error
+		wantLimit    rate.Limit
+	}{
+		{
+			name:         "throttled request decreases rate",
+			currentLimit: 10,
+			maxLimit:     10,
+			matches:      true,
+			err:          awserr.New("Throttling", "Rate exceeded", nil),
+			wantLimit:    5,
+		},
+		{
+			name:         "throttled request never decreases rate below minimum",
+			currentLimit: 0.7,
+			maxLimit:     10,
+			matches:      true,
+			err:          awserr.New("RequestLimitExceeded", "Request limit exceeded.", nil),
+			wantLimit:    0.625,
+		},
+		{
+			name:         "succeeded request recovers rate",
+			currentLimit: 5,
+			maxLimit:     10,
+			matches:      true,
+			wantLimit:    5.5,
+		},
+		{
+			name:         "succeeded request never recovers rate above configured rate",
+			currentLimit: 9.8,
+			maxLimit:     10,
+			matches:      true,
+			wantLimit:    10,
+		},
+		{
+			name:         "failed request keeps rate",
+			currentLimit: 5,
+			maxLimit:     10,
+			matches:      true,
+			err:          awserr.New("ValidationError", "", nil),
+			wantLimit:    5,
+		},
+		{
+			name:         "non-matching request keeps rate",
+			currentLimit: 10,
+			maxLimit:     10,
+			matches:      false,
+			err:          awserr.New("Throttling", "Rate exceeded", nil),
+			wantLimit:    10,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := rate.NewLimiter(tt.currentLimit, 1)
+			throttler := &throttler{
+				conditionLimiters: []conditionLimiter{
+					{
+						condition: func(r *request.Request) bool {
+							return tt.matches
+						},
+						limiter:  limiter,
+						maxLimit: tt.maxLimit,
+					},
+				},
+				adaptive: true,
+			}
+			throttler.afterAttempt(&request.Request{Error: tt.err})
+			assert.InDelta(t, float64(tt.wantLimit), float64(limiter.Limit()), 1e-9)
+		})
+	}
 }
 
 // Test beforeSign to check whether throttle applies correctly.
@@ -2,18 +2,21 @@ package backend
 
 import (
 	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// TODO: for pod endpoints, we currently rely on endpoints events, we might change to use pod events directly in the future.
+// TODO: for pod endpoints, we currently rely on endpointSlices events, we might change to use pod events directly in the future.
 // under current implementation with pod readinessGate enabled, an unready endpoint but not match our inclusionCriteria won't be registered,
-// and it won't turn ready due to blocked by readinessGate, and no future endpoint events will trigger.
+// and it won't turn ready due to blocked by readinessGate, and no future endpointSlices events will trigger.
 // We solve this by requeue the TGB if unready endpoints have the potential to be ready if reconcile in later time.
 
 //  EndpointResolver resolves the endpoints for specific service & service Port.
@@ -55,41 +58,56 @@ func (r *defaultEndpointResolver) ResolvePodEndpoints(ctx context.Context, svcKe
 	if err != nil {
 		return nil, false, err
 	}
-	epsKey := k8s.NamespacedName(svc) // k8s Endpoints have same name as k8s Service
-	eps := &corev1.Endpoints{}
-	if err := r.k8sClient.Get(ctx, epsKey, eps); err != nil {
+	epSliceList := &discovery.EndpointSliceList{}
+	if err := r.k8sClient.List(ctx, epSliceList,
+		client.InNamespace(svc.Namespace),
+		client.MatchingLabels{discovery.LabelServiceName: svc.Name}); err != nil {
 		return nil, false, err
 	}
 
 	containsPotentialReadyEndpoints := false
+	drainingByNodeName := make(map[string]bool)
 	var endpoints []PodEndpoint
-	for _, epSubset := range eps.Subsets {
-		for _, epPort := range epSubset.Ports {
+	for _, epSlice := range epSliceList.Items {
+		if epSlice.AddressType != resolveOpts.EndpointAddressType {
+			continue
+		}
+		for _, epPort := range epSlice.Ports {
 			// servicePort.Name is optional if there is only one port
-			if svcPort.Name != "" && svcPort.Name != epPort.Name {
+			if svcPort.Name != "" && svcPort.Name != awssdk.StringValue(epPort.Name) {
+				continue
+			}
+			if epPort.Port == nil {
 				continue
 			}
 
-			for _, epAddr := range epSubset.Addresses {
-				if epAddr.TargetRef == nil || epAddr.TargetRef.Kind != "Pod" {
+			for _, ep := range epSlice.Endpoints {
+				if ep.TargetRef == nil || ep.TargetRef.Kind != "Pod" || len(ep.Addresses) == 0 {
 					continue
 				}
-				pod, exists, err := r.findPodByReference(ctx, svc.Namespace, *epAddr.TargetRef)
-				if err != nil {
-					return nil, false, err
-				}
-				if !exists {
-					return nil, false, errors.New("couldn't find podInfo for ready endpoint")
+				if isEndpointReady(ep) {
+					pod, exists, err := r.findPodByReference(ctx, svc.Namespace, *ep.TargetRef)
+					if err != nil {
+						return nil, false, err
+					}
+					if !exists {
+						return nil, false, errors.New("couldn't find podInfo for ready endpoint")
+					}
+					if resolveOpts.ExcludeDrainingNodes {
+						draining, err := r.isPodOnDrainingNode(ctx, pod, drainingByNodeName)
+						if err != nil {
+							return nil, false, err
+						}
+						if draining {
+							continue
+						}
+					}
+					endpoints = append(endpoints, buildPodEndpoint(pod, ep, *epPort.Port))
+					continue
 				}
-				endpoints = append(endpoints, buildPodEndpoint(pod, epAddr, epPort))
-			}
 
-			if len(resolveOpts.PodReadinessGates) != 0 {
-				for _, epAddr := range epSubset.NotReadyAddresses {
-					if epAddr.TargetRef == nil || epAddr.TargetRef.Kind != "Pod" {
-						continue
-					}
-					pod, exists, err := r.findPodByReference(ctx, svc.Namespace, *epAddr.TargetRef)
+				if len(resolveOpts.PodReadinessGates) != 0 {
+					pod, exists, err := r.findPodByReference(ctx, svc.Namespace, *ep.TargetRef)
 					if err != nil {
 						return nil, false, err
 					}
@@ -104,7 +122,16 @@ func (r *defaultEndpointResolver) ResolvePodEndpoints(ctx context.Context, svcKe
 						containsPotentialReadyEndpoints = true
 						continue
 					}
-					endpoints = append(endpoints, buildPodEndpoint(pod, epAddr, epPort))
+					if resolveOpts.ExcludeDrainingNodes {
+						draining, err := r.isPodOnDrainingNode(ctx, pod, drainingByNodeName)
+						if err != nil {
+							return nil, false, err
+						}
+						if draining {
+							continue
+						}
+					}
+					endpoints = append(endpoints, buildPodEndpoint(pod, ep, *epPort.Port))
 				}
 			}
 		}
@@ -125,6 +152,9 @@ func (r *defaultEndpointResolver) ResolveNodePortEndpoints(ctx context.Context,
 		return nil, errors.Errorf("service type must be either 'NodePort' or 'LoadBalancer': %v", svcKey)
 	}
 	svcNodePort := svcPort.NodePort
+	if svcNodePort == 0 {
+		return nil, errors.Errorf("nodePort isn't allocated for port %v of service: %v", port.String(), svcKey)
+	}
 	nodeList := &corev1.NodeList{}
 	if err := r.k8sClient.List(ctx, nodeList, client.MatchingLabelsSelector{Selector: resolveOpts.NodeSelector}); err != nil {
 		return nil, err
@@ -133,7 +163,10 @@ func (r *defaultEndpointResolver) ResolveNodePortEndpoints(ctx context.Context,
 	var endpoints []NodePortEndpoint
 	for i := range nodeList.Items {
 		node := &nodeList.Items[i]
-		if !k8s.IsNodeReady(node) {
+		if !k8s.IsNodeReady(node) || IsNodeExcludedByTaints(node, resolveOpts.ExcludedNodeTaintKeys) {
+			continue
+		}
+		if resolveOpts.ExcludeDrainingNodes && k8s.IsNodeDraining(node) {
 			continue
 		}
 		instanceID, err := k8s.ExtractNodeInstanceID(node)
@@ -164,11 +197,41 @@ func (r *defaultEndpointResolver) findPodByReference(ctx context.Context, namesp
 	return r.podInfoRepo.Get(ctx, podKey)
 }
 
-func buildPodEndpoint(pod k8s.PodInfo, epAddr corev1.EndpointAddress, epPort corev1.EndpointPort) PodEndpoint {
+// isEndpointReady checks whether an endpoint within EndpointSlice is ready.
+// an endpoint without ready condition should be interpreted as ready.
+func isEndpointReady(ep discovery.Endpoint) bool {
+	return ep.Conditions.Ready == nil || *ep.Conditions.Ready
+}
+
+// isPodOnDrainingNode checks whether pod is running on a draining node, drainingByNodeName memorizes results of nodes that are checked.
+func (r *defaultEndpointResolver) isPodOnDrainingNode(ctx context.Context, pod k8s.PodInfo, drainingByNodeName map[string]bool) (bool, error) {
+	if pod.NodeName == "" {
+		return false, nil
+	}
+	if draining, ok := drainingByNodeName[pod.NodeName]; ok {
+		return draining, nil
+	}
+	node := &corev1.Node{}
+	if err := r.k8sClient.Get(ctx, types.NamespacedName{Name: pod.NodeName}, node); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return false, err
+		}
+		// nodes that are already gone leave the pods to be removed from endpoints.
+		drainingByNodeName[pod.NodeName] = false
+		return false, nil
+	}
+	draining := k8s.IsNodeDraining(node)
+	drainingByNodeName[pod.NodeName] = draining
+	return draining, nil
+}
+
+func buildPodEndpoint(pod k8s.PodInfo, ep discovery.Endpoint, port int32) PodEndpoint {
+	// addresses within an endpoint are fungible, and the first one is used by consumers of EndpointSlice.
 	return PodEndpoint{
-		IP:   epAddr.IP,
-		Port: int64(epPort.Port),
+		IP:   ep.Addresses[0],
+		Port: int64(port),
 		Pod:  pod,
+		Zone: ep.Topology[corev1.LabelZoneFailureDomainStable],
 	}
 }
 
@@ -3,11 +3,13 @@ package backend
 import (
 	"context"
 	"errors"
+	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -131,238 +133,355 @@ func Test_defaultEndpointResolver_ResolvePodEndpoints(t *testing.T) {
 			},
 		},
 	}
-	ep1A := &corev1.Endpoints{
+	epSlice1A := &discovery.EndpointSlice{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: testNS,
-			Name:      "svc-1",
+			Name:      "svc-1-a",
+			Labels: map[string]string{
+				discovery.LabelServiceName: "svc-1",
+			},
 		},
-		Subsets: []corev1.EndpointSubset{
+		AddressType: discovery.AddressTypeIPv4,
+		Ports: []discovery.EndpointPort{
 			{
-				Ports: []corev1.EndpointPort{
-					{
-						Name: "http",
-						Port: 8080,
-					},
+				Name: awssdk.String("http"),
+				Port: awssdk.Int32(8080),
+			},
+		},
+		Endpoints: []discovery.Endpoint{
+			{
+				Addresses: []string{pod1.PodIP},
+				Conditions: discovery.EndpointConditions{
+					Ready: awssdk.Bool(true),
 				},
-				Addresses: []corev1.EndpointAddress{
-					{
-						IP: pod1.PodIP,
-						TargetRef: &corev1.ObjectReference{
-							Kind:      "Pod",
-							Namespace: pod1.Key.Namespace,
-							Name:      pod1.Key.Name,
-						},
-					},
-					{
-						IP: pod2.PodIP,
-						TargetRef: &corev1.ObjectReference{
-							Kind:      "Pod",
-							Namespace: pod2.Key.Namespace,
-							Name:      pod2.Key.Name,
-						},
-					},
+				TargetRef: &corev1.ObjectReference{
+					Kind:      "Pod",
+					Namespace: pod1.Key.Namespace,
+					Name:      pod1.Key.Name,
 				},
-				NotReadyAddresses: []corev1.EndpointAddress{
-					{
-						IP: pod3.PodIP,
-						TargetRef: &corev1.ObjectReference{
-							Kind:      "Pod",
-							Namespace: pod3.Key.Namespace,
-							Name:      pod3.Key.Name,
-						},
-					},
-					{
-						IP: pod4.PodIP,
-						TargetRef: &corev1.ObjectReference{
-							Kind:      "Pod",
-							Namespace: pod4.Key.Namespace,
-							Name:      pod4.Key.Name,
-						},
-					},
+			},
+			{
+				Addresses: []string{pod2.PodIP},
+				Conditions: discovery.EndpointConditions{
+					Ready: awssdk.Bool(true),
+				},
+				TargetRef: &corev1.ObjectReference{
+					Kind:      "Pod",
+					Namespace: pod2.Key.Namespace,
+					Name:      pod2.Key.Name,
+				},
+			},
+			{
+				Addresses: []string{pod3.PodIP},
+				Conditions: discovery.EndpointConditions{
+					Ready: awssdk.Bool(false),
+				},
+				TargetRef: &corev1.ObjectReference{
+					Kind:      "Pod",
+					Namespace: pod3.Key.Namespace,
+					Name:      pod3.Key.Name,
+				},
+			},
+			{
+				Addresses: []string{pod4.PodIP},
+				Conditions: discovery.EndpointConditions{
+					Ready: awssdk.Bool(false),
+				},
+				TargetRef: &corev1.ObjectReference{
+					Kind:      "Pod",
+					Namespace: pod4.Key.Namespace,
+					Name:      pod4.Key.Name,
 				},
 			},
 		},
 	}
-	ep1B := &corev1.Endpoints{
+	epSlice1B1 := &discovery.EndpointSlice{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: testNS,
-			Name:      "svc-1",
+			Name:      "svc-1-b1",
+			Labels: map[string]string{
+				discovery.LabelServiceName: "svc-1",
+			},
 		},
-		Subsets: []corev1.EndpointSubset{
+		AddressType: discovery.AddressTypeIPv4,
+		Ports: []discovery.EndpointPort{
 			{
-				Ports: []corev1.EndpointPort{
-					{
-						Name: "http",
-						Port: 8080,
-					},
+				Name: awssdk.String("http"),
+				Port: awssdk.Int32(8080),
+			},
+		},
+		Endpoints: []discovery.Endpoint{
+			{
+				Addresses: []string{pod1.PodIP},
+				Conditions: discovery.EndpointConditions{
+					Ready: awssdk.Bool(true),
 				},
-				Addresses: []corev1.EndpointAddress{
-					{
-						IP: pod1.PodIP,
-						TargetRef: &corev1.ObjectReference{
-							Kind:      "Pod",
-							Namespace: pod1.Key.Namespace,
-							Name:      pod1.Key.Name,
-						},
-					},
+				TargetRef: &corev1.ObjectReference{
+					Kind:      "Pod",
+					Namespace: pod1.Key.Namespace,
+					Name:      pod1.Key.Name,
 				},
-				NotReadyAddresses: []corev1.EndpointAddress{
-					{
-						IP: pod3.PodIP,
-						TargetRef: &corev1.ObjectReference{
-							Kind:      "Pod",
-							Namespace: pod3.Key.Namespace,
-							Name:      pod3.Key.Name,
-						},
-					},
+			},
+			{
+				Addresses: []string{pod3.PodIP},
+				Conditions: discovery.EndpointConditions{
+					Ready: awssdk.Bool(false),
+				},
+				TargetRef: &corev1.ObjectReference{
+					Kind:      "Pod",
+					Namespace: pod3.Key.Namespace,
+					Name:      pod3.Key.Name,
 				},
 			},
+		},
+	}
+	epSlice1B2 := &discovery.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNS,
+			Name:      "svc-1-b2",
+			Labels: map[string]string{
+				discovery.LabelServiceName: "svc-1",
+			},
+		},
+		AddressType: discovery.AddressTypeIPv4,
+		Ports: []discovery.EndpointPort{
 			{
-				Ports: []corev1.EndpointPort{
-					{
-						Name: "http",
-						Port: 8080,
-					},
+				Name: awssdk.String("http"),
+				Port: awssdk.Int32(8080),
+			},
+		},
+		Endpoints: []discovery.Endpoint{
+			{
+				Addresses: []string{pod2.PodIP},
+				Conditions: discovery.EndpointConditions{
+					Ready: awssdk.Bool(true),
 				},
-				Addresses: []corev1.EndpointAddress{
-					{
-						IP: pod2.PodIP,
-						TargetRef: &corev1.ObjectReference{
-							Kind:      "Pod",
-							Namespace: pod2.Key.Namespace,
-							Name:      pod2.Key.Name,
-						},
-					},
+				TargetRef: &corev1.ObjectReference{
+					Kind:      "Pod",
+					Namespace: pod2.Key.Namespace,
+					Name:      pod2.Key.Name,
 				},
-				NotReadyAddresses: []corev1.EndpointAddress{
-					{
-						IP: pod4.PodIP,
-						TargetRef: &corev1.ObjectReference{
-							Kind:      "Pod",
-							Namespace: pod4.Key.Namespace,
-							Name:      pod4.Key.Name,
-						},
-					},
+			},
+			{
+				Addresses: []string{pod4.PodIP},
+				Conditions: discovery.EndpointConditions{
+					Ready: awssdk.Bool(false),
+				},
+				TargetRef: &corev1.ObjectReference{
+					Kind:      "Pod",
+					Namespace: pod4.Key.Namespace,
+					Name:      pod4.Key.Name,
 				},
 			},
 		},
 	}
-	ep1C := &corev1.Endpoints{
+	epSlice1C := &discovery.EndpointSlice{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: testNS,
-			Name:      "svc-1",
+			Name:      "svc-1-c",
+			Labels: map[string]string{
+				discovery.LabelServiceName: "svc-1",
+			},
 		},
-		Subsets: []corev1.EndpointSubset{
+		AddressType: discovery.AddressTypeIPv4,
+		Ports: []discovery.EndpointPort{
 			{
-				Ports: []corev1.EndpointPort{
-					{
-						Name: "http",
-						Port: 8080,
-					},
-					{
-						Name: "https",
-						Port: 8080,
-					},
+				Name: awssdk.String("http"),
+				Port: awssdk.Int32(8080),
+			},
+			{
+				Name: awssdk.String("https"),
+				Port: awssdk.Int32(8080),
+			},
+		},
+		Endpoints: []discovery.Endpoint{
+			{
+				Addresses: []string{pod1.PodIP},
+				Conditions: discovery.EndpointConditions{
+					Ready: awssdk.Bool(true),
 				},
-				Addresses: []corev1.EndpointAddress{
-					{
-						IP: pod1.PodIP,
-						TargetRef: &corev1.ObjectReference{
-							Kind:      "Pod",
-							Namespace: pod1.Key.Namespace,
-							Name:      pod1.Key.Name,
-						},
-					},
-					{
-						IP: pod2.PodIP,
-						TargetRef: &corev1.ObjectReference{
-							Kind:      "Pod",
-							Namespace: pod2.Key.Namespace,
-							Name:      pod2.Key.Name,
-						},
-					},
+				TargetRef: &corev1.ObjectReference{
+					Kind:      "Pod",
+					Namespace: pod1.Key.Namespace,
+					Name:      pod1.Key.Name,
 				},
-				NotReadyAddresses: []corev1.EndpointAddress{
-					{
-						IP: pod3.PodIP,
-						TargetRef: &corev1.ObjectReference{
-							Kind:      "Pod",
-							Namespace: pod3.Key.Namespace,
-							Name:      pod3.Key.Name,
-						},
-					},
-					{
-						IP: pod4.PodIP,
-						TargetRef: &corev1.ObjectReference{
-							Kind:      "Pod",
-							Namespace: pod4.Key.Namespace,
-							Name:      pod4.Key.Name,
-						},
-					},
+			},
+			{
+				Addresses: []string{pod2.PodIP},
+				Conditions: discovery.EndpointConditions{
+					Ready: awssdk.Bool(true),
+				},
+				TargetRef: &corev1.ObjectReference{
+					Kind:      "Pod",
+					Namespace: pod2.Key.Namespace,
+					Name:      pod2.Key.Name,
+				},
+			},
+			{
+				Addresses: []string{pod3.PodIP},
+				Conditions: discovery.EndpointConditions{
+					Ready: awssdk.Bool(false),
+				},
+				TargetRef: &corev1.ObjectReference{
+					Kind:      "Pod",
+					Namespace: pod3.Key.Namespace,
+					Name:      pod3.Key.Name,
+				},
+			},
+			{
+				Addresses: []string{pod4.PodIP},
+				Conditions: discovery.EndpointConditions{
+					Ready: awssdk.Bool(false),
+				},
+				TargetRef: &corev1.ObjectReference{
+					Kind:      "Pod",
+					Namespace: pod4.Key.Namespace,
+					Name:      pod4.Key.Name,
 				},
 			},
 		},
 	}
-	ep1D := &corev1.Endpoints{
+	epSlice1D := &discovery.EndpointSlice{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: testNS,
-			Name:      "svc-1",
+			Name:      "svc-1-d",
+			Labels: map[string]string{
+				discovery.LabelServiceName: "svc-1",
+			},
 		},
-		Subsets: []corev1.EndpointSubset{
+		AddressType: discovery.AddressTypeIPv4,
+		Ports: []discovery.EndpointPort{
 			{
-				Ports: []corev1.EndpointPort{
-					{
-						Name: "http",
-						Port: 8080,
-					},
+				Name: awssdk.String("http"),
+				Port: awssdk.Int32(8080),
+			},
+		},
+		Endpoints: []discovery.Endpoint{
+			{
+				Addresses: []string{pod1.PodIP},
+				Conditions: discovery.EndpointConditions{
+					Ready: awssdk.Bool(true),
 				},
-				Addresses: []corev1.EndpointAddress{
-					{
-						IP: pod1.PodIP,
-						TargetRef: &corev1.ObjectReference{
-							Kind:      "Pod",
-							Namespace: pod1.Key.Namespace,
-							Name:      pod1.Key.Name,
-						},
-					},
-					{
-						IP: pod2.PodIP,
-						TargetRef: &corev1.ObjectReference{
-							Kind:      "Pod",
-							Namespace: pod2.Key.Namespace,
-							Name:      pod2.Key.Name,
-						},
-					},
+				TargetRef: &corev1.ObjectReference{
+					Kind:      "Pod",
+					Namespace: pod1.Key.Namespace,
+					Name:      pod1.Key.Name,
 				},
-				NotReadyAddresses: []corev1.EndpointAddress{
-					{
-						IP: pod3.PodIP,
-						TargetRef: &corev1.ObjectReference{
-							Kind:      "Pod",
-							Namespace: pod3.Key.Namespace,
-							Name:      pod3.Key.Name,
-						},
-					},
-					{
-						IP: pod4.PodIP,
-						TargetRef: &corev1.ObjectReference{
-							Kind:      "Pod",
-							Namespace: pod4.Key.Namespace,
-							Name:      pod4.Key.Name,
-						},
-					},
-					{
-						IP: pod5.PodIP,
-						TargetRef: &corev1.ObjectReference{
-							Kind:      "Pod",
-							Namespace: pod5.Key.Namespace,
-							Name:      pod5.Key.Name,
-						},
-					},
+			},
+			{
+				Addresses: []string{pod2.PodIP},
+				Conditions: discovery.EndpointConditions{
+					Ready: awssdk.Bool(true),
+				},
+				TargetRef: &corev1.ObjectReference{
+					Kind:      "Pod",
+					Namespace: pod2.Key.Namespace,
+					Name:      pod2.Key.Name,
+				},
+			},
+			{
+				Addresses: []string{pod3.PodIP},
+				Conditions: discovery.EndpointConditions{
+					Ready: awssdk.Bool(false),
+				},
+				TargetRef: &corev1.ObjectReference{
+					Kind:      "Pod",
+					Namespace: pod3.Key.Namespace,
+					Name:      pod3.Key.Name,
+				},
+			},
+			{
+				Addresses: []string{pod4.PodIP},
+				Conditions: discovery.EndpointConditions{
+					Ready: awssdk.Bool(false),
+				},
+				TargetRef: &corev1.ObjectReference{
+					Kind:      "Pod",
+					Namespace: pod4.Key.Namespace,
+					Name:      pod4.Key.Name,
+				},
+			},
+			{
+				Addresses: []string{pod5.PodIP},
+				Conditions: discovery.EndpointConditions{
+					Ready: awssdk.Bool(false),
+				},
+				TargetRef: &corev1.ObjectReference{
+					Kind:      "Pod",
+					Namespace: pod5.Key.Namespace,
+					Name:      pod5.Key.Name,
+				},
+			},
+		},
+	}
+	pod6 := k8s.PodInfo{
+		Key: types.NamespacedName{Namespace: testNS, Name: "pod-6"},
+		UID: "pod-uuid-6",
+		Conditions: []corev1.PodCondition{
+			{
+				Type:   corev1.PodReady,
+				Status: corev1.ConditionTrue,
+			},
+			{
+				Type:   corev1.ContainersReady,
+				Status: corev1.ConditionTrue,
+			},
+		},
+		PodIP: "2600:1f14:f8c:2700::1",
+	}
+	epSlice1E := &discovery.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNS,
+			Name:      "svc-1-e",
+			Labels: map[string]string{
+				discovery.LabelServiceName: "svc-1",
+			},
+		},
+		AddressType: discovery.AddressTypeIPv6,
+		Ports: []discovery.EndpointPort{
+			{
+				Name: awssdk.String("http"),
+				Port: awssdk.Int32(8080),
+			},
+		},
+		Endpoints: []discovery.Endpoint{
+			{
+				Addresses: []string{pod6.PodIP},
+				Conditions: discovery.EndpointConditions{
+					Ready: awssdk.Bool(true),
+				},
+				TargetRef: &corev1.ObjectReference{
+					Kind:      "Pod",
+					Namespace: pod6.Key.Namespace,
+					Name:      pod6.Key.Name,
+				},
+			},
+		},
+	}
+
+	drainingNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-draining",
+		},
+		Spec: corev1.NodeSpec{
+			Unschedulable: true,
+			Taints: []corev1.Taint{
+				{
+					Key:    "aws-node-termination-handler/spot-itn",
+					Effect: corev1.TaintEffectNoSchedule,
 				},
 			},
 		},
 	}
+	schedulableNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-schedulable",
+		},
+	}
+	pod1OnDrainingNode := pod1
+	pod1OnDrainingNode.NodeName = drainingNode.Name
+	pod2OnSchedulableNode := pod2
+	pod2OnSchedulableNode.NodeName = schedulableNode.Name
 
 	type podInfoRepoGetCall struct {
 		key    types.NamespacedName
@@ -371,8 +490,9 @@ func Test_defaultEndpointResolver_ResolvePodEndpoints(t *testing.T) {
 		err    error
 	}
 	type env struct {
-		services      []*corev1.Service
-		endpointsList []*corev1.Endpoints
+		nodes    []*corev1.Node
+		services []*corev1.Service
+		epSlices []*discovery.EndpointSlice
 	}
 	type fields struct {
 		podInfoRepoGetCalls []podInfoRepoGetCall
@@ -394,8 +514,8 @@ func Test_defaultEndpointResolver_ResolvePodEndpoints(t *testing.T) {
 		{
 			name: "only ready pods will be included by default",
 			env: env{
-				services:      []*corev1.Service{svc1},
-				endpointsList: []*corev1.Endpoints{ep1A},
+				services: []*corev1.Service{svc1},
+				epSlices: []*discovery.EndpointSlice{epSlice1A},
 			},
 			fields: fields{
 				podInfoRepoGetCalls: []podInfoRepoGetCall{
@@ -433,8 +553,8 @@ func Test_defaultEndpointResolver_ResolvePodEndpoints(t *testing.T) {
 		{
 			name: "unready only be included if it have readinessGate and containerReady",
 			env: env{
-				services:      []*corev1.Service{svc1},
-				endpointsList: []*corev1.Endpoints{ep1A},
+				services: []*corev1.Service{svc1},
+				epSlices: []*discovery.EndpointSlice{epSlice1A},
 			},
 			fields: fields{
 				podInfoRepoGetCalls: []podInfoRepoGetCall{
@@ -485,10 +605,10 @@ func Test_defaultEndpointResolver_ResolvePodEndpoints(t *testing.T) {
 			wantContainsPotentialReadyEndpoints: false,
 		},
 		{
-			name: "endpoints with multiple subsets should work as expected",
+			name: "multiple endpointSlices should work as expected",
 			env: env{
-				services:      []*corev1.Service{svc1},
-				endpointsList: []*corev1.Endpoints{ep1B},
+				services: []*corev1.Service{svc1},
+				epSlices: []*discovery.EndpointSlice{epSlice1B1, epSlice1B2},
 			},
 			fields: fields{
 				podInfoRepoGetCalls: []podInfoRepoGetCall{
@@ -539,10 +659,10 @@ func Test_defaultEndpointResolver_ResolvePodEndpoints(t *testing.T) {
 			wantContainsPotentialReadyEndpoints: false,
 		},
 		{
-			name: "endpoints with multiple ports should work as expected",
+			name: "endpointSlices with multiple ports should work as expected",
 			env: env{
-				services:      []*corev1.Service{svc1},
-				endpointsList: []*corev1.Endpoints{ep1C},
+				services: []*corev1.Service{svc1},
+				epSlices: []*discovery.EndpointSlice{epSlice1C},
 			},
 			fields: fields{
 				podInfoRepoGetCalls: []podInfoRepoGetCall{
@@ -595,8 +715,8 @@ func Test_defaultEndpointResolver_ResolvePodEndpoints(t *testing.T) {
 		{
 			name: "unready but not found pod will be ignored, but signal potentialReadyEndpoints",
 			env: env{
-				services:      []*corev1.Service{svc1},
-				endpointsList: []*corev1.Endpoints{ep1A},
+				services: []*corev1.Service{svc1},
+				epSlices: []*discovery.EndpointSlice{epSlice1A},
 			},
 			fields: fields{
 				podInfoRepoGetCalls: []podInfoRepoGetCall{
@@ -648,8 +768,8 @@ func Test_defaultEndpointResolver_ResolvePodEndpoints(t *testing.T) {
 		{
 			name: "unready only be included if it have readinessGate and containerReady - not containerReady will signal containsPotentialReadyEndpoints",
 			env: env{
-				services:      []*corev1.Service{svc1},
-				endpointsList: []*corev1.Endpoints{ep1D},
+				services: []*corev1.Service{svc1},
+				epSlices: []*discovery.EndpointSlice{epSlice1D},
 			},
 			fields: fields{
 				podInfoRepoGetCalls: []podInfoRepoGetCall{
@@ -704,6 +824,160 @@ func Test_defaultEndpointResolver_ResolvePodEndpoints(t *testing.T) {
 			},
 			wantContainsPotentialReadyEndpoints: true,
 		},
+		{
+			name: "only IPv4 endpointSlices will be included by default",
+			env: env{
+				services: []*corev1.Service{svc1},
+				epSlices: []*discovery.EndpointSlice{epSlice1B1, epSlice1E},
+			},
+			fields: fields{
+				podInfoRepoGetCalls: []podInfoRepoGetCall{
+					{
+						key:    pod1.Key,
+						pod:    pod1,
+						exists: true,
+					},
+				},
+			},
+			args: args{
+				svcKey: k8s.NamespacedName(svc1),
+				port:   intstr.FromString("http"),
+				opts:   nil,
+			},
+			want: []PodEndpoint{
+				{
+					IP:   "192.168.1.1",
+					Port: 8080,
+					Pod:  pod1,
+				},
+			},
+			wantContainsPotentialReadyEndpoints: false,
+		},
+		{
+			name: "only IPv6 endpointSlices will be included with IPv6 endpointAddressType",
+			env: env{
+				services: []*corev1.Service{svc1},
+				epSlices: []*discovery.EndpointSlice{epSlice1B1, epSlice1E},
+			},
+			fields: fields{
+				podInfoRepoGetCalls: []podInfoRepoGetCall{
+					{
+						key:    pod6.Key,
+						pod:    pod6,
+						exists: true,
+					},
+				},
+			},
+			args: args{
+				svcKey: k8s.NamespacedName(svc1),
+				port:   intstr.FromString("http"),
+				opts:   []EndpointResolveOption{WithEndpointAddressType(discovery.AddressTypeIPv6)},
+			},
+			want: []PodEndpoint{
+				{
+					IP:   "2600:1f14:f8c:2700::1",
+					Port: 8080,
+					Pod:  pod6,
+				},
+			},
+			wantContainsPotentialReadyEndpoints: false,
+		},
+		{
+			name: "pods on draining nodes will be included by default",
+			env: env{
+				nodes:    []*corev1.Node{drainingNode, schedulableNode},
+				services: []*corev1.Service{svc1},
+				epSlices: []*discovery.EndpointSlice{epSlice1A},
+			},
+			fields: fields{
+				podInfoRepoGetCalls: []podInfoRepoGetCall{
+					{
+						key:    pod1.Key,
+						pod:    pod1OnDrainingNode,
+						exists: true,
+					},
+					{
+						key:    pod2.Key,
+						pod:    pod2OnSchedulableNode,
+						exists: true,
+					},
+				},
+			},
+			args: args{
+				svcKey: k8s.NamespacedName(svc1),
+				port:   intstr.FromString("http"),
+				opts:   nil,
+			},
+			want: []PodEndpoint{
+				{
+					IP:   "192.168.1.1",
+					Port: 8080,
+					Pod:  pod1OnDrainingNode,
+				},
+				{
+					IP:   "192.168.1.2",
+					Port: 8080,
+					Pod:  pod2OnSchedulableNode,
+				},
+			},
+			wantContainsPotentialReadyEndpoints: false,
+		},
+		{
+			name: "pods on draining nodes will be excluded with excludeDrainingNodes",
+			env: env{
+				nodes:    []*corev1.Node{drainingNode, schedulableNode},
+				services: []*corev1.Service{svc1},
+				epSlices: []*discovery.EndpointSlice{epSlice1A},
+			},
+			fields: fields{
+				podInfoRepoGetCalls: []podInfoRepoGetCall{
+					{
+						key:    pod1.Key,
+						pod:    pod1OnDrainingNode,
+						exists: true,
+					},
+					{
+						key:    pod2.Key,
+						pod:    pod2OnSchedulableNode,
+						exists: true,
+					},
+				},
+			},
+			args: args{
+				svcKey: k8s.NamespacedName(svc1),
+				port:   intstr.FromString("http"),
+				opts:   []EndpointResolveOption{WithExcludeDrainingNodes(true)},
+			},
+			want: []PodEndpoint{
+				{
+					IP:   "192.168.1.2",
+					Port: 8080,
+					Pod:  pod2OnSchedulableNode,
+				},
+			},
+			wantContainsPotentialReadyEndpoints: false,
+		},
+		{
+			name: "ready endpoint without podInfo is an error",
+			env: env{
+				services: []*corev1.Service{svc1},
+				epSlices: []*discovery.EndpointSlice{epSlice1B1},
+			},
+			fields: fields{
+				podInfoRepoGetCalls: []podInfoRepoGetCall{
+					{
+						key:    pod1.Key,
+						exists: false,
+					},
+				},
+			},
+			args: args{
+				svcKey: k8s.NamespacedName(svc1),
+				port:   intstr.FromString("http"),
+				opts:   nil,
+			},
+			wantErr: errors.New("couldn't find podInfo for ready endpoint"),
+		},
 	}
 
 	for _, tt := range tests {
@@ -720,11 +994,14 @@ func Test_defaultEndpointResolver_ResolvePodEndpoints(t *testing.T) {
 			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
 
 			ctx := context.Background()
+			for _, node := range tt.env.nodes {
+				assert.NoError(t, k8sClient.Create(ctx, node.DeepCopy()))
+			}
 			for _, svc := range tt.env.services {
 				assert.NoError(t, k8sClient.Create(ctx, svc.DeepCopy()))
 			}
-			for _, endpoints := range tt.env.endpointsList {
-				assert.NoError(t, k8sClient.Create(ctx, endpoints.DeepCopy()))
+			for _, epSlice := range tt.env.epSlices {
+				assert.NoError(t, k8sClient.Create(ctx, epSlice.DeepCopy()))
 			}
 
 			r := &defaultEndpointResolver{
@@ -829,6 +1106,56 @@ func Test_defaultEndpointResolver_ResolveNodePortEndpoints(t *testing.T) {
 			},
 		},
 	}
+	node5 := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-5",
+			Labels: map[string]string{
+				"labelA": "valueA",
+			},
+		},
+		Spec: corev1.NodeSpec{
+			ProviderID: "aws:///us-west-2b/i-abcdefg5",
+			Taints: []corev1.Taint{
+				{
+					Key:    "example.com/draining",
+					Effect: corev1.TaintEffectNoSchedule,
+				},
+			},
+		},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{
+				{
+					Type:   corev1.NodeReady,
+					Status: corev1.ConditionTrue,
+				},
+			},
+		},
+	}
+	node6 := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-6",
+			Labels: map[string]string{
+				"labelA": "valueA",
+			},
+		},
+		Spec: corev1.NodeSpec{
+			ProviderID: "aws:///us-west-2b/i-abcdefg6",
+			Taints: []corev1.Taint{
+				{
+					Key:    "aws-node-termination-handler/spot-itn",
+					Effect: corev1.TaintEffectNoSchedule,
+				},
+			},
+		},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{
+				{
+					Type:   corev1.NodeReady,
+					Status: corev1.ConditionTrue,
+				},
+			},
+		},
+	}
 	svc1 := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: testNS,
@@ -871,6 +1198,21 @@ func Test_defaultEndpointResolver_ResolveNodePortEndpoints(t *testing.T) {
 			},
 		},
 	}
+	svc3 := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNS,
+			Name:      "svc-3",
+		},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeLoadBalancer,
+			Ports: []corev1.ServicePort{
+				{
+					Name: "http",
+					Port: 80,
+				},
+			},
+		},
+	}
 
 	type env struct {
 		nodes    []*corev1.Node
@@ -944,6 +1286,55 @@ func Test_defaultEndpointResolver_ResolveNodePortEndpoints(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "choose every ready node with tainted nodes excluded",
+			env: env{
+				nodes:    []*corev1.Node{node1, node2, node3, node4, node5},
+				services: []*corev1.Service{svc1},
+			},
+			args: args{
+				svcKey: k8s.NamespacedName(svc1),
+				port:   intstr.FromString("http"),
+				opts: []EndpointResolveOption{
+					WithNodeSelector(labels.Set{"labelA": "valueA"}.AsSelectorPreValidated()),
+					WithExcludedNodeTaintKeys([]string{"example.com/draining"}),
+				},
+			},
+			want: []NodePortEndpoint{
+				{
+					InstanceID: "i-abcdefg1",
+					Port:       18080,
+					Node:       node1,
+				},
+			},
+		},
+		{
+			name: "choose every ready node with draining nodes excluded",
+			env: env{
+				nodes:    []*corev1.Node{node1, node2, node3, node4, node6},
+				services: []*corev1.Service{svc1},
+			},
+			args: args{
+				svcKey: k8s.NamespacedName(svc1),
+				port:   intstr.FromString("http"),
+				opts: []EndpointResolveOption{
+					WithNodeSelector(labels.Everything()),
+					WithExcludeDrainingNodes(true),
+				},
+			},
+			want: []NodePortEndpoint{
+				{
+					InstanceID: "i-abcdefg1",
+					Port:       18080,
+					Node:       node1,
+				},
+				{
+					InstanceID: "i-abcdefg2",
+					Port:       18080,
+					Node:       node2,
+				},
+			},
+		},
 		{
 			name: "clusterIP service is not supported",
 			env: env{
@@ -957,6 +1348,19 @@ func Test_defaultEndpointResolver_ResolveNodePortEndpoints(t *testing.T) {
 			},
 			wantErr: errors.New("service type must be either 'NodePort' or 'LoadBalancer': test-ns/svc-2"),
 		},
+		{
+			name: "service port without nodePort is not supported",
+			env: env{
+				nodes:    []*corev1.Node{node1, node2, node3, node4},
+				services: []*corev1.Service{svc3},
+			},
+			args: args{
+				svcKey: k8s.NamespacedName(svc3),
+				port:   intstr.FromString("http"),
+				opts:   []EndpointResolveOption{WithNodeSelector(labels.Everything())},
+			},
+			wantErr: errors.New("nodePort isn't allocated for port http of service: test-ns/svc-3"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -993,3 +1397,48 @@ func Test_defaultEndpointResolver_ResolveNodePortEndpoints(t *testing.T) {
 		})
 	}
 }
+
+func Test_buildPodEndpoint(t *testing.T) {
+	pod := k8s.PodInfo{
+		Key: types.NamespacedName{Namespace: "default", Name: "pod-1"},
+		UID: "pod-uuid-1",
+	}
+	tests := []struct {
+		name string
+		ep   discovery.Endpoint
+		want PodEndpoint
+	}{
+		{
+			name: "endpoint with zone",
+			ep: discovery.Endpoint{
+				Addresses: []string{"192.168.1.1"},
+				Topology: map[string]string{
+					"topology.kubernetes.io/zone": "us-west-2a",
+				},
+			},
+			want: PodEndpoint{
+				IP:   "192.168.1.1",
+				Port: 8080,
+				Pod:  pod,
+				Zone: "us-west-2a",
+			},
+		},
+		{
+			name: "endpoint without zone",
+			ep: discovery.Endpoint{
+				Addresses: []string{"192.168.1.1", "192.168.1.2"},
+			},
+			want: PodEndpoint{
+				IP:   "192.168.1.1",
+				Port: 8080,
+				Pod:  pod,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildPodEndpoint(pod, tt.ep, 8080)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package backend
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1beta1"
 	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 )
@@ -14,6 +15,8 @@ type PodEndpoint struct {
 	Port int64
 	// Pod that provides this endpoint.
 	Pod k8s.PodInfo
+	// Availability zone of this endpoint, empty if unknown.
+	Zone string
 }
 
 // An endpoint provided by nodePort as traffic proxy.
@@ -32,9 +35,21 @@ type EndpointResolveOptions struct {
 	// By default, no node will be selected.
 	NodeSelector labels.Selector
 
+	// [NodePort Endpoint] nodes with a taint of any of these keys will be excluded.
+	// By default, no node is excluded by taints.
+	ExcludedNodeTaintKeys []string
+
+	// [NodePort Endpoint] [Pod Endpoint] whether to exclude nodes and pods on nodes that are drained or tainted to be terminated.
+	// By default, draining nodes are not excluded.
+	ExcludeDrainingNodes bool
+
 	// [Pod Endpoint] If pod readinessGates is defined, then pods from unready addresses with any of these readinessGates and containersReady condition will be included as well.
 	// By default, no readinessGate is specified.
 	PodReadinessGates []corev1.PodConditionType
+
+	// [Pod Endpoint] only endpoints from EndpointSlices with this addressType will be included.
+	// By default, IPv4 addressType is used.
+	EndpointAddressType discovery.AddressType
 }
 
 func (opts *EndpointResolveOptions) ApplyOptions(options []EndpointResolveOption) {
@@ -52,6 +67,20 @@ func WithNodeSelector(nodeSelector labels.Selector) EndpointResolveOption {
 	}
 }
 
+// WithExcludedNodeTaintKeys is a option that sets excludedNodeTaintKeys.
+func WithExcludedNodeTaintKeys(taintKeys []string) EndpointResolveOption {
+	return func(opts *EndpointResolveOptions) {
+		opts.ExcludedNodeTaintKeys = taintKeys
+	}
+}
+
+// WithExcludeDrainingNodes is a option that sets excludeDrainingNodes.
+func WithExcludeDrainingNodes(excludeDrainingNodes bool) EndpointResolveOption {
+	return func(opts *EndpointResolveOptions) {
+		opts.ExcludeDrainingNodes = excludeDrainingNodes
+	}
+}
+
 // WithPodReadinessGate is a option that appends podReadinessGate into EndpointResolveOptions.
 func WithPodReadinessGate(cond corev1.PodConditionType) EndpointResolveOption {
 	return func(opts *EndpointResolveOptions) {
@@ -59,10 +88,20 @@ func WithPodReadinessGate(cond corev1.PodConditionType) EndpointResolveOption {
 	}
 }
 
+// WithEndpointAddressType is a option that sets endpointAddressType.
+func WithEndpointAddressType(addressType discovery.AddressType) EndpointResolveOption {
+	return func(opts *EndpointResolveOptions) {
+		opts.EndpointAddressType = addressType
+	}
+}
+
 // defaultEndpointResolveOptions returns the default value for EndpointResolveOptions.
 func defaultEndpointResolveOptions() EndpointResolveOptions {
 	return EndpointResolveOptions{
-		NodeSelector:      labels.Nothing(),
-		PodReadinessGates: nil,
+		NodeSelector:          labels.Nothing(),
+		ExcludedNodeTaintKeys: nil,
+		ExcludeDrainingNodes:  false,
+		PodReadinessGates:     nil,
+		EndpointAddressType:   discovery.AddressTypeIPv4,
 	}
 }
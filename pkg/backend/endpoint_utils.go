@@ -1,6 +1,8 @@
 package backend
 
 import (
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
@@ -38,8 +40,28 @@ var (
 )
 
 // GetTrafficProxyNodeSelector returns the trafficProxy node label selector for specific targetGroupBinding.
-func GetTrafficProxyNodeSelector(_ *elbv2api.TargetGroupBinding) labels.Selector {
-	// TODO: consider expose nodeSelector on targetGroupBindings, so users can optionally specify different nodes for different tgbs.
+// nodes are further selected by the nodeSelector of targetGroupBinding if specified.
+func GetTrafficProxyNodeSelector(tgb *elbv2api.TargetGroupBinding) (labels.Selector, error) {
 	selector, _ := metav1.LabelSelectorAsSelector(&defaultTrafficProxyNodeLabelSelector)
-	return selector
+	if tgb.Spec.NodeSelector == nil {
+		return selector, nil
+	}
+	tgbSelector, err := metav1.LabelSelectorAsSelector(tgb.Spec.NodeSelector)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid nodeSelector")
+	}
+	tgbRequirements, _ := tgbSelector.Requirements()
+	return selector.Add(tgbRequirements...), nil
+}
+
+// IsNodeExcludedByTaints checks whether node has a taint with any of excludedTaintKeys.
+func IsNodeExcludedByTaints(node *corev1.Node, excludedTaintKeys []string) bool {
+	for _, taint := range node.Spec.Taints {
+		for _, taintKey := range excludedTaintKeys {
+			if taint.Key == taintKey {
+				return true
+			}
+		}
+	}
+	return false
 }
@@ -0,0 +1,159 @@
+package backend
+
+import (
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"testing"
+)
+
+func TestGetTrafficProxyNodeSelector(t *testing.T) {
+	tests := []struct {
+		name           string
+		tgb            *elbv2api.TargetGroupBinding
+		matchingLabels []labels.Set
+		excludedLabels []labels.Set
+		wantErr        error
+	}{
+		{
+			name: "nodeSelector is not set",
+			tgb:  &elbv2api.TargetGroupBinding{},
+			matchingLabels: []labels.Set{
+				{},
+				{"node-group": "ingress"},
+			},
+			excludedLabels: []labels.Set{
+				{"node-role.kubernetes.io/master": ""},
+				{"alpha.service-controller.kubernetes.io/exclude-balancer": "true"},
+			},
+		},
+		{
+			name: "nodeSelector is set",
+			tgb: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					NodeSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"node-group": "ingress",
+						},
+					},
+				},
+			},
+			matchingLabels: []labels.Set{
+				{"node-group": "ingress"},
+			},
+			excludedLabels: []labels.Set{
+				{},
+				{"node-group": "batch"},
+				{"node-group": "ingress", "node-role.kubernetes.io/master": ""},
+			},
+		},
+		{
+			name: "nodeSelector is invalid",
+			tgb: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					NodeSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{
+								Key:      "node-group",
+								Operator: "Equals",
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("invalid nodeSelector: \"Equals\" is not a valid pod selector operator"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetTrafficProxyNodeSelector(tt.tgb)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			for _, nodeLabels := range tt.matchingLabels {
+				assert.True(t, got.Matches(nodeLabels), "expect match %v", nodeLabels)
+			}
+			for _, nodeLabels := range tt.excludedLabels {
+				assert.False(t, got.Matches(nodeLabels), "expect no match %v", nodeLabels)
+			}
+		})
+	}
+}
+
+func TestIsNodeExcludedByTaints(t *testing.T) {
+	tests := []struct {
+		name              string
+		node              *corev1.Node
+		excludedTaintKeys []string
+		want              bool
+	}{
+		{
+			name: "node without taints",
+			node: &corev1.Node{},
+			excludedTaintKeys: []string{
+				"example.com/draining",
+			},
+			want: false,
+		},
+		{
+			name: "node with excluded taint",
+			node: &corev1.Node{
+				Spec: corev1.NodeSpec{
+					Taints: []corev1.Taint{
+						{
+							Key:    "example.com/draining",
+							Effect: corev1.TaintEffectNoExecute,
+						},
+					},
+				},
+			},
+			excludedTaintKeys: []string{
+				"example.com/draining",
+			},
+			want: true,
+		},
+		{
+			name: "node with other taints",
+			node: &corev1.Node{
+				Spec: corev1.NodeSpec{
+					Taints: []corev1.Taint{
+						{
+							Key:    "example.com/dedicated",
+							Effect: corev1.TaintEffectNoSchedule,
+						},
+					},
+				},
+			},
+			excludedTaintKeys: []string{
+				"example.com/draining",
+			},
+			want: false,
+		},
+		{
+			name: "no excluded taint keys",
+			node: &corev1.Node{
+				Spec: corev1.NodeSpec{
+					Taints: []corev1.Taint{
+						{
+							Key:    "example.com/draining",
+							Effect: corev1.TaintEffectNoExecute,
+						},
+					},
+				},
+			},
+			excludedTaintKeys: nil,
+			want:              false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := IsNodeExcludedByTaints(tt.node, tt.excludedTaintKeys)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -3,10 +3,15 @@ package config
 import "github.com/spf13/pflag"
 
 const (
-	flagWAFEnabled    = "enable-waf"
-	flagWAFV2Enabled  = "enable-wafv2"
-	flagShieldEnabled = "enable-shield"
-	defaultEnabled    = true
+	flagWAFEnabled                = "enable-waf"
+	flagWAFV2Enabled              = "enable-wafv2"
+	flagShieldEnabled             = "enable-shield"
+	flagZonalShiftEnabled         = "enable-zonal-shift"
+	flagIPAMEnabled               = "enable-ipam"
+	flagVPCEndpointServiceEnabled = "enable-vpc-endpoint-service"
+	flagRoute53Enabled            = "enable-route53"
+	flagRoute53HostedZoneIDs      = "route53-hosted-zone-ids"
+	defaultEnabled                = true
 )
 
 // AddonsConfig contains configuration for the addon features
@@ -17,6 +22,16 @@ type AddonsConfig struct {
 	WAFV2Enabled bool
 	// Shield addon for ALB
 	ShieldEnabled bool
+	// ZonalShift addon for ALB and NLB
+	ZonalShiftEnabled bool
+	// IPAM addon for NLB
+	IPAMEnabled bool
+	// VPC endpoint service(PrivateLink) addon for NLB
+	VPCEndpointServiceEnabled bool
+	// Route 53 addon for ALB
+	Route53Enabled bool
+	// Route53HostedZoneIDs restricts the hosted zones that Route 53 addon manages records in, all hosted zones are managed if empty.
+	Route53HostedZoneIDs []string
 }
 
 // BindFlags binds the command line flags to the fields in the config object
@@ -24,4 +39,9 @@ func (f *AddonsConfig) BindFlags(fs *pflag.FlagSet) {
 	fs.BoolVar(&f.WAFEnabled, flagWAFEnabled, defaultEnabled, "Enable WAF addon for ALB")
 	fs.BoolVar(&f.WAFV2Enabled, flagWAFV2Enabled, defaultEnabled, "Enable WAF V2 addon for ALB")
 	fs.BoolVar(&f.ShieldEnabled, flagShieldEnabled, defaultEnabled, "Enable Shield addon for ALB")
+	fs.BoolVar(&f.ZonalShiftEnabled, flagZonalShiftEnabled, false, "Enable zonal shift addon for ALB and NLB")
+	fs.BoolVar(&f.IPAMEnabled, flagIPAMEnabled, false, "Enable IPAM addon for NLB")
+	fs.BoolVar(&f.VPCEndpointServiceEnabled, flagVPCEndpointServiceEnabled, false, "Enable VPC endpoint service addon for NLB")
+	fs.BoolVar(&f.Route53Enabled, flagRoute53Enabled, false, "Enable Route 53 addon for ALB")
+	fs.StringSliceVar(&f.Route53HostedZoneIDs, flagRoute53HostedZoneIDs, nil, "IDs of hosted zones that Route 53 addon manages records in, defaults to all hosted zones")
 }
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"time"
+)
+
+const (
+	flagALBWarmPoolSize                 = "alb-warm-pool-size"
+	flagALBWarmPoolScheme               = "alb-warm-pool-scheme"
+	flagALBWarmPoolReplenishInterval    = "alb-warm-pool-replenish-interval"
+	defaultALBWarmPoolSize              = 0
+	defaultALBWarmPoolScheme            = "internet-facing"
+	defaultALBWarmPoolReplenishInterval = time.Minute
+
+	// MinALBWarmPoolReplenishInterval is the minimal interval to replenish the warm pool, which protects the AWS API quotas.
+	MinALBWarmPoolReplenishInterval = 15 * time.Second
+)
+
+// ALBWarmPoolConfig contains the configurations for the warm pool of pre-provisioned ALBs
+type ALBWarmPoolConfig struct {
+	// Number of spare ALBs kept pre-provisioned for new IngressGroups.
+	// The warm pool is disabled if it's zero.
+	Size int
+
+	// Scheme of spare ALBs, only IngressGroups of this scheme are served from the warm pool.
+	Scheme string
+
+	// Interval at which spare ALBs are replenished.
+	ReplenishInterval time.Duration
+}
+
+// BindFlags binds the command line flags to the fields in the config object
+func (cfg *ALBWarmPoolConfig) BindFlags(fs *pflag.FlagSet) {
+	fs.IntVar(&cfg.Size, flagALBWarmPoolSize, defaultALBWarmPoolSize,
+		"Number of spare ALBs kept pre-provisioned and assigned to new IngressGroups instead of creating ALBs, disabled if zero")
+	fs.StringVar(&cfg.Scheme, flagALBWarmPoolScheme, defaultALBWarmPoolScheme,
+		"Scheme of spare ALBs in the warm pool, either internet-facing or internal")
+	fs.DurationVar(&cfg.ReplenishInterval, flagALBWarmPoolReplenishInterval, defaultALBWarmPoolReplenishInterval,
+		"Interval at which spare ALBs in the warm pool are replenished")
+}
+
+// Validate the ALB warm pool configuration
+func (cfg *ALBWarmPoolConfig) Validate() error {
+	if cfg.Size < 0 {
+		return errors.Errorf("%v must not be negative", flagALBWarmPoolSize)
+	}
+	if cfg.Scheme != "internet-facing" && cfg.Scheme != "internal" {
+		return errors.Errorf("%v must be one of internet-facing or internal", flagALBWarmPoolScheme)
+	}
+	if cfg.ReplenishInterval < MinALBWarmPoolReplenishInterval {
+		return errors.Errorf("%v must be at least %v", flagALBWarmPoolReplenishInterval, MinALBWarmPoolReplenishInterval)
+	}
+	return nil
+}
@@ -3,8 +3,13 @@ package config
 import (
 	"github.com/pkg/errors"
 	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/audit"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/inject"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/tracing"
+	"text/template"
+	"time"
 )
 
 const (
@@ -12,8 +17,51 @@ const (
 	flagK8sClusterName                            = "cluster-name"
 	flagServiceMaxConcurrentReconciles            = "service-max-concurrent-reconciles"
 	flagTargetGroupBindingMaxConcurrentReconciles = "targetgroupbinding-max-concurrent-reconciles"
+	flagDeployMaxConcurrency                      = "deploy-max-concurrency"
+	flagDriftSyncPeriod                           = "drift-sync-period"
+	flagProvisionedResourcesConfigMap             = "provisioned-resources-configmap"
+	flagSGRuleDescriptionTemplate                 = "sg-rule-description-template"
+	flagEnableListenerRuleBinding                 = "enable-listener-rule-binding"
+	flagListenerRuleBindingAllowedListeners       = "listener-rule-binding-allowed-listeners"
+	flagReconcileStallTimeout                     = "reconcile-stall-timeout"
+	flagEnableTGBNetworkingInference              = "enable-tgb-networking-inference"
+	flagTargetNodeExcludedTaintKeys               = "target-node-excluded-taint-keys"
+	flagEnableDrainingNodeDeregistration          = "enable-draining-node-deregistration"
+	flagEnableStackExportEndpoint                 = "enable-stack-export-endpoint"
+	flagTargetHealthPollPeriod                    = "target-health-poll-period"
+	flagSlowReconcileThreshold                    = "slow-reconcile-threshold"
+	flagEnableCostEstimate                        = "enable-cost-estimate"
+	flagCostRates                                 = "cost-rates"
+	flagEnableRGTAPI                              = "enable-rgt-api"
+	flagSGRuleReconcileMode                       = "sg-rule-reconcile-mode"
+	flagEnableIAMPermissionsCheck                 = "enable-iam-permissions-check"
+	flagDynamicConfigConfigMap                    = "dynamic-config-configmap"
+	flagFeatureGates                              = "feature-gates"
+	flagGracefulShutdownTimeout                   = "graceful-shutdown-timeout"
+	flagResumeMarkersConfigMap                    = "resume-markers-configmap"
+	flagTargetGroupBindingAllowedIAMRoleARNs      = "targetgroupbinding-allowed-iam-role-arns"
 	defaultLogLevel                               = "info"
 	defaultMaxConcurrentReconciles                = 3
+	defaultDeployMaxConcurrency                   = 1
+	defaultDriftSyncPeriod                        = 0
+	defaultReconcileStallTimeout                  = 15 * time.Minute
+	defaultTargetHealthPollPeriod                 = 0
+	defaultSlowReconcileThreshold                 = 0
+	defaultSGRuleReconcileMode                    = SGRuleReconcileModeFull
+	defaultGracefulShutdownTimeout                = 0
+	defaultProvisionedResourcesConfigMap          = "aws-load-balancer-provisioned-resources"
+
+	// SGRuleReconcileModeFull authorizes missing permissions and revokes extra permissions of managed SecurityGroup rules.
+	SGRuleReconcileModeFull = "full"
+	// SGRuleReconcileModeAdditive only revokes extra permissions granted by the controller, the other extra permissions are reported instead of revoked.
+	SGRuleReconcileModeAdditive = "additive"
+
+	// MinDriftSyncPeriod is the minimal period to forcibly re-synchronize Ingresses and Services, which protects the AWS API quotas.
+	MinDriftSyncPeriod = 30 * time.Second
+	// MinResourceTTL is the minimal TTL of Ingresses and Services, which prevents AWS resources from being torn down right after provisioning.
+	MinResourceTTL = time.Minute
+	// MinTargetHealthPollPeriod is the minimal period to poll target health of TargetGroupBindings, which protects the AWS API quotas.
+	MinTargetHealthPollPeriod = 15 * time.Second
 )
 
 // ControllerConfig contains the controller configuration
@@ -32,11 +80,67 @@ type ControllerConfig struct {
 	IngressConfig IngressConfig
 	// Configurations for Addons feature
 	AddonsConfig AddonsConfig
+	// Configurations for the garbage collector of orphaned AWS resources
+	OrphanGCConfig OrphanGCConfig
+	// Configurations for the warm pool of pre-provisioned ALBs
+	ALBWarmPoolConfig ALBWarmPoolConfig
+	// Configurations for auditing mutations of AWS resources
+	MutationAuditConfig audit.Config
+	// Configurations for retrying failed reconcile requests
+	ReconcileBackoffConfig ReconcileBackoffConfig
+	// Configurations for tracing reconciles with OpenTelemetry
+	TracingConfig tracing.Config
 
 	// Max concurrent reconcile loops for Service objects
 	ServiceMaxConcurrentReconciles int
 	// Max concurrent reconcile loops for TargetGroupBinding objects
 	TargetGroupBindingMaxConcurrentReconciles int
+	// Max number of resources deployed concurrently for each stack
+	DeployMaxConcurrency int
+	// Period at which Ingresses and Services are forcibly re-synchronized to revert changes made outside of the controller
+	DriftSyncPeriod time.Duration
+	// Name of the ConfigMap per namespace that provisioned AWS resources of Ingresses and Services are exported into
+	ProvisionedResourcesConfigMap string
+	// Template of descriptions for rules of SecurityGroups managed by the controller
+	SGRuleDescriptionTemplate string
+	// Whether the ListenerRuleBinding controller is enabled, it requires the ListenerRuleBinding CRD installed
+	EnableListenerRuleBinding bool
+	// ARNs of listeners that ListenerRuleBindings can attach rules to
+	ListenerRuleBindingAllowedListeners []string
+	// Duration that a controller can have queued requests without finishing any reconcile before its healthz check fails
+	ReconcileStallTimeout time.Duration
+	// Whether networking rules are inferred from LoadBalancers for TargetGroupBindings without spec.networking
+	EnableTGBNetworkingInference bool
+	// Keys of taints that exclude nodes from being registered as instance targets
+	TargetNodeExcludedTaintKeys []string
+	// Whether targets on nodes that are drained or tainted to be terminated are deregistered ahead of termination
+	EnableDrainingNodeDeregistration bool
+	// Whether the latest deployed stacks of Ingresses and Services are served as Terraform or CloudFormation from the metrics server
+	EnableStackExportEndpoint bool
+	// Period at which target health of TargetGroupBindings is polled and reported on their status and metrics
+	TargetHealthPollPeriod time.Duration
+	// Duration of reconciles after which events with the per-stage timing breakdown are emitted
+	SlowReconcileThreshold time.Duration
+	// Whether monthly cost estimates of LoadBalancers are reported on Ingresses, Services and metrics
+	EnableCostEstimate bool
+	// Rates overriding the built-in us-east-1 rates of cost estimates
+	CostRates CostRateOverrides
+	// Whether LoadBalancers and TargetGroups are discovered by tags via Resource Groups Tagging API instead of ELBv2 DescribeTags
+	EnableRGTAPI bool
+	// Mode to reconcile managed SecurityGroup rules with, either full or additive
+	SGRuleReconcileMode string
+	// Whether the IAM permissions of controller's AWS credentials are verified at startup and reported by the readiness probe
+	EnableIAMPermissionsCheck bool
+	// Namespace/name of the ConfigMap that tunables like default tags and feature gates are reloaded from without restarting the controller
+	DynamicConfigConfigMap string
+	// Features explicitly enabled or disabled
+	FeatureGates FeatureGates
+	// Duration to wait for in-flight stack deployments to finish upon shutdown
+	GracefulShutdownTimeout time.Duration
+	// Namespace/name of the ConfigMap that requests with deployments interrupted by shutdown are persisted into, so that the next leader reconciles them first
+	ResumeMarkersConfigMap string
+	// ARNs of IAM roles that TargetGroupBindings are allowed to assume in addition to the ones configured on IngressClasses
+	TargetGroupBindingAllowedIAMRoleARNs []string
 }
 
 // BindFlags binds the command line flags to the fields in the config object
@@ -48,6 +152,52 @@ func (cfg *ControllerConfig) BindFlags(fs *pflag.FlagSet) {
 		"Maximum number of concurrently running reconcile loops for service")
 	fs.IntVar(&cfg.TargetGroupBindingMaxConcurrentReconciles, flagTargetGroupBindingMaxConcurrentReconciles, defaultMaxConcurrentReconciles,
 		"Maximum number of concurrently running reconcile loops for targetGroupBinding")
+	fs.IntVar(&cfg.DeployMaxConcurrency, flagDeployMaxConcurrency, defaultDeployMaxConcurrency,
+		"Maximum number of independent AWS resources deployed concurrently for each Ingress group or Service")
+	fs.DurationVar(&cfg.DriftSyncPeriod, flagDriftSyncPeriod, defaultDriftSyncPeriod,
+		"Period at which Ingresses and Services are forcibly re-synchronized with AWS to revert changes made outside of the controller, disabled if zero")
+	fs.StringVar(&cfg.ProvisionedResourcesConfigMap, flagProvisionedResourcesConfigMap, defaultProvisionedResourcesConfigMap,
+		"Name of the ConfigMap in each namespace that AWS resources provisioned for Ingresses and Services are exported into, disabled if empty")
+	fs.StringVar(&cfg.SGRuleDescriptionTemplate, flagSGRuleDescriptionTemplate, "",
+		"Go template of descriptions for rules of managed SecurityGroups, with {{.ClusterName}}, {{.Namespace}} and {{.Name}} of the Ingress group or Service, disabled if empty")
+	fs.BoolVar(&cfg.EnableListenerRuleBinding, flagEnableListenerRuleBinding, false,
+		"Enable the controller for ListenerRuleBinding, which attaches listener rules to existing listeners")
+	fs.StringSliceVar(&cfg.ListenerRuleBindingAllowedListeners, flagListenerRuleBindingAllowedListeners, nil,
+		"ARNs of listeners that ListenerRuleBindings can attach rules to, no listener is allowed if empty")
+	fs.DurationVar(&cfg.ReconcileStallTimeout, flagReconcileStallTimeout, defaultReconcileStallTimeout,
+		"Duration that the Ingress, Service or TargetGroupBinding controller can have queued requests without finishing any reconcile before its healthz check fails, disabled if zero")
+	fs.BoolVar(&cfg.EnableTGBNetworkingInference, flagEnableTGBNetworkingInference, false,
+		"Enable inferring networking rules from the SecurityGroups or subnets of LoadBalancers for TargetGroupBindings without spec.networking")
+	fs.StringSliceVar(&cfg.TargetNodeExcludedTaintKeys, flagTargetNodeExcludedTaintKeys, nil,
+		"Keys of taints that exclude nodes from being registered as targets for instance TargetType, like ToBeDeletedByClusterAutoscaler")
+	fs.BoolVar(&cfg.EnableDrainingNodeDeregistration, flagEnableDrainingNodeDeregistration, false,
+		"Enable deregistering targets on nodes that are drained or tainted to be terminated like on EC2 Spot interruption, ahead of the node termination")
+	fs.BoolVar(&cfg.EnableStackExportEndpoint, flagEnableStackExportEndpoint, false,
+		"Enable serving the AWS resources of Ingresses and Services as Terraform or CloudFormation under /debug/stack-export of the metrics server")
+	fs.DurationVar(&cfg.TargetHealthPollPeriod, flagTargetHealthPollPeriod, defaultTargetHealthPollPeriod,
+		"Period at which target health of TargetGroupBindings is polled and reported on TargetGroupBindings, disabled if zero")
+	fs.DurationVar(&cfg.SlowReconcileThreshold, flagSlowReconcileThreshold, defaultSlowReconcileThreshold,
+		"Duration of Ingress, Service or TargetGroupBinding reconciles after which events with the per-stage timing breakdown are emitted, disabled if zero")
+	fs.BoolVar(&cfg.EnableCostEstimate, flagEnableCostEstimate, false,
+		"Enable reporting rough monthly cost estimates of LoadBalancers via events on Ingresses and Services and in metrics, attributed to their namespaces")
+	fs.Var(&cfg.CostRates, flagCostRates,
+		"Rates in USD overriding the built-in us-east-1 rates of cost estimates, format: alb-hourly=0.0252,alb-lcu-hourly=0.008, with rates alb-hourly, alb-lcu-hourly, nlb-hourly, nlb-nlcu-hourly, public-ipv4-hourly and baseline-capacity-units")
+	fs.BoolVar(&cfg.EnableRGTAPI, flagEnableRGTAPI, false,
+		"Enable discovering orphaned LoadBalancers and TargetGroups by tags via Resource Groups Tagging API, which takes far fewer API calls than ELBv2 DescribeTags but requires the API reachable from the controller")
+	fs.StringVar(&cfg.SGRuleReconcileMode, flagSGRuleReconcileMode, defaultSGRuleReconcileMode,
+		"Mode to reconcile managed SecurityGroup rules with, either full, or additive that reports extra rules not authorized by the controller in metrics and events instead of revoking them")
+	fs.BoolVar(&cfg.EnableIAMPermissionsCheck, flagEnableIAMPermissionsCheck, false,
+		"Enable verifying the IAM permissions of the controller's AWS credentials via IAM policy simulation, the readiness probe fails until all required actions are allowed")
+	fs.StringVar(&cfg.DynamicConfigConfigMap, flagDynamicConfigConfigMap, "",
+		"Namespace/name of the ConfigMap that default tags, default SSL policy, default attributes, SecurityGroup deletion timeout and feature gates are hot reloaded from, disabled if empty")
+	fs.Var(&cfg.FeatureGates, flagFeatureGates,
+		"Features explicitly enabled or disabled, format: Feature1=true,Feature2=false, which can be overridden via the dynamic config ConfigMap and per IngressClass")
+	fs.DurationVar(&cfg.GracefulShutdownTimeout, flagGracefulShutdownTimeout, defaultGracefulShutdownTimeout,
+		"Duration to wait for in-flight deployments of Ingress groups and Services to finish upon shutdown, which should be less than the terminationGracePeriodSeconds of controller pods")
+	fs.StringVar(&cfg.ResumeMarkersConfigMap, flagResumeMarkersConfigMap, "",
+		"Namespace/name of the ConfigMap that Ingress groups and Services with deployments interrupted by shutdown are persisted into, so that the next leader reconciles them first, disabled if empty")
+	fs.StringSliceVar(&cfg.TargetGroupBindingAllowedIAMRoleARNs, flagTargetGroupBindingAllowedIAMRoleARNs, nil,
+		"ARNs of IAM roles that TargetGroupBindings are allowed to assume via spec.iamRoleARNToAssume, in addition to the ones configured on IngressClasses")
 
 	cfg.AWSConfig.BindFlags(fs)
 	cfg.RuntimeConfig.BindFlags(fs)
@@ -55,6 +205,16 @@ func (cfg *ControllerConfig) BindFlags(fs *pflag.FlagSet) {
 	cfg.PodWebhookConfig.BindFlags(fs)
 	cfg.IngressConfig.BindFlags(fs)
 	cfg.AddonsConfig.BindFlags(fs)
+	cfg.OrphanGCConfig.BindFlags(fs)
+	cfg.ALBWarmPoolConfig.BindFlags(fs)
+	cfg.MutationAuditConfig.BindFlags(fs)
+	cfg.ReconcileBackoffConfig.BindFlags(fs)
+	cfg.TracingConfig.BindFlags(fs)
+}
+
+// ResolveFeatureGates resolves the feature gates from flags, features toggled by dedicated flags default to them.
+func (cfg *ControllerConfig) ResolveFeatureGates() FeatureGates {
+	return FeatureGates{FeatureCostEstimate: cfg.EnableCostEstimate}.Merge(cfg.FeatureGates)
 }
 
 // Validate the controller configuration
@@ -62,5 +222,65 @@ func (cfg *ControllerConfig) Validate() error {
 	if len(cfg.ClusterName) == 0 {
 		return errors.New("kubernetes cluster name must be specified")
 	}
+	if err := cfg.AWSConfig.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.RuntimeConfig.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.IngressConfig.Validate(); err != nil {
+		return err
+	}
+	if cfg.DeployMaxConcurrency < 1 {
+		return errors.Errorf("%v must be positive", flagDeployMaxConcurrency)
+	}
+	if cfg.DriftSyncPeriod != 0 && cfg.DriftSyncPeriod < MinDriftSyncPeriod {
+		return errors.Errorf("%v must be zero or at least %v", flagDriftSyncPeriod, MinDriftSyncPeriod)
+	}
+	if cfg.TargetHealthPollPeriod != 0 && cfg.TargetHealthPollPeriod < MinTargetHealthPollPeriod {
+		return errors.Errorf("%v must be zero or at least %v", flagTargetHealthPollPeriod, MinTargetHealthPollPeriod)
+	}
+	if _, err := template.New(flagSGRuleDescriptionTemplate).Parse(cfg.SGRuleDescriptionTemplate); err != nil {
+		return errors.Wrapf(err, "invalid %v", flagSGRuleDescriptionTemplate)
+	}
+	if cfg.SGRuleReconcileMode != SGRuleReconcileModeFull && cfg.SGRuleReconcileMode != SGRuleReconcileModeAdditive {
+		return errors.Errorf("%v must be one of %v or %v", flagSGRuleReconcileMode, SGRuleReconcileModeFull, SGRuleReconcileModeAdditive)
+	}
+	if cfg.DynamicConfigConfigMap != "" {
+		if _, err := ParseDynamicConfigConfigMapKey(cfg.DynamicConfigConfigMap); err != nil {
+			return err
+		}
+	}
+	if cfg.ResumeMarkersConfigMap != "" {
+		if _, err := ParseResumeMarkersConfigMapKey(cfg.ResumeMarkersConfigMap); err != nil {
+			return err
+		}
+	}
+	if cfg.GracefulShutdownTimeout < 0 {
+		return errors.Errorf("%v must not be negative", flagGracefulShutdownTimeout)
+	}
+	if cfg.ReconcileStallTimeout < 0 {
+		return errors.Errorf("%v must not be negative", flagReconcileStallTimeout)
+	}
+	if cfg.SlowReconcileThreshold < 0 {
+		return errors.Errorf("%v must not be negative", flagSlowReconcileThreshold)
+	}
+	if err := cfg.ReconcileBackoffConfig.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.TracingConfig.Validate(); err != nil {
+		return err
+	}
+	if cfg.OrphanGCConfig.Interval < 0 {
+		return errors.Errorf("%v must not be negative", flagOrphanGCInterval)
+	}
+	if err := cfg.ALBWarmPoolConfig.Validate(); err != nil {
+		return err
+	}
 	return nil
 }
+
+// ParseResumeMarkersConfigMapKey parses the key of resume markers ConfigMap in namespace/name format.
+func ParseResumeMarkersConfigMapKey(rawKey string) (types.NamespacedName, error) {
+	return parseConfigMapKey(flagResumeMarkersConfigMap, rawKey)
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CostRate is the name of a rate that cost estimates of LoadBalancers are computed with.
+type CostRate string
+
+const (
+	CostRateALBHourly             CostRate = "alb-hourly"
+	CostRateALBLCUHourly          CostRate = "alb-lcu-hourly"
+	CostRateNLBHourly             CostRate = "nlb-hourly"
+	CostRateNLBNLCUHourly         CostRate = "nlb-nlcu-hourly"
+	CostRatePublicIPv4Hourly      CostRate = "public-ipv4-hourly"
+	CostRateBaselineCapacityUnits CostRate = "baseline-capacity-units"
+)
+
+var knownCostRates = map[CostRate]bool{
+	CostRateALBHourly:             true,
+	CostRateALBLCUHourly:          true,
+	CostRateNLBHourly:             true,
+	CostRateNLBNLCUHourly:         true,
+	CostRatePublicIPv4Hourly:      true,
+	CostRateBaselineCapacityUnits: true,
+}
+
+var _ pflag.Value = &CostRateOverrides{}
+
+// CostRateOverrides are the rates overriding the built-in rates of cost estimates, rates absent keep the built-in ones.
+type CostRateOverrides map[CostRate]float64
+
+func (o *CostRateOverrides) String() string {
+	if o == nil {
+		return ""
+	}
+	pairs := make([]string, 0, len(*o))
+	for rate, value := range *o {
+		pairs = append(pairs, fmt.Sprintf("%v=%v", rate, value))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func (o *CostRateOverrides) Set(val string) error {
+	rawRates, err := parseDynamicConfigStringMap(val)
+	if err != nil {
+		return err
+	}
+	overrides := make(CostRateOverrides, len(rawRates))
+	for rawRate, rawValue := range rawRates {
+		rate := CostRate(rawRate)
+		if !knownCostRates[rate] {
+			return errors.Errorf("unknown cost rate: %v", rawRate)
+		}
+		value, err := strconv.ParseFloat(rawValue, 64)
+		if err != nil || value < 0 {
+			return errors.Errorf("cost rate %v must be a non-negative number: %v", rawRate, rawValue)
+		}
+		overrides[rate] = value
+	}
+	if *o == nil {
+		*o = make(CostRateOverrides, len(overrides))
+	}
+	for rate, value := range overrides {
+		(*o)[rate] = value
+	}
+	return nil
+}
+
+func (o *CostRateOverrides) Type() string {
+	return "mapStringFloat"
+}
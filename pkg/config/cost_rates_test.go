@@ -0,0 +1,17 @@
+package config
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_CostRateOverrides_Set(t *testing.T) {
+	var overrides CostRateOverrides
+	assert.NoError(t, overrides.Set("alb-hourly=0.0252,alb-lcu-hourly=0.008"))
+	assert.NoError(t, overrides.Set("alb-hourly=0.027"))
+	assert.Equal(t, CostRateOverrides{CostRateALBHourly: 0.027, CostRateALBLCUHourly: 0.008}, overrides)
+	assert.Equal(t, "alb-hourly=0.027,alb-lcu-hourly=0.008", overrides.String())
+	assert.EqualError(t, overrides.Set("clb-hourly=0.025"), "unknown cost rate: clb-hourly")
+	assert.EqualError(t, overrides.Set("nlb-hourly=-1"), "cost rate nlb-hourly must be a non-negative number: -1")
+	assert.Equal(t, CostRateOverrides{CostRateALBHourly: 0.027, CostRateALBLCUHourly: 0.008}, overrides)
+}
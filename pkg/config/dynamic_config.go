@@ -0,0 +1,106 @@
+package config
+
+import (
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	dynamicConfigKeyDefaultTags          = "default-tags"
+	dynamicConfigKeyDefaultSSLPolicy     = "default-ssl-policy"
+	dynamicConfigKeyDefaultALBAttributes = "default-alb-attributes"
+	dynamicConfigKeyDefaultNLBAttributes = "default-nlb-attributes"
+	dynamicConfigKeySGDeletionTimeout    = "sg-deletion-timeout"
+	dynamicConfigKeyFeatureGates         = "feature-gates"
+)
+
+// DynamicConfig contains the controller configurations that are reloaded from a ConfigMap without restarting the controller.
+// zero values keep the built-in defaults of the controller.
+type DynamicConfig struct {
+	// Tags applied to all AWS resources provisioned for Ingresses and Services, tags from annotations take precedence.
+	DefaultTags map[string]string
+	// SSL policy of ALB HTTPS listeners without explicit SSL policy.
+	DefaultSSLPolicy string
+	// Attributes of ALBs, attributes from annotations and IngressClassParams take precedence.
+	DefaultALBAttributes map[string]string
+	// Attributes of NLBs, attributes from annotations take precedence.
+	DefaultNLBAttributes map[string]string
+	// Timeout to wait for managed SecurityGroups to be deleted while their dependencies are being released.
+	SGDeletionTimeout time.Duration
+	// Features explicitly enabled or disabled.
+	FeatureGates FeatureGates
+}
+
+// ParseDynamicConfig parses the DynamicConfig from data of a ConfigMap.
+func ParseDynamicConfig(data map[string]string) (DynamicConfig, error) {
+	var cfg DynamicConfig
+	for _, key := range sortedKeys(data) {
+		rawValue := strings.TrimSpace(data[key])
+		var err error
+		switch key {
+		case dynamicConfigKeyDefaultTags:
+			cfg.DefaultTags, err = parseDynamicConfigStringMap(rawValue)
+		case dynamicConfigKeyDefaultSSLPolicy:
+			cfg.DefaultSSLPolicy = rawValue
+		case dynamicConfigKeyDefaultALBAttributes:
+			cfg.DefaultALBAttributes, err = parseDynamicConfigStringMap(rawValue)
+		case dynamicConfigKeyDefaultNLBAttributes:
+			cfg.DefaultNLBAttributes, err = parseDynamicConfigStringMap(rawValue)
+		case dynamicConfigKeySGDeletionTimeout:
+			cfg.SGDeletionTimeout, err = time.ParseDuration(rawValue)
+			if err == nil && cfg.SGDeletionTimeout <= 0 {
+				err = errors.New("must be positive")
+			}
+		case dynamicConfigKeyFeatureGates:
+			cfg.FeatureGates, err = ParseFeatureGates(rawValue)
+		default:
+			err = errors.New("unknown key")
+		}
+		if err != nil {
+			return DynamicConfig{}, errors.Wrapf(err, "invalid %v", key)
+		}
+	}
+	return cfg, nil
+}
+
+// parseDynamicConfigStringMap parses a comma separated list of key=value pairs.
+func parseDynamicConfigStringMap(rawValue string) (map[string]string, error) {
+	if rawValue == "" {
+		return nil, nil
+	}
+	ret := make(map[string]string)
+	for _, rawPair := range strings.Split(rawValue, ",") {
+		pair := strings.SplitN(strings.TrimSpace(rawPair), "=", 2)
+		if len(pair) != 2 || strings.TrimSpace(pair[0]) == "" {
+			return nil, errors.Errorf("failed to parse key=value pair: %v", rawPair)
+		}
+		ret[strings.TrimSpace(pair[0])] = strings.TrimSpace(pair[1])
+	}
+	return ret, nil
+}
+
+// ParseDynamicConfigConfigMapKey parses the key of dynamic config ConfigMap in namespace/name format.
+func ParseDynamicConfigConfigMapKey(rawKey string) (types.NamespacedName, error) {
+	return parseConfigMapKey(flagDynamicConfigConfigMap, rawKey)
+}
+
+// parseConfigMapKey parses the namespace/name of ConfigMap specified by flag.
+func parseConfigMapKey(flag string, rawKey string) (types.NamespacedName, error) {
+	parts := strings.Split(rawKey, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return types.NamespacedName{}, errors.Errorf("%v must be in namespace/name format: %v", flag, rawKey)
+	}
+	return types.NamespacedName{Namespace: parts[0], Name: parts[1]}, nil
+}
+
+func sortedKeys(data map[string]string) []string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
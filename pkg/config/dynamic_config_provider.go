@@ -0,0 +1,121 @@
+package config
+
+import (
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sync"
+)
+
+const resourceTypeConfigMaps = "configmaps"
+
+// DynamicConfigProvider provides the latest DynamicConfig.
+type DynamicConfigProvider interface {
+	// Get returns the latest DynamicConfig, the returned DynamicConfig must not be modified.
+	Get() DynamicConfig
+}
+
+// NewStaticDynamicConfigProvider constructs new staticDynamicConfigProvider.
+func NewStaticDynamicConfigProvider(cfg DynamicConfig) *staticDynamicConfigProvider {
+	return &staticDynamicConfigProvider{
+		cfg: cfg,
+	}
+}
+
+var _ DynamicConfigProvider = &staticDynamicConfigProvider{}
+
+// staticDynamicConfigProvider provides a DynamicConfig that never changes.
+type staticDynamicConfigProvider struct {
+	cfg DynamicConfig
+}
+
+func (p *staticDynamicConfigProvider) Get() DynamicConfig {
+	return p.cfg
+}
+
+// NewConfigMapDynamicConfigProvider constructs new configMapDynamicConfigProvider.
+// featureGates are the feature gates from flags, which are overridden by the feature gates from the ConfigMap.
+func NewConfigMapDynamicConfigProvider(getter cache.Getter, configMapKey types.NamespacedName, featureGates FeatureGates, logger logr.Logger) *configMapDynamicConfigProvider {
+	p := &configMapDynamicConfigProvider{
+		configMapKey: configMapKey,
+		featureGates: featureGates,
+		logger:       logger,
+		cfg:          DynamicConfig{FeatureGates: featureGates},
+	}
+	lw := cache.NewListWatchFromClient(getter, resourceTypeConfigMaps, configMapKey.Namespace,
+		fields.OneTermEqualSelector("metadata.name", configMapKey.Name))
+	_, p.informer = cache.NewInformer(lw, &corev1.ConfigMap{}, 0, cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			p.reload(obj.(*corev1.ConfigMap))
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			p.reload(obj.(*corev1.ConfigMap))
+		},
+		DeleteFunc: func(_ interface{}) {
+			p.reset()
+		},
+	})
+	return p
+}
+
+var _ DynamicConfigProvider = &configMapDynamicConfigProvider{}
+var _ manager.Runnable = &configMapDynamicConfigProvider{}
+
+// configMapDynamicConfigProvider provides the DynamicConfig from a watched ConfigMap.
+// invalid changes to the ConfigMap are logged and ignored, the last valid DynamicConfig stays in effect until the ConfigMap is fixed.
+// the built-in defaults and the feature gates from flags are restored once the ConfigMap is deleted.
+type configMapDynamicConfigProvider struct {
+	configMapKey types.NamespacedName
+	featureGates FeatureGates
+	informer     cache.Controller
+	logger       logr.Logger
+
+	cfg      DynamicConfig
+	cfgMutex sync.RWMutex
+}
+
+func (p *configMapDynamicConfigProvider) Get() DynamicConfig {
+	p.cfgMutex.RLock()
+	defer p.cfgMutex.RUnlock()
+	return p.cfg
+}
+
+// Start will start watching the ConfigMap until stopChan is closed.
+func (p *configMapDynamicConfigProvider) Start(stopChan <-chan struct{}) error {
+	p.informer.Run(stopChan)
+	return nil
+}
+
+// WaitForCacheSync waits for the initial load of the ConfigMap.
+func (p *configMapDynamicConfigProvider) WaitForCacheSync(stopChan <-chan struct{}) error {
+	if !cache.WaitForCacheSync(stopChan, p.informer.HasSynced) {
+		return errors.Errorf("failed to load dynamic config from configMap: %v", p.configMapKey)
+	}
+	return nil
+}
+
+func (p *configMapDynamicConfigProvider) reload(configMap *corev1.ConfigMap) {
+	cfg, err := ParseDynamicConfig(configMap.Data)
+	if err != nil {
+		p.logger.Error(err, "ignoring invalid dynamic config",
+			"configMap", p.configMapKey, "resourceVersion", configMap.ResourceVersion)
+		return
+	}
+	cfg.FeatureGates = p.featureGates.Merge(cfg.FeatureGates)
+	p.cfgMutex.Lock()
+	defer p.cfgMutex.Unlock()
+	p.cfg = cfg
+	p.logger.Info("reloaded dynamic config",
+		"configMap", p.configMapKey, "resourceVersion", configMap.ResourceVersion)
+}
+
+func (p *configMapDynamicConfigProvider) reset() {
+	p.cfgMutex.Lock()
+	defer p.cfgMutex.Unlock()
+	p.cfg = DynamicConfig{FeatureGates: p.featureGates}
+	p.logger.Info("restored default dynamic config", "configMap", p.configMapKey)
+}
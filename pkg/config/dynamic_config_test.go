@@ -0,0 +1,139 @@
+package config
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+	"time"
+)
+
+func Test_ParseDynamicConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    map[string]string
+		want    DynamicConfig
+		wantErr error
+	}{
+		{
+			name: "empty data",
+			data: nil,
+			want: DynamicConfig{},
+		},
+		{
+			name: "all keys",
+			data: map[string]string{
+				"default-tags":           "team=platform, cost-center = 1234",
+				"default-ssl-policy":     "ELBSecurityPolicy-TLS13-1-2-2021-06",
+				"default-alb-attributes": "routing.http2.enabled=true,idle_timeout.timeout_seconds=120",
+				"default-nlb-attributes": "load_balancing.cross_zone.enabled=true",
+				"sg-deletion-timeout":    "5m",
+				"feature-gates":          "CostEstimate=true",
+			},
+			want: DynamicConfig{
+				DefaultTags: map[string]string{
+					"team":        "platform",
+					"cost-center": "1234",
+				},
+				DefaultSSLPolicy: "ELBSecurityPolicy-TLS13-1-2-2021-06",
+				DefaultALBAttributes: map[string]string{
+					"routing.http2.enabled":        "true",
+					"idle_timeout.timeout_seconds": "120",
+				},
+				DefaultNLBAttributes: map[string]string{
+					"load_balancing.cross_zone.enabled": "true",
+				},
+				SGDeletionTimeout: 5 * time.Minute,
+				FeatureGates: FeatureGates{
+					FeatureCostEstimate: true,
+				},
+			},
+		},
+		{
+			name: "unknown key",
+			data: map[string]string{
+				"default-tag": "team=platform",
+			},
+			wantErr: errors.New("invalid default-tag: unknown key"),
+		},
+		{
+			name: "invalid tags",
+			data: map[string]string{
+				"default-tags": "team",
+			},
+			wantErr: errors.New("invalid default-tags: failed to parse key=value pair: team"),
+		},
+		{
+			name: "non-positive sg deletion timeout",
+			data: map[string]string{
+				"sg-deletion-timeout": "0s",
+			},
+			wantErr: errors.New("invalid sg-deletion-timeout: must be positive"),
+		},
+		{
+			name: "unknown feature",
+			data: map[string]string{
+				"feature-gates": "Teleport=true",
+			},
+			wantErr: errors.New("invalid feature-gates: unknown feature: Teleport"),
+		},
+		{
+			name: "invalid feature gate",
+			data: map[string]string{
+				"feature-gates": "CostEstimate=sometimes",
+			},
+			wantErr: errors.New("invalid feature-gates: failed to parse feature gate CostEstimate: sometimes"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDynamicConfig(tt.data)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_configMapDynamicConfigProvider_reload(t *testing.T) {
+	p := &configMapDynamicConfigProvider{
+		configMapKey: types.NamespacedName{Namespace: "kube-system", Name: "aws-load-balancer-controller-config"},
+		logger:       &log.NullLogger{},
+	}
+	buildConfigMap := func(resourceVersion string, data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       "kube-system",
+				Name:            "aws-load-balancer-controller-config",
+				ResourceVersion: resourceVersion,
+			},
+			Data: data,
+		}
+	}
+	validConfig := DynamicConfig{DefaultSSLPolicy: "ELBSecurityPolicy-TLS13-1-2-2021-06"}
+
+	p.reload(buildConfigMap("1", map[string]string{"default-ssl-policy": "ELBSecurityPolicy-TLS13-1-2-2021-06"}))
+	assert.Equal(t, validConfig, p.Get())
+
+	// invalid changes keep the last valid config in effect.
+	p.reload(buildConfigMap("2", map[string]string{"sg-deletion-timeout": "forever"}))
+	assert.Equal(t, validConfig, p.Get())
+
+	p.reset()
+	assert.Equal(t, DynamicConfig{}, p.Get())
+}
+
+func Test_ParseDynamicConfigConfigMapKey(t *testing.T) {
+	got, err := ParseDynamicConfigConfigMapKey("kube-system/aws-load-balancer-controller-config")
+	assert.NoError(t, err)
+	assert.Equal(t, types.NamespacedName{Namespace: "kube-system", Name: "aws-load-balancer-controller-config"}, got)
+
+	_, err = ParseDynamicConfigConfigMapKey("aws-load-balancer-controller-config")
+	assert.EqualError(t, err, "dynamic-config-configmap must be in namespace/name format: aws-load-balancer-controller-config")
+}
@@ -0,0 +1,138 @@
+package config
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Feature is the name of a controller behavior that can be toggled via feature gates.
+type Feature string
+
+const (
+	// FeatureCostEstimate toggles reporting monthly cost estimates of LoadBalancers.
+	FeatureCostEstimate Feature = "CostEstimate"
+	// FeatureRuleSplitting toggles splitting listener rules whose conditions have more values than ALB allows into multiple rules.
+	FeatureRuleSplitting Feature = "RuleSplitting"
+	// FeatureNLBSecurityGroup toggles managing SecurityGroups for NLBs via the manage-security-group annotation.
+	FeatureNLBSecurityGroup Feature = "NLBSecurityGroup"
+	// FeatureLoadBalancerInventory toggles maintaining LoadBalancerInventories that list the AWS resources owned by the controller.
+	FeatureLoadBalancerInventory Feature = "LoadBalancerInventory"
+)
+
+// featureDefaults are whether each known feature is enabled unless it's explicitly enabled or disabled.
+var featureDefaults = map[Feature]bool{
+	FeatureCostEstimate:          false,
+	FeatureRuleSplitting:         true,
+	FeatureNLBSecurityGroup:      true,
+	FeatureLoadBalancerInventory: false,
+}
+
+// ingressClassFeatures are the features that can be overridden per IngressClass via IngressClassParams.
+var ingressClassFeatures = map[Feature]bool{
+	FeatureRuleSplitting: true,
+}
+
+var _ pflag.Value = &FeatureGates{}
+
+// FeatureGates are the features explicitly enabled or disabled, features absent keep their default.
+type FeatureGates map[Feature]bool
+
+// Enabled returns whether feature is enabled.
+func (g FeatureGates) Enabled(feature Feature) bool {
+	if enabled, ok := g[feature]; ok {
+		return enabled
+	}
+	return featureDefaults[feature]
+}
+
+// Merge returns the feature gates with overrides taking precedence.
+func (g FeatureGates) Merge(overrides FeatureGates) FeatureGates {
+	if len(overrides) == 0 {
+		return g
+	}
+	merged := make(FeatureGates, len(g)+len(overrides))
+	for feature, enabled := range g {
+		merged[feature] = enabled
+	}
+	for feature, enabled := range overrides {
+		merged[feature] = enabled
+	}
+	return merged
+}
+
+func (g *FeatureGates) String() string {
+	if g == nil {
+		return ""
+	}
+	pairs := make([]string, 0, len(*g))
+	for feature, enabled := range *g {
+		pairs = append(pairs, fmt.Sprintf("%v=%v", feature, enabled))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func (g *FeatureGates) Set(val string) error {
+	gates, err := ParseFeatureGates(val)
+	if err != nil {
+		return err
+	}
+	*g = g.Merge(gates)
+	return nil
+}
+
+func (g *FeatureGates) Type() string {
+	return "mapStringBool"
+}
+
+// ParseFeatureGates parses a comma separated list of Feature=true|false pairs.
+func ParseFeatureGates(rawValue string) (FeatureGates, error) {
+	rawGates, err := parseDynamicConfigStringMap(rawValue)
+	if err != nil {
+		return nil, err
+	}
+	rawEnabledByFeature := make(map[string]bool, len(rawGates))
+	for rawFeature, rawEnabled := range rawGates {
+		enabled, err := strconv.ParseBool(rawEnabled)
+		if err != nil {
+			return nil, errors.Errorf("failed to parse feature gate %v: %v", rawFeature, rawEnabled)
+		}
+		rawEnabledByFeature[rawFeature] = enabled
+	}
+	return BuildFeatureGates(rawEnabledByFeature)
+}
+
+// BuildFeatureGates builds the feature gates from whether each feature is enabled by name.
+func BuildFeatureGates(rawEnabledByFeature map[string]bool) (FeatureGates, error) {
+	if len(rawEnabledByFeature) == 0 {
+		return nil, nil
+	}
+	gates := make(FeatureGates, len(rawEnabledByFeature))
+	for rawFeature, enabled := range rawEnabledByFeature {
+		feature := Feature(rawFeature)
+		if _, known := featureDefaults[feature]; !known {
+			return nil, errors.Errorf("unknown feature: %v", rawFeature)
+		}
+		gates[feature] = enabled
+	}
+	return gates, nil
+}
+
+// BuildIngressClassFeatureGates builds the feature gates overridden by IngressClassParams,
+// only features that apply per IngressClass can be overridden.
+func BuildIngressClassFeatureGates(rawEnabledByFeature map[string]bool) (FeatureGates, error) {
+	gates, err := BuildFeatureGates(rawEnabledByFeature)
+	if err != nil {
+		return nil, err
+	}
+	for feature := range gates {
+		if !ingressClassFeatures[feature] {
+			return nil, errors.Errorf("feature cannot be overridden per IngressClass: %v", feature)
+		}
+	}
+	return gates, nil
+}
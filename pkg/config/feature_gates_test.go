@@ -0,0 +1,71 @@
+package config
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_FeatureGates_Enabled(t *testing.T) {
+	gates := FeatureGates{FeatureRuleSplitting: false}
+	assert.False(t, gates.Enabled(FeatureRuleSplitting))
+	assert.True(t, FeatureGates(nil).Enabled(FeatureRuleSplitting))
+	assert.False(t, FeatureGates(nil).Enabled(FeatureCostEstimate))
+}
+
+func Test_FeatureGates_Merge(t *testing.T) {
+	gates := FeatureGates{FeatureCostEstimate: true, FeatureRuleSplitting: true}
+	got := gates.Merge(FeatureGates{FeatureRuleSplitting: false})
+	assert.Equal(t, FeatureGates{FeatureCostEstimate: true, FeatureRuleSplitting: false}, got)
+	assert.Equal(t, FeatureGates{FeatureCostEstimate: true, FeatureRuleSplitting: true}, gates)
+}
+
+func Test_FeatureGates_Set(t *testing.T) {
+	var gates FeatureGates
+	assert.NoError(t, gates.Set("CostEstimate=true,RuleSplitting=false"))
+	assert.NoError(t, gates.Set("RuleSplitting=true"))
+	assert.Equal(t, FeatureGates{FeatureCostEstimate: true, FeatureRuleSplitting: true}, gates)
+	assert.Equal(t, "CostEstimate=true,RuleSplitting=true", gates.String())
+	assert.EqualError(t, gates.Set("Teleport=true"), "unknown feature: Teleport")
+}
+
+func Test_BuildIngressClassFeatureGates(t *testing.T) {
+	tests := []struct {
+		name                string
+		rawEnabledByFeature map[string]bool
+		want                FeatureGates
+		wantErr             error
+	}{
+		{
+			name:                "no feature gates",
+			rawEnabledByFeature: nil,
+			want:                nil,
+		},
+		{
+			name:                "feature that applies per IngressClass",
+			rawEnabledByFeature: map[string]bool{"RuleSplitting": false},
+			want:                FeatureGates{FeatureRuleSplitting: false},
+		},
+		{
+			name:                "feature that applies to the controller",
+			rawEnabledByFeature: map[string]bool{"CostEstimate": true},
+			wantErr:             errors.New("feature cannot be overridden per IngressClass: CostEstimate"),
+		},
+		{
+			name:                "unknown feature",
+			rawEnabledByFeature: map[string]bool{"Teleport": true},
+			wantErr:             errors.New("unknown feature: Teleport"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuildIngressClassFeatureGates(tt.rawEnabledByFeature)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -1,12 +1,21 @@
 package config
 
-import "github.com/spf13/pflag"
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"time"
+)
 
 const (
 	flagIngressClass                      = "ingress-class"
 	flagIngressMaxConcurrentReconciles    = "ingress-max-concurrent-reconciles"
+	flagCertTagsResyncPeriod              = "cert-tags-resync-period"
+	flagEnableLegacyResourceAdoption      = "enable-legacy-resource-adoption"
+	flagDefaultTargetType                 = "default-target-type"
 	defaultIngressClass                   = ""
 	defaultMaxIngressConcurrentReconciles = 3
+	defaultCertTagsResyncPeriod           = 5 * time.Minute
+	defaultTargetType                     = "instance"
 )
 
 // IngressConfig contains the configurations for the Ingress controller
@@ -17,6 +26,17 @@ type IngressConfig struct {
 
 	// Max concurrent reconcile loops for Ingress objects
 	MaxConcurrentReconciles int
+
+	// Period at which IngressGroups with tag selected certificates are reconciled
+	// to pick up certificates rotated by external tooling.
+	CertTagsResyncPeriod time.Duration
+
+	// Whether AWS resources provisioned by AWSALBIngressController(before v1.1.3) are adopted for Ingresses
+	// they were provisioned for, instead of being recreated.
+	EnableLegacyResourceAdoption bool
+
+	// Default TargetType of TargetGroups for Ingresses, when it's not specified via annotations or IngressClassParams.
+	DefaultTargetType string
 }
 
 // BindFlags binds the command line flags to the fields in the config object
@@ -25,4 +45,18 @@ func (cfg *IngressConfig) BindFlags(fs *pflag.FlagSet) {
 		"Name of the ingress class this controller satisfies")
 	fs.IntVar(&cfg.MaxConcurrentReconciles, flagIngressMaxConcurrentReconciles, defaultMaxIngressConcurrentReconciles,
 		"Maximum number of concurrently running reconcile loops for ingress")
+	fs.DurationVar(&cfg.CertTagsResyncPeriod, flagCertTagsResyncPeriod, defaultCertTagsResyncPeriod,
+		"Period at which the ingresses using certificate-tags annotation are reconciled to discover rotated certificates")
+	fs.BoolVar(&cfg.EnableLegacyResourceAdoption, flagEnableLegacyResourceAdoption, false,
+		"Enable adopting the LoadBalancers, TargetGroups and SecurityGroups provisioned by aws-alb-ingress-controller before v1.1.3 for ingresses, instead of recreating them")
+	fs.StringVar(&cfg.DefaultTargetType, flagDefaultTargetType, defaultTargetType,
+		"Default target type of target groups for ingresses without target-type annotation or IngressClassParams targetType, one of instance or ip")
+}
+
+// Validate the ingress configuration
+func (cfg *IngressConfig) Validate() error {
+	if cfg.DefaultTargetType != "instance" && cfg.DefaultTargetType != "ip" {
+		return errors.Errorf("%v must be one of instance or ip", flagDefaultTargetType)
+	}
+	return nil
 }
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"github.com/spf13/pflag"
+	"time"
+)
+
+const (
+	flagOrphanGCInterval    = "orphan-gc-interval"
+	flagOrphanGCDryRun      = "orphan-gc-dry-run"
+	defaultOrphanGCInterval = 0
+	defaultOrphanGCDryRun   = false
+)
+
+// OrphanGCConfig contains the configurations for the garbage collector of orphaned AWS resources
+type OrphanGCConfig struct {
+	// Period at which AWS resources whose owning Kubernetes resource no longer exists are collected.
+	// The garbage collector is disabled if it's zero.
+	Interval time.Duration
+
+	// Only report orphaned AWS resources instead of deleting them.
+	DryRun bool
+}
+
+// BindFlags binds the command line flags to the fields in the config object
+func (cfg *OrphanGCConfig) BindFlags(fs *pflag.FlagSet) {
+	fs.DurationVar(&cfg.Interval, flagOrphanGCInterval, defaultOrphanGCInterval,
+		"Period at which orphaned AWS resources left by the controller are garbage collected, disabled if zero")
+	fs.BoolVar(&cfg.DryRun, flagOrphanGCDryRun, defaultOrphanGCDryRun,
+		"Only report orphaned AWS resources instead of deleting them")
+}
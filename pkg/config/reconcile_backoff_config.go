@@ -0,0 +1,53 @@
+package config
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"time"
+)
+
+const (
+	flagReconcileBackoffBaseDelay       = "reconcile-backoff-base-delay"
+	flagReconcileBackoffMaxDelay        = "reconcile-backoff-max-delay"
+	flagReconcileMaxTerminalFailures    = "reconcile-max-terminal-failures"
+	defaultReconcileBackoffBaseDelay    = 5 * time.Millisecond
+	defaultReconcileBackoffMaxDelay     = 1000 * time.Second
+	defaultReconcileMaxTerminalFailures = 5
+)
+
+// ReconcileBackoffConfig contains the configurations for retrying failed reconcile requests
+type ReconcileBackoffConfig struct {
+	// Initial delay to retry a failed reconcile request, it's doubled for each consecutive failure.
+	BaseDelay time.Duration
+
+	// Maximum delay to retry a failed reconcile request.
+	MaxDelay time.Duration
+
+	// Number of consecutive terminal failures after which a reconcile request is no longer retried and its object is marked as paused.
+	// Requests are always retried if it's zero.
+	MaxTerminalFailures int
+}
+
+// BindFlags binds the command line flags to the fields in the config object
+func (cfg *ReconcileBackoffConfig) BindFlags(fs *pflag.FlagSet) {
+	fs.DurationVar(&cfg.BaseDelay, flagReconcileBackoffBaseDelay, defaultReconcileBackoffBaseDelay,
+		"Initial delay to retry a failed reconcile request, doubled for each consecutive failure")
+	fs.DurationVar(&cfg.MaxDelay, flagReconcileBackoffMaxDelay, defaultReconcileBackoffMaxDelay,
+		"Maximum delay to retry a failed reconcile request")
+	fs.IntVar(&cfg.MaxTerminalFailures, flagReconcileMaxTerminalFailures, defaultReconcileMaxTerminalFailures,
+		"Number of consecutive terminal failures after which a reconcile request is no longer retried until its object changes, always retried if zero")
+}
+
+// Validate the reconcile backoff configuration
+func (cfg *ReconcileBackoffConfig) Validate() error {
+	if cfg.BaseDelay <= 0 {
+		return errors.Errorf("%v must be positive", flagReconcileBackoffBaseDelay)
+	}
+	if cfg.MaxDelay < cfg.BaseDelay {
+		return errors.Errorf("%v must not be less than %v", flagReconcileBackoffMaxDelay, flagReconcileBackoffBaseDelay)
+	}
+	if cfg.MaxTerminalFailures < 0 {
+		return errors.Errorf("%v must not be negative", flagReconcileMaxTerminalFailures)
+	}
+	return nil
+}
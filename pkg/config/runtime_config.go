@@ -1,8 +1,10 @@
 package config
 
 import (
+	"github.com/pkg/errors"
 	"github.com/spf13/pflag"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -11,24 +13,35 @@ import (
 )
 
 const (
-	flagMetricsBindAddr         = "metrics-bind-addr"
-	flagHealthProbeBindAddr     = "health-probe-bind-addr"
-	flagWebhookBindPort         = "webhook-bind-port"
-	flagEnableLeaderElection    = "enable-leader-election"
-	flagLeaderElectionID        = "leader-election-id"
-	flagLeaderElectionNamespace = "leader-election-namespace"
-	flagWatchNamespace          = "watch-namespace"
-	flagSyncPeriod              = "sync-period"
-	flagKubeconfig              = "kubeconfig"
+	flagMetricsBindAddr             = "metrics-bind-addr"
+	flagHealthProbeBindAddr         = "health-probe-bind-addr"
+	flagDebugBindAddr               = "debug-bind-addr"
+	flagWebhookBindPort             = "webhook-bind-port"
+	flagEnableLeaderElection        = "enable-leader-election"
+	flagLeaderElectionID            = "leader-election-id"
+	flagLeaderElectionNamespace     = "leader-election-namespace"
+	flagLeaderElectionLeaseDuration = "leader-election-lease-duration"
+	flagLeaderElectionRenewDeadline = "leader-election-renew-deadline"
+	flagLeaderElectionRetryPeriod   = "leader-election-retry-period"
+	flagWatchNamespace              = "watch-namespace"
+	flagWatchNamespaceSelector      = "watch-namespace-selector"
+	flagShardCount                  = "shard-count"
+	flagShardIndex                  = "shard-index"
+	flagSyncPeriod                  = "sync-period"
+	flagKubeconfig                  = "kubeconfig"
 
-	defaultKubeconfig              = ""
-	defaultLeaderElectionID        = "aws-load-balancer-controller-leader"
-	defaultLeaderElectionNamespace = ""
-	defaultWatchNamespace          = corev1.NamespaceAll
-	defaultMetricsAddr             = ":8080"
-	defaultHealthProbeBindAddress  = ":61779"
-	defaultSyncPeriod              = 60 * time.Minute
-	defaultWebhookBindPort         = 9443
+	defaultKubeconfig                  = ""
+	defaultLeaderElectionID            = "aws-load-balancer-controller-leader"
+	defaultLeaderElectionNamespace     = ""
+	defaultLeaderElectionLeaseDuration = 15 * time.Second
+	defaultLeaderElectionRenewDeadline = 10 * time.Second
+	defaultLeaderElectionRetryPeriod   = 2 * time.Second
+	defaultWatchNamespace              = corev1.NamespaceAll
+	defaultMetricsAddr                 = ":8080"
+	defaultHealthProbeBindAddress      = ":61779"
+	defaultSyncPeriod                  = 60 * time.Minute
+	defaultWebhookBindPort             = 9443
+	defaultShardCount                  = 1
 	// High enough QPS to fit all expected use cases. QPS=0 is not set here, because
 	// client code is overriding it.
 	defaultQPS = 1e6
@@ -39,16 +52,23 @@ const (
 
 // RuntimeConfig stores the configuration for the controller-runtime
 type RuntimeConfig struct {
-	APIServer               string
-	KubeConfig              string
-	WebhookBindPort         int
-	MetricsBindAddress      string
-	HealthProbeBindAddress  string
-	EnableLeaderElection    bool
-	LeaderElectionID        string
-	LeaderElectionNamespace string
-	WatchNamespace          string
-	SyncPeriod              time.Duration
+	APIServer                   string
+	KubeConfig                  string
+	WebhookBindPort             int
+	MetricsBindAddress          string
+	HealthProbeBindAddress      string
+	DebugBindAddress            string
+	EnableLeaderElection        bool
+	LeaderElectionID            string
+	LeaderElectionNamespace     string
+	LeaderElectionLeaseDuration time.Duration
+	LeaderElectionRenewDeadline time.Duration
+	LeaderElectionRetryPeriod   time.Duration
+	WatchNamespace              string
+	WatchNamespaceSelector      string
+	ShardCount                  int
+	ShardIndex                  int
+	SyncPeriod                  time.Duration
 }
 
 // BindFlags binds the command line flags to the fields in the config object
@@ -59,6 +79,8 @@ func (c *RuntimeConfig) BindFlags(fs *pflag.FlagSet) {
 		"The address the metric endpoint binds to.")
 	fs.StringVar(&c.HealthProbeBindAddress, flagHealthProbeBindAddr, defaultHealthProbeBindAddress,
 		"The address the health probes binds to.")
+	fs.StringVar(&c.DebugBindAddress, flagDebugBindAddr, "",
+		"The address the debug endpoint with pprof and reconcile introspection binds to, disabled if empty. Binds to 127.0.0.1 if the host is omitted.")
 	fs.IntVar(&c.WebhookBindPort, flagWebhookBindPort, defaultWebhookBindPort,
 		"The TCP port the Webhook server binds to.")
 	fs.BoolVar(&c.EnableLeaderElection, flagEnableLeaderElection, true,
@@ -68,12 +90,47 @@ func (c *RuntimeConfig) BindFlags(fs *pflag.FlagSet) {
 		"Name of the leader election ID to use for this controller")
 	fs.StringVar(&c.LeaderElectionNamespace, flagLeaderElectionNamespace, defaultLeaderElectionNamespace,
 		"Name of the leader election ID to use for this controller")
+	fs.DurationVar(&c.LeaderElectionLeaseDuration, flagLeaderElectionLeaseDuration, defaultLeaderElectionLeaseDuration,
+		"Duration that non-leader candidates will wait after observing a leadership renewal before attempting to acquire leadership.")
+	fs.DurationVar(&c.LeaderElectionRenewDeadline, flagLeaderElectionRenewDeadline, defaultLeaderElectionRenewDeadline,
+		"Duration that the acting leader will retry refreshing leadership before giving up.")
+	fs.DurationVar(&c.LeaderElectionRetryPeriod, flagLeaderElectionRetryPeriod, defaultLeaderElectionRetryPeriod,
+		"Duration the leader election clients should wait between tries of actions.")
 	fs.StringVar(&c.WatchNamespace, flagWatchNamespace, defaultWatchNamespace,
 		"Namespace the controller watches for updates to Kubernetes objects, If empty, all namespaces are watched.")
+	fs.StringVar(&c.WatchNamespaceSelector, flagWatchNamespaceSelector, "",
+		"Label selector of namespaces the controller reconciles Kubernetes objects in, If empty, objects in all watched namespaces are reconciled.")
+	fs.IntVar(&c.ShardCount, flagShardCount, defaultShardCount,
+		"Number of controller deployments that explicit IngressGroups and ServiceGroups are sharded across by hashing their names.")
+	fs.IntVar(&c.ShardIndex, flagShardIndex, 0,
+		"Index of this controller deployment among the shards, explicit IngressGroups and ServiceGroups whose names hash to the index are reconciled.")
 	fs.DurationVar(&c.SyncPeriod, flagSyncPeriod, defaultSyncPeriod,
 		"Period at which the controller forces the repopulation of its local object stores.")
 }
 
+// Validate the runtime configuration
+func (c *RuntimeConfig) Validate() error {
+	if c.LeaderElectionRetryPeriod <= 0 {
+		return errors.Errorf("%v must be positive", flagLeaderElectionRetryPeriod)
+	}
+	if c.LeaderElectionRenewDeadline <= c.LeaderElectionRetryPeriod {
+		return errors.Errorf("%v must be greater than %v", flagLeaderElectionRenewDeadline, flagLeaderElectionRetryPeriod)
+	}
+	if c.LeaderElectionLeaseDuration <= c.LeaderElectionRenewDeadline {
+		return errors.Errorf("%v must be greater than %v", flagLeaderElectionLeaseDuration, flagLeaderElectionRenewDeadline)
+	}
+	if _, err := BuildWatchNamespaceSelector(*c); err != nil {
+		return err
+	}
+	if c.ShardCount < 1 {
+		return errors.Errorf("%v must be positive", flagShardCount)
+	}
+	if c.ShardIndex < 0 || c.ShardIndex >= c.ShardCount {
+		return errors.Errorf("%v must be within [0, %v)", flagShardIndex, flagShardCount)
+	}
+	return nil
+}
+
 // BuildRestConfig builds the REST config for the controller runtime
 func BuildRestConfig(rtCfg RuntimeConfig) (*rest.Config, error) {
 	var restCFG *rest.Config
@@ -103,7 +160,19 @@ func BuildRuntimeOptions(rtCfg RuntimeConfig, scheme *runtime.Scheme) ctrl.Optio
 		LeaderElection:          rtCfg.EnableLeaderElection,
 		LeaderElectionID:        rtCfg.LeaderElectionID,
 		LeaderElectionNamespace: rtCfg.LeaderElectionNamespace,
+		LeaseDuration:           &rtCfg.LeaderElectionLeaseDuration,
+		RenewDeadline:           &rtCfg.LeaderElectionRenewDeadline,
+		RetryPeriod:             &rtCfg.LeaderElectionRetryPeriod,
 		Namespace:               rtCfg.WatchNamespace,
 		SyncPeriod:              &rtCfg.SyncPeriod,
 	}
 }
+
+// BuildWatchNamespaceSelector builds the label selector of namespaces the controller reconciles objects in based on config
+func BuildWatchNamespaceSelector(rtCfg RuntimeConfig) (labels.Selector, error) {
+	selector, err := labels.Parse(rtCfg.WatchNamespaceSelector)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %v", flagWatchNamespaceSelector)
+	}
+	return selector, nil
+}
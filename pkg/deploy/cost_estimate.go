@@ -0,0 +1,154 @@
+package deploy
+
+import (
+	"encoding/json"
+	"math"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+)
+
+// hoursPerMonth is the number of hours AWS uses to estimate monthly costs.
+const hoursPerMonth = 730
+
+// CostRates contains the hourly rates in USD that cost of LoadBalancers are estimated with.
+type CostRates struct {
+	// The rate per hour of Application Load Balancer.
+	ALBHourly float64
+
+	// The rate per LCU-hour of Application Load Balancer.
+	ALBLCUHourly float64
+
+	// The rate per hour of Network Load Balancer.
+	NLBHourly float64
+
+	// The rate per NLCU-hour of Network Load Balancer.
+	NLBNLCUHourly float64
+
+	// The rate per hour of public IPv4 address, including Elastic IP addresses.
+	PublicIPv4Hourly float64
+
+	// The number of capacity units LoadBalancers are assumed to consume at baseline.
+	BaselineCapacityUnits float64
+}
+
+// DefaultCostRates are the on-demand rates in us-east-1, with a baseline of one capacity unit.
+var DefaultCostRates = CostRates{
+	ALBHourly:             0.0225,
+	ALBLCUHourly:          0.008,
+	NLBHourly:             0.0225,
+	NLBNLCUHourly:         0.006,
+	PublicIPv4Hourly:      0.005,
+	BaselineCapacityUnits: 1,
+}
+
+// WithOverrides returns the rates with overrides taking precedence.
+func (r CostRates) WithOverrides(overrides config.CostRateOverrides) CostRates {
+	ratesByName := map[config.CostRate]*float64{
+		config.CostRateALBHourly:             &r.ALBHourly,
+		config.CostRateALBLCUHourly:          &r.ALBLCUHourly,
+		config.CostRateNLBHourly:             &r.NLBHourly,
+		config.CostRateNLBNLCUHourly:         &r.NLBNLCUHourly,
+		config.CostRatePublicIPv4Hourly:      &r.PublicIPv4Hourly,
+		config.CostRateBaselineCapacityUnits: &r.BaselineCapacityUnits,
+	}
+	for rate, value := range overrides {
+		if ratePtr, ok := ratesByName[rate]; ok {
+			*ratePtr = value
+		}
+	}
+	return r
+}
+
+// CostEstimate contains the rough monthly cost estimate of a LoadBalancer provisioned for a stack.
+// It excludes the cost of traffic beyond the baseline capacity units, data transfer and other AWS resources.
+type CostEstimate struct {
+	// The currency of estimated costs.
+	Currency string `json:"currency"`
+
+	// The estimated monthly cost in total.
+	Monthly float64 `json:"monthly"`
+
+	// The estimated monthly cost of LoadBalancer hours.
+	LoadBalancerHours float64 `json:"loadBalancerHours"`
+
+	// The estimated monthly cost of baseline capacity units, i.e. LCUs for ALB and NLCUs for NLB.
+	CapacityUnits float64 `json:"capacityUnits"`
+
+	// The estimated monthly cost of public IPv4 addresses, including Elastic IP addresses.
+	PublicIPv4Addresses float64 `json:"publicIPv4Addresses"`
+}
+
+// BuildCostEstimate builds the CostEstimate of LoadBalancer provisioned for a deployed stack with rates.
+// it's nil if the stack doesn't provision a LoadBalancer, including existing LoadBalancers referenced by the stack.
+func BuildCostEstimate(stack core.Stack, rates CostRates) (*CostEstimate, error) {
+	var resLBs []*elbv2model.LoadBalancer
+	if err := stack.ListResources(&resLBs); err != nil {
+		return nil, err
+	}
+	if len(resLBs) == 0 || resLBs[0].Spec.ExistingLoadBalancerARN != nil {
+		return nil, nil
+	}
+	resLB := resLBs[0]
+
+	hourlyRate, capacityUnitHourlyRate := rates.ALBHourly, rates.ALBLCUHourly
+	if resLB.Spec.Type == elbv2model.LoadBalancerTypeNetwork {
+		hourlyRate, capacityUnitHourlyRate = rates.NLBHourly, rates.NLBNLCUHourly
+	}
+	publicIPv4Addresses := 0
+	if hasPublicIPv4Addresses(resLB.Spec) {
+		// internet-facing LoadBalancers have a public IPv4 address in each subnet, either assigned by AWS or from Elastic IP.
+		publicIPv4Addresses = len(resLB.Spec.SubnetMappings)
+	}
+
+	estimate := &CostEstimate{
+		Currency:            "USD",
+		LoadBalancerHours:   roundToCents(hourlyRate * hoursPerMonth),
+		CapacityUnits:       roundToCents(capacityUnitHourlyRate * rates.BaselineCapacityUnits * hoursPerMonth),
+		PublicIPv4Addresses: roundToCents(rates.PublicIPv4Hourly * float64(publicIPv4Addresses) * hoursPerMonth),
+	}
+	estimate.Monthly = roundToCents(estimate.LoadBalancerHours + estimate.CapacityUnits + estimate.PublicIPv4Addresses)
+	return estimate, nil
+}
+
+// BuildCostEstimatePayload builds the JSON payload of the CostEstimate of a deployed stack, which is reported via events.
+// it's empty if there is no CostEstimate.
+func BuildCostEstimatePayload(estimate *CostEstimate) (string, error) {
+	if estimate == nil {
+		return "", nil
+	}
+	payload, err := json.Marshal(estimate)
+	if err != nil {
+		return "", err
+	}
+	return string(payload), nil
+}
+
+// SplitCostEstimateByNamespace splits the monthly cost of estimate evenly between members of a stack, and attributes it to their namespaces.
+// it's empty if there is no CostEstimate or no members.
+func SplitCostEstimateByNamespace(estimate *CostEstimate, memberNamespaces []string) map[string]float64 {
+	if estimate == nil || len(memberNamespaces) == 0 {
+		return nil
+	}
+	memberCost := estimate.Monthly / float64(len(memberNamespaces))
+	costByNamespace := make(map[string]float64)
+	for _, namespace := range memberNamespaces {
+		costByNamespace[namespace] += memberCost
+	}
+	for namespace, cost := range costByNamespace {
+		costByNamespace[namespace] = roundToCents(cost)
+	}
+	return costByNamespace
+}
+
+// hasPublicIPv4Addresses checks whether LoadBalancer is charged for public IPv4 addresses.
+func hasPublicIPv4Addresses(lbSpec elbv2model.LoadBalancerSpec) bool {
+	if lbSpec.Scheme == nil || *lbSpec.Scheme != elbv2model.LoadBalancerSchemeInternetFacing {
+		return false
+	}
+	return lbSpec.IPAddressType == nil || *lbSpec.IPAddressType != elbv2model.IPAddressTypeDualStackWithoutPublicIPV4
+}
+
+func roundToCents(cost float64) float64 {
+	return math.Round(cost*100) / 100
+}
@@ -0,0 +1,189 @@
+package deploy
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"testing"
+)
+
+func Test_BuildCostEstimate(t *testing.T) {
+	schemeInternetFacing := elbv2model.LoadBalancerSchemeInternetFacing
+	schemeInternal := elbv2model.LoadBalancerSchemeInternal
+	ipAddressTypeWithoutPublicIPv4 := elbv2model.IPAddressTypeDualStackWithoutPublicIPV4
+	existingLBARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-alb/1234567890"
+	subnetMappings := []elbv2model.SubnetMapping{{SubnetID: "subnet-a"}, {SubnetID: "subnet-b"}, {SubnetID: "subnet-c"}}
+	tests := []struct {
+		name       string
+		buildStack func(stack core.Stack)
+		want       *CostEstimate
+	}{
+		{
+			name: "internet-facing ALB",
+			buildStack: func(stack core.Stack) {
+				elbv2model.NewLoadBalancer(stack, "LoadBalancer", elbv2model.LoadBalancerSpec{
+					Type:           elbv2model.LoadBalancerTypeApplication,
+					Scheme:         &schemeInternetFacing,
+					SubnetMappings: subnetMappings,
+				})
+			},
+			want: &CostEstimate{
+				Currency:            "USD",
+				Monthly:             33.22,
+				LoadBalancerHours:   16.43,
+				CapacityUnits:       5.84,
+				PublicIPv4Addresses: 10.95,
+			},
+		},
+		{
+			name: "internal NLB",
+			buildStack: func(stack core.Stack) {
+				elbv2model.NewLoadBalancer(stack, "LoadBalancer", elbv2model.LoadBalancerSpec{
+					Type:           elbv2model.LoadBalancerTypeNetwork,
+					Scheme:         &schemeInternal,
+					SubnetMappings: subnetMappings,
+				})
+			},
+			want: &CostEstimate{
+				Currency:            "USD",
+				Monthly:             20.81,
+				LoadBalancerHours:   16.43,
+				CapacityUnits:       4.38,
+				PublicIPv4Addresses: 0,
+			},
+		},
+		{
+			name: "internet-facing ALB without public IPv4 addresses",
+			buildStack: func(stack core.Stack) {
+				elbv2model.NewLoadBalancer(stack, "LoadBalancer", elbv2model.LoadBalancerSpec{
+					Type:           elbv2model.LoadBalancerTypeApplication,
+					Scheme:         &schemeInternetFacing,
+					IPAddressType:  &ipAddressTypeWithoutPublicIPv4,
+					SubnetMappings: subnetMappings,
+				})
+			},
+			want: &CostEstimate{
+				Currency:            "USD",
+				Monthly:             22.27,
+				LoadBalancerHours:   16.43,
+				CapacityUnits:       5.84,
+				PublicIPv4Addresses: 0,
+			},
+		},
+		{
+			name: "existing LoadBalancer",
+			buildStack: func(stack core.Stack) {
+				elbv2model.NewLoadBalancer(stack, "LoadBalancer", elbv2model.LoadBalancerSpec{
+					Type:                    elbv2model.LoadBalancerTypeApplication,
+					Scheme:                  &schemeInternetFacing,
+					SubnetMappings:          subnetMappings,
+					ExistingLoadBalancerARN: &existingLBARN,
+				})
+			},
+			want: nil,
+		},
+		{
+			name:       "stack without LoadBalancer",
+			buildStack: func(stack core.Stack) {},
+			want:       nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stack := core.NewDefaultStack(core.StackID{Namespace: "awesome-ns", Name: "awesome-ing"})
+			tt.buildStack(stack)
+			got, err := BuildCostEstimate(stack, DefaultCostRates)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_BuildCostEstimatePayload(t *testing.T) {
+	tests := []struct {
+		name     string
+		estimate *CostEstimate
+		want     string
+	}{
+		{
+			name: "cost estimated",
+			estimate: &CostEstimate{
+				Currency:            "USD",
+				Monthly:             33.22,
+				LoadBalancerHours:   16.43,
+				CapacityUnits:       5.84,
+				PublicIPv4Addresses: 10.95,
+			},
+			want: `{"currency":"USD","monthly":33.22,"loadBalancerHours":16.43,"capacityUnits":5.84,"publicIPv4Addresses":10.95}`,
+		},
+		{
+			name:     "cost not estimated",
+			estimate: nil,
+			want:     "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuildCostEstimatePayload(tt.estimate)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_SplitCostEstimateByNamespace(t *testing.T) {
+	tests := []struct {
+		name             string
+		estimate         *CostEstimate
+		memberNamespaces []string
+		want             map[string]float64
+	}{
+		{
+			name:             "single member",
+			estimate:         &CostEstimate{Currency: "USD", Monthly: 33.22},
+			memberNamespaces: []string{"ns-a"},
+			want:             map[string]float64{"ns-a": 33.22},
+		},
+		{
+			name:             "members across namespaces",
+			estimate:         &CostEstimate{Currency: "USD", Monthly: 33.22},
+			memberNamespaces: []string{"ns-a", "ns-b", "ns-a"},
+			want:             map[string]float64{"ns-a": 22.15, "ns-b": 11.07},
+		},
+		{
+			name:             "cost not estimated",
+			estimate:         nil,
+			memberNamespaces: []string{"ns-a"},
+			want:             nil,
+		},
+		{
+			name:             "no members",
+			estimate:         &CostEstimate{Currency: "USD", Monthly: 33.22},
+			memberNamespaces: nil,
+			want:             nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SplitCostEstimateByNamespace(tt.estimate, tt.memberNamespaces)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_CostRates_WithOverrides(t *testing.T) {
+	got := DefaultCostRates.WithOverrides(config.CostRateOverrides{
+		config.CostRateALBHourly:             0.0252,
+		config.CostRateBaselineCapacityUnits: 2,
+	})
+	assert.Equal(t, CostRates{
+		ALBHourly:             0.0252,
+		ALBLCUHourly:          0.008,
+		NLBHourly:             0.0225,
+		NLBNLCUHourly:         0.006,
+		PublicIPv4Hourly:      0.005,
+		BaselineCapacityUnits: 2,
+	}, got)
+	assert.Equal(t, 0.0225, DefaultCostRates.ALBHourly)
+}
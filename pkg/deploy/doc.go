@@ -0,0 +1,37 @@
+/*
+Package deploy reconciles model stacks into AWS and Kubernetes.
+
+Operators other than this controller can reuse the provisioning engine by constructing a core.Stack
+with resources from pkg/model/elbv2 and pkg/model/ec2, and deploying it with a StackDeployer:
+
+	stack := core.NewDefaultStack(core.StackID{Namespace: "my-ns", Name: "my-app"})
+	lb := elbv2model.NewLoadBalancer(stack, "LoadBalancer", elbv2model.LoadBalancerSpec{...})
+	tg := elbv2model.NewTargetGroup(stack, "my-ns/my-app:80", elbv2model.TargetGroupSpec{...})
+	elbv2model.NewListener(stack, "80", elbv2model.ListenerSpec{LoadBalancerARN: lb.LoadBalancerARN(), ...})
+	elbv2model.NewTargetGroupBindingResource(stack, tg.ID(), elbv2model.TargetGroupBindingResourceSpec{...})
+
+	deployer, err := deploy.NewStackDeployer(cloud, k8sClient, deploy.StackDeployerConfig{
+		ClusterName: "my-cluster",
+		TagPrefix:   "my-operator.example.com",
+	}, logger)
+	if err != nil {
+		return err
+	}
+	err = deployer.Deploy(ctx, stack)
+
+Stacks can also be built from an IngressGroup with the same annotations this controller supports:
+
+	builder, err := ingress.NewModelBuilder(cloud, k8sClient, eventRecorder, ingress.ModelBuilderConfig{
+		ClusterName: "my-cluster",
+	}, logger)
+	if err != nil {
+		return err
+	}
+	stack, _, err := builder.Build(ctx, ingGroup)
+
+AWS resources are tracked by tags derived from the stack ID and TagPrefix: resources that exist in AWS but are absent
+from the stack are deleted, so deploying an empty stack tears down everything previously provisioned for that stack ID.
+TargetGroupBindings in stacks are reconciled by the TargetGroupBinding controller, which needs the TargetGroupBinding CRD
+installed and this controller running in the cluster.
+*/
+package deploy
@@ -0,0 +1,44 @@
+package deploy
+
+import (
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
+	"time"
+)
+
+const (
+	// driftSyncJitterFactor spreads the forced re-synchronizations so that stacks don't re-synchronize at once.
+	driftSyncJitterFactor = 0.1
+)
+
+// ResolveDriftSyncPeriod resolves the period at which a stack is forcibly re-synchronized to revert drifts made outside of the controller.
+// The shortest among defaultPeriod and the drift sync annotation of each object is used, where zero means forced re-synchronization is disabled.
+func ResolveDriftSyncPeriod(annotationParser annotations.Parser, annotationSuffix string, defaultPeriod time.Duration,
+	objAnnotations ...map[string]string) (time.Duration, error) {
+	driftSyncPeriod := defaultPeriod
+	for _, annotations := range objAnnotations {
+		rawPeriod := ""
+		if exists := annotationParser.ParseStringAnnotation(annotationSuffix, &rawPeriod, annotations); !exists {
+			continue
+		}
+		period, err := time.ParseDuration(rawPeriod)
+		if err != nil {
+			return 0, errors.Wrapf(err, "failed to parse drift sync period: %v", rawPeriod)
+		}
+		if period < config.MinDriftSyncPeriod {
+			return 0, errors.Errorf("drift sync period must be at least %v, period: %v", config.MinDriftSyncPeriod, rawPeriod)
+		}
+		if driftSyncPeriod == 0 || period < driftSyncPeriod {
+			driftSyncPeriod = period
+		}
+	}
+	return driftSyncPeriod, nil
+}
+
+// NewDriftSyncRequeue returns an error that instructs controller-runtime to re-synchronize a stack after driftSyncPeriod with jitter.
+func NewDriftSyncRequeue(driftSyncPeriod time.Duration) error {
+	return runtime.NewRequeueNeededAfter("forced drift sync", wait.Jitter(driftSyncPeriod, driftSyncJitterFactor))
+}
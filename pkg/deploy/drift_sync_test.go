@@ -0,0 +1,101 @@
+package deploy
+
+import (
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
+	"testing"
+	"time"
+)
+
+func Test_ResolveDriftSyncPeriod(t *testing.T) {
+	tests := []struct {
+		name           string
+		defaultPeriod  time.Duration
+		objAnnotations []map[string]string
+		want           time.Duration
+		wantErr        error
+	}{
+		{
+			name:          "disabled by default",
+			defaultPeriod: 0,
+			objAnnotations: []map[string]string{
+				{},
+			},
+			want: 0,
+		},
+		{
+			name:          "default period",
+			defaultPeriod: 1 * time.Hour,
+			objAnnotations: []map[string]string{
+				{},
+			},
+			want: 1 * time.Hour,
+		},
+		{
+			name:          "annotation opts into drift sync",
+			defaultPeriod: 0,
+			objAnnotations: []map[string]string{
+				{"alb.ingress.kubernetes.io/drift-sync-period": "5m"},
+			},
+			want: 5 * time.Minute,
+		},
+		{
+			name:          "shortest period is used",
+			defaultPeriod: 1 * time.Hour,
+			objAnnotations: []map[string]string{
+				{"alb.ingress.kubernetes.io/drift-sync-period": "5m"},
+				{"alb.ingress.kubernetes.io/drift-sync-period": "2m"},
+				{},
+			},
+			want: 2 * time.Minute,
+		},
+		{
+			name:          "annotation longer than default period",
+			defaultPeriod: 1 * time.Minute,
+			objAnnotations: []map[string]string{
+				{"alb.ingress.kubernetes.io/drift-sync-period": "5m"},
+			},
+			want: 1 * time.Minute,
+		},
+		{
+			name:          "invalid period",
+			defaultPeriod: 0,
+			objAnnotations: []map[string]string{
+				{"alb.ingress.kubernetes.io/drift-sync-period": "5"},
+			},
+			wantErr: errors.New("failed to parse drift sync period: 5: time: missing unit in duration \"5\""),
+		},
+		{
+			name:          "period too short",
+			defaultPeriod: 0,
+			objAnnotations: []map[string]string{
+				{"alb.ingress.kubernetes.io/drift-sync-period": "10s"},
+			},
+			wantErr: errors.New("drift sync period must be at least 30s, period: 10s"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			annotationParser := annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
+			got, err := ResolveDriftSyncPeriod(annotationParser, annotations.IngressSuffixDriftSyncPeriod, tt.defaultPeriod, tt.objAnnotations...)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_NewDriftSyncRequeue(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		err := NewDriftSyncRequeue(10 * time.Minute)
+		var requeueNeededAfter *runtime.RequeueNeededAfter
+		assert.True(t, errors.As(err, &requeueNeededAfter))
+		assert.True(t, requeueNeededAfter.Duration() >= 10*time.Minute)
+		assert.True(t, requeueNeededAfter.Duration() <= 11*time.Minute)
+	}
+}
@@ -0,0 +1,256 @@
+package ec2
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
+	"sync"
+	"time"
+)
+
+const (
+	// ElasticIPPoolTagKey is the tag key that assigns Elastic IP addresses to a pool, the tag value is the pool name.
+	ElasticIPPoolTagKey = "elbv2.k8s.aws/eip-pool"
+	// ElasticIPPoolAllocatedTagKey is the tag key for Elastic IP addresses allocated into pool by controller.
+	// such Elastic IP addresses are released instead of returned to pool once they are no longer needed.
+	ElasticIPPoolAllocatedTagKey = "elbv2.k8s.aws/eip-pool-allocated"
+
+	defaultWaitEIPReleasePollInterval = 2 * time.Second
+	defaultWaitEIPReleaseTimeout      = 2 * time.Minute
+)
+
+// ElasticIPInfo wraps necessary information about an Elastic IP address.
+type ElasticIPInfo struct {
+	// The allocation ID of Elastic IP address.
+	AllocationID string
+	// The Elastic IP address.
+	PublicIP string
+	// The association ID of Elastic IP address, it's empty if not associated.
+	AssociationID string
+	// Tags on Elastic IP address.
+	Tags map[string]string
+}
+
+// NewRawElasticIPInfo constructs new ElasticIPInfo from raw address.
+func NewRawElasticIPInfo(address *ec2sdk.Address) ElasticIPInfo {
+	tags := make(map[string]string, len(address.Tags))
+	for _, tag := range address.Tags {
+		tags[awssdk.StringValue(tag.Key)] = awssdk.StringValue(tag.Value)
+	}
+	return ElasticIPInfo{
+		AllocationID:  awssdk.StringValue(address.AllocationId),
+		PublicIP:      awssdk.StringValue(address.PublicIp),
+		AssociationID: awssdk.StringValue(address.AssociationId),
+		Tags:          tags,
+	}
+}
+
+// ElasticIPManager is responsible for claim/update/release ElasticIP resources.
+type ElasticIPManager interface {
+	// Create claims a free Elastic IP address from pool, or allocates a new one into pool if allowed.
+	Create(ctx context.Context, resEIP *ec2model.ElasticIP) (ec2model.ElasticIPStatus, error)
+
+	Update(ctx context.Context, resEIP *ec2model.ElasticIP, sdkEIP ElasticIPInfo) (ec2model.ElasticIPStatus, error)
+
+	// Delete releases Elastic IP addresses allocated by controller, and returns others to their pool.
+	Delete(ctx context.Context, stack core.Stack, sdkEIP ElasticIPInfo) error
+}
+
+// NewDefaultElasticIPManager constructs new defaultElasticIPManager.
+func NewDefaultElasticIPManager(ec2Client services.EC2, trackingProvider tracking.Provider, taggingManager TaggingManager, logger logr.Logger) *defaultElasticIPManager {
+	return &defaultElasticIPManager{
+		ec2Client:        ec2Client,
+		trackingProvider: trackingProvider,
+		taggingManager:   taggingManager,
+		logger:           logger,
+
+		waitEIPReleasePollInterval: defaultWaitEIPReleasePollInterval,
+		waitEIPReleaseTimeout:      defaultWaitEIPReleaseTimeout,
+	}
+}
+
+var _ ElasticIPManager = &defaultElasticIPManager{}
+
+// default implementation for ElasticIPManager.
+// Elastic IP addresses are claimed by tagging them with the tracking tags of ElasticIP resources.
+type defaultElasticIPManager struct {
+	ec2Client        services.EC2
+	trackingProvider tracking.Provider
+	taggingManager   TaggingManager
+	logger           logr.Logger
+
+	waitEIPReleasePollInterval time.Duration
+	waitEIPReleaseTimeout      time.Duration
+
+	// claimMutex serializes claims, so that concurrently deployed stacks won't claim the same Elastic IP address.
+	claimMutex sync.Mutex
+}
+
+func (m *defaultElasticIPManager) Create(ctx context.Context, resEIP *ec2model.ElasticIP) (ec2model.ElasticIPStatus, error) {
+	m.claimMutex.Lock()
+	defer m.claimMutex.Unlock()
+
+	poolEIPs, err := m.taggingManager.ListElasticIPs(ctx, tracking.TagFilter{
+		ElasticIPPoolTagKey: {resEIP.Spec.PoolName},
+	})
+	if err != nil {
+		return ec2model.ElasticIPStatus{}, err
+	}
+	for _, poolEIP := range poolEIPs {
+		if !m.isElasticIPFree(poolEIP) {
+			continue
+		}
+		return m.claimElasticIP(ctx, resEIP, poolEIP)
+	}
+	if !resEIP.Spec.AllocateIfPoolEmpty {
+		return ec2model.ElasticIPStatus{}, runtime.NewClassifiedError(runtime.ErrorClassQuotaExceeded,
+			errors.Errorf("no free Elastic IP in pool %v", resEIP.Spec.PoolName))
+	}
+	return m.allocateElasticIP(ctx, resEIP)
+}
+
+func (m *defaultElasticIPManager) Update(ctx context.Context, resEIP *ec2model.ElasticIP, sdkEIP ElasticIPInfo) (ec2model.ElasticIPStatus, error) {
+	// tags on Elastic IP addresses are owned by pool owners, only the tracking tags are added back if missing.
+	desiredTags := make(map[string]string, len(sdkEIP.Tags))
+	for key, value := range sdkEIP.Tags {
+		desiredTags[key] = value
+	}
+	for key, value := range m.trackingProvider.ResourceTags(resEIP.Stack(), resEIP, nil) {
+		desiredTags[key] = value
+	}
+	if err := m.taggingManager.ReconcileTags(ctx, sdkEIP.AllocationID, desiredTags, WithCurrentTags(sdkEIP.Tags)); err != nil {
+		return ec2model.ElasticIPStatus{}, err
+	}
+	return ec2model.ElasticIPStatus{
+		AllocationID: sdkEIP.AllocationID,
+		PublicIP:     sdkEIP.PublicIP,
+	}, nil
+}
+
+func (m *defaultElasticIPManager) Delete(ctx context.Context, stack core.Stack, sdkEIP ElasticIPInfo) error {
+	if _, allocated := sdkEIP.Tags[ElasticIPPoolAllocatedTagKey]; allocated {
+		return m.releaseElasticIP(ctx, sdkEIP)
+	}
+	return m.returnElasticIP(ctx, stack, sdkEIP)
+}
+
+// isElasticIPFree checks whether the Elastic IP address is neither associated nor claimed by any resource.
+func (m *defaultElasticIPManager) isElasticIPFree(sdkEIP ElasticIPInfo) bool {
+	if sdkEIP.AssociationID != "" {
+		return false
+	}
+	_, claimed := sdkEIP.Tags[m.trackingProvider.ResourceIDTagKey()]
+	return !claimed
+}
+
+func (m *defaultElasticIPManager) claimElasticIP(ctx context.Context, resEIP *ec2model.ElasticIP, sdkEIP ElasticIPInfo) (ec2model.ElasticIPStatus, error) {
+	eipTags := m.trackingProvider.ResourceTags(resEIP.Stack(), resEIP, nil)
+	req := &ec2sdk.CreateTagsInput{
+		Resources: []*string{awssdk.String(sdkEIP.AllocationID)},
+		Tags:      convertTagsToSDKTags(eipTags),
+	}
+	m.logger.Info("claiming elasticIP",
+		"resourceID", resEIP.ID(),
+		"pool", resEIP.Spec.PoolName,
+		"allocationID", sdkEIP.AllocationID)
+	if _, err := m.ec2Client.CreateTagsWithContext(ctx, req); err != nil {
+		return ec2model.ElasticIPStatus{}, errors.Wrap(err, "failed to claim elasticIP")
+	}
+	m.logger.Info("claimed elasticIP",
+		"resourceID", resEIP.ID(),
+		"allocationID", sdkEIP.AllocationID)
+	return ec2model.ElasticIPStatus{
+		AllocationID: sdkEIP.AllocationID,
+		PublicIP:     sdkEIP.PublicIP,
+	}, nil
+}
+
+func (m *defaultElasticIPManager) allocateElasticIP(ctx context.Context, resEIP *ec2model.ElasticIP) (ec2model.ElasticIPStatus, error) {
+	eipTags := m.trackingProvider.ResourceTags(resEIP.Stack(), resEIP, resEIP.Spec.Tags)
+	eipTags[ElasticIPPoolTagKey] = resEIP.Spec.PoolName
+	eipTags[ElasticIPPoolAllocatedTagKey] = "true"
+	req := &ec2sdk.AllocateAddressInput{
+		Domain: awssdk.String(ec2sdk.DomainTypeVpc),
+		TagSpecifications: []*ec2sdk.TagSpecification{
+			{
+				ResourceType: awssdk.String("elastic-ip"),
+				Tags:         convertTagsToSDKTags(eipTags),
+			},
+		},
+	}
+	m.logger.Info("allocating elasticIP",
+		"resourceID", resEIP.ID(),
+		"pool", resEIP.Spec.PoolName)
+	resp, err := m.ec2Client.AllocateAddressWithContext(ctx, req)
+	if err != nil {
+		return ec2model.ElasticIPStatus{}, errors.Wrap(err, "failed to allocate elasticIP")
+	}
+	m.logger.Info("allocated elasticIP",
+		"resourceID", resEIP.ID(),
+		"allocationID", awssdk.StringValue(resp.AllocationId))
+	return ec2model.ElasticIPStatus{
+		AllocationID: awssdk.StringValue(resp.AllocationId),
+		PublicIP:     awssdk.StringValue(resp.PublicIp),
+	}, nil
+}
+
+func (m *defaultElasticIPManager) releaseElasticIP(ctx context.Context, sdkEIP ElasticIPInfo) error {
+	req := &ec2sdk.ReleaseAddressInput{
+		AllocationId: awssdk.String(sdkEIP.AllocationID),
+	}
+	m.logger.Info("releasing elasticIP",
+		"allocationID", sdkEIP.AllocationID)
+	// the association is removed asynchronously after LoadBalancer deletion.
+	if err := runtime.RetryImmediateOnError(m.waitEIPReleasePollInterval, m.waitEIPReleaseTimeout, isElasticIPInUseError, func() error {
+		_, err := m.ec2Client.ReleaseAddressWithContext(ctx, req)
+		return err
+	}); err != nil {
+		return errors.Wrap(err, "failed to release elasticIP")
+	}
+	m.logger.Info("released elasticIP",
+		"allocationID", sdkEIP.AllocationID)
+	return nil
+}
+
+func (m *defaultElasticIPManager) returnElasticIP(ctx context.Context, stack core.Stack, sdkEIP ElasticIPInfo) error {
+	trackingTagKeys := append([]string{m.trackingProvider.ResourceIDTagKey()}, sets.StringKeySet(m.trackingProvider.StackTags(stack)).List()...)
+	trackingTags := make(map[string]string)
+	for _, key := range trackingTagKeys {
+		if value, ok := sdkEIP.Tags[key]; ok {
+			trackingTags[key] = value
+		}
+	}
+	if len(trackingTags) == 0 {
+		return nil
+	}
+	req := &ec2sdk.DeleteTagsInput{
+		Resources: []*string{awssdk.String(sdkEIP.AllocationID)},
+		Tags:      convertTagsToSDKTags(trackingTags),
+	}
+	m.logger.Info("returning elasticIP to pool",
+		"allocationID", sdkEIP.AllocationID,
+		"pool", sdkEIP.Tags[ElasticIPPoolTagKey])
+	if _, err := m.ec2Client.DeleteTagsWithContext(ctx, req); err != nil {
+		return errors.Wrap(err, "failed to return elasticIP to pool")
+	}
+	m.logger.Info("returned elasticIP to pool",
+		"allocationID", sdkEIP.AllocationID)
+	return nil
+}
+
+func isElasticIPInUseError(err error) bool {
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		return awsErr.Code() == "InvalidIPAddress.InUse"
+	}
+	return false
+}
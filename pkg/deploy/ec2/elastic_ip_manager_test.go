@@ -0,0 +1,167 @@
+package ec2
+
+import (
+	"context"
+	"errors"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_defaultElasticIPManager_Create(t *testing.T) {
+	type describeAddressesCall struct {
+		resp *ec2sdk.DescribeAddressesOutput
+		err  error
+	}
+	type createTagsCall struct {
+		req *ec2sdk.CreateTagsInput
+		err error
+	}
+	type allocateAddressCall struct {
+		resp *ec2sdk.AllocateAddressOutput
+		err  error
+	}
+	claimTags := []*ec2sdk.Tag{
+		{Key: awssdk.String("elbv2.k8s.aws/cluster"), Value: awssdk.String("cluster-name")},
+		{Key: awssdk.String("service.k8s.aws/resource"), Value: awssdk.String("ElasticIP-subnet-1")},
+		{Key: awssdk.String("service.k8s.aws/stack"), Value: awssdk.String("namespace/name")},
+	}
+	tests := []struct {
+		name                  string
+		spec                  ec2model.ElasticIPSpec
+		describeAddressesCall describeAddressesCall
+		createTagsCalls       []createTagsCall
+		allocateAddressCalls  []allocateAddressCall
+		want                  ec2model.ElasticIPStatus
+		wantErr               error
+	}{
+		{
+			name: "claims the first free Elastic IP from pool",
+			spec: ec2model.ElasticIPSpec{PoolName: "my-pool"},
+			describeAddressesCall: describeAddressesCall{
+				resp: &ec2sdk.DescribeAddressesOutput{
+					Addresses: []*ec2sdk.Address{
+						{
+							AllocationId:  awssdk.String("eipalloc-a"),
+							PublicIp:      awssdk.String("1.1.1.1"),
+							AssociationId: awssdk.String("eipassoc-a"),
+						},
+						{
+							AllocationId: awssdk.String("eipalloc-b"),
+							PublicIp:     awssdk.String("2.2.2.2"),
+							Tags: []*ec2sdk.Tag{
+								{Key: awssdk.String("service.k8s.aws/resource"), Value: awssdk.String("ElasticIP-subnet-2")},
+							},
+						},
+						{
+							AllocationId: awssdk.String("eipalloc-c"),
+							PublicIp:     awssdk.String("3.3.3.3"),
+						},
+					},
+				},
+			},
+			createTagsCalls: []createTagsCall{
+				{
+					req: &ec2sdk.CreateTagsInput{
+						Resources: awssdk.StringSlice([]string{"eipalloc-c"}),
+						Tags:      claimTags,
+					},
+				},
+			},
+			want: ec2model.ElasticIPStatus{
+				AllocationID: "eipalloc-c",
+				PublicIP:     "3.3.3.3",
+			},
+		},
+		{
+			name: "allocates Elastic IP when pool is empty",
+			spec: ec2model.ElasticIPSpec{PoolName: "my-pool", AllocateIfPoolEmpty: true},
+			describeAddressesCall: describeAddressesCall{
+				resp: &ec2sdk.DescribeAddressesOutput{},
+			},
+			allocateAddressCalls: []allocateAddressCall{
+				{
+					resp: &ec2sdk.AllocateAddressOutput{
+						AllocationId: awssdk.String("eipalloc-d"),
+						PublicIp:     awssdk.String("4.4.4.4"),
+					},
+				},
+			},
+			want: ec2model.ElasticIPStatus{
+				AllocationID: "eipalloc-d",
+				PublicIP:     "4.4.4.4",
+			},
+		},
+		{
+			name: "fails when pool is empty",
+			spec: ec2model.ElasticIPSpec{PoolName: "my-pool"},
+			describeAddressesCall: describeAddressesCall{
+				resp: &ec2sdk.DescribeAddressesOutput{},
+			},
+			wantErr: errors.New("no free Elastic IP in pool my-pool"),
+		},
+		{
+			name: "fails when addresses cannot be described",
+			spec: ec2model.ElasticIPSpec{PoolName: "my-pool"},
+			describeAddressesCall: describeAddressesCall{
+				err: errors.New("some error"),
+			},
+			wantErr: errors.New("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ec2Client := mock_services.NewMockEC2(ctrl)
+			ec2Client.EXPECT().DescribeAddressesWithContext(gomock.Any(), &ec2sdk.DescribeAddressesInput{
+				Filters: []*ec2sdk.Filter{
+					{
+						Name:   awssdk.String("domain"),
+						Values: awssdk.StringSlice([]string{"vpc"}),
+					},
+					{
+						Name:   awssdk.String("tag:elbv2.k8s.aws/eip-pool"),
+						Values: awssdk.StringSlice([]string{"my-pool"}),
+					},
+				},
+			}).Return(tt.describeAddressesCall.resp, tt.describeAddressesCall.err)
+			for _, call := range tt.createTagsCalls {
+				ec2Client.EXPECT().CreateTagsWithContext(gomock.Any(), call.req).Return(&ec2sdk.CreateTagsOutput{}, call.err)
+			}
+			for _, call := range tt.allocateAddressCalls {
+				ec2Client.EXPECT().AllocateAddressWithContext(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, req *ec2sdk.AllocateAddressInput, opts ...interface{}) (*ec2sdk.AllocateAddressOutput, error) {
+						tags := make(map[string]string)
+						for _, tag := range req.TagSpecifications[0].Tags {
+							tags[awssdk.StringValue(tag.Key)] = awssdk.StringValue(tag.Value)
+						}
+						assert.Equal(t, "my-pool", tags[ElasticIPPoolTagKey])
+						assert.Equal(t, "true", tags[ElasticIPPoolAllocatedTagKey])
+						assert.Equal(t, "ElasticIP-subnet-1", tags["service.k8s.aws/resource"])
+						return call.resp, call.err
+					})
+			}
+
+			trackingProvider := tracking.NewDefaultProvider("service.k8s.aws", "cluster-name")
+			m := NewDefaultElasticIPManager(ec2Client, trackingProvider, &defaultTaggingManager{ec2Client: ec2Client}, &log.NullLogger{})
+			stack := core.NewDefaultStack(core.StackID{Namespace: "namespace", Name: "name"})
+			resEIP := ec2model.NewElasticIP(stack, "ElasticIP-subnet-1", tt.spec)
+			got, err := m.Create(context.Background(), resEIP)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
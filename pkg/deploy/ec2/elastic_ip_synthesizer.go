@@ -0,0 +1,124 @@
+package ec2
+
+import (
+	"context"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+)
+
+// NewElasticIPSynthesizer constructs new elasticIPSynthesizer.
+func NewElasticIPSynthesizer(trackingProvider tracking.Provider, taggingManager TaggingManager,
+	eipManager ElasticIPManager, logger logr.Logger, stack core.Stack) *elasticIPSynthesizer {
+	return &elasticIPSynthesizer{
+		trackingProvider: trackingProvider,
+		taggingManager:   taggingManager,
+		eipManager:       eipManager,
+		logger:           logger,
+		stack:            stack,
+		unmatchedSDKEIPs: nil,
+	}
+}
+
+type elasticIPSynthesizer struct {
+	trackingProvider tracking.Provider
+	taggingManager   TaggingManager
+	eipManager       ElasticIPManager
+	logger           logr.Logger
+
+	stack            core.Stack
+	unmatchedSDKEIPs []ElasticIPInfo
+}
+
+func (s *elasticIPSynthesizer) Synthesize(ctx context.Context) error {
+	var resEIPs []*ec2model.ElasticIP
+	s.stack.ListResources(&resEIPs)
+	sdkEIPs, err := s.findSDKElasticIPs(ctx)
+	if err != nil {
+		return err
+	}
+	matchedResAndSDKEIPs, unmatchedResEIPs, unmatchedSDKEIPs, err := matchResAndSDKElasticIPs(resEIPs, sdkEIPs, s.trackingProvider.ResourceIDTagKey())
+	if err != nil {
+		return err
+	}
+
+	// For ElasticIP, we release unmatched ones during post synthesize, after they are disassociated from LoadBalancers.
+	s.unmatchedSDKEIPs = unmatchedSDKEIPs
+
+	for _, resEIP := range unmatchedResEIPs {
+		eipStatus, err := s.eipManager.Create(ctx, resEIP)
+		if err != nil {
+			return err
+		}
+		resEIP.SetStatus(eipStatus)
+	}
+	for _, resAndSDKEIP := range matchedResAndSDKEIPs {
+		eipStatus, err := s.eipManager.Update(ctx, resAndSDKEIP.resEIP, resAndSDKEIP.sdkEIP)
+		if err != nil {
+			return err
+		}
+		resAndSDKEIP.resEIP.SetStatus(eipStatus)
+	}
+	return nil
+}
+
+func (s *elasticIPSynthesizer) PostSynthesize(ctx context.Context) error {
+	for _, sdkEIP := range s.unmatchedSDKEIPs {
+		if err := s.eipManager.Delete(ctx, s.stack, sdkEIP); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findSDKElasticIPs will find all Elastic IP addresses claimed for stack.
+func (s *elasticIPSynthesizer) findSDKElasticIPs(ctx context.Context) ([]ElasticIPInfo, error) {
+	stackTags := s.trackingProvider.StackTags(s.stack)
+	return s.taggingManager.ListElasticIPs(ctx, tracking.TagsAsTagFilter(stackTags))
+}
+
+type resAndSDKElasticIPPair struct {
+	resEIP *ec2model.ElasticIP
+	sdkEIP ElasticIPInfo
+}
+
+func matchResAndSDKElasticIPs(resEIPs []*ec2model.ElasticIP, sdkEIPs []ElasticIPInfo,
+	resourceIDTagKey string) ([]resAndSDKElasticIPPair, []*ec2model.ElasticIP, []ElasticIPInfo, error) {
+	var matchedResAndSDKEIPs []resAndSDKElasticIPPair
+	var unmatchedResEIPs []*ec2model.ElasticIP
+	var unmatchedSDKEIPs []ElasticIPInfo
+
+	resEIPsByID := make(map[string]*ec2model.ElasticIP, len(resEIPs))
+	for _, resEIP := range resEIPs {
+		resEIPsByID[resEIP.ID()] = resEIP
+	}
+	sdkEIPsByID := make(map[string][]ElasticIPInfo, len(sdkEIPs))
+	for _, sdkEIP := range sdkEIPs {
+		resourceID, ok := sdkEIP.Tags[resourceIDTagKey]
+		if !ok {
+			return nil, nil, nil, errors.Errorf("unexpected elasticIP with no resourceID: %v", sdkEIP.AllocationID)
+		}
+		sdkEIPsByID[resourceID] = append(sdkEIPsByID[resourceID], sdkEIP)
+	}
+
+	resEIPIDs := sets.StringKeySet(resEIPsByID)
+	sdkEIPIDs := sets.StringKeySet(sdkEIPsByID)
+	for _, resID := range resEIPIDs.Intersection(sdkEIPIDs).List() {
+		sdkEIPs := sdkEIPsByID[resID]
+		matchedResAndSDKEIPs = append(matchedResAndSDKEIPs, resAndSDKElasticIPPair{
+			resEIP: resEIPsByID[resID],
+			sdkEIP: sdkEIPs[0],
+		})
+		unmatchedSDKEIPs = append(unmatchedSDKEIPs, sdkEIPs[1:]...)
+	}
+	for _, resID := range resEIPIDs.Difference(sdkEIPIDs).List() {
+		unmatchedResEIPs = append(unmatchedResEIPs, resEIPsByID[resID])
+	}
+	for _, resID := range sdkEIPIDs.Difference(resEIPIDs).List() {
+		unmatchedSDKEIPs = append(unmatchedSDKEIPs, sdkEIPsByID[resID]...)
+	}
+	return matchedResAndSDKEIPs, unmatchedResEIPs, unmatchedSDKEIPs, nil
+}
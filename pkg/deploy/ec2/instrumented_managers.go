@@ -0,0 +1,201 @@
+package ec2
+
+import (
+	"context"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/metrics"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+)
+
+const (
+	resourceKindSecurityGroup      = "AWS::EC2::SecurityGroup"
+	resourceKindElasticIP          = "AWS::EC2::EIP"
+	resourceKindIPAMPoolAllocation = "AWS::EC2::IPAMPoolAllocation"
+	resourceKindVPCEndpointService = "AWS::EC2::VPCEndpointService"
+)
+
+// NewInstrumentedSecurityGroupManager constructs new SecurityGroupManager that collects metrics for sgManager.
+func NewInstrumentedSecurityGroupManager(sgManager SecurityGroupManager, metricsCollector metrics.Collector) *instrumentedSecurityGroupManager {
+	return &instrumentedSecurityGroupManager{
+		SecurityGroupManager: sgManager,
+		metricsCollector:     metricsCollector,
+	}
+}
+
+var _ SecurityGroupManager = &instrumentedSecurityGroupManager{}
+
+type instrumentedSecurityGroupManager struct {
+	SecurityGroupManager
+	metricsCollector metrics.Collector
+}
+
+func (m *instrumentedSecurityGroupManager) Create(ctx context.Context, resSG *ec2model.SecurityGroup) (ec2model.SecurityGroupStatus, error) {
+	var sgStatus ec2model.SecurityGroupStatus
+	err := metrics.InstrumentResourceOperation(ctx, m.metricsCollector, resourceKindSecurityGroup, metrics.OperationCreate, func(ctx context.Context) error {
+		var err error
+		sgStatus, err = m.SecurityGroupManager.Create(ctx, resSG)
+		return err
+	})
+	return sgStatus, err
+}
+
+func (m *instrumentedSecurityGroupManager) Update(ctx context.Context, resSG *ec2model.SecurityGroup, sdkSG networking.SecurityGroupInfo) (ec2model.SecurityGroupStatus, error) {
+	var sgStatus ec2model.SecurityGroupStatus
+	err := metrics.InstrumentResourceOperation(ctx, m.metricsCollector, resourceKindSecurityGroup, metrics.OperationUpdate, func(ctx context.Context) error {
+		var err error
+		sgStatus, err = m.SecurityGroupManager.Update(ctx, resSG, sdkSG)
+		return err
+	})
+	return sgStatus, err
+}
+
+func (m *instrumentedSecurityGroupManager) Delete(ctx context.Context, sdkSG networking.SecurityGroupInfo) error {
+	return metrics.InstrumentResourceOperation(ctx, m.metricsCollector, resourceKindSecurityGroup, metrics.OperationDelete, func(ctx context.Context) error {
+		return m.SecurityGroupManager.Delete(ctx, sdkSG)
+	})
+}
+
+// NewInstrumentedElasticIPManager constructs new ElasticIPManager that collects metrics for eipManager.
+func NewInstrumentedElasticIPManager(eipManager ElasticIPManager, metricsCollector metrics.Collector) *instrumentedElasticIPManager {
+	return &instrumentedElasticIPManager{
+		ElasticIPManager: eipManager,
+		metricsCollector: metricsCollector,
+	}
+}
+
+var _ ElasticIPManager = &instrumentedElasticIPManager{}
+
+type instrumentedElasticIPManager struct {
+	ElasticIPManager
+	metricsCollector metrics.Collector
+}
+
+func (m *instrumentedElasticIPManager) Create(ctx context.Context, resEIP *ec2model.ElasticIP) (ec2model.ElasticIPStatus, error) {
+	var eipStatus ec2model.ElasticIPStatus
+	err := metrics.InstrumentResourceOperation(ctx, m.metricsCollector, resourceKindElasticIP, metrics.OperationCreate, func(ctx context.Context) error {
+		var err error
+		eipStatus, err = m.ElasticIPManager.Create(ctx, resEIP)
+		return err
+	})
+	return eipStatus, err
+}
+
+func (m *instrumentedElasticIPManager) Update(ctx context.Context, resEIP *ec2model.ElasticIP, sdkEIP ElasticIPInfo) (ec2model.ElasticIPStatus, error) {
+	var eipStatus ec2model.ElasticIPStatus
+	err := metrics.InstrumentResourceOperation(ctx, m.metricsCollector, resourceKindElasticIP, metrics.OperationUpdate, func(ctx context.Context) error {
+		var err error
+		eipStatus, err = m.ElasticIPManager.Update(ctx, resEIP, sdkEIP)
+		return err
+	})
+	return eipStatus, err
+}
+
+func (m *instrumentedElasticIPManager) Delete(ctx context.Context, stack core.Stack, sdkEIP ElasticIPInfo) error {
+	return metrics.InstrumentResourceOperation(ctx, m.metricsCollector, resourceKindElasticIP, metrics.OperationDelete, func(ctx context.Context) error {
+		return m.ElasticIPManager.Delete(ctx, stack, sdkEIP)
+	})
+}
+
+// NewInstrumentedIPAMPoolAllocationManager constructs new IPAMPoolAllocationManager that collects metrics for allocationManager.
+func NewInstrumentedIPAMPoolAllocationManager(allocationManager IPAMPoolAllocationManager, metricsCollector metrics.Collector) *instrumentedIPAMPoolAllocationManager {
+	return &instrumentedIPAMPoolAllocationManager{
+		IPAMPoolAllocationManager: allocationManager,
+		metricsCollector:          metricsCollector,
+	}
+}
+
+var _ IPAMPoolAllocationManager = &instrumentedIPAMPoolAllocationManager{}
+
+type instrumentedIPAMPoolAllocationManager struct {
+	IPAMPoolAllocationManager
+	metricsCollector metrics.Collector
+}
+
+func (m *instrumentedIPAMPoolAllocationManager) Create(ctx context.Context, resAllocation *ec2model.IPAMPoolAllocation) (ec2model.IPAMPoolAllocationStatus, error) {
+	var allocationStatus ec2model.IPAMPoolAllocationStatus
+	err := metrics.InstrumentResourceOperation(ctx, m.metricsCollector, resourceKindIPAMPoolAllocation, metrics.OperationCreate, func(ctx context.Context) error {
+		var err error
+		allocationStatus, err = m.IPAMPoolAllocationManager.Create(ctx, resAllocation)
+		return err
+	})
+	return allocationStatus, err
+}
+
+func (m *instrumentedIPAMPoolAllocationManager) Update(ctx context.Context, resAllocation *ec2model.IPAMPoolAllocation, sdkAllocation IPAMPoolAllocationInfo) (ec2model.IPAMPoolAllocationStatus, error) {
+	var allocationStatus ec2model.IPAMPoolAllocationStatus
+	err := metrics.InstrumentResourceOperation(ctx, m.metricsCollector, resourceKindIPAMPoolAllocation, metrics.OperationUpdate, func(ctx context.Context) error {
+		var err error
+		allocationStatus, err = m.IPAMPoolAllocationManager.Update(ctx, resAllocation, sdkAllocation)
+		return err
+	})
+	return allocationStatus, err
+}
+
+func (m *instrumentedIPAMPoolAllocationManager) Delete(ctx context.Context, sdkAllocation IPAMPoolAllocationInfo) error {
+	return metrics.InstrumentResourceOperation(ctx, m.metricsCollector, resourceKindIPAMPoolAllocation, metrics.OperationDelete, func(ctx context.Context) error {
+		return m.IPAMPoolAllocationManager.Delete(ctx, sdkAllocation)
+	})
+}
+
+// NewInstrumentedTaggingManager constructs new TaggingManager that collects metrics for taggingManager.
+func NewInstrumentedTaggingManager(taggingManager TaggingManager, metricsCollector metrics.Collector) *instrumentedTaggingManager {
+	return &instrumentedTaggingManager{
+		TaggingManager:   taggingManager,
+		metricsCollector: metricsCollector,
+	}
+}
+
+var _ TaggingManager = &instrumentedTaggingManager{}
+
+type instrumentedTaggingManager struct {
+	TaggingManager
+	metricsCollector metrics.Collector
+}
+
+func (m *instrumentedTaggingManager) ReconcileTags(ctx context.Context, resID string, desiredTags map[string]string, opts ...ReconcileTagsOption) error {
+	return metrics.InstrumentTagsReconcile(ctx, m.metricsCollector, resourceKindSecurityGroup, func(ctx context.Context) error {
+		return m.TaggingManager.ReconcileTags(ctx, resID, desiredTags, opts...)
+	})
+}
+
+// NewInstrumentedVPCEndpointServiceManager constructs new VPCEndpointServiceManager that collects metrics for esManager.
+func NewInstrumentedVPCEndpointServiceManager(esManager VPCEndpointServiceManager, metricsCollector metrics.Collector) *instrumentedVPCEndpointServiceManager {
+	return &instrumentedVPCEndpointServiceManager{
+		VPCEndpointServiceManager: esManager,
+		metricsCollector:          metricsCollector,
+	}
+}
+
+var _ VPCEndpointServiceManager = &instrumentedVPCEndpointServiceManager{}
+
+type instrumentedVPCEndpointServiceManager struct {
+	VPCEndpointServiceManager
+	metricsCollector metrics.Collector
+}
+
+func (m *instrumentedVPCEndpointServiceManager) Create(ctx context.Context, resES *ec2model.VPCEndpointService) (ec2model.VPCEndpointServiceStatus, error) {
+	var esStatus ec2model.VPCEndpointServiceStatus
+	err := metrics.InstrumentResourceOperation(ctx, m.metricsCollector, resourceKindVPCEndpointService, metrics.OperationCreate, func(ctx context.Context) error {
+		var err error
+		esStatus, err = m.VPCEndpointServiceManager.Create(ctx, resES)
+		return err
+	})
+	return esStatus, err
+}
+
+func (m *instrumentedVPCEndpointServiceManager) Update(ctx context.Context, resES *ec2model.VPCEndpointService, sdkES VPCEndpointServiceInfo) (ec2model.VPCEndpointServiceStatus, error) {
+	var esStatus ec2model.VPCEndpointServiceStatus
+	err := metrics.InstrumentResourceOperation(ctx, m.metricsCollector, resourceKindVPCEndpointService, metrics.OperationUpdate, func(ctx context.Context) error {
+		var err error
+		esStatus, err = m.VPCEndpointServiceManager.Update(ctx, resES, sdkES)
+		return err
+	})
+	return esStatus, err
+}
+
+func (m *instrumentedVPCEndpointServiceManager) Delete(ctx context.Context, sdkES VPCEndpointServiceInfo) error {
+	return metrics.InstrumentResourceOperation(ctx, m.metricsCollector, resourceKindVPCEndpointService, metrics.OperationDelete, func(ctx context.Context) error {
+		return m.VPCEndpointServiceManager.Delete(ctx, sdkES)
+	})
+}
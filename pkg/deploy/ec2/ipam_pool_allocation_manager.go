@@ -0,0 +1,221 @@
+package ec2
+
+import (
+	"context"
+	"fmt"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"net"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+	"strings"
+	"time"
+)
+
+const (
+	// the number of addresses reserved by AWS at the start of each subnet.
+	subnetReservedLeadingAddresses = 4
+
+	defaultAllocationsCacheTTL = 10 * time.Minute
+	allocationsCacheKey        = "allocations"
+)
+
+// IPAMPoolAllocationInfo wraps necessary information about an IPAM pool allocation.
+type IPAMPoolAllocationInfo struct {
+	// The ID of IPAM pool.
+	IPAMPoolID string
+	// The ID of IPAM pool allocation.
+	AllocationID string
+	// The allocated CIDR.
+	CIDR string
+	// Tags encoded in the description of IPAM pool allocation, since allocations cannot be tagged.
+	Tags map[string]string
+}
+
+// IPAMPoolAllocationManager is responsible for create/update/delete IPAMPoolAllocation resources.
+type IPAMPoolAllocationManager interface {
+	Create(ctx context.Context, resAllocation *ec2model.IPAMPoolAllocation) (ec2model.IPAMPoolAllocationStatus, error)
+
+	Update(ctx context.Context, resAllocation *ec2model.IPAMPoolAllocation, sdkAllocation IPAMPoolAllocationInfo) (ec2model.IPAMPoolAllocationStatus, error)
+
+	Delete(ctx context.Context, sdkAllocation IPAMPoolAllocationInfo) error
+
+	// ListAllocations returns the custom allocations of all IPv4 IPAM pools, with tags decoded from their description.
+	ListAllocations(ctx context.Context) ([]IPAMPoolAllocationInfo, error)
+}
+
+// NewDefaultIPAMPoolAllocationManager constructs new defaultIPAMPoolAllocationManager.
+func NewDefaultIPAMPoolAllocationManager(ec2Client services.EC2, trackingProvider tracking.Provider, logger logr.Logger) *defaultIPAMPoolAllocationManager {
+	return &defaultIPAMPoolAllocationManager{
+		ec2Client:           ec2Client,
+		trackingProvider:    trackingProvider,
+		logger:              logger,
+		allocationsCache:    cache.NewExpiring(),
+		allocationsCacheTTL: defaultAllocationsCacheTTL,
+	}
+}
+
+var _ IPAMPoolAllocationManager = &defaultIPAMPoolAllocationManager{}
+
+// default implementation for IPAMPoolAllocationManager.
+type defaultIPAMPoolAllocationManager struct {
+	ec2Client        services.EC2
+	trackingProvider tracking.Provider
+	logger           logr.Logger
+
+	// cache that stores the allocations of all IPv4 IPAM pools as []IPAMPoolAllocationInfo,
+	// since allocations cannot be tagged and finding the ones of a stack requires scanning all pools.
+	// It's invalidated whenever allocations are created or released by controller.
+	allocationsCache    *cache.Expiring
+	allocationsCacheTTL time.Duration
+}
+
+func (m *defaultIPAMPoolAllocationManager) Create(ctx context.Context, resAllocation *ec2model.IPAMPoolAllocation) (ec2model.IPAMPoolAllocationStatus, error) {
+	disallowedCIDRs, err := buildSubnetReservedCIDRs(resAllocation.Spec.SubnetCIDR)
+	if err != nil {
+		return ec2model.IPAMPoolAllocationStatus{}, err
+	}
+	allocationTags := m.trackingProvider.ResourceTags(resAllocation.Stack(), resAllocation, nil)
+	req := &ec2sdk.AllocateIpamPoolCidrInput{
+		IpamPoolId:      awssdk.String(resAllocation.Spec.IPAMPoolID),
+		NetmaskLength:   awssdk.Int64(32),
+		AllowedCidrs:    awssdk.StringSlice([]string{resAllocation.Spec.SubnetCIDR}),
+		DisallowedCidrs: awssdk.StringSlice(disallowedCIDRs),
+		Description:     awssdk.String(encodeIPAMPoolAllocationDescription(allocationTags)),
+	}
+	m.logger.Info("allocating from ipamPool",
+		"resourceID", resAllocation.ID(),
+		"ipamPoolID", resAllocation.Spec.IPAMPoolID)
+	resp, err := m.ec2Client.AllocateIpamPoolCidrWithContext(ctx, req)
+	if err != nil {
+		return ec2model.IPAMPoolAllocationStatus{}, errors.Wrap(err, "failed to allocate from ipamPool")
+	}
+	m.allocationsCache.Delete(allocationsCacheKey)
+	allocationID := awssdk.StringValue(resp.IpamPoolAllocation.IpamPoolAllocationId)
+	cidr := awssdk.StringValue(resp.IpamPoolAllocation.Cidr)
+	m.logger.Info("allocated from ipamPool",
+		"resourceID", resAllocation.ID(),
+		"allocationID", allocationID,
+		"cidr", cidr)
+	return ec2model.IPAMPoolAllocationStatus{
+		AllocationID: allocationID,
+		CIDR:         cidr,
+	}, nil
+}
+
+func (m *defaultIPAMPoolAllocationManager) Update(_ context.Context, _ *ec2model.IPAMPoolAllocation, sdkAllocation IPAMPoolAllocationInfo) (ec2model.IPAMPoolAllocationStatus, error) {
+	// private IPv4 addresses of LoadBalancers are immutable, so are the allocations backing them.
+	return ec2model.IPAMPoolAllocationStatus{
+		AllocationID: sdkAllocation.AllocationID,
+		CIDR:         sdkAllocation.CIDR,
+	}, nil
+}
+
+func (m *defaultIPAMPoolAllocationManager) Delete(ctx context.Context, sdkAllocation IPAMPoolAllocationInfo) error {
+	req := &ec2sdk.ReleaseIpamPoolAllocationInput{
+		IpamPoolId:           awssdk.String(sdkAllocation.IPAMPoolID),
+		IpamPoolAllocationId: awssdk.String(sdkAllocation.AllocationID),
+		Cidr:                 awssdk.String(sdkAllocation.CIDR),
+	}
+	m.logger.Info("releasing ipamPool allocation",
+		"allocationID", sdkAllocation.AllocationID)
+	if _, err := m.ec2Client.ReleaseIpamPoolAllocationWithContext(ctx, req); err != nil {
+		return errors.Wrap(err, "failed to release ipamPool allocation")
+	}
+	m.allocationsCache.Delete(allocationsCacheKey)
+	m.logger.Info("released ipamPool allocation",
+		"allocationID", sdkAllocation.AllocationID)
+	return nil
+}
+
+func (m *defaultIPAMPoolAllocationManager) ListAllocations(ctx context.Context) ([]IPAMPoolAllocationInfo, error) {
+	if rawCacheItem, exists := m.allocationsCache.Get(allocationsCacheKey); exists {
+		return rawCacheItem.([]IPAMPoolAllocationInfo), nil
+	}
+	pools, err := m.ec2Client.DescribeIpamPoolsAsList(ctx, &ec2sdk.DescribeIpamPoolsInput{
+		Filters: []*ec2sdk.Filter{
+			{
+				Name:   awssdk.String("address-family"),
+				Values: awssdk.StringSlice([]string{ec2sdk.AddressFamilyIpv4}),
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var allocationInfos []IPAMPoolAllocationInfo
+	for _, pool := range pools {
+		poolID := awssdk.StringValue(pool.IpamPoolId)
+		allocations, err := m.ec2Client.GetIpamPoolAllocationsAsList(ctx, &ec2sdk.GetIpamPoolAllocationsInput{
+			IpamPoolId: pool.IpamPoolId,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, allocation := range allocations {
+			if awssdk.StringValue(allocation.ResourceType) != ec2sdk.IpamPoolAllocationResourceTypeCustom {
+				continue
+			}
+			allocationInfos = append(allocationInfos, IPAMPoolAllocationInfo{
+				IPAMPoolID:   poolID,
+				AllocationID: awssdk.StringValue(allocation.IpamPoolAllocationId),
+				CIDR:         awssdk.StringValue(allocation.Cidr),
+				Tags:         decodeIPAMPoolAllocationDescription(awssdk.StringValue(allocation.Description)),
+			})
+		}
+	}
+	m.allocationsCache.Set(allocationsCacheKey, allocationInfos, m.allocationsCacheTTL)
+	return allocationInfos, nil
+}
+
+// buildSubnetReservedCIDRs builds the CIDRs of addresses reserved by AWS in subnet, which cannot be assigned to LoadBalancers.
+func buildSubnetReservedCIDRs(subnetCIDR string) ([]string, error) {
+	_, ipNet, err := net.ParseCIDR(subnetCIDR)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid subnet CIDR: %v", subnetCIDR)
+	}
+	networkIP := ipNet.IP.To4()
+	if networkIP == nil {
+		return nil, errors.Errorf("subnet CIDR must be IPv4: %v", subnetCIDR)
+	}
+	var reservedCIDRs []string
+	for i := 0; i < subnetReservedLeadingAddresses; i++ {
+		reservedIP := make(net.IP, len(networkIP))
+		copy(reservedIP, networkIP)
+		reservedIP[3] += byte(i)
+		reservedCIDRs = append(reservedCIDRs, fmt.Sprintf("%v/32", reservedIP))
+	}
+	broadcastIP := make(net.IP, len(networkIP))
+	for i := range networkIP {
+		broadcastIP[i] = networkIP[i] | ^ipNet.Mask[i]
+	}
+	reservedCIDRs = append(reservedCIDRs, fmt.Sprintf("%v/32", broadcastIP))
+	return reservedCIDRs, nil
+}
+
+// encodeIPAMPoolAllocationDescription encodes tags into the description of IPAM pool allocation, e.g. "k1=v1,k2=v2".
+func encodeIPAMPoolAllocationDescription(tags map[string]string) string {
+	pairs := make([]string, 0, len(tags))
+	for _, key := range sets.StringKeySet(tags).List() {
+		pairs = append(pairs, fmt.Sprintf("%v=%v", key, tags[key]))
+	}
+	return strings.Join(pairs, ",")
+}
+
+// decodeIPAMPoolAllocationDescription decodes tags from the description of IPAM pool allocation.
+func decodeIPAMPoolAllocationDescription(description string) map[string]string {
+	tags := make(map[string]string)
+	for _, pair := range strings.Split(description, ",") {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		tags[parts[0]] = parts[1]
+	}
+	return tags
+}
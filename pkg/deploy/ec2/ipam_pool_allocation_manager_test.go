@@ -0,0 +1,126 @@
+package ec2
+
+import (
+	"context"
+	"errors"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_buildSubnetReservedCIDRs(t *testing.T) {
+	tests := []struct {
+		name       string
+		subnetCIDR string
+		want       []string
+		wantErr    error
+	}{
+		{
+			name:       "/24 subnet",
+			subnetCIDR: "192.168.1.0/24",
+			want:       []string{"192.168.1.0/32", "192.168.1.1/32", "192.168.1.2/32", "192.168.1.3/32", "192.168.1.255/32"},
+		},
+		{
+			name:       "/19 subnet",
+			subnetCIDR: "192.168.32.0/19",
+			want:       []string{"192.168.32.0/32", "192.168.32.1/32", "192.168.32.2/32", "192.168.32.3/32", "192.168.63.255/32"},
+		},
+		{
+			name:       "IPv6 subnet",
+			subnetCIDR: "2600:1f13:837:8500::/64",
+			wantErr:    errors.New("subnet CIDR must be IPv4: 2600:1f13:837:8500::/64"),
+		},
+		{
+			name:       "invalid subnet CIDR",
+			subnetCIDR: "192.168.1.0",
+			wantErr:    errors.New("invalid subnet CIDR: 192.168.1.0: invalid CIDR address: 192.168.1.0"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buildSubnetReservedCIDRs(tt.subnetCIDR)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_decodeIPAMPoolAllocationDescription(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		want        map[string]string
+	}{
+		{
+			name:        "encoded tags",
+			description: encodeIPAMPoolAllocationDescription(map[string]string{"service.k8s.aws/stack": "ns/svc", "elbv2.k8s.aws/cluster": "cluster"}),
+			want:        map[string]string{"service.k8s.aws/stack": "ns/svc", "elbv2.k8s.aws/cluster": "cluster"},
+		},
+		{
+			name:        "description not created by controller",
+			description: "reserved for on-premises network",
+			want:        map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := decodeIPAMPoolAllocationDescription(tt.description)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_defaultIPAMPoolAllocationManager_ListAllocations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ec2Client := mock_services.NewMockEC2(ctrl)
+	// allocations are scanned once until controller releases an allocation.
+	ec2Client.EXPECT().DescribeIpamPoolsAsList(gomock.Any(), gomock.Any()).Return([]*ec2sdk.IpamPool{
+		{IpamPoolId: awssdk.String("ipam-pool-1")},
+	}, nil).Times(2)
+	ec2Client.EXPECT().GetIpamPoolAllocationsAsList(gomock.Any(), &ec2sdk.GetIpamPoolAllocationsInput{
+		IpamPoolId: awssdk.String("ipam-pool-1"),
+	}).Return([]*ec2sdk.IpamPoolAllocation{
+		{
+			IpamPoolAllocationId: awssdk.String("ipam-pool-alloc-1"),
+			Cidr:                 awssdk.String("192.168.0.10/32"),
+			ResourceType:         awssdk.String("custom"),
+			Description:          awssdk.String("service.k8s.aws/stack=awesome-ns/svc-1"),
+		},
+		{
+			IpamPoolAllocationId: awssdk.String("ipam-pool-alloc-2"),
+			Cidr:                 awssdk.String("192.168.0.0/24"),
+			ResourceType:         awssdk.String("vpc"),
+		},
+	}, nil).Times(2)
+	ec2Client.EXPECT().ReleaseIpamPoolAllocationWithContext(gomock.Any(), gomock.Any()).Return(&ec2sdk.ReleaseIpamPoolAllocationOutput{}, nil)
+
+	m := NewDefaultIPAMPoolAllocationManager(ec2Client, nil, &log.NullLogger{})
+	ctx := context.Background()
+	want := []IPAMPoolAllocationInfo{
+		{
+			IPAMPoolID:   "ipam-pool-1",
+			AllocationID: "ipam-pool-alloc-1",
+			CIDR:         "192.168.0.10/32",
+			Tags:         map[string]string{"service.k8s.aws/stack": "awesome-ns/svc-1"},
+		},
+	}
+	for i := 0; i < 2; i++ {
+		got, err := m.ListAllocations(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+	assert.NoError(t, m.Delete(ctx, want[0]))
+	got, err := m.ListAllocations(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,153 @@
+package ec2
+
+import (
+	"context"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+)
+
+// NewIPAMPoolAllocationSynthesizer constructs new ipamPoolAllocationSynthesizer.
+func NewIPAMPoolAllocationSynthesizer(trackingProvider tracking.Provider, allocationManager IPAMPoolAllocationManager,
+	logger logr.Logger, stack core.Stack) *ipamPoolAllocationSynthesizer {
+	return &ipamPoolAllocationSynthesizer{
+		trackingProvider:        trackingProvider,
+		allocationManager:       allocationManager,
+		logger:                  logger,
+		stack:                   stack,
+		unmatchedSDKAllocations: nil,
+	}
+}
+
+type ipamPoolAllocationSynthesizer struct {
+	trackingProvider  tracking.Provider
+	allocationManager IPAMPoolAllocationManager
+	logger            logr.Logger
+
+	stack                   core.Stack
+	unmatchedSDKAllocations []IPAMPoolAllocationInfo
+}
+
+func (s *ipamPoolAllocationSynthesizer) Synthesize(ctx context.Context) error {
+	var resAllocations []*ec2model.IPAMPoolAllocation
+	s.stack.ListResources(&resAllocations)
+	sdkAllocations, err := s.findSDKIPAMPoolAllocations(ctx)
+	if err != nil {
+		return err
+	}
+	matchedResAndSDKAllocations, unmatchedResAllocations, unmatchedSDKAllocations, err := matchResAndSDKIPAMPoolAllocations(resAllocations, sdkAllocations, s.trackingProvider.ResourceIDTagKey())
+	if err != nil {
+		return err
+	}
+
+	// For IPAMPoolAllocation, we release unmatched ones during post synthesize, after LoadBalancers using them are deleted.
+	s.unmatchedSDKAllocations = unmatchedSDKAllocations
+
+	for _, resAllocation := range unmatchedResAllocations {
+		allocationStatus, err := s.allocationManager.Create(ctx, resAllocation)
+		if err != nil {
+			return err
+		}
+		resAllocation.SetStatus(allocationStatus)
+	}
+	for _, resAndSDKAllocation := range matchedResAndSDKAllocations {
+		allocationStatus, err := s.allocationManager.Update(ctx, resAndSDKAllocation.resAllocation, resAndSDKAllocation.sdkAllocation)
+		if err != nil {
+			return err
+		}
+		resAndSDKAllocation.resAllocation.SetStatus(allocationStatus)
+	}
+	return nil
+}
+
+func (s *ipamPoolAllocationSynthesizer) PostSynthesize(ctx context.Context) error {
+	for _, sdkAllocation := range s.unmatchedSDKAllocations {
+		if err := s.allocationManager.Delete(ctx, sdkAllocation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findSDKIPAMPoolAllocations will find all IPAM pool allocations created for stack.
+func (s *ipamPoolAllocationSynthesizer) findSDKIPAMPoolAllocations(ctx context.Context) ([]IPAMPoolAllocationInfo, error) {
+	stackTags := s.trackingProvider.StackTags(s.stack)
+	allocations, err := s.allocationManager.ListAllocations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var sdkAllocations []IPAMPoolAllocationInfo
+	for _, allocation := range allocations {
+		if matchesStackTags(allocation.Tags, stackTags) {
+			sdkAllocations = append(sdkAllocations, allocation)
+		}
+	}
+	return sdkAllocations, nil
+}
+
+// matchesStackTags checks whether tags contains all stackTags.
+func matchesStackTags(tags map[string]string, stackTags map[string]string) bool {
+	for key, value := range stackTags {
+		if tags[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+type resAndSDKIPAMPoolAllocationPair struct {
+	resAllocation *ec2model.IPAMPoolAllocation
+	sdkAllocation IPAMPoolAllocationInfo
+}
+
+func matchResAndSDKIPAMPoolAllocations(resAllocations []*ec2model.IPAMPoolAllocation, sdkAllocations []IPAMPoolAllocationInfo,
+	resourceIDTagKey string) ([]resAndSDKIPAMPoolAllocationPair, []*ec2model.IPAMPoolAllocation, []IPAMPoolAllocationInfo, error) {
+	var matchedResAndSDKAllocations []resAndSDKIPAMPoolAllocationPair
+	var unmatchedResAllocations []*ec2model.IPAMPoolAllocation
+	var unmatchedSDKAllocations []IPAMPoolAllocationInfo
+
+	resAllocationsByID := make(map[string]*ec2model.IPAMPoolAllocation, len(resAllocations))
+	for _, resAllocation := range resAllocations {
+		resAllocationsByID[resAllocation.ID()] = resAllocation
+	}
+	sdkAllocationsByID := make(map[string][]IPAMPoolAllocationInfo, len(sdkAllocations))
+	for _, sdkAllocation := range sdkAllocations {
+		resourceID, ok := sdkAllocation.Tags[resourceIDTagKey]
+		if !ok {
+			return nil, nil, nil, errors.Errorf("unexpected ipamPool allocation with no resourceID: %v", sdkAllocation.AllocationID)
+		}
+		sdkAllocationsByID[resourceID] = append(sdkAllocationsByID[resourceID], sdkAllocation)
+	}
+
+	resAllocationIDs := sets.StringKeySet(resAllocationsByID)
+	sdkAllocationIDs := sets.StringKeySet(sdkAllocationsByID)
+	for _, resID := range resAllocationIDs.Intersection(sdkAllocationIDs).List() {
+		resAllocation := resAllocationsByID[resID]
+		var matched bool
+		for _, sdkAllocation := range sdkAllocationsByID[resID] {
+			// allocations from another pool are replaced, since they cannot be moved across pools.
+			if !matched && sdkAllocation.IPAMPoolID == resAllocation.Spec.IPAMPoolID {
+				matchedResAndSDKAllocations = append(matchedResAndSDKAllocations, resAndSDKIPAMPoolAllocationPair{
+					resAllocation: resAllocation,
+					sdkAllocation: sdkAllocation,
+				})
+				matched = true
+				continue
+			}
+			unmatchedSDKAllocations = append(unmatchedSDKAllocations, sdkAllocation)
+		}
+		if !matched {
+			unmatchedResAllocations = append(unmatchedResAllocations, resAllocation)
+		}
+	}
+	for _, resID := range resAllocationIDs.Difference(sdkAllocationIDs).List() {
+		unmatchedResAllocations = append(unmatchedResAllocations, resAllocationsByID[resID])
+	}
+	for _, resID := range sdkAllocationIDs.Difference(resAllocationIDs).List() {
+		unmatchedSDKAllocations = append(unmatchedSDKAllocations, sdkAllocationsByID[resID]...)
+	}
+	return matchedResAndSDKAllocations, unmatchedResAllocations, unmatchedSDKAllocations, nil
+}
@@ -0,0 +1,85 @@
+package ec2
+
+import (
+	"bytes"
+	"github.com/pkg/errors"
+	"regexp"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	"text/template"
+)
+
+// the maximum length of SecurityGroup rule descriptions.
+const maxRuleDescriptionLength = 255
+
+// characters that are not allowed in SecurityGroup rule descriptions.
+var invalidRuleDescriptionCharsPattern = regexp.MustCompile(`[^a-zA-Z0-9. _\-:/()#,@\[\]+=&;{}!$*]`)
+
+// RuleDescriptionTemplateData is the data available to SecurityGroup rule description templates.
+type RuleDescriptionTemplateData struct {
+	// ClusterName is the name of kubernetes cluster.
+	ClusterName string
+	// Namespace is the namespace of stack, it's empty for explicit IngressGroups.
+	Namespace string
+	// Name is the name of stack, i.e. the name of Ingress or Service, or the name of explicit IngressGroup.
+	Name string
+}
+
+// RuleDescriptionBuilder builds descriptions of SecurityGroup rules, so that they can be traced back to their stack.
+type RuleDescriptionBuilder interface {
+	// Build the description of SecurityGroup rules for stack, it's empty if no template is configured.
+	Build(stack core.Stack) (string, error)
+}
+
+// NewDefaultRuleDescriptionBuilder constructs new defaultRuleDescriptionBuilder.
+// rawTemplate is a go template rendered with RuleDescriptionTemplateData, descriptions are left empty if rawTemplate is empty.
+func NewDefaultRuleDescriptionBuilder(rawTemplate string, clusterName string) *defaultRuleDescriptionBuilder {
+	builder := &defaultRuleDescriptionBuilder{
+		clusterName: clusterName,
+	}
+	if rawTemplate != "" {
+		builder.tmpl, builder.tmplErr = parseRuleDescriptionTemplate(rawTemplate)
+	}
+	return builder
+}
+
+var _ RuleDescriptionBuilder = &defaultRuleDescriptionBuilder{}
+
+// default implementation for RuleDescriptionBuilder.
+type defaultRuleDescriptionBuilder struct {
+	clusterName string
+	tmpl        *template.Template
+	tmplErr     error
+}
+
+func (b *defaultRuleDescriptionBuilder) Build(stack core.Stack) (string, error) {
+	if b.tmplErr != nil {
+		return "", b.tmplErr
+	}
+	if b.tmpl == nil {
+		return "", nil
+	}
+	stackID := stack.StackID()
+	data := RuleDescriptionTemplateData{
+		ClusterName: b.clusterName,
+		Namespace:   stackID.Namespace,
+		Name:        stackID.Name,
+	}
+	var buf bytes.Buffer
+	if err := b.tmpl.Execute(&buf, data); err != nil {
+		return "", errors.Wrap(err, "failed to render SecurityGroup rule description")
+	}
+	description := invalidRuleDescriptionCharsPattern.ReplaceAllString(buf.String(), "")
+	if len(description) > maxRuleDescriptionLength {
+		description = description[:maxRuleDescriptionLength]
+	}
+	return description, nil
+}
+
+// parseRuleDescriptionTemplate parses the template of SecurityGroup rule descriptions.
+func parseRuleDescriptionTemplate(rawTemplate string) (*template.Template, error) {
+	tmpl, err := template.New("rule-description").Option("missingkey=error").Parse(rawTemplate)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse SecurityGroup rule description template")
+	}
+	return tmpl, nil
+}
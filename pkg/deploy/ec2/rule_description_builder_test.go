@@ -0,0 +1,75 @@
+package ec2
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	"strings"
+	"testing"
+)
+
+func Test_defaultRuleDescriptionBuilder_Build(t *testing.T) {
+	tests := []struct {
+		name        string
+		rawTemplate string
+		stackID     core.StackID
+		want        string
+		wantErr     error
+	}{
+		{
+			name:        "template not configured",
+			rawTemplate: "",
+			stackID:     core.StackID{Namespace: "awesome-ns", Name: "ing-1"},
+			want:        "",
+		},
+		{
+			name:        "template for implicit IngressGroup",
+			rawTemplate: "elbv2.k8s.aws/cluster={{.ClusterName}},source={{.Namespace}}/{{.Name}}",
+			stackID:     core.StackID{Namespace: "awesome-ns", Name: "ing-1"},
+			want:        "elbv2.k8s.aws/cluster=my-cluster,source=awesome-ns/ing-1",
+		},
+		{
+			name:        "template for explicit IngressGroup",
+			rawTemplate: "[k8s] {{.ClusterName}} {{if .Namespace}}{{.Namespace}}/{{end}}{{.Name}}",
+			stackID:     core.StackID{Name: "awesome-group"},
+			want:        "[k8s] my-cluster awesome-group",
+		},
+		{
+			name:        "invalid characters are removed",
+			rawTemplate: "{{.Name}} <owned by \"{{.ClusterName}}\">",
+			stackID:     core.StackID{Namespace: "awesome-ns", Name: "ing-1"},
+			want:        "ing-1 owned by my-cluster",
+		},
+		{
+			name:        "description is truncated",
+			rawTemplate: strings.Repeat("a", 300),
+			stackID:     core.StackID{Namespace: "awesome-ns", Name: "ing-1"},
+			want:        strings.Repeat("a", 255),
+		},
+		{
+			name:        "invalid template",
+			rawTemplate: "{{.Name",
+			stackID:     core.StackID{Namespace: "awesome-ns", Name: "ing-1"},
+			wantErr:     errors.New("failed to parse SecurityGroup rule description template: template: rule-description:1: unclosed action"),
+		},
+		{
+			name:        "unknown template field",
+			rawTemplate: "{{.IngressName}}",
+			stackID:     core.StackID{Namespace: "awesome-ns", Name: "ing-1"},
+			wantErr:     errors.New("failed to render SecurityGroup rule description: template: rule-description:1:2: executing \"rule-description\" at <.IngressName>: can't evaluate field IngressName in type ec2.RuleDescriptionTemplateData"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := NewDefaultRuleDescriptionBuilder(tt.rawTemplate, "my-cluster")
+			stack := core.NewDefaultStack(tt.stackID)
+			got, err := builder.Build(stack)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -8,16 +8,20 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
 	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
+	"strings"
 	"time"
 )
 
 const (
-	defaultWaitSGDeletionPollInterval = 2 * time.Second
-	defaultWaitSGDeletionTimeout      = 2 * time.Minute
+	defaultWaitSGDeletionPollInterval  = 2 * time.Second
+	defaultWaitSGDeletionTimeout       = 2 * time.Minute
+	defaultWaitSGExistencePollInterval = 2 * time.Second
+	defaultWaitSGExistenceTimeout      = 20 * time.Second
 )
 
 // SecurityGroupManager is responsible for create/update/delete SecurityGroup resources.
@@ -31,17 +35,23 @@ type SecurityGroupManager interface {
 
 // NewDefaultSecurityGroupManager constructs new defaultSecurityGroupManager.
 func NewDefaultSecurityGroupManager(ec2Client services.EC2, trackingProvider tracking.Provider, taggingManager TaggingManager,
-	networkingSGReconciler networking.SecurityGroupReconciler, vpcID string, logger logr.Logger) *defaultSecurityGroupManager {
+	networkingSGManager networking.SecurityGroupManager, networkingSGReconciler networking.SecurityGroupReconciler,
+	ruleDescriptionBuilder RuleDescriptionBuilder, dynamicConfigProvider config.DynamicConfigProvider, vpcID string, logger logr.Logger) *defaultSecurityGroupManager {
 	return &defaultSecurityGroupManager{
 		ec2Client:              ec2Client,
 		trackingProvider:       trackingProvider,
 		taggingManager:         taggingManager,
+		networkingSGManager:    networkingSGManager,
 		networkingSGReconciler: networkingSGReconciler,
+		ruleDescriptionBuilder: ruleDescriptionBuilder,
+		dynamicConfigProvider:  dynamicConfigProvider,
 		vpcID:                  vpcID,
 		logger:                 logger,
 
-		waitSGDeletionPollInterval: defaultWaitSGDeletionPollInterval,
-		waitSGDeletionTimeout:      defaultWaitSGDeletionTimeout,
+		waitSGDeletionPollInterval:  defaultWaitSGDeletionPollInterval,
+		waitSGDeletionTimeout:       defaultWaitSGDeletionTimeout,
+		waitSGExistencePollInterval: defaultWaitSGExistencePollInterval,
+		waitSGExistenceTimeout:      defaultWaitSGExistenceTimeout,
 	}
 }
 
@@ -50,18 +60,27 @@ type defaultSecurityGroupManager struct {
 	ec2Client              services.EC2
 	trackingProvider       tracking.Provider
 	taggingManager         TaggingManager
+	networkingSGManager    networking.SecurityGroupManager
 	networkingSGReconciler networking.SecurityGroupReconciler
+	ruleDescriptionBuilder RuleDescriptionBuilder
+	dynamicConfigProvider  config.DynamicConfigProvider
 	vpcID                  string
 	logger                 logr.Logger
 
-	waitSGDeletionPollInterval time.Duration
-	waitSGDeletionTimeout      time.Duration
+	waitSGDeletionPollInterval  time.Duration
+	waitSGDeletionTimeout       time.Duration
+	waitSGExistencePollInterval time.Duration
+	waitSGExistenceTimeout      time.Duration
 }
 
 func (m *defaultSecurityGroupManager) Create(ctx context.Context, resSG *ec2model.SecurityGroup) (ec2model.SecurityGroupStatus, error) {
 	sgTags := m.trackingProvider.ResourceTags(resSG.Stack(), resSG, resSG.Spec.Tags)
 	sdkTags := convertTagsToSDKTags(sgTags)
-	permissionInfos, err := buildIPPermissionInfos(resSG.Spec.Ingress)
+	ruleDescription, err := m.ruleDescriptionBuilder.Build(resSG.Stack())
+	if err != nil {
+		return ec2model.SecurityGroupStatus{}, err
+	}
+	permissionInfos, err := buildIPPermissionInfos(resSG.Spec.Ingress, ruleDescription)
 	if err != nil {
 		return ec2model.SecurityGroupStatus{}, err
 	}
@@ -87,8 +106,13 @@ func (m *defaultSecurityGroupManager) Create(ctx context.Context, resSG *ec2mode
 	m.logger.Info("created securityGroup",
 		"resourceID", resSG.ID(),
 		"securityGroupID", sgID)
+	m.networkingSGManager.InvalidateSGInfos(sgID)
 
-	if err := m.networkingSGReconciler.ReconcileIngress(ctx, sgID, permissionInfos); err != nil {
+	// newly created securityGroup might not be visible to other EC2 APIs yet due to eventual consistency.
+	if err := runtime.RetryImmediateOnError(m.waitSGExistencePollInterval, m.waitSGExistenceTimeout, isSecurityGroupNotFoundError, func() error {
+		return m.networkingSGReconciler.ReconcileIngress(ctx, sgID, permissionInfos,
+			networking.WithRuleTags(m.trackingProvider.StackTags(resSG.Stack())))
+	}); err != nil {
 		return ec2model.SecurityGroupStatus{}, err
 	}
 
@@ -98,14 +122,19 @@ func (m *defaultSecurityGroupManager) Create(ctx context.Context, resSG *ec2mode
 }
 
 func (m *defaultSecurityGroupManager) Update(ctx context.Context, resSG *ec2model.SecurityGroup, sdkSG networking.SecurityGroupInfo) (ec2model.SecurityGroupStatus, error) {
-	permissionInfos, err := buildIPPermissionInfos(resSG.Spec.Ingress)
+	ruleDescription, err := m.ruleDescriptionBuilder.Build(resSG.Stack())
+	if err != nil {
+		return ec2model.SecurityGroupStatus{}, err
+	}
+	permissionInfos, err := buildIPPermissionInfos(resSG.Spec.Ingress, ruleDescription)
 	if err != nil {
 		return ec2model.SecurityGroupStatus{}, err
 	}
 	if err := m.updateSDKSecurityGroupGroupWithTags(ctx, resSG, sdkSG); err != nil {
 		return ec2model.SecurityGroupStatus{}, err
 	}
-	if err := m.networkingSGReconciler.ReconcileIngress(ctx, sdkSG.SecurityGroupID, permissionInfos); err != nil {
+	if err := m.networkingSGReconciler.ReconcileIngress(ctx, sdkSG.SecurityGroupID, permissionInfos,
+		networking.WithRuleTags(m.trackingProvider.StackTags(resSG.Stack()))); err != nil {
 		return ec2model.SecurityGroupStatus{}, err
 	}
 	return ec2model.SecurityGroupStatus{
@@ -120,14 +149,24 @@ func (m *defaultSecurityGroupManager) Delete(ctx context.Context, sdkSG networki
 
 	m.logger.Info("deleting securityGroup",
 		"securityGroupID", sdkSG.SecurityGroupID)
-	if err := runtime.RetryImmediateOnError(m.waitSGDeletionPollInterval, m.waitSGDeletionTimeout, isSecurityGroupDependencyViolationError, func() error {
-		_, err := m.ec2Client.DeleteSecurityGroupWithContext(ctx, req)
-		return err
+	waitSGDeletionTimeout := m.waitSGDeletionTimeout
+	if timeout := m.dynamicConfigProvider.Get().SGDeletionTimeout; timeout != 0 {
+		waitSGDeletionTimeout = timeout
+	}
+	var deleteErr error
+	if err := runtime.RetryImmediateOnError(m.waitSGDeletionPollInterval, waitSGDeletionTimeout, isSecurityGroupDependencyViolationError, func() error {
+		_, deleteErr = m.ec2Client.DeleteSecurityGroupWithContext(ctx, req)
+		return deleteErr
 	}); err != nil {
+		// the last DependencyViolation error is lost once the wait times out.
+		if isSecurityGroupDependencyViolationError(deleteErr) {
+			err = runtime.NewClassifiedError(runtime.ErrorClassDependencyViolation, err)
+		}
 		return errors.Wrap(err, "failed to delete securityGroup")
 	}
 	m.logger.Info("deleted securityGroup",
 		"securityGroupID", sdkSG.SecurityGroupID)
+	m.networkingSGManager.InvalidateSGInfos(sdkSG.SecurityGroupID)
 
 	return nil
 }
@@ -136,13 +175,15 @@ func (m *defaultSecurityGroupManager) updateSDKSecurityGroupGroupWithTags(ctx co
 	desiredSGTags := m.trackingProvider.ResourceTags(resSG.Stack(), resSG, resSG.Spec.Tags)
 	return m.taggingManager.ReconcileTags(ctx, sdkSG.SecurityGroupID, desiredSGTags,
 		WithCurrentTags(sdkSG.Tags),
-		WithIgnoredTagKeys(m.trackingProvider.LegacyTagKeys()))
+		WithIgnoredTagKeys(m.trackingProvider.LegacyTagKeys()),
+		WithDeferredTagging())
 }
 
-func buildIPPermissionInfos(permissions []ec2model.IPPermission) ([]networking.IPPermissionInfo, error) {
+// buildIPPermissionInfos builds IPPermissionInfos from permissions, defaultDescription is used for permissions without description.
+func buildIPPermissionInfos(permissions []ec2model.IPPermission, defaultDescription string) ([]networking.IPPermissionInfo, error) {
 	permissionInfos := make([]networking.IPPermissionInfo, 0, len(permissions))
 	for _, permission := range permissions {
-		permissionInfo, err := buildIPPermissionInfo(permission)
+		permissionInfo, err := buildIPPermissionInfo(permission, defaultDescription)
 		if err != nil {
 			return nil, err
 		}
@@ -151,23 +192,69 @@ func buildIPPermissionInfos(permissions []ec2model.IPPermission) ([]networking.I
 	return permissionInfos, nil
 }
 
-func buildIPPermissionInfo(permission ec2model.IPPermission) (networking.IPPermissionInfo, error) {
+func buildIPPermissionInfo(permission ec2model.IPPermission, defaultDescription string) (networking.IPPermissionInfo, error) {
 	protocol := permission.IPProtocol
+	fromPort, toPort, err := buildIPPermissionPortRange(permission)
+	if err != nil {
+		return networking.IPPermissionInfo{}, err
+	}
 	if len(permission.IPRanges) == 1 {
-		labels := networking.NewIPPermissionLabelsForRawDescription(permission.IPRanges[0].Description)
-		return networking.NewCIDRIPPermission(protocol, permission.FromPort, permission.ToPort, permission.IPRanges[0].CIDRIP, labels), nil
+		labels := networking.NewIPPermissionLabelsForRawDescription(descriptionOrDefault(permission.IPRanges[0].Description, defaultDescription))
+		return networking.NewCIDRIPPermission(protocol, fromPort, toPort, permission.IPRanges[0].CIDRIP, labels), nil
 	}
 	if len(permission.IPv6Range) == 1 {
-		labels := networking.NewIPPermissionLabelsForRawDescription(permission.IPv6Range[0].Description)
-		return networking.NewCIDRv6IPPermission(protocol, permission.FromPort, permission.ToPort, permission.IPv6Range[0].CIDRIPv6, labels), nil
+		labels := networking.NewIPPermissionLabelsForRawDescription(descriptionOrDefault(permission.IPv6Range[0].Description, defaultDescription))
+		return networking.NewCIDRv6IPPermission(protocol, fromPort, toPort, permission.IPv6Range[0].CIDRIPv6, labels), nil
 	}
 	if len(permission.UserIDGroupPairs) == 1 {
-		labels := networking.NewIPPermissionLabelsForRawDescription(permission.UserIDGroupPairs[0].Description)
-		return networking.NewGroupIDIPPermission(protocol, permission.FromPort, permission.ToPort, permission.UserIDGroupPairs[0].GroupID, labels), nil
+		labels := networking.NewIPPermissionLabelsForRawDescription(descriptionOrDefault(permission.UserIDGroupPairs[0].Description, defaultDescription))
+		return networking.NewGroupIDIPPermission(protocol, fromPort, toPort, permission.UserIDGroupPairs[0].GroupID, labels), nil
+	}
+	if len(permission.PrefixLists) == 1 {
+		labels := networking.NewIPPermissionLabelsForRawDescription(descriptionOrDefault(permission.PrefixLists[0].Description, defaultDescription))
+		return networking.NewPrefixListIDPermission(protocol, fromPort, toPort, permission.PrefixLists[0].PrefixListID, labels), nil
 	}
 	return networking.IPPermissionInfo{}, errors.New("invalid ipPermission")
 }
 
+// buildIPPermissionPortRange computes the FromPort and ToPort of permission in the way EC2 expects.
+// EC2 encodes the ICMP type and code as FromPort and ToPort, and doesn't accept ports for all-protocol permissions.
+func buildIPPermissionPortRange(permission ec2model.IPPermission) (*int64, *int64, error) {
+	switch strings.ToLower(permission.IPProtocol) {
+	case ec2model.IPProtocolAll:
+		if permission.FromPort != nil || permission.ToPort != nil || permission.ICMPTypeCode != nil {
+			return nil, nil, errors.New("invalid ipPermission, ports cannot be specified for all protocols")
+		}
+		return nil, nil, nil
+	case ec2model.IPProtocolICMP, ec2model.IPProtocolICMPv6:
+		if permission.ICMPTypeCode == nil {
+			if permission.FromPort != nil || permission.ToPort != nil {
+				return nil, nil, errors.Errorf("invalid ipPermission, use icmpTypeCode instead of ports for %v", permission.IPProtocol)
+			}
+			return awssdk.Int64(-1), awssdk.Int64(-1), nil
+		}
+		if permission.FromPort != nil || permission.ToPort != nil {
+			return nil, nil, errors.New("invalid ipPermission, ports cannot be specified together with icmpTypeCode")
+		}
+		if permission.ICMPTypeCode.Type == -1 && permission.ICMPTypeCode.Code != -1 {
+			return nil, nil, errors.New("invalid ipPermission, ICMP code requires a specific ICMP type")
+		}
+		return awssdk.Int64(permission.ICMPTypeCode.Type), awssdk.Int64(permission.ICMPTypeCode.Code), nil
+	default:
+		if permission.ICMPTypeCode != nil {
+			return nil, nil, errors.Errorf("invalid ipPermission, icmpTypeCode cannot be specified for %v", permission.IPProtocol)
+		}
+		return permission.FromPort, permission.ToPort, nil
+	}
+}
+
+func descriptionOrDefault(description string, defaultDescription string) string {
+	if description == "" {
+		return defaultDescription
+	}
+	return description
+}
+
 func isSecurityGroupDependencyViolationError(err error) bool {
 	var awsErr awserr.Error
 	if errors.As(err, &awsErr) {
@@ -175,3 +262,11 @@ func isSecurityGroupDependencyViolationError(err error) bool {
 	}
 	return false
 }
+
+func isSecurityGroupNotFoundError(err error) bool {
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		return awsErr.Code() == "InvalidGroup.NotFound"
+	}
+	return false
+}
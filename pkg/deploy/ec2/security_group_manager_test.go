@@ -1,10 +1,29 @@
 package ec2
 
 import (
+	"context"
+	"fmt"
+	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/faultinjection"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sync"
 	"testing"
+	"time"
 )
 
 func Test_isSecurityGroupDependencyViolationError(t *testing.T) {
@@ -45,3 +64,321 @@ func Test_isSecurityGroupDependencyViolationError(t *testing.T) {
 		})
 	}
 }
+
+func Test_isSecurityGroupNotFoundError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "wraps InvalidGroup.NotFound error",
+			err:  errors.Wrap(awserr.New("InvalidGroup.NotFound", "some message", nil), "wrapped message"),
+			want: true,
+		},
+		{
+			name: "isn't InvalidGroup.NotFound error",
+			err:  awserr.New("DependencyViolation", "some message", nil),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := isSecurityGroupNotFoundError(tt.err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_defaultSecurityGroupManager_Create_withFaultInjection(t *testing.T) {
+	tests := []struct {
+		name                   string
+		faults                 string
+		sgVisibleDelay         time.Duration
+		waitSGExistenceTimeout time.Duration
+		wantCalls              []string
+		wantErr                string
+	}{
+		{
+			name:                   "security group becomes visible within timeout",
+			sgVisibleDelay:         100 * time.Millisecond,
+			waitSGExistenceTimeout: 5 * time.Second,
+			wantCalls:              []string{"CreateSecurityGroup", "DescribeSecurityGroups", "DescribeSecurityGroupRules", "AuthorizeSecurityGroupIngress"},
+		},
+		{
+			name:                   "security group doesn't become visible within timeout",
+			sgVisibleDelay:         time.Minute,
+			waitSGExistenceTimeout: 100 * time.Millisecond,
+			wantCalls:              []string{"CreateSecurityGroup"},
+			wantErr:                "timed out waiting for the condition",
+		},
+		{
+			name:                   "throttled calls are retried",
+			faults:                 "EC2:.*=RequestLimitExceeded:2",
+			waitSGExistenceTimeout: 5 * time.Second,
+			wantCalls:              []string{"CreateSecurityGroup", "DescribeSecurityGroups", "DescribeSecurityGroupRules", "AuthorizeSecurityGroupIngress"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ec2Client, server := newFaultInjectedEC2Client(t, tt.faults, tt.sgVisibleDelay)
+			defer server.Close()
+			networkingSGManager := networking.NewDefaultSecurityGroupManager(ec2Client, &log.NullLogger{})
+			m := NewDefaultSecurityGroupManager(ec2Client, tracking.NewDefaultProvider("elbv2.k8s.aws", "cluster-name"), nil,
+				networkingSGManager, networking.NewDefaultSecurityGroupReconciler(networkingSGManager, nil, &log.NullLogger{}),
+				NewDefaultRuleDescriptionBuilder("", "cluster-name"), config.NewStaticDynamicConfigProvider(config.DynamicConfig{}),
+				"vpc-0123", &log.NullLogger{})
+			m.waitSGExistencePollInterval = 10 * time.Millisecond
+			m.waitSGExistenceTimeout = tt.waitSGExistenceTimeout
+
+			stack := core.NewDefaultStack(core.StackID{Namespace: "namespace", Name: "name"})
+			resSG := ec2model.NewSecurityGroup(stack, "ManagedLBSecurityGroup", ec2model.SecurityGroupSpec{
+				GroupName:   "k8s-namespace-name-0123456789",
+				Description: "[k8s] Managed SecurityGroup for LoadBalancer",
+				Ingress: []ec2model.IPPermission{
+					{
+						IPProtocol: ec2model.IPProtocolTCP,
+						FromPort:   awssdk.Int64(80),
+						ToPort:     awssdk.Int64(80),
+						IPRanges: []ec2model.IPRange{
+							{
+								CIDRIP: "0.0.0.0/0",
+							},
+						},
+					},
+				},
+			})
+			got, err := m.Create(context.Background(), resSG)
+			if tt.wantErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, ec2model.SecurityGroupStatus{GroupID: fakeEC2SecurityGroupID}, got)
+			}
+			assert.Equal(t, tt.wantCalls, server.Calls())
+		})
+	}
+}
+
+func Test_defaultSecurityGroupManager_Delete_withFaultInjection(t *testing.T) {
+	tests := []struct {
+		name          string
+		faults        string
+		dynamicConfig config.DynamicConfig
+		wantCalls     []string
+		wantErr       string
+	}{
+		{
+			name:      "dependency violations are retried",
+			faults:    "EC2:DeleteSecurityGroup=DependencyViolation:3",
+			wantCalls: []string{"DeleteSecurityGroup"},
+		},
+		{
+			name:      "throttled calls are retried",
+			faults:    "EC2:DeleteSecurityGroup=Throttling:2",
+			wantCalls: []string{"DeleteSecurityGroup"},
+		},
+		{
+			name:    "dependency violations persist beyond timeout",
+			faults:  "EC2:DeleteSecurityGroup=DependencyViolation:1000",
+			wantErr: "failed to delete securityGroup: timed out waiting for the condition",
+		},
+		{
+			name:   "dependency violations persist beyond timeout from dynamic config",
+			faults: "EC2:DeleteSecurityGroup=DependencyViolation:3",
+			dynamicConfig: config.DynamicConfig{
+				SGDeletionTimeout: time.Millisecond,
+			},
+			wantErr: "failed to delete securityGroup: timed out waiting for the condition",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ec2Client, server := newFaultInjectedEC2Client(t, tt.faults, 0)
+			defer server.Close()
+			networkingSGManager := networking.NewDefaultSecurityGroupManager(ec2Client, &log.NullLogger{})
+			m := NewDefaultSecurityGroupManager(ec2Client, tracking.NewDefaultProvider("elbv2.k8s.aws", "cluster-name"), nil,
+				networkingSGManager, networking.NewDefaultSecurityGroupReconciler(networkingSGManager, nil, &log.NullLogger{}),
+				NewDefaultRuleDescriptionBuilder("", "cluster-name"), config.NewStaticDynamicConfigProvider(tt.dynamicConfig),
+				"vpc-0123", &log.NullLogger{})
+			m.waitSGDeletionPollInterval = 10 * time.Millisecond
+			m.waitSGDeletionTimeout = 200 * time.Millisecond
+
+			err := m.Delete(context.Background(), networking.SecurityGroupInfo{SecurityGroupID: fakeEC2SecurityGroupID})
+			if tt.wantErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantCalls, server.Calls())
+		})
+	}
+}
+
+// the ID of security groups created by fakeEC2Server.
+const fakeEC2SecurityGroupID = "sg-0123456789abcdef0"
+
+// fakeEC2Server serves the EC2 API calls made by SecurityGroupManager, and records the calls that reached it.
+type fakeEC2Server struct {
+	*httptest.Server
+
+	mutex sync.Mutex
+	calls []string
+}
+
+func (s *fakeEC2Server) Calls() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.calls
+}
+
+func (s *fakeEC2Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	action := r.Form.Get("Action")
+	s.mutex.Lock()
+	s.calls = append(s.calls, action)
+	s.mutex.Unlock()
+
+	var body string
+	switch action {
+	case "CreateSecurityGroup":
+		body = fmt.Sprintf("<groupId>%s</groupId>", fakeEC2SecurityGroupID)
+	case "DescribeSecurityGroups":
+		body = fmt.Sprintf("<securityGroupInfo><item><groupId>%s</groupId><ipPermissions/><tagSet/></item></securityGroupInfo>", fakeEC2SecurityGroupID)
+	case "DescribeSecurityGroupRules":
+		body = "<securityGroupRuleSet/>"
+	default:
+		body = "<return>true</return>"
+	}
+	w.Header().Set("Content-Type", "text/xml")
+	_, _ = fmt.Fprintf(w, "<%sResponse><requestId>request-id</requestId>%s</%sResponse>", action, body, action)
+}
+
+// newFaultInjectedEC2Client builds EC2 client that calls fakeEC2Server with faults injected.
+func newFaultInjectedEC2Client(t *testing.T, faults string, sgVisibleDelay time.Duration) (services.EC2, *fakeEC2Server) {
+	server := &fakeEC2Server{}
+	server.Server = httptest.NewServer(http.HandlerFunc(server.serveHTTP))
+
+	awsCFG := awssdk.NewConfig().WithRegion("us-west-2").WithEndpoint(server.URL).
+		WithCredentials(credentials.NewStaticCredentials("AKID", "SECRET", "")).WithMaxRetries(3)
+	awsCFG = request.WithRetryer(awsCFG, client.DefaultRetryer{
+		NumMaxRetries:    3,
+		MinRetryDelay:    time.Millisecond,
+		MaxRetryDelay:    time.Millisecond,
+		MinThrottleDelay: time.Millisecond,
+		MaxThrottleDelay: time.Millisecond,
+	})
+	sess := session.Must(session.NewSession(awsCFG))
+	faultsCFG := faultinjection.ServiceOperationsFaultConfig{}
+	if faults != "" {
+		assert.NoError(t, faultsCFG.Set(faults))
+	}
+	faultinjection.NewInjector(&faultsCFG, sgVisibleDelay).InjectHandlers(&sess.Handlers)
+	return services.NewEC2(sess), server
+}
+
+func Test_buildIPPermissionPortRange(t *testing.T) {
+	tests := []struct {
+		name         string
+		permission   ec2model.IPPermission
+		wantFromPort *int64
+		wantToPort   *int64
+		wantErr      error
+	}{
+		{
+			name: "tcp permission",
+			permission: ec2model.IPPermission{
+				IPProtocol: ec2model.IPProtocolTCP,
+				FromPort:   awssdk.Int64(80),
+				ToPort:     awssdk.Int64(80),
+			},
+			wantFromPort: awssdk.Int64(80),
+			wantToPort:   awssdk.Int64(80),
+		},
+		{
+			name: "all-protocol permission",
+			permission: ec2model.IPPermission{
+				IPProtocol: ec2model.IPProtocolAll,
+			},
+			wantFromPort: nil,
+			wantToPort:   nil,
+		},
+		{
+			name: "all-protocol permission with ports",
+			permission: ec2model.IPPermission{
+				IPProtocol: ec2model.IPProtocolAll,
+				FromPort:   awssdk.Int64(0),
+				ToPort:     awssdk.Int64(65535),
+			},
+			wantErr: errors.New("invalid ipPermission, ports cannot be specified for all protocols"),
+		},
+		{
+			name: "icmp permission for all types",
+			permission: ec2model.IPPermission{
+				IPProtocol: ec2model.IPProtocolICMP,
+			},
+			wantFromPort: awssdk.Int64(-1),
+			wantToPort:   awssdk.Int64(-1),
+		},
+		{
+			name: "icmp permission for echo request",
+			permission: ec2model.IPPermission{
+				IPProtocol: ec2model.IPProtocolICMP,
+				ICMPTypeCode: &ec2model.ICMPTypeCode{
+					Type: 8,
+					Code: -1,
+				},
+			},
+			wantFromPort: awssdk.Int64(8),
+			wantToPort:   awssdk.Int64(-1),
+		},
+		{
+			name: "icmpv6 permission with code of all types",
+			permission: ec2model.IPPermission{
+				IPProtocol: ec2model.IPProtocolICMPv6,
+				ICMPTypeCode: &ec2model.ICMPTypeCode{
+					Type: -1,
+					Code: 0,
+				},
+			},
+			wantErr: errors.New("invalid ipPermission, ICMP code requires a specific ICMP type"),
+		},
+		{
+			name: "icmp permission with ports",
+			permission: ec2model.IPPermission{
+				IPProtocol: ec2model.IPProtocolICMP,
+				FromPort:   awssdk.Int64(8),
+				ToPort:     awssdk.Int64(0),
+			},
+			wantErr: errors.New("invalid ipPermission, use icmpTypeCode instead of ports for icmp"),
+		},
+		{
+			name: "udp permission with icmpTypeCode",
+			permission: ec2model.IPPermission{
+				IPProtocol: ec2model.IPProtocolUDP,
+				ICMPTypeCode: &ec2model.ICMPTypeCode{
+					Type: 8,
+					Code: 0,
+				},
+			},
+			wantErr: errors.New("invalid ipPermission, icmpTypeCode cannot be specified for udp"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotFromPort, gotToPort, err := buildIPPermissionPortRange(tt.permission)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantFromPort, gotFromPort)
+				assert.Equal(t, tt.wantToPort, gotToPort)
+			}
+		})
+	}
+}
@@ -14,6 +14,11 @@ import (
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
 )
 
+const (
+	// EC2 API supports up to 1000 resources per CreateTags or DeleteTags API call.
+	defaultModifyTagsChunkSize = 1000
+)
+
 // options for ReconcileTags API.
 type ReconcileTagsOptions struct {
 	// CurrentTags on resources.
@@ -23,6 +28,10 @@ type ReconcileTagsOptions struct {
 	// IgnoredTagKeys defines the tag keys that should be ignored.
 	// these tags shouldn't be altered or deleted.
 	IgnoredTagKeys []string
+
+	// Deferred defines whether the tag changes can be deferred into the TagBatch within context.
+	// when there is no TagBatch within context, the tag changes are applied immediately.
+	Deferred bool
 }
 
 func (opts *ReconcileTagsOptions) ApplyOptions(options []ReconcileTagsOption) {
@@ -47,13 +56,43 @@ func WithIgnoredTagKeys(ignoredTagKeys []string) ReconcileTagsOption {
 	}
 }
 
+// WithDeferredTagging is a reconcile option that defers tag changes into the TagBatch within context.
+// it should only be used when the resource isn't looked up by the changed tags until the TagBatch is applied.
+func WithDeferredTagging() ReconcileTagsOption {
+	return func(opts *ReconcileTagsOptions) {
+		opts.Deferred = true
+	}
+}
+
+type tagBatchContextKey struct{}
+
+// ContextWithTagBatch returns a context that deferred tag changes of EC2 resources are recorded into batch within.
+func ContextWithTagBatch(ctx context.Context, batch *tracking.TagBatch) context.Context {
+	return context.WithValue(ctx, tagBatchContextKey{}, batch)
+}
+
+// TagBatchFromContext returns the TagBatch of EC2 resources within ctx, or nil if there is none.
+func TagBatchFromContext(ctx context.Context) *tracking.TagBatch {
+	batch, _ := ctx.Value(tagBatchContextKey{}).(*tracking.TagBatch)
+	return batch
+}
+
 // abstraction around tagging operations for EC2.
 type TaggingManager interface {
 	// ReconcileTags will reconcile tags on resources.
 	ReconcileTags(ctx context.Context, resID string, desiredTags map[string]string, opts ...ReconcileTagsOption) error
 
+	// ApplyTagBatch applies the tag changes recorded in batch, resources with identical changes are tagged together.
+	ApplyTagBatch(ctx context.Context, batch *tracking.TagBatch) error
+
 	// ListSecurityGroups returns SecurityGroups that matches any of the tagging requirements.
 	ListSecurityGroups(ctx context.Context, tagFilters ...tracking.TagFilter) ([]networking.SecurityGroupInfo, error)
+
+	// ListElasticIPs returns Elastic IP addresses that matches any of the tagging requirements.
+	ListElasticIPs(ctx context.Context, tagFilters ...tracking.TagFilter) ([]ElasticIPInfo, error)
+
+	// ListVPCEndpointServices returns VPC endpoint services that matches any of the tagging requirements.
+	ListVPCEndpointServices(ctx context.Context, tagFilters ...tracking.TagFilter) ([]VPCEndpointServiceInfo, error)
 }
 
 // NewDefaultTaggingManager constructs new defaultTaggingManager.
@@ -63,6 +102,8 @@ func NewDefaultTaggingManager(ec2Client services.EC2, networkingSGManager networ
 		networkingSGManager: networkingSGManager,
 		vpcID:               vpcID,
 		logger:              logger,
+
+		modifyTagsChunkSize: defaultModifyTagsChunkSize,
 	}
 }
 
@@ -74,6 +115,8 @@ type defaultTaggingManager struct {
 	networkingSGManager networking.SecurityGroupManager
 	vpcID               string
 	logger              logr.Logger
+
+	modifyTagsChunkSize int
 }
 
 func (m *defaultTaggingManager) ReconcileTags(ctx context.Context, resID string, desiredTags map[string]string, opts ...ReconcileTagsOption) error {
@@ -93,6 +136,10 @@ func (m *defaultTaggingManager) ReconcileTags(ctx context.Context, resID string,
 		delete(tagsToUpdate, ignoredTagKey)
 		delete(tagsToRemove, ignoredTagKey)
 	}
+	if batch := TagBatchFromContext(ctx); reconcileOpts.Deferred && batch != nil {
+		batch.Add(resID, tagsToUpdate, sets.StringKeySet(tagsToRemove).List())
+		return nil
+	}
 
 	if len(tagsToUpdate) > 0 {
 		req := &ec2sdk.CreateTagsInput{
@@ -125,6 +172,56 @@ func (m *defaultTaggingManager) ReconcileTags(ctx context.Context, resID string,
 		m.logger.Info("removed resource tags",
 			"resourceID", resID)
 	}
+	if len(tagsToUpdate) > 0 || len(tagsToRemove) > 0 {
+		m.networkingSGManager.InvalidateSGInfos(resID)
+	}
+	return nil
+}
+
+func (m *defaultTaggingManager) ApplyTagBatch(ctx context.Context, batch *tracking.TagBatch) error {
+	tagUpdates, tagRemovals := batch.Drain()
+	for _, tagUpdate := range tagUpdates {
+		for _, resIDsChunk := range algorithm.ChunkStrings(tagUpdate.ResIDs, m.modifyTagsChunkSize) {
+			req := &ec2sdk.CreateTagsInput{
+				Resources: awssdk.StringSlice(resIDsChunk),
+				Tags:      convertTagsToSDKTags(tagUpdate.Tags),
+			}
+
+			m.logger.Info("adding resource tags",
+				"resourceIDs", resIDsChunk,
+				"change", tagUpdate.Tags)
+			_, err := m.ec2Client.CreateTagsWithContext(ctx, req)
+			m.networkingSGManager.InvalidateSGInfos(resIDsChunk...)
+			if err != nil {
+				return err
+			}
+			m.logger.Info("added resource tags",
+				"resourceIDs", resIDsChunk)
+		}
+	}
+	for _, tagRemoval := range tagRemovals {
+		for _, resIDsChunk := range algorithm.ChunkStrings(tagRemoval.ResIDs, m.modifyTagsChunkSize) {
+			sdkTags := make([]*ec2sdk.Tag, 0, len(tagRemoval.TagKeys))
+			for _, tagKey := range tagRemoval.TagKeys {
+				sdkTags = append(sdkTags, &ec2sdk.Tag{Key: awssdk.String(tagKey)})
+			}
+			req := &ec2sdk.DeleteTagsInput{
+				Resources: awssdk.StringSlice(resIDsChunk),
+				Tags:      sdkTags,
+			}
+
+			m.logger.Info("removing resource tags",
+				"resourceIDs", resIDsChunk,
+				"change", tagRemoval.TagKeys)
+			_, err := m.ec2Client.DeleteTagsWithContext(ctx, req)
+			m.networkingSGManager.InvalidateSGInfos(resIDsChunk...)
+			if err != nil {
+				return err
+			}
+			m.logger.Info("removed resource tags",
+				"resourceIDs", resIDsChunk)
+		}
+	}
 	return nil
 }
 
@@ -147,6 +244,62 @@ func (m *defaultTaggingManager) ListSecurityGroups(ctx context.Context, tagFilte
 	return sgInfos, nil
 }
 
+func (m *defaultTaggingManager) ListElasticIPs(ctx context.Context, tagFilters ...tracking.TagFilter) ([]ElasticIPInfo, error) {
+	eipInfoByID := make(map[string]ElasticIPInfo)
+	for _, tagFilter := range tagFilters {
+		req := &ec2sdk.DescribeAddressesInput{
+			Filters: append([]*ec2sdk.Filter{
+				{
+					Name:   awssdk.String("domain"),
+					Values: awssdk.StringSlice([]string{ec2sdk.DomainTypeVpc}),
+				},
+			}, buildSDKTagFilters(tagFilter)...),
+		}
+		resp, err := m.ec2Client.DescribeAddressesWithContext(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		for _, address := range resp.Addresses {
+			eipInfo := NewRawElasticIPInfo(address)
+			eipInfoByID[eipInfo.AllocationID] = eipInfo
+		}
+	}
+
+	eipInfos := make([]ElasticIPInfo, 0, len(eipInfoByID))
+	for _, allocationID := range sets.StringKeySet(eipInfoByID).List() {
+		eipInfos = append(eipInfos, eipInfoByID[allocationID])
+	}
+	return eipInfos, nil
+}
+
+func (m *defaultTaggingManager) ListVPCEndpointServices(ctx context.Context, tagFilters ...tracking.TagFilter) ([]VPCEndpointServiceInfo, error) {
+	esInfoByID := make(map[string]VPCEndpointServiceInfo)
+	for _, tagFilter := range tagFilters {
+		req := &ec2sdk.DescribeVpcEndpointServiceConfigurationsInput{
+			Filters: buildSDKTagFilters(tagFilter),
+		}
+		serviceConfigurations, err := m.ec2Client.DescribeVpcEndpointServiceConfigurationsAsList(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		for _, serviceConfiguration := range serviceConfigurations {
+			// endpoint services being deleted are no longer usable.
+			switch awssdk.StringValue(serviceConfiguration.ServiceState) {
+			case ec2sdk.ServiceStateDeleting, ec2sdk.ServiceStateDeleted:
+				continue
+			}
+			esInfo := NewRawVPCEndpointServiceInfo(serviceConfiguration)
+			esInfoByID[esInfo.ServiceID] = esInfo
+		}
+	}
+
+	esInfos := make([]VPCEndpointServiceInfo, 0, len(esInfoByID))
+	for _, serviceID := range sets.StringKeySet(esInfoByID).List() {
+		esInfos = append(esInfos, esInfoByID[serviceID])
+	}
+	return esInfos, nil
+}
+
 func (m *defaultTaggingManager) listSecurityGroupsWithTagFilter(ctx context.Context, tagFilter tracking.TagFilter) (map[string]networking.SecurityGroupInfo, error) {
 	req := &ec2sdk.DescribeSecurityGroupsInput{
 		Filters: []*ec2sdk.Filter{
@@ -157,6 +310,13 @@ func (m *defaultTaggingManager) listSecurityGroupsWithTagFilter(ctx context.Cont
 		},
 	}
 
+	req.Filters = append(req.Filters, buildSDKTagFilters(tagFilter)...)
+	return m.networkingSGManager.FetchSGInfosByRequest(ctx, req)
+}
+
+// buildSDKTagFilters builds the AWS SDK filters for tagFilter.
+func buildSDKTagFilters(tagFilter tracking.TagFilter) []*ec2sdk.Filter {
+	var filters []*ec2sdk.Filter
 	for _, tagKey := range sets.StringKeySet(tagFilter).List() {
 		tagValues := tagFilter[tagKey]
 		var filter ec2sdk.Filter
@@ -169,10 +329,9 @@ func (m *defaultTaggingManager) listSecurityGroupsWithTagFilter(ctx context.Cont
 			filter.Name = awssdk.String(tagFilterName)
 			filter.Values = awssdk.StringSlice(tagValues)
 		}
-		req.Filters = append(req.Filters, &filter)
+		filters = append(filters, &filter)
 	}
-
-	return m.networkingSGManager.FetchSGInfosByRequest(ctx, req)
+	return filters
 }
 
 // convert tags into AWS SDK tag presentation.
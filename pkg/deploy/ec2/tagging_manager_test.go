@@ -162,9 +162,13 @@ func Test_defaultTaggingManager_ReconcileTags(t *testing.T) {
 				ec2Client.EXPECT().DeleteTagsWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
 			}
 
+			networkingSGManager := mock_networking.NewMockSecurityGroupManager(ctrl)
+			networkingSGManager.EXPECT().InvalidateSGInfos(tt.args.resID).AnyTimes()
+
 			m := &defaultTaggingManager{
-				ec2Client: ec2Client,
-				logger:    &log.NullLogger{},
+				ec2Client:           ec2Client,
+				networkingSGManager: networkingSGManager,
+				logger:              &log.NullLogger{},
 			}
 			err := m.ReconcileTags(context.Background(), tt.args.resID, tt.args.desiredTags, tt.args.opts...)
 			if tt.wantErr != nil {
@@ -0,0 +1,290 @@
+package ec2
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+)
+
+// VPCEndpointServiceInfo wraps necessary information about a VPC endpoint service.
+type VPCEndpointServiceInfo struct {
+	// The ID of endpoint service.
+	ServiceID string
+	// The name of endpoint service.
+	ServiceName string
+	// Whether requests to create an endpoint to the service must be accepted manually.
+	AcceptanceRequired bool
+	// The ARNs of Network Load Balancers fronted by endpoint service.
+	NetworkLoadBalancerARNs []string
+	// Tags on endpoint service.
+	Tags map[string]string
+}
+
+// NewRawVPCEndpointServiceInfo constructs new VPCEndpointServiceInfo from raw service configuration.
+func NewRawVPCEndpointServiceInfo(serviceConfiguration *ec2sdk.ServiceConfiguration) VPCEndpointServiceInfo {
+	tags := make(map[string]string, len(serviceConfiguration.Tags))
+	for _, tag := range serviceConfiguration.Tags {
+		tags[awssdk.StringValue(tag.Key)] = awssdk.StringValue(tag.Value)
+	}
+	return VPCEndpointServiceInfo{
+		ServiceID:               awssdk.StringValue(serviceConfiguration.ServiceId),
+		ServiceName:             awssdk.StringValue(serviceConfiguration.ServiceName),
+		AcceptanceRequired:      awssdk.BoolValue(serviceConfiguration.AcceptanceRequired),
+		NetworkLoadBalancerARNs: awssdk.StringValueSlice(serviceConfiguration.NetworkLoadBalancerArns),
+		Tags:                    tags,
+	}
+}
+
+// VPCEndpointServiceManager is responsible for create/update/delete VPCEndpointService resources.
+type VPCEndpointServiceManager interface {
+	Create(ctx context.Context, resES *ec2model.VPCEndpointService) (ec2model.VPCEndpointServiceStatus, error)
+
+	Update(ctx context.Context, resES *ec2model.VPCEndpointService, sdkES VPCEndpointServiceInfo) (ec2model.VPCEndpointServiceStatus, error)
+
+	// Delete rejects the endpoint connections to the service, then deletes the service.
+	Delete(ctx context.Context, sdkES VPCEndpointServiceInfo) error
+}
+
+// NewDefaultVPCEndpointServiceManager constructs new defaultVPCEndpointServiceManager.
+func NewDefaultVPCEndpointServiceManager(ec2Client services.EC2, trackingProvider tracking.Provider, taggingManager TaggingManager, logger logr.Logger) *defaultVPCEndpointServiceManager {
+	return &defaultVPCEndpointServiceManager{
+		ec2Client:        ec2Client,
+		trackingProvider: trackingProvider,
+		taggingManager:   taggingManager,
+		logger:           logger,
+	}
+}
+
+var _ VPCEndpointServiceManager = &defaultVPCEndpointServiceManager{}
+
+// default implementation for VPCEndpointServiceManager.
+type defaultVPCEndpointServiceManager struct {
+	ec2Client        services.EC2
+	trackingProvider tracking.Provider
+	taggingManager   TaggingManager
+	logger           logr.Logger
+}
+
+func (m *defaultVPCEndpointServiceManager) Create(ctx context.Context, resES *ec2model.VPCEndpointService) (ec2model.VPCEndpointServiceStatus, error) {
+	lbARNs, err := resolveNetworkLoadBalancerARNs(ctx, resES)
+	if err != nil {
+		return ec2model.VPCEndpointServiceStatus{}, err
+	}
+	esTags := m.trackingProvider.ResourceTags(resES.Stack(), resES, resES.Spec.Tags)
+	req := &ec2sdk.CreateVpcEndpointServiceConfigurationInput{
+		NetworkLoadBalancerArns: awssdk.StringSlice(lbARNs),
+		AcceptanceRequired:      awssdk.Bool(resES.Spec.AcceptanceRequired),
+		TagSpecifications: []*ec2sdk.TagSpecification{
+			{
+				ResourceType: awssdk.String(ec2sdk.ResourceTypeVpcEndpointService),
+				Tags:         convertTagsToSDKTags(esTags),
+			},
+		},
+	}
+	m.logger.Info("creating vpcEndpointService",
+		"resourceID", resES.ID())
+	resp, err := m.ec2Client.CreateVpcEndpointServiceConfigurationWithContext(ctx, req)
+	if err != nil {
+		return ec2model.VPCEndpointServiceStatus{}, errors.Wrap(err, "failed to create vpcEndpointService")
+	}
+	serviceID := awssdk.StringValue(resp.ServiceConfiguration.ServiceId)
+	m.logger.Info("created vpcEndpointService",
+		"resourceID", resES.ID(),
+		"serviceID", serviceID)
+	if err := m.updateSDKVPCEndpointServiceWithPermissions(ctx, resES, serviceID, nil); err != nil {
+		return ec2model.VPCEndpointServiceStatus{}, err
+	}
+	return ec2model.VPCEndpointServiceStatus{
+		ServiceID:   serviceID,
+		ServiceName: awssdk.StringValue(resp.ServiceConfiguration.ServiceName),
+	}, nil
+}
+
+func (m *defaultVPCEndpointServiceManager) Update(ctx context.Context, resES *ec2model.VPCEndpointService, sdkES VPCEndpointServiceInfo) (ec2model.VPCEndpointServiceStatus, error) {
+	if err := m.updateSDKVPCEndpointServiceWithTags(ctx, resES, sdkES); err != nil {
+		return ec2model.VPCEndpointServiceStatus{}, err
+	}
+	if err := m.updateSDKVPCEndpointServiceWithConfiguration(ctx, resES, sdkES); err != nil {
+		return ec2model.VPCEndpointServiceStatus{}, err
+	}
+	allowedPrincipals, err := m.fetchSDKVPCEndpointServiceAllowedPrincipals(ctx, sdkES.ServiceID)
+	if err != nil {
+		return ec2model.VPCEndpointServiceStatus{}, err
+	}
+	if err := m.updateSDKVPCEndpointServiceWithPermissions(ctx, resES, sdkES.ServiceID, allowedPrincipals); err != nil {
+		return ec2model.VPCEndpointServiceStatus{}, err
+	}
+	return ec2model.VPCEndpointServiceStatus{
+		ServiceID:   sdkES.ServiceID,
+		ServiceName: sdkES.ServiceName,
+	}, nil
+}
+
+func (m *defaultVPCEndpointServiceManager) Delete(ctx context.Context, sdkES VPCEndpointServiceInfo) error {
+	// endpoint services cannot be deleted while there are available or pending endpoint connections.
+	if err := m.rejectSDKVPCEndpointConnections(ctx, sdkES.ServiceID); err != nil {
+		return err
+	}
+	req := &ec2sdk.DeleteVpcEndpointServiceConfigurationsInput{
+		ServiceIds: awssdk.StringSlice([]string{sdkES.ServiceID}),
+	}
+	m.logger.Info("deleting vpcEndpointService",
+		"serviceID", sdkES.ServiceID)
+	resp, err := m.ec2Client.DeleteVpcEndpointServiceConfigurationsWithContext(ctx, req)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete vpcEndpointService")
+	}
+	for _, item := range resp.Unsuccessful {
+		if item.Error != nil {
+			return errors.Errorf("failed to delete vpcEndpointService %v: %v: %v", sdkES.ServiceID,
+				awssdk.StringValue(item.Error.Code), awssdk.StringValue(item.Error.Message))
+		}
+	}
+	m.logger.Info("deleted vpcEndpointService",
+		"serviceID", sdkES.ServiceID)
+	return nil
+}
+
+func (m *defaultVPCEndpointServiceManager) updateSDKVPCEndpointServiceWithTags(ctx context.Context, resES *ec2model.VPCEndpointService, sdkES VPCEndpointServiceInfo) error {
+	desiredESTags := m.trackingProvider.ResourceTags(resES.Stack(), resES, resES.Spec.Tags)
+	return m.taggingManager.ReconcileTags(ctx, sdkES.ServiceID, desiredESTags, WithCurrentTags(sdkES.Tags), WithDeferredTagging())
+}
+
+func (m *defaultVPCEndpointServiceManager) updateSDKVPCEndpointServiceWithConfiguration(ctx context.Context, resES *ec2model.VPCEndpointService, sdkES VPCEndpointServiceInfo) error {
+	lbARNs, err := resolveNetworkLoadBalancerARNs(ctx, resES)
+	if err != nil {
+		return err
+	}
+	desiredLBARNs := sets.NewString(lbARNs...)
+	currentLBARNs := sets.NewString(sdkES.NetworkLoadBalancerARNs...)
+	lbARNsToAdd := desiredLBARNs.Difference(currentLBARNs)
+	lbARNsToRemove := currentLBARNs.Difference(desiredLBARNs)
+	if resES.Spec.AcceptanceRequired == sdkES.AcceptanceRequired && len(lbARNsToAdd) == 0 && len(lbARNsToRemove) == 0 {
+		return nil
+	}
+	req := &ec2sdk.ModifyVpcEndpointServiceConfigurationInput{
+		ServiceId:          awssdk.String(sdkES.ServiceID),
+		AcceptanceRequired: awssdk.Bool(resES.Spec.AcceptanceRequired),
+	}
+	if len(lbARNsToAdd) != 0 {
+		req.AddNetworkLoadBalancerArns = awssdk.StringSlice(lbARNsToAdd.List())
+	}
+	if len(lbARNsToRemove) != 0 {
+		req.RemoveNetworkLoadBalancerArns = awssdk.StringSlice(lbARNsToRemove.List())
+	}
+	m.logger.Info("modifying vpcEndpointService",
+		"resourceID", resES.ID(),
+		"serviceID", sdkES.ServiceID)
+	if _, err := m.ec2Client.ModifyVpcEndpointServiceConfigurationWithContext(ctx, req); err != nil {
+		return errors.Wrap(err, "failed to modify vpcEndpointService")
+	}
+	m.logger.Info("modified vpcEndpointService",
+		"resourceID", resES.ID(),
+		"serviceID", sdkES.ServiceID)
+	return nil
+}
+
+// updateSDKVPCEndpointServiceWithPermissions reconciles the principals allowed to create endpoints to the service.
+func (m *defaultVPCEndpointServiceManager) updateSDKVPCEndpointServiceWithPermissions(ctx context.Context, resES *ec2model.VPCEndpointService,
+	serviceID string, currentAllowedPrincipals []string) error {
+	desiredPrincipals := sets.NewString(resES.Spec.AllowedPrincipals...)
+	currentPrincipals := sets.NewString(currentAllowedPrincipals...)
+	principalsToAdd := desiredPrincipals.Difference(currentPrincipals)
+	principalsToRemove := currentPrincipals.Difference(desiredPrincipals)
+	if len(principalsToAdd) == 0 && len(principalsToRemove) == 0 {
+		return nil
+	}
+	req := &ec2sdk.ModifyVpcEndpointServicePermissionsInput{
+		ServiceId: awssdk.String(serviceID),
+	}
+	if len(principalsToAdd) != 0 {
+		req.AddAllowedPrincipals = awssdk.StringSlice(principalsToAdd.List())
+	}
+	if len(principalsToRemove) != 0 {
+		req.RemoveAllowedPrincipals = awssdk.StringSlice(principalsToRemove.List())
+	}
+	m.logger.Info("modifying vpcEndpointService permissions",
+		"resourceID", resES.ID(),
+		"serviceID", serviceID,
+		"addedPrincipals", principalsToAdd.List(),
+		"removedPrincipals", principalsToRemove.List())
+	if _, err := m.ec2Client.ModifyVpcEndpointServicePermissionsWithContext(ctx, req); err != nil {
+		return errors.Wrap(err, "failed to modify vpcEndpointService permissions")
+	}
+	m.logger.Info("modified vpcEndpointService permissions",
+		"resourceID", resES.ID(),
+		"serviceID", serviceID)
+	return nil
+}
+
+func (m *defaultVPCEndpointServiceManager) fetchSDKVPCEndpointServiceAllowedPrincipals(ctx context.Context, serviceID string) ([]string, error) {
+	req := &ec2sdk.DescribeVpcEndpointServicePermissionsInput{
+		ServiceId: awssdk.String(serviceID),
+	}
+	sdkPrincipals, err := m.ec2Client.DescribeVpcEndpointServicePermissionsAsList(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	principals := make([]string, 0, len(sdkPrincipals))
+	for _, sdkPrincipal := range sdkPrincipals {
+		principals = append(principals, awssdk.StringValue(sdkPrincipal.Principal))
+	}
+	return principals, nil
+}
+
+func (m *defaultVPCEndpointServiceManager) rejectSDKVPCEndpointConnections(ctx context.Context, serviceID string) error {
+	req := &ec2sdk.DescribeVpcEndpointConnectionsInput{
+		Filters: []*ec2sdk.Filter{
+			{
+				Name:   awssdk.String("service-id"),
+				Values: awssdk.StringSlice([]string{serviceID}),
+			},
+			{
+				Name:   awssdk.String("vpc-endpoint-state"),
+				Values: awssdk.StringSlice([]string{ec2sdk.StateAvailable, ec2sdk.StatePendingAcceptance}),
+			},
+		},
+	}
+	connections, err := m.ec2Client.DescribeVpcEndpointConnectionsAsList(ctx, req)
+	if err != nil {
+		return err
+	}
+	if len(connections) == 0 {
+		return nil
+	}
+	vpcEndpointIDs := make([]string, 0, len(connections))
+	for _, connection := range connections {
+		vpcEndpointIDs = append(vpcEndpointIDs, awssdk.StringValue(connection.VpcEndpointId))
+	}
+	m.logger.Info("rejecting vpcEndpoint connections",
+		"serviceID", serviceID,
+		"vpcEndpointIDs", vpcEndpointIDs)
+	if _, err := m.ec2Client.RejectVpcEndpointConnectionsWithContext(ctx, &ec2sdk.RejectVpcEndpointConnectionsInput{
+		ServiceId:      awssdk.String(serviceID),
+		VpcEndpointIds: awssdk.StringSlice(vpcEndpointIDs),
+	}); err != nil {
+		return errors.Wrap(err, "failed to reject vpcEndpoint connections")
+	}
+	m.logger.Info("rejected vpcEndpoint connections",
+		"serviceID", serviceID)
+	return nil
+}
+
+// resolveNetworkLoadBalancerARNs resolves the ARNs of Network Load Balancers fronted by endpoint service.
+func resolveNetworkLoadBalancerARNs(ctx context.Context, resES *ec2model.VPCEndpointService) ([]string, error) {
+	lbARNs := make([]string, 0, len(resES.Spec.NetworkLoadBalancerARNs))
+	for _, token := range resES.Spec.NetworkLoadBalancerARNs {
+		lbARN, err := token.Resolve(ctx)
+		if err != nil {
+			return nil, err
+		}
+		lbARNs = append(lbARNs, lbARN)
+	}
+	return lbARNs, nil
+}
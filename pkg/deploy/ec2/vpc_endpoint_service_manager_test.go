@@ -0,0 +1,69 @@
+package ec2
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	coremodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_defaultVPCEndpointServiceManager_updateSDKVPCEndpointServiceWithPermissions(t *testing.T) {
+	tests := []struct {
+		name                     string
+		desiredPrincipals        []string
+		currentAllowedPrincipals []string
+		wantReq                  *ec2sdk.ModifyVpcEndpointServicePermissionsInput
+	}{
+		{
+			name:                     "principals unchanged",
+			desiredPrincipals:        []string{"arn:aws:iam::123456789012:root"},
+			currentAllowedPrincipals: []string{"arn:aws:iam::123456789012:root"},
+		},
+		{
+			name:              "principals added",
+			desiredPrincipals: []string{"arn:aws:iam::123456789012:root"},
+			wantReq: &ec2sdk.ModifyVpcEndpointServicePermissionsInput{
+				ServiceId:            awssdk.String("vpce-svc-1"),
+				AddAllowedPrincipals: awssdk.StringSlice([]string{"arn:aws:iam::123456789012:root"}),
+			},
+		},
+		{
+			name:                     "principals added and removed",
+			desiredPrincipals:        []string{"arn:aws:iam::123456789012:root"},
+			currentAllowedPrincipals: []string{"arn:aws:iam::210987654321:root"},
+			wantReq: &ec2sdk.ModifyVpcEndpointServicePermissionsInput{
+				ServiceId:               awssdk.String("vpce-svc-1"),
+				AddAllowedPrincipals:    awssdk.StringSlice([]string{"arn:aws:iam::123456789012:root"}),
+				RemoveAllowedPrincipals: awssdk.StringSlice([]string{"arn:aws:iam::210987654321:root"}),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ec2Client := mock_services.NewMockEC2(ctrl)
+			if tt.wantReq != nil {
+				ec2Client.EXPECT().ModifyVpcEndpointServicePermissionsWithContext(gomock.Any(), tt.wantReq).
+					Return(&ec2sdk.ModifyVpcEndpointServicePermissionsOutput{}, nil)
+			}
+			stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "namespace", Name: "name"})
+			resES := ec2model.NewVPCEndpointService(stack, "LoadBalancer", ec2model.VPCEndpointServiceSpec{
+				AllowedPrincipals: tt.desiredPrincipals,
+			})
+			m := &defaultVPCEndpointServiceManager{
+				ec2Client: ec2Client,
+				logger:    &log.NullLogger{},
+			}
+			err := m.updateSDKVPCEndpointServiceWithPermissions(context.Background(), resES, "vpce-svc-1", tt.currentAllowedPrincipals)
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package ec2
+
+import (
+	"context"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+)
+
+// NewVPCEndpointServiceSynthesizer constructs new vpcEndpointServiceSynthesizer.
+func NewVPCEndpointServiceSynthesizer(trackingProvider tracking.Provider, taggingManager TaggingManager,
+	esManager VPCEndpointServiceManager, logger logr.Logger, stack core.Stack) *vpcEndpointServiceSynthesizer {
+	return &vpcEndpointServiceSynthesizer{
+		trackingProvider: trackingProvider,
+		taggingManager:   taggingManager,
+		esManager:        esManager,
+		logger:           logger,
+		stack:            stack,
+	}
+}
+
+// vpcEndpointServiceSynthesizer creates or updates the VPC endpoint services in stack.
+// it must run after the LoadBalancers fronted by endpoint services are synthesized.
+type vpcEndpointServiceSynthesizer struct {
+	trackingProvider tracking.Provider
+	taggingManager   TaggingManager
+	esManager        VPCEndpointServiceManager
+	logger           logr.Logger
+
+	stack core.Stack
+}
+
+func (s *vpcEndpointServiceSynthesizer) Synthesize(ctx context.Context) error {
+	var resESs []*ec2model.VPCEndpointService
+	s.stack.ListResources(&resESs)
+	sdkESs, err := findSDKVPCEndpointServices(ctx, s.trackingProvider, s.taggingManager, s.stack)
+	if err != nil {
+		return err
+	}
+	matchedResAndSDKESs, unmatchedResESs, _, err := matchResAndSDKVPCEndpointServices(resESs, sdkESs, s.trackingProvider.ResourceIDTagKey())
+	if err != nil {
+		return err
+	}
+	// unmatched endpoint services are deleted by vpcEndpointServiceDeletionSynthesizer before LoadBalancers are synthesized.
+	for _, resES := range unmatchedResESs {
+		esStatus, err := s.esManager.Create(ctx, resES)
+		if err != nil {
+			return err
+		}
+		resES.SetStatus(esStatus)
+	}
+	for _, resAndSDKES := range matchedResAndSDKESs {
+		esStatus, err := s.esManager.Update(ctx, resAndSDKES.resES, resAndSDKES.sdkES)
+		if err != nil {
+			return err
+		}
+		resAndSDKES.resES.SetStatus(esStatus)
+	}
+	return nil
+}
+
+func (s *vpcEndpointServiceSynthesizer) PostSynthesize(_ context.Context) error {
+	// nothing to do here.
+	return nil
+}
+
+// NewVPCEndpointServiceDeletionSynthesizer constructs new vpcEndpointServiceDeletionSynthesizer.
+func NewVPCEndpointServiceDeletionSynthesizer(trackingProvider tracking.Provider, taggingManager TaggingManager,
+	esManager VPCEndpointServiceManager, logger logr.Logger, stack core.Stack) *vpcEndpointServiceDeletionSynthesizer {
+	return &vpcEndpointServiceDeletionSynthesizer{
+		trackingProvider: trackingProvider,
+		taggingManager:   taggingManager,
+		esManager:        esManager,
+		logger:           logger,
+		stack:            stack,
+	}
+}
+
+// vpcEndpointServiceDeletionSynthesizer deletes the VPC endpoint services no longer in stack.
+// it must run before LoadBalancers are synthesized, since LoadBalancers cannot be deleted while fronted by endpoint services.
+type vpcEndpointServiceDeletionSynthesizer struct {
+	trackingProvider tracking.Provider
+	taggingManager   TaggingManager
+	esManager        VPCEndpointServiceManager
+	logger           logr.Logger
+
+	stack core.Stack
+}
+
+func (s *vpcEndpointServiceDeletionSynthesizer) Synthesize(ctx context.Context) error {
+	var resESs []*ec2model.VPCEndpointService
+	s.stack.ListResources(&resESs)
+	sdkESs, err := findSDKVPCEndpointServices(ctx, s.trackingProvider, s.taggingManager, s.stack)
+	if err != nil {
+		return err
+	}
+	_, _, unmatchedSDKESs, err := matchResAndSDKVPCEndpointServices(resESs, sdkESs, s.trackingProvider.ResourceIDTagKey())
+	if err != nil {
+		return err
+	}
+	for _, sdkES := range unmatchedSDKESs {
+		if err := s.esManager.Delete(ctx, sdkES); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *vpcEndpointServiceDeletionSynthesizer) PostSynthesize(_ context.Context) error {
+	// nothing to do here.
+	return nil
+}
+
+// findSDKVPCEndpointServices will find all VPC endpoint services created for stack.
+func findSDKVPCEndpointServices(ctx context.Context, trackingProvider tracking.Provider, taggingManager TaggingManager, stack core.Stack) ([]VPCEndpointServiceInfo, error) {
+	stackTags := trackingProvider.StackTags(stack)
+	return taggingManager.ListVPCEndpointServices(ctx, tracking.TagsAsTagFilter(stackTags))
+}
+
+type resAndSDKVPCEndpointServicePair struct {
+	resES *ec2model.VPCEndpointService
+	sdkES VPCEndpointServiceInfo
+}
+
+func matchResAndSDKVPCEndpointServices(resESs []*ec2model.VPCEndpointService, sdkESs []VPCEndpointServiceInfo,
+	resourceIDTagKey string) ([]resAndSDKVPCEndpointServicePair, []*ec2model.VPCEndpointService, []VPCEndpointServiceInfo, error) {
+	var matchedResAndSDKESs []resAndSDKVPCEndpointServicePair
+	var unmatchedResESs []*ec2model.VPCEndpointService
+	var unmatchedSDKESs []VPCEndpointServiceInfo
+
+	resESsByID := make(map[string]*ec2model.VPCEndpointService, len(resESs))
+	for _, resES := range resESs {
+		resESsByID[resES.ID()] = resES
+	}
+	sdkESsByID := make(map[string][]VPCEndpointServiceInfo, len(sdkESs))
+	for _, sdkES := range sdkESs {
+		resourceID, ok := sdkES.Tags[resourceIDTagKey]
+		if !ok {
+			return nil, nil, nil, errors.Errorf("unexpected vpcEndpointService with no resourceID: %v", sdkES.ServiceID)
+		}
+		sdkESsByID[resourceID] = append(sdkESsByID[resourceID], sdkES)
+	}
+
+	resESIDs := sets.StringKeySet(resESsByID)
+	sdkESIDs := sets.StringKeySet(sdkESsByID)
+	for _, resID := range resESIDs.Intersection(sdkESIDs).List() {
+		resES := resESsByID[resID]
+		sdkESs := sdkESsByID[resID]
+		matchedResAndSDKESs = append(matchedResAndSDKESs, resAndSDKVPCEndpointServicePair{
+			resES: resES,
+			sdkES: sdkESs[0],
+		})
+		unmatchedSDKESs = append(unmatchedSDKESs, sdkESs[1:]...)
+	}
+	for _, resID := range resESIDs.Difference(sdkESIDs).List() {
+		unmatchedResESs = append(unmatchedResESs, resESsByID[resID])
+	}
+	for _, resID := range sdkESIDs.Difference(resESIDs).List() {
+		unmatchedSDKESs = append(unmatchedSDKESs, sdkESsByID[resID]...)
+	}
+	return matchedResAndSDKESs, unmatchedResESs, unmatchedSDKESs, nil
+}
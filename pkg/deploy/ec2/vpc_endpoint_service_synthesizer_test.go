@@ -0,0 +1,71 @@
+package ec2
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	coremodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+	"testing"
+)
+
+func Test_matchResAndSDKVPCEndpointServices(t *testing.T) {
+	stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "namespace", Name: "name"})
+	resES := ec2model.NewVPCEndpointService(stack, "LoadBalancer", ec2model.VPCEndpointServiceSpec{})
+	sdkES := VPCEndpointServiceInfo{
+		ServiceID: "vpce-svc-1",
+		Tags:      map[string]string{"service.k8s.aws/resource": "LoadBalancer"},
+	}
+	sdkESDuplicate := VPCEndpointServiceInfo{
+		ServiceID: "vpce-svc-2",
+		Tags:      map[string]string{"service.k8s.aws/resource": "LoadBalancer"},
+	}
+	sdkESStale := VPCEndpointServiceInfo{
+		ServiceID: "vpce-svc-3",
+		Tags:      map[string]string{"service.k8s.aws/resource": "Stale"},
+	}
+	tests := []struct {
+		name                    string
+		resESs                  []*ec2model.VPCEndpointService
+		sdkESs                  []VPCEndpointServiceInfo
+		wantMatchedResAndSDKESs []resAndSDKVPCEndpointServicePair
+		wantUnmatchedResESs     []*ec2model.VPCEndpointService
+		wantUnmatchedSDKESs     []VPCEndpointServiceInfo
+		wantErr                 error
+	}{
+		{
+			name:                "endpoint service to create",
+			resESs:              []*ec2model.VPCEndpointService{resES},
+			wantUnmatchedResESs: []*ec2model.VPCEndpointService{resES},
+		},
+		{
+			name:                    "endpoint service to update, and duplicates to delete",
+			resESs:                  []*ec2model.VPCEndpointService{resES},
+			sdkESs:                  []VPCEndpointServiceInfo{sdkES, sdkESDuplicate, sdkESStale},
+			wantMatchedResAndSDKESs: []resAndSDKVPCEndpointServicePair{{resES: resES, sdkES: sdkES}},
+			wantUnmatchedSDKESs:     []VPCEndpointServiceInfo{sdkESDuplicate, sdkESStale},
+		},
+		{
+			name:                "endpoint service to delete",
+			sdkESs:              []VPCEndpointServiceInfo{sdkES},
+			wantUnmatchedSDKESs: []VPCEndpointServiceInfo{sdkES},
+		},
+		{
+			name:    "endpoint service without resourceID",
+			sdkESs:  []VPCEndpointServiceInfo{{ServiceID: "vpce-svc-4"}},
+			wantErr: errors.New("unexpected vpcEndpointService with no resourceID: vpce-svc-4"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotMatched, gotUnmatchedRes, gotUnmatchedSDK, err := matchResAndSDKVPCEndpointServices(tt.resESs, tt.sdkESs, "service.k8s.aws/resource")
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantMatchedResAndSDKESs, gotMatched)
+			assert.Equal(t, tt.wantUnmatchedResESs, gotUnmatchedRes)
+			assert.Equal(t, tt.wantUnmatchedSDKESs, gotUnmatchedSDK)
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package elbv2
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/metrics"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"strings"
+)
+
+const (
+	resourceKindLoadBalancer       = "AWS::ElasticLoadBalancingV2::LoadBalancer"
+	resourceKindListener           = "AWS::ElasticLoadBalancingV2::Listener"
+	resourceKindListenerRule       = "AWS::ElasticLoadBalancingV2::ListenerRule"
+	resourceKindTargetGroup        = "AWS::ElasticLoadBalancingV2::TargetGroup"
+	resourceKindTargetGroupBinding = "K8S::ElasticLoadBalancingV2::TargetGroupBinding"
+)
+
+// NewInstrumentedLoadBalancerManager constructs new LoadBalancerManager that collects metrics for loadBalancerManager.
+func NewInstrumentedLoadBalancerManager(loadBalancerManager LoadBalancerManager, metricsCollector metrics.Collector) *instrumentedLoadBalancerManager {
+	return &instrumentedLoadBalancerManager{
+		LoadBalancerManager: loadBalancerManager,
+		metricsCollector:    metricsCollector,
+	}
+}
+
+var _ LoadBalancerManager = &instrumentedLoadBalancerManager{}
+
+type instrumentedLoadBalancerManager struct {
+	LoadBalancerManager
+	metricsCollector metrics.Collector
+}
+
+func (m *instrumentedLoadBalancerManager) Create(ctx context.Context, resLB *elbv2model.LoadBalancer) (elbv2model.LoadBalancerStatus, error) {
+	var lbStatus elbv2model.LoadBalancerStatus
+	err := metrics.InstrumentResourceOperation(ctx, m.metricsCollector, resourceKindLoadBalancer, metrics.OperationCreate, func(ctx context.Context) error {
+		var err error
+		lbStatus, err = m.LoadBalancerManager.Create(ctx, resLB)
+		return err
+	})
+	return lbStatus, err
+}
+
+func (m *instrumentedLoadBalancerManager) Update(ctx context.Context, resLB *elbv2model.LoadBalancer, sdkLB LoadBalancerWithTags) (elbv2model.LoadBalancerStatus, error) {
+	var lbStatus elbv2model.LoadBalancerStatus
+	err := metrics.InstrumentResourceOperation(ctx, m.metricsCollector, resourceKindLoadBalancer, metrics.OperationUpdate, func(ctx context.Context) error {
+		var err error
+		lbStatus, err = m.LoadBalancerManager.Update(ctx, resLB, sdkLB)
+		return err
+	})
+	return lbStatus, err
+}
+
+func (m *instrumentedLoadBalancerManager) Delete(ctx context.Context, sdkLB LoadBalancerWithTags) error {
+	return metrics.InstrumentResourceOperation(ctx, m.metricsCollector, resourceKindLoadBalancer, metrics.OperationDelete, func(ctx context.Context) error {
+		return m.LoadBalancerManager.Delete(ctx, sdkLB)
+	})
+}
+
+// NewInstrumentedListenerManager constructs new ListenerManager that collects metrics for listenerManager.
+func NewInstrumentedListenerManager(listenerManager ListenerManager, metricsCollector metrics.Collector) *instrumentedListenerManager {
+	return &instrumentedListenerManager{
+		ListenerManager:  listenerManager,
+		metricsCollector: metricsCollector,
+	}
+}
+
+var _ ListenerManager = &instrumentedListenerManager{}
+
+type instrumentedListenerManager struct {
+	ListenerManager
+	metricsCollector metrics.Collector
+}
+
+func (m *instrumentedListenerManager) Create(ctx context.Context, resLS *elbv2model.Listener) (elbv2model.ListenerStatus, error) {
+	var lsStatus elbv2model.ListenerStatus
+	err := metrics.InstrumentResourceOperation(ctx, m.metricsCollector, resourceKindListener, metrics.OperationCreate, func(ctx context.Context) error {
+		var err error
+		lsStatus, err = m.ListenerManager.Create(ctx, resLS)
+		return err
+	})
+	return lsStatus, err
+}
+
+func (m *instrumentedListenerManager) Update(ctx context.Context, resLS *elbv2model.Listener, sdkLS *elbv2sdk.Listener) (elbv2model.ListenerStatus, error) {
+	var lsStatus elbv2model.ListenerStatus
+	err := metrics.InstrumentResourceOperation(ctx, m.metricsCollector, resourceKindListener, metrics.OperationUpdate, func(ctx context.Context) error {
+		var err error
+		lsStatus, err = m.ListenerManager.Update(ctx, resLS, sdkLS)
+		return err
+	})
+	return lsStatus, err
+}
+
+func (m *instrumentedListenerManager) Delete(ctx context.Context, sdkLS *elbv2sdk.Listener) error {
+	return metrics.InstrumentResourceOperation(ctx, m.metricsCollector, resourceKindListener, metrics.OperationDelete, func(ctx context.Context) error {
+		return m.ListenerManager.Delete(ctx, sdkLS)
+	})
+}
+
+// NewInstrumentedListenerRuleManager constructs new ListenerRuleManager that collects metrics for listenerRuleManager.
+func NewInstrumentedListenerRuleManager(listenerRuleManager ListenerRuleManager, metricsCollector metrics.Collector) *instrumentedListenerRuleManager {
+	return &instrumentedListenerRuleManager{
+		ListenerRuleManager: listenerRuleManager,
+		metricsCollector:    metricsCollector,
+	}
+}
+
+var _ ListenerRuleManager = &instrumentedListenerRuleManager{}
+
+type instrumentedListenerRuleManager struct {
+	ListenerRuleManager
+	metricsCollector metrics.Collector
+}
+
+func (m *instrumentedListenerRuleManager) Create(ctx context.Context, resLR *elbv2model.ListenerRule) (elbv2model.ListenerRuleStatus, error) {
+	var lrStatus elbv2model.ListenerRuleStatus
+	err := metrics.InstrumentResourceOperation(ctx, m.metricsCollector, resourceKindListenerRule, metrics.OperationCreate, func(ctx context.Context) error {
+		var err error
+		lrStatus, err = m.ListenerRuleManager.Create(ctx, resLR)
+		return err
+	})
+	return lrStatus, err
+}
+
+func (m *instrumentedListenerRuleManager) Update(ctx context.Context, resLR *elbv2model.ListenerRule, sdkLR *elbv2sdk.Rule) (elbv2model.ListenerRuleStatus, error) {
+	var lrStatus elbv2model.ListenerRuleStatus
+	err := metrics.InstrumentResourceOperation(ctx, m.metricsCollector, resourceKindListenerRule, metrics.OperationUpdate, func(ctx context.Context) error {
+		var err error
+		lrStatus, err = m.ListenerRuleManager.Update(ctx, resLR, sdkLR)
+		return err
+	})
+	return lrStatus, err
+}
+
+func (m *instrumentedListenerRuleManager) Delete(ctx context.Context, sdkLR *elbv2sdk.Rule) error {
+	return metrics.InstrumentResourceOperation(ctx, m.metricsCollector, resourceKindListenerRule, metrics.OperationDelete, func(ctx context.Context) error {
+		return m.ListenerRuleManager.Delete(ctx, sdkLR)
+	})
+}
+
+// NewInstrumentedTargetGroupManager constructs new TargetGroupManager that collects metrics for targetGroupManager.
+func NewInstrumentedTargetGroupManager(targetGroupManager TargetGroupManager, metricsCollector metrics.Collector) *instrumentedTargetGroupManager {
+	return &instrumentedTargetGroupManager{
+		TargetGroupManager: targetGroupManager,
+		metricsCollector:   metricsCollector,
+	}
+}
+
+var _ TargetGroupManager = &instrumentedTargetGroupManager{}
+
+type instrumentedTargetGroupManager struct {
+	TargetGroupManager
+	metricsCollector metrics.Collector
+}
+
+func (m *instrumentedTargetGroupManager) Create(ctx context.Context, resTG *elbv2model.TargetGroup) (elbv2model.TargetGroupStatus, error) {
+	var tgStatus elbv2model.TargetGroupStatus
+	err := metrics.InstrumentResourceOperation(ctx, m.metricsCollector, resourceKindTargetGroup, metrics.OperationCreate, func(ctx context.Context) error {
+		var err error
+		tgStatus, err = m.TargetGroupManager.Create(ctx, resTG)
+		return err
+	})
+	return tgStatus, err
+}
+
+func (m *instrumentedTargetGroupManager) Update(ctx context.Context, resTG *elbv2model.TargetGroup, sdkTG TargetGroupWithTags) (elbv2model.TargetGroupStatus, error) {
+	var tgStatus elbv2model.TargetGroupStatus
+	err := metrics.InstrumentResourceOperation(ctx, m.metricsCollector, resourceKindTargetGroup, metrics.OperationUpdate, func(ctx context.Context) error {
+		var err error
+		tgStatus, err = m.TargetGroupManager.Update(ctx, resTG, sdkTG)
+		return err
+	})
+	return tgStatus, err
+}
+
+func (m *instrumentedTargetGroupManager) Delete(ctx context.Context, sdkTG TargetGroupWithTags) error {
+	return metrics.InstrumentResourceOperation(ctx, m.metricsCollector, resourceKindTargetGroup, metrics.OperationDelete, func(ctx context.Context) error {
+		return m.TargetGroupManager.Delete(ctx, sdkTG)
+	})
+}
+
+// NewInstrumentedTargetGroupBindingManager constructs new TargetGroupBindingManager that collects metrics for targetGroupBindingManager.
+func NewInstrumentedTargetGroupBindingManager(targetGroupBindingManager TargetGroupBindingManager, metricsCollector metrics.Collector) *instrumentedTargetGroupBindingManager {
+	return &instrumentedTargetGroupBindingManager{
+		TargetGroupBindingManager: targetGroupBindingManager,
+		metricsCollector:          metricsCollector,
+	}
+}
+
+var _ TargetGroupBindingManager = &instrumentedTargetGroupBindingManager{}
+
+type instrumentedTargetGroupBindingManager struct {
+	TargetGroupBindingManager
+	metricsCollector metrics.Collector
+}
+
+func (m *instrumentedTargetGroupBindingManager) Create(ctx context.Context, resTGB *elbv2model.TargetGroupBindingResource) (elbv2model.TargetGroupBindingResourceStatus, error) {
+	var tgbStatus elbv2model.TargetGroupBindingResourceStatus
+	err := metrics.InstrumentResourceOperation(ctx, m.metricsCollector, resourceKindTargetGroupBinding, metrics.OperationCreate, func(ctx context.Context) error {
+		var err error
+		tgbStatus, err = m.TargetGroupBindingManager.Create(ctx, resTGB)
+		return err
+	})
+	return tgbStatus, err
+}
+
+func (m *instrumentedTargetGroupBindingManager) Update(ctx context.Context, resTGB *elbv2model.TargetGroupBindingResource, k8sTGB *elbv2api.TargetGroupBinding) (elbv2model.TargetGroupBindingResourceStatus, error) {
+	var tgbStatus elbv2model.TargetGroupBindingResourceStatus
+	err := metrics.InstrumentResourceOperation(ctx, m.metricsCollector, resourceKindTargetGroupBinding, metrics.OperationUpdate, func(ctx context.Context) error {
+		var err error
+		tgbStatus, err = m.TargetGroupBindingManager.Update(ctx, resTGB, k8sTGB)
+		return err
+	})
+	return tgbStatus, err
+}
+
+func (m *instrumentedTargetGroupBindingManager) Delete(ctx context.Context, k8sTGB *elbv2api.TargetGroupBinding) error {
+	return metrics.InstrumentResourceOperation(ctx, m.metricsCollector, resourceKindTargetGroupBinding, metrics.OperationDelete, func(ctx context.Context) error {
+		return m.TargetGroupBindingManager.Delete(ctx, k8sTGB)
+	})
+}
+
+// NewInstrumentedTaggingManager constructs new TaggingManager that collects metrics for taggingManager.
+func NewInstrumentedTaggingManager(taggingManager TaggingManager, metricsCollector metrics.Collector) *instrumentedTaggingManager {
+	return &instrumentedTaggingManager{
+		TaggingManager:   taggingManager,
+		metricsCollector: metricsCollector,
+	}
+}
+
+var _ TaggingManager = &instrumentedTaggingManager{}
+
+type instrumentedTaggingManager struct {
+	TaggingManager
+	metricsCollector metrics.Collector
+}
+
+func (m *instrumentedTaggingManager) ReconcileTags(ctx context.Context, arn string, desiredTags map[string]string, opts ...ReconcileTagsOption) error {
+	return metrics.InstrumentTagsReconcile(ctx, m.metricsCollector, resourceKindForARN(arn), func(ctx context.Context) error {
+		return m.TaggingManager.ReconcileTags(ctx, arn, desiredTags, opts...)
+	})
+}
+
+// resourceKindForARN returns the resource kind for ELBV2 resource ARN.
+func resourceKindForARN(resARN string) string {
+	parsedARN, err := arn.Parse(resARN)
+	if err != nil {
+		return "unknown"
+	}
+	resourceType := strings.SplitN(parsedARN.Resource, "/", 2)[0]
+	switch resourceType {
+	case "loadbalancer":
+		return resourceKindLoadBalancer
+	case "targetgroup":
+		return resourceKindTargetGroup
+	case "listener":
+		return resourceKindListener
+	case "listener-rule":
+		return resourceKindListenerRule
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,46 @@
+package elbv2
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_resourceKindForARN(t *testing.T) {
+	tests := []struct {
+		name   string
+		resARN string
+		want   string
+	}{
+		{
+			name:   "loadBalancer",
+			resARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-lb/50dc6c495c0c9188",
+			want:   "AWS::ElasticLoadBalancingV2::LoadBalancer",
+		},
+		{
+			name:   "targetGroup",
+			resARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/73e2d6bc24d8a067",
+			want:   "AWS::ElasticLoadBalancingV2::TargetGroup",
+		},
+		{
+			name:   "listener",
+			resARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener/app/my-lb/50dc6c495c0c9188/f2f7dc8efc522ab2",
+			want:   "AWS::ElasticLoadBalancingV2::Listener",
+		},
+		{
+			name:   "listenerRule",
+			resARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener-rule/app/my-lb/50dc6c495c0c9188/f2f7dc8efc522ab2/9683b2d02a6cabee",
+			want:   "AWS::ElasticLoadBalancingV2::ListenerRule",
+		},
+		{
+			name:   "invalid ARN",
+			resARN: "my-lb",
+			want:   "unknown",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resourceKindForARN(tt.resARN)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
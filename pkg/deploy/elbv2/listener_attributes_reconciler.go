@@ -0,0 +1,112 @@
+package elbv2
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/audit"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+)
+
+// reconciler for Listener attributes
+type ListenerAttributesReconciler interface {
+	// Reconcile Listener attributes
+	Reconcile(ctx context.Context, resLS *elbv2model.Listener, sdkLS *elbv2sdk.Listener) error
+}
+
+// NewDefaultListenerAttributesReconciler constructs new ListenerAttributesReconciler.
+func NewDefaultListenerAttributesReconciler(elbv2Client services.ELBV2, logger logr.Logger) *defaultListenerAttributesReconciler {
+	return &defaultListenerAttributesReconciler{
+		elbv2Client: elbv2Client,
+		logger:      logger,
+	}
+}
+
+var _ ListenerAttributesReconciler = &defaultListenerAttributesReconciler{}
+
+// default implementation for ListenerAttributesReconciler
+// only the explicitly specified attributes are reconciled, other attributes are considered externally managed.
+type defaultListenerAttributesReconciler struct {
+	elbv2Client services.ELBV2
+	logger      logr.Logger
+}
+
+func (r *defaultListenerAttributesReconciler) Reconcile(ctx context.Context, resLS *elbv2model.Listener, sdkLS *elbv2sdk.Listener) error {
+	desiredAttrs := r.getDesiredListenerAttributes(ctx, resLS)
+	if len(desiredAttrs) == 0 {
+		return nil
+	}
+	currentAttrs, err := r.getCurrentListenerAttributes(ctx, sdkLS)
+	if err != nil {
+		return err
+	}
+
+	attributesToUpdate, _ := algorithm.DiffStringMap(desiredAttrs, currentAttrs)
+	if len(attributesToUpdate) > 0 {
+		req := &services.ModifyListenerAttributesInput{
+			ListenerArn: sdkLS.ListenerArn,
+			Attributes:  nil,
+		}
+		for _, attrKey := range sets.StringKeySet(attributesToUpdate).List() {
+			req.Attributes = append(req.Attributes, &services.ListenerAttribute{
+				Key:   awssdk.String(attrKey),
+				Value: awssdk.String(attributesToUpdate[attrKey]),
+			})
+		}
+
+		r.logger.Info("modifying listener attributes",
+			"stackID", resLS.Stack().StackID(),
+			"resourceID", resLS.ID(),
+			"arn", awssdk.StringValue(sdkLS.ListenerArn),
+			"change", attributesToUpdate)
+		if _, err := r.elbv2Client.ModifyListenerAttributesWithContext(ctx, req); err != nil {
+			return err
+		}
+		r.logger.Info("modified listener attributes",
+			"stackID", resLS.Stack().StackID(),
+			"resourceID", resLS.ID(),
+			"arn", awssdk.StringValue(sdkLS.ListenerArn))
+		previousAttrs := make(map[string]string, len(attributesToUpdate))
+		for attrKey := range attributesToUpdate {
+			if attrValue, exists := currentAttrs[attrKey]; exists {
+				previousAttrs[attrKey] = attrValue
+			}
+		}
+		audit.RecordMutation(ctx, audit.Mutation{
+			ResourceKind: audit.ResourceKindListenerAttribute,
+			ResourceID:   awssdk.StringValue(sdkLS.ListenerArn),
+			Operation:    audit.OperationModify,
+			Diff:         audit.ComputeDiff(previousAttrs, attributesToUpdate),
+		})
+	}
+	return nil
+}
+
+func (r *defaultListenerAttributesReconciler) getDesiredListenerAttributes(ctx context.Context, resLS *elbv2model.Listener) map[string]string {
+	lsAttributes := make(map[string]string, len(resLS.Spec.ListenerAttributes))
+	for _, attr := range resLS.Spec.ListenerAttributes {
+		lsAttributes[attr.Key] = attr.Value
+	}
+	return lsAttributes
+}
+
+func (r *defaultListenerAttributesReconciler) getCurrentListenerAttributes(ctx context.Context, sdkLS *elbv2sdk.Listener) (map[string]string, error) {
+	req := &services.DescribeListenerAttributesInput{
+		ListenerArn: sdkLS.ListenerArn,
+	}
+
+	resp, err := r.elbv2Client.DescribeListenerAttributesWithContext(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	lsAttributes := make(map[string]string, len(resp.Attributes))
+	for _, attr := range resp.Attributes {
+		lsAttributes[awssdk.StringValue(attr.Key)] = awssdk.StringValue(attr.Value)
+	}
+	return lsAttributes, nil
+}
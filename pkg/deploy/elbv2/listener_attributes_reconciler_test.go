@@ -0,0 +1,255 @@
+package elbv2
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	coremodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_defaultListenerAttributesReconciler_Reconcile(t *testing.T) {
+	type describeListenerAttributesWithContextCall struct {
+		req  *services.DescribeListenerAttributesInput
+		resp *services.DescribeListenerAttributesOutput
+		err  error
+	}
+
+	type modifyListenerAttributesWithContextCall struct {
+		req  *services.ModifyListenerAttributesInput
+		resp *services.ModifyListenerAttributesOutput
+		err  error
+	}
+
+	type fields struct {
+		describeListenerAttributesWithContextCalls []describeListenerAttributesWithContextCall
+		modifyListenerAttributesWithContextCalls   []modifyListenerAttributesWithContextCall
+	}
+	type args struct {
+		sdkLS *elbv2sdk.Listener
+		resLS *elbv2model.Listener
+	}
+
+	stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "namespace", Name: "name"})
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "tcp idle timeout should be updated",
+			fields: fields{
+				describeListenerAttributesWithContextCalls: []describeListenerAttributesWithContextCall{
+					{
+						req: &services.DescribeListenerAttributesInput{
+							ListenerArn: awssdk.String("my-arn"),
+						},
+						resp: &services.DescribeListenerAttributesOutput{
+							Attributes: []*services.ListenerAttribute{
+								{
+									Key:   awssdk.String("tcp.idle_timeout.seconds"),
+									Value: awssdk.String("350"),
+								},
+							},
+						},
+					},
+				},
+				modifyListenerAttributesWithContextCalls: []modifyListenerAttributesWithContextCall{
+					{
+						req: &services.ModifyListenerAttributesInput{
+							ListenerArn: awssdk.String("my-arn"),
+							Attributes: []*services.ListenerAttribute{
+								{
+									Key:   awssdk.String("tcp.idle_timeout.seconds"),
+									Value: awssdk.String("600"),
+								},
+							},
+						},
+						resp: &services.ModifyListenerAttributesOutput{},
+					},
+				},
+			},
+			args: args{
+				sdkLS: &elbv2sdk.Listener{
+					ListenerArn: awssdk.String("my-arn"),
+				},
+				resLS: &elbv2model.Listener{
+					ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::Listener", "id-1"),
+					Spec: elbv2model.ListenerSpec{
+						ListenerAttributes: []elbv2model.ListenerAttribute{
+							{
+								Key:   "tcp.idle_timeout.seconds",
+								Value: "600",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "no attributes should be updated",
+			fields: fields{
+				describeListenerAttributesWithContextCalls: []describeListenerAttributesWithContextCall{
+					{
+						req: &services.DescribeListenerAttributesInput{
+							ListenerArn: awssdk.String("my-arn"),
+						},
+						resp: &services.DescribeListenerAttributesOutput{
+							Attributes: []*services.ListenerAttribute{
+								{
+									Key:   awssdk.String("tcp.idle_timeout.seconds"),
+									Value: awssdk.String("600"),
+								},
+								{
+									Key:   awssdk.String("some.other.attribute"),
+									Value: awssdk.String("externally-managed"),
+								},
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				sdkLS: &elbv2sdk.Listener{
+					ListenerArn: awssdk.String("my-arn"),
+				},
+				resLS: &elbv2model.Listener{
+					ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::Listener", "id-1"),
+					Spec: elbv2model.ListenerSpec{
+						ListenerAttributes: []elbv2model.ListenerAttribute{
+							{
+								Key:   "tcp.idle_timeout.seconds",
+								Value: "600",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "attributes shouldn't be described when not specified",
+			fields: fields{},
+			args: args{
+				sdkLS: &elbv2sdk.Listener{
+					ListenerArn: awssdk.String("my-arn"),
+				},
+				resLS: &elbv2model.Listener{
+					ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::Listener", "id-1"),
+					Spec:         elbv2model.ListenerSpec{},
+				},
+			},
+		},
+		{
+			name: "failed to describe attributes",
+			fields: fields{
+				describeListenerAttributesWithContextCalls: []describeListenerAttributesWithContextCall{
+					{
+						req: &services.DescribeListenerAttributesInput{
+							ListenerArn: awssdk.String("my-arn"),
+						},
+						err: errors.New("some error"),
+					},
+				},
+			},
+			args: args{
+				sdkLS: &elbv2sdk.Listener{
+					ListenerArn: awssdk.String("my-arn"),
+				},
+				resLS: &elbv2model.Listener{
+					ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::Listener", "id-1"),
+					Spec: elbv2model.ListenerSpec{
+						ListenerAttributes: []elbv2model.ListenerAttribute{
+							{
+								Key:   "tcp.idle_timeout.seconds",
+								Value: "600",
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("some error"),
+		},
+		{
+			name: "failed to modify attributes",
+			fields: fields{
+				describeListenerAttributesWithContextCalls: []describeListenerAttributesWithContextCall{
+					{
+						req: &services.DescribeListenerAttributesInput{
+							ListenerArn: awssdk.String("my-arn"),
+						},
+						resp: &services.DescribeListenerAttributesOutput{
+							Attributes: []*services.ListenerAttribute{
+								{
+									Key:   awssdk.String("tcp.idle_timeout.seconds"),
+									Value: awssdk.String("350"),
+								},
+							},
+						},
+					},
+				},
+				modifyListenerAttributesWithContextCalls: []modifyListenerAttributesWithContextCall{
+					{
+						req: &services.ModifyListenerAttributesInput{
+							ListenerArn: awssdk.String("my-arn"),
+							Attributes: []*services.ListenerAttribute{
+								{
+									Key:   awssdk.String("tcp.idle_timeout.seconds"),
+									Value: awssdk.String("6000"),
+								},
+							},
+						},
+						err: errors.New("some error"),
+					},
+				},
+			},
+			args: args{
+				sdkLS: &elbv2sdk.Listener{
+					ListenerArn: awssdk.String("my-arn"),
+				},
+				resLS: &elbv2model.Listener{
+					ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::Listener", "id-1"),
+					Spec: elbv2model.ListenerSpec{
+						ListenerAttributes: []elbv2model.ListenerAttribute{
+							{
+								Key:   "tcp.idle_timeout.seconds",
+								Value: "6000",
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			elbv2Client := mock_services.NewMockELBV2(ctrl)
+			for _, call := range tt.fields.describeListenerAttributesWithContextCalls {
+				elbv2Client.EXPECT().DescribeListenerAttributesWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			for _, call := range tt.fields.modifyListenerAttributesWithContextCalls {
+				elbv2Client.EXPECT().ModifyListenerAttributesWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			r := &defaultListenerAttributesReconciler{
+				elbv2Client: elbv2Client,
+				logger:      &log.NullLogger{},
+			}
+			err := r.Reconcile(context.Background(), tt.args.resLS, tt.args.sdkLS)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
 	elbv2equality "sigs.k8s.io/aws-load-balancer-controller/pkg/equality/elbv2"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
@@ -25,9 +26,11 @@ type ListenerManager interface {
 	Delete(ctx context.Context, sdkLS *elbv2sdk.Listener) error
 }
 
-func NewDefaultListenerManager(elbv2Client services.ELBV2, logger logr.Logger) *defaultListenerManager {
+func NewDefaultListenerManager(elbv2Client services.ELBV2, trackingProvider tracking.Provider, logger logr.Logger) *defaultListenerManager {
 	return &defaultListenerManager{
 		elbv2Client:                 elbv2Client,
+		trackingProvider:            trackingProvider,
+		attributesReconciler:        NewDefaultListenerAttributesReconciler(elbv2Client, logger),
 		logger:                      logger,
 		waitLSExistencePollInterval: defaultWaitLSExistencePollInterval,
 		waitLSExistenceTimeout:      defaultWaitLSExistenceTimeout,
@@ -38,8 +41,10 @@ var _ ListenerManager = &defaultListenerManager{}
 
 // default implementation for ListenerManager
 type defaultListenerManager struct {
-	elbv2Client services.ELBV2
-	logger      logr.Logger
+	elbv2Client          services.ELBV2
+	trackingProvider     tracking.Provider
+	attributesReconciler ListenerAttributesReconciler
+	logger               logr.Logger
 
 	waitLSExistencePollInterval time.Duration
 	waitLSExistenceTimeout      time.Duration
@@ -50,6 +55,11 @@ func (m *defaultListenerManager) Create(ctx context.Context, resLS *elbv2model.L
 	if err != nil {
 		return elbv2model.ListenerStatus{}, err
 	}
+	// listeners on existing LoadBalancers are tracked by tags, since they share LoadBalancer with listeners not managed by controller.
+	if isListenerOnExistingLoadBalancer(resLS) {
+		lsTags := m.trackingProvider.ResourceTags(resLS.Stack(), resLS, nil)
+		req.Tags = convertTagsToSDKTags(lsTags)
+	}
 
 	m.logger.Info("creating listener",
 		"stackID", resLS.Stack().StackID(),
@@ -69,6 +79,11 @@ func (m *defaultListenerManager) Create(ctx context.Context, resLS *elbv2model.L
 	}); err != nil {
 		return elbv2model.ListenerStatus{}, errors.Wrap(err, "failed to update extra certificates on listener")
 	}
+	if err := runtime.RetryImmediateOnError(m.waitLSExistencePollInterval, m.waitLSExistenceTimeout, isListenerNotFoundError, func() error {
+		return m.attributesReconciler.Reconcile(ctx, resLS, sdkLS)
+	}); err != nil {
+		return elbv2model.ListenerStatus{}, errors.Wrap(err, "failed to update attributes on listener")
+	}
 	return buildResListenerStatus(sdkLS), nil
 }
 
@@ -79,6 +94,9 @@ func (m *defaultListenerManager) Update(ctx context.Context, resLS *elbv2model.L
 	if err := m.updateSDKListenerWithExtraCertificates(ctx, resLS, sdkLS, false); err != nil {
 		return elbv2model.ListenerStatus{}, err
 	}
+	if err := m.attributesReconciler.Reconcile(ctx, resLS, sdkLS); err != nil {
+		return elbv2model.ListenerStatus{}, err
+	}
 	return buildResListenerStatus(sdkLS), nil
 }
 
@@ -101,6 +119,7 @@ func (m *defaultListenerManager) updateSDKListenerWithSettings(ctx context.Conte
 	if err != nil {
 		return err
 	}
+	preserveExternallyManagedWeights(resLS.Spec.DefaultActions, desiredDefaultActions, sdkLS.DefaultActions)
 	desiredDefaultCerts, _ := buildSDKCertificates(resLS.Spec.Certificates)
 	if !isSDKListenerSettingsDrifted(resLS.Spec, sdkLS, desiredDefaultActions, desiredDefaultCerts) {
 		return nil
@@ -125,8 +144,12 @@ func (m *defaultListenerManager) updateSDKListenerWithSettings(ctx context.Conte
 // currentExtraCertificates is the current extra certificates, if it's nil, the current extra certificates will be fetched from AWS.
 func (m *defaultListenerManager) updateSDKListenerWithExtraCertificates(ctx context.Context, resLS *elbv2model.Listener,
 	sdkLS *elbv2sdk.Listener, isNewSDKListener bool) error {
+	desiredDefaultCertARNs := sets.NewString()
 	desiredExtraCertARNs := sets.NewString()
-	_, desiredExtraCerts := buildSDKCertificates(resLS.Spec.Certificates)
+	desiredDefaultCerts, desiredExtraCerts := buildSDKCertificates(resLS.Spec.Certificates)
+	for _, cert := range desiredDefaultCerts {
+		desiredDefaultCertARNs.Insert(awssdk.StringValue(cert.CertificateArn))
+	}
 	for _, cert := range desiredExtraCerts {
 		desiredExtraCertARNs.Insert(awssdk.StringValue(cert.CertificateArn))
 	}
@@ -138,6 +161,9 @@ func (m *defaultListenerManager) updateSDKListenerWithExtraCertificates(ctx cont
 		}
 		currentExtraCertARNs.Insert(certARNs...)
 	}
+	// the default certificate might still be listed as extra certificate when it's promoted from extra certificates,
+	// it shouldn't be removed since ALB don't allow removal of default certificate.
+	currentExtraCertARNs = currentExtraCertARNs.Difference(desiredDefaultCertARNs)
 
 	for _, certARN := range desiredExtraCertARNs.Difference(currentExtraCertARNs).List() {
 		req := &elbv2sdk.AddListenerCertificatesInput{
@@ -294,3 +320,13 @@ func buildResListenerStatus(sdkLS *elbv2sdk.Listener) elbv2model.ListenerStatus
 		ListenerARN: awssdk.StringValue(sdkLS.ListenerArn),
 	}
 }
+
+// isListenerOnExistingLoadBalancer checks whether listener is on an existing LoadBalancer that isn't managed by controller.
+func isListenerOnExistingLoadBalancer(resLS *elbv2model.Listener) bool {
+	for _, dep := range resLS.Spec.LoadBalancerARN.Dependencies() {
+		if resLB, ok := dep.(*elbv2model.LoadBalancer); ok && resLB.Spec.ExistingLoadBalancerARN != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/audit"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
 	elbv2equality "sigs.k8s.io/aws-load-balancer-controller/pkg/equality/elbv2"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
@@ -66,6 +67,12 @@ func (m *defaultListenerRuleManager) Create(ctx context.Context, resLR *elbv2mod
 		"stackID", resLR.Stack().StackID(),
 		"resourceID", resLR.ID(),
 		"arn", awssdk.StringValue(sdkLR.RuleArn))
+	audit.RecordMutation(ctx, audit.Mutation{
+		ResourceKind: audit.ResourceKindListenerRule,
+		ResourceID:   awssdk.StringValue(sdkLR.RuleArn),
+		Operation:    audit.OperationCreate,
+		Diff:         audit.ComputeDiff(nil, buildListenerRuleSettingsForAudit(req.Priority, req.Actions, req.Conditions)),
+	})
 
 	return buildResListenerRuleStatus(sdkLR), nil
 }
@@ -88,6 +95,12 @@ func (m *defaultListenerRuleManager) Delete(ctx context.Context, sdkLR *elbv2sdk
 	}
 	m.logger.Info("deleted listener rule",
 		"arn", awssdk.StringValue(req.RuleArn))
+	audit.RecordMutation(ctx, audit.Mutation{
+		ResourceKind: audit.ResourceKindListenerRule,
+		ResourceID:   awssdk.StringValue(req.RuleArn),
+		Operation:    audit.OperationDelete,
+		Diff:         audit.ComputeDiff(buildListenerRuleSettingsForAudit(sdkLR.Priority, sdkLR.Actions, sdkLR.Conditions), nil),
+	})
 	return nil
 }
 
@@ -96,6 +109,7 @@ func (m *defaultListenerRuleManager) updateSDKListenerRuleWithSettings(ctx conte
 	if err != nil {
 		return err
 	}
+	preserveExternallyManagedWeights(resLR.Spec.Actions, desiredActions, sdkLR.Actions)
 	desiredConditions := buildSDKRuleConditions(resLR.Spec.Conditions)
 	if !isSDKListenerRuleSettingsDrifted(resLR.Spec, sdkLR, desiredActions, desiredConditions) {
 		return nil
@@ -114,6 +128,13 @@ func (m *defaultListenerRuleManager) updateSDKListenerRuleWithSettings(ctx conte
 		"stackID", resLR.Stack().StackID(),
 		"resourceID", resLR.ID(),
 		"arn", awssdk.StringValue(sdkLR.RuleArn))
+	audit.RecordMutation(ctx, audit.Mutation{
+		ResourceKind: audit.ResourceKindListenerRule,
+		ResourceID:   awssdk.StringValue(sdkLR.RuleArn),
+		Operation:    audit.OperationModify,
+		Diff: audit.ComputeDiff(buildListenerRuleSettingsForAudit(nil, sdkLR.Actions, sdkLR.Conditions),
+			buildListenerRuleSettingsForAudit(nil, desiredActions, desiredConditions)),
+	})
 	return nil
 }
 
@@ -155,6 +176,15 @@ func buildSDKModifyListenerRuleInput(_ elbv2model.ListenerRuleSpec, desiredActio
 	return sdkObj
 }
 
+// buildListenerRuleSettingsForAudit builds the listener rule settings reported in mutation diffs.
+func buildListenerRuleSettingsForAudit(priority interface{}, actions []*elbv2sdk.Action, conditions []*elbv2sdk.RuleCondition) map[string]interface{} {
+	return map[string]interface{}{
+		"priority":   priority,
+		"actions":    actions,
+		"conditions": conditions,
+	}
+}
+
 func buildResListenerRuleStatus(sdkLR *elbv2sdk.Rule) elbv2model.ListenerRuleStatus {
 	return elbv2model.ListenerRuleStatus{
 		RuleARN: awssdk.StringValue(sdkLR.RuleArn),
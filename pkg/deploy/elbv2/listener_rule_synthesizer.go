@@ -9,23 +9,26 @@ import (
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
 	"strconv"
 )
 
 // NewListenerRuleSynthesizer constructs new listenerRuleSynthesizer.
-func NewListenerRuleSynthesizer(elbv2Client services.ELBV2, lrManager ListenerRuleManager, logger logr.Logger, stack core.Stack) *listenerRuleSynthesizer {
+func NewListenerRuleSynthesizer(elbv2Client services.ELBV2, lrManager ListenerRuleManager, maxConcurrency int, logger logr.Logger, stack core.Stack) *listenerRuleSynthesizer {
 	return &listenerRuleSynthesizer{
-		elbv2Client: elbv2Client,
-		lrManager:   lrManager,
-		logger:      logger,
-		stack:       stack,
+		elbv2Client:    elbv2Client,
+		lrManager:      lrManager,
+		maxConcurrency: maxConcurrency,
+		logger:         logger,
+		stack:          stack,
 	}
 }
 
 type listenerRuleSynthesizer struct {
-	elbv2Client services.ELBV2
-	lrManager   ListenerRuleManager
-	logger      logr.Logger
+	elbv2Client    services.ELBV2
+	lrManager      ListenerRuleManager
+	maxConcurrency int
+	logger         logr.Logger
 
 	stack core.Stack
 }
@@ -65,26 +68,35 @@ func (s *listenerRuleSynthesizer) synthesizeListenerRulesOnListener(ctx context.
 	}
 
 	matchedResAndSDKLRs, unmatchedResLRs, unmatchedSDKLRs := matchResAndSDKListenerRules(resLRs, sdkLRs)
-	for _, sdkLR := range unmatchedSDKLRs {
-		if err := s.lrManager.Delete(ctx, sdkLR); err != nil {
-			return err
-		}
+	// rules are matched by priority, so rules with distinct priorities can be deleted, created or updated in parallel.
+	// a logical rule split into rules with consecutive priorities is diffed the same way, the surplus rules left over
+	// when it's split into fewer rules are unmatched and deleted.
+	// unmatched rules must be deleted before creating new rules to free up rule quota.
+	if err := runtime.ParallelizeUntilError(ctx, s.maxConcurrency, len(unmatchedSDKLRs), func(ctx context.Context, i int) error {
+		return s.lrManager.Delete(ctx, unmatchedSDKLRs[i])
+	}); err != nil {
+		return err
 	}
-	for _, resLR := range unmatchedResLRs {
+	if err := runtime.ParallelizeUntilError(ctx, s.maxConcurrency, len(unmatchedResLRs), func(ctx context.Context, i int) error {
+		resLR := unmatchedResLRs[i]
 		lrStatus, err := s.lrManager.Create(ctx, resLR)
 		if err != nil {
 			return err
 		}
 		resLR.SetStatus(lrStatus)
+		return nil
+	}); err != nil {
+		return err
 	}
-	for _, resAndSDKLR := range matchedResAndSDKLRs {
+	return runtime.ParallelizeUntilError(ctx, s.maxConcurrency, len(matchedResAndSDKLRs), func(ctx context.Context, i int) error {
+		resAndSDKLR := matchedResAndSDKLRs[i]
 		lsStatus, err := s.lrManager.Update(ctx, resAndSDKLR.resLR, resAndSDKLR.sdkLR)
 		if err != nil {
 			return err
 		}
 		resAndSDKLR.resLR.SetStatus(lsStatus)
-	}
-	return nil
+		return nil
+	})
 }
 
 // findSDKListenersRulesOnLS returns the listenerRules configured on Listener.
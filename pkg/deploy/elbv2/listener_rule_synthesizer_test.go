@@ -0,0 +1,60 @@
+package elbv2
+
+import (
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/stretchr/testify/assert"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"testing"
+)
+
+func Test_matchResAndSDKListenerRules(t *testing.T) {
+	resLR := func(priority int64) *elbv2model.ListenerRule {
+		return &elbv2model.ListenerRule{
+			Spec: elbv2model.ListenerRuleSpec{
+				Priority: priority,
+			},
+		}
+	}
+	sdkLR := func(priority string) *elbv2sdk.Rule {
+		return &elbv2sdk.Rule{
+			Priority: awssdk.String(priority),
+		}
+	}
+	tests := []struct {
+		name                    string
+		resLRs                  []*elbv2model.ListenerRule
+		sdkLRs                  []*elbv2sdk.Rule
+		wantMatchedResAndSDKLRs []resAndSDKListenerRulePair
+		wantUnmatchedResLRs     []*elbv2model.ListenerRule
+		wantUnmatchedSDKLRs     []*elbv2sdk.Rule
+	}{
+		{
+			name:   "split rules grows",
+			resLRs: []*elbv2model.ListenerRule{resLR(1), resLR(2), resLR(3)},
+			sdkLRs: []*elbv2sdk.Rule{sdkLR("1"), sdkLR("2")},
+			wantMatchedResAndSDKLRs: []resAndSDKListenerRulePair{
+				{resLR: resLR(1), sdkLR: sdkLR("1")},
+				{resLR: resLR(2), sdkLR: sdkLR("2")},
+			},
+			wantUnmatchedResLRs: []*elbv2model.ListenerRule{resLR(3)},
+		},
+		{
+			name:   "split rules shrinks",
+			resLRs: []*elbv2model.ListenerRule{resLR(1)},
+			sdkLRs: []*elbv2sdk.Rule{sdkLR("1"), sdkLR("2"), sdkLR("3")},
+			wantMatchedResAndSDKLRs: []resAndSDKListenerRulePair{
+				{resLR: resLR(1), sdkLR: sdkLR("1")},
+			},
+			wantUnmatchedSDKLRs: []*elbv2sdk.Rule{sdkLR("2"), sdkLR("3")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotMatchedResAndSDKLRs, gotUnmatchedResLRs, gotUnmatchedSDKLRs := matchResAndSDKListenerRules(tt.resLRs, tt.sdkLRs)
+			assert.Equal(t, tt.wantMatchedResAndSDKLRs, gotMatchedResAndSDKLRs)
+			assert.Equal(t, tt.wantUnmatchedResLRs, gotUnmatchedResLRs)
+			assert.Equal(t, tt.wantUnmatchedSDKLRs, gotUnmatchedSDKLRs)
+		})
+	}
+}
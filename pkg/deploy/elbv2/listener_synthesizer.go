@@ -5,25 +5,33 @@ import (
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
 )
 
-func NewListenerSynthesizer(elbv2Client services.ELBV2, lsManager ListenerManager, logger logr.Logger, stack core.Stack) *listenerSynthesizer {
+func NewListenerSynthesizer(elbv2Client services.ELBV2, trackingProvider tracking.Provider, taggingManager TaggingManager,
+	lsManager ListenerManager, logger logr.Logger, stack core.Stack) *listenerSynthesizer {
 	return &listenerSynthesizer{
-		elbv2Client: elbv2Client,
-		lsManager:   lsManager,
-		logger:      logger,
-		stack:       stack,
+		elbv2Client:      elbv2Client,
+		trackingProvider: trackingProvider,
+		taggingManager:   taggingManager,
+		lsManager:        lsManager,
+		logger:           logger,
+		stack:            stack,
 	}
 }
 
 type listenerSynthesizer struct {
-	elbv2Client services.ELBV2
-	lsManager   ListenerManager
-	logger      logr.Logger
+	elbv2Client      services.ELBV2
+	trackingProvider tracking.Provider
+	taggingManager   TaggingManager
+	lsManager        ListenerManager
+	logger           logr.Logger
 
 	stack core.Stack
 }
@@ -35,12 +43,24 @@ func (s *listenerSynthesizer) Synthesize(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	existingLBARNs, err := s.findExistingLoadBalancerARNs(ctx, resLSsByLBARN)
+	if err != nil {
+		return err
+	}
 
 	for lbARN, resLSs := range resLSsByLBARN {
+		if existingLBARNs.Has(lbARN) {
+			continue
+		}
 		if err := s.synthesizeListenersOnLB(ctx, lbARN, resLSs); err != nil {
 			return err
 		}
 	}
+	for _, lbARN := range existingLBARNs.List() {
+		if err := s.synthesizeListenersOnExistingLB(ctx, lbARN, resLSsByLBARN[lbARN]); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -77,6 +97,86 @@ func (s *listenerSynthesizer) synthesizeListenersOnLB(ctx context.Context, lbARN
 	return nil
 }
 
+// synthesizeListenersOnExistingLB synthesizes listeners on LoadBalancer that isn't managed by controller.
+// only listeners tagged for stack are owned by us, other listeners on LoadBalancer are never altered.
+func (s *listenerSynthesizer) synthesizeListenersOnExistingLB(ctx context.Context, lbARN string, resLSs []*elbv2model.Listener) error {
+	sdkLSs, err := s.taggingManager.ListListeners(ctx, lbARN)
+	if err != nil {
+		return err
+	}
+	stackTagFilter := tracking.TagsAsTagFilter(s.trackingProvider.StackTags(s.stack))
+	var ownedSDKLSs []*elbv2sdk.Listener
+	unownedSDKLSPorts := sets.NewInt64()
+	for _, sdkLS := range sdkLSs {
+		if stackTagFilter.Matches(sdkLS.Tags) {
+			ownedSDKLSs = append(ownedSDKLSs, sdkLS.Listener)
+		} else {
+			unownedSDKLSPorts.Insert(awssdk.Int64Value(sdkLS.Listener.Port))
+		}
+	}
+	for _, resLS := range resLSs {
+		if unownedSDKLSPorts.Has(resLS.Spec.Port) {
+			return runtime.NewClassifiedError(runtime.ErrorClassInvalidConfig,
+				errors.Errorf("listener on port %v of loadBalancer %v isn't managed by controller", resLS.Spec.Port, lbARN))
+		}
+	}
+
+	matchedResAndSDKLSs, unmatchedResLSs, unmatchedSDKLSs := matchResAndSDKListeners(resLSs, ownedSDKLSs)
+	for _, sdkLS := range unmatchedSDKLSs {
+		if err := s.lsManager.Delete(ctx, sdkLS); err != nil {
+			return err
+		}
+	}
+	for _, resLS := range unmatchedResLSs {
+		lsStatus, err := s.lsManager.Create(ctx, resLS)
+		if err != nil {
+			return err
+		}
+		resLS.SetStatus(lsStatus)
+	}
+	for _, resAndSDKLS := range matchedResAndSDKLSs {
+		lsStatus, err := s.lsManager.Update(ctx, resAndSDKLS.resLS, resAndSDKLS.sdkLS)
+		if err != nil {
+			return err
+		}
+		resAndSDKLS.resLS.SetStatus(lsStatus)
+	}
+	return nil
+}
+
+// findExistingLoadBalancerARNs returns the ARNs of LoadBalancers that aren't managed by controller, but have listeners of stack.
+// besides the LoadBalancers in stack, LoadBalancers with listeners tagged for stack are included as well,
+// so that listeners are cleaned up once the LoadBalancer is no longer referenced by stack.
+func (s *listenerSynthesizer) findExistingLoadBalancerARNs(ctx context.Context, resLSsByLBARN map[string][]*elbv2model.Listener) (sets.String, error) {
+	var resLBs []*elbv2model.LoadBalancer
+	s.stack.ListResources(&resLBs)
+	existingLBARNs := sets.NewString()
+	for _, resLB := range resLBs {
+		if resLB.Spec.ExistingLoadBalancerARN == nil {
+			continue
+		}
+		lbARN, err := resLB.LoadBalancerARN().Resolve(ctx)
+		if err != nil {
+			return nil, err
+		}
+		existingLBARNs.Insert(lbARN)
+	}
+
+	stackTagFilter := tracking.TagsAsTagFilter(s.trackingProvider.StackTags(s.stack))
+	taggedLBARNs, err := s.taggingManager.ListListenerLoadBalancerARNs(ctx, stackTagFilter)
+	if err != nil {
+		return nil, err
+	}
+	for _, lbARN := range taggedLBARNs {
+		// listeners on LoadBalancers managed by controller are matched by port instead.
+		if _, managed := resLSsByLBARN[lbARN]; managed && !existingLBARNs.Has(lbARN) {
+			continue
+		}
+		existingLBARNs.Insert(lbARN)
+	}
+	return existingLBARNs, nil
+}
+
 // findSDKListenersOnLB returns the listeners configured on LoadBalancer.
 func (s *listenerSynthesizer) findSDKListenersOnLB(ctx context.Context, lbARN string) ([]*elbv2sdk.Listener, error) {
 	req := &elbv2sdk.DescribeListenersInput{
@@ -102,6 +202,11 @@ func matchResAndSDKListeners(resLSs []*elbv2model.Listener, sdkLSs []*elbv2sdk.L
 	for _, port := range resLSPorts.Intersection(sdkLSPorts).List() {
 		resLS := resLSByPort[port]
 		sdkLS := sdkLSByPort[port]
+		if isSDKListenerRequiresReplacement(sdkLS, resLS) {
+			unmatchedResLSs = append(unmatchedResLSs, resLS)
+			unmatchedSDKLSs = append(unmatchedSDKLSs, sdkLS)
+			continue
+		}
 		matchedResAndSDKLSs = append(matchedResAndSDKLSs, resAndSDKListenerPair{
 			resLS: resLS,
 			sdkLS: sdkLS,
@@ -116,6 +221,21 @@ func matchResAndSDKListeners(resLSs []*elbv2model.Listener, sdkLSs []*elbv2sdk.L
 	return matchedResAndSDKLSs, unmatchedResLSs, unmatchedSDKLSs
 }
 
+// isSDKListenerRequiresReplacement checks whether a sdk Listener requires replacement to fulfill a Listener resource.
+// the protocol of UDP listeners cannot be modified from or to other protocols, e.g. when a Service switches to mixed TCP and UDP protocol.
+func isSDKListenerRequiresReplacement(sdkLS *elbv2sdk.Listener, resLS *elbv2model.Listener) bool {
+	sdkProtocol := elbv2model.Protocol(awssdk.StringValue(sdkLS.Protocol))
+	if sdkProtocol == resLS.Spec.Protocol {
+		return false
+	}
+	return isUDPListenerProtocol(sdkProtocol) || isUDPListenerProtocol(resLS.Spec.Protocol)
+}
+
+// isUDPListenerProtocol checks whether listeners of protocol receive UDP traffic.
+func isUDPListenerProtocol(protocol elbv2model.Protocol) bool {
+	return protocol == elbv2model.ProtocolUDP || protocol == elbv2model.ProtocolTCP_UDP
+}
+
 func mapResListenerByPort(resLSs []*elbv2model.Listener) map[int64]*elbv2model.Listener {
 	resLSByPort := make(map[int64]*elbv2model.Listener, len(resLSs))
 	for _, ls := range resLSs {
@@ -0,0 +1,218 @@
+package elbv2
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	rgtsdk "github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	coremodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_listenerSynthesizer_synthesizeListenersOnExistingLB(t *testing.T) {
+	lbARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-alb/50dc6c495c0c9188"
+	ownedLSARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener/app/my-alb/50dc6c495c0c9188/f2f7dc8efc522ab2"
+	unownedLSARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener/app/my-alb/50dc6c495c0c9188/0467ef3c8400ae65"
+	type deleteListenerCall struct {
+		req *elbv2sdk.DeleteListenerInput
+	}
+	tests := []struct {
+		name                string
+		resLSPorts          []int64
+		deleteListenerCalls []deleteListenerCall
+		wantErr             error
+	}{
+		{
+			name:       "only owned listeners are deleted",
+			resLSPorts: nil,
+			deleteListenerCalls: []deleteListenerCall{
+				{
+					req: &elbv2sdk.DeleteListenerInput{
+						ListenerArn: awssdk.String(ownedLSARN),
+					},
+				},
+			},
+		},
+		{
+			name:       "listener collides with listener not managed by controller",
+			resLSPorts: []int64{443},
+			wantErr:    errors.New("listener on port 443 of loadBalancer arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-alb/50dc6c495c0c9188 isn't managed by controller"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			elbv2Client := mock_services.NewMockELBV2(ctrl)
+			elbv2Client.EXPECT().DescribeListenersAsList(gomock.Any(), &elbv2sdk.DescribeListenersInput{
+				LoadBalancerArn: awssdk.String(lbARN),
+			}).Return([]*elbv2sdk.Listener{
+				{ListenerArn: awssdk.String(ownedLSARN), Port: awssdk.Int64(80)},
+				{ListenerArn: awssdk.String(unownedLSARN), Port: awssdk.Int64(443)},
+			}, nil)
+			elbv2Client.EXPECT().DescribeTagsWithContext(gomock.Any(), &elbv2sdk.DescribeTagsInput{
+				ResourceArns: awssdk.StringSlice([]string{ownedLSARN, unownedLSARN}),
+			}).Return(&elbv2sdk.DescribeTagsOutput{
+				TagDescriptions: []*elbv2sdk.TagDescription{
+					{
+						ResourceArn: awssdk.String(ownedLSARN),
+						Tags: []*elbv2sdk.Tag{
+							{Key: awssdk.String("elbv2.k8s.aws/cluster"), Value: awssdk.String("cluster-name")},
+							{Key: awssdk.String("ingress.k8s.aws/stack"), Value: awssdk.String("namespace/name")},
+							{Key: awssdk.String("ingress.k8s.aws/resource"), Value: awssdk.String("80")},
+						},
+					},
+					{
+						ResourceArn: awssdk.String(unownedLSARN),
+						Tags: []*elbv2sdk.Tag{
+							{Key: awssdk.String("team"), Value: awssdk.String("infra")},
+						},
+					},
+				},
+			}, nil)
+			for _, call := range tt.deleteListenerCalls {
+				elbv2Client.EXPECT().DeleteListenerWithContext(gomock.Any(), call.req).Return(&elbv2sdk.DeleteListenerOutput{}, nil)
+			}
+
+			trackingProvider := tracking.NewDefaultProvider("ingress.k8s.aws", "cluster-name")
+			stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "namespace", Name: "name"})
+			var resLSs []*elbv2model.Listener
+			for _, port := range tt.resLSPorts {
+				resLSs = append(resLSs, elbv2model.NewListener(stack, "443", elbv2model.ListenerSpec{
+					LoadBalancerARN: coremodel.LiteralStringToken(lbARN),
+					Port:            port,
+					Protocol:        elbv2model.ProtocolHTTPS,
+				}))
+			}
+			s := NewListenerSynthesizer(elbv2Client, trackingProvider, NewDefaultTaggingManager(elbv2Client, nil, &log.NullLogger{}),
+				NewDefaultListenerManager(elbv2Client, trackingProvider, &log.NullLogger{}), &log.NullLogger{}, stack)
+			err := s.synthesizeListenersOnExistingLB(context.Background(), lbARN, resLSs)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_listenerSynthesizer_Synthesize_existingLBNoLongerInStack(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	lbARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-alb/50dc6c495c0c9188"
+	ownedLSARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener/app/my-alb/50dc6c495c0c9188/f2f7dc8efc522ab2"
+	stackTags := []*rgtsdk.Tag{
+		{Key: awssdk.String("elbv2.k8s.aws/cluster"), Value: awssdk.String("cluster-name")},
+		{Key: awssdk.String("ingress.k8s.aws/stack"), Value: awssdk.String("namespace/name")},
+	}
+
+	elbv2Client := mock_services.NewMockELBV2(ctrl)
+	rgtClient := mock_services.NewMockRGT(ctrl)
+	// the load-balancer-arn annotation is removed, so the listeners on LoadBalancer are only found by the stack tags.
+	rgtClient.EXPECT().GetResourcesAsList(gomock.Any(), gomock.Any()).Return([]*rgtsdk.ResourceTagMapping{
+		{ResourceARN: awssdk.String(ownedLSARN), Tags: stackTags},
+	}, nil)
+	elbv2Client.EXPECT().DescribeListenersAsList(gomock.Any(), &elbv2sdk.DescribeListenersInput{
+		LoadBalancerArn: awssdk.String(lbARN),
+	}).Return([]*elbv2sdk.Listener{
+		{ListenerArn: awssdk.String(ownedLSARN), Port: awssdk.Int64(80)},
+	}, nil)
+	elbv2Client.EXPECT().DescribeTagsWithContext(gomock.Any(), &elbv2sdk.DescribeTagsInput{
+		ResourceArns: awssdk.StringSlice([]string{ownedLSARN}),
+	}).Return(&elbv2sdk.DescribeTagsOutput{
+		TagDescriptions: []*elbv2sdk.TagDescription{
+			{
+				ResourceArn: awssdk.String(ownedLSARN),
+				Tags: []*elbv2sdk.Tag{
+					{Key: awssdk.String("elbv2.k8s.aws/cluster"), Value: awssdk.String("cluster-name")},
+					{Key: awssdk.String("ingress.k8s.aws/stack"), Value: awssdk.String("namespace/name")},
+				},
+			},
+		},
+	}, nil)
+	elbv2Client.EXPECT().DeleteListenerWithContext(gomock.Any(), &elbv2sdk.DeleteListenerInput{
+		ListenerArn: awssdk.String(ownedLSARN),
+	}).Return(&elbv2sdk.DeleteListenerOutput{}, nil)
+
+	trackingProvider := tracking.NewDefaultProvider("ingress.k8s.aws", "cluster-name")
+	stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "namespace", Name: "name"})
+	s := NewListenerSynthesizer(elbv2Client, trackingProvider, NewDefaultTaggingManager(elbv2Client, rgtClient, &log.NullLogger{}),
+		NewDefaultListenerManager(elbv2Client, trackingProvider, &log.NullLogger{}), &log.NullLogger{}, stack)
+	assert.NoError(t, s.Synthesize(context.Background()))
+}
+
+func Test_matchResAndSDKListeners(t *testing.T) {
+	lbARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/net/my-nlb/50dc6c495c0c9188"
+	stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "namespace", Name: "name"})
+	resLS80 := elbv2model.NewListener(stack, "80", elbv2model.ListenerSpec{LoadBalancerARN: coremodel.LiteralStringToken(lbARN), Port: 80, Protocol: elbv2model.ProtocolTCP_UDP})
+	resLS443 := elbv2model.NewListener(stack, "443", elbv2model.ListenerSpec{LoadBalancerARN: coremodel.LiteralStringToken(lbARN), Port: 443, Protocol: elbv2model.ProtocolTLS})
+	resLS8080 := elbv2model.NewListener(stack, "8080", elbv2model.ListenerSpec{LoadBalancerARN: coremodel.LiteralStringToken(lbARN), Port: 8080, Protocol: elbv2model.ProtocolTCP})
+	sdkLS80 := &elbv2sdk.Listener{Port: awssdk.Int64(80), Protocol: awssdk.String("TCP")}
+	sdkLS443 := &elbv2sdk.Listener{Port: awssdk.Int64(443), Protocol: awssdk.String("TCP")}
+	sdkLS9090 := &elbv2sdk.Listener{Port: awssdk.Int64(9090), Protocol: awssdk.String("UDP")}
+
+	matchedResAndSDKLSs, unmatchedResLSs, unmatchedSDKLSs := matchResAndSDKListeners(
+		[]*elbv2model.Listener{resLS80, resLS443, resLS8080},
+		[]*elbv2sdk.Listener{sdkLS80, sdkLS443, sdkLS9090})
+	assert.Equal(t, []resAndSDKListenerPair{{resLS: resLS443, sdkLS: sdkLS443}}, matchedResAndSDKLSs)
+	assert.Equal(t, []*elbv2model.Listener{resLS80, resLS8080}, unmatchedResLSs)
+	assert.Equal(t, []*elbv2sdk.Listener{sdkLS80, sdkLS9090}, unmatchedSDKLSs)
+}
+
+func Test_isSDKListenerRequiresReplacement(t *testing.T) {
+	lbARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/net/my-nlb/50dc6c495c0c9188"
+	tests := []struct {
+		name        string
+		sdkProtocol string
+		resProtocol elbv2model.Protocol
+		want        bool
+	}{
+		{
+			name:        "same protocol",
+			sdkProtocol: "UDP",
+			resProtocol: elbv2model.ProtocolUDP,
+			want:        false,
+		},
+		{
+			name:        "TCP to TLS",
+			sdkProtocol: "TCP",
+			resProtocol: elbv2model.ProtocolTLS,
+			want:        false,
+		},
+		{
+			name:        "TCP to TCP_UDP",
+			sdkProtocol: "TCP",
+			resProtocol: elbv2model.ProtocolTCP_UDP,
+			want:        true,
+		},
+		{
+			name:        "UDP to TCP_UDP",
+			sdkProtocol: "UDP",
+			resProtocol: elbv2model.ProtocolTCP_UDP,
+			want:        true,
+		},
+		{
+			name:        "TCP_UDP to TCP",
+			sdkProtocol: "TCP_UDP",
+			resProtocol: elbv2model.ProtocolTCP,
+			want:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "namespace", Name: "name"})
+			resLS := elbv2model.NewListener(stack, "80", elbv2model.ListenerSpec{LoadBalancerARN: coremodel.LiteralStringToken(lbARN), Port: 80, Protocol: tt.resProtocol})
+			sdkLS := &elbv2sdk.Listener{Port: awssdk.Int64(80), Protocol: awssdk.String(tt.sdkProtocol)}
+			assert.Equal(t, tt.want, isSDKListenerRequiresReplacement(sdkLS, resLS))
+		})
+	}
+}
@@ -129,6 +129,46 @@ func buildSDKForwardActionConfig(modelCfg elbv2model.ForwardActionConfig) (*elbv
 	return sdkObj, nil
 }
 
+// preserveExternallyManagedWeights will retain the current target group weights of sdkActions within desiredActions,
+// for forward actions whose weights are managed externally and still forward to the same target groups.
+// desiredActions must be built from modelActions in the same order.
+func preserveExternallyManagedWeights(modelActions []elbv2model.Action, desiredActions []*elbv2sdk.Action, sdkActions []*elbv2sdk.Action) {
+	sdkActionByOrder := make(map[int64]*elbv2sdk.Action, len(sdkActions))
+	for _, sdkAction := range sdkActions {
+		sdkActionByOrder[awssdk.Int64Value(sdkAction.Order)] = sdkAction
+	}
+	for index, modelAction := range modelActions {
+		if modelAction.ForwardConfig == nil || !modelAction.ForwardConfig.ExternallyManagedWeights {
+			continue
+		}
+		desiredAction := desiredActions[index]
+		sdkAction, exists := sdkActionByOrder[awssdk.Int64Value(desiredAction.Order)]
+		if !exists || awssdk.StringValue(sdkAction.Type) != elbv2sdk.ActionTypeEnumForward || sdkAction.ForwardConfig == nil {
+			continue
+		}
+		sdkWeightByTGARN := make(map[string]*int64, len(sdkAction.ForwardConfig.TargetGroups))
+		for _, sdkTGT := range sdkAction.ForwardConfig.TargetGroups {
+			sdkWeightByTGARN[awssdk.StringValue(sdkTGT.TargetGroupArn)] = sdkTGT.Weight
+		}
+		if len(sdkWeightByTGARN) != len(desiredAction.ForwardConfig.TargetGroups) {
+			continue
+		}
+		sameTargetGroups := true
+		for _, desiredTGT := range desiredAction.ForwardConfig.TargetGroups {
+			if _, exists := sdkWeightByTGARN[awssdk.StringValue(desiredTGT.TargetGroupArn)]; !exists {
+				sameTargetGroups = false
+				break
+			}
+		}
+		if !sameTargetGroups {
+			continue
+		}
+		for _, desiredTGT := range desiredAction.ForwardConfig.TargetGroups {
+			desiredTGT.Weight = sdkWeightByTGARN[awssdk.StringValue(desiredTGT.TargetGroupArn)]
+		}
+	}
+}
+
 func buildSDKRuleConditions(modelConditions []elbv2model.RuleCondition) []*elbv2sdk.RuleCondition {
 	var sdkConditions []*elbv2sdk.RuleCondition
 	if len(modelConditions) != 0 {
@@ -1,9 +1,13 @@
 package elbv2
 
 import (
+	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"testing"
 )
 
@@ -45,3 +49,80 @@ func Test_isListenerNotFoundError(t *testing.T) {
 		})
 	}
 }
+
+func Test_preserveExternallyManagedWeights(t *testing.T) {
+	buildModelActions := func(externallyManagedWeights bool) []elbv2model.Action {
+		return []elbv2model.Action{
+			{
+				Type: elbv2model.ActionTypeForward,
+				ForwardConfig: &elbv2model.ForwardActionConfig{
+					TargetGroups: []elbv2model.TargetGroupTuple{
+						{TargetGroupARN: core.LiteralStringToken("tg-blue"), Weight: awssdk.Int64(100)},
+						{TargetGroupARN: core.LiteralStringToken("tg-green"), Weight: awssdk.Int64(0)},
+					},
+					ExternallyManagedWeights: externallyManagedWeights,
+				},
+			},
+		}
+	}
+	buildSDKForwardActions := func(weightByTGARN ...interface{}) []*elbv2sdk.Action {
+		var tgTuples []*elbv2sdk.TargetGroupTuple
+		for i := 0; i < len(weightByTGARN); i += 2 {
+			tgTuples = append(tgTuples, &elbv2sdk.TargetGroupTuple{
+				TargetGroupArn: awssdk.String(weightByTGARN[i].(string)),
+				Weight:         awssdk.Int64(weightByTGARN[i+1].(int64)),
+			})
+		}
+		return []*elbv2sdk.Action{
+			{
+				Type:          awssdk.String("forward"),
+				Order:         awssdk.Int64(1),
+				ForwardConfig: &elbv2sdk.ForwardActionConfig{TargetGroups: tgTuples},
+			},
+		}
+	}
+	tests := []struct {
+		name         string
+		modelActions []elbv2model.Action
+		sdkActions   []*elbv2sdk.Action
+		want         []*elbv2sdk.Action
+	}{
+		{
+			name:         "weights are managed by controller",
+			modelActions: buildModelActions(false),
+			sdkActions:   buildSDKForwardActions("tg-blue", int64(10), "tg-green", int64(90)),
+			want:         buildSDKForwardActions("tg-blue", int64(100), "tg-green", int64(0)),
+		},
+		{
+			name:         "weights are managed externally",
+			modelActions: buildModelActions(true),
+			sdkActions:   buildSDKForwardActions("tg-blue", int64(10), "tg-green", int64(90)),
+			want:         buildSDKForwardActions("tg-blue", int64(10), "tg-green", int64(90)),
+		},
+		{
+			name:         "weights are managed externally but target groups changed",
+			modelActions: buildModelActions(true),
+			sdkActions:   buildSDKForwardActions("tg-blue", int64(10), "tg-other", int64(90)),
+			want:         buildSDKForwardActions("tg-blue", int64(100), "tg-green", int64(0)),
+		},
+		{
+			name:         "weights are managed externally but action is not forward",
+			modelActions: buildModelActions(true),
+			sdkActions: []*elbv2sdk.Action{
+				{
+					Type:  awssdk.String("fixed-response"),
+					Order: awssdk.Int64(1),
+				},
+			},
+			want: buildSDKForwardActions("tg-blue", int64(100), "tg-green", int64(0)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desiredActions, err := buildSDKActions(tt.modelActions)
+			assert.NoError(t, err)
+			preserveExternallyManagedWeights(tt.modelActions, desiredActions, tt.sdkActions)
+			assert.Equal(t, tt.want, desiredActions)
+		})
+	}
+}
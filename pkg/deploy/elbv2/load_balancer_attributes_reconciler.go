@@ -39,6 +39,20 @@ func (r *defaultLoadBalancerAttributeReconciler) Reconcile(ctx context.Context,
 	if err != nil {
 		return err
 	}
+	var defaultAttrs map[string]string
+	switch resLB.Spec.Type {
+	case elbv2model.LoadBalancerTypeApplication:
+		defaultAttrs = defaultApplicationLoadBalancerAttributes
+	case elbv2model.LoadBalancerTypeNetwork:
+		defaultAttrs = defaultNetworkLoadBalancerAttributes
+	}
+	for attrKey, attrValue := range defaultAttrs {
+		_, desired := desiredAttrs[attrKey]
+		_, reported := currentAttrs[attrKey]
+		if !desired && reported {
+			desiredAttrs[attrKey] = attrValue
+		}
+	}
 
 	attributesToUpdate, _ := algorithm.DiffStringMap(desiredAttrs, currentAttrs)
 	if len(attributesToUpdate) > 0 {
@@ -69,6 +83,20 @@ func (r *defaultLoadBalancerAttributeReconciler) Reconcile(ctx context.Context,
 	return nil
 }
 
+// defaultApplicationLoadBalancerAttributes are the AWS default values of application LoadBalancer attributes managed by controller.
+// they are used to revert drift when these attributes are not explicitly specified, and only if they are reported by AWS.
+var defaultApplicationLoadBalancerAttributes = map[string]string{
+	elbv2model.LoadBalancerAttributeKeyDesyncMitigationMode:   "defensive",
+	elbv2model.LoadBalancerAttributeKeyClientKeepAliveSeconds: "3600",
+	elbv2model.LoadBalancerAttributeKeyHTTP2Enabled:           "true",
+}
+
+// defaultNetworkLoadBalancerAttributes are the AWS default values of network LoadBalancer attributes managed by controller.
+// they are used to revert drift when these attributes are not explicitly specified, and only if they are reported by AWS.
+var defaultNetworkLoadBalancerAttributes = map[string]string{
+	elbv2model.LoadBalancerAttributeKeyDNSRecordClientRoutingPolicy: elbv2model.ClientRoutingPolicyAnyAvailabilityZone,
+}
+
 func (r *defaultLoadBalancerAttributeReconciler) getDesiredLoadBalancerAttributes(ctx context.Context, resLB *elbv2model.LoadBalancer) map[string]string {
 	lbAttributes := make(map[string]string, len(resLB.Spec.LoadBalancerAttributes))
 	for _, attr := range resLB.Spec.LoadBalancerAttributes {
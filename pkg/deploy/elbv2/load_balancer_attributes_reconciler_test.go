@@ -149,6 +149,134 @@ func Test_defaultLoadBalancerAttributeReconciler_updateSDKLoadBalancerWithAttrib
 				},
 			},
 		},
+		{
+			name: "drifted default attributes of application loadBalancer should be reverted",
+			fields: fields{
+				describeLoadBalancerAttributesWithContextCalls: []describeLoadBalancerAttributesWithContextCall{
+					{
+						req: &elbv2sdk.DescribeLoadBalancerAttributesInput{
+							LoadBalancerArn: awssdk.String("my-arn"),
+						},
+						resp: &elbv2sdk.DescribeLoadBalancerAttributesOutput{
+							Attributes: []*elbv2sdk.LoadBalancerAttribute{
+								{
+									Key:   awssdk.String("idle_timeout.timeout_seconds"),
+									Value: awssdk.String("60"),
+								},
+								{
+									Key:   awssdk.String("routing.http.desync_mitigation_mode"),
+									Value: awssdk.String("monitor"),
+								},
+								{
+									Key:   awssdk.String("client_keep_alive.seconds"),
+									Value: awssdk.String("3600"),
+								},
+								{
+									Key:   awssdk.String("routing.http2.enabled"),
+									Value: awssdk.String("false"),
+								},
+							},
+						},
+					},
+				},
+				modifyLoadBalancerAttributesWithContextCalls: []modifyLoadBalancerAttributesWithContextCall{
+					{
+						req: &elbv2sdk.ModifyLoadBalancerAttributesInput{
+							LoadBalancerArn: awssdk.String("my-arn"),
+							Attributes: []*elbv2sdk.LoadBalancerAttribute{
+								{
+									Key:   awssdk.String("routing.http.desync_mitigation_mode"),
+									Value: awssdk.String("strictest"),
+								},
+								{
+									Key:   awssdk.String("routing.http2.enabled"),
+									Value: awssdk.String("true"),
+								},
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				sdkLB: LoadBalancerWithTags{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						LoadBalancerArn: awssdk.String("my-arn"),
+					},
+				},
+				resLB: &elbv2model.LoadBalancer{
+					ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::LoadBalancer", "id-1"),
+					Spec: elbv2model.LoadBalancerSpec{
+						Type: elbv2model.LoadBalancerTypeApplication,
+						LoadBalancerAttributes: []elbv2model.LoadBalancerAttribute{
+							{
+								Key:   "idle_timeout.timeout_seconds",
+								Value: "60",
+							},
+							{
+								Key:   "routing.http.desync_mitigation_mode",
+								Value: "strictest",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "drifted client routing policy of network loadBalancer should be reverted",
+			fields: fields{
+				describeLoadBalancerAttributesWithContextCalls: []describeLoadBalancerAttributesWithContextCall{
+					{
+						req: &elbv2sdk.DescribeLoadBalancerAttributesInput{
+							LoadBalancerArn: awssdk.String("my-arn"),
+						},
+						resp: &elbv2sdk.DescribeLoadBalancerAttributesOutput{
+							Attributes: []*elbv2sdk.LoadBalancerAttribute{
+								{
+									Key:   awssdk.String("load_balancing.cross_zone.enabled"),
+									Value: awssdk.String("false"),
+								},
+								{
+									Key:   awssdk.String("dns_record.client_routing_policy"),
+									Value: awssdk.String("availability_zone_affinity"),
+								},
+							},
+						},
+					},
+				},
+				modifyLoadBalancerAttributesWithContextCalls: []modifyLoadBalancerAttributesWithContextCall{
+					{
+						req: &elbv2sdk.ModifyLoadBalancerAttributesInput{
+							LoadBalancerArn: awssdk.String("my-arn"),
+							Attributes: []*elbv2sdk.LoadBalancerAttribute{
+								{
+									Key:   awssdk.String("dns_record.client_routing_policy"),
+									Value: awssdk.String("any_availability_zone"),
+								},
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				sdkLB: LoadBalancerWithTags{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						LoadBalancerArn: awssdk.String("my-arn"),
+					},
+				},
+				resLB: &elbv2model.LoadBalancer{
+					ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::LoadBalancer", "id-1"),
+					Spec: elbv2model.LoadBalancerSpec{
+						Type: elbv2model.LoadBalancerTypeNetwork,
+						LoadBalancerAttributes: []elbv2model.LoadBalancerAttribute{
+							{
+								Key:   "load_balancing.cross_zone.enabled",
+								Value: "false",
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
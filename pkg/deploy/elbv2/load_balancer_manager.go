@@ -12,6 +12,7 @@ import (
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
 	coremodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
 )
 
 // LoadBalancerManager is responsible for create/update/delete LoadBalancer resources.
@@ -23,13 +24,35 @@ type LoadBalancerManager interface {
 	Delete(ctx context.Context, sdkLB LoadBalancerWithTags) error
 }
 
+// reasonLoadBalancerReplacementRequired is the terminal reason of LoadBalancerReplacementRequiredError.
+const reasonLoadBalancerReplacementRequired = "LoadBalancerReplacementRequired"
+
+// LoadBalancerReplacementRequiredError is returned when a change to an existing LoadBalancer cannot be applied in place.
+// It won't resolve until the change is reverted, or the LoadBalancer is recreated.
+// It's wrapped as terminal error unless the change may revert itself, e.g. when subnets are auto-discovered.
+type LoadBalancerReplacementRequiredError struct {
+	// LoadBalancerARN is the ARN of LoadBalancer.
+	LoadBalancerARN string
+	// Reason describes the change that cannot be applied in place.
+	Reason string
+}
+
+func (e *LoadBalancerReplacementRequiredError) Error() string {
+	return fmt.Sprintf("%v, recreate the loadBalancer instead", e.Reason)
+}
+
+func (e *LoadBalancerReplacementRequiredError) ErrorClass() runtime.ErrorClass {
+	return runtime.ErrorClassInvalidConfig
+}
+
 // NewDefaultLoadBalancerManager constructs new defaultLoadBalancerManager.
 func NewDefaultLoadBalancerManager(elbv2Client services.ELBV2, trackingProvider tracking.Provider,
-	taggingManager TaggingManager, logger logr.Logger) *defaultLoadBalancerManager {
+	taggingManager TaggingManager, warmPool LoadBalancerWarmPool, logger logr.Logger) *defaultLoadBalancerManager {
 	return &defaultLoadBalancerManager{
 		elbv2Client:          elbv2Client,
 		trackingProvider:     trackingProvider,
 		taggingManager:       taggingManager,
+		warmPool:             warmPool,
 		attributesReconciler: NewDefaultLoadBalancerAttributeReconciler(elbv2Client, logger),
 		logger:               logger,
 	}
@@ -43,6 +66,8 @@ type defaultLoadBalancerManager struct {
 	trackingProvider     tracking.Provider
 	taggingManager       TaggingManager
 	attributesReconciler LoadBalancerAttributeReconciler
+	// warmPool is optional, LoadBalancers are always created if it's nil.
+	warmPool LoadBalancerWarmPool
 
 	logger logr.Logger
 }
@@ -53,6 +78,15 @@ func (m *defaultLoadBalancerManager) Create(ctx context.Context, resLB *elbv2mod
 		return elbv2model.LoadBalancerStatus{}, err
 	}
 	lbTags := m.trackingProvider.ResourceTags(resLB.Stack(), resLB, resLB.Spec.Tags)
+	if m.warmPool != nil {
+		sdkLB, claimed, err := m.warmPool.Claim(ctx, resLB, lbTags)
+		if err != nil {
+			return elbv2model.LoadBalancerStatus{}, err
+		}
+		if claimed {
+			return m.Update(ctx, resLB, sdkLB)
+		}
+	}
 	req.Tags = convertTagsToSDKTags(lbTags)
 
 	m.logger.Info("creating loadBalancer",
@@ -156,10 +190,26 @@ func (m *defaultLoadBalancerManager) updateSDKLoadBalancerWithSubnetMappings(ctx
 	if desiredSubnets.Equal(currentSubnets) {
 		return nil
 	}
+	// subnets can be added to network loadBalancers, but cannot be removed from them.
+	if removedSubnets := currentSubnets.Difference(desiredSubnets); resLB.Spec.Type == elbv2model.LoadBalancerTypeNetwork && removedSubnets.Len() != 0 {
+		replacementRequiredErr := &LoadBalancerReplacementRequiredError{
+			LoadBalancerARN: awssdk.StringValue(sdkLB.LoadBalancer.LoadBalancerArn),
+			Reason:          fmt.Sprintf("subnets %v cannot be removed from network loadBalancer", removedSubnets.List()),
+		}
+		// auto-discovered subnets may change between reconciles, so the removal is retried until discovery settles.
+		if resLB.Spec.SubnetsAutoDiscovered {
+			return replacementRequiredErr
+		}
+		return runtime.NewTerminalError(reasonLoadBalancerReplacementRequired, replacementRequiredErr)
+	}
 
+	sdkSubnetMappings, err := buildSDKSubnetMappings(resLB.Spec.SubnetMappings)
+	if err != nil {
+		return err
+	}
 	req := &elbv2sdk.SetSubnetsInput{
 		LoadBalancerArn: sdkLB.LoadBalancer.LoadBalancerArn,
-		SubnetMappings:  buildSDKSubnetMappings(resLB.Spec.SubnetMappings),
+		SubnetMappings:  sdkSubnetMappings,
 	}
 	changeDesc := fmt.Sprintf("%v => %v", currentSubnets.List(), desiredSubnets.List())
 	m.logger.Info("modifying loadBalancer subnetMappings",
@@ -188,6 +238,12 @@ func (m *defaultLoadBalancerManager) updateSDKLoadBalancerWithSecurityGroups(ctx
 	if desiredSecurityGroups.Equal(currentSecurityGroups) {
 		return nil
 	}
+	if resLB.Spec.Type == elbv2model.LoadBalancerTypeNetwork && len(currentSecurityGroups) == 0 {
+		return runtime.NewTerminalError(reasonLoadBalancerReplacementRequired, &LoadBalancerReplacementRequiredError{
+			LoadBalancerARN: awssdk.StringValue(sdkLB.LoadBalancer.LoadBalancerArn),
+			Reason:          "securityGroups cannot be added to network loadBalancer created without securityGroups unless a replacement strategy is specified",
+		})
+	}
 
 	req := &elbv2sdk.SetSecurityGroupsInput{
 		LoadBalancerArn: sdkLB.LoadBalancer.LoadBalancerArn,
@@ -221,7 +277,8 @@ func (m *defaultLoadBalancerManager) updateSDKLoadBalancerWithTags(ctx context.C
 	desiredLBTags := m.trackingProvider.ResourceTags(resLB.Stack(), resLB, resLB.Spec.Tags)
 	return m.taggingManager.ReconcileTags(ctx, awssdk.StringValue(sdkLB.LoadBalancer.LoadBalancerArn), desiredLBTags,
 		WithCurrentTags(sdkLB.Tags),
-		WithIgnoredTagKeys(m.trackingProvider.LegacyTagKeys()))
+		WithIgnoredTagKeys(m.trackingProvider.LegacyTagKeys()),
+		WithDeferredTagging())
 }
 
 func buildSDKCreateLoadBalancerInput(lbSpec elbv2model.LoadBalancerSpec) (*elbv2sdk.CreateLoadBalancerInput, error) {
@@ -241,7 +298,11 @@ func buildSDKCreateLoadBalancerInput(lbSpec elbv2model.LoadBalancerSpec) (*elbv2
 		sdkObj.IpAddressType = nil
 	}
 
-	sdkObj.SubnetMappings = buildSDKSubnetMappings(lbSpec.SubnetMappings)
+	if sdkSubnetMappings, err := buildSDKSubnetMappings(lbSpec.SubnetMappings); err != nil {
+		return nil, err
+	} else {
+		sdkObj.SubnetMappings = sdkSubnetMappings
+	}
 	if sdkSecurityGroups, err := buildSDKSecurityGroups(lbSpec.SecurityGroups); err != nil {
 		return nil, err
 	} else {
@@ -252,15 +313,19 @@ func buildSDKCreateLoadBalancerInput(lbSpec elbv2model.LoadBalancerSpec) (*elbv2
 	return sdkObj, nil
 }
 
-func buildSDKSubnetMappings(modelSubnetMappings []elbv2model.SubnetMapping) []*elbv2sdk.SubnetMapping {
+func buildSDKSubnetMappings(modelSubnetMappings []elbv2model.SubnetMapping) ([]*elbv2sdk.SubnetMapping, error) {
 	var sdkSubnetMappings []*elbv2sdk.SubnetMapping
 	if len(modelSubnetMappings) != 0 {
 		sdkSubnetMappings = make([]*elbv2sdk.SubnetMapping, 0, len(modelSubnetMappings))
 		for _, modelSubnetMapping := range modelSubnetMappings {
-			sdkSubnetMappings = append(sdkSubnetMappings, buildSDKSubnetMapping(modelSubnetMapping))
+			sdkSubnetMapping, err := buildSDKSubnetMapping(modelSubnetMapping)
+			if err != nil {
+				return nil, err
+			}
+			sdkSubnetMappings = append(sdkSubnetMappings, sdkSubnetMapping)
 		}
 	}
-	return sdkSubnetMappings
+	return sdkSubnetMappings, nil
 }
 
 func buildSDKSecurityGroups(modelSecurityGroups []coremodel.StringToken) ([]*string, error) {
@@ -279,17 +344,34 @@ func buildSDKSecurityGroups(modelSecurityGroups []coremodel.StringToken) ([]*str
 	return sdkSecurityGroups, nil
 }
 
-func buildSDKSubnetMapping(modelSubnetMapping elbv2model.SubnetMapping) *elbv2sdk.SubnetMapping {
+func buildSDKSubnetMapping(modelSubnetMapping elbv2model.SubnetMapping) (*elbv2sdk.SubnetMapping, error) {
+	var allocationID *string
+	if modelSubnetMapping.AllocationID != nil {
+		token, err := modelSubnetMapping.AllocationID.Resolve(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		allocationID = awssdk.String(token)
+	}
+	var privateIPv4Address *string
+	if modelSubnetMapping.PrivateIPv4Address != nil {
+		token, err := modelSubnetMapping.PrivateIPv4Address.Resolve(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		privateIPv4Address = awssdk.String(token)
+	}
 	return &elbv2sdk.SubnetMapping{
-		AllocationId:       modelSubnetMapping.AllocationID,
-		PrivateIPv4Address: modelSubnetMapping.PrivateIPv4Address,
+		AllocationId:       allocationID,
+		PrivateIPv4Address: privateIPv4Address,
 		SubnetId:           awssdk.String(modelSubnetMapping.SubnetID),
-	}
+	}, nil
 }
 
 func buildResLoadBalancerStatus(sdkLB LoadBalancerWithTags) elbv2model.LoadBalancerStatus {
 	return elbv2model.LoadBalancerStatus{
-		LoadBalancerARN: awssdk.StringValue(sdkLB.LoadBalancer.LoadBalancerArn),
-		DNSName:         awssdk.StringValue(sdkLB.LoadBalancer.DNSName),
+		LoadBalancerARN:       awssdk.StringValue(sdkLB.LoadBalancer.LoadBalancerArn),
+		DNSName:               awssdk.StringValue(sdkLB.LoadBalancer.DNSName),
+		CanonicalHostedZoneID: awssdk.StringValue(sdkLB.LoadBalancer.CanonicalHostedZoneId),
 	}
 }
@@ -5,9 +5,13 @@ import (
 	"errors"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
 	coremodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
 )
 
@@ -181,7 +185,8 @@ func Test_buildSDKSubnetMappings(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := buildSDKSubnetMappings(tt.args.modelSubnetMappings)
+			got, err := buildSDKSubnetMappings(tt.args.modelSubnetMappings)
+			assert.NoError(t, err)
 			assert.Equal(t, tt.want, got)
 		})
 	}
@@ -243,8 +248,8 @@ func Test_buildSDKSubnetMapping(t *testing.T) {
 			name: "stand case",
 			args: args{
 				modelSubnetMapping: elbv2model.SubnetMapping{
-					AllocationID:       awssdk.String("some-id"),
-					PrivateIPv4Address: awssdk.String("192.168.100.0"),
+					AllocationID:       coremodel.LiteralStringToken("some-id"),
+					PrivateIPv4Address: coremodel.LiteralStringToken("192.168.100.0"),
 					SubnetID:           "subnet-abc",
 				},
 			},
@@ -268,7 +273,8 @@ func Test_buildSDKSubnetMapping(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := buildSDKSubnetMapping(tt.args.modelSubnetMapping)
+			got, err := buildSDKSubnetMapping(tt.args.modelSubnetMapping)
+			assert.NoError(t, err)
 			assert.Equal(t, tt.want, got)
 		})
 	}
@@ -410,3 +416,224 @@ func Test_defaultLoadBalancerManager_checkSDKLoadBalancerWithCOIPv4Pool(t *testi
 		})
 	}
 }
+
+func Test_defaultLoadBalancerManager_updateSDKLoadBalancerWithSubnetMappings(t *testing.T) {
+	type setSubnetsWithContextCall struct {
+		req  *elbv2sdk.SetSubnetsInput
+		resp *elbv2sdk.SetSubnetsOutput
+		err  error
+	}
+	type args struct {
+		lbType                elbv2model.LoadBalancerType
+		desiredSubnets        []string
+		currentSubnets        []string
+		subnetsAutoDiscovered bool
+	}
+	tests := []struct {
+		name                       string
+		setSubnetsWithContextCalls []setSubnetsWithContextCall
+		args                       args
+		wantErr                    error
+	}{
+		{
+			name: "subnets unchanged",
+			args: args{
+				lbType:         elbv2model.LoadBalancerTypeNetwork,
+				desiredSubnets: []string{"subnet-b", "subnet-a"},
+				currentSubnets: []string{"subnet-a", "subnet-b"},
+			},
+		},
+		{
+			name: "subnets removed from application loadBalancer",
+			setSubnetsWithContextCalls: []setSubnetsWithContextCall{
+				{
+					req: &elbv2sdk.SetSubnetsInput{
+						LoadBalancerArn: awssdk.String("my-arn"),
+						SubnetMappings: []*elbv2sdk.SubnetMapping{
+							{SubnetId: awssdk.String("subnet-a")},
+							{SubnetId: awssdk.String("subnet-b")},
+						},
+					},
+					resp: &elbv2sdk.SetSubnetsOutput{},
+				},
+			},
+			args: args{
+				lbType:         elbv2model.LoadBalancerTypeApplication,
+				desiredSubnets: []string{"subnet-a", "subnet-b"},
+				currentSubnets: []string{"subnet-a", "subnet-b", "subnet-c"},
+			},
+		},
+		{
+			name: "subnets added to network loadBalancer",
+			setSubnetsWithContextCalls: []setSubnetsWithContextCall{
+				{
+					req: &elbv2sdk.SetSubnetsInput{
+						LoadBalancerArn: awssdk.String("my-arn"),
+						SubnetMappings: []*elbv2sdk.SubnetMapping{
+							{SubnetId: awssdk.String("subnet-a")},
+							{SubnetId: awssdk.String("subnet-b")},
+						},
+					},
+					resp: &elbv2sdk.SetSubnetsOutput{},
+				},
+			},
+			args: args{
+				lbType:         elbv2model.LoadBalancerTypeNetwork,
+				desiredSubnets: []string{"subnet-a", "subnet-b"},
+				currentSubnets: []string{"subnet-a"},
+			},
+		},
+		{
+			name: "subnets removed from network loadBalancer",
+			args: args{
+				lbType:         elbv2model.LoadBalancerTypeNetwork,
+				desiredSubnets: []string{"subnet-a", "subnet-d"},
+				currentSubnets: []string{"subnet-a", "subnet-b", "subnet-c"},
+			},
+			wantErr: runtime.NewTerminalError("LoadBalancerReplacementRequired", &LoadBalancerReplacementRequiredError{
+				LoadBalancerARN: "my-arn",
+				Reason:          "subnets [subnet-b subnet-c] cannot be removed from network loadBalancer",
+			}),
+		},
+		{
+			name: "auto-discovered subnets removed from network loadBalancer",
+			args: args{
+				lbType:                elbv2model.LoadBalancerTypeNetwork,
+				desiredSubnets:        []string{"subnet-a", "subnet-d"},
+				currentSubnets:        []string{"subnet-a", "subnet-b"},
+				subnetsAutoDiscovered: true,
+			},
+			wantErr: &LoadBalancerReplacementRequiredError{
+				LoadBalancerARN: "my-arn",
+				Reason:          "subnets [subnet-b] cannot be removed from network loadBalancer",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			elbv2Client := mock_services.NewMockELBV2(ctrl)
+			for _, call := range tt.setSubnetsWithContextCalls {
+				elbv2Client.EXPECT().SetSubnetsWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+
+			stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "namespace", Name: "name"})
+			var subnetMappings []elbv2model.SubnetMapping
+			for _, subnetID := range tt.args.desiredSubnets {
+				subnetMappings = append(subnetMappings, elbv2model.SubnetMapping{SubnetID: subnetID})
+			}
+			resLB := elbv2model.NewLoadBalancer(stack, "LoadBalancer", elbv2model.LoadBalancerSpec{
+				Type:                  tt.args.lbType,
+				SubnetMappings:        subnetMappings,
+				SubnetsAutoDiscovered: tt.args.subnetsAutoDiscovered,
+			})
+			var azs []*elbv2sdk.AvailabilityZone
+			for _, subnetID := range tt.args.currentSubnets {
+				azs = append(azs, &elbv2sdk.AvailabilityZone{SubnetId: awssdk.String(subnetID)})
+			}
+			sdkLB := LoadBalancerWithTags{
+				LoadBalancer: &elbv2sdk.LoadBalancer{
+					LoadBalancerArn:   awssdk.String("my-arn"),
+					AvailabilityZones: azs,
+				},
+			}
+
+			m := &defaultLoadBalancerManager{
+				elbv2Client: elbv2Client,
+				logger:      &log.NullLogger{},
+			}
+			err := m.updateSDKLoadBalancerWithSubnetMappings(context.Background(), resLB, sdkLB)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_defaultLoadBalancerManager_updateSDKLoadBalancerWithSecurityGroups(t *testing.T) {
+	type setSecurityGroupsWithContextCall struct {
+		req  *elbv2sdk.SetSecurityGroupsInput
+		resp *elbv2sdk.SetSecurityGroupsOutput
+		err  error
+	}
+	type args struct {
+		lbType                elbv2model.LoadBalancerType
+		desiredSecurityGroups []string
+		currentSecurityGroups []string
+	}
+	tests := []struct {
+		name                              string
+		setSecurityGroupsWithContextCalls []setSecurityGroupsWithContextCall
+		args                              args
+		wantErr                           error
+	}{
+		{
+			name: "securityGroups changed on network loadBalancer",
+			setSecurityGroupsWithContextCalls: []setSecurityGroupsWithContextCall{
+				{
+					req: &elbv2sdk.SetSecurityGroupsInput{
+						LoadBalancerArn: awssdk.String("my-arn"),
+						SecurityGroups:  awssdk.StringSlice([]string{"sg-a"}),
+					},
+					resp: &elbv2sdk.SetSecurityGroupsOutput{},
+				},
+			},
+			args: args{
+				lbType:                elbv2model.LoadBalancerTypeNetwork,
+				desiredSecurityGroups: []string{"sg-a"},
+				currentSecurityGroups: []string{"sg-b"},
+			},
+		},
+		{
+			name: "securityGroups added to network loadBalancer created without securityGroups",
+			args: args{
+				lbType:                elbv2model.LoadBalancerTypeNetwork,
+				desiredSecurityGroups: []string{"sg-a"},
+			},
+			wantErr: runtime.NewTerminalError("LoadBalancerReplacementRequired", &LoadBalancerReplacementRequiredError{
+				LoadBalancerARN: "my-arn",
+				Reason:          "securityGroups cannot be added to network loadBalancer created without securityGroups unless a replacement strategy is specified",
+			}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			elbv2Client := mock_services.NewMockELBV2(ctrl)
+			for _, call := range tt.setSecurityGroupsWithContextCalls {
+				elbv2Client.EXPECT().SetSecurityGroupsWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+
+			stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "namespace", Name: "name"})
+			var securityGroups []coremodel.StringToken
+			for _, sgID := range tt.args.desiredSecurityGroups {
+				securityGroups = append(securityGroups, coremodel.LiteralStringToken(sgID))
+			}
+			resLB := elbv2model.NewLoadBalancer(stack, "LoadBalancer", elbv2model.LoadBalancerSpec{
+				Type:           tt.args.lbType,
+				SecurityGroups: securityGroups,
+			})
+			sdkLB := LoadBalancerWithTags{
+				LoadBalancer: &elbv2sdk.LoadBalancer{
+					LoadBalancerArn: awssdk.String("my-arn"),
+					SecurityGroups:  awssdk.StringSlice(tt.args.currentSecurityGroups),
+				},
+			}
+
+			m := &defaultLoadBalancerManager{
+				elbv2Client: elbv2Client,
+				logger:      &log.NullLogger{},
+			}
+			err := m.updateSDKLoadBalancerWithSecurityGroups(context.Background(), resLB, sdkLB)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,517 @@
+package elbv2
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"time"
+)
+
+const (
+	// LoadBalancerReplacementCutoverTagKey is the tag key on a LoadBalancer being replaced, once its listeners are being moved onto
+	// cloned targetGroups so that the replacement can take over the original ones. The tag value is the cutover time in RFC3339,
+	// or "in-progress" until all listeners are moved.
+	LoadBalancerReplacementCutoverTagKey = "elbv2.k8s.aws/replacement-cutover"
+	// LoadBalancerReplacementRetireTagKey is the tag key on a LoadBalancer being replaced, once DNS outputs refer to the replacement.
+	// The tag value is the time in RFC3339 the LoadBalancer is deleted at.
+	LoadBalancerReplacementRetireTagKey = "elbv2.k8s.aws/replacement-retire"
+	// ReplacedLoadBalancerTagKey is the tag key on targetGroups cloned for a LoadBalancer being replaced,
+	// the tag value is the ARN of the LoadBalancer.
+	ReplacedLoadBalancerTagKey = "elbv2.k8s.aws/replaced-load-balancer"
+	// ClonedTargetGroupTagKey is the tag key on targetGroups cloned for a LoadBalancer being replaced,
+	// the tag value is the ARN of the targetGroup it's cloned from.
+	ClonedTargetGroupTagKey = "elbv2.k8s.aws/cloned-target-group"
+
+	// the name prefix of targetGroups cloned for a LoadBalancer being replaced.
+	replacedTargetGroupNamePrefix = "k8s-replaced-"
+	// the value of cutover tag until all listeners of the replaced LoadBalancer are moved onto cloned targetGroups.
+	replacementCutoverInProgress = "in-progress"
+)
+
+// LoadBalancerReplacementManager is responsible for blue/green replacement of LoadBalancers.
+// A replacement progresses in phases across reconciles:
+//  1. Provisioning: the replacement is created, and the replaced LoadBalancer still serves as the LoadBalancer of stack.
+//  2. Verifying: once the replacement is active, listeners of replaced LoadBalancer are moved onto cloned targetGroups,
+//     so that listeners of the replacement can forward to the original ones. DNS outputs still refer to the replaced LoadBalancer.
+//  3. Draining: once targets of the replacement are healthy, DNS outputs refer to the replacement,
+//     and the replaced LoadBalancer keeps serving until the drain window elapses.
+//
+// Targets of cloned targetGroups follow the targets of the targetGroups they're cloned from until the replaced LoadBalancer is deleted.
+type LoadBalancerReplacementManager interface {
+	// Reconcile progresses the replacement of replacedLB by the LoadBalancer with lbStatus, and returns the status for resLB.
+	Reconcile(ctx context.Context, resLB *elbv2model.LoadBalancer, replacedLB LoadBalancerWithTags, lbStatus elbv2model.LoadBalancerStatus) (elbv2model.LoadBalancerStatus, error)
+
+	// Delete deletes replacedLB along with the targetGroups cloned for it.
+	Delete(ctx context.Context, replacedLB LoadBalancerWithTags) error
+}
+
+// NewDefaultLoadBalancerReplacementManager constructs new defaultLoadBalancerReplacementManager.
+func NewDefaultLoadBalancerReplacementManager(elbv2Client services.ELBV2, lbManager LoadBalancerManager, tgManager TargetGroupManager,
+	taggingManager TaggingManager, logger logr.Logger) *defaultLoadBalancerReplacementManager {
+	return &defaultLoadBalancerReplacementManager{
+		elbv2Client:    elbv2Client,
+		lbManager:      lbManager,
+		tgManager:      tgManager,
+		taggingManager: taggingManager,
+		logger:         logger,
+	}
+}
+
+var _ LoadBalancerReplacementManager = &defaultLoadBalancerReplacementManager{}
+
+// default implementation for LoadBalancerReplacementManager.
+// the phase of replacement is tracked by tags on the replaced LoadBalancer, so that it survives controller restarts.
+// cloned targetGroups are tracked by tags as well, so that they're found regardless of whether listeners still forward to them.
+type defaultLoadBalancerReplacementManager struct {
+	elbv2Client    services.ELBV2
+	lbManager      LoadBalancerManager
+	tgManager      TargetGroupManager
+	taggingManager TaggingManager
+	logger         logr.Logger
+}
+
+func (m *defaultLoadBalancerReplacementManager) Reconcile(ctx context.Context, resLB *elbv2model.LoadBalancer, replacedLB LoadBalancerWithTags,
+	lbStatus elbv2model.LoadBalancerStatus) (elbv2model.LoadBalancerStatus, error) {
+	replacedLBARN := awssdk.StringValue(replacedLB.LoadBalancer.LoadBalancerArn)
+	if rawRetireTime, ok := replacedLB.Tags[LoadBalancerReplacementRetireTagKey]; ok {
+		retireTime, err := time.Parse(time.RFC3339, rawRetireTime)
+		if err != nil {
+			return elbv2model.LoadBalancerStatus{}, errors.Wrapf(err, "failed to parse retire time of loadBalancer: %v", replacedLBARN)
+		}
+		if !time.Now().Before(retireTime) {
+			if err := m.Delete(ctx, replacedLB); err != nil {
+				return elbv2model.LoadBalancerStatus{}, err
+			}
+			return lbStatus, nil
+		}
+		if err := m.syncClonedTargetGroups(ctx, replacedLBARN); err != nil {
+			return elbv2model.LoadBalancerStatus{}, err
+		}
+		lbStatus.Replacement = &elbv2model.LoadBalancerReplacementStatus{
+			ReplacedLoadBalancerARN: replacedLBARN,
+			Phase:                   elbv2model.LoadBalancerReplacementPhaseDraining,
+			RetireTime:              &retireTime,
+		}
+		return lbStatus, nil
+	}
+
+	if cutover, ok := replacedLB.Tags[LoadBalancerReplacementCutoverTagKey]; !ok || cutover == replacementCutoverInProgress {
+		active, err := m.isLoadBalancerActive(ctx, lbStatus.LoadBalancerARN)
+		if err != nil {
+			return elbv2model.LoadBalancerStatus{}, err
+		}
+		if !active {
+			replacedLBStatus := buildResLoadBalancerStatus(replacedLB)
+			replacedLBStatus.Replacement = &elbv2model.LoadBalancerReplacementStatus{
+				ReplacedLoadBalancerARN: replacedLBARN,
+				Phase:                   elbv2model.LoadBalancerReplacementPhaseProvisioning,
+			}
+			return replacedLBStatus, nil
+		}
+		if err := m.cutover(ctx, replacedLB); err != nil {
+			return elbv2model.LoadBalancerStatus{}, err
+		}
+	} else {
+		if err := m.syncClonedTargetGroups(ctx, replacedLBARN); err != nil {
+			return elbv2model.LoadBalancerStatus{}, err
+		}
+		healthy, err := m.isLoadBalancerTargetsHealthy(ctx, lbStatus.LoadBalancerARN)
+		if err != nil {
+			return elbv2model.LoadBalancerStatus{}, err
+		}
+		if healthy {
+			var drainWindow time.Duration
+			if resLB.Spec.Replacement != nil {
+				drainWindow = time.Duration(resLB.Spec.Replacement.DrainWindowSeconds) * time.Second
+			}
+			retireTime := time.Now().Add(drainWindow).UTC().Truncate(time.Second)
+			if err := m.tagReplacedLoadBalancer(ctx, replacedLBARN, LoadBalancerReplacementRetireTagKey, retireTime.Format(time.RFC3339)); err != nil {
+				return elbv2model.LoadBalancerStatus{}, err
+			}
+			lbStatus.Replacement = &elbv2model.LoadBalancerReplacementStatus{
+				ReplacedLoadBalancerARN: replacedLBARN,
+				Phase:                   elbv2model.LoadBalancerReplacementPhaseDraining,
+				RetireTime:              &retireTime,
+			}
+			return lbStatus, nil
+		}
+	}
+
+	// listeners are served by the replacement, while DNS outputs still refer to the replaced LoadBalancer.
+	lbStatus.DNSName = awssdk.StringValue(replacedLB.LoadBalancer.DNSName)
+	lbStatus.CanonicalHostedZoneID = awssdk.StringValue(replacedLB.LoadBalancer.CanonicalHostedZoneId)
+	lbStatus.Replacement = &elbv2model.LoadBalancerReplacementStatus{
+		ReplacedLoadBalancerARN: replacedLBARN,
+		Phase:                   elbv2model.LoadBalancerReplacementPhaseVerifying,
+	}
+	return lbStatus, nil
+}
+
+func (m *defaultLoadBalancerReplacementManager) Delete(ctx context.Context, replacedLB LoadBalancerWithTags) error {
+	replacedLBARN := awssdk.StringValue(replacedLB.LoadBalancer.LoadBalancerArn)
+	if err := m.lbManager.Delete(ctx, replacedLB); err != nil {
+		return err
+	}
+	// cloned targetGroups are found by tags, including the ones left behind by a cutover that failed halfway.
+	clonedTGs, err := m.listClonedTargetGroups(ctx, replacedLBARN)
+	if err != nil {
+		return err
+	}
+	for _, clonedTG := range clonedTGs {
+		if err := m.tgManager.Delete(ctx, clonedTG); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cutover moves listeners of replacedLB onto cloned targetGroups, which frees the original targetGroups for the replacement.
+// the replaced LoadBalancer is tagged before any listener is moved, so that the cloned targetGroups are deleted with it even if cutover fails halfway.
+func (m *defaultLoadBalancerReplacementManager) cutover(ctx context.Context, replacedLB LoadBalancerWithTags) error {
+	replacedLBARN := awssdk.StringValue(replacedLB.LoadBalancer.LoadBalancerArn)
+	if replacedLB.Tags[LoadBalancerReplacementCutoverTagKey] != replacementCutoverInProgress {
+		if err := m.tagReplacedLoadBalancer(ctx, replacedLBARN, LoadBalancerReplacementCutoverTagKey, replacementCutoverInProgress); err != nil {
+			return err
+		}
+	}
+	sdkLSs, err := m.elbv2Client.DescribeListenersAsList(ctx, &elbv2sdk.DescribeListenersInput{
+		LoadBalancerArn: replacedLB.LoadBalancer.LoadBalancerArn,
+	})
+	if err != nil {
+		return err
+	}
+	// targetGroups cloned by a previous cutover attempt are reused, and never cloned again when listeners already forward to them.
+	clonedTGs, err := m.listClonedTargetGroups(ctx, replacedLBARN)
+	if err != nil {
+		return err
+	}
+	clonedTGARNByTGARN := make(map[string]string, len(clonedTGs))
+	clonedTGARNs := make(map[string]bool, len(clonedTGs))
+	for _, clonedTG := range clonedTGs {
+		clonedTGARN := awssdk.StringValue(clonedTG.TargetGroup.TargetGroupArn)
+		clonedTGARNs[clonedTGARN] = true
+		if tgARN, ok := clonedTG.Tags[ClonedTargetGroupTagKey]; ok {
+			clonedTGARNByTGARN[tgARN] = clonedTGARN
+		}
+	}
+	for _, sdkLS := range sdkLSs {
+		var sdkRules []*elbv2sdk.Rule
+		if awssdk.StringValue(replacedLB.LoadBalancer.Type) == string(elbv2model.LoadBalancerTypeApplication) {
+			sdkRules, err = m.elbv2Client.DescribeRulesAsList(ctx, &elbv2sdk.DescribeRulesInput{
+				ListenerArn: sdkLS.ListenerArn,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		for _, tgARN := range listSDKActionsTargetGroupARNs(sdkLS.DefaultActions) {
+			if clonedTGARNs[tgARN] {
+				continue
+			}
+			if err := m.cloneTargetGroup(ctx, replacedLBARN, tgARN, clonedTGARNByTGARN); err != nil {
+				return err
+			}
+		}
+		for _, sdkRule := range sdkRules {
+			if awssdk.BoolValue(sdkRule.IsDefault) {
+				continue
+			}
+			for _, tgARN := range listSDKActionsTargetGroupARNs(sdkRule.Actions) {
+				if clonedTGARNs[tgARN] {
+					continue
+				}
+				if err := m.cloneTargetGroup(ctx, replacedLBARN, tgARN, clonedTGARNByTGARN); err != nil {
+					return err
+				}
+			}
+		}
+
+		if replaceSDKActionsTargetGroups(sdkLS.DefaultActions, clonedTGARNByTGARN) {
+			if _, err := m.elbv2Client.ModifyListenerWithContext(ctx, &elbv2sdk.ModifyListenerInput{
+				ListenerArn:    sdkLS.ListenerArn,
+				DefaultActions: sdkLS.DefaultActions,
+			}); err != nil {
+				return err
+			}
+		}
+		for _, sdkRule := range sdkRules {
+			if awssdk.BoolValue(sdkRule.IsDefault) || !replaceSDKActionsTargetGroups(sdkRule.Actions, clonedTGARNByTGARN) {
+				continue
+			}
+			if _, err := m.elbv2Client.ModifyRuleWithContext(ctx, &elbv2sdk.ModifyRuleInput{
+				RuleArn: sdkRule.RuleArn,
+				Actions: sdkRule.Actions,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	m.logger.Info("moved listeners of replaced loadBalancer onto cloned targetGroups",
+		"arn", replacedLBARN,
+		"targetGroups", clonedTGARNByTGARN)
+	return m.tagReplacedLoadBalancer(ctx, replacedLBARN, LoadBalancerReplacementCutoverTagKey, time.Now().UTC().Truncate(time.Second).Format(time.RFC3339))
+}
+
+// cloneTargetGroup clones the targetGroup with tgARN along with its attributes and registered targets, unless it's cloned already.
+func (m *defaultLoadBalancerReplacementManager) cloneTargetGroup(ctx context.Context, replacedLBARN string, tgARN string, clonedTGARNByTGARN map[string]string) error {
+	if _, ok := clonedTGARNByTGARN[tgARN]; ok {
+		return nil
+	}
+	sdkTGs, err := m.elbv2Client.DescribeTargetGroupsAsList(ctx, &elbv2sdk.DescribeTargetGroupsInput{
+		TargetGroupArns: awssdk.StringSlice([]string{tgARN}),
+	})
+	if err != nil {
+		return err
+	}
+	if len(sdkTGs) == 0 {
+		return errors.Errorf("targetGroup not found: %v", tgARN)
+	}
+	req := buildSDKCloneTargetGroupInput(sdkTGs[0])
+	req.Tags = convertTagsToSDKTags(map[string]string{
+		ReplacedLoadBalancerTagKey: replacedLBARN,
+		ClonedTargetGroupTagKey:    tgARN,
+	})
+	// CreateTargetGroup is idempotent for the same name and settings, so that a failed cutover can be retried.
+	resp, err := m.elbv2Client.CreateTargetGroupWithContext(ctx, req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to clone targetGroup: %v", tgARN)
+	}
+	clonedTGARN := awssdk.StringValue(resp.TargetGroups[0].TargetGroupArn)
+
+	attrsResp, err := m.elbv2Client.DescribeTargetGroupAttributesWithContext(ctx, &elbv2sdk.DescribeTargetGroupAttributesInput{
+		TargetGroupArn: awssdk.String(tgARN),
+	})
+	if err != nil {
+		return err
+	}
+	if len(attrsResp.Attributes) != 0 {
+		if _, err := m.elbv2Client.ModifyTargetGroupAttributesWithContext(ctx, &elbv2sdk.ModifyTargetGroupAttributesInput{
+			TargetGroupArn: awssdk.String(clonedTGARN),
+			Attributes:     attrsResp.Attributes,
+		}); err != nil {
+			return err
+		}
+	}
+
+	if err := m.syncClonedTargets(ctx, tgARN, clonedTGARN); err != nil {
+		return err
+	}
+	clonedTGARNByTGARN[tgARN] = clonedTGARN
+	return nil
+}
+
+// syncClonedTargetGroups updates the targets of targetGroups cloned for the LoadBalancer with replacedLBARN,
+// so that the replaced LoadBalancer keeps forwarding to live targets while pods are replaced.
+func (m *defaultLoadBalancerReplacementManager) syncClonedTargetGroups(ctx context.Context, replacedLBARN string) error {
+	clonedTGs, err := m.listClonedTargetGroups(ctx, replacedLBARN)
+	if err != nil {
+		return err
+	}
+	for _, clonedTG := range clonedTGs {
+		tgARN, ok := clonedTG.Tags[ClonedTargetGroupTagKey]
+		if !ok {
+			continue
+		}
+		if err := m.syncClonedTargets(ctx, tgARN, awssdk.StringValue(clonedTG.TargetGroup.TargetGroupArn)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncClonedTargets registers the targets of targetGroup with tgARN onto its clone with clonedTGARN, and deregisters the ones gone.
+// draining targets are excluded, since they're being deregistered from the original targetGroup.
+func (m *defaultLoadBalancerReplacementManager) syncClonedTargets(ctx context.Context, tgARN string, clonedTGARN string) error {
+	desiredTargets, err := m.listActiveTargets(ctx, tgARN)
+	if err != nil {
+		return err
+	}
+	currentTargets, err := m.listActiveTargets(ctx, clonedTGARN)
+	if err != nil {
+		return err
+	}
+	var targetsToRegister []*elbv2sdk.TargetDescription
+	for key, target := range desiredTargets {
+		if _, ok := currentTargets[key]; !ok {
+			targetsToRegister = append(targetsToRegister, target)
+		}
+	}
+	var targetsToDeregister []*elbv2sdk.TargetDescription
+	for key, target := range currentTargets {
+		if _, ok := desiredTargets[key]; !ok {
+			targetsToDeregister = append(targetsToDeregister, target)
+		}
+	}
+	if len(targetsToRegister) != 0 {
+		if _, err := m.elbv2Client.RegisterTargetsWithContext(ctx, &elbv2sdk.RegisterTargetsInput{
+			TargetGroupArn: awssdk.String(clonedTGARN),
+			Targets:        targetsToRegister,
+		}); err != nil {
+			return err
+		}
+	}
+	if len(targetsToDeregister) != 0 {
+		if _, err := m.elbv2Client.DeregisterTargetsWithContext(ctx, &elbv2sdk.DeregisterTargetsInput{
+			TargetGroupArn: awssdk.String(clonedTGARN),
+			Targets:        targetsToDeregister,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listActiveTargets lists the targets of targetGroup with tgARN that aren't draining, keyed by their ID and port.
+func (m *defaultLoadBalancerReplacementManager) listActiveTargets(ctx context.Context, tgARN string) (map[string]*elbv2sdk.TargetDescription, error) {
+	resp, err := m.elbv2Client.DescribeTargetHealthWithContext(ctx, &elbv2sdk.DescribeTargetHealthInput{
+		TargetGroupArn: awssdk.String(tgARN),
+	})
+	if err != nil {
+		return nil, err
+	}
+	targets := make(map[string]*elbv2sdk.TargetDescription, len(resp.TargetHealthDescriptions))
+	for _, targetHealth := range resp.TargetHealthDescriptions {
+		if targetHealth.TargetHealth != nil && awssdk.StringValue(targetHealth.TargetHealth.State) == elbv2sdk.TargetHealthStateEnumDraining {
+			continue
+		}
+		key := fmt.Sprintf("%v:%v", awssdk.StringValue(targetHealth.Target.Id), awssdk.Int64Value(targetHealth.Target.Port))
+		targets[key] = targetHealth.Target
+	}
+	return targets, nil
+}
+
+// listClonedTargetGroups lists the targetGroups cloned for the LoadBalancer with replacedLBARN by their tags.
+func (m *defaultLoadBalancerReplacementManager) listClonedTargetGroups(ctx context.Context, replacedLBARN string) ([]TargetGroupWithTags, error) {
+	return m.taggingManager.ListTargetGroups(ctx, tracking.TagFilter{ReplacedLoadBalancerTagKey: []string{replacedLBARN}})
+}
+
+// isLoadBalancerActive checks whether the LoadBalancer with lbARN finished provisioning.
+func (m *defaultLoadBalancerReplacementManager) isLoadBalancerActive(ctx context.Context, lbARN string) (bool, error) {
+	sdkLBs, err := m.elbv2Client.DescribeLoadBalancersAsList(ctx, &elbv2sdk.DescribeLoadBalancersInput{
+		LoadBalancerArns: awssdk.StringSlice([]string{lbARN}),
+	})
+	if err != nil {
+		return false, err
+	}
+	if len(sdkLBs) == 0 || sdkLBs[0].State == nil {
+		return false, nil
+	}
+	return awssdk.StringValue(sdkLBs[0].State.Code) == elbv2sdk.LoadBalancerStateEnumActive, nil
+}
+
+// isLoadBalancerTargetsHealthy checks whether every targetGroup of the LoadBalancer with lbARN has healthy targets.
+// targetGroups without targets are ignored.
+func (m *defaultLoadBalancerReplacementManager) isLoadBalancerTargetsHealthy(ctx context.Context, lbARN string) (bool, error) {
+	sdkTGs, err := m.elbv2Client.DescribeTargetGroupsAsList(ctx, &elbv2sdk.DescribeTargetGroupsInput{
+		LoadBalancerArn: awssdk.String(lbARN),
+	})
+	if err != nil {
+		return false, err
+	}
+	for _, sdkTG := range sdkTGs {
+		resp, err := m.elbv2Client.DescribeTargetHealthWithContext(ctx, &elbv2sdk.DescribeTargetHealthInput{
+			TargetGroupArn: sdkTG.TargetGroupArn,
+		})
+		if err != nil {
+			return false, err
+		}
+		if len(resp.TargetHealthDescriptions) == 0 {
+			continue
+		}
+		healthy := false
+		for _, targetHealth := range resp.TargetHealthDescriptions {
+			if awssdk.StringValue(targetHealth.TargetHealth.State) == elbv2sdk.TargetHealthStateEnumHealthy {
+				healthy = true
+				break
+			}
+		}
+		if !healthy {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (m *defaultLoadBalancerReplacementManager) tagReplacedLoadBalancer(ctx context.Context, lbARN string, tagKey string, tagValue string) error {
+	if _, err := m.elbv2Client.AddTagsWithContext(ctx, &elbv2sdk.AddTagsInput{
+		ResourceArns: awssdk.StringSlice([]string{lbARN}),
+		Tags:         convertTagsToSDKTags(map[string]string{tagKey: tagValue}),
+	}); err != nil {
+		return err
+	}
+	m.logger.Info("tagged replaced loadBalancer",
+		"arn", lbARN,
+		tagKey, tagValue)
+	return nil
+}
+
+// listSDKActionsTargetGroupARNs lists the ARNs of targetGroups forwarded to by actions.
+func listSDKActionsTargetGroupARNs(sdkActions []*elbv2sdk.Action) []string {
+	var tgARNs []string
+	for _, sdkAction := range sdkActions {
+		if sdkAction.TargetGroupArn != nil {
+			tgARNs = append(tgARNs, awssdk.StringValue(sdkAction.TargetGroupArn))
+		}
+		if sdkAction.ForwardConfig != nil {
+			for _, tgTuple := range sdkAction.ForwardConfig.TargetGroups {
+				tgARNs = append(tgARNs, awssdk.StringValue(tgTuple.TargetGroupArn))
+			}
+		}
+	}
+	return tgARNs
+}
+
+// replaceSDKActionsTargetGroups replaces targetGroups forwarded to by actions in place, and returns whether any is replaced.
+func replaceSDKActionsTargetGroups(sdkActions []*elbv2sdk.Action, tgARNMapping map[string]string) bool {
+	replaced := false
+	for _, sdkAction := range sdkActions {
+		if tgARN, ok := tgARNMapping[awssdk.StringValue(sdkAction.TargetGroupArn)]; ok {
+			sdkAction.TargetGroupArn = awssdk.String(tgARN)
+			replaced = true
+		}
+		if sdkAction.ForwardConfig != nil {
+			for _, tgTuple := range sdkAction.ForwardConfig.TargetGroups {
+				if tgARN, ok := tgARNMapping[awssdk.StringValue(tgTuple.TargetGroupArn)]; ok {
+					tgTuple.TargetGroupArn = awssdk.String(tgARN)
+					replaced = true
+				}
+			}
+		}
+		// client secrets are never described, the existing one must be kept explicitly.
+		if sdkAction.AuthenticateOidcConfig != nil && sdkAction.AuthenticateOidcConfig.ClientSecret == nil {
+			sdkAction.AuthenticateOidcConfig.UseExistingClientSecret = awssdk.Bool(true)
+		}
+	}
+	return replaced
+}
+
+// buildSDKCloneTargetGroupInput builds the input to clone sdkTG, the name of clone is derived from the ARN of sdkTG.
+func buildSDKCloneTargetGroupInput(sdkTG *elbv2sdk.TargetGroup) *elbv2sdk.CreateTargetGroupInput {
+	nameHash := sha256.Sum256([]byte(awssdk.StringValue(sdkTG.TargetGroupArn)))
+	return &elbv2sdk.CreateTargetGroupInput{
+		Name:                       awssdk.String(fmt.Sprintf("%v%.19s", replacedTargetGroupNamePrefix, hex.EncodeToString(nameHash[:]))),
+		TargetType:                 sdkTG.TargetType,
+		Protocol:                   sdkTG.Protocol,
+		ProtocolVersion:            sdkTG.ProtocolVersion,
+		Port:                       sdkTG.Port,
+		VpcId:                      sdkTG.VpcId,
+		IpAddressType:              sdkTG.IpAddressType,
+		HealthCheckEnabled:         sdkTG.HealthCheckEnabled,
+		HealthCheckProtocol:        sdkTG.HealthCheckProtocol,
+		HealthCheckPort:            sdkTG.HealthCheckPort,
+		HealthCheckPath:            sdkTG.HealthCheckPath,
+		HealthCheckIntervalSeconds: sdkTG.HealthCheckIntervalSeconds,
+		HealthCheckTimeoutSeconds:  sdkTG.HealthCheckTimeoutSeconds,
+		HealthyThresholdCount:      sdkTG.HealthyThresholdCount,
+		UnhealthyThresholdCount:    sdkTG.UnhealthyThresholdCount,
+		Matcher:                    sdkTG.Matcher,
+	}
+}
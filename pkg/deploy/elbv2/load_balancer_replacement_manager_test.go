@@ -0,0 +1,543 @@
+package elbv2
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+	"time"
+)
+
+func Test_defaultLoadBalancerReplacementManager_Reconcile(t *testing.T) {
+	type describeLoadBalancersAsListCall struct {
+		req  *elbv2sdk.DescribeLoadBalancersInput
+		resp []*elbv2sdk.LoadBalancer
+	}
+	type describeTargetGroupsAsListCall struct {
+		req  *elbv2sdk.DescribeTargetGroupsInput
+		resp []*elbv2sdk.TargetGroup
+	}
+	type describeTagsWithContextCall struct {
+		req  *elbv2sdk.DescribeTagsInput
+		resp *elbv2sdk.DescribeTagsOutput
+	}
+	type describeTargetHealthWithContextCall struct {
+		req  *elbv2sdk.DescribeTargetHealthInput
+		resp *elbv2sdk.DescribeTargetHealthOutput
+	}
+	type registerTargetsWithContextCall struct {
+		req *elbv2sdk.RegisterTargetsInput
+	}
+	type deregisterTargetsWithContextCall struct {
+		req *elbv2sdk.DeregisterTargetsInput
+	}
+	type deleteLoadBalancerWithContextCall struct {
+		req *elbv2sdk.DeleteLoadBalancerInput
+	}
+	type deleteTargetGroupWithContextCall struct {
+		req *elbv2sdk.DeleteTargetGroupInput
+	}
+	futureRetireTime := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	pastRetireTime := time.Now().Add(-time.Minute).UTC().Truncate(time.Second)
+	lbStatus := elbv2model.LoadBalancerStatus{
+		LoadBalancerARN:       "lb-arn",
+		DNSName:               "lb.elb.amazonaws.com",
+		CanonicalHostedZoneID: "Z1",
+	}
+	tests := []struct {
+		name                                 string
+		replacedLBTags                       map[string]string
+		describeLoadBalancersAsListCalls     []describeLoadBalancersAsListCall
+		describeTargetGroupsAsListCalls      []describeTargetGroupsAsListCall
+		describeTagsWithContextCalls         []describeTagsWithContextCall
+		describeTargetHealthWithContextCalls []describeTargetHealthWithContextCall
+		registerTargetsWithContextCalls      []registerTargetsWithContextCall
+		deregisterTargetsWithContextCalls    []deregisterTargetsWithContextCall
+		deleteLoadBalancerWithContextCalls   []deleteLoadBalancerWithContextCall
+		deleteTargetGroupWithContextCalls    []deleteTargetGroupWithContextCall
+		want                                 elbv2model.LoadBalancerStatus
+		wantAddTags                          bool
+	}{
+		{
+			name:           "replacement is provisioning",
+			replacedLBTags: map[string]string{},
+			describeLoadBalancersAsListCalls: []describeLoadBalancersAsListCall{
+				{
+					req: &elbv2sdk.DescribeLoadBalancersInput{LoadBalancerArns: awssdk.StringSlice([]string{"lb-arn"})},
+					resp: []*elbv2sdk.LoadBalancer{
+						{
+							LoadBalancerArn: awssdk.String("lb-arn"),
+							State:           &elbv2sdk.LoadBalancerState{Code: awssdk.String(elbv2sdk.LoadBalancerStateEnumProvisioning)},
+						},
+					},
+				},
+			},
+			want: elbv2model.LoadBalancerStatus{
+				LoadBalancerARN:       "replaced-lb-arn",
+				DNSName:               "replaced-lb.elb.amazonaws.com",
+				CanonicalHostedZoneID: "Z1",
+				Replacement: &elbv2model.LoadBalancerReplacementStatus{
+					ReplacedLoadBalancerARN: "replaced-lb-arn",
+					Phase:                   elbv2model.LoadBalancerReplacementPhaseProvisioning,
+				},
+			},
+		},
+		{
+			name: "targets of replacement aren't healthy yet",
+			replacedLBTags: map[string]string{
+				LoadBalancerReplacementCutoverTagKey: "2026-01-01T00:00:00Z",
+			},
+			describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+				{
+					req:  &elbv2sdk.DescribeTargetGroupsInput{},
+					resp: nil,
+				},
+				{
+					req:  &elbv2sdk.DescribeTargetGroupsInput{LoadBalancerArn: awssdk.String("lb-arn")},
+					resp: []*elbv2sdk.TargetGroup{{TargetGroupArn: awssdk.String("tg-arn")}},
+				},
+			},
+			describeTargetHealthWithContextCalls: []describeTargetHealthWithContextCall{
+				{
+					req: &elbv2sdk.DescribeTargetHealthInput{TargetGroupArn: awssdk.String("tg-arn")},
+					resp: &elbv2sdk.DescribeTargetHealthOutput{
+						TargetHealthDescriptions: []*elbv2sdk.TargetHealthDescription{
+							{
+								Target:       &elbv2sdk.TargetDescription{Id: awssdk.String("i-a")},
+								TargetHealth: &elbv2sdk.TargetHealth{State: awssdk.String(elbv2sdk.TargetHealthStateEnumInitial)},
+							},
+						},
+					},
+				},
+			},
+			want: elbv2model.LoadBalancerStatus{
+				LoadBalancerARN:       "lb-arn",
+				DNSName:               "replaced-lb.elb.amazonaws.com",
+				CanonicalHostedZoneID: "Z1",
+				Replacement: &elbv2model.LoadBalancerReplacementStatus{
+					ReplacedLoadBalancerARN: "replaced-lb-arn",
+					Phase:                   elbv2model.LoadBalancerReplacementPhaseVerifying,
+				},
+			},
+		},
+		{
+			name: "targets of replacement are healthy",
+			replacedLBTags: map[string]string{
+				LoadBalancerReplacementCutoverTagKey: "2026-01-01T00:00:00Z",
+			},
+			describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+				{
+					req:  &elbv2sdk.DescribeTargetGroupsInput{},
+					resp: nil,
+				},
+				{
+					req:  &elbv2sdk.DescribeTargetGroupsInput{LoadBalancerArn: awssdk.String("lb-arn")},
+					resp: []*elbv2sdk.TargetGroup{{TargetGroupArn: awssdk.String("tg-arn")}},
+				},
+			},
+			describeTargetHealthWithContextCalls: []describeTargetHealthWithContextCall{
+				{
+					req: &elbv2sdk.DescribeTargetHealthInput{TargetGroupArn: awssdk.String("tg-arn")},
+					resp: &elbv2sdk.DescribeTargetHealthOutput{
+						TargetHealthDescriptions: []*elbv2sdk.TargetHealthDescription{
+							{
+								Target:       &elbv2sdk.TargetDescription{Id: awssdk.String("i-a")},
+								TargetHealth: &elbv2sdk.TargetHealth{State: awssdk.String(elbv2sdk.TargetHealthStateEnumHealthy)},
+							},
+						},
+					},
+				},
+			},
+			wantAddTags: true,
+			want: elbv2model.LoadBalancerStatus{
+				LoadBalancerARN:       "lb-arn",
+				DNSName:               "lb.elb.amazonaws.com",
+				CanonicalHostedZoneID: "Z1",
+				Replacement: &elbv2model.LoadBalancerReplacementStatus{
+					ReplacedLoadBalancerARN: "replaced-lb-arn",
+					Phase:                   elbv2model.LoadBalancerReplacementPhaseDraining,
+				},
+			},
+		},
+		{
+			name: "replaced LoadBalancer is draining",
+			replacedLBTags: map[string]string{
+				LoadBalancerReplacementCutoverTagKey: "2026-01-01T00:00:00Z",
+				LoadBalancerReplacementRetireTagKey:  futureRetireTime.Format(time.RFC3339),
+			},
+			describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+				{
+					req: &elbv2sdk.DescribeTargetGroupsInput{},
+					resp: []*elbv2sdk.TargetGroup{
+						{TargetGroupArn: awssdk.String("tg-arn")},
+						{TargetGroupArn: awssdk.String("cloned-tg-arn")},
+					},
+				},
+			},
+			describeTagsWithContextCalls: []describeTagsWithContextCall{
+				{
+					req: &elbv2sdk.DescribeTagsInput{ResourceArns: awssdk.StringSlice([]string{"tg-arn", "cloned-tg-arn"})},
+					resp: &elbv2sdk.DescribeTagsOutput{
+						TagDescriptions: []*elbv2sdk.TagDescription{
+							{
+								ResourceArn: awssdk.String("cloned-tg-arn"),
+								Tags: []*elbv2sdk.Tag{
+									{Key: awssdk.String(ClonedTargetGroupTagKey), Value: awssdk.String("tg-arn")},
+									{Key: awssdk.String(ReplacedLoadBalancerTagKey), Value: awssdk.String("replaced-lb-arn")},
+								},
+							},
+						},
+					},
+				},
+			},
+			describeTargetHealthWithContextCalls: []describeTargetHealthWithContextCall{
+				{
+					req: &elbv2sdk.DescribeTargetHealthInput{TargetGroupArn: awssdk.String("tg-arn")},
+					resp: &elbv2sdk.DescribeTargetHealthOutput{
+						TargetHealthDescriptions: []*elbv2sdk.TargetHealthDescription{
+							{
+								Target:       &elbv2sdk.TargetDescription{Id: awssdk.String("i-a"), Port: awssdk.Int64(8080)},
+								TargetHealth: &elbv2sdk.TargetHealth{State: awssdk.String(elbv2sdk.TargetHealthStateEnumHealthy)},
+							},
+							{
+								Target:       &elbv2sdk.TargetDescription{Id: awssdk.String("i-b"), Port: awssdk.Int64(8080)},
+								TargetHealth: &elbv2sdk.TargetHealth{State: awssdk.String(elbv2sdk.TargetHealthStateEnumDraining)},
+							},
+						},
+					},
+				},
+				{
+					req: &elbv2sdk.DescribeTargetHealthInput{TargetGroupArn: awssdk.String("cloned-tg-arn")},
+					resp: &elbv2sdk.DescribeTargetHealthOutput{
+						TargetHealthDescriptions: []*elbv2sdk.TargetHealthDescription{
+							{
+								Target:       &elbv2sdk.TargetDescription{Id: awssdk.String("i-b"), Port: awssdk.Int64(8080)},
+								TargetHealth: &elbv2sdk.TargetHealth{State: awssdk.String(elbv2sdk.TargetHealthStateEnumHealthy)},
+							},
+						},
+					},
+				},
+			},
+			registerTargetsWithContextCalls: []registerTargetsWithContextCall{
+				{
+					req: &elbv2sdk.RegisterTargetsInput{
+						TargetGroupArn: awssdk.String("cloned-tg-arn"),
+						Targets:        []*elbv2sdk.TargetDescription{{Id: awssdk.String("i-a"), Port: awssdk.Int64(8080)}},
+					},
+				},
+			},
+			deregisterTargetsWithContextCalls: []deregisterTargetsWithContextCall{
+				{
+					req: &elbv2sdk.DeregisterTargetsInput{
+						TargetGroupArn: awssdk.String("cloned-tg-arn"),
+						Targets:        []*elbv2sdk.TargetDescription{{Id: awssdk.String("i-b"), Port: awssdk.Int64(8080)}},
+					},
+				},
+			},
+			want: elbv2model.LoadBalancerStatus{
+				LoadBalancerARN:       "lb-arn",
+				DNSName:               "lb.elb.amazonaws.com",
+				CanonicalHostedZoneID: "Z1",
+				Replacement: &elbv2model.LoadBalancerReplacementStatus{
+					ReplacedLoadBalancerARN: "replaced-lb-arn",
+					Phase:                   elbv2model.LoadBalancerReplacementPhaseDraining,
+					RetireTime:              &futureRetireTime,
+				},
+			},
+		},
+		{
+			name: "drain window of replaced LoadBalancer elapsed",
+			replacedLBTags: map[string]string{
+				LoadBalancerReplacementCutoverTagKey: "2026-01-01T00:00:00Z",
+				LoadBalancerReplacementRetireTagKey:  pastRetireTime.Format(time.RFC3339),
+			},
+			describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+				{
+					req: &elbv2sdk.DescribeTargetGroupsInput{},
+					resp: []*elbv2sdk.TargetGroup{
+						{TargetGroupArn: awssdk.String("tg-arn")},
+						{TargetGroupArn: awssdk.String("cloned-tg-arn")},
+					},
+				},
+			},
+			describeTagsWithContextCalls: []describeTagsWithContextCall{
+				{
+					req: &elbv2sdk.DescribeTagsInput{ResourceArns: awssdk.StringSlice([]string{"tg-arn", "cloned-tg-arn"})},
+					resp: &elbv2sdk.DescribeTagsOutput{
+						TagDescriptions: []*elbv2sdk.TagDescription{
+							{
+								ResourceArn: awssdk.String("cloned-tg-arn"),
+								Tags: []*elbv2sdk.Tag{
+									{Key: awssdk.String(ClonedTargetGroupTagKey), Value: awssdk.String("tg-arn")},
+									{Key: awssdk.String(ReplacedLoadBalancerTagKey), Value: awssdk.String("replaced-lb-arn")},
+								},
+							},
+						},
+					},
+				},
+			},
+			deleteLoadBalancerWithContextCalls: []deleteLoadBalancerWithContextCall{
+				{
+					req: &elbv2sdk.DeleteLoadBalancerInput{LoadBalancerArn: awssdk.String("replaced-lb-arn")},
+				},
+			},
+			deleteTargetGroupWithContextCalls: []deleteTargetGroupWithContextCall{
+				{
+					req: &elbv2sdk.DeleteTargetGroupInput{TargetGroupArn: awssdk.String("cloned-tg-arn")},
+				},
+			},
+			want: lbStatus,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			elbv2Client := mock_services.NewMockELBV2(ctrl)
+			for _, call := range tt.describeLoadBalancersAsListCalls {
+				elbv2Client.EXPECT().DescribeLoadBalancersAsList(gomock.Any(), call.req).Return(call.resp, nil)
+			}
+			for _, call := range tt.describeTargetGroupsAsListCalls {
+				elbv2Client.EXPECT().DescribeTargetGroupsAsList(gomock.Any(), call.req).Return(call.resp, nil)
+			}
+			for _, call := range tt.describeTagsWithContextCalls {
+				elbv2Client.EXPECT().DescribeTagsWithContext(gomock.Any(), call.req).Return(call.resp, nil)
+			}
+			for _, call := range tt.describeTargetHealthWithContextCalls {
+				elbv2Client.EXPECT().DescribeTargetHealthWithContext(gomock.Any(), call.req).Return(call.resp, nil)
+			}
+			for _, call := range tt.registerTargetsWithContextCalls {
+				elbv2Client.EXPECT().RegisterTargetsWithContext(gomock.Any(), call.req).Return(&elbv2sdk.RegisterTargetsOutput{}, nil)
+			}
+			for _, call := range tt.deregisterTargetsWithContextCalls {
+				elbv2Client.EXPECT().DeregisterTargetsWithContext(gomock.Any(), call.req).Return(&elbv2sdk.DeregisterTargetsOutput{}, nil)
+			}
+			for _, call := range tt.deleteLoadBalancerWithContextCalls {
+				elbv2Client.EXPECT().DeleteLoadBalancerWithContext(gomock.Any(), call.req).Return(&elbv2sdk.DeleteLoadBalancerOutput{}, nil)
+			}
+			for _, call := range tt.deleteTargetGroupWithContextCalls {
+				elbv2Client.EXPECT().DeleteTargetGroupWithContext(gomock.Any(), call.req).Return(&elbv2sdk.DeleteTargetGroupOutput{}, nil)
+			}
+			if tt.wantAddTags {
+				elbv2Client.EXPECT().AddTagsWithContext(gomock.Any(), gomock.Any()).Return(&elbv2sdk.AddTagsOutput{}, nil)
+			}
+
+			lbManager := NewDefaultLoadBalancerManager(elbv2Client, nil, nil, nil, log.NullLogger{})
+			tgManager := NewDefaultTargetGroupManager(elbv2Client, nil, nil, "vpc-id", log.NullLogger{})
+			taggingManager := NewDefaultTaggingManager(elbv2Client, nil, log.NullLogger{})
+			m := NewDefaultLoadBalancerReplacementManager(elbv2Client, lbManager, tgManager, taggingManager, log.NullLogger{})
+			stack := core.NewDefaultStack(core.StackID{Namespace: "awesome-ns", Name: "awesome-ing"})
+			resLB := elbv2model.NewLoadBalancer(stack, "LoadBalancer", elbv2model.LoadBalancerSpec{
+				Type: elbv2model.LoadBalancerTypeApplication,
+				Replacement: &elbv2model.LoadBalancerReplacement{
+					Strategy: elbv2model.LoadBalancerReplacementStrategyBlueGreen,
+				},
+			})
+			replacedLB := LoadBalancerWithTags{
+				LoadBalancer: &elbv2sdk.LoadBalancer{
+					LoadBalancerArn:       awssdk.String("replaced-lb-arn"),
+					DNSName:               awssdk.String("replaced-lb.elb.amazonaws.com"),
+					CanonicalHostedZoneId: awssdk.String("Z1"),
+					Type:                  awssdk.String("application"),
+				},
+				Tags: tt.replacedLBTags,
+			}
+			got, err := m.Reconcile(context.Background(), resLB, replacedLB, lbStatus)
+			assert.NoError(t, err)
+			if tt.wantAddTags {
+				// the retire time is derived from current time.
+				assert.NotNil(t, got.Replacement.RetireTime)
+				got.Replacement.RetireTime = nil
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_defaultLoadBalancerReplacementManager_cutover(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	elbv2Client := mock_services.NewMockELBV2(ctrl)
+	// listener ls-a is moved onto cloned-tg-a already, while cloned-tg-b is cloned but listener ls-b isn't moved yet.
+	elbv2Client.EXPECT().DescribeListenersAsList(gomock.Any(), &elbv2sdk.DescribeListenersInput{
+		LoadBalancerArn: awssdk.String("replaced-lb-arn"),
+	}).Return([]*elbv2sdk.Listener{
+		{
+			ListenerArn:    awssdk.String("ls-a"),
+			DefaultActions: []*elbv2sdk.Action{{Type: awssdk.String("forward"), TargetGroupArn: awssdk.String("cloned-tg-a")}},
+		},
+		{
+			ListenerArn:    awssdk.String("ls-b"),
+			DefaultActions: []*elbv2sdk.Action{{Type: awssdk.String("forward"), TargetGroupArn: awssdk.String("tg-b")}},
+		},
+	}, nil)
+	elbv2Client.EXPECT().DescribeTargetGroupsAsList(gomock.Any(), &elbv2sdk.DescribeTargetGroupsInput{}).Return([]*elbv2sdk.TargetGroup{
+		{TargetGroupArn: awssdk.String("cloned-tg-a")},
+		{TargetGroupArn: awssdk.String("cloned-tg-b")},
+		{TargetGroupArn: awssdk.String("tg-b")},
+	}, nil)
+	elbv2Client.EXPECT().DescribeTagsWithContext(gomock.Any(), gomock.Any()).Return(&elbv2sdk.DescribeTagsOutput{
+		TagDescriptions: []*elbv2sdk.TagDescription{
+			{
+				ResourceArn: awssdk.String("cloned-tg-a"),
+				Tags: []*elbv2sdk.Tag{
+					{Key: awssdk.String(ClonedTargetGroupTagKey), Value: awssdk.String("tg-a")},
+					{Key: awssdk.String(ReplacedLoadBalancerTagKey), Value: awssdk.String("replaced-lb-arn")},
+				},
+			},
+			{
+				ResourceArn: awssdk.String("cloned-tg-b"),
+				Tags: []*elbv2sdk.Tag{
+					{Key: awssdk.String(ClonedTargetGroupTagKey), Value: awssdk.String("tg-b")},
+					{Key: awssdk.String(ReplacedLoadBalancerTagKey), Value: awssdk.String("replaced-lb-arn")},
+				},
+			},
+		},
+	}, nil)
+	elbv2Client.EXPECT().ModifyListenerWithContext(gomock.Any(), &elbv2sdk.ModifyListenerInput{
+		ListenerArn:    awssdk.String("ls-b"),
+		DefaultActions: []*elbv2sdk.Action{{Type: awssdk.String("forward"), TargetGroupArn: awssdk.String("cloned-tg-b")}},
+	}).Return(&elbv2sdk.ModifyListenerOutput{}, nil)
+	// the cutover tag is "in-progress" already, so only the cutover time is tagged.
+	elbv2Client.EXPECT().AddTagsWithContext(gomock.Any(), gomock.Any()).Return(&elbv2sdk.AddTagsOutput{}, nil)
+
+	taggingManager := NewDefaultTaggingManager(elbv2Client, nil, log.NullLogger{})
+	m := NewDefaultLoadBalancerReplacementManager(elbv2Client, nil, nil, taggingManager, log.NullLogger{})
+	replacedLB := LoadBalancerWithTags{
+		LoadBalancer: &elbv2sdk.LoadBalancer{
+			LoadBalancerArn: awssdk.String("replaced-lb-arn"),
+			Type:            awssdk.String("network"),
+		},
+		Tags: map[string]string{
+			LoadBalancerReplacementCutoverTagKey: replacementCutoverInProgress,
+		},
+	}
+	assert.NoError(t, m.cutover(context.Background(), replacedLB))
+}
+
+func Test_replaceSDKActionsTargetGroups(t *testing.T) {
+	tests := []struct {
+		name         string
+		sdkActions   []*elbv2sdk.Action
+		tgARNMapping map[string]string
+		want         []*elbv2sdk.Action
+		wantReplaced bool
+	}{
+		{
+			name: "forward actions are replaced",
+			sdkActions: []*elbv2sdk.Action{
+				{
+					Type:           awssdk.String("forward"),
+					TargetGroupArn: awssdk.String("tg-a"),
+					ForwardConfig: &elbv2sdk.ForwardActionConfig{
+						TargetGroups: []*elbv2sdk.TargetGroupTuple{
+							{TargetGroupArn: awssdk.String("tg-a"), Weight: awssdk.Int64(1)},
+						},
+					},
+				},
+				{
+					Type: awssdk.String("forward"),
+					ForwardConfig: &elbv2sdk.ForwardActionConfig{
+						TargetGroups: []*elbv2sdk.TargetGroupTuple{
+							{TargetGroupArn: awssdk.String("tg-b"), Weight: awssdk.Int64(1)},
+							{TargetGroupArn: awssdk.String("tg-c"), Weight: awssdk.Int64(3)},
+						},
+					},
+				},
+			},
+			tgARNMapping: map[string]string{
+				"tg-a": "cloned-tg-a",
+				"tg-b": "cloned-tg-b",
+			},
+			want: []*elbv2sdk.Action{
+				{
+					Type:           awssdk.String("forward"),
+					TargetGroupArn: awssdk.String("cloned-tg-a"),
+					ForwardConfig: &elbv2sdk.ForwardActionConfig{
+						TargetGroups: []*elbv2sdk.TargetGroupTuple{
+							{TargetGroupArn: awssdk.String("cloned-tg-a"), Weight: awssdk.Int64(1)},
+						},
+					},
+				},
+				{
+					Type: awssdk.String("forward"),
+					ForwardConfig: &elbv2sdk.ForwardActionConfig{
+						TargetGroups: []*elbv2sdk.TargetGroupTuple{
+							{TargetGroupArn: awssdk.String("cloned-tg-b"), Weight: awssdk.Int64(1)},
+							{TargetGroupArn: awssdk.String("tg-c"), Weight: awssdk.Int64(3)},
+						},
+					},
+				},
+			},
+			wantReplaced: true,
+		},
+		{
+			name: "existing client secret is kept for authenticate actions",
+			sdkActions: []*elbv2sdk.Action{
+				{
+					Type: awssdk.String("authenticate-oidc"),
+					AuthenticateOidcConfig: &elbv2sdk.AuthenticateOidcActionConfig{
+						ClientId: awssdk.String("client-id"),
+					},
+				},
+				{
+					Type:           awssdk.String("forward"),
+					TargetGroupArn: awssdk.String("tg-a"),
+				},
+			},
+			tgARNMapping: map[string]string{
+				"tg-a": "cloned-tg-a",
+			},
+			want: []*elbv2sdk.Action{
+				{
+					Type: awssdk.String("authenticate-oidc"),
+					AuthenticateOidcConfig: &elbv2sdk.AuthenticateOidcActionConfig{
+						ClientId:                awssdk.String("client-id"),
+						UseExistingClientSecret: awssdk.Bool(true),
+					},
+				},
+				{
+					Type:           awssdk.String("forward"),
+					TargetGroupArn: awssdk.String("cloned-tg-a"),
+				},
+			},
+			wantReplaced: true,
+		},
+		{
+			name: "fixed response actions are kept",
+			sdkActions: []*elbv2sdk.Action{
+				{
+					Type: awssdk.String("fixed-response"),
+					FixedResponseConfig: &elbv2sdk.FixedResponseActionConfig{
+						StatusCode: awssdk.String("404"),
+					},
+				},
+			},
+			tgARNMapping: map[string]string{
+				"tg-a": "cloned-tg-a",
+			},
+			want: []*elbv2sdk.Action{
+				{
+					Type: awssdk.String("fixed-response"),
+					FixedResponseConfig: &elbv2sdk.FixedResponseActionConfig{
+						StatusCode: awssdk.String("404"),
+					},
+				},
+			},
+			wantReplaced: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotReplaced := replaceSDKActionsTargetGroups(tt.sdkActions, tt.tgARNMapping)
+			assert.Equal(t, tt.wantReplaced, gotReplaced)
+			assert.Equal(t, tt.want, tt.sdkActions)
+		})
+	}
+}
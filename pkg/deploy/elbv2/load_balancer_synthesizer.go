@@ -2,7 +2,11 @@ package elbv2
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -14,55 +18,81 @@ import (
 
 // NewLoadBalancerSynthesizer constructs loadBalancerSynthesizer
 func NewLoadBalancerSynthesizer(elbv2Client services.ELBV2, trackingProvider tracking.Provider, taggingManager TaggingManager,
-	lbManager LoadBalancerManager, logger logr.Logger, stack core.Stack) *loadBalancerSynthesizer {
+	lbManager LoadBalancerManager, lbReplacementManager LoadBalancerReplacementManager, logger logr.Logger, stack core.Stack) *loadBalancerSynthesizer {
 	return &loadBalancerSynthesizer{
-		elbv2Client:      elbv2Client,
-		trackingProvider: trackingProvider,
-		taggingManager:   taggingManager,
-		lbManager:        lbManager,
-		logger:           logger,
-		stack:            stack,
+		elbv2Client:          elbv2Client,
+		trackingProvider:     trackingProvider,
+		taggingManager:       taggingManager,
+		lbManager:            lbManager,
+		lbReplacementManager: lbReplacementManager,
+		logger:               logger,
+		stack:                stack,
 	}
 }
 
 // loadBalancerSynthesizer is responsible for synthesize LoadBalancer resources types for certain stack.
 type loadBalancerSynthesizer struct {
-	elbv2Client      services.ELBV2
-	trackingProvider tracking.Provider
-	taggingManager   TaggingManager
-	lbManager        LoadBalancerManager
-	logger           logr.Logger
+	elbv2Client          services.ELBV2
+	trackingProvider     tracking.Provider
+	taggingManager       TaggingManager
+	lbManager            LoadBalancerManager
+	lbReplacementManager LoadBalancerReplacementManager
+	logger               logr.Logger
 
 	stack core.Stack
 }
 
 func (s *loadBalancerSynthesizer) Synthesize(ctx context.Context) error {
-	var resLBs []*elbv2model.LoadBalancer
-	s.stack.ListResources(&resLBs)
+	var allResLBs []*elbv2model.LoadBalancer
+	s.stack.ListResources(&allResLBs)
+	resLBs, existingResLBs := partitionResLoadBalancersByExistence(allResLBs)
+	existingLBARNs := sets.NewString()
+	for _, resLB := range existingResLBs {
+		lbStatus, err := s.resolveExistingLoadBalancer(ctx, resLB)
+		if err != nil {
+			return err
+		}
+		resLB.SetStatus(lbStatus)
+		existingLBARNs.Insert(lbStatus.LoadBalancerARN)
+	}
 	sdkLBs, err := s.findSDKLoadBalancers(ctx)
 	if err != nil {
 		return err
 	}
+	// existing LoadBalancers are never deleted, even if they're tagged for stack.
+	sdkLBs = excludeSDKLoadBalancersByARN(sdkLBs, existingLBARNs)
 
 	matchedResAndSDKLBs, unmatchedResLBs, unmatchedSDKLBs, err := matchResAndSDKLoadBalancers(resLBs, sdkLBs, s.trackingProvider.ResourceIDTagKey())
 	if err != nil {
 		return err
 	}
+	// LoadBalancers replaced with blue/green strategy are kept until their replacements take over.
+	replacedSDKLBsByResID, unmatchedSDKLBs := partitionSDKLoadBalancersByBlueGreenReplacement(resLBs, unmatchedSDKLBs, s.trackingProvider.ResourceIDTagKey())
 
 	// For LoadBalancers, we delete unmatched ones first given below facts:
 	//  * LoadBalancer delete will automatically delete listeners attached to it.
 	//  * we can avoid the operation to detach a targetGroup from unmatched LBs. (a targetGroup can only attach to one LB).
 	// I don't like this, but it's the easiest solution to meet our requirement :D.
 	for _, sdkLB := range unmatchedSDKLBs {
+		if _, ok := sdkLB.Tags[LoadBalancerReplacementCutoverTagKey]; ok {
+			if err := s.lbReplacementManager.Delete(ctx, sdkLB); err != nil {
+				return err
+			}
+			continue
+		}
 		if err := s.lbManager.Delete(ctx, sdkLB); err != nil {
 			return err
 		}
 	}
 	for _, resLB := range unmatchedResLBs {
+		ensureDistinctReplacementName(resLB, replacedSDKLBsByResID[resLB.ID()])
 		lbStatus, err := s.lbManager.Create(ctx, resLB)
 		if err != nil {
 			return err
 		}
+		if lbStatus, err = s.reconcileBlueGreenReplacement(ctx, resLB, replacedSDKLBsByResID[resLB.ID()], lbStatus); err != nil {
+			return err
+		}
 		resLB.SetStatus(lbStatus)
 	}
 	for _, resAndSDKLB := range matchedResAndSDKLBs {
@@ -70,16 +100,68 @@ func (s *loadBalancerSynthesizer) Synthesize(ctx context.Context) error {
 		if err != nil {
 			return err
 		}
+		if lbStatus, err = s.reconcileBlueGreenReplacement(ctx, resAndSDKLB.resLB, replacedSDKLBsByResID[resAndSDKLB.resLB.ID()], lbStatus); err != nil {
+			return err
+		}
 		resAndSDKLB.resLB.SetStatus(lbStatus)
 	}
 	return nil
 }
 
+// ensureDistinctReplacementName derives a distinct name for resLB if its name is taken by any of replacedSDKLBs,
+// since the replaced LoadBalancers are kept until the replacement takes over, and LoadBalancer names are unique per region.
+// the derived name is stable per replaced LoadBalancer, so that retries after a failed creation won't create another LoadBalancer.
+func ensureDistinctReplacementName(resLB *elbv2model.LoadBalancer, replacedSDKLBs []LoadBalancerWithTags) {
+	for _, replacedSDKLB := range replacedSDKLBs {
+		if awssdk.StringValue(replacedSDKLB.LoadBalancer.LoadBalancerName) != resLB.Spec.Name {
+			continue
+		}
+		nameHash := sha256.Sum256([]byte(awssdk.StringValue(replacedSDKLB.LoadBalancer.LoadBalancerArn)))
+		resLB.Spec.Name = fmt.Sprintf("%.25s-%.6s", resLB.Spec.Name, hex.EncodeToString(nameHash[:]))
+		return
+	}
+}
+
+// reconcileBlueGreenReplacement progresses the replacement of replacedSDKLBs by the LoadBalancer with lbStatus,
+// and returns the status for resLB.
+func (s *loadBalancerSynthesizer) reconcileBlueGreenReplacement(ctx context.Context, resLB *elbv2model.LoadBalancer,
+	replacedSDKLBs []LoadBalancerWithTags, lbStatus elbv2model.LoadBalancerStatus) (elbv2model.LoadBalancerStatus, error) {
+	// only one replaced LoadBalancer is kept if the LoadBalancer is replaced again before its previous replacement completes.
+	for i, replacedSDKLB := range replacedSDKLBs {
+		if i == len(replacedSDKLBs)-1 {
+			break
+		}
+		if err := s.lbReplacementManager.Delete(ctx, replacedSDKLB); err != nil {
+			return elbv2model.LoadBalancerStatus{}, err
+		}
+	}
+	if len(replacedSDKLBs) == 0 {
+		return lbStatus, nil
+	}
+	return s.lbReplacementManager.Reconcile(ctx, resLB, replacedSDKLBs[len(replacedSDKLBs)-1], lbStatus)
+}
+
 func (s *loadBalancerSynthesizer) PostSynthesize(ctx context.Context) error {
 	// nothing to do here.
 	return nil
 }
 
+// resolveExistingLoadBalancer resolves the status of an existing LoadBalancer that isn't managed by controller.
+func (s *loadBalancerSynthesizer) resolveExistingLoadBalancer(ctx context.Context, resLB *elbv2model.LoadBalancer) (elbv2model.LoadBalancerStatus, error) {
+	lbARN := awssdk.StringValue(resLB.Spec.ExistingLoadBalancerARN)
+	req := &elbv2sdk.DescribeLoadBalancersInput{
+		LoadBalancerArns: awssdk.StringSlice([]string{lbARN}),
+	}
+	sdkLBs, err := s.elbv2Client.DescribeLoadBalancersAsList(ctx, req)
+	if err != nil {
+		return elbv2model.LoadBalancerStatus{}, errors.Wrapf(err, "failed to resolve existing loadBalancer: %v", lbARN)
+	}
+	if len(sdkLBs) == 0 {
+		return elbv2model.LoadBalancerStatus{}, errors.Errorf("existing loadBalancer not found: %v", lbARN)
+	}
+	return buildResLoadBalancerStatus(LoadBalancerWithTags{LoadBalancer: sdkLBs[0]}), nil
+}
+
 // findSDKLoadBalancers will find all AWS LoadBalancer created for stack.
 func (s *loadBalancerSynthesizer) findSDKLoadBalancers(ctx context.Context) ([]LoadBalancerWithTags, error) {
 	stackTags := s.trackingProvider.StackTags(s.stack)
@@ -137,6 +219,54 @@ func matchResAndSDKLoadBalancers(resLBs []*elbv2model.LoadBalancer, sdkLBs []Loa
 	return matchedResAndSDKLBs, unmatchedResLBs, unmatchedSDKLBs, nil
 }
 
+// partitionSDKLoadBalancersByBlueGreenReplacement partitions unmatched sdk LoadBalancers into the ones replaced by
+// LoadBalancer resources with blue/green strategy grouped by resourceID, and the others.
+// sdk LoadBalancers of a different type cannot share targetGroups with their replacements, they're always deleted first.
+func partitionSDKLoadBalancersByBlueGreenReplacement(resLBs []*elbv2model.LoadBalancer, unmatchedSDKLBs []LoadBalancerWithTags,
+	resourceIDTagKey string) (map[string][]LoadBalancerWithTags, []LoadBalancerWithTags) {
+	resLBsByID := mapResLoadBalancerByResourceID(resLBs)
+	replacedSDKLBsByResID := make(map[string][]LoadBalancerWithTags)
+	var otherSDKLBs []LoadBalancerWithTags
+	for _, sdkLB := range unmatchedSDKLBs {
+		resLB, ok := resLBsByID[sdkLB.Tags[resourceIDTagKey]]
+		if !ok || resLB.Spec.Replacement == nil || resLB.Spec.Replacement.Strategy != elbv2model.LoadBalancerReplacementStrategyBlueGreen ||
+			string(resLB.Spec.Type) != awssdk.StringValue(sdkLB.LoadBalancer.Type) {
+			otherSDKLBs = append(otherSDKLBs, sdkLB)
+			continue
+		}
+		replacedSDKLBsByResID[resLB.ID()] = append(replacedSDKLBsByResID[resLB.ID()], sdkLB)
+	}
+	return replacedSDKLBsByResID, otherSDKLBs
+}
+
+// partitionResLoadBalancersByExistence partitions LoadBalancer resources into the ones managed by controller and existing ones.
+func partitionResLoadBalancersByExistence(resLBs []*elbv2model.LoadBalancer) ([]*elbv2model.LoadBalancer, []*elbv2model.LoadBalancer) {
+	var managedResLBs []*elbv2model.LoadBalancer
+	var existingResLBs []*elbv2model.LoadBalancer
+	for _, resLB := range resLBs {
+		if resLB.Spec.ExistingLoadBalancerARN != nil {
+			existingResLBs = append(existingResLBs, resLB)
+		} else {
+			managedResLBs = append(managedResLBs, resLB)
+		}
+	}
+	return managedResLBs, existingResLBs
+}
+
+func excludeSDKLoadBalancersByARN(sdkLBs []LoadBalancerWithTags, lbARNs sets.String) []LoadBalancerWithTags {
+	if lbARNs.Len() == 0 {
+		return sdkLBs
+	}
+	var filteredSDKLBs []LoadBalancerWithTags
+	for _, sdkLB := range sdkLBs {
+		if lbARNs.Has(awssdk.StringValue(sdkLB.LoadBalancer.LoadBalancerArn)) {
+			continue
+		}
+		filteredSDKLBs = append(filteredSDKLBs, sdkLB)
+	}
+	return filteredSDKLBs
+}
+
 func mapResLoadBalancerByResourceID(resLBs []*elbv2model.LoadBalancer) map[string]*elbv2model.LoadBalancer {
 	resLBsByID := make(map[string]*elbv2model.LoadBalancer, len(resLBs))
 	for _, resLB := range resLBs {
@@ -165,5 +295,11 @@ func isSDKLoadBalancerRequiresReplacement(sdkLB LoadBalancerWithTags, resLB *elb
 	if resLB.Spec.Scheme != nil && string(*resLB.Spec.Scheme) != awssdk.StringValue(sdkLB.LoadBalancer.Scheme) {
 		return true
 	}
+	// securityGroups cannot be added to network loadBalancers created without securityGroups.
+	// it's only replaced when a replacement strategy is specified explicitly, otherwise updating it fails with LoadBalancerReplacementRequiredError.
+	if resLB.Spec.Replacement != nil && resLB.Spec.Type == elbv2model.LoadBalancerTypeNetwork &&
+		len(resLB.Spec.SecurityGroups) != 0 && len(sdkLB.LoadBalancer.SecurityGroups) == 0 {
+		return true
+	}
 	return false
 }
@@ -586,6 +586,76 @@ func Test_isSDKLoadBalancerRequiresReplacement(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "securityGroups added to network loadBalancer without replacement strategy",
+			args: args{
+				sdkLB: LoadBalancerWithTags{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						Type:             awssdk.String("network"),
+						Scheme:           awssdk.String("internet-facing"),
+						LoadBalancerName: awssdk.String("my-lb"),
+					},
+				},
+				resLB: &elbv2model.LoadBalancer{
+					Spec: elbv2model.LoadBalancerSpec{
+						Type:           elbv2model.LoadBalancerTypeNetwork,
+						Scheme:         &schemaInternetFacing,
+						Name:           "my-lb",
+						SecurityGroups: []coremodel.StringToken{coremodel.LiteralStringToken("sg-1")},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "securityGroups added to network loadBalancer with replacement strategy",
+			args: args{
+				sdkLB: LoadBalancerWithTags{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						Type:             awssdk.String("network"),
+						Scheme:           awssdk.String("internet-facing"),
+						LoadBalancerName: awssdk.String("my-lb"),
+					},
+				},
+				resLB: &elbv2model.LoadBalancer{
+					Spec: elbv2model.LoadBalancerSpec{
+						Type:           elbv2model.LoadBalancerTypeNetwork,
+						Scheme:         &schemaInternetFacing,
+						Name:           "my-lb",
+						SecurityGroups: []coremodel.StringToken{coremodel.LiteralStringToken("sg-1")},
+						Replacement: &elbv2model.LoadBalancerReplacement{
+							Strategy: elbv2model.LoadBalancerReplacementStrategyDeleteCreate,
+						},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "securityGroups changed on network loadBalancer with replacement strategy",
+			args: args{
+				sdkLB: LoadBalancerWithTags{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						Type:             awssdk.String("network"),
+						Scheme:           awssdk.String("internet-facing"),
+						LoadBalancerName: awssdk.String("my-lb"),
+						SecurityGroups:   awssdk.StringSlice([]string{"sg-2"}),
+					},
+				},
+				resLB: &elbv2model.LoadBalancer{
+					Spec: elbv2model.LoadBalancerSpec{
+						Type:           elbv2model.LoadBalancerTypeNetwork,
+						Scheme:         &schemaInternetFacing,
+						Name:           "my-lb",
+						SecurityGroups: []coremodel.StringToken{coremodel.LiteralStringToken("sg-1")},
+						Replacement: &elbv2model.LoadBalancerReplacement{
+							Strategy: elbv2model.LoadBalancerReplacementStrategyDeleteCreate,
+						},
+					},
+				},
+			},
+			want: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -594,3 +664,82 @@ func Test_isSDKLoadBalancerRequiresReplacement(t *testing.T) {
 		})
 	}
 }
+
+func Test_partitionSDKLoadBalancersByBlueGreenReplacement(t *testing.T) {
+	stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "namespace", Name: "name"})
+	resLBBlueGreen := elbv2model.NewLoadBalancer(stack, "id-1", elbv2model.LoadBalancerSpec{
+		Type: elbv2model.LoadBalancerTypeApplication,
+		Replacement: &elbv2model.LoadBalancerReplacement{
+			Strategy: elbv2model.LoadBalancerReplacementStrategyBlueGreen,
+		},
+	})
+	resLBDeleteCreate := elbv2model.NewLoadBalancer(stack, "id-2", elbv2model.LoadBalancerSpec{
+		Type: elbv2model.LoadBalancerTypeApplication,
+	})
+	sdkLB1 := LoadBalancerWithTags{
+		LoadBalancer: &elbv2sdk.LoadBalancer{LoadBalancerArn: awssdk.String("lb-1"), Type: awssdk.String("application")},
+		Tags:         map[string]string{"ingress.k8s.aws/resource": "id-1"},
+	}
+	sdkLB2 := LoadBalancerWithTags{
+		LoadBalancer: &elbv2sdk.LoadBalancer{LoadBalancerArn: awssdk.String("lb-2"), Type: awssdk.String("network")},
+		Tags:         map[string]string{"ingress.k8s.aws/resource": "id-1"},
+	}
+	sdkLB3 := LoadBalancerWithTags{
+		LoadBalancer: &elbv2sdk.LoadBalancer{LoadBalancerArn: awssdk.String("lb-3"), Type: awssdk.String("application")},
+		Tags:         map[string]string{"ingress.k8s.aws/resource": "id-2"},
+	}
+	sdkLB4 := LoadBalancerWithTags{
+		LoadBalancer: &elbv2sdk.LoadBalancer{LoadBalancerArn: awssdk.String("lb-4"), Type: awssdk.String("application")},
+		Tags:         map[string]string{"ingress.k8s.aws/resource": "id-3"},
+	}
+
+	gotReplacedSDKLBsByResID, gotOtherSDKLBs := partitionSDKLoadBalancersByBlueGreenReplacement(
+		[]*elbv2model.LoadBalancer{resLBBlueGreen, resLBDeleteCreate},
+		[]LoadBalancerWithTags{sdkLB1, sdkLB2, sdkLB3, sdkLB4}, "ingress.k8s.aws/resource")
+	assert.Equal(t, map[string][]LoadBalancerWithTags{"id-1": {sdkLB1}}, gotReplacedSDKLBsByResID)
+	assert.Equal(t, []LoadBalancerWithTags{sdkLB2, sdkLB3, sdkLB4}, gotOtherSDKLBs)
+}
+
+func Test_ensureDistinctReplacementName(t *testing.T) {
+	tests := []struct {
+		name           string
+		lbName         string
+		replacedSDKLBs []LoadBalancerWithTags
+		want           string
+	}{
+		{
+			name:   "name differs from replaced LoadBalancers",
+			lbName: "k8s-awesomeingress-9e5f0a1b2c",
+			replacedSDKLBs: []LoadBalancerWithTags{
+				{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						LoadBalancerArn:  awssdk.String("lb-1"),
+						LoadBalancerName: awssdk.String("k8s-awesomeingress-1a2b3c4d5e"),
+					},
+				},
+			},
+			want: "k8s-awesomeingress-9e5f0a1b2c",
+		},
+		{
+			name:   "name taken by replaced LoadBalancer",
+			lbName: "k8s-awesomeingress-9e5f0a1b2c",
+			replacedSDKLBs: []LoadBalancerWithTags{
+				{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						LoadBalancerArn:  awssdk.String("lb-1"),
+						LoadBalancerName: awssdk.String("k8s-awesomeingress-9e5f0a1b2c"),
+					},
+				},
+			},
+			want: "k8s-awesomeingress-9e5f0a-07e7b0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "namespace", Name: "name"})
+			resLB := elbv2model.NewLoadBalancer(stack, "LoadBalancer", elbv2model.LoadBalancerSpec{Name: tt.lbName})
+			ensureDistinctReplacementName(resLB, tt.replacedSDKLBs)
+			assert.Equal(t, tt.want, resLB.Spec.Name)
+		})
+	}
+}
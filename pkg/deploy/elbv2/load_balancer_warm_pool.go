@@ -0,0 +1,222 @@
+package elbv2
+
+import (
+	"context"
+	"fmt"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// LoadBalancerWarmPoolTagKey is the tag key for spare LoadBalancers in the warm pool, the tag value is the cluster name.
+	// the tag is replaced by the tracking tags of the stack once a spare LoadBalancer is claimed.
+	LoadBalancerWarmPoolTagKey = "elbv2.k8s.aws/warm-pool"
+
+	// the name prefix of spare LoadBalancers, names are immutable so claimed LoadBalancers keep it.
+	warmPoolLoadBalancerNamePrefix = "k8s-warmpool-"
+	// the name prefix of LoadBalancers named by the controller, LoadBalancers named otherwise never claim spare LoadBalancers.
+	generatedLoadBalancerNamePrefix = "k8s-"
+)
+
+// LoadBalancerWarmPool maintains spare application LoadBalancers without listeners, which are claimed by new stacks
+// instead of waiting for CreateLoadBalancer to provision new ones.
+// It runs as a leader election runnable that replenishes spare LoadBalancers periodically,
+// a pool of zero size deletes the spare LoadBalancers left from when it was enabled and stops.
+type LoadBalancerWarmPool interface {
+	manager.Runnable
+
+	// Claim claims a spare LoadBalancer compatible with resLB by replacing its tags with lbTags.
+	// It returns false if there is no compatible spare LoadBalancer, or resLB isn't named by the controller.
+	Claim(ctx context.Context, resLB *elbv2model.LoadBalancer, lbTags map[string]string) (LoadBalancerWithTags, bool, error)
+
+	// Replenish creates or deletes spare LoadBalancers until the pool reaches its size.
+	Replenish(ctx context.Context) error
+}
+
+// NewDefaultLoadBalancerWarmPool constructs new defaultLoadBalancerWarmPool.
+func NewDefaultLoadBalancerWarmPool(elbv2Client services.ELBV2, taggingManager TaggingManager, subnetsResolver networking.SubnetsResolver,
+	clusterName string, cfg config.ALBWarmPoolConfig, logger logr.Logger) *defaultLoadBalancerWarmPool {
+	return &defaultLoadBalancerWarmPool{
+		elbv2Client:       elbv2Client,
+		taggingManager:    taggingManager,
+		subnetsResolver:   subnetsResolver,
+		clusterName:       clusterName,
+		size:              cfg.Size,
+		scheme:            elbv2model.LoadBalancerScheme(cfg.Scheme),
+		replenishInterval: cfg.ReplenishInterval,
+		logger:            logger,
+	}
+}
+
+var _ LoadBalancerWarmPool = &defaultLoadBalancerWarmPool{}
+
+// default implementation for LoadBalancerWarmPool.
+// Spare LoadBalancers are tracked by the warm pool tag, a claimed LoadBalancer is owned by its stack like any other LoadBalancer.
+type defaultLoadBalancerWarmPool struct {
+	elbv2Client       services.ELBV2
+	taggingManager    TaggingManager
+	subnetsResolver   networking.SubnetsResolver
+	clusterName       string
+	size              int
+	scheme            elbv2model.LoadBalancerScheme
+	replenishInterval time.Duration
+	logger            logr.Logger
+
+	// poolMutex serializes claims and replenishes, so that concurrently deployed stacks won't claim the same LoadBalancer.
+	poolMutex sync.Mutex
+}
+
+// Start replenishes the pool periodically until stopped, or until the pool of zero size is drained.
+func (p *defaultLoadBalancerWarmPool) Start(stop <-chan struct{}) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	wait.Until(func() {
+		if err := p.Replenish(ctx); err != nil {
+			p.logger.Error(err, "failed to replenish loadBalancer warm pool")
+			return
+		}
+		if p.size == 0 {
+			p.logger.Info("drained loadBalancer warm pool")
+			cancel()
+		}
+	}, p.replenishInterval, ctx.Done())
+	return nil
+}
+
+func (p *defaultLoadBalancerWarmPool) Claim(ctx context.Context, resLB *elbv2model.LoadBalancer, lbTags map[string]string) (LoadBalancerWithTags, bool, error) {
+	if p.size == 0 || resLB.Spec.Type != elbv2model.LoadBalancerTypeApplication || (resLB.Spec.Scheme != nil && *resLB.Spec.Scheme != p.scheme) {
+		return LoadBalancerWithTags{}, false, nil
+	}
+	// claimed LoadBalancers keep the name of spare LoadBalancers, which is only acceptable if the name isn't chosen explicitly.
+	if !strings.HasPrefix(resLB.Spec.Name, generatedLoadBalancerNamePrefix) {
+		return LoadBalancerWithTags{}, false, nil
+	}
+	p.poolMutex.Lock()
+	defer p.poolMutex.Unlock()
+
+	spareLBs, err := p.listSpareLoadBalancers(ctx)
+	if err != nil {
+		return LoadBalancerWithTags{}, false, err
+	}
+	for _, spareLB := range spareLBs {
+		if !isSpareLoadBalancerActive(spareLB) {
+			continue
+		}
+		lbARN := awssdk.StringValue(spareLB.LoadBalancer.LoadBalancerArn)
+		if err := p.taggingManager.ReconcileTags(ctx, lbARN, lbTags, WithCurrentTags(spareLB.Tags)); err != nil {
+			return LoadBalancerWithTags{}, false, err
+		}
+		p.logger.Info("claimed loadBalancer from warm pool",
+			"stackID", resLB.Stack().StackID(),
+			"resourceID", resLB.ID(),
+			"arn", lbARN)
+		return LoadBalancerWithTags{
+			LoadBalancer: spareLB.LoadBalancer,
+			Tags:         lbTags,
+		}, true, nil
+	}
+	return LoadBalancerWithTags{}, false, nil
+}
+
+func (p *defaultLoadBalancerWarmPool) Replenish(ctx context.Context) error {
+	p.poolMutex.Lock()
+	defer p.poolMutex.Unlock()
+
+	spareLBs, err := p.listSpareLoadBalancers(ctx)
+	if err != nil {
+		return err
+	}
+	// spare LoadBalancers are deleted when the pool is shrunk or its scheme is changed.
+	var keptLBs []LoadBalancerWithTags
+	for _, spareLB := range spareLBs {
+		if len(keptLBs) < p.size && awssdk.StringValue(spareLB.LoadBalancer.Scheme) == string(p.scheme) {
+			keptLBs = append(keptLBs, spareLB)
+			continue
+		}
+		if err := p.deleteSpareLoadBalancer(ctx, spareLB); err != nil {
+			return err
+		}
+	}
+	for i := len(keptLBs); i < p.size; i++ {
+		if err := p.createSpareLoadBalancer(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listSpareLoadBalancers returns the unclaimed LoadBalancers in the pool.
+func (p *defaultLoadBalancerWarmPool) listSpareLoadBalancers(ctx context.Context) ([]LoadBalancerWithTags, error) {
+	return p.taggingManager.ListLoadBalancers(ctx, tracking.TagFilter{
+		LoadBalancerWarmPoolTagKey: {p.clusterName},
+	})
+}
+
+func (p *defaultLoadBalancerWarmPool) createSpareLoadBalancer(ctx context.Context) error {
+	subnets, err := p.subnetsResolver.ResolveViaDiscovery(ctx,
+		networking.WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeApplication),
+		networking.WithSubnetsResolveLBScheme(p.scheme),
+	)
+	if err != nil {
+		return err
+	}
+	req := &elbv2sdk.CreateLoadBalancerInput{
+		Name:   awssdk.String(fmt.Sprintf("%s%s", warmPoolLoadBalancerNamePrefix, rand.String(10))),
+		Type:   awssdk.String(string(elbv2model.LoadBalancerTypeApplication)),
+		Scheme: awssdk.String(string(p.scheme)),
+		Tags: convertTagsToSDKTags(map[string]string{
+			LoadBalancerWarmPoolTagKey: p.clusterName,
+		}),
+	}
+	for _, subnet := range subnets {
+		req.SubnetMappings = append(req.SubnetMappings, &elbv2sdk.SubnetMapping{
+			SubnetId: subnet.SubnetId,
+		})
+	}
+	p.logger.Info("creating spare loadBalancer for warm pool")
+	resp, err := p.elbv2Client.CreateLoadBalancerWithContext(ctx, req)
+	if err != nil {
+		return err
+	}
+	p.logger.Info("created spare loadBalancer for warm pool",
+		"arn", awssdk.StringValue(resp.LoadBalancers[0].LoadBalancerArn))
+	return nil
+}
+
+func (p *defaultLoadBalancerWarmPool) deleteSpareLoadBalancer(ctx context.Context, spareLB LoadBalancerWithTags) error {
+	req := &elbv2sdk.DeleteLoadBalancerInput{
+		LoadBalancerArn: spareLB.LoadBalancer.LoadBalancerArn,
+	}
+	p.logger.Info("deleting spare loadBalancer from warm pool",
+		"arn", awssdk.StringValue(req.LoadBalancerArn))
+	if _, err := p.elbv2Client.DeleteLoadBalancerWithContext(ctx, req); err != nil {
+		return err
+	}
+	p.logger.Info("deleted spare loadBalancer from warm pool",
+		"arn", awssdk.StringValue(req.LoadBalancerArn))
+	return nil
+}
+
+// isSpareLoadBalancerActive checks whether spare LoadBalancer has finished provisioning, only active ones are claimed.
+func isSpareLoadBalancerActive(spareLB LoadBalancerWithTags) bool {
+	return spareLB.LoadBalancer.State != nil && awssdk.StringValue(spareLB.LoadBalancer.State.Code) == elbv2sdk.LoadBalancerStateEnumActive
+}
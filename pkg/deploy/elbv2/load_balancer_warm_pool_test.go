@@ -0,0 +1,241 @@
+package elbv2
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	mock_networking "sigs.k8s.io/aws-load-balancer-controller/mocks/networking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	coremodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_defaultLoadBalancerWarmPool_Claim(t *testing.T) {
+	schemeInternetFacing := elbv2model.LoadBalancerSchemeInternetFacing
+	schemeInternal := elbv2model.LoadBalancerSchemeInternal
+	spareLBs := []*elbv2sdk.LoadBalancer{
+		{
+			LoadBalancerArn: awssdk.String("lb-provisioning"),
+			Scheme:          awssdk.String("internet-facing"),
+			State:           &elbv2sdk.LoadBalancerState{Code: awssdk.String(elbv2sdk.LoadBalancerStateEnumProvisioning)},
+		},
+		{
+			LoadBalancerArn: awssdk.String("lb-active"),
+			Scheme:          awssdk.String("internet-facing"),
+			State:           &elbv2sdk.LoadBalancerState{Code: awssdk.String(elbv2sdk.LoadBalancerStateEnumActive)},
+		},
+		{
+			LoadBalancerArn: awssdk.String("lb-other"),
+			Scheme:          awssdk.String("internet-facing"),
+			State:           &elbv2sdk.LoadBalancerState{Code: awssdk.String(elbv2sdk.LoadBalancerStateEnumActive)},
+		},
+	}
+	spareLBTagDescriptions := []*elbv2sdk.TagDescription{
+		{
+			ResourceArn: awssdk.String("lb-provisioning"),
+			Tags:        []*elbv2sdk.Tag{{Key: awssdk.String(LoadBalancerWarmPoolTagKey), Value: awssdk.String("my-cluster")}},
+		},
+		{
+			ResourceArn: awssdk.String("lb-active"),
+			Tags:        []*elbv2sdk.Tag{{Key: awssdk.String(LoadBalancerWarmPoolTagKey), Value: awssdk.String("my-cluster")}},
+		},
+		{
+			ResourceArn: awssdk.String("lb-other"),
+			Tags:        []*elbv2sdk.Tag{{Key: awssdk.String(LoadBalancerWarmPoolTagKey), Value: awssdk.String("other-cluster")}},
+		},
+	}
+	lbTags := map[string]string{
+		"elbv2.k8s.aws/cluster": "my-cluster",
+		"ingress.k8s.aws/stack": "my-group",
+	}
+
+	tests := []struct {
+		name        string
+		lbSpec      elbv2model.LoadBalancerSpec
+		wantLBARN   string
+		wantClaimed bool
+	}{
+		{
+			name: "claims active spare loadBalancer",
+			lbSpec: elbv2model.LoadBalancerSpec{
+				Name:   "k8s-mygroup-9e5f0a1b2c",
+				Type:   elbv2model.LoadBalancerTypeApplication,
+				Scheme: &schemeInternetFacing,
+			},
+			wantLBARN:   "lb-active",
+			wantClaimed: true,
+		},
+		{
+			name: "skips loadBalancer with different scheme",
+			lbSpec: elbv2model.LoadBalancerSpec{
+				Type:   elbv2model.LoadBalancerTypeApplication,
+				Scheme: &schemeInternal,
+			},
+			wantClaimed: false,
+		},
+		{
+			name: "skips explicitly named loadBalancer",
+			lbSpec: elbv2model.LoadBalancerSpec{
+				Name:   "my-alb",
+				Type:   elbv2model.LoadBalancerTypeApplication,
+				Scheme: &schemeInternetFacing,
+			},
+			wantClaimed: false,
+		},
+		{
+			name: "skips network loadBalancer",
+			lbSpec: elbv2model.LoadBalancerSpec{
+				Type:   elbv2model.LoadBalancerTypeNetwork,
+				Scheme: &schemeInternetFacing,
+			},
+			wantClaimed: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			elbv2Client := mock_services.NewMockELBV2(ctrl)
+			if tt.wantClaimed {
+				elbv2Client.EXPECT().DescribeLoadBalancersAsList(gomock.Any(), gomock.Any()).Return(spareLBs, nil)
+				elbv2Client.EXPECT().DescribeTagsWithContext(gomock.Any(), gomock.Any()).Return(&elbv2sdk.DescribeTagsOutput{
+					TagDescriptions: spareLBTagDescriptions,
+				}, nil)
+				elbv2Client.EXPECT().AddTagsWithContext(gomock.Any(), &elbv2sdk.AddTagsInput{
+					ResourceArns: awssdk.StringSlice([]string{tt.wantLBARN}),
+					Tags:         convertTagsToSDKTags(lbTags),
+				}).Return(&elbv2sdk.AddTagsOutput{}, nil)
+				elbv2Client.EXPECT().RemoveTagsWithContext(gomock.Any(), &elbv2sdk.RemoveTagsInput{
+					ResourceArns: awssdk.StringSlice([]string{tt.wantLBARN}),
+					TagKeys:      awssdk.StringSlice([]string{LoadBalancerWarmPoolTagKey}),
+				}).Return(&elbv2sdk.RemoveTagsOutput{}, nil)
+			}
+			taggingManager := NewDefaultTaggingManager(elbv2Client, nil, log.Log)
+			pool := NewDefaultLoadBalancerWarmPool(elbv2Client, taggingManager, nil, "my-cluster", config.ALBWarmPoolConfig{
+				Size:              2,
+				Scheme:            "internet-facing",
+				ReplenishInterval: time.Minute,
+			}, log.Log)
+
+			stack := coremodel.NewDefaultStack(coremodel.StackID{Name: "my-group"})
+			resLB := elbv2model.NewLoadBalancer(stack, "LoadBalancer", tt.lbSpec)
+			got, claimed, err := pool.Claim(context.Background(), resLB, lbTags)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantClaimed, claimed)
+			if tt.wantClaimed {
+				assert.Equal(t, tt.wantLBARN, awssdk.StringValue(got.LoadBalancer.LoadBalancerArn))
+				assert.Equal(t, lbTags, got.Tags)
+			}
+		})
+	}
+}
+
+func Test_defaultLoadBalancerWarmPool_Replenish(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	elbv2Client := mock_services.NewMockELBV2(ctrl)
+	elbv2Client.EXPECT().DescribeLoadBalancersAsList(gomock.Any(), gomock.Any()).Return([]*elbv2sdk.LoadBalancer{
+		{
+			LoadBalancerArn: awssdk.String("lb-internet-facing"),
+			Scheme:          awssdk.String("internet-facing"),
+		},
+		{
+			LoadBalancerArn: awssdk.String("lb-internal"),
+			Scheme:          awssdk.String("internal"),
+		},
+	}, nil)
+	elbv2Client.EXPECT().DescribeTagsWithContext(gomock.Any(), gomock.Any()).Return(&elbv2sdk.DescribeTagsOutput{
+		TagDescriptions: []*elbv2sdk.TagDescription{
+			{
+				ResourceArn: awssdk.String("lb-internet-facing"),
+				Tags:        []*elbv2sdk.Tag{{Key: awssdk.String(LoadBalancerWarmPoolTagKey), Value: awssdk.String("my-cluster")}},
+			},
+			{
+				ResourceArn: awssdk.String("lb-internal"),
+				Tags:        []*elbv2sdk.Tag{{Key: awssdk.String(LoadBalancerWarmPoolTagKey), Value: awssdk.String("my-cluster")}},
+			},
+		},
+	}, nil)
+	elbv2Client.EXPECT().DeleteLoadBalancerWithContext(gomock.Any(), &elbv2sdk.DeleteLoadBalancerInput{
+		LoadBalancerArn: awssdk.String("lb-internal"),
+	}).Return(&elbv2sdk.DeleteLoadBalancerOutput{}, nil)
+	elbv2Client.EXPECT().CreateLoadBalancerWithContext(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, req *elbv2sdk.CreateLoadBalancerInput, opts ...interface{}) (*elbv2sdk.CreateLoadBalancerOutput, error) {
+			assert.True(t, strings.HasPrefix(awssdk.StringValue(req.Name), "k8s-warmpool-"))
+			assert.Equal(t, "internet-facing", awssdk.StringValue(req.Scheme))
+			assert.Equal(t, "application", awssdk.StringValue(req.Type))
+			assert.Equal(t, []*elbv2sdk.SubnetMapping{{SubnetId: awssdk.String("subnet-a")}, {SubnetId: awssdk.String("subnet-b")}}, req.SubnetMappings)
+			assert.Equal(t, []*elbv2sdk.Tag{{Key: awssdk.String(LoadBalancerWarmPoolTagKey), Value: awssdk.String("my-cluster")}}, req.Tags)
+			return &elbv2sdk.CreateLoadBalancerOutput{
+				LoadBalancers: []*elbv2sdk.LoadBalancer{{LoadBalancerArn: awssdk.String("lb-new")}},
+			}, nil
+		})
+	subnetsResolver := mock_networking.NewMockSubnetsResolver(ctrl)
+	subnetsResolver.EXPECT().ResolveViaDiscovery(gomock.Any(), gomock.Any(), gomock.Any()).Return([]*ec2sdk.Subnet{
+		{SubnetId: awssdk.String("subnet-a")},
+		{SubnetId: awssdk.String("subnet-b")},
+	}, nil)
+
+	taggingManager := NewDefaultTaggingManager(elbv2Client, nil, log.Log)
+	pool := NewDefaultLoadBalancerWarmPool(elbv2Client, taggingManager, subnetsResolver, "my-cluster", config.ALBWarmPoolConfig{
+		Size:              2,
+		Scheme:            "internet-facing",
+		ReplenishInterval: time.Minute,
+	}, log.Log)
+	assert.NoError(t, pool.Replenish(context.Background()))
+}
+
+func Test_defaultLoadBalancerWarmPool_Start_drainsPoolOfZeroSize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	elbv2Client := mock_services.NewMockELBV2(ctrl)
+	elbv2Client.EXPECT().DescribeLoadBalancersAsList(gomock.Any(), gomock.Any()).Return([]*elbv2sdk.LoadBalancer{
+		{
+			LoadBalancerArn: awssdk.String("lb-spare"),
+			Scheme:          awssdk.String("internet-facing"),
+		},
+	}, nil)
+	elbv2Client.EXPECT().DescribeTagsWithContext(gomock.Any(), gomock.Any()).Return(&elbv2sdk.DescribeTagsOutput{
+		TagDescriptions: []*elbv2sdk.TagDescription{
+			{
+				ResourceArn: awssdk.String("lb-spare"),
+				Tags:        []*elbv2sdk.Tag{{Key: awssdk.String(LoadBalancerWarmPoolTagKey), Value: awssdk.String("my-cluster")}},
+			},
+		},
+	}, nil)
+	elbv2Client.EXPECT().DeleteLoadBalancerWithContext(gomock.Any(), &elbv2sdk.DeleteLoadBalancerInput{
+		LoadBalancerArn: awssdk.String("lb-spare"),
+	}).Return(&elbv2sdk.DeleteLoadBalancerOutput{}, nil)
+
+	taggingManager := NewDefaultTaggingManager(elbv2Client, nil, log.Log)
+	pool := NewDefaultLoadBalancerWarmPool(elbv2Client, taggingManager, nil, "my-cluster", config.ALBWarmPoolConfig{
+		Size:              0,
+		Scheme:            "internet-facing",
+		ReplenishInterval: time.Minute,
+	}, log.Log)
+	// Start returns once the pool is drained, without waiting to be stopped.
+	stop := make(chan struct{})
+	defer close(stop)
+	assert.NoError(t, pool.Start(stop))
+
+	// spare loadBalancers are never claimed by a pool of zero size.
+	stack := coremodel.NewDefaultStack(coremodel.StackID{Name: "my-group"})
+	resLB := elbv2model.NewLoadBalancer(stack, "LoadBalancer", elbv2model.LoadBalancerSpec{
+		Name: "k8s-mygroup-9e5f0a1b2c",
+		Type: elbv2model.LoadBalancerTypeApplication,
+	})
+	_, claimed, err := pool.Claim(context.Background(), resLB, nil)
+	assert.NoError(t, err)
+	assert.False(t, claimed)
+}
@@ -0,0 +1,515 @@
+package elbv2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	s3sdk "github.com/aws/aws-sdk-go/service/s3"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"regexp"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
+	"strings"
+	"time"
+)
+
+const (
+	defaultBucketPolicyCacheTTL = 5 * time.Minute
+
+	logTypeAccessLogs     = "access_logs"
+	logTypeConnectionLogs = "connection_logs"
+
+	// service principal that delivers ALB logs in regions launched after August 2022.
+	albLogDeliveryServicePrincipal = "logdelivery.elasticloadbalancing.amazonaws.com"
+	// service principal that delivers NLB access logs.
+	nlbLogDeliveryServicePrincipal = "delivery.logs.amazonaws.com"
+
+	s3ErrCodeNoSuchBucket       = "NoSuchBucket"
+	s3ErrCodeNoSuchBucketPolicy = "NoSuchBucketPolicy"
+)
+
+// elbAccountIDByRegion are the AWS accounts of Elastic Load Balancing that deliver ALB logs in each region.
+// see https://docs.aws.amazon.com/elasticloadbalancing/latest/application/enable-access-logging.html
+var elbAccountIDByRegion = map[string]string{
+	"us-east-1":      "127311923021",
+	"us-east-2":      "033677994240",
+	"us-west-1":      "027434742980",
+	"us-west-2":      "797873946194",
+	"af-south-1":     "098369216593",
+	"ap-east-1":      "754344448648",
+	"ap-southeast-3": "589379963580",
+	"ap-south-1":     "718504428378",
+	"ap-northeast-3": "383597477331",
+	"ap-northeast-2": "600734575887",
+	"ap-southeast-1": "114774131450",
+	"ap-southeast-2": "783225319266",
+	"ap-northeast-1": "582318560864",
+	"ca-central-1":   "985666609251",
+	"eu-central-1":   "054676820928",
+	"eu-west-1":      "156460612806",
+	"eu-west-2":      "652711504416",
+	"eu-south-1":     "635631232127",
+	"eu-west-3":      "009996457667",
+	"eu-north-1":     "897822967062",
+	"me-south-1":     "076674570225",
+	"sa-east-1":      "507241528517",
+	"us-gov-west-1":  "048591011584",
+	"us-gov-east-1":  "190560391635",
+	"cn-north-1":     "638102146993",
+	"cn-northwest-1": "037604701340",
+}
+
+// LogBucketValidator validates whether S3 buckets configured for LoadBalancer logs permit log delivery.
+type LogBucketValidator interface {
+	// Validate returns an error describing why log delivery to S3 buckets configured for LoadBalancer would fail.
+	Validate(ctx context.Context, resLB *elbv2model.LoadBalancer) error
+}
+
+// NewDefaultLogBucketValidator constructs new defaultLogBucketValidator.
+func NewDefaultLogBucketValidator(s3Client services.S3, region string, logger logr.Logger) *defaultLogBucketValidator {
+	return &defaultLogBucketValidator{
+		s3Client:             s3Client,
+		region:               region,
+		logger:               logger,
+		bucketPolicyCache:    cache.NewExpiring(),
+		bucketPolicyCacheTTL: defaultBucketPolicyCacheTTL,
+	}
+}
+
+var _ LogBucketValidator = &defaultLogBucketValidator{}
+
+// default implementation for LogBucketValidator, which validates the bucket policy of S3 buckets.
+// It's a best-effort check: bucket policies that cannot be retrieved, or that only allow log delivery under
+// conditions that cannot be evaluated, are not validated.
+type defaultLogBucketValidator struct {
+	s3Client services.S3
+	region   string
+	logger   logr.Logger
+
+	// bucketPolicyCache caches the bucket policies, keyed by bucket name.
+	// concurrent lookups of the same bucket share a single retrieval via bucketPolicyFlight.
+	bucketPolicyCache    *cache.Expiring
+	bucketPolicyCacheTTL time.Duration
+	bucketPolicyFlight   runtime.SingleFlight
+}
+
+// logBucketPolicy is the bucket policy of S3 bucket for log delivery.
+type logBucketPolicy struct {
+	// bucketExists is false if the bucket doesn't exist.
+	bucketExists bool
+	// document is nil if the bucket has no bucket policy.
+	document *bucketPolicyDocument
+}
+
+func (v *defaultLogBucketValidator) Validate(ctx context.Context, resLB *elbv2model.LoadBalancer) error {
+	lbAttributes := make(map[string]string, len(resLB.Spec.LoadBalancerAttributes))
+	for _, attr := range resLB.Spec.LoadBalancerAttributes {
+		lbAttributes[attr.Key] = attr.Value
+	}
+	logTypes := []string{logTypeAccessLogs}
+	if resLB.Spec.Type == elbv2model.LoadBalancerTypeApplication {
+		logTypes = append(logTypes, logTypeConnectionLogs)
+	}
+	for _, logType := range logTypes {
+		if lbAttributes[logType+".s3.enabled"] != "true" {
+			continue
+		}
+		bucket := lbAttributes[logType+".s3.bucket"]
+		prefix := lbAttributes[logType+".s3.prefix"]
+		if bucket == "" {
+			return errors.Errorf("%v.s3.bucket must be specified when %v.s3.enabled is true", logType, logType)
+		}
+		if err := v.validateBucket(ctx, resLB.Spec.Type, logType, bucket, prefix); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *defaultLogBucketValidator) validateBucket(ctx context.Context, lbType elbv2model.LoadBalancerType, logType string, bucket string, prefix string) error {
+	principals := logDeliveryPrincipals(lbType, v.region)
+	bucketPolicy, err := v.fetchBucketPolicy(ctx, bucket)
+	if err != nil {
+		v.logger.Info("unable to validate S3 bucket policy for log delivery", "bucket", bucket, "error", err)
+		return nil
+	}
+	if !bucketPolicy.bucketExists {
+		return errors.Errorf("S3 bucket %v for %v doesn't exist", bucket, logType)
+	}
+	if bucketPolicy.document == nil {
+		return errors.Errorf("S3 bucket %v for %v has no bucket policy, it must allow s3:PutObject for %v",
+			bucket, logType, strings.Join(principals, " or "))
+	}
+
+	objectARN := buildLogObjectARN(v.region, bucket, prefix)
+	sampleObjectARN := func(accountID string) string {
+		return objectARN + buildSampleLogObjectKey(lbType, v.region, accountID, time.Now().UTC())
+	}
+	switch bucketPolicy.document.evaluatePutObject(principals, sampleObjectARN, logDeliveryConditionValues(lbType)) {
+	case policyDecisionExplicitDeny:
+		return errors.Errorf("S3 bucket %v policy denies s3:PutObject on %v for %v, see https://docs.aws.amazon.com/elasticloadbalancing/latest/application/enable-access-logging.html",
+			bucket, objectARN, strings.Join(principals, " or "))
+	case policyDecisionImplicitDeny:
+		return errors.Errorf("S3 bucket %v policy doesn't allow s3:PutObject on %v for %v, see https://docs.aws.amazon.com/elasticloadbalancing/latest/application/enable-access-logging.html",
+			bucket, objectARN, strings.Join(principals, " or "))
+	case policyDecisionUndetermined:
+		v.logger.Info("unable to validate S3 bucket policy for log delivery, it's only allowed under conditions that cannot be evaluated", "bucket", bucket)
+	}
+	return nil
+}
+
+// fetchBucketPolicy returns the bucket policy of bucket, results are served from cache when possible.
+func (v *defaultLogBucketValidator) fetchBucketPolicy(ctx context.Context, bucket string) (*logBucketPolicy, error) {
+	if rawCacheItem, exists := v.bucketPolicyCache.Get(bucket); exists {
+		return rawCacheItem.(*logBucketPolicy), nil
+	}
+	rawBucketPolicy, err := v.bucketPolicyFlight.Do(bucket, func() (interface{}, error) {
+		return v.fetchBucketPolicyFromAWS(ctx, bucket)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rawBucketPolicy.(*logBucketPolicy), nil
+}
+
+// fetchBucketPolicyFromAWS retrieves the bucket policy of bucket from S3, the results are cached.
+// missing buckets and bucket policies are cached as well, while other failures are not.
+func (v *defaultLogBucketValidator) fetchBucketPolicyFromAWS(ctx context.Context, bucket string) (*logBucketPolicy, error) {
+	bucketPolicy := &logBucketPolicy{bucketExists: true}
+	resp, err := v.s3Client.GetBucketPolicyWithContext(ctx, &s3sdk.GetBucketPolicyInput{
+		Bucket: awssdk.String(bucket),
+	})
+	if err != nil {
+		var awsErr awserr.Error
+		if !errors.As(err, &awsErr) {
+			return nil, err
+		}
+		switch awsErr.Code() {
+		case s3ErrCodeNoSuchBucket:
+			bucketPolicy.bucketExists = false
+		case s3ErrCodeNoSuchBucketPolicy:
+		default:
+			return nil, err
+		}
+	} else {
+		var document bucketPolicyDocument
+		if err := json.Unmarshal([]byte(awssdk.StringValue(resp.Policy)), &document); err != nil {
+			return nil, errors.Wrap(err, "failed to parse bucket policy")
+		}
+		bucketPolicy.document = &document
+	}
+	v.bucketPolicyCache.Set(bucket, bucketPolicy, v.bucketPolicyCacheTTL)
+	return bucketPolicy, nil
+}
+
+// logDeliveryPrincipals returns the principals that deliver logs for specified LoadBalancer type and region.
+// AWS principals are represented by account root ARNs, and service principals by their service names.
+func logDeliveryPrincipals(lbType elbv2model.LoadBalancerType, region string) []string {
+	if lbType == elbv2model.LoadBalancerTypeNetwork {
+		return []string{nlbLogDeliveryServicePrincipal}
+	}
+	var principals []string
+	if accountID, ok := elbAccountIDByRegion[region]; ok {
+		principals = append(principals, fmt.Sprintf("arn:%v:iam::%v:root", partitionForRegion(region), accountID))
+	}
+	return append(principals, albLogDeliveryServicePrincipal)
+}
+
+// logDeliveryConditionValues returns the values of policy condition keys known for log delivery, keyed by lower-cased condition key.
+// log objects are delivered over TLS, and NLB log delivery grants the bucket owner full control over them.
+func logDeliveryConditionValues(lbType elbv2model.LoadBalancerType) map[string]string {
+	conditionValues := map[string]string{
+		"aws:securetransport": "true",
+	}
+	if lbType == elbv2model.LoadBalancerTypeNetwork {
+		conditionValues["s3:x-amz-acl"] = "bucket-owner-full-control"
+	}
+	return conditionValues
+}
+
+// buildLogObjectARN builds the prefix of ARN for log objects, the AWS account and remaining path are appended during matching.
+func buildLogObjectARN(region string, bucket string, prefix string) string {
+	keyPrefix := ""
+	if prefix != "" {
+		keyPrefix = strings.Trim(prefix, "/") + "/"
+	}
+	return fmt.Sprintf("arn:%v:s3:::%v/%vAWSLogs/", partitionForRegion(region), bucket, keyPrefix)
+}
+
+// buildSampleLogObjectKey builds the key of a log object under AWSLogs/ the way ELB delivers it, i.e.
+// <account>/elasticloadbalancing/<region>/<yyyy>/<mm>/<dd>/<account>_elasticloadbalancing_<region>_<lb>_<end-time>_<ip>_<random>.log.gz
+func buildSampleLogObjectKey(lbType elbv2model.LoadBalancerType, region string, accountID string, deliveryTime time.Time) string {
+	lbID := "app.my-loadbalancer.1234567890abcdef"
+	if lbType == elbv2model.LoadBalancerTypeNetwork {
+		lbID = "net.my-loadbalancer.1234567890abcdef"
+	}
+	fileName := fmt.Sprintf("%v_elasticloadbalancing_%v_%v_%v_192.0.2.1_2soosksgqb9idsbh.log.gz",
+		accountID, region, lbID, deliveryTime.Format("20060102T1504Z"))
+	return fmt.Sprintf("%v/elasticloadbalancing/%v/%v/%v", accountID, region, deliveryTime.Format("2006/01/02"), fileName)
+}
+
+func partitionForRegion(region string) string {
+	switch {
+	case strings.HasPrefix(region, "cn-"):
+		return "aws-cn"
+	case strings.HasPrefix(region, "us-gov-"):
+		return "aws-us-gov"
+	default:
+		return "aws"
+	}
+}
+
+// bucketPolicyDocument is the subset of S3 bucket policy needed for log delivery validation.
+type bucketPolicyDocument struct {
+	Statement bucketPolicyStatements `json:"Statement"`
+}
+
+type bucketPolicyStatement struct {
+	Effect    string                `json:"Effect"`
+	Principal bucketPolicyPrincipal `json:"Principal"`
+	Action    stringOrSlice         `json:"Action"`
+	Resource  stringOrSlice         `json:"Resource"`
+	// Condition is keyed by condition operator and then condition key.
+	Condition map[string]map[string]conditionValues `json:"Condition"`
+}
+
+// bucketPolicyStatements accepts both single statement and list of statements.
+type bucketPolicyStatements []bucketPolicyStatement
+
+func (s *bucketPolicyStatements) UnmarshalJSON(data []byte) error {
+	var statements []bucketPolicyStatement
+	if err := json.Unmarshal(data, &statements); err == nil {
+		*s = statements
+		return nil
+	}
+	var statement bucketPolicyStatement
+	if err := json.Unmarshal(data, &statement); err != nil {
+		return err
+	}
+	*s = []bucketPolicyStatement{statement}
+	return nil
+}
+
+// bucketPolicyPrincipal accepts both wildcard principal and principal map.
+type bucketPolicyPrincipal struct {
+	Wildcard bool
+	AWS      stringOrSlice
+	Service  stringOrSlice
+}
+
+func (p *bucketPolicyPrincipal) UnmarshalJSON(data []byte) error {
+	var wildcard string
+	if err := json.Unmarshal(data, &wildcard); err == nil {
+		p.Wildcard = wildcard == "*"
+		return nil
+	}
+	var principals struct {
+		AWS     stringOrSlice `json:"AWS"`
+		Service stringOrSlice `json:"Service"`
+	}
+	if err := json.Unmarshal(data, &principals); err != nil {
+		return err
+	}
+	p.AWS = principals.AWS
+	p.Service = principals.Service
+	return nil
+}
+
+// stringOrSlice accepts both single string and list of strings.
+type stringOrSlice []string
+
+func (s *stringOrSlice) UnmarshalJSON(data []byte) error {
+	var values []string
+	if err := json.Unmarshal(data, &values); err == nil {
+		*s = values
+		return nil
+	}
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*s = []string{value}
+	return nil
+}
+
+// conditionValues accepts both single value and list of values, where values can be strings, booleans or numbers.
+type conditionValues []string
+
+func (s *conditionValues) UnmarshalJSON(data []byte) error {
+	var values []interface{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		var value interface{}
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+		values = []interface{}{value}
+	}
+	*s = make([]string, 0, len(values))
+	for _, value := range values {
+		*s = append(*s, fmt.Sprint(value))
+	}
+	return nil
+}
+
+// policyDecision is the result of evaluating a bucket policy for a request.
+type policyDecision int
+
+const (
+	// policyDecisionImplicitDeny means no statement allows the request.
+	policyDecisionImplicitDeny policyDecision = iota
+	// policyDecisionAllow means a statement allows the request, and no statement denies it.
+	policyDecisionAllow
+	// policyDecisionExplicitDeny means a statement denies the request.
+	policyDecisionExplicitDeny
+	// policyDecisionUndetermined means statements only allow the request under conditions that cannot be evaluated.
+	policyDecisionUndetermined
+)
+
+// conditionMatch is the result of evaluating the conditions of a statement.
+type conditionMatch int
+
+const (
+	conditionMatchTrue conditionMatch = iota
+	conditionMatchFalse
+	conditionMatchUnknown
+)
+
+var logAccountIDPattern = regexp.MustCompile(`AWSLogs/(\d{12})/`)
+
+// evaluatePutObject evaluates s3:PutObject on log objects for any of principals, with the known values of condition keys.
+// sampleObjectARN builds the ARN of a log object delivered for specified AWS account.
+// explicit denies take precedence over allows. Deny statements with conditions that cannot be evaluated are ignored,
+// since the check must not report failures that may not happen.
+func (d *bucketPolicyDocument) evaluatePutObject(principals []string, sampleObjectARN func(accountID string) string, conditionValues map[string]string) policyDecision {
+	decision := policyDecisionImplicitDeny
+	for _, statement := range d.Statement {
+		if !statement.Principal.matchesAny(principals) {
+			continue
+		}
+		if !matchesAnyPattern(statement.Action, "s3:PutObject", true) {
+			continue
+		}
+		if !matchesAnyLogObjectResource(statement.Resource, sampleObjectARN) {
+			continue
+		}
+		match := statement.evaluateConditions(conditionValues)
+		switch {
+		case statement.Effect == "Deny" && match == conditionMatchTrue:
+			return policyDecisionExplicitDeny
+		case statement.Effect == "Allow" && match == conditionMatchTrue:
+			decision = policyDecisionAllow
+		case statement.Effect == "Allow" && match == conditionMatchUnknown && decision == policyDecisionImplicitDeny:
+			decision = policyDecisionUndetermined
+		}
+	}
+	return decision
+}
+
+// matchesAnyLogObjectResource checks whether any of resources matches log objects.
+func matchesAnyLogObjectResource(resources []string, sampleObjectARN func(accountID string) string) bool {
+	for _, resource := range resources {
+		// policies may restrict log delivery to specific AWS account, use the same account for matching.
+		accountID := "000000000000"
+		if match := logAccountIDPattern.FindStringSubmatch(resource); match != nil {
+			accountID = match[1]
+		}
+		if matchesPattern(resource, sampleObjectARN(accountID), false) {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluateConditions evaluates the conditions of statement, all conditions must match for the statement to apply.
+// conditions on keys without known values, or with unsupported operators, cannot be evaluated.
+func (s *bucketPolicyStatement) evaluateConditions(knownValues map[string]string) conditionMatch {
+	match := conditionMatchTrue
+	for operator, valuesByKey := range s.Condition {
+		for key, values := range valuesByKey {
+			value, ok := knownValues[strings.ToLower(key)]
+			if !ok {
+				match = conditionMatchUnknown
+				continue
+			}
+			matched, supported := evaluateConditionOperator(operator, value, values)
+			if !supported {
+				match = conditionMatchUnknown
+				continue
+			}
+			if !matched {
+				return conditionMatchFalse
+			}
+		}
+	}
+	return match
+}
+
+// evaluateConditionOperator evaluates condition operator against value, it returns whether the operator is supported as well.
+func evaluateConditionOperator(operator string, value string, values []string) (bool, bool) {
+	switch operator {
+	case "StringEquals":
+		return containsString(values, value, false), true
+	case "StringNotEquals":
+		return !containsString(values, value, false), true
+	case "StringEqualsIgnoreCase", "Bool":
+		return containsString(values, value, true), true
+	case "StringNotEqualsIgnoreCase":
+		return !containsString(values, value, true), true
+	case "StringLike":
+		return matchesAnyPattern(values, value, false), true
+	case "StringNotLike":
+		return !matchesAnyPattern(values, value, false), true
+	default:
+		return false, false
+	}
+}
+
+func containsString(values []string, value string, caseInsensitive bool) bool {
+	for _, candidate := range values {
+		if candidate == value || (caseInsensitive && strings.EqualFold(candidate, value)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *bucketPolicyPrincipal) matchesAny(principals []string) bool {
+	if p.Wildcard {
+		return true
+	}
+	for _, principal := range principals {
+		if strings.HasPrefix(principal, "arn:") {
+			accountID := strings.Split(principal, ":")[4]
+			if matchesAnyPattern(p.AWS, principal, false) || matchesAnyPattern(p.AWS, accountID, false) {
+				return true
+			}
+		} else if matchesAnyPattern(p.Service, principal, false) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesAnyPattern(patterns []string, value string, caseInsensitive bool) bool {
+	for _, pattern := range patterns {
+		if matchesPattern(pattern, value, caseInsensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesPattern matches value against IAM policy pattern, where `*` matches any characters and `?` matches a single character.
+func matchesPattern(pattern string, value string, caseInsensitive bool) bool {
+	regexPattern := strings.NewReplacer(`\*`, `.*`, `\?`, `.`).Replace(regexp.QuoteMeta(pattern))
+	if caseInsensitive {
+		regexPattern = "(?i)" + regexPattern
+	}
+	matched, _ := regexp.MatchString("^"+regexPattern+"$", value)
+	return matched
+}
@@ -0,0 +1,411 @@
+package elbv2
+
+import (
+	"context"
+	"errors"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	s3sdk "github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/stretchr/testify/assert"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+	"time"
+)
+
+// stubS3 stubs the GetBucketPolicy API of S3.
+type stubS3 struct {
+	s3iface.S3API
+	policyByBucket map[string]string
+	errByBucket    map[string]error
+	calls          int
+}
+
+func (s *stubS3) GetBucketPolicyWithContext(_ context.Context, input *s3sdk.GetBucketPolicyInput, _ ...request.Option) (*s3sdk.GetBucketPolicyOutput, error) {
+	s.calls++
+	bucket := awssdk.StringValue(input.Bucket)
+	if err, ok := s.errByBucket[bucket]; ok {
+		return nil, err
+	}
+	return &s3sdk.GetBucketPolicyOutput{Policy: awssdk.String(s.policyByBucket[bucket])}, nil
+}
+
+func Test_defaultLogBucketValidator_Validate(t *testing.T) {
+	elbAccountPolicy := `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Principal": {"AWS": "arn:aws:iam::797873946194:root"},
+      "Action": "s3:PutObject",
+      "Resource": "arn:aws:s3:::my-bucket/my-prefix/AWSLogs/123456789012/*"
+    }
+  ]
+}`
+	regionScopedPolicy := `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Principal": {"AWS": "arn:aws:iam::797873946194:root"},
+      "Action": "s3:PutObject",
+      "Resource": "arn:aws:s3:::my-bucket/AWSLogs/123456789012/elasticloadbalancing/us-west-2/*"
+    }
+  ]
+}`
+	otherRegionScopedPolicy := `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Principal": {"AWS": "arn:aws:iam::797873946194:root"},
+      "Action": "s3:PutObject",
+      "Resource": "arn:aws:s3:::my-bucket/AWSLogs/123456789012/elasticloadbalancing/us-east-1/*"
+    }
+  ]
+}`
+	servicePrincipalPolicy := `{
+  "Version": "2012-10-17",
+  "Statement": {
+    "Effect": "Allow",
+    "Principal": {"Service": ["logdelivery.elasticloadbalancing.amazonaws.com"]},
+    "Action": ["s3:*"],
+    "Resource": ["arn:aws:s3:::my-bucket/*"]
+  }
+}`
+	nlbPolicy := `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Principal": {"Service": "delivery.logs.amazonaws.com"},
+      "Action": "s3:PutObject",
+      "Resource": "arn:aws:s3:::my-bucket/AWSLogs/*"
+    }
+  ]
+}`
+	unrelatedPolicy := `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Principal": {"AWS": "arn:aws:iam::797873946194:root"},
+      "Action": "s3:GetObject",
+      "Resource": "arn:aws:s3:::my-bucket/*"
+    },
+    {
+      "Effect": "Deny",
+      "Principal": "*",
+      "Action": "s3:PutObject",
+      "Resource": "arn:aws:s3:::my-bucket/*"
+    }
+  ]
+}`
+	secureTransportPolicy := `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Principal": {"Service": "logdelivery.elasticloadbalancing.amazonaws.com"},
+      "Action": "s3:PutObject",
+      "Resource": "arn:aws:s3:::my-bucket/*"
+    },
+    {
+      "Effect": "Deny",
+      "Principal": "*",
+      "Action": "s3:*",
+      "Resource": ["arn:aws:s3:::my-bucket", "arn:aws:s3:::my-bucket/*"],
+      "Condition": {"Bool": {"aws:SecureTransport": false}}
+    }
+  ]
+}`
+	sourceAccountPolicy := `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Principal": {"Service": "delivery.logs.amazonaws.com"},
+      "Action": "s3:PutObject",
+      "Resource": "arn:aws:s3:::my-bucket/AWSLogs/*",
+      "Condition": {
+        "StringEquals": {"s3:x-amz-acl": "bucket-owner-full-control", "aws:SourceAccount": ["123456789012"]}
+      }
+    },
+    {
+      "Effect": "Deny",
+      "Principal": "*",
+      "Action": "s3:PutObject",
+      "Resource": "arn:aws:s3:::my-bucket/*",
+      "Condition": {"StringNotEquals": {"aws:SourceAccount": "123456789012"}}
+    }
+  ]
+}`
+	aclPolicy := `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Principal": {"Service": "delivery.logs.amazonaws.com"},
+      "Action": "s3:PutObject",
+      "Resource": "arn:aws:s3:::my-bucket/AWSLogs/*",
+      "Condition": {"StringEquals": {"s3:x-amz-acl": "private"}}
+    }
+  ]
+}`
+	buildLB := func(lbType elbv2model.LoadBalancerType, attrs map[string]string) *elbv2model.LoadBalancer {
+		var lbAttributes []elbv2model.LoadBalancerAttribute
+		for key, value := range attrs {
+			lbAttributes = append(lbAttributes, elbv2model.LoadBalancerAttribute{Key: key, Value: value})
+		}
+		return &elbv2model.LoadBalancer{
+			Spec: elbv2model.LoadBalancerSpec{
+				Type:                   lbType,
+				LoadBalancerAttributes: lbAttributes,
+			},
+		}
+	}
+	tests := []struct {
+		name           string
+		policyByBucket map[string]string
+		errByBucket    map[string]error
+		resLB          *elbv2model.LoadBalancer
+		wantErr        error
+	}{
+		{
+			name: "access logs disabled",
+			resLB: buildLB(elbv2model.LoadBalancerTypeApplication, map[string]string{
+				"access_logs.s3.enabled": "false",
+				"access_logs.s3.bucket":  "my-bucket",
+			}),
+		},
+		{
+			name:           "access logs allowed for ELB account",
+			policyByBucket: map[string]string{"my-bucket": elbAccountPolicy},
+			resLB: buildLB(elbv2model.LoadBalancerTypeApplication, map[string]string{
+				"access_logs.s3.enabled": "true",
+				"access_logs.s3.bucket":  "my-bucket",
+				"access_logs.s3.prefix":  "my-prefix",
+			}),
+		},
+		{
+			name:           "access logs with mismatched prefix",
+			policyByBucket: map[string]string{"my-bucket": elbAccountPolicy},
+			resLB: buildLB(elbv2model.LoadBalancerTypeApplication, map[string]string{
+				"access_logs.s3.enabled": "true",
+				"access_logs.s3.bucket":  "my-bucket",
+				"access_logs.s3.prefix":  "other-prefix",
+			}),
+			wantErr: errors.New("S3 bucket my-bucket policy doesn't allow s3:PutObject on arn:aws:s3:::my-bucket/other-prefix/AWSLogs/ for arn:aws:iam::797873946194:root or logdelivery.elasticloadbalancing.amazonaws.com, see https://docs.aws.amazon.com/elasticloadbalancing/latest/application/enable-access-logging.html"),
+		},
+		{
+			name:           "access logs allowed for region",
+			policyByBucket: map[string]string{"my-bucket": regionScopedPolicy},
+			resLB: buildLB(elbv2model.LoadBalancerTypeApplication, map[string]string{
+				"access_logs.s3.enabled": "true",
+				"access_logs.s3.bucket":  "my-bucket",
+			}),
+		},
+		{
+			name:           "access logs allowed for other region",
+			policyByBucket: map[string]string{"my-bucket": otherRegionScopedPolicy},
+			resLB: buildLB(elbv2model.LoadBalancerTypeApplication, map[string]string{
+				"access_logs.s3.enabled": "true",
+				"access_logs.s3.bucket":  "my-bucket",
+			}),
+			wantErr: errors.New("S3 bucket my-bucket policy doesn't allow s3:PutObject on arn:aws:s3:::my-bucket/AWSLogs/ for arn:aws:iam::797873946194:root or logdelivery.elasticloadbalancing.amazonaws.com, see https://docs.aws.amazon.com/elasticloadbalancing/latest/application/enable-access-logging.html"),
+		},
+		{
+			name:           "connection logs allowed for service principal",
+			policyByBucket: map[string]string{"my-bucket": servicePrincipalPolicy},
+			resLB: buildLB(elbv2model.LoadBalancerTypeApplication, map[string]string{
+				"connection_logs.s3.enabled": "true",
+				"connection_logs.s3.bucket":  "my-bucket",
+			}),
+		},
+		{
+			name:           "connection logs not allowed",
+			policyByBucket: map[string]string{"my-bucket": unrelatedPolicy},
+			resLB: buildLB(elbv2model.LoadBalancerTypeApplication, map[string]string{
+				"connection_logs.s3.enabled": "true",
+				"connection_logs.s3.bucket":  "my-bucket",
+			}),
+			wantErr: errors.New("S3 bucket my-bucket policy denies s3:PutObject on arn:aws:s3:::my-bucket/AWSLogs/ for arn:aws:iam::797873946194:root or logdelivery.elasticloadbalancing.amazonaws.com, see https://docs.aws.amazon.com/elasticloadbalancing/latest/application/enable-access-logging.html"),
+		},
+		{
+			name:           "access logs allowed with deny of insecure transport",
+			policyByBucket: map[string]string{"my-bucket": secureTransportPolicy},
+			resLB: buildLB(elbv2model.LoadBalancerTypeApplication, map[string]string{
+				"access_logs.s3.enabled": "true",
+				"access_logs.s3.bucket":  "my-bucket",
+			}),
+		},
+		{
+			name:           "NLB access logs allowed under conditions that cannot be evaluated",
+			policyByBucket: map[string]string{"my-bucket": sourceAccountPolicy},
+			resLB: buildLB(elbv2model.LoadBalancerTypeNetwork, map[string]string{
+				"access_logs.s3.enabled": "true",
+				"access_logs.s3.bucket":  "my-bucket",
+			}),
+		},
+		{
+			name:           "NLB access logs with mismatched condition",
+			policyByBucket: map[string]string{"my-bucket": aclPolicy},
+			resLB: buildLB(elbv2model.LoadBalancerTypeNetwork, map[string]string{
+				"access_logs.s3.enabled": "true",
+				"access_logs.s3.bucket":  "my-bucket",
+			}),
+			wantErr: errors.New("S3 bucket my-bucket policy doesn't allow s3:PutObject on arn:aws:s3:::my-bucket/AWSLogs/ for delivery.logs.amazonaws.com, see https://docs.aws.amazon.com/elasticloadbalancing/latest/application/enable-access-logging.html"),
+		},
+		{
+			name:           "NLB access logs allowed for log delivery service",
+			policyByBucket: map[string]string{"my-bucket": nlbPolicy},
+			resLB: buildLB(elbv2model.LoadBalancerTypeNetwork, map[string]string{
+				"access_logs.s3.enabled": "true",
+				"access_logs.s3.bucket":  "my-bucket",
+			}),
+		},
+		{
+			name: "access logs without bucket",
+			resLB: buildLB(elbv2model.LoadBalancerTypeApplication, map[string]string{
+				"access_logs.s3.enabled": "true",
+			}),
+			wantErr: errors.New("access_logs.s3.bucket must be specified when access_logs.s3.enabled is true"),
+		},
+		{
+			name:        "bucket doesn't exist",
+			errByBucket: map[string]error{"my-bucket": awserr.New("NoSuchBucket", "The specified bucket does not exist", nil)},
+			resLB: buildLB(elbv2model.LoadBalancerTypeApplication, map[string]string{
+				"access_logs.s3.enabled": "true",
+				"access_logs.s3.bucket":  "my-bucket",
+			}),
+			wantErr: errors.New("S3 bucket my-bucket for access_logs doesn't exist"),
+		},
+		{
+			name:        "bucket without policy",
+			errByBucket: map[string]error{"my-bucket": awserr.New("NoSuchBucketPolicy", "The bucket policy does not exist", nil)},
+			resLB: buildLB(elbv2model.LoadBalancerTypeNetwork, map[string]string{
+				"access_logs.s3.enabled": "true",
+				"access_logs.s3.bucket":  "my-bucket",
+			}),
+			wantErr: errors.New("S3 bucket my-bucket for access_logs has no bucket policy, it must allow s3:PutObject for delivery.logs.amazonaws.com"),
+		},
+		{
+			name:        "bucket policy cannot be retrieved",
+			errByBucket: map[string]error{"my-bucket": awserr.New("AccessDenied", "Access Denied", nil)},
+			resLB: buildLB(elbv2model.LoadBalancerTypeApplication, map[string]string{
+				"access_logs.s3.enabled": "true",
+				"access_logs.s3.bucket":  "my-bucket",
+			}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s3Client := &stubS3{
+				policyByBucket: tt.policyByBucket,
+				errByBucket:    tt.errByBucket,
+			}
+			v := NewDefaultLogBucketValidator(s3Client, "us-west-2", &log.NullLogger{})
+			err := v.Validate(context.Background(), tt.resLB)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_defaultLogBucketValidator_Validate_cachesBucketPolicy(t *testing.T) {
+	s3Client := &stubS3{
+		errByBucket: map[string]error{
+			"missing-bucket": awserr.New("NoSuchBucket", "The specified bucket does not exist", nil),
+			"denied-bucket":  awserr.New("AccessDenied", "Access Denied", nil),
+		},
+	}
+	v := NewDefaultLogBucketValidator(s3Client, "us-west-2", &log.NullLogger{})
+	buildLB := func(bucket string) *elbv2model.LoadBalancer {
+		return &elbv2model.LoadBalancer{
+			Spec: elbv2model.LoadBalancerSpec{
+				Type: elbv2model.LoadBalancerTypeNetwork,
+				LoadBalancerAttributes: []elbv2model.LoadBalancerAttribute{
+					{Key: "access_logs.s3.enabled", Value: "true"},
+					{Key: "access_logs.s3.bucket", Value: bucket},
+				},
+			},
+		}
+	}
+
+	// missing buckets are cached.
+	for i := 0; i < 2; i++ {
+		assert.EqualError(t, v.Validate(context.Background(), buildLB("missing-bucket")), "S3 bucket missing-bucket for access_logs doesn't exist")
+	}
+	assert.Equal(t, 1, s3Client.calls)
+
+	// failures to retrieve bucket policy are not cached.
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, v.Validate(context.Background(), buildLB("denied-bucket")))
+	}
+	assert.Equal(t, 3, s3Client.calls)
+}
+
+func Test_logDeliveryPrincipals(t *testing.T) {
+	tests := []struct {
+		name   string
+		lbType elbv2model.LoadBalancerType
+		region string
+		want   []string
+	}{
+		{
+			name:   "ALB in region with ELB account",
+			lbType: elbv2model.LoadBalancerTypeApplication,
+			region: "cn-north-1",
+			want:   []string{"arn:aws-cn:iam::638102146993:root", "logdelivery.elasticloadbalancing.amazonaws.com"},
+		},
+		{
+			name:   "ALB in region without ELB account",
+			lbType: elbv2model.LoadBalancerTypeApplication,
+			region: "ap-southeast-4",
+			want:   []string{"logdelivery.elasticloadbalancing.amazonaws.com"},
+		},
+		{
+			name:   "NLB",
+			lbType: elbv2model.LoadBalancerTypeNetwork,
+			region: "us-west-2",
+			want:   []string{"delivery.logs.amazonaws.com"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := logDeliveryPrincipals(tt.lbType, tt.region)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_buildSampleLogObjectKey(t *testing.T) {
+	deliveryTime := time.Date(2022, 3, 4, 5, 6, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		lbType elbv2model.LoadBalancerType
+		want   string
+	}{
+		{
+			name:   "ALB",
+			lbType: elbv2model.LoadBalancerTypeApplication,
+			want:   "123456789012/elasticloadbalancing/us-west-2/2022/03/04/123456789012_elasticloadbalancing_us-west-2_app.my-loadbalancer.1234567890abcdef_20220304T0506Z_192.0.2.1_2soosksgqb9idsbh.log.gz",
+		},
+		{
+			name:   "NLB",
+			lbType: elbv2model.LoadBalancerTypeNetwork,
+			want:   "123456789012/elasticloadbalancing/us-west-2/2022/03/04/123456789012_elasticloadbalancing_us-west-2_net.my-loadbalancer.1234567890abcdef_20220304T0506Z_192.0.2.1_2soosksgqb9idsbh.log.gz",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildSampleLogObjectKey(tt.lbType, "us-west-2", "123456789012", deliveryTime)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
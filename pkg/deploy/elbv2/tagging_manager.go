@@ -3,17 +3,30 @@ package elbv2
 import (
 	"context"
 	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	rgtsdk "github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
 	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"strings"
 )
 
 const (
 	// ELBV2 API supports up to 20 resource per DescribeTags API call.
 	defaultDescribeTagsChunkSize = 20
+	// ELBV2 API supports up to 20 resource per AddTags or RemoveTags API call.
+	defaultModifyTagsChunkSize = 20
+	// Resource Groups Tagging API supports up to 100 resources per GetResources API call.
+	defaultRGTResourcesPerPage = 100
+
+	// resource types of ELBV2 resources in Resource Groups Tagging API.
+	rgtResourceTypeLoadBalancer = "elasticloadbalancing:loadbalancer"
+	rgtResourceTypeTargetGroup  = "elasticloadbalancing:targetgroup"
+	rgtResourceTypeListener     = "elasticloadbalancing:listener"
 )
 
 // LoadBalancer with it's tags.
@@ -28,6 +41,12 @@ type TargetGroupWithTags struct {
 	Tags        map[string]string
 }
 
+// Listener with it's tags.
+type ListenerWithTags struct {
+	Listener *elbv2sdk.Listener
+	Tags     map[string]string
+}
+
 // options for ReconcileTags API.
 type ReconcileTagsOptions struct {
 	// CurrentTags on resources.
@@ -37,6 +56,10 @@ type ReconcileTagsOptions struct {
 	// IgnoredTagKeys defines the tag keys that should be ignored.
 	// these tags shouldn't be altered or deleted.
 	IgnoredTagKeys []string
+
+	// Deferred defines whether the tag changes can be deferred into the TagBatch within context.
+	// when there is no TagBatch within context, the tag changes are applied immediately.
+	Deferred bool
 }
 
 func (opts *ReconcileTagsOptions) ApplyOptions(options []ReconcileTagsOption) {
@@ -61,37 +84,76 @@ func WithIgnoredTagKeys(ignoredTagKeys []string) ReconcileTagsOption {
 	}
 }
 
+// WithDeferredTagging is a reconcile option that defers tag changes into the TagBatch within context.
+// it should only be used when the resource isn't looked up by the changed tags until the TagBatch is applied.
+func WithDeferredTagging() ReconcileTagsOption {
+	return func(opts *ReconcileTagsOptions) {
+		opts.Deferred = true
+	}
+}
+
+type tagBatchContextKey struct{}
+
+// ContextWithTagBatch returns a context that deferred tag changes of ELBV2 resources are recorded into batch within.
+func ContextWithTagBatch(ctx context.Context, batch *tracking.TagBatch) context.Context {
+	return context.WithValue(ctx, tagBatchContextKey{}, batch)
+}
+
+// TagBatchFromContext returns the TagBatch of ELBV2 resources within ctx, or nil if there is none.
+func TagBatchFromContext(ctx context.Context) *tracking.TagBatch {
+	batch, _ := ctx.Value(tagBatchContextKey{}).(*tracking.TagBatch)
+	return batch
+}
+
 // abstraction around tagging operations for ELBV2.
 type TaggingManager interface {
 	// ReconcileTags will reconcile tags on resources.
 	ReconcileTags(ctx context.Context, arn string, desiredTags map[string]string, opts ...ReconcileTagsOption) error
 
+	// ApplyTagBatch applies the tag changes recorded in batch, resources with identical changes are tagged together.
+	ApplyTagBatch(ctx context.Context, batch *tracking.TagBatch) error
+
 	// ListLoadBalancers returns LoadBalancers that matches any of the tagging requirements.
 	ListLoadBalancers(ctx context.Context, tagFilters ...tracking.TagFilter) ([]LoadBalancerWithTags, error)
 
 	// ListTargetGroups returns TargetGroups that matches any of the tagging requirements.
 	ListTargetGroups(ctx context.Context, tagFilters ...tracking.TagFilter) ([]TargetGroupWithTags, error)
+
+	// ListListeners returns all Listeners on LoadBalancer with their tags.
+	ListListeners(ctx context.Context, lbARN string) ([]ListenerWithTags, error)
+
+	// ListListenerLoadBalancerARNs returns ARNs of LoadBalancers that have Listeners matching any of the tagging requirements.
+	ListListenerLoadBalancerARNs(ctx context.Context, tagFilters ...tracking.TagFilter) ([]string, error)
 }
 
 // NewDefaultTaggingManager constructs default TaggingManager.
-func NewDefaultTaggingManager(elbv2Client services.ELBV2, logger logr.Logger) *defaultTaggingManager {
+// rgtClient is optional, when specified, LoadBalancers and TargetGroups are discovered by tags via Resource Groups Tagging API.
+// Resource Groups Tagging API is eventually consistent and misses recently tagged resources, so it must only be specified for
+// periodic scans like the orphaned resources garbage collection, but not for deployments, which must see the resources they created.
+func NewDefaultTaggingManager(elbv2Client services.ELBV2, rgtClient services.RGT, logger logr.Logger) *defaultTaggingManager {
 	return &defaultTaggingManager{
 		elbv2Client: elbv2Client,
+		rgtClient:   rgtClient,
 		logger:      logger,
 
 		describeTagsChunkSize: defaultDescribeTagsChunkSize,
+		modifyTagsChunkSize:   defaultModifyTagsChunkSize,
+		rgtResourcesPerPage:   defaultRGTResourcesPerPage,
 	}
 }
 
 var _ TaggingManager = &defaultTaggingManager{}
 
 // default implementation for TaggingManager
-// @TODO: use AWS Resource Groups Tagging API to optimize this implementation once it have PrivateLink support.
 type defaultTaggingManager struct {
 	elbv2Client services.ELBV2
-	logger      logr.Logger
+	// rgtClient is nil unless discovery via Resource Groups Tagging API is enabled, which requires the API reachable from the controller.
+	rgtClient services.RGT
+	logger    logr.Logger
 
 	describeTagsChunkSize int
+	modifyTagsChunkSize   int
+	rgtResourcesPerPage   int
 }
 
 func (m *defaultTaggingManager) ReconcileTags(ctx context.Context, arn string, desiredTags map[string]string, opts ...ReconcileTagsOption) error {
@@ -114,6 +176,10 @@ func (m *defaultTaggingManager) ReconcileTags(ctx context.Context, arn string, d
 		delete(tagsToUpdate, ignoredTagKey)
 		delete(tagsToRemove, ignoredTagKey)
 	}
+	if batch := TagBatchFromContext(ctx); reconcileOpts.Deferred && batch != nil {
+		batch.Add(arn, tagsToUpdate, sets.StringKeySet(tagsToRemove).List())
+		return nil
+	}
 
 	if len(tagsToUpdate) > 0 {
 		req := &elbv2sdk.AddTagsInput{
@@ -150,6 +216,45 @@ func (m *defaultTaggingManager) ReconcileTags(ctx context.Context, arn string, d
 	return nil
 }
 
+func (m *defaultTaggingManager) ApplyTagBatch(ctx context.Context, batch *tracking.TagBatch) error {
+	tagUpdates, tagRemovals := batch.Drain()
+	for _, tagUpdate := range tagUpdates {
+		for _, arnsChunk := range algorithm.ChunkStrings(tagUpdate.ResIDs, m.modifyTagsChunkSize) {
+			req := &elbv2sdk.AddTagsInput{
+				ResourceArns: awssdk.StringSlice(arnsChunk),
+				Tags:         convertTagsToSDKTags(tagUpdate.Tags),
+			}
+
+			m.logger.Info("adding resource tags",
+				"arns", arnsChunk,
+				"change", tagUpdate.Tags)
+			if _, err := m.elbv2Client.AddTagsWithContext(ctx, req); err != nil {
+				return err
+			}
+			m.logger.Info("added resource tags",
+				"arns", arnsChunk)
+		}
+	}
+	for _, tagRemoval := range tagRemovals {
+		for _, arnsChunk := range algorithm.ChunkStrings(tagRemoval.ResIDs, m.modifyTagsChunkSize) {
+			req := &elbv2sdk.RemoveTagsInput{
+				ResourceArns: awssdk.StringSlice(arnsChunk),
+				TagKeys:      awssdk.StringSlice(tagRemoval.TagKeys),
+			}
+
+			m.logger.Info("removing resource tags",
+				"arns", arnsChunk,
+				"change", tagRemoval.TagKeys)
+			if _, err := m.elbv2Client.RemoveTagsWithContext(ctx, req); err != nil {
+				return err
+			}
+			m.logger.Info("removed resource tags",
+				"arns", arnsChunk)
+		}
+	}
+	return nil
+}
+
 func (m *defaultTaggingManager) ListLoadBalancers(ctx context.Context, tagFilters ...tracking.TagFilter) ([]LoadBalancerWithTags, error) {
 	req := &elbv2sdk.DescribeLoadBalancersInput{}
 	lbs, err := m.elbv2Client.DescribeLoadBalancersAsList(ctx, req)
@@ -164,7 +269,7 @@ func (m *defaultTaggingManager) ListLoadBalancers(ctx context.Context, tagFilter
 		lbARNs = append(lbARNs, lbARN)
 		lbByARN[lbARN] = lb
 	}
-	tagsByARN, err := m.describeResourceTags(ctx, lbARNs)
+	tagsByARN, err := m.listResourceTags(ctx, rgtResourceTypeLoadBalancer, lbARNs, tagFilters)
 	if err != nil {
 		return nil, err
 	}
@@ -203,7 +308,7 @@ func (m *defaultTaggingManager) ListTargetGroups(ctx context.Context, tagFilters
 		tgARNs = append(tgARNs, tgARN)
 		tgByARN[tgARN] = tg
 	}
-	tagsByARN, err := m.describeResourceTags(ctx, tgARNs)
+	tagsByARN, err := m.listResourceTags(ctx, rgtResourceTypeTargetGroup, tgARNs, tagFilters)
 	if err != nil {
 		return nil, err
 	}
@@ -228,6 +333,148 @@ func (m *defaultTaggingManager) ListTargetGroups(ctx context.Context, tagFilters
 	return matchedTGs, nil
 }
 
+func (m *defaultTaggingManager) ListListeners(ctx context.Context, lbARN string) ([]ListenerWithTags, error) {
+	req := &elbv2sdk.DescribeListenersInput{
+		LoadBalancerArn: awssdk.String(lbARN),
+	}
+	lss, err := m.elbv2Client.DescribeListenersAsList(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	lsARNs := make([]string, 0, len(lss))
+	for _, ls := range lss {
+		lsARNs = append(lsARNs, awssdk.StringValue(ls.ListenerArn))
+	}
+	tagsByARN, err := m.describeResourceTags(ctx, lsARNs)
+	if err != nil {
+		return nil, err
+	}
+
+	lssWithTags := make([]ListenerWithTags, 0, len(lss))
+	for _, ls := range lss {
+		lssWithTags = append(lssWithTags, ListenerWithTags{
+			Listener: ls,
+			Tags:     tagsByARN[awssdk.StringValue(ls.ListenerArn)],
+		})
+	}
+	return lssWithTags, nil
+}
+
+func (m *defaultTaggingManager) ListListenerLoadBalancerARNs(ctx context.Context, tagFilters ...tracking.TagFilter) ([]string, error) {
+	if m.rgtClient != nil {
+		return m.listListenerLoadBalancerARNsViaRGT(ctx, tagFilters)
+	}
+
+	// without Resource Groups Tagging API, listeners on every LoadBalancer have to be described to find the tagged ones.
+	lbs, err := m.elbv2Client.DescribeLoadBalancersAsList(ctx, &elbv2sdk.DescribeLoadBalancersInput{})
+	if err != nil {
+		return nil, err
+	}
+	var lbARNs []string
+	for _, lb := range lbs {
+		lbARN := awssdk.StringValue(lb.LoadBalancerArn)
+		lss, err := m.ListListeners(ctx, lbARN)
+		if err != nil {
+			return nil, err
+		}
+		if anyListenerMatchesTagFilters(lss, tagFilters) {
+			lbARNs = append(lbARNs, lbARN)
+		}
+	}
+	return lbARNs, nil
+}
+
+// listListenerLoadBalancerARNsViaRGT returns ARNs of LoadBalancers that have Listeners matching any of the tagFilters via Resource Groups Tagging API.
+func (m *defaultTaggingManager) listListenerLoadBalancerARNsViaRGT(ctx context.Context, tagFilters []tracking.TagFilter) ([]string, error) {
+	tagsByLSARN, err := m.getResourceTagsByTagFilters(ctx, rgtResourceTypeListener, tagFilters)
+	if err != nil {
+		return nil, err
+	}
+	lbARNs := sets.NewString()
+	for lsARN := range tagsByLSARN {
+		lbARN, err := loadBalancerARNForListenerARN(lsARN)
+		if err != nil {
+			return nil, err
+		}
+		lbARNs.Insert(lbARN)
+	}
+	return lbARNs.List(), nil
+}
+
+// anyListenerMatchesTagFilters checks whether any of the listeners matches any of the tagFilters.
+func anyListenerMatchesTagFilters(lss []ListenerWithTags, tagFilters []tracking.TagFilter) bool {
+	for _, ls := range lss {
+		for _, tagFilter := range tagFilters {
+			if tagFilter.Matches(ls.Tags) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// loadBalancerARNForListenerARN returns the ARN of LoadBalancer the listener of lsARN is on.
+// e.g. listener/app/my-alb/50dc6c495c0c9188/f2f7dc8efc522ab2 is on loadbalancer/app/my-alb/50dc6c495c0c9188.
+func loadBalancerARNForListenerARN(lsARN string) (string, error) {
+	parsedARN, err := arn.Parse(lsARN)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to parse listener ARN: %v", lsARN)
+	}
+	resourceParts := strings.Split(parsedARN.Resource, "/")
+	if len(resourceParts) != 5 || resourceParts[0] != "listener" {
+		return "", errors.Errorf("invalid listener ARN: %v", lsARN)
+	}
+	parsedARN.Resource = strings.Join(append([]string{"loadbalancer"}, resourceParts[1:4]...), "/")
+	return parsedARN.String(), nil
+}
+
+// listResourceTags lists tags for elbv2 resources of rgtResourceType that may match any of the tagFilters.
+// returns tags indexed by resource ARN, resources not matching any of the tagFilters might be absent.
+func (m *defaultTaggingManager) listResourceTags(ctx context.Context, rgtResourceType string, arns []string, tagFilters []tracking.TagFilter) (map[string]map[string]string, error) {
+	if m.rgtClient == nil || len(arns) == 0 {
+		return m.describeResourceTags(ctx, arns)
+	}
+	rgtTagsByARN, err := m.getResourceTagsByTagFilters(ctx, rgtResourceType, tagFilters)
+	if err != nil {
+		return nil, err
+	}
+	// Resource Groups Tagging API is eventually consistent, the candidates' tags might be stale,
+	// so they are confirmed via ELBV2 DescribeTags, which only takes an API call per 20 candidates.
+	var candidateARNs []string
+	for _, arn := range arns {
+		if _, ok := rgtTagsByARN[arn]; ok {
+			candidateARNs = append(candidateARNs, arn)
+		}
+	}
+	return m.describeResourceTags(ctx, candidateARNs)
+}
+
+// getResourceTagsByTagFilters gets tags for elbv2 resources of rgtResourceType that matches any of the tagFilters via Resource Groups Tagging API.
+// returns tags indexed by resource ARN.
+func (m *defaultTaggingManager) getResourceTagsByTagFilters(ctx context.Context, rgtResourceType string, tagFilters []tracking.TagFilter) (map[string]map[string]string, error) {
+	tagsByARN := make(map[string]map[string]string)
+	for _, tagFilter := range tagFilters {
+		req := &rgtsdk.GetResourcesInput{
+			ResourceTypeFilters: awssdk.StringSlice([]string{rgtResourceType}),
+			TagFilters:          convertTagFilterToRGTTagFilters(tagFilter),
+			ResourcesPerPage:    awssdk.Int64(int64(m.rgtResourcesPerPage)),
+		}
+		resources, err := m.rgtClient.GetResourcesAsList(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		for _, resource := range resources {
+			tags := make(map[string]string, len(resource.Tags))
+			for _, tag := range resource.Tags {
+				tags[awssdk.StringValue(tag.Key)] = awssdk.StringValue(tag.Value)
+			}
+			tagsByARN[awssdk.StringValue(resource.ResourceARN)] = tags
+		}
+	}
+	return tagsByARN, nil
+}
+
 // describeResourceTags describes tags for elbv2 resources.
 // returns tags indexed by resource ARN.
 func (m *defaultTaggingManager) describeResourceTags(ctx context.Context, arns []string) (map[string]map[string]string, error) {
@@ -264,6 +511,18 @@ func convertTagsToSDKTags(tags map[string]string) []*elbv2sdk.Tag {
 	return sdkTags
 }
 
+// convert tagFilter into Resource Groups Tagging API tag filter presentation.
+func convertTagFilterToRGTTagFilters(tagFilter tracking.TagFilter) []*rgtsdk.TagFilter {
+	rgtTagFilters := make([]*rgtsdk.TagFilter, 0, len(tagFilter))
+	for _, key := range sets.StringKeySet(tagFilter).List() {
+		rgtTagFilters = append(rgtTagFilters, &rgtsdk.TagFilter{
+			Key:    awssdk.String(key),
+			Values: awssdk.StringSlice(tagFilter[key]),
+		})
+	}
+	return rgtTagFilters
+}
+
 // convert AWS SDK tag presentation into tags.
 func convertSDKTagsToTags(sdkTags []*elbv2sdk.Tag) map[string]string {
 	tags := make(map[string]string, len(sdkTags))
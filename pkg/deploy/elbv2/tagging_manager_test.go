@@ -4,7 +4,9 @@ import (
 	"context"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	rgtsdk "github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
 	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
@@ -239,6 +241,199 @@ func Test_defaultTaggingManager_ReconcileTags(t *testing.T) {
 	}
 }
 
+func Test_defaultTaggingManager_ApplyTagBatch(t *testing.T) {
+	type addTagsWithContextCall struct {
+		req  *elbv2sdk.AddTagsInput
+		resp *elbv2sdk.AddTagsOutput
+		err  error
+	}
+	type removeTagsWithContextCall struct {
+		req  *elbv2sdk.RemoveTagsInput
+		resp *elbv2sdk.RemoveTagsOutput
+		err  error
+	}
+	type fields struct {
+		addTagsWithContextCalls    []addTagsWithContextCall
+		removeTagsWithContextCalls []removeTagsWithContextCall
+	}
+	type reconcileTagsArgs struct {
+		arn         string
+		desiredTags map[string]string
+		opts        []ReconcileTagsOption
+	}
+	tests := []struct {
+		name              string
+		fields            fields
+		reconcileTagsArgs []reconcileTagsArgs
+		wantErr           error
+	}{
+		{
+			name: "identical changes are applied together in chunks",
+			fields: fields{
+				addTagsWithContextCalls: []addTagsWithContextCall{
+					{
+						req: &elbv2sdk.AddTagsInput{
+							ResourceArns: awssdk.StringSlice([]string{"arn-1", "arn-2"}),
+							Tags: []*elbv2sdk.Tag{
+								{
+									Key:   awssdk.String("keyA"),
+									Value: awssdk.String("valueA2"),
+								},
+							},
+						},
+						resp: &elbv2sdk.AddTagsOutput{},
+					},
+					{
+						req: &elbv2sdk.AddTagsInput{
+							ResourceArns: awssdk.StringSlice([]string{"arn-3"}),
+							Tags: []*elbv2sdk.Tag{
+								{
+									Key:   awssdk.String("keyA"),
+									Value: awssdk.String("valueA2"),
+								},
+							},
+						},
+						resp: &elbv2sdk.AddTagsOutput{},
+					},
+				},
+				removeTagsWithContextCalls: []removeTagsWithContextCall{
+					{
+						req: &elbv2sdk.RemoveTagsInput{
+							ResourceArns: awssdk.StringSlice([]string{"arn-1", "arn-3"}),
+							TagKeys:      awssdk.StringSlice([]string{"keyB"}),
+						},
+						resp: &elbv2sdk.RemoveTagsOutput{},
+					},
+				},
+			},
+			reconcileTagsArgs: []reconcileTagsArgs{
+				{
+					arn:         "arn-3",
+					desiredTags: map[string]string{"keyA": "valueA2"},
+					opts: []ReconcileTagsOption{
+						WithCurrentTags(map[string]string{"keyA": "valueA", "keyB": "valueB"}),
+						WithDeferredTagging(),
+					},
+				},
+				{
+					arn:         "arn-1",
+					desiredTags: map[string]string{"keyA": "valueA2"},
+					opts: []ReconcileTagsOption{
+						WithCurrentTags(map[string]string{"keyA": "valueA", "keyB": "valueB"}),
+						WithDeferredTagging(),
+					},
+				},
+				{
+					arn:         "arn-2",
+					desiredTags: map[string]string{"keyA": "valueA2"},
+					opts: []ReconcileTagsOption{
+						WithCurrentTags(map[string]string{"keyA": "valueA"}),
+						WithDeferredTagging(),
+					},
+				},
+				{
+					arn:         "arn-4",
+					desiredTags: map[string]string{"keyA": "valueA"},
+					opts: []ReconcileTagsOption{
+						WithCurrentTags(map[string]string{"keyA": "valueA"}),
+						WithDeferredTagging(),
+					},
+				},
+			},
+		},
+		{
+			name: "changes without deferred option are applied immediately",
+			fields: fields{
+				addTagsWithContextCalls: []addTagsWithContextCall{
+					{
+						req: &elbv2sdk.AddTagsInput{
+							ResourceArns: awssdk.StringSlice([]string{"arn-1"}),
+							Tags: []*elbv2sdk.Tag{
+								{
+									Key:   awssdk.String("keyA"),
+									Value: awssdk.String("valueA"),
+								},
+							},
+						},
+						resp: &elbv2sdk.AddTagsOutput{},
+					},
+				},
+			},
+			reconcileTagsArgs: []reconcileTagsArgs{
+				{
+					arn:         "arn-1",
+					desiredTags: map[string]string{"keyA": "valueA"},
+					opts: []ReconcileTagsOption{
+						WithCurrentTags(map[string]string{}),
+					},
+				},
+			},
+		},
+		{
+			name: "failed to add tags",
+			fields: fields{
+				addTagsWithContextCalls: []addTagsWithContextCall{
+					{
+						req: &elbv2sdk.AddTagsInput{
+							ResourceArns: awssdk.StringSlice([]string{"arn-1"}),
+							Tags: []*elbv2sdk.Tag{
+								{
+									Key:   awssdk.String("keyA"),
+									Value: awssdk.String("valueA"),
+								},
+							},
+						},
+						err: errors.New("some error"),
+					},
+				},
+			},
+			reconcileTagsArgs: []reconcileTagsArgs{
+				{
+					arn:         "arn-1",
+					desiredTags: map[string]string{"keyA": "valueA"},
+					opts: []ReconcileTagsOption{
+						WithCurrentTags(map[string]string{}),
+						WithDeferredTagging(),
+					},
+				},
+			},
+			wantErr: errors.New("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			elbv2Client := mock_services.NewMockELBV2(ctrl)
+			var calls []*gomock.Call
+			for _, call := range tt.fields.addTagsWithContextCalls {
+				calls = append(calls, elbv2Client.EXPECT().AddTagsWithContext(gomock.Any(), call.req).Return(call.resp, call.err))
+			}
+			for _, call := range tt.fields.removeTagsWithContextCalls {
+				calls = append(calls, elbv2Client.EXPECT().RemoveTagsWithContext(gomock.Any(), call.req).Return(call.resp, call.err))
+			}
+			gomock.InOrder(calls...)
+
+			m := &defaultTaggingManager{
+				elbv2Client:         elbv2Client,
+				logger:              &log.NullLogger{},
+				modifyTagsChunkSize: 2,
+			}
+			batch := tracking.NewTagBatch()
+			ctx := ContextWithTagBatch(context.Background(), batch)
+			for _, args := range tt.reconcileTagsArgs {
+				assert.NoError(t, m.ReconcileTags(ctx, args.arn, args.desiredTags, args.opts...))
+			}
+			err := m.ApplyTagBatch(ctx, batch)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func Test_defaultTaggingManager_ListLoadBalancers(t *testing.T) {
 	type describeLoadBalancersAsListCall struct {
 		req  *elbv2sdk.DescribeLoadBalancersInput
@@ -974,6 +1169,369 @@ func Test_defaultTaggingManager_describeResourceTags(t *testing.T) {
 	}
 }
 
+func Test_defaultTaggingManager_ListTargetGroups_withRGT(t *testing.T) {
+	type getResourcesAsListCall struct {
+		req  *rgtsdk.GetResourcesInput
+		resp []*rgtsdk.ResourceTagMapping
+		err  error
+	}
+	type describeTagsWithContextCall struct {
+		req  *elbv2sdk.DescribeTagsInput
+		resp *elbv2sdk.DescribeTagsOutput
+	}
+	type fields struct {
+		describeTargetGroupsAsListResp []*elbv2sdk.TargetGroup
+		getResourcesAsListCalls        []getResourcesAsListCall
+		describeTagsWithContextCalls   []describeTagsWithContextCall
+	}
+	type args struct {
+		tagFilters []tracking.TagFilter
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []TargetGroupWithTags
+		wantErr error
+	}{
+		{
+			name: "targetGroups matches any of the tagFilters",
+			fields: fields{
+				describeTargetGroupsAsListResp: []*elbv2sdk.TargetGroup{
+					{
+						TargetGroupArn: awssdk.String("tg-1"),
+					},
+					{
+						TargetGroupArn: awssdk.String("tg-2"),
+					},
+					{
+						TargetGroupArn: awssdk.String("tg-3"),
+					},
+				},
+				getResourcesAsListCalls: []getResourcesAsListCall{
+					{
+						req: &rgtsdk.GetResourcesInput{
+							ResourceTypeFilters: awssdk.StringSlice([]string{"elasticloadbalancing:targetgroup"}),
+							TagFilters: []*rgtsdk.TagFilter{
+								{
+									Key:    awssdk.String("keyA"),
+									Values: awssdk.StringSlice([]string{"valueA1"}),
+								},
+								{
+									Key:    awssdk.String("keyB"),
+									Values: awssdk.StringSlice([]string{}),
+								},
+							},
+							ResourcesPerPage: awssdk.Int64(100),
+						},
+						resp: []*rgtsdk.ResourceTagMapping{
+							{
+								ResourceARN: awssdk.String("tg-1"),
+								Tags: []*rgtsdk.Tag{
+									{
+										Key:   awssdk.String("keyA"),
+										Value: awssdk.String("valueA1"),
+									},
+									{
+										Key:   awssdk.String("keyB"),
+										Value: awssdk.String("valueB1"),
+									},
+								},
+							},
+						},
+					},
+					{
+						req: &rgtsdk.GetResourcesInput{
+							ResourceTypeFilters: awssdk.StringSlice([]string{"elasticloadbalancing:targetgroup"}),
+							TagFilters: []*rgtsdk.TagFilter{
+								{
+									Key:    awssdk.String("keyA"),
+									Values: awssdk.StringSlice([]string{"valueA3"}),
+								},
+							},
+							ResourcesPerPage: awssdk.Int64(100),
+						},
+						resp: []*rgtsdk.ResourceTagMapping{
+							{
+								ResourceARN: awssdk.String("tg-3"),
+								Tags: []*rgtsdk.Tag{
+									{
+										Key:   awssdk.String("keyA"),
+										Value: awssdk.String("valueA3"),
+									},
+								},
+							},
+							{
+								// stale tags from Resource Groups Tagging API are confirmed via DescribeTags.
+								ResourceARN: awssdk.String("tg-2"),
+								Tags: []*rgtsdk.Tag{
+									{
+										Key:   awssdk.String("keyA"),
+										Value: awssdk.String("valueA3"),
+									},
+								},
+							},
+							{
+								// stale resources from Resource Groups Tagging API are ignored.
+								ResourceARN: awssdk.String("tg-4"),
+								Tags: []*rgtsdk.Tag{
+									{
+										Key:   awssdk.String("keyA"),
+										Value: awssdk.String("valueA3"),
+									},
+								},
+							},
+						},
+					},
+				},
+				describeTagsWithContextCalls: []describeTagsWithContextCall{
+					{
+						req: &elbv2sdk.DescribeTagsInput{
+							ResourceArns: awssdk.StringSlice([]string{"tg-1", "tg-2", "tg-3"}),
+						},
+						resp: &elbv2sdk.DescribeTagsOutput{
+							TagDescriptions: []*elbv2sdk.TagDescription{
+								{
+									ResourceArn: awssdk.String("tg-1"),
+									Tags: []*elbv2sdk.Tag{
+										{
+											Key:   awssdk.String("keyA"),
+											Value: awssdk.String("valueA1"),
+										},
+										{
+											Key:   awssdk.String("keyB"),
+											Value: awssdk.String("valueB1"),
+										},
+									},
+								},
+								{
+									ResourceArn: awssdk.String("tg-2"),
+									Tags: []*elbv2sdk.Tag{
+										{
+											Key:   awssdk.String("keyA"),
+											Value: awssdk.String("valueA2"),
+										},
+									},
+								},
+								{
+									ResourceArn: awssdk.String("tg-3"),
+									Tags: []*elbv2sdk.Tag{
+										{
+											Key:   awssdk.String("keyA"),
+											Value: awssdk.String("valueA3"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				tagFilters: []tracking.TagFilter{
+					{
+						"keyA": {"valueA1"},
+						"keyB": {},
+					},
+					{
+						"keyA": {"valueA3"},
+					},
+				},
+			},
+			want: []TargetGroupWithTags{
+				{
+					TargetGroup: &elbv2sdk.TargetGroup{
+						TargetGroupArn: awssdk.String("tg-1"),
+					},
+					Tags: map[string]string{
+						"keyA": "valueA1",
+						"keyB": "valueB1",
+					},
+				},
+				{
+					TargetGroup: &elbv2sdk.TargetGroup{
+						TargetGroupArn: awssdk.String("tg-3"),
+					},
+					Tags: map[string]string{
+						"keyA": "valueA3",
+					},
+				},
+			},
+		},
+		{
+			name: "failed to get resources",
+			fields: fields{
+				describeTargetGroupsAsListResp: []*elbv2sdk.TargetGroup{
+					{
+						TargetGroupArn: awssdk.String("tg-1"),
+					},
+				},
+				getResourcesAsListCalls: []getResourcesAsListCall{
+					{
+						req: &rgtsdk.GetResourcesInput{
+							ResourceTypeFilters: awssdk.StringSlice([]string{"elasticloadbalancing:targetgroup"}),
+							TagFilters: []*rgtsdk.TagFilter{
+								{
+									Key:    awssdk.String("keyA"),
+									Values: awssdk.StringSlice([]string{"valueA1"}),
+								},
+							},
+							ResourcesPerPage: awssdk.Int64(100),
+						},
+						err: errors.New("some error"),
+					},
+				},
+			},
+			args: args{
+				tagFilters: []tracking.TagFilter{
+					{
+						"keyA": {"valueA1"},
+					},
+				},
+			},
+			wantErr: errors.New("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			elbv2Client := mock_services.NewMockELBV2(ctrl)
+			elbv2Client.EXPECT().DescribeTargetGroupsAsList(gomock.Any(), &elbv2sdk.DescribeTargetGroupsInput{}).Return(tt.fields.describeTargetGroupsAsListResp, nil)
+			rgtClient := mock_services.NewMockRGT(ctrl)
+			for _, call := range tt.fields.getResourcesAsListCalls {
+				rgtClient.EXPECT().GetResourcesAsList(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			for _, call := range tt.fields.describeTagsWithContextCalls {
+				elbv2Client.EXPECT().DescribeTagsWithContext(gomock.Any(), call.req).Return(call.resp, nil)
+			}
+
+			m := NewDefaultTaggingManager(elbv2Client, rgtClient, &log.NullLogger{})
+			got, err := m.ListTargetGroups(context.Background(), tt.args.tagFilters...)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultTaggingManager_ListListenerLoadBalancerARNs(t *testing.T) {
+	lbARN1 := "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/lb-1/50dc6c495c0c9188"
+	lbARN2 := "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/lb-2/0467ef3c8400ae65"
+	lsARN1 := "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener/app/lb-1/50dc6c495c0c9188/f2f7dc8efc522ab2"
+	lsARN2 := "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener/app/lb-2/0467ef3c8400ae65/8f1b2a9c3d4e5f60"
+	tagFilter := tracking.TagFilter{"keyA": {"valueA"}}
+
+	t.Run("listeners are described on every loadBalancer without RGT", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		elbv2Client := mock_services.NewMockELBV2(ctrl)
+		elbv2Client.EXPECT().DescribeLoadBalancersAsList(gomock.Any(), &elbv2sdk.DescribeLoadBalancersInput{}).Return([]*elbv2sdk.LoadBalancer{
+			{LoadBalancerArn: awssdk.String(lbARN1)},
+			{LoadBalancerArn: awssdk.String(lbARN2)},
+		}, nil)
+		for _, lbAndLSARN := range [][2]string{{lbARN1, lsARN1}, {lbARN2, lsARN2}} {
+			elbv2Client.EXPECT().DescribeListenersAsList(gomock.Any(), &elbv2sdk.DescribeListenersInput{
+				LoadBalancerArn: awssdk.String(lbAndLSARN[0]),
+			}).Return([]*elbv2sdk.Listener{{ListenerArn: awssdk.String(lbAndLSARN[1])}}, nil)
+		}
+		elbv2Client.EXPECT().DescribeTagsWithContext(gomock.Any(), &elbv2sdk.DescribeTagsInput{
+			ResourceArns: awssdk.StringSlice([]string{lsARN1}),
+		}).Return(&elbv2sdk.DescribeTagsOutput{
+			TagDescriptions: []*elbv2sdk.TagDescription{
+				{
+					ResourceArn: awssdk.String(lsARN1),
+					Tags:        []*elbv2sdk.Tag{{Key: awssdk.String("keyA"), Value: awssdk.String("valueA")}},
+				},
+			},
+		}, nil)
+		elbv2Client.EXPECT().DescribeTagsWithContext(gomock.Any(), &elbv2sdk.DescribeTagsInput{
+			ResourceArns: awssdk.StringSlice([]string{lsARN2}),
+		}).Return(&elbv2sdk.DescribeTagsOutput{
+			TagDescriptions: []*elbv2sdk.TagDescription{
+				{
+					ResourceArn: awssdk.String(lsARN2),
+					Tags:        []*elbv2sdk.Tag{{Key: awssdk.String("keyA"), Value: awssdk.String("valueB")}},
+				},
+			},
+		}, nil)
+
+		m := NewDefaultTaggingManager(elbv2Client, nil, &log.NullLogger{})
+		got, err := m.ListListenerLoadBalancerARNs(context.Background(), tagFilter)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{lbARN1}, got)
+	})
+
+	t.Run("listeners are looked up by tags with RGT", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		elbv2Client := mock_services.NewMockELBV2(ctrl)
+		rgtClient := mock_services.NewMockRGT(ctrl)
+		rgtClient.EXPECT().GetResourcesAsList(gomock.Any(), &rgtsdk.GetResourcesInput{
+			ResourceTypeFilters: awssdk.StringSlice([]string{"elasticloadbalancing:listener"}),
+			TagFilters: []*rgtsdk.TagFilter{
+				{
+					Key:    awssdk.String("keyA"),
+					Values: awssdk.StringSlice([]string{"valueA"}),
+				},
+			},
+			ResourcesPerPage: awssdk.Int64(100),
+		}).Return([]*rgtsdk.ResourceTagMapping{
+			{
+				ResourceARN: awssdk.String(lsARN2),
+				Tags:        []*rgtsdk.Tag{{Key: awssdk.String("keyA"), Value: awssdk.String("valueA")}},
+			},
+		}, nil)
+
+		m := NewDefaultTaggingManager(elbv2Client, rgtClient, &log.NullLogger{})
+		got, err := m.ListListenerLoadBalancerARNs(context.Background(), tagFilter)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{lbARN2}, got)
+	})
+}
+
+func Test_loadBalancerARNForListenerARN(t *testing.T) {
+	tests := []struct {
+		name    string
+		lsARN   string
+		want    string
+		wantErr error
+	}{
+		{
+			name:  "application loadBalancer listener",
+			lsARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener/app/my-alb/50dc6c495c0c9188/f2f7dc8efc522ab2",
+			want:  "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-alb/50dc6c495c0c9188",
+		},
+		{
+			name:  "network loadBalancer listener",
+			lsARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener/net/my-nlb/50dc6c495c0c9188/f2f7dc8efc522ab2",
+			want:  "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/net/my-nlb/50dc6c495c0c9188",
+		},
+		{
+			name:    "not a listener",
+			lsARN:   "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-alb/50dc6c495c0c9188",
+			wantErr: errors.New("invalid listener ARN: arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-alb/50dc6c495c0c9188"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := loadBalancerARNForListenerARN(tt.lsARN)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
 func Test_convertTagsToSDKTags(t *testing.T) {
 	type args struct {
 		tags map[string]string
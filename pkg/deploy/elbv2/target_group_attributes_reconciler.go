@@ -6,7 +6,7 @@ import (
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/util/sets"
-	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/audit"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 )
@@ -39,8 +39,18 @@ func (r *defaultTargetGroupAttributeReconciler) Reconcile(ctx context.Context, r
 	if err != nil {
 		return err
 	}
+	for attrKey, attrValue := range defaultTargetGroupAttributes {
+		_, desired := desiredAttrs[attrKey]
+		_, reported := currentAttrs[attrKey]
+		if !desired && reported {
+			desiredAttrs[attrKey] = attrValue
+		}
+	}
 
-	attributesToUpdate, _ := algorithm.DiffStringMap(desiredAttrs, currentAttrs)
+	attributesToUpdate, err := elbv2model.BuildTargetGroupAttributesToUpdate(desiredAttrs, currentAttrs)
+	if err != nil {
+		return err
+	}
 	if len(attributesToUpdate) > 0 {
 		req := &elbv2sdk.ModifyTargetGroupAttributesInput{
 			TargetGroupArn: sdkTG.TargetGroup.TargetGroupArn,
@@ -65,10 +75,33 @@ func (r *defaultTargetGroupAttributeReconciler) Reconcile(ctx context.Context, r
 			"stackID", resTG.Stack().StackID(),
 			"resourceID", resTG.ID(),
 			"arn", awssdk.StringValue(sdkTG.TargetGroup.TargetGroupArn))
+		previousAttrs := make(map[string]string, len(attributesToUpdate))
+		for attrKey := range attributesToUpdate {
+			if attrValue, exists := currentAttrs[attrKey]; exists {
+				previousAttrs[attrKey] = attrValue
+			}
+		}
+		audit.RecordMutation(ctx, audit.Mutation{
+			ResourceKind: audit.ResourceKindTargetGroupAttribute,
+			ResourceID:   awssdk.StringValue(sdkTG.TargetGroup.TargetGroupArn),
+			Operation:    audit.OperationModify,
+			Diff:         audit.ComputeDiff(previousAttrs, attributesToUpdate),
+		})
 	}
 	return nil
 }
 
+// defaultTargetGroupAttributes are the AWS default values of TargetGroup attributes managed by controller.
+// they are used to revert drift when these attributes are not explicitly specified, and only if they are reported by AWS.
+var defaultTargetGroupAttributes = map[string]string{
+	elbv2model.TargetGroupAttributeKeyLoadBalancingCrossZoneEnabled:                        elbv2model.TargetGroupCrossZoneLoadBalancingUseLoadBalancerConfiguration,
+	elbv2model.TargetGroupAttributeKeyLoadBalancingAlgorithmAnomalyMitigation:              elbv2model.TargetGroupAnomalyMitigationOff,
+	elbv2model.TargetGroupAttributeKeyDNSFailoverMinimumHealthyTargetsCount:                "1",
+	elbv2model.TargetGroupAttributeKeyDNSFailoverMinimumHealthyTargetsPercentage:           elbv2model.TargetGroupHealthRequirementOff,
+	elbv2model.TargetGroupAttributeKeyUnhealthyStateRoutingMinimumHealthyTargetsCount:      "1",
+	elbv2model.TargetGroupAttributeKeyUnhealthyStateRoutingMinimumHealthyTargetsPercentage: elbv2model.TargetGroupHealthRequirementOff,
+}
+
 func (r *defaultTargetGroupAttributeReconciler) getDesiredTargetGroupAttributes(ctx context.Context, resTG *elbv2model.TargetGroup) map[string]string {
 	tgAttributes := make(map[string]string, len(resTG.Spec.TargetGroupAttributes))
 	for _, attr := range resTG.Spec.TargetGroupAttributes {
@@ -149,6 +149,282 @@ func Test_defaultTargetGroupAttributeReconciler_Reconcile(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "cross-zone load balancing should be reverted to default when not specified",
+			fields: fields{
+				describeTargetGroupAttributesWithContextCalls: []describeTargetGroupAttributesWithContextCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupAttributesInput{
+							TargetGroupArn: awssdk.String("my-arn"),
+						},
+						resp: &elbv2sdk.DescribeTargetGroupAttributesOutput{
+							Attributes: []*elbv2sdk.TargetGroupAttribute{
+								{
+									Key:   awssdk.String("load_balancing.cross_zone.enabled"),
+									Value: awssdk.String("false"),
+								},
+								{
+									Key:   awssdk.String("stickiness.enabled"),
+									Value: awssdk.String("false"),
+								},
+							},
+						},
+					},
+				},
+				modifyTargetGroupAttributesWithContextCalls: []modifyTargetGroupAttributesWithContextCall{
+					{
+						req: &elbv2sdk.ModifyTargetGroupAttributesInput{
+							TargetGroupArn: awssdk.String("my-arn"),
+							Attributes: []*elbv2sdk.TargetGroupAttribute{
+								{
+									Key:   awssdk.String("load_balancing.cross_zone.enabled"),
+									Value: awssdk.String("use_load_balancer_configuration"),
+								},
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				sdkTG: TargetGroupWithTags{
+					TargetGroup: &elbv2sdk.TargetGroup{
+						TargetGroupArn: awssdk.String("my-arn"),
+					},
+				},
+				resTG: &elbv2model.TargetGroup{
+					ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::TargetGroup", "id-1"),
+					Spec: elbv2model.TargetGroupSpec{
+						TargetGroupAttributes: []elbv2model.TargetGroupAttribute{
+							{
+								Key:   "stickiness.enabled",
+								Value: "false",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "anomaly mitigation should be reverted to default when not specified",
+			fields: fields{
+				describeTargetGroupAttributesWithContextCalls: []describeTargetGroupAttributesWithContextCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupAttributesInput{
+							TargetGroupArn: awssdk.String("my-arn"),
+						},
+						resp: &elbv2sdk.DescribeTargetGroupAttributesOutput{
+							Attributes: []*elbv2sdk.TargetGroupAttribute{
+								{
+									Key:   awssdk.String("load_balancing.algorithm.anomaly_mitigation"),
+									Value: awssdk.String("on"),
+								},
+								{
+									Key:   awssdk.String("target_group_health.dns_failover.minimum_healthy_targets.count"),
+									Value: awssdk.String("1"),
+								},
+								{
+									Key:   awssdk.String("target_group_health.unhealthy_state_routing.minimum_healthy_targets.percentage"),
+									Value: awssdk.String("off"),
+								},
+							},
+						},
+					},
+				},
+				modifyTargetGroupAttributesWithContextCalls: []modifyTargetGroupAttributesWithContextCall{
+					{
+						req: &elbv2sdk.ModifyTargetGroupAttributesInput{
+							TargetGroupArn: awssdk.String("my-arn"),
+							Attributes: []*elbv2sdk.TargetGroupAttribute{
+								{
+									Key:   awssdk.String("load_balancing.algorithm.anomaly_mitigation"),
+									Value: awssdk.String("off"),
+								},
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				sdkTG: TargetGroupWithTags{
+					TargetGroup: &elbv2sdk.TargetGroup{
+						TargetGroupArn: awssdk.String("my-arn"),
+					},
+				},
+				resTG: &elbv2model.TargetGroup{
+					ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::TargetGroup", "id-1"),
+					Spec: elbv2model.TargetGroupSpec{
+						TargetGroupAttributes: []elbv2model.TargetGroupAttribute{},
+					},
+				},
+			},
+		},
+		{
+			name: "stickiness should be kept as is when not specified",
+			fields: fields{
+				describeTargetGroupAttributesWithContextCalls: []describeTargetGroupAttributesWithContextCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupAttributesInput{
+							TargetGroupArn: awssdk.String("my-arn"),
+						},
+						resp: &elbv2sdk.DescribeTargetGroupAttributesOutput{
+							Attributes: []*elbv2sdk.TargetGroupAttribute{
+								{
+									Key:   awssdk.String("stickiness.enabled"),
+									Value: awssdk.String("true"),
+								},
+								{
+									Key:   awssdk.String("stickiness.type"),
+									Value: awssdk.String("app_cookie"),
+								},
+								{
+									Key:   awssdk.String("stickiness.app_cookie.cookie_name"),
+									Value: awssdk.String("SESSIONID"),
+								},
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				sdkTG: TargetGroupWithTags{
+					TargetGroup: &elbv2sdk.TargetGroup{
+						TargetGroupArn: awssdk.String("my-arn"),
+					},
+				},
+				resTG: &elbv2model.TargetGroup{
+					ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::TargetGroup", "id-1"),
+					Spec: elbv2model.TargetGroupSpec{
+						TargetGroupAttributes: []elbv2model.TargetGroupAttribute{},
+					},
+				},
+			},
+		},
+		{
+			name: "stickiness should be switched from lb_cookie to app_cookie within a single request",
+			fields: fields{
+				describeTargetGroupAttributesWithContextCalls: []describeTargetGroupAttributesWithContextCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupAttributesInput{
+							TargetGroupArn: awssdk.String("my-arn"),
+						},
+						resp: &elbv2sdk.DescribeTargetGroupAttributesOutput{
+							Attributes: []*elbv2sdk.TargetGroupAttribute{
+								{
+									Key:   awssdk.String("stickiness.enabled"),
+									Value: awssdk.String("true"),
+								},
+								{
+									Key:   awssdk.String("stickiness.type"),
+									Value: awssdk.String("lb_cookie"),
+								},
+								{
+									Key:   awssdk.String("stickiness.app_cookie.cookie_name"),
+									Value: awssdk.String("SESSIONID"),
+								},
+							},
+						},
+					},
+				},
+				modifyTargetGroupAttributesWithContextCalls: []modifyTargetGroupAttributesWithContextCall{
+					{
+						req: &elbv2sdk.ModifyTargetGroupAttributesInput{
+							TargetGroupArn: awssdk.String("my-arn"),
+							Attributes: []*elbv2sdk.TargetGroupAttribute{
+								{
+									Key:   awssdk.String("stickiness.app_cookie.cookie_name"),
+									Value: awssdk.String("SESSIONID"),
+								},
+								{
+									Key:   awssdk.String("stickiness.enabled"),
+									Value: awssdk.String("true"),
+								},
+								{
+									Key:   awssdk.String("stickiness.type"),
+									Value: awssdk.String("app_cookie"),
+								},
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				sdkTG: TargetGroupWithTags{
+					TargetGroup: &elbv2sdk.TargetGroup{
+						TargetGroupArn: awssdk.String("my-arn"),
+					},
+				},
+				resTG: &elbv2model.TargetGroup{
+					ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::TargetGroup", "id-1"),
+					Spec: elbv2model.TargetGroupSpec{
+						TargetGroupAttributes: []elbv2model.TargetGroupAttribute{
+							{
+								Key:   "stickiness.enabled",
+								Value: "true",
+							},
+							{
+								Key:   "stickiness.type",
+								Value: "app_cookie",
+							},
+							{
+								Key:   "stickiness.app_cookie.cookie_name",
+								Value: "SESSIONID",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "cross-zone load balancing should be updated when specified",
+			fields: fields{
+				describeTargetGroupAttributesWithContextCalls: []describeTargetGroupAttributesWithContextCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupAttributesInput{
+							TargetGroupArn: awssdk.String("my-arn"),
+						},
+						resp: &elbv2sdk.DescribeTargetGroupAttributesOutput{
+							Attributes: []*elbv2sdk.TargetGroupAttribute{
+								{
+									Key:   awssdk.String("load_balancing.cross_zone.enabled"),
+									Value: awssdk.String("use_load_balancer_configuration"),
+								},
+							},
+						},
+					},
+				},
+				modifyTargetGroupAttributesWithContextCalls: []modifyTargetGroupAttributesWithContextCall{
+					{
+						req: &elbv2sdk.ModifyTargetGroupAttributesInput{
+							TargetGroupArn: awssdk.String("my-arn"),
+							Attributes: []*elbv2sdk.TargetGroupAttribute{
+								{
+									Key:   awssdk.String("load_balancing.cross_zone.enabled"),
+									Value: awssdk.String("true"),
+								},
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				sdkTG: TargetGroupWithTags{
+					TargetGroup: &elbv2sdk.TargetGroup{
+						TargetGroupArn: awssdk.String("my-arn"),
+					},
+				},
+				resTG: &elbv2model.TargetGroup{
+					ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::TargetGroup", "id-1"),
+					Spec: elbv2model.TargetGroupSpec{
+						TargetGroupAttributes: []elbv2model.TargetGroupAttribute{
+							{
+								Key:   "load_balancing.cross_zone.enabled",
+								Value: "true",
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
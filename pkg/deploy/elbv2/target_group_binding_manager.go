@@ -173,9 +173,12 @@ func buildK8sTargetGroupBindingSpec(ctx context.Context, resTGB *elbv2model.Targ
 	}
 
 	k8sTGBSpec := elbv2api.TargetGroupBindingSpec{
-		TargetGroupARN: tgARN,
-		TargetType:     resTGB.Spec.Template.Spec.TargetType,
-		ServiceRef:     resTGB.Spec.Template.Spec.ServiceRef,
+		TargetGroupARN:     tgARN,
+		TargetType:         resTGB.Spec.Template.Spec.TargetType,
+		ServiceRef:         resTGB.Spec.Template.Spec.ServiceRef,
+		IPAddressType:      resTGB.Spec.Template.Spec.IPAddressType,
+		IAMRoleARNToAssume: resTGB.Spec.Template.Spec.IAMRoleARNToAssume,
+		NodeSelector:       resTGB.Spec.Template.Spec.NodeSelector,
 	}
 
 	if resTGB.Spec.Template.Spec.Networking != nil {
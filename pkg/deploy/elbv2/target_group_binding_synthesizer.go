@@ -8,15 +8,18 @@ import (
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // NewTargetGroupBindingSynthesizer constructs new targetGroupBindingSynthesizer
-func NewTargetGroupBindingSynthesizer(k8sClient client.Client, trackingProvider tracking.Provider, tgbManager TargetGroupBindingManager, logger logr.Logger, stack core.Stack) *targetGroupBindingSynthesizer {
+func NewTargetGroupBindingSynthesizer(k8sClient client.Client, trackingProvider tracking.Provider, tgbManager TargetGroupBindingManager,
+	maxConcurrency int, logger logr.Logger, stack core.Stack) *targetGroupBindingSynthesizer {
 	return &targetGroupBindingSynthesizer{
 		k8sClient:        k8sClient,
 		trackingProvider: trackingProvider,
 		tgbManager:       tgbManager,
+		maxConcurrency:   maxConcurrency,
 		logger:           logger,
 		stack:            stack,
 
@@ -29,6 +32,7 @@ type targetGroupBindingSynthesizer struct {
 	k8sClient        client.Client
 	trackingProvider tracking.Provider
 	tgbManager       TargetGroupBindingManager
+	maxConcurrency   int
 	logger           logr.Logger
 	stack            core.Stack
 
@@ -49,30 +53,32 @@ func (s *targetGroupBindingSynthesizer) Synthesize(ctx context.Context) error {
 	}
 	s.unmatchedK8sTGBs = unmatchedK8sTGBs
 
-	for _, resTGB := range unmatchedResTGBs {
+	if err := runtime.ParallelizeUntilError(ctx, s.maxConcurrency, len(unmatchedResTGBs), func(ctx context.Context, i int) error {
+		resTGB := unmatchedResTGBs[i]
 		tgbStatus, err := s.tgbManager.Create(ctx, resTGB)
 		if err != nil {
 			return err
 		}
 		resTGB.SetStatus(tgbStatus)
+		return nil
+	}); err != nil {
+		return err
 	}
-	for _, resAndK8sTGB := range matchedResAndK8sTGBs {
+	return runtime.ParallelizeUntilError(ctx, s.maxConcurrency, len(matchedResAndK8sTGBs), func(ctx context.Context, i int) error {
+		resAndK8sTGB := matchedResAndK8sTGBs[i]
 		tgbStatus, err := s.tgbManager.Update(ctx, resAndK8sTGB.resTGB, resAndK8sTGB.k8sTGB)
 		if err != nil {
 			return err
 		}
 		resAndK8sTGB.resTGB.SetStatus(tgbStatus)
-	}
-	return nil
+		return nil
+	})
 }
 
 func (s *targetGroupBindingSynthesizer) PostSynthesize(ctx context.Context) error {
-	for _, k8sTGB := range s.unmatchedK8sTGBs {
-		if err := s.tgbManager.Delete(ctx, k8sTGB); err != nil {
-			return err
-		}
-	}
-	return nil
+	return runtime.ParallelizeUntilError(ctx, s.maxConcurrency, len(s.unmatchedK8sTGBs), func(ctx context.Context, i int) error {
+		return s.tgbManager.Delete(ctx, s.unmatchedK8sTGBs[i])
+	})
 }
 
 func (s *targetGroupBindingSynthesizer) findK8sTargetGroupBindings(ctx context.Context) ([]*elbv2api.TargetGroupBinding, error) {
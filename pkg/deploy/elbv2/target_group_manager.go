@@ -147,7 +147,8 @@ func (m *defaultTargetGroupManager) updateSDKTargetGroupWithTags(ctx context.Con
 	desiredTGTags := m.trackingProvider.ResourceTags(resTG.Stack(), resTG, resTG.Spec.Tags)
 	return m.taggingManager.ReconcileTags(ctx, awssdk.StringValue(sdkTG.TargetGroup.TargetGroupArn), desiredTGTags,
 		WithCurrentTags(sdkTG.Tags),
-		WithIgnoredTagKeys(m.trackingProvider.LegacyTagKeys()))
+		WithIgnoredTagKeys(m.trackingProvider.LegacyTagKeys()),
+		WithDeferredTagging())
 }
 
 func isSDKTargetGroupHealthCheckDrifted(tgSpec elbv2model.TargetGroupSpec, sdkTG TargetGroupWithTags) bool {
@@ -187,6 +188,9 @@ func buildSDKCreateTargetGroupInput(tgSpec elbv2model.TargetGroupSpec) *elbv2sdk
 	sdkObj := &elbv2sdk.CreateTargetGroupInput{}
 	sdkObj.Name = awssdk.String(tgSpec.Name)
 	sdkObj.TargetType = awssdk.String(string(tgSpec.TargetType))
+	if tgSpec.IPAddressType != nil {
+		sdkObj.IpAddressType = (*string)(tgSpec.IPAddressType)
+	}
 	sdkObj.Port = awssdk.Int64(tgSpec.Port)
 	sdkObj.Protocol = awssdk.String(string(tgSpec.Protocol))
 	if tgSpec.ProtocolVersion != nil {
@@ -10,16 +10,18 @@ import (
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
 )
 
 // NewTargetGroupSynthesizer constructs targetGroupSynthesizer
 func NewTargetGroupSynthesizer(elbv2Client services.ELBV2, trackingProvider tracking.Provider, taggingManager TaggingManager,
-	tgManager TargetGroupManager, logger logr.Logger, stack core.Stack) *targetGroupSynthesizer {
+	tgManager TargetGroupManager, maxConcurrency int, logger logr.Logger, stack core.Stack) *targetGroupSynthesizer {
 	return &targetGroupSynthesizer{
 		elbv2Client:      elbv2Client,
 		trackingProvider: trackingProvider,
 		taggingManager:   taggingManager,
 		tgManager:        tgManager,
+		maxConcurrency:   maxConcurrency,
 		logger:           logger,
 		stack:            stack,
 		unmatchedSDKTGs:  nil,
@@ -32,6 +34,7 @@ type targetGroupSynthesizer struct {
 	trackingProvider tracking.Provider
 	taggingManager   TaggingManager
 	tgManager        TargetGroupManager
+	maxConcurrency   int
 	logger           logr.Logger
 
 	stack           core.Stack
@@ -54,30 +57,33 @@ func (s *targetGroupSynthesizer) Synthesize(ctx context.Context) error {
 	// * unmatched targetGroups might still be use by a listener rule.
 	s.unmatchedSDKTGs = unmatchedSDKTGs
 
-	for _, resTG := range unmatchedResTGs {
+	// TargetGroups are independent of each other, thus can be created or updated in parallel.
+	if err := runtime.ParallelizeUntilError(ctx, s.maxConcurrency, len(unmatchedResTGs), func(ctx context.Context, i int) error {
+		resTG := unmatchedResTGs[i]
 		tgStatus, err := s.tgManager.Create(ctx, resTG)
 		if err != nil {
 			return err
 		}
 		resTG.SetStatus(tgStatus)
+		return nil
+	}); err != nil {
+		return err
 	}
-	for _, resAndSDKTG := range matchedResAndSDKTGs {
+	return runtime.ParallelizeUntilError(ctx, s.maxConcurrency, len(matchedResAndSDKTGs), func(ctx context.Context, i int) error {
+		resAndSDKTG := matchedResAndSDKTGs[i]
 		tgStatus, err := s.tgManager.Update(ctx, resAndSDKTG.resTG, resAndSDKTG.sdkTG)
 		if err != nil {
 			return err
 		}
 		resAndSDKTG.resTG.SetStatus(tgStatus)
-	}
-	return nil
+		return nil
+	})
 }
 
 func (s *targetGroupSynthesizer) PostSynthesize(ctx context.Context) error {
-	for _, sdkTG := range s.unmatchedSDKTGs {
-		if err := s.tgManager.Delete(ctx, sdkTG); err != nil {
-			return err
-		}
-	}
-	return nil
+	return runtime.ParallelizeUntilError(ctx, s.maxConcurrency, len(s.unmatchedSDKTGs), func(ctx context.Context, i int) error {
+		return s.tgManager.Delete(ctx, s.unmatchedSDKTGs[i])
+	})
 }
 
 // findSDKTargetGroups will find all AWS TargetGroups created for stack.
@@ -574,6 +574,28 @@ func Test_isSDKTargetGroupRequiresReplacement(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "port change of instance targetGroup shouldn't need replacement",
+			args: args{
+				sdkTG: TargetGroupWithTags{
+					TargetGroup: &elbv2sdk.TargetGroup{
+						TargetType:      awssdk.String("instance"),
+						Port:            awssdk.Int64(31080),
+						Protocol:        awssdk.String("HTTP"),
+						TargetGroupName: awssdk.String("my-tg"),
+					},
+				},
+				resTG: &elbv2model.TargetGroup{
+					Spec: elbv2model.TargetGroupSpec{
+						TargetType: elbv2model.TargetTypeInstance,
+						Port:       32080,
+						Protocol:   elbv2model.ProtocolHTTP,
+						Name:       "my-tg",
+					},
+				},
+			},
+			want: false,
+		},
 		{
 			name: "targetType change need replacement",
 			args: args{
@@ -0,0 +1,226 @@
+package deploy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// InventoryOwnerKindIngress is the kind of LoadBalancerInventory owners for IngressGroups.
+	InventoryOwnerKindIngress = "Ingress"
+	// InventoryOwnerKindService is the kind of LoadBalancerInventory owners for ServiceGroups.
+	InventoryOwnerKindService = "Service"
+
+	// the max length of the readable stackID within name of LoadBalancerInventory.
+	inventoryNameStackIDMaxLength = 200
+)
+
+// InventoryManager maintains a LoadBalancerInventory for each stack, which lists the AWS resources owned by the controller.
+type InventoryManager interface {
+	// Sync creates or updates the LoadBalancerInventory of a deployed stack, owned by objects of ownerKind.
+	Sync(ctx context.Context, ownerKind string, stack core.Stack, owners []types.NamespacedName) error
+
+	// Delete deletes the LoadBalancerInventory of stack if it exists.
+	Delete(ctx context.Context, ownerKind string, stackID core.StackID) error
+}
+
+// NewDefaultInventoryManager constructs new defaultInventoryManager.
+func NewDefaultInventoryManager(k8sClient client.Client, logger logr.Logger) *defaultInventoryManager {
+	return &defaultInventoryManager{
+		k8sClient: k8sClient,
+		logger:    logger,
+		now:       time.Now,
+	}
+}
+
+var _ InventoryManager = &defaultInventoryManager{}
+
+// default implementation for InventoryManager.
+type defaultInventoryManager struct {
+	k8sClient client.Client
+	logger    logr.Logger
+	now       func() time.Time
+}
+
+func (m *defaultInventoryManager) Sync(ctx context.Context, ownerKind string, stack core.Stack, owners []types.NamespacedName) error {
+	resources, err := BuildInventoryResources(stack)
+	if err != nil {
+		return err
+	}
+	inventoryOwners := make([]elbv2api.InventoryOwner, 0, len(owners))
+	for _, owner := range owners {
+		inventoryOwners = append(inventoryOwners, elbv2api.InventoryOwner{Namespace: owner.Namespace, Name: owner.Name})
+	}
+	sort.Slice(inventoryOwners, func(i, j int) bool {
+		if inventoryOwners[i].Namespace != inventoryOwners[j].Namespace {
+			return inventoryOwners[i].Namespace < inventoryOwners[j].Namespace
+		}
+		return inventoryOwners[i].Name < inventoryOwners[j].Name
+	})
+	lastSyncTime := metav1.NewTime(m.now())
+	status := elbv2api.LoadBalancerInventoryStatus{
+		OwnerKind:    ownerKind,
+		StackID:      stack.StackID().String(),
+		Owners:       inventoryOwners,
+		Resources:    resources,
+		LastSyncTime: &lastSyncTime,
+	}
+
+	inventoryKey := types.NamespacedName{Name: BuildInventoryName(ownerKind, stack.StackID())}
+	inventory := &elbv2api.LoadBalancerInventory{}
+	if err := m.k8sClient.Get(ctx, inventoryKey, inventory); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to get LoadBalancerInventory: %v", inventoryKey.Name)
+		}
+		inventory = &elbv2api.LoadBalancerInventory{
+			ObjectMeta: metav1.ObjectMeta{Name: inventoryKey.Name},
+			Status:     status,
+		}
+		if err := m.k8sClient.Create(ctx, inventory); err != nil {
+			return errors.Wrapf(err, "failed to create LoadBalancerInventory: %v", inventoryKey.Name)
+		}
+		m.logger.V(1).Info("created LoadBalancerInventory", "inventory", inventoryKey.Name, "stackID", status.StackID)
+		return nil
+	}
+	inventory.Status = status
+	if err := m.k8sClient.Update(ctx, inventory); err != nil {
+		return errors.Wrapf(err, "failed to update LoadBalancerInventory: %v", inventoryKey.Name)
+	}
+	return nil
+}
+
+func (m *defaultInventoryManager) Delete(ctx context.Context, ownerKind string, stackID core.StackID) error {
+	inventory := &elbv2api.LoadBalancerInventory{
+		ObjectMeta: metav1.ObjectMeta{Name: BuildInventoryName(ownerKind, stackID)},
+	}
+	if err := m.k8sClient.Delete(ctx, inventory); err != nil {
+		// there is nothing to delete if LoadBalancerInventory isn't installed.
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to delete LoadBalancerInventory: %v", inventory.Name)
+	}
+	m.logger.V(1).Info("deleted LoadBalancerInventory", "inventory", inventory.Name, "stackID", stackID.String())
+	return nil
+}
+
+// BuildInventoryName builds the name of LoadBalancerInventory for stack.
+// the stackID keeps the name readable, and the hash of it keeps names unique between stacks whose stackIDs are sanitized alike.
+func BuildInventoryName(ownerKind string, stackID core.StackID) string {
+	uuidHash := sha256.New()
+	_, _ = uuidHash.Write([]byte(ownerKind))
+	_, _ = uuidHash.Write([]byte(stackID.String()))
+	uuid := hex.EncodeToString(uuidHash.Sum(nil))
+
+	readableStackID := strings.ReplaceAll(stackID.String(), "/", ".")
+	if len(readableStackID) > inventoryNameStackIDMaxLength {
+		readableStackID = strings.TrimRight(readableStackID[:inventoryNameStackIDMaxLength], ".-")
+	}
+	return fmt.Sprintf("%s-%s-%.8s", strings.ToLower(ownerKind), readableStackID, uuid)
+}
+
+// BuildInventoryResources builds the AWS resources owned by the controller from a deployed stack, ordered by type and ID.
+func BuildInventoryResources(stack core.Stack) ([]elbv2api.InventoryResource, error) {
+	var resources []elbv2api.InventoryResource
+	addResource := func(res core.Resource, id string) {
+		resources = append(resources, elbv2api.InventoryResource{Type: res.Type(), ID: id})
+	}
+
+	var resLBs []*elbv2model.LoadBalancer
+	if err := stack.ListResources(&resLBs); err != nil {
+		return nil, err
+	}
+	for _, resLB := range resLBs {
+		if resLB.Status != nil {
+			addResource(resLB, resLB.Status.LoadBalancerARN)
+		}
+	}
+	var resLSs []*elbv2model.Listener
+	if err := stack.ListResources(&resLSs); err != nil {
+		return nil, err
+	}
+	for _, resLS := range resLSs {
+		if resLS.Status != nil {
+			addResource(resLS, resLS.Status.ListenerARN)
+		}
+	}
+	var resLRs []*elbv2model.ListenerRule
+	if err := stack.ListResources(&resLRs); err != nil {
+		return nil, err
+	}
+	for _, resLR := range resLRs {
+		if resLR.Status != nil {
+			addResource(resLR, resLR.Status.RuleARN)
+		}
+	}
+	var resTGs []*elbv2model.TargetGroup
+	if err := stack.ListResources(&resTGs); err != nil {
+		return nil, err
+	}
+	for _, resTG := range resTGs {
+		if resTG.Status != nil {
+			addResource(resTG, resTG.Status.TargetGroupARN)
+		}
+	}
+	var resSGs []*ec2model.SecurityGroup
+	if err := stack.ListResources(&resSGs); err != nil {
+		return nil, err
+	}
+	for _, resSG := range resSGs {
+		if resSG.Status != nil {
+			addResource(resSG, resSG.Status.GroupID)
+		}
+	}
+	var resEIPs []*ec2model.ElasticIP
+	if err := stack.ListResources(&resEIPs); err != nil {
+		return nil, err
+	}
+	for _, resEIP := range resEIPs {
+		if resEIP.Status != nil {
+			addResource(resEIP, resEIP.Status.AllocationID)
+		}
+	}
+	var resIPAMAllocations []*ec2model.IPAMPoolAllocation
+	if err := stack.ListResources(&resIPAMAllocations); err != nil {
+		return nil, err
+	}
+	for _, resIPAMAllocation := range resIPAMAllocations {
+		if resIPAMAllocation.Status != nil {
+			addResource(resIPAMAllocation, resIPAMAllocation.Status.AllocationID)
+		}
+	}
+	var resESs []*ec2model.VPCEndpointService
+	if err := stack.ListResources(&resESs); err != nil {
+		return nil, err
+	}
+	for _, resES := range resESs {
+		if resES.Status != nil {
+			addResource(resES, resES.Status.ServiceID)
+		}
+	}
+
+	// resources are listed in random order, sort them to keep the inventory stable.
+	sort.Slice(resources, func(i, j int) bool {
+		if resources[i].Type != resources[j].Type {
+			return resources[i].Type < resources[j].Type
+		}
+		return resources[i].ID < resources[j].ID
+	})
+	return resources, nil
+}
@@ -0,0 +1,272 @@
+package deploy
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_BuildInventoryName(t *testing.T) {
+	tests := []struct {
+		name      string
+		ownerKind string
+		stackID   core.StackID
+		want      string
+	}{
+		{
+			name:      "explicit IngressGroup",
+			ownerKind: InventoryOwnerKindIngress,
+			stackID:   core.StackID{Name: "awesome-group"},
+			want:      "ingress-awesome-group-2171c507",
+		},
+		{
+			name:      "implicit IngressGroup",
+			ownerKind: InventoryOwnerKindIngress,
+			stackID:   core.StackID{Namespace: "awesome-ns", Name: "ing-1"},
+			want:      "ingress-awesome-ns.ing-1-51491dd0",
+		},
+		{
+			name:      "explicit IngressGroup named alike implicit IngressGroup",
+			ownerKind: InventoryOwnerKindIngress,
+			stackID:   core.StackID{Name: "awesome-ns.ing-1"},
+			want:      "ingress-awesome-ns.ing-1-c98692e3",
+		},
+		{
+			name:      "Service",
+			ownerKind: InventoryOwnerKindService,
+			stackID:   core.StackID{Namespace: "awesome-ns", Name: "svc-1"},
+			want:      "service-awesome-ns.svc-1-5f810e3c",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BuildInventoryName(tt.ownerKind, tt.stackID)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_BuildInventoryName_longStackID(t *testing.T) {
+	stackID := core.StackID{Namespace: "awesome-ns", Name: strings.Repeat("a", 188) + "." + strings.Repeat("b", 60)}
+	got := BuildInventoryName(InventoryOwnerKindIngress, stackID)
+	assert.True(t, strings.HasPrefix(got, "ingress-awesome-ns."+strings.Repeat("a", 188)+"-"))
+	assert.Len(t, got, len("ingress-")+len("awesome-ns.")+188+len("-")+8)
+}
+
+func Test_BuildInventoryResources(t *testing.T) {
+	tests := []struct {
+		name       string
+		buildStack func(stack core.Stack)
+		want       []elbv2api.InventoryResource
+	}{
+		{
+			name: "deployed stack",
+			buildStack: func(stack core.Stack) {
+				lb := elbv2model.NewLoadBalancer(stack, "LoadBalancer", elbv2model.LoadBalancerSpec{})
+				lb.SetStatus(elbv2model.LoadBalancerStatus{
+					LoadBalancerARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-lb/1111111111",
+				})
+				ls443 := elbv2model.NewListener(stack, "443", elbv2model.ListenerSpec{LoadBalancerARN: core.LiteralStringToken("lb-arn")})
+				ls443.SetStatus(elbv2model.ListenerStatus{
+					ListenerARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener/app/my-lb/1111111111/bbbbbbbbbb",
+				})
+				ls80 := elbv2model.NewListener(stack, "80", elbv2model.ListenerSpec{LoadBalancerARN: core.LiteralStringToken("lb-arn")})
+				ls80.SetStatus(elbv2model.ListenerStatus{
+					ListenerARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener/app/my-lb/1111111111/aaaaaaaaaa",
+				})
+				lr := elbv2model.NewListenerRule(stack, "443:1", elbv2model.ListenerRuleSpec{ListenerARN: core.LiteralStringToken("ls-arn")})
+				lr.SetStatus(elbv2model.ListenerRuleStatus{
+					RuleARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener-rule/app/my-lb/1111111111/bbbbbbbbbb/cccccccccc",
+				})
+				tg := elbv2model.NewTargetGroup(stack, "awesome-ns/ing-svc:80", elbv2model.TargetGroupSpec{})
+				tg.SetStatus(elbv2model.TargetGroupStatus{
+					TargetGroupARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/2222222222",
+				})
+				sg := ec2model.NewSecurityGroup(stack, "ManagedLBSecurityGroup", ec2model.SecurityGroupSpec{})
+				sg.SetStatus(ec2model.SecurityGroupStatus{
+					GroupID: "sg-xxxxxxx",
+				})
+				eip := ec2model.NewElasticIP(stack, "us-west-2a", ec2model.ElasticIPSpec{})
+				eip.SetStatus(ec2model.ElasticIPStatus{
+					AllocationID: "eipalloc-xxxxxxx",
+					PublicIP:     "1.2.3.4",
+				})
+				es := ec2model.NewVPCEndpointService(stack, "VPCEndpointService", ec2model.VPCEndpointServiceSpec{})
+				es.SetStatus(ec2model.VPCEndpointServiceStatus{
+					ServiceID:   "vpce-svc-xxxxxxx",
+					ServiceName: "com.amazonaws.vpce.us-west-2.vpce-svc-xxxxxxx",
+				})
+			},
+			want: []elbv2api.InventoryResource{
+				{Type: "AWS::EC2::EIP", ID: "eipalloc-xxxxxxx"},
+				{Type: "AWS::EC2::SecurityGroup", ID: "sg-xxxxxxx"},
+				{Type: "AWS::EC2::VPCEndpointService", ID: "vpce-svc-xxxxxxx"},
+				{Type: "AWS::ElasticLoadBalancingV2::Listener", ID: "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener/app/my-lb/1111111111/aaaaaaaaaa"},
+				{Type: "AWS::ElasticLoadBalancingV2::Listener", ID: "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener/app/my-lb/1111111111/bbbbbbbbbb"},
+				{Type: "AWS::ElasticLoadBalancingV2::ListenerRule", ID: "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener-rule/app/my-lb/1111111111/bbbbbbbbbb/cccccccccc"},
+				{Type: "AWS::ElasticLoadBalancingV2::LoadBalancer", ID: "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-lb/1111111111"},
+				{Type: "AWS::ElasticLoadBalancingV2::TargetGroup", ID: "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/2222222222"},
+			},
+		},
+		{
+			name: "resources not deployed",
+			buildStack: func(stack core.Stack) {
+				elbv2model.NewLoadBalancer(stack, "LoadBalancer", elbv2model.LoadBalancerSpec{})
+				elbv2model.NewListener(stack, "80", elbv2model.ListenerSpec{LoadBalancerARN: core.LiteralStringToken("lb-arn")})
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stack := core.NewDefaultStack(core.StackID{Namespace: "awesome-ns", Name: "ing-1"})
+			tt.buildStack(stack)
+			got, err := BuildInventoryResources(stack)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_defaultInventoryManager_Sync(t *testing.T) {
+	lastSyncTime := metav1.NewTime(time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC))
+	tests := []struct {
+		name              string
+		existingInventory *elbv2api.LoadBalancerInventory
+		owners            []types.NamespacedName
+		want              elbv2api.LoadBalancerInventoryStatus
+	}{
+		{
+			name: "inventory not exists",
+			owners: []types.NamespacedName{
+				{Namespace: "awesome-ns", Name: "ing-2"},
+				{Namespace: "awesome-ns", Name: "ing-1"},
+			},
+			want: elbv2api.LoadBalancerInventoryStatus{
+				OwnerKind: InventoryOwnerKindIngress,
+				StackID:   "awesome-group",
+				Owners: []elbv2api.InventoryOwner{
+					{Namespace: "awesome-ns", Name: "ing-1"},
+					{Namespace: "awesome-ns", Name: "ing-2"},
+				},
+				Resources: []elbv2api.InventoryResource{
+					{Type: "AWS::ElasticLoadBalancingV2::LoadBalancer", ID: "lb-arn"},
+				},
+				LastSyncTime: &lastSyncTime,
+			},
+		},
+		{
+			name: "inventory exists",
+			existingInventory: &elbv2api.LoadBalancerInventory{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ingress-awesome-group-2171c507",
+				},
+				Status: elbv2api.LoadBalancerInventoryStatus{
+					OwnerKind: InventoryOwnerKindIngress,
+					StackID:   "awesome-group",
+					Owners: []elbv2api.InventoryOwner{
+						{Namespace: "awesome-ns", Name: "ing-0"},
+					},
+					Resources: []elbv2api.InventoryResource{
+						{Type: "AWS::ElasticLoadBalancingV2::LoadBalancer", ID: "old-lb-arn"},
+					},
+				},
+			},
+			owners: []types.NamespacedName{
+				{Namespace: "awesome-ns", Name: "ing-1"},
+			},
+			want: elbv2api.LoadBalancerInventoryStatus{
+				OwnerKind: InventoryOwnerKindIngress,
+				StackID:   "awesome-group",
+				Owners: []elbv2api.InventoryOwner{
+					{Namespace: "awesome-ns", Name: "ing-1"},
+				},
+				Resources: []elbv2api.InventoryResource{
+					{Type: "AWS::ElasticLoadBalancingV2::LoadBalancer", ID: "lb-arn"},
+				},
+				LastSyncTime: &lastSyncTime,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			if tt.existingInventory != nil {
+				assert.NoError(t, k8sClient.Create(ctx, tt.existingInventory.DeepCopy()))
+			}
+			stack := core.NewDefaultStack(core.StackID{Name: "awesome-group"})
+			lb := elbv2model.NewLoadBalancer(stack, "LoadBalancer", elbv2model.LoadBalancerSpec{})
+			lb.SetStatus(elbv2model.LoadBalancerStatus{LoadBalancerARN: "lb-arn"})
+
+			m := NewDefaultInventoryManager(k8sClient, &log.NullLogger{})
+			m.now = func() time.Time { return lastSyncTime.Time }
+			err := m.Sync(ctx, InventoryOwnerKindIngress, stack, tt.owners)
+			assert.NoError(t, err)
+
+			inventory := &elbv2api.LoadBalancerInventory{}
+			assert.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Name: "ingress-awesome-group-2171c507"}, inventory))
+			assert.Equal(t, tt.want.Owners, inventory.Status.Owners)
+			assert.Equal(t, tt.want.Resources, inventory.Status.Resources)
+			assert.Equal(t, tt.want.OwnerKind, inventory.Status.OwnerKind)
+			assert.Equal(t, tt.want.StackID, inventory.Status.StackID)
+			assert.True(t, tt.want.LastSyncTime.Equal(inventory.Status.LastSyncTime))
+		})
+	}
+}
+
+func Test_defaultInventoryManager_Delete(t *testing.T) {
+	tests := []struct {
+		name              string
+		existingInventory *elbv2api.LoadBalancerInventory
+	}{
+		{
+			name: "inventory exists",
+			existingInventory: &elbv2api.LoadBalancerInventory{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "service-awesome-ns.svc-1-5f810e3c",
+				},
+			},
+		},
+		{
+			name: "inventory not exists",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			if tt.existingInventory != nil {
+				assert.NoError(t, k8sClient.Create(ctx, tt.existingInventory.DeepCopy()))
+			}
+
+			m := NewDefaultInventoryManager(k8sClient, &log.NullLogger{})
+			stackID := core.StackID{Namespace: "awesome-ns", Name: "svc-1"}
+			err := m.Delete(ctx, InventoryOwnerKindService, stackID)
+			assert.NoError(t, err)
+
+			inventory := &elbv2api.LoadBalancerInventory{}
+			err = k8sClient.Get(ctx, types.NamespacedName{Name: BuildInventoryName(InventoryOwnerKindService, stackID)}, inventory)
+			assert.True(t, apierrors.IsNotFound(err))
+		})
+	}
+}
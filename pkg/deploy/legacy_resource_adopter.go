@@ -0,0 +1,239 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/ec2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"strings"
+	"time"
+)
+
+const (
+	// AWSALBIngressController(before v1.1.3) tags TargetGroups with the backend service.
+	tagKeyV1ServiceName = "kubernetes.io/service-name"
+	tagKeyV1ServicePort = "kubernetes.io/service-port"
+	// AWSALBIngressController(before v1.1.3) creates a SecurityGroup for worker nodes besides the LoadBalancer's one,
+	// which has the LoadBalancer's SecurityGroup name with this prefix.
+	v1InstanceSGNamePrefix = "instance-"
+
+	defaultAdoptedStacksCacheTTL = 24 * time.Hour
+)
+
+// NewLegacyResourceAdopter constructs new legacyResourceAdopter.
+// adoptedStacksCache is shared across deployments, so that legacy resources are only looked up until they are adopted.
+func NewLegacyResourceAdopter(ec2Client services.EC2, trackingProvider tracking.Provider,
+	elbv2TaggingManager elbv2.TaggingManager, ec2TaggingManager ec2.TaggingManager, vpcID string, adoptedStacksCache *cache.Expiring,
+	logger logr.Logger, stack core.Stack) *legacyResourceAdopter {
+	return &legacyResourceAdopter{
+		ec2Client:           ec2Client,
+		trackingProvider:    trackingProvider,
+		elbv2TaggingManager: elbv2TaggingManager,
+		ec2TaggingManager:   ec2TaggingManager,
+		vpcID:               vpcID,
+		adoptedStacksCache:  adoptedStacksCache,
+		logger:              logger,
+		stack:               stack,
+	}
+}
+
+// legacyResourceAdopter adopts AWS resources provisioned by AWSALBIngressController(before v1.1.3) for stack.
+// these resources are only tagged with the legacy tags, it adds the stack tags and resourceIDs onto them,
+// so that they are matched by the other synthesizers and reconciled in place instead of recreated.
+type legacyResourceAdopter struct {
+	ec2Client           services.EC2
+	trackingProvider    tracking.Provider
+	elbv2TaggingManager elbv2.TaggingManager
+	ec2TaggingManager   ec2.TaggingManager
+	vpcID               string
+	logger              logr.Logger
+
+	// adoptedStacksCache contains the stackIDs whose legacy resources are adopted.
+	// AWSALBIngressController no longer provisions resources once replaced, so stacks adopted once don't need to be looked up again.
+	adoptedStacksCache *cache.Expiring
+
+	stack core.Stack
+}
+
+func (a *legacyResourceAdopter) Synthesize(ctx context.Context) error {
+	stackTagsV1 := a.trackingProvider.StackTagsV1(a.stack)
+	if len(stackTagsV1) == 0 {
+		return nil
+	}
+	stackID := a.stack.StackID().String()
+	if _, adopted := a.adoptedStacksCache.Get(stackID); adopted {
+		return nil
+	}
+	if err := a.adoptLoadBalancer(ctx, stackTagsV1); err != nil {
+		return err
+	}
+	if err := a.adoptSecurityGroup(ctx, stackTagsV1); err != nil {
+		return err
+	}
+	if err := a.adoptTargetGroups(ctx, stackTagsV1); err != nil {
+		return err
+	}
+	a.adoptedStacksCache.Set(stackID, true, defaultAdoptedStacksCacheTTL)
+	return nil
+}
+
+func (a *legacyResourceAdopter) PostSynthesize(ctx context.Context) error {
+	// nothing to do here.
+	return nil
+}
+
+// adoptLoadBalancer adopts the LoadBalancer for the managed LoadBalancer resource in stack.
+func (a *legacyResourceAdopter) adoptLoadBalancer(ctx context.Context, stackTagsV1 map[string]string) error {
+	var resLBs []*elbv2model.LoadBalancer
+	a.stack.ListResources(&resLBs)
+	var managedResLBs []*elbv2model.LoadBalancer
+	for _, resLB := range resLBs {
+		if resLB.Spec.ExistingLoadBalancerARN == nil {
+			managedResLBs = append(managedResLBs, resLB)
+		}
+	}
+	if len(managedResLBs) != 1 {
+		return nil
+	}
+	sdkLBs, err := a.elbv2TaggingManager.ListLoadBalancers(ctx, tracking.TagsAsTagFilter(stackTagsV1))
+	if err != nil {
+		return err
+	}
+	for _, sdkLB := range sdkLBs {
+		if !a.isLegacyResource(sdkLB.Tags) {
+			continue
+		}
+		lbARN := awssdk.StringValue(sdkLB.LoadBalancer.LoadBalancerArn)
+		if err := a.adoptELBV2Resource(ctx, lbARN, sdkLB.Tags, managedResLBs[0]); err != nil {
+			return err
+		}
+		// AWSALBIngressController provisions only one LoadBalancer per Ingress.
+		break
+	}
+	return nil
+}
+
+// adoptSecurityGroup adopts the LoadBalancer's SecurityGroup for the managed SecurityGroup resource in stack.
+// the SecurityGroup for worker nodes isn't adopted, it's no longer needed and can be deleted after the migration.
+func (a *legacyResourceAdopter) adoptSecurityGroup(ctx context.Context, stackTagsV1 map[string]string) error {
+	var resSGs []*ec2model.SecurityGroup
+	a.stack.ListResources(&resSGs)
+	if len(resSGs) != 1 {
+		return nil
+	}
+	req := &ec2sdk.DescribeSecurityGroupsInput{
+		Filters: []*ec2sdk.Filter{
+			{
+				Name:   awssdk.String("vpc-id"),
+				Values: awssdk.StringSlice([]string{a.vpcID}),
+			},
+		},
+	}
+	for _, tagKey := range sets.StringKeySet(stackTagsV1).List() {
+		req.Filters = append(req.Filters, &ec2sdk.Filter{
+			Name:   awssdk.String(fmt.Sprintf("tag:%v", tagKey)),
+			Values: awssdk.StringSlice([]string{stackTagsV1[tagKey]}),
+		})
+	}
+	sdkSGs, err := a.ec2Client.DescribeSecurityGroupsAsList(ctx, req)
+	if err != nil {
+		return err
+	}
+	for _, sdkSG := range sdkSGs {
+		if strings.HasPrefix(awssdk.StringValue(sdkSG.GroupName), v1InstanceSGNamePrefix) {
+			continue
+		}
+		sgTags := make(map[string]string, len(sdkSG.Tags))
+		for _, tag := range sdkSG.Tags {
+			sgTags[awssdk.StringValue(tag.Key)] = awssdk.StringValue(tag.Value)
+		}
+		if !a.isLegacyResource(sgTags) {
+			continue
+		}
+		sgID := awssdk.StringValue(sdkSG.GroupId)
+		desiredTags := a.buildAdoptedResourceTags(sgTags, resSGs[0])
+		a.logger.Info("adopting legacy securityGroup", "securityGroupID", sgID, "resourceID", resSGs[0].ID())
+		if err := a.ec2TaggingManager.ReconcileTags(ctx, sgID, desiredTags, ec2.WithCurrentTags(sgTags)); err != nil {
+			return err
+		}
+		a.logger.Info("adopted legacy securityGroup", "securityGroupID", sgID)
+		break
+	}
+	return nil
+}
+
+// adoptTargetGroups adopts the TargetGroups for the TargetGroup resources in stack with the same backend service.
+func (a *legacyResourceAdopter) adoptTargetGroups(ctx context.Context, stackTagsV1 map[string]string) error {
+	var resTGs []*elbv2model.TargetGroup
+	a.stack.ListResources(&resTGs)
+	if len(resTGs) == 0 {
+		return nil
+	}
+	resTGByID := make(map[string]*elbv2model.TargetGroup, len(resTGs))
+	for _, resTG := range resTGs {
+		resTGByID[resTG.ID()] = resTG
+	}
+	sdkTGs, err := a.elbv2TaggingManager.ListTargetGroups(ctx, tracking.TagsAsTagFilter(stackTagsV1))
+	if err != nil {
+		return err
+	}
+	for _, sdkTG := range sdkTGs {
+		if !a.isLegacyResource(sdkTG.Tags) {
+			continue
+		}
+		resTG, exists := resTGByID[buildLegacyTargetGroupResourceID(sdkTG.Tags)]
+		if !exists {
+			continue
+		}
+		tgARN := awssdk.StringValue(sdkTG.TargetGroup.TargetGroupArn)
+		if err := a.adoptELBV2Resource(ctx, tgARN, sdkTG.Tags, resTG); err != nil {
+			return err
+		}
+		delete(resTGByID, resTG.ID())
+	}
+	return nil
+}
+
+func (a *legacyResourceAdopter) adoptELBV2Resource(ctx context.Context, arn string, currentTags map[string]string, res core.Resource) error {
+	desiredTags := a.buildAdoptedResourceTags(currentTags, res)
+	a.logger.Info("adopting legacy resource", "arn", arn, "resourceID", res.ID())
+	if err := a.elbv2TaggingManager.ReconcileTags(ctx, arn, desiredTags, elbv2.WithCurrentTags(currentTags)); err != nil {
+		return err
+	}
+	a.logger.Info("adopted legacy resource", "arn", arn)
+	return nil
+}
+
+// isLegacyResource checks whether the AWS resource with tags is only tracked by legacy tags.
+// resources of AWSALBIngressController(v1.1.3+) already carry resourceIDs, and are matched by the synthesizers directly.
+func (a *legacyResourceAdopter) isLegacyResource(tags map[string]string) bool {
+	_, hasResourceID := tags[a.trackingProvider.ResourceIDTagKey()]
+	return !hasResourceID
+}
+
+// buildAdoptedResourceTags builds the tags for adopted resource, existing tags are preserved.
+func (a *legacyResourceAdopter) buildAdoptedResourceTags(currentTags map[string]string, res core.Resource) map[string]string {
+	return algorithm.MergeStringMap(
+		a.trackingProvider.StackTags(a.stack),
+		map[string]string{a.trackingProvider.ResourceIDTagKey(): res.ID()},
+		currentTags,
+	)
+}
+
+// buildLegacyTargetGroupResourceID builds the resourceID of TargetGroup from the tags by AWSALBIngressController(before v1.1.3).
+// it's in the same format as TargetGroup resources for Ingresses: `namespace/ingressName-serviceName:servicePort`.
+func buildLegacyTargetGroupResourceID(tags map[string]string) string {
+	return fmt.Sprintf("%s/%s-%s:%s", tags["kubernetes.io/namespace"], tags["kubernetes.io/ingress-name"],
+		tags[tagKeyV1ServiceName], tags[tagKeyV1ServicePort])
+}
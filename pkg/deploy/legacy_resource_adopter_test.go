@@ -0,0 +1,197 @@
+package deploy
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/cache"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	mock_networking "sigs.k8s.io/aws-load-balancer-controller/mocks/networking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/ec2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_legacyResourceAdopter_Synthesize(t *testing.T) {
+	v1Tags := func(extraTags ...*elbv2sdk.Tag) []*elbv2sdk.Tag {
+		return append([]*elbv2sdk.Tag{
+			{Key: awssdk.String("kubernetes.io/cluster/my-cluster"), Value: awssdk.String("owned")},
+			{Key: awssdk.String("kubernetes.io/ingress-name"), Value: awssdk.String("ing")},
+			{Key: awssdk.String("kubernetes.io/namespace"), Value: awssdk.String("ns")},
+		}, extraTags...)
+	}
+	v1SGTags := []*ec2sdk.Tag{
+		{Key: awssdk.String("kubernetes.io/cluster/my-cluster"), Value: awssdk.String("owned")},
+		{Key: awssdk.String("kubernetes.io/ingress-name"), Value: awssdk.String("ing")},
+		{Key: awssdk.String("kubernetes.io/namespace"), Value: awssdk.String("ns")},
+	}
+	adoptedTags := func(resID string, extraTags ...*elbv2sdk.Tag) []*elbv2sdk.Tag {
+		return append([]*elbv2sdk.Tag{
+			{Key: awssdk.String("elbv2.k8s.aws/cluster"), Value: awssdk.String("my-cluster")},
+			{Key: awssdk.String("ingress.k8s.aws/resource"), Value: awssdk.String(resID)},
+			{Key: awssdk.String("ingress.k8s.aws/stack"), Value: awssdk.String("ns/ing")},
+		}, extraTags...)
+	}
+	type describeTagsCall struct {
+		arns []string
+		resp []*elbv2sdk.TagDescription
+	}
+	tests := []struct {
+		name              string
+		stackID           core.StackID
+		sdkLBs            []*elbv2sdk.LoadBalancer
+		sdkTGs            []*elbv2sdk.TargetGroup
+		describeTagsCalls []describeTagsCall
+		sdkSGs            []*ec2sdk.SecurityGroup
+		wantAddTagsReqs   []*elbv2sdk.AddTagsInput
+		wantCreateTagsReq *ec2sdk.CreateTagsInput
+	}{
+		{
+			name:    "adopt legacy resources",
+			stackID: core.StackID{Namespace: "ns", Name: "ing"},
+			sdkLBs: []*elbv2sdk.LoadBalancer{
+				{LoadBalancerArn: awssdk.String("lb-1")},
+			},
+			sdkTGs: []*elbv2sdk.TargetGroup{
+				{TargetGroupArn: awssdk.String("tg-1")},
+				{TargetGroupArn: awssdk.String("tg-2")},
+			},
+			describeTagsCalls: []describeTagsCall{
+				{
+					arns: []string{"lb-1"},
+					resp: []*elbv2sdk.TagDescription{
+						{ResourceArn: awssdk.String("lb-1"), Tags: v1Tags()},
+					},
+				},
+				{
+					arns: []string{"tg-1", "tg-2"},
+					resp: []*elbv2sdk.TagDescription{
+						{
+							ResourceArn: awssdk.String("tg-1"),
+							Tags: v1Tags(
+								&elbv2sdk.Tag{Key: awssdk.String("kubernetes.io/service-name"), Value: awssdk.String("svc")},
+								&elbv2sdk.Tag{Key: awssdk.String("kubernetes.io/service-port"), Value: awssdk.String("80")},
+							),
+						},
+						{
+							ResourceArn: awssdk.String("tg-2"),
+							Tags: v1Tags(
+								&elbv2sdk.Tag{Key: awssdk.String("kubernetes.io/service-name"), Value: awssdk.String("stale-svc")},
+								&elbv2sdk.Tag{Key: awssdk.String("kubernetes.io/service-port"), Value: awssdk.String("80")},
+							),
+						},
+					},
+				},
+			},
+			sdkSGs: []*ec2sdk.SecurityGroup{
+				{GroupId: awssdk.String("sg-lb"), GroupName: awssdk.String("abcd-ns-ing-1234"), Tags: v1SGTags},
+				{GroupId: awssdk.String("sg-instance"), GroupName: awssdk.String("instance-abcd-ns-ing-1234"), Tags: v1SGTags},
+			},
+			wantAddTagsReqs: []*elbv2sdk.AddTagsInput{
+				{
+					ResourceArns: awssdk.StringSlice([]string{"lb-1"}),
+					Tags:         adoptedTags("LoadBalancer"),
+				},
+				{
+					ResourceArns: awssdk.StringSlice([]string{"tg-1"}),
+					Tags:         adoptedTags("ns/ing-svc:80"),
+				},
+			},
+			wantCreateTagsReq: &ec2sdk.CreateTagsInput{
+				Resources: awssdk.StringSlice([]string{"sg-lb"}),
+				Tags: []*ec2sdk.Tag{
+					{Key: awssdk.String("elbv2.k8s.aws/cluster"), Value: awssdk.String("my-cluster")},
+					{Key: awssdk.String("ingress.k8s.aws/resource"), Value: awssdk.String("ManagedLBSecurityGroup")},
+					{Key: awssdk.String("ingress.k8s.aws/stack"), Value: awssdk.String("ns/ing")},
+				},
+			},
+		},
+		{
+			name:    "resources with resourceID are already tracked",
+			stackID: core.StackID{Namespace: "ns", Name: "ing"},
+			sdkLBs: []*elbv2sdk.LoadBalancer{
+				{LoadBalancerArn: awssdk.String("lb-1")},
+			},
+			describeTagsCalls: []describeTagsCall{
+				{
+					arns: []string{"lb-1"},
+					resp: []*elbv2sdk.TagDescription{
+						{
+							ResourceArn: awssdk.String("lb-1"),
+							Tags:        v1Tags(&elbv2sdk.Tag{Key: awssdk.String("ingress.k8s.aws/resource"), Value: awssdk.String("LoadBalancer")}),
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "explicit IngressGroup isn't adopted",
+			stackID: core.StackID{Name: "awesome-group"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			stack := core.NewDefaultStack(tt.stackID)
+			elbv2model.NewLoadBalancer(stack, "LoadBalancer", elbv2model.LoadBalancerSpec{})
+			elbv2model.NewTargetGroup(stack, "ns/ing-svc:80", elbv2model.TargetGroupSpec{})
+			ec2model.NewSecurityGroup(stack, "ManagedLBSecurityGroup", ec2model.SecurityGroupSpec{})
+
+			elbv2Client := mock_services.NewMockELBV2(ctrl)
+			ec2Client := mock_services.NewMockEC2(ctrl)
+			sgManager := mock_networking.NewMockSecurityGroupManager(ctrl)
+			if tt.stackID.Namespace != "" {
+				elbv2Client.EXPECT().DescribeLoadBalancersAsList(gomock.Any(), gomock.Any()).Return(tt.sdkLBs, nil)
+				elbv2Client.EXPECT().DescribeTargetGroupsAsList(gomock.Any(), gomock.Any()).Return(tt.sdkTGs, nil)
+				ec2Client.EXPECT().DescribeSecurityGroupsAsList(gomock.Any(), gomock.Any()).Return(tt.sdkSGs, nil)
+			}
+			for _, call := range tt.describeTagsCalls {
+				elbv2Client.EXPECT().DescribeTagsWithContext(gomock.Any(), &elbv2sdk.DescribeTagsInput{
+					ResourceArns: awssdk.StringSlice(call.arns),
+				}).Return(&elbv2sdk.DescribeTagsOutput{TagDescriptions: call.resp}, nil)
+			}
+			for _, req := range tt.wantAddTagsReqs {
+				elbv2Client.EXPECT().AddTagsWithContext(gomock.Any(), req).Return(&elbv2sdk.AddTagsOutput{}, nil)
+			}
+			if tt.wantCreateTagsReq != nil {
+				ec2Client.EXPECT().CreateTagsWithContext(gomock.Any(), tt.wantCreateTagsReq).Return(&ec2sdk.CreateTagsOutput{}, nil)
+				sgManager.EXPECT().InvalidateSGInfos(awssdk.StringValue(tt.wantCreateTagsReq.Resources[0]))
+			}
+
+			adoptedStacksCache := cache.NewExpiring()
+			trackingProvider := tracking.NewDefaultProvider("ingress.k8s.aws", "my-cluster")
+			elbv2TaggingManager := elbv2.NewDefaultTaggingManager(elbv2Client, nil, &log.NullLogger{})
+			ec2TaggingManager := ec2.NewDefaultTaggingManager(ec2Client, sgManager, "vpc-1", &log.NullLogger{})
+			a := NewLegacyResourceAdopter(ec2Client, trackingProvider, elbv2TaggingManager, ec2TaggingManager,
+				"vpc-1", adoptedStacksCache, &log.NullLogger{}, stack)
+			err := a.Synthesize(context.Background())
+			assert.NoError(t, err)
+
+			// adopted stacks are not looked up again.
+			a = NewLegacyResourceAdopter(ec2Client, trackingProvider, elbv2TaggingManager, ec2TaggingManager,
+				"vpc-1", adoptedStacksCache, &log.NullLogger{}, stack)
+			err = a.Synthesize(context.Background())
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_buildLegacyTargetGroupResourceID(t *testing.T) {
+	tags := map[string]string{
+		"kubernetes.io/namespace":    "ns",
+		"kubernetes.io/ingress-name": "ing",
+		"kubernetes.io/service-name": "svc",
+		"kubernetes.io/service-port": "http",
+	}
+	assert.Equal(t, "ns/ing-svc:http", buildLegacyTargetGroupResourceID(tags))
+}
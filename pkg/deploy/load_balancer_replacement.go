@@ -0,0 +1,97 @@
+package deploy
+
+import (
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"time"
+)
+
+const (
+	// defaultReplacementDrainWindow is the default duration the replaced LoadBalancer keeps serving after DNS outputs switch.
+	defaultReplacementDrainWindow = 5 * time.Minute
+	// replacementPollInterval is the interval to check whether a replacement is active or its targets are healthy.
+	replacementPollInterval = 15 * time.Second
+)
+
+// ResolveLoadBalancerReplacement resolves how the LoadBalancer is replaced from the replacement annotations of each object.
+// nil is returned if no replacement strategy is specified, in which case LoadBalancer is deleted before creating its replacement,
+// but only for changes that always require replacement, like a scheme change.
+func ResolveLoadBalancerReplacement(annotationParser annotations.Parser, strategySuffix string, drainWindowSuffix string,
+	objAnnotations ...map[string]string) (*elbv2model.LoadBalancerReplacement, error) {
+	rawStrategies := sets.NewString()
+	rawDrainWindows := sets.NewString()
+	for _, annotations := range objAnnotations {
+		rawStrategy := ""
+		if exists := annotationParser.ParseStringAnnotation(strategySuffix, &rawStrategy, annotations); exists {
+			rawStrategies.Insert(rawStrategy)
+		}
+		rawDrainWindow := ""
+		if exists := annotationParser.ParseStringAnnotation(drainWindowSuffix, &rawDrainWindow, annotations); exists {
+			rawDrainWindows.Insert(rawDrainWindow)
+		}
+	}
+	if len(rawStrategies) > 1 {
+		return nil, errors.Errorf("conflicting replacement strategy: %v", rawStrategies.List())
+	}
+	if len(rawDrainWindows) > 1 {
+		return nil, errors.Errorf("conflicting replacement drain window: %v", rawDrainWindows.List())
+	}
+	rawStrategy, _ := rawStrategies.PopAny()
+	switch elbv2model.LoadBalancerReplacementStrategy(rawStrategy) {
+	case "":
+		return nil, nil
+	case elbv2model.LoadBalancerReplacementStrategyDeleteCreate:
+		return &elbv2model.LoadBalancerReplacement{
+			Strategy: elbv2model.LoadBalancerReplacementStrategyDeleteCreate,
+		}, nil
+	case elbv2model.LoadBalancerReplacementStrategyBlueGreen:
+	default:
+		return nil, errors.Errorf("unknown replacement strategy: %v", rawStrategy)
+	}
+	drainWindow := defaultReplacementDrainWindow
+	if rawDrainWindow, ok := rawDrainWindows.PopAny(); ok {
+		var err error
+		if drainWindow, err = time.ParseDuration(rawDrainWindow); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse replacement drain window: %v", rawDrainWindow)
+		}
+		if drainWindow < 0 {
+			return nil, errors.Errorf("replacement drain window must be non-negative, drainWindow: %v", rawDrainWindow)
+		}
+	}
+	return &elbv2model.LoadBalancerReplacement{
+		Strategy:           elbv2model.LoadBalancerReplacementStrategyBlueGreen,
+		DrainWindowSeconds: int64(drainWindow / time.Second),
+	}, nil
+}
+
+// BuildLoadBalancerReplacementRequeueAfter returns the duration after which blue/green replacements of LoadBalancers in stack
+// progress to the next phase, zero is returned if no LoadBalancer is being replaced.
+func BuildLoadBalancerReplacementRequeueAfter(stack core.Stack, now time.Time) (time.Duration, error) {
+	var resLBs []*elbv2model.LoadBalancer
+	if err := stack.ListResources(&resLBs); err != nil {
+		return 0, err
+	}
+	requeueAfter := time.Duration(0)
+	for _, resLB := range resLBs {
+		if resLB.Status == nil || resLB.Status.Replacement == nil {
+			continue
+		}
+		// replacements are polled while the replaced LoadBalancer drains as well, so that targets of cloned targetGroups are kept up to date.
+		nextPhaseAfter := replacementPollInterval
+		if resLB.Status.Replacement.RetireTime != nil {
+			if retireAfter := resLB.Status.Replacement.RetireTime.Sub(now); retireAfter < nextPhaseAfter {
+				nextPhaseAfter = retireAfter
+			}
+			if nextPhaseAfter < time.Second {
+				nextPhaseAfter = time.Second
+			}
+		}
+		if requeueAfter == 0 || nextPhaseAfter < requeueAfter {
+			requeueAfter = nextPhaseAfter
+		}
+	}
+	return requeueAfter, nil
+}
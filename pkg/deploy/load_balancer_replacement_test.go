@@ -0,0 +1,177 @@
+package deploy
+
+import (
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"testing"
+	"time"
+)
+
+func Test_ResolveLoadBalancerReplacement(t *testing.T) {
+	tests := []struct {
+		name           string
+		objAnnotations []map[string]string
+		want           *elbv2model.LoadBalancerReplacement
+		wantErr        error
+	}{
+		{
+			name:           "no annotations",
+			objAnnotations: []map[string]string{{}},
+			want:           nil,
+		},
+		{
+			name: "delete-create strategy",
+			objAnnotations: []map[string]string{
+				{"alb.ingress.kubernetes.io/replacement-strategy": "delete-create"},
+			},
+			want: &elbv2model.LoadBalancerReplacement{
+				Strategy: elbv2model.LoadBalancerReplacementStrategyDeleteCreate,
+			},
+		},
+		{
+			name: "blue-green strategy with default drain window",
+			objAnnotations: []map[string]string{
+				{"alb.ingress.kubernetes.io/replacement-strategy": "blue-green"},
+				{},
+			},
+			want: &elbv2model.LoadBalancerReplacement{
+				Strategy:           elbv2model.LoadBalancerReplacementStrategyBlueGreen,
+				DrainWindowSeconds: 300,
+			},
+		},
+		{
+			name: "blue-green strategy with drain window",
+			objAnnotations: []map[string]string{
+				{"alb.ingress.kubernetes.io/replacement-strategy": "blue-green"},
+				{
+					"alb.ingress.kubernetes.io/replacement-strategy":     "blue-green",
+					"alb.ingress.kubernetes.io/replacement-drain-window": "90s",
+				},
+			},
+			want: &elbv2model.LoadBalancerReplacement{
+				Strategy:           elbv2model.LoadBalancerReplacementStrategyBlueGreen,
+				DrainWindowSeconds: 90,
+			},
+		},
+		{
+			name: "conflicting strategies",
+			objAnnotations: []map[string]string{
+				{"alb.ingress.kubernetes.io/replacement-strategy": "blue-green"},
+				{"alb.ingress.kubernetes.io/replacement-strategy": "delete-create"},
+			},
+			wantErr: errors.New("conflicting replacement strategy: [blue-green delete-create]"),
+		},
+		{
+			name: "unknown strategy",
+			objAnnotations: []map[string]string{
+				{"alb.ingress.kubernetes.io/replacement-strategy": "rolling"},
+			},
+			wantErr: errors.New("unknown replacement strategy: rolling"),
+		},
+		{
+			name: "negative drain window",
+			objAnnotations: []map[string]string{
+				{
+					"alb.ingress.kubernetes.io/replacement-strategy":     "blue-green",
+					"alb.ingress.kubernetes.io/replacement-drain-window": "-1m",
+				},
+			},
+			wantErr: errors.New("replacement drain window must be non-negative, drainWindow: -1m"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			annotationParser := annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
+			got, err := ResolveLoadBalancerReplacement(annotationParser, "replacement-strategy", "replacement-drain-window", tt.objAnnotations...)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_BuildLoadBalancerReplacementRequeueAfter(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	retireTime := now.Add(2 * time.Minute)
+	imminentRetireTime := now.Add(5 * time.Second)
+	expiredRetireTime := now.Add(-time.Minute)
+	tests := []struct {
+		name     string
+		lbStatus *elbv2model.LoadBalancerStatus
+		want     time.Duration
+	}{
+		{
+			name:     "LoadBalancer not fulfilled",
+			lbStatus: nil,
+			want:     0,
+		},
+		{
+			name:     "no replacement",
+			lbStatus: &elbv2model.LoadBalancerStatus{LoadBalancerARN: "lb-arn"},
+			want:     0,
+		},
+		{
+			name: "provisioning replacement",
+			lbStatus: &elbv2model.LoadBalancerStatus{
+				LoadBalancerARN: "lb-arn",
+				Replacement: &elbv2model.LoadBalancerReplacementStatus{
+					ReplacedLoadBalancerARN: "replaced-lb-arn",
+					Phase:                   elbv2model.LoadBalancerReplacementPhaseProvisioning,
+				},
+			},
+			want: 15 * time.Second,
+		},
+		{
+			name: "draining replaced LoadBalancer",
+			lbStatus: &elbv2model.LoadBalancerStatus{
+				LoadBalancerARN: "lb-arn",
+				Replacement: &elbv2model.LoadBalancerReplacementStatus{
+					ReplacedLoadBalancerARN: "replaced-lb-arn",
+					Phase:                   elbv2model.LoadBalancerReplacementPhaseDraining,
+					RetireTime:              &retireTime,
+				},
+			},
+			want: 15 * time.Second,
+		},
+		{
+			name: "replaced LoadBalancer retires before next poll",
+			lbStatus: &elbv2model.LoadBalancerStatus{
+				LoadBalancerARN: "lb-arn",
+				Replacement: &elbv2model.LoadBalancerReplacementStatus{
+					ReplacedLoadBalancerARN: "replaced-lb-arn",
+					Phase:                   elbv2model.LoadBalancerReplacementPhaseDraining,
+					RetireTime:              &imminentRetireTime,
+				},
+			},
+			want: 5 * time.Second,
+		},
+		{
+			name: "drain window elapsed",
+			lbStatus: &elbv2model.LoadBalancerStatus{
+				LoadBalancerARN: "lb-arn",
+				Replacement: &elbv2model.LoadBalancerReplacementStatus{
+					ReplacedLoadBalancerARN: "replaced-lb-arn",
+					Phase:                   elbv2model.LoadBalancerReplacementPhaseDraining,
+					RetireTime:              &expiredRetireTime,
+				},
+			},
+			want: time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stack := core.NewDefaultStack(core.StackID{Namespace: "awesome-ns", Name: "awesome-ing"})
+			lb := elbv2model.NewLoadBalancer(stack, "LoadBalancer", elbv2model.LoadBalancerSpec{})
+			lb.Status = tt.lbStatus
+			got, err := BuildLoadBalancerReplacementRequeueAfter(stack, now)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
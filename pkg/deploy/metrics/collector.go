@@ -0,0 +1,140 @@
+package metrics
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/util/sets"
+	awsmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/aws/metrics"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
+	"sync"
+	"time"
+)
+
+const (
+	OperationCreate = "create"
+	OperationUpdate = "update"
+	OperationDelete = "delete"
+)
+
+// Collector collects metrics for operations deploy managers perform on resources.
+type Collector interface {
+	// ObserveResourceOperation observes the latency and result of an operation on resource of specified kind.
+	ObserveResourceOperation(resourceKind string, operation string, duration time.Duration, err error)
+
+	// ObserveTagsReconcile observes the latency of tags reconcile on resource of specified kind.
+	ObserveTagsReconcile(resourceKind string, duration time.Duration)
+
+	// ObserveCostEstimate observes the monthly cost estimate of LoadBalancer for stack, attributed to namespaces of its members.
+	// costs previously observed for namespaces absent from monthlyCostByNamespace are removed.
+	ObserveCostEstimate(stackID string, monthlyCostByNamespace map[string]float64)
+}
+
+// NewCollector constructs new collector that registers metrics to registerer.
+func NewCollector(registerer prometheus.Registerer) (*collector, error) {
+	instruments, err := newInstruments(registerer)
+	if err != nil {
+		return nil, err
+	}
+	return &collector{
+		instruments:                   instruments,
+		costEstimateNamespacesByStack: make(map[string]sets.String),
+	}, nil
+}
+
+var _ Collector = &collector{}
+
+type collector struct {
+	instruments *instruments
+
+	// costEstimateNamespacesByStack contains the namespaces that cost estimate is observed for each stack.
+	costEstimateNamespacesByStack map[string]sets.String
+	costEstimateMutex             sync.Mutex
+}
+
+func (c *collector) ObserveResourceOperation(resourceKind string, operation string, duration time.Duration, err error) {
+	c.instruments.resourceOperationDurationSeconds.With(map[string]string{
+		labelResourceKind: resourceKind,
+		labelOperation:    operation,
+	}).Observe(duration.Seconds())
+	if err != nil {
+		c.instruments.resourceOperationErrorsTotal.With(map[string]string{
+			labelResourceKind: resourceKind,
+			labelOperation:    operation,
+			labelErrorCode:    errorCodeForError(err),
+		}).Inc()
+	}
+}
+
+func (c *collector) ObserveTagsReconcile(resourceKind string, duration time.Duration) {
+	c.instruments.tagsReconcileDurationSeconds.With(map[string]string{
+		labelResourceKind: resourceKind,
+	}).Observe(duration.Seconds())
+}
+
+func (c *collector) ObserveCostEstimate(stackID string, monthlyCostByNamespace map[string]float64) {
+	c.costEstimateMutex.Lock()
+	defer c.costEstimateMutex.Unlock()
+	namespaces := sets.StringKeySet(monthlyCostByNamespace)
+	for _, namespace := range c.costEstimateNamespacesByStack[stackID].Difference(namespaces).List() {
+		c.instruments.loadBalancerMonthlyCostEstimate.Delete(map[string]string{
+			labelStack:     stackID,
+			labelNamespace: namespace,
+		})
+	}
+	for namespace, monthlyCost := range monthlyCostByNamespace {
+		c.instruments.loadBalancerMonthlyCostEstimate.With(map[string]string{
+			labelStack:     stackID,
+			labelNamespace: namespace,
+		}).Set(monthlyCost)
+	}
+	if len(namespaces) == 0 {
+		delete(c.costEstimateNamespacesByStack, stackID)
+	} else {
+		c.costEstimateNamespacesByStack[stackID] = namespaces
+	}
+}
+
+// NewNoopCollector constructs new Collector that discards all metrics.
+func NewNoopCollector() *noopCollector {
+	return &noopCollector{}
+}
+
+var _ Collector = &noopCollector{}
+
+type noopCollector struct{}
+
+func (c *noopCollector) ObserveResourceOperation(_ string, _ string, _ time.Duration, _ error) {}
+
+func (c *noopCollector) ObserveTagsReconcile(_ string, _ time.Duration) {}
+
+func (c *noopCollector) ObserveCostEstimate(_ string, _ map[string]float64) {}
+
+// InstrumentResourceOperation runs an operation on resource of specified kind and observes its latency and result.
+// AWS API calls made by the operation are attributed to the resource kind as well.
+func InstrumentResourceOperation(ctx context.Context, collector Collector, resourceKind string, operation string, fn func(ctx context.Context) error) error {
+	startTime := time.Now()
+	err := fn(awsmetrics.ContextWithResourceKind(ctx, resourceKind))
+	collector.ObserveResourceOperation(resourceKind, operation, time.Since(startTime), err)
+	return err
+}
+
+// InstrumentTagsReconcile runs tags reconcile on resource of specified kind and observes its latency.
+// AWS API calls made by the tags reconcile are attributed to the resource kind as well, and it's timed as ReconcileTags stage.
+func InstrumentTagsReconcile(ctx context.Context, collector Collector, resourceKind string, fn func(ctx context.Context) error) error {
+	startTime := time.Now()
+	err := runtime.TimeStage(awsmetrics.ContextWithResourceKind(ctx, resourceKind), "ReconcileTags", fn)
+	collector.ObserveTagsReconcile(resourceKind, time.Since(startTime))
+	return err
+}
+
+// errorCodeForError returns the AWS error code for err.
+// if err isn't an AWS error, returns "internal".
+func errorCodeForError(err error) string {
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		return awsErr.Code()
+	}
+	return "internal"
+}
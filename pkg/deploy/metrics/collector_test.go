@@ -0,0 +1,112 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	awsmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/aws/metrics"
+	"testing"
+)
+
+func Test_errorCodeForError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "internal error",
+			err:  errors.New("oops, some internal error"),
+			want: "internal",
+		},
+		{
+			name: "aws error",
+			err:  awserr.New("InvalidGroup.NotFound", "", nil),
+			want: "InvalidGroup.NotFound",
+		},
+		{
+			name: "wrapped aws error",
+			err:  pkgerrors.Wrap(awserr.New("Throttling", "", nil), "failed to create loadBalancer"),
+			want: "Throttling",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := errorCodeForError(tt.err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestInstrumentResourceOperation(t *testing.T) {
+	collector, err := NewCollector(prometheus.NewRegistry())
+	assert.NoError(t, err)
+
+	err = InstrumentResourceOperation(context.Background(), collector, "AWS::EC2::SecurityGroup", OperationCreate, func(ctx context.Context) error {
+		resourceKind, ok := awsmetrics.ResourceKindFromContext(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, "AWS::EC2::SecurityGroup", resourceKind)
+		return awserr.New("InvalidGroup.Duplicate", "", nil)
+	})
+	assert.EqualError(t, err, "InvalidGroup.Duplicate: ")
+	assert.Equal(t, float64(1), testutil.ToFloat64(collector.instruments.resourceOperationErrorsTotal.With(map[string]string{
+		labelResourceKind: "AWS::EC2::SecurityGroup",
+		labelOperation:    OperationCreate,
+		labelErrorCode:    "InvalidGroup.Duplicate",
+	})))
+
+	err = InstrumentResourceOperation(context.Background(), collector, "AWS::EC2::SecurityGroup", OperationDelete, func(ctx context.Context) error {
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, float64(0), testutil.ToFloat64(collector.instruments.resourceOperationErrorsTotal.With(map[string]string{
+		labelResourceKind: "AWS::EC2::SecurityGroup",
+		labelOperation:    OperationDelete,
+		labelErrorCode:    "internal",
+	})))
+}
+
+func Test_collector_ObserveCostEstimate(t *testing.T) {
+	collector, err := NewCollector(prometheus.NewRegistry())
+	assert.NoError(t, err)
+
+	collector.ObserveCostEstimate("awesome-group", map[string]float64{
+		"namespace-a": 10.5,
+		"namespace-b": 10.5,
+	})
+	assert.Equal(t, 2, countMetrics(collector.instruments.loadBalancerMonthlyCostEstimate))
+	assert.Equal(t, 10.5, testutil.ToFloat64(collector.instruments.loadBalancerMonthlyCostEstimate.With(map[string]string{
+		labelStack:     "awesome-group",
+		labelNamespace: "namespace-a",
+	})))
+
+	collector.ObserveCostEstimate("awesome-group", map[string]float64{
+		"namespace-a": 21,
+	})
+	assert.Equal(t, 1, countMetrics(collector.instruments.loadBalancerMonthlyCostEstimate))
+	assert.Equal(t, float64(21), testutil.ToFloat64(collector.instruments.loadBalancerMonthlyCostEstimate.With(map[string]string{
+		labelStack:     "awesome-group",
+		labelNamespace: "namespace-a",
+	})))
+
+	collector.ObserveCostEstimate("awesome-group", nil)
+	assert.Equal(t, 0, countMetrics(collector.instruments.loadBalancerMonthlyCostEstimate))
+	assert.Empty(t, collector.costEstimateNamespacesByStack)
+}
+
+func countMetrics(c prometheus.Collector) int {
+	ch := make(chan prometheus.Metric)
+	go func() {
+		c.Collect(ch)
+		close(ch)
+	}()
+	count := 0
+	for range ch {
+		count++
+	}
+	return count
+}
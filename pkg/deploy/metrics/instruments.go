@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	metricSubsystemDeploy = "deploy"
+
+	metricResourceOperationDurationSeconds = "resource_operation_duration_seconds"
+	metricResourceOperationErrorsTotal     = "resource_operation_errors_total"
+	metricTagsReconcileDurationSeconds     = "tags_reconcile_duration_seconds"
+	metricLoadBalancerMonthlyCostEstimate  = "load_balancer_monthly_cost_estimate_usd"
+)
+
+const (
+	labelResourceKind = "resource_kind"
+	labelOperation    = "operation"
+	labelErrorCode    = "error_code"
+	labelStack        = "stack"
+	labelNamespace    = "namespace"
+)
+
+type instruments struct {
+	resourceOperationDurationSeconds *prometheus.HistogramVec
+	resourceOperationErrorsTotal     *prometheus.CounterVec
+	tagsReconcileDurationSeconds     *prometheus.HistogramVec
+	loadBalancerMonthlyCostEstimate  *prometheus.GaugeVec
+}
+
+// newInstruments allocates and register new metrics to registerer
+func newInstruments(registerer prometheus.Registerer) (*instruments, error) {
+	resourceOperationDurationSeconds := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Subsystem: metricSubsystemDeploy,
+		Name:      metricResourceOperationDurationSeconds,
+		Help:      "Latency of create/update/delete operations on resources, includes all AWS API calls made by the operation",
+	}, []string{labelResourceKind, labelOperation})
+	resourceOperationErrorsTotal := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricSubsystemDeploy,
+		Name:      metricResourceOperationErrorsTotal,
+		Help:      "Total number of failed create/update/delete operations on resources",
+	}, []string{labelResourceKind, labelOperation, labelErrorCode})
+	tagsReconcileDurationSeconds := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Subsystem: metricSubsystemDeploy,
+		Name:      metricTagsReconcileDurationSeconds,
+		Help:      "Latency of tags reconcile on resources",
+	}, []string{labelResourceKind})
+	loadBalancerMonthlyCostEstimate := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricSubsystemDeploy,
+		Name:      metricLoadBalancerMonthlyCostEstimate,
+		Help:      "Rough monthly cost estimate in USD of the LoadBalancer of each stack, split evenly between its members and attributed to their namespaces",
+	}, []string{labelStack, labelNamespace})
+
+	if err := registerer.Register(resourceOperationDurationSeconds); err != nil {
+		return nil, err
+	}
+	if err := registerer.Register(resourceOperationErrorsTotal); err != nil {
+		return nil, err
+	}
+	if err := registerer.Register(tagsReconcileDurationSeconds); err != nil {
+		return nil, err
+	}
+	if err := registerer.Register(loadBalancerMonthlyCostEstimate); err != nil {
+		return nil, err
+	}
+	return &instruments{
+		resourceOperationDurationSeconds: resourceOperationDurationSeconds,
+		resourceOperationErrorsTotal:     resourceOperationErrorsTotal,
+		tagsReconcileDurationSeconds:     tagsReconcileDurationSeconds,
+		loadBalancerMonthlyCostEstimate:  loadBalancerMonthlyCostEstimate,
+	}, nil
+}
@@ -0,0 +1,127 @@
+package deploy
+
+import (
+	"encoding/json"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	zonalshiftmodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/zonalshift"
+	"sort"
+	"time"
+)
+
+// ProvisionedResources contains identifiers of AWS resources provisioned for a stack.
+type ProvisionedResources struct {
+	// The Amazon Resource Name (ARN) of the load balancer.
+	LoadBalancerARN string `json:"loadBalancerARN,omitempty"`
+
+	// The Amazon Resource Names (ARN) of the listeners.
+	ListenerARNs []string `json:"listenerARNs,omitempty"`
+
+	// The Amazon Resource Names (ARN) of the target groups.
+	TargetGroupARNs []string `json:"targetGroupARNs,omitempty"`
+
+	// The IDs of security groups managed by the controller.
+	SecurityGroupIDs []string `json:"securityGroupIDs,omitempty"`
+
+	// The zonal shifts active on the load balancer.
+	ZonalShifts []ProvisionedZonalShift `json:"zonalShifts,omitempty"`
+
+	// The name of VPC endpoint service fronting the load balancer, which service consumers use to create endpoints.
+	VPCEndpointServiceName string `json:"vpcEndpointServiceName,omitempty"`
+}
+
+// ProvisionedZonalShift contains information of a zonal shift started for a stack.
+type ProvisionedZonalShift struct {
+	// The ID of the zonal shift.
+	ZonalShiftID string `json:"zonalShiftID"`
+
+	// The Availability Zone ID that traffic is moved away from.
+	AwayFrom string `json:"awayFrom"`
+
+	// The time when the zonal shift expires.
+	ExpiryTime string `json:"expiryTime"`
+}
+
+// BuildProvisionedResources builds the ProvisionedResources from a deployed stack.
+func BuildProvisionedResources(stack core.Stack) (ProvisionedResources, error) {
+	var resLBs []*elbv2model.LoadBalancer
+	if err := stack.ListResources(&resLBs); err != nil {
+		return ProvisionedResources{}, err
+	}
+	var resLSs []*elbv2model.Listener
+	if err := stack.ListResources(&resLSs); err != nil {
+		return ProvisionedResources{}, err
+	}
+	var resTGs []*elbv2model.TargetGroup
+	if err := stack.ListResources(&resTGs); err != nil {
+		return ProvisionedResources{}, err
+	}
+	var resSGs []*ec2model.SecurityGroup
+	if err := stack.ListResources(&resSGs); err != nil {
+		return ProvisionedResources{}, err
+	}
+	var resZonalShifts []*zonalshiftmodel.ZonalShift
+	if err := stack.ListResources(&resZonalShifts); err != nil {
+		return ProvisionedResources{}, err
+	}
+	var resESs []*ec2model.VPCEndpointService
+	if err := stack.ListResources(&resESs); err != nil {
+		return ProvisionedResources{}, err
+	}
+
+	var resources ProvisionedResources
+	for _, resLB := range resLBs {
+		if resLB.Status != nil {
+			resources.LoadBalancerARN = resLB.Status.LoadBalancerARN
+		}
+	}
+	for _, resLS := range resLSs {
+		if resLS.Status != nil {
+			resources.ListenerARNs = append(resources.ListenerARNs, resLS.Status.ListenerARN)
+		}
+	}
+	for _, resTG := range resTGs {
+		if resTG.Status != nil {
+			resources.TargetGroupARNs = append(resources.TargetGroupARNs, resTG.Status.TargetGroupARN)
+		}
+	}
+	for _, resSG := range resSGs {
+		if resSG.Status != nil {
+			resources.SecurityGroupIDs = append(resources.SecurityGroupIDs, resSG.Status.GroupID)
+		}
+	}
+	for _, resZonalShift := range resZonalShifts {
+		if resZonalShift.Status != nil {
+			resources.ZonalShifts = append(resources.ZonalShifts, ProvisionedZonalShift{
+				ZonalShiftID: resZonalShift.Status.ZonalShiftID,
+				AwayFrom:     resZonalShift.Spec.AwayFrom,
+				ExpiryTime:   resZonalShift.Status.ExpiryTime.UTC().Format(time.RFC3339),
+			})
+		}
+	}
+	for _, resES := range resESs {
+		if resES.Status != nil {
+			resources.VPCEndpointServiceName = resES.Status.ServiceName
+		}
+	}
+	// resources are listed in random order, sort them to keep the payload stable.
+	sort.Strings(resources.ListenerARNs)
+	sort.Strings(resources.TargetGroupARNs)
+	sort.Strings(resources.SecurityGroupIDs)
+	return resources, nil
+}
+
+// BuildProvisionedResourcesPayload builds the JSON payload of ProvisionedResources from a deployed stack,
+// which is reported via events and exported by ProvisionedResourcesExporter.
+func BuildProvisionedResourcesPayload(stack core.Stack) (string, error) {
+	resources, err := BuildProvisionedResources(stack)
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(resources)
+	if err != nil {
+		return "", err
+	}
+	return string(payload), nil
+}
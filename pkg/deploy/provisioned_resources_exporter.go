@@ -0,0 +1,132 @@
+package deploy
+
+import (
+	"context"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sync"
+)
+
+const (
+	// ProvisionedResourcesKeyPrefixIngress is the prefix of keys in the provisioned resources ConfigMap for Ingresses.
+	ProvisionedResourcesKeyPrefixIngress = "ingress."
+	// ProvisionedResourcesKeyPrefixService is the prefix of keys in the provisioned resources ConfigMap for Services.
+	ProvisionedResourcesKeyPrefixService = "service."
+)
+
+// ProvisionedResourcesExporter exports the AWS resources provisioned for Ingresses and Services into a ConfigMap per namespace,
+// so that external consumers can look them up without querying AWS.
+type ProvisionedResourcesExporter interface {
+	// Export writes the provisioned resources under key into the ConfigMap of namespace,
+	// it returns whether the provisioned resources changed since they were last exported under key.
+	Export(ctx context.Context, namespace string, key string, provisionedResources string) (bool, error)
+
+	// Unexport removes the key from the ConfigMap of namespace.
+	Unexport(ctx context.Context, namespace string, key string) error
+}
+
+// NewDefaultProvisionedResourcesExporter constructs new defaultProvisionedResourcesExporter.
+// provisioned resources won't be exported if configMapName is empty, but changes are still tracked in memory.
+func NewDefaultProvisionedResourcesExporter(k8sClient client.Client, configMapName string, logger logr.Logger) *defaultProvisionedResourcesExporter {
+	return &defaultProvisionedResourcesExporter{
+		k8sClient:          k8sClient,
+		configMapName:      configMapName,
+		logger:             logger,
+		exportedValueByKey: make(map[types.NamespacedName]string),
+	}
+}
+
+var _ ProvisionedResourcesExporter = &defaultProvisionedResourcesExporter{}
+
+// default implementation for ProvisionedResourcesExporter.
+type defaultProvisionedResourcesExporter struct {
+	k8sClient     client.Client
+	configMapName string
+	logger        logr.Logger
+
+	// the provisioned resources exported by namespace and key, only tracked when export is disabled.
+	exportedValueByKeyMutex sync.Mutex
+	exportedValueByKey      map[types.NamespacedName]string
+}
+
+func (e *defaultProvisionedResourcesExporter) Export(ctx context.Context, namespace string, key string, provisionedResources string) (bool, error) {
+	if e.configMapName == "" {
+		return e.trackExportedValue(namespace, key, provisionedResources), nil
+	}
+	cmKey := types.NamespacedName{Namespace: namespace, Name: e.configMapName}
+	cm := &corev1.ConfigMap{}
+	if err := e.k8sClient.Get(ctx, cmKey, cm); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return false, errors.Wrapf(err, "failed to get provisioned resources configMap: %v", cmKey)
+		}
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      e.configMapName,
+			},
+			Data: map[string]string{
+				key: provisionedResources,
+			},
+		}
+		if err := e.k8sClient.Create(ctx, cm); err != nil {
+			return false, errors.Wrapf(err, "failed to create provisioned resources configMap: %v", cmKey)
+		}
+		e.logger.Info("created provisioned resources configMap", "configMap", cmKey)
+		return true, nil
+	}
+	if existingValue, exists := cm.Data[key]; exists && existingValue == provisionedResources {
+		return false, nil
+	}
+	cmOld := cm.DeepCopy()
+	if cm.Data == nil {
+		cm.Data = make(map[string]string)
+	}
+	cm.Data[key] = provisionedResources
+	if err := e.k8sClient.Patch(ctx, cm, client.MergeFrom(cmOld)); err != nil {
+		return false, errors.Wrapf(err, "failed to update provisioned resources configMap: %v", cmKey)
+	}
+	return true, nil
+}
+
+func (e *defaultProvisionedResourcesExporter) Unexport(ctx context.Context, namespace string, key string) error {
+	if e.configMapName == "" {
+		e.exportedValueByKeyMutex.Lock()
+		delete(e.exportedValueByKey, types.NamespacedName{Namespace: namespace, Name: key})
+		e.exportedValueByKeyMutex.Unlock()
+		return nil
+	}
+	cmKey := types.NamespacedName{Namespace: namespace, Name: e.configMapName}
+	cm := &corev1.ConfigMap{}
+	if err := e.k8sClient.Get(ctx, cmKey, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to get provisioned resources configMap: %v", cmKey)
+	}
+	if _, exists := cm.Data[key]; !exists {
+		return nil
+	}
+	cmOld := cm.DeepCopy()
+	delete(cm.Data, key)
+	if err := e.k8sClient.Patch(ctx, cm, client.MergeFrom(cmOld)); err != nil {
+		return errors.Wrapf(err, "failed to update provisioned resources configMap: %v", cmKey)
+	}
+	return nil
+}
+
+// trackExportedValue records provisionedResources as exported under key of namespace in memory, it returns whether they changed.
+func (e *defaultProvisionedResourcesExporter) trackExportedValue(namespace string, key string, provisionedResources string) bool {
+	e.exportedValueByKeyMutex.Lock()
+	defer e.exportedValueByKeyMutex.Unlock()
+	trackingKey := types.NamespacedName{Namespace: namespace, Name: key}
+	if existingValue, exists := e.exportedValueByKey[trackingKey]; exists && existingValue == provisionedResources {
+		return false
+	}
+	e.exportedValueByKey[trackingKey] = provisionedResources
+	return true
+}
@@ -0,0 +1,222 @@
+package deploy
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_defaultProvisionedResourcesExporter_Export(t *testing.T) {
+	tests := []struct {
+		name          string
+		configMapName string
+		existingCM    *corev1.ConfigMap
+		key           string
+		value         string
+		wantChanged   bool
+		wantData      map[string]string
+	}{
+		{
+			name:          "export disabled",
+			configMapName: "",
+			key:           "ingress.ing-1",
+			value:         `{"loadBalancerARN":"lb-arn"}`,
+			wantChanged:   true,
+			wantData:      nil,
+		},
+		{
+			name:          "configMap not exists",
+			configMapName: "provisioned-resources",
+			key:           "ingress.ing-1",
+			value:         `{"loadBalancerARN":"lb-arn"}`,
+			wantChanged:   true,
+			wantData: map[string]string{
+				"ingress.ing-1": `{"loadBalancerARN":"lb-arn"}`,
+			},
+		},
+		{
+			name:          "configMap exists with other keys",
+			configMapName: "provisioned-resources",
+			existingCM: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "provisioned-resources",
+				},
+				Data: map[string]string{
+					"service.svc-1": `{"loadBalancerARN":"lb-arn-2"}`,
+					"ingress.ing-1": `{"loadBalancerARN":"lb-arn-old"}`,
+				},
+			},
+			key:         "ingress.ing-1",
+			value:       `{"loadBalancerARN":"lb-arn"}`,
+			wantChanged: true,
+			wantData: map[string]string{
+				"service.svc-1": `{"loadBalancerARN":"lb-arn-2"}`,
+				"ingress.ing-1": `{"loadBalancerARN":"lb-arn"}`,
+			},
+		},
+		{
+			name:          "configMap exists with unchanged key",
+			configMapName: "provisioned-resources",
+			existingCM: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "provisioned-resources",
+				},
+				Data: map[string]string{
+					"ingress.ing-1": `{"loadBalancerARN":"lb-arn"}`,
+				},
+			},
+			key:         "ingress.ing-1",
+			value:       `{"loadBalancerARN":"lb-arn"}`,
+			wantChanged: false,
+			wantData: map[string]string{
+				"ingress.ing-1": `{"loadBalancerARN":"lb-arn"}`,
+			},
+		},
+		{
+			name:          "configMap exists without data",
+			configMapName: "provisioned-resources",
+			existingCM: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "provisioned-resources",
+				},
+			},
+			key:         "service.svc-1",
+			value:       `{"loadBalancerARN":"lb-arn"}`,
+			wantChanged: true,
+			wantData: map[string]string{
+				"service.svc-1": `{"loadBalancerARN":"lb-arn"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			ctx := context.Background()
+			if tt.existingCM != nil {
+				assert.NoError(t, k8sClient.Create(ctx, tt.existingCM.DeepCopy()))
+			}
+			e := NewDefaultProvisionedResourcesExporter(k8sClient, tt.configMapName, &log.NullLogger{})
+			changed, err := e.Export(ctx, "awesome-ns", tt.key, tt.value)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantChanged, changed)
+			changed, err = e.Export(ctx, "awesome-ns", tt.key, tt.value)
+			assert.NoError(t, err)
+			assert.False(t, changed)
+
+			cm := &corev1.ConfigMap{}
+			err = k8sClient.Get(ctx, types.NamespacedName{Namespace: "awesome-ns", Name: "provisioned-resources"}, cm)
+			if tt.wantData == nil {
+				assert.True(t, apierrors.IsNotFound(err))
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantData, cm.Data)
+			}
+		})
+	}
+}
+
+func Test_defaultProvisionedResourcesExporter_Unexport(t *testing.T) {
+	tests := []struct {
+		name       string
+		existingCM *corev1.ConfigMap
+		key        string
+		wantData   map[string]string
+	}{
+		{
+			name: "configMap not exists",
+			key:  "ingress.ing-1",
+		},
+		{
+			name: "configMap contains key",
+			existingCM: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "provisioned-resources",
+				},
+				Data: map[string]string{
+					"service.svc-1": `{"loadBalancerARN":"lb-arn-2"}`,
+					"ingress.ing-1": `{"loadBalancerARN":"lb-arn"}`,
+				},
+			},
+			key: "ingress.ing-1",
+			wantData: map[string]string{
+				"service.svc-1": `{"loadBalancerARN":"lb-arn-2"}`,
+			},
+		},
+		{
+			name: "configMap doesn't contain key",
+			existingCM: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "provisioned-resources",
+				},
+				Data: map[string]string{
+					"service.svc-1": `{"loadBalancerARN":"lb-arn-2"}`,
+				},
+			},
+			key: "ingress.ing-1",
+			wantData: map[string]string{
+				"service.svc-1": `{"loadBalancerARN":"lb-arn-2"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			ctx := context.Background()
+			if tt.existingCM != nil {
+				assert.NoError(t, k8sClient.Create(ctx, tt.existingCM.DeepCopy()))
+			}
+			e := NewDefaultProvisionedResourcesExporter(k8sClient, "provisioned-resources", &log.NullLogger{})
+			err := e.Unexport(ctx, "awesome-ns", tt.key)
+			assert.NoError(t, err)
+
+			if tt.existingCM != nil {
+				cm := &corev1.ConfigMap{}
+				assert.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: "awesome-ns", Name: "provisioned-resources"}, cm))
+				assert.Equal(t, tt.wantData, cm.Data)
+			}
+		})
+	}
+}
+
+func Test_defaultProvisionedResourcesExporter_exportDisabled(t *testing.T) {
+	k8sSchema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+	ctx := context.Background()
+	e := NewDefaultProvisionedResourcesExporter(k8sClient, "", &log.NullLogger{})
+
+	changed, err := e.Export(ctx, "awesome-ns", "ingress.ing-1", `{"loadBalancerARN":"lb-arn"}`)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	changed, err = e.Export(ctx, "awesome-ns", "ingress.ing-1", `{"loadBalancerARN":"lb-arn"}`)
+	assert.NoError(t, err)
+	assert.False(t, changed)
+	changed, err = e.Export(ctx, "other-ns", "ingress.ing-1", `{"loadBalancerARN":"lb-arn"}`)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	changed, err = e.Export(ctx, "awesome-ns", "ingress.ing-1", `{"loadBalancerARN":"lb-arn-2"}`)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+
+	assert.NoError(t, e.Unexport(ctx, "awesome-ns", "ingress.ing-1"))
+	changed, err = e.Export(ctx, "awesome-ns", "ingress.ing-1", `{"loadBalancerARN":"lb-arn-2"}`)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+}
@@ -0,0 +1,113 @@
+package deploy
+
+import (
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	zonalshiftmodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/zonalshift"
+	"testing"
+	"time"
+)
+
+func Test_BuildProvisionedResourcesPayload(t *testing.T) {
+	tests := []struct {
+		name       string
+		buildStack func(stack core.Stack)
+		want       string
+	}{
+		{
+			name: "deployed stack",
+			buildStack: func(stack core.Stack) {
+				lb := elbv2model.NewLoadBalancer(stack, "LoadBalancer", elbv2model.LoadBalancerSpec{})
+				lb.SetStatus(elbv2model.LoadBalancerStatus{
+					LoadBalancerARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-lb/1111111111",
+					DNSName:         "my-lb-1111111111.us-west-2.elb.amazonaws.com",
+				})
+				ls443 := elbv2model.NewListener(stack, "443", elbv2model.ListenerSpec{LoadBalancerARN: core.LiteralStringToken("lb-arn")})
+				ls443.SetStatus(elbv2model.ListenerStatus{
+					ListenerARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener/app/my-lb/1111111111/bbbbbbbbbb",
+				})
+				ls80 := elbv2model.NewListener(stack, "80", elbv2model.ListenerSpec{LoadBalancerARN: core.LiteralStringToken("lb-arn")})
+				ls80.SetStatus(elbv2model.ListenerStatus{
+					ListenerARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener/app/my-lb/1111111111/aaaaaaaaaa",
+				})
+				tg := elbv2model.NewTargetGroup(stack, "awesome-ns/ing-svc:80", elbv2model.TargetGroupSpec{})
+				tg.SetStatus(elbv2model.TargetGroupStatus{
+					TargetGroupARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/2222222222",
+				})
+				sg := ec2model.NewSecurityGroup(stack, "ManagedLBSecurityGroup", ec2model.SecurityGroupSpec{})
+				sg.SetStatus(ec2model.SecurityGroupStatus{
+					GroupID: "sg-xxxxxxx",
+				})
+			},
+			want: `{"loadBalancerARN":"arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-lb/1111111111",` +
+				`"listenerARNs":["arn:aws:elasticloadbalancing:us-west-2:123456789012:listener/app/my-lb/1111111111/aaaaaaaaaa",` +
+				`"arn:aws:elasticloadbalancing:us-west-2:123456789012:listener/app/my-lb/1111111111/bbbbbbbbbb"],` +
+				`"targetGroupARNs":["arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/2222222222"],` +
+				`"securityGroupIDs":["sg-xxxxxxx"]}`,
+		},
+		{
+			name: "resources not deployed",
+			buildStack: func(stack core.Stack) {
+				elbv2model.NewLoadBalancer(stack, "LoadBalancer", elbv2model.LoadBalancerSpec{})
+				elbv2model.NewListener(stack, "80", elbv2model.ListenerSpec{LoadBalancerARN: core.LiteralStringToken("lb-arn")})
+			},
+			want: `{}`,
+		},
+		{
+			name: "deployed stack with zonal shift",
+			buildStack: func(stack core.Stack) {
+				lb := elbv2model.NewLoadBalancer(stack, "LoadBalancer", elbv2model.LoadBalancerSpec{})
+				lb.SetStatus(elbv2model.LoadBalancerStatus{
+					LoadBalancerARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-lb/1111111111",
+				})
+				zonalShift := zonalshiftmodel.NewZonalShift(stack, "ZonalShift", zonalshiftmodel.ZonalShiftSpec{
+					ResourceARN: core.LiteralStringToken("lb-arn"),
+					AwayFrom:    "usw2-az1",
+					ExpiresIn:   "1h",
+				})
+				zonalShift.SetStatus(zonalshiftmodel.ZonalShiftStatus{
+					ZonalShiftID: "zonal-shift-id",
+					Status:       "ACTIVE",
+					ExpiryTime:   metav1.NewTime(time.Date(2021, 1, 1, 1, 0, 0, 0, time.UTC)),
+				})
+			},
+			want: `{"loadBalancerARN":"arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-lb/1111111111",` +
+				`"zonalShifts":[{"zonalShiftID":"zonal-shift-id","awayFrom":"usw2-az1","expiryTime":"2021-01-01T01:00:00Z"}]}`,
+		},
+		{
+			name: "deployed stack with vpc endpoint service",
+			buildStack: func(stack core.Stack) {
+				lb := elbv2model.NewLoadBalancer(stack, "LoadBalancer", elbv2model.LoadBalancerSpec{})
+				lb.SetStatus(elbv2model.LoadBalancerStatus{
+					LoadBalancerARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/net/my-lb/1111111111",
+				})
+				es := ec2model.NewVPCEndpointService(stack, "LoadBalancer", ec2model.VPCEndpointServiceSpec{
+					NetworkLoadBalancerARNs: []core.StringToken{core.LiteralStringToken("lb-arn")},
+				})
+				es.SetStatus(ec2model.VPCEndpointServiceStatus{
+					ServiceID:   "vpce-svc-0123456789abcdef0",
+					ServiceName: "com.amazonaws.vpce.us-west-2.vpce-svc-0123456789abcdef0",
+				})
+			},
+			want: `{"loadBalancerARN":"arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/net/my-lb/1111111111",` +
+				`"vpcEndpointServiceName":"com.amazonaws.vpce.us-west-2.vpce-svc-0123456789abcdef0"}`,
+		},
+		{
+			name:       "empty stack",
+			buildStack: func(stack core.Stack) {},
+			want:       `{}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stack := core.NewDefaultStack(core.StackID{Namespace: "awesome-ns", Name: "ing"})
+			tt.buildStack(stack)
+			got, err := BuildProvisionedResourcesPayload(stack)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package quota
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sync"
+)
+
+// NamespaceResource is a kind of AWS resource limited per namespace by ResourceQuotaPolicies.
+type NamespaceResource string
+
+const (
+	NamespaceResourceApplicationLoadBalancers NamespaceResource = "applicationLoadBalancers"
+	NamespaceResourceNetworkLoadBalancers     NamespaceResource = "networkLoadBalancers"
+	NamespaceResourceElasticIPs               NamespaceResource = "elasticIPs"
+	NamespaceResourceTargetGroups             NamespaceResource = "targetGroups"
+)
+
+// namespaceResources are the kinds of AWS resources limited per namespace, in the order they're checked.
+var namespaceResources = []NamespaceResource{
+	NamespaceResourceApplicationLoadBalancers,
+	NamespaceResourceNetworkLoadBalancers,
+	NamespaceResourceElasticIPs,
+	NamespaceResourceTargetGroups,
+}
+
+// NamespaceUsage is the number of AWS resources by kind.
+type NamespaceUsage map[NamespaceResource]int64
+
+// BuildNamespaceUsage builds the number of AWS resources by kind in stack.
+func BuildNamespaceUsage(stack core.Stack) NamespaceUsage {
+	usage := make(NamespaceUsage)
+	var resLBs []*elbv2model.LoadBalancer
+	stack.ListResources(&resLBs)
+	for _, resLB := range resLBs {
+		if resLB.Spec.Type == elbv2model.LoadBalancerTypeApplication {
+			usage[NamespaceResourceApplicationLoadBalancers]++
+		} else {
+			usage[NamespaceResourceNetworkLoadBalancers]++
+		}
+	}
+	var resEIPs []*ec2model.ElasticIP
+	stack.ListResources(&resEIPs)
+	if len(resEIPs) != 0 {
+		usage[NamespaceResourceElasticIPs] = int64(len(resEIPs))
+	}
+	var resTGs []*elbv2model.TargetGroup
+	stack.ListResources(&resTGs)
+	if len(resTGs) != 0 {
+		usage[NamespaceResourceTargetGroups] = int64(len(resTGs))
+	}
+	return usage
+}
+
+// NamespaceLimit is the limit of a kind of AWS resource in a namespace.
+type NamespaceLimit struct {
+	// Value is the maximum number of resources.
+	Value int64
+	// Policy is the name of ResourceQuotaPolicy imposing the limit.
+	Policy string
+}
+
+// NamespaceLimits are the limits of a namespace by kind of AWS resource, kinds without a limit are unlimited.
+type NamespaceLimits map[NamespaceResource]NamespaceLimit
+
+// Check returns a NamespaceQuotaExceededError if desired number of resource exceeds its limit in namespace.
+func (l NamespaceLimits) Check(namespace string, resource NamespaceResource, desired int64) error {
+	limit, ok := l[resource]
+	if !ok || desired <= limit.Value {
+		return nil
+	}
+	return &NamespaceQuotaExceededError{
+		Namespace: namespace,
+		Resource:  resource,
+		Policy:    limit.Policy,
+		Value:     limit.Value,
+		Desired:   desired,
+	}
+}
+
+// ResolveNamespaceLimits resolves the limits of namespace from the ResourceQuotaPolicies selecting it.
+// the lowest limit applies if multiple policies select namespace, and namespaces are unlimited if ResourceQuotaPolicy isn't installed.
+func ResolveNamespaceLimits(ctx context.Context, k8sClient client.Client, namespace string) (NamespaceLimits, error) {
+	policyList := &elbv2api.ResourceQuotaPolicyList{}
+	if err := k8sClient.List(ctx, policyList); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to list ResourceQuotaPolicies")
+	}
+	if len(policyList.Items) == 0 {
+		return nil, nil
+	}
+	ns := &corev1.Namespace{}
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		return nil, errors.Wrapf(err, "failed to get namespace: %v", namespace)
+	}
+
+	limits := make(NamespaceLimits)
+	for _, policy := range policyList.Items {
+		selector := labels.Everything()
+		if policy.Spec.NamespaceSelector != nil {
+			var err error
+			if selector, err = metav1.LabelSelectorAsSelector(policy.Spec.NamespaceSelector); err != nil {
+				return nil, errors.Wrapf(err, "invalid namespaceSelector of ResourceQuotaPolicy: %v", policy.Name)
+			}
+		}
+		if !selector.Matches(labels.Set(ns.Labels)) {
+			continue
+		}
+		hard := policy.Spec.Hard
+		for resource, value := range map[NamespaceResource]*int64{
+			NamespaceResourceApplicationLoadBalancers: hard.ApplicationLoadBalancers,
+			NamespaceResourceNetworkLoadBalancers:     hard.NetworkLoadBalancers,
+			NamespaceResourceElasticIPs:               hard.ElasticIPs,
+			NamespaceResourceTargetGroups:             hard.TargetGroups,
+		} {
+			if value == nil {
+				continue
+			}
+			if limit, ok := limits[resource]; ok && limit.Value <= *value {
+				continue
+			}
+			limits[resource] = NamespaceLimit{Value: *value, Policy: policy.Name}
+		}
+	}
+	return limits, nil
+}
+
+// NamespaceQuotaExceededError is returned when desired resources exceed the limit of a namespace.
+type NamespaceQuotaExceededError struct {
+	// Namespace is the namespace whose limit is exceeded.
+	Namespace string
+	// Resource is the kind of AWS resource exceeding its limit.
+	Resource NamespaceResource
+	// Policy is the name of ResourceQuotaPolicy imposing the limit.
+	Policy string
+	// Value is the value of limit.
+	Value int64
+	// Desired is the desired number of resources in namespace.
+	Desired int64
+}
+
+func (e *NamespaceQuotaExceededError) Error() string {
+	return fmt.Sprintf("ResourceQuotaPolicy %v exceeded for %v in namespace %v: %v desired, limit %v",
+		e.Policy, e.Resource, e.Namespace, e.Desired, e.Value)
+}
+
+func (e *NamespaceQuotaExceededError) ErrorClass() runtime.ErrorClass {
+	return runtime.ErrorClassQuotaExceeded
+}
+
+// NamespaceQuotaChecker checks resource stacks against the limits of namespaces they're provisioned for.
+type NamespaceQuotaChecker interface {
+	// Reserve records resources in stack as the usage of namespaces if they don't exceed the limit of any of namespaces,
+	// it returns a NamespaceQuotaExceededError otherwise. A stack without resources releases its usage.
+	// deployed indicates whether stack was deployed before, per the status of its objects in cache.
+	Reserve(ctx context.Context, stack core.Stack, namespaces []string, deployed bool) error
+}
+
+// NewDefaultNamespaceQuotaChecker constructs new defaultNamespaceQuotaChecker.
+func NewDefaultNamespaceQuotaChecker(k8sClient client.Client) *defaultNamespaceQuotaChecker {
+	return &defaultNamespaceQuotaChecker{
+		k8sClient:   k8sClient,
+		stackUsages: make(map[core.StackID]stackUsage),
+	}
+}
+
+var _ NamespaceQuotaChecker = &defaultNamespaceQuotaChecker{}
+
+// stackUsage is the usage of a stack, which is charged to each of its namespaces.
+type stackUsage struct {
+	namespaces sets.String
+	usage      NamespaceUsage
+}
+
+// default implementation for NamespaceQuotaChecker.
+// the usage of namespaces is tracked in memory from the stacks reserved by controller, and rebuilt as stacks are reconciled after restart.
+// only growth of a stack beyond its recorded usage is checked, so that lowering a limit doesn't block updates of existing stacks.
+// stacks already deployed but not yet recorded(e.g. after restart) take their desired usage as recorded usage, since their
+// usage before restart is unknown.
+// usage is reserved ahead of deployment under a single lock, so that concurrent reconciles cannot both pass the check.
+type defaultNamespaceQuotaChecker struct {
+	k8sClient client.Client
+
+	stackUsages      map[core.StackID]stackUsage
+	stackUsagesMutex sync.Mutex
+}
+
+func (c *defaultNamespaceQuotaChecker) Reserve(ctx context.Context, stack core.Stack, namespaces []string, deployed bool) error {
+	desiredUsage := BuildNamespaceUsage(stack)
+	c.stackUsagesMutex.Lock()
+	defer c.stackUsagesMutex.Unlock()
+	if len(desiredUsage) == 0 || len(namespaces) == 0 {
+		delete(c.stackUsages, stack.StackID())
+		return nil
+	}
+	if _, recorded := c.stackUsages[stack.StackID()]; recorded || !deployed {
+		if err := c.checkNamespaceLimits(ctx, stack.StackID(), desiredUsage, namespaces); err != nil {
+			return err
+		}
+	}
+	c.stackUsages[stack.StackID()] = stackUsage{
+		namespaces: sets.NewString(namespaces...),
+		usage:      desiredUsage,
+	}
+	return nil
+}
+
+// checkNamespaceLimits checks the growth of stack to desiredUsage against the limits of namespaces.
+// it must be invoked with stackUsagesMutex held.
+func (c *defaultNamespaceQuotaChecker) checkNamespaceLimits(ctx context.Context, stackID core.StackID, desiredUsage NamespaceUsage, namespaces []string) error {
+	for _, namespace := range sets.NewString(namespaces...).List() {
+		limits, err := ResolveNamespaceLimits(ctx, c.k8sClient, namespace)
+		if err != nil {
+			return err
+		}
+		if len(limits) == 0 {
+			continue
+		}
+		recordedUsage, otherUsage := c.namespaceUsage(stackID, namespace)
+		for _, resource := range namespaceResources {
+			if desiredUsage[resource] <= recordedUsage[resource] {
+				continue
+			}
+			if err := limits.Check(namespace, resource, otherUsage[resource]+desiredUsage[resource]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// namespaceUsage returns the recorded usage of stack and the total usage of other stacks in namespace.
+// it must be invoked with stackUsagesMutex held.
+func (c *defaultNamespaceQuotaChecker) namespaceUsage(stackID core.StackID, namespace string) (NamespaceUsage, NamespaceUsage) {
+	recordedUsage := make(NamespaceUsage)
+	otherUsage := make(NamespaceUsage)
+	for id, stackUsage := range c.stackUsages {
+		if !stackUsage.namespaces.Has(namespace) {
+			continue
+		}
+		usage := otherUsage
+		if id == stackID {
+			usage = recordedUsage
+		}
+		for resource, count := range stackUsage.usage {
+			usage[resource] += count
+		}
+	}
+	return recordedUsage, otherUsage
+}
@@ -0,0 +1,244 @@
+package quota
+
+import (
+	"context"
+	"fmt"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+func buildNamespaceQuotaTestStack(name string, lbType elbv2model.LoadBalancerType, eipCount int, tgCount int) core.Stack {
+	stack := core.NewDefaultStack(core.StackID{Namespace: "awesome-ns", Name: name})
+	elbv2model.NewLoadBalancer(stack, "LoadBalancer", elbv2model.LoadBalancerSpec{Type: lbType})
+	for i := 0; i < eipCount; i++ {
+		ec2model.NewElasticIP(stack, fmt.Sprintf("ElasticIP-%d", i), ec2model.ElasticIPSpec{})
+	}
+	for i := 0; i < tgCount; i++ {
+		elbv2model.NewTargetGroup(stack, fmt.Sprintf("TargetGroup-%d", i), elbv2model.TargetGroupSpec{})
+	}
+	return stack
+}
+
+func Test_BuildNamespaceUsage(t *testing.T) {
+	tests := []struct {
+		name  string
+		stack core.Stack
+		want  NamespaceUsage
+	}{
+		{
+			name:  "empty stack",
+			stack: core.NewDefaultStack(core.StackID{Namespace: "awesome-ns", Name: "empty"}),
+			want:  NamespaceUsage{},
+		},
+		{
+			name:  "ALB stack",
+			stack: buildNamespaceQuotaTestStack("alb", elbv2model.LoadBalancerTypeApplication, 0, 3),
+			want: NamespaceUsage{
+				NamespaceResourceApplicationLoadBalancers: 1,
+				NamespaceResourceTargetGroups:             3,
+			},
+		},
+		{
+			name:  "NLB stack with ElasticIPs",
+			stack: buildNamespaceQuotaTestStack("nlb", elbv2model.LoadBalancerTypeNetwork, 2, 1),
+			want: NamespaceUsage{
+				NamespaceResourceNetworkLoadBalancers: 1,
+				NamespaceResourceElasticIPs:           2,
+				NamespaceResourceTargetGroups:         1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BuildNamespaceUsage(tt.stack)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_ResolveNamespaceLimits(t *testing.T) {
+	tests := []struct {
+		name     string
+		policies []elbv2api.ResourceQuotaPolicy
+		want     NamespaceLimits
+	}{
+		{
+			name:     "no policies",
+			policies: nil,
+			want:     nil,
+		},
+		{
+			name: "lowest limit among selecting policies applies",
+			policies: []elbv2api.ResourceQuotaPolicy{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "all-namespaces"},
+					Spec: elbv2api.ResourceQuotaPolicySpec{
+						Hard: elbv2api.ResourceQuotaLimits{
+							ApplicationLoadBalancers: awssdk.Int64(5),
+							TargetGroups:             awssdk.Int64(20),
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "tenants"},
+					Spec: elbv2api.ResourceQuotaPolicySpec{
+						NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "tenant"}},
+						Hard: elbv2api.ResourceQuotaLimits{
+							ApplicationLoadBalancers: awssdk.Int64(2),
+							ElasticIPs:               awssdk.Int64(0),
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "system"},
+					Spec: elbv2api.ResourceQuotaPolicySpec{
+						NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "system"}},
+						Hard: elbv2api.ResourceQuotaLimits{
+							TargetGroups: awssdk.Int64(1),
+						},
+					},
+				},
+			},
+			want: NamespaceLimits{
+				NamespaceResourceApplicationLoadBalancers: {Value: 2, Policy: "tenants"},
+				NamespaceResourceElasticIPs:               {Value: 0, Policy: "tenants"},
+				NamespaceResourceTargetGroups:             {Value: 20, Policy: "all-namespaces"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sClient := buildNamespaceQuotaTestClient(t, tt.policies)
+			got, err := ResolveNamespaceLimits(context.Background(), k8sClient, "awesome-ns")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_defaultNamespaceQuotaChecker_Reserve(t *testing.T) {
+	policies := []elbv2api.ResourceQuotaPolicy{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "tenants"},
+			Spec: elbv2api.ResourceQuotaPolicySpec{
+				Hard: elbv2api.ResourceQuotaLimits{
+					ApplicationLoadBalancers: awssdk.Int64(2),
+					TargetGroups:             awssdk.Int64(4),
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name           string
+		recordedStacks []core.Stack
+		stack          core.Stack
+		deployed       bool
+		wantErr        error
+	}{
+		{
+			name:  "within limits",
+			stack: buildNamespaceQuotaTestStack("a", elbv2model.LoadBalancerTypeApplication, 0, 2),
+		},
+		{
+			name: "ALBs exceeded",
+			recordedStacks: []core.Stack{
+				buildNamespaceQuotaTestStack("a", elbv2model.LoadBalancerTypeApplication, 0, 1),
+				buildNamespaceQuotaTestStack("b", elbv2model.LoadBalancerTypeApplication, 0, 1),
+			},
+			stack: buildNamespaceQuotaTestStack("c", elbv2model.LoadBalancerTypeApplication, 0, 1),
+			wantErr: &NamespaceQuotaExceededError{
+				Namespace: "awesome-ns",
+				Resource:  NamespaceResourceApplicationLoadBalancers,
+				Policy:    "tenants",
+				Value:     2,
+				Desired:   3,
+			},
+		},
+		{
+			name: "TargetGroups exceeded by growth of recorded stack",
+			recordedStacks: []core.Stack{
+				buildNamespaceQuotaTestStack("a", elbv2model.LoadBalancerTypeApplication, 0, 2),
+				buildNamespaceQuotaTestStack("b", elbv2model.LoadBalancerTypeApplication, 0, 2),
+			},
+			stack:    buildNamespaceQuotaTestStack("b", elbv2model.LoadBalancerTypeApplication, 0, 3),
+			deployed: true,
+			wantErr: &NamespaceQuotaExceededError{
+				Namespace: "awesome-ns",
+				Resource:  NamespaceResourceTargetGroups,
+				Policy:    "tenants",
+				Value:     4,
+				Desired:   5,
+			},
+		},
+		{
+			name: "recorded stack beyond limits without growth",
+			recordedStacks: []core.Stack{
+				buildNamespaceQuotaTestStack("a", elbv2model.LoadBalancerTypeApplication, 0, 3),
+				buildNamespaceQuotaTestStack("b", elbv2model.LoadBalancerTypeApplication, 0, 3),
+			},
+			stack:    buildNamespaceQuotaTestStack("b", elbv2model.LoadBalancerTypeApplication, 0, 2),
+			deployed: true,
+		},
+		{
+			name: "deployed stack beyond limits not yet recorded",
+			recordedStacks: []core.Stack{
+				buildNamespaceQuotaTestStack("a", elbv2model.LoadBalancerTypeApplication, 0, 3),
+			},
+			stack:    buildNamespaceQuotaTestStack("b", elbv2model.LoadBalancerTypeApplication, 0, 3),
+			deployed: true,
+		},
+		{
+			name: "released stack isn't counted",
+			recordedStacks: []core.Stack{
+				buildNamespaceQuotaTestStack("a", elbv2model.LoadBalancerTypeApplication, 0, 1),
+				buildNamespaceQuotaTestStack("b", elbv2model.LoadBalancerTypeApplication, 0, 1),
+				core.NewDefaultStack(core.StackID{Namespace: "awesome-ns", Name: "b"}),
+			},
+			stack: buildNamespaceQuotaTestStack("c", elbv2model.LoadBalancerTypeApplication, 0, 1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sClient := buildNamespaceQuotaTestClient(t, policies)
+			checker := NewDefaultNamespaceQuotaChecker(k8sClient)
+			for _, stack := range tt.recordedStacks {
+				assert.NoError(t, checker.Reserve(context.Background(), stack, []string{"awesome-ns"}, true))
+			}
+			err := checker.Reserve(context.Background(), tt.stack, []string{"awesome-ns"}, tt.deployed)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func buildNamespaceQuotaTestClient(t *testing.T, policies []elbv2api.ResourceQuotaPolicy) client.Client {
+	k8sSchema := k8sruntime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	elbv2api.AddToScheme(k8sSchema)
+	k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+	ctx := context.Background()
+	assert.NoError(t, k8sClient.Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "awesome-ns",
+			Labels: map[string]string{"tier": "tenant"},
+		},
+	}))
+	for i := range policies {
+		assert.NoError(t, k8sClient.Create(ctx, policies[i].DeepCopy()))
+	}
+	return k8sClient
+}
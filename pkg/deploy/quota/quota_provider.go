@@ -0,0 +1,193 @@
+package quota
+
+import (
+	"context"
+	"fmt"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	servicequotassdk "github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
+	"time"
+)
+
+const (
+	defaultQuotaValuesCacheTTL = 1 * time.Hour
+
+	serviceCodeVPC                  = "vpc"
+	serviceCodeElasticLoadBalancing = "elasticloadbalancing"
+)
+
+// Quota is an AWS service quota.
+type Quota struct {
+	// ServiceCode is the code of AWS service in Service Quotas, e.g. "vpc".
+	ServiceCode string
+	// Name is the name of quota in Service Quotas.
+	Name string
+	// DefaultValue is used when the value of quota cannot be retrieved from Service Quotas.
+	DefaultValue int64
+}
+
+var (
+	// QuotaRulesPerSecurityGroup limits inbound rules per SecurityGroup, it's enforced separately for IPv4 and IPv6 rules.
+	QuotaRulesPerSecurityGroup = Quota{
+		ServiceCode:  serviceCodeVPC,
+		Name:         "Inbound or outbound rules per security group",
+		DefaultValue: 60,
+	}
+	// QuotaSecurityGroupsPerNetworkInterface limits SecurityGroups per network interface, thus SecurityGroups per LoadBalancer.
+	QuotaSecurityGroupsPerNetworkInterface = Quota{
+		ServiceCode:  serviceCodeVPC,
+		Name:         "Security groups per network interface",
+		DefaultValue: 5,
+	}
+	// QuotaListenersPerApplicationLoadBalancer limits listeners per ALB.
+	QuotaListenersPerApplicationLoadBalancer = Quota{
+		ServiceCode:  serviceCodeElasticLoadBalancing,
+		Name:         "Listeners per Application Load Balancer",
+		DefaultValue: 50,
+	}
+	// QuotaListenersPerNetworkLoadBalancer limits listeners per NLB.
+	QuotaListenersPerNetworkLoadBalancer = Quota{
+		ServiceCode:  serviceCodeElasticLoadBalancing,
+		Name:         "Listeners per Network Load Balancer",
+		DefaultValue: 50,
+	}
+	// QuotaRulesPerApplicationLoadBalancer limits listener rules per ALB, default rules are not counted.
+	QuotaRulesPerApplicationLoadBalancer = Quota{
+		ServiceCode:  serviceCodeElasticLoadBalancing,
+		Name:         "Rules per Application Load Balancer",
+		DefaultValue: 100,
+	}
+	// QuotaTargetsPerTargetGroup limits targets registered per TargetGroup.
+	QuotaTargetsPerTargetGroup = Quota{
+		ServiceCode:  serviceCodeElasticLoadBalancing,
+		Name:         "Targets per Target Group per Region",
+		DefaultValue: 1000,
+	}
+)
+
+// ExceededError is returned when desired resources exceed the value of a quota.
+type ExceededError struct {
+	// Quota is the exhausted quota.
+	Quota Quota
+	// Value is the value of quota.
+	Value int64
+	// Desired is the desired usage of quota.
+	Desired int64
+	// Resource describes the resource the quota applies to.
+	Resource string
+}
+
+func (e *ExceededError) Error() string {
+	return fmt.Sprintf("quota %q exceeded for %v: %v desired, limit %v", e.Quota.Name, e.Resource, e.Desired, e.Value)
+}
+
+// TerminalReason classifies quota exceeded errors as terminal, since they won't resolve until the quota is increased.
+func (e *ExceededError) TerminalReason() string {
+	return "QuotaExceeded"
+}
+
+func (e *ExceededError) ErrorClass() runtime.ErrorClass {
+	return runtime.ErrorClassQuotaExceeded
+}
+
+// Provider provides the values of AWS service quotas.
+type Provider interface {
+	// GetQuotaValue returns the applied value of quota, or the default value if it cannot be retrieved.
+	GetQuotaValue(ctx context.Context, quota Quota) int64
+}
+
+// NewDefaultProvider constructs new defaultProvider.
+func NewDefaultProvider(serviceQuotasClient services.ServiceQuotas, logger logr.Logger) *defaultProvider {
+	return &defaultProvider{
+		serviceQuotasClient: serviceQuotasClient,
+		logger:              logger,
+		quotaValuesCache:    cache.NewExpiring(),
+		quotaValuesCacheTTL: defaultQuotaValuesCacheTTL,
+	}
+}
+
+var _ Provider = &defaultProvider{}
+
+// default implementation for Provider, which retrieves quota values from Service Quotas.
+// quota values are cached per service, and failures to retrieve them are cached as well,
+// so that a missing IAM permission for Service Quotas doesn't result in API calls on every reconcile.
+type defaultProvider struct {
+	serviceQuotasClient services.ServiceQuotas
+	logger              logr.Logger
+
+	// quotaValuesCache caches the quota values by quota name, keyed by service code.
+	// concurrent lookups of the same service share a single retrieval via quotaValuesFlight.
+	quotaValuesCache    *cache.Expiring
+	quotaValuesCacheTTL time.Duration
+	quotaValuesFlight   runtime.SingleFlight
+}
+
+func (p *defaultProvider) GetQuotaValue(ctx context.Context, quota Quota) int64 {
+	quotaValues := p.fetchQuotaValues(ctx, quota.ServiceCode)
+	if value, ok := quotaValues[quota.Name]; ok {
+		return value
+	}
+	return quota.DefaultValue
+}
+
+// fetchQuotaValues returns the quota values by quota name for service, results are served from cache when possible.
+func (p *defaultProvider) fetchQuotaValues(ctx context.Context, serviceCode string) map[string]int64 {
+	if rawCacheItem, exists := p.quotaValuesCache.Get(serviceCode); exists {
+		return rawCacheItem.(map[string]int64)
+	}
+	rawQuotaValues, _ := p.quotaValuesFlight.Do(serviceCode, func() (interface{}, error) {
+		return p.fetchQuotaValuesFromAWS(ctx, serviceCode), nil
+	})
+	return rawQuotaValues.(map[string]int64)
+}
+
+// fetchQuotaValuesFromAWS retrieves the quota values by quota name for service from Service Quotas, the results are cached.
+// applied values take precedence over AWS default values, since applied values are only available for adjusted quotas.
+func (p *defaultProvider) fetchQuotaValuesFromAWS(ctx context.Context, serviceCode string) map[string]int64 {
+	quotaValues := make(map[string]int64)
+	defaultQuotas, err := p.serviceQuotasClient.ListAWSDefaultServiceQuotasAsList(ctx, &servicequotassdk.ListAWSDefaultServiceQuotasInput{
+		ServiceCode: awssdk.String(serviceCode),
+	})
+	if err != nil {
+		p.logger.Info("unable to retrieve default service quotas, built-in defaults are used", "serviceCode", serviceCode, "error", err)
+	}
+	appliedQuotas, err := p.serviceQuotasClient.ListServiceQuotasAsList(ctx, &servicequotassdk.ListServiceQuotasInput{
+		ServiceCode: awssdk.String(serviceCode),
+	})
+	if err != nil {
+		p.logger.Info("unable to retrieve applied service quotas, default values are used", "serviceCode", serviceCode, "error", err)
+	}
+	for _, quota := range append(defaultQuotas, appliedQuotas...) {
+		if quota.Value == nil {
+			continue
+		}
+		quotaValues[awssdk.StringValue(quota.QuotaName)] = int64(awssdk.Float64Value(quota.Value))
+	}
+	p.quotaValuesCache.Set(serviceCode, quotaValues, p.quotaValuesCacheTTL)
+	return quotaValues
+}
+
+// NewStaticProvider constructs new staticProvider.
+func NewStaticProvider(quotaValues map[string]int64) *staticProvider {
+	return &staticProvider{
+		quotaValues: quotaValues,
+	}
+}
+
+var _ Provider = &staticProvider{}
+
+// staticProvider provides quota values by quota name, it falls back to the default values of quotas not present.
+// it's used where quota values cannot be retrieved, like building models offline.
+type staticProvider struct {
+	quotaValues map[string]int64
+}
+
+func (p *staticProvider) GetQuotaValue(_ context.Context, quota Quota) int64 {
+	if value, ok := p.quotaValues[quota.Name]; ok {
+		return value
+	}
+	return quota.DefaultValue
+}
@@ -0,0 +1,95 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	servicequotassdk "github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_defaultProvider_GetQuotaValue(t *testing.T) {
+	type listQuotasCall struct {
+		resp []*servicequotassdk.ServiceQuota
+		err  error
+	}
+	tests := []struct {
+		name                  string
+		listDefaultQuotasCall listQuotasCall
+		listAppliedQuotasCall listQuotasCall
+		quota                 Quota
+		want                  int64
+	}{
+		{
+			name: "applied quota value takes precedence",
+			listDefaultQuotasCall: listQuotasCall{
+				resp: []*servicequotassdk.ServiceQuota{
+					{
+						QuotaName: awssdk.String("Inbound or outbound rules per security group"),
+						Value:     awssdk.Float64(60),
+					},
+				},
+			},
+			listAppliedQuotasCall: listQuotasCall{
+				resp: []*servicequotassdk.ServiceQuota{
+					{
+						QuotaName: awssdk.String("Inbound or outbound rules per security group"),
+						Value:     awssdk.Float64(200),
+					},
+				},
+			},
+			quota: QuotaRulesPerSecurityGroup,
+			want:  200,
+		},
+		{
+			name: "AWS default quota value is used when quota isn't adjusted",
+			listDefaultQuotasCall: listQuotasCall{
+				resp: []*servicequotassdk.ServiceQuota{
+					{
+						QuotaName: awssdk.String("Inbound or outbound rules per security group"),
+						Value:     awssdk.Float64(80),
+					},
+				},
+			},
+			listAppliedQuotasCall: listQuotasCall{
+				resp: nil,
+			},
+			quota: QuotaRulesPerSecurityGroup,
+			want:  80,
+		},
+		{
+			name: "built-in default quota value is used when Service Quotas is unavailable",
+			listDefaultQuotasCall: listQuotasCall{
+				err: errors.New("AccessDeniedException"),
+			},
+			listAppliedQuotasCall: listQuotasCall{
+				err: errors.New("AccessDeniedException"),
+			},
+			quota: QuotaRulesPerSecurityGroup,
+			want:  60,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			serviceQuotasClient := mock_services.NewMockServiceQuotas(ctrl)
+			serviceQuotasClient.EXPECT().ListAWSDefaultServiceQuotasAsList(gomock.Any(), &servicequotassdk.ListAWSDefaultServiceQuotasInput{
+				ServiceCode: awssdk.String(tt.quota.ServiceCode),
+			}).Return(tt.listDefaultQuotasCall.resp, tt.listDefaultQuotasCall.err).Times(1)
+			serviceQuotasClient.EXPECT().ListServiceQuotasAsList(gomock.Any(), &servicequotassdk.ListServiceQuotasInput{
+				ServiceCode: awssdk.String(tt.quota.ServiceCode),
+			}).Return(tt.listAppliedQuotasCall.resp, tt.listAppliedQuotasCall.err).Times(1)
+
+			provider := NewDefaultProvider(serviceQuotasClient, &log.NullLogger{})
+			assert.Equal(t, tt.want, provider.GetQuotaValue(context.Background(), tt.quota))
+			// quota values are cached per service.
+			assert.Equal(t, tt.want, provider.GetQuotaValue(context.Background(), tt.quota))
+		})
+	}
+}
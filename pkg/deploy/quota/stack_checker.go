@@ -0,0 +1,123 @@
+package quota
+
+import (
+	"context"
+	"fmt"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+)
+
+// StackChecker runs pre-flight checks of resource stacks against AWS service quotas.
+type StackChecker interface {
+	// Check returns an ExceededError if resources in stack exceed any quota.
+	Check(ctx context.Context, stack core.Stack) error
+}
+
+// NewDefaultStackChecker constructs new defaultStackChecker.
+func NewDefaultStackChecker(quotaProvider Provider) *defaultStackChecker {
+	return &defaultStackChecker{
+		quotaProvider: quotaProvider,
+	}
+}
+
+var _ StackChecker = &defaultStackChecker{}
+
+// default implementation for StackChecker.
+// it checks the quotas that apply per resource, i.e. rules per SecurityGroup, listeners and rules per LoadBalancer.
+type defaultStackChecker struct {
+	quotaProvider Provider
+}
+
+func (c *defaultStackChecker) Check(ctx context.Context, stack core.Stack) error {
+	if err := c.checkSecurityGroups(ctx, stack); err != nil {
+		return err
+	}
+	return c.checkLoadBalancers(ctx, stack)
+}
+
+func (c *defaultStackChecker) checkSecurityGroups(ctx context.Context, stack core.Stack) error {
+	var resSGs []*ec2model.SecurityGroup
+	stack.ListResources(&resSGs)
+	for _, resSG := range resSGs {
+		// rules referencing SecurityGroups or PrefixLists count as both an IPv4 and an IPv6 rule.
+		// EC2 counts rules referencing PrefixLists by their max entries, so the desired count is a lower bound for them.
+		var ipv4RuleCount, ipv6RuleCount int64
+		for _, permission := range resSG.Spec.Ingress {
+			ipv4RuleCount += int64(len(permission.IPRanges) + len(permission.UserIDGroupPairs) + len(permission.PrefixLists))
+			ipv6RuleCount += int64(len(permission.IPv6Range) + len(permission.UserIDGroupPairs) + len(permission.PrefixLists))
+		}
+		desired := ipv4RuleCount
+		if ipv6RuleCount > desired {
+			desired = ipv6RuleCount
+		}
+		if err := CheckQuota(ctx, c.quotaProvider, QuotaRulesPerSecurityGroup, desired, fmt.Sprintf("SecurityGroup %v", resSG.ID())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *defaultStackChecker) checkLoadBalancers(ctx context.Context, stack core.Stack) error {
+	var resLBs []*elbv2model.LoadBalancer
+	stack.ListResources(&resLBs)
+	if len(resLBs) == 0 {
+		return nil
+	}
+	var resLSs []*elbv2model.Listener
+	stack.ListResources(&resLSs)
+	var resLRs []*elbv2model.ListenerRule
+	stack.ListResources(&resLRs)
+
+	lsCountByLBID := make(map[string]int64)
+	lbIDByLSID := make(map[string]string)
+	for _, resLS := range resLSs {
+		for _, dep := range resLS.Spec.LoadBalancerARN.Dependencies() {
+			lsCountByLBID[dep.ID()]++
+			lbIDByLSID[resLS.ID()] = dep.ID()
+		}
+	}
+	lrCountByLBID := make(map[string]int64)
+	for _, resLR := range resLRs {
+		for _, dep := range resLR.Spec.ListenerARN.Dependencies() {
+			if lbID, ok := lbIDByLSID[dep.ID()]; ok {
+				lrCountByLBID[lbID]++
+			}
+		}
+	}
+
+	for _, resLB := range resLBs {
+		resource := fmt.Sprintf("LoadBalancer %v", resLB.ID())
+		lsQuota := QuotaListenersPerNetworkLoadBalancer
+		if resLB.Spec.Type == elbv2model.LoadBalancerTypeApplication {
+			lsQuota = QuotaListenersPerApplicationLoadBalancer
+		}
+		if err := CheckQuota(ctx, c.quotaProvider, lsQuota, lsCountByLBID[resLB.ID()], resource); err != nil {
+			return err
+		}
+		if resLB.Spec.Type == elbv2model.LoadBalancerTypeApplication {
+			if err := CheckQuota(ctx, c.quotaProvider, QuotaRulesPerApplicationLoadBalancer, lrCountByLBID[resLB.ID()], resource); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// CheckQuota returns an ExceededError if desired usage of quota for resource exceeds its value.
+// quota values are only retrieved when desired usage exceeds the default value, since quotas can only be increased.
+func CheckQuota(ctx context.Context, quotaProvider Provider, quota Quota, desired int64, resource string) error {
+	if desired <= quota.DefaultValue {
+		return nil
+	}
+	value := quotaProvider.GetQuotaValue(ctx, quota)
+	if desired > value {
+		return &ExceededError{
+			Quota:    quota,
+			Value:    value,
+			Desired:  desired,
+			Resource: resource,
+		}
+	}
+	return nil
+}
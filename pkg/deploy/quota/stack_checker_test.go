@@ -0,0 +1,132 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"testing"
+)
+
+func Test_defaultStackChecker_Check(t *testing.T) {
+	buildSGPermissions := func(ipv4Count int, ipv6Count int) []ec2model.IPPermission {
+		var permissions []ec2model.IPPermission
+		for i := 0; i < ipv4Count; i++ {
+			permissions = append(permissions, ec2model.IPPermission{
+				IPProtocol: "tcp",
+				FromPort:   awssdk.Int64(80),
+				ToPort:     awssdk.Int64(80),
+				IPRanges:   []ec2model.IPRange{{CIDRIP: fmt.Sprintf("10.0.%d.0/24", i)}},
+			})
+		}
+		for i := 0; i < ipv6Count; i++ {
+			permissions = append(permissions, ec2model.IPPermission{
+				IPProtocol: "tcp",
+				FromPort:   awssdk.Int64(80),
+				ToPort:     awssdk.Int64(80),
+				IPv6Range:  []ec2model.IPv6Range{{CIDRIPv6: fmt.Sprintf("2600:%x::/32", i)}},
+			})
+		}
+		return permissions
+	}
+	type stackSpec struct {
+		sgIPv4RuleCount int
+		sgIPv6RuleCount int
+		lbType          elbv2model.LoadBalancerType
+		listenerCount   int
+		ruleCount       int
+	}
+	tests := []struct {
+		name        string
+		stackSpec   stackSpec
+		quotaValues map[string]int64
+		wantErr     error
+	}{
+		{
+			name: "resources within default quotas",
+			stackSpec: stackSpec{
+				sgIPv4RuleCount: 60,
+				sgIPv6RuleCount: 60,
+				lbType:          elbv2model.LoadBalancerTypeApplication,
+				listenerCount:   2,
+				ruleCount:       100,
+			},
+		},
+		{
+			name: "SecurityGroup rules exceed quota",
+			stackSpec: stackSpec{
+				sgIPv4RuleCount: 61,
+				lbType:          elbv2model.LoadBalancerTypeApplication,
+				listenerCount:   1,
+			},
+			wantErr: errors.New("quota \"Inbound or outbound rules per security group\" exceeded for SecurityGroup ManagedLBSecurityGroup: 61 desired, limit 60"),
+		},
+		{
+			name: "SecurityGroup rules within adjusted quota",
+			stackSpec: stackSpec{
+				sgIPv4RuleCount: 61,
+				lbType:          elbv2model.LoadBalancerTypeApplication,
+				listenerCount:   1,
+			},
+			quotaValues: map[string]int64{"Inbound or outbound rules per security group": 100},
+		},
+		{
+			name: "ALB listener rules exceed quota",
+			stackSpec: stackSpec{
+				lbType:        elbv2model.LoadBalancerTypeApplication,
+				listenerCount: 2,
+				ruleCount:     101,
+			},
+			wantErr: errors.New("quota \"Rules per Application Load Balancer\" exceeded for LoadBalancer LoadBalancer: 101 desired, limit 100"),
+		},
+		{
+			name: "NLB listeners exceed adjusted quota",
+			stackSpec: stackSpec{
+				lbType:        elbv2model.LoadBalancerTypeNetwork,
+				listenerCount: 51,
+			},
+			quotaValues: map[string]int64{"Listeners per Network Load Balancer": 40},
+			wantErr:     errors.New("quota \"Listeners per Network Load Balancer\" exceeded for LoadBalancer LoadBalancer: 51 desired, limit 40"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stack := core.NewDefaultStack(core.StackID{Namespace: "awesome-ns", Name: "ing-1"})
+			if tt.stackSpec.sgIPv4RuleCount+tt.stackSpec.sgIPv6RuleCount > 0 {
+				ec2model.NewSecurityGroup(stack, "ManagedLBSecurityGroup", ec2model.SecurityGroupSpec{
+					GroupName: "k8s-awesomen-ing1-a1b2c3d4e5",
+					Ingress:   buildSGPermissions(tt.stackSpec.sgIPv4RuleCount, tt.stackSpec.sgIPv6RuleCount),
+				})
+			}
+			lb := elbv2model.NewLoadBalancer(stack, "LoadBalancer", elbv2model.LoadBalancerSpec{
+				Type: tt.stackSpec.lbType,
+			})
+			var listeners []*elbv2model.Listener
+			for i := 0; i < tt.stackSpec.listenerCount; i++ {
+				listeners = append(listeners, elbv2model.NewListener(stack, fmt.Sprintf("%v", 80+i), elbv2model.ListenerSpec{
+					LoadBalancerARN: lb.LoadBalancerARN(),
+					Port:            int64(80 + i),
+				}))
+			}
+			for i := 0; i < tt.stackSpec.ruleCount; i++ {
+				ls := listeners[i%len(listeners)]
+				elbv2model.NewListenerRule(stack, fmt.Sprintf("%v:%v", ls.ID(), i+1), elbv2model.ListenerRuleSpec{
+					ListenerARN: ls.ListenerARN(),
+					Priority:    int64(i + 1),
+				})
+			}
+
+			checker := NewDefaultStackChecker(NewStaticProvider(tt.quotaValues))
+			err := checker.Check(context.Background(), stack)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+package route53
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	route53sdk "github.com/aws/aws-sdk-go/service/route53"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	hostedZoneIDPrefix = "/hostedzone/"
+
+	defaultHostedZonesCacheTTL      = 10 * time.Minute
+	defaultOwnershipRecordsCacheTTL = 1 * time.Hour
+	hostedZonesCacheKey             = "hostedZones"
+	// records of a name are listed in a single page, which only needs to hold the A and AAAA records sorted ahead of other types.
+	recordSetsByNameMaxItems = "10"
+)
+
+type RecordSetManager interface {
+	// ListHostedZones returns the hosted zones that records can be managed in.
+	ListHostedZones(ctx context.Context) ([]HostedZoneInfo, error)
+
+	// ListOwnershipRecordSets returns the ownership records in hosted zone by host.
+	ListOwnershipRecordSets(ctx context.Context, hostedZoneID string) (map[string]*route53sdk.ResourceRecordSet, error)
+
+	// ListRecordSets returns the records of names in hosted zone.
+	ListRecordSets(ctx context.Context, hostedZoneID string, names []string) ([]*route53sdk.ResourceRecordSet, error)
+
+	// ChangeRecordSets applies changes to records in hosted zone atomically.
+	ChangeRecordSets(ctx context.Context, hostedZoneID string, changes []*route53sdk.Change) error
+}
+
+// NewDefaultRecordSetManager constructs new defaultRecordSetManager.
+// records are managed in all hosted zones visible to controller if hostedZoneIDs is empty.
+func NewDefaultRecordSetManager(route53Client services.Route53, hostedZoneIDs []string, logger logr.Logger) *defaultRecordSetManager {
+	return &defaultRecordSetManager{
+		route53Client:            route53Client,
+		hostedZoneIDs:            sets.NewString(hostedZoneIDs...),
+		logger:                   logger,
+		hostedZonesCache:         cache.NewExpiring(),
+		hostedZonesCacheTTL:      defaultHostedZonesCacheTTL,
+		ownershipRecordsCache:    cache.NewExpiring(),
+		ownershipRecordsCacheTTL: defaultOwnershipRecordsCacheTTL,
+	}
+}
+
+var _ RecordSetManager = &defaultRecordSetManager{}
+
+// Route 53 limits API calls to 5 per second per account, so hosted zones are cached, and the ownership records of a hosted zone
+// are indexed with a single listing shared by all stacks, which is kept up to date with the changes made by controller.
+type defaultRecordSetManager struct {
+	route53Client services.Route53
+	hostedZoneIDs sets.String
+	logger        logr.Logger
+
+	// cache that stores the hosted zones as []HostedZoneInfo.
+	hostedZonesCache    *cache.Expiring
+	hostedZonesCacheTTL time.Duration
+	// cache that stores the ownership records by host indexed by hostedZoneID.
+	// The cache value is map[string]*route53sdk.ResourceRecordSet, which is replaced instead of mutated.
+	ownershipRecordsCache    *cache.Expiring
+	ownershipRecordsCacheTTL time.Duration
+	// ownershipRecordsCacheMutex serializes updates to cached ownership records from changes, it's never held across API calls.
+	ownershipRecordsCacheMutex sync.Mutex
+}
+
+// HostedZoneInfo wraps necessary information about a hosted zone.
+type HostedZoneInfo struct {
+	// The ID of hosted zone, without the "/hostedzone/" prefix.
+	ID string
+	// The domain name of hosted zone, without the trailing dot.
+	Name string
+	// Whether it's a private hosted zone.
+	Private bool
+}
+
+func (m *defaultRecordSetManager) ListHostedZones(ctx context.Context) ([]HostedZoneInfo, error) {
+	if rawCacheItem, exists := m.hostedZonesCache.Get(hostedZonesCacheKey); exists {
+		return rawCacheItem.([]HostedZoneInfo), nil
+	}
+	sdkZones, err := m.route53Client.ListHostedZonesAsList(ctx, &route53sdk.ListHostedZonesInput{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list hosted zones")
+	}
+	zones := make([]HostedZoneInfo, 0, len(sdkZones))
+	for _, sdkZone := range sdkZones {
+		zoneID := strings.TrimPrefix(awssdk.StringValue(sdkZone.Id), hostedZoneIDPrefix)
+		if len(m.hostedZoneIDs) != 0 && !m.hostedZoneIDs.Has(zoneID) {
+			continue
+		}
+		var private bool
+		if sdkZone.Config != nil {
+			private = awssdk.BoolValue(sdkZone.Config.PrivateZone)
+		}
+		zones = append(zones, HostedZoneInfo{
+			ID:      zoneID,
+			Name:    normalizeRecordName(awssdk.StringValue(sdkZone.Name)),
+			Private: private,
+		})
+	}
+	m.hostedZonesCache.Set(hostedZonesCacheKey, zones, m.hostedZonesCacheTTL)
+	return zones, nil
+}
+
+func (m *defaultRecordSetManager) ListOwnershipRecordSets(ctx context.Context, hostedZoneID string) (map[string]*route53sdk.ResourceRecordSet, error) {
+	if rawCacheItem, exists := m.ownershipRecordsCache.Get(hostedZoneID); exists {
+		return rawCacheItem.(map[string]*route53sdk.ResourceRecordSet), nil
+	}
+	req := &route53sdk.ListResourceRecordSetsInput{
+		HostedZoneId: awssdk.String(hostedZoneID),
+	}
+	sdkRecords, err := m.route53Client.ListResourceRecordSetsAsList(ctx, req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list records in hosted zone: %v", hostedZoneID)
+	}
+	ownershipRecords := make(map[string]*route53sdk.ResourceRecordSet)
+	for _, sdkRecord := range sdkRecords {
+		if host, ok := ownershipRecordHost(sdkRecord); ok {
+			ownershipRecords[host] = sdkRecord
+		}
+	}
+	m.ownershipRecordsCacheMutex.Lock()
+	defer m.ownershipRecordsCacheMutex.Unlock()
+	// changes made while listing have already been applied to the cached ownership records, which are more recent.
+	if rawCacheItem, exists := m.ownershipRecordsCache.Get(hostedZoneID); exists {
+		return rawCacheItem.(map[string]*route53sdk.ResourceRecordSet), nil
+	}
+	m.ownershipRecordsCache.Set(hostedZoneID, ownershipRecords, m.ownershipRecordsCacheTTL)
+	return ownershipRecords, nil
+}
+
+func (m *defaultRecordSetManager) ListRecordSets(ctx context.Context, hostedZoneID string, names []string) ([]*route53sdk.ResourceRecordSet, error) {
+	var sdkRecords []*route53sdk.ResourceRecordSet
+	for _, name := range names {
+		req := &route53sdk.ListResourceRecordSetsInput{
+			HostedZoneId:    awssdk.String(hostedZoneID),
+			StartRecordName: awssdk.String(name),
+			MaxItems:        awssdk.String(recordSetsByNameMaxItems),
+		}
+		resp, err := m.route53Client.ListResourceRecordSetsWithContext(ctx, req)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list records of %v in hosted zone: %v", name, hostedZoneID)
+		}
+		for _, sdkRecord := range resp.ResourceRecordSets {
+			if normalizeRecordName(awssdk.StringValue(sdkRecord.Name)) == name {
+				sdkRecords = append(sdkRecords, sdkRecord)
+			}
+		}
+	}
+	return sdkRecords, nil
+}
+
+func (m *defaultRecordSetManager) ChangeRecordSets(ctx context.Context, hostedZoneID string, changes []*route53sdk.Change) error {
+	req := &route53sdk.ChangeResourceRecordSetsInput{
+		HostedZoneId: awssdk.String(hostedZoneID),
+		ChangeBatch: &route53sdk.ChangeBatch{
+			Comment: awssdk.String(recordSetChangeComment),
+			Changes: changes,
+		},
+	}
+	m.logger.Info("changing records",
+		"hostedZoneID", hostedZoneID,
+		"changes", describeRecordSetChanges(changes))
+	if _, err := m.route53Client.ChangeResourceRecordSetsWithContext(ctx, req); err != nil {
+		// the ownership records might have been changed by others, which are listed again next time.
+		m.ownershipRecordsCache.Delete(hostedZoneID)
+		return errors.Wrapf(err, "failed to change records in hosted zone: %v", hostedZoneID)
+	}
+	m.updateCachedOwnershipRecords(hostedZoneID, changes)
+	m.logger.Info("changed records",
+		"hostedZoneID", hostedZoneID)
+	return nil
+}
+
+// updateCachedOwnershipRecords applies the changes to ownership records onto the cached ownership records of hosted zone.
+func (m *defaultRecordSetManager) updateCachedOwnershipRecords(hostedZoneID string, changes []*route53sdk.Change) {
+	m.ownershipRecordsCacheMutex.Lock()
+	defer m.ownershipRecordsCacheMutex.Unlock()
+	rawCacheItem, exists := m.ownershipRecordsCache.Get(hostedZoneID)
+	if !exists {
+		return
+	}
+	ownershipRecords := make(map[string]*route53sdk.ResourceRecordSet)
+	for host, sdkRecord := range rawCacheItem.(map[string]*route53sdk.ResourceRecordSet) {
+		ownershipRecords[host] = sdkRecord
+	}
+	for _, change := range changes {
+		host, ok := ownershipRecordHost(change.ResourceRecordSet)
+		if !ok {
+			continue
+		}
+		if awssdk.StringValue(change.Action) == route53sdk.ChangeActionDelete {
+			delete(ownershipRecords, host)
+		} else {
+			ownershipRecords[host] = change.ResourceRecordSet
+		}
+	}
+	m.ownershipRecordsCache.Set(hostedZoneID, ownershipRecords, m.ownershipRecordsCacheTTL)
+}
+
+// ownershipRecordHost returns the host of ownership record, it returns false if sdkRecord isn't an ownership record.
+func ownershipRecordHost(sdkRecord *route53sdk.ResourceRecordSet) (string, bool) {
+	key := buildRecordSetKey(sdkRecord)
+	if key.recordType != route53sdk.RRTypeTxt || !strings.HasPrefix(key.name, ownershipRecordNamePrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(key.name, ownershipRecordNamePrefix), true
+}
+
+// describeRecordSetChanges describes changes as "<action> <type> <name>" for logging.
+func describeRecordSetChanges(changes []*route53sdk.Change) []string {
+	descriptions := make([]string, 0, len(changes))
+	for _, change := range changes {
+		descriptions = append(descriptions, strings.Join([]string{
+			awssdk.StringValue(change.Action),
+			awssdk.StringValue(change.ResourceRecordSet.Type),
+			normalizeRecordName(awssdk.StringValue(change.ResourceRecordSet.Name)),
+		}, " "))
+	}
+	return descriptions
+}
@@ -0,0 +1,87 @@
+package route53
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	route53sdk "github.com/aws/aws-sdk-go/service/route53"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_defaultRecordSetManager_ListOwnershipRecordSets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ownershipRecord := func(name string, value string) *route53sdk.ResourceRecordSet {
+		return &route53sdk.ResourceRecordSet{
+			Name:            awssdk.String(name),
+			Type:            awssdk.String("TXT"),
+			ResourceRecords: []*route53sdk.ResourceRecord{{Value: awssdk.String(value)}},
+		}
+	}
+	wwwOwnershipRecord := ownershipRecord("_aws-lbc-owner.www.example.com.", `"owner-a"`)
+	apiOwnershipRecord := ownershipRecord("_aws-lbc-owner.api.example.com", `"owner-b"`)
+
+	route53Client := mock_services.NewMockRoute53(ctrl)
+	// ownership records are listed once and shared by subsequent calls.
+	route53Client.EXPECT().ListResourceRecordSetsAsList(gomock.Any(), &route53sdk.ListResourceRecordSetsInput{
+		HostedZoneId: awssdk.String("Z1"),
+	}).Return([]*route53sdk.ResourceRecordSet{
+		wwwOwnershipRecord,
+		ownershipRecord("example.com.", `"v=spf1 -all"`),
+	}, nil)
+	route53Client.EXPECT().ChangeResourceRecordSetsWithContext(gomock.Any(), gomock.Any()).Return(&route53sdk.ChangeResourceRecordSetsOutput{}, nil)
+
+	m := NewDefaultRecordSetManager(route53Client, nil, &log.NullLogger{})
+	ctx := context.Background()
+	got, err := m.ListOwnershipRecordSets(ctx, "Z1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]*route53sdk.ResourceRecordSet{"www.example.com": wwwOwnershipRecord}, got)
+
+	// changes to ownership records are applied onto cached ones.
+	assert.NoError(t, m.ChangeRecordSets(ctx, "Z1", []*route53sdk.Change{
+		{Action: awssdk.String("DELETE"), ResourceRecordSet: wwwOwnershipRecord},
+		{Action: awssdk.String("CREATE"), ResourceRecordSet: apiOwnershipRecord},
+	}))
+	got, err = m.ListOwnershipRecordSets(ctx, "Z1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]*route53sdk.ResourceRecordSet{"api.example.com": apiOwnershipRecord}, got)
+}
+
+func Test_defaultRecordSetManager_ListRecordSets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	aliasRecord := func(name string, recordType string) *route53sdk.ResourceRecordSet {
+		return &route53sdk.ResourceRecordSet{
+			Name: awssdk.String(name),
+			Type: awssdk.String(recordType),
+			AliasTarget: &route53sdk.AliasTarget{
+				DNSName: awssdk.String("my-alb.us-west-2.elb.amazonaws.com."),
+			},
+		}
+	}
+	route53Client := mock_services.NewMockRoute53(ctrl)
+	route53Client.EXPECT().ListResourceRecordSetsWithContext(gomock.Any(), &route53sdk.ListResourceRecordSetsInput{
+		HostedZoneId:    awssdk.String("Z1"),
+		StartRecordName: awssdk.String("www.example.com"),
+		MaxItems:        awssdk.String("10"),
+	}).Return(&route53sdk.ListResourceRecordSetsOutput{
+		ResourceRecordSets: []*route53sdk.ResourceRecordSet{
+			aliasRecord("www.example.com.", "A"),
+			aliasRecord("www.example.com.", "AAAA"),
+			aliasRecord("other.www.example.com.", "A"),
+		},
+	}, nil)
+
+	m := NewDefaultRecordSetManager(route53Client, nil, &log.NullLogger{})
+	got, err := m.ListRecordSets(context.Background(), "Z1", []string{"www.example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, []*route53sdk.ResourceRecordSet{
+		aliasRecord("www.example.com.", "A"),
+		aliasRecord("www.example.com.", "AAAA"),
+	}, got)
+}
@@ -0,0 +1,290 @@
+package route53
+
+import (
+	"context"
+	"fmt"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	route53sdk "github.com/aws/aws-sdk-go/service/route53"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	route53model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/route53"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
+	"sort"
+	"strings"
+)
+
+const (
+	// ownership records are TXT records named with this prefix and the host name, which identify the stack owning records of the host.
+	ownershipRecordNamePrefix = "_aws-lbc-owner."
+	ownershipRecordTTL        = 300
+	ownershipHeritage         = "heritage=aws-load-balancer-controller"
+	recordSetChangeComment    = "managed by aws-load-balancer-controller"
+)
+
+// NewRecordSetSynthesizer constructs new recordSetSynthesizer.
+func NewRecordSetSynthesizer(rsManager RecordSetManager, trackingProvider tracking.Provider, logger logr.Logger, stack core.Stack) *recordSetSynthesizer {
+	return &recordSetSynthesizer{
+		rsManager:        rsManager,
+		trackingProvider: trackingProvider,
+		logger:           logger,
+		stack:            stack,
+	}
+}
+
+type recordSetSynthesizer struct {
+	rsManager        RecordSetManager
+	trackingProvider tracking.Provider
+	logger           logr.Logger
+	stack            core.Stack
+}
+
+func (s *recordSetSynthesizer) Synthesize(ctx context.Context) error {
+	var resRSs []*route53model.RecordSet
+	s.stack.ListResources(&resRSs)
+	zones, err := s.rsManager.ListHostedZones(ctx)
+	if err != nil {
+		return err
+	}
+	desiredRecordsByZoneID := make(map[string][]*route53sdk.ResourceRecordSet)
+	zoneIDsByResRS := make(map[*route53model.RecordSet][]string, len(resRSs))
+	for _, resRS := range resRSs {
+		desiredRecord, err := buildSDKAliasRecordSet(ctx, resRS)
+		if err != nil {
+			return err
+		}
+		matchedZones := findHostedZonesForHost(zones, resRS.Spec.Name)
+		if len(matchedZones) == 0 {
+			return runtime.NewClassifiedError(runtime.ErrorClassInvalidConfig, errors.Errorf("no hosted zone found for host: %v", resRS.Spec.Name))
+		}
+		for _, zone := range matchedZones {
+			desiredRecordsByZoneID[zone.ID] = append(desiredRecordsByZoneID[zone.ID], desiredRecord)
+			zoneIDsByResRS[resRS] = append(zoneIDsByResRS[resRS], zone.ID)
+		}
+	}
+
+	// records are owned by stack instead of cluster, so that hosts cannot be claimed by multiple Ingress groups.
+	ownershipValue := buildOwnershipRecordValue(s.trackingProvider.StackTags(s.stack))
+	for _, zone := range zones {
+		ownershipRecords, err := s.rsManager.ListOwnershipRecordSets(ctx, zone.ID)
+		if err != nil {
+			return err
+		}
+		// only the records of desired hosts and hosts owned by stack are listed.
+		hosts := sets.NewString()
+		for _, desiredRecord := range desiredRecordsByZoneID[zone.ID] {
+			hosts.Insert(buildRecordSetKey(desiredRecord).name)
+		}
+		for host, ownershipRecord := range ownershipRecords {
+			if isOwnershipRecord(ownershipRecord, ownershipValue) {
+				hosts.Insert(host)
+			}
+		}
+		if len(hosts) == 0 {
+			continue
+		}
+		sdkRecords, err := s.rsManager.ListRecordSets(ctx, zone.ID, hosts.List())
+		if err != nil {
+			return err
+		}
+		for _, host := range hosts.List() {
+			if ownershipRecord, exists := ownershipRecords[host]; exists {
+				sdkRecords = append(sdkRecords, ownershipRecord)
+			}
+		}
+		changes, err := buildRecordSetChanges(zone, desiredRecordsByZoneID[zone.ID], sdkRecords, ownershipValue)
+		if err != nil {
+			return err
+		}
+		if len(changes) == 0 {
+			continue
+		}
+		if err := s.rsManager.ChangeRecordSets(ctx, zone.ID, changes); err != nil {
+			return err
+		}
+	}
+	for _, resRS := range resRSs {
+		resRS.SetStatus(route53model.RecordSetStatus{
+			HostedZoneIDs: zoneIDsByResRS[resRS],
+		})
+	}
+	return nil
+}
+
+func (s *recordSetSynthesizer) PostSynthesize(_ context.Context) error {
+	// nothing to do here.
+	return nil
+}
+
+// buildRecordSetChanges computes the changes to make the records owned by stack in hosted zone match the desired records.
+// records of hosts not owned by stack are never changed.
+func buildRecordSetChanges(zone HostedZoneInfo, desiredRecords []*route53sdk.ResourceRecordSet,
+	sdkRecords []*route53sdk.ResourceRecordSet, ownershipValue string) ([]*route53sdk.Change, error) {
+	sdkRecordsByKey := make(map[recordSetKey]*route53sdk.ResourceRecordSet, len(sdkRecords))
+	ownedHosts := sets.NewString()
+	for _, sdkRecord := range sdkRecords {
+		sdkRecordsByKey[buildRecordSetKey(sdkRecord)] = sdkRecord
+		if host, ok := ownershipRecordHost(sdkRecord); ok && isOwnershipRecord(sdkRecord, ownershipValue) {
+			ownedHosts.Insert(host)
+		}
+	}
+
+	var changes []*route53sdk.Change
+	desiredKeys := make(map[recordSetKey]bool, len(desiredRecords))
+	desiredHosts := sets.NewString()
+	for _, desiredRecord := range desiredRecords {
+		key := buildRecordSetKey(desiredRecord)
+		desiredKeys[key] = true
+		desiredHosts.Insert(key.name)
+		sdkRecord, exists := sdkRecordsByKey[key]
+		if !ownedHosts.Has(key.name) {
+			if exists {
+				return nil, runtime.NewClassifiedError(runtime.ErrorClassInvalidConfig,
+					errors.Errorf("%v record %v in hosted zone %v isn't managed by controller", key.recordType, key.name, zone.ID))
+			}
+			if _, exists := sdkRecordsByKey[recordSetKey{name: ownershipRecordNamePrefix + key.name, recordType: route53sdk.RRTypeTxt}]; exists {
+				return nil, runtime.NewClassifiedError(runtime.ErrorClassInvalidConfig,
+					errors.Errorf("host %v in hosted zone %v is managed by another owner", key.name, zone.ID))
+			}
+		}
+		if exists && isAliasRecordUpToDate(sdkRecord, desiredRecord) {
+			continue
+		}
+		changes = append(changes, &route53sdk.Change{
+			Action:            awssdk.String(route53sdk.ChangeActionUpsert),
+			ResourceRecordSet: desiredRecord,
+		})
+	}
+	for _, host := range desiredHosts.Difference(ownedHosts).List() {
+		changes = append(changes, &route53sdk.Change{
+			Action:            awssdk.String(route53sdk.ChangeActionCreate),
+			ResourceRecordSet: buildSDKOwnershipRecordSet(host, ownershipValue),
+		})
+	}
+	for _, host := range ownedHosts.List() {
+		for _, recordType := range []string{route53sdk.RRTypeA, route53sdk.RRTypeAaaa} {
+			key := recordSetKey{name: host, recordType: recordType}
+			if sdkRecord, exists := sdkRecordsByKey[key]; exists && !desiredKeys[key] {
+				changes = append(changes, &route53sdk.Change{
+					Action:            awssdk.String(route53sdk.ChangeActionDelete),
+					ResourceRecordSet: sdkRecord,
+				})
+			}
+		}
+		if !desiredHosts.Has(host) {
+			ownershipKey := recordSetKey{name: ownershipRecordNamePrefix + host, recordType: route53sdk.RRTypeTxt}
+			changes = append(changes, &route53sdk.Change{
+				Action:            awssdk.String(route53sdk.ChangeActionDelete),
+				ResourceRecordSet: sdkRecordsByKey[ownershipKey],
+			})
+		}
+	}
+	return changes, nil
+}
+
+// findHostedZonesForHost returns the hosted zones whose domain name matches host best.
+// both the public and private hosted zones are returned if they share the same domain name.
+func findHostedZonesForHost(zones []HostedZoneInfo, host string) []HostedZoneInfo {
+	var matchedZones []HostedZoneInfo
+	for _, zone := range zones {
+		if host != zone.Name && !strings.HasSuffix(host, "."+zone.Name) {
+			continue
+		}
+		if len(matchedZones) != 0 {
+			if len(zone.Name) < len(matchedZones[0].Name) {
+				continue
+			}
+			if len(zone.Name) > len(matchedZones[0].Name) {
+				matchedZones = nil
+			}
+		}
+		matchedZones = append(matchedZones, zone)
+	}
+	return matchedZones
+}
+
+func buildSDKAliasRecordSet(ctx context.Context, resRS *route53model.RecordSet) (*route53sdk.ResourceRecordSet, error) {
+	dnsName, err := resRS.Spec.AliasTarget.DNSName.Resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	hostedZoneID, err := resRS.Spec.AliasTarget.HostedZoneID.Resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &route53sdk.ResourceRecordSet{
+		Name: awssdk.String(normalizeRecordName(resRS.Spec.Name)),
+		Type: awssdk.String(string(resRS.Spec.Type)),
+		AliasTarget: &route53sdk.AliasTarget{
+			DNSName:              awssdk.String(dnsName),
+			HostedZoneId:         awssdk.String(hostedZoneID),
+			EvaluateTargetHealth: awssdk.Bool(resRS.Spec.AliasTarget.EvaluateTargetHealth),
+		},
+	}, nil
+}
+
+func buildSDKOwnershipRecordSet(host string, ownershipValue string) *route53sdk.ResourceRecordSet {
+	return &route53sdk.ResourceRecordSet{
+		Name: awssdk.String(ownershipRecordNamePrefix + host),
+		Type: awssdk.String(route53sdk.RRTypeTxt),
+		TTL:  awssdk.Int64(ownershipRecordTTL),
+		ResourceRecords: []*route53sdk.ResourceRecord{
+			{
+				Value: awssdk.String(ownershipValue),
+			},
+		},
+	}
+}
+
+// buildOwnershipRecordValue builds the quoted TXT value of ownership records from stack tags.
+func buildOwnershipRecordValue(stackTags map[string]string) string {
+	tagKeys := make([]string, 0, len(stackTags))
+	for tagKey := range stackTags {
+		tagKeys = append(tagKeys, tagKey)
+	}
+	sort.Strings(tagKeys)
+	attributes := []string{ownershipHeritage}
+	for _, tagKey := range tagKeys {
+		attributes = append(attributes, fmt.Sprintf("%v=%v", tagKey, stackTags[tagKey]))
+	}
+	return fmt.Sprintf("%q", strings.Join(attributes, ","))
+}
+
+func isOwnershipRecord(sdkRecord *route53sdk.ResourceRecordSet, ownershipValue string) bool {
+	for _, record := range sdkRecord.ResourceRecords {
+		if awssdk.StringValue(record.Value) == ownershipValue {
+			return true
+		}
+	}
+	return false
+}
+
+func isAliasRecordUpToDate(sdkRecord *route53sdk.ResourceRecordSet, desiredRecord *route53sdk.ResourceRecordSet) bool {
+	if sdkRecord.AliasTarget == nil {
+		return false
+	}
+	return normalizeRecordName(awssdk.StringValue(sdkRecord.AliasTarget.DNSName)) == normalizeRecordName(awssdk.StringValue(desiredRecord.AliasTarget.DNSName)) &&
+		awssdk.StringValue(sdkRecord.AliasTarget.HostedZoneId) == awssdk.StringValue(desiredRecord.AliasTarget.HostedZoneId) &&
+		awssdk.BoolValue(sdkRecord.AliasTarget.EvaluateTargetHealth) == awssdk.BoolValue(desiredRecord.AliasTarget.EvaluateTargetHealth)
+}
+
+// recordSetKey identifies a record within hosted zone.
+type recordSetKey struct {
+	name       string
+	recordType string
+}
+
+func buildRecordSetKey(sdkRecord *route53sdk.ResourceRecordSet) recordSetKey {
+	return recordSetKey{
+		name:       normalizeRecordName(awssdk.StringValue(sdkRecord.Name)),
+		recordType: awssdk.StringValue(sdkRecord.Type),
+	}
+}
+
+// normalizeRecordName normalizes the record name returned by Route 53, which is fully qualified and escapes the wildcard.
+func normalizeRecordName(name string) string {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	return strings.ReplaceAll(name, `\052`, "*")
+}
@@ -0,0 +1,196 @@
+package route53
+
+import (
+	"errors"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	route53sdk "github.com/aws/aws-sdk-go/service/route53"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_buildRecordSetChanges(t *testing.T) {
+	zone := HostedZoneInfo{ID: "Z0123456789", Name: "example.com"}
+	ownershipValue := buildOwnershipRecordValue(map[string]string{
+		"elbv2.k8s.aws/cluster": "cluster-name",
+		"ingress.k8s.aws/stack": "awesome-group",
+	})
+	otherOwnershipValue := buildOwnershipRecordValue(map[string]string{
+		"elbv2.k8s.aws/cluster": "cluster-name",
+		"ingress.k8s.aws/stack": "other-group",
+	})
+	aliasRecord := func(name string, recordType string, dnsName string) *route53sdk.ResourceRecordSet {
+		return &route53sdk.ResourceRecordSet{
+			Name: awssdk.String(name),
+			Type: awssdk.String(recordType),
+			AliasTarget: &route53sdk.AliasTarget{
+				DNSName:              awssdk.String(dnsName),
+				HostedZoneId:         awssdk.String("Z35SXDOTRQ7X7K"),
+				EvaluateTargetHealth: awssdk.Bool(true),
+			},
+		}
+	}
+	ownershipRecord := func(name string, value string) *route53sdk.ResourceRecordSet {
+		return &route53sdk.ResourceRecordSet{
+			Name:            awssdk.String(name),
+			Type:            awssdk.String("TXT"),
+			TTL:             awssdk.Int64(300),
+			ResourceRecords: []*route53sdk.ResourceRecord{{Value: awssdk.String(value)}},
+		}
+	}
+	tests := []struct {
+		name           string
+		desiredRecords []*route53sdk.ResourceRecordSet
+		sdkRecords     []*route53sdk.ResourceRecordSet
+		want           []*route53sdk.Change
+		wantErr        error
+	}{
+		{
+			name: "create records for new host",
+			desiredRecords: []*route53sdk.ResourceRecordSet{
+				aliasRecord("www.example.com", "A", "my-alb.us-west-2.elb.amazonaws.com"),
+			},
+			sdkRecords: []*route53sdk.ResourceRecordSet{
+				ownershipRecord("example.com.", `"v=spf1 -all"`),
+			},
+			want: []*route53sdk.Change{
+				{
+					Action:            awssdk.String("UPSERT"),
+					ResourceRecordSet: aliasRecord("www.example.com", "A", "my-alb.us-west-2.elb.amazonaws.com"),
+				},
+				{
+					Action:            awssdk.String("CREATE"),
+					ResourceRecordSet: ownershipRecord("_aws-lbc-owner.www.example.com", ownershipValue),
+				},
+			},
+		},
+		{
+			name: "records up to date",
+			desiredRecords: []*route53sdk.ResourceRecordSet{
+				aliasRecord("*.example.com", "A", "my-alb.us-west-2.elb.amazonaws.com"),
+			},
+			sdkRecords: []*route53sdk.ResourceRecordSet{
+				aliasRecord(`\052.example.com.`, "A", "my-alb.us-west-2.elb.amazonaws.com."),
+				ownershipRecord(`_aws-lbc-owner.\052.example.com.`, ownershipValue),
+			},
+		},
+		{
+			name: "update records of owned host",
+			desiredRecords: []*route53sdk.ResourceRecordSet{
+				aliasRecord("www.example.com", "A", "new-alb.us-west-2.elb.amazonaws.com"),
+			},
+			sdkRecords: []*route53sdk.ResourceRecordSet{
+				aliasRecord("www.example.com.", "A", "my-alb.us-west-2.elb.amazonaws.com."),
+				aliasRecord("www.example.com.", "AAAA", "my-alb.us-west-2.elb.amazonaws.com."),
+				ownershipRecord("_aws-lbc-owner.www.example.com.", ownershipValue),
+			},
+			want: []*route53sdk.Change{
+				{
+					Action:            awssdk.String("UPSERT"),
+					ResourceRecordSet: aliasRecord("www.example.com", "A", "new-alb.us-west-2.elb.amazonaws.com"),
+				},
+				{
+					Action:            awssdk.String("DELETE"),
+					ResourceRecordSet: aliasRecord("www.example.com.", "AAAA", "my-alb.us-west-2.elb.amazonaws.com."),
+				},
+			},
+		},
+		{
+			name: "delete records of owned host no longer desired",
+			sdkRecords: []*route53sdk.ResourceRecordSet{
+				aliasRecord("www.example.com.", "A", "my-alb.us-west-2.elb.amazonaws.com."),
+				ownershipRecord("_aws-lbc-owner.www.example.com.", ownershipValue),
+				aliasRecord("other.example.com.", "A", "other-alb.us-west-2.elb.amazonaws.com."),
+				ownershipRecord("_aws-lbc-owner.other.example.com.", otherOwnershipValue),
+			},
+			want: []*route53sdk.Change{
+				{
+					Action:            awssdk.String("DELETE"),
+					ResourceRecordSet: aliasRecord("www.example.com.", "A", "my-alb.us-west-2.elb.amazonaws.com."),
+				},
+				{
+					Action:            awssdk.String("DELETE"),
+					ResourceRecordSet: ownershipRecord("_aws-lbc-owner.www.example.com.", ownershipValue),
+				},
+			},
+		},
+		{
+			name: "record not managed by controller",
+			desiredRecords: []*route53sdk.ResourceRecordSet{
+				aliasRecord("www.example.com", "A", "my-alb.us-west-2.elb.amazonaws.com"),
+			},
+			sdkRecords: []*route53sdk.ResourceRecordSet{
+				aliasRecord("www.example.com.", "A", "cdn.example.net."),
+			},
+			wantErr: errors.New("A record www.example.com in hosted zone Z0123456789 isn't managed by controller"),
+		},
+		{
+			name: "host owned by another stack",
+			desiredRecords: []*route53sdk.ResourceRecordSet{
+				aliasRecord("other.example.com", "AAAA", "my-alb.us-west-2.elb.amazonaws.com"),
+			},
+			sdkRecords: []*route53sdk.ResourceRecordSet{
+				aliasRecord("other.example.com.", "A", "other-alb.us-west-2.elb.amazonaws.com."),
+				ownershipRecord("_aws-lbc-owner.other.example.com.", otherOwnershipValue),
+			},
+			wantErr: errors.New("host other.example.com in hosted zone Z0123456789 is managed by another owner"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buildRecordSetChanges(zone, tt.desiredRecords, tt.sdkRecords, ownershipValue)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_findHostedZonesForHost(t *testing.T) {
+	publicZone := HostedZoneInfo{ID: "Z1", Name: "example.com"}
+	privateZone := HostedZoneInfo{ID: "Z2", Name: "example.com", Private: true}
+	subZone := HostedZoneInfo{ID: "Z3", Name: "apps.example.com"}
+	otherZone := HostedZoneInfo{ID: "Z4", Name: "ample.com"}
+	zones := []HostedZoneInfo{publicZone, subZone, privateZone, otherZone}
+	tests := []struct {
+		name string
+		host string
+		want []HostedZoneInfo
+	}{
+		{
+			name: "public and private zones",
+			host: "www.example.com",
+			want: []HostedZoneInfo{publicZone, privateZone},
+		},
+		{
+			name: "apex of zone",
+			host: "example.com",
+			want: []HostedZoneInfo{publicZone, privateZone},
+		},
+		{
+			name: "most specific zone",
+			host: "*.apps.example.com",
+			want: []HostedZoneInfo{subZone},
+		},
+		{
+			name: "no matching zone",
+			host: "www.example.org",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, findHostedZonesForHost(zones, tt.host))
+		})
+	}
+}
+
+func Test_buildOwnershipRecordValue(t *testing.T) {
+	got := buildOwnershipRecordValue(map[string]string{
+		"ingress.k8s.aws/stack": "awesome-ns/ing",
+		"elbv2.k8s.aws/cluster": "cluster-name",
+	})
+	assert.Equal(t, `"heritage=aws-load-balancer-controller,elbv2.k8s.aws/cluster=cluster-name,ingress.k8s.aws/stack=awesome-ns/ing"`, got)
+}
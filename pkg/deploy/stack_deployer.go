@@ -3,16 +3,25 @@ package deploy
 import (
 	"context"
 	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/util/cache"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/ec2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/metrics"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/quota"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/route53"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/shield"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/wafregional"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/wafv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/zonalshift"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/tracing"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -25,31 +34,109 @@ type StackDeployer interface {
 // NewDefaultStackDeployer constructs new defaultStackDeployer.
 func NewDefaultStackDeployer(cloud aws.Cloud, k8sClient client.Client,
 	networkingSGManager networking.SecurityGroupManager, networkingSGReconciler networking.SecurityGroupReconciler,
-	config config.ControllerConfig, tagPrefix string, logger logr.Logger) *defaultStackDeployer {
+	config config.ControllerConfig, dynamicConfigProvider config.DynamicConfigProvider, tagPrefix string, metricsCollector metrics.Collector,
+	logger logr.Logger, opts ...StackDeployerOption) *defaultStackDeployer {
 
 	trackingProvider := tracking.NewDefaultProvider(tagPrefix, config.ClusterName)
-	ec2TaggingManager := ec2.NewDefaultTaggingManager(cloud.EC2(), networkingSGManager, cloud.VpcID(), logger)
-	elbv2TaggingManager := elbv2.NewDefaultTaggingManager(cloud.ELBV2(), logger)
+	ec2TaggingManager := ec2.NewInstrumentedTaggingManager(ec2.NewDefaultTaggingManager(cloud.EC2(), networkingSGManager, cloud.VpcID(), logger), metricsCollector)
+	// resources are discovered via ELBV2 DescribeTags, since Resource Groups Tagging API misses recently created resources.
+	elbv2TaggingManager := elbv2.NewInstrumentedTaggingManager(elbv2.NewDefaultTaggingManager(cloud.ELBV2(), nil, logger), metricsCollector)
+	ruleDescriptionBuilder := ec2.NewDefaultRuleDescriptionBuilder(config.SGRuleDescriptionTemplate, config.ClusterName)
 
-	return &defaultStackDeployer{
+	d := &defaultStackDeployer{
 		cloud:                               cloud,
 		k8sClient:                           k8sClient,
 		addonsConfig:                        config.AddonsConfig,
 		trackingProvider:                    trackingProvider,
 		ec2TaggingManager:                   ec2TaggingManager,
-		ec2SGManager:                        ec2.NewDefaultSecurityGroupManager(cloud.EC2(), trackingProvider, ec2TaggingManager, networkingSGReconciler, cloud.VpcID(), logger),
+		ec2SGManager:                        ec2.NewInstrumentedSecurityGroupManager(ec2.NewDefaultSecurityGroupManager(cloud.EC2(), trackingProvider, ec2TaggingManager, networkingSGManager, networkingSGReconciler, ruleDescriptionBuilder, dynamicConfigProvider, cloud.VpcID(), logger), metricsCollector),
+		ec2EIPManager:                       ec2.NewInstrumentedElasticIPManager(ec2.NewDefaultElasticIPManager(cloud.EC2(), trackingProvider, ec2TaggingManager, logger), metricsCollector),
+		ec2IPAMPoolAllocationManager:        ec2.NewInstrumentedIPAMPoolAllocationManager(ec2.NewDefaultIPAMPoolAllocationManager(cloud.EC2(), trackingProvider, logger), metricsCollector),
+		ec2VPCEndpointServiceManager:        ec2.NewInstrumentedVPCEndpointServiceManager(ec2.NewDefaultVPCEndpointServiceManager(cloud.EC2(), trackingProvider, ec2TaggingManager, logger), metricsCollector),
 		elbv2TaggingManager:                 elbv2TaggingManager,
-		elbv2LBManager:                      elbv2.NewDefaultLoadBalancerManager(cloud.ELBV2(), trackingProvider, elbv2TaggingManager, logger),
-		elbv2LSManager:                      elbv2.NewDefaultListenerManager(cloud.ELBV2(), logger),
-		elbv2LRManager:                      elbv2.NewDefaultListenerRuleManager(cloud.ELBV2(), logger),
-		elbv2TGManager:                      elbv2.NewDefaultTargetGroupManager(cloud.ELBV2(), trackingProvider, elbv2TaggingManager, cloud.VpcID(), logger),
-		elbv2TGBManager:                     elbv2.NewDefaultTargetGroupBindingManager(k8sClient, trackingProvider, logger),
+		elbv2LSManager:                      elbv2.NewInstrumentedListenerManager(elbv2.NewDefaultListenerManager(cloud.ELBV2(), trackingProvider, logger), metricsCollector),
+		elbv2LRManager:                      elbv2.NewInstrumentedListenerRuleManager(elbv2.NewDefaultListenerRuleManager(cloud.ELBV2(), logger), metricsCollector),
+		elbv2TGManager:                      elbv2.NewInstrumentedTargetGroupManager(elbv2.NewDefaultTargetGroupManager(cloud.ELBV2(), trackingProvider, elbv2TaggingManager, cloud.VpcID(), logger), metricsCollector),
+		elbv2TGBManager:                     elbv2.NewInstrumentedTargetGroupBindingManager(elbv2.NewDefaultTargetGroupBindingManager(k8sClient, trackingProvider, logger), metricsCollector),
 		wafv2WebACLAssociationManager:       wafv2.NewDefaultWebACLAssociationManager(cloud.WAFv2(), logger),
 		wafRegionalWebACLAssociationManager: wafregional.NewDefaultWebACLAssociationManager(cloud.WAFRegional(), logger),
 		shieldProtectionManager:             shield.NewDefaultProtectionManager(cloud.Shield(), logger),
+		zonalShiftManager:                   zonalshift.NewDefaultZonalShiftManager(cloud.ARCZonalShift(), logger),
+		route53RecordSetManager:             route53.NewDefaultRecordSetManager(cloud.Route53(), config.AddonsConfig.Route53HostedZoneIDs, logger),
+		quotaChecker:                        quota.NewDefaultStackChecker(quota.NewDefaultProvider(cloud.ServiceQuotas(), logger)),
 		vpcID:                               cloud.VpcID(),
+		maxConcurrency:                      config.DeployMaxConcurrency,
 		logger:                              logger,
 	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	d.elbv2LBManager = elbv2.NewInstrumentedLoadBalancerManager(elbv2.NewDefaultLoadBalancerManager(cloud.ELBV2(), trackingProvider, elbv2TaggingManager, d.elbv2LBWarmPool, logger), metricsCollector)
+	d.elbv2LBReplacementManager = elbv2.NewDefaultLoadBalancerReplacementManager(cloud.ELBV2(), d.elbv2LBManager, d.elbv2TGManager, elbv2TaggingManager, logger)
+	return d
+}
+
+// StackDeployerOption configures the defaultStackDeployer.
+type StackDeployerOption func(d *defaultStackDeployer)
+
+// WithLegacyResourceAdoption is a StackDeployer option that adopts AWS resources provisioned by AWSALBIngressController(before v1.1.3)
+// for implicit IngressGroups, instead of recreating them.
+func WithLegacyResourceAdoption() StackDeployerOption {
+	return func(d *defaultStackDeployer) {
+		d.legacyResourceAdoption = true
+		d.legacyAdoptedStacksCache = cache.NewExpiring()
+	}
+}
+
+// WithLoadBalancerWarmPool is a StackDeployer option that claims spare ALBs from the warm pool for new stacks,
+// instead of creating them.
+func WithLoadBalancerWarmPool(warmPool elbv2.LoadBalancerWarmPool) StackDeployerOption {
+	return func(d *defaultStackDeployer) {
+		d.elbv2LBWarmPool = warmPool
+	}
+}
+
+// StackDeployerConfig contains configuration for StackDeployers constructed via NewStackDeployer.
+type StackDeployerConfig struct {
+	// ClusterName is the name of kubernetes cluster, it's used to tag and discover AWS resources.
+	ClusterName string
+	// TagPrefix is the prefix of tags used to track AWS resources of stacks, e.g. "my-operator.example.com".
+	// it must be unique per operator, otherwise resources provisioned by other operators might be modified or deleted.
+	TagPrefix string
+	// AddonsConfig enables WAF, WAFV2 and Shield addons for ALB, and zonal shift addon for ALB and NLB.
+	AddonsConfig config.AddonsConfig
+	// MetricsRegisterer registers deploy metrics if specified.
+	MetricsRegisterer prometheus.Registerer
+	// MaxConcurrency is the maximum number of resources deployed concurrently, resources are deployed sequentially if unset.
+	MaxConcurrency int
+}
+
+// NewStackDeployer constructs a StackDeployer for operators that build model stacks programmatically.
+// The returned StackDeployer reconciles AWS resources and TargetGroupBindings in stacks exactly the way this controller does.
+func NewStackDeployer(cloud aws.Cloud, k8sClient client.Client, cfg StackDeployerConfig, logger logr.Logger) (StackDeployer, error) {
+	if cfg.ClusterName == "" {
+		return nil, errors.New("clusterName must be specified")
+	}
+	if cfg.TagPrefix == "" {
+		return nil, errors.New("tagPrefix must be specified")
+	}
+	sgManager := networking.NewDefaultSecurityGroupManager(cloud.EC2(), logger)
+	sgReconciler := networking.NewDefaultSecurityGroupReconciler(sgManager, nil, logger)
+	var metricsCollector metrics.Collector = metrics.NewNoopCollector()
+	if cfg.MetricsRegisterer != nil {
+		collector, err := metrics.NewCollector(cfg.MetricsRegisterer)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to initialize deploy metrics collector")
+		}
+		metricsCollector = collector
+	}
+	controllerCFG := config.ControllerConfig{
+		ClusterName:          cfg.ClusterName,
+		AddonsConfig:         cfg.AddonsConfig,
+		DeployMaxConcurrency: cfg.MaxConcurrency,
+	}
+	dynamicConfigProvider := config.NewStaticDynamicConfigProvider(config.DynamicConfig{})
+	return NewDefaultStackDeployer(cloud, k8sClient, sgManager, sgReconciler, controllerCFG, dynamicConfigProvider, cfg.TagPrefix, metricsCollector, logger), nil
 }
 
 var _ StackDeployer = &defaultStackDeployer{}
@@ -62,8 +149,13 @@ type defaultStackDeployer struct {
 	trackingProvider                    tracking.Provider
 	ec2TaggingManager                   ec2.TaggingManager
 	ec2SGManager                        ec2.SecurityGroupManager
+	ec2EIPManager                       ec2.ElasticIPManager
+	ec2IPAMPoolAllocationManager        ec2.IPAMPoolAllocationManager
+	ec2VPCEndpointServiceManager        ec2.VPCEndpointServiceManager
 	elbv2TaggingManager                 elbv2.TaggingManager
 	elbv2LBManager                      elbv2.LoadBalancerManager
+	elbv2LBWarmPool                     elbv2.LoadBalancerWarmPool
+	elbv2LBReplacementManager           elbv2.LoadBalancerReplacementManager
 	elbv2LSManager                      elbv2.ListenerManager
 	elbv2LRManager                      elbv2.ListenerRuleManager
 	elbv2TGManager                      elbv2.TargetGroupManager
@@ -71,7 +163,13 @@ type defaultStackDeployer struct {
 	wafv2WebACLAssociationManager       wafv2.WebACLAssociationManager
 	wafRegionalWebACLAssociationManager wafregional.WebACLAssociationManager
 	shieldProtectionManager             shield.ProtectionManager
+	zonalShiftManager                   zonalshift.ZonalShiftManager
+	route53RecordSetManager             route53.RecordSetManager
+	quotaChecker                        quota.StackChecker
 	vpcID                               string
+	maxConcurrency                      int
+	legacyResourceAdoption              bool
+	legacyAdoptedStacksCache            *cache.Expiring
 
 	logger logr.Logger
 }
@@ -83,39 +181,171 @@ type ResourceSynthesizer interface {
 
 // Deploy a resource stack.
 func (d *defaultStackDeployer) Deploy(ctx context.Context, stack core.Stack) error {
-	synthesizers := []ResourceSynthesizer{
-		ec2.NewSecurityGroupSynthesizer(d.cloud.EC2(), d.trackingProvider, d.ec2TaggingManager, d.ec2SGManager, d.vpcID, d.logger, stack),
-		elbv2.NewTargetGroupSynthesizer(d.cloud.ELBV2(), d.trackingProvider, d.elbv2TaggingManager, d.elbv2TGManager, d.logger, stack),
-		elbv2.NewLoadBalancerSynthesizer(d.cloud.ELBV2(), d.trackingProvider, d.elbv2TaggingManager, d.elbv2LBManager, d.logger, stack),
-		elbv2.NewListenerSynthesizer(d.cloud.ELBV2(), d.elbv2LSManager, d.logger, stack),
-		elbv2.NewListenerRuleSynthesizer(d.cloud.ELBV2(), d.elbv2LRManager, d.logger, stack),
-		elbv2.NewTargetGroupBindingSynthesizer(d.k8sClient, d.trackingProvider, d.elbv2TGBManager, d.logger, stack),
+	ctx, span := tracing.StartSpan(ctx, "DeployStack", tracing.AttributeStackID.String(stack.StackID().String()))
+	err := d.deploy(ctx, stack)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (d *defaultStackDeployer) deploy(ctx context.Context, stack core.Stack) error {
+	// pre-flight check, so that exhausted quotas are reported before any resource is changed.
+	if err := runtime.TimeStage(ctx, "CheckQuotas", func(ctx context.Context) error {
+		return d.quotaChecker.Check(ctx, stack)
+	}); err != nil {
+		return err
+	}
+	loadBalancerDependencies := []string{"SecurityGroup", "ElasticIP"}
+	if d.addonsConfig.IPAMEnabled {
+		loadBalancerDependencies = append(loadBalancerDependencies, "IPAMPoolAllocation")
+	}
+	// LoadBalancers cannot be deleted while fronted by endpoint services.
+	if d.addonsConfig.VPCEndpointServiceEnabled {
+		loadBalancerDependencies = append(loadBalancerDependencies, "VPCEndpointServiceDeletion")
+	}
+	// legacy resources must be adopted before they are matched against the resources in stack.
+	var adoptionDependencies []string
+	if d.legacyResourceAdoption {
+		adoptionDependencies = []string{"LegacyResourceAdoption"}
+		loadBalancerDependencies = append(loadBalancerDependencies, adoptionDependencies...)
+	}
+	synthesizers := []stackSynthesizer{
+		{
+			name:         "SecurityGroup",
+			synthesizer:  ec2.NewSecurityGroupSynthesizer(d.cloud.EC2(), d.trackingProvider, d.ec2TaggingManager, d.ec2SGManager, d.vpcID, d.logger, stack),
+			dependencies: adoptionDependencies,
+		},
+		{
+			name:        "ElasticIP",
+			synthesizer: ec2.NewElasticIPSynthesizer(d.trackingProvider, d.ec2TaggingManager, d.ec2EIPManager, d.logger, stack),
+		},
+		{
+			name:         "TargetGroup",
+			synthesizer:  elbv2.NewTargetGroupSynthesizer(d.cloud.ELBV2(), d.trackingProvider, d.elbv2TaggingManager, d.elbv2TGManager, d.maxConcurrency, d.logger, stack),
+			dependencies: adoptionDependencies,
+		},
+		{
+			name:         "LoadBalancer",
+			synthesizer:  elbv2.NewLoadBalancerSynthesizer(d.cloud.ELBV2(), d.trackingProvider, d.elbv2TaggingManager, d.elbv2LBManager, d.elbv2LBReplacementManager, d.logger, stack),
+			dependencies: loadBalancerDependencies,
+		},
+		{
+			name:         "Listener",
+			synthesizer:  elbv2.NewListenerSynthesizer(d.cloud.ELBV2(), d.trackingProvider, d.elbv2TaggingManager, d.elbv2LSManager, d.logger, stack),
+			dependencies: []string{"LoadBalancer", "TargetGroup"},
+		},
+		{
+			name:         "ListenerRule",
+			synthesizer:  elbv2.NewListenerRuleSynthesizer(d.cloud.ELBV2(), d.elbv2LRManager, d.maxConcurrency, d.logger, stack),
+			dependencies: []string{"Listener", "TargetGroup"},
+		},
+		{
+			name:         "TargetGroupBinding",
+			synthesizer:  elbv2.NewTargetGroupBindingSynthesizer(d.k8sClient, d.trackingProvider, d.elbv2TGBManager, d.maxConcurrency, d.logger, stack),
+			dependencies: []string{"TargetGroup", "SecurityGroup"},
+		},
 	}
 
+	if d.legacyResourceAdoption {
+		synthesizers = append(synthesizers, stackSynthesizer{
+			name:        "LegacyResourceAdoption",
+			synthesizer: NewLegacyResourceAdopter(d.cloud.EC2(), d.trackingProvider, d.elbv2TaggingManager, d.ec2TaggingManager, d.vpcID, d.legacyAdoptedStacksCache, d.logger, stack),
+		})
+	}
+	if d.addonsConfig.IPAMEnabled {
+		synthesizers = append(synthesizers, stackSynthesizer{
+			name:        "IPAMPoolAllocation",
+			synthesizer: ec2.NewIPAMPoolAllocationSynthesizer(d.trackingProvider, d.ec2IPAMPoolAllocationManager, d.logger, stack),
+		})
+	}
+	if d.addonsConfig.VPCEndpointServiceEnabled {
+		synthesizers = append(synthesizers, stackSynthesizer{
+			name:        "VPCEndpointServiceDeletion",
+			synthesizer: ec2.NewVPCEndpointServiceDeletionSynthesizer(d.trackingProvider, d.ec2TaggingManager, d.ec2VPCEndpointServiceManager, d.logger, stack),
+		}, stackSynthesizer{
+			name:         "VPCEndpointService",
+			synthesizer:  ec2.NewVPCEndpointServiceSynthesizer(d.trackingProvider, d.ec2TaggingManager, d.ec2VPCEndpointServiceManager, d.logger, stack),
+			dependencies: []string{"LoadBalancer"},
+		})
+	}
 	if d.addonsConfig.WAFV2Enabled {
-		synthesizers = append(synthesizers, wafv2.NewWebACLAssociationSynthesizer(d.wafv2WebACLAssociationManager, d.logger, stack))
+		synthesizers = append(synthesizers, stackSynthesizer{
+			name:         "WAFv2WebACLAssociation",
+			synthesizer:  wafv2.NewWebACLAssociationSynthesizer(d.wafv2WebACLAssociationManager, d.logger, stack),
+			dependencies: []string{"LoadBalancer"},
+		})
 	}
 	if d.addonsConfig.WAFEnabled && d.cloud.WAFRegional().Available() {
-		synthesizers = append(synthesizers, wafregional.NewWebACLAssociationSynthesizer(d.wafRegionalWebACLAssociationManager, d.logger, stack))
+		synthesizers = append(synthesizers, stackSynthesizer{
+			name:         "WAFRegionalWebACLAssociation",
+			synthesizer:  wafregional.NewWebACLAssociationSynthesizer(d.wafRegionalWebACLAssociationManager, d.logger, stack),
+			dependencies: []string{"LoadBalancer"},
+		})
 	}
 	shieldNeeded := false
 	if d.addonsConfig.ShieldEnabled {
 		shieldNeeded, _ = d.cloud.Shield().Available()
 	}
 	if shieldNeeded {
-		synthesizers = append(synthesizers, shield.NewProtectionSynthesizer(d.shieldProtectionManager, d.logger, stack))
+		synthesizers = append(synthesizers, stackSynthesizer{
+			name:         "ShieldProtection",
+			synthesizer:  shield.NewProtectionSynthesizer(d.shieldProtectionManager, d.logger, stack),
+			dependencies: []string{"LoadBalancer"},
+		})
+	}
+	if d.addonsConfig.ZonalShiftEnabled {
+		synthesizers = append(synthesizers, stackSynthesizer{
+			name:         "ZonalShift",
+			synthesizer:  zonalshift.NewZonalShiftSynthesizer(d.zonalShiftManager, d.logger, stack),
+			dependencies: []string{"LoadBalancer"},
+		})
+	}
+	if d.addonsConfig.Route53Enabled {
+		synthesizers = append(synthesizers, stackSynthesizer{
+			name:         "Route53RecordSet",
+			synthesizer:  route53.NewRecordSetSynthesizer(d.route53RecordSetManager, d.trackingProvider, d.logger, stack),
+			dependencies: []string{"LoadBalancer"},
+		})
 	}
 
+	// synthesizers run after the synthesizers they depend on, and post synthesizers run in reverse order.
+	synthesizeGraph := runtime.NewTaskGraph()
+	postSynthesizeGraph := runtime.NewTaskGraph()
+	dependentsByName := make(map[string][]string)
 	for _, synthesizer := range synthesizers {
-		if err := synthesizer.Synthesize(ctx); err != nil {
-			return err
+		synthesizeGraph.AddTask(synthesizer.name, tracing.TraceFunc("Synthesize"+synthesizer.name,
+			runtime.TimeStageFunc("Synthesize"+synthesizer.name, synthesizer.synthesizer.Synthesize)),
+			synthesizer.dependencies...)
+		for _, dependency := range synthesizer.dependencies {
+			dependentsByName[dependency] = append(dependentsByName[dependency], synthesizer.name)
 		}
 	}
 	for i := len(synthesizers) - 1; i >= 0; i-- {
-		if err := synthesizers[i].PostSynthesize(ctx); err != nil {
+		synthesizer := synthesizers[i]
+		postSynthesizeGraph.AddTask(synthesizer.name, tracing.TraceFunc("PostSynthesize"+synthesizer.name,
+			runtime.TimeStageFunc("PostSynthesize"+synthesizer.name, synthesizer.synthesizer.PostSynthesize)),
+			dependentsByName[synthesizer.name]...)
+	}
+	// tag changes of updated resources are applied together after synthesis, so that resources with identical changes take a single API call.
+	elbv2TagBatch := tracking.NewTagBatch()
+	ec2TagBatch := tracking.NewTagBatch()
+	synthesizeCtx := elbv2.ContextWithTagBatch(ec2.ContextWithTagBatch(ctx, ec2TagBatch), elbv2TagBatch)
+	if err := synthesizeGraph.Run(synthesizeCtx, d.maxConcurrency); err != nil {
+		return err
+	}
+	if err := runtime.TimeStage(ctx, "ApplyTagBatches", func(ctx context.Context) error {
+		if err := d.elbv2TaggingManager.ApplyTagBatch(ctx, elbv2TagBatch); err != nil {
 			return err
 		}
+		return d.ec2TaggingManager.ApplyTagBatch(ctx, ec2TagBatch)
+	}); err != nil {
+		return err
 	}
+	return postSynthesizeGraph.Run(ctx, d.maxConcurrency)
+}
 
-	return nil
+// stackSynthesizer is a ResourceSynthesizer with the names of other synthesizers it depends on.
+type stackSynthesizer struct {
+	name         string
+	synthesizer  ResourceSynthesizer
+	dependencies []string
 }
@@ -0,0 +1,36 @@
+package deploy
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func TestNewStackDeployer(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     StackDeployerConfig
+		wantErr string
+	}{
+		{
+			name: "clusterName not specified",
+			cfg: StackDeployerConfig{
+				TagPrefix: "my-operator.example.com",
+			},
+			wantErr: "clusterName must be specified",
+		},
+		{
+			name: "tagPrefix not specified",
+			cfg: StackDeployerConfig{
+				ClusterName: "my-cluster",
+			},
+			wantErr: "tagPrefix must be specified",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewStackDeployer(nil, nil, tt.cfg, &log.NullLogger{})
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package deploy
+
+import (
+	"fmt"
+	"github.com/go-logr/logr"
+	"net/http"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	"strings"
+	"sync"
+)
+
+const (
+	stackExportQueryParamStack  = "stack"
+	stackExportQueryParamFormat = "format"
+)
+
+// StackExportHandler serves the AWS resources of the latest deployed stacks as infrastructure as code.
+// Stacks are selected by `stack` query parameter with the stackID, like `namespace/name` for Services or implicit IngressGroups,
+// and rendered in the format of `format` query parameter, which defaults to terraform.
+type StackExportHandler interface {
+	http.Handler
+
+	// Record records stack as the latest deployed stack for its stackID.
+	Record(stack core.Stack)
+
+	// Forget forgets the stack with stackID.
+	Forget(stackID core.StackID)
+}
+
+// NewDefaultStackExportHandler constructs new defaultStackExportHandler.
+func NewDefaultStackExportHandler(exporter StackExporter, logger logr.Logger) *defaultStackExportHandler {
+	return &defaultStackExportHandler{
+		exporter:    exporter,
+		logger:      logger,
+		stackByID:   make(map[core.StackID]core.Stack),
+		stackByIDMu: sync.RWMutex{},
+	}
+}
+
+var _ StackExportHandler = &defaultStackExportHandler{}
+
+type defaultStackExportHandler struct {
+	exporter StackExporter
+	logger   logr.Logger
+
+	stackByID   map[core.StackID]core.Stack
+	stackByIDMu sync.RWMutex
+}
+
+func (h *defaultStackExportHandler) Record(stack core.Stack) {
+	h.stackByIDMu.Lock()
+	defer h.stackByIDMu.Unlock()
+	h.stackByID[stack.StackID()] = stack
+}
+
+func (h *defaultStackExportHandler) Forget(stackID core.StackID) {
+	h.stackByIDMu.Lock()
+	defer h.stackByIDMu.Unlock()
+	delete(h.stackByID, stackID)
+}
+
+func (h *defaultStackExportHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	rawStackID := req.URL.Query().Get(stackExportQueryParamStack)
+	if rawStackID == "" {
+		http.Error(w, fmt.Sprintf("query parameter %v must be specified", stackExportQueryParamStack), http.StatusBadRequest)
+		return
+	}
+	format := StackExportFormat(req.URL.Query().Get(stackExportQueryParamFormat))
+	if format == "" {
+		format = StackExportFormatTerraform
+	}
+	if format != StackExportFormatTerraform && format != StackExportFormatCloudFormation {
+		http.Error(w, fmt.Sprintf("unsupported export format: %v", format), http.StatusBadRequest)
+		return
+	}
+
+	stackID := parseStackID(rawStackID)
+	h.stackByIDMu.RLock()
+	stack, exists := h.stackByID[stackID]
+	h.stackByIDMu.RUnlock()
+	if !exists {
+		http.Error(w, fmt.Sprintf("stack not found: %v", stackID), http.StatusNotFound)
+		return
+	}
+	payload, err := h.exporter.Export(stack, format)
+	if err != nil {
+		h.logger.Error(err, "failed to export stack", "stackID", stackID, "format", format)
+		http.Error(w, fmt.Sprintf("failed to export stack: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if format == StackExportFormatCloudFormation {
+		w.Header().Set("Content-Type", "application/json")
+	} else {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	}
+	_, _ = w.Write([]byte(payload))
+}
+
+// parseStackID parses stackID from its string representation.
+func parseStackID(rawStackID string) core.StackID {
+	parts := strings.SplitN(rawStackID, "/", 2)
+	if len(parts) == 1 {
+		return core.StackID{Name: parts[0]}
+	}
+	return core.StackID{Namespace: parts[0], Name: parts[1]}
+}
@@ -0,0 +1,69 @@
+package deploy
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_defaultStackExportHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		forget     bool
+		wantStatus int
+	}{
+		{
+			name:       "export as terraform by default",
+			url:        "/debug/stack-export/ingress?stack=ns/ing",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "export as cloudformation",
+			url:        "/debug/stack-export/ingress?stack=ns/ing&format=cloudformation",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "stack not specified",
+			url:        "/debug/stack-export/ingress",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unsupported format",
+			url:        "/debug/stack-export/ingress?stack=ns/ing&format=pulumi",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "stack not found",
+			url:        "/debug/stack-export/ingress?stack=awesome-group",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "stack forgotten",
+			url:        "/debug/stack-export/ingress?stack=ns/ing",
+			forget:     true,
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewDefaultStackExportHandler(NewDefaultStackExporter("vpc-1"), &log.NullLogger{})
+			stack := buildStackForExport()
+			h.Record(stack)
+			if tt.forget {
+				h.Forget(stack.StackID())
+			}
+			recorder := httptest.NewRecorder()
+			h.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tt.url, nil))
+			assert.Equal(t, tt.wantStatus, recorder.Code)
+		})
+	}
+}
+
+func Test_parseStackID(t *testing.T) {
+	assert.Equal(t, core.StackID{Namespace: "ns", Name: "ing"}, parseStackID("ns/ing"))
+	assert.Equal(t, core.StackID{Name: "awesome-group"}, parseStackID("awesome-group"))
+}
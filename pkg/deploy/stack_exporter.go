@@ -0,0 +1,216 @@
+package deploy
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// StackExportFormat is the infrastructure as code format that stacks are exported into.
+type StackExportFormat string
+
+const (
+	StackExportFormatTerraform      StackExportFormat = "terraform"
+	StackExportFormatCloudFormation StackExportFormat = "cloudformation"
+)
+
+// StackExporter will export the AWS resources of a resource stack as infrastructure as code.
+// It's intended for migrating the ownership of AWS resources out of the controller, the exported configuration
+// doesn't contain the tags used by controller to track resources, nor the targets registered by TargetGroupBindings.
+type StackExporter interface {
+	// Export renders the AWS resources in stack with format.
+	Export(stack core.Stack, format StackExportFormat) (string, error)
+}
+
+// NewDefaultStackExporter constructs new defaultStackExporter.
+// vpcID is the default VPC of exported TargetGroups and SecurityGroups.
+func NewDefaultStackExporter(vpcID string) *defaultStackExporter {
+	return &defaultStackExporter{
+		vpcID: vpcID,
+	}
+}
+
+var _ StackExporter = &defaultStackExporter{}
+
+type defaultStackExporter struct {
+	vpcID string
+}
+
+func (e *defaultStackExporter) Export(stack core.Stack, format StackExportFormat) (string, error) {
+	resources, err := listExportedResources(stack)
+	if err != nil {
+		return "", err
+	}
+	switch format {
+	case StackExportFormatTerraform:
+		return renderTerraform(stack.StackID(), e.vpcID, resources)
+	case StackExportFormatCloudFormation:
+		return renderCloudFormation(stack.StackID(), e.vpcID, resources)
+	default:
+		return "", errors.Errorf("unsupported export format: %v", format)
+	}
+}
+
+// exportedResources are the AWS resources in stack that are exported, sorted by resource ID.
+type exportedResources struct {
+	loadBalancers  []*elbv2model.LoadBalancer
+	listeners      []*elbv2model.Listener
+	listenerRules  []*elbv2model.ListenerRule
+	targetGroups   []*elbv2model.TargetGroup
+	securityGroups []*ec2model.SecurityGroup
+
+	// existingLBARNByID contains the ARN of existing LoadBalancers that aren't managed by controller, they are not exported.
+	existingLBARNByID map[string]string
+}
+
+func listExportedResources(stack core.Stack) (exportedResources, error) {
+	resources := exportedResources{
+		existingLBARNByID: make(map[string]string),
+	}
+	var resLBs []*elbv2model.LoadBalancer
+	if err := stack.ListResources(&resLBs); err != nil {
+		return exportedResources{}, err
+	}
+	for _, resLB := range resLBs {
+		if resLB.Spec.ExistingLoadBalancerARN != nil {
+			resources.existingLBARNByID[resLB.ID()] = *resLB.Spec.ExistingLoadBalancerARN
+			continue
+		}
+		resources.loadBalancers = append(resources.loadBalancers, resLB)
+	}
+	if err := stack.ListResources(&resources.listeners); err != nil {
+		return exportedResources{}, err
+	}
+	if err := stack.ListResources(&resources.listenerRules); err != nil {
+		return exportedResources{}, err
+	}
+	if err := stack.ListResources(&resources.targetGroups); err != nil {
+		return exportedResources{}, err
+	}
+	if err := stack.ListResources(&resources.securityGroups); err != nil {
+		return exportedResources{}, err
+	}
+	sort.Slice(resources.loadBalancers, func(i, j int) bool { return resources.loadBalancers[i].ID() < resources.loadBalancers[j].ID() })
+	sort.Slice(resources.listeners, func(i, j int) bool { return resources.listeners[i].ID() < resources.listeners[j].ID() })
+	sort.Slice(resources.listenerRules, func(i, j int) bool { return resources.listenerRules[i].ID() < resources.listenerRules[j].ID() })
+	sort.Slice(resources.targetGroups, func(i, j int) bool { return resources.targetGroups[i].ID() < resources.targetGroups[j].ID() })
+	sort.Slice(resources.securityGroups, func(i, j int) bool { return resources.securityGroups[i].ID() < resources.securityGroups[j].ID() })
+	return resources, nil
+}
+
+// all returns all exported resources, in the order they are rendered.
+func (r exportedResources) all() []core.Resource {
+	var resources []core.Resource
+	for _, res := range r.securityGroups {
+		resources = append(resources, res)
+	}
+	for _, res := range r.targetGroups {
+		resources = append(resources, res)
+	}
+	for _, res := range r.loadBalancers {
+		resources = append(resources, res)
+	}
+	for _, res := range r.listeners {
+		resources = append(resources, res)
+	}
+	for _, res := range r.listenerRules {
+		resources = append(resources, res)
+	}
+	return resources
+}
+
+// exportNameRegistry assigns unique names to exported resources and the inputs of exported configuration.
+type exportNameRegistry struct {
+	nameByResource map[core.Resource]string
+	usedNames      map[string]bool
+}
+
+func newExportNameRegistry() *exportNameRegistry {
+	return &exportNameRegistry{
+		nameByResource: make(map[core.Resource]string),
+		usedNames:      make(map[string]bool),
+	}
+}
+
+// assign assigns an unique name based on name, a numeric suffix is added when name is already used.
+func (r *exportNameRegistry) assign(name string) string {
+	uniqueName := name
+	for i := 2; r.usedNames[uniqueName]; i++ {
+		uniqueName = fmt.Sprintf("%v%v", name, i)
+	}
+	r.usedNames[uniqueName] = true
+	return uniqueName
+}
+
+// assignResource assigns an unique name for resource, it's a no-op if resource already has one.
+func (r *exportNameRegistry) assignResource(res core.Resource, name string) string {
+	if existingName, ok := r.nameByResource[res]; ok {
+		return existingName
+	}
+	uniqueName := r.assign(name)
+	r.nameByResource[res] = uniqueName
+	return uniqueName
+}
+
+// lookupResource returns the name assigned for resource.
+func (r *exportNameRegistry) lookupResource(res core.Resource) (string, bool) {
+	name, ok := r.nameByResource[res]
+	return name, ok
+}
+
+// resourceKind returns the kind of resource from its type, e.g. "LoadBalancer" for "AWS::ElasticLoadBalancingV2::LoadBalancer".
+func resourceKind(res core.Resource) string {
+	resType := res.Type()
+	return resType[strings.LastIndex(resType, ":")+1:]
+}
+
+// splitNameWords splits name into words, separated by non-alphanumeric characters or case changes.
+// e.g. "ns/ing-svc:80" is split into ["ns", "ing", "svc", "80"], and "ManagedLBSecurityGroup" into ["Managed", "LB", "Security", "Group"].
+func splitNameWords(name string) []string {
+	var words []string
+	var word []rune
+	runes := []rune(name)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if len(word) > 0 {
+				words = append(words, string(word))
+				word = nil
+			}
+			continue
+		}
+		if unicode.IsUpper(r) && len(word) > 0 {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				words = append(words, string(word))
+				word = nil
+			}
+		}
+		word = append(word, r)
+	}
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+	return words
+}
+
+// pascalCaseName converts name into PascalCase, e.g. "ns/ing-svc:80" into "NsIngSvc80".
+func pascalCaseName(name string) string {
+	var sb strings.Builder
+	for _, word := range splitNameWords(name) {
+		runes := []rune(word)
+		sb.WriteRune(unicode.ToUpper(runes[0]))
+		sb.WriteString(string(runes[1:]))
+	}
+	return sb.String()
+}
+
+// snakeCaseName converts name into snake_case, e.g. "ManagedLBSecurityGroup" into "managed_lb_security_group".
+func snakeCaseName(name string) string {
+	return strings.ToLower(strings.Join(splitNameWords(name), "_"))
+}
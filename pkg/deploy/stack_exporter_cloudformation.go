@@ -0,0 +1,486 @@
+package deploy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sort"
+	"strings"
+)
+
+const cfnParameterVPCID = "VpcId"
+
+type cfnTemplate struct {
+	AWSTemplateFormatVersion string                  `json:"AWSTemplateFormatVersion"`
+	Description              string                  `json:"Description"`
+	Parameters               map[string]cfnParameter `json:"Parameters,omitempty"`
+	Resources                map[string]cfnResource  `json:"Resources"`
+}
+
+type cfnParameter struct {
+	Type        string `json:"Type"`
+	Description string `json:"Description,omitempty"`
+	Default     string `json:"Default,omitempty"`
+	NoEcho      bool   `json:"NoEcho,omitempty"`
+}
+
+type cfnResource struct {
+	Type       string                 `json:"Type"`
+	Properties map[string]interface{} `json:"Properties"`
+}
+
+// cfnRenderer renders exported resources as CloudFormation template.
+type cfnRenderer struct {
+	resources exportedResources
+	names     *exportNameRegistry
+	template  cfnTemplate
+}
+
+func renderCloudFormation(stackID core.StackID, vpcID string, resources exportedResources) (string, error) {
+	r := &cfnRenderer{
+		resources: resources,
+		names:     newExportNameRegistry(),
+		template: cfnTemplate{
+			AWSTemplateFormatVersion: "2010-09-09",
+			Description:              fmt.Sprintf("AWS resources exported from stack %v of aws-load-balancer-controller", stackID),
+			Parameters:               make(map[string]cfnParameter),
+			Resources:                make(map[string]cfnResource),
+		},
+	}
+	r.names.assign(cfnParameterVPCID)
+	r.template.Parameters[cfnParameterVPCID] = cfnParameter{
+		Type:        "AWS::EC2::VPC::Id",
+		Description: "The VPC of TargetGroups and SecurityGroups",
+		Default:     vpcID,
+	}
+	for _, res := range resources.all() {
+		kind := resourceKind(res)
+		name := pascalCaseName(res.ID())
+		if name != kind {
+			name = kind + name
+		}
+		r.names.assignResource(res, name)
+	}
+	for _, sg := range resources.securityGroups {
+		r.renderSecurityGroup(sg)
+	}
+	for _, tg := range resources.targetGroups {
+		r.renderTargetGroup(tg)
+	}
+	for _, lb := range resources.loadBalancers {
+		r.renderLoadBalancer(lb)
+	}
+	for _, ls := range resources.listeners {
+		r.renderListener(ls)
+	}
+	for _, lr := range resources.listenerRules {
+		r.renderListenerRule(lr)
+	}
+	payload, err := json.MarshalIndent(r.template, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(payload), nil
+}
+
+func (r *cfnRenderer) addResource(res core.Resource, properties map[string]interface{}) {
+	name, _ := r.names.lookupResource(res)
+	r.template.Resources[name] = cfnResource{
+		Type:       res.Type(),
+		Properties: properties,
+	}
+}
+
+// addParameter adds a template parameter for values that cannot be exported, and returns the reference to it.
+func (r *cfnRenderer) addParameter(name string, description string, noEcho bool) interface{} {
+	name = r.names.assign(name)
+	r.template.Parameters[name] = cfnParameter{
+		Type:        "String",
+		Description: description,
+		NoEcho:      noEcho,
+	}
+	return map[string]interface{}{"Ref": name}
+}
+
+// renderToken renders the value of token, tokens that reference resources which aren't exported become parameters.
+func (r *cfnRenderer) renderToken(token core.StringToken) interface{} {
+	fieldToken, ok := token.(*core.ResourceFieldStringToken)
+	if !ok {
+		value, _ := token.Resolve(context.Background())
+		return value
+	}
+	res := fieldToken.Dependencies()[0]
+	if lbARN, ok := r.resources.existingLBARNByID[res.ID()]; ok && res.Type() == "AWS::ElasticLoadBalancingV2::LoadBalancer" {
+		return lbARN
+	}
+	name, exported := r.names.lookupResource(res)
+	if exported {
+		switch fieldToken.FieldPath() {
+		case "status/loadBalancerARN", "status/targetGroupARN", "status/listenerARN":
+			return map[string]interface{}{"Ref": name}
+		case "status/dnsName":
+			return map[string]interface{}{"Fn::GetAtt": []string{name, "DNSName"}}
+		case "status/groupID":
+			return map[string]interface{}{"Fn::GetAtt": []string{name, "GroupId"}}
+		}
+	}
+	fieldName := fieldToken.FieldPath()[strings.LastIndex(fieldToken.FieldPath(), "/")+1:]
+	return r.addParameter(resourceKind(res)+pascalCaseName(res.ID())+pascalCaseName(fieldName),
+		fmt.Sprintf("The %v of %v %v", fieldName, res.Type(), res.ID()), false)
+}
+
+func (r *cfnRenderer) renderTags(tags map[string]string) []interface{} {
+	if len(tags) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	cfnTags := make([]interface{}, 0, len(tags))
+	for _, key := range keys {
+		cfnTags = append(cfnTags, map[string]interface{}{"Key": key, "Value": tags[key]})
+	}
+	return cfnTags
+}
+
+func (r *cfnRenderer) renderSecurityGroup(sg *ec2model.SecurityGroup) {
+	var ingress []interface{}
+	for _, permission := range sg.Spec.Ingress {
+		base := map[string]interface{}{"IpProtocol": permission.IPProtocol}
+		if permission.ICMPTypeCode != nil {
+			base["FromPort"] = permission.ICMPTypeCode.Type
+			base["ToPort"] = permission.ICMPTypeCode.Code
+		} else {
+			if permission.FromPort != nil {
+				base["FromPort"] = *permission.FromPort
+			}
+			if permission.ToPort != nil {
+				base["ToPort"] = *permission.ToPort
+			}
+		}
+		withSource := func(sourceKey string, source string, description string) map[string]interface{} {
+			rule := make(map[string]interface{}, len(base)+2)
+			for k, v := range base {
+				rule[k] = v
+			}
+			rule[sourceKey] = source
+			if description != "" {
+				rule["Description"] = description
+			}
+			return rule
+		}
+		for _, ipRange := range permission.IPRanges {
+			ingress = append(ingress, withSource("CidrIp", ipRange.CIDRIP, ipRange.Description))
+		}
+		for _, ipv6Range := range permission.IPv6Range {
+			ingress = append(ingress, withSource("CidrIpv6", ipv6Range.CIDRIPv6, ipv6Range.Description))
+		}
+		for _, groupPair := range permission.UserIDGroupPairs {
+			ingress = append(ingress, withSource("SourceSecurityGroupId", groupPair.GroupID, groupPair.Description))
+		}
+		for _, prefixList := range permission.PrefixLists {
+			ingress = append(ingress, withSource("SourcePrefixListId", prefixList.PrefixListID, prefixList.Description))
+		}
+	}
+	properties := map[string]interface{}{
+		"GroupName":        sg.Spec.GroupName,
+		"GroupDescription": sg.Spec.Description,
+		"VpcId":            map[string]interface{}{"Ref": cfnParameterVPCID},
+	}
+	if len(ingress) > 0 {
+		properties["SecurityGroupIngress"] = ingress
+	}
+	if tags := r.renderTags(sg.Spec.Tags); tags != nil {
+		properties["Tags"] = tags
+	}
+	r.addResource(sg, properties)
+}
+
+func (r *cfnRenderer) renderTargetGroup(tg *elbv2model.TargetGroup) {
+	properties := map[string]interface{}{
+		"Name":       tg.Spec.Name,
+		"TargetType": string(tg.Spec.TargetType),
+		"Port":       tg.Spec.Port,
+		"Protocol":   string(tg.Spec.Protocol),
+		"VpcId":      map[string]interface{}{"Ref": cfnParameterVPCID},
+	}
+	if tg.Spec.IPAddressType != nil {
+		properties["IpAddressType"] = string(*tg.Spec.IPAddressType)
+	}
+	if tg.Spec.ProtocolVersion != nil {
+		properties["ProtocolVersion"] = string(*tg.Spec.ProtocolVersion)
+	}
+	if hc := tg.Spec.HealthCheckConfig; hc != nil {
+		properties["HealthCheckEnabled"] = true
+		if hc.Port != nil {
+			properties["HealthCheckPort"] = hc.Port.String()
+		}
+		if hc.Protocol != nil {
+			properties["HealthCheckProtocol"] = string(*hc.Protocol)
+		}
+		if hc.Path != nil {
+			properties["HealthCheckPath"] = *hc.Path
+		}
+		if hc.Matcher != nil {
+			matcher := make(map[string]interface{})
+			if hc.Matcher.HTTPCode != nil {
+				matcher["HttpCode"] = *hc.Matcher.HTTPCode
+			}
+			if hc.Matcher.GRPCCode != nil {
+				matcher["GrpcCode"] = *hc.Matcher.GRPCCode
+			}
+			properties["Matcher"] = matcher
+		}
+		if hc.IntervalSeconds != nil {
+			properties["HealthCheckIntervalSeconds"] = *hc.IntervalSeconds
+		}
+		if hc.TimeoutSeconds != nil {
+			properties["HealthCheckTimeoutSeconds"] = *hc.TimeoutSeconds
+		}
+		if hc.HealthyThresholdCount != nil {
+			properties["HealthyThresholdCount"] = *hc.HealthyThresholdCount
+		}
+		if hc.UnhealthyThresholdCount != nil {
+			properties["UnhealthyThresholdCount"] = *hc.UnhealthyThresholdCount
+		}
+	}
+	if len(tg.Spec.TargetGroupAttributes) > 0 {
+		var attributes []interface{}
+		for _, attr := range tg.Spec.TargetGroupAttributes {
+			attributes = append(attributes, map[string]interface{}{"Key": attr.Key, "Value": attr.Value})
+		}
+		properties["TargetGroupAttributes"] = attributes
+	}
+	if tags := r.renderTags(tg.Spec.Tags); tags != nil {
+		properties["Tags"] = tags
+	}
+	r.addResource(tg, properties)
+}
+
+func (r *cfnRenderer) renderLoadBalancer(lb *elbv2model.LoadBalancer) {
+	properties := map[string]interface{}{
+		"Name": lb.Spec.Name,
+		"Type": string(lb.Spec.Type),
+	}
+	if lb.Spec.Scheme != nil {
+		properties["Scheme"] = string(*lb.Spec.Scheme)
+	}
+	if lb.Spec.IPAddressType != nil {
+		properties["IpAddressType"] = string(*lb.Spec.IPAddressType)
+	}
+	if len(lb.Spec.SubnetMappings) > 0 {
+		var subnetMappings []interface{}
+		for _, mapping := range lb.Spec.SubnetMappings {
+			subnetMapping := map[string]interface{}{"SubnetId": mapping.SubnetID}
+			if mapping.AllocationID != nil {
+				subnetMapping["AllocationId"] = r.renderToken(mapping.AllocationID)
+			}
+			if mapping.PrivateIPv4Address != nil {
+				subnetMapping["PrivateIPv4Address"] = r.renderToken(mapping.PrivateIPv4Address)
+			}
+			subnetMappings = append(subnetMappings, subnetMapping)
+		}
+		properties["SubnetMappings"] = subnetMappings
+	}
+	if len(lb.Spec.SecurityGroups) > 0 {
+		var securityGroups []interface{}
+		for _, sgToken := range lb.Spec.SecurityGroups {
+			securityGroups = append(securityGroups, r.renderToken(sgToken))
+		}
+		properties["SecurityGroups"] = securityGroups
+	}
+	if len(lb.Spec.LoadBalancerAttributes) > 0 {
+		var attributes []interface{}
+		for _, attr := range lb.Spec.LoadBalancerAttributes {
+			attributes = append(attributes, map[string]interface{}{"Key": attr.Key, "Value": attr.Value})
+		}
+		properties["LoadBalancerAttributes"] = attributes
+	}
+	if tags := r.renderTags(lb.Spec.Tags); tags != nil {
+		properties["Tags"] = tags
+	}
+	r.addResource(lb, properties)
+}
+
+func (r *cfnRenderer) renderListener(ls *elbv2model.Listener) {
+	properties := map[string]interface{}{
+		"LoadBalancerArn": r.renderToken(ls.Spec.LoadBalancerARN),
+		"Port":            ls.Spec.Port,
+		"Protocol":        string(ls.Spec.Protocol),
+		"DefaultActions":  r.renderActions(ls, ls.Spec.DefaultActions),
+	}
+	if ls.Spec.SSLPolicy != nil {
+		properties["SslPolicy"] = *ls.Spec.SSLPolicy
+	}
+	if len(ls.Spec.ALPNPolicy) > 0 {
+		properties["AlpnPolicy"] = ls.Spec.ALPNPolicy
+	}
+	name, _ := r.names.lookupResource(ls)
+	var extraCerts []interface{}
+	for i, cert := range ls.Spec.Certificates {
+		if cert.CertificateARN == nil {
+			continue
+		}
+		// the default certificate is configured on listener, and the other certificates are added via ListenerCertificate.
+		if i == 0 {
+			properties["Certificates"] = []interface{}{map[string]interface{}{"CertificateArn": *cert.CertificateARN}}
+			continue
+		}
+		extraCerts = append(extraCerts, map[string]interface{}{"CertificateArn": *cert.CertificateARN})
+	}
+	r.addResource(ls, properties)
+	if len(extraCerts) > 0 {
+		r.template.Resources[r.names.assign(name+"Certificates")] = cfnResource{
+			Type: "AWS::ElasticLoadBalancingV2::ListenerCertificate",
+			Properties: map[string]interface{}{
+				"ListenerArn":  map[string]interface{}{"Ref": name},
+				"Certificates": extraCerts,
+			},
+		}
+	}
+}
+
+func (r *cfnRenderer) renderListenerRule(lr *elbv2model.ListenerRule) {
+	var conditions []interface{}
+	for _, condition := range lr.Spec.Conditions {
+		conditions = append(conditions, r.renderRuleCondition(condition))
+	}
+	r.addResource(lr, map[string]interface{}{
+		"ListenerArn": r.renderToken(lr.Spec.ListenerARN),
+		"Priority":    lr.Spec.Priority,
+		"Actions":     r.renderActions(lr, lr.Spec.Actions),
+		"Conditions":  conditions,
+	})
+}
+
+func (r *cfnRenderer) renderRuleCondition(condition elbv2model.RuleCondition) map[string]interface{} {
+	cfnCondition := map[string]interface{}{"Field": string(condition.Field)}
+	switch {
+	case condition.HostHeaderConfig != nil:
+		cfnCondition["HostHeaderConfig"] = map[string]interface{}{"Values": condition.HostHeaderConfig.Values}
+	case condition.HTTPHeaderConfig != nil:
+		cfnCondition["HttpHeaderConfig"] = map[string]interface{}{
+			"HttpHeaderName": condition.HTTPHeaderConfig.HTTPHeaderName,
+			"Values":         condition.HTTPHeaderConfig.Values,
+		}
+	case condition.HTTPRequestMethodConfig != nil:
+		cfnCondition["HttpRequestMethodConfig"] = map[string]interface{}{"Values": condition.HTTPRequestMethodConfig.Values}
+	case condition.PathPatternConfig != nil:
+		cfnCondition["PathPatternConfig"] = map[string]interface{}{"Values": condition.PathPatternConfig.Values}
+	case condition.QueryStringConfig != nil:
+		var values []interface{}
+		for _, pair := range condition.QueryStringConfig.Values {
+			value := map[string]interface{}{"Value": pair.Value}
+			if pair.Key != nil {
+				value["Key"] = *pair.Key
+			}
+			values = append(values, value)
+		}
+		cfnCondition["QueryStringConfig"] = map[string]interface{}{"Values": values}
+	case condition.SourceIPConfig != nil:
+		cfnCondition["SourceIpConfig"] = map[string]interface{}{"Values": condition.SourceIPConfig.Values}
+	}
+	return cfnCondition
+}
+
+// renderActions renders actions of listener or listenerRule res, credentials of OIDC IdP become parameters.
+func (r *cfnRenderer) renderActions(res core.Resource, actions []elbv2model.Action) []interface{} {
+	cfnActions := make([]interface{}, 0, len(actions))
+	for i, action := range actions {
+		cfnAction := map[string]interface{}{
+			"Type":  string(action.Type),
+			"Order": i + 1,
+		}
+		if cfg := action.AuthenticateCognitoConfig; cfg != nil {
+			cognitoConfig := map[string]interface{}{
+				"UserPoolArn":      cfg.UserPoolARN,
+				"UserPoolClientId": cfg.UserPoolClientID,
+				"UserPoolDomain":   cfg.UserPoolDomain,
+			}
+			r.renderAuthenticateOptions(cognitoConfig, cfg.AuthenticationRequestExtraParams, (*string)(cfg.OnUnauthenticatedRequest),
+				cfg.Scope, cfg.SessionCookieName, cfg.SessionTimeout)
+			cfnAction["AuthenticateCognitoConfig"] = cognitoConfig
+		}
+		if cfg := action.AuthenticateOIDCConfig; cfg != nil {
+			name, _ := r.names.lookupResource(res)
+			oidcConfig := map[string]interface{}{
+				"Issuer":                cfg.Issuer,
+				"AuthorizationEndpoint": cfg.AuthorizationEndpoint,
+				"TokenEndpoint":         cfg.TokenEndpoint,
+				"UserInfoEndpoint":      cfg.UserInfoEndpoint,
+				"ClientId":              r.addParameter(name+"OidcClientId", fmt.Sprintf("The OIDC client ID of %v", res.ID()), false),
+				"ClientSecret":          r.addParameter(name+"OidcClientSecret", fmt.Sprintf("The OIDC client secret of %v", res.ID()), true),
+			}
+			r.renderAuthenticateOptions(oidcConfig, cfg.AuthenticationRequestExtraParams, (*string)(cfg.OnUnauthenticatedRequest),
+				cfg.Scope, cfg.SessionCookieName, cfg.SessionTimeout)
+			cfnAction["AuthenticateOidcConfig"] = oidcConfig
+		}
+		if cfg := action.FixedResponseConfig; cfg != nil {
+			fixedResponseConfig := map[string]interface{}{"StatusCode": cfg.StatusCode}
+			if cfg.ContentType != nil {
+				fixedResponseConfig["ContentType"] = *cfg.ContentType
+			}
+			if cfg.MessageBody != nil {
+				fixedResponseConfig["MessageBody"] = *cfg.MessageBody
+			}
+			cfnAction["FixedResponseConfig"] = fixedResponseConfig
+		}
+		if cfg := action.RedirectConfig; cfg != nil {
+			redirectConfig := map[string]interface{}{"StatusCode": cfg.StatusCode}
+			for key, value := range map[string]*string{"Host": cfg.Host, "Path": cfg.Path, "Port": cfg.Port, "Protocol": cfg.Protocol, "Query": cfg.Query} {
+				if value != nil {
+					redirectConfig[key] = *value
+				}
+			}
+			cfnAction["RedirectConfig"] = redirectConfig
+		}
+		if cfg := action.ForwardConfig; cfg != nil {
+			var targetGroups []interface{}
+			for _, tgTuple := range cfg.TargetGroups {
+				targetGroup := map[string]interface{}{"TargetGroupArn": r.renderToken(tgTuple.TargetGroupARN)}
+				if tgTuple.Weight != nil {
+					targetGroup["Weight"] = *tgTuple.Weight
+				}
+				targetGroups = append(targetGroups, targetGroup)
+			}
+			forwardConfig := map[string]interface{}{"TargetGroups": targetGroups}
+			if stickiness := cfg.TargetGroupStickinessConfig; stickiness != nil {
+				stickinessConfig := make(map[string]interface{})
+				if stickiness.Enabled != nil {
+					stickinessConfig["Enabled"] = *stickiness.Enabled
+				}
+				if stickiness.DurationSeconds != nil {
+					stickinessConfig["DurationSeconds"] = *stickiness.DurationSeconds
+				}
+				forwardConfig["TargetGroupStickinessConfig"] = stickinessConfig
+			}
+			cfnAction["ForwardConfig"] = forwardConfig
+		}
+		cfnActions = append(cfnActions, cfnAction)
+	}
+	return cfnActions
+}
+
+func (r *cfnRenderer) renderAuthenticateOptions(cfnConfig map[string]interface{}, extraParams map[string]string,
+	onUnauthenticatedRequest *string, scope *string, sessionCookieName *string, sessionTimeout *int64) {
+	if len(extraParams) > 0 {
+		cfnConfig["AuthenticationRequestExtraParams"] = extraParams
+	}
+	if onUnauthenticatedRequest != nil {
+		cfnConfig["OnUnauthenticatedRequest"] = *onUnauthenticatedRequest
+	}
+	if scope != nil {
+		cfnConfig["Scope"] = *scope
+	}
+	if sessionCookieName != nil {
+		cfnConfig["SessionCookieName"] = *sessionCookieName
+	}
+	if sessionTimeout != nil {
+		cfnConfig["SessionTimeout"] = *sessionTimeout
+	}
+}
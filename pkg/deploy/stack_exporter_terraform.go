@@ -0,0 +1,606 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const tfVariableVPCID = "vpc_id"
+
+var (
+	// tfLoadBalancerAttributeArguments maps LoadBalancer attributes into arguments of aws_lb.
+	tfLoadBalancerAttributeArguments = map[string]string{
+		"idle_timeout.timeout_seconds":                    "idle_timeout",
+		"deletion_protection.enabled":                     "enable_deletion_protection",
+		"routing.http2.enabled":                           "enable_http2",
+		"load_balancing.cross_zone.enabled":               "enable_cross_zone_load_balancing",
+		"routing.http.drop_invalid_header_fields.enabled": "drop_invalid_header_fields",
+		"routing.http.desync_mitigation_mode":             "desync_mitigation_mode",
+		"client_keep_alive.seconds":                       "client_keep_alive",
+		"dns_record.client_routing_policy":                "dns_record_client_routing_policy",
+	}
+	// tfLoadBalancerAccessLogsAttributeArguments maps LoadBalancer attributes into arguments of the access_logs block of aws_lb.
+	tfLoadBalancerAccessLogsAttributeArguments = map[string]string{
+		"access_logs.s3.enabled": "enabled",
+		"access_logs.s3.bucket":  "bucket",
+		"access_logs.s3.prefix":  "prefix",
+	}
+	// tfTargetGroupAttributeArguments maps TargetGroup attributes into arguments of aws_lb_target_group.
+	tfTargetGroupAttributeArguments = map[string]string{
+		"deregistration_delay.timeout_seconds":                "deregistration_delay",
+		"deregistration_delay.connection_termination.enabled": "connection_termination",
+		"slow_start.duration_seconds":                         "slow_start",
+		"load_balancing.algorithm.type":                       "load_balancing_algorithm_type",
+		"load_balancing.cross_zone.enabled":                   "load_balancing_cross_zone_enabled",
+		"preserve_client_ip.enabled":                          "preserve_client_ip",
+		"proxy_protocol_v2.enabled":                           "proxy_protocol_v2",
+	}
+)
+
+// tfRenderer renders exported resources as Terraform configuration.
+type tfRenderer struct {
+	resources exportedResources
+	names     *exportNameRegistry
+	variables *hclWriter
+	body      *hclWriter
+}
+
+func renderTerraform(stackID core.StackID, vpcID string, resources exportedResources) (string, error) {
+	r := &tfRenderer{
+		resources: resources,
+		names:     newExportNameRegistry(),
+		variables: &hclWriter{},
+		body:      &hclWriter{},
+	}
+	r.names.assign(tfVariableVPCID)
+	r.variables.openBlock(fmt.Sprintf("variable %q", tfVariableVPCID))
+	r.variables.attr("description", hclString("The VPC of TargetGroups and SecurityGroups"))
+	r.variables.attr("type", "string")
+	r.variables.attr("default", hclString(vpcID))
+	r.variables.closeBlock()
+	for _, res := range resources.all() {
+		name := snakeCaseName(res.ID())
+		if name == "" || unicode.IsDigit([]rune(name)[0]) {
+			name = strings.TrimSuffix(snakeCaseName(resourceKind(res))+"_"+name, "_")
+		}
+		r.names.assignResource(res, name)
+	}
+	for _, sg := range resources.securityGroups {
+		r.renderSecurityGroup(sg)
+	}
+	for _, tg := range resources.targetGroups {
+		r.renderTargetGroup(tg)
+	}
+	for _, lb := range resources.loadBalancers {
+		r.renderLoadBalancer(lb)
+	}
+	for _, ls := range resources.listeners {
+		r.renderListener(ls)
+	}
+	for _, lr := range resources.listenerRules {
+		r.renderListenerRule(lr)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# AWS resources exported from stack %v of aws-load-balancer-controller.\n\n", stackID))
+	sb.WriteString(r.variables.String())
+	sb.WriteString(r.body.String())
+	return sb.String(), nil
+}
+
+// addVariable adds a variable for values that cannot be exported, and returns the reference to it.
+func (r *tfRenderer) addVariable(name string, description string, sensitive bool) string {
+	name = r.names.assign(name)
+	r.variables.openBlock(fmt.Sprintf("variable %q", name))
+	r.variables.attr("description", hclString(description))
+	r.variables.attr("type", "string")
+	if sensitive {
+		r.variables.attr("sensitive", "true")
+	}
+	r.variables.closeBlock()
+	return "var." + name
+}
+
+func (r *tfRenderer) resourceAddress(res core.Resource) string {
+	name, _ := r.names.lookupResource(res)
+	return fmt.Sprintf("%v.%v", tfResourceType(res), name)
+}
+
+// renderToken renders the value of token, tokens that reference resources which aren't exported become variables.
+func (r *tfRenderer) renderToken(token core.StringToken) string {
+	fieldToken, ok := token.(*core.ResourceFieldStringToken)
+	if !ok {
+		value, _ := token.Resolve(context.Background())
+		return hclString(value)
+	}
+	res := fieldToken.Dependencies()[0]
+	if lbARN, ok := r.resources.existingLBARNByID[res.ID()]; ok && res.Type() == "AWS::ElasticLoadBalancingV2::LoadBalancer" {
+		return hclString(lbARN)
+	}
+	if _, exported := r.names.lookupResource(res); exported {
+		switch fieldToken.FieldPath() {
+		case "status/loadBalancerARN", "status/targetGroupARN", "status/listenerARN":
+			return r.resourceAddress(res) + ".arn"
+		case "status/dnsName":
+			return r.resourceAddress(res) + ".dns_name"
+		case "status/groupID":
+			return r.resourceAddress(res) + ".id"
+		}
+	}
+	fieldName := fieldToken.FieldPath()[strings.LastIndex(fieldToken.FieldPath(), "/")+1:]
+	return r.addVariable(snakeCaseName(resourceKind(res))+"_"+snakeCaseName(res.ID())+"_"+snakeCaseName(fieldName),
+		fmt.Sprintf("The %v of %v %v", fieldName, res.Type(), res.ID()), false)
+}
+
+func (r *tfRenderer) openResource(res core.Resource) {
+	name, _ := r.names.lookupResource(res)
+	r.body.openBlock(fmt.Sprintf("resource %q %q", tfResourceType(res), name))
+}
+
+func (r *tfRenderer) renderSecurityGroup(sg *ec2model.SecurityGroup) {
+	r.openResource(sg)
+	r.body.attr("name", hclString(sg.Spec.GroupName))
+	r.body.attr("description", hclString(sg.Spec.Description))
+	r.body.attr("vpc_id", "var."+tfVariableVPCID)
+	for _, permission := range sg.Spec.Ingress {
+		var fromPort, toPort int64
+		if permission.ICMPTypeCode != nil {
+			fromPort, toPort = permission.ICMPTypeCode.Type, permission.ICMPTypeCode.Code
+		} else {
+			if permission.FromPort != nil {
+				fromPort = *permission.FromPort
+			}
+			if permission.ToPort != nil {
+				toPort = *permission.ToPort
+			}
+		}
+		renderIngress := func(sourceArg string, source string, description string) {
+			r.body.openBlock("ingress")
+			r.body.attr("protocol", hclString(permission.IPProtocol))
+			r.body.attr("from_port", strconv.FormatInt(fromPort, 10))
+			r.body.attr("to_port", strconv.FormatInt(toPort, 10))
+			r.body.attr(sourceArg, hclStringList([]string{source}))
+			if description != "" {
+				r.body.attr("description", hclString(description))
+			}
+			r.body.closeBlock()
+		}
+		for _, ipRange := range permission.IPRanges {
+			renderIngress("cidr_blocks", ipRange.CIDRIP, ipRange.Description)
+		}
+		for _, ipv6Range := range permission.IPv6Range {
+			renderIngress("ipv6_cidr_blocks", ipv6Range.CIDRIPv6, ipv6Range.Description)
+		}
+		for _, groupPair := range permission.UserIDGroupPairs {
+			renderIngress("security_groups", groupPair.GroupID, groupPair.Description)
+		}
+		for _, prefixList := range permission.PrefixLists {
+			renderIngress("prefix_list_ids", prefixList.PrefixListID, prefixList.Description)
+		}
+	}
+	r.renderTags(sg.Spec.Tags)
+	r.body.closeBlock()
+}
+
+func (r *tfRenderer) renderTargetGroup(tg *elbv2model.TargetGroup) {
+	r.openResource(tg)
+	r.body.attr("name", hclString(tg.Spec.Name))
+	r.body.attr("target_type", hclString(string(tg.Spec.TargetType)))
+	r.body.attr("port", strconv.FormatInt(tg.Spec.Port, 10))
+	r.body.attr("protocol", hclString(string(tg.Spec.Protocol)))
+	r.body.attr("vpc_id", "var."+tfVariableVPCID)
+	if tg.Spec.IPAddressType != nil {
+		r.body.attr("ip_address_type", hclString(string(*tg.Spec.IPAddressType)))
+	}
+	if tg.Spec.ProtocolVersion != nil {
+		r.body.attr("protocol_version", hclString(string(*tg.Spec.ProtocolVersion)))
+	}
+	stickinessAttrs := make(map[string]string)
+	var unsupportedAttrs [][2]string
+	for _, attr := range tg.Spec.TargetGroupAttributes {
+		if arg, ok := tfTargetGroupAttributeArguments[attr.Key]; ok {
+			r.body.attr(arg, hclScalar(attr.Value))
+		} else if strings.HasPrefix(attr.Key, "stickiness.") {
+			stickinessAttrs[attr.Key] = attr.Value
+		} else {
+			unsupportedAttrs = append(unsupportedAttrs, [2]string{attr.Key, attr.Value})
+		}
+	}
+	if hc := tg.Spec.HealthCheckConfig; hc != nil {
+		r.body.openBlock("health_check")
+		r.body.attr("enabled", "true")
+		if hc.Port != nil {
+			r.body.attr("port", hclString(hc.Port.String()))
+		}
+		if hc.Protocol != nil {
+			r.body.attr("protocol", hclString(string(*hc.Protocol)))
+		}
+		if hc.Path != nil {
+			r.body.attr("path", hclString(*hc.Path))
+		}
+		if hc.Matcher != nil && hc.Matcher.HTTPCode != nil {
+			r.body.attr("matcher", hclString(*hc.Matcher.HTTPCode))
+		} else if hc.Matcher != nil && hc.Matcher.GRPCCode != nil {
+			r.body.attr("matcher", hclString(*hc.Matcher.GRPCCode))
+		}
+		if hc.IntervalSeconds != nil {
+			r.body.attr("interval", strconv.FormatInt(*hc.IntervalSeconds, 10))
+		}
+		if hc.TimeoutSeconds != nil {
+			r.body.attr("timeout", strconv.FormatInt(*hc.TimeoutSeconds, 10))
+		}
+		if hc.HealthyThresholdCount != nil {
+			r.body.attr("healthy_threshold", strconv.FormatInt(*hc.HealthyThresholdCount, 10))
+		}
+		if hc.UnhealthyThresholdCount != nil {
+			r.body.attr("unhealthy_threshold", strconv.FormatInt(*hc.UnhealthyThresholdCount, 10))
+		}
+		r.body.closeBlock()
+	}
+	if len(stickinessAttrs) > 0 {
+		stickinessType := stickinessAttrs["stickiness.type"]
+		r.body.openBlock("stickiness")
+		if enabled, ok := stickinessAttrs["stickiness.enabled"]; ok {
+			r.body.attr("enabled", hclScalar(enabled))
+		}
+		if stickinessType != "" {
+			r.body.attr("type", hclString(stickinessType))
+		}
+		if duration, ok := stickinessAttrs[fmt.Sprintf("stickiness.%v.duration_seconds", stickinessType)]; ok {
+			r.body.attr("cookie_duration", hclScalar(duration))
+		}
+		if cookieName, ok := stickinessAttrs["stickiness.app_cookie.cookie_name"]; ok {
+			r.body.attr("cookie_name", hclString(cookieName))
+		}
+		r.body.closeBlock()
+	}
+	r.renderUnsupportedAttributes(unsupportedAttrs)
+	r.renderTags(tg.Spec.Tags)
+	r.body.closeBlock()
+}
+
+func (r *tfRenderer) renderLoadBalancer(lb *elbv2model.LoadBalancer) {
+	r.openResource(lb)
+	r.body.attr("name", hclString(lb.Spec.Name))
+	r.body.attr("load_balancer_type", hclString(string(lb.Spec.Type)))
+	if lb.Spec.Scheme != nil {
+		r.body.attr("internal", strconv.FormatBool(*lb.Spec.Scheme == elbv2model.LoadBalancerSchemeInternal))
+	}
+	if lb.Spec.IPAddressType != nil {
+		r.body.attr("ip_address_type", hclString(string(*lb.Spec.IPAddressType)))
+	}
+	if lb.Spec.CustomerOwnedIPv4Pool != nil {
+		r.body.attr("customer_owned_ipv4_pool", hclString(*lb.Spec.CustomerOwnedIPv4Pool))
+	}
+	if len(lb.Spec.SecurityGroups) > 0 {
+		var securityGroups []string
+		for _, sgToken := range lb.Spec.SecurityGroups {
+			securityGroups = append(securityGroups, r.renderToken(sgToken))
+		}
+		r.body.attr("security_groups", "["+strings.Join(securityGroups, ", ")+"]")
+	}
+	accessLogsAttrs := make(map[string]string)
+	var unsupportedAttrs [][2]string
+	for _, attr := range lb.Spec.LoadBalancerAttributes {
+		if arg, ok := tfLoadBalancerAttributeArguments[attr.Key]; ok {
+			r.body.attr(arg, hclScalar(attr.Value))
+		} else if arg, ok := tfLoadBalancerAccessLogsAttributeArguments[attr.Key]; ok {
+			accessLogsAttrs[arg] = attr.Value
+		} else {
+			unsupportedAttrs = append(unsupportedAttrs, [2]string{attr.Key, attr.Value})
+		}
+	}
+	for _, mapping := range lb.Spec.SubnetMappings {
+		r.body.openBlock("subnet_mapping")
+		r.body.attr("subnet_id", hclString(mapping.SubnetID))
+		if mapping.AllocationID != nil {
+			r.body.attr("allocation_id", r.renderToken(mapping.AllocationID))
+		}
+		if mapping.PrivateIPv4Address != nil {
+			r.body.attr("private_ipv4_address", r.renderToken(mapping.PrivateIPv4Address))
+		}
+		r.body.closeBlock()
+	}
+	if len(accessLogsAttrs) > 0 {
+		r.body.openBlock("access_logs")
+		for _, arg := range []string{"enabled", "bucket", "prefix"} {
+			if value, ok := accessLogsAttrs[arg]; ok {
+				r.body.attr(arg, hclScalar(value))
+			}
+		}
+		r.body.closeBlock()
+	}
+	r.renderUnsupportedAttributes(unsupportedAttrs)
+	r.renderTags(lb.Spec.Tags)
+	r.body.closeBlock()
+}
+
+func (r *tfRenderer) renderListener(ls *elbv2model.Listener) {
+	r.openResource(ls)
+	r.body.attr("load_balancer_arn", r.renderToken(ls.Spec.LoadBalancerARN))
+	r.body.attr("port", strconv.FormatInt(ls.Spec.Port, 10))
+	r.body.attr("protocol", hclString(string(ls.Spec.Protocol)))
+	if ls.Spec.SSLPolicy != nil {
+		r.body.attr("ssl_policy", hclString(*ls.Spec.SSLPolicy))
+	}
+	if len(ls.Spec.ALPNPolicy) > 0 {
+		r.body.attr("alpn_policy", hclString(ls.Spec.ALPNPolicy[0]))
+	}
+	var extraCertARNs []string
+	for i, cert := range ls.Spec.Certificates {
+		if cert.CertificateARN == nil {
+			continue
+		}
+		// the default certificate is configured on listener, and the other certificates are added via aws_lb_listener_certificate.
+		if i == 0 {
+			r.body.attr("certificate_arn", hclString(*cert.CertificateARN))
+			continue
+		}
+		extraCertARNs = append(extraCertARNs, *cert.CertificateARN)
+	}
+	r.renderActions(ls, "default_action", ls.Spec.DefaultActions)
+	r.body.closeBlock()
+
+	name, _ := r.names.lookupResource(ls)
+	for _, certARN := range extraCertARNs {
+		r.body.openBlock(fmt.Sprintf("resource %q %q", "aws_lb_listener_certificate", r.names.assign(name+"_certificate")))
+		r.body.attr("listener_arn", r.resourceAddress(ls)+".arn")
+		r.body.attr("certificate_arn", hclString(certARN))
+		r.body.closeBlock()
+	}
+}
+
+func (r *tfRenderer) renderListenerRule(lr *elbv2model.ListenerRule) {
+	r.openResource(lr)
+	r.body.attr("listener_arn", r.renderToken(lr.Spec.ListenerARN))
+	r.body.attr("priority", strconv.FormatInt(lr.Spec.Priority, 10))
+	r.renderActions(lr, "action", lr.Spec.Actions)
+	for _, condition := range lr.Spec.Conditions {
+		r.body.openBlock("condition")
+		switch {
+		case condition.HostHeaderConfig != nil:
+			r.body.openBlock("host_header")
+			r.body.attr("values", hclStringList(condition.HostHeaderConfig.Values))
+			r.body.closeBlock()
+		case condition.HTTPHeaderConfig != nil:
+			r.body.openBlock("http_header")
+			r.body.attr("http_header_name", hclString(condition.HTTPHeaderConfig.HTTPHeaderName))
+			r.body.attr("values", hclStringList(condition.HTTPHeaderConfig.Values))
+			r.body.closeBlock()
+		case condition.HTTPRequestMethodConfig != nil:
+			r.body.openBlock("http_request_method")
+			r.body.attr("values", hclStringList(condition.HTTPRequestMethodConfig.Values))
+			r.body.closeBlock()
+		case condition.PathPatternConfig != nil:
+			r.body.openBlock("path_pattern")
+			r.body.attr("values", hclStringList(condition.PathPatternConfig.Values))
+			r.body.closeBlock()
+		case condition.QueryStringConfig != nil:
+			for _, pair := range condition.QueryStringConfig.Values {
+				r.body.openBlock("query_string")
+				if pair.Key != nil {
+					r.body.attr("key", hclString(*pair.Key))
+				}
+				r.body.attr("value", hclString(pair.Value))
+				r.body.closeBlock()
+			}
+		case condition.SourceIPConfig != nil:
+			r.body.openBlock("source_ip")
+			r.body.attr("values", hclStringList(condition.SourceIPConfig.Values))
+			r.body.closeBlock()
+		}
+		r.body.closeBlock()
+	}
+	r.body.closeBlock()
+}
+
+// renderActions renders actions of listener or listenerRule res as blockType blocks, credentials of OIDC IdP become variables.
+func (r *tfRenderer) renderActions(res core.Resource, blockType string, actions []elbv2model.Action) {
+	for i, action := range actions {
+		r.body.openBlock(blockType)
+		r.body.attr("type", hclString(string(action.Type)))
+		r.body.attr("order", strconv.Itoa(i+1))
+		if cfg := action.AuthenticateCognitoConfig; cfg != nil {
+			r.body.openBlock("authenticate_cognito")
+			r.body.attr("user_pool_arn", hclString(cfg.UserPoolARN))
+			r.body.attr("user_pool_client_id", hclString(cfg.UserPoolClientID))
+			r.body.attr("user_pool_domain", hclString(cfg.UserPoolDomain))
+			r.renderAuthenticateOptions(cfg.AuthenticationRequestExtraParams, (*string)(cfg.OnUnauthenticatedRequest),
+				cfg.Scope, cfg.SessionCookieName, cfg.SessionTimeout)
+			r.body.closeBlock()
+		}
+		if cfg := action.AuthenticateOIDCConfig; cfg != nil {
+			name, _ := r.names.lookupResource(res)
+			r.body.openBlock("authenticate_oidc")
+			r.body.attr("issuer", hclString(cfg.Issuer))
+			r.body.attr("authorization_endpoint", hclString(cfg.AuthorizationEndpoint))
+			r.body.attr("token_endpoint", hclString(cfg.TokenEndpoint))
+			r.body.attr("user_info_endpoint", hclString(cfg.UserInfoEndpoint))
+			r.body.attr("client_id", r.addVariable(name+"_oidc_client_id", fmt.Sprintf("The OIDC client ID of %v", res.ID()), false))
+			r.body.attr("client_secret", r.addVariable(name+"_oidc_client_secret", fmt.Sprintf("The OIDC client secret of %v", res.ID()), true))
+			r.renderAuthenticateOptions(cfg.AuthenticationRequestExtraParams, (*string)(cfg.OnUnauthenticatedRequest),
+				cfg.Scope, cfg.SessionCookieName, cfg.SessionTimeout)
+			r.body.closeBlock()
+		}
+		if cfg := action.FixedResponseConfig; cfg != nil {
+			r.body.openBlock("fixed_response")
+			r.body.attr("status_code", hclString(cfg.StatusCode))
+			if cfg.ContentType != nil {
+				r.body.attr("content_type", hclString(*cfg.ContentType))
+			}
+			if cfg.MessageBody != nil {
+				r.body.attr("message_body", hclString(*cfg.MessageBody))
+			}
+			r.body.closeBlock()
+		}
+		if cfg := action.RedirectConfig; cfg != nil {
+			r.body.openBlock("redirect")
+			r.body.attr("status_code", hclString(cfg.StatusCode))
+			for _, arg := range []struct {
+				name  string
+				value *string
+			}{{"host", cfg.Host}, {"path", cfg.Path}, {"port", cfg.Port}, {"protocol", cfg.Protocol}, {"query", cfg.Query}} {
+				if arg.value != nil {
+					r.body.attr(arg.name, hclString(*arg.value))
+				}
+			}
+			r.body.closeBlock()
+		}
+		if cfg := action.ForwardConfig; cfg != nil {
+			r.body.openBlock("forward")
+			for _, tgTuple := range cfg.TargetGroups {
+				r.body.openBlock("target_group")
+				r.body.attr("arn", r.renderToken(tgTuple.TargetGroupARN))
+				if tgTuple.Weight != nil {
+					r.body.attr("weight", strconv.FormatInt(*tgTuple.Weight, 10))
+				}
+				r.body.closeBlock()
+			}
+			if stickiness := cfg.TargetGroupStickinessConfig; stickiness != nil {
+				r.body.openBlock("stickiness")
+				if stickiness.Enabled != nil {
+					r.body.attr("enabled", strconv.FormatBool(*stickiness.Enabled))
+				}
+				if stickiness.DurationSeconds != nil {
+					r.body.attr("duration", strconv.FormatInt(*stickiness.DurationSeconds, 10))
+				}
+				r.body.closeBlock()
+			}
+			r.body.closeBlock()
+		}
+		r.body.closeBlock()
+	}
+}
+
+func (r *tfRenderer) renderAuthenticateOptions(extraParams map[string]string, onUnauthenticatedRequest *string,
+	scope *string, sessionCookieName *string, sessionTimeout *int64) {
+	if len(extraParams) > 0 {
+		r.body.attr("authentication_request_extra_params", hclStringMap(extraParams))
+	}
+	if onUnauthenticatedRequest != nil {
+		r.body.attr("on_unauthenticated_request", hclString(*onUnauthenticatedRequest))
+	}
+	if scope != nil {
+		r.body.attr("scope", hclString(*scope))
+	}
+	if sessionCookieName != nil {
+		r.body.attr("session_cookie_name", hclString(*sessionCookieName))
+	}
+	if sessionTimeout != nil {
+		r.body.attr("session_timeout", strconv.FormatInt(*sessionTimeout, 10))
+	}
+}
+
+// renderUnsupportedAttributes renders attributes without corresponding arguments as comments, so that they can be configured manually.
+// attrs are key/value pairs of attributes.
+func (r *tfRenderer) renderUnsupportedAttributes(attrs [][2]string) {
+	for _, attr := range attrs {
+		r.body.comment(fmt.Sprintf("unsupported attribute: %v = %v", attr[0], attr[1]))
+	}
+}
+
+func (r *tfRenderer) renderTags(tags map[string]string) {
+	if len(tags) > 0 {
+		r.body.attr("tags", hclStringMap(tags))
+	}
+}
+
+// tfResourceType returns the terraform resource type of resource.
+func tfResourceType(res core.Resource) string {
+	switch res.(type) {
+	case *ec2model.SecurityGroup:
+		return "aws_security_group"
+	case *elbv2model.TargetGroup:
+		return "aws_lb_target_group"
+	case *elbv2model.LoadBalancer:
+		return "aws_lb"
+	case *elbv2model.Listener:
+		return "aws_lb_listener"
+	case *elbv2model.ListenerRule:
+		return "aws_lb_listener_rule"
+	}
+	return ""
+}
+
+// hclWriter writes HCL blocks and attributes with indentation.
+type hclWriter struct {
+	sb     strings.Builder
+	indent int
+}
+
+func (w *hclWriter) openBlock(header string) {
+	w.writeLine(header + " {")
+	w.indent++
+}
+
+func (w *hclWriter) closeBlock() {
+	w.indent--
+	w.writeLine("}")
+	if w.indent == 0 {
+		w.sb.WriteString("\n")
+	}
+}
+
+func (w *hclWriter) attr(name string, expr string) {
+	w.writeLine(fmt.Sprintf("%v = %v", name, expr))
+}
+
+func (w *hclWriter) comment(text string) {
+	w.writeLine("# " + text)
+}
+
+func (w *hclWriter) writeLine(line string) {
+	w.sb.WriteString(strings.Repeat("  ", w.indent))
+	w.sb.WriteString(line)
+	w.sb.WriteString("\n")
+}
+
+func (w *hclWriter) String() string {
+	return w.sb.String()
+}
+
+// hclString renders value as HCL string literal, template sequences are escaped.
+func hclString(value string) string {
+	value = strings.ReplaceAll(value, "${", "$${")
+	value = strings.ReplaceAll(value, "%{", "%%{")
+	return strconv.Quote(value)
+}
+
+// hclScalar renders value as HCL bool or number literal if possible, otherwise as string literal.
+func hclScalar(value string) string {
+	if value == "true" || value == "false" {
+		return value
+	}
+	if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return value
+	}
+	return hclString(value)
+}
+
+func hclStringList(values []string) string {
+	quotedValues := make([]string, 0, len(values))
+	for _, value := range values {
+		quotedValues = append(quotedValues, hclString(value))
+	}
+	return "[" + strings.Join(quotedValues, ", ") + "]"
+}
+
+func hclStringMap(values map[string]string) string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	entries := make([]string, 0, len(values))
+	for _, key := range keys {
+		entries = append(entries, fmt.Sprintf("%v = %v", hclString(key), hclString(values[key])))
+	}
+	return "{ " + strings.Join(entries, ", ") + " }"
+}
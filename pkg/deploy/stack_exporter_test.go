@@ -0,0 +1,324 @@
+package deploy
+
+import (
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"testing"
+)
+
+func buildStackForExport() core.Stack {
+	stack := core.NewDefaultStack(core.StackID{Namespace: "ns", Name: "ing"})
+	sg := ec2model.NewSecurityGroup(stack, "ManagedLBSecurityGroup", ec2model.SecurityGroupSpec{
+		GroupName:   "k8s-ns-ing-abcd",
+		Description: "[k8s] Managed SecurityGroup for LoadBalancer",
+		Ingress: []ec2model.IPPermission{
+			{
+				IPProtocol: "tcp",
+				FromPort:   awssdk.Int64(80),
+				ToPort:     awssdk.Int64(80),
+				IPRanges:   []ec2model.IPRange{{CIDRIP: "0.0.0.0/0"}},
+			},
+		},
+	})
+	tg := elbv2model.NewTargetGroup(stack, "ns/ing-svc:80", elbv2model.TargetGroupSpec{
+		Name:       "k8s-ns-svc-abcd",
+		TargetType: elbv2model.TargetTypeIP,
+		Port:       8080,
+		Protocol:   elbv2model.ProtocolHTTP,
+		HealthCheckConfig: &elbv2model.TargetGroupHealthCheckConfig{
+			Path: awssdk.String("/healthz"),
+		},
+		TargetGroupAttributes: []elbv2model.TargetGroupAttribute{
+			{Key: "deregistration_delay.timeout_seconds", Value: "30"},
+			{Key: "target_group_health.dns_failover.minimum_healthy_targets.count", Value: "1"},
+		},
+	})
+	scheme := elbv2model.LoadBalancerSchemeInternetFacing
+	lb := elbv2model.NewLoadBalancer(stack, "LoadBalancer", elbv2model.LoadBalancerSpec{
+		Name:           "k8s-ns-ing-abcd",
+		Type:           elbv2model.LoadBalancerTypeApplication,
+		Scheme:         &scheme,
+		SubnetMappings: []elbv2model.SubnetMapping{{SubnetID: "subnet-1"}},
+		SecurityGroups: []core.StringToken{sg.GroupID(), core.LiteralStringToken("sg-extra")},
+		LoadBalancerAttributes: []elbv2model.LoadBalancerAttribute{
+			{Key: "idle_timeout.timeout_seconds", Value: "120"},
+		},
+		Tags: map[string]string{"team": "a"},
+	})
+	ls := elbv2model.NewListener(stack, "80", elbv2model.ListenerSpec{
+		LoadBalancerARN: lb.LoadBalancerARN(),
+		Port:            80,
+		Protocol:        elbv2model.ProtocolHTTP,
+		DefaultActions: []elbv2model.Action{
+			{
+				Type: elbv2model.ActionTypeFixedResponse,
+				FixedResponseConfig: &elbv2model.FixedResponseActionConfig{
+					ContentType: awssdk.String("text/plain"),
+					StatusCode:  "404",
+				},
+			},
+		},
+	})
+	elbv2model.NewListenerRule(stack, "80:1", elbv2model.ListenerRuleSpec{
+		ListenerARN: ls.ListenerARN(),
+		Priority:    1,
+		Actions: []elbv2model.Action{
+			{
+				Type: elbv2model.ActionTypeForward,
+				ForwardConfig: &elbv2model.ForwardActionConfig{
+					TargetGroups: []elbv2model.TargetGroupTuple{{TargetGroupARN: tg.TargetGroupARN()}},
+				},
+			},
+		},
+		Conditions: []elbv2model.RuleCondition{
+			{
+				Field:             elbv2model.RuleConditionFieldPathPattern,
+				PathPatternConfig: &elbv2model.PathPatternConditionConfig{Values: []string{"/api/*"}},
+			},
+		},
+	})
+	return stack
+}
+
+func Test_defaultStackExporter_Export(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  StackExportFormat
+		want    string
+		wantErr error
+	}{
+		{
+			name:   "terraform",
+			format: StackExportFormatTerraform,
+			want: `# AWS resources exported from stack ns/ing of aws-load-balancer-controller.
+
+variable "vpc_id" {
+  description = "The VPC of TargetGroups and SecurityGroups"
+  type = string
+  default = "vpc-1"
+}
+
+resource "aws_security_group" "managed_lb_security_group" {
+  name = "k8s-ns-ing-abcd"
+  description = "[k8s] Managed SecurityGroup for LoadBalancer"
+  vpc_id = var.vpc_id
+  ingress {
+    protocol = "tcp"
+    from_port = 80
+    to_port = 80
+    cidr_blocks = ["0.0.0.0/0"]
+  }
+}
+
+resource "aws_lb_target_group" "ns_ing_svc_80" {
+  name = "k8s-ns-svc-abcd"
+  target_type = "ip"
+  port = 8080
+  protocol = "HTTP"
+  vpc_id = var.vpc_id
+  deregistration_delay = 30
+  health_check {
+    enabled = true
+    path = "/healthz"
+  }
+  # unsupported attribute: target_group_health.dns_failover.minimum_healthy_targets.count = 1
+}
+
+resource "aws_lb" "load_balancer" {
+  name = "k8s-ns-ing-abcd"
+  load_balancer_type = "application"
+  internal = false
+  security_groups = [aws_security_group.managed_lb_security_group.id, "sg-extra"]
+  idle_timeout = 120
+  subnet_mapping {
+    subnet_id = "subnet-1"
+  }
+  tags = { "team" = "a" }
+}
+
+resource "aws_lb_listener" "listener_80" {
+  load_balancer_arn = aws_lb.load_balancer.arn
+  port = 80
+  protocol = "HTTP"
+  default_action {
+    type = "fixed-response"
+    order = 1
+    fixed_response {
+      status_code = "404"
+      content_type = "text/plain"
+    }
+  }
+}
+
+resource "aws_lb_listener_rule" "listener_rule_80_1" {
+  listener_arn = aws_lb_listener.listener_80.arn
+  priority = 1
+  action {
+    type = "forward"
+    order = 1
+    forward {
+      target_group {
+        arn = aws_lb_target_group.ns_ing_svc_80.arn
+      }
+    }
+  }
+  condition {
+    path_pattern {
+      values = ["/api/*"]
+    }
+  }
+}
+
+`,
+		},
+		{
+			name:    "unsupported format",
+			format:  "pulumi",
+			wantErr: errors.New("unsupported export format: pulumi"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewDefaultStackExporter("vpc-1")
+			got, err := e.Export(buildStackForExport(), tt.format)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultStackExporter_Export_CloudFormation(t *testing.T) {
+	e := NewDefaultStackExporter("vpc-1")
+	got, err := e.Export(buildStackForExport(), StackExportFormatCloudFormation)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+  "AWSTemplateFormatVersion": "2010-09-09",
+  "Description": "AWS resources exported from stack ns/ing of aws-load-balancer-controller",
+  "Parameters": {
+    "VpcId": {
+      "Type": "AWS::EC2::VPC::Id",
+      "Description": "The VPC of TargetGroups and SecurityGroups",
+      "Default": "vpc-1"
+    }
+  },
+  "Resources": {
+    "SecurityGroupManagedLBSecurityGroup": {
+      "Type": "AWS::EC2::SecurityGroup",
+      "Properties": {
+        "GroupName": "k8s-ns-ing-abcd",
+        "GroupDescription": "[k8s] Managed SecurityGroup for LoadBalancer",
+        "VpcId": {"Ref": "VpcId"},
+        "SecurityGroupIngress": [
+          {"IpProtocol": "tcp", "FromPort": 80, "ToPort": 80, "CidrIp": "0.0.0.0/0"}
+        ]
+      }
+    },
+    "TargetGroupNsIngSvc80": {
+      "Type": "AWS::ElasticLoadBalancingV2::TargetGroup",
+      "Properties": {
+        "Name": "k8s-ns-svc-abcd",
+        "TargetType": "ip",
+        "Port": 8080,
+        "Protocol": "HTTP",
+        "VpcId": {"Ref": "VpcId"},
+        "HealthCheckEnabled": true,
+        "HealthCheckPath": "/healthz",
+        "TargetGroupAttributes": [
+          {"Key": "deregistration_delay.timeout_seconds", "Value": "30"},
+          {"Key": "target_group_health.dns_failover.minimum_healthy_targets.count", "Value": "1"}
+        ]
+      }
+    },
+    "LoadBalancer": {
+      "Type": "AWS::ElasticLoadBalancingV2::LoadBalancer",
+      "Properties": {
+        "Name": "k8s-ns-ing-abcd",
+        "Type": "application",
+        "Scheme": "internet-facing",
+        "SubnetMappings": [{"SubnetId": "subnet-1"}],
+        "SecurityGroups": [{"Fn::GetAtt": ["SecurityGroupManagedLBSecurityGroup", "GroupId"]}, "sg-extra"],
+        "LoadBalancerAttributes": [{"Key": "idle_timeout.timeout_seconds", "Value": "120"}],
+        "Tags": [{"Key": "team", "Value": "a"}]
+      }
+    },
+    "Listener80": {
+      "Type": "AWS::ElasticLoadBalancingV2::Listener",
+      "Properties": {
+        "LoadBalancerArn": {"Ref": "LoadBalancer"},
+        "Port": 80,
+        "Protocol": "HTTP",
+        "DefaultActions": [
+          {"Type": "fixed-response", "Order": 1, "FixedResponseConfig": {"StatusCode": "404", "ContentType": "text/plain"}}
+        ]
+      }
+    },
+    "ListenerRule801": {
+      "Type": "AWS::ElasticLoadBalancingV2::ListenerRule",
+      "Properties": {
+        "ListenerArn": {"Ref": "Listener80"},
+        "Priority": 1,
+        "Actions": [
+          {"Type": "forward", "Order": 1, "ForwardConfig": {"TargetGroups": [{"TargetGroupArn": {"Ref": "TargetGroupNsIngSvc80"}}]}}
+        ],
+        "Conditions": [
+          {"Field": "path-pattern", "PathPatternConfig": {"Values": ["/api/*"]}}
+        ]
+      }
+    }
+  }
+}`, got)
+}
+
+func Test_splitNameWords(t *testing.T) {
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{
+			name: "ns/ing-svc:80",
+			want: []string{"ns", "ing", "svc", "80"},
+		},
+		{
+			name: "ManagedLBSecurityGroup",
+			want: []string{"Managed", "LB", "Security", "Group"},
+		},
+		{
+			name: "80:1",
+			want: []string{"80", "1"},
+		},
+		{
+			name: "tcp80",
+			want: []string{"tcp80"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, splitNameWords(tt.name))
+		})
+	}
+}
+
+func Test_pascalCaseName(t *testing.T) {
+	assert.Equal(t, "NsIngSvc80", pascalCaseName("ns/ing-svc:80"))
+	assert.Equal(t, "ManagedLBSecurityGroup", pascalCaseName("ManagedLBSecurityGroup"))
+}
+
+func Test_snakeCaseName(t *testing.T) {
+	assert.Equal(t, "ns_ing_svc_80", snakeCaseName("ns/ing-svc:80"))
+	assert.Equal(t, "managed_lb_security_group", snakeCaseName("ManagedLBSecurityGroup"))
+}
+
+func Test_exportNameRegistry_assign(t *testing.T) {
+	r := newExportNameRegistry()
+	assert.Equal(t, "name", r.assign("name"))
+	assert.Equal(t, "name2", r.assign("name"))
+	assert.Equal(t, "name3", r.assign("name"))
+}
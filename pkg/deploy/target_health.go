@@ -0,0 +1,87 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sort"
+	"strings"
+)
+
+// TargetGroupHealth contains the aggregate target health of a TargetGroup provisioned for a stack.
+type TargetGroupHealth struct {
+	// The Amazon Resource Name (ARN) of the target group.
+	TargetGroupARN string `json:"targetGroupARN"`
+
+	// The number of healthy targets.
+	HealthyTargets int32 `json:"healthyTargets"`
+
+	// The number of targets, excluding targets being deregistered.
+	TotalTargets int32 `json:"totalTargets"`
+}
+
+// BuildTargetHealth builds the aggregate target health of TargetGroups from the TargetGroupBindings of a deployed stack.
+// TargetGroups whose target health isn't reported on their TargetGroupBindings yet are omitted.
+func BuildTargetHealth(ctx context.Context, k8sClient client.Client, stack core.Stack) ([]TargetGroupHealth, error) {
+	var resTGBs []*elbv2model.TargetGroupBindingResource
+	if err := stack.ListResources(&resTGBs); err != nil {
+		return nil, err
+	}
+
+	var targetHealth []TargetGroupHealth
+	for _, resTGB := range resTGBs {
+		if resTGB.Status == nil {
+			continue
+		}
+		tgbKey := types.NamespacedName{
+			Namespace: resTGB.Status.TargetGroupBindingRef.Namespace,
+			Name:      resTGB.Status.TargetGroupBindingRef.Name,
+		}
+		tgb := &elbv2api.TargetGroupBinding{}
+		if err := k8sClient.Get(ctx, tgbKey, tgb); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to get targetGroupBinding: %v", tgbKey)
+		}
+		if tgb.Status.TargetHealth == nil {
+			continue
+		}
+		targetHealth = append(targetHealth, TargetGroupHealth{
+			TargetGroupARN: tgb.Spec.TargetGroupARN,
+			HealthyTargets: tgb.Status.TargetHealth.HealthyTargets,
+			TotalTargets:   tgb.Status.TargetHealth.TotalTargets,
+		})
+	}
+	// resources are listed in random order, sort them to keep the report stable.
+	sort.Slice(targetHealth, func(i, j int) bool {
+		return targetHealth[i].TargetGroupARN < targetHealth[j].TargetGroupARN
+	})
+	return targetHealth, nil
+}
+
+// BuildUnhealthyTargetsMessage builds the message that reports TargetGroups with unhealthy targets from a deployed stack.
+// it's empty if all targets are healthy or target health isn't reported for any TargetGroup of the stack.
+func BuildUnhealthyTargetsMessage(ctx context.Context, k8sClient client.Client, stack core.Stack) (string, error) {
+	targetHealth, err := BuildTargetHealth(ctx, k8sClient, stack)
+	if err != nil {
+		return "", err
+	}
+	var unhealthyTGs []string
+	for _, tgHealth := range targetHealth {
+		if tgHealth.HealthyTargets == tgHealth.TotalTargets {
+			continue
+		}
+		unhealthyTGs = append(unhealthyTGs, fmt.Sprintf("%v (%v of %v targets healthy)", tgHealth.TargetGroupARN, tgHealth.HealthyTargets, tgHealth.TotalTargets))
+	}
+	if len(unhealthyTGs) == 0 {
+		return "", nil
+	}
+	return fmt.Sprintf("TargetGroups have unhealthy targets: %v", strings.Join(unhealthyTGs, ", ")), nil
+}
@@ -0,0 +1,103 @@
+package deploy
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+func Test_BuildUnhealthyTargetsMessage(t *testing.T) {
+	buildTGB := func(name string, tgARN string, targetHealth *elbv2api.TargetHealthSummary) *elbv2api.TargetGroupBinding {
+		return &elbv2api.TargetGroupBinding{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: name},
+			Spec:       elbv2api.TargetGroupBindingSpec{TargetGroupARN: tgARN},
+			Status:     elbv2api.TargetGroupBindingStatus{TargetHealth: targetHealth},
+		}
+	}
+	buildResTGB := func(stack core.Stack, id string, tgbName string) {
+		resTGB := elbv2model.NewTargetGroupBindingResource(stack, id, elbv2model.TargetGroupBindingResourceSpec{
+			Template: elbv2model.TargetGroupBindingTemplate{
+				Spec: elbv2model.TargetGroupBindingSpec{TargetGroupARN: core.LiteralStringToken("tg-arn")},
+			},
+		})
+		resTGB.SetStatus(elbv2model.TargetGroupBindingResourceStatus{
+			TargetGroupBindingRef: corev1.ObjectReference{Namespace: "awesome-ns", Name: tgbName},
+		})
+	}
+	tests := []struct {
+		name       string
+		tgbs       []*elbv2api.TargetGroupBinding
+		buildStack func(stack core.Stack)
+		want       string
+	}{
+		{
+			name: "target health reported",
+			tgbs: []*elbv2api.TargetGroupBinding{
+				buildTGB("tgb-2", "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/tg-2/2222222222",
+					&elbv2api.TargetHealthSummary{HealthyTargets: 0, TotalTargets: 3}),
+				buildTGB("tgb-1", "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/tg-1/1111111111",
+					&elbv2api.TargetHealthSummary{HealthyTargets: 2, TotalTargets: 2}),
+			},
+			buildStack: func(stack core.Stack) {
+				buildResTGB(stack, "tgb-2", "tgb-2")
+				buildResTGB(stack, "tgb-1", "tgb-1")
+			},
+			want: "TargetGroups have unhealthy targets: arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/tg-2/2222222222 (0 of 3 targets healthy)",
+		},
+		{
+			name: "all targets healthy",
+			tgbs: []*elbv2api.TargetGroupBinding{
+				buildTGB("tgb-1", "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/tg-1/1111111111",
+					&elbv2api.TargetHealthSummary{HealthyTargets: 2, TotalTargets: 2}),
+			},
+			buildStack: func(stack core.Stack) {
+				buildResTGB(stack, "tgb-1", "tgb-1")
+			},
+			want: "",
+		},
+		{
+			name: "target health not reported yet",
+			tgbs: []*elbv2api.TargetGroupBinding{
+				buildTGB("tgb-1", "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/tg-1/1111111111", nil),
+			},
+			buildStack: func(stack core.Stack) {
+				buildResTGB(stack, "tgb-1", "tgb-1")
+				buildResTGB(stack, "tgb-2", "tgb-2")
+			},
+			want: "",
+		},
+		{
+			name: "stack without TargetGroupBindings",
+			buildStack: func(stack core.Stack) {
+				elbv2model.NewLoadBalancer(stack, "LoadBalancer", elbv2model.LoadBalancerSpec{})
+			},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			ctx := context.Background()
+			for _, tgb := range tt.tgbs {
+				assert.NoError(t, k8sClient.Create(ctx, tgb.DeepCopy()))
+			}
+
+			stack := core.NewDefaultStack(core.StackID{Namespace: "awesome-ns", Name: "awesome-ing"})
+			tt.buildStack(stack)
+			got, err := BuildUnhealthyTargetsMessage(ctx, k8sClient, stack)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
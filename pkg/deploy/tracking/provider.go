@@ -32,6 +32,13 @@ import (
 //    * `service.k8s.aws/stack-namespace: namespace`
 //    * `service.k8s.aws/stack-name: serviceName`
 
+const (
+	// IngressTagPrefix is the prefix of tags and labels to track resources provisioned for Ingresses.
+	IngressTagPrefix = "ingress.k8s.aws"
+	// ServiceTagPrefix is the prefix of tags and labels to track resources provisioned for Services.
+	ServiceTagPrefix = "service.k8s.aws"
+)
+
 // AWS TagKey for cluster resources.
 const clusterNameTagKey = "elbv2.k8s.aws/cluster"
 
@@ -60,6 +67,10 @@ type Provider interface {
 	// These tag keys is required for AWSALBIngressController(v1.1.3+) to identify resources.
 	// To be able to downgrade AWSLoadBalancerController to AWSALBIngressController(v1.1.3+), we shouldn't remove these tag keys.
 	LegacyTagKeys() []string
+
+	// StackTagsV1 provides the tags for stack added by AWSALBIngressController(before v1.1.3), which tracks resources per Ingress.
+	// it returns nil for stacks of explicit IngressGroup, which doesn't exist in AWSALBIngressController.
+	StackTagsV1(stack core.Stack) map[string]string
 }
 
 // NewDefaultProvider constructs defaultProvider
@@ -131,6 +142,37 @@ func (p *defaultProvider) LegacyTagKeys() []string {
 	}
 }
 
+func (p *defaultProvider) StackTagsV1(stack core.Stack) map[string]string {
+	stackID := stack.StackID()
+	if stackID.Namespace == "" {
+		return nil
+	}
+	return map[string]string{
+		fmt.Sprintf("kubernetes.io/cluster/%s", p.clusterName): "owned",
+		"kubernetes.io/namespace":                              stackID.Namespace,
+		"kubernetes.io/ingress-name":                           stackID.Name,
+	}
+}
+
 func (p *defaultProvider) prefixedTrackingKey(tag string) string {
 	return fmt.Sprintf("%v/%v", p.tagPrefix, tag)
 }
+
+// IsStackLabelled returns whether a K8s resource carries the labels of a stack with tagPrefix, i.e. it's created by this controller for a stack.
+func IsStackLabelled(tagPrefix string, labels map[string]string) bool {
+	_, ok := StackIDFromLabels(tagPrefix, labels)
+	return ok
+}
+
+// StackIDFromLabels returns the ID of stack whose labels with tagPrefix are carried by a K8s resource.
+func StackIDFromLabels(tagPrefix string, labels map[string]string) (core.StackID, bool) {
+	if name, ok := labels[fmt.Sprintf("%v/%v", tagPrefix, "stack")]; ok {
+		return core.StackID{Name: name}, true
+	}
+	namespace, namespaceOK := labels[fmt.Sprintf("%v/%v", tagPrefix, "stack-namespace")]
+	name, nameOK := labels[fmt.Sprintf("%v/%v", tagPrefix, "stack-name")]
+	if !namespaceOK || !nameOK {
+		return core.StackID{}, false
+	}
+	return core.StackID{Namespace: namespace, Name: name}, true
+}
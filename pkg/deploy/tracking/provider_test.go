@@ -240,3 +240,143 @@ func Test_defaultProvider_LegacyTagKeys(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultProvider_StackTagsV1(t *testing.T) {
+	type args struct {
+		stack core.Stack
+	}
+	tests := []struct {
+		name     string
+		provider *defaultProvider
+		args     args
+		want     map[string]string
+	}{
+		{
+			name:     "stackTags for explicit IngressGroup",
+			provider: NewDefaultProvider("ingress.k8s.aws", "cluster-name"),
+			args:     args{stack: core.NewDefaultStack(core.StackID{Namespace: "", Name: "awesome-group"})},
+			want:     nil,
+		},
+		{
+			name:     "stackTags for implicit IngressGroup",
+			provider: NewDefaultProvider("ingress.k8s.aws", "cluster-name"),
+			args:     args{stack: core.NewDefaultStack(core.StackID{Namespace: "namespace", Name: "ingressName"})},
+			want: map[string]string{
+				"kubernetes.io/cluster/cluster-name": "owned",
+				"kubernetes.io/namespace":            "namespace",
+				"kubernetes.io/ingress-name":         "ingressName",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.provider.StackTagsV1(tt.args.stack)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIsStackLabelled(t *testing.T) {
+	tests := []struct {
+		name      string
+		tagPrefix string
+		labels    map[string]string
+		want      bool
+	}{
+		{
+			name:      "labelled for explicit IngressGroup",
+			tagPrefix: IngressTagPrefix,
+			labels:    map[string]string{"ingress.k8s.aws/stack": "awesome-group"},
+			want:      true,
+		},
+		{
+			name:      "labelled for Service",
+			tagPrefix: ServiceTagPrefix,
+			labels: map[string]string{
+				"service.k8s.aws/stack-namespace": "namespace",
+				"service.k8s.aws/stack-name":      "serviceName",
+			},
+			want: true,
+		},
+		{
+			name:      "labelled for stack with other tagPrefix",
+			tagPrefix: IngressTagPrefix,
+			labels:    map[string]string{"service.k8s.aws/stack-namespace": "namespace", "service.k8s.aws/stack-name": "serviceName"},
+			want:      false,
+		},
+		{
+			name:      "labelled by users with similar keys",
+			tagPrefix: IngressTagPrefix,
+			labels:    map[string]string{"example.com/stack": "prod", "ingress.k8s.aws/stack-name": "awesome-app"},
+			want:      false,
+		},
+		{
+			name:      "not labelled",
+			tagPrefix: IngressTagPrefix,
+			labels:    map[string]string{"app": "awesome-app"},
+			want:      false,
+		},
+		{
+			name:      "without labels",
+			tagPrefix: IngressTagPrefix,
+			labels:    nil,
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := IsStackLabelled(tt.tagPrefix, tt.labels)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestStackIDFromLabels(t *testing.T) {
+	tests := []struct {
+		name      string
+		tagPrefix string
+		labels    map[string]string
+		want      core.StackID
+		wantOK    bool
+	}{
+		{
+			name:      "labelled for explicit IngressGroup",
+			tagPrefix: "ingress.k8s.aws",
+			labels:    map[string]string{"ingress.k8s.aws/stack": "awesome-group"},
+			want:      core.StackID{Namespace: "", Name: "awesome-group"},
+			wantOK:    true,
+		},
+		{
+			name:      "labelled for Service",
+			tagPrefix: "service.k8s.aws",
+			labels: map[string]string{
+				"service.k8s.aws/stack-namespace": "namespace",
+				"service.k8s.aws/stack-name":      "serviceName",
+			},
+			want:   core.StackID{Namespace: "namespace", Name: "serviceName"},
+			wantOK: true,
+		},
+		{
+			name:      "labelled with another tagPrefix",
+			tagPrefix: "ingress.k8s.aws",
+			labels: map[string]string{
+				"service.k8s.aws/stack-namespace": "namespace",
+				"service.k8s.aws/stack-name":      "serviceName",
+			},
+			wantOK: false,
+		},
+		{
+			name:      "not labelled",
+			tagPrefix: "ingress.k8s.aws",
+			labels:    map[string]string{"app": "awesome-app"},
+			wantOK:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotOK := StackIDFromLabels(tt.tagPrefix, tt.labels)
+			assert.Equal(t, tt.wantOK, gotOK)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package tracking
+
+import (
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// TagUpdate is a set of tags to add or overwrite on resources.
+type TagUpdate struct {
+	// ResIDs are the IDs or ARNs of resources.
+	ResIDs []string
+	// Tags are the tags to add or overwrite.
+	Tags map[string]string
+}
+
+// TagRemoval is a set of tag keys to remove from resources.
+type TagRemoval struct {
+	// ResIDs are the IDs or ARNs of resources.
+	ResIDs []string
+	// TagKeys are the keys of tags to remove.
+	TagKeys []string
+}
+
+// TagBatch accumulates tag changes of resources during a stack deployment,
+// so that resources with identical tag changes can be tagged with a single API call.
+type TagBatch struct {
+	mutex    sync.Mutex
+	updates  map[string]*TagUpdate
+	removals map[string]*TagRemoval
+}
+
+// NewTagBatch constructs new TagBatch.
+func NewTagBatch() *TagBatch {
+	return &TagBatch{
+		updates:  make(map[string]*TagUpdate),
+		removals: make(map[string]*TagRemoval),
+	}
+}
+
+// Add records tagsToUpdate and tagKeysToRemove of resource.
+func (b *TagBatch) Add(resID string, tagsToUpdate map[string]string, tagKeysToRemove []string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if len(tagsToUpdate) != 0 {
+		key := encodeTags(tagsToUpdate)
+		update, ok := b.updates[key]
+		if !ok {
+			update = &TagUpdate{Tags: tagsToUpdate}
+			b.updates[key] = update
+		}
+		update.ResIDs = append(update.ResIDs, resID)
+	}
+	if len(tagKeysToRemove) != 0 {
+		tagKeys := sets.NewString(tagKeysToRemove...).List()
+		key := strings.Join(tagKeys, "\x00")
+		removal, ok := b.removals[key]
+		if !ok {
+			removal = &TagRemoval{TagKeys: tagKeys}
+			b.removals[key] = removal
+		}
+		removal.ResIDs = append(removal.ResIDs, resID)
+	}
+}
+
+// Drain returns the recorded tag changes grouped by identical changes, and resets the batch.
+// The changes are ordered deterministically by their tags.
+func (b *TagBatch) Drain() ([]TagUpdate, []TagRemoval) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	updates := make([]TagUpdate, 0, len(b.updates))
+	for _, key := range sets.StringKeySet(b.updates).List() {
+		update := *b.updates[key]
+		sort.Strings(update.ResIDs)
+		updates = append(updates, update)
+	}
+	removals := make([]TagRemoval, 0, len(b.removals))
+	for _, key := range sets.StringKeySet(b.removals).List() {
+		removal := *b.removals[key]
+		sort.Strings(removal.ResIDs)
+		removals = append(removals, removal)
+	}
+	b.updates = make(map[string]*TagUpdate)
+	b.removals = make(map[string]*TagRemoval)
+	return updates, removals
+}
+
+// encodeTags encodes tags into a string that is identical for identical tags.
+func encodeTags(tags map[string]string) string {
+	var sb strings.Builder
+	for _, key := range sets.StringKeySet(tags).List() {
+		sb.WriteString(key)
+		sb.WriteString("\x00")
+		sb.WriteString(tags[key])
+		sb.WriteString("\x00")
+	}
+	return sb.String()
+}
@@ -0,0 +1,79 @@
+package tracking
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTagBatch_Drain(t *testing.T) {
+	type change struct {
+		resID           string
+		tagsToUpdate    map[string]string
+		tagKeysToRemove []string
+	}
+	tests := []struct {
+		name         string
+		changes      []change
+		wantUpdates  []TagUpdate
+		wantRemovals []TagRemoval
+	}{
+		{
+			name:         "no changes",
+			wantUpdates:  []TagUpdate{},
+			wantRemovals: []TagRemoval{},
+		},
+		{
+			name: "identical changes are grouped",
+			changes: []change{
+				{
+					resID:           "res-2",
+					tagsToUpdate:    map[string]string{"keyA": "valueA", "keyB": "valueB"},
+					tagKeysToRemove: []string{"keyD", "keyC"},
+				},
+				{
+					resID:        "res-1",
+					tagsToUpdate: map[string]string{"keyB": "valueB", "keyA": "valueA"},
+				},
+				{
+					resID:           "res-3",
+					tagsToUpdate:    map[string]string{"keyA": "valueA2"},
+					tagKeysToRemove: []string{"keyC", "keyD"},
+				},
+				{
+					resID: "res-4",
+				},
+			},
+			wantUpdates: []TagUpdate{
+				{
+					ResIDs: []string{"res-1", "res-2"},
+					Tags:   map[string]string{"keyA": "valueA", "keyB": "valueB"},
+				},
+				{
+					ResIDs: []string{"res-3"},
+					Tags:   map[string]string{"keyA": "valueA2"},
+				},
+			},
+			wantRemovals: []TagRemoval{
+				{
+					ResIDs:  []string{"res-2", "res-3"},
+					TagKeys: []string{"keyC", "keyD"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			batch := NewTagBatch()
+			for _, change := range tt.changes {
+				batch.Add(change.resID, change.tagsToUpdate, change.tagKeysToRemove)
+			}
+			gotUpdates, gotRemovals := batch.Drain()
+			assert.Equal(t, tt.wantUpdates, gotUpdates)
+			assert.Equal(t, tt.wantRemovals, gotRemovals)
+
+			gotUpdates, gotRemovals = batch.Drain()
+			assert.Empty(t, gotUpdates)
+			assert.Empty(t, gotRemovals)
+		})
+	}
+}
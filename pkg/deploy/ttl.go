@@ -0,0 +1,88 @@
+package deploy
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"time"
+)
+
+// Expiry is when the AWS resources provisioned for an object expire per its TTL annotations.
+type Expiry struct {
+	// ExpiresAt is the time the TTL annotation was set on object plus its TTL.
+	ExpiresAt time.Time
+	// DeleteObject indicates the object itself should be deleted once expired, instead of only tearing down its AWS resources.
+	DeleteObject bool
+}
+
+// Expired checks whether the expiry has passed at time now.
+func (e Expiry) Expired(now time.Time) bool {
+	return !now.Before(e.ExpiresAt)
+}
+
+// ResolveExpiry resolves the expiry of obj per its TTL annotations, it returns nil if obj has no TTL.
+// The TTL counts from when the TTL annotation was set, so that adding a TTL to an existing object doesn't expire it right away.
+func ResolveExpiry(annotationParser annotations.Parser, annotationPrefix string, ttlSuffix string, deleteObjectSuffix string, obj k8s.APIObject) (*Expiry, error) {
+	rawTTL := ""
+	if exists := annotationParser.ParseStringAnnotation(ttlSuffix, &rawTTL, obj.GetAnnotations()); !exists {
+		return nil, nil
+	}
+	ttl, err := time.ParseDuration(rawTTL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse ttl: %v", rawTTL)
+	}
+	if ttl < config.MinResourceTTL {
+		return nil, errors.Errorf("ttl must be at least %v, ttl: %v", config.MinResourceTTL, rawTTL)
+	}
+	deleteObject := false
+	if _, err := annotationParser.ParseBoolAnnotation(deleteObjectSuffix, &deleteObject, obj.GetAnnotations()); err != nil {
+		return nil, err
+	}
+	return &Expiry{
+		ExpiresAt:    resolveAnnotationSetAt(obj, annotationPrefix+"/"+ttlSuffix).Add(ttl),
+		DeleteObject: deleteObject,
+	}, nil
+}
+
+// DeleteExpiredObject deletes the expired object if requested per expiry.
+// it returns whether obj is newly deleted, so that callers only report the deletion once.
+func DeleteExpiredObject(ctx context.Context, k8sClient client.Client, obj k8s.APIObject, expiry Expiry) (bool, error) {
+	if !expiry.DeleteObject || !obj.GetDeletionTimestamp().IsZero() {
+		return false, nil
+	}
+	if err := k8sClient.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+		return false, errors.Wrapf(err, "failed to delete expired object: %v", k8s.NamespacedName(obj))
+	}
+	return true, nil
+}
+
+// resolveAnnotationSetAt returns when the annotation of key was last set on obj, per the managed fields of obj.
+// it falls back to the creation time of obj if the managed fields don't track the annotation.
+func resolveAnnotationSetAt(obj k8s.APIObject, key string) time.Time {
+	setAt := obj.GetCreationTimestamp().Time
+	for _, managedFields := range obj.GetManagedFields() {
+		if managedFields.Time == nil || managedFields.FieldsV1 == nil {
+			continue
+		}
+		var fields struct {
+			Metadata struct {
+				Annotations map[string]json.RawMessage `json:"f:annotations"`
+			} `json:"f:metadata"`
+		}
+		if err := json.Unmarshal(managedFields.FieldsV1.Raw, &fields); err != nil {
+			continue
+		}
+		if _, managed := fields.Metadata.Annotations["f:"+key]; !managed {
+			continue
+		}
+		if managedFields.Time.Time.After(setAt) {
+			setAt = managedFields.Time.Time
+		}
+	}
+	return setAt
+}
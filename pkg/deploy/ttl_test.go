@@ -0,0 +1,159 @@
+package deploy
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+	"time"
+)
+
+func Test_ResolveExpiry(t *testing.T) {
+	creationTime := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	ttlSetTime := metav1.NewTime(time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC))
+	otherUpdateTime := metav1.NewTime(time.Date(2026, 1, 6, 0, 0, 0, 0, time.UTC))
+	tests := []struct {
+		name           string
+		objAnnotations map[string]string
+		managedFields  []metav1.ManagedFieldsEntry
+		want           *Expiry
+		wantErr        error
+	}{
+		{
+			name:           "no ttl",
+			objAnnotations: map[string]string{},
+			want:           nil,
+		},
+		{
+			name: "ttl",
+			objAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/ttl": "72h",
+			},
+			want: &Expiry{
+				ExpiresAt: creationTime.Add(72 * time.Hour),
+			},
+		},
+		{
+			name: "ttl with object deletion",
+			objAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/ttl":               "2h",
+				"alb.ingress.kubernetes.io/ttl-delete-object": "true",
+			},
+			want: &Expiry{
+				ExpiresAt:    creationTime.Add(2 * time.Hour),
+				DeleteObject: true,
+			},
+		},
+		{
+			name: "ttl set after creation",
+			objAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/ttl": "2h",
+			},
+			managedFields: []metav1.ManagedFieldsEntry{
+				{
+					Manager:  "kubectl-client-side-apply",
+					Time:     &ttlSetTime,
+					FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:annotations":{".":{},"f:alb.ingress.kubernetes.io/ttl":{}}}}`)},
+				},
+				{
+					Manager:  "controller",
+					Time:     &otherUpdateTime,
+					FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:finalizers":{}}}`)},
+				},
+			},
+			want: &Expiry{
+				ExpiresAt: ttlSetTime.Add(2 * time.Hour),
+			},
+		},
+		{
+			name: "malformed ttl",
+			objAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/ttl": "2 days",
+			},
+			wantErr: errors.New("failed to parse ttl: 2 days: time: unknown unit \" days\" in duration \"2 days\""),
+		},
+		{
+			name: "ttl too short",
+			objAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/ttl": "10s",
+			},
+			wantErr: errors.New("ttl must be at least 1m0s, ttl: 10s"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			annotationParser := annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
+			obj := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:         "awesome-ns",
+					Name:              "awesome-svc",
+					Annotations:       tt.objAnnotations,
+					CreationTimestamp: metav1.NewTime(creationTime),
+					ManagedFields:     tt.managedFields,
+				},
+			}
+			got, err := ResolveExpiry(annotationParser, "alb.ingress.kubernetes.io", "ttl", "ttl-delete-object", obj)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_DeleteExpiredObject(t *testing.T) {
+	expiresAt := time.Date(2026, 1, 1, 2, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		expiry      Expiry
+		wantDeleted bool
+	}{
+		{
+			name: "keep object",
+			expiry: Expiry{
+				ExpiresAt: expiresAt,
+			},
+			wantDeleted: false,
+		},
+		{
+			name: "delete object",
+			expiry: Expiry{
+				ExpiresAt:    expiresAt,
+				DeleteObject: true,
+			},
+			wantDeleted: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			ctx := context.Background()
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "awesome-svc",
+				},
+			}
+			assert.NoError(t, k8sClient.Create(ctx, svc))
+
+			gotDeleted, err := DeleteExpiredObject(ctx, k8sClient, svc, tt.expiry)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantDeleted, gotDeleted)
+
+			err = k8sClient.Get(ctx, types.NamespacedName{Namespace: "awesome-ns", Name: "awesome-svc"}, &corev1.Service{})
+			assert.Equal(t, tt.wantDeleted, apierrors.IsNotFound(err))
+		})
+	}
+}
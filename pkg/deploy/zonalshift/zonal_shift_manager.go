@@ -0,0 +1,109 @@
+package zonalshift
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	arczonalshiftsdk "github.com/aws/aws-sdk-go/service/arczonalshift"
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"time"
+)
+
+type ZonalShiftManager interface {
+	// ListZonalShifts returns zonal shifts with specific status for resource.
+	ListZonalShifts(ctx context.Context, resourceARN string, status string) ([]ZonalShiftInfo, error)
+
+	// StartZonalShift starts zonal shift for resource.
+	StartZonalShift(ctx context.Context, resourceARN string, awayFrom string, expiresIn string, comment string) (ZonalShiftInfo, error)
+
+	// CancelZonalShift cancels zonal shift for resource.
+	CancelZonalShift(ctx context.Context, resourceARN string, zonalShiftID string) error
+}
+
+func NewDefaultZonalShiftManager(arcZonalShiftClient services.ARCZonalShift, logger logr.Logger) *defaultZonalShiftManager {
+	return &defaultZonalShiftManager{
+		arcZonalShiftClient: arcZonalShiftClient,
+		logger:              logger,
+	}
+}
+
+var _ ZonalShiftManager = &defaultZonalShiftManager{}
+
+type defaultZonalShiftManager struct {
+	arcZonalShiftClient services.ARCZonalShift
+	logger              logr.Logger
+}
+
+type ZonalShiftInfo struct {
+	ID         string
+	AwayFrom   string
+	Comment    string
+	Status     string
+	ExpiryTime time.Time
+}
+
+func (m *defaultZonalShiftManager) ListZonalShifts(ctx context.Context, resourceARN string, status string) ([]ZonalShiftInfo, error) {
+	req := &arczonalshiftsdk.ListZonalShiftsInput{
+		ResourceIdentifier: awssdk.String(resourceARN),
+		Status:             awssdk.String(status),
+	}
+	shifts, err := m.arcZonalShiftClient.ListZonalShiftsAsList(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	shiftInfos := make([]ZonalShiftInfo, 0, len(shifts))
+	for _, shift := range shifts {
+		shiftInfos = append(shiftInfos, ZonalShiftInfo{
+			ID:         awssdk.StringValue(shift.ZonalShiftId),
+			AwayFrom:   awssdk.StringValue(shift.AwayFrom),
+			Comment:    awssdk.StringValue(shift.Comment),
+			Status:     awssdk.StringValue(shift.Status),
+			ExpiryTime: awssdk.TimeValue(shift.ExpiryTime),
+		})
+	}
+	return shiftInfos, nil
+}
+
+func (m *defaultZonalShiftManager) StartZonalShift(ctx context.Context, resourceARN string, awayFrom string, expiresIn string, comment string) (ZonalShiftInfo, error) {
+	req := &arczonalshiftsdk.StartZonalShiftInput{
+		ResourceIdentifier: awssdk.String(resourceARN),
+		AwayFrom:           awssdk.String(awayFrom),
+		ExpiresIn:          awssdk.String(expiresIn),
+		Comment:            awssdk.String(comment),
+	}
+	m.logger.Info("starting zonal shift",
+		"resourceARN", resourceARN,
+		"awayFrom", awayFrom,
+		"expiresIn", expiresIn)
+	resp, err := m.arcZonalShiftClient.StartZonalShiftWithContext(ctx, req)
+	if err != nil {
+		return ZonalShiftInfo{}, err
+	}
+	shiftInfo := ZonalShiftInfo{
+		ID:         awssdk.StringValue(resp.ZonalShiftId),
+		AwayFrom:   awssdk.StringValue(resp.AwayFrom),
+		Comment:    awssdk.StringValue(resp.Comment),
+		Status:     awssdk.StringValue(resp.Status),
+		ExpiryTime: awssdk.TimeValue(resp.ExpiryTime),
+	}
+	m.logger.Info("started zonal shift",
+		"resourceARN", resourceARN,
+		"zonalShiftID", shiftInfo.ID)
+	return shiftInfo, nil
+}
+
+func (m *defaultZonalShiftManager) CancelZonalShift(ctx context.Context, resourceARN string, zonalShiftID string) error {
+	req := &arczonalshiftsdk.CancelZonalShiftInput{
+		ZonalShiftId: awssdk.String(zonalShiftID),
+	}
+	m.logger.Info("canceling zonal shift",
+		"resourceARN", resourceARN,
+		"zonalShiftID", zonalShiftID)
+	if _, err := m.arcZonalShiftClient.CancelZonalShiftWithContext(ctx, req); err != nil {
+		return err
+	}
+	m.logger.Info("canceled zonal shift",
+		"resourceARN", resourceARN,
+		"zonalShiftID", zonalShiftID)
+	return nil
+}
@@ -0,0 +1,173 @@
+package zonalshift
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	arczonalshiftsdk "github.com/aws/aws-sdk-go/service/arczonalshift"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	zonalshiftmodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/zonalshift"
+	"strings"
+)
+
+const (
+	zonalShiftCommentManagedPrefix = "managed by aws-load-balancer-controller"
+	zonalShiftCommentMaxLength     = 128
+)
+
+// NewZonalShiftSynthesizer constructs new zonalShiftSynthesizer
+func NewZonalShiftSynthesizer(zonalShiftManager ZonalShiftManager, logger logr.Logger, stack core.Stack) *zonalShiftSynthesizer {
+	return &zonalShiftSynthesizer{
+		zonalShiftManager: zonalShiftManager,
+		logger:            logger,
+		stack:             stack,
+	}
+}
+
+type zonalShiftSynthesizer struct {
+	zonalShiftManager ZonalShiftManager
+	logger            logr.Logger
+	stack             core.Stack
+}
+
+func (s *zonalShiftSynthesizer) Synthesize(ctx context.Context) error {
+	var resZonalShifts []*zonalshiftmodel.ZonalShift
+	s.stack.ListResources(&resZonalShifts)
+	resZonalShiftsByResARN, err := mapResZonalShiftByResourceARN(resZonalShifts)
+	if err != nil {
+		return err
+	}
+
+	var resLBs []*elbv2model.LoadBalancer
+	s.stack.ListResources(&resLBs)
+	for _, resLB := range resLBs {
+		lbARN, err := resLB.LoadBalancerARN().Resolve(ctx)
+		if err != nil {
+			return err
+		}
+		resZonalShifts := resZonalShiftsByResARN[lbARN]
+		if err := s.synthesizeZonalShiftsOnLB(ctx, lbARN, resZonalShifts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *zonalShiftSynthesizer) PostSynthesize(ctx context.Context) error {
+	// nothing to do here.
+	return nil
+}
+
+// synthesizeZonalShiftsOnLB reconciles the zonal shifts on LoadBalancer.
+// zonal shifts started by controller are identified by their comments, and the ones started by others are left untouched.
+// once the desired zonal shift expired or got canceled, it won't be started again until the desired zonal shift changes.
+func (s *zonalShiftSynthesizer) synthesizeZonalShiftsOnLB(ctx context.Context, lbARN string, resZonalShifts []*zonalshiftmodel.ZonalShift) error {
+	if len(resZonalShifts) > 1 {
+		return errors.Errorf("[should never happen] multiple zonal shifts desired on LoadBalancer: %v", lbARN)
+	}
+	var resZonalShift *zonalshiftmodel.ZonalShift
+	desiredComment := ""
+	if len(resZonalShifts) == 1 {
+		resZonalShift = resZonalShifts[0]
+		desiredComment = buildZonalShiftComment(resZonalShift.Spec)
+	}
+
+	activeShifts, err := s.zonalShiftManager.ListZonalShifts(ctx, lbARN, arczonalshiftsdk.ZonalShiftStatusActive)
+	if err != nil {
+		return errors.Wrap(err, "failed to list zonal shifts on LoadBalancer")
+	}
+	var matchedShift *ZonalShiftInfo
+	var unmanagedShifts []ZonalShiftInfo
+	for i := range activeShifts {
+		shift := activeShifts[i]
+		if !strings.HasPrefix(shift.Comment, zonalShiftCommentManagedPrefix) {
+			unmanagedShifts = append(unmanagedShifts, shift)
+			continue
+		}
+		if resZonalShift != nil && matchedShift == nil && shift.Comment == desiredComment {
+			matchedShift = &shift
+			continue
+		}
+		if err := s.zonalShiftManager.CancelZonalShift(ctx, lbARN, shift.ID); err != nil {
+			return errors.Wrap(err, "failed to cancel zonal shift on LoadBalancer")
+		}
+	}
+	if resZonalShift == nil {
+		return nil
+	}
+
+	if matchedShift == nil {
+		if len(unmanagedShifts) != 0 {
+			s.logger.Info("ignoring desired zonal shift due to unmanaged zonal shift",
+				"resourceARN", lbARN,
+				"zonalShiftID", unmanagedShifts[0].ID)
+			return nil
+		}
+		finished, err := s.isZonalShiftFinished(ctx, lbARN, desiredComment)
+		if err != nil {
+			return err
+		}
+		if finished {
+			return nil
+		}
+		shift, err := s.zonalShiftManager.StartZonalShift(ctx, lbARN, resZonalShift.Spec.AwayFrom, resZonalShift.Spec.ExpiresIn, desiredComment)
+		if err != nil {
+			return errors.Wrap(err, "failed to start zonal shift on LoadBalancer")
+		}
+		matchedShift = &shift
+	}
+	resZonalShift.SetStatus(zonalshiftmodel.ZonalShiftStatus{
+		ZonalShiftID: matchedShift.ID,
+		Status:       matchedShift.Status,
+		ExpiryTime:   metav1.NewTime(matchedShift.ExpiryTime),
+	})
+	return nil
+}
+
+// isZonalShiftFinished checks whether the zonal shift with comment already expired or got canceled.
+func (s *zonalShiftSynthesizer) isZonalShiftFinished(ctx context.Context, lbARN string, comment string) (bool, error) {
+	for _, status := range []string{arczonalshiftsdk.ZonalShiftStatusExpired, arczonalshiftsdk.ZonalShiftStatusCanceled} {
+		shifts, err := s.zonalShiftManager.ListZonalShifts(ctx, lbARN, status)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to list zonal shifts on LoadBalancer")
+		}
+		for _, shift := range shifts {
+			if shift.Comment == comment {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// buildZonalShiftComment builds the comment for zonal shifts started by controller.
+// the comment contains a hash of desired zonal shift, which ensures a new zonal shift is started once desired zonal shift changes.
+func buildZonalShiftComment(spec zonalshiftmodel.ZonalShiftSpec) string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%s", spec.AwayFrom, spec.ExpiresIn, spec.Comment)))
+	comment := fmt.Sprintf("%s(%s)", zonalShiftCommentManagedPrefix, hex.EncodeToString(hash[:])[:8])
+	if spec.Comment != "" {
+		comment = fmt.Sprintf("%s: %s", comment, spec.Comment)
+	}
+	if len(comment) > zonalShiftCommentMaxLength {
+		comment = comment[:zonalShiftCommentMaxLength]
+	}
+	return comment
+}
+
+func mapResZonalShiftByResourceARN(resZonalShifts []*zonalshiftmodel.ZonalShift) (map[string][]*zonalshiftmodel.ZonalShift, error) {
+	resZonalShiftsByResARN := make(map[string][]*zonalshiftmodel.ZonalShift, len(resZonalShifts))
+	ctx := context.Background()
+	for _, resZonalShift := range resZonalShifts {
+		resARN, err := resZonalShift.Spec.ResourceARN.Resolve(ctx)
+		if err != nil {
+			return nil, err
+		}
+		resZonalShiftsByResARN[resARN] = append(resZonalShiftsByResARN[resARN], resZonalShift)
+	}
+	return resZonalShiftsByResARN, nil
+}
@@ -0,0 +1,265 @@
+package zonalshift
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	arczonalshiftsdk "github.com/aws/aws-sdk-go/service/arczonalshift"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	zonalshiftmodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/zonalshift"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_zonalShiftSynthesizer_synthesizeZonalShiftsOnLB(t *testing.T) {
+	lbARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-lb/1111111111"
+	expiryTime := time.Date(2021, 1, 1, 1, 0, 0, 0, time.UTC)
+	desiredSpec := zonalshiftmodel.ZonalShiftSpec{
+		ResourceARN: core.LiteralStringToken(lbARN),
+		AwayFrom:    "usw2-az1",
+		ExpiresIn:   "1h",
+		Comment:     "drain az1",
+	}
+	desiredComment := buildZonalShiftComment(desiredSpec)
+	staleComment := buildZonalShiftComment(zonalshiftmodel.ZonalShiftSpec{AwayFrom: "usw2-az2", ExpiresIn: "1h"})
+
+	type listZonalShiftsAsListCall struct {
+		status string
+		resp   []*arczonalshiftsdk.ZonalShiftSummary
+	}
+	type fields struct {
+		listZonalShiftsAsListCalls []listZonalShiftsAsListCall
+		cancelZonalShiftIDs        []string
+		startZonalShiftResp        *arczonalshiftsdk.StartZonalShiftOutput
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		resSpec    *zonalshiftmodel.ZonalShiftSpec
+		wantStatus *zonalshiftmodel.ZonalShiftStatus
+	}{
+		{
+			name: "no desired zonal shift - cancel managed zonal shifts",
+			fields: fields{
+				listZonalShiftsAsListCalls: []listZonalShiftsAsListCall{
+					{
+						status: arczonalshiftsdk.ZonalShiftStatusActive,
+						resp: []*arczonalshiftsdk.ZonalShiftSummary{
+							{ZonalShiftId: awssdk.String("shift-1"), Comment: awssdk.String(staleComment)},
+							{ZonalShiftId: awssdk.String("shift-2"), Comment: awssdk.String("started by on-call")},
+						},
+					},
+				},
+				cancelZonalShiftIDs: []string{"shift-1"},
+			},
+		},
+		{
+			name: "desired zonal shift already active",
+			fields: fields{
+				listZonalShiftsAsListCalls: []listZonalShiftsAsListCall{
+					{
+						status: arczonalshiftsdk.ZonalShiftStatusActive,
+						resp: []*arczonalshiftsdk.ZonalShiftSummary{
+							{
+								ZonalShiftId: awssdk.String("shift-1"),
+								Comment:      awssdk.String(desiredComment),
+								Status:       awssdk.String(arczonalshiftsdk.ZonalShiftStatusActive),
+								ExpiryTime:   awssdk.Time(expiryTime),
+							},
+						},
+					},
+				},
+			},
+			resSpec: &desiredSpec,
+			wantStatus: &zonalshiftmodel.ZonalShiftStatus{
+				ZonalShiftID: "shift-1",
+				Status:       arczonalshiftsdk.ZonalShiftStatusActive,
+				ExpiryTime:   metav1.NewTime(expiryTime),
+			},
+		},
+		{
+			name: "desired zonal shift changed - cancel stale zonal shift and start new one",
+			fields: fields{
+				listZonalShiftsAsListCalls: []listZonalShiftsAsListCall{
+					{
+						status: arczonalshiftsdk.ZonalShiftStatusActive,
+						resp: []*arczonalshiftsdk.ZonalShiftSummary{
+							{ZonalShiftId: awssdk.String("shift-1"), Comment: awssdk.String(staleComment)},
+						},
+					},
+					{
+						status: arczonalshiftsdk.ZonalShiftStatusExpired,
+						resp: []*arczonalshiftsdk.ZonalShiftSummary{
+							{ZonalShiftId: awssdk.String("shift-0"), Comment: awssdk.String(staleComment)},
+						},
+					},
+					{
+						status: arczonalshiftsdk.ZonalShiftStatusCanceled,
+					},
+				},
+				cancelZonalShiftIDs: []string{"shift-1"},
+				startZonalShiftResp: &arczonalshiftsdk.StartZonalShiftOutput{
+					ZonalShiftId: awssdk.String("shift-2"),
+					AwayFrom:     awssdk.String("usw2-az1"),
+					Comment:      awssdk.String(desiredComment),
+					Status:       awssdk.String(arczonalshiftsdk.ZonalShiftStatusActive),
+					ExpiryTime:   awssdk.Time(expiryTime),
+				},
+			},
+			resSpec: &desiredSpec,
+			wantStatus: &zonalshiftmodel.ZonalShiftStatus{
+				ZonalShiftID: "shift-2",
+				Status:       arczonalshiftsdk.ZonalShiftStatusActive,
+				ExpiryTime:   metav1.NewTime(expiryTime),
+			},
+		},
+		{
+			name: "desired zonal shift expired - don't start again",
+			fields: fields{
+				listZonalShiftsAsListCalls: []listZonalShiftsAsListCall{
+					{
+						status: arczonalshiftsdk.ZonalShiftStatusActive,
+					},
+					{
+						status: arczonalshiftsdk.ZonalShiftStatusExpired,
+						resp: []*arczonalshiftsdk.ZonalShiftSummary{
+							{ZonalShiftId: awssdk.String("shift-1"), Comment: awssdk.String(desiredComment)},
+						},
+					},
+				},
+			},
+			resSpec: &desiredSpec,
+		},
+		{
+			name: "desired zonal shift canceled - don't start again",
+			fields: fields{
+				listZonalShiftsAsListCalls: []listZonalShiftsAsListCall{
+					{
+						status: arczonalshiftsdk.ZonalShiftStatusActive,
+					},
+					{
+						status: arczonalshiftsdk.ZonalShiftStatusExpired,
+					},
+					{
+						status: arczonalshiftsdk.ZonalShiftStatusCanceled,
+						resp: []*arczonalshiftsdk.ZonalShiftSummary{
+							{ZonalShiftId: awssdk.String("shift-1"), Comment: awssdk.String(desiredComment)},
+						},
+					},
+				},
+			},
+			resSpec: &desiredSpec,
+		},
+		{
+			name: "unmanaged zonal shift active - don't start desired zonal shift",
+			fields: fields{
+				listZonalShiftsAsListCalls: []listZonalShiftsAsListCall{
+					{
+						status: arczonalshiftsdk.ZonalShiftStatusActive,
+						resp: []*arczonalshiftsdk.ZonalShiftSummary{
+							{ZonalShiftId: awssdk.String("shift-1"), Comment: awssdk.String("started by on-call")},
+						},
+					},
+				},
+			},
+			resSpec: &desiredSpec,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			arcZonalShiftClient := mock_services.NewMockARCZonalShift(ctrl)
+			for _, call := range tt.fields.listZonalShiftsAsListCalls {
+				arcZonalShiftClient.EXPECT().ListZonalShiftsAsList(gomock.Any(), &arczonalshiftsdk.ListZonalShiftsInput{
+					ResourceIdentifier: awssdk.String(lbARN),
+					Status:             awssdk.String(call.status),
+				}).Return(call.resp, nil)
+			}
+			for _, shiftID := range tt.fields.cancelZonalShiftIDs {
+				arcZonalShiftClient.EXPECT().CancelZonalShiftWithContext(gomock.Any(), &arczonalshiftsdk.CancelZonalShiftInput{
+					ZonalShiftId: awssdk.String(shiftID),
+				}).Return(&arczonalshiftsdk.CancelZonalShiftOutput{}, nil)
+			}
+			if tt.fields.startZonalShiftResp != nil {
+				arcZonalShiftClient.EXPECT().StartZonalShiftWithContext(gomock.Any(), &arczonalshiftsdk.StartZonalShiftInput{
+					ResourceIdentifier: awssdk.String(lbARN),
+					AwayFrom:           awssdk.String(tt.resSpec.AwayFrom),
+					ExpiresIn:          awssdk.String(tt.resSpec.ExpiresIn),
+					Comment:            awssdk.String(desiredComment),
+				}).Return(tt.fields.startZonalShiftResp, nil)
+			}
+
+			stack := core.NewDefaultStack(core.StackID{Namespace: "awesome-ns", Name: "ing"})
+			var resZonalShifts []*zonalshiftmodel.ZonalShift
+			if tt.resSpec != nil {
+				resZonalShifts = append(resZonalShifts, zonalshiftmodel.NewZonalShift(stack, "ZonalShift", *tt.resSpec))
+			}
+			manager := NewDefaultZonalShiftManager(arcZonalShiftClient, &log.NullLogger{})
+			s := NewZonalShiftSynthesizer(manager, &log.NullLogger{}, stack)
+			err := s.synthesizeZonalShiftsOnLB(context.Background(), lbARN, resZonalShifts)
+			assert.NoError(t, err)
+			if tt.resSpec != nil {
+				assert.Equal(t, tt.wantStatus, resZonalShifts[0].Status)
+			}
+		})
+	}
+}
+
+func Test_buildZonalShiftComment(t *testing.T) {
+	tests := []struct {
+		name       string
+		spec       zonalshiftmodel.ZonalShiftSpec
+		wantPrefix string
+		wantSuffix string
+	}{
+		{
+			name: "without comment",
+			spec: zonalshiftmodel.ZonalShiftSpec{
+				AwayFrom:  "usw2-az1",
+				ExpiresIn: "1h",
+			},
+			wantPrefix: "managed by aws-load-balancer-controller(",
+			wantSuffix: ")",
+		},
+		{
+			name: "with comment",
+			spec: zonalshiftmodel.ZonalShiftSpec{
+				AwayFrom:  "usw2-az1",
+				ExpiresIn: "1h",
+				Comment:   "drain az1",
+			},
+			wantPrefix: "managed by aws-load-balancer-controller(",
+			wantSuffix: "): drain az1",
+		},
+		{
+			name: "with long comment",
+			spec: zonalshiftmodel.ZonalShiftSpec{
+				AwayFrom:  "usw2-az1",
+				ExpiresIn: "1h",
+				Comment:   strings.Repeat("a", 128),
+			},
+			wantPrefix: "managed by aws-load-balancer-controller(",
+			wantSuffix: "aaaa",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildZonalShiftComment(tt.spec)
+			assert.True(t, strings.HasPrefix(got, tt.wantPrefix))
+			assert.True(t, strings.HasSuffix(got, tt.wantSuffix))
+			assert.LessOrEqual(t, len(got), 128)
+		})
+	}
+	t.Run("changes once desired zonal shift changes", func(t *testing.T) {
+		comment1 := buildZonalShiftComment(zonalshiftmodel.ZonalShiftSpec{AwayFrom: "usw2-az1", ExpiresIn: "1h"})
+		comment2 := buildZonalShiftComment(zonalshiftmodel.ZonalShiftSpec{AwayFrom: "usw2-az1", ExpiresIn: "2h"})
+		assert.NotEqual(t, comment1, comment2)
+	})
+}
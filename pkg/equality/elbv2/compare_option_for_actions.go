@@ -6,12 +6,18 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/equality"
+	"sort"
 )
 
 func CompareOptionForTargetGroupTuples() cmp.Option {
 	return cmpopts.AcyclicTransformer("normalizeWeights", func(tgt []*elbv2sdk.TargetGroupTuple) []*elbv2sdk.TargetGroupTuple {
 		if len(tgt) != 1 {
-			return tgt
+			// ELBV2 doesn't preserve the order of target groups within forward action.
+			sortedTGT := append([]*elbv2sdk.TargetGroupTuple(nil), tgt...)
+			sort.SliceStable(sortedTGT, func(i, j int) bool {
+				return awssdk.StringValue(sortedTGT[i].TargetGroupArn) < awssdk.StringValue(sortedTGT[j].TargetGroupArn)
+			})
+			return sortedTGT
 		}
 		singleTG := tgt[0]
 		return []*elbv2sdk.TargetGroupTuple{
@@ -23,10 +29,24 @@ func CompareOptionForTargetGroupTuples() cmp.Option {
 	})
 }
 
+// CompareOptionForTargetGroupStickinessConfig returns the compare option for target group stickiness config,
+// the duration is irrelevant when stickiness is disabled.
+func CompareOptionForTargetGroupStickinessConfig() cmp.Option {
+	return cmpopts.AcyclicTransformer("normalizeTargetGroupStickinessConfig", func(config *elbv2sdk.TargetGroupStickinessConfig) *elbv2sdk.TargetGroupStickinessConfig {
+		if config == nil || awssdk.BoolValue(config.Enabled) {
+			return config
+		}
+		return &elbv2sdk.TargetGroupStickinessConfig{
+			Enabled: awssdk.Bool(false),
+		}
+	})
+}
+
 func CompareOptionForForwardActionConfig() cmp.Option {
 	return cmp.Options{
 		equality.IgnoreLeftHandUnset(elbv2sdk.ForwardActionConfig{}, "TargetGroupStickinessConfig"),
 		CompareOptionForTargetGroupTuples(),
+		CompareOptionForTargetGroupStickinessConfig(),
 	}
 }
 
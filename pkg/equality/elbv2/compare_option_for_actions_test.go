@@ -410,6 +410,128 @@ func TestCompareOptionForRedirectActionConfig(t *testing.T) {
 	}
 }
 
+func TestCompareOptionForForwardActionConfig(t *testing.T) {
+	type args struct {
+		lhs *elbv2sdk.ForwardActionConfig
+		rhs *elbv2sdk.ForwardActionConfig
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "single target group equals irrelevant of weight",
+			args: args{
+				lhs: &elbv2sdk.ForwardActionConfig{
+					TargetGroups: []*elbv2sdk.TargetGroupTuple{
+						{TargetGroupArn: awssdk.String("tg-1")},
+					},
+				},
+				rhs: &elbv2sdk.ForwardActionConfig{
+					TargetGroups: []*elbv2sdk.TargetGroupTuple{
+						{TargetGroupArn: awssdk.String("tg-1"), Weight: awssdk.Int64(1)},
+					},
+					TargetGroupStickinessConfig: &elbv2sdk.TargetGroupStickinessConfig{
+						Enabled: awssdk.Bool(false),
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "multiple target groups equals irrelevant of their order",
+			args: args{
+				lhs: &elbv2sdk.ForwardActionConfig{
+					TargetGroups: []*elbv2sdk.TargetGroupTuple{
+						{TargetGroupArn: awssdk.String("tg-2"), Weight: awssdk.Int64(20)},
+						{TargetGroupArn: awssdk.String("tg-1"), Weight: awssdk.Int64(80)},
+					},
+				},
+				rhs: &elbv2sdk.ForwardActionConfig{
+					TargetGroups: []*elbv2sdk.TargetGroupTuple{
+						{TargetGroupArn: awssdk.String("tg-1"), Weight: awssdk.Int64(80)},
+						{TargetGroupArn: awssdk.String("tg-2"), Weight: awssdk.Int64(20)},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "multiple target groups not equals when weights differ",
+			args: args{
+				lhs: &elbv2sdk.ForwardActionConfig{
+					TargetGroups: []*elbv2sdk.TargetGroupTuple{
+						{TargetGroupArn: awssdk.String("tg-2"), Weight: awssdk.Int64(50)},
+						{TargetGroupArn: awssdk.String("tg-1"), Weight: awssdk.Int64(50)},
+					},
+				},
+				rhs: &elbv2sdk.ForwardActionConfig{
+					TargetGroups: []*elbv2sdk.TargetGroupTuple{
+						{TargetGroupArn: awssdk.String("tg-1"), Weight: awssdk.Int64(80)},
+						{TargetGroupArn: awssdk.String("tg-2"), Weight: awssdk.Int64(20)},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "disabled stickiness equals irrelevant of duration",
+			args: args{
+				lhs: &elbv2sdk.ForwardActionConfig{
+					TargetGroups: []*elbv2sdk.TargetGroupTuple{
+						{TargetGroupArn: awssdk.String("tg-1")},
+					},
+					TargetGroupStickinessConfig: &elbv2sdk.TargetGroupStickinessConfig{
+						Enabled:         awssdk.Bool(false),
+						DurationSeconds: awssdk.Int64(3600),
+					},
+				},
+				rhs: &elbv2sdk.ForwardActionConfig{
+					TargetGroups: []*elbv2sdk.TargetGroupTuple{
+						{TargetGroupArn: awssdk.String("tg-1"), Weight: awssdk.Int64(1)},
+					},
+					TargetGroupStickinessConfig: &elbv2sdk.TargetGroupStickinessConfig{
+						Enabled: awssdk.Bool(false),
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "enabled stickiness not equals when duration differs",
+			args: args{
+				lhs: &elbv2sdk.ForwardActionConfig{
+					TargetGroups: []*elbv2sdk.TargetGroupTuple{
+						{TargetGroupArn: awssdk.String("tg-1")},
+					},
+					TargetGroupStickinessConfig: &elbv2sdk.TargetGroupStickinessConfig{
+						Enabled:         awssdk.Bool(true),
+						DurationSeconds: awssdk.Int64(3600),
+					},
+				},
+				rhs: &elbv2sdk.ForwardActionConfig{
+					TargetGroups: []*elbv2sdk.TargetGroupTuple{
+						{TargetGroupArn: awssdk.String("tg-1"), Weight: awssdk.Int64(1)},
+					},
+					TargetGroupStickinessConfig: &elbv2sdk.TargetGroupStickinessConfig{
+						Enabled:         awssdk.Bool(true),
+						DurationSeconds: awssdk.Int64(60),
+					},
+				},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := CompareOptionForForwardActionConfig()
+			got := cmp.Equal(tt.args.lhs, tt.args.rhs, opts)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestCompareOptionForAction(t *testing.T) {
 	type args struct {
 		lhs elbv2sdk.Action
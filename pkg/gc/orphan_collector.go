@@ -0,0 +1,273 @@
+package gc
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/ec2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/ingress"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/service"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"strings"
+	"time"
+)
+
+const (
+	// AWS TagKey for cluster resources.
+	clusterNameTagKey = "elbv2.k8s.aws/cluster"
+	// AWS TagKey for stacks of Ingress resources.
+	ingressStackTagKey = "ingress.k8s.aws/stack"
+	// AWS TagKey for stacks of Service resources.
+	serviceStackTagKey = "service.k8s.aws/stack"
+
+	resourceKindLoadBalancer  = "LoadBalancer"
+	resourceKindTargetGroup   = "TargetGroup"
+	resourceKindSecurityGroup = "SecurityGroup"
+)
+
+// OrphanCollector collects AWS resources provisioned by this controller whose owning Kubernetes resource no longer exists.
+type OrphanCollector interface {
+	// Collect deletes orphaned AWS resources, or only reports them in dry-run mode.
+	Collect(ctx context.Context) error
+}
+
+// NewDefaultOrphanCollector constructs new defaultOrphanCollector.
+// rgtClient is optional, when specified, LoadBalancers and TargetGroups are discovered by tags via Resource Groups Tagging API.
+func NewDefaultOrphanCollector(elbv2Client services.ELBV2, ec2Client services.EC2, rgtClient services.RGT, k8sClient client.Client,
+	groupLoader ingress.GroupLoader, svcGroupLoader service.GroupLoader, networkingSGManager networking.SecurityGroupManager, networkingSGReconciler networking.SecurityGroupReconciler,
+	dynamicConfigProvider config.DynamicConfigProvider, vpcID string, clusterName string, cfg config.OrphanGCConfig, logger logr.Logger) *defaultOrphanCollector {
+	// the tracking provider is only used by resource managers when creating or updating resources.
+	trackingProvider := tracking.NewDefaultProvider("", clusterName)
+	elbv2TaggingManager := elbv2.NewDefaultTaggingManager(elbv2Client, rgtClient, logger)
+	ec2TaggingManager := ec2.NewDefaultTaggingManager(ec2Client, networkingSGManager, vpcID, logger)
+	// the SecurityGroup manager is only used to delete orphaned SecurityGroups, their rules are never described.
+	ruleDescriptionBuilder := ec2.NewDefaultRuleDescriptionBuilder("", clusterName)
+	return &defaultOrphanCollector{
+		k8sClient:           k8sClient,
+		groupLoader:         groupLoader,
+		svcGroupLoader:      svcGroupLoader,
+		elbv2TaggingManager: elbv2TaggingManager,
+		ec2TaggingManager:   ec2TaggingManager,
+		elbv2LBManager:      elbv2.NewDefaultLoadBalancerManager(elbv2Client, trackingProvider, elbv2TaggingManager, nil, logger),
+		elbv2TGManager:      elbv2.NewDefaultTargetGroupManager(elbv2Client, trackingProvider, elbv2TaggingManager, vpcID, logger),
+		ec2SGManager:        ec2.NewDefaultSecurityGroupManager(ec2Client, trackingProvider, ec2TaggingManager, networkingSGManager, networkingSGReconciler, ruleDescriptionBuilder, dynamicConfigProvider, vpcID, logger),
+		vpcID:               vpcID,
+		clusterName:         clusterName,
+		interval:            cfg.Interval,
+		dryRun:              cfg.DryRun,
+		logger:              logger,
+
+		orphanCandidates: sets.NewString(),
+	}
+}
+
+var _ OrphanCollector = &defaultOrphanCollector{}
+var _ manager.Runnable = &defaultOrphanCollector{}
+
+// default implementation for OrphanCollector.
+// To tolerate stale caches and resources being provisioned, an AWS resource is only deleted if it's found orphaned
+// during two consecutive collections. ListenerRules and Listeners are deleted together with their LoadBalancer.
+type defaultOrphanCollector struct {
+	k8sClient           client.Client
+	groupLoader         ingress.GroupLoader
+	svcGroupLoader      service.GroupLoader
+	elbv2TaggingManager elbv2.TaggingManager
+	ec2TaggingManager   ec2.TaggingManager
+	elbv2LBManager      elbv2.LoadBalancerManager
+	elbv2TGManager      elbv2.TargetGroupManager
+	ec2SGManager        ec2.SecurityGroupManager
+	vpcID               string
+	clusterName         string
+	interval            time.Duration
+	dryRun              bool
+	logger              logr.Logger
+
+	// the IDs of AWS resources found orphaned during last collection.
+	orphanCandidates sets.String
+}
+
+// Start runs the collection periodically until stopped.
+func (c *defaultOrphanCollector) Start(stop <-chan struct{}) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-stop
+		cancel()
+	}()
+	wait.Until(func() {
+		if err := c.Collect(ctx); err != nil {
+			c.logger.Error(err, "failed to collect orphaned AWS resources")
+		}
+	}, c.interval, stop)
+	return nil
+}
+
+func (c *defaultOrphanCollector) Collect(ctx context.Context) error {
+	clusterTagFilter := tracking.TagFilter{clusterNameTagKey: []string{c.clusterName}}
+	sdkLBs, err := c.elbv2TaggingManager.ListLoadBalancers(ctx, clusterTagFilter)
+	if err != nil {
+		return err
+	}
+	sdkTGs, err := c.elbv2TaggingManager.ListTargetGroups(ctx, clusterTagFilter)
+	if err != nil {
+		return err
+	}
+	sdkSGs, err := c.ec2TaggingManager.ListSecurityGroups(ctx, clusterTagFilter)
+	if err != nil {
+		return err
+	}
+	tgARNsInUse, err := c.listTargetGroupARNsInUse(ctx)
+	if err != nil {
+		return err
+	}
+
+	resolver := &stackOwnerResolver{
+		groupLoader:    c.groupLoader,
+		svcGroupLoader: c.svcGroupLoader,
+		orphanByStack:  make(map[string]bool),
+	}
+	orphanCandidates := sets.NewString()
+	// LoadBalancers are deleted first, since TargetGroups and SecurityGroups cannot be deleted while in use by them.
+	for _, sdkLB := range sdkLBs {
+		lbARN := awssdk.StringValue(sdkLB.LoadBalancer.LoadBalancerArn)
+		if awssdk.StringValue(sdkLB.LoadBalancer.VpcId) != c.vpcID {
+			continue
+		}
+		c.collectResource(ctx, resolver, orphanCandidates, resourceKindLoadBalancer, lbARN, sdkLB.Tags, func() error {
+			return c.elbv2LBManager.Delete(ctx, sdkLB)
+		})
+	}
+	for _, sdkTG := range sdkTGs {
+		tgARN := awssdk.StringValue(sdkTG.TargetGroup.TargetGroupArn)
+		if awssdk.StringValue(sdkTG.TargetGroup.VpcId) != c.vpcID || tgARNsInUse.Has(tgARN) {
+			continue
+		}
+		c.collectResource(ctx, resolver, orphanCandidates, resourceKindTargetGroup, tgARN, sdkTG.Tags, func() error {
+			return c.elbv2TGManager.Delete(ctx, sdkTG)
+		})
+	}
+	for _, sdkSG := range sdkSGs {
+		c.collectResource(ctx, resolver, orphanCandidates, resourceKindSecurityGroup, sdkSG.SecurityGroupID, sdkSG.Tags, func() error {
+			return c.ec2SGManager.Delete(ctx, sdkSG)
+		})
+	}
+	c.orphanCandidates = orphanCandidates
+	return nil
+}
+
+// collectResource deletes the AWS resource if it has been found orphaned during two consecutive collections.
+// Failures to resolve owner or delete are logged and retried during next collection.
+func (c *defaultOrphanCollector) collectResource(ctx context.Context, resolver *stackOwnerResolver, orphanCandidates sets.String,
+	resKind string, resID string, tags map[string]string, deleteFunc func() error) {
+	orphaned, err := resolver.isOrphaned(ctx, tags)
+	if err != nil {
+		c.logger.Error(err, "failed to resolve owner of AWS resource", "kind", resKind, "id", resID)
+		return
+	}
+	if !orphaned {
+		return
+	}
+	if !c.orphanCandidates.Has(resID) {
+		c.logger.Info("found orphaned AWS resource", "kind", resKind, "id", resID, "tags", tags)
+		orphanCandidates.Insert(resID)
+		return
+	}
+	if c.dryRun {
+		c.logger.Info("dry-run: would delete orphaned AWS resource", "kind", resKind, "id", resID, "tags", tags)
+		orphanCandidates.Insert(resID)
+		return
+	}
+	c.logger.Info("deleting orphaned AWS resource", "kind", resKind, "id", resID, "tags", tags)
+	if err := deleteFunc(); err != nil {
+		c.logger.Error(err, "failed to delete orphaned AWS resource", "kind", resKind, "id", resID)
+		orphanCandidates.Insert(resID)
+	}
+}
+
+// listTargetGroupARNsInUse returns the ARNs of TargetGroups referenced by TargetGroupBindings.
+// These TargetGroups are cleaned up by owners of TargetGroupBindings instead.
+func (c *defaultOrphanCollector) listTargetGroupARNsInUse(ctx context.Context) (sets.String, error) {
+	tgbList := &elbv2api.TargetGroupBindingList{}
+	if err := c.k8sClient.List(ctx, tgbList); err != nil {
+		return nil, err
+	}
+	tgARNs := sets.NewString()
+	for _, tgb := range tgbList.Items {
+		tgARNs.Insert(tgb.Spec.TargetGroupARN)
+	}
+	return tgARNs, nil
+}
+
+// stackOwnerResolver resolves whether the owning Kubernetes resource of a stack exists.
+// results are cached per stack during a single collection.
+type stackOwnerResolver struct {
+	groupLoader    ingress.GroupLoader
+	svcGroupLoader service.GroupLoader
+	orphanByStack  map[string]bool
+}
+
+// isOrphaned checks whether AWS resources with specified tags are orphaned.
+// AWS resources without stack tags are not considered as orphaned.
+func (r *stackOwnerResolver) isOrphaned(ctx context.Context, tags map[string]string) (bool, error) {
+	var stackKey string
+	var resolveFunc func() (bool, error)
+	if stackID, ok := tags[ingressStackTagKey]; ok {
+		stackKey = ingressStackTagKey + "=" + stackID
+		resolveFunc = func() (bool, error) {
+			return r.isIngressStackOrphaned(ctx, stackID)
+		}
+	} else if stackID, ok := tags[serviceStackTagKey]; ok {
+		stackKey = serviceStackTagKey + "=" + stackID
+		resolveFunc = func() (bool, error) {
+			return r.isServiceStackOrphaned(ctx, stackID)
+		}
+	} else {
+		return false, nil
+	}
+
+	if orphaned, ok := r.orphanByStack[stackKey]; ok {
+		return orphaned, nil
+	}
+	orphaned, err := resolveFunc()
+	if err != nil {
+		return false, err
+	}
+	r.orphanByStack[stackKey] = orphaned
+	return orphaned, nil
+}
+
+// isIngressStackOrphaned checks whether the IngressGroup for stack has no members and no inactive members holding finalizers.
+func (r *stackOwnerResolver) isIngressStackOrphaned(ctx context.Context, stackID string) (bool, error) {
+	groupID := ingress.NewGroupIDForExplicitGroup(stackID)
+	if parts := strings.SplitN(stackID, "/", 2); len(parts) == 2 {
+		groupID = ingress.NewGroupIDForImplicitGroup(types.NamespacedName{Namespace: parts[0], Name: parts[1]})
+	}
+	ingGroup, err := r.groupLoader.Load(ctx, groupID)
+	if err != nil {
+		return false, err
+	}
+	return len(ingGroup.Members) == 0 && len(ingGroup.InactiveMembers) == 0, nil
+}
+
+// isServiceStackOrphaned checks whether the Service group for stack has no members and no inactive members holding finalizers.
+func (r *stackOwnerResolver) isServiceStackOrphaned(ctx context.Context, stackID string) (bool, error) {
+	groupID := service.NewGroupIDForExplicitGroup(stackID)
+	if parts := strings.SplitN(stackID, "/", 2); len(parts) == 2 {
+		groupID = service.NewGroupIDForImplicitGroup(types.NamespacedName{Namespace: parts[0], Name: parts[1]})
+	}
+	svcGroup, err := r.svcGroupLoader.Load(ctx, groupID)
+	if err != nil {
+		return false, err
+	}
+	return len(svcGroup.Members) == 0 && len(svcGroup.InactiveMembers) == 0, nil
+}
@@ -0,0 +1,266 @@
+package gc
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	mock_networking "sigs.k8s.io/aws-load-balancer-controller/mocks/networking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/ingress"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/service"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_stackOwnerResolver_isOrphaned(t *testing.T) {
+	groupMember := &networkingv1beta1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "ing-1",
+			Annotations: map[string]string{
+				"kubernetes.io/ingress.class":          "alb",
+				"alb.ingress.kubernetes.io/group.name": "awesome-group",
+			},
+		},
+	}
+	inactiveGroupMember := &networkingv1beta1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "ing-2",
+			Annotations: map[string]string{
+				"kubernetes.io/ingress.class": "nginx",
+			},
+			Finalizers: []string{"group.ingress.k8s.aws/inactive-group"},
+		},
+	}
+	standaloneIngress := &networkingv1beta1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "ing-3",
+			Annotations: map[string]string{
+				"kubernetes.io/ingress.class": "alb",
+			},
+		},
+	}
+	svcWithFinalizer := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  "awesome-ns",
+			Name:       "svc-1",
+			Finalizers: []string{"service.k8s.aws/resources"},
+		},
+	}
+	svcWithoutFinalizer := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "svc-2",
+		},
+	}
+	svcGroupMember := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "svc-4",
+			Annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-type":       "nlb-ip",
+				"service.beta.kubernetes.io/aws-load-balancer-group-name": "awesome-svc-group",
+			},
+		},
+	}
+	tests := []struct {
+		name string
+		tags map[string]string
+		want bool
+	}{
+		{
+			name: "explicit IngressGroup with members",
+			tags: map[string]string{"ingress.k8s.aws/stack": "awesome-group"},
+			want: false,
+		},
+		{
+			name: "explicit IngressGroup with inactive members",
+			tags: map[string]string{"ingress.k8s.aws/stack": "inactive-group"},
+			want: false,
+		},
+		{
+			name: "explicit IngressGroup without members",
+			tags: map[string]string{"ingress.k8s.aws/stack": "deleted-group"},
+			want: true,
+		},
+		{
+			name: "implicit IngressGroup with Ingress",
+			tags: map[string]string{"ingress.k8s.aws/stack": "awesome-ns/ing-3"},
+			want: false,
+		},
+		{
+			name: "implicit IngressGroup without Ingress",
+			tags: map[string]string{"ingress.k8s.aws/stack": "awesome-ns/ing-4"},
+			want: true,
+		},
+		{
+			name: "Service with finalizer",
+			tags: map[string]string{"service.k8s.aws/stack": "awesome-ns/svc-1"},
+			want: false,
+		},
+		{
+			name: "Service without finalizer",
+			tags: map[string]string{"service.k8s.aws/stack": "awesome-ns/svc-2"},
+			want: true,
+		},
+		{
+			name: "Service not found",
+			tags: map[string]string{"service.k8s.aws/stack": "awesome-ns/svc-3"},
+			want: true,
+		},
+		{
+			name: "explicit Service group with members",
+			tags: map[string]string{"service.k8s.aws/stack": "awesome-svc-group"},
+			want: false,
+		},
+		{
+			name: "explicit Service group without members",
+			tags: map[string]string{"service.k8s.aws/stack": "deleted-svc-group"},
+			want: true,
+		},
+		{
+			name: "resource without stack tags",
+			tags: map[string]string{"elbv2.k8s.aws/cluster": "cluster-name"},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			for _, ing := range []*networkingv1beta1.Ingress{groupMember, inactiveGroupMember, standaloneIngress} {
+				assert.NoError(t, k8sClient.Create(ctx, ing.DeepCopy()))
+			}
+			for _, svc := range []*corev1.Service{svcWithFinalizer, svcWithoutFinalizer, svcGroupMember} {
+				assert.NoError(t, k8sClient.Create(ctx, svc.DeepCopy()))
+			}
+			annotationParser := annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
+			svcAnnotationParser := annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io")
+			namespaceMatcher := k8s.NewDefaultNamespaceMatcher(k8sClient, labels.Everything())
+			r := &stackOwnerResolver{
+				groupLoader:    ingress.NewDefaultGroupLoader(k8sClient, record.NewFakeRecorder(10), annotationParser, namespaceMatcher, ""),
+				svcGroupLoader: service.NewDefaultGroupLoader(k8sClient, svcAnnotationParser, namespaceMatcher),
+				orphanByStack:  make(map[string]bool),
+			}
+			got, err := r.isOrphaned(ctx, tt.tags)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_defaultOrphanCollector_Collect(t *testing.T) {
+	orphanedLBARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/k8s-orphaned/1111111111"
+	ownedLBARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/k8s-owned/2222222222"
+	otherVPCLBARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/k8s-other-vpc/3333333333"
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  "awesome-ns",
+			Name:       "svc-1",
+			Finalizers: []string{"service.k8s.aws/resources"},
+		},
+	}
+	tests := []struct {
+		name        string
+		dryRun      bool
+		collections int
+		wantDeletes int
+	}{
+		{
+			name:        "orphaned resource is not deleted during first collection",
+			collections: 1,
+			wantDeletes: 0,
+		},
+		{
+			name:        "orphaned resource is deleted during second collection",
+			collections: 2,
+			wantDeletes: 1,
+		},
+		{
+			name:        "orphaned resource is never deleted in dry-run mode",
+			dryRun:      true,
+			collections: 3,
+			wantDeletes: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			elbv2Client := mock_services.NewMockELBV2(ctrl)
+			ec2Client := mock_services.NewMockEC2(ctrl)
+			networkingSGManager := mock_networking.NewMockSecurityGroupManager(ctrl)
+			networkingSGReconciler := networking.NewDefaultSecurityGroupReconciler(networkingSGManager, nil, &log.NullLogger{})
+			elbv2Client.EXPECT().DescribeLoadBalancersAsList(gomock.Any(), gomock.Any()).Return([]*elbv2sdk.LoadBalancer{
+				{LoadBalancerArn: awssdk.String(orphanedLBARN), VpcId: awssdk.String("vpc-xxxxxxx")},
+				{LoadBalancerArn: awssdk.String(ownedLBARN), VpcId: awssdk.String("vpc-xxxxxxx")},
+				{LoadBalancerArn: awssdk.String(otherVPCLBARN), VpcId: awssdk.String("vpc-yyyyyyy")},
+			}, nil).Times(tt.collections)
+			elbv2Client.EXPECT().DescribeTagsWithContext(gomock.Any(), gomock.Any()).Return(&elbv2sdk.DescribeTagsOutput{
+				TagDescriptions: []*elbv2sdk.TagDescription{
+					{
+						ResourceArn: awssdk.String(orphanedLBARN),
+						Tags: []*elbv2sdk.Tag{
+							{Key: awssdk.String("elbv2.k8s.aws/cluster"), Value: awssdk.String("cluster-name")},
+							{Key: awssdk.String("ingress.k8s.aws/stack"), Value: awssdk.String("deleted-group")},
+						},
+					},
+					{
+						ResourceArn: awssdk.String(ownedLBARN),
+						Tags: []*elbv2sdk.Tag{
+							{Key: awssdk.String("elbv2.k8s.aws/cluster"), Value: awssdk.String("cluster-name")},
+							{Key: awssdk.String("service.k8s.aws/stack"), Value: awssdk.String("awesome-ns/svc-1")},
+						},
+					},
+					{
+						ResourceArn: awssdk.String(otherVPCLBARN),
+						Tags: []*elbv2sdk.Tag{
+							{Key: awssdk.String("elbv2.k8s.aws/cluster"), Value: awssdk.String("cluster-name")},
+							{Key: awssdk.String("ingress.k8s.aws/stack"), Value: awssdk.String("deleted-group")},
+						},
+					},
+				},
+			}, nil).Times(tt.collections)
+			elbv2Client.EXPECT().DescribeTargetGroupsAsList(gomock.Any(), gomock.Any()).Return(nil, nil).Times(tt.collections)
+			networkingSGManager.EXPECT().FetchSGInfosByRequest(gomock.Any(), gomock.Any()).Return(nil, nil).Times(tt.collections)
+			elbv2Client.EXPECT().DeleteLoadBalancerWithContext(gomock.Any(), &elbv2sdk.DeleteLoadBalancerInput{
+				LoadBalancerArn: awssdk.String(orphanedLBARN),
+			}).Return(&elbv2sdk.DeleteLoadBalancerOutput{}, nil).Times(tt.wantDeletes)
+
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			assert.NoError(t, k8sClient.Create(ctx, svc.DeepCopy()))
+			annotationParser := annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
+			groupLoader := ingress.NewDefaultGroupLoader(k8sClient, record.NewFakeRecorder(10), annotationParser, k8s.NewDefaultNamespaceMatcher(k8sClient, labels.Everything()), "")
+			svcGroupLoader := service.NewDefaultGroupLoader(k8sClient, annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io"), k8s.NewDefaultNamespaceMatcher(k8sClient, labels.Everything()))
+			c := NewDefaultOrphanCollector(elbv2Client, ec2Client, nil, k8sClient, groupLoader, svcGroupLoader, networkingSGManager, networkingSGReconciler,
+				config.NewStaticDynamicConfigProvider(config.DynamicConfig{}), "vpc-xxxxxxx", "cluster-name", config.OrphanGCConfig{DryRun: tt.dryRun}, &log.NullLogger{})
+			for i := 0; i < tt.collections; i++ {
+				assert.NoError(t, c.Collect(ctx))
+			}
+		})
+	}
+}
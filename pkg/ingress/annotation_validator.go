@@ -0,0 +1,161 @@
+package ingress
+
+import (
+	"github.com/pkg/errors"
+	"regexp"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	zonalshiftmodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/zonalshift"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	"time"
+)
+
+const (
+	// suffix prefixes of annotations that configures the backend of Ingress rules by service name.
+	annotationSuffixPrefixActions      = "actions."
+	annotationSuffixPrefixConditions   = "conditions."
+	annotationSuffixPrefixAuthSettings = "auth-settings."
+	// suffix prefix of annotations that configures the attributes of listeners by protocol and port, like listener-attributes.HTTPS-443.
+	annotationSuffixPrefixListenerAttributes = "listener-attributes."
+)
+
+// successCodesPattern matches a success code or range of success codes, like 200 or 200-299.
+var successCodesPattern = regexp.MustCompile(`^[0-9]+(-[0-9]+)?$`)
+
+// NewAnnotationValidator constructs the validator for Ingress annotations.
+// the same annotations are validated on Services as well, since backend services of Ingresses can override target group settings.
+func NewAnnotationValidator() annotations.Validator {
+	return annotations.NewSuffixAnnotationValidator([]string{annotations.AnnotationPrefixIngress},
+		map[string]annotations.ValueValidator{
+			annotations.IngressSuffixGroupName:                validateGroupName,
+			annotations.IngressSuffixGroupOrder:               annotations.ValidateInt64InRange(minGroupOrder, maxGroupOder),
+			annotations.IngressSuffixTags:                     annotations.ValidateStringMap,
+			annotations.IngressSuffixIPAddressType:            annotations.ValidateOneOf(string(elbv2model.IPAddressTypeIPV4), string(elbv2model.IPAddressTypeDualStack), string(elbv2model.IPAddressTypeDualStackWithoutPublicIPV4)),
+			annotations.IngressSuffixScheme:                   annotations.ValidateOneOf(string(elbv2model.LoadBalancerSchemeInternetFacing), string(elbv2model.LoadBalancerSchemeInternal)),
+			annotations.IngressSuffixSubnetLocale:             annotations.ValidateOneOf(string(networking.SubnetLocaleTypeAvailabilityZone), string(networking.SubnetLocaleTypeLocalZone), string(networking.SubnetLocaleTypeWavelengthZone), string(networking.SubnetLocaleTypeOutpost)),
+			annotations.IngressSuffixSubnetTags:               annotations.ValidateStringMap,
+			annotations.IngressSuffixCustomerOwnedIPv4Pool:    annotations.ValidateNonEmpty,
+			annotations.IngressSuffixLoadBalancerAttributes:   annotations.ValidateStringMapWith(validateLoadBalancerAttributes),
+			annotations.IngressSuffixLoadBalancerARN:          validateLoadBalancerARN,
+			annotations.IngressSuffixShieldAdvancedProtection: annotations.ValidateBool,
+			annotations.IngressSuffixManageRoute53Records:     annotations.ValidateBool,
+			annotations.IngressSuffixZonalShiftAwayFrom:       zonalshiftmodel.ValidateZonalShiftAwayFrom,
+			annotations.IngressSuffixZonalShiftExpiresIn:      zonalshiftmodel.ValidateZonalShiftExpiresIn,
+			annotations.IngressSuffixListenPorts:              validateListenPortsAnnotation,
+			annotations.IngressSuffixInboundCIDRs:             annotations.ValidateStringSlice(annotations.ValidateCIDR),
+			annotations.IngressSuffixCertificateTags:          annotations.ValidateStringMap,
+			annotations.IngressSuffixTargetType:               annotations.ValidateOneOf(string(elbv2model.TargetTypeInstance), string(elbv2model.TargetTypeIP)),
+			annotations.IngressSuffixBackendProtocol:          annotations.ValidateOneOf(string(elbv2model.ProtocolHTTP), string(elbv2model.ProtocolHTTPS)),
+			annotations.IngressSuffixBackendProtocolVersion:   annotations.ValidateOneOf(string(elbv2model.ProtocolVersionHTTP1), string(elbv2model.ProtocolVersionHTTP2), string(elbv2model.ProtocolVersionGRPC)),
+			annotations.IngressSuffixTargetGroupAttributes:    annotations.ValidateStringMap,
+			annotations.IngressSuffixTargetGroupCrossZone: func(value string) error {
+				return elbv2model.ValidateTargetGroupCrossZone(value, nil)
+			},
+			annotations.IngressSuffixTargetGroupAnomalyMitigation: annotations.ValidateOneOf(elbv2model.TargetGroupAnomalyMitigationOn, elbv2model.TargetGroupAnomalyMitigationOff),
+			annotations.IngressSuffixCodeDeployBlueGreen:          annotations.ValidateBool,
+			annotations.IngressSuffixCodeDeployActiveColor:        annotations.ValidateOneOf(codeDeployColorBlue, codeDeployColorGreen),
+			annotations.IngressSuffixCodeDeployPausedUntil:        validateRFC3339Time,
+			annotations.IngressSuffixHealthCheckProtocol:          annotations.ValidateOneOf(string(elbv2model.ProtocolHTTP), string(elbv2model.ProtocolHTTPS)),
+			annotations.IngressSuffixHealthCheckIntervalSeconds:   annotations.ValidateInt64InRange(5, 300),
+			annotations.IngressSuffixHealthCheckTimeoutSeconds:    annotations.ValidateInt64InRange(2, 120),
+			annotations.IngressSuffixHealthyThresholdCount:        annotations.ValidateInt64InRange(2, 10),
+			annotations.IngressSuffixUnhealthyThresholdCount:      annotations.ValidateInt64InRange(2, 10),
+			annotations.IngressSuffixSuccessCodes:                 annotations.ValidateStringSlice(validateSuccessCodes),
+			annotations.IngressSuffixAuthType:                     annotations.ValidateOneOf(string(AuthTypeNone), string(AuthTypeCognito), string(AuthTypeOIDC)),
+			annotations.IngressSuffixTargetGroupSlowStart: func(value string) error {
+				return validateTargetGroupSlowStart(value, nil)
+			},
+			annotations.IngressSuffixTargetGroupStickinessAppCookieName:            elbv2model.ValidateTargetGroupAppCookieName,
+			annotations.IngressSuffixTargetGroupStickinessAppCookieDurationSeconds: elbv2model.ValidateTargetGroupAppCookieDuration,
+			annotations.IngressSuffixTargetGroupWeightRamp: annotations.ValidateJSON(func() interface{} {
+				return &WeightRampConfig{}
+			}, func(obj interface{}) error {
+				return obj.(*WeightRampConfig).validate()
+			}),
+			annotations.IngressSuffixGroupDefaultAction: annotations.ValidateJSON(func() interface{} {
+				return &Action{}
+			}, func(obj interface{}) error {
+				return validateGroupDefaultAction(*obj.(*Action))
+			}),
+			annotations.IngressSuffixAuthIDPCognito: annotations.ValidateJSON(func() interface{} {
+				return &AuthIDPConfigCognito{}
+			}, nil),
+			annotations.IngressSuffixAuthIDPOIDC: annotations.ValidateJSON(func() interface{} {
+				return &AuthIDPConfigOIDC{}
+			}, nil),
+			annotations.IngressSuffixAuthOnUnauthenticatedRequest: annotations.ValidateOneOf("authenticate", "allow", "deny"),
+			annotations.IngressSuffixAuthSessionTimeout:           annotations.ValidateInt64InRange(1, defaultAuthSessionTimeout),
+			annotations.IngressSuffixDriftSyncPeriod:              annotations.ValidateDurationAtLeast(config.MinDriftSyncPeriod),
+			annotations.IngressSuffixWildcardHostMatchApex:        annotations.ValidateBool,
+			annotations.IngressSuffixTTL:                          annotations.ValidateDurationAtLeast(config.MinResourceTTL),
+			annotations.IngressSuffixTTLDeleteObject:              annotations.ValidateBool,
+
+			annotations.IngressSuffixLoadBalancerAttributesOwner:       annotations.ValidateBool,
+			annotations.IngressSuffixLoadBalancerAttributesMergePolicy: annotations.ValidateStringMapWith(validateLoadBalancerAttributesMergePolicies),
+
+			annotations.IngressSuffixTargetGroupHealthDNSFailoverMinHealthyTargetsCount:                validateTargetGroupHealthRequirementAnnotation(annotations.IngressSuffixTargetGroupHealthDNSFailoverMinHealthyTargetsCount),
+			annotations.IngressSuffixTargetGroupHealthDNSFailoverMinHealthyTargetsPercentage:           validateTargetGroupHealthRequirementAnnotation(annotations.IngressSuffixTargetGroupHealthDNSFailoverMinHealthyTargetsPercentage),
+			annotations.IngressSuffixTargetGroupHealthUnhealthyStateRoutingMinHealthyTargetsCount:      validateTargetGroupHealthRequirementAnnotation(annotations.IngressSuffixTargetGroupHealthUnhealthyStateRoutingMinHealthyTargetsCount),
+			annotations.IngressSuffixTargetGroupHealthUnhealthyStateRoutingMinHealthyTargetsPercentage: validateTargetGroupHealthRequirementAnnotation(annotations.IngressSuffixTargetGroupHealthUnhealthyStateRoutingMinHealthyTargetsPercentage),
+		},
+		map[string]annotations.ValueValidator{
+			annotationSuffixPrefixActions: annotations.ValidateJSON(func() interface{} {
+				return &Action{}
+			}, func(obj interface{}) error {
+				return obj.(*Action).validate()
+			}),
+			annotationSuffixPrefixConditions: annotations.ValidateJSON(func() interface{} {
+				return &[]RuleCondition{}
+			}, func(obj interface{}) error {
+				for index, condition := range *obj.(*[]RuleCondition) {
+					if err := condition.validate(); err != nil {
+						return errors.Wrapf(err, "invalid condition %v", index)
+					}
+				}
+				return nil
+			}),
+			annotationSuffixPrefixAuthSettings: annotations.ValidateJSON(func() interface{} {
+				return &AuthSettings{}
+			}, func(obj interface{}) error {
+				return obj.(*AuthSettings).validate()
+			}),
+			annotationSuffixPrefixListenerAttributes: annotations.ValidateStringMapWith(elbv2model.ValidateApplicationListenerAttributes),
+		},
+	)
+}
+
+// validateGroupDefaultAction checks the listener default action for IngressGroup, which can only be fixed-response or redirect.
+func validateGroupDefaultAction(action Action) error {
+	if action.Type != ActionTypeFixedResponse && action.Type != ActionTypeRedirect {
+		return errors.Errorf("type must be within [%v, %v]: %v", ActionTypeFixedResponse, ActionTypeRedirect, action.Type)
+	}
+	return action.validate()
+}
+
+func validateListenPortsAnnotation(value string) error {
+	_, err := parseListenPorts(value)
+	return err
+}
+
+func validateRFC3339Time(value string) error {
+	if _, err := time.Parse(time.RFC3339, value); err != nil {
+		return errors.New("must be a RFC3339 time like 2006-01-02T15:04:05Z")
+	}
+	return nil
+}
+
+func validateSuccessCodes(value string) error {
+	if !successCodesPattern.MatchString(value) {
+		return errors.New("must be a code or range of codes like 200 or 200-299")
+	}
+	return nil
+}
+
+// validateTargetGroupHealthRequirementAnnotation returns ValueValidator for the target group health requirement annotation.
+func validateTargetGroupHealthRequirementAnnotation(annotationSuffix string) annotations.ValueValidator {
+	attrKey := targetGroupHealthAttributeKeyByAnnotation[annotationSuffix]
+	return func(value string) error {
+		return elbv2model.ValidateTargetGroupHealthRequirement(attrKey, value, nil)
+	}
+}
@@ -24,12 +24,17 @@ const (
 	defaultImportedCertDomainsCacheTTL = 5 * time.Minute
 	// the domain names for private certificates won't change, cache for a longer time.
 	defaultPrivateCertDomainsCacheTTL = 10 * time.Hour
+	// the tags for certificates can be changed by external tooling at any time, cache for 1 minute.
+	defaultCertTagsCacheTTL = 1 * time.Minute
 )
 
 // CertDiscovery is responsible for auto-discover TLS certificates for tls hosts.
 type CertDiscovery interface {
 	// Discover will try to find valid certificateARNs for each tlsHost.
 	Discover(ctx context.Context, tlsHosts []string) ([]string, error)
+
+	// DiscoverByTags will try to find valid certificateARNs that contains all specified tags.
+	DiscoverByTags(ctx context.Context, tags map[string]string) ([]string, error)
 }
 
 // NewACMCertDiscovery constructs new acmCertDiscovery
@@ -44,6 +49,8 @@ func NewACMCertDiscovery(acmClient services.ACM, logger logr.Logger) *acmCertDis
 		certDomainsCache:            cache.NewExpiring(),
 		importedCertDomainsCacheTTL: defaultImportedCertDomainsCacheTTL,
 		privateCertDomainsCacheTTL:  defaultPrivateCertDomainsCacheTTL,
+		certTagsCache:               cache.NewExpiring(),
+		certTagsCacheTTL:            defaultCertTagsCacheTTL,
 	}
 }
 
@@ -54,13 +61,15 @@ type acmCertDiscovery struct {
 	acmClient services.ACM
 	logger    logr.Logger
 
-	// mutex to serialize the call to loadDomainsForAllCertificates
+	// mutex to serialize the call to loadDomainsForAllCertificates and loadTagsForAllCertificates
 	loadDomainsByCertARNMutex   sync.Mutex
 	certARNsCache               *cache.Expiring
 	certARNsCacheTTL            time.Duration
 	certDomainsCache            *cache.Expiring
 	importedCertDomainsCacheTTL time.Duration
 	privateCertDomainsCacheTTL  time.Duration
+	certTagsCache               *cache.Expiring
+	certTagsCacheTTL            time.Duration
 }
 
 func (d *acmCertDiscovery) Discover(ctx context.Context, tlsHosts []string) ([]string, error) {
@@ -91,6 +100,23 @@ func (d *acmCertDiscovery) Discover(ctx context.Context, tlsHosts []string) ([]s
 	return certARNs.List(), nil
 }
 
+func (d *acmCertDiscovery) DiscoverByTags(ctx context.Context, tags map[string]string) ([]string, error) {
+	tagsByCertARN, err := d.loadTagsForAllCertificates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	certARNs := sets.NewString()
+	for certARN, certTags := range tagsByCertARN {
+		if d.certTagsMatchesSelector(certTags, tags) {
+			certARNs.Insert(certARN)
+		}
+	}
+	if len(certARNs) == 0 {
+		return nil, errors.Errorf("none certificate found for tags: %v", tags)
+	}
+	return certARNs.List(), nil
+}
+
 func (d *acmCertDiscovery) loadDomainsForAllCertificates(ctx context.Context) (map[string]sets.String, error) {
 	d.loadDomainsByCertARNMutex.Lock()
 	defer d.loadDomainsByCertARNMutex.Unlock()
@@ -153,6 +179,54 @@ func (d *acmCertDiscovery) loadDomainsForCertificate(ctx context.Context, certAR
 	return domains, nil
 }
 
+func (d *acmCertDiscovery) loadTagsForAllCertificates(ctx context.Context) (map[string]map[string]string, error) {
+	d.loadDomainsByCertARNMutex.Lock()
+	defer d.loadDomainsByCertARNMutex.Unlock()
+
+	certARNs, err := d.loadAllCertificateARNs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tagsByCertARN := make(map[string]map[string]string, len(certARNs))
+	for _, certARN := range certARNs {
+		certTags, err := d.loadTagsForCertificate(ctx, certARN)
+		if err != nil {
+			return nil, err
+		}
+		tagsByCertARN[certARN] = certTags
+	}
+	return tagsByCertARN, nil
+}
+
+func (d *acmCertDiscovery) loadTagsForCertificate(ctx context.Context, certARN string) (map[string]string, error) {
+	if rawCacheItem, ok := d.certTagsCache.Get(certARN); ok {
+		return rawCacheItem.(map[string]string), nil
+	}
+	req := &acm.ListTagsForCertificateInput{
+		CertificateArn: aws.String(certARN),
+	}
+	resp, err := d.acmClient.ListTagsForCertificateWithContext(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	certTags := make(map[string]string, len(resp.Tags))
+	for _, tag := range resp.Tags {
+		certTags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+	d.certTagsCache.Set(certARN, certTags, d.certTagsCacheTTL)
+	return certTags, nil
+}
+
+// certTagsMatchesSelector checks whether certTags contains all tags from selector.
+func (d *acmCertDiscovery) certTagsMatchesSelector(certTags map[string]string, selector map[string]string) bool {
+	for key, value := range selector {
+		if certValue, ok := certTags[key]; !ok || certValue != value {
+			return false
+		}
+	}
+	return true
+}
+
 func (d *acmCertDiscovery) domainMatchesHost(domainName string, tlsHost string) bool {
 	if strings.HasPrefix(domainName, "*.") {
 		ds := strings.Split(domainName, ".")
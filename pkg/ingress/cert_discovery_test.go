@@ -1,7 +1,10 @@
 package ingress
 
 import (
+	"context"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/cache"
 	"testing"
 )
 
@@ -64,3 +67,147 @@ func Test_acmCertDiscovery_domainMatchesHost(t *testing.T) {
 		})
 	}
 }
+
+func Test_acmCertDiscovery_certTagsMatchesSelector(t *testing.T) {
+	type args struct {
+		certTags map[string]string
+		selector map[string]string
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "all selector tags matches",
+			args: args{
+				certTags: map[string]string{
+					"app":  "web",
+					"env":  "prod",
+					"team": "platform",
+				},
+				selector: map[string]string{
+					"app": "web",
+					"env": "prod",
+				},
+			},
+			want: true,
+		},
+		{
+			name: "selector tag value didn't matches",
+			args: args{
+				certTags: map[string]string{
+					"app": "web",
+					"env": "dev",
+				},
+				selector: map[string]string{
+					"app": "web",
+					"env": "prod",
+				},
+			},
+			want: false,
+		},
+		{
+			name: "selector tag key didn't exists",
+			args: args{
+				certTags: map[string]string{
+					"app": "web",
+				},
+				selector: map[string]string{
+					"app": "web",
+					"env": "prod",
+				},
+			},
+			want: false,
+		},
+		{
+			name: "certificate without tags",
+			args: args{
+				certTags: nil,
+				selector: map[string]string{
+					"app": "web",
+				},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &acmCertDiscovery{}
+			got := d.certTagsMatchesSelector(tt.args.certTags, tt.args.selector)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_acmCertDiscovery_DiscoverByTags(t *testing.T) {
+	type args struct {
+		tags map[string]string
+	}
+	tests := []struct {
+		name          string
+		certARNs      []string
+		tagsByCertARN map[string]map[string]string
+		args          args
+		want          []string
+		wantErr       error
+	}{
+		{
+			name:     "single certificate matches",
+			certARNs: []string{"cert-1", "cert-2"},
+			tagsByCertARN: map[string]map[string]string{
+				"cert-1": {"app": "web"},
+				"cert-2": {"app": "api"},
+			},
+			args: args{
+				tags: map[string]string{"app": "web"},
+			},
+			want: []string{"cert-1"},
+		},
+		{
+			name:     "multiple certificate matches",
+			certARNs: []string{"cert-1", "cert-2", "cert-3"},
+			tagsByCertARN: map[string]map[string]string{
+				"cert-1": {"app": "web", "version": "v1"},
+				"cert-2": {"app": "api"},
+				"cert-3": {"app": "web", "version": "v2"},
+			},
+			args: args{
+				tags: map[string]string{"app": "web"},
+			},
+			want: []string{"cert-1", "cert-3"},
+		},
+		{
+			name:     "none certificate matches",
+			certARNs: []string{"cert-1"},
+			tagsByCertARN: map[string]map[string]string{
+				"cert-1": {"app": "api"},
+			},
+			args: args{
+				tags: map[string]string{"app": "web"},
+			},
+			wantErr: errors.New("none certificate found for tags: map[app:web]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &acmCertDiscovery{
+				certARNsCache:    cache.NewExpiring(),
+				certARNsCacheTTL: defaultCertARNsCacheTTL,
+				certTagsCache:    cache.NewExpiring(),
+				certTagsCacheTTL: defaultCertTagsCacheTTL,
+			}
+			d.certARNsCache.Set(certARNsCacheKey, tt.certARNs, d.certARNsCacheTTL)
+			for certARN, certTags := range tt.tagsByCertARN {
+				d.certTagsCache.Set(certARN, certTags, d.certTagsCacheTTL)
+			}
+			got, err := d.DiscoverByTags(context.Background(), tt.args.tags)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package ingress
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	networking "k8s.io/api/networking/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// IngressClassParamsKind is the Kind of IngressClassParams referenced by IngressClass's parameters.
+	IngressClassParamsKind = "IngressClassParams"
+)
+
+// ClassParamsLoader loads the IngressClassParams for Ingresses.
+type ClassParamsLoader interface {
+	// Load returns the IngressClassParams referenced by Ingress's IngressClass, it returns nil if there is no IngressClassParams.
+	Load(ctx context.Context, ing *networking.Ingress) (*elbv2api.IngressClassParams, error)
+}
+
+// NewDefaultClassParamsLoader constructs new defaultClassParamsLoader.
+func NewDefaultClassParamsLoader(k8sClient client.Client) *defaultClassParamsLoader {
+	return &defaultClassParamsLoader{
+		k8sClient: k8sClient,
+	}
+}
+
+var _ ClassParamsLoader = &defaultClassParamsLoader{}
+
+// default implementation for ClassParamsLoader, which loads IngressClassParams from the parameters of IngressClass.
+type defaultClassParamsLoader struct {
+	k8sClient client.Client
+}
+
+func (l *defaultClassParamsLoader) Load(ctx context.Context, ing *networking.Ingress) (*elbv2api.IngressClassParams, error) {
+	if ing.Spec.IngressClassName == nil {
+		return nil, nil
+	}
+	ingClassKey := types.NamespacedName{Name: *ing.Spec.IngressClassName}
+	ingClass := &networking.IngressClass{}
+	if err := l.k8sClient.Get(ctx, ingClassKey, ingClass); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	params := ingClass.Spec.Parameters
+	if params == nil {
+		return nil, nil
+	}
+	if params.APIGroup == nil || *params.APIGroup != elbv2api.GroupVersion.Group || params.Kind != IngressClassParamsKind {
+		return nil, errors.Errorf("IngressClass %v references unsupported parameters", ingClass.Name)
+	}
+	ingClassParams := &elbv2api.IngressClassParams{}
+	if err := l.k8sClient.Get(ctx, types.NamespacedName{Name: params.Name}, ingClassParams); err != nil {
+		return nil, errors.Wrapf(err, "failed to load IngressClassParams %v for IngressClass %v", params.Name, ingClass.Name)
+	}
+	return ingClassParams, nil
+}
@@ -0,0 +1,173 @@
+package ingress
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+func Test_defaultClassParamsLoader_Load(t *testing.T) {
+	type env struct {
+		ingClasses       []*networking.IngressClass
+		ingClassParamses []*elbv2api.IngressClassParams
+	}
+	ingClassParams := &elbv2api.IngressClassParams{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "params-a",
+		},
+		Spec: elbv2api.IngressClassParamsSpec{
+			LoadBalancerAttributes: []elbv2api.Attribute{
+				{
+					Key:   "routing.http2.enabled",
+					Value: "false",
+				},
+			},
+		},
+	}
+	ingClassWithParams := &networking.IngressClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "class-with-params",
+		},
+		Spec: networking.IngressClassSpec{
+			Controller: ingressClassControllerALB,
+			Parameters: &corev1.TypedLocalObjectReference{
+				APIGroup: awssdk.String("elbv2.k8s.aws"),
+				Kind:     "IngressClassParams",
+				Name:     "params-a",
+			},
+		},
+	}
+	ingClassWithoutParams := &networking.IngressClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "class-without-params",
+		},
+		Spec: networking.IngressClassSpec{
+			Controller: ingressClassControllerALB,
+		},
+	}
+	ingClassWithUnsupportedParams := &networking.IngressClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "class-with-unsupported-params",
+		},
+		Spec: networking.IngressClassSpec{
+			Controller: ingressClassControllerALB,
+			Parameters: &corev1.TypedLocalObjectReference{
+				APIGroup: awssdk.String("example.com"),
+				Kind:     "SomeParams",
+				Name:     "params-a",
+			},
+		},
+	}
+	ingClassWithMissingParams := &networking.IngressClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "class-with-missing-params",
+		},
+		Spec: networking.IngressClassSpec{
+			Controller: ingressClassControllerALB,
+			Parameters: &corev1.TypedLocalObjectReference{
+				APIGroup: awssdk.String("elbv2.k8s.aws"),
+				Kind:     "IngressClassParams",
+				Name:     "params-missing",
+			},
+		},
+	}
+	buildIngress := func(ingClassName *string) *networking.Ingress {
+		return &networking.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "awesome-ns",
+				Name:      "ing-1",
+			},
+			Spec: networking.IngressSpec{
+				IngressClassName: ingClassName,
+			},
+		}
+	}
+	env1 := env{
+		ingClasses: []*networking.IngressClass{
+			ingClassWithParams, ingClassWithoutParams, ingClassWithUnsupportedParams, ingClassWithMissingParams,
+		},
+		ingClassParamses: []*elbv2api.IngressClassParams{ingClassParams},
+	}
+
+	tests := []struct {
+		name    string
+		env     env
+		ing     *networking.Ingress
+		want    *elbv2api.IngressClassParams
+		wantErr error
+	}{
+		{
+			name: "ingress without ingressClassName",
+			env:  env1,
+			ing:  buildIngress(nil),
+			want: nil,
+		},
+		{
+			name: "ingress with non-existent IngressClass",
+			env:  env1,
+			ing:  buildIngress(awssdk.String("class-non-existent")),
+			want: nil,
+		},
+		{
+			name: "ingress with IngressClass without parameters",
+			env:  env1,
+			ing:  buildIngress(awssdk.String("class-without-params")),
+			want: nil,
+		},
+		{
+			name: "ingress with IngressClass with IngressClassParams",
+			env:  env1,
+			ing:  buildIngress(awssdk.String("class-with-params")),
+			want: ingClassParams,
+		},
+		{
+			name:    "ingress with IngressClass with unsupported parameters",
+			env:     env1,
+			ing:     buildIngress(awssdk.String("class-with-unsupported-params")),
+			wantErr: errors.New("IngressClass class-with-unsupported-params references unsupported parameters"),
+		},
+		{
+			name:    "ingress with IngressClass with missing IngressClassParams",
+			env:     env1,
+			ing:     buildIngress(awssdk.String("class-with-missing-params")),
+			wantErr: errors.New("failed to load IngressClassParams params-missing for IngressClass class-with-missing-params: ingressclassparamses.elbv2.k8s.aws \"params-missing\" not found"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			for _, ingClass := range tt.env.ingClasses {
+				assert.NoError(t, k8sClient.Create(ctx, ingClass.DeepCopy()))
+			}
+			for _, params := range tt.env.ingClassParamses {
+				assert.NoError(t, k8sClient.Create(ctx, params.DeepCopy()))
+			}
+
+			l := NewDefaultClassParamsLoader(k8sClient)
+			got, err := l.Load(ctx, tt.ing)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				if tt.want == nil {
+					assert.Nil(t, got)
+				} else {
+					assert.Equal(t, tt.want.Spec, got.Spec)
+				}
+			}
+		})
+	}
+}
@@ -1,9 +1,13 @@
 package ingress
 
 import (
+	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"regexp"
+	"strings"
 )
 
 // NOTE: these types are user-facing data structures.
@@ -20,6 +24,10 @@ type FixedResponseActionConfig struct {
 	// +optional
 	MessageBody *string `json:"messageBody,omitempty"`
 
+	// The ConfigMap key to load message from, it's mutually exclusive with MessageBody.
+	// +optional
+	MessageBodyConfigMapRef *ConfigMapKeyReference `json:"messageBodyConfigMapRef,omitempty"`
+
 	// The HTTP response code.
 	StatusCode string `json:"statusCode"`
 }
@@ -28,6 +36,33 @@ func (c *FixedResponseActionConfig) validate() error {
 	if len(c.StatusCode) == 0 {
 		return errors.New("statusCode is required")
 	}
+	if c.MessageBody != nil && c.MessageBodyConfigMapRef != nil {
+		return errors.New("messageBody and messageBodyConfigMapRef are mutually exclusive")
+	}
+	if c.MessageBodyConfigMapRef != nil {
+		if err := c.MessageBodyConfigMapRef.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reference to a key within ConfigMap in the same namespace as Ingress.
+type ConfigMapKeyReference struct {
+	// The name of ConfigMap.
+	Name string `json:"name"`
+
+	// The key within ConfigMap.
+	Key string `json:"key"`
+}
+
+func (r *ConfigMapKeyReference) validate() error {
+	if len(r.Name) == 0 {
+		return errors.New("configMap name is required")
+	}
+	if len(r.Key) == 0 {
+		return errors.New("configMap key is required")
+	}
 	return nil
 }
 
@@ -61,6 +96,49 @@ func (c *RedirectActionConfig) validate() error {
 	if len(c.StatusCode) == 0 {
 		return errors.New("statusCode is required")
 	}
+	if c.StatusCode != redirectStatusCodeHTTP301 && c.StatusCode != redirectStatusCodeHTTP302 {
+		return errors.Errorf("statusCode must be within [%v, %v]: %v", redirectStatusCodeHTTP301, redirectStatusCodeHTTP302, c.StatusCode)
+	}
+	if c.Protocol != nil {
+		switch *c.Protocol {
+		case string(elbv2.ProtocolEnumHttp), string(elbv2.ProtocolEnumHttps), redirectVariableProtocol:
+		default:
+			return errors.Errorf("protocol must be within [%v, %v, %v]: %v", elbv2.ProtocolEnumHttp, elbv2.ProtocolEnumHttps, redirectVariableProtocol, *c.Protocol)
+		}
+	}
+	if c.Path != nil && !strings.HasPrefix(*c.Path, "/") {
+		return errors.Errorf("path must start with /: %v", *c.Path)
+	}
+	for _, value := range []*string{c.Host, c.Path, c.Port, c.Query} {
+		if value == nil {
+			continue
+		}
+		if err := validateRedirectVariables(*value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const (
+	redirectStatusCodeHTTP301 = "HTTP_301"
+	redirectStatusCodeHTTP302 = "HTTP_302"
+	redirectVariableProtocol  = "#{protocol}"
+)
+
+// redirectVariablePattern matches variables used within redirect templates, e.g. #{host}
+var redirectVariablePattern = regexp.MustCompile(`#\{([^}]*)\}`)
+
+// redirectVariables contains the variables supported within redirect templates.
+var redirectVariables = sets.NewString("protocol", "host", "port", "path", "query")
+
+// validateRedirectVariables checks whether only supported variables are used within redirect templates.
+func validateRedirectVariables(template string) error {
+	for _, match := range redirectVariablePattern.FindAllStringSubmatch(template, -1) {
+		if !redirectVariables.Has(match[1]) {
+			return errors.Errorf("unsupported variable %v in redirect template: %v", match[0], template)
+		}
+	}
 	return nil
 }
 
@@ -88,9 +166,20 @@ func (t *TargetGroupTuple) validate() error {
 	if t.ServiceName != nil && t.ServicePort == nil {
 		return errors.New("missing servicePort")
 	}
+	if t.Weight != nil && (*t.Weight < 0 || *t.Weight > maxTargetGroupWeight) {
+		return errors.Errorf("weight must be within [0, %v]: %v", maxTargetGroupWeight, *t.Weight)
+	}
 	return nil
 }
 
+// key identifies the target group of tuple.
+func (t *TargetGroupTuple) key() string {
+	if t.TargetGroupARN != nil {
+		return *t.TargetGroupARN
+	}
+	return *t.ServiceName + ":" + t.ServicePort.String()
+}
+
 // Information about the target group stickiness for a rule.
 type TargetGroupStickinessConfig struct {
 	// Indicates whether target group stickiness is enabled.
@@ -102,6 +191,16 @@ type TargetGroupStickinessConfig struct {
 	DurationSeconds *int64 `json:"durationSeconds,omitempty"`
 }
 
+func (c *TargetGroupStickinessConfig) validate() error {
+	if awssdk.BoolValue(c.Enabled) && c.DurationSeconds == nil {
+		return errors.New("durationSeconds must be set when stickiness is enabled")
+	}
+	if c.DurationSeconds != nil && (*c.DurationSeconds < 1 || *c.DurationSeconds > maxTargetGroupStickinessDurationSeconds) {
+		return errors.Errorf("durationSeconds must be within [1, %v]: %v", maxTargetGroupStickinessDurationSeconds, *c.DurationSeconds)
+	}
+	return nil
+}
+
 // Information about a forward action.
 type ForwardActionConfig struct {
 	// One or more target groups.
@@ -114,10 +213,21 @@ type ForwardActionConfig struct {
 }
 
 func (c *ForwardActionConfig) validate() error {
+	if len(c.TargetGroups) == 0 {
+		return errors.New("missing targetGroups")
+	}
+	if len(c.TargetGroups) > maxTargetGroupsPerForwardAction {
+		return errors.Errorf("at most %v target groups can be specified: %v", maxTargetGroupsPerForwardAction, len(c.TargetGroups))
+	}
+	tgKeys := sets.NewString()
 	for _, t := range c.TargetGroups {
 		if err := t.validate(); err != nil {
 			return errors.Wrap(err, "invalid TargetGroupTuple")
 		}
+		if tgKeys.Has(t.key()) {
+			return errors.Errorf("duplicate target group: %v", t.key())
+		}
+		tgKeys.Insert(t.key())
 	}
 	if len(c.TargetGroups) > 1 {
 		for _, t := range c.TargetGroups {
@@ -126,9 +236,23 @@ func (c *ForwardActionConfig) validate() error {
 			}
 		}
 	}
+	if c.TargetGroupStickinessConfig != nil {
+		if err := c.TargetGroupStickinessConfig.validate(); err != nil {
+			return errors.Wrap(err, "invalid TargetGroupStickinessConfig")
+		}
+	}
 	return nil
 }
 
+const (
+	// the maximum number of target groups per forward action.
+	maxTargetGroupsPerForwardAction = 5
+	// the maximum weight of a target group within forward action.
+	maxTargetGroupWeight = 999
+	// the maximum duration of target group stickiness, which is 7 days.
+	maxTargetGroupStickinessDurationSeconds = 604800
+)
+
 // The type of action.
 type ActionType string
 
@@ -212,6 +336,11 @@ func (c *HostHeaderConditionConfig) validate() error {
 	if len(c.Values) == 0 {
 		return errors.New("values cannot be empty")
 	}
+	for _, value := range c.Values {
+		if err := ValidateHostPattern(value); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -414,3 +543,36 @@ type AuthIDPConfigOIDC struct {
 	// +optional
 	AuthenticationRequestExtraParams map[string]string `json:"authenticationRequestExtraParams,omitempty"`
 }
+
+// configuration to override authentication settings for specific rule.
+type AuthSettings struct {
+	// The behavior if the user is not authenticated.
+	// +optional
+	OnUnauthenticatedRequest *string `json:"onUnauthenticatedRequest,omitempty"`
+
+	// The set of user claims to be requested from the IdP.
+	// +optional
+	Scope *string `json:"scope,omitempty"`
+
+	// The name of the cookie used to maintain session information.
+	// +optional
+	SessionCookieName *string `json:"sessionCookieName,omitempty"`
+
+	// The maximum duration of the authentication session, in seconds.
+	// +optional
+	SessionTimeout *int64 `json:"sessionTimeout,omitempty"`
+}
+
+func (s *AuthSettings) validate() error {
+	if s.OnUnauthenticatedRequest != nil {
+		switch *s.OnUnauthenticatedRequest {
+		case "authenticate", "allow", "deny":
+		default:
+			return errors.Errorf("onUnauthenticatedRequest must be within [authenticate, allow, deny]: %v", *s.OnUnauthenticatedRequest)
+		}
+	}
+	if s.SessionTimeout != nil && (*s.SessionTimeout < 1 || *s.SessionTimeout > 604800) {
+		return errors.Errorf("sessionTimeout must be within [1, 604800]: %v", *s.SessionTimeout)
+	}
+	return nil
+}
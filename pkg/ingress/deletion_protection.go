@@ -0,0 +1,35 @@
+package ingress
+
+import (
+	"github.com/pkg/errors"
+	networking "k8s.io/api/networking/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+)
+
+// FindUnconfirmedDeletionProtectedIngress finds the Ingress that protects the LoadBalancer of IngressGroup from deletion,
+// without the deletion being confirmed. nil is returned if the LoadBalancer can be deleted or won't be deleted.
+//
+// The LoadBalancer is deleted once IngressGroup has no members left, Ingresses leaving the IngressGroup with the
+// "deletion-protection" annotation must confirm the deletion with the "deletion-confirmation" annotation set to their name,
+// so that a LoadBalancer isn't torn down by accident, e.g. when Ingresses are pruned by GitOps tools.
+func FindUnconfirmedDeletionProtectedIngress(annotationParser annotations.Parser, ingGroup Group) (*networking.Ingress, error) {
+	if len(ingGroup.Members) != 0 {
+		return nil, nil
+	}
+	for _, ing := range ingGroup.InactiveMembers {
+		protected := false
+		if _, err := annotationParser.ParseBoolAnnotation(annotations.IngressSuffixDeletionProtection, &protected, ing.Annotations); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse deletion protection of ingress: %v", k8s.NamespacedName(ing))
+		}
+		if !protected {
+			continue
+		}
+		confirmation := ""
+		_ = annotationParser.ParseStringAnnotation(annotations.IngressSuffixDeletionConfirmation, &confirmation, ing.Annotations)
+		if confirmation != ing.Name {
+			return ing, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,98 @@
+package ingress
+
+import (
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	networking "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"testing"
+)
+
+func TestFindUnconfirmedDeletionProtectedIngress(t *testing.T) {
+	ingWithAnnotations := func(name string, ingAnnotations map[string]string) *networking.Ingress {
+		return &networking.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "awesome-ns",
+				Name:        name,
+				Annotations: ingAnnotations,
+			},
+		}
+	}
+	protectedIng := ingWithAnnotations("ing-1", map[string]string{
+		"alb.ingress.kubernetes.io/deletion-protection": "true",
+	})
+	tests := []struct {
+		name     string
+		ingGroup Group
+		want     *networking.Ingress
+		wantErr  error
+	}{
+		{
+			name: "LoadBalancer won't be deleted",
+			ingGroup: Group{
+				Members:         []*networking.Ingress{ingWithAnnotations("ing-2", nil)},
+				InactiveMembers: []*networking.Ingress{protectedIng},
+			},
+			want: nil,
+		},
+		{
+			name: "LoadBalancer is deleted without protection",
+			ingGroup: Group{
+				InactiveMembers: []*networking.Ingress{ingWithAnnotations("ing-2", nil)},
+			},
+			want: nil,
+		},
+		{
+			name: "LoadBalancer is deleted with protection",
+			ingGroup: Group{
+				InactiveMembers: []*networking.Ingress{ingWithAnnotations("ing-2", nil), protectedIng},
+			},
+			want: protectedIng,
+		},
+		{
+			name: "LoadBalancer is deleted with protection and confirmation",
+			ingGroup: Group{
+				InactiveMembers: []*networking.Ingress{ingWithAnnotations("ing-1", map[string]string{
+					"alb.ingress.kubernetes.io/deletion-protection":   "true",
+					"alb.ingress.kubernetes.io/deletion-confirmation": "ing-1",
+				})},
+			},
+			want: nil,
+		},
+		{
+			name: "LoadBalancer is deleted with protection and mismatched confirmation",
+			ingGroup: Group{
+				InactiveMembers: []*networking.Ingress{ingWithAnnotations("ing-1", map[string]string{
+					"alb.ingress.kubernetes.io/deletion-protection":   "true",
+					"alb.ingress.kubernetes.io/deletion-confirmation": "true",
+				})},
+			},
+			want: ingWithAnnotations("ing-1", map[string]string{
+				"alb.ingress.kubernetes.io/deletion-protection":   "true",
+				"alb.ingress.kubernetes.io/deletion-confirmation": "true",
+			}),
+		},
+		{
+			name: "invalid deletion protection",
+			ingGroup: Group{
+				InactiveMembers: []*networking.Ingress{ingWithAnnotations("ing-1", map[string]string{
+					"alb.ingress.kubernetes.io/deletion-protection": "yes",
+				})},
+			},
+			wantErr: errors.New("failed to parse deletion protection of ingress: awesome-ns/ing-1: failed to parse bool annotation, alb.ingress.kubernetes.io/deletion-protection: yes: strconv.ParseBool: parsing \"yes\": invalid syntax"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			annotationParser := annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
+			got, err := FindUnconfirmedDeletionProtectedIngress(annotationParser, tt.ingGroup)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -19,6 +19,9 @@ const (
 type EnhancedBackend struct {
 	Conditions []RuleCondition
 	Action     Action
+
+	// AuthSettings overrides the authentication settings for this backend only.
+	AuthSettings *AuthSettings
 }
 
 // EnhancedBackendBuilder is capable of build  EnhancedBackend for Ingress backend.
@@ -46,6 +49,11 @@ func (b *defaultEnhancedBackendBuilder) Build(ctx context.Context, ing *networki
 		return EnhancedBackend{}, err
 	}
 
+	authSettings, err := b.buildAuthSettings(ctx, ing.Annotations, backend.ServiceName)
+	if err != nil {
+		return EnhancedBackend{}, err
+	}
+
 	var action Action
 	if backend.ServicePort.String() == magicServicePortUseAnnotation {
 		action, err = b.buildActionViaAnnotation(ctx, ing.Annotations, backend.ServiceName)
@@ -57,8 +65,9 @@ func (b *defaultEnhancedBackendBuilder) Build(ctx context.Context, ing *networki
 	}
 
 	return EnhancedBackend{
-		Conditions: conditions,
-		Action:     action,
+		Conditions:   conditions,
+		Action:       action,
+		AuthSettings: authSettings,
 	}, nil
 }
 
@@ -77,6 +86,22 @@ func (b *defaultEnhancedBackendBuilder) buildConditions(_ context.Context, ingAn
 	return conditions, nil
 }
 
+func (b *defaultEnhancedBackendBuilder) buildAuthSettings(_ context.Context, ingAnnotation map[string]string, svcName string) (*AuthSettings, error) {
+	authSettings := AuthSettings{}
+	annotationKey := fmt.Sprintf("auth-settings.%v", svcName)
+	exists, err := b.annotationParser.ParseJSONAnnotation(annotationKey, &authSettings, ingAnnotation)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+	if err := authSettings.validate(); err != nil {
+		return nil, err
+	}
+	return &authSettings, nil
+}
+
 func (b *defaultEnhancedBackendBuilder) buildActionViaAnnotation(_ context.Context, ingAnnotation map[string]string, svcName string) (Action, error) {
 	action := Action{}
 	annotationKey := fmt.Sprintf("actions.%v", svcName)
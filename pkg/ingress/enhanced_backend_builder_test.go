@@ -748,6 +748,184 @@ func Test_defaultEnhancedBackendBuilder_buildActionViaAnnotation(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "fixed-response action - message body from configMap",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/actions.response-503": `{"type":"fixed-response","fixedResponseConfig":{"contentType":"text/html","statusCode":"503","messageBodyConfigMapRef":{"name":"maintenance-page","key":"index.html"}}}`,
+				},
+				svcName: "response-503",
+			},
+			want: Action{
+				Type: ActionTypeFixedResponse,
+				FixedResponseConfig: &FixedResponseActionConfig{
+					ContentType: awssdk.String("text/html"),
+					MessageBodyConfigMapRef: &ConfigMapKeyReference{
+						Name: "maintenance-page",
+						Key:  "index.html",
+					},
+					StatusCode: "503",
+				},
+			},
+		},
+		{
+			name: "fixed-response action - both message body and configMap specified",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/actions.response-503": `{"type":"fixed-response","fixedResponseConfig":{"statusCode":"503","messageBody":"503 error text","messageBodyConfigMapRef":{"name":"maintenance-page","key":"index.html"}}}`,
+				},
+				svcName: "response-503",
+			},
+			wantErr: errors.New("invalid FixedResponseConfig: messageBody and messageBodyConfigMapRef are mutually exclusive"),
+		},
+		{
+			name: "fixed-response action - configMap key missing",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/actions.response-503": `{"type":"fixed-response","fixedResponseConfig":{"statusCode":"503","messageBodyConfigMapRef":{"name":"maintenance-page"}}}`,
+				},
+				svcName: "response-503",
+			},
+			wantErr: errors.New("invalid FixedResponseConfig: configMap key is required"),
+		},
+		{
+			name: "redirect action - templated host and path",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/actions.redirect-to-www": `{"type":"redirect","redirectConfig":{"host":"www.#{host}","path":"/app/#{path}","query":"#{query}&src=redirect","statusCode":"HTTP_301"}}`,
+				},
+				svcName: "redirect-to-www",
+			},
+			want: Action{
+				Type: ActionTypeRedirect,
+				RedirectConfig: &RedirectActionConfig{
+					Host:       awssdk.String("www.#{host}"),
+					Path:       awssdk.String("/app/#{path}"),
+					Query:      awssdk.String("#{query}&src=redirect"),
+					StatusCode: "HTTP_301",
+				},
+			},
+		},
+		{
+			name: "redirect action - unsupported template variable",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/actions.redirect-to-www": `{"type":"redirect","redirectConfig":{"host":"#{hostname}","statusCode":"HTTP_301"}}`,
+				},
+				svcName: "redirect-to-www",
+			},
+			wantErr: errors.New("invalid RedirectConfig: unsupported variable #{hostname} in redirect template: #{hostname}"),
+		},
+		{
+			name: "redirect action - invalid path",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/actions.redirect-to-www": `{"type":"redirect","redirectConfig":{"path":"#{path}","statusCode":"HTTP_301"}}`,
+				},
+				svcName: "redirect-to-www",
+			},
+			wantErr: errors.New("invalid RedirectConfig: path must start with /: #{path}"),
+		},
+		{
+			name: "redirect action - invalid protocol",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/actions.redirect-to-www": `{"type":"redirect","redirectConfig":{"protocol":"FTP","statusCode":"HTTP_301"}}`,
+				},
+				svcName: "redirect-to-www",
+			},
+			wantErr: errors.New("invalid RedirectConfig: protocol must be within [HTTP, HTTPS, #{protocol}]: FTP"),
+		},
+		{
+			name: "redirect action - invalid statusCode",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/actions.redirect-to-www": `{"type":"redirect","redirectConfig":{"statusCode":"HTTP_307"}}`,
+				},
+				svcName: "redirect-to-www",
+			},
+			wantErr: errors.New("invalid RedirectConfig: statusCode must be within [HTTP_301, HTTP_302]: HTTP_307"),
+		},
+		{
+			name: "forward action - multiple target groups with stickiness",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/actions.canary": `{"type":"forward","forwardConfig":{"targetGroups":[{"serviceName":"svc-stable","servicePort":80,"weight":90},{"serviceName":"svc-canary","servicePort":80,"weight":10}],"targetGroupStickinessConfig":{"enabled":true,"durationSeconds":3600}}}`,
+				},
+				svcName: "canary",
+			},
+			want: Action{
+				Type: ActionTypeForward,
+				ForwardConfig: &ForwardActionConfig{
+					TargetGroups: []TargetGroupTuple{
+						{
+							ServiceName: awssdk.String("svc-stable"),
+							ServicePort: &port80,
+							Weight:      awssdk.Int64(90),
+						},
+						{
+							ServiceName: awssdk.String("svc-canary"),
+							ServicePort: &port80,
+							Weight:      awssdk.Int64(10),
+						},
+					},
+					TargetGroupStickinessConfig: &TargetGroupStickinessConfig{
+						Enabled:         awssdk.Bool(true),
+						DurationSeconds: awssdk.Int64(3600),
+					},
+				},
+			},
+		},
+		{
+			name: "forward action - too many target groups",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/actions.canary": `{"type":"forward","forwardConfig":{"targetGroups":[{"targetGroupARN":"tg-1","weight":1},{"targetGroupARN":"tg-2","weight":1},{"targetGroupARN":"tg-3","weight":1},{"targetGroupARN":"tg-4","weight":1},{"targetGroupARN":"tg-5","weight":1},{"targetGroupARN":"tg-6","weight":1}]}}`,
+				},
+				svcName: "canary",
+			},
+			wantErr: errors.New("invalid ForwardConfig: at most 5 target groups can be specified: 6"),
+		},
+		{
+			name: "forward action - duplicate target groups",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/actions.canary": `{"type":"forward","forwardConfig":{"targetGroups":[{"serviceName":"svc-stable","servicePort":80,"weight":90},{"serviceName":"svc-stable","servicePort":80,"weight":10}]}}`,
+				},
+				svcName: "canary",
+			},
+			wantErr: errors.New("invalid ForwardConfig: duplicate target group: svc-stable:80"),
+		},
+		{
+			name: "forward action - invalid weight",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/actions.canary": `{"type":"forward","forwardConfig":{"targetGroups":[{"targetGroupARN":"tg-1","weight":1000},{"targetGroupARN":"tg-2","weight":0}]}}`,
+				},
+				svcName: "canary",
+			},
+			wantErr: errors.New("invalid ForwardConfig: invalid TargetGroupTuple: weight must be within [0, 999]: 1000"),
+		},
+		{
+			name: "forward action - stickiness enabled without duration",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/actions.canary": `{"type":"forward","forwardConfig":{"targetGroups":[{"targetGroupARN":"tg-1","weight":90},{"targetGroupARN":"tg-2","weight":10}],"targetGroupStickinessConfig":{"enabled":true}}}`,
+				},
+				svcName: "canary",
+			},
+			wantErr: errors.New("invalid ForwardConfig: invalid TargetGroupStickinessConfig: durationSeconds must be set when stickiness is enabled"),
+		},
+		{
+			name: "forward action - invalid stickiness duration",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/actions.canary": `{"type":"forward","forwardConfig":{"targetGroups":[{"targetGroupARN":"tg-1","weight":90},{"targetGroupARN":"tg-2","weight":10}],"targetGroupStickinessConfig":{"enabled":true,"durationSeconds":604801}}}`,
+				},
+				svcName: "canary",
+			},
+			wantErr: errors.New("invalid ForwardConfig: invalid TargetGroupStickinessConfig: durationSeconds must be within [1, 604800]: 604801"),
+		},
 		{
 			name: "non-exists action",
 			args: args{
@@ -813,3 +991,85 @@ func Test_defaultEnhancedBackendBuilder_buildActionViaServiceAndServicePort(t *t
 		})
 	}
 }
+
+func Test_defaultEnhancedBackendBuilder_buildAuthSettings(t *testing.T) {
+	type args struct {
+		ingAnnotation map[string]string
+		svcName       string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *AuthSettings
+		wantErr error
+	}{
+		{
+			name: "auth settings not specified",
+			args: args{
+				ingAnnotation: map[string]string{},
+				svcName:       "svc-1",
+			},
+			want: nil,
+		},
+		{
+			name: "auth settings specified",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/auth-settings.svc-1": `{"onUnauthenticatedRequest":"deny","scope":"email openid","sessionCookieName":"my-cookie","sessionTimeout":3600}`,
+				},
+				svcName: "svc-1",
+			},
+			want: &AuthSettings{
+				OnUnauthenticatedRequest: awssdk.String("deny"),
+				Scope:                    awssdk.String("email openid"),
+				SessionCookieName:        awssdk.String("my-cookie"),
+				SessionTimeout:           awssdk.Int64(3600),
+			},
+		},
+		{
+			name: "auth settings specified for another service",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/auth-settings.svc-2": `{"scope":"email openid"}`,
+				},
+				svcName: "svc-1",
+			},
+			want: nil,
+		},
+		{
+			name: "invalid onUnauthenticatedRequest",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/auth-settings.svc-1": `{"onUnauthenticatedRequest":"reject"}`,
+				},
+				svcName: "svc-1",
+			},
+			wantErr: errors.New("onUnauthenticatedRequest must be within [authenticate, allow, deny]: reject"),
+		},
+		{
+			name: "invalid sessionTimeout",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/auth-settings.svc-1": `{"sessionTimeout":0}`,
+				},
+				svcName: "svc-1",
+			},
+			wantErr: errors.New("sessionTimeout must be within [1, 604800]: 0"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			annotationParser := annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
+			b := &defaultEnhancedBackendBuilder{
+				annotationParser: annotationParser,
+			}
+			got, err := b.buildAuthSettings(context.Background(), tt.args.ingAnnotation, tt.args.svcName)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -66,3 +66,8 @@ func buildGroupFinalizer(groupID GroupID) string {
 	}
 	return implicitGroupFinalizer
 }
+
+// HasGroupFinalizer checks whether Ingress holds the finalizer of specified Ingress group.
+func HasGroupFinalizer(groupID GroupID, ing *networking.Ingress) bool {
+	return k8s.HasFinalizer(ing, buildGroupFinalizer(groupID))
+}
@@ -48,11 +48,13 @@ type GroupLoader interface {
 }
 
 // NewDefaultGroupLoader constructs new GroupLoader instance.
-func NewDefaultGroupLoader(client client.Client, eventRecorder record.EventRecorder, annotationParser annotations.Parser, ingressClass string) *defaultGroupLoader {
+func NewDefaultGroupLoader(client client.Client, eventRecorder record.EventRecorder, annotationParser annotations.Parser,
+	namespaceMatcher k8s.NamespaceMatcher, ingressClass string) *defaultGroupLoader {
 	return &defaultGroupLoader{
 		client:           client,
 		eventRecorder:    eventRecorder,
 		annotationParser: annotationParser,
+		namespaceMatcher: namespaceMatcher,
 		ingressClass:     ingressClass,
 	}
 }
@@ -64,6 +66,7 @@ type defaultGroupLoader struct {
 	client           client.Client
 	eventRecorder    record.EventRecorder
 	annotationParser annotations.Parser
+	namespaceMatcher k8s.NamespaceMatcher
 
 	ingressClass string
 }
@@ -86,6 +89,14 @@ func (m *defaultGroupLoader) FindGroupID(ctx context.Context, ing *networking.In
 		return &groupID, nil
 	}
 
+	// explicit IngressGroups span namespaces and are sharded by their names instead, see NamespaceMatcher.MatchesExplicitGroup.
+	matchesNamespace, err := m.namespaceMatcher.Matches(ctx, ing.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if !matchesNamespace {
+		return nil, nil
+	}
 	groupID := NewGroupIDForImplicitGroup(k8s.NamespacedName(ing))
 	return &groupID, nil
 }
@@ -265,3 +276,8 @@ func validateGroupName(groupName string) error {
 	}
 	return nil
 }
+
+// IsInvalidIngressClassError tests whether err is caused by Ingress referencing an IngressClass that doesn't exist.
+func IsInvalidIngressClassError(err error) bool {
+	return errors.Is(err, errInvalidIngressClass)
+}
@@ -6,13 +6,16 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
 	mock_client "sigs.k8s.io/aws-load-balancer-controller/mocks/controller-runtime/client"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"testing"
 )
@@ -96,6 +99,7 @@ func Test_defaultGroupLoader_FindGroupID(t *testing.T) {
 			m := &defaultGroupLoader{
 				client:           client,
 				annotationParser: annotationParser,
+				namespaceMatcher: k8s.NewDefaultNamespaceMatcher(client, labels.Everything()),
 				ingressClass:     "alb",
 			}
 			got, err := m.FindGroupID(context.Background(), tt.ing)
@@ -582,6 +586,7 @@ func Test_defaultGroupLoader_Load(t *testing.T) {
 			m := &defaultGroupLoader{
 				client:           client,
 				annotationParser: annotationParser,
+				namespaceMatcher: k8s.NewDefaultNamespaceMatcher(client, labels.Everything()),
 				ingressClass:     "alb",
 			}
 			if tt.listIngressesCall != nil {
@@ -598,6 +603,82 @@ func Test_defaultGroupLoader_Load(t *testing.T) {
 	}
 }
 
+func Test_defaultGroupLoader_Load_withNamespaceSelector(t *testing.T) {
+	awesomeNS := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "awesome-ns",
+			Labels: map[string]string{"shard": "a"},
+		},
+	}
+	otherNS := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "other-ns",
+			Labels: map[string]string{"shard": "b"},
+		},
+	}
+	ingInAwesomeNS := &networking.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "ing-1",
+			Annotations: map[string]string{
+				"kubernetes.io/ingress.class":          "alb",
+				"alb.ingress.kubernetes.io/group.name": "awesome-group",
+			},
+		},
+	}
+	ingInOtherNS := &networking.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "other-ns",
+			Name:      "ing-2",
+			Annotations: map[string]string{
+				"kubernetes.io/ingress.class":          "alb",
+				"alb.ingress.kubernetes.io/group.name": "awesome-group",
+			},
+			Finalizers: []string{"group.ingress.k8s.aws/awesome-group"},
+		},
+	}
+	implicitIngInOtherNS := &networking.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "other-ns",
+			Name:      "ing-3",
+			Annotations: map[string]string{
+				"kubernetes.io/ingress.class": "alb",
+			},
+		},
+	}
+
+	ctx := context.Background()
+	k8sSchema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+	for _, obj := range []k8s.APIObject{awesomeNS, otherNS, ingInAwesomeNS, ingInOtherNS, implicitIngInOtherNS} {
+		assert.NoError(t, k8sClient.Create(ctx, obj.DeepCopyObject()))
+	}
+	selector, err := labels.Parse("shard=a")
+	assert.NoError(t, err)
+	m := NewDefaultGroupLoader(k8sClient, record.NewFakeRecorder(10), annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+		k8s.NewDefaultNamespaceMatcher(k8sClient, selector), "")
+
+	// explicit IngressGroups span namespaces, they are sharded by group name instead.
+	groupID := NewGroupIDForExplicitGroup("awesome-group")
+	got, err := m.Load(ctx, groupID)
+	assert.NoError(t, err)
+	assert.Equal(t, groupID, got.ID)
+	assert.Len(t, got.Members, 2)
+	assert.Equal(t, k8s.NamespacedName(ingInAwesomeNS), k8s.NamespacedName(got.Members[0]))
+	assert.Equal(t, k8s.NamespacedName(ingInOtherNS), k8s.NamespacedName(got.Members[1]))
+	assert.Empty(t, got.InactiveMembers)
+
+	gotGroupID, err := m.FindGroupID(ctx, ingInOtherNS)
+	assert.NoError(t, err)
+	assert.Equal(t, &groupID, gotGroupID)
+
+	// implicit IngressGroups in namespaces sharded to other controllers are left untouched.
+	gotGroupID, err = m.FindGroupID(ctx, implicitIngInOtherNS)
+	assert.NoError(t, err)
+	assert.Nil(t, gotGroupID)
+}
+
 func Test_defaultGroupLoader_matchesIngressClass(t *testing.T) {
 	type env struct {
 		ingClasses []*networking.IngressClass
@@ -847,9 +928,10 @@ func Test_defaultGroupLoader_matchesIngressClass(t *testing.T) {
 			}
 
 			m := &defaultGroupLoader{
-				client:        k8sClient,
-				eventRecorder: record.NewFakeRecorder(10),
-				ingressClass:  tt.fields.ingressClass,
+				client:           k8sClient,
+				eventRecorder:    record.NewFakeRecorder(10),
+				namespaceMatcher: k8s.NewDefaultNamespaceMatcher(k8sClient, labels.Everything()),
+				ingressClass:     tt.fields.ingressClass,
 			}
 			got, err := m.matchesIngressClass(ctx, tt.ing)
 			if tt.wantErr != nil {
@@ -1136,6 +1218,7 @@ func Test_defaultGroupLoader_isGroupMember(t *testing.T) {
 				client:           k8sClient,
 				eventRecorder:    record.NewFakeRecorder(10),
 				annotationParser: annotationParser,
+				namespaceMatcher: k8s.NewDefaultNamespaceMatcher(k8sClient, labels.Everything()),
 				ingressClass:     "alb",
 			}
 			got, err := m.isGroupMember(context.Background(), tt.groupID, tt.ing)
@@ -1636,6 +1719,7 @@ func Test_defaultGroupLoader_sortGroupMembers(t *testing.T) {
 			m := &defaultGroupLoader{
 				client:           client,
 				annotationParser: annotationParser,
+				namespaceMatcher: k8s.NewDefaultNamespaceMatcher(client, labels.Everything()),
 				ingressClass:     "alb",
 			}
 			got, err := m.sortGroupMembers(context.Background(), tt.members)
@@ -0,0 +1,210 @@
+package ingress
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	networking "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GroupMembershipValidator validates whether an Ingress can join its IngressGroup.
+type GroupMembershipValidator interface {
+	// Validate returns an error if Ingress conflicts with other members of its IngressGroup,
+	// or it isn't allowed to join its IngressGroup.
+	Validate(ctx context.Context, ing *networking.Ingress) error
+}
+
+// NewDefaultGroupMembershipValidator constructs new defaultGroupMembershipValidator.
+func NewDefaultGroupMembershipValidator(k8sClient client.Client, groupLoader GroupLoader, annotationParser annotations.Parser) *defaultGroupMembershipValidator {
+	return &defaultGroupMembershipValidator{
+		k8sClient:        k8sClient,
+		groupLoader:      groupLoader,
+		annotationParser: annotationParser,
+	}
+}
+
+var _ GroupMembershipValidator = &defaultGroupMembershipValidator{}
+
+// default implementation for GroupMembershipValidator.
+// At most one Ingress within IngressGroup can define the default backend.
+// Explicit group order must be unique within IngressGroup, and load balancer attributes must not conflict within IngressGroup. If any IngressClass for ALB restricts an IngressGroup
+// via "group.allowlist" annotation, only Ingresses from namespaces allowed by these IngressClasses can join that IngressGroup.
+type defaultGroupMembershipValidator struct {
+	k8sClient        client.Client
+	groupLoader      GroupLoader
+	annotationParser annotations.Parser
+}
+
+func (v *defaultGroupMembershipValidator) Validate(ctx context.Context, ing *networking.Ingress) error {
+	// Ingress being deleted is leaving its IngressGroup, which shouldn't block removing its finalizer.
+	if !ing.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	groupID, err := v.groupLoader.FindGroupID(ctx, ing)
+	if err != nil {
+		// the IngressClass may not be created yet, it's reported during reconcile instead.
+		if errors.Is(err, errInvalidIngressClass) {
+			return nil
+		}
+		return err
+	}
+	if groupID == nil {
+		return nil
+	}
+	if err := v.checkDefaultBackend(ctx, *groupID, ing); err != nil {
+		return err
+	}
+	if !groupID.IsExplicit() {
+		return nil
+	}
+	if err := v.checkGroupAllowlist(ctx, *groupID, ing); err != nil {
+		return err
+	}
+	if err := v.checkGroupOrder(ctx, *groupID, ing); err != nil {
+		return err
+	}
+	if err := v.checkLoadBalancerAttributes(ctx, *groupID, ing); err != nil {
+		return err
+	}
+	return nil
+}
+
+// checkGroupAllowlist checks whether Ingress's namespace is allowed to join the IngressGroup.
+func (v *defaultGroupMembershipValidator) checkGroupAllowlist(ctx context.Context, groupID GroupID, ing *networking.Ingress) error {
+	ingClassList := &networking.IngressClassList{}
+	if err := v.k8sClient.List(ctx, ingClassList); err != nil {
+		return err
+	}
+	restricted := false
+	allowedNamespaces := sets.NewString()
+	for _, ingClass := range ingClassList.Items {
+		if ingClass.Spec.Controller != ingressClassControllerALB {
+			continue
+		}
+		var allowlist map[string][]string
+		exists, err := v.annotationParser.ParseJSONAnnotation(annotations.IngressClassSuffixGroupAllowlist, &allowlist, ingClass.Annotations)
+		if err != nil {
+			return errors.Wrapf(err, "failed to load Ingress group allowlist for IngressClass %v", ingClass.Name)
+		}
+		if !exists {
+			continue
+		}
+		if namespaces, ok := allowlist[groupID.Name]; ok {
+			restricted = true
+			allowedNamespaces.Insert(namespaces...)
+		}
+	}
+	if restricted && !allowedNamespaces.Has(ing.Namespace) {
+		return errors.Errorf("namespace %v is not allowed to join Ingress group %v", ing.Namespace, groupID.Name)
+	}
+	return nil
+}
+
+// checkDefaultBackend checks whether Ingress's default backend conflicts with other members of the IngressGroup.
+// the group default action only applies when no member defines a default backend, so they don't conflict.
+func (v *defaultGroupMembershipValidator) checkDefaultBackend(ctx context.Context, groupID GroupID, ing *networking.Ingress) error {
+	if ing.Spec.Backend == nil || !groupID.IsExplicit() {
+		return nil
+	}
+
+	members, err := v.listOtherGroupMembers(ctx, groupID, ing)
+	if err != nil {
+		return err
+	}
+	for _, member := range members {
+		if member.Spec.Backend != nil {
+			return errors.Errorf("conflict default backend, it's already defined by Ingress %v", k8s.NamespacedName(member))
+		}
+	}
+	return nil
+}
+
+// checkGroupOrder checks whether Ingress's explicit group order conflicts with other members of the IngressGroup.
+func (v *defaultGroupMembershipValidator) checkGroupOrder(ctx context.Context, groupID GroupID, ing *networking.Ingress) error {
+	var order int64
+	exists, err := v.annotationParser.ParseInt64Annotation(annotations.IngressSuffixGroupOrder, &order, ing.Annotations)
+	if err != nil {
+		return errors.Wrapf(err, "failed to load Ingress group order")
+	}
+	if !exists {
+		return nil
+	}
+	if order < minGroupOrder || order > maxGroupOder {
+		return errors.Errorf("explicit Ingress group order must be within [%v:%v], order: %v",
+			minGroupOrder, maxGroupOder, order)
+	}
+
+	members, err := v.listOtherGroupMembers(ctx, groupID, ing)
+	if err != nil {
+		return err
+	}
+	for _, member := range members {
+		var memberOrder int64
+		memberOrderExists, err := v.annotationParser.ParseInt64Annotation(annotations.IngressSuffixGroupOrder, &memberOrder, member.Annotations)
+		if err != nil || !memberOrderExists {
+			continue
+		}
+		if memberOrder == order {
+			return errors.Errorf("conflict Ingress group order: %v, it's already used by Ingress %v", order, k8s.NamespacedName(member))
+		}
+	}
+	return nil
+}
+
+// checkLoadBalancerAttributes checks whether Ingress's load balancer attributes conflict with other members of the IngressGroup.
+func (v *defaultGroupMembershipValidator) checkLoadBalancerAttributes(ctx context.Context, groupID GroupID, ing *networking.Ingress) error {
+	declaresAttributes := false
+	for _, annotation := range []string{annotations.IngressSuffixLoadBalancerAttributes, annotations.IngressSuffixLoadBalancerAttributesOwner,
+		annotations.IngressSuffixLoadBalancerAttributesMergePolicy} {
+		var rawValue string
+		if exists := v.annotationParser.ParseStringAnnotation(annotation, &rawValue, ing.Annotations); exists {
+			declaresAttributes = true
+			break
+		}
+	}
+	if !declaresAttributes {
+		return nil
+	}
+
+	otherMembers, err := v.listOtherGroupMembers(ctx, groupID, ing)
+	if err != nil {
+		return err
+	}
+	members := append([]*networking.Ingress{ing}, otherMembers...)
+	if _, err := mergeGroupLoadBalancerAttributes(v.annotationParser, members); err != nil {
+		return errors.Wrapf(err, "invalid load balancer attributes within Ingress group %v", groupID.Name)
+	}
+	return nil
+}
+
+// listOtherGroupMembers lists the members of IngressGroup other than Ingress, excluding members being deleted.
+// Ingresses with invalid IngressClass or IngressGroup are not members of any IngressGroup, and are skipped like when loading IngressGroups.
+func (v *defaultGroupMembershipValidator) listOtherGroupMembers(ctx context.Context, groupID GroupID, ing *networking.Ingress) ([]*networking.Ingress, error) {
+	ingList := &networking.IngressList{}
+	if err := v.k8sClient.List(ctx, ingList); err != nil {
+		return nil, err
+	}
+	ingKey := k8s.NamespacedName(ing)
+	var members []*networking.Ingress
+	for index := range ingList.Items {
+		member := &ingList.Items[index]
+		if k8s.NamespacedName(member) == ingKey || !member.DeletionTimestamp.IsZero() {
+			continue
+		}
+		memberGroupID, err := v.groupLoader.FindGroupID(ctx, member)
+		if err != nil {
+			if errors.Is(err, errInvalidIngressGroup) || errors.Is(err, errInvalidIngressClass) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to find Ingress group of Ingress %v", k8s.NamespacedName(member))
+		}
+		if memberGroupID == nil || *memberGroupID != groupID {
+			continue
+		}
+		members = append(members, member)
+	}
+	return members, nil
+}
@@ -0,0 +1,383 @@
+package ingress
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	networking "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+	"time"
+)
+
+func Test_defaultGroupMembershipValidator_Validate(t *testing.T) {
+	ingClassWithAllowlist := &networking.IngressClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "alb-restricted",
+			Annotations: map[string]string{
+				"alb.ingress.kubernetes.io/group.allowlist": `{"team-a.group":["team-a","team-a-canary"]}`,
+			},
+		},
+		Spec: networking.IngressClassSpec{
+			Controller: "ingress.k8s.aws/alb",
+		},
+	}
+	otherControllerIngClassWithAllowlist := &networking.IngressClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "nginx",
+			Annotations: map[string]string{
+				"alb.ingress.kubernetes.io/group.allowlist": `{"team-b.group":["team-b"]}`,
+			},
+		},
+		Spec: networking.IngressClassSpec{
+			Controller: "k8s.io/nginx",
+		},
+	}
+	ingClassWithInvalidAllowlist := &networking.IngressClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "alb-invalid",
+			Annotations: map[string]string{
+				"alb.ingress.kubernetes.io/group.allowlist": `team-a.group=team-a`,
+			},
+		},
+		Spec: networking.IngressClassSpec{
+			Controller: "ingress.k8s.aws/alb",
+		},
+	}
+	buildIngress := func(namespace string, name string, groupName string, groupOrder string) *networking.Ingress {
+		ing := &networking.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      name,
+				Annotations: map[string]string{
+					"kubernetes.io/ingress.class": "alb",
+				},
+			},
+		}
+		if groupName != "" {
+			ing.Annotations["alb.ingress.kubernetes.io/group.name"] = groupName
+		}
+		if groupOrder != "" {
+			ing.Annotations["alb.ingress.kubernetes.io/group.order"] = groupOrder
+		}
+		return ing
+	}
+	withDefaultBackend := func(ing *networking.Ingress) *networking.Ingress {
+		ing.Spec.Backend = &networking.IngressBackend{
+			ServiceName: "svc-1",
+			ServicePort: intstr.FromInt(80),
+		}
+		return ing
+	}
+	withAnnotations := func(ing *networking.Ingress, annotations map[string]string) *networking.Ingress {
+		for key, value := range annotations {
+			ing.Annotations[key] = value
+		}
+		return ing
+	}
+	withDeletionTimestamp := func(ing *networking.Ingress) *networking.Ingress {
+		ing.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		return ing
+	}
+	type env struct {
+		ingClasses []*networking.IngressClass
+		ingresses  []*networking.Ingress
+	}
+	tests := []struct {
+		name    string
+		env     env
+		ing     *networking.Ingress
+		wantErr error
+	}{
+		{
+			name: "implicit group",
+			env: env{
+				ingClasses: []*networking.IngressClass{ingClassWithAllowlist},
+			},
+			ing: buildIngress("team-b", "ing-1", "", "10"),
+		},
+		{
+			name: "ingress doesn't belong to this controller",
+			env: env{
+				ingClasses: []*networking.IngressClass{ingClassWithAllowlist},
+			},
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "team-b",
+					Name:      "ing-1",
+					Annotations: map[string]string{
+						"kubernetes.io/ingress.class":          "nginx",
+						"alb.ingress.kubernetes.io/group.name": "team-a.group",
+					},
+				},
+			},
+		},
+		{
+			name: "ingress with IngressClass not found",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "team-a",
+					Name:      "ing-1",
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/group.name": "team-a.group",
+					},
+				},
+				Spec: networking.IngressSpec{
+					IngressClassName: awssdk.String("alb-not-found"),
+				},
+			},
+		},
+		{
+			name: "ingress with invalid group name",
+			ing:  buildIngress("team-a", "ing-1", "Team-A", ""),
+			wantErr: errors.New("invalid ingress group: groupName must consist of lower case alphanumeric characters, '-' or '.', " +
+				"and must start and end with an alphanumeric character"),
+		},
+		{
+			name: "namespace allowed to join restricted group",
+			env: env{
+				ingClasses: []*networking.IngressClass{ingClassWithAllowlist},
+			},
+			ing: buildIngress("team-a-canary", "ing-1", "team-a.group", ""),
+		},
+		{
+			name: "namespace not allowed to join restricted group",
+			env: env{
+				ingClasses: []*networking.IngressClass{ingClassWithAllowlist},
+			},
+			ing:     buildIngress("team-b", "ing-1", "team-a.group", ""),
+			wantErr: errors.New("namespace team-b is not allowed to join Ingress group team-a.group"),
+		},
+		{
+			name: "namespace joins unrestricted group",
+			env: env{
+				ingClasses: []*networking.IngressClass{ingClassWithAllowlist},
+			},
+			ing: buildIngress("team-b", "ing-1", "team-b.group", ""),
+		},
+		{
+			name: "allowlist on IngressClass of other controllers is ignored",
+			env: env{
+				ingClasses: []*networking.IngressClass{otherControllerIngClassWithAllowlist},
+			},
+			ing: buildIngress("team-c", "ing-1", "team-b.group", ""),
+		},
+		{
+			name: "invalid allowlist",
+			env: env{
+				ingClasses: []*networking.IngressClass{ingClassWithInvalidAllowlist},
+			},
+			ing:     buildIngress("team-a", "ing-1", "team-a.group", ""),
+			wantErr: errors.New("failed to load Ingress group allowlist for IngressClass alb-invalid: failed to parse json annotation, alb.ingress.kubernetes.io/group.allowlist: team-a.group=team-a: invalid character 'e' in literal true (expecting 'r')"),
+		},
+		{
+			name: "unique group order",
+			env: env{
+				ingresses: []*networking.Ingress{
+					buildIngress("team-a", "ing-2", "team-a.group", "20"),
+					buildIngress("team-a", "ing-3", "team-a.group", ""),
+				},
+			},
+			ing: buildIngress("team-a", "ing-1", "team-a.group", "10"),
+		},
+		{
+			name: "group order conflicts with other member",
+			env: env{
+				ingresses: []*networking.Ingress{
+					buildIngress("team-a", "ing-2", "team-a.group", "10"),
+				},
+			},
+			ing:     buildIngress("team-a", "ing-1", "team-a.group", "10"),
+			wantErr: errors.New("conflict Ingress group order: 10, it's already used by Ingress team-a/ing-2"),
+		},
+		{
+			name: "group order conflicts with other member on ingress being deleted",
+			env: env{
+				ingresses: []*networking.Ingress{
+					buildIngress("team-a", "ing-2", "team-a.group", "10"),
+				},
+			},
+			ing: withDeletionTimestamp(buildIngress("team-a", "ing-1", "team-a.group", "10")),
+		},
+		{
+			name: "group order same as ingress in other group",
+			env: env{
+				ingresses: []*networking.Ingress{
+					buildIngress("team-a", "ing-2", "team-b.group", "10"),
+				},
+			},
+			ing: buildIngress("team-a", "ing-1", "team-a.group", "10"),
+		},
+		{
+			name: "group order unchanged on update",
+			env: env{
+				ingresses: []*networking.Ingress{
+					buildIngress("team-a", "ing-1", "team-a.group", "10"),
+				},
+			},
+			ing: buildIngress("team-a", "ing-1", "team-a.group", "10"),
+		},
+		{
+			name:    "group order out of range",
+			ing:     buildIngress("team-a", "ing-1", "team-a.group", "1001"),
+			wantErr: errors.New("explicit Ingress group order must be within [1:1000], order: 1001"),
+		},
+		{
+			name: "load balancer attributes conflict with other member",
+			env: env{
+				ingresses: []*networking.Ingress{
+					withAnnotations(buildIngress("team-a", "ing-2", "team-a.group", ""), map[string]string{
+						"alb.ingress.kubernetes.io/load-balancer-attributes": "idle_timeout.timeout_seconds=600",
+					}),
+				},
+			},
+			ing: withAnnotations(buildIngress("team-a", "ing-1", "team-a.group", ""), map[string]string{
+				"alb.ingress.kubernetes.io/load-balancer-attributes": "idle_timeout.timeout_seconds=60",
+			}),
+			wantErr: errors.New("invalid load balancer attributes within Ingress group team-a.group: " +
+				"conflicting loadBalancerAttribute idle_timeout.timeout_seconds: 60 | 600, declared by Ingress team-a/ing-1 and team-a/ing-2"),
+		},
+		{
+			name: "load balancer attributes merged by policy of other member",
+			env: env{
+				ingresses: []*networking.Ingress{
+					withAnnotations(buildIngress("team-a", "ing-2", "team-a.group", ""), map[string]string{
+						"alb.ingress.kubernetes.io/load-balancer-attributes":              "idle_timeout.timeout_seconds=600",
+						"alb.ingress.kubernetes.io/load-balancer-attributes.merge-policy": "idle_timeout.timeout_seconds=max",
+					}),
+				},
+			},
+			ing: withAnnotations(buildIngress("team-a", "ing-1", "team-a.group", ""), map[string]string{
+				"alb.ingress.kubernetes.io/load-balancer-attributes": "idle_timeout.timeout_seconds=60",
+			}),
+		},
+		{
+			name: "load balancer attributes same as ingress in other group",
+			env: env{
+				ingresses: []*networking.Ingress{
+					withAnnotations(buildIngress("team-a", "ing-2", "team-b.group", ""), map[string]string{
+						"alb.ingress.kubernetes.io/load-balancer-attributes": "idle_timeout.timeout_seconds=600",
+					}),
+				},
+			},
+			ing: withAnnotations(buildIngress("team-a", "ing-1", "team-a.group", ""), map[string]string{
+				"alb.ingress.kubernetes.io/load-balancer-attributes": "idle_timeout.timeout_seconds=60",
+			}),
+		},
+		{
+			name: "merge policy conflicts with other member",
+			env: env{
+				ingresses: []*networking.Ingress{
+					withAnnotations(buildIngress("team-a", "ing-2", "team-a.group", ""), map[string]string{
+						"alb.ingress.kubernetes.io/load-balancer-attributes.merge-policy": "idle_timeout.timeout_seconds=max",
+					}),
+				},
+			},
+			ing: withAnnotations(buildIngress("team-a", "ing-1", "team-a.group", ""), map[string]string{
+				"alb.ingress.kubernetes.io/load-balancer-attributes.merge-policy": "idle_timeout.timeout_seconds=min",
+			}),
+			wantErr: errors.New("invalid load balancer attributes within Ingress group team-a.group: " +
+				"conflicting merge policy for loadBalancerAttribute idle_timeout.timeout_seconds: min | max"),
+		},
+		{
+			name: "unique default backend",
+			env: env{
+				ingresses: []*networking.Ingress{
+					buildIngress("team-a", "ing-2", "team-a.group", ""),
+					withDefaultBackend(buildIngress("team-a", "ing-3", "team-b.group", "")),
+				},
+			},
+			ing: withDefaultBackend(buildIngress("team-a", "ing-1", "team-a.group", "")),
+		},
+		{
+			name: "default backend conflicts with other member",
+			env: env{
+				ingresses: []*networking.Ingress{
+					withDefaultBackend(buildIngress("team-a", "ing-2", "team-a.group", "")),
+				},
+			},
+			ing:     withDefaultBackend(buildIngress("team-a", "ing-1", "team-a.group", "")),
+			wantErr: errors.New("conflict default backend, it's already defined by Ingress team-a/ing-2"),
+		},
+		{
+			name: "default backend unchanged on update",
+			env: env{
+				ingresses: []*networking.Ingress{
+					withDefaultBackend(buildIngress("team-a", "ing-1", "team-a.group", "")),
+				},
+			},
+			ing: withDefaultBackend(buildIngress("team-a", "ing-1", "team-a.group", "")),
+		},
+		{
+			name: "default backend with group default action on same ingress",
+			ing: withDefaultBackend(withAnnotations(buildIngress("team-a", "ing-1", "", ""), map[string]string{
+				"alb.ingress.kubernetes.io/group.default-action": `{"type":"fixed-response","fixedResponseConfig":{"statusCode":"503"}}`,
+			})),
+		},
+		{
+			name: "default backend with group default action of other member",
+			env: env{
+				ingresses: []*networking.Ingress{
+					withAnnotations(buildIngress("team-a", "ing-2", "team-a.group", ""), map[string]string{
+						"alb.ingress.kubernetes.io/group.default-action": `{"type":"fixed-response","fixedResponseConfig":{"statusCode":"503"}}`,
+					}),
+				},
+			},
+			ing: withDefaultBackend(buildIngress("team-a", "ing-1", "team-a.group", "")),
+		},
+		{
+			name: "ingresses with invalid ingress group or class are not members",
+			env: env{
+				ingresses: []*networking.Ingress{
+					withDefaultBackend(buildIngress("team-a", "ing-2", "Team-A.group", "")),
+					withDefaultBackend(&networking.Ingress{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "team-a",
+							Name:      "ing-3",
+							Annotations: map[string]string{
+								"alb.ingress.kubernetes.io/group.name": "team-a.group",
+							},
+						},
+						Spec: networking.IngressSpec{
+							IngressClassName: awssdk.String("absent-class"),
+						},
+					}),
+				},
+			},
+			ing: withDefaultBackend(buildIngress("team-a", "ing-1", "team-a.group", "")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			for _, ingClass := range tt.env.ingClasses {
+				assert.NoError(t, k8sClient.Create(ctx, ingClass.DeepCopy()))
+			}
+			for _, ing := range tt.env.ingresses {
+				assert.NoError(t, k8sClient.Create(ctx, ing.DeepCopy()))
+			}
+
+			annotationParser := annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
+			groupLoader := NewDefaultGroupLoader(k8sClient, record.NewFakeRecorder(10), annotationParser, k8s.NewDefaultNamespaceMatcher(k8sClient, labels.Everything()), "")
+			v := NewDefaultGroupMembershipValidator(k8sClient, groupLoader, annotationParser)
+			err := v.Validate(ctx, tt.ing)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
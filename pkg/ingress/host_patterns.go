@@ -0,0 +1,59 @@
+package ingress
+
+import (
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"regexp"
+	"strings"
+)
+
+const (
+	// the maximum length of each host-header condition value.
+	maxHostPatternLength = 128
+	// the characters of regular expressions, which are often mistakenly used within host patterns.
+	regexMetaCharacters = `^$()[]{}|+\`
+)
+
+// hostPatternPattern matches the host patterns supported by ALB host-header conditions.
+var hostPatternPattern = regexp.MustCompile(`^[A-Za-z0-9.*?-]+$`)
+
+// ValidateHostPattern checks whether host is a valid pattern for ALB host-header conditions.
+// ALB host patterns support the * and ? wildcards only, where * matches zero or more characters across labels.
+func ValidateHostPattern(host string) error {
+	if len(host) == 0 {
+		return errors.New("host cannot be empty")
+	}
+	if len(host) > maxHostPatternLength {
+		return errors.Errorf("host %v exceeds %v characters", host, maxHostPatternLength)
+	}
+	if strings.ContainsAny(host, regexMetaCharacters) {
+		return errors.Errorf("host %v looks like a regular expression, which is not supported, use * and ? wildcards instead", host)
+	}
+	if !hostPatternPattern.MatchString(host) {
+		return errors.Errorf("host %v must only contain A-Z, a-z, 0-9, -, ., * and ?", host)
+	}
+	return nil
+}
+
+// expandHosts expands the hosts into host-header condition values, duplicated hosts are removed.
+// If matchApex is true, wildcard hosts like *.example.com are expanded to match the apex domain example.com as well,
+// since the ALB wildcard requires the leading "." to be present.
+func expandHosts(hosts []string, matchApex bool) []string {
+	expandedHosts := make([]string, 0, len(hosts))
+	seenHosts := sets.NewString()
+	appendHost := func(host string) {
+		normalizedHost := strings.ToLower(host)
+		if seenHosts.Has(normalizedHost) {
+			return
+		}
+		seenHosts.Insert(normalizedHost)
+		expandedHosts = append(expandedHosts, host)
+	}
+	for _, host := range hosts {
+		if matchApex && strings.HasPrefix(host, "*.") {
+			appendHost(strings.TrimPrefix(host, "*."))
+		}
+		appendHost(host)
+	}
+	return expandedHosts
+}
@@ -0,0 +1,87 @@
+package ingress
+
+import (
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestValidateHostPattern(t *testing.T) {
+	tests := []struct {
+		name    string
+		host    string
+		wantErr error
+	}{
+		{
+			name: "exact host",
+			host: "app.example.com",
+		},
+		{
+			name: "wildcard host",
+			host: "*.example.com",
+		},
+		{
+			name: "host with ? wildcard",
+			host: "app-?.example.com",
+		},
+		{
+			name:    "regular expression host",
+			host:    "^app[0-9]+\\.example\\.com$",
+			wantErr: errors.New("host ^app[0-9]+\\.example\\.com$ looks like a regular expression, which is not supported, use * and ? wildcards instead"),
+		},
+		{
+			name:    "host with unsupported characters",
+			host:    "app_v2.example.com",
+			wantErr: errors.New("host app_v2.example.com must only contain A-Z, a-z, 0-9, -, ., * and ?"),
+		},
+		{
+			name:    "host too long",
+			host:    strings.Repeat("a", 129),
+			wantErr: errors.Errorf("host %v exceeds 128 characters", strings.Repeat("a", 129)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateHostPattern(tt.host)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_expandHosts(t *testing.T) {
+	tests := []struct {
+		name      string
+		hosts     []string
+		matchApex bool
+		want      []string
+	}{
+		{
+			name:      "wildcard host without matching apex",
+			hosts:     []string{"*.example.com"},
+			matchApex: false,
+			want:      []string{"*.example.com"},
+		},
+		{
+			name:      "wildcard host matching apex",
+			hosts:     []string{"*.example.com", "app.example.org"},
+			matchApex: true,
+			want:      []string{"example.com", "*.example.com", "app.example.org"},
+		},
+		{
+			name:      "duplicated hosts are removed",
+			hosts:     []string{"example.com", "*.example.com", "App.example.org", "app.example.org"},
+			matchApex: true,
+			want:      []string{"example.com", "*.example.com", "App.example.org"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, expandHosts(tt.hosts, tt.matchApex))
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package ingress
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	networking "k8s.io/api/networking/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// IAMRoleResolver resolves the IAM role to assume when provisioning AWS resources for an IngressGroup.
+type IAMRoleResolver interface {
+	// Resolve returns the IAM role ARN for IngressGroup, it returns empty string if controller's own IAM role should be used.
+	Resolve(ctx context.Context, ingGroup Group) (string, error)
+}
+
+// NewDefaultIAMRoleResolver constructs new defaultIAMRoleResolver.
+func NewDefaultIAMRoleResolver(k8sClient client.Client, annotationParser annotations.Parser) *defaultIAMRoleResolver {
+	return &defaultIAMRoleResolver{
+		k8sClient:        k8sClient,
+		annotationParser: annotationParser,
+	}
+}
+
+var _ IAMRoleResolver = &defaultIAMRoleResolver{}
+
+// default implementation for IAMRoleResolver, which resolves IAM role from the IngressClass of Ingresses.
+type defaultIAMRoleResolver struct {
+	k8sClient        client.Client
+	annotationParser annotations.Parser
+}
+
+func (r *defaultIAMRoleResolver) Resolve(ctx context.Context, ingGroup Group) (string, error) {
+	// when IngressGroup is being deleted, the AWS resources need to be cleaned up with the IAM role of inactive members.
+	ingList := ingGroup.Members
+	if len(ingList) == 0 {
+		ingList = ingGroup.InactiveMembers
+	}
+
+	roleARNs := sets.NewString()
+	for _, ing := range ingList {
+		roleARN, err := r.resolveForIngress(ctx, ing)
+		if err != nil {
+			return "", err
+		}
+		roleARNs.Insert(roleARN)
+	}
+	if len(roleARNs) > 1 {
+		return "", errors.Errorf("conflicting IAM roles: %v", roleARNs.List())
+	}
+	if len(roleARNs) == 1 {
+		roleARN, _ := roleARNs.PopAny()
+		return roleARN, nil
+	}
+	return "", nil
+}
+
+// resolveForIngress resolves the IAM role ARN for a single Ingress.
+func (r *defaultIAMRoleResolver) resolveForIngress(ctx context.Context, ing *networking.Ingress) (string, error) {
+	if ing.Spec.IngressClassName == nil {
+		return "", nil
+	}
+	ingClassKey := types.NamespacedName{Name: *ing.Spec.IngressClassName}
+	ingClass := &networking.IngressClass{}
+	if err := r.k8sClient.Get(ctx, ingClassKey, ingClass); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	roleARN := ""
+	_ = r.annotationParser.ParseStringAnnotation(annotations.IngressClassSuffixIAMRoleARN, &roleARN, ingClass.Annotations)
+	return roleARN, nil
+}
@@ -0,0 +1,188 @@
+package ingress
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	networking "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+func Test_defaultIAMRoleResolver_Resolve(t *testing.T) {
+	type env struct {
+		ingClasses []*networking.IngressClass
+	}
+	ingClassWithRoleA := &networking.IngressClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "class-a",
+			Annotations: map[string]string{
+				"alb.ingress.kubernetes.io/iam-role-arn": "arn:aws:iam::111111111111:role/role-a",
+			},
+		},
+		Spec: networking.IngressClassSpec{
+			Controller: ingressClassControllerALB,
+		},
+	}
+	ingClassWithRoleB := &networking.IngressClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "class-b",
+			Annotations: map[string]string{
+				"alb.ingress.kubernetes.io/iam-role-arn": "arn:aws:iam::222222222222:role/role-b",
+			},
+		},
+		Spec: networking.IngressClassSpec{
+			Controller: ingressClassControllerALB,
+		},
+	}
+	ingClassWithoutRole := &networking.IngressClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "class-default",
+		},
+		Spec: networking.IngressClassSpec{
+			Controller: ingressClassControllerALB,
+		},
+	}
+	buildIngress := func(name string, ingClassName *string) *networking.Ingress {
+		return &networking.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "awesome-ns",
+				Name:      name,
+			},
+			Spec: networking.IngressSpec{
+				IngressClassName: ingClassName,
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		env      env
+		ingGroup Group
+		want     string
+		wantErr  error
+	}{
+		{
+			name: "members without ingressClassName",
+			ingGroup: Group{
+				Members: []*networking.Ingress{
+					buildIngress("ing-1", nil),
+				},
+			},
+			want: "",
+		},
+		{
+			name: "members with IngressClass without IAM role",
+			env: env{
+				ingClasses: []*networking.IngressClass{ingClassWithoutRole},
+			},
+			ingGroup: Group{
+				Members: []*networking.Ingress{
+					buildIngress("ing-1", awssdk.String("class-default")),
+				},
+			},
+			want: "",
+		},
+		{
+			name: "members with IngressClass with IAM role",
+			env: env{
+				ingClasses: []*networking.IngressClass{ingClassWithRoleA},
+			},
+			ingGroup: Group{
+				Members: []*networking.Ingress{
+					buildIngress("ing-1", awssdk.String("class-a")),
+					buildIngress("ing-2", awssdk.String("class-a")),
+				},
+			},
+			want: "arn:aws:iam::111111111111:role/role-a",
+		},
+		{
+			name: "inactive members are used when there is no members",
+			env: env{
+				ingClasses: []*networking.IngressClass{ingClassWithRoleA},
+			},
+			ingGroup: Group{
+				InactiveMembers: []*networking.Ingress{
+					buildIngress("ing-1", awssdk.String("class-a")),
+				},
+			},
+			want: "arn:aws:iam::111111111111:role/role-a",
+		},
+		{
+			name: "inactive members are ignored when there are members",
+			env: env{
+				ingClasses: []*networking.IngressClass{ingClassWithRoleA, ingClassWithRoleB},
+			},
+			ingGroup: Group{
+				Members: []*networking.Ingress{
+					buildIngress("ing-1", awssdk.String("class-a")),
+				},
+				InactiveMembers: []*networking.Ingress{
+					buildIngress("ing-2", awssdk.String("class-b")),
+				},
+			},
+			want: "arn:aws:iam::111111111111:role/role-a",
+		},
+		{
+			name: "ingressClass not found",
+			ingGroup: Group{
+				InactiveMembers: []*networking.Ingress{
+					buildIngress("ing-1", awssdk.String("class-a")),
+				},
+			},
+			want: "",
+		},
+		{
+			name: "members with conflicting IAM roles",
+			env: env{
+				ingClasses: []*networking.IngressClass{ingClassWithRoleA, ingClassWithRoleB},
+			},
+			ingGroup: Group{
+				Members: []*networking.Ingress{
+					buildIngress("ing-1", awssdk.String("class-a")),
+					buildIngress("ing-2", awssdk.String("class-b")),
+				},
+			},
+			wantErr: errors.New("conflicting IAM roles: [arn:aws:iam::111111111111:role/role-a arn:aws:iam::222222222222:role/role-b]"),
+		},
+		{
+			name: "members with and without IAM role",
+			env: env{
+				ingClasses: []*networking.IngressClass{ingClassWithRoleA},
+			},
+			ingGroup: Group{
+				Members: []*networking.Ingress{
+					buildIngress("ing-1", awssdk.String("class-a")),
+					buildIngress("ing-2", nil),
+				},
+			},
+			wantErr: errors.New("conflicting IAM roles: [ arn:aws:iam::111111111111:role/role-a]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			for _, ingClass := range tt.env.ingClasses {
+				assert.NoError(t, k8sClient.Create(ctx, ingClass.DeepCopy()))
+			}
+
+			annotationParser := annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
+			r := NewDefaultIAMRoleResolver(k8sClient, annotationParser)
+			got, err := r.Resolve(ctx, tt.ingGroup)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
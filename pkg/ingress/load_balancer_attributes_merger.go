@@ -0,0 +1,142 @@
+package ingress
+
+import (
+	"github.com/pkg/errors"
+	networking "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"strconv"
+)
+
+// merge policies for load balancer attributes declared with different values by Ingresses within IngressGroup.
+const (
+	// the largest value wins, only applicable to integer attributes like idle_timeout.timeout_seconds.
+	lbAttrsMergePolicyMax = "max"
+	// the smallest value wins, only applicable to integer attributes like idle_timeout.timeout_seconds.
+	lbAttrsMergePolicyMin = "min"
+	// the value declared by owner Ingresses wins.
+	lbAttrsMergePolicyOwner = "owner"
+)
+
+// lbAttrDeclaration is a load balancer attribute value declared by an Ingress.
+type lbAttrDeclaration struct {
+	value  string
+	ingKey types.NamespacedName
+	owner  bool
+}
+
+// mergeGroupLoadBalancerAttributes merges the load balancer attributes from annotations of Ingresses within IngressGroup.
+// attributes declared with different values by members are conflicts, unless a merge policy is declared for them.
+func mergeGroupLoadBalancerAttributes(annotationParser annotations.Parser, members []*networking.Ingress) (map[string]string, error) {
+	mergePolicies, err := buildGroupLoadBalancerAttributesMergePolicies(annotationParser, members)
+	if err != nil {
+		return nil, err
+	}
+	declarationsByAttrKey := make(map[string][]lbAttrDeclaration)
+	for _, ing := range members {
+		var rawAttributes map[string]string
+		if _, err := annotationParser.ParseStringMapAnnotation(annotations.IngressSuffixLoadBalancerAttributes, &rawAttributes, ing.Annotations); err != nil {
+			return nil, err
+		}
+		if len(rawAttributes) == 0 {
+			continue
+		}
+		owner := false
+		if _, err := annotationParser.ParseBoolAnnotation(annotations.IngressSuffixLoadBalancerAttributesOwner, &owner, ing.Annotations); err != nil {
+			return nil, err
+		}
+		for attrKey, attrValue := range rawAttributes {
+			declarationsByAttrKey[attrKey] = append(declarationsByAttrKey[attrKey], lbAttrDeclaration{
+				value:  attrValue,
+				ingKey: k8s.NamespacedName(ing),
+				owner:  owner,
+			})
+		}
+	}
+
+	mergedAttributes := make(map[string]string, len(declarationsByAttrKey))
+	for attrKey, declarations := range declarationsByAttrKey {
+		attrValue, err := mergeLoadBalancerAttribute(attrKey, mergePolicies[attrKey], declarations)
+		if err != nil {
+			return nil, err
+		}
+		mergedAttributes[attrKey] = attrValue
+	}
+	return mergedAttributes, nil
+}
+
+// buildGroupLoadBalancerAttributesMergePolicies builds the merge policy of load balancer attributes from annotations of Ingresses within IngressGroup.
+// members may declare merge policy for different attributes, but they must agree on the merge policy of the same attribute.
+func buildGroupLoadBalancerAttributesMergePolicies(annotationParser annotations.Parser, members []*networking.Ingress) (map[string]string, error) {
+	mergePolicies := make(map[string]string)
+	for _, ing := range members {
+		var rawMergePolicies map[string]string
+		if _, err := annotationParser.ParseStringMapAnnotation(annotations.IngressSuffixLoadBalancerAttributesMergePolicy, &rawMergePolicies, ing.Annotations); err != nil {
+			return nil, err
+		}
+		if err := validateLoadBalancerAttributesMergePolicies(rawMergePolicies); err != nil {
+			return nil, errors.Wrapf(err, "ingress: %v", k8s.NamespacedName(ing))
+		}
+		for attrKey, mergePolicy := range rawMergePolicies {
+			if existingMergePolicy, exists := mergePolicies[attrKey]; exists && existingMergePolicy != mergePolicy {
+				return nil, errors.Errorf("conflicting merge policy for loadBalancerAttribute %v: %v | %v", attrKey, existingMergePolicy, mergePolicy)
+			}
+			mergePolicies[attrKey] = mergePolicy
+		}
+	}
+	return mergePolicies, nil
+}
+
+// mergeLoadBalancerAttribute merges the values of a load balancer attribute declared by Ingresses with mergePolicy.
+func mergeLoadBalancerAttribute(attrKey string, mergePolicy string, declarations []lbAttrDeclaration) (string, error) {
+	switch mergePolicy {
+	case lbAttrsMergePolicyMax, lbAttrsMergePolicyMin:
+		var mergedValue int64
+		for index, declaration := range declarations {
+			value, err := strconv.ParseInt(declaration.value, 10, 64)
+			if err != nil {
+				return "", errors.Errorf("loadBalancerAttribute %v with merge policy %v must be an integer, got %v from Ingress %v",
+					attrKey, mergePolicy, declaration.value, declaration.ingKey)
+			}
+			if index == 0 || (mergePolicy == lbAttrsMergePolicyMax && value > mergedValue) ||
+				(mergePolicy == lbAttrsMergePolicyMin && value < mergedValue) {
+				mergedValue = value
+			}
+		}
+		return strconv.FormatInt(mergedValue, 10), nil
+	case lbAttrsMergePolicyOwner:
+		var ownerDeclarations []lbAttrDeclaration
+		for _, declaration := range declarations {
+			if declaration.owner {
+				ownerDeclarations = append(ownerDeclarations, declaration)
+			}
+		}
+		// values from other members must agree if none of the owners declares this attribute.
+		if len(ownerDeclarations) != 0 {
+			declarations = ownerDeclarations
+		}
+	}
+
+	for _, declaration := range declarations[1:] {
+		if declaration.value != declarations[0].value {
+			return "", errors.Errorf("conflicting loadBalancerAttribute %v: %v | %v, declared by Ingress %v and %v",
+				attrKey, declarations[0].value, declaration.value, declarations[0].ingKey, declaration.ingKey)
+		}
+	}
+	return declarations[0].value, nil
+}
+
+// validateLoadBalancerAttributesMergePolicies validates merge policies of load balancer attributes.
+func validateLoadBalancerAttributesMergePolicies(mergePolicies map[string]string) error {
+	for _, attrKey := range sets.StringKeySet(mergePolicies).List() {
+		switch mergePolicies[attrKey] {
+		case lbAttrsMergePolicyMax, lbAttrsMergePolicyMin, lbAttrsMergePolicyOwner:
+		default:
+			return errors.Errorf("invalid merge policy for loadBalancerAttribute %v: %v, must be %v, %v or %v",
+				attrKey, mergePolicies[attrKey], lbAttrsMergePolicyMax, lbAttrsMergePolicyMin, lbAttrsMergePolicyOwner)
+		}
+	}
+	return nil
+}
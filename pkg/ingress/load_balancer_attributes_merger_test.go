@@ -0,0 +1,170 @@
+package ingress
+
+import (
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	networking "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"testing"
+)
+
+func Test_mergeGroupLoadBalancerAttributes(t *testing.T) {
+	buildIngress := func(name string, annotations map[string]string) *networking.Ingress {
+		return &networking.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "awesome-ns",
+				Name:        name,
+				Annotations: annotations,
+			},
+		}
+	}
+	tests := []struct {
+		name    string
+		members []*networking.Ingress
+		want    map[string]string
+		wantErr error
+	}{
+		{
+			name: "attributes from members are merged",
+			members: []*networking.Ingress{
+				buildIngress("ing-1", map[string]string{
+					"alb.ingress.kubernetes.io/load-balancer-attributes": "idle_timeout.timeout_seconds=600,routing.http2.enabled=true",
+				}),
+				buildIngress("ing-2", map[string]string{
+					"alb.ingress.kubernetes.io/load-balancer-attributes": "idle_timeout.timeout_seconds=600,deletion_protection.enabled=true",
+				}),
+				buildIngress("ing-3", nil),
+			},
+			want: map[string]string{
+				"idle_timeout.timeout_seconds": "600",
+				"routing.http2.enabled":        "true",
+				"deletion_protection.enabled":  "true",
+			},
+		},
+		{
+			name: "conflicting attributes without merge policy",
+			members: []*networking.Ingress{
+				buildIngress("ing-1", map[string]string{
+					"alb.ingress.kubernetes.io/load-balancer-attributes": "idle_timeout.timeout_seconds=600",
+				}),
+				buildIngress("ing-2", map[string]string{
+					"alb.ingress.kubernetes.io/load-balancer-attributes": "idle_timeout.timeout_seconds=120",
+				}),
+			},
+			wantErr: errors.New("conflicting loadBalancerAttribute idle_timeout.timeout_seconds: 600 | 120, declared by Ingress awesome-ns/ing-1 and awesome-ns/ing-2"),
+		},
+		{
+			name: "conflicting attributes with max merge policy",
+			members: []*networking.Ingress{
+				buildIngress("ing-1", map[string]string{
+					"alb.ingress.kubernetes.io/load-balancer-attributes": "idle_timeout.timeout_seconds=120",
+				}),
+				buildIngress("ing-2", map[string]string{
+					"alb.ingress.kubernetes.io/load-balancer-attributes":              "idle_timeout.timeout_seconds=600",
+					"alb.ingress.kubernetes.io/load-balancer-attributes.merge-policy": "idle_timeout.timeout_seconds=max",
+				}),
+				buildIngress("ing-3", map[string]string{
+					"alb.ingress.kubernetes.io/load-balancer-attributes": "idle_timeout.timeout_seconds=300",
+				}),
+			},
+			want: map[string]string{
+				"idle_timeout.timeout_seconds": "600",
+			},
+		},
+		{
+			name: "conflicting attributes with min merge policy",
+			members: []*networking.Ingress{
+				buildIngress("ing-1", map[string]string{
+					"alb.ingress.kubernetes.io/load-balancer-attributes":              "idle_timeout.timeout_seconds=120",
+					"alb.ingress.kubernetes.io/load-balancer-attributes.merge-policy": "idle_timeout.timeout_seconds=min",
+				}),
+				buildIngress("ing-2", map[string]string{
+					"alb.ingress.kubernetes.io/load-balancer-attributes":              "idle_timeout.timeout_seconds=60",
+					"alb.ingress.kubernetes.io/load-balancer-attributes.merge-policy": "idle_timeout.timeout_seconds=min",
+				}),
+			},
+			want: map[string]string{
+				"idle_timeout.timeout_seconds": "60",
+			},
+		},
+		{
+			name: "non-integer attributes with max merge policy",
+			members: []*networking.Ingress{
+				buildIngress("ing-1", map[string]string{
+					"alb.ingress.kubernetes.io/load-balancer-attributes":              "routing.http2.enabled=true",
+					"alb.ingress.kubernetes.io/load-balancer-attributes.merge-policy": "routing.http2.enabled=max",
+				}),
+			},
+			wantErr: errors.New("loadBalancerAttribute routing.http2.enabled with merge policy max must be an integer, got true from Ingress awesome-ns/ing-1"),
+		},
+		{
+			name: "conflicting attributes with owner merge policy",
+			members: []*networking.Ingress{
+				buildIngress("ing-1", map[string]string{
+					"alb.ingress.kubernetes.io/load-balancer-attributes":              "idle_timeout.timeout_seconds=120,routing.http2.enabled=false",
+					"alb.ingress.kubernetes.io/load-balancer-attributes.merge-policy": "idle_timeout.timeout_seconds=owner,routing.http2.enabled=owner",
+				}),
+				buildIngress("ing-2", map[string]string{
+					"alb.ingress.kubernetes.io/load-balancer-attributes":       "idle_timeout.timeout_seconds=600",
+					"alb.ingress.kubernetes.io/load-balancer-attributes.owner": "true",
+				}),
+				buildIngress("ing-3", map[string]string{
+					"alb.ingress.kubernetes.io/load-balancer-attributes": "idle_timeout.timeout_seconds=300,routing.http2.enabled=false",
+				}),
+			},
+			want: map[string]string{
+				"idle_timeout.timeout_seconds": "600",
+				"routing.http2.enabled":        "false",
+			},
+		},
+		{
+			name: "conflicting attributes between owners",
+			members: []*networking.Ingress{
+				buildIngress("ing-1", map[string]string{
+					"alb.ingress.kubernetes.io/load-balancer-attributes":              "idle_timeout.timeout_seconds=120",
+					"alb.ingress.kubernetes.io/load-balancer-attributes.merge-policy": "idle_timeout.timeout_seconds=owner",
+					"alb.ingress.kubernetes.io/load-balancer-attributes.owner":        "true",
+				}),
+				buildIngress("ing-2", map[string]string{
+					"alb.ingress.kubernetes.io/load-balancer-attributes":       "idle_timeout.timeout_seconds=600",
+					"alb.ingress.kubernetes.io/load-balancer-attributes.owner": "true",
+				}),
+			},
+			wantErr: errors.New("conflicting loadBalancerAttribute idle_timeout.timeout_seconds: 120 | 600, declared by Ingress awesome-ns/ing-1 and awesome-ns/ing-2"),
+		},
+		{
+			name: "conflicting merge policies",
+			members: []*networking.Ingress{
+				buildIngress("ing-1", map[string]string{
+					"alb.ingress.kubernetes.io/load-balancer-attributes.merge-policy": "idle_timeout.timeout_seconds=max",
+				}),
+				buildIngress("ing-2", map[string]string{
+					"alb.ingress.kubernetes.io/load-balancer-attributes.merge-policy": "idle_timeout.timeout_seconds=owner",
+				}),
+			},
+			wantErr: errors.New("conflicting merge policy for loadBalancerAttribute idle_timeout.timeout_seconds: max | owner"),
+		},
+		{
+			name: "invalid merge policy",
+			members: []*networking.Ingress{
+				buildIngress("ing-1", map[string]string{
+					"alb.ingress.kubernetes.io/load-balancer-attributes.merge-policy": "idle_timeout.timeout_seconds=last",
+				}),
+			},
+			wantErr: errors.New("ingress: awesome-ns/ing-1: invalid merge policy for loadBalancerAttribute idle_timeout.timeout_seconds: last, must be max, min or owner"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			annotationParser := annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
+			got, err := mergeGroupLoadBalancerAttributes(annotationParser, tt.members)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
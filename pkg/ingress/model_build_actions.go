@@ -14,6 +14,9 @@ import (
 	"unicode"
 )
 
+// the maximum length of message body for fixed response actions.
+const maxFixedResponseMessageBodyLength = 1024
+
 func (t *defaultModelBuildTask) buildActions(ctx context.Context, protocol elbv2model.Protocol, ing *networking.Ingress, backend EnhancedBackend) ([]elbv2model.Action, error) {
 	var actions []elbv2model.Action
 	if protocol == elbv2model.ProtocolHTTPS {
@@ -36,7 +39,7 @@ func (t *defaultModelBuildTask) buildActions(ctx context.Context, protocol elbv2
 func (t *defaultModelBuildTask) buildBackendAction(ctx context.Context, ing *networking.Ingress, actionCfg Action) (elbv2model.Action, error) {
 	switch actionCfg.Type {
 	case ActionTypeFixedResponse:
-		return t.buildFixedResponseAction(ctx, actionCfg)
+		return t.buildFixedResponseAction(ctx, ing, actionCfg)
 	case ActionTypeRedirect:
 		return t.buildRedirectAction(ctx, actionCfg)
 	case ActionTypeForward:
@@ -69,6 +72,10 @@ func (t *defaultModelBuildTask) buildAuthAction(ctx context.Context, ing *networ
 	if err != nil {
 		return nil, err
 	}
+	// auth settings for specific backend will take priority than service and ingress.
+	if backend.AuthSettings != nil {
+		authCfg = applyAuthSettings(authCfg, *backend.AuthSettings)
+	}
 	switch authCfg.Type {
 	case AuthTypeCognito:
 		action, err := t.buildAuthenticateCognitoAction(ctx, authCfg)
@@ -87,20 +94,64 @@ func (t *defaultModelBuildTask) buildAuthAction(ctx context.Context, ing *networ
 	}
 }
 
-func (t *defaultModelBuildTask) buildFixedResponseAction(_ context.Context, actionCfg Action) (elbv2model.Action, error) {
+// applyAuthSettings overrides authCfg with explicitly specified settings.
+func applyAuthSettings(authCfg AuthConfig, authSettings AuthSettings) AuthConfig {
+	if authSettings.OnUnauthenticatedRequest != nil {
+		authCfg.OnUnauthenticatedRequest = *authSettings.OnUnauthenticatedRequest
+	}
+	if authSettings.Scope != nil {
+		authCfg.Scope = *authSettings.Scope
+	}
+	if authSettings.SessionCookieName != nil {
+		authCfg.SessionCookieName = *authSettings.SessionCookieName
+	}
+	if authSettings.SessionTimeout != nil {
+		authCfg.SessionTimeout = *authSettings.SessionTimeout
+	}
+	return authCfg
+}
+
+func (t *defaultModelBuildTask) buildFixedResponseAction(ctx context.Context, ing *networking.Ingress, actionCfg Action) (elbv2model.Action, error) {
 	if actionCfg.FixedResponseConfig == nil {
 		return elbv2model.Action{}, errors.New("missing FixedResponseConfig")
 	}
+	messageBody := actionCfg.FixedResponseConfig.MessageBody
+	if actionCfg.FixedResponseConfig.MessageBodyConfigMapRef != nil {
+		var err error
+		messageBody, err = t.buildFixedResponseMessageBodyFromConfigMap(ctx, ing.Namespace, *actionCfg.FixedResponseConfig.MessageBodyConfigMapRef)
+		if err != nil {
+			return elbv2model.Action{}, err
+		}
+	}
 	return elbv2model.Action{
 		Type: elbv2model.ActionTypeFixedResponse,
 		FixedResponseConfig: &elbv2model.FixedResponseActionConfig{
 			ContentType: actionCfg.FixedResponseConfig.ContentType,
-			MessageBody: actionCfg.FixedResponseConfig.MessageBody,
+			MessageBody: messageBody,
 			StatusCode:  actionCfg.FixedResponseConfig.StatusCode,
 		},
 	}, nil
 }
 
+func (t *defaultModelBuildTask) buildFixedResponseMessageBodyFromConfigMap(ctx context.Context, namespace string, configMapRef ConfigMapKeyReference) (*string, error) {
+	configMapKey := types.NamespacedName{
+		Namespace: namespace,
+		Name:      configMapRef.Name,
+	}
+	configMap := &corev1.ConfigMap{}
+	if err := t.k8sClient.Get(ctx, configMapKey, configMap); err != nil {
+		return nil, err
+	}
+	rawMessageBody, ok := configMap.Data[configMapRef.Key]
+	if !ok {
+		return nil, errors.Errorf("missing key %v in configMap %v", configMapRef.Key, configMapKey)
+	}
+	if len(rawMessageBody) > maxFixedResponseMessageBodyLength {
+		return nil, errors.Errorf("message body from configMap %v exceeds %v characters", configMapKey, maxFixedResponseMessageBodyLength)
+	}
+	return awssdk.String(rawMessageBody), nil
+}
+
 func (t *defaultModelBuildTask) buildRedirectAction(_ context.Context, actionCfg Action) (elbv2model.Action, error) {
 	if actionCfg.RedirectConfig == nil {
 		return elbv2model.Action{}, errors.New("missing RedirectConfig")
@@ -123,7 +174,15 @@ func (t *defaultModelBuildTask) buildForwardAction(ctx context.Context, ing *net
 		return elbv2model.Action{}, errors.New("missing ForwardConfig")
 	}
 
+	var rampCfg *WeightRampConfig
+	if len(actionCfg.ForwardConfig.TargetGroups) > 1 {
+		var err error
+		if rampCfg, err = parseWeightRampConfig(t.annotationParser, ing); err != nil {
+			return elbv2model.Action{}, err
+		}
+	}
 	var targetGroupTuples []elbv2model.TargetGroupTuple
+	externallyManagedWeights := false
 	for _, tgt := range actionCfg.ForwardConfig.TargetGroups {
 		var tgARN core.StringToken
 		if tgt.TargetGroupARN != nil {
@@ -141,7 +200,31 @@ func (t *defaultModelBuildTask) buildForwardAction(ctx context.Context, ing *net
 			if err != nil {
 				return elbv2model.Action{}, err
 			}
+			blueGreenTGTuples, weightsPaused, err := t.buildCodeDeployBlueGreenTargetGroupTuples(ctx, ing, svc, *tgt.ServicePort, tg)
+			if err != nil {
+				return elbv2model.Action{}, err
+			}
+			if blueGreenTGTuples != nil {
+				if len(actionCfg.ForwardConfig.TargetGroups) != 1 {
+					return elbv2model.Action{}, errors.Errorf("CodeDeploy blue/green mode requires service %v to be the only target of forward action", svcKey)
+				}
+				targetGroupTuples = append(targetGroupTuples, blueGreenTGTuples...)
+				externallyManagedWeights = weightsPaused
+				continue
+			}
 			tgARN = tg.TargetGroupARN()
+			if rampCfg != nil {
+				tgbKey := types.NamespacedName{Namespace: svc.Namespace, Name: tg.Spec.Name}
+				weight, err := t.buildRampedTargetGroupWeight(ctx, *rampCfg, tgbKey, tgt.Weight)
+				if err != nil {
+					return elbv2model.Action{}, err
+				}
+				targetGroupTuples = append(targetGroupTuples, elbv2model.TargetGroupTuple{
+					TargetGroupARN: tgARN,
+					Weight:         weight,
+				})
+				continue
+			}
 		}
 		targetGroupTuples = append(targetGroupTuples, elbv2model.TargetGroupTuple{
 			TargetGroupARN: tgARN,
@@ -161,6 +244,7 @@ func (t *defaultModelBuildTask) buildForwardAction(ctx context.Context, ing *net
 		ForwardConfig: &elbv2model.ForwardActionConfig{
 			TargetGroups:                targetGroupTuples,
 			TargetGroupStickinessConfig: stickinessCfg,
+			ExternallyManagedWeights:    externallyManagedWeights,
 		},
 	}, nil
 }
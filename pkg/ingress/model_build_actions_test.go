@@ -6,11 +6,13 @@ import (
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"strings"
 	"testing"
 )
 
@@ -248,3 +250,259 @@ func Test_defaultModelBuildTask_buildAuthenticateOIDCAction(t *testing.T) {
 		})
 	}
 }
+
+func Test_applyAuthSettings(t *testing.T) {
+	baseAuthCfg := AuthConfig{
+		Type: AuthTypeCognito,
+		IDPConfigCognito: &AuthIDPConfigCognito{
+			UserPoolARN:      "pool-arn",
+			UserPoolClientID: "pool-client-id",
+			UserPoolDomain:   "pool-domain",
+		},
+		OnUnauthenticatedRequest: "authenticate",
+		Scope:                    "openid",
+		SessionCookieName:        "AWSELBAuthSessionCookie",
+		SessionTimeout:           604800,
+	}
+	type args struct {
+		authCfg      AuthConfig
+		authSettings AuthSettings
+	}
+	tests := []struct {
+		name string
+		args args
+		want AuthConfig
+	}{
+		{
+			name: "empty auth settings",
+			args: args{
+				authCfg:      baseAuthCfg,
+				authSettings: AuthSettings{},
+			},
+			want: baseAuthCfg,
+		},
+		{
+			name: "override all auth settings",
+			args: args{
+				authCfg: baseAuthCfg,
+				authSettings: AuthSettings{
+					OnUnauthenticatedRequest: awssdk.String("allow"),
+					Scope:                    awssdk.String("email openid"),
+					SessionCookieName:        awssdk.String("my-cookie"),
+					SessionTimeout:           awssdk.Int64(3600),
+				},
+			},
+			want: AuthConfig{
+				Type:                     AuthTypeCognito,
+				IDPConfigCognito:         baseAuthCfg.IDPConfigCognito,
+				OnUnauthenticatedRequest: "allow",
+				Scope:                    "email openid",
+				SessionCookieName:        "my-cookie",
+				SessionTimeout:           3600,
+			},
+		},
+		{
+			name: "override partial auth settings",
+			args: args{
+				authCfg: baseAuthCfg,
+				authSettings: AuthSettings{
+					Scope: awssdk.String("email openid"),
+				},
+			},
+			want: AuthConfig{
+				Type:                     AuthTypeCognito,
+				IDPConfigCognito:         baseAuthCfg.IDPConfigCognito,
+				OnUnauthenticatedRequest: "authenticate",
+				Scope:                    "email openid",
+				SessionCookieName:        "AWSELBAuthSessionCookie",
+				SessionTimeout:           604800,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := applyAuthSettings(tt.args.authCfg, tt.args.authSettings)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildFixedResponseAction(t *testing.T) {
+	type env struct {
+		configMaps []*corev1.ConfigMap
+	}
+	type args struct {
+		ing       *networking.Ingress
+		actionCfg Action
+	}
+	tests := []struct {
+		name    string
+		env     env
+		args    args
+		want    elbv2model.Action
+		wantErr error
+	}{
+		{
+			name: "message body specified inline",
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "my-ns",
+						Name:      "my-ing",
+					},
+				},
+				actionCfg: Action{
+					Type: ActionTypeFixedResponse,
+					FixedResponseConfig: &FixedResponseActionConfig{
+						ContentType: awssdk.String("text/plain"),
+						MessageBody: awssdk.String("503 error text"),
+						StatusCode:  "503",
+					},
+				},
+			},
+			want: elbv2model.Action{
+				Type: elbv2model.ActionTypeFixedResponse,
+				FixedResponseConfig: &elbv2model.FixedResponseActionConfig{
+					ContentType: awssdk.String("text/plain"),
+					MessageBody: awssdk.String("503 error text"),
+					StatusCode:  "503",
+				},
+			},
+		},
+		{
+			name: "message body from configMap",
+			env: env{
+				configMaps: []*corev1.ConfigMap{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "my-ns",
+							Name:      "maintenance-page",
+						},
+						Data: map[string]string{
+							"index.html": "<html>under maintenance</html>",
+						},
+					},
+				},
+			},
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "my-ns",
+						Name:      "my-ing",
+					},
+				},
+				actionCfg: Action{
+					Type: ActionTypeFixedResponse,
+					FixedResponseConfig: &FixedResponseActionConfig{
+						ContentType: awssdk.String("text/html"),
+						MessageBodyConfigMapRef: &ConfigMapKeyReference{
+							Name: "maintenance-page",
+							Key:  "index.html",
+						},
+						StatusCode: "503",
+					},
+				},
+			},
+			want: elbv2model.Action{
+				Type: elbv2model.ActionTypeFixedResponse,
+				FixedResponseConfig: &elbv2model.FixedResponseActionConfig{
+					ContentType: awssdk.String("text/html"),
+					MessageBody: awssdk.String("<html>under maintenance</html>"),
+					StatusCode:  "503",
+				},
+			},
+		},
+		{
+			name: "message body from configMap - key missing",
+			env: env{
+				configMaps: []*corev1.ConfigMap{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "my-ns",
+							Name:      "maintenance-page",
+						},
+						Data: map[string]string{
+							"index.html": "<html>under maintenance</html>",
+						},
+					},
+				},
+			},
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "my-ns",
+						Name:      "my-ing",
+					},
+				},
+				actionCfg: Action{
+					Type: ActionTypeFixedResponse,
+					FixedResponseConfig: &FixedResponseActionConfig{
+						MessageBodyConfigMapRef: &ConfigMapKeyReference{
+							Name: "maintenance-page",
+							Key:  "503.html",
+						},
+						StatusCode: "503",
+					},
+				},
+			},
+			wantErr: errors.New("missing key 503.html in configMap my-ns/maintenance-page"),
+		},
+		{
+			name: "message body from configMap - message body too long",
+			env: env{
+				configMaps: []*corev1.ConfigMap{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "my-ns",
+							Name:      "maintenance-page",
+						},
+						Data: map[string]string{
+							"index.html": strings.Repeat("a", 1025),
+						},
+					},
+				},
+			},
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "my-ns",
+						Name:      "my-ing",
+					},
+				},
+				actionCfg: Action{
+					Type: ActionTypeFixedResponse,
+					FixedResponseConfig: &FixedResponseActionConfig{
+						MessageBodyConfigMapRef: &ConfigMapKeyReference{
+							Name: "maintenance-page",
+							Key:  "index.html",
+						},
+						StatusCode: "503",
+					},
+				},
+			},
+			wantErr: errors.New("message body from configMap my-ns/maintenance-page exceeds 1024 characters"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			for _, configMap := range tt.env.configMaps {
+				assert.NoError(t, k8sClient.Create(ctx, configMap.DeepCopy()))
+			}
+
+			task := &defaultModelBuildTask{
+				k8sClient: k8sClient,
+			}
+			got, err := task.buildFixedResponseAction(ctx, tt.args.ing, tt.args.actionCfg)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package ingress
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"strings"
+	"time"
+)
+
+const (
+	codeDeployColorBlue  = "blue"
+	codeDeployColorGreen = "green"
+)
+
+// buildCodeDeployBlueGreenTargetGroupTuples builds the target groups to forward to for backend service in CodeDeploy blue/green mode.
+// a "green" TargetGroup without TargetGroupBinding is provisioned alongside the "blue" TargetGroup of backend service,
+// whose targets are managed externally during CodeDeploy deployments.
+// the returned bool indicates whether weights of target groups are left to CodeDeploy traffic shifting.
+// nil target groups are returned if CodeDeploy blue/green mode isn't enabled for backend service.
+func (t *defaultModelBuildTask) buildCodeDeployBlueGreenTargetGroupTuples(ctx context.Context, ing *networking.Ingress,
+	svc *corev1.Service, port intstr.IntOrString, blueTG *elbv2model.TargetGroup) ([]elbv2model.TargetGroupTuple, bool, error) {
+	svcAndIngAnnotations := algorithm.MergeStringMap(svc.Annotations, ing.Annotations)
+	enabled := false
+	if _, err := t.annotationParser.ParseBoolAnnotation(annotations.IngressSuffixCodeDeployBlueGreen, &enabled, svcAndIngAnnotations); err != nil {
+		return nil, false, err
+	}
+	if !enabled {
+		return nil, false, nil
+	}
+	activeColor := codeDeployColorBlue
+	_ = t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixCodeDeployActiveColor, &activeColor, svcAndIngAnnotations)
+	if activeColor != codeDeployColorBlue && activeColor != codeDeployColorGreen {
+		return nil, false, errors.Errorf("invalid CodeDeploy active color: %v, must be %v or %v", activeColor, codeDeployColorBlue, codeDeployColorGreen)
+	}
+	weightsPaused, err := t.buildCodeDeployWeightsPaused(ctx, svcAndIngAnnotations)
+	if err != nil {
+		return nil, false, err
+	}
+
+	greenTG := t.buildCodeDeployGreenTargetGroup(ctx, ing, svc, port, blueTG)
+	blueWeight, greenWeight := int64(100), int64(0)
+	if activeColor == codeDeployColorGreen {
+		blueWeight, greenWeight = 0, 100
+	}
+	return []elbv2model.TargetGroupTuple{
+		{
+			TargetGroupARN: blueTG.TargetGroupARN(),
+			Weight:         awssdk.Int64(blueWeight),
+		},
+		{
+			TargetGroupARN: greenTG.TargetGroupARN(),
+			Weight:         awssdk.Int64(greenWeight),
+		},
+	}, weightsPaused, nil
+}
+
+// buildCodeDeployGreenTargetGroup builds the "green" TargetGroup that mirrors the settings of "blue" TargetGroup.
+func (t *defaultModelBuildTask) buildCodeDeployGreenTargetGroup(_ context.Context, ing *networking.Ingress,
+	svc *corev1.Service, port intstr.IntOrString, blueTG *elbv2model.TargetGroup) *elbv2model.TargetGroup {
+	tgResID := fmt.Sprintf("%s:%s", t.buildTargetGroupResourceID(k8s.NamespacedName(ing), k8s.NamespacedName(svc), port), codeDeployColorGreen)
+	if tg, exists := t.tgByResID[tgResID]; exists {
+		return tg
+	}
+	tgSpec := blueTG.Spec
+	tgSpec.Name = buildCodeDeployGreenTargetGroupName(blueTG.Spec.Name)
+	tg := elbv2model.NewTargetGroup(t.stack, tgResID, tgSpec)
+	t.tgByResID[tgResID] = tg
+	return tg
+}
+
+// buildCodeDeployWeightsPaused checks whether reconciliation of target group weights is paused for CodeDeploy traffic shifting.
+func (t *defaultModelBuildTask) buildCodeDeployWeightsPaused(_ context.Context, svcAndIngAnnotations map[string]string) (bool, error) {
+	rawPausedUntil := ""
+	if exists := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixCodeDeployPausedUntil, &rawPausedUntil, svcAndIngAnnotations); !exists {
+		return false, nil
+	}
+	pausedUntil, err := time.Parse(time.RFC3339, rawPausedUntil)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to parse CodeDeploy paused-until time: %v", rawPausedUntil)
+	}
+	return time.Now().Before(pausedUntil), nil
+}
+
+// buildCodeDeployGreenTargetGroupName derives the name of "green" TargetGroup from the name of "blue" TargetGroup.
+func buildCodeDeployGreenTargetGroupName(blueTGName string) string {
+	uuidHash := sha256.New()
+	_, _ = uuidHash.Write([]byte(blueTGName))
+	_, _ = uuidHash.Write([]byte(codeDeployColorGreen))
+	uuid := hex.EncodeToString(uuidHash.Sum(nil))
+
+	namePrefix := blueTGName
+	if idx := strings.LastIndex(blueTGName, "-"); idx != -1 {
+		namePrefix = blueTGName[:idx]
+	}
+	return fmt.Sprintf("%.21s-%.10s", namePrefix, uuid)
+}
@@ -0,0 +1,143 @@
+package ingress
+
+import (
+	"context"
+	"errors"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"testing"
+	"time"
+)
+
+func Test_defaultModelBuildTask_buildCodeDeployBlueGreenTargetGroupTuples(t *testing.T) {
+	tests := []struct {
+		name              string
+		ingAnnotations    map[string]string
+		svcAnnotations    map[string]string
+		wantWeights       []int64
+		wantWeightsPaused bool
+		wantGreenTGName   string
+		wantErr           error
+	}{
+		{
+			name:           "blue/green mode not enabled",
+			ingAnnotations: map[string]string{},
+			svcAnnotations: map[string]string{},
+		},
+		{
+			name:           "blue/green mode enabled on service",
+			ingAnnotations: map[string]string{},
+			svcAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/codedeploy-blue-green": "true",
+			},
+			wantWeights:     []int64{100, 0},
+			wantGreenTGName: "k8s-awesomen-svc1-c68420758e",
+		},
+		{
+			name: "blue/green mode enabled with green active",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/codedeploy-blue-green":   "true",
+				"alb.ingress.kubernetes.io/codedeploy-active-color": "green",
+			},
+			svcAnnotations:  map[string]string{},
+			wantWeights:     []int64{0, 100},
+			wantGreenTGName: "k8s-awesomen-svc1-c68420758e",
+		},
+		{
+			name:           "blue/green mode paused for traffic shifting",
+			ingAnnotations: map[string]string{},
+			svcAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/codedeploy-blue-green":   "true",
+				"alb.ingress.kubernetes.io/codedeploy-paused-until": time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+			},
+			wantWeights:       []int64{100, 0},
+			wantWeightsPaused: true,
+			wantGreenTGName:   "k8s-awesomen-svc1-c68420758e",
+		},
+		{
+			name:           "blue/green mode pause expired",
+			ingAnnotations: map[string]string{},
+			svcAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/codedeploy-blue-green":   "true",
+				"alb.ingress.kubernetes.io/codedeploy-paused-until": "2021-01-01T00:00:00Z",
+			},
+			wantWeights:     []int64{100, 0},
+			wantGreenTGName: "k8s-awesomen-svc1-c68420758e",
+		},
+		{
+			name:           "invalid active color",
+			ingAnnotations: map[string]string{},
+			svcAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/codedeploy-blue-green":   "true",
+				"alb.ingress.kubernetes.io/codedeploy-active-color": "red",
+			},
+			wantErr: errors.New("invalid CodeDeploy active color: red, must be blue or green"),
+		},
+		{
+			name:           "invalid paused-until time",
+			ingAnnotations: map[string]string{},
+			svcAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/codedeploy-blue-green":   "true",
+				"alb.ingress.kubernetes.io/codedeploy-paused-until": "1h",
+			},
+			wantErr: errors.New("failed to parse CodeDeploy paused-until time: 1h: parsing time \"1h\" as \"2006-01-02T15:04:05Z07:00\": cannot parse \"1h\" as \"2006\""),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stack := core.NewDefaultStack(core.StackID{Namespace: "awesome-ns", Name: "ing-1"})
+			task := &defaultModelBuildTask{
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				stack:            stack,
+				tgByResID:        make(map[string]*elbv2model.TargetGroup),
+			}
+			ing := &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "awesome-ns",
+					Name:        "ing-1",
+					Annotations: tt.ingAnnotations,
+				},
+			}
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "awesome-ns",
+					Name:        "svc-1",
+					Annotations: tt.svcAnnotations,
+				},
+			}
+			blueTG := elbv2model.NewTargetGroup(stack, "awesome-ns/ing-1-svc-1:http", elbv2model.TargetGroupSpec{
+				Name:       "k8s-awesomen-svc1-9d7d2f7b0a",
+				TargetType: elbv2model.TargetTypeIP,
+				Port:       8080,
+			})
+			got, gotWeightsPaused, err := task.buildCodeDeployBlueGreenTargetGroupTuples(context.Background(), ing, svc, intstr.FromString("http"), blueTG)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantWeightsPaused, gotWeightsPaused)
+			if tt.wantWeights == nil {
+				assert.Nil(t, got)
+				return
+			}
+			var gotWeights []int64
+			for _, tgt := range got {
+				gotWeights = append(gotWeights, awssdk.Int64Value(tgt.Weight))
+			}
+			assert.Equal(t, tt.wantWeights, gotWeights)
+			greenTG := task.tgByResID["awesome-ns/ing-1-svc-1:http:green"]
+			assert.NotNil(t, greenTG)
+			assert.Equal(t, tt.wantGreenTGName, greenTG.Spec.Name)
+			assert.Equal(t, blueTG.Spec.TargetType, greenTG.Spec.TargetType)
+			assert.Equal(t, blueTG.Spec.Port, greenTG.Spec.Port)
+		})
+	}
+}
@@ -5,11 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	networking "k8s.io/api/networking/v1beta1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"net"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
@@ -38,13 +40,21 @@ func (t *defaultModelBuildTask) buildListenerSpec(ctx context.Context, lbARN cor
 			CertificateARN: awssdk.String(certARN),
 		})
 	}
+	var listenerAttributes []elbv2model.ListenerAttribute
+	for _, attrKey := range sets.StringKeySet(config.listenerAttributes).List() {
+		listenerAttributes = append(listenerAttributes, elbv2model.ListenerAttribute{
+			Key:   attrKey,
+			Value: config.listenerAttributes[attrKey],
+		})
+	}
 	return elbv2model.ListenerSpec{
-		LoadBalancerARN: lbARN,
-		Port:            port,
-		Protocol:        config.protocol,
-		DefaultActions:  defaultActions,
-		Certificates:    certs,
-		SSLPolicy:       config.sslPolicy,
+		LoadBalancerARN:    lbARN,
+		Port:               port,
+		Protocol:           config.protocol,
+		DefaultActions:     defaultActions,
+		Certificates:       certs,
+		SSLPolicy:          config.sslPolicy,
+		ListenerAttributes: listenerAttributes,
 	}, nil
 }
 
@@ -56,8 +66,11 @@ func (t *defaultModelBuildTask) buildListenerDefaultActions(ctx context.Context,
 		}
 	}
 	if len(ingsWithDefaultBackend) == 0 {
-		action404 := t.build404Action(ctx)
-		return []elbv2model.Action{action404}, nil
+		groupDefaultAction, err := t.buildGroupDefaultAction(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return []elbv2model.Action{groupDefaultAction}, nil
 	}
 	if len(ingsWithDefaultBackend) > 1 {
 		ingKeys := make([]types.NamespacedName, 0, len(ingsWithDefaultBackend))
@@ -74,6 +87,104 @@ func (t *defaultModelBuildTask) buildListenerDefaultActions(ctx context.Context,
 	return t.buildActions(ctx, protocol, ing, enhancedBackend)
 }
 
+// buildGroupDefaultAction builds the listener default action for IngressGroup without default backend.
+// The default action from IngressClassParams takes precedence over the group.default-action annotation of Ingresses within IngressGroup,
+// and it's a fixed 404 response if neither is specified.
+func (t *defaultModelBuildTask) buildGroupDefaultAction(ctx context.Context) (elbv2model.Action, error) {
+	actionCfg, err := t.buildIngressClassDefaultActionConfig(ctx)
+	if err != nil {
+		return elbv2model.Action{}, err
+	}
+	actionIng := t.ingGroup.Members[0]
+	if actionCfg == nil {
+		actionCfg, actionIng, err = t.buildGroupDefaultActionAnnotationConfig(ctx)
+		if err != nil {
+			return elbv2model.Action{}, err
+		}
+	}
+	if actionCfg == nil {
+		return t.build404Action(ctx), nil
+	}
+	if err := validateGroupDefaultAction(*actionCfg); err != nil {
+		return elbv2model.Action{}, errors.Wrap(err, "invalid group default action")
+	}
+	if actionCfg.Type == ActionTypeRedirect {
+		return t.buildRedirectAction(ctx, *actionCfg)
+	}
+	return t.buildFixedResponseAction(ctx, actionIng, *actionCfg)
+}
+
+// buildGroupDefaultActionAnnotationConfig builds the listener default action config from the group.default-action annotation of Ingresses within IngressGroup.
+// The annotation can be specified on any Ingress, but all Ingresses specifying it must agree on the same action.
+// It returns the config with the first Ingress specifying it, whose namespace ConfigMaps of fixed response bodies are resolved in, or nil if not specified.
+func (t *defaultModelBuildTask) buildGroupDefaultActionAnnotationConfig(_ context.Context) (*Action, *networking.Ingress, error) {
+	var chosenActionCfg *Action
+	var chosenIng *networking.Ingress
+	for _, ing := range t.ingGroup.Members {
+		rawActionCfg := Action{}
+		exists, err := t.annotationParser.ParseJSONAnnotation(annotations.IngressSuffixGroupDefaultAction, &rawActionCfg, ing.Annotations)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !exists {
+			continue
+		}
+		if chosenActionCfg == nil {
+			chosenActionCfg = &rawActionCfg
+			chosenIng = ing
+			continue
+		}
+		// ConfigMaps of fixed response bodies are resolved in the Ingress's namespace, so the same reference from other namespaces is a different action.
+		usesConfigMap := rawActionCfg.FixedResponseConfig != nil && rawActionCfg.FixedResponseConfig.MessageBodyConfigMapRef != nil
+		if !cmp.Equal(*chosenActionCfg, rawActionCfg) || (usesConfigMap && ing.Namespace != chosenIng.Namespace) {
+			return nil, nil, errors.Errorf("conflicting annotation %v on Ingresses %v and %v", annotations.IngressSuffixGroupDefaultAction,
+				k8s.NamespacedName(chosenIng), k8s.NamespacedName(ing))
+		}
+	}
+	return chosenActionCfg, chosenIng, nil
+}
+
+// buildIngressClassDefaultActionConfig builds the listener default action config from IngressClassParams of Ingresses within IngressGroup, it's nil if not specified.
+func (t *defaultModelBuildTask) buildIngressClassDefaultActionConfig(ctx context.Context) (*Action, error) {
+	var chosenDefaultAction *elbv2api.ListenerDefaultAction
+	for _, ing := range t.ingGroup.Members {
+		ingClassParams, err := t.classParamsLoader.Load(ctx, ing)
+		if err != nil {
+			return nil, err
+		}
+		if ingClassParams == nil || ingClassParams.Spec.DefaultAction == nil {
+			continue
+		}
+		defaultAction := ingClassParams.Spec.DefaultAction
+		if chosenDefaultAction != nil && !cmp.Equal(chosenDefaultAction, defaultAction) {
+			return nil, errors.New("conflicting defaultAction from IngressClassParams")
+		}
+		chosenDefaultAction = defaultAction
+	}
+	if chosenDefaultAction == nil {
+		return nil, nil
+	}
+	actionCfg := &Action{Type: ActionType(chosenDefaultAction.Type)}
+	if cfg := chosenDefaultAction.FixedResponseConfig; cfg != nil {
+		actionCfg.FixedResponseConfig = &FixedResponseActionConfig{
+			ContentType: cfg.ContentType,
+			MessageBody: cfg.MessageBody,
+			StatusCode:  cfg.StatusCode,
+		}
+	}
+	if cfg := chosenDefaultAction.RedirectConfig; cfg != nil {
+		actionCfg.RedirectConfig = &RedirectActionConfig{
+			Host:       cfg.Host,
+			Path:       cfg.Path,
+			Port:       cfg.Port,
+			Protocol:   cfg.Protocol,
+			Query:      cfg.Query,
+			StatusCode: cfg.StatusCode,
+		}
+	}
+	return actionCfg, nil
+}
+
 // the listen port config for specific Ingress's port
 type listenPortConfig struct {
 	protocol       elbv2model.Protocol
@@ -81,16 +192,23 @@ type listenPortConfig struct {
 	inboundCIDRv6s []string
 	sslPolicy      *string
 	tlsCerts       []string
+	defaultTLSCert *string
+	// listener attributes, e.g. to modify HTTP headers.
+	listenerAttributes map[string]string
 }
 
 func (t *defaultModelBuildTask) computeIngressListenPortConfigByPort(ctx context.Context, ing *networking.Ingress) (map[int64]listenPortConfig, error) {
-	explicitTLSCertARNs := t.computeIngressExplicitTLSCertARNs(ctx, ing)
+	explicitTLSCertARNs, err := t.computeIngressExplicitTLSCertARNs(ctx, ing)
+	if err != nil {
+		return nil, err
+	}
+	explicitDefaultTLSCertARN := t.computeIngressExplicitDefaultTLSCertARN(ctx, ing)
 	explicitSSLPolicy := t.computeIngressExplicitSSLPolicy(ctx, ing)
 	inboundCIDRv4s, inboundCIDRV6s, err := t.computeIngressExplicitInboundCIDRs(ctx, ing)
 	if err != nil {
 		return nil, err
 	}
-	preferTLS := len(explicitTLSCertARNs) != 0
+	preferTLS := len(explicitTLSCertARNs) != 0 || explicitDefaultTLSCertARN != nil
 	listenPorts, err := t.computeIngressListenPorts(ctx, ing, preferTLS)
 	if err != nil {
 		return nil, err
@@ -113,10 +231,15 @@ func (t *defaultModelBuildTask) computeIngressListenPortConfigByPort(ctx context
 
 	listenPortConfigByPort := make(map[int64]listenPortConfig, len(listenPorts))
 	for port, protocol := range listenPorts {
+		listenerAttributes, err := t.computeIngressListenerAttributes(ctx, ing, port, protocol)
+		if err != nil {
+			return nil, err
+		}
 		cfg := listenPortConfig{
-			protocol:       protocol,
-			inboundCIDRv4s: inboundCIDRv4s,
-			inboundCIDRv6s: inboundCIDRV6s,
+			protocol:           protocol,
+			inboundCIDRv4s:     inboundCIDRv4s,
+			inboundCIDRv6s:     inboundCIDRV6s,
+			listenerAttributes: listenerAttributes,
 		}
 		if protocol == elbv2model.ProtocolHTTPS {
 			if len(explicitTLSCertARNs) == 0 {
@@ -124,6 +247,7 @@ func (t *defaultModelBuildTask) computeIngressListenPortConfigByPort(ctx context
 			} else {
 				cfg.tlsCerts = explicitTLSCertARNs
 			}
+			cfg.defaultTLSCert = explicitDefaultTLSCertARN
 			cfg.sslPolicy = explicitSSLPolicy
 		}
 		listenPortConfigByPort[port] = cfg
@@ -132,10 +256,44 @@ func (t *defaultModelBuildTask) computeIngressListenPortConfigByPort(ctx context
 	return listenPortConfigByPort, nil
 }
 
-func (t *defaultModelBuildTask) computeIngressExplicitTLSCertARNs(_ context.Context, ing *networking.Ingress) []string {
+// computeIngressExplicitTLSCertARNs computes the TLS certificates explicitly specified via annotation, either by certificateARN or by tag selector.
+// certificates specified by certificateARN comes first, followed by certificates discovered by tag selector.
+func (t *defaultModelBuildTask) computeIngressExplicitTLSCertARNs(ctx context.Context, ing *networking.Ingress) ([]string, error) {
 	var rawTLSCertARNs []string
 	_ = t.annotationParser.ParseStringSliceAnnotation(annotations.IngressSuffixCertificateARN, &rawTLSCertARNs, ing.Annotations)
-	return rawTLSCertARNs
+
+	var rawTLSCertTags map[string]string
+	exists, err := t.annotationParser.ParseStringMapAnnotation(annotations.IngressSuffixCertificateTags, &rawTLSCertTags, ing.Annotations)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return rawTLSCertARNs, nil
+	}
+	if len(rawTLSCertTags) == 0 {
+		return nil, errors.Errorf("empty %v settings on Ingress: %v", annotations.IngressSuffixCertificateTags, k8s.NamespacedName(ing))
+	}
+	taggedTLSCertARNs, err := t.certDiscovery.DiscoverByTags(ctx, rawTLSCertTags)
+	if err != nil {
+		return nil, err
+	}
+	tlsCertARNs := rawTLSCertARNs
+	existingTLSCertARNs := sets.NewString(rawTLSCertARNs...)
+	for _, certARN := range taggedTLSCertARNs {
+		if !existingTLSCertARNs.Has(certARN) {
+			tlsCertARNs = append(tlsCertARNs, certARN)
+			existingTLSCertARNs.Insert(certARN)
+		}
+	}
+	return tlsCertARNs, nil
+}
+
+func (t *defaultModelBuildTask) computeIngressExplicitDefaultTLSCertARN(_ context.Context, ing *networking.Ingress) *string {
+	var rawDefaultTLSCertARN string
+	if exists := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixDefaultCertificateARN, &rawDefaultTLSCertARN, ing.Annotations); !exists {
+		return nil
+	}
+	return &rawDefaultTLSCertARN
 }
 
 func (t *defaultModelBuildTask) computeIngressInferredTLSCertARNs(ctx context.Context, ing *networking.Ingress) ([]string, error) {
@@ -159,7 +317,11 @@ func (t *defaultModelBuildTask) computeIngressListenPorts(_ context.Context, ing
 		}
 		return map[int64]elbv2model.Protocol{80: elbv2model.ProtocolHTTP}, nil
 	}
+	return parseListenPorts(rawListenPorts)
+}
 
+// parseListenPorts parses the listen-ports configuration into protocols by port.
+func parseListenPorts(rawListenPorts string) (map[int64]elbv2model.Protocol, error) {
 	var entries []map[string]int64
 	if err := json.Unmarshal([]byte(rawListenPorts), &entries); err != nil {
 		return nil, errors.Wrapf(err, "failed to parse listen-ports configuration: `%s`", rawListenPorts)
@@ -207,6 +369,19 @@ func (t *defaultModelBuildTask) computeIngressExplicitInboundCIDRs(_ context.Con
 	return inboundCIDRv4s, inboundCIDRv6s, nil
 }
 
+// computeIngressListenerAttributes computes the listener attributes specified via the listener-attributes.{Protocol}-{Port} annotation.
+func (t *defaultModelBuildTask) computeIngressListenerAttributes(_ context.Context, ing *networking.Ingress, port int64, protocol elbv2model.Protocol) (map[string]string, error) {
+	annotationSuffix := fmt.Sprintf("%v%v-%v", annotationSuffixPrefixListenerAttributes, protocol, port)
+	var rawAttributes map[string]string
+	if _, err := t.annotationParser.ParseStringMapAnnotation(annotationSuffix, &rawAttributes, ing.Annotations); err != nil {
+		return nil, err
+	}
+	if err := elbv2model.ValidateApplicationListenerAttributes(rawAttributes); err != nil {
+		return nil, errors.Wrapf(err, "invalid %v settings on Ingress: %v", annotationSuffix, k8s.NamespacedName(ing))
+	}
+	return rawAttributes, nil
+}
+
 func (t *defaultModelBuildTask) computeIngressExplicitSSLPolicy(_ context.Context, ing *networking.Ingress) *string {
 	var rawSSLPolicy string
 	if exists := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixSSLPolicy, &rawSSLPolicy, ing.Annotations); !exists {
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"github.com/pkg/errors"
 	networking "k8s.io/api/networking/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
@@ -13,6 +15,10 @@ import (
 func (t *defaultModelBuildTask) buildListenerRules(ctx context.Context, lsARN core.StringToken, port int64, protocol elbv2model.Protocol, ingList []*networking.Ingress) error {
 	var rules []Rule
 	for _, ing := range ingList {
+		matchWildcardHostApex := false
+		if _, err := t.annotationParser.ParseBoolAnnotation(annotations.IngressSuffixWildcardHostMatchApex, &matchWildcardHostApex, ing.Annotations); err != nil {
+			return errors.Wrapf(err, "ingress: %v", k8s.NamespacedName(ing))
+		}
 		for _, rule := range ing.Spec.Rules {
 			if rule.HTTP == nil {
 				continue
@@ -22,10 +28,13 @@ func (t *defaultModelBuildTask) buildListenerRules(ctx context.Context, lsARN co
 				if err != nil {
 					return errors.Wrapf(err, "ingress: %v", k8s.NamespacedName(ing))
 				}
-				conditions, err := t.buildRuleConditions(ctx, rule, path, enhancedBackend)
+				conditions, err := t.buildRuleConditions(ctx, rule, path, enhancedBackend, matchWildcardHostApex)
 				if err != nil {
 					return errors.Wrapf(err, "ingress: %v", k8s.NamespacedName(ing))
 				}
+				if err := validateRuleConditionCount(conditions); err != nil {
+					return errors.Wrapf(err, "ingress: %v", k8s.NamespacedName(ing))
+				}
 				actions, err := t.buildActions(ctx, protocol, ing, enhancedBackend)
 				if err != nil {
 					return errors.Wrapf(err, "ingress: %v", k8s.NamespacedName(ing))
@@ -42,23 +51,37 @@ func (t *defaultModelBuildTask) buildListenerRules(ctx context.Context, lsARN co
 		return err
 	}
 
-	priority := int64(1)
-	for _, rule := range optimizedRules {
-		ruleResID := fmt.Sprintf("%v:%v", port, priority)
-		_ = elbv2model.NewListenerRule(t.stack, ruleResID, elbv2model.ListenerRuleSpec{
-			ListenerARN: lsARN,
-			Priority:    priority,
-			Conditions:  rule.Conditions,
-			Actions:     rule.Actions,
-		})
-		priority += 1
+	ruleSplittingEnabled := t.featureGates.Enabled(config.FeatureRuleSplitting)
+	for ruleIdx, rule := range optimizedRules {
+		priority := int64(ruleIdx + 1)
+		splitConditions := [][]elbv2model.RuleCondition{rule.Conditions}
+		if ruleSplittingEnabled {
+			// rules with more condition values than ALB allows are split into rules with consecutive priorities and the same actions.
+			// each rule reserves priorities for its split rules, so that priorities of later rules are stable regardless of splitting.
+			priority = int64(ruleIdx*maxSplitRulesPerRule + 1)
+			if splitConditions, err = splitRuleConditions(rule.Conditions); err != nil {
+				return err
+			}
+		} else if err := validateRuleConditionValueCount(rule.Conditions); err != nil {
+			return errors.Wrapf(err, "enable the %v feature to split the rule", config.FeatureRuleSplitting)
+		}
+		for _, conditions := range splitConditions {
+			ruleResID := fmt.Sprintf("%v:%v", port, priority)
+			_ = elbv2model.NewListenerRule(t.stack, ruleResID, elbv2model.ListenerRuleSpec{
+				ListenerARN: lsARN,
+				Priority:    priority,
+				Conditions:  conditions,
+				Actions:     rule.Actions,
+			})
+			priority += 1
+		}
 	}
 
 	return nil
 }
 
 func (t *defaultModelBuildTask) buildRuleConditions(ctx context.Context, rule networking.IngressRule,
-	path networking.HTTPIngressPath, backend EnhancedBackend) ([]elbv2model.RuleCondition, error) {
+	path networking.HTTPIngressPath, backend EnhancedBackend, matchWildcardHostApex bool) ([]elbv2model.RuleCondition, error) {
 	var hosts []string
 	if rule.Host != "" {
 		hosts = append(hosts, rule.Host)
@@ -106,8 +129,13 @@ func (t *defaultModelBuildTask) buildRuleConditions(ctx context.Context, rule ne
 			conditions = append(conditions, sourceIPCondition)
 		}
 	}
+	for _, host := range hosts {
+		if err := ValidateHostPattern(host); err != nil {
+			return nil, err
+		}
+	}
 	if len(hosts) != 0 {
-		conditions = append(conditions, t.buildHostHeaderCondition(ctx, hosts))
+		conditions = append(conditions, t.buildHostHeaderCondition(ctx, expandHosts(hosts, matchWildcardHostApex)))
 	}
 	if len(paths) != 0 {
 		conditions = append(conditions, t.buildPathPatternCondition(ctx, paths))
@@ -0,0 +1,218 @@
+package ingress
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+func Test_defaultModelBuildTask_buildGroupDefaultAction(t *testing.T) {
+	ingClassWithParams := &networking.IngressClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "class-with-params",
+		},
+		Spec: networking.IngressClassSpec{
+			Controller: ingressClassControllerALB,
+			Parameters: &corev1.TypedLocalObjectReference{
+				APIGroup: awssdk.String("elbv2.k8s.aws"),
+				Kind:     "IngressClassParams",
+				Name:     "params-a",
+			},
+		},
+	}
+	ingClassParams := &elbv2api.IngressClassParams{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "params-a",
+		},
+		Spec: elbv2api.IngressClassParamsSpec{
+			DefaultAction: &elbv2api.ListenerDefaultAction{
+				Type: elbv2api.ListenerDefaultActionTypeRedirect,
+				RedirectConfig: &elbv2api.RedirectActionConfig{
+					Host:       awssdk.String("maintenance.example.com"),
+					StatusCode: "HTTP_302",
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name    string
+		members []*networking.Ingress
+		want    elbv2model.Action
+		wantErr error
+	}{
+		{
+			name: "default action not specified",
+			members: []*networking.Ingress{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "ing-1"},
+				},
+			},
+			want: elbv2model.Action{
+				Type: elbv2model.ActionTypeFixedResponse,
+				FixedResponseConfig: &elbv2model.FixedResponseActionConfig{
+					ContentType: awssdk.String("text/plain"),
+					StatusCode:  "404",
+				},
+			},
+		},
+		{
+			name: "default action from annotation on any Ingress",
+			members: []*networking.Ingress{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "ing-1"},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "ing-2",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/group.default-action": `{"type":"fixed-response","fixedResponseConfig":{"contentType":"text/html","messageBody":"<html>not found</html>","statusCode":"404"}}`,
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "other-ns",
+						Name:      "ing-3",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/group.default-action": `{"type":"fixed-response","fixedResponseConfig":{"contentType":"text/html","messageBody":"<html>not found</html>","statusCode":"404"}}`,
+						},
+					},
+				},
+			},
+			want: elbv2model.Action{
+				Type: elbv2model.ActionTypeFixedResponse,
+				FixedResponseConfig: &elbv2model.FixedResponseActionConfig{
+					ContentType: awssdk.String("text/html"),
+					MessageBody: awssdk.String("<html>not found</html>"),
+					StatusCode:  "404",
+				},
+			},
+		},
+		{
+			name: "conflicting default action from annotation",
+			members: []*networking.Ingress{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "ing-1",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/group.default-action": `{"type":"fixed-response","fixedResponseConfig":{"contentType":"text/html","messageBody":"<html>not found</html>","statusCode":"404"}}`,
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "ing-2",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/group.default-action": `{"type":"fixed-response","fixedResponseConfig":{"statusCode":"503"}}`,
+						},
+					},
+				},
+			},
+			wantErr: errors.New("conflicting annotation group.default-action on Ingresses awesome-ns/ing-1 and awesome-ns/ing-2"),
+		},
+		{
+			name: "default action from annotation referencing ConfigMaps in different namespaces",
+			members: []*networking.Ingress{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "ing-1",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/group.default-action": `{"type":"fixed-response","fixedResponseConfig":{"messageBodyConfigMapRef":{"name":"maintenance-page","key":"index.html"},"statusCode":"503"}}`,
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "other-ns",
+						Name:      "ing-2",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/group.default-action": `{"type":"fixed-response","fixedResponseConfig":{"messageBodyConfigMapRef":{"name":"maintenance-page","key":"index.html"},"statusCode":"503"}}`,
+						},
+					},
+				},
+			},
+			wantErr: errors.New("conflicting annotation group.default-action on Ingresses awesome-ns/ing-1 and other-ns/ing-2"),
+		},
+		{
+			name: "default action from IngressClassParams takes precedence over annotation",
+			members: []*networking.Ingress{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "ing-1",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/group.default-action": `{"type":"fixed-response","fixedResponseConfig":{"statusCode":"503"}}`,
+						},
+					},
+					Spec: networking.IngressSpec{
+						IngressClassName: awssdk.String("class-with-params"),
+					},
+				},
+			},
+			want: elbv2model.Action{
+				Type: elbv2model.ActionTypeRedirect,
+				RedirectConfig: &elbv2model.RedirectActionConfig{
+					Host:       awssdk.String("maintenance.example.com"),
+					StatusCode: "HTTP_302",
+				},
+			},
+		},
+		{
+			name: "forward default action via annotation is not supported",
+			members: []*networking.Ingress{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "ing-1",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/group.default-action": `{"type":"forward","targetGroupARN":"arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/tg-1/abc"}`,
+						},
+					},
+				},
+			},
+			wantErr: errors.New("invalid group default action: type must be within [fixed-response, redirect]: forward"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			assert.NoError(t, k8sClient.Create(context.Background(), ingClassWithParams.DeepCopy()))
+			assert.NoError(t, k8sClient.Create(context.Background(), ingClassParams.DeepCopy()))
+
+			task := &defaultModelBuildTask{
+				k8sClient:         k8sClient,
+				annotationParser:  annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				classParamsLoader: NewDefaultClassParamsLoader(k8sClient),
+				ingGroup: Group{
+					ID:      GroupID{Name: "awesome-group"},
+					Members: tt.members,
+				},
+			}
+			got, err := task.buildGroupDefaultAction(context.Background())
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -6,17 +6,22 @@ import (
 	"encoding/hex"
 	"fmt"
 	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
 	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"regexp"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/equality"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	"strconv"
 	"strings"
 )
 
@@ -34,12 +39,61 @@ func (t *defaultModelBuildTask) buildLoadBalancer(ctx context.Context, listenPor
 	return lb, nil
 }
 
+// buildExistingLoadBalancer builds the LoadBalancer resource for an existing LoadBalancer that isn't managed by controller.
+// only listeners and their rules are managed on such LoadBalancer, the LoadBalancer itself and its SecurityGroups are never modified.
+func (t *defaultModelBuildTask) buildExistingLoadBalancer(_ context.Context, lbARN string) *elbv2model.LoadBalancer {
+	lb := elbv2model.NewLoadBalancer(t.stack, resourceIDLoadBalancer, elbv2model.LoadBalancerSpec{
+		Type:                    elbv2model.LoadBalancerTypeApplication,
+		ExistingLoadBalancerARN: awssdk.String(lbARN),
+	})
+	t.loadBalancer = lb
+	return lb
+}
+
+// buildExistingLoadBalancerARN builds the ARN of existing LoadBalancer referenced by Ingresses.
+// when IngressGroup is being deleted, it's built from inactive members so that listeners on existing LoadBalancer can be cleaned up.
+// returns nil if Ingresses don't reference an existing LoadBalancer.
+func (t *defaultModelBuildTask) buildExistingLoadBalancerARN(_ context.Context) (*string, error) {
+	ingList := t.ingGroup.Members
+	if len(ingList) == 0 {
+		ingList = t.ingGroup.InactiveMembers
+	}
+	explicitLBARNs := sets.NewString()
+	for _, ing := range ingList {
+		rawLBARN := ""
+		if exists := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixLoadBalancerARN, &rawLBARN, ing.Annotations); !exists {
+			continue
+		}
+		explicitLBARNs.Insert(rawLBARN)
+	}
+	if len(explicitLBARNs) == 0 {
+		return nil, nil
+	}
+	if len(explicitLBARNs) > 1 {
+		return nil, errors.Errorf("conflicting load balancer ARN: %v", explicitLBARNs.List())
+	}
+	rawLBARN, _ := explicitLBARNs.PopAny()
+	if err := validateLoadBalancerARN(rawLBARN); err != nil {
+		return nil, err
+	}
+	return awssdk.String(rawLBARN), nil
+}
+
+// validateLoadBalancerARN validates whether the ARN is of an Application Load Balancer.
+func validateLoadBalancerARN(rawLBARN string) error {
+	parsedLBARN, err := arn.Parse(rawLBARN)
+	if err != nil || !strings.HasPrefix(parsedLBARN.Resource, "loadbalancer/app/") {
+		return errors.Errorf("invalid load balancer ARN: %v, must be the ARN of an Application Load Balancer", rawLBARN)
+	}
+	return nil
+}
+
 func (t *defaultModelBuildTask) buildLoadBalancerSpec(ctx context.Context, listenPortConfigByPort map[int64]listenPortConfig) (elbv2model.LoadBalancerSpec, error) {
 	scheme, err := t.buildLoadBalancerScheme(ctx)
 	if err != nil {
 		return elbv2model.LoadBalancerSpec{}, err
 	}
-	ipAddressType, err := t.buildLoadBalancerIPAddressType(ctx)
+	ipAddressType, err := t.buildLoadBalancerIPAddressType(ctx, scheme)
 	if err != nil {
 		return elbv2model.LoadBalancerSpec{}, err
 	}
@@ -63,6 +117,10 @@ func (t *defaultModelBuildTask) buildLoadBalancerSpec(ctx context.Context, liste
 	if err != nil {
 		return elbv2model.LoadBalancerSpec{}, err
 	}
+	replacement, err := t.buildLoadBalancerReplacement(ctx)
+	if err != nil {
+		return elbv2model.LoadBalancerSpec{}, err
+	}
 	name := t.buildLoadBalancerName(ctx, scheme)
 	return elbv2model.LoadBalancerSpec{
 		Name:                   name,
@@ -74,9 +132,20 @@ func (t *defaultModelBuildTask) buildLoadBalancerSpec(ctx context.Context, liste
 		CustomerOwnedIPv4Pool:  coIPv4Pool,
 		LoadBalancerAttributes: loadBalancerAttributes,
 		Tags:                   tags,
+		Replacement:            replacement,
 	}, nil
 }
 
+// buildLoadBalancerReplacement builds how the LoadBalancer is replaced from Ingresses within IngressGroup.
+func (t *defaultModelBuildTask) buildLoadBalancerReplacement(_ context.Context) (*elbv2model.LoadBalancerReplacement, error) {
+	memberAnnotations := make([]map[string]string, 0, len(t.ingGroup.Members))
+	for _, ing := range t.ingGroup.Members {
+		memberAnnotations = append(memberAnnotations, ing.Annotations)
+	}
+	return deploy.ResolveLoadBalancerReplacement(t.annotationParser, annotations.IngressSuffixReplacementStrategy,
+		annotations.IngressSuffixReplacementDrainWindow, memberAnnotations...)
+}
+
 var invalidLoadBalancerNamePattern = regexp.MustCompile("[[:^alnum:]]")
 
 func (t *defaultModelBuildTask) buildLoadBalancerName(_ context.Context, scheme elbv2model.LoadBalancerScheme) string {
@@ -123,7 +192,7 @@ func (t *defaultModelBuildTask) buildLoadBalancerScheme(_ context.Context) (elbv
 }
 
 // buildLoadBalancerIPAddressType builds the LoadBalancer IPAddressType.
-func (t *defaultModelBuildTask) buildLoadBalancerIPAddressType(_ context.Context) (elbv2model.IPAddressType, error) {
+func (t *defaultModelBuildTask) buildLoadBalancerIPAddressType(_ context.Context, scheme elbv2model.LoadBalancerScheme) (elbv2model.IPAddressType, error) {
 	explicitIPAddressTypes := sets.NewString()
 	for _, ing := range t.ingGroup.Members {
 		rawIPAddressType := ""
@@ -144,12 +213,49 @@ func (t *defaultModelBuildTask) buildLoadBalancerIPAddressType(_ context.Context
 		return elbv2model.IPAddressTypeIPV4, nil
 	case string(elbv2model.IPAddressTypeDualStack):
 		return elbv2model.IPAddressTypeDualStack, nil
+	case string(elbv2model.IPAddressTypeDualStackWithoutPublicIPV4):
+		if scheme != elbv2model.LoadBalancerSchemeInternetFacing {
+			return "", errors.Errorf("IPAddressType %v is only supported for %v scheme", rawIPAddressType, elbv2model.LoadBalancerSchemeInternetFacing)
+		}
+		return elbv2model.IPAddressTypeDualStackWithoutPublicIPV4, nil
 	default:
 		return "", errors.Errorf("unknown IPAddressType: %v", rawIPAddressType)
 	}
 }
 
+// isIPv6Supported checks whether the LoadBalancer IPAddressType serves IPv6 clients.
+func isIPv6Supported(ipAddressType elbv2model.IPAddressType) bool {
+	switch ipAddressType {
+	case elbv2model.IPAddressTypeDualStack, elbv2model.IPAddressTypeDualStackWithoutPublicIPV4:
+		return true
+	default:
+		return false
+	}
+}
+
 func (t *defaultModelBuildTask) buildLoadBalancerSubnetMappings(ctx context.Context, scheme elbv2model.LoadBalancerScheme) ([]elbv2model.SubnetMapping, error) {
+	subnetLocale, err := t.buildLoadBalancerSubnetLocale(ctx)
+	if err != nil {
+		return nil, err
+	}
+	subnetSelector, err := t.buildLoadBalancerSubnetSelector(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resolveOpts := []networking.SubnetsResolveOption{
+		networking.WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeApplication),
+		networking.WithSubnetsResolveLBScheme(scheme),
+		networking.WithSubnetsResolveLocaleType(subnetLocale),
+		networking.WithSubnetsResolveExclusions(t.buildLoadBalancerExcludedSubnets(ctx)),
+	}
+	if subnetSelector != nil {
+		chosenSubnets, err := t.subnetsResolver.ResolveViaSelector(ctx, subnetSelector, resolveOpts...)
+		if err != nil {
+			return nil, errors.Wrap(err, "couldn't resolve subnets via IngressClassParams")
+		}
+		return buildLoadBalancerSubnetMappingsWithSubnets(chosenSubnets), nil
+	}
+
 	var explicitSubnetNameOrIDsList [][]string
 	for _, ing := range t.ingGroup.Members {
 		var rawSubnetNameOrIDs []string
@@ -160,10 +266,19 @@ func (t *defaultModelBuildTask) buildLoadBalancerSubnetMappings(ctx context.Cont
 	}
 
 	if len(explicitSubnetNameOrIDsList) == 0 {
-		chosenSubnets, err := t.subnetsResolver.ResolveViaDiscovery(ctx,
-			networking.WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeApplication),
-			networking.WithSubnetsResolveLBScheme(scheme),
-		)
+		subnetTags, err := t.buildLoadBalancerSubnetTags(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(subnetTags) != 0 {
+			chosenSubnets, err := t.subnetsResolver.ResolveViaSelector(ctx, subnetTags, resolveOpts...)
+			if err != nil {
+				return nil, errors.Wrap(err, "couldn't resolve subnets via subnet tags")
+			}
+			return buildLoadBalancerSubnetMappingsWithSubnets(chosenSubnets), nil
+		}
+
+		chosenSubnets, err := t.subnetsResolver.ResolveViaDiscovery(ctx, resolveOpts...)
 		if err != nil {
 			return nil, errors.Wrap(err, "couldn't auto-discover subnets")
 		}
@@ -177,16 +292,97 @@ func (t *defaultModelBuildTask) buildLoadBalancerSubnetMappings(ctx context.Cont
 			return nil, errors.Errorf("conflicting subnets: %v | %v", chosenSubnetNameOrIDs, subnetNameOrIDs)
 		}
 	}
-	chosenSubnets, err := t.subnetsResolver.ResolveViaNameOrIDSlice(ctx, chosenSubnetNameOrIDs,
-		networking.WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeApplication),
-		networking.WithSubnetsResolveLBScheme(scheme),
-	)
+	chosenSubnets, err := t.subnetsResolver.ResolveViaNameOrIDSlice(ctx, chosenSubnetNameOrIDs, resolveOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return buildLoadBalancerSubnetMappingsWithSubnets(chosenSubnets), nil
 }
 
+// buildLoadBalancerExcludedSubnets builds the excluded subnets and availability zones from annotations of Ingresses within IngressGroup.
+// the exclusions of all Ingresses apply, since any of them excluding an availability zone means it shouldn't serve the IngressGroup.
+func (t *defaultModelBuildTask) buildLoadBalancerExcludedSubnets(_ context.Context) []string {
+	excludedSubnets := sets.NewString()
+	for _, ing := range t.ingGroup.Members {
+		var rawExcludedSubnets []string
+		if exists := t.annotationParser.ParseStringSliceAnnotation(annotations.IngressSuffixExcludedSubnets, &rawExcludedSubnets, ing.Annotations); !exists {
+			continue
+		}
+		excludedSubnets.Insert(rawExcludedSubnets...)
+	}
+	return excludedSubnets.List()
+}
+
+// buildLoadBalancerSubnetSelector builds the subnet selector from IngressClassParams of Ingresses within IngressGroup, it's nil if not specified.
+func (t *defaultModelBuildTask) buildLoadBalancerSubnetSelector(ctx context.Context) (map[string][]string, error) {
+	var chosenSubnetSelector map[string][]string
+	for _, ing := range t.ingGroup.Members {
+		ingClassParams, err := t.classParamsLoader.Load(ctx, ing)
+		if err != nil {
+			return nil, err
+		}
+		if ingClassParams == nil || ingClassParams.Spec.Subnets == nil {
+			continue
+		}
+		subnetSelector := ingClassParams.Spec.Subnets.Tags
+		if chosenSubnetSelector != nil && !cmp.Equal(chosenSubnetSelector, subnetSelector) {
+			return nil, errors.Errorf("conflicting subnets from IngressClassParams: %v | %v", chosenSubnetSelector, subnetSelector)
+		}
+		chosenSubnetSelector = subnetSelector
+	}
+	return chosenSubnetSelector, nil
+}
+
+// buildLoadBalancerSubnetTags builds the subnet tags selector from annotations of Ingresses within IngressGroup.
+func (t *defaultModelBuildTask) buildLoadBalancerSubnetTags(_ context.Context) (map[string][]string, error) {
+	mergedSubnetTags := make(map[string][]string)
+	for _, ing := range t.ingGroup.Members {
+		var rawSubnetTags map[string]string
+		if _, err := t.annotationParser.ParseStringMapAnnotation(annotations.IngressSuffixSubnetTags, &rawSubnetTags, ing.Annotations); err != nil {
+			return nil, err
+		}
+		for tagKey, rawTagValues := range rawSubnetTags {
+			tagValues := networking.SplitSubnetTagValues(rawTagValues)
+			if existingTagValues, exists := mergedSubnetTags[tagKey]; exists && !cmp.Equal(existingTagValues, tagValues) {
+				return nil, errors.Errorf("conflicting subnet tag %v: %v | %v", tagKey, existingTagValues, tagValues)
+			}
+			mergedSubnetTags[tagKey] = tagValues
+		}
+	}
+	return mergedSubnetTags, nil
+}
+
+// buildLoadBalancerSubnetLocale builds the required locale type of LoadBalancer subnets, it's empty if not specified.
+func (t *defaultModelBuildTask) buildLoadBalancerSubnetLocale(_ context.Context) (networking.SubnetLocaleType, error) {
+	explicitSubnetLocales := sets.NewString()
+	for _, ing := range t.ingGroup.Members {
+		rawSubnetLocale := ""
+		if exists := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixSubnetLocale, &rawSubnetLocale, ing.Annotations); !exists {
+			continue
+		}
+		explicitSubnetLocales.Insert(rawSubnetLocale)
+	}
+	if len(explicitSubnetLocales) == 0 {
+		return "", nil
+	}
+	if len(explicitSubnetLocales) > 1 {
+		return "", errors.Errorf("conflicting subnet locale: %v", explicitSubnetLocales.List())
+	}
+	rawSubnetLocale, _ := explicitSubnetLocales.PopAny()
+	switch rawSubnetLocale {
+	case string(networking.SubnetLocaleTypeAvailabilityZone):
+		return networking.SubnetLocaleTypeAvailabilityZone, nil
+	case string(networking.SubnetLocaleTypeLocalZone):
+		return networking.SubnetLocaleTypeLocalZone, nil
+	case string(networking.SubnetLocaleTypeWavelengthZone):
+		return networking.SubnetLocaleTypeWavelengthZone, nil
+	case string(networking.SubnetLocaleTypeOutpost):
+		return networking.SubnetLocaleTypeOutpost, nil
+	default:
+		return "", errors.Errorf("unknown subnet locale: %v", rawSubnetLocale)
+	}
+}
+
 func (t *defaultModelBuildTask) buildLoadBalancerSecurityGroups(ctx context.Context, listenPortConfigByPort map[int64]listenPortConfig, ipAddressType elbv2model.IPAddressType) ([]core.StringToken, error) {
 	var explicitSGNameOrIDsList [][]string
 	for _, ing := range t.ingGroup.Members {
@@ -197,11 +393,15 @@ func (t *defaultModelBuildTask) buildLoadBalancerSecurityGroups(ctx context.Cont
 		explicitSGNameOrIDsList = append(explicitSGNameOrIDsList, rawSGNameOrIDs)
 	}
 	if len(explicitSGNameOrIDsList) == 0 {
-		sg, err := t.buildManagedSecurityGroup(ctx, listenPortConfigByPort, ipAddressType)
+		sgs, err := t.buildManagedSecurityGroups(ctx, listenPortConfigByPort, ipAddressType)
 		if err != nil {
 			return nil, err
 		}
-		return []core.StringToken{sg.GroupID()}, nil
+		sgIDTokens := make([]core.StringToken, 0, len(sgs))
+		for _, sg := range sgs {
+			sgIDTokens = append(sgIDTokens, sg.GroupID())
+		}
+		return sgIDTokens, nil
 	}
 
 	chosenSGNameOrIDs := explicitSGNameOrIDsList[0]
@@ -211,7 +411,11 @@ func (t *defaultModelBuildTask) buildLoadBalancerSecurityGroups(ctx context.Cont
 			return nil, errors.Errorf("conflicting securityGroups: %v | %v", chosenSGNameOrIDs, sgNameOrIDs)
 		}
 	}
-	chosenSGIDs, err := t.resolveSecurityGroupIDsViaNameOrIDSlice(ctx, chosenSGNameOrIDs)
+	if err := t.sgPolicyPermissionBuilder.ReportIgnored(ctx, elbv2api.SecurityGroupPolicyTargetKindIngress, t.buildSecurityGroupPolicyTargets(),
+		"the LoadBalancer uses explicit securityGroups"); err != nil {
+		return nil, err
+	}
+	chosenSGIDs, err := t.sgResolver.ResolveViaNameOrID(ctx, chosenSGNameOrIDs)
 	if err != nil {
 		return nil, err
 	}
@@ -247,20 +451,28 @@ func (t *defaultModelBuildTask) buildLoadBalancerCOIPv4Pool(_ context.Context) (
 	return &rawCOIPv4Pool, nil
 }
 
-func (t *defaultModelBuildTask) buildLoadBalancerAttributes(_ context.Context) ([]elbv2model.LoadBalancerAttribute, error) {
-	mergedAttributes := make(map[string]string)
-	for _, ing := range t.ingGroup.Members {
-		var rawAttributes map[string]string
-		if _, err := t.annotationParser.ParseStringMapAnnotation(annotations.IngressSuffixLoadBalancerAttributes, &rawAttributes, ing.Annotations); err != nil {
-			return nil, err
-		}
-		for attrKey, attrValue := range rawAttributes {
-			if existingAttrValue, exists := mergedAttributes[attrKey]; exists && existingAttrValue != attrValue {
-				return nil, errors.Errorf("conflicting loadBalancerAttribute %v: %v | %v", attrKey, existingAttrValue, attrValue)
-			}
+func (t *defaultModelBuildTask) buildLoadBalancerAttributes(ctx context.Context) ([]elbv2model.LoadBalancerAttribute, error) {
+	mergedAttributes, err := t.buildIngressGroupLoadBalancerAttributes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ingClassAttributes, err := t.buildIngressClassLoadBalancerAttributes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// attributes from IngressClassParams take precedence over attributes from Ingress annotations.
+	for attrKey, attrValue := range ingClassAttributes {
+		mergedAttributes[attrKey] = attrValue
+	}
+	// default attributes from dynamic config only apply to attributes that aren't explicitly specified.
+	for attrKey, attrValue := range t.defaultLoadBalancerAttributes {
+		if _, exists := mergedAttributes[attrKey]; !exists {
 			mergedAttributes[attrKey] = attrValue
 		}
 	}
+	if err := validateLoadBalancerAttributes(mergedAttributes); err != nil {
+		return nil, err
+	}
 	attributes := make([]elbv2model.LoadBalancerAttribute, 0, len(mergedAttributes))
 	for attrKey, attrValue := range mergedAttributes {
 		attributes = append(attributes, elbv2model.LoadBalancerAttribute{
@@ -271,6 +483,58 @@ func (t *defaultModelBuildTask) buildLoadBalancerAttributes(_ context.Context) (
 	return attributes, nil
 }
 
+// buildIngressGroupLoadBalancerAttributes builds the load balancer attributes from annotations of Ingresses within IngressGroup.
+func (t *defaultModelBuildTask) buildIngressGroupLoadBalancerAttributes(_ context.Context) (map[string]string, error) {
+	return mergeGroupLoadBalancerAttributes(t.annotationParser, t.ingGroup.Members)
+}
+
+// buildIngressClassLoadBalancerAttributes builds the load balancer attributes from IngressClassParams of Ingresses within IngressGroup.
+func (t *defaultModelBuildTask) buildIngressClassLoadBalancerAttributes(ctx context.Context) (map[string]string, error) {
+	mergedAttributes := make(map[string]string)
+	for _, ing := range t.ingGroup.Members {
+		ingClassParams, err := t.classParamsLoader.Load(ctx, ing)
+		if err != nil {
+			return nil, err
+		}
+		if ingClassParams == nil {
+			continue
+		}
+		for _, attr := range ingClassParams.Spec.LoadBalancerAttributes {
+			if existingAttrValue, exists := mergedAttributes[attr.Key]; exists && existingAttrValue != attr.Value {
+				return nil, errors.Errorf("conflicting loadBalancerAttribute %v from IngressClassParams: %v | %v", attr.Key, existingAttrValue, attr.Value)
+			}
+			mergedAttributes[attr.Key] = attr.Value
+		}
+	}
+	return mergedAttributes, nil
+}
+
+// validateLoadBalancerAttributes validates values of well-known load balancer attributes.
+func validateLoadBalancerAttributes(attributes map[string]string) error {
+	if rawMode, exists := attributes[elbv2model.LoadBalancerAttributeKeyDesyncMitigationMode]; exists {
+		switch rawMode {
+		case "monitor", "defensive", "strictest":
+		default:
+			return errors.Errorf("invalid loadBalancerAttribute %v: %v, must be monitor, defensive or strictest",
+				elbv2model.LoadBalancerAttributeKeyDesyncMitigationMode, rawMode)
+		}
+	}
+	if rawSeconds, exists := attributes[elbv2model.LoadBalancerAttributeKeyClientKeepAliveSeconds]; exists {
+		seconds, err := strconv.ParseInt(rawSeconds, 10, 64)
+		if err != nil || seconds < 60 || seconds > 604800 {
+			return errors.Errorf("invalid loadBalancerAttribute %v: %v, must be an integer within [60, 604800]",
+				elbv2model.LoadBalancerAttributeKeyClientKeepAliveSeconds, rawSeconds)
+		}
+	}
+	if rawEnabled, exists := attributes[elbv2model.LoadBalancerAttributeKeyHTTP2Enabled]; exists {
+		if _, err := strconv.ParseBool(rawEnabled); err != nil {
+			return errors.Errorf("invalid loadBalancerAttribute %v: %v, must be true or false",
+				elbv2model.LoadBalancerAttributeKeyHTTP2Enabled, rawEnabled)
+		}
+	}
+	return nil
+}
+
 func (t *defaultModelBuildTask) buildLoadBalancerTags(_ context.Context) (map[string]string, error) {
 	mergedTags := make(map[string]string)
 	for _, ing := range t.ingGroup.Members {
@@ -285,57 +549,15 @@ func (t *defaultModelBuildTask) buildLoadBalancerTags(_ context.Context) (map[st
 			mergedTags[tagKey] = tagValue
 		}
 	}
-	return mergedTags, nil
+	return t.mergeDefaultTags(mergedTags), nil
 }
 
-func (t *defaultModelBuildTask) resolveSecurityGroupIDsViaNameOrIDSlice(ctx context.Context, sgNameOrIDs []string) ([]string, error) {
-	var sgIDs []string
-	var sgNames []string
-	for _, nameOrID := range sgNameOrIDs {
-		if strings.HasPrefix(nameOrID, "sg-") {
-			sgIDs = append(sgIDs, nameOrID)
-		} else {
-			sgNames = append(sgNames, nameOrID)
-		}
-	}
-	var resolvedSGs []*ec2sdk.SecurityGroup
-	if len(sgIDs) > 0 {
-		req := &ec2sdk.DescribeSecurityGroupsInput{
-			GroupIds: awssdk.StringSlice(sgIDs),
-		}
-		sgs, err := t.ec2Client.DescribeSecurityGroupsAsList(ctx, req)
-		if err != nil {
-			return nil, err
-		}
-		resolvedSGs = append(resolvedSGs, sgs...)
-	}
-	if len(sgNames) > 0 {
-		req := &ec2sdk.DescribeSecurityGroupsInput{
-			Filters: []*ec2sdk.Filter{
-				{
-					Name:   awssdk.String("tag:Name"),
-					Values: awssdk.StringSlice(sgNames),
-				},
-				{
-					Name:   awssdk.String("vpc-id"),
-					Values: awssdk.StringSlice([]string{t.vpcID}),
-				},
-			},
-		}
-		sgs, err := t.ec2Client.DescribeSecurityGroupsAsList(ctx, req)
-		if err != nil {
-			return nil, err
-		}
-		resolvedSGs = append(resolvedSGs, sgs...)
-	}
-	resolvedSGIDs := make([]string, 0, len(resolvedSGs))
-	for _, sg := range resolvedSGs {
-		resolvedSGIDs = append(resolvedSGIDs, awssdk.StringValue(sg.GroupId))
-	}
-	if len(resolvedSGIDs) != len(sgNameOrIDs) {
-		return nil, errors.Errorf("couldn't found all securityGroups, nameOrIDs: %v, found: %v", sgNameOrIDs, resolvedSGIDs)
+// mergeDefaultTags merges the default tags from dynamic config into tags, tags from annotations take precedence.
+func (t *defaultModelBuildTask) mergeDefaultTags(tags map[string]string) map[string]string {
+	if len(t.defaultTags) == 0 {
+		return tags
 	}
-	return resolvedSGIDs, nil
+	return algorithm.MergeStringMap(tags, t.defaultTags)
 }
 
 func buildLoadBalancerSubnetMappingsWithSubnets(subnets []*ec2sdk.Subnet) []elbv2model.SubnetMapping {
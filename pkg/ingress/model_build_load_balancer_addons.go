@@ -9,6 +9,11 @@ import (
 	shieldmodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/shield"
 	wafregionalmodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/wafregional"
 	wafv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/wafv2"
+	zonalshiftmodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/zonalshift"
+)
+
+const (
+	defaultZonalShiftExpiresIn = "1h"
 )
 
 func (t *defaultModelBuildTask) buildLoadBalancerAddOns(ctx context.Context, lbARN core.StringToken) error {
@@ -21,6 +26,9 @@ func (t *defaultModelBuildTask) buildLoadBalancerAddOns(ctx context.Context, lbA
 	if _, err := t.buildShieldProtection(ctx, lbARN); err != nil {
 		return err
 	}
+	if _, err := t.buildZonalShift(ctx, lbARN); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -102,3 +110,41 @@ func (t *defaultModelBuildTask) buildShieldProtection(_ context.Context, lbARN c
 	}
 	return nil, nil
 }
+
+func (t *defaultModelBuildTask) buildZonalShift(_ context.Context, lbARN core.StringToken) (*zonalshiftmodel.ZonalShift, error) {
+	explicitZonalShifts := make(map[zonalshiftmodel.ZonalShiftSpec]struct{})
+	for _, ing := range t.ingGroup.Members {
+		rawAwayFrom := ""
+		if exists := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixZonalShiftAwayFrom, &rawAwayFrom, ing.Annotations); !exists {
+			continue
+		}
+		rawExpiresIn := defaultZonalShiftExpiresIn
+		_ = t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixZonalShiftExpiresIn, &rawExpiresIn, ing.Annotations)
+		rawComment := ""
+		_ = t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixZonalShiftComment, &rawComment, ing.Annotations)
+		explicitZonalShifts[zonalshiftmodel.ZonalShiftSpec{
+			AwayFrom:  rawAwayFrom,
+			ExpiresIn: rawExpiresIn,
+			Comment:   rawComment,
+		}] = struct{}{}
+	}
+	if len(explicitZonalShifts) == 0 {
+		return nil, nil
+	}
+	if len(explicitZonalShifts) > 1 {
+		return nil, errors.New("conflicting zonal shifts")
+	}
+	var spec zonalshiftmodel.ZonalShiftSpec
+	for explicitSpec := range explicitZonalShifts {
+		spec = explicitSpec
+	}
+	if spec.AwayFrom == "" {
+		return nil, nil
+	}
+	if err := zonalshiftmodel.ValidateZonalShift(spec.AwayFrom, spec.ExpiresIn); err != nil {
+		return nil, err
+	}
+	spec.ResourceARN = lbARN
+	zonalShift := zonalshiftmodel.NewZonalShift(t.stack, resourceIDLoadBalancer, spec)
+	return zonalShift, nil
+}
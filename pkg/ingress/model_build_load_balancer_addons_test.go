@@ -0,0 +1,162 @@
+package ingress
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	networking "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	zonalshiftmodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/zonalshift"
+	"testing"
+)
+
+func Test_defaultModelBuildTask_buildZonalShift(t *testing.T) {
+	lbARN := core.LiteralStringToken("lb-arn")
+	buildIngress := func(name string, annotations map[string]string) *networking.Ingress {
+		return &networking.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "awesome-ns",
+				Name:        name,
+				Annotations: annotations,
+			},
+		}
+	}
+	tests := []struct {
+		name     string
+		ingGroup Group
+		want     *zonalshiftmodel.ZonalShiftSpec
+		wantErr  error
+	}{
+		{
+			name: "zonal shift not configured",
+			ingGroup: Group{
+				Members: []*networking.Ingress{
+					buildIngress("ing-1", map[string]string{}),
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "zonal shift configured with default expiry",
+			ingGroup: Group{
+				Members: []*networking.Ingress{
+					buildIngress("ing-1", map[string]string{
+						"alb.ingress.kubernetes.io/zonal-shift.away-from": "use1-az1",
+					}),
+					buildIngress("ing-2", map[string]string{}),
+				},
+			},
+			want: &zonalshiftmodel.ZonalShiftSpec{
+				ResourceARN: lbARN,
+				AwayFrom:    "use1-az1",
+				ExpiresIn:   "1h",
+			},
+		},
+		{
+			name: "zonal shift configured on multiple Ingresses",
+			ingGroup: Group{
+				Members: []*networking.Ingress{
+					buildIngress("ing-1", map[string]string{
+						"alb.ingress.kubernetes.io/zonal-shift.away-from":  "use1-az1",
+						"alb.ingress.kubernetes.io/zonal-shift.expires-in": "30m",
+						"alb.ingress.kubernetes.io/zonal-shift.comment":    "INC-1234",
+					}),
+					buildIngress("ing-2", map[string]string{
+						"alb.ingress.kubernetes.io/zonal-shift.away-from":  "use1-az1",
+						"alb.ingress.kubernetes.io/zonal-shift.expires-in": "30m",
+						"alb.ingress.kubernetes.io/zonal-shift.comment":    "INC-1234",
+					}),
+				},
+			},
+			want: &zonalshiftmodel.ZonalShiftSpec{
+				ResourceARN: lbARN,
+				AwayFrom:    "use1-az1",
+				ExpiresIn:   "30m",
+				Comment:     "INC-1234",
+			},
+		},
+		{
+			name: "empty zonal shift away-from",
+			ingGroup: Group{
+				Members: []*networking.Ingress{
+					buildIngress("ing-1", map[string]string{
+						"alb.ingress.kubernetes.io/zonal-shift.away-from": "",
+					}),
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "conflicting zonal shifts",
+			ingGroup: Group{
+				Members: []*networking.Ingress{
+					buildIngress("ing-1", map[string]string{
+						"alb.ingress.kubernetes.io/zonal-shift.away-from": "use1-az1",
+					}),
+					buildIngress("ing-2", map[string]string{
+						"alb.ingress.kubernetes.io/zonal-shift.away-from": "use1-az2",
+					}),
+				},
+			},
+			wantErr: errors.New("conflicting zonal shifts"),
+		},
+		{
+			name: "invalid zonal shift away-from",
+			ingGroup: Group{
+				Members: []*networking.Ingress{
+					buildIngress("ing-1", map[string]string{
+						"alb.ingress.kubernetes.io/zonal-shift.away-from": "us-east-1a",
+					}),
+				},
+			},
+			wantErr: errors.New("invalid zonal shift away-from us-east-1a, must be an availability zone ID like use1-az1"),
+		},
+		{
+			name: "invalid zonal shift expires-in",
+			ingGroup: Group{
+				Members: []*networking.Ingress{
+					buildIngress("ing-1", map[string]string{
+						"alb.ingress.kubernetes.io/zonal-shift.away-from":  "use1-az1",
+						"alb.ingress.kubernetes.io/zonal-shift.expires-in": "1d",
+					}),
+				},
+			},
+			wantErr: errors.New("invalid zonal shift expires-in 1d, must be minutes or hours like 30m or 12h"),
+		},
+		{
+			name: "zonal shift expires-in too long",
+			ingGroup: Group{
+				Members: []*networking.Ingress{
+					buildIngress("ing-1", map[string]string{
+						"alb.ingress.kubernetes.io/zonal-shift.away-from":  "use1-az1",
+						"alb.ingress.kubernetes.io/zonal-shift.expires-in": "73h",
+					}),
+				},
+			},
+			wantErr: errors.New("invalid zonal shift expires-in 73h, must be no longer than 72h"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stack := core.NewDefaultStack(core.StackID{Namespace: "awesome-ns", Name: "ing-1"})
+			task := &defaultModelBuildTask{
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				ingGroup:         tt.ingGroup,
+				stack:            stack,
+			}
+			got, err := task.buildZonalShift(context.Background(), lbARN)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				if tt.want == nil {
+					assert.Nil(t, got)
+				} else {
+					assert.Equal(t, *tt.want, got.Spec)
+				}
+			}
+		})
+	}
+}
@@ -5,9 +5,17 @@ import (
 	"errors"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	networkingpkg "sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sort"
 	"testing"
 )
 
@@ -200,3 +208,638 @@ func Test_defaultModelBuildTask_buildLoadBalancerCOIPv4Pool(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultModelBuildTask_buildExistingLoadBalancerARN(t *testing.T) {
+	type fields struct {
+		ingGroup Group
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    *string
+		wantErr error
+	}{
+		{
+			name: "existing LoadBalancer not referenced",
+			fields: fields{
+				ingGroup: Group{
+					Members: []*networking.Ingress{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace:   "awesome-ns",
+								Name:        "ing-1",
+								Annotations: map[string]string{},
+							},
+						},
+					},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "existing LoadBalancer referenced by standalone Ingress",
+			fields: fields{
+				ingGroup: Group{
+					Members: []*networking.Ingress{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: "awesome-ns",
+								Name:      "ing-1",
+								Annotations: map[string]string{
+									"alb.ingress.kubernetes.io/load-balancer-arn": "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-alb/50dc6c495c0c9188",
+								},
+							},
+						},
+					},
+				},
+			},
+			want: awssdk.String("arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-alb/50dc6c495c0c9188"),
+		},
+		{
+			name: "existing LoadBalancer referenced by some Ingresses among IngressGroup",
+			fields: fields{
+				ingGroup: Group{
+					Members: []*networking.Ingress{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: "awesome-ns",
+								Name:      "ing-1",
+								Annotations: map[string]string{
+									"alb.ingress.kubernetes.io/load-balancer-arn": "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-alb/50dc6c495c0c9188",
+								},
+							},
+						},
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace:   "awesome-ns",
+								Name:        "ing-2",
+								Annotations: map[string]string{},
+							},
+						},
+					},
+				},
+			},
+			want: awssdk.String("arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-alb/50dc6c495c0c9188"),
+		},
+		{
+			name: "existing LoadBalancer referenced by inactive Ingresses of IngressGroup being deleted",
+			fields: fields{
+				ingGroup: Group{
+					InactiveMembers: []*networking.Ingress{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: "awesome-ns",
+								Name:      "ing-1",
+								Annotations: map[string]string{
+									"alb.ingress.kubernetes.io/load-balancer-arn": "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-alb/50dc6c495c0c9188",
+								},
+							},
+						},
+					},
+				},
+			},
+			want: awssdk.String("arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-alb/50dc6c495c0c9188"),
+		},
+		{
+			name: "inactive Ingresses are ignored when IngressGroup has members",
+			fields: fields{
+				ingGroup: Group{
+					Members: []*networking.Ingress{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace:   "awesome-ns",
+								Name:        "ing-1",
+								Annotations: map[string]string{},
+							},
+						},
+					},
+					InactiveMembers: []*networking.Ingress{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: "awesome-ns",
+								Name:      "ing-2",
+								Annotations: map[string]string{
+									"alb.ingress.kubernetes.io/load-balancer-arn": "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-alb/50dc6c495c0c9188",
+								},
+							},
+						},
+					},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "conflicting existing LoadBalancers among IngressGroup",
+			fields: fields{
+				ingGroup: Group{
+					Members: []*networking.Ingress{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: "awesome-ns",
+								Name:      "ing-1",
+								Annotations: map[string]string{
+									"alb.ingress.kubernetes.io/load-balancer-arn": "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-alb/50dc6c495c0c9188",
+								},
+							},
+						},
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: "awesome-ns",
+								Name:      "ing-2",
+								Annotations: map[string]string{
+									"alb.ingress.kubernetes.io/load-balancer-arn": "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-another-alb/a3d2c5f1e9b8d7c6",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("conflicting load balancer ARN: [arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-alb/50dc6c495c0c9188 arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-another-alb/a3d2c5f1e9b8d7c6]"),
+		},
+		{
+			name: "existing Network LoadBalancer referenced",
+			fields: fields{
+				ingGroup: Group{
+					Members: []*networking.Ingress{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: "awesome-ns",
+								Name:      "ing-1",
+								Annotations: map[string]string{
+									"alb.ingress.kubernetes.io/load-balancer-arn": "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/net/my-nlb/50dc6c495c0c9188",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("invalid load balancer ARN: arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/net/my-nlb/50dc6c495c0c9188, must be the ARN of an Application Load Balancer"),
+		},
+		{
+			name: "malformed LoadBalancer ARN referenced",
+			fields: fields{
+				ingGroup: Group{
+					Members: []*networking.Ingress{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: "awesome-ns",
+								Name:      "ing-1",
+								Annotations: map[string]string{
+									"alb.ingress.kubernetes.io/load-balancer-arn": "my-alb",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("invalid load balancer ARN: my-alb, must be the ARN of an Application Load Balancer"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			annotationParser := annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
+			task := &defaultModelBuildTask{
+				annotationParser: annotationParser,
+				ingGroup:         tt.fields.ingGroup,
+			}
+			got, err := task.buildExistingLoadBalancerARN(context.Background())
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildLoadBalancerIPAddressType(t *testing.T) {
+	type fields struct {
+		ingGroup Group
+	}
+	type args struct {
+		scheme elbv2model.LoadBalancerScheme
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    elbv2model.IPAddressType
+		wantErr error
+	}{
+		{
+			name: "IPAddressType not configured",
+			fields: fields{
+				ingGroup: Group{
+					Members: []*networking.Ingress{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: "awesome-ns",
+								Name:      "ing-1",
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				scheme: elbv2model.LoadBalancerSchemeInternal,
+			},
+			want: elbv2model.IPAddressTypeIPV4,
+		},
+		{
+			name: "dualstack IPAddressType",
+			fields: fields{
+				ingGroup: Group{
+					Members: []*networking.Ingress{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: "awesome-ns",
+								Name:      "ing-1",
+								Annotations: map[string]string{
+									"alb.ingress.kubernetes.io/ip-address-type": "dualstack",
+								},
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				scheme: elbv2model.LoadBalancerSchemeInternal,
+			},
+			want: elbv2model.IPAddressTypeDualStack,
+		},
+		{
+			name: "dualstack-without-public-ipv4 IPAddressType with internet-facing scheme",
+			fields: fields{
+				ingGroup: Group{
+					Members: []*networking.Ingress{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: "awesome-ns",
+								Name:      "ing-1",
+								Annotations: map[string]string{
+									"alb.ingress.kubernetes.io/ip-address-type": "dualstack-without-public-ipv4",
+								},
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				scheme: elbv2model.LoadBalancerSchemeInternetFacing,
+			},
+			want: elbv2model.IPAddressTypeDualStackWithoutPublicIPV4,
+		},
+		{
+			name: "dualstack-without-public-ipv4 IPAddressType with internal scheme",
+			fields: fields{
+				ingGroup: Group{
+					Members: []*networking.Ingress{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: "awesome-ns",
+								Name:      "ing-1",
+								Annotations: map[string]string{
+									"alb.ingress.kubernetes.io/ip-address-type": "dualstack-without-public-ipv4",
+								},
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				scheme: elbv2model.LoadBalancerSchemeInternal,
+			},
+			wantErr: errors.New("IPAddressType dualstack-without-public-ipv4 is only supported for internet-facing scheme"),
+		},
+		{
+			name: "conflicting IPAddressType among IngressGroup",
+			fields: fields{
+				ingGroup: Group{
+					Members: []*networking.Ingress{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: "awesome-ns",
+								Name:      "ing-1",
+								Annotations: map[string]string{
+									"alb.ingress.kubernetes.io/ip-address-type": "dualstack",
+								},
+							},
+						},
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: "awesome-ns",
+								Name:      "ing-2",
+								Annotations: map[string]string{
+									"alb.ingress.kubernetes.io/ip-address-type": "dualstack-without-public-ipv4",
+								},
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				scheme: elbv2model.LoadBalancerSchemeInternetFacing,
+			},
+			wantErr: errors.New("conflicting IPAddressType: [dualstack dualstack-without-public-ipv4]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			annotationParser := annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
+			task := &defaultModelBuildTask{
+				annotationParser:     annotationParser,
+				ingGroup:             tt.fields.ingGroup,
+				defaultIPAddressType: elbv2model.IPAddressTypeIPV4,
+			}
+			got, err := task.buildLoadBalancerIPAddressType(context.Background(), tt.args.scheme)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildLoadBalancerSubnetLocale(t *testing.T) {
+	tests := []struct {
+		name           string
+		ingAnnotations []map[string]string
+		want           networkingpkg.SubnetLocaleType
+		wantErr        error
+	}{
+		{
+			name:           "subnet locale not configured",
+			ingAnnotations: []map[string]string{{}},
+			want:           "",
+		},
+		{
+			name: "localZone subnet locale",
+			ingAnnotations: []map[string]string{
+				{"alb.ingress.kubernetes.io/subnet-locale": "localZone"},
+				{},
+			},
+			want: networkingpkg.SubnetLocaleTypeLocalZone,
+		},
+		{
+			name: "conflicting subnet locales",
+			ingAnnotations: []map[string]string{
+				{"alb.ingress.kubernetes.io/subnet-locale": "localZone"},
+				{"alb.ingress.kubernetes.io/subnet-locale": "outpost"},
+			},
+			wantErr: errors.New("conflicting subnet locale: [localZone outpost]"),
+		},
+		{
+			name: "unknown subnet locale",
+			ingAnnotations: []map[string]string{
+				{"alb.ingress.kubernetes.io/subnet-locale": "local-zone"},
+			},
+			wantErr: errors.New("unknown subnet locale: local-zone"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var members []*networking.Ingress
+			for _, ingAnnotations := range tt.ingAnnotations {
+				members = append(members, &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "awesome-ns",
+						Annotations: ingAnnotations,
+					},
+				})
+			}
+			task := &defaultModelBuildTask{
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				ingGroup:         Group{Members: members},
+			}
+			got, err := task.buildLoadBalancerSubnetLocale(context.Background())
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildLoadBalancerSubnetTags(t *testing.T) {
+	tests := []struct {
+		name           string
+		ingAnnotations []map[string]string
+		want           map[string][]string
+		wantErr        error
+	}{
+		{
+			name:           "subnet tags not configured",
+			ingAnnotations: []map[string]string{{}},
+			want:           map[string][]string{},
+		},
+		{
+			name: "subnet tags merged from Ingresses",
+			ingAnnotations: []map[string]string{
+				{"alb.ingress.kubernetes.io/subnet-tags": "tenant=team-b|team-a, ingress="},
+				{"alb.ingress.kubernetes.io/subnet-tags": "tenant=team-a|team-b,tier=private"},
+			},
+			want: map[string][]string{
+				"tenant":  {"team-a", "team-b"},
+				"ingress": nil,
+				"tier":    {"private"},
+			},
+		},
+		{
+			name: "conflicting subnet tags",
+			ingAnnotations: []map[string]string{
+				{"alb.ingress.kubernetes.io/subnet-tags": "tenant=team-a"},
+				{"alb.ingress.kubernetes.io/subnet-tags": "tenant=team-b"},
+			},
+			wantErr: errors.New("conflicting subnet tag tenant: [team-a] | [team-b]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var members []*networking.Ingress
+			for _, ingAnnotations := range tt.ingAnnotations {
+				members = append(members, &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "awesome-ns",
+						Annotations: ingAnnotations,
+					},
+				})
+			}
+			task := &defaultModelBuildTask{
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				ingGroup:         Group{Members: members},
+			}
+			got, err := task.buildLoadBalancerSubnetTags(context.Background())
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildLoadBalancerAttributes(t *testing.T) {
+	ingClassParams := &elbv2api.IngressClassParams{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "awesome-params",
+		},
+		Spec: elbv2api.IngressClassParamsSpec{
+			LoadBalancerAttributes: []elbv2api.Attribute{
+				{
+					Key:   "routing.http.desync_mitigation_mode",
+					Value: "strictest",
+				},
+				{
+					Key:   "client_keep_alive.seconds",
+					Value: "600",
+				},
+			},
+		},
+	}
+	ingClass := &networking.IngressClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "awesome-class",
+		},
+		Spec: networking.IngressClassSpec{
+			Controller: ingressClassControllerALB,
+			Parameters: &corev1.TypedLocalObjectReference{
+				APIGroup: awssdk.String("elbv2.k8s.aws"),
+				Kind:     "IngressClassParams",
+				Name:     "awesome-params",
+			},
+		},
+	}
+	buildIngress := func(ingClassName *string, attributes string) *networking.Ingress {
+		ing := &networking.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "awesome-ns",
+				Name:        "ing-1",
+				Annotations: map[string]string{},
+			},
+			Spec: networking.IngressSpec{
+				IngressClassName: ingClassName,
+			},
+		}
+		if attributes != "" {
+			ing.Annotations["alb.ingress.kubernetes.io/load-balancer-attributes"] = attributes
+		}
+		return ing
+	}
+	tests := []struct {
+		name              string
+		ing               *networking.Ingress
+		defaultAttributes map[string]string
+		want              []elbv2model.LoadBalancerAttribute
+		wantErr           error
+	}{
+		{
+			name: "attributes from annotation",
+			ing:  buildIngress(nil, "routing.http2.enabled=false,client_keep_alive.seconds=120"),
+			want: []elbv2model.LoadBalancerAttribute{
+				{
+					Key:   "client_keep_alive.seconds",
+					Value: "120",
+				},
+				{
+					Key:   "routing.http2.enabled",
+					Value: "false",
+				},
+			},
+		},
+		{
+			name: "attributes from IngressClassParams take precedence",
+			ing:  buildIngress(awssdk.String("awesome-class"), "routing.http2.enabled=false,client_keep_alive.seconds=120"),
+			want: []elbv2model.LoadBalancerAttribute{
+				{
+					Key:   "client_keep_alive.seconds",
+					Value: "600",
+				},
+				{
+					Key:   "routing.http.desync_mitigation_mode",
+					Value: "strictest",
+				},
+				{
+					Key:   "routing.http2.enabled",
+					Value: "false",
+				},
+			},
+		},
+		{
+			name: "default attributes from dynamic config only apply to unspecified attributes",
+			ing:  buildIngress(awssdk.String("awesome-class"), "routing.http2.enabled=false"),
+			defaultAttributes: map[string]string{
+				"client_keep_alive.seconds":    "3600",
+				"routing.http2.enabled":        "true",
+				"idle_timeout.timeout_seconds": "120",
+			},
+			want: []elbv2model.LoadBalancerAttribute{
+				{
+					Key:   "client_keep_alive.seconds",
+					Value: "600",
+				},
+				{
+					Key:   "idle_timeout.timeout_seconds",
+					Value: "120",
+				},
+				{
+					Key:   "routing.http.desync_mitigation_mode",
+					Value: "strictest",
+				},
+				{
+					Key:   "routing.http2.enabled",
+					Value: "false",
+				},
+			},
+		},
+		{
+			name:    "invalid desync mitigation mode",
+			ing:     buildIngress(nil, "routing.http.desync_mitigation_mode=relaxed"),
+			wantErr: errors.New("invalid loadBalancerAttribute routing.http.desync_mitigation_mode: relaxed, must be monitor, defensive or strictest"),
+		},
+		{
+			name:    "invalid client keep-alive seconds",
+			ing:     buildIngress(nil, "client_keep_alive.seconds=30"),
+			wantErr: errors.New("invalid loadBalancerAttribute client_keep_alive.seconds: 30, must be an integer within [60, 604800]"),
+		},
+		{
+			name:    "invalid http2 enabled",
+			ing:     buildIngress(nil, "routing.http2.enabled=maybe"),
+			wantErr: errors.New("invalid loadBalancerAttribute routing.http2.enabled: maybe, must be true or false"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			assert.NoError(t, k8sClient.Create(ctx, ingClass.DeepCopy()))
+			assert.NoError(t, k8sClient.Create(ctx, ingClassParams.DeepCopy()))
+
+			task := &defaultModelBuildTask{
+				ingGroup:          Group{Members: []*networking.Ingress{tt.ing}},
+				annotationParser:  annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				classParamsLoader: NewDefaultClassParamsLoader(k8sClient),
+
+				defaultLoadBalancerAttributes: tt.defaultAttributes,
+			}
+			got, err := task.buildLoadBalancerAttributes(ctx)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				sort.Slice(got, func(i, j int) bool {
+					return got[i].Key < got[j].Key
+				})
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -7,25 +7,49 @@ import (
 	"fmt"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"regexp"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/quota"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	networkingpkg "sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/securitygrouppolicy"
+	"sort"
 )
 
 const (
 	resourceIDManagedSecurityGroup = "ManagedLBSecurityGroup"
 )
 
-func (t *defaultModelBuildTask) buildManagedSecurityGroup(ctx context.Context, listenPortConfigByPort map[int64]listenPortConfig, ipAddressType elbv2model.IPAddressType) (*ec2model.SecurityGroup, error) {
+// buildManagedSecurityGroups builds the managed SecurityGroups for the LoadBalancer.
+// inbound rules are sharded into additional SecurityGroups when they exceed the rule quota of a single SecurityGroup,
+// up to the quota of SecurityGroups per network interface. The first SecurityGroup is used as the source of traffic to backends.
+func (t *defaultModelBuildTask) buildManagedSecurityGroups(ctx context.Context, listenPortConfigByPort map[int64]listenPortConfig, ipAddressType elbv2model.IPAddressType) ([]*ec2model.SecurityGroup, error) {
 	sgSpec, err := t.buildManagedSecurityGroupSpec(ctx, listenPortConfigByPort, ipAddressType)
 	if err != nil {
 		return nil, err
 	}
+	maxRulesPerSG := t.quotaProvider.GetQuotaValue(ctx, quota.QuotaRulesPerSecurityGroup)
+	maxSGs := t.quotaProvider.GetQuotaValue(ctx, quota.QuotaSecurityGroupsPerNetworkInterface)
+	shardSGSpecs, err := networkingpkg.ShardSecurityGroupSpec(sgSpec, int(maxRulesPerSG), int(maxSGs))
+	if err != nil {
+		return nil, err
+	}
 
-	sg := ec2model.NewSecurityGroup(t.stack, resourceIDManagedSecurityGroup, sgSpec)
-	t.managedSG = sg
-	return sg, nil
+	sgs := make([]*ec2model.SecurityGroup, 0, len(shardSGSpecs))
+	for shardIndex, shardSGSpec := range shardSGSpecs {
+		resID := resourceIDManagedSecurityGroup
+		if shardIndex > 0 {
+			resID = fmt.Sprintf("%v-%v", resourceIDManagedSecurityGroup, shardIndex)
+		}
+		sgs = append(sgs, ec2model.NewSecurityGroup(t.stack, resID, shardSGSpec))
+	}
+	t.managedSG = sgs[0]
+	return sgs, nil
 }
 
 func (t *defaultModelBuildTask) buildManagedSecurityGroupSpec(ctx context.Context, listenPortConfigByPort map[int64]listenPortConfig, ipAddressType elbv2model.IPAddressType) (ec2model.SecurityGroupSpec, error) {
@@ -35,6 +59,11 @@ func (t *defaultModelBuildTask) buildManagedSecurityGroupSpec(ctx context.Contex
 		return ec2model.SecurityGroupSpec{}, err
 	}
 	ingressPermissions := t.buildManagedSecurityGroupIngressPermissions(ctx, listenPortConfigByPort, ipAddressType)
+	policyPermissions, err := t.buildManagedSecurityGroupPolicyPermissions(ctx, listenPortConfigByPort)
+	if err != nil {
+		return ec2model.SecurityGroupSpec{}, err
+	}
+	ingressPermissions = append(ingressPermissions, policyPermissions...)
 	return ec2model.SecurityGroupSpec{
 		GroupName:   name,
 		Description: "[k8s] Managed SecurityGroup for LoadBalancer",
@@ -75,13 +104,30 @@ func (t *defaultModelBuildTask) buildManagedSecurityGroupTags(_ context.Context)
 			mergedTags[tagKey] = tagValue
 		}
 	}
-	return mergedTags, nil
+	return t.mergeDefaultTags(mergedTags), nil
 }
 
+// buildManagedSecurityGroupIngressPermissions builds the inbound permissions for listen ports from their inbound CIDRs.
+// by default, all IPv4 clients are allowed, as well as all IPv6 clients if the LoadBalancer serves IPv6 clients,
+// IPv6 inbound CIDRs for LoadBalancers that don't serve IPv6 clients are ignored with a warning event on members of IngressGroup.
 func (t *defaultModelBuildTask) buildManagedSecurityGroupIngressPermissions(_ context.Context, listenPortConfigByPort map[int64]listenPortConfig, ipAddressType elbv2model.IPAddressType) []ec2model.IPPermission {
 	var permissions []ec2model.IPPermission
 	for port, cfg := range listenPortConfigByPort {
-		for _, cidr := range cfg.inboundCIDRv4s {
+		inboundCIDRv4s, inboundCIDRv6s := cfg.inboundCIDRv4s, cfg.inboundCIDRv6s
+		if len(inboundCIDRv4s) == 0 && len(inboundCIDRv6s) == 0 {
+			inboundCIDRv4s = []string{"0.0.0.0/0"}
+			if isIPv6Supported(ipAddressType) {
+				inboundCIDRv6s = []string{"::/0"}
+			}
+		} else if len(inboundCIDRv6s) != 0 && !isIPv6Supported(ipAddressType) {
+			for _, ing := range t.ingGroup.Members {
+				t.eventRecorder.Eventf(ing, corev1.EventTypeWarning, k8s.IngressEventReasonIgnoredInboundCIDRs,
+					"Ignored IPv6 %v %v for port %v since they are only supported with dualstack IPAddressType, got %v",
+					annotations.IngressSuffixInboundCIDRs, inboundCIDRv6s, port, ipAddressType)
+			}
+			inboundCIDRv6s = nil
+		}
+		for _, cidr := range inboundCIDRv4s {
 			permissions = append(permissions, ec2model.IPPermission{
 				IPProtocol: "tcp",
 				FromPort:   awssdk.Int64(port),
@@ -93,20 +139,40 @@ func (t *defaultModelBuildTask) buildManagedSecurityGroupIngressPermissions(_ co
 				},
 			})
 		}
-		if ipAddressType == elbv2model.IPAddressTypeDualStack {
-			for _, cidr := range cfg.inboundCIDRv6s {
-				permissions = append(permissions, ec2model.IPPermission{
-					IPProtocol: "tcp",
-					FromPort:   awssdk.Int64(port),
-					ToPort:     awssdk.Int64(port),
-					IPv6Range: []ec2model.IPv6Range{
-						{
-							CIDRIPv6: cidr,
-						},
+		for _, cidr := range inboundCIDRv6s {
+			permissions = append(permissions, ec2model.IPPermission{
+				IPProtocol: "tcp",
+				FromPort:   awssdk.Int64(port),
+				ToPort:     awssdk.Int64(port),
+				IPv6Range: []ec2model.IPv6Range{
+					{
+						CIDRIPv6: cidr,
 					},
-				})
-			}
+				},
+			})
 		}
 	}
 	return permissions
 }
+
+// buildManagedSecurityGroupPolicyPermissions builds the inbound permissions from SecurityGroupPolicies targeting members of IngressGroup.
+// SecurityGroupPolicies of any member apply to the shared SecurityGroup, thus open the listener ports for all members of IngressGroup.
+func (t *defaultModelBuildTask) buildManagedSecurityGroupPolicyPermissions(ctx context.Context, listenPortConfigByPort map[int64]listenPortConfig) ([]ec2model.IPPermission, error) {
+	listenPorts := make([]securitygrouppolicy.ListenPort, 0, len(listenPortConfigByPort))
+	for port := range listenPortConfigByPort {
+		listenPorts = append(listenPorts, securitygrouppolicy.ListenPort{Protocol: ec2model.IPProtocolTCP, Port: port})
+	}
+	sort.Slice(listenPorts, func(i, j int) bool {
+		return listenPorts[i].Port < listenPorts[j].Port
+	})
+	return t.sgPolicyPermissionBuilder.Build(ctx, elbv2api.SecurityGroupPolicyTargetKindIngress, t.buildSecurityGroupPolicyTargets(), listenPorts)
+}
+
+// buildSecurityGroupPolicyTargets builds the targets of SecurityGroupPolicies that apply to the LoadBalancer, which are members of IngressGroup.
+func (t *defaultModelBuildTask) buildSecurityGroupPolicyTargets() []types.NamespacedName {
+	targets := make([]types.NamespacedName, 0, len(t.ingGroup.Members))
+	for _, ing := range t.ingGroup.Members {
+		targets = append(targets, k8s.NamespacedName(ing))
+	}
+	return targets
+}
@@ -0,0 +1,177 @@
+package ingress
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	networking "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/securitygrouppolicy"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+func Test_defaultModelBuildTask_buildManagedSecurityGroupIngressPermissions(t *testing.T) {
+	defaultListenPortConfigByPort := map[int64]listenPortConfig{
+		80: {
+			protocol: elbv2model.ProtocolHTTP,
+		},
+	}
+	ipv6ListenPortConfigByPort := map[int64]listenPortConfig{
+		80: {
+			protocol:       elbv2model.ProtocolHTTP,
+			inboundCIDRv6s: []string{"::/0"},
+		},
+	}
+	ipv4Permission := ec2model.IPPermission{
+		IPProtocol: "tcp",
+		FromPort:   awssdk.Int64(80),
+		ToPort:     awssdk.Int64(80),
+		IPRanges: []ec2model.IPRange{
+			{
+				CIDRIP: "0.0.0.0/0",
+			},
+		},
+	}
+	ipv6Permission := ec2model.IPPermission{
+		IPProtocol: "tcp",
+		FromPort:   awssdk.Int64(80),
+		ToPort:     awssdk.Int64(80),
+		IPv6Range: []ec2model.IPv6Range{
+			{
+				CIDRIPv6: "::/0",
+			},
+		},
+	}
+	type args struct {
+		listenPortConfigByPort map[int64]listenPortConfig
+		ipAddressType          elbv2model.IPAddressType
+	}
+	tests := []struct {
+		name       string
+		args       args
+		want       []ec2model.IPPermission
+		wantEvents []string
+	}{
+		{
+			name: "default inboundCIDRs with ipv4 IPAddressType",
+			args: args{
+				listenPortConfigByPort: defaultListenPortConfigByPort,
+				ipAddressType:          elbv2model.IPAddressTypeIPV4,
+			},
+			want: []ec2model.IPPermission{ipv4Permission},
+		},
+		{
+			name: "default inboundCIDRs with dualstack IPAddressType",
+			args: args{
+				listenPortConfigByPort: defaultListenPortConfigByPort,
+				ipAddressType:          elbv2model.IPAddressTypeDualStack,
+			},
+			want: []ec2model.IPPermission{ipv4Permission, ipv6Permission},
+		},
+		{
+			name: "default inboundCIDRs with dualstack-without-public-ipv4 IPAddressType",
+			args: args{
+				listenPortConfigByPort: defaultListenPortConfigByPort,
+				ipAddressType:          elbv2model.IPAddressTypeDualStackWithoutPublicIPV4,
+			},
+			want: []ec2model.IPPermission{ipv4Permission, ipv6Permission},
+		},
+		{
+			name: "IPv6 inboundCIDRs with dualstack IPAddressType",
+			args: args{
+				listenPortConfigByPort: ipv6ListenPortConfigByPort,
+				ipAddressType:          elbv2model.IPAddressTypeDualStack,
+			},
+			want: []ec2model.IPPermission{ipv6Permission},
+		},
+		{
+			name: "IPv6 inboundCIDRs with ipv4 IPAddressType",
+			args: args{
+				listenPortConfigByPort: ipv6ListenPortConfigByPort,
+				ipAddressType:          elbv2model.IPAddressTypeIPV4,
+			},
+			want: nil,
+			wantEvents: []string{
+				"Warning IgnoredInboundCIDRs Ignored IPv6 inbound-cidrs [::/0] for port 80 since they are only supported with dualstack IPAddressType, got ipv4",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventRecorder := record.NewFakeRecorder(10)
+			task := &defaultModelBuildTask{
+				eventRecorder: eventRecorder,
+				ingGroup: Group{
+					Members: []*networking.Ingress{
+						{ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "ing-1"}},
+					},
+				},
+			}
+			got := task.buildManagedSecurityGroupIngressPermissions(context.Background(), tt.args.listenPortConfigByPort, tt.args.ipAddressType)
+			assert.Equal(t, tt.want, got)
+			close(eventRecorder.Events)
+			var gotEvents []string
+			for event := range eventRecorder.Events {
+				gotEvents = append(gotEvents, event)
+			}
+			assert.Equal(t, tt.wantEvents, gotEvents)
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildManagedSecurityGroupPolicyPermissions(t *testing.T) {
+	k8sSchema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	elbv2api.AddToScheme(k8sSchema)
+	k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+	policy := &elbv2api.SecurityGroupPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "office"},
+		Spec: elbv2api.SecurityGroupPolicySpec{
+			TargetRef: elbv2api.SecurityGroupPolicyTargetReference{Kind: elbv2api.SecurityGroupPolicyTargetKindIngress, Name: "ing-2"},
+			Ingress: []elbv2api.SecurityGroupPolicyIngressRule{
+				{
+					From: []elbv2api.SecurityGroupPolicyPeer{{IPBlock: &elbv2api.IPBlock{CIDR: "10.0.0.0/16"}}},
+				},
+			},
+		},
+	}
+	assert.NoError(t, k8sClient.Create(context.Background(), policy))
+
+	task := &defaultModelBuildTask{
+		sgPolicyPermissionBuilder: securitygrouppolicy.NewDefaultPermissionBuilder(k8sClient, record.NewFakeRecorder(10)),
+		ingGroup: Group{
+			ID: GroupID{Name: "awesome-group"},
+			Members: []*networking.Ingress{
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "ing-1"}},
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "ing-2"}},
+			},
+		},
+	}
+	listenPortConfigByPort := map[int64]listenPortConfig{
+		443: {protocol: elbv2model.ProtocolHTTPS},
+		80:  {protocol: elbv2model.ProtocolHTTP},
+	}
+	got, err := task.buildManagedSecurityGroupPolicyPermissions(context.Background(), listenPortConfigByPort)
+	assert.NoError(t, err)
+	assert.Equal(t, []ec2model.IPPermission{
+		{
+			IPProtocol: "tcp",
+			FromPort:   awssdk.Int64(80),
+			ToPort:     awssdk.Int64(80),
+			IPRanges:   []ec2model.IPRange{{CIDRIP: "10.0.0.0/16", Description: "elbv2.k8s.aws/security-group-policy=awesome-ns/office"}},
+		},
+		{
+			IPProtocol: "tcp",
+			FromPort:   awssdk.Int64(443),
+			ToPort:     awssdk.Int64(443),
+			IPRanges:   []ec2model.IPRange{{CIDRIP: "10.0.0.0/16", Description: "elbv2.k8s.aws/security-group-policy=awesome-ns/office"}},
+		},
+	}, got)
+}
@@ -0,0 +1,53 @@
+package ingress
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	route53model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/route53"
+	"strings"
+)
+
+// buildRoute53RecordSets builds the alias records routing traffic for hosts of Ingresses that opted into Route 53 record management.
+// AAAA records are built in addition to A records if the LoadBalancer supports IPv6.
+func (t *defaultModelBuildTask) buildRoute53RecordSets(_ context.Context, lb *elbv2model.LoadBalancer) ([]*route53model.RecordSet, error) {
+	hosts := sets.NewString()
+	for _, ing := range t.ingGroup.Members {
+		manageRecords := false
+		if _, err := t.annotationParser.ParseBoolAnnotation(annotations.IngressSuffixManageRoute53Records, &manageRecords, ing.Annotations); err != nil {
+			return nil, errors.Wrapf(err, "ingress: %v", k8s.NamespacedName(ing))
+		}
+		if !manageRecords {
+			continue
+		}
+		for _, rule := range ing.Spec.Rules {
+			if len(rule.Host) != 0 {
+				hosts.Insert(strings.ToLower(rule.Host))
+			}
+		}
+	}
+	recordTypes := []route53model.RecordType{route53model.RecordTypeA}
+	if lb.Spec.IPAddressType != nil && isIPv6Supported(*lb.Spec.IPAddressType) {
+		recordTypes = append(recordTypes, route53model.RecordTypeAAAA)
+	}
+	var recordSets []*route53model.RecordSet
+	for _, host := range hosts.List() {
+		for _, recordType := range recordTypes {
+			resID := fmt.Sprintf("RecordSet-%v-%v", recordType, host)
+			recordSets = append(recordSets, route53model.NewRecordSet(t.stack, resID, route53model.RecordSetSpec{
+				Name: host,
+				Type: recordType,
+				AliasTarget: route53model.AliasTarget{
+					DNSName:              lb.DNSName(),
+					HostedZoneID:         lb.CanonicalHostedZoneID(),
+					EvaluateTargetHealth: true,
+				},
+			}))
+		}
+	}
+	return recordSets, nil
+}
@@ -0,0 +1,117 @@
+package ingress
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	networking "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	route53model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/route53"
+	"testing"
+)
+
+func Test_defaultModelBuildTask_buildRoute53RecordSets(t *testing.T) {
+	buildIngress := func(name string, annotations map[string]string, hosts ...string) *networking.Ingress {
+		ing := &networking.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "awesome-ns",
+				Name:        name,
+				Annotations: annotations,
+			},
+		}
+		for _, host := range hosts {
+			ing.Spec.Rules = append(ing.Spec.Rules, networking.IngressRule{Host: host})
+		}
+		return ing
+	}
+	manageRecords := map[string]string{"alb.ingress.kubernetes.io/manage-route53-records": "true"}
+	ipv4 := elbv2model.IPAddressTypeIPV4
+	dualStack := elbv2model.IPAddressTypeDualStack
+	type recordSet struct {
+		name       string
+		recordType route53model.RecordType
+	}
+	tests := []struct {
+		name          string
+		ingGroup      Group
+		ipAddressType *elbv2model.IPAddressType
+		want          []recordSet
+		wantErr       error
+	}{
+		{
+			name: "records not managed",
+			ingGroup: Group{
+				Members: []*networking.Ingress{
+					buildIngress("ing-1", map[string]string{}, "www.example.com"),
+				},
+			},
+			ipAddressType: &ipv4,
+		},
+		{
+			name: "records managed for opted-in Ingresses",
+			ingGroup: Group{
+				Members: []*networking.Ingress{
+					buildIngress("ing-1", manageRecords, "www.example.com", "", "API.example.com"),
+					buildIngress("ing-2", map[string]string{}, "other.example.com"),
+					buildIngress("ing-3", manageRecords, "www.example.com", "*.apps.example.com"),
+				},
+			},
+			ipAddressType: &ipv4,
+			want: []recordSet{
+				{name: "*.apps.example.com", recordType: route53model.RecordTypeA},
+				{name: "api.example.com", recordType: route53model.RecordTypeA},
+				{name: "www.example.com", recordType: route53model.RecordTypeA},
+			},
+		},
+		{
+			name: "AAAA records for dualstack LoadBalancer",
+			ingGroup: Group{
+				Members: []*networking.Ingress{
+					buildIngress("ing-1", manageRecords, "www.example.com"),
+				},
+			},
+			ipAddressType: &dualStack,
+			want: []recordSet{
+				{name: "www.example.com", recordType: route53model.RecordTypeA},
+				{name: "www.example.com", recordType: route53model.RecordTypeAAAA},
+			},
+		},
+		{
+			name: "invalid annotation",
+			ingGroup: Group{
+				Members: []*networking.Ingress{
+					buildIngress("ing-1", map[string]string{"alb.ingress.kubernetes.io/manage-route53-records": "yes"}, "www.example.com"),
+				},
+			},
+			wantErr: errors.New("ingress: awesome-ns/ing-1: failed to parse bool annotation, alb.ingress.kubernetes.io/manage-route53-records: yes: strconv.ParseBool: parsing \"yes\": invalid syntax"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stack := core.NewDefaultStack(core.StackID{Namespace: "awesome-ns", Name: "ing-1"})
+			task := &defaultModelBuildTask{
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				ingGroup:         tt.ingGroup,
+				stack:            stack,
+			}
+			lb := elbv2model.NewLoadBalancer(stack, resourceIDLoadBalancer, elbv2model.LoadBalancerSpec{
+				IPAddressType: tt.ipAddressType,
+			})
+			got, err := task.buildRoute53RecordSets(context.Background(), lb)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			var gotRecordSets []recordSet
+			for _, rs := range got {
+				gotRecordSets = append(gotRecordSets, recordSet{name: rs.Spec.Name, recordType: rs.Spec.Type})
+				assert.True(t, rs.Spec.AliasTarget.EvaluateTargetHealth)
+			}
+			assert.Equal(t, tt.want, gotRecordSets)
+		})
+	}
+}
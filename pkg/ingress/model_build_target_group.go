@@ -11,6 +11,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"regexp"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
@@ -49,6 +50,11 @@ func (t *defaultModelBuildTask) buildTargetGroupBinding(ctx context.Context, tg
 
 func (t *defaultModelBuildTask) buildTargetGroupBindingSpec(ctx context.Context, tg *elbv2model.TargetGroup, svc *corev1.Service, port intstr.IntOrString) elbv2model.TargetGroupBindingResourceSpec {
 	targetType := elbv2api.TargetType(tg.Spec.TargetType)
+	var ipAddressType *elbv2api.TargetGroupIPAddressType
+	if tg.Spec.IPAddressType != nil {
+		tgbIPAddressType := elbv2api.TargetGroupIPAddressType(*tg.Spec.IPAddressType)
+		ipAddressType = &tgbIPAddressType
+	}
 	tgbNetworking := t.buildTargetGroupBindingNetworking(ctx)
 	return elbv2model.TargetGroupBindingResourceSpec{
 		Template: elbv2model.TargetGroupBindingTemplate{
@@ -63,7 +69,9 @@ func (t *defaultModelBuildTask) buildTargetGroupBindingSpec(ctx context.Context,
 					Name: svc.Name,
 					Port: port,
 				},
-				Networking: tgbNetworking,
+				IPAddressType:      ipAddressType,
+				Networking:         tgbNetworking,
+				IAMRoleARNToAssume: t.iamRoleARNToAssume,
 			},
 		},
 	}
@@ -98,7 +106,11 @@ func (t *defaultModelBuildTask) buildTargetGroupBindingNetworking(_ context.Cont
 func (t *defaultModelBuildTask) buildTargetGroupSpec(ctx context.Context,
 	ing *networking.Ingress, svc *corev1.Service, port intstr.IntOrString) (elbv2model.TargetGroupSpec, error) {
 	svcAndIngAnnotations := algorithm.MergeStringMap(svc.Annotations, ing.Annotations)
-	targetType, err := t.buildTargetGroupTargetType(ctx, svcAndIngAnnotations)
+	targetType, err := t.targetTypeResolver.Resolve(ctx, ing, svc)
+	if err != nil {
+		return elbv2model.TargetGroupSpec{}, err
+	}
+	ipAddressType, err := t.buildTargetGroupIPAddressType(ctx, svc, targetType)
 	if err != nil {
 		return elbv2model.TargetGroupSpec{}, err
 	}
@@ -127,10 +139,11 @@ func (t *defaultModelBuildTask) buildTargetGroupSpec(ctx context.Context,
 		return elbv2model.TargetGroupSpec{}, err
 	}
 	tgPort := t.buildTargetGroupPort(ctx, targetType, svcPort)
-	name := t.buildTargetGroupName(ctx, k8s.NamespacedName(ing), svc, port, tgPort, targetType, tgProtocol, tgProtocolVersion)
+	name := t.buildTargetGroupName(ctx, k8s.NamespacedName(ing), svc, port, tgPort, targetType, ipAddressType, tgProtocol, tgProtocolVersion)
 	return elbv2model.TargetGroupSpec{
 		Name:                  name,
 		TargetType:            targetType,
+		IPAddressType:         &ipAddressType,
 		Port:                  tgPort,
 		Protocol:              tgProtocol,
 		ProtocolVersion:       &tgProtocolVersion,
@@ -145,7 +158,7 @@ var invalidTargetGroupNamePattern = regexp.MustCompile("[[:^alnum:]]")
 // buildTargetGroupName will calculate the targetGroup's name.
 func (t *defaultModelBuildTask) buildTargetGroupName(_ context.Context,
 	ingKey types.NamespacedName, svc *corev1.Service, port intstr.IntOrString, tgPort int64,
-	targetType elbv2model.TargetType, tgProtocol elbv2model.Protocol, tgProtocolVersion elbv2model.ProtocolVersion) string {
+	targetType elbv2model.TargetType, ipAddressType elbv2model.TargetGroupIPAddressType, tgProtocol elbv2model.Protocol, tgProtocolVersion elbv2model.ProtocolVersion) string {
 	uuidHash := sha256.New()
 	_, _ = uuidHash.Write([]byte(t.clusterName))
 	_, _ = uuidHash.Write([]byte(t.ingGroup.ID.String()))
@@ -155,6 +168,10 @@ func (t *defaultModelBuildTask) buildTargetGroupName(_ context.Context,
 	_, _ = uuidHash.Write([]byte(port.String()))
 	_, _ = uuidHash.Write([]byte(strconv.Itoa(int(tgPort))))
 	_, _ = uuidHash.Write([]byte(targetType))
+	// ipv4 is excluded to keep names of existing TargetGroups unchanged.
+	if ipAddressType == elbv2model.TargetGroupIPAddressTypeIPv6 {
+		_, _ = uuidHash.Write([]byte(ipAddressType))
+	}
 	_, _ = uuidHash.Write([]byte(tgProtocol))
 	_, _ = uuidHash.Write([]byte(tgProtocolVersion))
 	uuid := hex.EncodeToString(uuidHash.Sum(nil))
@@ -164,17 +181,16 @@ func (t *defaultModelBuildTask) buildTargetGroupName(_ context.Context,
 	return fmt.Sprintf("k8s-%.8s-%.8s-%.10s", sanitizedNamespace, sanitizedName, uuid)
 }
 
-func (t *defaultModelBuildTask) buildTargetGroupTargetType(_ context.Context, svcAndIngAnnotations map[string]string) (elbv2model.TargetType, error) {
-	rawTargetType := string(t.defaultTargetType)
-	_ = t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixTargetType, &rawTargetType, svcAndIngAnnotations)
-	switch rawTargetType {
-	case string(elbv2model.TargetTypeInstance):
-		return elbv2model.TargetTypeInstance, nil
-	case string(elbv2model.TargetTypeIP):
-		return elbv2model.TargetTypeIP, nil
-	default:
-		return "", errors.Errorf("unknown targetType: %v", rawTargetType)
+// buildTargetGroupIPAddressType builds the TargetGroup's IPAddressType.
+// IPv6 TargetGroups are only used for ip TargetType with IPv6 services, which requires a dualstack LoadBalancer.
+func (t *defaultModelBuildTask) buildTargetGroupIPAddressType(_ context.Context, svc *corev1.Service, targetType elbv2model.TargetType) (elbv2model.TargetGroupIPAddressType, error) {
+	if targetType != elbv2model.TargetTypeIP || svc.Spec.IPFamily == nil || (*svc.Spec.IPFamily) != corev1.IPv6Protocol {
+		return elbv2model.TargetGroupIPAddressTypeIPv4, nil
+	}
+	if t.loadBalancer == nil || t.loadBalancer.Spec.IPAddressType == nil || !isIPv6Supported(*t.loadBalancer.Spec.IPAddressType) {
+		return "", errors.Errorf("unsupported IPv6 configuration for service %v, dualstack LoadBalancer is required", k8s.NamespacedName(svc))
 	}
+	return elbv2model.TargetGroupIPAddressTypeIPv6, nil
 }
 
 // buildTargetGroupPort constructs the TargetGroup's port.
@@ -359,6 +375,50 @@ func (t *defaultModelBuildTask) buildTargetGroupAttributes(_ context.Context, sv
 	if _, err := t.annotationParser.ParseStringMapAnnotation(annotations.IngressSuffixTargetGroupAttributes, &rawAttributes, svcAndIngAnnotations); err != nil {
 		return nil, err
 	}
+	if rawAttributes == nil {
+		rawAttributes = make(map[string]string)
+	}
+	rawCrossZone := ""
+	if exists := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixTargetGroupCrossZone, &rawCrossZone, svcAndIngAnnotations); exists {
+		if err := elbv2model.ValidateTargetGroupCrossZone(rawCrossZone, rawAttributes); err != nil {
+			return nil, err
+		}
+		rawAttributes[elbv2model.TargetGroupAttributeKeyLoadBalancingCrossZoneEnabled] = rawCrossZone
+	}
+	rawAnomalyMitigation := ""
+	if exists := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixTargetGroupAnomalyMitigation, &rawAnomalyMitigation, svcAndIngAnnotations); exists {
+		if err := applyTargetGroupAnomalyMitigation(rawAnomalyMitigation, rawAttributes); err != nil {
+			return nil, err
+		}
+	}
+	rawSlowStart := ""
+	if exists := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixTargetGroupSlowStart, &rawSlowStart, svcAndIngAnnotations); exists {
+		if err := validateTargetGroupSlowStart(rawSlowStart, rawAttributes); err != nil {
+			return nil, err
+		}
+		rawAttributes[elbv2model.TargetGroupAttributeKeySlowStartDurationSeconds] = rawSlowStart
+	}
+	rawAppCookieName := ""
+	rawAppCookieDuration := ""
+	appCookieNameExists := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixTargetGroupStickinessAppCookieName, &rawAppCookieName, svcAndIngAnnotations)
+	appCookieDurationExists := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixTargetGroupStickinessAppCookieDurationSeconds, &rawAppCookieDuration, svcAndIngAnnotations)
+	if appCookieNameExists {
+		if err := applyTargetGroupAppCookieStickiness(rawAppCookieName, rawAppCookieDuration, rawAttributes); err != nil {
+			return nil, err
+		}
+	} else if appCookieDurationExists {
+		return nil, errors.Errorf("target group app cookie duration requires annotation %v", annotations.IngressSuffixTargetGroupStickinessAppCookieName)
+	}
+	for annotation, attrKey := range targetGroupHealthAttributeKeyByAnnotation {
+		rawValue := ""
+		if exists := t.annotationParser.ParseStringAnnotation(annotation, &rawValue, svcAndIngAnnotations); !exists {
+			continue
+		}
+		if err := elbv2model.ValidateTargetGroupHealthRequirement(attrKey, rawValue, rawAttributes); err != nil {
+			return nil, err
+		}
+		rawAttributes[attrKey] = rawValue
+	}
 	attributes := make([]elbv2model.TargetGroupAttribute, 0, len(rawAttributes))
 	for attrKey, attrValue := range rawAttributes {
 		attributes = append(attributes, elbv2model.TargetGroupAttribute{
@@ -369,12 +429,86 @@ func (t *defaultModelBuildTask) buildTargetGroupAttributes(_ context.Context, sv
 	return attributes, nil
 }
 
+// applyTargetGroupAnomalyMitigation applies the anomaly mitigation setting into target group attributes,
+// the weighted_random load balancing algorithm is configured as well since it's required by anomaly mitigation.
+func applyTargetGroupAnomalyMitigation(rawAnomalyMitigation string, rawAttributes map[string]string) error {
+	switch rawAnomalyMitigation {
+	case elbv2model.TargetGroupAnomalyMitigationOn, elbv2model.TargetGroupAnomalyMitigationOff:
+	default:
+		return errors.Errorf("invalid target group anomaly mitigation %v, must be one of on or off", rawAnomalyMitigation)
+	}
+	if rawAttrValue, ok := rawAttributes[elbv2model.TargetGroupAttributeKeyLoadBalancingAlgorithmAnomalyMitigation]; ok && rawAttrValue != rawAnomalyMitigation {
+		return errors.Errorf("conflicting target group anomaly mitigation: %v, %v", rawAnomalyMitigation, rawAttrValue)
+	}
+	rawAttributes[elbv2model.TargetGroupAttributeKeyLoadBalancingAlgorithmAnomalyMitigation] = rawAnomalyMitigation
+	if rawAnomalyMitigation != elbv2model.TargetGroupAnomalyMitigationOn {
+		return nil
+	}
+	if rawAlgorithm, ok := rawAttributes[elbv2model.TargetGroupAttributeKeyLoadBalancingAlgorithmType]; ok && rawAlgorithm != elbv2model.TargetGroupLoadBalancingAlgorithmTypeWeightedRandom {
+		return errors.Errorf("target group anomaly mitigation requires weighted_random load balancing algorithm, got %v", rawAlgorithm)
+	}
+	rawAttributes[elbv2model.TargetGroupAttributeKeyLoadBalancingAlgorithmType] = elbv2model.TargetGroupLoadBalancingAlgorithmTypeWeightedRandom
+	return nil
+}
+
+// validateTargetGroupSlowStart validates the slow start duration for target group against explicitly specified target group attributes.
+// the duration must be between 30 and 900 seconds or 0 to turn it off, and slow start isn't supported by the weighted_random load balancing algorithm.
+func validateTargetGroupSlowStart(rawSlowStart string, rawAttributes map[string]string) error {
+	durationSeconds, err := strconv.ParseInt(rawSlowStart, 10, 64)
+	if err != nil || (durationSeconds != 0 && (durationSeconds < 30 || durationSeconds > 900)) {
+		return errors.Errorf("invalid target group slow start duration %v, must be 0 or between 30 and 900 seconds", rawSlowStart)
+	}
+	if rawAttrValue, ok := rawAttributes[elbv2model.TargetGroupAttributeKeySlowStartDurationSeconds]; ok && rawAttrValue != rawSlowStart {
+		return errors.Errorf("conflicting target group slow start duration: %v, %v", rawSlowStart, rawAttrValue)
+	}
+	if durationSeconds != 0 && rawAttributes[elbv2model.TargetGroupAttributeKeyLoadBalancingAlgorithmType] == elbv2model.TargetGroupLoadBalancingAlgorithmTypeWeightedRandom {
+		return errors.Errorf("target group slow start isn't supported by weighted_random load balancing algorithm")
+	}
+	return nil
+}
+
+// applyTargetGroupAppCookieStickiness applies app_cookie stickiness into target group attributes,
+// the cookie duration is left to ELBv2 default unless rawDurationSeconds is specified.
+func applyTargetGroupAppCookieStickiness(rawCookieName string, rawDurationSeconds string, rawAttributes map[string]string) error {
+	if err := elbv2model.ValidateTargetGroupAppCookieName(rawCookieName); err != nil {
+		return err
+	}
+	stickinessAttrs := map[string]string{
+		elbv2model.TargetGroupAttributeKeyStickinessEnabled:             "true",
+		elbv2model.TargetGroupAttributeKeyStickinessType:                elbv2model.TargetGroupStickinessTypeAppCookie,
+		elbv2model.TargetGroupAttributeKeyStickinessAppCookieCookieName: rawCookieName,
+	}
+	if rawDurationSeconds != "" {
+		if err := elbv2model.ValidateTargetGroupAppCookieDuration(rawDurationSeconds); err != nil {
+			return err
+		}
+		stickinessAttrs[elbv2model.TargetGroupAttributeKeyStickinessAppCookieDurationSeconds] = rawDurationSeconds
+	}
+	for _, attrKey := range sets.StringKeySet(stickinessAttrs).List() {
+		if rawAttrValue, ok := rawAttributes[attrKey]; ok && rawAttrValue != stickinessAttrs[attrKey] {
+			return errors.Errorf("conflicting target group stickiness %v: %v, %v", attrKey, stickinessAttrs[attrKey], rawAttrValue)
+		}
+	}
+	for attrKey, attrValue := range stickinessAttrs {
+		rawAttributes[attrKey] = attrValue
+	}
+	return nil
+}
+
+// targetGroupHealthAttributeKeyByAnnotation maps annotations of target group health requirements to target group attributes.
+var targetGroupHealthAttributeKeyByAnnotation = map[string]string{
+	annotations.IngressSuffixTargetGroupHealthDNSFailoverMinHealthyTargetsCount:                elbv2model.TargetGroupAttributeKeyDNSFailoverMinimumHealthyTargetsCount,
+	annotations.IngressSuffixTargetGroupHealthDNSFailoverMinHealthyTargetsPercentage:           elbv2model.TargetGroupAttributeKeyDNSFailoverMinimumHealthyTargetsPercentage,
+	annotations.IngressSuffixTargetGroupHealthUnhealthyStateRoutingMinHealthyTargetsCount:      elbv2model.TargetGroupAttributeKeyUnhealthyStateRoutingMinimumHealthyTargetsCount,
+	annotations.IngressSuffixTargetGroupHealthUnhealthyStateRoutingMinHealthyTargetsPercentage: elbv2model.TargetGroupAttributeKeyUnhealthyStateRoutingMinimumHealthyTargetsPercentage,
+}
+
 func (t *defaultModelBuildTask) buildTargetGroupTags(_ context.Context, svcAndIngAnnotations map[string]string) (map[string]string, error) {
 	var rawTags map[string]string
 	if _, err := t.annotationParser.ParseStringMapAnnotation(annotations.IngressSuffixTags, &rawTags, svcAndIngAnnotations); err != nil {
 		return nil, err
 	}
-	return rawTags, nil
+	return t.mergeDefaultTags(rawTags), nil
 }
 
 func (t *defaultModelBuildTask) buildTargetGroupResourceID(ingKey types.NamespacedName, svcKey types.NamespacedName, port intstr.IntOrString) string {
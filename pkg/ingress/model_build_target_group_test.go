@@ -2,11 +2,14 @@ package ingress
 
 import (
 	"context"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"testing"
 )
@@ -18,6 +21,7 @@ func Test_defaultModelBuildTask_buildTargetGroupName(t *testing.T) {
 		port              intstr.IntOrString
 		tgPort            int64
 		targetType        elbv2model.TargetType
+		ipAddressType     elbv2model.TargetGroupIPAddressType
 		tgProtocol        elbv2model.Protocol
 		tgProtocolVersion elbv2model.ProtocolVersion
 	}
@@ -40,6 +44,7 @@ func Test_defaultModelBuildTask_buildTargetGroupName(t *testing.T) {
 				port:              intstr.FromString("http"),
 				tgPort:            8080,
 				targetType:        elbv2model.TargetTypeIP,
+				ipAddressType:     elbv2model.TargetGroupIPAddressTypeIPv4,
 				tgProtocol:        elbv2model.ProtocolHTTP,
 				tgProtocolVersion: elbv2model.ProtocolVersionHTTP1,
 			},
@@ -59,6 +64,7 @@ func Test_defaultModelBuildTask_buildTargetGroupName(t *testing.T) {
 				port:              intstr.FromInt(80),
 				tgPort:            8080,
 				targetType:        elbv2model.TargetTypeIP,
+				ipAddressType:     elbv2model.TargetGroupIPAddressTypeIPv4,
 				tgProtocol:        elbv2model.ProtocolHTTP,
 				tgProtocolVersion: elbv2model.ProtocolVersionHTTP1,
 			},
@@ -78,6 +84,7 @@ func Test_defaultModelBuildTask_buildTargetGroupName(t *testing.T) {
 				port:              intstr.FromString("http"),
 				tgPort:            9090,
 				targetType:        elbv2model.TargetTypeIP,
+				ipAddressType:     elbv2model.TargetGroupIPAddressTypeIPv4,
 				tgProtocol:        elbv2model.ProtocolHTTP,
 				tgProtocolVersion: elbv2model.ProtocolVersionHTTP1,
 			},
@@ -97,6 +104,7 @@ func Test_defaultModelBuildTask_buildTargetGroupName(t *testing.T) {
 				port:              intstr.FromString("http"),
 				tgPort:            8080,
 				targetType:        elbv2model.TargetTypeInstance,
+				ipAddressType:     elbv2model.TargetGroupIPAddressTypeIPv4,
 				tgProtocol:        elbv2model.ProtocolHTTP,
 				tgProtocolVersion: elbv2model.ProtocolVersionHTTP1,
 			},
@@ -116,21 +124,131 @@ func Test_defaultModelBuildTask_buildTargetGroupName(t *testing.T) {
 				port:              intstr.FromString("http"),
 				tgPort:            8080,
 				targetType:        elbv2model.TargetTypeIP,
+				ipAddressType:     elbv2model.TargetGroupIPAddressTypeIPv4,
 				tgProtocol:        elbv2model.ProtocolHTTPS,
 				tgProtocolVersion: elbv2model.ProtocolVersionHTTP1,
 			},
 			want: "k8s-ns1-name1-22fbce26a7",
 		},
+		{
+			name: "standard case - ipAddressType differs",
+			args: args{
+				ingKey: types.NamespacedName{Namespace: "ns-1", Name: "name-1"},
+				svc: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns-1",
+						Name:      "name-1",
+						UID:       "my-uuid",
+					},
+				},
+				port:              intstr.FromString("http"),
+				tgPort:            8080,
+				targetType:        elbv2model.TargetTypeIP,
+				ipAddressType:     elbv2model.TargetGroupIPAddressTypeIPv6,
+				tgProtocol:        elbv2model.ProtocolHTTP,
+				tgProtocolVersion: elbv2model.ProtocolVersionHTTP1,
+			},
+			want: "k8s-ns1-name1-9b215f9039",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			task := &defaultModelBuildTask{}
-			got := task.buildTargetGroupName(context.Background(), tt.args.ingKey, tt.args.svc, tt.args.port, tt.args.tgPort, tt.args.targetType, tt.args.tgProtocol, tt.args.tgProtocolVersion)
+			got := task.buildTargetGroupName(context.Background(), tt.args.ingKey, tt.args.svc, tt.args.port, tt.args.tgPort, tt.args.targetType, tt.args.ipAddressType, tt.args.tgProtocol, tt.args.tgProtocolVersion)
 			assert.Equal(t, tt.want, got)
 		})
 	}
 }
 
+func Test_defaultModelBuildTask_buildTargetGroupIPAddressType(t *testing.T) {
+	ipv6Family := corev1.IPv6Protocol
+	ipv4Family := corev1.IPv4Protocol
+	type args struct {
+		lbIPAddressType elbv2model.IPAddressType
+		svcIPFamily     *corev1.IPFamily
+		targetType      elbv2model.TargetType
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    elbv2model.TargetGroupIPAddressType
+		wantErr error
+	}{
+		{
+			name: "ip targetType with IPv4 service",
+			args: args{
+				lbIPAddressType: elbv2model.IPAddressTypeDualStack,
+				svcIPFamily:     &ipv4Family,
+				targetType:      elbv2model.TargetTypeIP,
+			},
+			want: elbv2model.TargetGroupIPAddressTypeIPv4,
+		},
+		{
+			name: "ip targetType with service without IPFamily",
+			args: args{
+				lbIPAddressType: elbv2model.IPAddressTypeDualStack,
+				svcIPFamily:     nil,
+				targetType:      elbv2model.TargetTypeIP,
+			},
+			want: elbv2model.TargetGroupIPAddressTypeIPv4,
+		},
+		{
+			name: "ip targetType with IPv6 service and dualstack LoadBalancer",
+			args: args{
+				lbIPAddressType: elbv2model.IPAddressTypeDualStack,
+				svcIPFamily:     &ipv6Family,
+				targetType:      elbv2model.TargetTypeIP,
+			},
+			want: elbv2model.TargetGroupIPAddressTypeIPv6,
+		},
+		{
+			name: "ip targetType with IPv6 service and ipv4 LoadBalancer",
+			args: args{
+				lbIPAddressType: elbv2model.IPAddressTypeIPV4,
+				svcIPFamily:     &ipv6Family,
+				targetType:      elbv2model.TargetTypeIP,
+			},
+			wantErr: errors.New("unsupported IPv6 configuration for service ns-1/name-1, dualstack LoadBalancer is required"),
+		},
+		{
+			name: "instance targetType with IPv6 service",
+			args: args{
+				lbIPAddressType: elbv2model.IPAddressTypeDualStack,
+				svcIPFamily:     &ipv6Family,
+				targetType:      elbv2model.TargetTypeInstance,
+			},
+			want: elbv2model.TargetGroupIPAddressTypeIPv4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stack := core.NewDefaultStack(core.StackID{Name: "awesome-stack"})
+			lb := elbv2model.NewLoadBalancer(stack, "LoadBalancer", elbv2model.LoadBalancerSpec{
+				IPAddressType: &tt.args.lbIPAddressType,
+			})
+			task := &defaultModelBuildTask{
+				loadBalancer: lb,
+			}
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns-1",
+					Name:      "name-1",
+				},
+				Spec: corev1.ServiceSpec{
+					IPFamily: tt.args.svcIPFamily,
+				},
+			}
+			got, err := task.buildTargetGroupIPAddressType(context.Background(), svc, tt.args.targetType)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
 func Test_defaultModelBuildTask_buildTargetGroupPort(t *testing.T) {
 	type args struct {
 		targetType elbv2model.TargetType
@@ -189,3 +307,303 @@ func Test_defaultModelBuildTask_buildTargetGroupPort(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultModelBuildTask_buildTargetGroupAttributes(t *testing.T) {
+	tests := []struct {
+		name                 string
+		svcAndIngAnnotations map[string]string
+		want                 []elbv2model.TargetGroupAttribute
+		wantErr              error
+	}{
+		{
+			name:                 "no attributes",
+			svcAndIngAnnotations: map[string]string{},
+			want:                 []elbv2model.TargetGroupAttribute{},
+		},
+		{
+			name: "target group attributes",
+			svcAndIngAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-group-attributes": "deregistration_delay.timeout_seconds=30",
+			},
+			want: []elbv2model.TargetGroupAttribute{
+				{
+					Key:   "deregistration_delay.timeout_seconds",
+					Value: "30",
+				},
+			},
+		},
+		{
+			name: "target group cross-zone load balancing",
+			svcAndIngAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-group-cross-zone-load-balancing": "use_load_balancer_configuration",
+			},
+			want: []elbv2model.TargetGroupAttribute{
+				{
+					Key:   "load_balancing.cross_zone.enabled",
+					Value: "use_load_balancer_configuration",
+				},
+			},
+		},
+		{
+			name: "target group cross-zone load balancing matches target group attributes",
+			svcAndIngAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-group-attributes":                "load_balancing.cross_zone.enabled=false",
+				"alb.ingress.kubernetes.io/target-group-cross-zone-load-balancing": "false",
+			},
+			want: []elbv2model.TargetGroupAttribute{
+				{
+					Key:   "load_balancing.cross_zone.enabled",
+					Value: "false",
+				},
+			},
+		},
+		{
+			name: "target group cross-zone load balancing conflicts with target group attributes",
+			svcAndIngAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-group-attributes":                "load_balancing.cross_zone.enabled=true",
+				"alb.ingress.kubernetes.io/target-group-cross-zone-load-balancing": "false",
+			},
+			wantErr: errors.New("conflicting target group cross-zone load balancing: false, true"),
+		},
+		{
+			name: "invalid target group cross-zone load balancing",
+			svcAndIngAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-group-cross-zone-load-balancing": "enabled",
+			},
+			wantErr: errors.New("invalid target group cross-zone load balancing enabled, must be one of true, false or use_load_balancer_configuration"),
+		},
+		{
+			name: "target group anomaly mitigation",
+			svcAndIngAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-group-anomaly-mitigation": "on",
+			},
+			want: []elbv2model.TargetGroupAttribute{
+				{
+					Key:   "load_balancing.algorithm.anomaly_mitigation",
+					Value: "on",
+				},
+				{
+					Key:   "load_balancing.algorithm.type",
+					Value: "weighted_random",
+				},
+			},
+		},
+		{
+			name: "target group anomaly mitigation conflicts with load balancing algorithm",
+			svcAndIngAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-group-attributes":         "load_balancing.algorithm.type=round_robin",
+				"alb.ingress.kubernetes.io/target-group-anomaly-mitigation": "on",
+			},
+			wantErr: errors.New("target group anomaly mitigation requires weighted_random load balancing algorithm, got round_robin"),
+		},
+		{
+			name: "invalid target group anomaly mitigation",
+			svcAndIngAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-group-anomaly-mitigation": "true",
+			},
+			wantErr: errors.New("invalid target group anomaly mitigation true, must be one of on or off"),
+		},
+		{
+			name: "target group slow start",
+			svcAndIngAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-group-slow-start-duration-seconds": "60",
+			},
+			want: []elbv2model.TargetGroupAttribute{
+				{
+					Key:   "slow_start.duration_seconds",
+					Value: "60",
+				},
+			},
+		},
+		{
+			name: "target group slow start turned off",
+			svcAndIngAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-group-attributes":                  "load_balancing.algorithm.type=weighted_random",
+				"alb.ingress.kubernetes.io/target-group-slow-start-duration-seconds": "0",
+			},
+			want: []elbv2model.TargetGroupAttribute{
+				{
+					Key:   "load_balancing.algorithm.type",
+					Value: "weighted_random",
+				},
+				{
+					Key:   "slow_start.duration_seconds",
+					Value: "0",
+				},
+			},
+		},
+		{
+			name: "target group slow start conflicts with target group attributes",
+			svcAndIngAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-group-attributes":                  "slow_start.duration_seconds=30",
+				"alb.ingress.kubernetes.io/target-group-slow-start-duration-seconds": "60",
+			},
+			wantErr: errors.New("conflicting target group slow start duration: 60, 30"),
+		},
+		{
+			name: "target group slow start with weighted_random load balancing algorithm",
+			svcAndIngAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-group-anomaly-mitigation":          "on",
+				"alb.ingress.kubernetes.io/target-group-slow-start-duration-seconds": "60",
+			},
+			wantErr: errors.New("target group slow start isn't supported by weighted_random load balancing algorithm"),
+		},
+		{
+			name: "target group slow start out of range",
+			svcAndIngAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-group-slow-start-duration-seconds": "10",
+			},
+			wantErr: errors.New("invalid target group slow start duration 10, must be 0 or between 30 and 900 seconds"),
+		},
+		{
+			name: "target group health requirements",
+			svcAndIngAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-group-health.dns-failover.minimum-healthy-targets.count":                 "off",
+				"alb.ingress.kubernetes.io/target-group-health.dns-failover.minimum-healthy-targets.percentage":            "50",
+				"alb.ingress.kubernetes.io/target-group-health.unhealthy-state-routing.minimum-healthy-targets.count":      "2",
+				"alb.ingress.kubernetes.io/target-group-health.unhealthy-state-routing.minimum-healthy-targets.percentage": "off",
+			},
+			want: []elbv2model.TargetGroupAttribute{
+				{
+					Key:   "target_group_health.dns_failover.minimum_healthy_targets.count",
+					Value: "off",
+				},
+				{
+					Key:   "target_group_health.dns_failover.minimum_healthy_targets.percentage",
+					Value: "50",
+				},
+				{
+					Key:   "target_group_health.unhealthy_state_routing.minimum_healthy_targets.count",
+					Value: "2",
+				},
+				{
+					Key:   "target_group_health.unhealthy_state_routing.minimum_healthy_targets.percentage",
+					Value: "off",
+				},
+			},
+		},
+		{
+			name: "target group health requirement conflicts with target group attributes",
+			svcAndIngAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-group-attributes":                                        "target_group_health.dns_failover.minimum_healthy_targets.count=2",
+				"alb.ingress.kubernetes.io/target-group-health.dns-failover.minimum-healthy-targets.count": "3",
+			},
+			wantErr: errors.New("conflicting target group health requirement target_group_health.dns_failover.minimum_healthy_targets.count: 3, 2"),
+		},
+		{
+			name: "unhealthy state routing count cannot be turned off",
+			svcAndIngAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-group-health.unhealthy-state-routing.minimum-healthy-targets.count": "off",
+			},
+			wantErr: errors.New("invalid target group health requirement target_group_health.unhealthy_state_routing.minimum_healthy_targets.count: off"),
+		},
+		{
+			name: "target group health percentage out of range",
+			svcAndIngAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-group-health.dns-failover.minimum-healthy-targets.percentage": "101",
+			},
+			wantErr: errors.New("invalid target group health requirement target_group_health.dns_failover.minimum_healthy_targets.percentage: 101"),
+		},
+		{
+			name: "target group app cookie stickiness",
+			svcAndIngAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-group-stickiness.app-cookie.name":             "SESSIONID",
+				"alb.ingress.kubernetes.io/target-group-stickiness.app-cookie.duration-seconds": "3600",
+			},
+			want: []elbv2model.TargetGroupAttribute{
+				{
+					Key:   "stickiness.enabled",
+					Value: "true",
+				},
+				{
+					Key:   "stickiness.type",
+					Value: "app_cookie",
+				},
+				{
+					Key:   "stickiness.app_cookie.cookie_name",
+					Value: "SESSIONID",
+				},
+				{
+					Key:   "stickiness.app_cookie.duration_seconds",
+					Value: "3600",
+				},
+			},
+		},
+		{
+			name: "target group app cookie stickiness matches target group attributes",
+			svcAndIngAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-group-attributes":                 "stickiness.enabled=true,stickiness.app_cookie.duration_seconds=600",
+				"alb.ingress.kubernetes.io/target-group-stickiness.app-cookie.name": "SESSIONID",
+			},
+			want: []elbv2model.TargetGroupAttribute{
+				{
+					Key:   "stickiness.enabled",
+					Value: "true",
+				},
+				{
+					Key:   "stickiness.type",
+					Value: "app_cookie",
+				},
+				{
+					Key:   "stickiness.app_cookie.cookie_name",
+					Value: "SESSIONID",
+				},
+				{
+					Key:   "stickiness.app_cookie.duration_seconds",
+					Value: "600",
+				},
+			},
+		},
+		{
+			name: "target group app cookie stickiness conflicts with target group attributes",
+			svcAndIngAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-group-attributes":                 "stickiness.enabled=true,stickiness.type=lb_cookie",
+				"alb.ingress.kubernetes.io/target-group-stickiness.app-cookie.name": "SESSIONID",
+			},
+			wantErr: errors.New("conflicting target group stickiness stickiness.type: app_cookie, lb_cookie"),
+		},
+		{
+			name: "target group app cookie name with reserved prefix",
+			svcAndIngAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-group-stickiness.app-cookie.name": "AWSALBAPP-0",
+			},
+			wantErr: errors.New("invalid target group app cookie name AWSALBAPP-0, prefix AWSALB is reserved"),
+		},
+		{
+			name: "invalid target group app cookie name",
+			svcAndIngAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-group-stickiness.app-cookie.name": "session id",
+			},
+			wantErr: errors.New("invalid target group app cookie name session id"),
+		},
+		{
+			name: "target group app cookie duration out of range",
+			svcAndIngAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-group-stickiness.app-cookie.name":             "SESSIONID",
+				"alb.ingress.kubernetes.io/target-group-stickiness.app-cookie.duration-seconds": "604801",
+			},
+			wantErr: errors.New("invalid target group app cookie duration 604801, must be between 1 and 604800 seconds"),
+		},
+		{
+			name: "target group app cookie duration without cookie name",
+			svcAndIngAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-group-stickiness.app-cookie.duration-seconds": "3600",
+			},
+			wantErr: errors.New("target group app cookie duration requires annotation target-group-stickiness.app-cookie.name"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+			}
+			got, err := task.buildTargetGroupAttributes(context.Background(), tt.svcAndIngAnnotations)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.ElementsMatch(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package ingress
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	networking "k8s.io/api/networking/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"time"
+)
+
+// WeightRampConfig defines how the weights of newly added TargetGroups within forward actions of multiple TargetGroups
+// are increased in stages, until they reach the weights configured in the forward action.
+type WeightRampConfig struct {
+	// The percentage of configured weight that new TargetGroups start with.
+	InitialWeightPercent int64 `json:"initialWeightPercent"`
+
+	// The percentage of configured weight that is added at each step.
+	StepWeightPercent int64 `json:"stepWeightPercent"`
+
+	// The interval in seconds between steps.
+	StepIntervalSeconds int64 `json:"stepIntervalSeconds"`
+}
+
+func (c *WeightRampConfig) validate() error {
+	// new TargetGroups must receive traffic from the start, since a zero weight would leave requests routed to them without targets.
+	if c.InitialWeightPercent < 1 || c.InitialWeightPercent > 100 {
+		return errors.Errorf("initialWeightPercent must be between 1 and 100, got %v", c.InitialWeightPercent)
+	}
+	if c.StepWeightPercent < 1 || c.StepWeightPercent > 100 {
+		return errors.Errorf("stepWeightPercent must be between 1 and 100, got %v", c.StepWeightPercent)
+	}
+	if c.StepIntervalSeconds < 1 {
+		return errors.Errorf("stepIntervalSeconds must be at least 1, got %v", c.StepIntervalSeconds)
+	}
+	return nil
+}
+
+// stepInterval returns the interval between steps.
+func (c *WeightRampConfig) stepInterval() time.Duration {
+	return time.Duration(c.StepIntervalSeconds) * time.Second
+}
+
+// rampDuration returns the duration for new TargetGroups to reach the configured weight.
+func (c *WeightRampConfig) rampDuration() time.Duration {
+	steps := (100 - c.InitialWeightPercent + c.StepWeightPercent - 1) / c.StepWeightPercent
+	return time.Duration(steps) * c.stepInterval()
+}
+
+// weightPercentAfter returns the percentage of configured weight for TargetGroups that were added elapsed ago.
+func (c *WeightRampConfig) weightPercentAfter(elapsed time.Duration) int64 {
+	percent := c.InitialWeightPercent + int64(elapsed/c.stepInterval())*c.StepWeightPercent
+	if percent > 100 {
+		return 100
+	}
+	return percent
+}
+
+// parseWeightRampConfig parses the weight ramp config from Ingress annotations, nil is returned if weight ramp isn't enabled.
+func parseWeightRampConfig(annotationParser annotations.Parser, ing *networking.Ingress) (*WeightRampConfig, error) {
+	rampCfg := WeightRampConfig{}
+	exists, err := annotationParser.ParseJSONAnnotation(annotations.IngressSuffixTargetGroupWeightRamp, &rampCfg, ing.Annotations)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+	if err := rampCfg.validate(); err != nil {
+		return nil, errors.Wrapf(err, "invalid target group weight ramp of ingress: %v", k8s.NamespacedName(ing))
+	}
+	return &rampCfg, nil
+}
+
+// buildRampedTargetGroupWeight builds the weight of TargetGroup for backend service under weight ramp.
+// TargetGroups are considered added once their TargetGroupBinding is created, so they start with the initial weight
+// if the TargetGroupBinding doesn't exist yet.
+func (t *defaultModelBuildTask) buildRampedTargetGroupWeight(ctx context.Context, rampCfg WeightRampConfig,
+	tgbKey types.NamespacedName, weight *int64) (*int64, error) {
+	if awssdk.Int64Value(weight) <= 0 {
+		return weight, nil
+	}
+	elapsed, err := buildTargetGroupBindingAge(ctx, t.k8sClient, tgbKey)
+	if err != nil {
+		return nil, err
+	}
+	percent := rampCfg.weightPercentAfter(elapsed)
+	return awssdk.Int64((*weight*percent + 99) / 100), nil
+}
+
+// BuildWeightRampRequeueAfter returns the duration after which the weights of TargetGroups within IngressGroup
+// ramp to the next step, zero is returned if no TargetGroup of IngressGroup is ramping.
+func BuildWeightRampRequeueAfter(ctx context.Context, k8sClient client.Client, annotationParser annotations.Parser,
+	ingGroup Group, stack core.Stack) (time.Duration, error) {
+	var resTGBs []*elbv2model.TargetGroupBindingResource
+	if err := stack.ListResources(&resTGBs); err != nil {
+		return 0, err
+	}
+	requeueAfter := time.Duration(0)
+	for _, ing := range ingGroup.Members {
+		rampCfg, err := parseWeightRampConfig(annotationParser, ing)
+		if err != nil {
+			return 0, err
+		}
+		if rampCfg == nil {
+			continue
+		}
+		for _, resTGB := range resTGBs {
+			if resTGB.Spec.Template.Namespace != ing.Namespace {
+				continue
+			}
+			tgbKey := types.NamespacedName{Namespace: resTGB.Spec.Template.Namespace, Name: resTGB.Spec.Template.Name}
+			elapsed, err := buildTargetGroupBindingAge(ctx, k8sClient, tgbKey)
+			if err != nil {
+				return 0, err
+			}
+			if elapsed >= rampCfg.rampDuration() {
+				continue
+			}
+			nextStepAfter := rampCfg.stepInterval() - elapsed%rampCfg.stepInterval()
+			if requeueAfter == 0 || nextStepAfter < requeueAfter {
+				requeueAfter = nextStepAfter
+			}
+		}
+	}
+	return requeueAfter, nil
+}
+
+// buildTargetGroupBindingAge returns the time elapsed since TargetGroupBinding was created, it's zero if the TargetGroupBinding doesn't exist yet.
+func buildTargetGroupBindingAge(ctx context.Context, k8sClient client.Client, tgbKey types.NamespacedName) (time.Duration, error) {
+	tgb := &elbv2api.TargetGroupBinding{}
+	if err := k8sClient.Get(ctx, tgbKey, tgb); err != nil {
+		if apierrors.IsNotFound(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	if age := time.Since(tgb.CreationTimestamp.Time); age > 0 {
+		return age, nil
+	}
+	return 0, nil
+}
@@ -0,0 +1,289 @@
+package ingress
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	networking "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+	"time"
+)
+
+func Test_WeightRampConfig_weightPercentAfter(t *testing.T) {
+	rampCfg := WeightRampConfig{
+		InitialWeightPercent: 10,
+		StepWeightPercent:    30,
+		StepIntervalSeconds:  60,
+	}
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		want    int64
+	}{
+		{
+			name:    "just added",
+			elapsed: 0,
+			want:    10,
+		},
+		{
+			name:    "within first step",
+			elapsed: 59 * time.Second,
+			want:    10,
+		},
+		{
+			name:    "after first step",
+			elapsed: 60 * time.Second,
+			want:    40,
+		},
+		{
+			name:    "after third step",
+			elapsed: 185 * time.Second,
+			want:    100,
+		},
+		{
+			name:    "long after ramp",
+			elapsed: time.Hour,
+			want:    100,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, rampCfg.weightPercentAfter(tt.elapsed))
+		})
+	}
+	assert.Equal(t, 180*time.Second, rampCfg.rampDuration())
+}
+
+func Test_parseWeightRampConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		ing     *networking.Ingress
+		want    *WeightRampConfig
+		wantErr error
+	}{
+		{
+			name: "weight ramp not enabled",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "ing-1",
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "weight ramp enabled",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "ing-1",
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/target-group-weight-ramp": `{"initialWeightPercent":10,"stepWeightPercent":30,"stepIntervalSeconds":60}`,
+					},
+				},
+			},
+			want: &WeightRampConfig{
+				InitialWeightPercent: 10,
+				StepWeightPercent:    30,
+				StepIntervalSeconds:  60,
+			},
+		},
+		{
+			name: "invalid step weight percent",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "ing-1",
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/target-group-weight-ramp": `{"initialWeightPercent":10,"stepWeightPercent":0,"stepIntervalSeconds":60}`,
+					},
+				},
+			},
+			wantErr: errors.New("invalid target group weight ramp of ingress: awesome-ns/ing-1: stepWeightPercent must be between 1 and 100, got 0"),
+		},
+		{
+			name: "zero initial weight percent",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "ing-1",
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/target-group-weight-ramp": `{"initialWeightPercent":0,"stepWeightPercent":30,"stepIntervalSeconds":60}`,
+					},
+				},
+			},
+			wantErr: errors.New("invalid target group weight ramp of ingress: awesome-ns/ing-1: initialWeightPercent must be between 1 and 100, got 0"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseWeightRampConfig(annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"), tt.ing)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildRampedTargetGroupWeight(t *testing.T) {
+	rampCfg := WeightRampConfig{
+		InitialWeightPercent: 10,
+		StepWeightPercent:    30,
+		StepIntervalSeconds:  60,
+	}
+	tests := []struct {
+		name   string
+		tgbAge *time.Duration
+		weight *int64
+		want   *int64
+	}{
+		{
+			name:   "TargetGroupBinding not created yet",
+			weight: awssdk.Int64(50),
+			want:   awssdk.Int64(5),
+		},
+		{
+			name:   "TargetGroupBinding ramping",
+			tgbAge: durationPtr(61 * time.Second),
+			weight: awssdk.Int64(50),
+			want:   awssdk.Int64(20),
+		},
+		{
+			name:   "TargetGroupBinding ramped",
+			tgbAge: durationPtr(time.Hour),
+			weight: awssdk.Int64(50),
+			want:   awssdk.Int64(50),
+		},
+		{
+			name:   "weight is rounded up",
+			weight: awssdk.Int64(1),
+			want:   awssdk.Int64(1),
+		},
+		{
+			name:   "zero weight isn't ramped",
+			weight: awssdk.Int64(0),
+			want:   awssdk.Int64(0),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			tgbKey := types.NamespacedName{Namespace: "awesome-ns", Name: "k8s-awesomen-svc1-1234567890"}
+			if tt.tgbAge != nil {
+				assert.NoError(t, k8sClient.Create(context.Background(), &elbv2api.TargetGroupBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:         tgbKey.Namespace,
+						Name:              tgbKey.Name,
+						CreationTimestamp: metav1.NewTime(time.Now().Add(-*tt.tgbAge)),
+					},
+				}))
+			}
+			task := &defaultModelBuildTask{
+				k8sClient: k8sClient,
+			}
+			got, err := task.buildRampedTargetGroupWeight(context.Background(), rampCfg, tgbKey, tt.weight)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_BuildWeightRampRequeueAfter(t *testing.T) {
+	rampAnnotations := map[string]string{
+		"alb.ingress.kubernetes.io/target-group-weight-ramp": `{"initialWeightPercent":10,"stepWeightPercent":30,"stepIntervalSeconds":60}`,
+	}
+	tests := []struct {
+		name           string
+		ingAnnotations map[string]string
+		tgbAge         time.Duration
+		wantMin        time.Duration
+		wantMax        time.Duration
+		wantNoRequeue  bool
+	}{
+		{
+			name:           "weight ramp not enabled",
+			ingAnnotations: nil,
+			tgbAge:         30 * time.Second,
+			wantNoRequeue:  true,
+		},
+		{
+			name:           "TargetGroupBinding ramping",
+			ingAnnotations: rampAnnotations,
+			tgbAge:         90 * time.Second,
+			wantMin:        25 * time.Second,
+			wantMax:        30 * time.Second,
+		},
+		{
+			name:           "TargetGroupBinding ramped",
+			ingAnnotations: rampAnnotations,
+			tgbAge:         time.Hour,
+			wantNoRequeue:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			assert.NoError(t, k8sClient.Create(context.Background(), &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:         "awesome-ns",
+					Name:              "k8s-awesomen-svc1-1234567890",
+					CreationTimestamp: metav1.NewTime(time.Now().Add(-tt.tgbAge)),
+				},
+			}))
+			stack := core.NewDefaultStack(core.StackID{Namespace: "awesome-ns", Name: "ing-1"})
+			_ = elbv2model.NewTargetGroupBindingResource(stack, "tg-1", elbv2model.TargetGroupBindingResourceSpec{
+				Template: elbv2model.TargetGroupBindingTemplate{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "k8s-awesomen-svc1-1234567890",
+					},
+					Spec: elbv2model.TargetGroupBindingSpec{
+						TargetGroupARN: core.LiteralStringToken("arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/k8s-awesomen-svc1-1234567890/1234567890abcdef"),
+					},
+				},
+			})
+			ingGroup := Group{
+				Members: []*networking.Ingress{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace:   "awesome-ns",
+							Name:        "ing-1",
+							Annotations: tt.ingAnnotations,
+						},
+					},
+				},
+			}
+			got, err := BuildWeightRampRequeueAfter(context.Background(), k8sClient,
+				annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"), ingGroup, stack)
+			assert.NoError(t, err)
+			if tt.wantNoRequeue {
+				assert.Equal(t, time.Duration(0), got)
+			} else {
+				assert.True(t, got >= tt.wantMin && got <= tt.wantMax, "unexpected requeue after %v", got)
+			}
+		})
+	}
+}
+
+func durationPtr(d time.Duration) *time.Duration {
+	return &d
+}
@@ -10,12 +10,17 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/quota"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	networkingpkg "sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/securitygrouppolicy"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/tracing"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -31,73 +36,141 @@ type ModelBuilder interface {
 
 // NewDefaultModelBuilder constructs new defaultModelBuilder.
 func NewDefaultModelBuilder(k8sClient client.Client, eventRecorder record.EventRecorder,
-	ec2Client services.EC2, acmClient services.ACM,
-	annotationParser annotations.Parser, subnetsResolver networkingpkg.SubnetsResolver,
+	acmClient services.ACM, annotationParser annotations.Parser,
+	subnetsResolver networkingpkg.SubnetsResolver, sgResolver networkingpkg.SecurityGroupResolver,
 	authConfigBuilder AuthConfigBuilder, enhancedBackendBuilder EnhancedBackendBuilder,
-	vpcID string, clusterName string, logger logr.Logger) *defaultModelBuilder {
+	quotaProvider quota.Provider, dynamicConfigProvider config.DynamicConfigProvider, vpcID string, clusterName string, iamRoleARNToAssume string,
+	defaultTargetType elbv2model.TargetType, logger logr.Logger) *defaultModelBuilder {
 	certDiscovery := NewACMCertDiscovery(acmClient, logger)
 	ruleOptimizer := NewDefaultRuleOptimizer(logger)
+	classParamsLoader := NewDefaultClassParamsLoader(k8sClient)
+	targetTypeResolver := NewDefaultTargetTypeResolver(annotationParser, classParamsLoader,
+		k8s.NewDefaultFargateDetector(k8sClient), defaultTargetType)
+	sgPolicyPermissionBuilder := securitygrouppolicy.NewDefaultPermissionBuilder(k8sClient, eventRecorder)
 	return &defaultModelBuilder{
-		k8sClient:              k8sClient,
-		eventRecorder:          eventRecorder,
-		ec2Client:              ec2Client,
-		vpcID:                  vpcID,
-		clusterName:            clusterName,
-		annotationParser:       annotationParser,
-		subnetsResolver:        subnetsResolver,
-		certDiscovery:          certDiscovery,
-		authConfigBuilder:      authConfigBuilder,
-		enhancedBackendBuilder: enhancedBackendBuilder,
-		ruleOptimizer:          ruleOptimizer,
-		logger:                 logger,
+		k8sClient:                 k8sClient,
+		eventRecorder:             eventRecorder,
+		vpcID:                     vpcID,
+		clusterName:               clusterName,
+		iamRoleARNToAssume:        iamRoleARNToAssume,
+		annotationParser:          annotationParser,
+		subnetsResolver:           subnetsResolver,
+		sgResolver:                sgResolver,
+		certDiscovery:             certDiscovery,
+		authConfigBuilder:         authConfigBuilder,
+		enhancedBackendBuilder:    enhancedBackendBuilder,
+		ruleOptimizer:             ruleOptimizer,
+		classParamsLoader:         classParamsLoader,
+		targetTypeResolver:        targetTypeResolver,
+		sgPolicyPermissionBuilder: sgPolicyPermissionBuilder,
+		quotaProvider:             quotaProvider,
+		dynamicConfigProvider:     dynamicConfigProvider,
+		logger:                    logger,
 	}
 }
 
+// ModelBuilderConfig contains the configuration of ModelBuilder constructed by NewModelBuilder.
+type ModelBuilderConfig struct {
+	// ClusterName is the name of kubernetes cluster, it's used to tag and discover AWS resources.
+	ClusterName string
+	// DefaultTargetType is the target type of TargetGroups when not specified by annotation or IngressClassParams, defaults to instance.
+	DefaultTargetType elbv2model.TargetType
+	// DynamicConfig contains the settings that are dynamically configurable for this controller.
+	DynamicConfig config.DynamicConfig
+}
+
+// NewModelBuilder constructs a ModelBuilder for operators that build model stacks from IngressGroups programmatically.
+// The returned ModelBuilder builds stacks from Ingress annotations exactly the way this controller does,
+// and the stacks can be deployed with the StackDeployer from deploy.NewStackDeployer.
+func NewModelBuilder(cloud aws.Cloud, k8sClient client.Client, eventRecorder record.EventRecorder, cfg ModelBuilderConfig, logger logr.Logger) (ModelBuilder, error) {
+	if cfg.ClusterName == "" {
+		return nil, errors.New("clusterName must be specified")
+	}
+	defaultTargetType := cfg.DefaultTargetType
+	if defaultTargetType == "" {
+		defaultTargetType = elbv2model.TargetTypeInstance
+	}
+	annotationParser := annotations.NewSuffixAnnotationParser(annotations.AnnotationPrefixIngress)
+	authConfigBuilder := NewDefaultAuthConfigBuilder(annotationParser)
+	enhancedBackendBuilder := NewDefaultEnhancedBackendBuilder(annotationParser)
+	subnetsResolver := networkingpkg.NewDefaultSubnetsResolver(cloud.EC2(), cloud.VpcID(), cfg.ClusterName, logger)
+	sgResolver := networkingpkg.NewDefaultSecurityGroupResolver(cloud.EC2(), cloud.VpcID(), logger)
+	quotaProvider := quota.NewDefaultProvider(cloud.ServiceQuotas(), logger)
+	dynamicConfigProvider := config.NewStaticDynamicConfigProvider(cfg.DynamicConfig)
+	return NewDefaultModelBuilder(k8sClient, eventRecorder, cloud.ACM(), annotationParser,
+		subnetsResolver, sgResolver, authConfigBuilder, enhancedBackendBuilder, quotaProvider, dynamicConfigProvider,
+		cloud.VpcID(), cfg.ClusterName, "", defaultTargetType, logger), nil
+}
+
 var _ ModelBuilder = &defaultModelBuilder{}
 
 // default implementation for ModelBuilder
 type defaultModelBuilder struct {
 	k8sClient     client.Client
 	eventRecorder record.EventRecorder
-	ec2Client     services.EC2
 
 	vpcID       string
 	clusterName string
+	// the IAM role assumed to provision AWS resources, empty if controller's own IAM role is used.
+	iamRoleARNToAssume string
 
-	annotationParser       annotations.Parser
-	subnetsResolver        networkingpkg.SubnetsResolver
-	certDiscovery          CertDiscovery
-	authConfigBuilder      AuthConfigBuilder
-	enhancedBackendBuilder EnhancedBackendBuilder
-	ruleOptimizer          RuleOptimizer
+	annotationParser          annotations.Parser
+	subnetsResolver           networkingpkg.SubnetsResolver
+	sgResolver                networkingpkg.SecurityGroupResolver
+	certDiscovery             CertDiscovery
+	authConfigBuilder         AuthConfigBuilder
+	enhancedBackendBuilder    EnhancedBackendBuilder
+	ruleOptimizer             RuleOptimizer
+	classParamsLoader         ClassParamsLoader
+	targetTypeResolver        TargetTypeResolver
+	sgPolicyPermissionBuilder securitygrouppolicy.PermissionBuilder
+	quotaProvider             quota.Provider
+	dynamicConfigProvider     config.DynamicConfigProvider
 
 	logger logr.Logger
 }
 
 // build mode stack for a IngressGroup.
 func (b *defaultModelBuilder) Build(ctx context.Context, ingGroup Group) (core.Stack, *elbv2model.LoadBalancer, error) {
+	ctx, span := tracing.StartSpan(ctx, "BuildIngressGroupModel", tracing.AttributeStackID.String(ingGroup.ID.String()))
+	stack, lb, err := b.build(ctx, ingGroup)
+	tracing.EndSpan(span, err)
+	return stack, lb, err
+}
+
+func (b *defaultModelBuilder) build(ctx context.Context, ingGroup Group) (core.Stack, *elbv2model.LoadBalancer, error) {
 	stack := core.NewDefaultStack(core.StackID(ingGroup.ID))
+	// the dynamic config is snapshotted per build, so that the stack is built consistently while it's reloaded.
+	dynamicConfig := b.dynamicConfigProvider.Get()
+	defaultSSLPolicy := "ELBSecurityPolicy-2016-08"
+	if dynamicConfig.DefaultSSLPolicy != "" {
+		defaultSSLPolicy = dynamicConfig.DefaultSSLPolicy
+	}
 	task := &defaultModelBuildTask{
-		k8sClient:              b.k8sClient,
-		eventRecorder:          b.eventRecorder,
-		ec2Client:              b.ec2Client,
-		vpcID:                  b.vpcID,
-		clusterName:            b.clusterName,
-		annotationParser:       b.annotationParser,
-		subnetsResolver:        b.subnetsResolver,
-		certDiscovery:          b.certDiscovery,
-		authConfigBuilder:      b.authConfigBuilder,
-		enhancedBackendBuilder: b.enhancedBackendBuilder,
-		ruleOptimizer:          b.ruleOptimizer,
-		logger:                 b.logger,
+		k8sClient:                 b.k8sClient,
+		eventRecorder:             b.eventRecorder,
+		vpcID:                     b.vpcID,
+		clusterName:               b.clusterName,
+		iamRoleARNToAssume:        b.iamRoleARNToAssume,
+		annotationParser:          b.annotationParser,
+		subnetsResolver:           b.subnetsResolver,
+		sgResolver:                b.sgResolver,
+		certDiscovery:             b.certDiscovery,
+		authConfigBuilder:         b.authConfigBuilder,
+		enhancedBackendBuilder:    b.enhancedBackendBuilder,
+		ruleOptimizer:             b.ruleOptimizer,
+		classParamsLoader:         b.classParamsLoader,
+		targetTypeResolver:        b.targetTypeResolver,
+		sgPolicyPermissionBuilder: b.sgPolicyPermissionBuilder,
+		quotaProvider:             b.quotaProvider,
+		logger:                    b.logger,
 
 		ingGroup: ingGroup,
 		stack:    stack,
 
 		defaultIPAddressType:                      elbv2model.IPAddressTypeIPV4,
 		defaultScheme:                             elbv2model.LoadBalancerSchemeInternal,
-		defaultSSLPolicy:                          "ELBSecurityPolicy-2016-08",
-		defaultTargetType:                         elbv2model.TargetTypeInstance,
+		defaultSSLPolicy:                          defaultSSLPolicy,
 		defaultBackendProtocol:                    elbv2model.ProtocolHTTP,
 		defaultBackendProtocolVersion:             elbv2model.ProtocolVersionHTTP1,
 		defaultHealthCheckPath:                    "/",
@@ -106,6 +179,9 @@ func (b *defaultModelBuilder) Build(ctx context.Context, ingGroup Group) (core.S
 		defaultHealthCheckHealthyThresholdCount:   2,
 		defaultHealthCheckUnhealthyThresholdCount: 2,
 		defaultHealthCheckMatcherHTTPCode:         "200",
+		defaultTags:                               dynamicConfig.DefaultTags,
+		defaultLoadBalancerAttributes:             dynamicConfig.DefaultALBAttributes,
+		featureGates:                              dynamicConfig.FeatureGates,
 
 		loadBalancer: nil,
 		tgByResID:    make(map[string]*elbv2model.TargetGroup),
@@ -118,18 +194,23 @@ func (b *defaultModelBuilder) Build(ctx context.Context, ingGroup Group) (core.S
 
 // the default model build task
 type defaultModelBuildTask struct {
-	k8sClient              client.Client
-	eventRecorder          record.EventRecorder
-	ec2Client              services.EC2
-	vpcID                  string
-	clusterName            string
-	annotationParser       annotations.Parser
-	subnetsResolver        networkingpkg.SubnetsResolver
-	certDiscovery          CertDiscovery
-	authConfigBuilder      AuthConfigBuilder
-	enhancedBackendBuilder EnhancedBackendBuilder
-	ruleOptimizer          RuleOptimizer
-	logger                 logr.Logger
+	k8sClient                 client.Client
+	eventRecorder             record.EventRecorder
+	vpcID                     string
+	clusterName               string
+	iamRoleARNToAssume        string
+	annotationParser          annotations.Parser
+	subnetsResolver           networkingpkg.SubnetsResolver
+	sgResolver                networkingpkg.SecurityGroupResolver
+	certDiscovery             CertDiscovery
+	authConfigBuilder         AuthConfigBuilder
+	enhancedBackendBuilder    EnhancedBackendBuilder
+	ruleOptimizer             RuleOptimizer
+	classParamsLoader         ClassParamsLoader
+	targetTypeResolver        TargetTypeResolver
+	sgPolicyPermissionBuilder securitygrouppolicy.PermissionBuilder
+	quotaProvider             quota.Provider
+	logger                    logr.Logger
 
 	ingGroup Group
 	stack    core.Stack
@@ -137,7 +218,6 @@ type defaultModelBuildTask struct {
 	defaultIPAddressType                      elbv2model.IPAddressType
 	defaultScheme                             elbv2model.LoadBalancerScheme
 	defaultSSLPolicy                          string
-	defaultTargetType                         elbv2model.TargetType
 	defaultBackendProtocol                    elbv2model.Protocol
 	defaultBackendProtocolVersion             elbv2model.ProtocolVersion
 	defaultHealthCheckPath                    string
@@ -146,6 +226,9 @@ type defaultModelBuildTask struct {
 	defaultHealthCheckHealthyThresholdCount   int64
 	defaultHealthCheckUnhealthyThresholdCount int64
 	defaultHealthCheckMatcherHTTPCode         string
+	defaultTags                               map[string]string
+	defaultLoadBalancerAttributes             map[string]string
+	featureGates                              config.FeatureGates
 
 	loadBalancer *elbv2model.LoadBalancer
 	managedSG    *ec2model.SecurityGroup
@@ -154,9 +237,23 @@ type defaultModelBuildTask struct {
 
 func (t *defaultModelBuildTask) run(ctx context.Context) error {
 	if len(t.ingGroup.Members) == 0 {
+		// listeners on existing LoadBalancer must be cleaned up explicitly, since the LoadBalancer outlives IngressGroup.
+		existingLBARN, err := t.buildExistingLoadBalancerARN(ctx)
+		if err != nil {
+			return err
+		}
+		if existingLBARN != nil {
+			t.buildExistingLoadBalancer(ctx, *existingLBARN)
+		}
 		return nil
 	}
 
+	ingClassFeatureGates, err := t.buildIngressClassFeatureGates(ctx)
+	if err != nil {
+		return err
+	}
+	t.featureGates = t.featureGates.Merge(ingClassFeatureGates)
+
 	ingListByPort := make(map[int64][]*networking.Ingress)
 	listenPortConfigsByPort := make(map[int64]map[types.NamespacedName]listenPortConfig)
 	for _, ing := range t.ingGroup.Members {
@@ -182,10 +279,19 @@ func (t *defaultModelBuildTask) run(ctx context.Context) error {
 		listenPortConfigByPort[port] = mergedCfg
 	}
 
-	lb, err := t.buildLoadBalancer(ctx, listenPortConfigByPort)
+	existingLBARN, err := t.buildExistingLoadBalancerARN(ctx)
 	if err != nil {
 		return err
 	}
+	var lb *elbv2model.LoadBalancer
+	if existingLBARN != nil {
+		lb = t.buildExistingLoadBalancer(ctx, *existingLBARN)
+	} else {
+		lb, err = t.buildLoadBalancer(ctx, listenPortConfigByPort)
+		if err != nil {
+			return err
+		}
+	}
 	for port, cfg := range listenPortConfigByPort {
 		ingList := ingListByPort[port]
 		ls, err := t.buildListener(ctx, lb.LoadBalancerARN(), port, cfg, ingList)
@@ -197,12 +303,44 @@ func (t *defaultModelBuildTask) run(ctx context.Context) error {
 		}
 	}
 
-	if err := t.buildLoadBalancerAddOns(ctx, lb.LoadBalancerARN()); err != nil {
+	if _, err := t.buildRoute53RecordSets(ctx, lb); err != nil {
 		return err
 	}
+
+	// add-ons of existing LoadBalancer are owned by the LoadBalancer owner.
+	if existingLBARN == nil {
+		if err := t.buildLoadBalancerAddOns(ctx, lb.LoadBalancerARN()); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// buildIngressClassFeatureGates builds the feature gates overridden by IngressClassParams of Ingresses within IngressGroup.
+func (t *defaultModelBuildTask) buildIngressClassFeatureGates(ctx context.Context) (config.FeatureGates, error) {
+	mergedGates := make(config.FeatureGates)
+	for _, ing := range t.ingGroup.Members {
+		ingClassParams, err := t.classParamsLoader.Load(ctx, ing)
+		if err != nil {
+			return nil, err
+		}
+		if ingClassParams == nil {
+			continue
+		}
+		gates, err := config.BuildIngressClassFeatureGates(ingClassParams.Spec.FeatureGates)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid featureGates in IngressClassParams %v", ingClassParams.Name)
+		}
+		for feature, enabled := range gates {
+			if existingEnabled, exists := mergedGates[feature]; exists && existingEnabled != enabled {
+				return nil, errors.Errorf("conflicting feature gate %v from IngressClassParams: %v | %v", feature, existingEnabled, enabled)
+			}
+			mergedGates[feature] = enabled
+		}
+	}
+	return mergedGates, nil
+}
+
 func (t *defaultModelBuildTask) mergeListenPortConfigs(_ context.Context, listenPortConfigByIngress map[types.NamespacedName]listenPortConfig) (listenPortConfig, error) {
 	var mergedProtocolProvider *types.NamespacedName
 	var mergedProtocol elbv2model.Protocol
@@ -214,8 +352,14 @@ func (t *defaultModelBuildTask) mergeListenPortConfigs(_ context.Context, listen
 	var mergedSSLPolicyProvider *types.NamespacedName
 	var mergedSSLPolicy *string
 
+	var mergedDefaultTLSCertProvider *types.NamespacedName
+	var mergedDefaultTLSCert *string
+
 	mergedTLSCerts := sets.NewString()
 
+	mergedListenerAttributesProvider := make(map[string]types.NamespacedName)
+	var mergedListenerAttributes map[string]string
+
 	for ingKey, cfg := range listenPortConfigByIngress {
 		if mergedProtocolProvider == nil {
 			mergedProtocolProvider = &ingKey
@@ -249,22 +393,59 @@ func (t *defaultModelBuildTask) mergeListenPortConfigs(_ context.Context, listen
 					*mergedSSLPolicyProvider, awssdk.StringValue(mergedSSLPolicy), ingKey, awssdk.StringValue(cfg.sslPolicy))
 			}
 		}
+		if cfg.defaultTLSCert != nil {
+			if mergedDefaultTLSCertProvider == nil {
+				mergedDefaultTLSCertProvider = &ingKey
+				mergedDefaultTLSCert = cfg.defaultTLSCert
+			} else if awssdk.StringValue(mergedDefaultTLSCert) != awssdk.StringValue(cfg.defaultTLSCert) {
+				return listenPortConfig{}, errors.Errorf("conflicting default certificate, %v: %v | %v: %v",
+					*mergedDefaultTLSCertProvider, awssdk.StringValue(mergedDefaultTLSCert), ingKey, awssdk.StringValue(cfg.defaultTLSCert))
+			}
+		}
 		mergedTLSCerts.Insert(cfg.tlsCerts...)
-	}
 
-	if len(mergedInboundCIDRv4s) == 0 && len(mergedInboundCIDRv6s) == 0 {
-		mergedInboundCIDRv4s.Insert("0.0.0.0/0")
-		mergedInboundCIDRv6s.Insert("::/0")
+		for attrKey, attrValue := range cfg.listenerAttributes {
+			if provider, exists := mergedListenerAttributesProvider[attrKey]; !exists {
+				if mergedListenerAttributes == nil {
+					mergedListenerAttributes = make(map[string]string)
+				}
+				mergedListenerAttributesProvider[attrKey] = ingKey
+				mergedListenerAttributes[attrKey] = attrValue
+			} else if mergedListenerAttributes[attrKey] != attrValue {
+				return listenPortConfig{}, errors.Errorf("conflicting listener attribute %v, %v: %v | %v: %v",
+					attrKey, provider, mergedListenerAttributes[attrKey], ingKey, attrValue)
+			}
+		}
 	}
+
 	if mergedProtocol == elbv2model.ProtocolHTTPS && mergedSSLPolicy == nil {
 		mergedSSLPolicy = awssdk.String(t.defaultSSLPolicy)
 	}
 
 	return listenPortConfig{
-		protocol:       mergedProtocol,
-		inboundCIDRv4s: mergedInboundCIDRv4s.List(),
-		inboundCIDRv6s: mergedInboundCIDRv6s.List(),
-		sslPolicy:      mergedSSLPolicy,
-		tlsCerts:       mergedTLSCerts.List(),
+		protocol:           mergedProtocol,
+		inboundCIDRv4s:     mergedInboundCIDRv4s.List(),
+		inboundCIDRv6s:     mergedInboundCIDRv6s.List(),
+		sslPolicy:          mergedSSLPolicy,
+		tlsCerts:           buildOrderedTLSCerts(mergedTLSCerts, mergedDefaultTLSCert),
+		defaultTLSCert:     mergedDefaultTLSCert,
+		listenerAttributes: mergedListenerAttributes,
 	}, nil
 }
+
+// buildOrderedTLSCerts builds the TLS certificates list where the first certificate will be used as default certificate.
+// if defaultTLSCert is specified, it will be placed first, otherwise the certificates are ordered lexicographically.
+func buildOrderedTLSCerts(tlsCerts sets.String, defaultTLSCert *string) []string {
+	if defaultTLSCert == nil {
+		return tlsCerts.List()
+	}
+	defaultCertARN := awssdk.StringValue(defaultTLSCert)
+	orderedTLSCerts := make([]string, 0, tlsCerts.Len()+1)
+	orderedTLSCerts = append(orderedTLSCerts, defaultCertARN)
+	for _, certARN := range tlsCerts.List() {
+		if certARN != defaultCertARN {
+			orderedTLSCerts = append(orderedTLSCerts, certARN)
+		}
+	}
+	return orderedTLSCerts
+}
@@ -5,19 +5,26 @@ import (
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
-	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	mock_ingress "sigs.k8s.io/aws-load-balancer-controller/mocks/ingress"
 	mock_networking "sigs.k8s.io/aws-load-balancer-controller/mocks/networking"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/quota"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/securitygrouppolicy"
 	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
@@ -294,12 +301,12 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
                     ]
                 }
             },
-            "80:2":{
+            "80:11":{
                 "spec":{
                     "listenerARN":{
                         "$ref":"#/resources/AWS::ElasticLoadBalancingV2::Listener/80/status/listenerARN"
                     },
-                    "priority":2,
+                    "priority":11,
                     "actions":[
                         {
                             "type":"forward",
@@ -334,12 +341,12 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
                     ]
                 }
             },
-            "80:3":{
+            "80:21":{
                 "spec":{
                     "listenerARN":{
                         "$ref":"#/resources/AWS::ElasticLoadBalancingV2::Listener/80/status/listenerARN"
                     },
-                    "priority":3,
+                    "priority":21,
                     "actions":[
                         {
                             "type":"forward",
@@ -403,6 +410,7 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
                 "spec":{
                     "name":"k8s-ns1-svc1-9889425938",
                     "targetType":"instance",
+                    "ipAddressType":"ipv4",
                     "port":32768,
                     "protocol":"HTTP",
 					"protocolVersion":"HTTP1",
@@ -424,6 +432,7 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
                 "spec":{
                     "name":"k8s-ns1-svc2-9889425938",
                     "targetType":"instance",
+                    "ipAddressType":"ipv4",
                     "port":32768,
                     "protocol":"HTTP",
 					"protocolVersion":"HTTP1",
@@ -445,6 +454,7 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
                 "spec":{
                     "name":"k8s-ns1-svc3-bf42870fba",
                     "targetType":"ip",
+                    "ipAddressType":"ipv4",
                     "port":8443,
                     "protocol":"HTTPS",
 					"protocolVersion":"HTTP1",
@@ -477,6 +487,7 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
                                 "$ref":"#/resources/AWS::ElasticLoadBalancingV2::TargetGroup/ns-1/ing-1-svc-1:http/status/targetGroupARN"
                             },
                             "targetType":"instance",
+                            "ipAddressType":"ipv4",
                             "serviceRef":{
                                 "name":"svc-1",
                                 "port":"http"
@@ -518,6 +529,7 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
                                 "$ref":"#/resources/AWS::ElasticLoadBalancingV2::TargetGroup/ns-1/ing-1-svc-2:http/status/targetGroupARN"
                             },
                             "targetType":"instance",
+                            "ipAddressType":"ipv4",
                             "serviceRef":{
                                 "name":"svc-2",
                                 "port":"http"
@@ -559,6 +571,7 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
                                 "$ref":"#/resources/AWS::ElasticLoadBalancingV2::TargetGroup/ns-1/ing-1-svc-3:https/status/targetGroupARN"
                             },
                             "targetType":"ip",
+                            "ipAddressType":"ipv4",
                             "serviceRef":{
                                 "name":"svc-3",
                                 "port":"https"
@@ -743,12 +756,12 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
                     ]
                 }
             },
-            "80:2":{
+            "80:11":{
                 "spec":{
                     "listenerARN":{
                         "$ref":"#/resources/AWS::ElasticLoadBalancingV2::Listener/80/status/listenerARN"
                     },
-                    "priority":2,
+                    "priority":11,
                     "actions":[
                         {
                             "type":"forward",
@@ -783,12 +796,12 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
                     ]
                 }
             },
-            "80:3":{
+            "80:21":{
                 "spec":{
                     "listenerARN":{
                         "$ref":"#/resources/AWS::ElasticLoadBalancingV2::Listener/80/status/listenerARN"
                     },
-                    "priority":3,
+                    "priority":21,
                     "actions":[
                         {
                             "type":"forward",
@@ -852,6 +865,7 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
                 "spec":{
                     "name":"k8s-ns1-svc1-9889425938",
                     "targetType":"instance",
+                    "ipAddressType":"ipv4",
                     "port":32768,
                     "protocol":"HTTP",
 					"protocolVersion":"HTTP1",
@@ -873,6 +887,7 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
                 "spec":{
                     "name":"k8s-ns1-svc2-9889425938",
                     "targetType":"instance",
+                    "ipAddressType":"ipv4",
                     "port":32768,
                     "protocol":"HTTP",
 					"protocolVersion": "HTTP1",
@@ -894,6 +909,7 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
                 "spec":{
                     "name":"k8s-ns1-svc3-bf42870fba",
                     "targetType":"ip",
+                    "ipAddressType":"ipv4",
                     "port":8443,
                     "protocol":"HTTPS",
 					"protocolVersion": "HTTP1",
@@ -926,6 +942,7 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
                                 "$ref":"#/resources/AWS::ElasticLoadBalancingV2::TargetGroup/ns-1/ing-1-svc-1:http/status/targetGroupARN"
                             },
                             "targetType":"instance",
+                            "ipAddressType":"ipv4",
                             "serviceRef":{
                                 "name":"svc-1",
                                 "port":"http"
@@ -967,6 +984,7 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
                                 "$ref":"#/resources/AWS::ElasticLoadBalancingV2::TargetGroup/ns-1/ing-1-svc-2:http/status/targetGroupARN"
                             },
                             "targetType":"instance",
+                            "ipAddressType":"ipv4",
                             "serviceRef":{
                                 "name":"svc-2",
                                 "port":"http"
@@ -1008,6 +1026,7 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
                                 "$ref":"#/resources/AWS::ElasticLoadBalancingV2::TargetGroup/ns-1/ing-1-svc-3:https/status/targetGroupARN"
                             },
                             "targetType":"ip",
+                            "ipAddressType":"ipv4",
                             "serviceRef":{
                                 "name":"svc-3",
                                 "port":"https"
@@ -1173,12 +1192,12 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
                     ]
                 }
             },
-            "80:2":{
+            "80:11":{
                 "spec":{
                     "listenerARN":{
                         "$ref":"#/resources/AWS::ElasticLoadBalancingV2::Listener/80/status/listenerARN"
                     },
-                    "priority":2,
+                    "priority":11,
                     "actions":[
                         {
                             "type":"forward",
@@ -1242,6 +1261,7 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
                 "spec":{
                     "name":"k8s-ns1-svc1-90b7d93b18",
                     "targetType":"instance",
+                    "ipAddressType":"ipv4",
                     "port":32768,
                     "protocol":"HTTP",
 					"protocolVersion": "HTTP1",
@@ -1263,6 +1283,7 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
                 "spec":{
                     "name":"k8s-ns1-svc1-9889425938",
                     "targetType":"instance",
+                    "ipAddressType":"ipv4",
                     "port":32768,
                     "protocol":"HTTP",
 					"protocolVersion": "HTTP1",
@@ -1295,6 +1316,7 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
                                 "$ref":"#/resources/AWS::ElasticLoadBalancingV2::TargetGroup/ns-1/ing-1-svc-1:80/status/targetGroupARN"
                             },
                             "targetType":"instance",
+                            "ipAddressType":"ipv4",
                             "serviceRef":{
                                 "name":"svc-1",
                                 "port":80
@@ -1336,6 +1358,7 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
                                 "$ref":"#/resources/AWS::ElasticLoadBalancingV2::TargetGroup/ns-1/ing-1-svc-1:http/status/targetGroupARN"
                             },
                             "targetType":"instance",
+                            "ipAddressType":"ipv4",
                             "serviceRef":{
                                 "name":"svc-1",
                                 "port":"http"
@@ -1377,6 +1400,7 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
 			ctx := context.Background()
 			k8sSchema := runtime.NewScheme()
 			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
 			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
 			for _, svc := range tt.env.svcs {
 				assert.NoError(t, k8sClient.Create(ctx, svc.DeepCopy()))
@@ -1384,33 +1408,41 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
 			eventRecorder := record.NewFakeRecorder(10)
 			vpcID := "vpc-dummy"
 			clusterName := "cluster-dummy"
-			ec2Client := mock_services.NewMockEC2(ctrl)
 			subnetsResolver := mock_networking.NewMockSubnetsResolver(ctrl)
 			for _, call := range tt.fields.resolveViaDiscoveryCalls {
 				subnetsResolver.EXPECT().ResolveViaDiscovery(gomock.Any(), gomock.Any()).Return(call.subnets, call.err)
 			}
 
+			sgResolver := mock_networking.NewMockSecurityGroupResolver(ctrl)
 			certDiscovery := mock_ingress.NewMockCertDiscovery(ctrl)
 			annotationParser := annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
 			authConfigBuilder := NewDefaultAuthConfigBuilder(annotationParser)
 			enhancedBackendBuilder := NewDefaultEnhancedBackendBuilder(annotationParser)
 			ruleOptimizer := NewDefaultRuleOptimizer(&log.NullLogger{})
+			classParamsLoader := NewDefaultClassParamsLoader(k8sClient)
+			targetTypeResolver := NewDefaultTargetTypeResolver(annotationParser, classParamsLoader,
+				k8s.NewDefaultFargateDetector(k8sClient), elbv2model.TargetTypeInstance)
 
 			stackMarshaller := deploy.NewDefaultStackMarshaller()
 
 			b := &defaultModelBuilder{
-				k8sClient:              k8sClient,
-				eventRecorder:          eventRecorder,
-				ec2Client:              ec2Client,
-				vpcID:                  vpcID,
-				clusterName:            clusterName,
-				annotationParser:       annotationParser,
-				subnetsResolver:        subnetsResolver,
-				certDiscovery:          certDiscovery,
-				authConfigBuilder:      authConfigBuilder,
-				enhancedBackendBuilder: enhancedBackendBuilder,
-				ruleOptimizer:          ruleOptimizer,
-				logger:                 &log.NullLogger{},
+				k8sClient:                 k8sClient,
+				eventRecorder:             eventRecorder,
+				vpcID:                     vpcID,
+				clusterName:               clusterName,
+				annotationParser:          annotationParser,
+				subnetsResolver:           subnetsResolver,
+				sgResolver:                sgResolver,
+				certDiscovery:             certDiscovery,
+				authConfigBuilder:         authConfigBuilder,
+				enhancedBackendBuilder:    enhancedBackendBuilder,
+				ruleOptimizer:             ruleOptimizer,
+				classParamsLoader:         classParamsLoader,
+				targetTypeResolver:        targetTypeResolver,
+				sgPolicyPermissionBuilder: securitygrouppolicy.NewDefaultPermissionBuilder(k8sClient, record.NewFakeRecorder(10)),
+				quotaProvider:             quota.NewStaticProvider(nil),
+				dynamicConfigProvider:     config.NewStaticDynamicConfigProvider(config.DynamicConfig{}),
+				logger:                    &log.NullLogger{},
 			}
 
 			gotStack, _, err := b.Build(context.Background(), tt.args.ingGroup)
@@ -1425,3 +1457,292 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultModelBuildTask_mergeListenPortConfigs(t *testing.T) {
+	type args struct {
+		listenPortConfigByIngress map[types.NamespacedName]listenPortConfig
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    listenPortConfig
+		wantErr error
+	}{
+		{
+			name: "merge tlsCerts without explicit default certificate",
+			args: args{
+				listenPortConfigByIngress: map[types.NamespacedName]listenPortConfig{
+					types.NamespacedName{Namespace: "awesome-ns", Name: "ing-1"}: {
+						protocol: elbv2model.ProtocolHTTPS,
+						tlsCerts: []string{"arn-2", "arn-1"},
+					},
+					types.NamespacedName{Namespace: "awesome-ns", Name: "ing-2"}: {
+						protocol: elbv2model.ProtocolHTTPS,
+						tlsCerts: []string{"arn-3"},
+					},
+				},
+			},
+			want: listenPortConfig{
+				protocol:       elbv2model.ProtocolHTTPS,
+				inboundCIDRv4s: []string{},
+				inboundCIDRv6s: []string{},
+				sslPolicy:      awssdk.String("ELBSecurityPolicy-2016-08"),
+				tlsCerts:       []string{"arn-1", "arn-2", "arn-3"},
+			},
+		},
+		{
+			name: "merge tlsCerts with explicit default certificate",
+			args: args{
+				listenPortConfigByIngress: map[types.NamespacedName]listenPortConfig{
+					types.NamespacedName{Namespace: "awesome-ns", Name: "ing-1"}: {
+						protocol:       elbv2model.ProtocolHTTPS,
+						tlsCerts:       []string{"arn-1", "arn-2"},
+						defaultTLSCert: awssdk.String("arn-2"),
+					},
+					types.NamespacedName{Namespace: "awesome-ns", Name: "ing-2"}: {
+						protocol: elbv2model.ProtocolHTTPS,
+						tlsCerts: []string{"arn-3"},
+					},
+				},
+			},
+			want: listenPortConfig{
+				protocol:       elbv2model.ProtocolHTTPS,
+				inboundCIDRv4s: []string{},
+				inboundCIDRv6s: []string{},
+				sslPolicy:      awssdk.String("ELBSecurityPolicy-2016-08"),
+				tlsCerts:       []string{"arn-2", "arn-1", "arn-3"},
+				defaultTLSCert: awssdk.String("arn-2"),
+			},
+		},
+		{
+			name: "explicit default certificate not in tlsCerts",
+			args: args{
+				listenPortConfigByIngress: map[types.NamespacedName]listenPortConfig{
+					types.NamespacedName{Namespace: "awesome-ns", Name: "ing-1"}: {
+						protocol:       elbv2model.ProtocolHTTPS,
+						tlsCerts:       []string{"arn-1"},
+						defaultTLSCert: awssdk.String("arn-2"),
+					},
+				},
+			},
+			want: listenPortConfig{
+				protocol:       elbv2model.ProtocolHTTPS,
+				inboundCIDRv4s: []string{},
+				inboundCIDRv6s: []string{},
+				sslPolicy:      awssdk.String("ELBSecurityPolicy-2016-08"),
+				tlsCerts:       []string{"arn-2", "arn-1"},
+				defaultTLSCert: awssdk.String("arn-2"),
+			},
+		},
+		{
+			name: "conflicting explicit default certificate",
+			args: args{
+				listenPortConfigByIngress: map[types.NamespacedName]listenPortConfig{
+					types.NamespacedName{Namespace: "awesome-ns", Name: "ing-1"}: {
+						protocol:       elbv2model.ProtocolHTTPS,
+						tlsCerts:       []string{"arn-1"},
+						defaultTLSCert: awssdk.String("arn-1"),
+					},
+					types.NamespacedName{Namespace: "awesome-ns", Name: "ing-2"}: {
+						protocol:       elbv2model.ProtocolHTTPS,
+						tlsCerts:       []string{"arn-2"},
+						defaultTLSCert: awssdk.String("arn-2"),
+					},
+				},
+			},
+			wantErr: errors.New("conflicting default certificate"),
+		},
+		{
+			name: "merge listener attributes",
+			args: args{
+				listenPortConfigByIngress: map[types.NamespacedName]listenPortConfig{
+					types.NamespacedName{Namespace: "awesome-ns", Name: "ing-1"}: {
+						protocol: elbv2model.ProtocolHTTP,
+						listenerAttributes: map[string]string{
+							"routing.http.response.server.enabled": "false",
+						},
+					},
+					types.NamespacedName{Namespace: "awesome-ns", Name: "ing-2"}: {
+						protocol: elbv2model.ProtocolHTTP,
+						listenerAttributes: map[string]string{
+							"routing.http.response.server.enabled":               "false",
+							"routing.http.response.x_frame_options.header_value": "DENY",
+						},
+					},
+				},
+			},
+			want: listenPortConfig{
+				protocol:       elbv2model.ProtocolHTTP,
+				inboundCIDRv4s: []string{},
+				inboundCIDRv6s: []string{},
+				tlsCerts:       []string{},
+				listenerAttributes: map[string]string{
+					"routing.http.response.server.enabled":               "false",
+					"routing.http.response.x_frame_options.header_value": "DENY",
+				},
+			},
+		},
+		{
+			name: "conflicting listener attributes",
+			args: args{
+				listenPortConfigByIngress: map[types.NamespacedName]listenPortConfig{
+					types.NamespacedName{Namespace: "awesome-ns", Name: "ing-1"}: {
+						protocol: elbv2model.ProtocolHTTP,
+						listenerAttributes: map[string]string{
+							"routing.http.response.x_frame_options.header_value": "DENY",
+						},
+					},
+					types.NamespacedName{Namespace: "awesome-ns", Name: "ing-2"}: {
+						protocol: elbv2model.ProtocolHTTP,
+						listenerAttributes: map[string]string{
+							"routing.http.response.x_frame_options.header_value": "SAMEORIGIN",
+						},
+					},
+				},
+			},
+			wantErr: errors.New("conflicting listener attribute routing.http.response.x_frame_options.header_value"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				defaultSSLPolicy: "ELBSecurityPolicy-2016-08",
+			}
+			got, err := task.mergeListenPortConfigs(context.Background(), tt.args.listenPortConfigByIngress)
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildIngressClassFeatureGates(t *testing.T) {
+	buildIngClass := func(name string, paramsName string) *networking.IngressClass {
+		return &networking.IngressClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: networking.IngressClassSpec{
+				Controller: ingressClassControllerALB,
+				Parameters: &corev1.TypedLocalObjectReference{
+					APIGroup: awssdk.String("elbv2.k8s.aws"),
+					Kind:     "IngressClassParams",
+					Name:     paramsName,
+				},
+			},
+		}
+	}
+	buildIngClassParams := func(name string, featureGates map[string]bool) *elbv2api.IngressClassParams {
+		return &elbv2api.IngressClassParams{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: elbv2api.IngressClassParamsSpec{
+				FeatureGates: featureGates,
+			},
+		}
+	}
+	buildIng := func(name string, ingClassName string) *networking.Ingress {
+		return &networking.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "awesome-ns",
+				Name:      name,
+			},
+			Spec: networking.IngressSpec{
+				IngressClassName: awssdk.String(ingClassName),
+			},
+		}
+	}
+	tests := []struct {
+		name           string
+		ingClasses     []*networking.IngressClass
+		ingClassParams []*elbv2api.IngressClassParams
+		members        []*networking.Ingress
+		want           config.FeatureGates
+		wantErr        error
+	}{
+		{
+			name:       "no feature gates from IngressClassParams",
+			ingClasses: []*networking.IngressClass{buildIngClass("class-a", "params-a")},
+			ingClassParams: []*elbv2api.IngressClassParams{
+				buildIngClassParams("params-a", nil),
+			},
+			members: []*networking.Ingress{buildIng("ing-1", "class-a")},
+			want:    config.FeatureGates{},
+		},
+		{
+			name: "consistent feature gates from IngressClassParams",
+			ingClasses: []*networking.IngressClass{
+				buildIngClass("class-a", "params-a"),
+				buildIngClass("class-b", "params-b"),
+			},
+			ingClassParams: []*elbv2api.IngressClassParams{
+				buildIngClassParams("params-a", map[string]bool{"RuleSplitting": false}),
+				buildIngClassParams("params-b", nil),
+			},
+			members: []*networking.Ingress{buildIng("ing-1", "class-a"), buildIng("ing-2", "class-b")},
+			want:    config.FeatureGates{config.FeatureRuleSplitting: false},
+		},
+		{
+			name: "conflicting feature gates from IngressClassParams",
+			ingClasses: []*networking.IngressClass{
+				buildIngClass("class-a", "params-a"),
+				buildIngClass("class-b", "params-b"),
+			},
+			ingClassParams: []*elbv2api.IngressClassParams{
+				buildIngClassParams("params-a", map[string]bool{"RuleSplitting": false}),
+				buildIngClassParams("params-b", map[string]bool{"RuleSplitting": true}),
+			},
+			members: []*networking.Ingress{buildIng("ing-1", "class-a"), buildIng("ing-2", "class-b")},
+			wantErr: errors.New("conflicting feature gate RuleSplitting from IngressClassParams: false | true"),
+		},
+		{
+			name:       "feature cannot be overridden per IngressClass",
+			ingClasses: []*networking.IngressClass{buildIngClass("class-a", "params-a")},
+			ingClassParams: []*elbv2api.IngressClassParams{
+				buildIngClassParams("params-a", map[string]bool{"CostEstimate": true}),
+			},
+			members: []*networking.Ingress{buildIng("ing-1", "class-a")},
+			wantErr: errors.New("invalid featureGates in IngressClassParams params-a: feature cannot be overridden per IngressClass: CostEstimate"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			for _, ingClass := range tt.ingClasses {
+				assert.NoError(t, k8sClient.Create(context.Background(), ingClass.DeepCopy()))
+			}
+			for _, ingClassParams := range tt.ingClassParams {
+				assert.NoError(t, k8sClient.Create(context.Background(), ingClassParams.DeepCopy()))
+			}
+
+			task := &defaultModelBuildTask{
+				k8sClient:         k8sClient,
+				classParamsLoader: NewDefaultClassParamsLoader(k8sClient),
+				ingGroup: Group{
+					ID:      GroupID{Name: "awesome-group"},
+					Members: tt.members,
+				},
+			}
+			got, err := task.buildIngressClassFeatureGates(context.Background())
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNewModelBuilder(t *testing.T) {
+	_, err := NewModelBuilder(nil, nil, nil, ModelBuilderConfig{}, &log.NullLogger{})
+	assert.EqualError(t, err, "clusterName must be specified")
+}
@@ -13,12 +13,15 @@ const (
 	IndexKeyServiceRefName = "ingress.serviceRef.name"
 	// IndexKey for secrets referenced by Ingress or Service.
 	IndexKeySecretRefName = "ingress.secretRef.name"
+	// IndexKey for configMaps referenced by Ingress.
+	IndexKeyConfigMapRefName = "ingress.configMapRef.name"
 )
 
 // ReferenceIndexer has the ability to index Ingresses with referenced objects.
 type ReferenceIndexer interface {
 	BuildServiceRefIndexes(ctx context.Context, ing *networking.Ingress) []string
 	BuildSecretRefIndexes(ctx context.Context, ingOrSvc metav1.Object) []string
+	BuildConfigMapRefIndexes(ctx context.Context, ing *networking.Ingress) []string
 }
 
 // NewDefaultReferenceIndexer constructs new defaultReferenceIndexer.
@@ -40,19 +43,7 @@ type defaultReferenceIndexer struct {
 }
 
 func (i *defaultReferenceIndexer) BuildServiceRefIndexes(ctx context.Context, ing *networking.Ingress) []string {
-	var backends []networking.IngressBackend
-	if ing.Spec.Backend != nil {
-		backends = append(backends, *ing.Spec.Backend)
-	}
-	for _, rule := range ing.Spec.Rules {
-		if rule.HTTP == nil {
-			continue
-		}
-		for _, path := range rule.HTTP.Paths {
-			backends = append(backends, path.Backend)
-		}
-	}
-
+	backends := extractBackendsFromIngress(ing)
 	serviceNames := sets.NewString()
 	for _, backend := range backends {
 		enhancedBackend, err := i.enhancedBackendBuilder.Build(ctx, ing, backend)
@@ -77,6 +68,38 @@ func (i *defaultReferenceIndexer) BuildSecretRefIndexes(ctx context.Context, ing
 	return extractSecretNamesFromAuthConfig(authCfg)
 }
 
+func (i *defaultReferenceIndexer) BuildConfigMapRefIndexes(ctx context.Context, ing *networking.Ingress) []string {
+	backends := extractBackendsFromIngress(ing)
+	configMapNames := sets.NewString()
+	for _, backend := range backends {
+		enhancedBackend, err := i.enhancedBackendBuilder.Build(ctx, ing, backend)
+		if err != nil {
+			i.logger.Error(err, "failed to build Ingress indexes",
+				"indexKey", IndexKeyConfigMapRefName)
+			return nil
+		}
+		configMapNamesFromBackend := extractConfigMapNamesFromAction(enhancedBackend.Action)
+		configMapNames.Insert(configMapNamesFromBackend...)
+	}
+	return configMapNames.List()
+}
+
+func extractBackendsFromIngress(ing *networking.Ingress) []networking.IngressBackend {
+	var backends []networking.IngressBackend
+	if ing.Spec.Backend != nil {
+		backends = append(backends, *ing.Spec.Backend)
+	}
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			backends = append(backends, path.Backend)
+		}
+	}
+	return backends
+}
+
 func extractServiceNamesFromAction(action Action) []string {
 	if action.Type != ActionTypeForward || action.ForwardConfig == nil {
 		return nil
@@ -96,6 +119,14 @@ func extractServiceNamesFromTargetGroupTuple(tgt TargetGroupTuple) []string {
 	return []string{*tgt.ServiceName}
 }
 
+func extractConfigMapNamesFromAction(action Action) []string {
+	if action.Type != ActionTypeFixedResponse || action.FixedResponseConfig == nil ||
+		action.FixedResponseConfig.MessageBodyConfigMapRef == nil {
+		return nil
+	}
+	return []string{action.FixedResponseConfig.MessageBodyConfigMapRef.Name}
+}
+
 func extractSecretNamesFromAuthConfig(authCfg AuthConfig) []string {
 	if authCfg.IDPConfigOIDC == nil {
 		return nil
@@ -313,3 +313,91 @@ func Test_defaultReferenceIndexer_BuildSecretRefIndexes(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultReferenceIndexer_BuildConfigMapRefIndexes(t *testing.T) {
+	type args struct {
+		ing *networking.Ingress
+	}
+	tests := []struct {
+		name string
+		args args
+		want []string
+	}{
+		{
+			name: "Ingress without fixed-response actions",
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-ing",
+					},
+					Spec: networking.IngressSpec{
+						Backend: &networking.IngressBackend{
+							ServiceName: "svc-a",
+							ServicePort: intstr.FromInt(80),
+						},
+					},
+				},
+			},
+			want: []string{},
+		},
+		{
+			name: "Ingress with fixed-response actions",
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-ing",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/actions.maintenance": `{"type":"fixed-response","fixedResponseConfig":{"contentType":"text/html","statusCode":"503","messageBodyConfigMapRef":{"name":"maintenance-page","key":"index.html"}}}`,
+							"alb.ingress.kubernetes.io/actions.not-found":   `{"type":"fixed-response","fixedResponseConfig":{"contentType":"text/plain","statusCode":"404","messageBody":"not found"}}`,
+						},
+					},
+					Spec: networking.IngressSpec{
+						Backend: &networking.IngressBackend{
+							ServiceName: "not-found",
+							ServicePort: intstr.FromString("use-annotation"),
+						},
+						Rules: []networking.IngressRule{
+							{
+								IngressRuleValue: networking.IngressRuleValue{
+									HTTP: &networking.HTTPIngressRuleValue{
+										Paths: []networking.HTTPIngressPath{
+											{
+												Path: "/pathA",
+												Backend: networking.IngressBackend{
+													ServiceName: "maintenance",
+													ServicePort: intstr.FromString("use-annotation"),
+												},
+											},
+											{
+												Path: "/pathB",
+												Backend: networking.IngressBackend{
+													ServiceName: "svc-b",
+													ServicePort: intstr.FromInt(80),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: []string{"maintenance-page"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			annotationParser := annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
+			authConfigBuilder := NewDefaultAuthConfigBuilder(annotationParser)
+			enhancedBackendBuilder := NewDefaultEnhancedBackendBuilder(annotationParser)
+			i := &defaultReferenceIndexer{
+				enhancedBackendBuilder: enhancedBackendBuilder,
+				authConfigBuilder:      authConfigBuilder,
+				logger:                 &log.NullLogger{},
+			}
+			got := i.BuildConfigMapRefIndexes(context.Background(), tt.args.ing)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
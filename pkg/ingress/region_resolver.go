@@ -0,0 +1,79 @@
+package ingress
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	networking "k8s.io/api/networking/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+)
+
+// Region is the AWS region and VPC to provision AWS resources in for an IngressGroup.
+type Region struct {
+	// Name of the AWS region, it's empty for the controller's own region.
+	Name string
+	// ID of the VPC in AWS region.
+	VpcID string
+}
+
+// RegionResolver resolves the AWS region to provision AWS resources in for an IngressGroup.
+type RegionResolver interface {
+	// Resolve returns the Region for IngressGroup, it returns empty Region if controller's own region should be used.
+	Resolve(ctx context.Context, ingGroup Group) (Region, error)
+}
+
+// NewDefaultRegionResolver constructs new defaultRegionResolver.
+func NewDefaultRegionResolver(classParamsLoader ClassParamsLoader) *defaultRegionResolver {
+	return &defaultRegionResolver{
+		classParamsLoader: classParamsLoader,
+	}
+}
+
+var _ RegionResolver = &defaultRegionResolver{}
+
+// default implementation for RegionResolver, which resolves region from the IngressClassParams of Ingresses.
+type defaultRegionResolver struct {
+	classParamsLoader ClassParamsLoader
+}
+
+func (r *defaultRegionResolver) Resolve(ctx context.Context, ingGroup Group) (Region, error) {
+	// when IngressGroup is being deleted, the AWS resources need to be cleaned up in the region of inactive members.
+	ingList := ingGroup.Members
+	if len(ingList) == 0 {
+		ingList = ingGroup.InactiveMembers
+	}
+
+	var resolvedRegion Region
+	for index, ing := range ingList {
+		region, err := r.resolveForIngress(ctx, ing)
+		if err != nil {
+			return Region{}, err
+		}
+		if index != 0 && region != resolvedRegion {
+			return Region{}, errors.Errorf("conflicting regions: %v/%v | %v/%v",
+				resolvedRegion.Name, resolvedRegion.VpcID, region.Name, region.VpcID)
+		}
+		resolvedRegion = region
+	}
+	return resolvedRegion, nil
+}
+
+// resolveForIngress resolves the Region for a single Ingress.
+func (r *defaultRegionResolver) resolveForIngress(ctx context.Context, ing *networking.Ingress) (Region, error) {
+	ingClassParams, err := r.classParamsLoader.Load(ctx, ing)
+	if err != nil {
+		return Region{}, err
+	}
+	if ingClassParams == nil || (ingClassParams.Spec.Region == nil && ingClassParams.Spec.VpcID == nil) {
+		return Region{}, nil
+	}
+	region := Region{
+		Name:  awssdk.StringValue(ingClassParams.Spec.Region),
+		VpcID: awssdk.StringValue(ingClassParams.Spec.VpcID),
+	}
+	if region.Name == "" || region.VpcID == "" {
+		return Region{}, errors.Errorf("both region and vpcID must be specified in IngressClassParams %v for Ingress %v",
+			ingClassParams.Name, k8s.NamespacedName(ing))
+	}
+	return region, nil
+}
@@ -0,0 +1,158 @@
+package ingress
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+func Test_defaultRegionResolver_Resolve(t *testing.T) {
+	buildIngClass := func(name string, paramsName string) *networking.IngressClass {
+		return &networking.IngressClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: networking.IngressClassSpec{
+				Controller: ingressClassControllerALB,
+				Parameters: &corev1.TypedLocalObjectReference{
+					APIGroup: awssdk.String("elbv2.k8s.aws"),
+					Kind:     "IngressClassParams",
+					Name:     paramsName,
+				},
+			},
+		}
+	}
+	buildIngClassParams := func(name string, region *string, vpcID *string) *elbv2api.IngressClassParams {
+		return &elbv2api.IngressClassParams{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: elbv2api.IngressClassParamsSpec{
+				Region: region,
+				VpcID:  vpcID,
+			},
+		}
+	}
+	buildIngress := func(name string, ingClassName *string) *networking.Ingress {
+		return &networking.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "awesome-ns",
+				Name:      name,
+			},
+			Spec: networking.IngressSpec{
+				IngressClassName: ingClassName,
+			},
+		}
+	}
+	ingClasses := []*networking.IngressClass{
+		buildIngClass("class-eu-west-1", "params-eu-west-1"),
+		buildIngClass("class-ap-south-1", "params-ap-south-1"),
+		buildIngClass("class-default-region", "params-default-region"),
+		buildIngClass("class-without-vpc", "params-without-vpc"),
+	}
+	ingClassParamses := []*elbv2api.IngressClassParams{
+		buildIngClassParams("params-eu-west-1", awssdk.String("eu-west-1"), awssdk.String("vpc-1")),
+		buildIngClassParams("params-ap-south-1", awssdk.String("ap-south-1"), awssdk.String("vpc-2")),
+		buildIngClassParams("params-default-region", nil, nil),
+		buildIngClassParams("params-without-vpc", awssdk.String("eu-west-1"), nil),
+	}
+
+	tests := []struct {
+		name     string
+		ingGroup Group
+		want     Region
+		wantErr  error
+	}{
+		{
+			name: "members without region",
+			ingGroup: Group{
+				Members: []*networking.Ingress{
+					buildIngress("ing-1", nil),
+					buildIngress("ing-2", awssdk.String("class-default-region")),
+				},
+			},
+			want: Region{},
+		},
+		{
+			name: "members with same region",
+			ingGroup: Group{
+				Members: []*networking.Ingress{
+					buildIngress("ing-1", awssdk.String("class-eu-west-1")),
+					buildIngress("ing-2", awssdk.String("class-eu-west-1")),
+				},
+			},
+			want: Region{Name: "eu-west-1", VpcID: "vpc-1"},
+		},
+		{
+			name: "inactive members are used when there are no members",
+			ingGroup: Group{
+				InactiveMembers: []*networking.Ingress{
+					buildIngress("ing-1", awssdk.String("class-ap-south-1")),
+				},
+			},
+			want: Region{Name: "ap-south-1", VpcID: "vpc-2"},
+		},
+		{
+			name: "members with conflicting regions",
+			ingGroup: Group{
+				Members: []*networking.Ingress{
+					buildIngress("ing-1", awssdk.String("class-eu-west-1")),
+					buildIngress("ing-2", awssdk.String("class-ap-south-1")),
+				},
+			},
+			wantErr: errors.New("conflicting regions: eu-west-1/vpc-1 | ap-south-1/vpc-2"),
+		},
+		{
+			name: "members with and without region",
+			ingGroup: Group{
+				Members: []*networking.Ingress{
+					buildIngress("ing-1", awssdk.String("class-eu-west-1")),
+					buildIngress("ing-2", nil),
+				},
+			},
+			wantErr: errors.New("conflicting regions: eu-west-1/vpc-1 | /"),
+		},
+		{
+			name: "region without vpcID",
+			ingGroup: Group{
+				Members: []*networking.Ingress{
+					buildIngress("ing-1", awssdk.String("class-without-vpc")),
+				},
+			},
+			wantErr: errors.New("both region and vpcID must be specified in IngressClassParams params-without-vpc for Ingress awesome-ns/ing-1"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			for _, ingClass := range ingClasses {
+				assert.NoError(t, k8sClient.Create(ctx, ingClass.DeepCopy()))
+			}
+			for _, params := range ingClassParamses {
+				assert.NoError(t, k8sClient.Create(ctx, params.DeepCopy()))
+			}
+
+			r := NewDefaultRegionResolver(NewDefaultClassParamsLoader(k8sClient))
+			got, err := r.Resolve(ctx, tt.ingGroup)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package ingress
+
+import (
+	"github.com/pkg/errors"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+)
+
+const (
+	// the maximum number of condition values per listener rule, counted across all its conditions.
+	maxValuesPerRule = 5
+	// the maximum number of conditions per listener rule.
+	maxConditionsPerRule = 5
+	// the maximum number of rules a logical rule can be split into.
+	// each logical rule reserves this many priorities when splitting is enabled, so that splitting a rule doesn't shift the priorities of later rules.
+	maxSplitRulesPerRule = 10
+)
+
+// validateRuleConditionCount checks whether the conditions of one logical rule fit into ALB rules.
+// Conditions are ANDed, so they cannot be distributed across rules without changing the rule semantic.
+func validateRuleConditionCount(conditions []elbv2model.RuleCondition) error {
+	if len(conditions) > maxConditionsPerRule {
+		return errors.Errorf("rule has %v conditions, which exceeds the limit of %v conditions per rule", len(conditions), maxConditionsPerRule)
+	}
+	return nil
+}
+
+// validateRuleConditionValueCount checks whether the conditions of one logical rule fit into one ALB rule without splitting.
+func validateRuleConditionValueCount(conditions []elbv2model.RuleCondition) error {
+	valueCount := 0
+	for _, condition := range conditions {
+		valueCount += ruleConditionValueCount(condition)
+	}
+	if valueCount > maxValuesPerRule {
+		return errors.Errorf("rule has %v condition values, which exceeds the limit of %v values per rule", valueCount, maxValuesPerRule)
+	}
+	return nil
+}
+
+// splitRuleConditions splits the conditions of one logical rule into conditions of multiple ALB rules,
+// so that each rule has at most maxValuesPerRule values across its conditions.
+// Values within a condition are ORed while conditions are ANDed, so the logical rule matches a request iff one of the split rules does.
+// The split rules are expected to be created with consecutive priorities, in the order returned.
+func splitRuleConditions(conditions []elbv2model.RuleCondition) ([][]elbv2model.RuleCondition, error) {
+	capacities := ruleConditionValueCapacities(conditions)
+	splitConditions := [][]elbv2model.RuleCondition{nil}
+	for i, condition := range conditions {
+		conditionChunks := splitRuleConditionValues(condition, capacities[i])
+		expandedConditions := make([][]elbv2model.RuleCondition, 0, len(splitConditions)*len(conditionChunks))
+		for _, ruleConditions := range splitConditions {
+			for _, conditionChunk := range conditionChunks {
+				chunkConditions := make([]elbv2model.RuleCondition, 0, len(ruleConditions)+1)
+				chunkConditions = append(chunkConditions, ruleConditions...)
+				chunkConditions = append(chunkConditions, conditionChunk)
+				expandedConditions = append(expandedConditions, chunkConditions)
+			}
+		}
+		splitConditions = expandedConditions
+	}
+	if len(splitConditions) > maxSplitRulesPerRule {
+		return nil, errors.Errorf("rule needs to be split into %v rules, which exceeds the limit of %v rules per split rule",
+			len(splitConditions), maxSplitRulesPerRule)
+	}
+	return splitConditions, nil
+}
+
+// ruleConditionValueCapacities returns the maximum number of values of each condition within one ALB rule.
+// each condition keeps at least one value, and the remaining values per rule are granted to conditions in order.
+func ruleConditionValueCapacities(conditions []elbv2model.RuleCondition) []int {
+	capacities := make([]int, 0, len(conditions))
+	remaining := maxValuesPerRule - len(conditions)
+	for _, condition := range conditions {
+		extra := ruleConditionValueCount(condition) - 1
+		if extra > remaining {
+			extra = remaining
+		}
+		if extra < 0 {
+			extra = 0
+		}
+		remaining -= extra
+		capacities = append(capacities, 1+extra)
+	}
+	return capacities
+}
+
+// splitRuleConditionValues splits the condition into conditions with at most capacity values.
+func splitRuleConditionValues(condition elbv2model.RuleCondition, capacity int) []elbv2model.RuleCondition {
+	valueCount := ruleConditionValueCount(condition)
+	if valueCount <= capacity {
+		return []elbv2model.RuleCondition{condition}
+	}
+	var conditionChunks []elbv2model.RuleCondition
+	for start := 0; start < valueCount; start += capacity {
+		end := start + capacity
+		if end > valueCount {
+			end = valueCount
+		}
+		conditionChunks = append(conditionChunks, sliceRuleConditionValues(condition, start, end))
+	}
+	return conditionChunks
+}
+
+// ruleConditionValueCount returns the number of values of the condition.
+func ruleConditionValueCount(condition elbv2model.RuleCondition) int {
+	switch condition.Field {
+	case elbv2model.RuleConditionFieldHostHeader:
+		if condition.HostHeaderConfig != nil {
+			return len(condition.HostHeaderConfig.Values)
+		}
+	case elbv2model.RuleConditionFieldPathPattern:
+		if condition.PathPatternConfig != nil {
+			return len(condition.PathPatternConfig.Values)
+		}
+	case elbv2model.RuleConditionFieldHTTPHeader:
+		if condition.HTTPHeaderConfig != nil {
+			return len(condition.HTTPHeaderConfig.Values)
+		}
+	case elbv2model.RuleConditionFieldHTTPRequestMethod:
+		if condition.HTTPRequestMethodConfig != nil {
+			return len(condition.HTTPRequestMethodConfig.Values)
+		}
+	case elbv2model.RuleConditionFieldQueryString:
+		if condition.QueryStringConfig != nil {
+			return len(condition.QueryStringConfig.Values)
+		}
+	case elbv2model.RuleConditionFieldSourceIP:
+		if condition.SourceIPConfig != nil {
+			return len(condition.SourceIPConfig.Values)
+		}
+	}
+	return 0
+}
+
+// sliceRuleConditionValues returns a copy of the condition with values in range [start, end) only.
+func sliceRuleConditionValues(condition elbv2model.RuleCondition, start int, end int) elbv2model.RuleCondition {
+	switch condition.Field {
+	case elbv2model.RuleConditionFieldHostHeader:
+		return elbv2model.RuleCondition{
+			Field: condition.Field,
+			HostHeaderConfig: &elbv2model.HostHeaderConditionConfig{
+				Values: condition.HostHeaderConfig.Values[start:end],
+			},
+		}
+	case elbv2model.RuleConditionFieldPathPattern:
+		return elbv2model.RuleCondition{
+			Field: condition.Field,
+			PathPatternConfig: &elbv2model.PathPatternConditionConfig{
+				Values: condition.PathPatternConfig.Values[start:end],
+			},
+		}
+	case elbv2model.RuleConditionFieldHTTPHeader:
+		return elbv2model.RuleCondition{
+			Field: condition.Field,
+			HTTPHeaderConfig: &elbv2model.HTTPHeaderConditionConfig{
+				HTTPHeaderName: condition.HTTPHeaderConfig.HTTPHeaderName,
+				Values:         condition.HTTPHeaderConfig.Values[start:end],
+			},
+		}
+	case elbv2model.RuleConditionFieldHTTPRequestMethod:
+		return elbv2model.RuleCondition{
+			Field: condition.Field,
+			HTTPRequestMethodConfig: &elbv2model.HTTPRequestMethodConditionConfig{
+				Values: condition.HTTPRequestMethodConfig.Values[start:end],
+			},
+		}
+	case elbv2model.RuleConditionFieldQueryString:
+		return elbv2model.RuleCondition{
+			Field: condition.Field,
+			QueryStringConfig: &elbv2model.QueryStringConditionConfig{
+				Values: condition.QueryStringConfig.Values[start:end],
+			},
+		}
+	case elbv2model.RuleConditionFieldSourceIP:
+		return elbv2model.RuleCondition{
+			Field: condition.Field,
+			SourceIPConfig: &elbv2model.SourceIPConditionConfig{
+				Values: condition.SourceIPConfig.Values[start:end],
+			},
+		}
+	}
+	return condition
+}
@@ -0,0 +1,207 @@
+package ingress
+
+import (
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"testing"
+)
+
+func Test_validateRuleConditionCount(t *testing.T) {
+	pathCondition := elbv2model.RuleCondition{
+		Field: elbv2model.RuleConditionFieldPathPattern,
+		PathPatternConfig: &elbv2model.PathPatternConditionConfig{
+			Values: []string{"/api/*"},
+		},
+	}
+	tests := []struct {
+		name       string
+		conditions []elbv2model.RuleCondition
+		wantErr    error
+	}{
+		{
+			name:       "conditions within limit",
+			conditions: []elbv2model.RuleCondition{pathCondition, pathCondition, pathCondition, pathCondition, pathCondition},
+		},
+		{
+			name:       "conditions exceeds limit",
+			conditions: []elbv2model.RuleCondition{pathCondition, pathCondition, pathCondition, pathCondition, pathCondition, pathCondition},
+			wantErr:    errors.New("rule has 6 conditions, which exceeds the limit of 5 conditions per rule"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRuleConditionCount(tt.conditions)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_validateRuleConditionValueCount(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions []elbv2model.RuleCondition
+		wantErr    error
+	}{
+		{
+			name: "values within limit",
+			conditions: []elbv2model.RuleCondition{
+				{
+					Field: elbv2model.RuleConditionFieldHostHeader,
+					HostHeaderConfig: &elbv2model.HostHeaderConditionConfig{
+						Values: []string{"a.example.com", "b.example.com", "c.example.com", "d.example.com", "e.example.com"},
+					},
+				},
+			},
+		},
+		{
+			name: "values across conditions exceeds limit",
+			conditions: []elbv2model.RuleCondition{
+				{
+					Field: elbv2model.RuleConditionFieldHostHeader,
+					HostHeaderConfig: &elbv2model.HostHeaderConditionConfig{
+						Values: []string{"a.example.com", "b.example.com", "c.example.com"},
+					},
+				},
+				{
+					Field: elbv2model.RuleConditionFieldPathPattern,
+					PathPatternConfig: &elbv2model.PathPatternConditionConfig{
+						Values: []string{"/a", "/b", "/c"},
+					},
+				},
+			},
+			wantErr: errors.New("rule has 6 condition values, which exceeds the limit of 5 values per rule"),
+		},
+		{
+			name: "values exceeds limit",
+			conditions: []elbv2model.RuleCondition{
+				{
+					Field: elbv2model.RuleConditionFieldHostHeader,
+					HostHeaderConfig: &elbv2model.HostHeaderConditionConfig{
+						Values: []string{"a.example.com", "b.example.com", "c.example.com", "d.example.com", "e.example.com", "f.example.com"},
+					},
+				},
+			},
+			wantErr: errors.New("rule has 6 condition values, which exceeds the limit of 5 values per rule"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRuleConditionValueCount(tt.conditions)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_splitRuleConditions(t *testing.T) {
+	pathCondition := func(paths ...string) elbv2model.RuleCondition {
+		return elbv2model.RuleCondition{
+			Field: elbv2model.RuleConditionFieldPathPattern,
+			PathPatternConfig: &elbv2model.PathPatternConditionConfig{
+				Values: paths,
+			},
+		}
+	}
+	hostCondition := func(hosts ...string) elbv2model.RuleCondition {
+		return elbv2model.RuleCondition{
+			Field: elbv2model.RuleConditionFieldHostHeader,
+			HostHeaderConfig: &elbv2model.HostHeaderConditionConfig{
+				Values: hosts,
+			},
+		}
+	}
+	httpHeaderCondition := func(values ...string) elbv2model.RuleCondition {
+		return elbv2model.RuleCondition{
+			Field: elbv2model.RuleConditionFieldHTTPHeader,
+			HTTPHeaderConfig: &elbv2model.HTTPHeaderConditionConfig{
+				HTTPHeaderName: "X-Tenant",
+				Values:         values,
+			},
+		}
+	}
+	queryStringCondition := func(values ...string) elbv2model.RuleCondition {
+		var pairs []elbv2model.QueryStringKeyValuePair
+		for _, value := range values {
+			pairs = append(pairs, elbv2model.QueryStringKeyValuePair{
+				Key:   awssdk.String("version"),
+				Value: value,
+			})
+		}
+		return elbv2model.RuleCondition{
+			Field: elbv2model.RuleConditionFieldQueryString,
+			QueryStringConfig: &elbv2model.QueryStringConditionConfig{
+				Values: pairs,
+			},
+		}
+	}
+	tests := []struct {
+		name       string
+		conditions []elbv2model.RuleCondition
+		want       [][]elbv2model.RuleCondition
+		wantErr    error
+	}{
+		{
+			name:       "values within limit",
+			conditions: []elbv2model.RuleCondition{hostCondition("a.com", "b.com", "c.com", "d.com"), pathCondition("/api/*")},
+			want: [][]elbv2model.RuleCondition{
+				{hostCondition("a.com", "b.com", "c.com", "d.com"), pathCondition("/api/*")},
+			},
+		},
+		{
+			name:       "host-header condition exceeds limit",
+			conditions: []elbv2model.RuleCondition{pathCondition("/api/*"), hostCondition("a.com", "b.com", "c.com", "d.com", "e.com", "f.com", "g.com")},
+			want: [][]elbv2model.RuleCondition{
+				{pathCondition("/api/*"), hostCondition("a.com", "b.com", "c.com", "d.com")},
+				{pathCondition("/api/*"), hostCondition("e.com", "f.com", "g.com")},
+			},
+		},
+		{
+			name: "values across conditions exceeds limit",
+			conditions: []elbv2model.RuleCondition{
+				httpHeaderCondition("t1", "t2", "t3"),
+				pathCondition("/p1", "/p2", "/p3"),
+			},
+			want: [][]elbv2model.RuleCondition{
+				{httpHeaderCondition("t1", "t2", "t3"), pathCondition("/p1", "/p2")},
+				{httpHeaderCondition("t1", "t2", "t3"), pathCondition("/p3")},
+			},
+		},
+		{
+			name:       "query-string condition exceeds limit",
+			conditions: []elbv2model.RuleCondition{queryStringCondition("v1", "v2", "v3", "v4", "v5", "v6", "v7", "v8", "v9", "v10", "v11")},
+			want: [][]elbv2model.RuleCondition{
+				{queryStringCondition("v1", "v2", "v3", "v4", "v5")},
+				{queryStringCondition("v6", "v7", "v8", "v9", "v10")},
+				{queryStringCondition("v11")},
+			},
+		},
+		{
+			name: "split rules exceeds limit",
+			conditions: []elbv2model.RuleCondition{
+				httpHeaderCondition("t1", "t2", "t3", "t4", "t5", "t6"),
+				pathCondition("/p1", "/p2", "/p3", "/p4", "/p5", "/p6"),
+			},
+			wantErr: errors.New("rule needs to be split into 12 rules, which exceeds the limit of 10 rules per split rule"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := splitRuleConditions(tt.conditions)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
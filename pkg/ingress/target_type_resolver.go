@@ -0,0 +1,82 @@
+package ingress
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+)
+
+// TargetTypeResolver resolves the TargetType of TargetGroups for Ingress backends.
+type TargetTypeResolver interface {
+	// Resolve returns the TargetType of TargetGroup for backend Service of Ingress, svc can be nil if the Service doesn't exist yet.
+	// The target-type annotation on Service or Ingress takes precedence over the targetType from IngressClassParams,
+	// which takes precedence over the default TargetType.
+	// Since instance targets never become healthy for pods on Fargate, ip TargetType is used instead of the unannotated instance TargetType
+	// for Services that select only Fargate pods, and it's an error to annotate such Services with instance TargetType.
+	Resolve(ctx context.Context, ing *networking.Ingress, svc *corev1.Service) (elbv2model.TargetType, error)
+}
+
+// NewDefaultTargetTypeResolver constructs new defaultTargetTypeResolver.
+func NewDefaultTargetTypeResolver(annotationParser annotations.Parser, classParamsLoader ClassParamsLoader,
+	fargateDetector k8s.FargateDetector, defaultTargetType elbv2model.TargetType) *defaultTargetTypeResolver {
+	return &defaultTargetTypeResolver{
+		annotationParser:  annotationParser,
+		classParamsLoader: classParamsLoader,
+		fargateDetector:   fargateDetector,
+		defaultTargetType: defaultTargetType,
+	}
+}
+
+var _ TargetTypeResolver = &defaultTargetTypeResolver{}
+
+// default implementation for TargetTypeResolver
+type defaultTargetTypeResolver struct {
+	annotationParser  annotations.Parser
+	classParamsLoader ClassParamsLoader
+	fargateDetector   k8s.FargateDetector
+	defaultTargetType elbv2model.TargetType
+}
+
+func (r *defaultTargetTypeResolver) Resolve(ctx context.Context, ing *networking.Ingress, svc *corev1.Service) (elbv2model.TargetType, error) {
+	rawTargetType := string(r.defaultTargetType)
+	ingClassParams, err := r.classParamsLoader.Load(ctx, ing)
+	if err != nil {
+		return "", err
+	}
+	if ingClassParams != nil && ingClassParams.Spec.TargetType != nil {
+		rawTargetType = string(*ingClassParams.Spec.TargetType)
+	}
+	svcAndIngAnnotations := ing.Annotations
+	if svc != nil {
+		svcAndIngAnnotations = algorithm.MergeStringMap(svc.Annotations, ing.Annotations)
+	}
+	explicitTargetType := r.annotationParser.ParseStringAnnotation(annotations.IngressSuffixTargetType, &rawTargetType, svcAndIngAnnotations)
+	var targetType elbv2model.TargetType
+	switch rawTargetType {
+	case string(elbv2model.TargetTypeInstance):
+		targetType = elbv2model.TargetTypeInstance
+	case string(elbv2model.TargetTypeIP):
+		targetType = elbv2model.TargetTypeIP
+	default:
+		return "", errors.Errorf("unknown targetType: %v", rawTargetType)
+	}
+	if targetType != elbv2model.TargetTypeInstance || svc == nil {
+		return targetType, nil
+	}
+	fargateOnly, err := r.fargateDetector.IsFargateOnlyService(ctx, svc)
+	if err != nil {
+		return "", err
+	}
+	if !fargateOnly {
+		return targetType, nil
+	}
+	if explicitTargetType {
+		return "", errors.Errorf("instance targetType is unsupported for service %v that selects only Fargate pods, use ip targetType instead", k8s.NamespacedName(svc))
+	}
+	return elbv2model.TargetTypeIP, nil
+}
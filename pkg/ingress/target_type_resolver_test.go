@@ -0,0 +1,219 @@
+package ingress
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1beta1"
+	networking "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+func Test_defaultTargetTypeResolver_Resolve(t *testing.T) {
+	ipTargetType := elbv2api.TargetTypeIP
+	ingClassParams := &elbv2api.IngressClassParams{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "params-ip",
+		},
+		Spec: elbv2api.IngressClassParamsSpec{
+			TargetType: &ipTargetType,
+		},
+	}
+	ingClass := &networking.IngressClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "class-ip",
+		},
+		Spec: networking.IngressClassSpec{
+			Controller: ingressClassControllerALB,
+			Parameters: &corev1.TypedLocalObjectReference{
+				APIGroup: awssdk.String("elbv2.k8s.aws"),
+				Kind:     "IngressClassParams",
+				Name:     "params-ip",
+			},
+		},
+	}
+	fargateNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "fargate-ip-192-168-1-1.ec2.internal",
+			Labels: map[string]string{
+				"kubernetes.io/hostname":         "fargate-ip-192-168-1-1.ec2.internal",
+				"eks.amazonaws.com/compute-type": "fargate",
+			},
+		},
+	}
+	fargateEPSlice := &discovery.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "fargate-svc-abcde",
+			Labels: map[string]string{
+				"kubernetes.io/service-name": "fargate-svc",
+			},
+		},
+		AddressType: discovery.AddressTypeIPv4,
+		Endpoints: []discovery.Endpoint{
+			{
+				Addresses: []string{"192.168.1.1"},
+				TargetRef: &corev1.ObjectReference{Kind: "Pod", Namespace: "ns", Name: "pod-1"},
+				Topology: map[string]string{
+					"kubernetes.io/hostname": "fargate-ip-192-168-1-1.ec2.internal",
+				},
+			},
+		},
+	}
+	type args struct {
+		ing *networking.Ingress
+		svc *corev1.Service
+	}
+	tests := []struct {
+		name              string
+		defaultTargetType elbv2model.TargetType
+		args              args
+		want              elbv2model.TargetType
+		wantErr           error
+	}{
+		{
+			name:              "default targetType",
+			defaultTargetType: elbv2model.TargetTypeIP,
+			args: args{
+				ing: &networking.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "ing"}},
+				svc: &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "svc"}},
+			},
+			want: elbv2model.TargetTypeIP,
+		},
+		{
+			name:              "targetType from IngressClassParams",
+			defaultTargetType: elbv2model.TargetTypeInstance,
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "ing"},
+					Spec: networking.IngressSpec{
+						IngressClassName: awssdk.String("class-ip"),
+					},
+				},
+				svc: &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "svc"}},
+			},
+			want: elbv2model.TargetTypeIP,
+		},
+		{
+			name:              "targetType from Ingress annotation takes precedence over IngressClassParams",
+			defaultTargetType: elbv2model.TargetTypeIP,
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns",
+						Name:      "ing",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/target-type": "instance",
+						},
+					},
+					Spec: networking.IngressSpec{
+						IngressClassName: awssdk.String("class-ip"),
+					},
+				},
+				svc: nil,
+			},
+			want: elbv2model.TargetTypeInstance,
+		},
+		{
+			name:              "targetType from Service annotation takes precedence over Ingress annotation",
+			defaultTargetType: elbv2model.TargetTypeInstance,
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns",
+						Name:      "ing",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/target-type": "instance",
+						},
+					},
+				},
+				svc: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns",
+						Name:      "svc",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/target-type": "ip",
+						},
+					},
+				},
+			},
+			want: elbv2model.TargetTypeIP,
+		},
+		{
+			name:              "unknown targetType",
+			defaultTargetType: elbv2model.TargetTypeInstance,
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns",
+						Name:      "ing",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/target-type": "lambda",
+						},
+					},
+				},
+			},
+			wantErr: errors.New("unknown targetType: lambda"),
+		},
+		{
+			name:              "ip targetType for Service that selects only Fargate pods",
+			defaultTargetType: elbv2model.TargetTypeInstance,
+			args: args{
+				ing: &networking.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "ing"}},
+				svc: &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "fargate-svc"}},
+			},
+			want: elbv2model.TargetTypeIP,
+		},
+		{
+			name:              "annotated instance targetType for Service that selects only Fargate pods",
+			defaultTargetType: elbv2model.TargetTypeIP,
+			args: args{
+				ing: &networking.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "ing"}},
+				svc: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns",
+						Name:      "fargate-svc",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/target-type": "instance",
+						},
+					},
+				},
+			},
+			wantErr: errors.New("instance targetType is unsupported for service ns/fargate-svc that selects only Fargate pods, use ip targetType instead"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			assert.NoError(t, k8sClient.Create(ctx, ingClass.DeepCopy()))
+			assert.NoError(t, k8sClient.Create(ctx, ingClassParams.DeepCopy()))
+			assert.NoError(t, k8sClient.Create(ctx, fargateNode.DeepCopy()))
+			assert.NoError(t, k8sClient.Create(ctx, fargateEPSlice.DeepCopy()))
+
+			annotationParser := annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
+			r := NewDefaultTargetTypeResolver(annotationParser, NewDefaultClassParamsLoader(k8sClient),
+				k8s.NewDefaultFargateDetector(k8sClient), tt.defaultTargetType)
+			got, err := r.Resolve(ctx, tt.args.ing, tt.args.svc)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -1,16 +1,40 @@
 package inject
 
-import "github.com/spf13/pflag"
+import (
+	"github.com/spf13/pflag"
+	"time"
+)
 
 const (
-	flagEnablePodReadinessGateInject = "enable-pod-readiness-gate-inject"
+	flagEnablePodReadinessGateInject  = "enable-pod-readiness-gate-inject"
+	flagPodReadinessGateMaxWait       = "pod-readiness-gate-max-wait"
+	flagEnablePodDeregistrationDrain  = "enable-pod-deregistration-drain"
+	flagPodDeregistrationDrainMaxWait = "pod-deregistration-drain-max-wait"
+
+	defaultPodDeregistrationDrainMaxWait = 10 * time.Minute
 )
 
 type Config struct {
 	EnablePodReadinessGateInject bool
+
+	// Max duration to wait for the targets of a pod to become healthy before the targetHealth readiness gate is timed out.
+	// The readiness gate waits forever if it's zero.
+	PodReadinessGateMaxWait time.Duration
+
+	// If enabled, the deletion of pods with targetHealth readiness gates is delayed until their targets finished draining.
+	EnablePodDeregistrationDrain bool
+
+	// Max duration to delay the deletion of pods waiting on their targets to finish draining.
+	PodDeregistrationDrainMaxWait time.Duration
 }
 
 func (cfg *Config) BindFlags(fs *pflag.FlagSet) {
 	fs.BoolVar(&cfg.EnablePodReadinessGateInject, flagEnablePodReadinessGateInject, true,
 		`If enabled, targetHealth readiness gate will get injected to the pod spec for the matching endpoint pods`)
+	fs.DurationVar(&cfg.PodReadinessGateMaxWait, flagPodReadinessGateMaxWait, 0,
+		`Max duration to wait for targets to become healthy before the targetHealth readiness gate is timed out and reported as not ready with ReadinessGateTimeout reason, wait forever if zero`)
+	fs.BoolVar(&cfg.EnablePodDeregistrationDrain, flagEnablePodDeregistrationDrain, false,
+		`If enabled, the deletion of pods with targetHealth readiness gates will be delayed until their targets finished draining`)
+	fs.DurationVar(&cfg.PodDeregistrationDrainMaxWait, flagPodDeregistrationDrainMaxWait, defaultPodDeregistrationDrainMaxWait,
+		`Max duration to delay the deletion of pods waiting on their targets to finish draining`)
 }
@@ -16,6 +16,14 @@ import (
 	"strings"
 )
 
+const (
+	// PodReadinessGateInjectLabel is the label on Namespaces to opt-in targetHealth readiness gate injection,
+	// and on Services to opt-out targetHealth readiness gate injection for their TargetGroupBindings.
+	PodReadinessGateInjectLabel = "elbv2.k8s.aws/pod-readiness-gate-inject"
+	// PodReadinessGateInjectDisabled is the value of PodReadinessGateInjectLabel to opt-out targetHealth readiness gate injection.
+	PodReadinessGateInjectDisabled = "disabled"
+)
+
 // NewPodReadinessGate constructs new PodReadinessGate
 func NewPodReadinessGate(config Config, k8sClient client.Client, logger logr.Logger) *PodReadinessGate {
 	return &PodReadinessGate{
@@ -81,6 +89,9 @@ func (m *PodReadinessGate) computeTargetHealthReadinessGateConditionTypes(ctx co
 			}
 			return nil, errors.Wrap(err, "unable to determine targetHealth readinessGates")
 		}
+		if svc.Labels[PodReadinessGateInjectLabel] == PodReadinessGateInjectDisabled {
+			continue
+		}
 		var svcSelector labels.Selector
 		if len(svc.Spec.Selector) == 0 {
 			svcSelector = labels.Nothing()
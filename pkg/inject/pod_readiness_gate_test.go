@@ -50,6 +50,21 @@ func Test_PodReadinessGate_Mutate(t *testing.T) {
 		},
 	}
 
+	svcOptedOut := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNS1,
+			Name:      "service-opted-out",
+			Labels: map[string]string{
+				"elbv2.k8s.aws/pod-readiness-gate-inject": "disabled",
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{
+				"app": "app-1",
+				"svc": "svc1",
+			},
+		},
+	}
 	targetTypeIP := elbv2api.TargetTypeIP
 	targetTypeInstance := elbv2api.TargetTypeInstance
 	tgb1 := &elbv2api.TargetGroupBinding{
@@ -112,6 +127,18 @@ func Test_PodReadinessGate_Mutate(t *testing.T) {
 			},
 		},
 	}
+	tgb6 := &elbv2api.TargetGroupBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tgb-6-l6qw6",
+			Namespace: testNS1,
+		},
+		Spec: elbv2api.TargetGroupBindingSpec{
+			TargetType: &targetTypeIP,
+			ServiceRef: elbv2api.ServiceReference{
+				Name: svcOptedOut.Name,
+			},
+		},
+	}
 
 	tests := []struct {
 		name      string
@@ -304,6 +331,28 @@ func Test_PodReadinessGate_Mutate(t *testing.T) {
 				EnablePodReadinessGateInject: true,
 			},
 		},
+		{
+			name:      "service opted-out readiness gate inject",
+			namespace: testNS1,
+			services:  []*corev1.Service{svc1, svcOptedOut},
+			tgbList:   []*elbv2api.TargetGroupBinding{tgb1, tgb6},
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"app": "app-1",
+						"svc": "svc1",
+					},
+				},
+			},
+			want: []corev1.PodReadinessGate{
+				{
+					ConditionType: "target-health.elbv2.k8s.aws/tgb-1-l6qw1",
+				},
+			},
+			config: Config{
+				EnablePodReadinessGateInject: true,
+			},
+		},
 		{
 			name:      "inject disabled",
 			namespace: testNS1,
@@ -2,27 +2,76 @@ package k8s
 
 const (
 	// Ingress events
-	IngressEventReasonConflictingIngressClass = "ConflictingIngressClass"
-	IngressEventReasonFailedLoadGroupID       = "FailedLoadGroupID"
-	IngressEventReasonFailedAddFinalizer      = "FailedAddFinalizer"
-	IngressEventReasonFailedRemoveFinalizer   = "FailedRemoveFinalizer"
-	IngressEventReasonFailedUpdateStatus      = "FailedUpdateStatus"
-	IngressEventReasonFailedBuildModel        = "FailedBuildModel"
-	IngressEventReasonFailedDeployModel       = "FailedDeployModel"
-	IngressEventReasonSuccessfullyReconciled  = "SuccessfullyReconciled"
+	IngressEventReasonConflictingIngressClass         = "ConflictingIngressClass"
+	IngressEventReasonAmbiguousSecurityGroup          = "AmbiguousSecurityGroup"
+	IngressEventReasonFailedLoadGroupID               = "FailedLoadGroupID"
+	IngressEventReasonFailedAddFinalizer              = "FailedAddFinalizer"
+	IngressEventReasonFailedRemoveFinalizer           = "FailedRemoveFinalizer"
+	IngressEventReasonFailedUpdateStatus              = "FailedUpdateStatus"
+	IngressEventReasonFailedBuildModel                = "FailedBuildModel"
+	IngressEventReasonFailedDeployModel               = "FailedDeployModel"
+	IngressEventReasonFailedReconcile                 = "FailedReconcile"
+	IngressEventReasonInvalidLogBucket                = "InvalidLogBucket"
+	IngressEventReasonQuotaExceeded                   = "QuotaExceeded"
+	IngressEventReasonLoadBalancerReplacementRequired = "LoadBalancerReplacementRequired"
+	IngressEventReasonNamespaceQuotaExceeded          = "NamespaceQuotaExceeded"
+	IngressEventReasonReconcilePaused                 = "ReconcilePaused"
+	IngressEventReasonDriftDetected                   = "DriftDetected"
+	IngressEventReasonDeletionProtected               = "DeletionProtected"
+	IngressEventReasonSlowReconcile                   = "SlowReconcile"
+	IngressEventReasonExpired                         = "Expired"
+	IngressEventReasonUnhealthyTargets                = "UnhealthyTargets"
+	IngressEventReasonIgnoredInboundCIDRs             = "IgnoredInboundCIDRs"
+	IngressEventReasonProvisionedResources            = "ProvisionedResources"
+	IngressEventReasonCostEstimate                    = "CostEstimate"
+	IngressEventReasonSuccessfullyReconciled          = "SuccessfullyReconciled"
 
 	// Service events
-	ServiceEventReasonFailedAddFinalizer     = "FailedAddFinalizer"
-	ServiceEventReasonFailedRemoveFinalizer  = "FailedRemoveFinalizer"
-	ServiceEventReasonFailedUpdateStatus     = "FailedUpdateStatus"
-	ServiceEventReasonFailedBuildModel       = "FailedBuildModel"
-	ServiceEventReasonFailedDeployModel      = "FailedDeployModel"
-	ServiceEventReasonSuccessfullyReconciled = "SuccessfullyReconciled"
+	ServiceEventReasonFailedLoadGroupID               = "FailedLoadGroupID"
+	ServiceEventReasonFailedAddFinalizer              = "FailedAddFinalizer"
+	ServiceEventReasonFailedRemoveFinalizer           = "FailedRemoveFinalizer"
+	ServiceEventReasonFailedUpdateStatus              = "FailedUpdateStatus"
+	ServiceEventReasonFailedBuildModel                = "FailedBuildModel"
+	ServiceEventReasonFailedDeployModel               = "FailedDeployModel"
+	ServiceEventReasonFailedReconcile                 = "FailedReconcile"
+	ServiceEventReasonInvalidLogBucket                = "InvalidLogBucket"
+	ServiceEventReasonQuotaExceeded                   = "QuotaExceeded"
+	ServiceEventReasonLoadBalancerReplacementRequired = "LoadBalancerReplacementRequired"
+	ServiceEventReasonNamespaceQuotaExceeded          = "NamespaceQuotaExceeded"
+	ServiceEventReasonReconcilePaused                 = "ReconcilePaused"
+	ServiceEventReasonDriftDetected                   = "DriftDetected"
+	ServiceEventReasonSlowReconcile                   = "SlowReconcile"
+	ServiceEventReasonExpired                         = "Expired"
+	ServiceEventReasonUnhealthyTargets                = "UnhealthyTargets"
+	ServiceEventReasonProvisionedResources            = "ProvisionedResources"
+	ServiceEventReasonCostEstimate                    = "CostEstimate"
+	ServiceEventReasonIgnoredManagedSecurityGroup     = "IgnoredManagedSecurityGroup"
+	ServiceEventReasonSuccessfullyReconciled          = "SuccessfullyReconciled"
 
 	// TargetGroupBinding events
 	TargetGroupBindingEventReasonFailedAddFinalizer     = "FailedAddFinalizer"
 	TargetGroupBindingEventReasonFailedRemoveFinalizer  = "FailedRemoveFinalizer"
 	TargetGroupBindingEventReasonFailedUpdateStatus     = "FailedUpdateStatus"
 	TargetGroupBindingEventReasonFailedCleanup          = "FailedCleanup"
+	TargetGroupBindingEventReasonQuotaExceeded          = "QuotaExceeded"
+	TargetGroupBindingEventReasonReconcilePaused        = "ReconcilePaused"
+	TargetGroupBindingEventReasonDriftDetected          = "DriftDetected"
+	TargetGroupBindingEventReasonSlowReconcile          = "SlowReconcile"
 	TargetGroupBindingEventReasonSuccessfullyReconciled = "SuccessfullyReconciled"
+
+	// ListenerRuleBinding events
+	ListenerRuleBindingEventReasonFailedAddFinalizer     = "FailedAddFinalizer"
+	ListenerRuleBindingEventReasonFailedRemoveFinalizer  = "FailedRemoveFinalizer"
+	ListenerRuleBindingEventReasonFailedUpdateStatus     = "FailedUpdateStatus"
+	ListenerRuleBindingEventReasonFailedReconcile        = "FailedReconcile"
+	ListenerRuleBindingEventReasonFailedCleanup          = "FailedCleanup"
+	ListenerRuleBindingEventReasonSuccessfullyReconciled = "SuccessfullyReconciled"
+
+	// SecurityGroupPolicy events
+	SecurityGroupPolicyEventReasonInvalidPolicy = "InvalidPolicy"
+	SecurityGroupPolicyEventReasonPolicyIgnored = "PolicyIgnored"
+
+	// AWS resource mutation events
+	EventReasonAWSResourceMutated  = "AWSResourceMutated"
+	EventReasonAWSResourceDiverged = "AWSResourceDiverged"
 )
@@ -0,0 +1,120 @@
+package k8s
+
+import (
+	"context"
+	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sync"
+)
+
+const (
+	// the label of nodes that endpoint topology refers to by hostname.
+	labelNodeHostname = "kubernetes.io/hostname"
+)
+
+// FargateDetector detects workloads that run on Fargate, which cannot be registered as instance targets.
+type FargateDetector interface {
+	// IsFargateOnlyCluster returns whether all nodes of cluster are Fargate nodes, it returns false if there are no nodes.
+	IsFargateOnlyCluster(ctx context.Context) (bool, error)
+
+	// IsFargateOnlyService returns whether all pods selected by service run on Fargate nodes.
+	// If there are no pods, it returns the last result for service, or false if unknown.
+	IsFargateOnlyService(ctx context.Context, svc *corev1.Service) (bool, error)
+}
+
+// NewDefaultFargateDetector constructs new defaultFargateDetector.
+func NewDefaultFargateDetector(k8sClient client.Client) *defaultFargateDetector {
+	return &defaultFargateDetector{
+		k8sClient:           k8sClient,
+		fargateOnlyServices: make(map[types.NamespacedName]bool),
+	}
+}
+
+var _ FargateDetector = &defaultFargateDetector{}
+
+// default implementation for FargateDetector, which detects Fargate nodes by their labels,
+// and the nodes of pods selected by service via the topology of service's EndpointSlices.
+type defaultFargateDetector struct {
+	k8sClient client.Client
+
+	// fargateOnlyServicesMutex protects fargateOnlyServices.
+	fargateOnlyServicesMutex sync.Mutex
+	// fargateOnlyServices records the services last detected to select only Fargate pods.
+	fargateOnlyServices map[types.NamespacedName]bool
+}
+
+func (d *defaultFargateDetector) IsFargateOnlyCluster(ctx context.Context) (bool, error) {
+	nodeList := &corev1.NodeList{}
+	if err := d.k8sClient.List(ctx, nodeList); err != nil {
+		return false, err
+	}
+	if len(nodeList.Items) == 0 {
+		return false, nil
+	}
+	for i := range nodeList.Items {
+		if !IsFargateNode(&nodeList.Items[i]) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (d *defaultFargateDetector) IsFargateOnlyService(ctx context.Context, svc *corev1.Service) (bool, error) {
+	svcKey := NamespacedName(svc)
+	epSliceList := &discovery.EndpointSliceList{}
+	if err := d.k8sClient.List(ctx, epSliceList,
+		client.InNamespace(svc.Namespace),
+		client.MatchingLabels{discovery.LabelServiceName: svc.Name}); err != nil {
+		return false, err
+	}
+	nodeHostnames := sets.NewString()
+	for _, epSlice := range epSliceList.Items {
+		for _, ep := range epSlice.Endpoints {
+			if ep.TargetRef == nil || ep.TargetRef.Kind != "Pod" {
+				continue
+			}
+			nodeHostname, ok := ep.Topology[labelNodeHostname]
+			if !ok {
+				return d.recordFargateOnlyService(svcKey, false), nil
+			}
+			nodeHostnames.Insert(nodeHostname)
+		}
+	}
+	if len(nodeHostnames) == 0 {
+		// keep the last result while service has no pods, so that scaling to zero doesn't flip its TargetType.
+		d.fargateOnlyServicesMutex.Lock()
+		defer d.fargateOnlyServicesMutex.Unlock()
+		return d.fargateOnlyServices[svcKey], nil
+	}
+
+	for _, nodeHostname := range nodeHostnames.List() {
+		// nodes are named by their hostname, which avoids listing all nodes of cluster.
+		node := &corev1.Node{}
+		if err := d.k8sClient.Get(ctx, types.NamespacedName{Name: nodeHostname}, node); err != nil {
+			if apierrors.IsNotFound(err) {
+				return d.recordFargateOnlyService(svcKey, false), nil
+			}
+			return false, err
+		}
+		if !IsFargateNode(node) {
+			return d.recordFargateOnlyService(svcKey, false), nil
+		}
+	}
+	return d.recordFargateOnlyService(svcKey, true), nil
+}
+
+// recordFargateOnlyService records whether service selects only Fargate pods, and returns fargateOnly.
+func (d *defaultFargateDetector) recordFargateOnlyService(svcKey types.NamespacedName, fargateOnly bool) bool {
+	d.fargateOnlyServicesMutex.Lock()
+	defer d.fargateOnlyServicesMutex.Unlock()
+	if fargateOnly {
+		d.fargateOnlyServices[svcKey] = true
+	} else {
+		delete(d.fargateOnlyServices, svcKey)
+	}
+	return fargateOnly
+}
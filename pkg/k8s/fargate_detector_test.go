@@ -0,0 +1,195 @@
+package k8s
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+func Test_defaultFargateDetector_IsFargateOnlyCluster(t *testing.T) {
+	tests := []struct {
+		name  string
+		nodes []*corev1.Node
+		want  bool
+	}{
+		{
+			name: "all nodes are Fargate nodes",
+			nodes: []*corev1.Node{
+				buildNodeForFargateDetection("fargate-ip-192-168-1-1", true),
+				buildNodeForFargateDetection("fargate-ip-192-168-1-2", true),
+			},
+			want: true,
+		},
+		{
+			name: "some nodes are Fargate nodes",
+			nodes: []*corev1.Node{
+				buildNodeForFargateDetection("fargate-ip-192-168-1-1", true),
+				buildNodeForFargateDetection("ip-192-168-1-2", false),
+			},
+			want: false,
+		},
+		{
+			name:  "no nodes",
+			nodes: nil,
+			want:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			for _, node := range tt.nodes {
+				assert.NoError(t, k8sClient.Create(ctx, node.DeepCopy()))
+			}
+			d := NewDefaultFargateDetector(k8sClient)
+			got, err := d.IsFargateOnlyCluster(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_defaultFargateDetector_IsFargateOnlyService(t *testing.T) {
+	nodes := []*corev1.Node{
+		buildNodeForFargateDetection("fargate-ip-192-168-1-1", true),
+		buildNodeForFargateDetection("fargate-ip-192-168-1-2", true),
+		buildNodeForFargateDetection("ip-192-168-1-3", false),
+	}
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "svc",
+		},
+	}
+	tests := []struct {
+		name          string
+		nodeHostnames []string
+		want          bool
+	}{
+		{
+			name:          "all pods on Fargate nodes",
+			nodeHostnames: []string{"fargate-ip-192-168-1-1", "fargate-ip-192-168-1-2"},
+			want:          true,
+		},
+		{
+			name:          "some pods on EC2 nodes",
+			nodeHostnames: []string{"fargate-ip-192-168-1-1", "ip-192-168-1-3"},
+			want:          false,
+		},
+		{
+			name:          "pods on unknown nodes",
+			nodeHostnames: []string{"fargate-ip-192-168-1-1", "ip-192-168-1-4"},
+			want:          false,
+		},
+		{
+			name:          "no pods",
+			nodeHostnames: nil,
+			want:          false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			for _, node := range nodes {
+				assert.NoError(t, k8sClient.Create(ctx, node.DeepCopy()))
+			}
+			epSlice := &discovery.EndpointSlice{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns",
+					Name:      "svc-abcde",
+					Labels: map[string]string{
+						discovery.LabelServiceName: "svc",
+					},
+				},
+				AddressType: discovery.AddressTypeIPv4,
+			}
+			for _, nodeHostname := range tt.nodeHostnames {
+				epSlice.Endpoints = append(epSlice.Endpoints, discovery.Endpoint{
+					Addresses: []string{"192.168.2.1"},
+					TargetRef: &corev1.ObjectReference{Kind: "Pod", Namespace: "ns", Name: "pod-" + nodeHostname},
+					Topology: map[string]string{
+						"kubernetes.io/hostname": nodeHostname,
+					},
+				})
+			}
+			assert.NoError(t, k8sClient.Create(ctx, epSlice))
+
+			d := NewDefaultFargateDetector(k8sClient)
+			got, err := d.IsFargateOnlyService(ctx, svc)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_defaultFargateDetector_IsFargateOnlyService_scaledToZero(t *testing.T) {
+	ctx := context.Background()
+	k8sSchema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+	assert.NoError(t, k8sClient.Create(ctx, buildNodeForFargateDetection("fargate-ip-192-168-1-1", true)))
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "svc",
+		},
+	}
+	epSlice := &discovery.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "svc-abcde",
+			Labels: map[string]string{
+				discovery.LabelServiceName: "svc",
+			},
+		},
+		AddressType: discovery.AddressTypeIPv4,
+		Endpoints: []discovery.Endpoint{
+			{
+				Addresses: []string{"192.168.2.1"},
+				TargetRef: &corev1.ObjectReference{Kind: "Pod", Namespace: "ns", Name: "pod-1"},
+				Topology: map[string]string{
+					"kubernetes.io/hostname": "fargate-ip-192-168-1-1",
+				},
+			},
+		},
+	}
+	assert.NoError(t, k8sClient.Create(ctx, epSlice))
+
+	d := NewDefaultFargateDetector(k8sClient)
+	got, err := d.IsFargateOnlyService(ctx, svc)
+	assert.NoError(t, err)
+	assert.True(t, got)
+
+	// the last result is kept once service scaled to zero.
+	epSlice.Endpoints = nil
+	assert.NoError(t, k8sClient.Update(ctx, epSlice))
+	got, err = d.IsFargateOnlyService(ctx, svc)
+	assert.NoError(t, err)
+	assert.True(t, got)
+}
+
+func buildNodeForFargateDetection(name string, fargate bool) *corev1.Node {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				"kubernetes.io/hostname": name,
+			},
+		},
+	}
+	if fargate {
+		node.Labels["eks.amazonaws.com/compute-type"] = "fargate"
+	}
+	return node
+}
@@ -0,0 +1,72 @@
+package k8s
+
+import (
+	"context"
+	"hash/fnv"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NamespaceMatcher tests whether objects in a namespace are sharded to this controller.
+type NamespaceMatcher interface {
+	// Matches returns whether objects in specified namespace should be reconciled by this controller.
+	Matches(ctx context.Context, namespace string) (bool, error)
+
+	// MatchesExplicitGroup returns whether the explicit group of specified name should be reconciled by this controller.
+	// explicit groups span namespaces, they are sharded by hashing their names instead.
+	MatchesExplicitGroup(groupName string) bool
+}
+
+// NewDefaultNamespaceMatcher constructs new defaultNamespaceMatcher that reconciles all explicit groups.
+func NewDefaultNamespaceMatcher(k8sClient client.Client, selector labels.Selector) *defaultNamespaceMatcher {
+	return NewShardedNamespaceMatcher(k8sClient, selector, 1, 0)
+}
+
+// NewShardedNamespaceMatcher constructs new defaultNamespaceMatcher that reconciles explicit groups
+// whose names hash to shardIndex among shardCount shards.
+func NewShardedNamespaceMatcher(k8sClient client.Client, selector labels.Selector, shardCount int, shardIndex int) *defaultNamespaceMatcher {
+	return &defaultNamespaceMatcher{
+		k8sClient:  k8sClient,
+		selector:   selector,
+		shardCount: shardCount,
+		shardIndex: shardIndex,
+	}
+}
+
+var _ NamespaceMatcher = &defaultNamespaceMatcher{}
+
+// default implementation for NamespaceMatcher, which matches namespaces by their labels.
+type defaultNamespaceMatcher struct {
+	k8sClient client.Client
+	selector  labels.Selector
+
+	shardCount int
+	shardIndex int
+}
+
+func (m *defaultNamespaceMatcher) Matches(ctx context.Context, namespace string) (bool, error) {
+	if m.selector.Empty() || namespace == "" {
+		return true, nil
+	}
+	ns := &corev1.Namespace{}
+	if err := m.k8sClient.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		// objects are gone together with their namespace, there is nothing to reconcile.
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return m.selector.Matches(labels.Set(ns.Labels)), nil
+}
+
+func (m *defaultNamespaceMatcher) MatchesExplicitGroup(groupName string) bool {
+	if m.shardCount <= 1 {
+		return true
+	}
+	hash := fnv.New32a()
+	hash.Write([]byte(groupName))
+	return int(hash.Sum32()%uint32(m.shardCount)) == m.shardIndex
+}
@@ -0,0 +1,111 @@
+package k8s
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+func Test_defaultNamespaceMatcher_Matches(t *testing.T) {
+	tests := []struct {
+		name       string
+		namespaces []*corev1.Namespace
+		selector   string
+		namespace  string
+		want       bool
+	}{
+		{
+			name:      "empty selector matches any namespace",
+			selector:  "",
+			namespace: "awesome-ns",
+			want:      true,
+		},
+		{
+			name: "namespace labels matches selector",
+			namespaces: []*corev1.Namespace{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "awesome-ns",
+						Labels: map[string]string{"shard": "a"},
+					},
+				},
+			},
+			selector:  "shard=a",
+			namespace: "awesome-ns",
+			want:      true,
+		},
+		{
+			name: "namespace labels mismatches selector",
+			namespaces: []*corev1.Namespace{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "awesome-ns",
+						Labels: map[string]string{"shard": "b"},
+					},
+				},
+			},
+			selector:  "shard=a",
+			namespace: "awesome-ns",
+			want:      false,
+		},
+		{
+			name:      "namespace not found",
+			selector:  "shard=a",
+			namespace: "awesome-ns",
+			want:      false,
+		},
+		{
+			name:      "cluster scoped objects always matches",
+			selector:  "shard=a",
+			namespace: "",
+			want:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			for _, ns := range tt.namespaces {
+				assert.NoError(t, k8sClient.Create(ctx, ns.DeepCopy()))
+			}
+			selector, err := labels.Parse(tt.selector)
+			assert.NoError(t, err)
+
+			m := NewDefaultNamespaceMatcher(k8sClient, selector)
+			got, err := m.Matches(ctx, tt.namespace)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_defaultNamespaceMatcher_MatchesExplicitGroup(t *testing.T) {
+	groupNames := []string{"awesome-group", "another-group", "third-group", "fourth-group", "fifth-group"}
+	t.Run("single shard matches any group", func(t *testing.T) {
+		m := NewDefaultNamespaceMatcher(nil, labels.Everything())
+		for _, groupName := range groupNames {
+			assert.True(t, m.MatchesExplicitGroup(groupName))
+		}
+	})
+	t.Run("each group matches exactly one shard", func(t *testing.T) {
+		shardCount := 3
+		for _, groupName := range groupNames {
+			matchedShards := 0
+			for shardIndex := 0; shardIndex < shardCount; shardIndex++ {
+				m := NewShardedNamespaceMatcher(nil, labels.Everything(), shardCount, shardIndex)
+				if m.MatchesExplicitGroup(groupName) {
+					matchedShards++
+				}
+			}
+			assert.Equal(t, 1, matchedShards, groupName)
+		}
+	})
+}
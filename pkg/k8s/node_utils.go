@@ -6,6 +6,48 @@ import (
 	"strings"
 )
 
+const (
+	// nodes in Fargate are labeled with their compute type.
+	labelEKSComputeType   = "eks.amazonaws.com/compute-type"
+	eksComputeTypeFargate = "fargate"
+)
+
+// keys of taints that indicate the node is about to be terminated.
+var nodeTerminationTaintKeys = []string{
+	// tainted by aws-node-termination-handler on EC2 Spot interruption notices and other termination events.
+	"aws-node-termination-handler/spot-itn",
+	"aws-node-termination-handler/rebalance-recommendation",
+	"aws-node-termination-handler/asg-lifecycle-termination",
+	"aws-node-termination-handler/scheduled-maintenance",
+	// tainted by cluster-autoscaler before scaling down the node.
+	"ToBeDeletedByClusterAutoscaler",
+	// tainted by karpenter before disrupting the node.
+	"karpenter.sh/disrupted",
+	"karpenter.sh/disruption",
+}
+
+// IsNodeDraining returns whether node is tainted to be terminated, e.g. on EC2 Spot interruption, or cordoned with its pods being evicted.
+// a plain cordon doesn't count, since nodes are also cordoned for maintenance while their pods keep serving.
+func IsNodeDraining(node *corev1.Node) bool {
+	for _, taint := range node.Spec.Taints {
+		for _, taintKey := range nodeTerminationTaintKeys {
+			if taint.Key == taintKey {
+				return true
+			}
+		}
+		// pods are evicted from nodes by NoExecute taints, e.g. node.kubernetes.io/out-of-service.
+		if node.Spec.Unschedulable && taint.Effect == corev1.TaintEffectNoExecute {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFargateNode returns whether node is a Fargate node.
+func IsFargateNode(node *corev1.Node) bool {
+	return node.Labels[labelEKSComputeType] == eksComputeTypeFargate
+}
+
 // IsNodeReady returns whether node is ready.
 func IsNodeReady(node *corev1.Node) bool {
 	nodeReadyCond := GetNodeCondition(node, corev1.NodeReady)
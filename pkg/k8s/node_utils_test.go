@@ -69,6 +69,117 @@ func TestIsNodeReady(t *testing.T) {
 	}
 }
 
+func TestIsNodeDraining(t *testing.T) {
+	type args struct {
+		node *corev1.Node
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "node is schedulable without taints",
+			args: args{
+				node: &corev1.Node{},
+			},
+			want: false,
+		},
+		{
+			name: "node is cordoned",
+			args: args{
+				node: &corev1.Node{
+					Spec: corev1.NodeSpec{
+						Unschedulable: true,
+						Taints: []corev1.Taint{
+							{
+								Key:    "node.kubernetes.io/unschedulable",
+								Effect: corev1.TaintEffectNoSchedule,
+							},
+						},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "node is cordoned with pods being evicted",
+			args: args{
+				node: &corev1.Node{
+					Spec: corev1.NodeSpec{
+						Unschedulable: true,
+						Taints: []corev1.Taint{
+							{
+								Key:    "node.kubernetes.io/out-of-service",
+								Value:  "nodeshutdown",
+								Effect: corev1.TaintEffectNoExecute,
+							},
+						},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "node is tainted on spot interruption",
+			args: args{
+				node: &corev1.Node{
+					Spec: corev1.NodeSpec{
+						Taints: []corev1.Taint{
+							{
+								Key:    "aws-node-termination-handler/spot-itn",
+								Value:  "1602619200",
+								Effect: corev1.TaintEffectNoSchedule,
+							},
+						},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "node is tainted by cluster-autoscaler",
+			args: args{
+				node: &corev1.Node{
+					Spec: corev1.NodeSpec{
+						Taints: []corev1.Taint{
+							{
+								Key:    "ToBeDeletedByClusterAutoscaler",
+								Value:  "1602619200",
+								Effect: corev1.TaintEffectNoSchedule,
+							},
+						},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "node is tainted for other purposes",
+			args: args{
+				node: &corev1.Node{
+					Spec: corev1.NodeSpec{
+						Taints: []corev1.Taint{
+							{
+								Key:    "dedicated",
+								Value:  "gpu",
+								Effect: corev1.TaintEffectNoSchedule,
+							},
+						},
+					},
+				},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := IsNodeDraining(tt.args.node)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestGetNodeCondition(t *testing.T) {
 	type args struct {
 		node          *corev1.Node
@@ -0,0 +1,9 @@
+package k8s
+
+const (
+	// AnnotationReconcile is the annotation on TargetGroupBindings to control their reconcile, mutations of AWS resources are frozen when it's ReconcileModePaused.
+	AnnotationReconcile = "elbv2.k8s.aws/reconcile"
+
+	// ReconcileModePaused is the reconcile mode that freezes mutations of AWS resources, while drifts are still reported.
+	ReconcileModePaused = "paused"
+)
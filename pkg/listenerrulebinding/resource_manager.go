@@ -0,0 +1,411 @@
+package listenerrulebinding
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/audit"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	elbv2equality "sigs.k8s.io/aws-load-balancer-controller/pkg/equality/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"strconv"
+)
+
+const (
+	// rules created for ListenerRuleBinding are tagged with the cluster and the namespaced name of ListenerRuleBinding,
+	// so that they can be found again if the status of ListenerRuleBinding is lost.
+	tagKeyCluster             = "elbv2.k8s.aws/cluster"
+	tagKeyListenerRuleBinding = "elbv2.k8s.aws/listener-rule-binding"
+
+	// ELBV2 API supports up to 20 resource per DescribeTags API call.
+	describeTagsChunkSize = 20
+)
+
+// ResourceManager manages the ListenerRuleBinding resource.
+type ResourceManager interface {
+	// Reconcile reconciles the listener rule for ListenerRuleBinding, the rule is recorded into status of ListenerRuleBinding.
+	Reconcile(ctx context.Context, lrb *elbv2api.ListenerRuleBinding) error
+
+	// Cleanup deletes the listener rule for ListenerRuleBinding.
+	Cleanup(ctx context.Context, lrb *elbv2api.ListenerRuleBinding) error
+}
+
+// NewDefaultResourceManager constructs new defaultResourceManager.
+// rules are only attached to the listeners of allowedListenerARNs.
+func NewDefaultResourceManager(k8sClient client.Client, elbv2Client services.ELBV2, clusterName string, allowedListenerARNs []string,
+	logger logr.Logger) *defaultResourceManager {
+	return &defaultResourceManager{
+		k8sClient:           k8sClient,
+		elbv2Client:         elbv2Client,
+		clusterName:         clusterName,
+		allowedListenerARNs: sets.NewString(allowedListenerARNs...),
+		logger:              logger,
+	}
+}
+
+var _ ResourceManager = &defaultResourceManager{}
+
+// default implementation for ListenerRuleBinding.
+// the listener rule is tracked by the status of ListenerRuleBinding, and tagged with the namespaced name of ListenerRuleBinding.
+// if the status is lost(e.g. failed to update status after rule created), the rule tagged for ListenerRuleBinding is adopted.
+type defaultResourceManager struct {
+	k8sClient   client.Client
+	elbv2Client services.ELBV2
+	clusterName string
+	// listener ARNs that rules can be attached to, since the listeners are managed outside of the namespaces of ListenerRuleBindings.
+	allowedListenerARNs sets.String
+	logger              logr.Logger
+}
+
+func (m *defaultResourceManager) Reconcile(ctx context.Context, lrb *elbv2api.ListenerRuleBinding) error {
+	if !m.allowedListenerARNs.Has(lrb.Spec.ListenerARN) {
+		return runtime.NewClassifiedError(runtime.ErrorClassInvalidConfig,
+			errors.Errorf("listener %v isn't allowed for ListenerRuleBinding, allowed listeners are configured via --listener-rule-binding-allowed-listeners", lrb.Spec.ListenerARN))
+	}
+	tgARN, err := m.resolveTargetGroupARN(ctx, lrb)
+	if err != nil {
+		return err
+	}
+	desiredActions := buildSDKActions(tgARN)
+	desiredConditions, err := buildSDKRuleConditions(lrb.Spec.Conditions)
+	if err != nil {
+		return err
+	}
+
+	sdkRule, err := m.findSDKRule(ctx, lrb, lrb.Spec.ListenerARN)
+	if err != nil {
+		return err
+	}
+	if sdkRule == nil {
+		sdkRule, err = m.createSDKRule(ctx, lrb, desiredActions, desiredConditions)
+		if err != nil {
+			return err
+		}
+	} else {
+		if err := m.updateSDKRule(ctx, lrb, sdkRule, desiredActions, desiredConditions); err != nil {
+			return err
+		}
+	}
+
+	// the rule is moved by creating it on new listener before deleting it from previous listener, so that traffic isn't interrupted.
+	// the status keeps tracking the previous rule until it's deleted, while the new rule can be found again via its tags.
+	if lrb.Status.RuleARN != nil && awssdk.StringValue(lrb.Status.ListenerARN) != lrb.Spec.ListenerARN {
+		if err := m.deleteSDKRule(ctx, awssdk.StringValue(lrb.Status.RuleARN)); err != nil {
+			return err
+		}
+	}
+	lrb.Status.ListenerARN = awssdk.String(lrb.Spec.ListenerARN)
+	lrb.Status.RuleARN = sdkRule.RuleArn
+	return nil
+}
+
+func (m *defaultResourceManager) Cleanup(ctx context.Context, lrb *elbv2api.ListenerRuleBinding) error {
+	ruleARN := awssdk.StringValue(lrb.Status.RuleARN)
+	if ruleARN == "" {
+		// the rule might have been created without status updated, which is found via its tags.
+		if !m.allowedListenerARNs.Has(lrb.Spec.ListenerARN) {
+			return nil
+		}
+		sdkRule, err := m.findSDKRule(ctx, lrb, lrb.Spec.ListenerARN)
+		if err != nil {
+			return err
+		}
+		if sdkRule == nil {
+			return nil
+		}
+		ruleARN = awssdk.StringValue(sdkRule.RuleArn)
+	}
+	if err := m.deleteSDKRule(ctx, ruleARN); err != nil {
+		return err
+	}
+	lrb.Status.RuleARN = nil
+	lrb.Status.ListenerARN = nil
+	return nil
+}
+
+// resolveTargetGroupARN resolves the ARN of TargetGroup from referenced TargetGroupBinding.
+func (m *defaultResourceManager) resolveTargetGroupARN(ctx context.Context, lrb *elbv2api.ListenerRuleBinding) (string, error) {
+	tgbKey := types.NamespacedName{Namespace: lrb.Namespace, Name: lrb.Spec.TargetGroupBindingRef.Name}
+	tgb := &elbv2api.TargetGroupBinding{}
+	if err := m.k8sClient.Get(ctx, tgbKey, tgb); err != nil {
+		return "", errors.Wrapf(err, "failed to get targetGroupBinding: %v", tgbKey)
+	}
+	return tgb.Spec.TargetGroupARN, nil
+}
+
+// findSDKRule finds the rule for ListenerRuleBinding on listener, returns nil if not found.
+func (m *defaultResourceManager) findSDKRule(ctx context.Context, lrb *elbv2api.ListenerRuleBinding, listenerARN string) (*elbv2sdk.Rule, error) {
+	req := &elbv2sdk.DescribeRulesInput{
+		ListenerArn: awssdk.String(listenerARN),
+	}
+	sdkRules, err := m.elbv2Client.DescribeRulesAsList(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	var ruleARNs []string
+	for _, sdkRule := range sdkRules {
+		if lrb.Status.RuleARN != nil && awssdk.StringValue(sdkRule.RuleArn) == awssdk.StringValue(lrb.Status.RuleARN) {
+			return sdkRule, nil
+		}
+		if !awssdk.BoolValue(sdkRule.IsDefault) {
+			ruleARNs = append(ruleARNs, awssdk.StringValue(sdkRule.RuleArn))
+		}
+	}
+	if len(ruleARNs) == 0 {
+		return nil, nil
+	}
+	tagsByARN, err := m.describeRuleTags(ctx, ruleARNs)
+	if err != nil {
+		return nil, err
+	}
+	ruleTags := m.buildRuleTags(lrb)
+	for _, sdkRule := range sdkRules {
+		if matchesTags(tagsByARN[awssdk.StringValue(sdkRule.RuleArn)], ruleTags) {
+			return sdkRule, nil
+		}
+	}
+	return nil, nil
+}
+
+// describeRuleTags describes the tags of rules by ARN.
+func (m *defaultResourceManager) describeRuleTags(ctx context.Context, ruleARNs []string) (map[string]map[string]string, error) {
+	tagsByARN := make(map[string]map[string]string, len(ruleARNs))
+	for _, arnsChunk := range algorithm.ChunkStrings(ruleARNs, describeTagsChunkSize) {
+		req := &elbv2sdk.DescribeTagsInput{
+			ResourceArns: awssdk.StringSlice(arnsChunk),
+		}
+		resp, err := m.elbv2Client.DescribeTagsWithContext(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		for _, tagDescription := range resp.TagDescriptions {
+			tags := make(map[string]string, len(tagDescription.Tags))
+			for _, tag := range tagDescription.Tags {
+				tags[awssdk.StringValue(tag.Key)] = awssdk.StringValue(tag.Value)
+			}
+			tagsByARN[awssdk.StringValue(tagDescription.ResourceArn)] = tags
+		}
+	}
+	return tagsByARN, nil
+}
+
+// buildRuleTags builds the tags that identify the rule of ListenerRuleBinding.
+func (m *defaultResourceManager) buildRuleTags(lrb *elbv2api.ListenerRuleBinding) map[string]string {
+	return map[string]string{
+		tagKeyCluster:             m.clusterName,
+		tagKeyListenerRuleBinding: k8s.NamespacedName(lrb).String(),
+	}
+}
+
+func (m *defaultResourceManager) createSDKRule(ctx context.Context, lrb *elbv2api.ListenerRuleBinding,
+	desiredActions []*elbv2sdk.Action, desiredConditions []*elbv2sdk.RuleCondition) (*elbv2sdk.Rule, error) {
+	ruleTags := m.buildRuleTags(lrb)
+	req := &elbv2sdk.CreateRuleInput{
+		ListenerArn: awssdk.String(lrb.Spec.ListenerARN),
+		Priority:    awssdk.Int64(lrb.Spec.Priority),
+		Actions:     desiredActions,
+		Conditions:  desiredConditions,
+		Tags:        make([]*elbv2sdk.Tag, 0, len(ruleTags)),
+	}
+	for _, key := range sets.StringKeySet(ruleTags).List() {
+		req.Tags = append(req.Tags, &elbv2sdk.Tag{Key: awssdk.String(key), Value: awssdk.String(ruleTags[key])})
+	}
+	m.logger.Info("creating listener rule",
+		"listenerRuleBinding", k8s.NamespacedName(lrb),
+		"listenerARN", lrb.Spec.ListenerARN)
+	resp, err := m.elbv2Client.CreateRuleWithContext(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	sdkRule := resp.Rules[0]
+	m.logger.Info("created listener rule",
+		"listenerRuleBinding", k8s.NamespacedName(lrb),
+		"arn", awssdk.StringValue(sdkRule.RuleArn))
+	audit.RecordMutation(ctx, audit.Mutation{
+		ResourceKind: audit.ResourceKindListenerRule,
+		ResourceID:   awssdk.StringValue(sdkRule.RuleArn),
+		Operation:    audit.OperationCreate,
+		Diff:         audit.ComputeDiff(nil, buildRuleSettingsForAudit(sdkRule.Priority, desiredActions, desiredConditions)),
+	})
+	return sdkRule, nil
+}
+
+func (m *defaultResourceManager) updateSDKRule(ctx context.Context, lrb *elbv2api.ListenerRuleBinding, sdkRule *elbv2sdk.Rule,
+	desiredActions []*elbv2sdk.Action, desiredConditions []*elbv2sdk.RuleCondition) error {
+	desiredPriority := strconv.FormatInt(lrb.Spec.Priority, 10)
+	if awssdk.StringValue(sdkRule.Priority) != desiredPriority {
+		req := &elbv2sdk.SetRulePrioritiesInput{
+			RulePriorities: []*elbv2sdk.RulePriorityPair{
+				{
+					RuleArn:  sdkRule.RuleArn,
+					Priority: awssdk.Int64(lrb.Spec.Priority),
+				},
+			},
+		}
+		m.logger.Info("modifying listener rule priority",
+			"listenerRuleBinding", k8s.NamespacedName(lrb),
+			"arn", awssdk.StringValue(sdkRule.RuleArn))
+		if _, err := m.elbv2Client.SetRulePrioritiesWithContext(ctx, req); err != nil {
+			return err
+		}
+		m.logger.Info("modified listener rule priority",
+			"listenerRuleBinding", k8s.NamespacedName(lrb),
+			"arn", awssdk.StringValue(sdkRule.RuleArn))
+		audit.RecordMutation(ctx, audit.Mutation{
+			ResourceKind: audit.ResourceKindListenerRule,
+			ResourceID:   awssdk.StringValue(sdkRule.RuleArn),
+			Operation:    audit.OperationModify,
+			Diff:         audit.ComputeDiff(map[string]interface{}{"priority": sdkRule.Priority}, map[string]interface{}{"priority": desiredPriority}),
+		})
+	}
+
+	if !isSDKRuleSettingsDrifted(sdkRule, desiredActions, desiredConditions) {
+		return nil
+	}
+	req := &elbv2sdk.ModifyRuleInput{
+		RuleArn:    sdkRule.RuleArn,
+		Actions:    desiredActions,
+		Conditions: desiredConditions,
+	}
+	m.logger.Info("modifying listener rule",
+		"listenerRuleBinding", k8s.NamespacedName(lrb),
+		"arn", awssdk.StringValue(sdkRule.RuleArn))
+	if _, err := m.elbv2Client.ModifyRuleWithContext(ctx, req); err != nil {
+		return err
+	}
+	m.logger.Info("modified listener rule",
+		"listenerRuleBinding", k8s.NamespacedName(lrb),
+		"arn", awssdk.StringValue(sdkRule.RuleArn))
+	audit.RecordMutation(ctx, audit.Mutation{
+		ResourceKind: audit.ResourceKindListenerRule,
+		ResourceID:   awssdk.StringValue(sdkRule.RuleArn),
+		Operation:    audit.OperationModify,
+		Diff: audit.ComputeDiff(buildRuleSettingsForAudit(nil, sdkRule.Actions, sdkRule.Conditions),
+			buildRuleSettingsForAudit(nil, desiredActions, desiredConditions)),
+	})
+	return nil
+}
+
+func (m *defaultResourceManager) deleteSDKRule(ctx context.Context, ruleARN string) error {
+	req := &elbv2sdk.DeleteRuleInput{
+		RuleArn: awssdk.String(ruleARN),
+	}
+	m.logger.Info("deleting listener rule",
+		"arn", ruleARN)
+	if _, err := m.elbv2Client.DeleteRuleWithContext(ctx, req); err != nil {
+		if isRuleNotFoundError(err) {
+			return nil
+		}
+		return err
+	}
+	m.logger.Info("deleted listener rule",
+		"arn", ruleARN)
+	audit.RecordMutation(ctx, audit.Mutation{
+		ResourceKind: audit.ResourceKindListenerRule,
+		ResourceID:   ruleARN,
+		Operation:    audit.OperationDelete,
+	})
+	return nil
+}
+
+func isSDKRuleSettingsDrifted(sdkRule *elbv2sdk.Rule, desiredActions []*elbv2sdk.Action, desiredConditions []*elbv2sdk.RuleCondition) bool {
+	if !cmp.Equal(desiredActions, sdkRule.Actions, elbv2equality.CompareOptionForActions()) {
+		return true
+	}
+	if !cmp.Equal(desiredConditions, sdkRule.Conditions, elbv2equality.CompareOptionForRuleConditions()) {
+		return true
+	}
+	return false
+}
+
+// matchesTags checks whether tags contains all expectedTags.
+func matchesTags(tags map[string]string, expectedTags map[string]string) bool {
+	for key, value := range expectedTags {
+		if tags[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+func buildSDKActions(tgARN string) []*elbv2sdk.Action {
+	return []*elbv2sdk.Action{
+		{
+			Type: awssdk.String(elbv2sdk.ActionTypeEnumForward),
+			ForwardConfig: &elbv2sdk.ForwardActionConfig{
+				TargetGroups: []*elbv2sdk.TargetGroupTuple{
+					{
+						TargetGroupArn: awssdk.String(tgARN),
+					},
+				},
+			},
+		},
+	}
+}
+
+func buildSDKRuleConditions(conditions elbv2api.ListenerRuleConditions) ([]*elbv2sdk.RuleCondition, error) {
+	var sdkConditions []*elbv2sdk.RuleCondition
+	if len(conditions.HostHeaders) != 0 {
+		sdkConditions = append(sdkConditions, &elbv2sdk.RuleCondition{
+			Field: awssdk.String("host-header"),
+			HostHeaderConfig: &elbv2sdk.HostHeaderConditionConfig{
+				Values: awssdk.StringSlice(conditions.HostHeaders),
+			},
+		})
+	}
+	if len(conditions.PathPatterns) != 0 {
+		sdkConditions = append(sdkConditions, &elbv2sdk.RuleCondition{
+			Field: awssdk.String("path-pattern"),
+			PathPatternConfig: &elbv2sdk.PathPatternConditionConfig{
+				Values: awssdk.StringSlice(conditions.PathPatterns),
+			},
+		})
+	}
+	if len(conditions.HTTPRequestMethods) != 0 {
+		sdkConditions = append(sdkConditions, &elbv2sdk.RuleCondition{
+			Field: awssdk.String("http-request-method"),
+			HttpRequestMethodConfig: &elbv2sdk.HttpRequestMethodConditionConfig{
+				Values: awssdk.StringSlice(conditions.HTTPRequestMethods),
+			},
+		})
+	}
+	if len(conditions.SourceIPs) != 0 {
+		sdkConditions = append(sdkConditions, &elbv2sdk.RuleCondition{
+			Field: awssdk.String("source-ip"),
+			SourceIpConfig: &elbv2sdk.SourceIpConditionConfig{
+				Values: awssdk.StringSlice(conditions.SourceIPs),
+			},
+		})
+	}
+	if len(sdkConditions) == 0 {
+		return nil, errors.New("at least one condition must be specified")
+	}
+	return sdkConditions, nil
+}
+
+// buildRuleSettingsForAudit builds the listener rule settings reported in mutation diffs.
+func buildRuleSettingsForAudit(priority interface{}, actions []*elbv2sdk.Action, conditions []*elbv2sdk.RuleCondition) map[string]interface{} {
+	return map[string]interface{}{
+		"priority":   priority,
+		"actions":    actions,
+		"conditions": conditions,
+	}
+}
+
+func isRuleNotFoundError(err error) bool {
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		return awsErr.Code() == "RuleNotFound"
+	}
+	return false
+}
@@ -0,0 +1,413 @@
+package listenerrulebinding
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_defaultResourceManager_Reconcile(t *testing.T) {
+	lsARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener/app/my-alb/50dc6c495c0c9188/f2f7dc8efc522ab2"
+	otherLSARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener/app/my-alb/50dc6c495c0c9188/0467ef3c8400ae65"
+	ruleARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener-rule/app/my-alb/50dc6c495c0c9188/f2f7dc8efc522ab2/9683b2d02a6cabee"
+	tgARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/73e2d6bc24d8a067"
+	desiredActions := []*elbv2sdk.Action{
+		{
+			Type: awssdk.String("forward"),
+			ForwardConfig: &elbv2sdk.ForwardActionConfig{
+				TargetGroups: []*elbv2sdk.TargetGroupTuple{
+					{TargetGroupArn: awssdk.String(tgARN)},
+				},
+			},
+		},
+	}
+	desiredConditions := []*elbv2sdk.RuleCondition{
+		{
+			Field: awssdk.String("path-pattern"),
+			PathPatternConfig: &elbv2sdk.PathPatternConditionConfig{
+				Values: awssdk.StringSlice([]string{"/api/*"}),
+			},
+		},
+	}
+	ruleTags := []*elbv2sdk.Tag{
+		{Key: awssdk.String("elbv2.k8s.aws/cluster"), Value: awssdk.String("my-cluster")},
+		{Key: awssdk.String("elbv2.k8s.aws/listener-rule-binding"), Value: awssdk.String("awesome-ns/my-lrb")},
+	}
+	type describeRulesCall struct {
+		req  *elbv2sdk.DescribeRulesInput
+		resp []*elbv2sdk.Rule
+	}
+	type describeTagsCall struct {
+		req  *elbv2sdk.DescribeTagsInput
+		resp *elbv2sdk.DescribeTagsOutput
+	}
+	type createRuleCall struct {
+		req  *elbv2sdk.CreateRuleInput
+		resp *elbv2sdk.CreateRuleOutput
+	}
+	type setRulePrioritiesCall struct {
+		req *elbv2sdk.SetRulePrioritiesInput
+	}
+	type deleteRuleCall struct {
+		req *elbv2sdk.DeleteRuleInput
+	}
+	tests := []struct {
+		name                   string
+		listenerARN            string
+		status                 elbv2api.ListenerRuleBindingStatus
+		describeRulesCalls     []describeRulesCall
+		describeTagsCalls      []describeTagsCall
+		createRuleCalls        []createRuleCall
+		setRulePrioritiesCalls []setRulePrioritiesCall
+		deleteRuleCalls        []deleteRuleCall
+		wantMutations          []string
+		wantStatus             elbv2api.ListenerRuleBindingStatus
+		wantErr                error
+	}{
+		{
+			name: "creates rule when not found",
+			describeRulesCalls: []describeRulesCall{
+				{
+					req: &elbv2sdk.DescribeRulesInput{ListenerArn: awssdk.String(lsARN)},
+					resp: []*elbv2sdk.Rule{
+						{RuleArn: awssdk.String("default-rule"), Priority: awssdk.String("default"), IsDefault: awssdk.Bool(true)},
+					},
+				},
+			},
+			createRuleCalls: []createRuleCall{
+				{
+					req: &elbv2sdk.CreateRuleInput{
+						ListenerArn: awssdk.String(lsARN),
+						Priority:    awssdk.Int64(10),
+						Actions:     desiredActions,
+						Conditions:  desiredConditions,
+						Tags:        ruleTags,
+					},
+					resp: &elbv2sdk.CreateRuleOutput{
+						Rules: []*elbv2sdk.Rule{{RuleArn: awssdk.String(ruleARN), Priority: awssdk.String("10")}},
+					},
+				},
+			},
+			wantStatus: elbv2api.ListenerRuleBindingStatus{
+				ListenerARN: awssdk.String(lsARN),
+				RuleARN:     awssdk.String(ruleARN),
+			},
+		},
+		{
+			name: "adopts rule tagged for ListenerRuleBinding when status is lost",
+			describeRulesCalls: []describeRulesCall{
+				{
+					req: &elbv2sdk.DescribeRulesInput{ListenerArn: awssdk.String(lsARN)},
+					resp: []*elbv2sdk.Rule{
+						{
+							RuleArn:    awssdk.String(ruleARN),
+							Priority:   awssdk.String("10"),
+							Actions:    desiredActions,
+							Conditions: desiredConditions,
+						},
+					},
+				},
+			},
+			describeTagsCalls: []describeTagsCall{
+				{
+					req: &elbv2sdk.DescribeTagsInput{ResourceArns: awssdk.StringSlice([]string{ruleARN})},
+					resp: &elbv2sdk.DescribeTagsOutput{
+						TagDescriptions: []*elbv2sdk.TagDescription{
+							{ResourceArn: awssdk.String(ruleARN), Tags: ruleTags},
+						},
+					},
+				},
+			},
+			wantStatus: elbv2api.ListenerRuleBindingStatus{
+				ListenerARN: awssdk.String(lsARN),
+				RuleARN:     awssdk.String(ruleARN),
+			},
+		},
+		{
+			name: "doesn't adopt rule with same priority not tagged for ListenerRuleBinding",
+			describeRulesCalls: []describeRulesCall{
+				{
+					req: &elbv2sdk.DescribeRulesInput{ListenerArn: awssdk.String(lsARN)},
+					resp: []*elbv2sdk.Rule{
+						{
+							RuleArn:    awssdk.String("other-rule"),
+							Priority:   awssdk.String("10"),
+							Actions:    desiredActions,
+							Conditions: desiredConditions,
+						},
+					},
+				},
+			},
+			describeTagsCalls: []describeTagsCall{
+				{
+					req: &elbv2sdk.DescribeTagsInput{ResourceArns: awssdk.StringSlice([]string{"other-rule"})},
+					resp: &elbv2sdk.DescribeTagsOutput{
+						TagDescriptions: []*elbv2sdk.TagDescription{
+							{ResourceArn: awssdk.String("other-rule")},
+						},
+					},
+				},
+			},
+			createRuleCalls: []createRuleCall{
+				{
+					req: &elbv2sdk.CreateRuleInput{
+						ListenerArn: awssdk.String(lsARN),
+						Priority:    awssdk.Int64(10),
+						Actions:     desiredActions,
+						Conditions:  desiredConditions,
+						Tags:        ruleTags,
+					},
+					resp: &elbv2sdk.CreateRuleOutput{
+						Rules: []*elbv2sdk.Rule{{RuleArn: awssdk.String(ruleARN), Priority: awssdk.String("10")}},
+					},
+				},
+			},
+			wantStatus: elbv2api.ListenerRuleBindingStatus{
+				ListenerARN: awssdk.String(lsARN),
+				RuleARN:     awssdk.String(ruleARN),
+			},
+		},
+		{
+			name: "updates priority of existing rule",
+			status: elbv2api.ListenerRuleBindingStatus{
+				ListenerARN: awssdk.String(lsARN),
+				RuleARN:     awssdk.String(ruleARN),
+			},
+			describeRulesCalls: []describeRulesCall{
+				{
+					req: &elbv2sdk.DescribeRulesInput{ListenerArn: awssdk.String(lsARN)},
+					resp: []*elbv2sdk.Rule{
+						{
+							RuleArn:    awssdk.String(ruleARN),
+							Priority:   awssdk.String("20"),
+							Actions:    desiredActions,
+							Conditions: desiredConditions,
+						},
+					},
+				},
+			},
+			setRulePrioritiesCalls: []setRulePrioritiesCall{
+				{
+					req: &elbv2sdk.SetRulePrioritiesInput{
+						RulePriorities: []*elbv2sdk.RulePriorityPair{
+							{RuleArn: awssdk.String(ruleARN), Priority: awssdk.Int64(10)},
+						},
+					},
+				},
+			},
+			wantStatus: elbv2api.ListenerRuleBindingStatus{
+				ListenerARN: awssdk.String(lsARN),
+				RuleARN:     awssdk.String(ruleARN),
+			},
+		},
+		{
+			name: "moves rule to new listener",
+			status: elbv2api.ListenerRuleBindingStatus{
+				ListenerARN: awssdk.String(otherLSARN),
+				RuleARN:     awssdk.String("old-rule"),
+			},
+			deleteRuleCalls: []deleteRuleCall{
+				{
+					req: &elbv2sdk.DeleteRuleInput{RuleArn: awssdk.String("old-rule")},
+				},
+			},
+			describeRulesCalls: []describeRulesCall{
+				{
+					req: &elbv2sdk.DescribeRulesInput{ListenerArn: awssdk.String(lsARN)},
+				},
+			},
+			createRuleCalls: []createRuleCall{
+				{
+					req: &elbv2sdk.CreateRuleInput{
+						ListenerArn: awssdk.String(lsARN),
+						Priority:    awssdk.Int64(10),
+						Actions:     desiredActions,
+						Conditions:  desiredConditions,
+						Tags:        ruleTags,
+					},
+					resp: &elbv2sdk.CreateRuleOutput{
+						Rules: []*elbv2sdk.Rule{{RuleArn: awssdk.String(ruleARN), Priority: awssdk.String("10")}},
+					},
+				},
+			},
+			wantMutations: []string{"create", "delete"},
+			wantStatus: elbv2api.ListenerRuleBindingStatus{
+				ListenerARN: awssdk.String(lsARN),
+				RuleARN:     awssdk.String(ruleARN),
+			},
+		},
+		{
+			name:        "listener not allowed",
+			listenerARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener/app/other-alb/60dc6c495c0c9188/f2f7dc8efc522ab2",
+			wantErr:     errors.New("listener arn:aws:elasticloadbalancing:us-west-2:123456789012:listener/app/other-alb/60dc6c495c0c9188/f2f7dc8efc522ab2 isn't allowed for ListenerRuleBinding, allowed listeners are configured via --listener-rule-binding-allowed-listeners"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			var mutations []string
+			elbv2Client := mock_services.NewMockELBV2(ctrl)
+			for _, call := range tt.describeRulesCalls {
+				elbv2Client.EXPECT().DescribeRulesAsList(gomock.Any(), call.req).Return(call.resp, nil)
+			}
+			for _, call := range tt.describeTagsCalls {
+				elbv2Client.EXPECT().DescribeTagsWithContext(gomock.Any(), call.req).Return(call.resp, nil)
+			}
+			for _, call := range tt.createRuleCalls {
+				elbv2Client.EXPECT().CreateRuleWithContext(gomock.Any(), call.req).Do(func(_ interface{}, _ interface{}) {
+					mutations = append(mutations, "create")
+				}).Return(call.resp, nil)
+			}
+			for _, call := range tt.setRulePrioritiesCalls {
+				elbv2Client.EXPECT().SetRulePrioritiesWithContext(gomock.Any(), call.req).Return(&elbv2sdk.SetRulePrioritiesOutput{}, nil)
+			}
+			for _, call := range tt.deleteRuleCalls {
+				elbv2Client.EXPECT().DeleteRuleWithContext(gomock.Any(), call.req).Do(func(_ interface{}, _ interface{}) {
+					mutations = append(mutations, "delete")
+				}).Return(&elbv2sdk.DeleteRuleOutput{}, nil)
+			}
+
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			tgb := &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "my-tgb"},
+				Spec:       elbv2api.TargetGroupBindingSpec{TargetGroupARN: tgARN},
+			}
+			assert.NoError(t, k8sClient.Create(context.Background(), tgb))
+
+			m := NewDefaultResourceManager(k8sClient, elbv2Client, "my-cluster", []string{lsARN, otherLSARN}, &log.NullLogger{})
+			listenerARN := lsARN
+			if tt.listenerARN != "" {
+				listenerARN = tt.listenerARN
+			}
+			lrb := &elbv2api.ListenerRuleBinding{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "my-lrb"},
+				Spec: elbv2api.ListenerRuleBindingSpec{
+					ListenerARN: listenerARN,
+					Priority:    10,
+					Conditions: elbv2api.ListenerRuleConditions{
+						PathPatterns: []string{"/api/*"},
+					},
+					TargetGroupBindingRef: elbv2api.TargetGroupBindingReference{Name: "my-tgb"},
+				},
+				Status: tt.status,
+			}
+			err := m.Reconcile(context.Background(), lrb)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantStatus, lrb.Status)
+				if tt.wantMutations != nil {
+					assert.Equal(t, tt.wantMutations, mutations)
+				}
+			}
+		})
+	}
+}
+
+func Test_defaultResourceManager_Cleanup(t *testing.T) {
+	lsARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener/app/my-alb/50dc6c495c0c9188/f2f7dc8efc522ab2"
+	ruleARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener-rule/app/my-alb/50dc6c495c0c9188/f2f7dc8efc522ab2/9683b2d02a6cabee"
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// the rule created without status updated is found via its tags.
+	elbv2Client := mock_services.NewMockELBV2(ctrl)
+	elbv2Client.EXPECT().DescribeRulesAsList(gomock.Any(), &elbv2sdk.DescribeRulesInput{ListenerArn: awssdk.String(lsARN)}).Return([]*elbv2sdk.Rule{
+		{RuleArn: awssdk.String("default-rule"), Priority: awssdk.String("default"), IsDefault: awssdk.Bool(true)},
+		{RuleArn: awssdk.String(ruleARN), Priority: awssdk.String("10")},
+	}, nil)
+	elbv2Client.EXPECT().DescribeTagsWithContext(gomock.Any(), &elbv2sdk.DescribeTagsInput{
+		ResourceArns: awssdk.StringSlice([]string{ruleARN}),
+	}).Return(&elbv2sdk.DescribeTagsOutput{
+		TagDescriptions: []*elbv2sdk.TagDescription{
+			{
+				ResourceArn: awssdk.String(ruleARN),
+				Tags: []*elbv2sdk.Tag{
+					{Key: awssdk.String("elbv2.k8s.aws/cluster"), Value: awssdk.String("my-cluster")},
+					{Key: awssdk.String("elbv2.k8s.aws/listener-rule-binding"), Value: awssdk.String("awesome-ns/my-lrb")},
+				},
+			},
+		},
+	}, nil)
+	elbv2Client.EXPECT().DeleteRuleWithContext(gomock.Any(), &elbv2sdk.DeleteRuleInput{RuleArn: awssdk.String(ruleARN)}).Return(&elbv2sdk.DeleteRuleOutput{}, nil)
+
+	m := NewDefaultResourceManager(nil, elbv2Client, "my-cluster", []string{lsARN}, &log.NullLogger{})
+	lrb := &elbv2api.ListenerRuleBinding{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "my-lrb"},
+		Spec: elbv2api.ListenerRuleBindingSpec{
+			ListenerARN: lsARN,
+			Priority:    10,
+		},
+	}
+	assert.NoError(t, m.Cleanup(context.Background(), lrb))
+}
+
+func Test_buildSDKRuleConditions(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions elbv2api.ListenerRuleConditions
+		want       []*elbv2sdk.RuleCondition
+		wantErr    error
+	}{
+		{
+			name: "all conditions specified",
+			conditions: elbv2api.ListenerRuleConditions{
+				HostHeaders:        []string{"example.com"},
+				PathPatterns:       []string{"/api/*"},
+				HTTPRequestMethods: []string{"GET"},
+				SourceIPs:          []string{"10.0.0.0/8"},
+			},
+			want: []*elbv2sdk.RuleCondition{
+				{
+					Field:            awssdk.String("host-header"),
+					HostHeaderConfig: &elbv2sdk.HostHeaderConditionConfig{Values: awssdk.StringSlice([]string{"example.com"})},
+				},
+				{
+					Field:             awssdk.String("path-pattern"),
+					PathPatternConfig: &elbv2sdk.PathPatternConditionConfig{Values: awssdk.StringSlice([]string{"/api/*"})},
+				},
+				{
+					Field:                   awssdk.String("http-request-method"),
+					HttpRequestMethodConfig: &elbv2sdk.HttpRequestMethodConditionConfig{Values: awssdk.StringSlice([]string{"GET"})},
+				},
+				{
+					Field:          awssdk.String("source-ip"),
+					SourceIpConfig: &elbv2sdk.SourceIpConditionConfig{Values: awssdk.StringSlice([]string{"10.0.0.0/8"})},
+				},
+			},
+		},
+		{
+			name:       "no condition specified",
+			conditions: elbv2api.ListenerRuleConditions{},
+			wantErr:    errors.New("at least one condition must be specified"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buildSDKRuleConditions(tt.conditions)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package listenerrulebinding
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+)
+
+const (
+	// Index Key for "TargetGroupBindingReference" index.
+	IndexKeyTargetGroupBindingRefName = "spec.targetGroupBindingRef.name"
+)
+
+// Index Func for "TargetGroupBindingReference" index.
+func IndexFuncTargetGroupBindingRefName(obj runtime.Object) []string {
+	lrb := obj.(*elbv2api.ListenerRuleBinding)
+	return []string{lrb.Spec.TargetGroupBindingRef.Name}
+}
@@ -57,6 +57,11 @@ func (t *ResourceFieldStringToken) Dependencies() []Resource {
 	return []Resource{t.res}
 }
 
+// FieldPath returns the path of the resource field that token resolves to, e.g. "status/loadBalancerARN".
+func (t *ResourceFieldStringToken) FieldPath() string {
+	return t.fieldPath
+}
+
 func (t *ResourceFieldStringToken) MarshalJSON() ([]byte, error) {
 	payload := fmt.Sprintf(`{"$ref": "#/resources/%v/%v/%v"}`, t.res.Type(), t.res.ID(), t.fieldPath)
 	return []byte(payload), nil
@@ -0,0 +1,73 @@
+package ec2
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+)
+
+var _ core.Resource = &ElasticIP{}
+
+// ElasticIP represents a EC2 Elastic IP address claimed from a pool.
+type ElasticIP struct {
+	core.ResourceMeta `json:"-"`
+
+	// desired state of ElasticIP
+	Spec ElasticIPSpec `json:"spec"`
+
+	// observed state of ElasticIP
+	Status *ElasticIPStatus `json:"status,omitempty"`
+}
+
+// NewElasticIP constructs new ElasticIP resource.
+func NewElasticIP(stack core.Stack, id string, spec ElasticIPSpec) *ElasticIP {
+	eip := &ElasticIP{
+		ResourceMeta: core.NewResourceMeta(stack, "AWS::EC2::EIP", id),
+		Spec:         spec,
+		Status:       nil,
+	}
+	stack.AddResource(eip)
+	return eip
+}
+
+// SetStatus sets the ElasticIP's status
+func (eip *ElasticIP) SetStatus(status ElasticIPStatus) {
+	eip.Status = &status
+}
+
+// AllocationID returns a token for this ElasticIP's allocationID.
+func (eip *ElasticIP) AllocationID() core.StringToken {
+	return core.NewResourceFieldStringToken(eip, "status/allocationID",
+		func(ctx context.Context, res core.Resource, fieldPath string) (s string, err error) {
+			eip := res.(*ElasticIP)
+			if eip.Status == nil {
+				return "", errors.Errorf("ElasticIP is not fulfilled yet: %v", eip.ID())
+			}
+			return eip.Status.AllocationID, nil
+		},
+	)
+}
+
+// ElasticIPSpec defines the desired state of ElasticIP
+type ElasticIPSpec struct {
+	// The name of pool to claim a free Elastic IP address from.
+	PoolName string `json:"poolName"`
+
+	// Whether to allocate a new Elastic IP address into pool when there is no free one.
+	// Allocated Elastic IP addresses are released once they are no longer needed.
+	// +optional
+	AllocateIfPoolEmpty bool `json:"allocateIfPoolEmpty,omitempty"`
+
+	// Tags for Elastic IP addresses allocated into pool.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// ElasticIPStatus defines the observed state of ElasticIP
+type ElasticIPStatus struct {
+	// The allocation ID of the Elastic IP address.
+	AllocationID string `json:"allocationID"`
+
+	// The Elastic IP address.
+	PublicIP string `json:"publicIP"`
+}
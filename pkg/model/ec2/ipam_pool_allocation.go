@@ -0,0 +1,68 @@
+package ec2
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	"strings"
+)
+
+var _ core.Resource = &IPAMPoolAllocation{}
+
+// IPAMPoolAllocation represents a private IPv4 address allocated from an IPAM pool.
+type IPAMPoolAllocation struct {
+	core.ResourceMeta `json:"-"`
+
+	// desired state of IPAMPoolAllocation
+	Spec IPAMPoolAllocationSpec `json:"spec"`
+
+	// observed state of IPAMPoolAllocation
+	Status *IPAMPoolAllocationStatus `json:"status,omitempty"`
+}
+
+// NewIPAMPoolAllocation constructs new IPAMPoolAllocation resource.
+func NewIPAMPoolAllocation(stack core.Stack, id string, spec IPAMPoolAllocationSpec) *IPAMPoolAllocation {
+	allocation := &IPAMPoolAllocation{
+		ResourceMeta: core.NewResourceMeta(stack, "AWS::EC2::IPAMPoolAllocation", id),
+		Spec:         spec,
+		Status:       nil,
+	}
+	stack.AddResource(allocation)
+	return allocation
+}
+
+// SetStatus sets the IPAMPoolAllocation's status
+func (a *IPAMPoolAllocation) SetStatus(status IPAMPoolAllocationStatus) {
+	a.Status = &status
+}
+
+// PrivateIPv4Address returns a token for this IPAMPoolAllocation's private IPv4 address.
+func (a *IPAMPoolAllocation) PrivateIPv4Address() core.StringToken {
+	return core.NewResourceFieldStringToken(a, "status/cidr",
+		func(ctx context.Context, res core.Resource, fieldPath string) (s string, err error) {
+			a := res.(*IPAMPoolAllocation)
+			if a.Status == nil {
+				return "", errors.Errorf("IPAMPoolAllocation is not fulfilled yet: %v", a.ID())
+			}
+			return strings.TrimSuffix(a.Status.CIDR, "/32"), nil
+		},
+	)
+}
+
+// IPAMPoolAllocationSpec defines the desired state of IPAMPoolAllocation
+type IPAMPoolAllocationSpec struct {
+	// The ID of IPAM pool to allocate from.
+	IPAMPoolID string `json:"ipamPoolID"`
+
+	// The IPv4 CIDR block of subnet the address is allocated for.
+	SubnetCIDR string `json:"subnetCIDR"`
+}
+
+// IPAMPoolAllocationStatus defines the observed state of IPAMPoolAllocation
+type IPAMPoolAllocationStatus struct {
+	// The ID of IPAM pool allocation.
+	AllocationID string `json:"allocationID"`
+
+	// The allocated CIDR, which contains a single IPv4 address.
+	CIDR string `json:"cidr"`
+}
@@ -1,5 +1,14 @@
 package ec2
 
+const (
+	IPProtocolTCP    = "tcp"
+	IPProtocolUDP    = "udp"
+	IPProtocolICMP   = "icmp"
+	IPProtocolICMPv6 = "icmpv6"
+	// IPProtocolAll allows traffic of all protocols on all ports.
+	IPProtocolAll = "-1"
+)
+
 type IPRange struct {
 	CIDRIP string `json:"cidrIP"`
 	// +optional
@@ -18,16 +27,34 @@ type UserIDGroupPair struct {
 	Description string `json:"description,omitempty"`
 }
 
+type PrefixList struct {
+	PrefixListID string `json:"prefixListID"`
+	// +optional
+	Description string `json:"description,omitempty"`
+}
+
+// ICMPTypeCode specifies the ICMP type and code, -1 matches all types or codes.
+type ICMPTypeCode struct {
+	Type int64 `json:"type"`
+	Code int64 `json:"code"`
+}
+
 type IPPermission struct {
 	IPProtocol string `json:"ipProtocol"`
 	// +optional
 	FromPort *int64 `json:"fromPort,omitempty"`
 	// +optional
 	ToPort *int64 `json:"toPort,omitempty"`
+	// The ICMP type and code of icmp and icmpv6 permissions, all ICMP types and codes are allowed if unset.
+	// FromPort and ToPort must not be specified together with it.
+	// +optional
+	ICMPTypeCode *ICMPTypeCode `json:"icmpTypeCode,omitempty"`
 	// +optional
 	IPRanges []IPRange `json:"ipRanges,omitempty"`
 	// +optional
 	IPv6Range []IPv6Range `json:"ipv6Ranges,omitempty"`
 	// +optional
 	UserIDGroupPairs []UserIDGroupPair `json:"userIDGroupPairs,omitempty"`
+	// +optional
+	PrefixLists []PrefixList `json:"prefixLists,omitempty"`
 }
@@ -257,6 +257,11 @@ type ForwardActionConfig struct {
 	// The target group stickiness for the rule.
 	// +optional
 	TargetGroupStickinessConfig *TargetGroupStickinessConfig `json:"targetGroupStickinessConfig,omitempty"`
+
+	// Whether the weights of target groups are managed externally, e.g. by CodeDeploy traffic shifting.
+	// the existing weights are preserved as long as the action forwards to the same target groups.
+	// +optional
+	ExternallyManagedWeights bool `json:"externallyManagedWeights,omitempty"`
 }
 
 // Information about an action.
@@ -304,6 +309,20 @@ const (
 	ALPNPolicyHTTP2Preferred ALPNPolicy = "HTTP2Preferred"
 )
 
+// Specifies a listener attribute.
+type ListenerAttribute struct {
+	// The name of the attribute.
+	Key string `json:"key"`
+
+	// The value of the attribute.
+	Value string `json:"value"`
+}
+
+// well-known listener attribute keys.
+const (
+	ListenerAttributeKeyTCPIdleTimeoutSeconds = "tcp.idle_timeout.seconds"
+)
+
 // ListenerSpec defines the desired state of Listener
 type ListenerSpec struct {
 	// The Amazon Resource Name (ARN) of the load balancer.
@@ -331,6 +350,11 @@ type ListenerSpec struct {
 	// [TLS listener] The name of the Application-Layer Protocol Negotiation (ALPN) policy.
 	// +optional
 	ALPNPolicy []string `json:"alpnPolicy,omitempty"`
+
+	// The listener attributes.
+	// Only the specified attributes are managed; others are left as is.
+	// +optional
+	ListenerAttributes []ListenerAttribute `json:"listenerAttributes,omitempty"`
 }
 
 // ListenerStatus defines the observed state of Listener
@@ -0,0 +1,50 @@
+package elbv2
+
+import (
+	"github.com/pkg/errors"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// listener attributes of Application LoadBalancers that modify HTTP headers.
+// response headers are inserted by setting *.header_value attributes and the server header is removed by disabling
+// routing.http.response.server.enabled, request headers added by ALB(e.g. mTLS and TLS headers) are renamed by *.header_name attributes.
+const (
+	ListenerAttributeKeyPrefixALB             = "routing.http."
+	ListenerAttributeKeyPrefixRequestHeader   = "routing.http.request."
+	ListenerAttributeKeySuffixHeaderName      = ".header_name"
+	ListenerAttributeKeyResponseServerEnabled = "routing.http.response.server.enabled"
+)
+
+// headerNamePattern matches the header names ALB accepts for renamed request headers.
+var headerNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,40}$`)
+
+// ValidateApplicationListenerAttributes validates listener attributes of Application LoadBalancers,
+// header names of renamed request headers must be valid and the server header can only be enabled or disabled.
+func ValidateApplicationListenerAttributes(rawAttributes map[string]string) error {
+	attrKeys := make([]string, 0, len(rawAttributes))
+	for attrKey := range rawAttributes {
+		attrKeys = append(attrKeys, attrKey)
+	}
+	sort.Strings(attrKeys)
+	for _, attrKey := range attrKeys {
+		attrValue := rawAttributes[attrKey]
+		switch {
+		case !strings.HasPrefix(attrKey, ListenerAttributeKeyPrefixALB):
+			return errors.Errorf("unsupported listener attribute %v, Application LoadBalancer listener attributes must start with %v",
+				attrKey, ListenerAttributeKeyPrefixALB)
+		case attrKey == ListenerAttributeKeyResponseServerEnabled:
+			if _, err := strconv.ParseBool(attrValue); err != nil {
+				return errors.Errorf("invalid listener attribute %v=%v, must be true or false", attrKey, attrValue)
+			}
+		case strings.HasPrefix(attrKey, ListenerAttributeKeyPrefixRequestHeader) && strings.HasSuffix(attrKey, ListenerAttributeKeySuffixHeaderName):
+			if !headerNamePattern.MatchString(attrValue) {
+				return errors.Errorf("invalid listener attribute %v=%v, header name must be 1-40 alphanumeric characters, hyphens or underscores",
+					attrKey, attrValue)
+			}
+		}
+	}
+	return nil
+}
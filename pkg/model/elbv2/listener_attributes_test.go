@@ -0,0 +1,66 @@
+package elbv2
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestValidateApplicationListenerAttributes(t *testing.T) {
+	tests := []struct {
+		name          string
+		rawAttributes map[string]string
+		wantErr       error
+	}{
+		{
+			name:          "no attributes",
+			rawAttributes: nil,
+		},
+		{
+			name: "header modification attributes",
+			rawAttributes: map[string]string{
+				"routing.http.response.server.enabled":                         "false",
+				"routing.http.response.strict_transport_security.header_value": "max-age=31536000",
+				"routing.http.request.x_amzn_tls_version.header_name":          "X-TLS-Version",
+			},
+		},
+		{
+			name: "attribute of Network LoadBalancer listeners",
+			rawAttributes: map[string]string{
+				"tcp.idle_timeout.seconds": "350",
+			},
+			wantErr: errors.New("unsupported listener attribute tcp.idle_timeout.seconds, Application LoadBalancer listener attributes must start with routing.http."),
+		},
+		{
+			name: "invalid server header setting",
+			rawAttributes: map[string]string{
+				"routing.http.response.server.enabled": "no",
+			},
+			wantErr: errors.New("invalid listener attribute routing.http.response.server.enabled=no, must be true or false"),
+		},
+		{
+			name: "invalid header name",
+			rawAttributes: map[string]string{
+				"routing.http.request.x_amzn_tls_version.header_name": "X TLS Version",
+			},
+			wantErr: errors.New("invalid listener attribute routing.http.request.x_amzn_tls_version.header_name=X TLS Version, header name must be 1-40 alphanumeric characters, hyphens or underscores"),
+		},
+		{
+			name: "empty header name",
+			rawAttributes: map[string]string{
+				"routing.http.request.x_amzn_tls_version.header_name": "",
+			},
+			wantErr: errors.New("invalid listener attribute routing.http.request.x_amzn_tls_version.header_name=, header name must be 1-40 alphanumeric characters, hyphens or underscores"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateApplicationListenerAttributes(tt.rawAttributes)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/pkg/errors"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	"time"
 )
 
 var _ core.Resource = &LoadBalancer{}
@@ -63,6 +64,19 @@ func (lb *LoadBalancer) DNSName() core.StringToken {
 	)
 }
 
+// CanonicalHostedZoneID returns The ID of the Amazon Route 53 hosted zone associated with the load balancer.
+func (lb *LoadBalancer) CanonicalHostedZoneID() core.StringToken {
+	return core.NewResourceFieldStringToken(lb, "status/canonicalHostedZoneID",
+		func(ctx context.Context, res core.Resource, fieldPath string) (s string, err error) {
+			lb := res.(*LoadBalancer)
+			if lb.Status == nil {
+				return "", errors.Errorf("LoadBalancer is not fulfilled yet: %v", lb.ID())
+			}
+			return lb.Status.CanonicalHostedZoneID, nil
+		},
+	)
+}
+
 // register dependencies for LoadBalancer.
 func (lb *LoadBalancer) registerDependencies(stack core.Stack) {
 	for _, sgToken := range lb.Spec.SecurityGroups {
@@ -82,8 +96,9 @@ const (
 type IPAddressType string
 
 const (
-	IPAddressTypeIPV4      IPAddressType = "ipv4"
-	IPAddressTypeDualStack IPAddressType = "dualstack"
+	IPAddressTypeIPV4                       IPAddressType = "ipv4"
+	IPAddressTypeDualStack                  IPAddressType = "dualstack"
+	IPAddressTypeDualStackWithoutPublicIPV4 IPAddressType = "dualstack-without-public-ipv4"
 )
 
 type LoadBalancerScheme string
@@ -97,10 +112,10 @@ const (
 type SubnetMapping struct {
 	// [Network Load Balancers] The allocation ID of the Elastic IP address for
 	// an internet-facing load balancer.
-	AllocationID *string `json:"allocationID,omitempty"`
+	AllocationID core.StringToken `json:"allocationID,omitempty"`
 
 	// [Network Load Balancers] The private IPv4 address for an internal load balancer.
-	PrivateIPv4Address *string `json:"privateIPv4Address,omitempty"`
+	PrivateIPv4Address core.StringToken `json:"privateIPv4Address,omitempty"`
 
 	// The ID of the subnet.
 	SubnetID string `json:"subnetID"`
@@ -115,6 +130,45 @@ type LoadBalancerAttribute struct {
 	Value string `json:"value"`
 }
 
+// well-known load balancer attribute keys for application LoadBalancer.
+const (
+	LoadBalancerAttributeKeyDesyncMitigationMode   = "routing.http.desync_mitigation_mode"
+	LoadBalancerAttributeKeyClientKeepAliveSeconds = "client_keep_alive.seconds"
+	LoadBalancerAttributeKeyHTTP2Enabled           = "routing.http2.enabled"
+)
+
+// well-known load balancer attribute keys for network LoadBalancer.
+const (
+	LoadBalancerAttributeKeyDNSRecordClientRoutingPolicy = "dns_record.client_routing_policy"
+)
+
+// valid values for LoadBalancerAttributeKeyDNSRecordClientRoutingPolicy.
+const (
+	ClientRoutingPolicyAvailabilityZoneAffinity        = "availability_zone_affinity"
+	ClientRoutingPolicyPartialAvailabilityZoneAffinity = "partial_availability_zone_affinity"
+	ClientRoutingPolicyAnyAvailabilityZone             = "any_availability_zone"
+)
+
+type LoadBalancerReplacementStrategy string
+
+const (
+	// LoadBalancerReplacementStrategyDeleteCreate deletes the load balancer before creating its replacement.
+	LoadBalancerReplacementStrategyDeleteCreate LoadBalancerReplacementStrategy = "delete-create"
+	// LoadBalancerReplacementStrategyBlueGreen creates the replacement first, and deletes the replaced load balancer
+	// once the replacement serves traffic and the drain window elapses.
+	LoadBalancerReplacementStrategyBlueGreen LoadBalancerReplacementStrategy = "blue-green"
+)
+
+// LoadBalancerReplacement defines how the load balancer is replaced when changes cannot be applied in place.
+type LoadBalancerReplacement struct {
+	// The replacement strategy.
+	Strategy LoadBalancerReplacementStrategy `json:"strategy"`
+
+	// [BlueGreen] The duration in seconds the replaced load balancer keeps serving after DNS outputs switch to the replacement.
+	// +optional
+	DrainWindowSeconds int64 `json:"drainWindowSeconds,omitempty"`
+}
+
 // LoadBalancerSpec defines the desired state of LoadBalancer
 type LoadBalancerSpec struct {
 	// The name of the load balancer.
@@ -136,6 +190,11 @@ type LoadBalancerSpec struct {
 	// +optional
 	SubnetMappings []SubnetMapping `json:"subnetMapping,omitempty"`
 
+	// [Network Load Balancers] Whether subnets are selected by tags or discovery instead of specified explicitly.
+	// Subnets that cannot be removed are retried instead of requiring replacement, since discovery may select them again.
+	// +optional
+	SubnetsAutoDiscovered bool `json:"subnetsAutoDiscovered,omitempty"`
+
 	// [Application Load Balancers] The IDs of the security groups for the load balancer.
 	// +optional
 	SecurityGroups []core.StringToken `json:"securityGroups,omitempty"`
@@ -151,6 +210,16 @@ type LoadBalancerSpec struct {
 	// The tags.
 	// +optional
 	Tags map[string]string `json:"tags,omitempty"`
+
+	// [Application Load Balancers] The ARN of an existing load balancer that isn't managed by controller.
+	// when specified, the load balancer itself is never modified, and only listeners created for stack are managed on it.
+	// +optional
+	ExistingLoadBalancerARN *string `json:"existingLoadBalancerARN,omitempty"`
+
+	// How the load balancer is replaced when changes cannot be applied in place, such as scheme changes.
+	// the load balancer is deleted before creating its replacement if unspecified.
+	// +optional
+	Replacement *LoadBalancerReplacement `json:"replacement,omitempty"`
 }
 
 // LoadBalancerStatus defines the observed state of LoadBalancer
@@ -160,4 +229,38 @@ type LoadBalancerStatus struct {
 
 	// The public DNS name of the load balancer.
 	DNSName string `json:"dnsName"`
+
+	// The ID of the Amazon Route 53 hosted zone associated with the load balancer.
+	CanonicalHostedZoneID string `json:"canonicalHostedZoneID,omitempty"`
+
+	// The blue/green replacement in progress.
+	// +optional
+	Replacement *LoadBalancerReplacementStatus `json:"replacement,omitempty"`
+}
+
+type LoadBalancerReplacementPhase string
+
+const (
+	// LoadBalancerReplacementPhaseProvisioning means the replacement is being provisioned,
+	// and the replaced load balancer still serves as the load balancer.
+	LoadBalancerReplacementPhaseProvisioning LoadBalancerReplacementPhase = "Provisioning"
+	// LoadBalancerReplacementPhaseVerifying means listeners are moved to the replacement, and DNS outputs still refer to
+	// the replaced load balancer until targets of the replacement are healthy.
+	LoadBalancerReplacementPhaseVerifying LoadBalancerReplacementPhase = "Verifying"
+	// LoadBalancerReplacementPhaseDraining means DNS outputs refer to the replacement,
+	// and the replaced load balancer is deleted once the drain window elapses.
+	LoadBalancerReplacementPhaseDraining LoadBalancerReplacementPhase = "Draining"
+)
+
+// LoadBalancerReplacementStatus defines the observed state of a blue/green replacement.
+type LoadBalancerReplacementStatus struct {
+	// The Amazon Resource Name (ARN) of the load balancer being replaced.
+	ReplacedLoadBalancerARN string `json:"replacedLoadBalancerARN"`
+
+	// The phase of replacement.
+	Phase LoadBalancerReplacementPhase `json:"phase"`
+
+	// [Draining] The time the replaced load balancer is deleted at.
+	// +optional
+	RetireTime *time.Time `json:"retireTime,omitempty"`
 }
@@ -57,6 +57,13 @@ const (
 	TargetTypeIP       TargetType = "ip"
 )
 
+type TargetGroupIPAddressType string
+
+const (
+	TargetGroupIPAddressTypeIPv4 TargetGroupIPAddressType = "ipv4"
+	TargetGroupIPAddressTypeIPv6 TargetGroupIPAddressType = "ipv6"
+)
+
 // Information to use when checking for a successful response from a target.
 type HealthCheckMatcher struct {
 	// The HTTP codes.
@@ -110,6 +117,75 @@ type TargetGroupAttribute struct {
 	Value string `json:"value"`
 }
 
+// well-known target group attribute keys.
+const (
+	TargetGroupAttributeKeyLoadBalancingCrossZoneEnabled                        = "load_balancing.cross_zone.enabled"
+	TargetGroupAttributeKeyLoadBalancingAlgorithmType                           = "load_balancing.algorithm.type"
+	TargetGroupAttributeKeyLoadBalancingAlgorithmAnomalyMitigation              = "load_balancing.algorithm.anomaly_mitigation"
+	TargetGroupAttributeKeySlowStartDurationSeconds                             = "slow_start.duration_seconds"
+	TargetGroupAttributeKeyDNSFailoverMinimumHealthyTargetsCount                = "target_group_health.dns_failover.minimum_healthy_targets.count"
+	TargetGroupAttributeKeyDNSFailoverMinimumHealthyTargetsPercentage           = "target_group_health.dns_failover.minimum_healthy_targets.percentage"
+	TargetGroupAttributeKeyUnhealthyStateRoutingMinimumHealthyTargetsCount      = "target_group_health.unhealthy_state_routing.minimum_healthy_targets.count"
+	TargetGroupAttributeKeyUnhealthyStateRoutingMinimumHealthyTargetsPercentage = "target_group_health.unhealthy_state_routing.minimum_healthy_targets.percentage"
+	TargetGroupAttributeKeyStickinessEnabled                                    = "stickiness.enabled"
+	TargetGroupAttributeKeyStickinessType                                       = "stickiness.type"
+	TargetGroupAttributeKeyStickinessLBCookieDurationSeconds                    = "stickiness.lb_cookie.duration_seconds"
+	TargetGroupAttributeKeyStickinessAppCookieCookieName                        = "stickiness.app_cookie.cookie_name"
+	TargetGroupAttributeKeyStickinessAppCookieDurationSeconds                   = "stickiness.app_cookie.duration_seconds"
+	TargetGroupAttributeKeyDeregistrationDelayTimeoutSeconds                    = "deregistration_delay.timeout_seconds"
+	TargetGroupAttributeKeyDeregistrationDelayConnectionTerminationEnabled      = "deregistration_delay.connection_termination.enabled"
+	TargetGroupAttributeKeyProxyProtocolV2Enabled                               = "proxy_protocol_v2.enabled"
+	TargetGroupAttributeKeyPreserveClientIPEnabled                              = "preserve_client_ip.enabled"
+	TargetGroupAttributeKeyLambdaMultiValueHeadersEnabled                       = "lambda.multi_value_headers.enabled"
+	TargetGroupAttributeKeyTargetFailoverOnDeregistration                       = "target_failover.on_deregistration"
+	TargetGroupAttributeKeyTargetFailoverOnUnhealthy                            = "target_failover.on_unhealthy"
+	TargetGroupAttributeKeyUnhealthyConnectionTerminationEnabled                = "target_health_state.unhealthy.connection_termination.enabled"
+	TargetGroupAttributeKeyUnhealthyDrainingIntervalSeconds                     = "target_health_state.unhealthy.draining_interval_seconds"
+)
+
+// TargetGroupAttributeKeyPrefixStickiness is the prefix of all target group stickiness attribute keys.
+const TargetGroupAttributeKeyPrefixStickiness = "stickiness."
+
+// valid values for TargetGroupAttributeKeyStickinessType.
+const (
+	TargetGroupStickinessTypeLBCookie  = "lb_cookie"
+	TargetGroupStickinessTypeAppCookie = "app_cookie"
+	TargetGroupStickinessTypeSourceIP  = "source_ip"
+	// source_ip_dest_ip and source_ip_dest_ip_proto are only supported by GENEVE TargetGroups of Gateway LoadBalancers.
+	TargetGroupStickinessTypeSourceIPDestIP      = "source_ip_dest_ip"
+	TargetGroupStickinessTypeSourceIPDestIPProto = "source_ip_dest_ip_proto"
+)
+
+// valid values for TargetGroupAttributeKeyLoadBalancingCrossZoneEnabled.
+const (
+	TargetGroupCrossZoneLoadBalancingEnabled                      = "true"
+	TargetGroupCrossZoneLoadBalancingDisabled                     = "false"
+	TargetGroupCrossZoneLoadBalancingUseLoadBalancerConfiguration = "use_load_balancer_configuration"
+)
+
+// valid values for TargetGroupAttributeKeyLoadBalancingAlgorithmAnomalyMitigation.
+const (
+	TargetGroupAnomalyMitigationOn  = "on"
+	TargetGroupAnomalyMitigationOff = "off"
+)
+
+// valid values for TargetGroupAttributeKeyLoadBalancingAlgorithmType.
+const (
+	TargetGroupLoadBalancingAlgorithmTypeRoundRobin               = "round_robin"
+	TargetGroupLoadBalancingAlgorithmTypeLeastOutstandingRequests = "least_outstanding_requests"
+	// TargetGroupLoadBalancingAlgorithmTypeWeightedRandom is the load balancing algorithm required by anomaly mitigation.
+	TargetGroupLoadBalancingAlgorithmTypeWeightedRandom = "weighted_random"
+)
+
+// valid values for TargetGroupAttributeKeyTargetFailoverOnDeregistration and TargetGroupAttributeKeyTargetFailoverOnUnhealthy.
+const (
+	TargetGroupTargetFailoverRebalance   = "rebalance"
+	TargetGroupTargetFailoverNoRebalance = "no_rebalance"
+)
+
+// TargetGroupHealthRequirementOff disables a target group health requirement.
+const TargetGroupHealthRequirementOff = "off"
+
 // TargetGroupSpec defines the observed state of TargetGroup
 type TargetGroupSpec struct {
 	// The name of the target group.
@@ -118,6 +194,10 @@ type TargetGroupSpec struct {
 	// The type of target that you must specify when registering targets with this target group.
 	TargetType TargetType `json:"targetType"`
 
+	// The type of IP addresses used by the target group.
+	// +optional
+	IPAddressType *TargetGroupIPAddressType `json:"ipAddressType,omitempty"`
+
 	// The port on which the targets receive traffic.
 	Port int64 `json:"port"`
 
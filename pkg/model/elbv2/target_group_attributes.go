@@ -0,0 +1,191 @@
+package elbv2
+
+import (
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"regexp"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
+	"strconv"
+	"strings"
+)
+
+// BuildTargetGroupAttributesToUpdate computes the TargetGroup attributes to modify so that current attributes match desired ones.
+// when stickiness type changes, all desired stickiness attributes are modified within the same request,
+// so that settings of the new type(e.g. app_cookie cookie name) are in place when ELBv2 validates the switch.
+func BuildTargetGroupAttributesToUpdate(desiredAttrs map[string]string, currentAttrs map[string]string) (map[string]string, error) {
+	attributesToUpdate, _ := algorithm.DiffStringMap(desiredAttrs, currentAttrs)
+	if _, typeChanged := attributesToUpdate[TargetGroupAttributeKeyStickinessType]; typeChanged {
+		for attrKey, attrValue := range desiredAttrs {
+			if strings.HasPrefix(attrKey, TargetGroupAttributeKeyPrefixStickiness) {
+				attributesToUpdate[attrKey] = attrValue
+			}
+		}
+	}
+	if effectiveTargetGroupAttribute(TargetGroupAttributeKeyStickinessEnabled, desiredAttrs, currentAttrs) == "true" &&
+		effectiveTargetGroupAttribute(TargetGroupAttributeKeyStickinessType, desiredAttrs, currentAttrs) == TargetGroupStickinessTypeAppCookie &&
+		effectiveTargetGroupAttribute(TargetGroupAttributeKeyStickinessAppCookieCookieName, desiredAttrs, currentAttrs) == "" {
+		return nil, errors.Errorf("app_cookie stickiness requires attribute %v", TargetGroupAttributeKeyStickinessAppCookieCookieName)
+	}
+	return attributesToUpdate, nil
+}
+
+// effectiveTargetGroupAttribute returns the value of TargetGroup attribute once desired attributes are applied.
+func effectiveTargetGroupAttribute(attrKey string, desiredAttrs map[string]string, currentAttrs map[string]string) string {
+	if attrValue, ok := desiredAttrs[attrKey]; ok {
+		return attrValue
+	}
+	return currentAttrs[attrKey]
+}
+
+// targetGroupAttributeValidators validates the value of each supported target group attribute by key.
+var targetGroupAttributeValidators = map[string]func(value string) error{
+	TargetGroupAttributeKeyDeregistrationDelayTimeoutSeconds:               validateTargetGroupAttributeInt64InRange(0, 3600),
+	TargetGroupAttributeKeyDeregistrationDelayConnectionTerminationEnabled: validateTargetGroupAttributeBool,
+	TargetGroupAttributeKeySlowStartDurationSeconds:                        validateTargetGroupSlowStartDuration,
+	TargetGroupAttributeKeyStickinessEnabled:                               validateTargetGroupAttributeBool,
+	TargetGroupAttributeKeyStickinessType: validateTargetGroupAttributeOneOf(TargetGroupStickinessTypeLBCookie, TargetGroupStickinessTypeAppCookie,
+		TargetGroupStickinessTypeSourceIP, TargetGroupStickinessTypeSourceIPDestIP, TargetGroupStickinessTypeSourceIPDestIPProto),
+	TargetGroupAttributeKeyStickinessLBCookieDurationSeconds:  validateTargetGroupAttributeInt64InRange(1, 604800),
+	TargetGroupAttributeKeyStickinessAppCookieCookieName:      ValidateTargetGroupAppCookieName,
+	TargetGroupAttributeKeyStickinessAppCookieDurationSeconds: ValidateTargetGroupAppCookieDuration,
+	TargetGroupAttributeKeyLoadBalancingAlgorithmType: validateTargetGroupAttributeOneOf(TargetGroupLoadBalancingAlgorithmTypeRoundRobin,
+		TargetGroupLoadBalancingAlgorithmTypeLeastOutstandingRequests, TargetGroupLoadBalancingAlgorithmTypeWeightedRandom),
+	TargetGroupAttributeKeyLoadBalancingAlgorithmAnomalyMitigation: validateTargetGroupAttributeOneOf(TargetGroupAnomalyMitigationOn, TargetGroupAnomalyMitigationOff),
+	TargetGroupAttributeKeyLoadBalancingCrossZoneEnabled: validateTargetGroupAttributeOneOf(TargetGroupCrossZoneLoadBalancingEnabled,
+		TargetGroupCrossZoneLoadBalancingDisabled, TargetGroupCrossZoneLoadBalancingUseLoadBalancerConfiguration),
+	TargetGroupAttributeKeyDNSFailoverMinimumHealthyTargetsCount:                validateTargetGroupAttributeHealthRequirement(1, 1<<31-1),
+	TargetGroupAttributeKeyDNSFailoverMinimumHealthyTargetsPercentage:           validateTargetGroupAttributeHealthRequirement(1, 100),
+	TargetGroupAttributeKeyUnhealthyStateRoutingMinimumHealthyTargetsCount:      validateTargetGroupAttributeInt64InRange(1, 1<<31-1),
+	TargetGroupAttributeKeyUnhealthyStateRoutingMinimumHealthyTargetsPercentage: validateTargetGroupAttributeHealthRequirement(1, 100),
+	TargetGroupAttributeKeyProxyProtocolV2Enabled:                               validateTargetGroupAttributeBool,
+	TargetGroupAttributeKeyPreserveClientIPEnabled:                              validateTargetGroupAttributeBool,
+	TargetGroupAttributeKeyLambdaMultiValueHeadersEnabled:                       validateTargetGroupAttributeBool,
+	TargetGroupAttributeKeyTargetFailoverOnDeregistration:                       validateTargetGroupAttributeOneOf(TargetGroupTargetFailoverRebalance, TargetGroupTargetFailoverNoRebalance),
+	TargetGroupAttributeKeyTargetFailoverOnUnhealthy:                            validateTargetGroupAttributeOneOf(TargetGroupTargetFailoverRebalance, TargetGroupTargetFailoverNoRebalance),
+	TargetGroupAttributeKeyUnhealthyConnectionTerminationEnabled:                validateTargetGroupAttributeBool,
+	TargetGroupAttributeKeyUnhealthyDrainingIntervalSeconds:                     validateTargetGroupAttributeInt64InRange(0, 360000),
+}
+
+// ValidateTargetGroupAttributes validates the keys and values of target group attributes.
+func ValidateTargetGroupAttributes(attrs map[string]string) error {
+	for _, attrKey := range sets.StringKeySet(attrs).List() {
+		validator, ok := targetGroupAttributeValidators[attrKey]
+		if !ok {
+			return errors.Errorf("unsupported target group attribute %v", attrKey)
+		}
+		if err := validator(attrs[attrKey]); err != nil {
+			return errors.Wrapf(err, "invalid target group attribute %v", attrKey)
+		}
+	}
+	return nil
+}
+
+// reservedAppCookieNamePrefixes are the cookie name prefixes reserved by ELBv2 for its own stickiness cookies.
+var reservedAppCookieNamePrefixes = []string{"AWSALB", "AWSALBAPP", "AWSALBTG"}
+
+// appCookieNamePattern matches the valid cookie names, which are tokens per RFC 6265.
+var appCookieNamePattern = regexp.MustCompile("^[a-zA-Z0-9!#$%&'*+.^_`|~-]+$")
+
+// ValidateTargetGroupAppCookieName validates the application cookie name for app_cookie stickiness.
+func ValidateTargetGroupAppCookieName(rawCookieName string) error {
+	if !appCookieNamePattern.MatchString(rawCookieName) {
+		return errors.Errorf("invalid target group app cookie name %v", rawCookieName)
+	}
+	for _, prefix := range reservedAppCookieNamePrefixes {
+		if strings.HasPrefix(rawCookieName, prefix) {
+			return errors.Errorf("invalid target group app cookie name %v, prefix %v is reserved", rawCookieName, prefix)
+		}
+	}
+	return nil
+}
+
+// ValidateTargetGroupAppCookieDuration validates the application cookie duration for app_cookie stickiness, which must be between 1 second and 7 days.
+func ValidateTargetGroupAppCookieDuration(rawDurationSeconds string) error {
+	durationSeconds, err := strconv.ParseInt(rawDurationSeconds, 10, 64)
+	if err != nil || durationSeconds < 1 || durationSeconds > 604800 {
+		return errors.Errorf("invalid target group app cookie duration %v, must be between 1 and 604800 seconds", rawDurationSeconds)
+	}
+	return nil
+}
+
+// ValidateTargetGroupCrossZone validates the cross-zone load balancing setting for target group against explicitly specified target group attributes.
+func ValidateTargetGroupCrossZone(rawCrossZone string, rawAttributes map[string]string) error {
+	switch rawCrossZone {
+	case TargetGroupCrossZoneLoadBalancingEnabled, TargetGroupCrossZoneLoadBalancingDisabled,
+		TargetGroupCrossZoneLoadBalancingUseLoadBalancerConfiguration:
+	default:
+		return errors.Errorf("invalid target group cross-zone load balancing %v, must be one of true, false or use_load_balancer_configuration", rawCrossZone)
+	}
+	if rawAttrValue, ok := rawAttributes[TargetGroupAttributeKeyLoadBalancingCrossZoneEnabled]; ok && rawAttrValue != rawCrossZone {
+		return errors.Errorf("conflicting target group cross-zone load balancing: %v, %v", rawCrossZone, rawAttrValue)
+	}
+	return nil
+}
+
+// ValidateTargetGroupHealthRequirement validates the target group health requirement against explicitly specified target group attributes.
+// counts must be at least 1 and percentages must be between 1 and 100, all of them except the unhealthy state routing count can be turned off.
+func ValidateTargetGroupHealthRequirement(attrKey string, rawValue string, rawAttributes map[string]string) error {
+	if rawValue != TargetGroupHealthRequirementOff ||
+		attrKey == TargetGroupAttributeKeyUnhealthyStateRoutingMinimumHealthyTargetsCount {
+		value, err := strconv.ParseInt(rawValue, 10, 64)
+		if err != nil || value < 1 {
+			return errors.Errorf("invalid target group health requirement %v: %v", attrKey, rawValue)
+		}
+		isPercentage := attrKey == TargetGroupAttributeKeyDNSFailoverMinimumHealthyTargetsPercentage ||
+			attrKey == TargetGroupAttributeKeyUnhealthyStateRoutingMinimumHealthyTargetsPercentage
+		if isPercentage && value > 100 {
+			return errors.Errorf("invalid target group health requirement %v: %v", attrKey, rawValue)
+		}
+	}
+	if rawAttrValue, ok := rawAttributes[attrKey]; ok && rawAttrValue != rawValue {
+		return errors.Errorf("conflicting target group health requirement %v: %v, %v", attrKey, rawValue, rawAttrValue)
+	}
+	return nil
+}
+
+// validateTargetGroupSlowStartDuration validates the slow start duration, which must be between 30 and 900 seconds or 0 to turn it off.
+func validateTargetGroupSlowStartDuration(value string) error {
+	if value == "0" {
+		return nil
+	}
+	return validateTargetGroupAttributeInt64InRange(30, 900)(value)
+}
+
+// validateTargetGroupAttributeHealthRequirement returns a validator for target group health requirements, which are either off or a number within range.
+func validateTargetGroupAttributeHealthRequirement(min int64, max int64) func(value string) error {
+	validateInRange := validateTargetGroupAttributeInt64InRange(min, max)
+	return func(value string) error {
+		if value == TargetGroupHealthRequirementOff {
+			return nil
+		}
+		return validateInRange(value)
+	}
+}
+
+// validateTargetGroupAttributeBool validates the value is either true or false.
+func validateTargetGroupAttributeBool(value string) error {
+	return validateTargetGroupAttributeOneOf("true", "false")(value)
+}
+
+// validateTargetGroupAttributeOneOf returns a validator that validates the value is one of allowedValues.
+func validateTargetGroupAttributeOneOf(allowedValues ...string) func(value string) error {
+	return func(value string) error {
+		for _, allowedValue := range allowedValues {
+			if value == allowedValue {
+				return nil
+			}
+		}
+		return errors.Errorf("value %v must be one of %v", value, strings.Join(allowedValues, ", "))
+	}
+}
+
+// validateTargetGroupAttributeInt64InRange returns a validator that validates the value is an integer between min and max.
+func validateTargetGroupAttributeInt64InRange(min int64, max int64) func(value string) error {
+	return func(value string) error {
+		intValue, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || intValue < min || intValue > max {
+			return errors.Errorf("value %v must be an integer between %v and %v", value, min, max)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,274 @@
+package elbv2
+
+import (
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBuildTargetGroupAttributesToUpdate(t *testing.T) {
+	tests := []struct {
+		name         string
+		desiredAttrs map[string]string
+		currentAttrs map[string]string
+		want         map[string]string
+		wantErr      error
+	}{
+		{
+			name: "only changed attributes are updated",
+			desiredAttrs: map[string]string{
+				"slow_start.duration_seconds": "60",
+				"stickiness.enabled":          "true",
+			},
+			currentAttrs: map[string]string{
+				"slow_start.duration_seconds": "0",
+				"stickiness.enabled":          "true",
+				"stickiness.type":             "lb_cookie",
+			},
+			want: map[string]string{
+				"slow_start.duration_seconds": "60",
+			},
+		},
+		{
+			name: "switch from lb_cookie to app_cookie updates all desired stickiness attributes",
+			desiredAttrs: map[string]string{
+				"stickiness.enabled":                     "true",
+				"stickiness.type":                        "app_cookie",
+				"stickiness.app_cookie.cookie_name":      "SESSIONID",
+				"stickiness.app_cookie.duration_seconds": "86400",
+			},
+			currentAttrs: map[string]string{
+				"stickiness.enabled":                     "true",
+				"stickiness.type":                        "lb_cookie",
+				"stickiness.lb_cookie.duration_seconds":  "86400",
+				"stickiness.app_cookie.cookie_name":      "SESSIONID",
+				"stickiness.app_cookie.duration_seconds": "86400",
+			},
+			want: map[string]string{
+				"stickiness.enabled":                     "true",
+				"stickiness.type":                        "app_cookie",
+				"stickiness.app_cookie.cookie_name":      "SESSIONID",
+				"stickiness.app_cookie.duration_seconds": "86400",
+			},
+		},
+		{
+			name: "switch from app_cookie to lb_cookie",
+			desiredAttrs: map[string]string{
+				"stickiness.enabled": "true",
+				"stickiness.type":    "lb_cookie",
+			},
+			currentAttrs: map[string]string{
+				"stickiness.enabled":                "true",
+				"stickiness.type":                   "app_cookie",
+				"stickiness.app_cookie.cookie_name": "SESSIONID",
+			},
+			want: map[string]string{
+				"stickiness.enabled": "true",
+				"stickiness.type":    "lb_cookie",
+			},
+		},
+		{
+			name: "app_cookie with cookie name configured already",
+			desiredAttrs: map[string]string{
+				"stickiness.enabled": "true",
+				"stickiness.type":    "app_cookie",
+			},
+			currentAttrs: map[string]string{
+				"stickiness.enabled":                "false",
+				"stickiness.type":                   "lb_cookie",
+				"stickiness.app_cookie.cookie_name": "SESSIONID",
+			},
+			want: map[string]string{
+				"stickiness.enabled": "true",
+				"stickiness.type":    "app_cookie",
+			},
+		},
+		{
+			name: "app_cookie without cookie name",
+			desiredAttrs: map[string]string{
+				"stickiness.enabled": "true",
+				"stickiness.type":    "app_cookie",
+			},
+			currentAttrs: map[string]string{
+				"stickiness.enabled":                "false",
+				"stickiness.type":                   "lb_cookie",
+				"stickiness.app_cookie.cookie_name": "",
+			},
+			wantErr: errors.New("app_cookie stickiness requires attribute stickiness.app_cookie.cookie_name"),
+		},
+		{
+			name: "disabled app_cookie without cookie name",
+			desiredAttrs: map[string]string{
+				"stickiness.enabled": "false",
+			},
+			currentAttrs: map[string]string{
+				"stickiness.enabled": "true",
+				"stickiness.type":    "app_cookie",
+			},
+			want: map[string]string{
+				"stickiness.enabled": "false",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuildTargetGroupAttributesToUpdate(tt.desiredAttrs, tt.currentAttrs)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestValidateTargetGroupAttributes(t *testing.T) {
+	tests := []struct {
+		name    string
+		attrs   map[string]string
+		wantErr error
+	}{
+		{
+			name: "valid attributes",
+			attrs: map[string]string{
+				"deregistration_delay.timeout_seconds": "30",
+				"slow_start.duration_seconds":          "0",
+				"stickiness.enabled":                   "true",
+				"stickiness.type":                      "app_cookie",
+				"stickiness.app_cookie.cookie_name":    "SESSIONID",
+				"load_balancing.algorithm.type":        "least_outstanding_requests",
+				"target_group_health.dns_failover.minimum_healthy_targets.percentage": "off",
+			},
+		},
+		{
+			name: "unsupported attribute",
+			attrs: map[string]string{
+				"stickiness.enable": "true",
+			},
+			wantErr: errors.New("unsupported target group attribute stickiness.enable"),
+		},
+		{
+			name: "non-boolean value",
+			attrs: map[string]string{
+				"preserve_client_ip.enabled": "yes",
+			},
+			wantErr: errors.New("invalid target group attribute preserve_client_ip.enabled: value yes must be one of true, false"),
+		},
+		{
+			name: "value out of range",
+			attrs: map[string]string{
+				"slow_start.duration_seconds": "10",
+			},
+			wantErr: errors.New("invalid target group attribute slow_start.duration_seconds: value 10 must be an integer between 30 and 900"),
+		},
+		{
+			name: "reserved app cookie name",
+			attrs: map[string]string{
+				"stickiness.app_cookie.cookie_name": "AWSALBAPP-0",
+			},
+			wantErr: errors.New("invalid target group attribute stickiness.app_cookie.cookie_name: invalid target group app cookie name AWSALBAPP-0, prefix AWSALB is reserved"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTargetGroupAttributes(tt.attrs)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateTargetGroupCrossZone(t *testing.T) {
+	tests := []struct {
+		name          string
+		rawCrossZone  string
+		rawAttributes map[string]string
+		wantErr       error
+	}{
+		{
+			name:         "use load balancer configuration",
+			rawCrossZone: "use_load_balancer_configuration",
+		},
+		{
+			name:         "same value as attribute",
+			rawCrossZone: "true",
+			rawAttributes: map[string]string{
+				"load_balancing.cross_zone.enabled": "true",
+			},
+		},
+		{
+			name:         "invalid value",
+			rawCrossZone: "enabled",
+			wantErr:      errors.New("invalid target group cross-zone load balancing enabled, must be one of true, false or use_load_balancer_configuration"),
+		},
+		{
+			name:         "conflicting with attribute",
+			rawCrossZone: "false",
+			rawAttributes: map[string]string{
+				"load_balancing.cross_zone.enabled": "true",
+			},
+			wantErr: errors.New("conflicting target group cross-zone load balancing: false, true"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTargetGroupCrossZone(tt.rawCrossZone, tt.rawAttributes)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateTargetGroupHealthRequirement(t *testing.T) {
+	tests := []struct {
+		name          string
+		attrKey       string
+		rawValue      string
+		rawAttributes map[string]string
+		wantErr       error
+	}{
+		{
+			name:     "count turned off",
+			attrKey:  "target_group_health.dns_failover.minimum_healthy_targets.count",
+			rawValue: "off",
+		},
+		{
+			name:     "unhealthy state routing count cannot be turned off",
+			attrKey:  "target_group_health.unhealthy_state_routing.minimum_healthy_targets.count",
+			rawValue: "off",
+			wantErr:  errors.New("invalid target group health requirement target_group_health.unhealthy_state_routing.minimum_healthy_targets.count: off"),
+		},
+		{
+			name:     "percentage out of range",
+			attrKey:  "target_group_health.dns_failover.minimum_healthy_targets.percentage",
+			rawValue: "101",
+			wantErr:  errors.New("invalid target group health requirement target_group_health.dns_failover.minimum_healthy_targets.percentage: 101"),
+		},
+		{
+			name:     "conflicting with attribute",
+			attrKey:  "target_group_health.unhealthy_state_routing.minimum_healthy_targets.percentage",
+			rawValue: "50",
+			rawAttributes: map[string]string{
+				"target_group_health.unhealthy_state_routing.minimum_healthy_targets.percentage": "off",
+			},
+			wantErr: errors.New("conflicting target group health requirement target_group_health.unhealthy_state_routing.minimum_healthy_targets.percentage: 50, off"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTargetGroupHealthRequirement(tt.attrKey, tt.rawValue, tt.rawAttributes)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -92,9 +92,21 @@ type TargetGroupBindingSpec struct {
 	// serviceRef is a reference to a Kubernetes Service and ServicePort.
 	ServiceRef elbv2api.ServiceReference `json:"serviceRef"`
 
+	// ipAddressType is the IP address type of TargetGroup.
+	// +optional
+	IPAddressType *elbv2api.TargetGroupIPAddressType `json:"ipAddressType,omitempty"`
+
 	// networking provides the networking setup for ELBV2 LoadBalancer to access targets in TargetGroup.
 	// +optional
 	Networking *TargetGroupBindingNetworking `json:"networking,omitempty"`
+
+	// iamRoleARNToAssume is the ARN of IAM role to assume when reconciling targets of the TargetGroup.
+	// +optional
+	IAMRoleARNToAssume string `json:"iamRoleARNToAssume,omitempty"`
+
+	// nodeSelector selects the nodes to register as targets for instance TargetType.
+	// +optional
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
 }
 
 // Template for TargetGroupBinding Custom Resource.
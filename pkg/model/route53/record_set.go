@@ -0,0 +1,81 @@
+package route53
+
+import (
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+)
+
+// RecordSet represents a Route 53 alias record that routes traffic for a host to a load balancer.
+type RecordSet struct {
+	core.ResourceMeta `json:"-"`
+
+	// desired state of RecordSet
+	Spec RecordSetSpec `json:"spec"`
+
+	// observed state of RecordSet
+	// +optional
+	Status *RecordSetStatus `json:"status,omitempty"`
+}
+
+// NewRecordSet constructs new RecordSet resource.
+func NewRecordSet(stack core.Stack, id string, spec RecordSetSpec) *RecordSet {
+	rs := &RecordSet{
+		ResourceMeta: core.NewResourceMeta(stack, "AWS::Route53::RecordSet", id),
+		Spec:         spec,
+		Status:       nil,
+	}
+	stack.AddResource(rs)
+	rs.registerDependencies(stack)
+	return rs
+}
+
+// SetStatus sets the RecordSet's status
+func (rs *RecordSet) SetStatus(status RecordSetStatus) {
+	rs.Status = &status
+}
+
+// register dependencies for RecordSet.
+func (rs *RecordSet) registerDependencies(stack core.Stack) {
+	for _, token := range []core.StringToken{rs.Spec.AliasTarget.DNSName, rs.Spec.AliasTarget.HostedZoneID} {
+		for _, dep := range token.Dependencies() {
+			stack.AddDependency(dep, rs)
+		}
+	}
+}
+
+// RecordType is the DNS record type of RecordSet.
+type RecordType string
+
+const (
+	RecordTypeA    RecordType = "A"
+	RecordTypeAAAA RecordType = "AAAA"
+)
+
+// AliasTarget is the load balancer that an alias record routes traffic to.
+type AliasTarget struct {
+	// The DNS name of the load balancer.
+	DNSName core.StringToken `json:"dnsName"`
+
+	// The ID of the hosted zone associated with the load balancer.
+	HostedZoneID core.StringToken `json:"hostedZoneID"`
+
+	// Whether the alias record inherits the health of the load balancer.
+	EvaluateTargetHealth bool `json:"evaluateTargetHealth"`
+}
+
+// RecordSetSpec defines the desired state of RecordSet
+type RecordSetSpec struct {
+	// The host name of the record, the record is created in the hosted zone whose domain name matches the host name best.
+	Name string `json:"name"`
+
+	// The DNS record type.
+	Type RecordType `json:"type"`
+
+	// The load balancer that the record routes traffic to.
+	AliasTarget AliasTarget `json:"aliasTarget"`
+}
+
+// RecordSetStatus defines the observed state of RecordSet
+type RecordSetStatus struct {
+	// The IDs of hosted zones containing the record.
+	HostedZoneIDs []string `json:"hostedZoneIDs"`
+}
@@ -0,0 +1,70 @@
+package zonalshift
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+)
+
+// ZonalShift represents a Route 53 ARC zonal shift that moves traffic away from an Availability Zone.
+type ZonalShift struct {
+	core.ResourceMeta `json:"-"`
+
+	// desired state of ZonalShift
+	Spec ZonalShiftSpec `json:"spec"`
+
+	// observed state of ZonalShift
+	// +optional
+	Status *ZonalShiftStatus `json:"status,omitempty"`
+}
+
+// NewZonalShift constructs new ZonalShift resource.
+func NewZonalShift(stack core.Stack, id string, spec ZonalShiftSpec) *ZonalShift {
+	s := &ZonalShift{
+		ResourceMeta: core.NewResourceMeta(stack, "AWS::ARCZonalShift::ZonalShift", id),
+		Spec:         spec,
+		Status:       nil,
+	}
+	stack.AddResource(s)
+	s.registerDependencies(stack)
+	return s
+}
+
+// SetStatus sets the ZonalShift's status
+func (s *ZonalShift) SetStatus(status ZonalShiftStatus) {
+	s.Status = &status
+}
+
+// register dependencies for ZonalShift.
+func (s *ZonalShift) registerDependencies(stack core.Stack) {
+	for _, dep := range s.Spec.ResourceARN.Dependencies() {
+		stack.AddDependency(dep, s)
+	}
+}
+
+// ZonalShiftSpec defines the desired state of ZonalShift
+type ZonalShiftSpec struct {
+	// The Amazon Resource Name (ARN) of the resource to shift traffic for.
+	ResourceARN core.StringToken `json:"resourceARN"`
+
+	// The Availability Zone ID that traffic is moved away from, e.g. use1-az1.
+	AwayFrom string `json:"awayFrom"`
+
+	// The length of time that the zonal shift is active, e.g. 30m or 12h.
+	ExpiresIn string `json:"expiresIn"`
+
+	// A comment about the zonal shift.
+	// +optional
+	Comment string `json:"comment,omitempty"`
+}
+
+// ZonalShiftStatus defines the observed state of ZonalShift
+type ZonalShiftStatus struct {
+	// The identifier of the zonal shift.
+	ZonalShiftID string `json:"zonalShiftID"`
+
+	// The status of the zonal shift, one of ACTIVE, EXPIRED or CANCELED.
+	Status string `json:"status"`
+
+	// The expiry time of the zonal shift.
+	ExpiryTime metav1.Time `json:"expiryTime"`
+}
@@ -0,0 +1,50 @@
+package zonalshift
+
+import (
+	"github.com/pkg/errors"
+	"regexp"
+	"strconv"
+)
+
+// zonal shifts can be active for up to three days.
+const maxZonalShiftExpiresInMinutes = 3 * 24 * 60
+
+var (
+	zonalShiftAwayFromPattern  = regexp.MustCompile(`^[a-z]+[0-9]+-az[0-9]+$`)
+	zonalShiftExpiresInPattern = regexp.MustCompile(`^([1-9][0-9]*)([mh])$`)
+)
+
+// ValidateZonalShift validates the Availability Zone ID and expiry duration of zonal shift.
+func ValidateZonalShift(awayFrom string, expiresIn string) error {
+	if err := ValidateZonalShiftAwayFrom(awayFrom); err != nil {
+		return err
+	}
+	return ValidateZonalShiftExpiresIn(expiresIn)
+}
+
+// ValidateZonalShiftAwayFrom validates the Availability Zone ID of zonal shift.
+func ValidateZonalShiftAwayFrom(awayFrom string) error {
+	if !zonalShiftAwayFromPattern.MatchString(awayFrom) {
+		return errors.Errorf("invalid zonal shift away-from %v, must be an availability zone ID like use1-az1", awayFrom)
+	}
+	return nil
+}
+
+// ValidateZonalShiftExpiresIn validates the expiry duration of zonal shift.
+func ValidateZonalShiftExpiresIn(expiresIn string) error {
+	matches := zonalShiftExpiresInPattern.FindStringSubmatch(expiresIn)
+	if matches == nil {
+		return errors.Errorf("invalid zonal shift expires-in %v, must be minutes or hours like 30m or 12h", expiresIn)
+	}
+	expiresInMinutes, err := strconv.Atoi(matches[1])
+	if err != nil {
+		return errors.Errorf("invalid zonal shift expires-in %v, must be minutes or hours like 30m or 12h", expiresIn)
+	}
+	if matches[2] == "h" {
+		expiresInMinutes *= 60
+	}
+	if expiresInMinutes > maxZonalShiftExpiresInMinutes {
+		return errors.Errorf("invalid zonal shift expires-in %v, must be no longer than %vh", expiresIn, maxZonalShiftExpiresInMinutes/60)
+	}
+	return nil
+}
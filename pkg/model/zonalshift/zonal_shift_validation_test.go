@@ -0,0 +1,61 @@
+package zonalshift
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestValidateZonalShift(t *testing.T) {
+	tests := []struct {
+		name      string
+		awayFrom  string
+		expiresIn string
+		wantErr   error
+	}{
+		{
+			name:      "shift in minutes",
+			awayFrom:  "use1-az1",
+			expiresIn: "30m",
+		},
+		{
+			name:      "shift for three days",
+			awayFrom:  "usw2-az3",
+			expiresIn: "72h",
+		},
+		{
+			name:      "availability zone name instead of ID",
+			awayFrom:  "us-east-1a",
+			expiresIn: "1h",
+			wantErr:   errors.New("invalid zonal shift away-from us-east-1a, must be an availability zone ID like use1-az1"),
+		},
+		{
+			name:      "expires-in without unit",
+			awayFrom:  "use1-az1",
+			expiresIn: "30",
+			wantErr:   errors.New("invalid zonal shift expires-in 30, must be minutes or hours like 30m or 12h"),
+		},
+		{
+			name:      "zero expires-in",
+			awayFrom:  "use1-az1",
+			expiresIn: "0m",
+			wantErr:   errors.New("invalid zonal shift expires-in 0m, must be minutes or hours like 30m or 12h"),
+		},
+		{
+			name:      "expires-in longer than three days",
+			awayFrom:  "use1-az1",
+			expiresIn: "4321m",
+			wantErr:   errors.New("invalid zonal shift expires-in 4321m, must be no longer than 72h"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateZonalShift(tt.awayFrom, tt.expiresIn)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
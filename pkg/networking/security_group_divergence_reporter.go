@@ -0,0 +1,61 @@
+package networking
+
+import (
+	"context"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/audit"
+)
+
+const (
+	metricSubsystemSecurityGroup   = "securitygroup"
+	metricRetainedExtraPermissions = "retained_extra_permissions"
+	metricLabelSecurityGroupID     = "security_group_id"
+)
+
+// SecurityGroupDivergenceReporter reports the extra permissions retained on SecurityGroups by additive-only reconciles.
+type SecurityGroupDivergenceReporter interface {
+	// Report reports the extra permissions retained on SecurityGroup, the report is cleared if there is none.
+	Report(ctx context.Context, sgID string, extraPermissions []IPPermissionInfo)
+}
+
+// NewDefaultSecurityGroupDivergenceReporter constructs new defaultSecurityGroupDivergenceReporter that registers metrics to registerer.
+func NewDefaultSecurityGroupDivergenceReporter(registerer prometheus.Registerer, logger logr.Logger) (*defaultSecurityGroupDivergenceReporter, error) {
+	retainedExtraPermissions := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricSubsystemSecurityGroup,
+		Name:      metricRetainedExtraPermissions,
+		Help:      "Number of managed permissions on SecurityGroups that are retained instead of revoked by additive-only reconciles",
+	}, []string{metricLabelSecurityGroupID})
+	if err := registerer.Register(retainedExtraPermissions); err != nil {
+		return nil, err
+	}
+	return &defaultSecurityGroupDivergenceReporter{
+		retainedExtraPermissions: retainedExtraPermissions,
+		logger:                   logger,
+	}, nil
+}
+
+var _ SecurityGroupDivergenceReporter = &defaultSecurityGroupDivergenceReporter{}
+
+// default implementation for SecurityGroupDivergenceReporter, which reports via metrics, and events on objects carried by context.
+type defaultSecurityGroupDivergenceReporter struct {
+	retainedExtraPermissions *prometheus.GaugeVec
+	logger                   logr.Logger
+}
+
+func (r *defaultSecurityGroupDivergenceReporter) Report(ctx context.Context, sgID string, extraPermissions []IPPermissionInfo) {
+	if len(extraPermissions) == 0 {
+		r.retainedExtraPermissions.Delete(prometheus.Labels{metricLabelSecurityGroupID: sgID})
+		return
+	}
+	r.retainedExtraPermissions.With(prometheus.Labels{metricLabelSecurityGroupID: sgID}).Set(float64(len(extraPermissions)))
+	diff := audit.ComputeDiff(nil, buildIngressPermissionsForAudit(extraPermissions))
+	r.logger.Info("retained extra securityGroup permissions",
+		"securityGroupID", sgID,
+		"permissions", diff)
+	audit.RecordDivergence(ctx, audit.Divergence{
+		ResourceKind: audit.ResourceKindSecurityGroupRule,
+		ResourceID:   sgID,
+		Diff:         diff,
+	})
+}
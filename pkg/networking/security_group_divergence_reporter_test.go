@@ -0,0 +1,45 @@
+package networking
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/audit"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_defaultSecurityGroupDivergenceReporter_Report(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "svc-1",
+		},
+	}
+	eventRecorder := record.NewFakeRecorder(10)
+	mutationRecorder := audit.NewDefaultMutationRecorder(eventRecorder, audit.Config{EnableEvents: true}, &log.NullLogger{})
+	ctx := audit.ContextWithMutationRecorder(context.Background(), mutationRecorder, svc)
+
+	reporter, err := NewDefaultSecurityGroupDivergenceReporter(prometheus.NewRegistry(), &log.NullLogger{})
+	assert.NoError(t, err)
+	reporter.Report(ctx, "sg-1", []IPPermissionInfo{
+		NewCIDRIPPermission("tcp", awssdk.Int64(22), awssdk.Int64(22), "192.168.0.0/16", nil),
+		NewCIDRIPPermission("tcp", awssdk.Int64(22), awssdk.Int64(22), "10.0.0.0/16", nil),
+	})
+	assert.Equal(t, float64(2), testutil.ToFloat64(reporter.retainedExtraPermissions.With(prometheus.Labels{
+		metricLabelSecurityGroupID: "sg-1",
+	})))
+	assert.Len(t, eventRecorder.Events, 1)
+	assert.Contains(t, <-eventRecorder.Events, "Warning AWSResourceDiverged Retained divergence of SecurityGroupRule sg-1")
+
+	reporter.Report(ctx, "sg-1", nil)
+	assert.Len(t, eventRecorder.Events, 0)
+	assert.False(t, reporter.retainedExtraPermissions.Delete(prometheus.Labels{
+		metricLabelSecurityGroupID: "sg-1",
+	}))
+}
@@ -13,8 +13,19 @@ import (
 const (
 	// the raw permission description
 	labelKeyRawDescription = "raw/description"
+
+	// the IP protocol of all-protocol permissions.
+	ipProtocolAll = "-1"
 )
 
+// ipProtocolNameByNumber contains the protocols that EC2 reports by name, even if they are specified by number.
+var ipProtocolNameByNumber = map[string]string{
+	"1":  "icmp",
+	"6":  "tcp",
+	"17": "udp",
+	"58": "icmpv6",
+}
+
 // SecurityGroupInfo wraps necessary information about a SecurityGroup.
 type SecurityGroupInfo struct {
 	// SecurityGroup's ID.
@@ -34,15 +45,34 @@ type IPPermissionInfo struct {
 	// a set of computed labels for IPPermission.
 	// we can use labels to select the rules we want to manage.
 	Labels map[string]string
+
+	// the tags applied to the securityGroup rule when the IPPermission is authorized.
+	Tags map[string]string
+}
+
+// SecurityGroupRuleInfo wraps necessary information about an ingress rule of SecurityGroup.
+type SecurityGroupRuleInfo struct {
+	// SecurityGroupRule's ID.
+	RuleID string
+
+	// the permission of the rule, which has the same HashCode as the IPPermissionInfo in SecurityGroupInfo.
+	Permission IPPermissionInfo
+
+	// Tags for the securityGroup rule.
+	Tags map[string]string
 }
 
 // HashCode returns the hashcode for the IPPermissionInfo.
 // The hashCode should only include the actual permission but not labels/descriptions.
 func (perm *IPPermissionInfo) HashCode() string {
-	protocol := awssdk.StringValue(perm.Permission.IpProtocol)
+	protocol := normalizeIPProtocol(awssdk.StringValue(perm.Permission.IpProtocol))
 	fromPort := awssdk.Int64Value(perm.Permission.FromPort)
 	toPort := awssdk.Int64Value(perm.Permission.ToPort)
 	base := fmt.Sprintf("IpProtocol: %v, FromPort: %v, ToPort: %v", protocol, fromPort, toPort)
+	// EC2 doesn't report ports for all-protocol permissions.
+	if protocol == ipProtocolAll {
+		base = fmt.Sprintf("IpProtocol: %v", protocol)
+	}
 	if len(perm.Permission.IpRanges) == 1 {
 		cidrIP := awssdk.StringValue(perm.Permission.IpRanges[0].CidrIp)
 		return fmt.Sprintf("%v, IpRange: %v", base, cidrIP)
@@ -82,6 +112,34 @@ func NewRawSecurityGroupInfo(sdkSG *ec2sdk.SecurityGroup) SecurityGroupInfo {
 	}
 }
 
+// NewRawSecurityGroupRuleInfo constructs new SecurityGroupRuleInfo with raw ec2SDK's SecurityGroupRule object.
+func NewRawSecurityGroupRuleInfo(sdkRule *ec2sdk.SecurityGroupRule) SecurityGroupRuleInfo {
+	sdkPermission := ec2sdk.IpPermission{
+		IpProtocol: sdkRule.IpProtocol,
+		FromPort:   sdkRule.FromPort,
+		ToPort:     sdkRule.ToPort,
+	}
+	switch {
+	case sdkRule.CidrIpv4 != nil:
+		sdkPermission.IpRanges = []*ec2sdk.IpRange{{CidrIp: sdkRule.CidrIpv4, Description: sdkRule.Description}}
+	case sdkRule.CidrIpv6 != nil:
+		sdkPermission.Ipv6Ranges = []*ec2sdk.Ipv6Range{{CidrIpv6: sdkRule.CidrIpv6, Description: sdkRule.Description}}
+	case sdkRule.PrefixListId != nil:
+		sdkPermission.PrefixListIds = []*ec2sdk.PrefixListId{{PrefixListId: sdkRule.PrefixListId, Description: sdkRule.Description}}
+	case sdkRule.ReferencedGroupInfo != nil:
+		sdkPermission.UserIdGroupPairs = []*ec2sdk.UserIdGroupPair{{GroupId: sdkRule.ReferencedGroupInfo.GroupId, Description: sdkRule.Description}}
+	}
+	ruleTags := make(map[string]string, len(sdkRule.Tags))
+	for _, tag := range sdkRule.Tags {
+		ruleTags[awssdk.StringValue(tag.Key)] = awssdk.StringValue(tag.Value)
+	}
+	return SecurityGroupRuleInfo{
+		RuleID:     awssdk.StringValue(sdkRule.SecurityGroupRuleId),
+		Permission: NewRawIPPermission(sdkPermission),
+		Tags:       ruleTags,
+	}
+}
+
 // NewCIDRIPPermission constructs new IPPermissionInfo with CIDR configuration.
 func NewCIDRIPPermission(ipProtocol string, fromPort *int64, toPort *int64, cidr string, labels map[string]string) IPPermissionInfo {
 	description := buildIPPermissionDescriptionForLabels(labels)
@@ -196,6 +254,15 @@ func NewIPPermissionLabelsForRawDescription(description string) map[string]strin
 	return map[string]string{labelKeyRawDescription: description}
 }
 
+// normalizeIPProtocol normalizes protocol into the form EC2 reports it, so that equivalent permissions have same hashCode.
+func normalizeIPProtocol(protocol string) string {
+	protocol = strings.ToLower(protocol)
+	if protocolName, exists := ipProtocolNameByNumber[protocol]; exists {
+		return protocolName
+	}
+	return protocol
+}
+
 // buildSecurityGroupTags generates the tags for securityGroup.
 func buildSecurityGroupTags(sdkSG *ec2sdk.SecurityGroup) map[string]string {
 	sgTags := make(map[string]string, len(sdkSG.Tags))
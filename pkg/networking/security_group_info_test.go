@@ -81,6 +81,36 @@ func TestIPPermissionInfo_HashCode(t *testing.T) {
 			},
 			want: "IpProtocol: tcp, FromPort: 80, ToPort: 8080, UserIdGroupPair: sg-xxxx",
 		},
+		{
+			name: "ICMP permission specified by protocol number",
+			fields: fields{
+				Permission: ec2sdk.IpPermission{
+					IpProtocol: awssdk.String("1"),
+					FromPort:   awssdk.Int64(8),
+					ToPort:     awssdk.Int64(-1),
+					IpRanges: []*ec2sdk.IpRange{
+						{
+							CidrIp: awssdk.String("10.0.0.0/8"),
+						},
+					},
+				},
+			},
+			want: "IpProtocol: icmp, FromPort: 8, ToPort: -1, IpRange: 10.0.0.0/8",
+		},
+		{
+			name: "all-protocol permission",
+			fields: fields{
+				Permission: ec2sdk.IpPermission{
+					IpProtocol: awssdk.String("-1"),
+					UserIdGroupPairs: []*ec2sdk.UserIdGroupPair{
+						{
+							GroupId: awssdk.String("sg-xxxx"),
+						},
+					},
+				},
+			},
+			want: "IpProtocol: -1, UserIdGroupPair: sg-xxxx",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -93,3 +123,61 @@ func TestIPPermissionInfo_HashCode(t *testing.T) {
 		})
 	}
 }
+
+func TestNewRawSecurityGroupRuleInfo(t *testing.T) {
+	tests := []struct {
+		name    string
+		sdkRule *ec2sdk.SecurityGroupRule
+		want    SecurityGroupRuleInfo
+	}{
+		{
+			name: "rule with cidr and tags",
+			sdkRule: &ec2sdk.SecurityGroupRule{
+				SecurityGroupRuleId: awssdk.String("sgr-1"),
+				IpProtocol:          awssdk.String("tcp"),
+				FromPort:            awssdk.Int64(80),
+				ToPort:              awssdk.Int64(80),
+				CidrIpv4:            awssdk.String("10.0.0.0/16"),
+				Description:         awssdk.String("owner=lbc"),
+				Tags:                []*ec2sdk.Tag{{Key: awssdk.String("owner"), Value: awssdk.String("lbc")}},
+			},
+			want: SecurityGroupRuleInfo{
+				RuleID: "sgr-1",
+				Permission: IPPermissionInfo{
+					Permission: ec2sdk.IpPermission{
+						IpProtocol: awssdk.String("tcp"),
+						FromPort:   awssdk.Int64(80),
+						ToPort:     awssdk.Int64(80),
+						IpRanges:   []*ec2sdk.IpRange{{CidrIp: awssdk.String("10.0.0.0/16"), Description: awssdk.String("owner=lbc")}},
+					},
+					Labels: map[string]string{labelKeyRawDescription: "owner=lbc", "owner": "lbc"},
+				},
+				Tags: map[string]string{"owner": "lbc"},
+			},
+		},
+		{
+			name: "rule with referenced group",
+			sdkRule: &ec2sdk.SecurityGroupRule{
+				SecurityGroupRuleId: awssdk.String("sgr-2"),
+				IpProtocol:          awssdk.String("-1"),
+				ReferencedGroupInfo: &ec2sdk.ReferencedSecurityGroup{GroupId: awssdk.String("sg-2")},
+			},
+			want: SecurityGroupRuleInfo{
+				RuleID: "sgr-2",
+				Permission: IPPermissionInfo{
+					Permission: ec2sdk.IpPermission{
+						IpProtocol:       awssdk.String("-1"),
+						UserIdGroupPairs: []*ec2sdk.UserIdGroupPair{{GroupId: awssdk.String("sg-2")}},
+					},
+					Labels: map[string]string{labelKeyRawDescription: ""},
+				},
+				Tags: map[string]string{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NewRawSecurityGroupRuleInfo(tt.sdkRule))
+		})
+	}
+}
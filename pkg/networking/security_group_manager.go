@@ -2,6 +2,7 @@ package networking
 
 import (
 	"context"
+	"fmt"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/go-logr/logr"
@@ -9,6 +10,9 @@ import (
 	"k8s.io/apimachinery/pkg/util/cache"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 )
@@ -16,6 +20,8 @@ import (
 const (
 	// we cache securityGroup's information by 10 minutes.
 	defaultSGInfoCacheTTL = 10 * time.Minute
+	// we cache batched securityGroup's information by tags by 1 minute.
+	defaultSGInfosByTagsCacheTTL = 1 * time.Minute
 )
 
 type FetchSGInfoOptions struct {
@@ -39,19 +45,43 @@ func WithReloadIgnoringCache() FetchSGInfoOption {
 	}
 }
 
+type reloadIgnoringCacheContextKey struct{}
+
+// ContextWithReloadIgnoringCache returns a context that makes SecurityGroupInfo fetched with it reloaded from AWS directly,
+// so that changes made outside of the controller are observed immediately.
+func ContextWithReloadIgnoringCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, reloadIgnoringCacheContextKey{}, true)
+}
+
+// isReloadIgnoringCache checks whether SecurityGroupInfo fetched with ctx should be reloaded from AWS directly.
+func isReloadIgnoringCache(ctx context.Context) bool {
+	reloadIgnoringCache, _ := ctx.Value(reloadIgnoringCacheContextKey{}).(bool)
+	return reloadIgnoringCache
+}
+
 // SecurityGroupManager is an abstraction around EC2's SecurityGroup API.
 type SecurityGroupManager interface {
 	// FetchSGInfosByID will fetch SecurityGroupInfo with SecurityGroup IDs.
 	FetchSGInfosByID(ctx context.Context, sgIDs []string, opts ...FetchSGInfoOption) (map[string]SecurityGroupInfo, error)
 
+	// FetchSGIngressRules will fetch the ingress rules of SecurityGroup, which contain the rule IDs and tags.
+	FetchSGIngressRules(ctx context.Context, sgID string) ([]SecurityGroupRuleInfo, error)
+
 	// FetchSGInfosByRequest will fetch SecurityGroupInfo with raw DescribeSecurityGroupsInput request.
+	// requests that only filter by vpc-id and tags are served from batched lookups by tag keys, which are cached.
 	FetchSGInfosByRequest(ctx context.Context, req *ec2sdk.DescribeSecurityGroupsInput) (map[string]SecurityGroupInfo, error)
 
+	// InvalidateSGInfos will invalidate cached SecurityGroupInfo after SecurityGroups are created, deleted or tagged.
+	InvalidateSGInfos(sgIDs ...string)
+
 	// AuthorizeSGIngress will authorize Ingress permissions to SecurityGroup.
 	AuthorizeSGIngress(ctx context.Context, sgID string, permissions []IPPermissionInfo) error
 
 	// RevokeSGIngress will revoke Ingress permissions from SecurityGroup.
 	RevokeSGIngress(ctx context.Context, sgID string, permissions []IPPermissionInfo) error
+
+	// TagSGRules will tag the rules of SecurityGroup.
+	TagSGRules(ctx context.Context, sgID string, ruleIDs []string, tags map[string]string) error
 }
 
 // NewDefaultSecurityGroupManager constructs new defaultSecurityGroupManager.
@@ -63,6 +93,12 @@ func NewDefaultSecurityGroupManager(ec2Client services.EC2, logger logr.Logger)
 		sgInfoCache:      cache.NewExpiring(),
 		sgInfoCacheMutex: sync.RWMutex{},
 		sgInfoCacheTTL:   defaultSGInfoCacheTTL,
+
+		sgInfosByTagsCache:      cache.NewExpiring(),
+		sgInfosByTagsCacheMutex: sync.Mutex{},
+		sgInfosByTagsCacheTTL:   defaultSGInfosByTagsCacheTTL,
+
+		sgRulesCache: cache.NewExpiring(),
 	}
 }
 
@@ -76,11 +112,21 @@ type defaultSecurityGroupManager struct {
 	sgInfoCache      *cache.Expiring
 	sgInfoCacheMutex sync.RWMutex
 	sgInfoCacheTTL   time.Duration
+
+	// sgInfosByTagsCache caches the results of batched lookups by tag keys.
+	// the mutex only guards swapping the cache upon invalidation, concurrent lookups are shared via sgInfosByTagsFlight instead.
+	sgInfosByTagsCache      *cache.Expiring
+	sgInfosByTagsCacheMutex sync.Mutex
+	sgInfosByTagsCacheTTL   time.Duration
+	sgInfosByTagsFlight     runtime.SingleFlight
+
+	// sgRulesCache caches the ingress rules by SecurityGroup ID, it expires together with sgInfoCache.
+	sgRulesCache *cache.Expiring
 }
 
 func (m *defaultSecurityGroupManager) FetchSGInfosByID(ctx context.Context, sgIDs []string, opts ...FetchSGInfoOption) (map[string]SecurityGroupInfo, error) {
 	fetchOpts := FetchSGInfoOptions{
-		ReloadIgnoringCache: false,
+		ReloadIgnoringCache: isReloadIgnoringCache(ctx),
 	}
 	fetchOpts.ApplyOptions(opts...)
 
@@ -117,6 +163,14 @@ func (m *defaultSecurityGroupManager) FetchSGInfosByID(ctx context.Context, sgID
 }
 
 func (m *defaultSecurityGroupManager) FetchSGInfosByRequest(ctx context.Context, req *ec2sdk.DescribeSecurityGroupsInput) (map[string]SecurityGroupInfo, error) {
+	if batchReq, tagValuesFilter, ok := buildBatchRequestByTagKeys(req); ok && !isReloadIgnoringCache(ctx) {
+		sgInfosByID, err := m.fetchSGInfosByTagKeys(ctx, batchReq)
+		if err != nil {
+			return nil, err
+		}
+		return filterSGInfosByTagValues(sgInfosByID, tagValuesFilter), nil
+	}
+
 	sgInfosByID, err := m.fetchSGInfosFromAWS(ctx, req)
 	if err != nil {
 		return nil, err
@@ -126,22 +180,70 @@ func (m *defaultSecurityGroupManager) FetchSGInfosByRequest(ctx context.Context,
 }
 
 func (m *defaultSecurityGroupManager) AuthorizeSGIngress(ctx context.Context, sgID string, permissions []IPPermissionInfo) error {
-	sdkIPPermissions := buildSDKIPPermissions(permissions)
-	req := &ec2sdk.AuthorizeSecurityGroupIngressInput{
-		GroupId:       awssdk.String(sgID),
-		IpPermissions: sdkIPPermissions,
+	// TODO: ideally we can remember the permissions we granted to save DescribeSecurityGroup API calls.
+	defer m.InvalidateSGInfos(sgID)
+
+	// the tags of a request apply to all rules it creates, so permissions with different tags are authorized separately.
+	for _, permissionsWithSameTags := range groupIPPermissionsByTags(permissions) {
+		sdkIPPermissions := buildSDKIPPermissions(permissionsWithSameTags)
+		req := &ec2sdk.AuthorizeSecurityGroupIngressInput{
+			GroupId:           awssdk.String(sgID),
+			IpPermissions:     sdkIPPermissions,
+			TagSpecifications: buildSDKSecurityGroupRuleTagSpecifications(permissionsWithSameTags[0].Tags),
+		}
+		m.logger.Info("authorizing securityGroup ingress",
+			"securityGroupID", sgID,
+			"permission", sdkIPPermissions)
+		if _, err := m.ec2Client.AuthorizeSecurityGroupIngressWithContext(ctx, req); err != nil {
+			return err
+		}
+		m.logger.Info("authorized securityGroup ingress",
+			"securityGroupID", sgID)
+	}
+	return nil
+}
+
+func (m *defaultSecurityGroupManager) FetchSGIngressRules(ctx context.Context, sgID string) ([]SecurityGroupRuleInfo, error) {
+	if rawCacheItem, exists := m.sgRulesCache.Get(sgID); exists && !isReloadIgnoringCache(ctx) {
+		return rawCacheItem.([]SecurityGroupRuleInfo), nil
+	}
+	req := &ec2sdk.DescribeSecurityGroupRulesInput{
+		Filters: []*ec2sdk.Filter{
+			{
+				Name:   awssdk.String("group-id"),
+				Values: awssdk.StringSlice([]string{sgID}),
+			},
+		},
+	}
+	sdkRules, err := m.ec2Client.DescribeSecurityGroupRulesAsList(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	ruleInfos := make([]SecurityGroupRuleInfo, 0, len(sdkRules))
+	for _, sdkRule := range sdkRules {
+		if awssdk.BoolValue(sdkRule.IsEgress) {
+			continue
+		}
+		ruleInfos = append(ruleInfos, NewRawSecurityGroupRuleInfo(sdkRule))
+	}
+	m.sgRulesCache.Set(sgID, ruleInfos, m.sgInfoCacheTTL)
+	return ruleInfos, nil
+}
+
+func (m *defaultSecurityGroupManager) TagSGRules(ctx context.Context, sgID string, ruleIDs []string, tags map[string]string) error {
+	req := &ec2sdk.CreateTagsInput{
+		Resources: awssdk.StringSlice(ruleIDs),
+		Tags:      buildSDKTags(tags),
 	}
-	m.logger.Info("authorizing securityGroup ingress",
+	m.logger.Info("tagging securityGroup rules",
 		"securityGroupID", sgID,
-		"permission", sdkIPPermissions)
-	if _, err := m.ec2Client.AuthorizeSecurityGroupIngressWithContext(ctx, req); err != nil {
+		"ruleIDs", ruleIDs)
+	if _, err := m.ec2Client.CreateTagsWithContext(ctx, req); err != nil {
 		return err
 	}
-	m.logger.Info("authorized securityGroup ingress",
+	m.logger.Info("tagged securityGroup rules",
 		"securityGroupID", sgID)
-
-	// TODO: ideally we can remember the permissions we granted to save DescribeSecurityGroup API calls.
-	m.clearSGInfosFromCache(sgID)
+	m.sgRulesCache.Delete(sgID)
 	return nil
 }
 
@@ -161,10 +263,47 @@ func (m *defaultSecurityGroupManager) RevokeSGIngress(ctx context.Context, sgID
 		"securityGroupID", sgID)
 
 	// TODO: ideally we can remember the permissions we revoked to save DescribeSecurityGroup API calls.
-	m.clearSGInfosFromCache(sgID)
+	m.InvalidateSGInfos(sgID)
 	return nil
 }
 
+func (m *defaultSecurityGroupManager) InvalidateSGInfos(sgIDs ...string) {
+	for _, sgID := range sgIDs {
+		m.clearSGInfosFromCache(sgID)
+		m.sgRulesCache.Delete(sgID)
+	}
+	// batched lookups might contain any of the SecurityGroups, or miss new ones.
+	m.sgInfosByTagsCacheMutex.Lock()
+	defer m.sgInfosByTagsCacheMutex.Unlock()
+	m.sgInfosByTagsCache = cache.NewExpiring()
+}
+
+// fetchSGInfosByTagKeys fetches SecurityGroupInfo with batched request by tag keys, the results are cached.
+// concurrent lookups of the same request share a single call to AWS.
+func (m *defaultSecurityGroupManager) fetchSGInfosByTagKeys(ctx context.Context, batchReq *ec2sdk.DescribeSecurityGroupsInput) (map[string]SecurityGroupInfo, error) {
+	cacheKey := computeBatchRequestCacheKey(batchReq)
+	m.sgInfosByTagsCacheMutex.Lock()
+	sgInfosByTagsCache := m.sgInfosByTagsCache
+	m.sgInfosByTagsCacheMutex.Unlock()
+	if rawCacheItem, exists := sgInfosByTagsCache.Get(cacheKey); exists {
+		return rawCacheItem.(map[string]SecurityGroupInfo), nil
+	}
+	rawSGInfosByID, err := m.sgInfosByTagsFlight.Do(cacheKey, func() (interface{}, error) {
+		sgInfosByID, err := m.fetchSGInfosFromAWS(ctx, batchReq)
+		if err != nil {
+			return nil, err
+		}
+		m.saveSGInfosToCache(sgInfosByID)
+		// results are saved into the cache the lookup started with, so that they're discarded if invalidated meanwhile.
+		sgInfosByTagsCache.Set(cacheKey, sgInfosByID, m.sgInfosByTagsCacheTTL)
+		return sgInfosByID, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rawSGInfosByID.(map[string]SecurityGroupInfo), nil
+}
+
 func (m *defaultSecurityGroupManager) fetchSGInfosFromCache(sgIDs []string) map[string]SecurityGroupInfo {
 	m.sgInfoCacheMutex.RLock()
 	defer m.sgInfoCacheMutex.RUnlock()
@@ -207,6 +346,78 @@ func (m *defaultSecurityGroupManager) fetchSGInfosFromAWS(ctx context.Context, r
 	return sgInfoByID, nil
 }
 
+// buildBatchRequestByTagKeys builds a request that fetches SecurityGroups with same tag keys as req regardless of tag values,
+// so that requests for different tag values(e.g. different stacks) can share the results.
+// tag values are returned as tagValuesFilter to be applied on the results.
+// returns false if req contains filters other than vpc-id and tags, tag values with wildcards, or it's paginated.
+func buildBatchRequestByTagKeys(req *ec2sdk.DescribeSecurityGroupsInput) (*ec2sdk.DescribeSecurityGroupsInput, map[string][]string, bool) {
+	if len(req.GroupIds) != 0 || len(req.GroupNames) != 0 || req.NextToken != nil || req.MaxResults != nil || len(req.Filters) == 0 {
+		return nil, nil, false
+	}
+	var tagKeys []string
+	var batchFilters []*ec2sdk.Filter
+	tagValuesFilter := make(map[string][]string)
+	for _, filter := range req.Filters {
+		filterName := awssdk.StringValue(filter.Name)
+		switch {
+		case filterName == "vpc-id" || filterName == "tag-key":
+			batchFilters = append(batchFilters, filter)
+		case strings.HasPrefix(filterName, "tag:"):
+			// tag values with wildcards cannot be matched locally.
+			for _, tagValue := range awssdk.StringValueSlice(filter.Values) {
+				if strings.ContainsAny(tagValue, "*?") {
+					return nil, nil, false
+				}
+			}
+			tagKey := strings.TrimPrefix(filterName, "tag:")
+			tagKeys = append(tagKeys, tagKey)
+			tagValuesFilter[tagKey] = awssdk.StringValueSlice(filter.Values)
+		default:
+			return nil, nil, false
+		}
+	}
+	if len(tagKeys) == 0 {
+		return nil, nil, false
+	}
+	for _, tagKey := range tagKeys {
+		batchFilters = append(batchFilters, &ec2sdk.Filter{
+			Name:   awssdk.String("tag-key"),
+			Values: awssdk.StringSlice([]string{tagKey}),
+		})
+	}
+	return &ec2sdk.DescribeSecurityGroupsInput{Filters: batchFilters}, tagValuesFilter, true
+}
+
+// filterSGInfosByTagValues returns SecurityGroupInfo whose tags match one of the values for each tag key in tagValuesFilter.
+func filterSGInfosByTagValues(sgInfosByID map[string]SecurityGroupInfo, tagValuesFilter map[string][]string) map[string]SecurityGroupInfo {
+	matchedSGInfosByID := make(map[string]SecurityGroupInfo)
+	for sgID, sgInfo := range sgInfosByID {
+		matched := true
+		for tagKey, tagValues := range tagValuesFilter {
+			tagValue, ok := sgInfo.Tags[tagKey]
+			if !ok || !sets.NewString(tagValues...).Has(tagValue) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			matchedSGInfosByID[sgID] = sgInfo
+		}
+	}
+	return matchedSGInfosByID
+}
+
+// computeBatchRequestCacheKey computes the cache key for batched request, which is independent of filter orders.
+func computeBatchRequestCacheKey(batchReq *ec2sdk.DescribeSecurityGroupsInput) string {
+	filterKeys := make([]string, 0, len(batchReq.Filters))
+	for _, filter := range batchReq.Filters {
+		filterValues := sets.NewString(awssdk.StringValueSlice(filter.Values)...).List()
+		filterKeys = append(filterKeys, fmt.Sprintf("%v=%v", awssdk.StringValue(filter.Name), strings.Join(filterValues, ",")))
+	}
+	sort.Strings(filterKeys)
+	return strings.Join(filterKeys, ";")
+}
+
 // buildSDKIPPermissions converts slice of IPPermissionInfo into slice of pointers to IPPermission
 // if targets is empty or nil, nil will be returned.
 func buildSDKIPPermissions(permissions []IPPermissionInfo) []*ec2sdk.IpPermission {
@@ -219,3 +430,42 @@ func buildSDKIPPermissions(permissions []IPPermissionInfo) []*ec2sdk.IpPermissio
 	}
 	return sdkPermissions
 }
+
+// groupIPPermissionsByTags groups permissions with same tags together, groups are sorted by tags.
+func groupIPPermissionsByTags(permissions []IPPermissionInfo) [][]IPPermissionInfo {
+	permissionsByTagsKey := make(map[string][]IPPermissionInfo)
+	for _, permission := range permissions {
+		tagsKey := buildIPPermissionDescriptionForLabels(permission.Tags)
+		permissionsByTagsKey[tagsKey] = append(permissionsByTagsKey[tagsKey], permission)
+	}
+	groups := make([][]IPPermissionInfo, 0, len(permissionsByTagsKey))
+	for _, tagsKey := range sets.StringKeySet(permissionsByTagsKey).List() {
+		groups = append(groups, permissionsByTagsKey[tagsKey])
+	}
+	return groups
+}
+
+// buildSDKSecurityGroupRuleTagSpecifications builds the tag specifications for securityGroup rules, nil is returned for empty tags.
+func buildSDKSecurityGroupRuleTagSpecifications(tags map[string]string) []*ec2sdk.TagSpecification {
+	if len(tags) == 0 {
+		return nil
+	}
+	return []*ec2sdk.TagSpecification{
+		{
+			ResourceType: awssdk.String(ec2sdk.ResourceTypeSecurityGroupRule),
+			Tags:         buildSDKTags(tags),
+		},
+	}
+}
+
+// buildSDKTags converts tags into EC2 tags sorted by key.
+func buildSDKTags(tags map[string]string) []*ec2sdk.Tag {
+	sdkTags := make([]*ec2sdk.Tag, 0, len(tags))
+	for _, key := range sets.StringKeySet(tags).List() {
+		sdkTags = append(sdkTags, &ec2sdk.Tag{
+			Key:   awssdk.String(key),
+			Value: awssdk.String(tags[key]),
+		})
+	}
+	return sdkTags
+}
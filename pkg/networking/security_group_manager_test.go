@@ -0,0 +1,323 @@
+package networking
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_defaultSecurityGroupManager_FetchSGInfosByRequest(t *testing.T) {
+	stackAReq := &ec2sdk.DescribeSecurityGroupsInput{
+		Filters: []*ec2sdk.Filter{
+			{
+				Name:   awssdk.String("vpc-id"),
+				Values: awssdk.StringSlice([]string{"vpc-xxxxxxx"}),
+			},
+			{
+				Name:   awssdk.String("tag:elbv2.k8s.aws/cluster"),
+				Values: awssdk.StringSlice([]string{"cluster-name"}),
+			},
+			{
+				Name:   awssdk.String("tag:ingress.k8s.aws/stack"),
+				Values: awssdk.StringSlice([]string{"stack-a"}),
+			},
+		},
+	}
+	stackBReq := &ec2sdk.DescribeSecurityGroupsInput{
+		Filters: []*ec2sdk.Filter{
+			{
+				Name:   awssdk.String("vpc-id"),
+				Values: awssdk.StringSlice([]string{"vpc-xxxxxxx"}),
+			},
+			{
+				Name:   awssdk.String("tag:ingress.k8s.aws/stack"),
+				Values: awssdk.StringSlice([]string{"stack-b"}),
+			},
+			{
+				Name:   awssdk.String("tag:elbv2.k8s.aws/cluster"),
+				Values: awssdk.StringSlice([]string{"cluster-name"}),
+			},
+		},
+	}
+	batchReq := &ec2sdk.DescribeSecurityGroupsInput{
+		Filters: []*ec2sdk.Filter{
+			{
+				Name:   awssdk.String("vpc-id"),
+				Values: awssdk.StringSlice([]string{"vpc-xxxxxxx"}),
+			},
+			{
+				Name:   awssdk.String("tag-key"),
+				Values: awssdk.StringSlice([]string{"elbv2.k8s.aws/cluster"}),
+			},
+			{
+				Name:   awssdk.String("tag-key"),
+				Values: awssdk.StringSlice([]string{"ingress.k8s.aws/stack"}),
+			},
+		},
+	}
+	sgs := []*ec2sdk.SecurityGroup{
+		{
+			GroupId: awssdk.String("sg-a"),
+			Tags: []*ec2sdk.Tag{
+				{Key: awssdk.String("elbv2.k8s.aws/cluster"), Value: awssdk.String("cluster-name")},
+				{Key: awssdk.String("ingress.k8s.aws/stack"), Value: awssdk.String("stack-a")},
+			},
+		},
+		{
+			GroupId: awssdk.String("sg-b"),
+			Tags: []*ec2sdk.Tag{
+				{Key: awssdk.String("elbv2.k8s.aws/cluster"), Value: awssdk.String("cluster-name")},
+				{Key: awssdk.String("ingress.k8s.aws/stack"), Value: awssdk.String("stack-b")},
+			},
+		},
+		{
+			GroupId: awssdk.String("sg-c"),
+			Tags: []*ec2sdk.Tag{
+				{Key: awssdk.String("elbv2.k8s.aws/cluster"), Value: awssdk.String("other-cluster")},
+				{Key: awssdk.String("ingress.k8s.aws/stack"), Value: awssdk.String("stack-a")},
+			},
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ec2Client := mock_services.NewMockEC2(ctrl)
+	// the batched request is served from cache until invalidated.
+	ec2Client.EXPECT().DescribeSecurityGroupsAsList(gomock.Any(), batchReq).Return(sgs, nil).Times(2)
+	m := NewDefaultSecurityGroupManager(ec2Client, &log.NullLogger{})
+
+	gotA, err := m.FetchSGInfosByRequest(context.Background(), stackAReq)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sg-a"}, sgIDsOf(gotA))
+
+	gotB, err := m.FetchSGInfosByRequest(context.Background(), stackBReq)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sg-b"}, sgIDsOf(gotB))
+
+	m.InvalidateSGInfos("sg-a")
+	gotA, err = m.FetchSGInfosByRequest(context.Background(), stackAReq)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sg-a"}, sgIDsOf(gotA))
+}
+
+func Test_buildBatchRequestByTagKeys(t *testing.T) {
+	tests := []struct {
+		name                string
+		req                 *ec2sdk.DescribeSecurityGroupsInput
+		wantBatchReq        *ec2sdk.DescribeSecurityGroupsInput
+		wantTagValuesFilter map[string][]string
+		wantOK              bool
+	}{
+		{
+			name: "request with vpc-id and tag filters",
+			req: &ec2sdk.DescribeSecurityGroupsInput{
+				Filters: []*ec2sdk.Filter{
+					{
+						Name:   awssdk.String("tag:kubernetes.io/cluster/cluster-name"),
+						Values: awssdk.StringSlice([]string{"owned", "shared"}),
+					},
+					{
+						Name:   awssdk.String("vpc-id"),
+						Values: awssdk.StringSlice([]string{"vpc-xxxxxxx"}),
+					},
+					{
+						Name:   awssdk.String("tag-key"),
+						Values: awssdk.StringSlice([]string{"keyC"}),
+					},
+				},
+			},
+			wantBatchReq: &ec2sdk.DescribeSecurityGroupsInput{
+				Filters: []*ec2sdk.Filter{
+					{
+						Name:   awssdk.String("vpc-id"),
+						Values: awssdk.StringSlice([]string{"vpc-xxxxxxx"}),
+					},
+					{
+						Name:   awssdk.String("tag-key"),
+						Values: awssdk.StringSlice([]string{"keyC"}),
+					},
+					{
+						Name:   awssdk.String("tag-key"),
+						Values: awssdk.StringSlice([]string{"kubernetes.io/cluster/cluster-name"}),
+					},
+				},
+			},
+			wantTagValuesFilter: map[string][]string{
+				"kubernetes.io/cluster/cluster-name": {"owned", "shared"},
+			},
+			wantOK: true,
+		},
+		{
+			name: "request without tag value filters",
+			req: &ec2sdk.DescribeSecurityGroupsInput{
+				Filters: []*ec2sdk.Filter{
+					{
+						Name:   awssdk.String("tag-key"),
+						Values: awssdk.StringSlice([]string{"keyC"}),
+					},
+				},
+			},
+			wantOK: false,
+		},
+		{
+			name: "request with other filters",
+			req: &ec2sdk.DescribeSecurityGroupsInput{
+				Filters: []*ec2sdk.Filter{
+					{
+						Name:   awssdk.String("tag:keyA"),
+						Values: awssdk.StringSlice([]string{"valueA"}),
+					},
+					{
+						Name:   awssdk.String("group-name"),
+						Values: awssdk.StringSlice([]string{"my-sg"}),
+					},
+				},
+			},
+			wantOK: false,
+		},
+		{
+			name: "request with wildcard tag values",
+			req: &ec2sdk.DescribeSecurityGroupsInput{
+				Filters: []*ec2sdk.Filter{
+					{
+						Name:   awssdk.String("tag:keyA"),
+						Values: awssdk.StringSlice([]string{"value*"}),
+					},
+				},
+			},
+			wantOK: false,
+		},
+		{
+			name: "request with groupIDs",
+			req: &ec2sdk.DescribeSecurityGroupsInput{
+				GroupIds: awssdk.StringSlice([]string{"sg-a"}),
+				Filters: []*ec2sdk.Filter{
+					{
+						Name:   awssdk.String("tag:keyA"),
+						Values: awssdk.StringSlice([]string{"valueA"}),
+					},
+				},
+			},
+			wantOK: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotBatchReq, gotTagValuesFilter, gotOK := buildBatchRequestByTagKeys(tt.req)
+			assert.Equal(t, tt.wantOK, gotOK)
+			assert.Equal(t, tt.wantBatchReq, gotBatchReq)
+			assert.Equal(t, tt.wantTagValuesFilter, gotTagValuesFilter)
+		})
+	}
+}
+
+func Test_computeBatchRequestCacheKey(t *testing.T) {
+	reqA := &ec2sdk.DescribeSecurityGroupsInput{
+		Filters: []*ec2sdk.Filter{
+			{
+				Name:   awssdk.String("vpc-id"),
+				Values: awssdk.StringSlice([]string{"vpc-xxxxxxx"}),
+			},
+			{
+				Name:   awssdk.String("tag-key"),
+				Values: awssdk.StringSlice([]string{"keyA"}),
+			},
+			{
+				Name:   awssdk.String("tag-key"),
+				Values: awssdk.StringSlice([]string{"keyB"}),
+			},
+		},
+	}
+	reqB := &ec2sdk.DescribeSecurityGroupsInput{
+		Filters: []*ec2sdk.Filter{
+			{
+				Name:   awssdk.String("tag-key"),
+				Values: awssdk.StringSlice([]string{"keyB"}),
+			},
+			{
+				Name:   awssdk.String("tag-key"),
+				Values: awssdk.StringSlice([]string{"keyA"}),
+			},
+			{
+				Name:   awssdk.String("vpc-id"),
+				Values: awssdk.StringSlice([]string{"vpc-xxxxxxx"}),
+			},
+		},
+	}
+	assert.Equal(t, "tag-key=keyA;tag-key=keyB;vpc-id=vpc-xxxxxxx", computeBatchRequestCacheKey(reqA))
+	assert.Equal(t, computeBatchRequestCacheKey(reqA), computeBatchRequestCacheKey(reqB))
+}
+
+func sgIDsOf(sgInfoByID map[string]SecurityGroupInfo) []string {
+	var sgIDs []string
+	for sgID := range sgInfoByID {
+		sgIDs = append(sgIDs, sgID)
+	}
+	return sgIDs
+}
+
+func Test_defaultSecurityGroupManager_FetchSGInfosByID(t *testing.T) {
+	req := &ec2sdk.DescribeSecurityGroupsInput{
+		GroupIds: awssdk.StringSlice([]string{"sg-a"}),
+	}
+	sgs := []*ec2sdk.SecurityGroup{
+		{
+			GroupId: awssdk.String("sg-a"),
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ec2Client := mock_services.NewMockEC2(ctrl)
+	// the request is served from cache unless the context requires reload.
+	ec2Client.EXPECT().DescribeSecurityGroupsAsList(gomock.Any(), req).Return(sgs, nil).Times(2)
+	m := NewDefaultSecurityGroupManager(ec2Client, &log.NullLogger{})
+
+	got, err := m.FetchSGInfosByID(context.Background(), []string{"sg-a"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sg-a"}, sgIDsOf(got))
+
+	got, err = m.FetchSGInfosByID(context.Background(), []string{"sg-a"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sg-a"}, sgIDsOf(got))
+
+	got, err = m.FetchSGInfosByID(ContextWithReloadIgnoringCache(context.Background()), []string{"sg-a"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sg-a"}, sgIDsOf(got))
+}
+
+func Test_groupIPPermissionsByTags(t *testing.T) {
+	permA := NewCIDRIPPermission("tcp", awssdk.Int64(80), awssdk.Int64(80), "10.0.0.0/16", nil)
+	permA.Tags = map[string]string{"owner": "b"}
+	permB := NewCIDRIPPermission("tcp", awssdk.Int64(80), awssdk.Int64(80), "10.1.0.0/16", nil)
+	permB.Tags = map[string]string{"owner": "a"}
+	permC := NewCIDRIPPermission("tcp", awssdk.Int64(80), awssdk.Int64(80), "10.2.0.0/16", nil)
+	permC.Tags = map[string]string{"owner": "b"}
+	permD := NewCIDRIPPermission("tcp", awssdk.Int64(80), awssdk.Int64(80), "10.3.0.0/16", nil)
+	tests := []struct {
+		name        string
+		permissions []IPPermissionInfo
+		want        [][]IPPermissionInfo
+	}{
+		{
+			name:        "permissions without tags",
+			permissions: []IPPermissionInfo{permD},
+			want:        [][]IPPermissionInfo{{permD}},
+		},
+		{
+			name:        "permissions with different tags",
+			permissions: []IPPermissionInfo{permA, permB, permC, permD},
+			want:        [][]IPPermissionInfo{{permD}, {permB}, {permA, permC}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, groupIPPermissionsByTags(tt.permissions))
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/audit"
 )
 
 // configuration options for SecurityGroup Reconcile options.
@@ -19,6 +20,12 @@ type SecurityGroupReconcileOptions struct {
 	// Whether only Authorize permissions.
 	// By default, it grants and revoke permission.
 	AuthorizeOnly bool
+
+	// RuleTags defines the tags to apply on granted rules.
+	// Rule tags are matched by PermissionSelector together with labels from description,
+	// so managed rules are still identified when the description is edited.
+	// By default, no tags are applied.
+	RuleTags map[string]string
 }
 
 // Apply SecurityGroupReconcileOption options
@@ -44,6 +51,13 @@ func WithAuthorizeOnly(authorizeOnly bool) SecurityGroupReconcileOption {
 	}
 }
 
+// WithRuleTags is a option that sets the RuleTags.
+func WithRuleTags(ruleTags map[string]string) SecurityGroupReconcileOption {
+	return func(opts *SecurityGroupReconcileOptions) {
+		opts.RuleTags = ruleTags
+	}
+}
+
 // SecurityGroupReconciler manages securityGroup rules on securityGroup.
 type SecurityGroupReconciler interface {
 	// ReconcileIngress will reconcile Ingress permission on SecurityGroup to be desiredPermission.
@@ -51,10 +65,12 @@ type SecurityGroupReconciler interface {
 }
 
 // NewDefaultSecurityGroupReconciler constructs new defaultSecurityGroupReconciler.
-func NewDefaultSecurityGroupReconciler(sgManager SecurityGroupManager, logger logr.Logger) *defaultSecurityGroupReconciler {
+// divergenceReporter is optional, when specified, reconciles are additive-only: extra permissions without the controller's labels are reported instead of revoked.
+func NewDefaultSecurityGroupReconciler(sgManager SecurityGroupManager, divergenceReporter SecurityGroupDivergenceReporter, logger logr.Logger) *defaultSecurityGroupReconciler {
 	return &defaultSecurityGroupReconciler{
-		sgManager: sgManager,
-		logger:    logger,
+		sgManager:          sgManager,
+		divergenceReporter: divergenceReporter,
+		logger:             logger,
 	}
 }
 
@@ -63,7 +79,9 @@ var _ SecurityGroupReconciler = &defaultSecurityGroupReconciler{}
 // default implementation for SecurityGroupReconciler.
 type defaultSecurityGroupReconciler struct {
 	sgManager SecurityGroupManager
-	logger    logr.Logger
+	// divergenceReporter is nil unless reconciles are additive-only, for out-of-band emergency rules that must never be revoked.
+	divergenceReporter SecurityGroupDivergenceReporter
+	logger             logr.Logger
 }
 
 func (r *defaultSecurityGroupReconciler) ReconcileIngress(ctx context.Context, sgID string, desiredPermissions []IPPermissionInfo, opts ...SecurityGroupReconcileOption) error {
@@ -96,25 +114,139 @@ func (r *defaultSecurityGroupReconciler) ReconcileIngress(ctx context.Context, s
 func (r *defaultSecurityGroupReconciler) reconcileIngressWithSGInfo(ctx context.Context, sgInfo SecurityGroupInfo, desiredPermissions []IPPermissionInfo, reconcileOpts SecurityGroupReconcileOptions) error {
 	extraPermissions := diffIPPermissionInfos(sgInfo.Ingress, desiredPermissions)
 	permissionsToRevoke := make([]IPPermissionInfo, 0, len(extraPermissions))
+	// labelledHashCodes are the extra permissions that carry the controller's labels, either by description or by rule tags.
+	labelledHashCodes := sets.NewString()
+	var unselectedPermissions []IPPermissionInfo
 	for _, permission := range extraPermissions {
 		if reconcileOpts.PermissionSelector.Matches(labels.Set(permission.Labels)) {
 			permissionsToRevoke = append(permissionsToRevoke, permission)
+			if !reconcileOpts.PermissionSelector.Empty() {
+				labelledHashCodes.Insert(permission.HashCode())
+			}
+		} else {
+			unselectedPermissions = append(unselectedPermissions, permission)
 		}
 	}
 	permissionsToGrant := diffIPPermissionInfos(desiredPermissions, sgInfo.Ingress)
+	for i := range permissionsToGrant {
+		permissionsToGrant[i].Tags = reconcileOpts.RuleTags
+	}
+
+	if len(unselectedPermissions) > 0 || len(reconcileOpts.RuleTags) > 0 {
+		ruleInfos, err := r.sgManager.FetchSGIngressRules(ctx, sgInfo.SecurityGroupID)
+		if err != nil {
+			if !isUnauthorizedOperationError(err) {
+				return err
+			}
+			// rules can still be identified by descriptions, this happens when the IAM policy haven't been updated.
+			r.logger.Info("unable to fetch securityGroup rules, rule tags are ignored",
+				"securityGroupID", sgInfo.SecurityGroupID, "error", err.Error())
+		} else {
+			ruleInfoByHashCode := mapSecurityGroupRuleInfoByHashCode(ruleInfos)
+			for _, permission := range unselectedPermissions {
+				ruleInfo, exists := ruleInfoByHashCode[permission.HashCode()]
+				if exists && reconcileOpts.PermissionSelector.Matches(labels.Merge(permission.Labels, ruleInfo.Tags)) {
+					permissionsToRevoke = append(permissionsToRevoke, permission)
+					labelledHashCodes.Insert(permission.HashCode())
+				}
+			}
+			if len(reconcileOpts.RuleTags) > 0 {
+				ruleTagsSelector := labels.SelectorFromSet(reconcileOpts.RuleTags)
+				for _, permission := range permissionsToRevoke {
+					ruleInfo, exists := ruleInfoByHashCode[permission.HashCode()]
+					if exists && ruleTagsSelector.Matches(labels.Set(ruleInfo.Tags)) {
+						labelledHashCodes.Insert(permission.HashCode())
+					}
+				}
+			}
+			if err := r.backfillRuleTags(ctx, sgInfo, desiredPermissions, ruleInfoByHashCode, reconcileOpts); err != nil {
+				if !isUnauthorizedOperationError(err) {
+					return err
+				}
+				r.logger.Info("unable to tag securityGroup rules, rule tags are ignored",
+					"securityGroupID", sgInfo.SecurityGroupID, "error", err.Error())
+			}
+		}
+	}
+
+	if r.divergenceReporter != nil {
+		// only the permissions granted by the controller are revoked, the ones without its labels might be out-of-band emergency rules.
+		var retainedPermissions []IPPermissionInfo
+		permissionsToRevoke, retainedPermissions = partitionIPPermissionInfosByHashCodes(permissionsToRevoke, labelledHashCodes)
+		// desired permissions are partial when only authorizing, so extra permissions aren't necessarily divergences.
+		if !reconcileOpts.AuthorizeOnly {
+			r.divergenceReporter.Report(ctx, sgInfo.SecurityGroupID, retainedPermissions)
+		}
+	}
 	if len(permissionsToRevoke) > 0 && !reconcileOpts.AuthorizeOnly {
 		if err := r.sgManager.RevokeSGIngress(ctx, sgInfo.SecurityGroupID, permissionsToRevoke); err != nil {
 			return err
 		}
+		audit.RecordMutation(ctx, audit.Mutation{
+			ResourceKind: audit.ResourceKindSecurityGroupRule,
+			ResourceID:   sgInfo.SecurityGroupID,
+			Operation:    audit.OperationDelete,
+			Diff:         audit.ComputeDiff(buildIngressPermissionsForAudit(permissionsToRevoke), nil),
+		})
 	}
 	if len(permissionsToGrant) > 0 {
-		if err := r.sgManager.AuthorizeSGIngress(ctx, sgInfo.SecurityGroupID, permissionsToGrant); err != nil {
+		if err := r.authorizeSGIngress(ctx, sgInfo.SecurityGroupID, permissionsToGrant); err != nil {
 			return err
 		}
+		audit.RecordMutation(ctx, audit.Mutation{
+			ResourceKind: audit.ResourceKindSecurityGroupRule,
+			ResourceID:   sgInfo.SecurityGroupID,
+			Operation:    audit.OperationCreate,
+			Diff:         audit.ComputeDiff(nil, buildIngressPermissionsForAudit(permissionsToGrant)),
+		})
 	}
 	return nil
 }
 
+// authorizeSGIngress authorizes the permissions, the permissions are authorized without tags if we are not allowed to tag the rules.
+func (r *defaultSecurityGroupReconciler) authorizeSGIngress(ctx context.Context, sgID string, permissions []IPPermissionInfo) error {
+	err := r.sgManager.AuthorizeSGIngress(ctx, sgID, permissions)
+	if err == nil || !isUnauthorizedOperationError(err) || len(permissions[0].Tags) == 0 {
+		return err
+	}
+	r.logger.Info("unable to tag securityGroup rules, authorizing without tags",
+		"securityGroupID", sgID, "error", err.Error())
+	untaggedPermissions := make([]IPPermissionInfo, 0, len(permissions))
+	for _, permission := range permissions {
+		permission.Tags = nil
+		untaggedPermissions = append(untaggedPermissions, permission)
+	}
+	return r.sgManager.AuthorizeSGIngress(ctx, sgID, untaggedPermissions)
+}
+
+// backfillRuleTags applies the RuleTags on existing rules that are desired and managed, but haven't been tagged yet.
+func (r *defaultSecurityGroupReconciler) backfillRuleTags(ctx context.Context, sgInfo SecurityGroupInfo, desiredPermissions []IPPermissionInfo,
+	ruleInfoByHashCode map[string]SecurityGroupRuleInfo, reconcileOpts SecurityGroupReconcileOptions) error {
+	if len(reconcileOpts.RuleTags) == 0 {
+		return nil
+	}
+	desiredHashCodes := sets.NewString()
+	for _, permission := range desiredPermissions {
+		desiredHashCodes.Insert(permission.HashCode())
+	}
+	ruleIDsToTag := sets.NewString()
+	for _, permission := range sgInfo.Ingress {
+		hashCode := permission.HashCode()
+		if !desiredHashCodes.Has(hashCode) || !reconcileOpts.PermissionSelector.Matches(labels.Set(permission.Labels)) {
+			continue
+		}
+		ruleInfo, exists := ruleInfoByHashCode[hashCode]
+		if !exists || labels.SelectorFromSet(reconcileOpts.RuleTags).Matches(labels.Set(ruleInfo.Tags)) {
+			continue
+		}
+		ruleIDsToTag.Insert(ruleInfo.RuleID)
+	}
+	if len(ruleIDsToTag) == 0 {
+		return nil
+	}
+	return r.sgManager.TagSGRules(ctx, sgInfo.SecurityGroupID, ruleIDsToTag.List(), reconcileOpts.RuleTags)
+}
+
 // shouldRetryWithoutCache tests whether we should retry SecurityGroup rules reconcile without cache.
 func (r *defaultSecurityGroupReconciler) shouldRetryWithoutCache(err error) bool {
 	var awsErr awserr.Error
@@ -124,6 +256,49 @@ func (r *defaultSecurityGroupReconciler) shouldRetryWithoutCache(err error) bool
 	return false
 }
 
+// isUnauthorizedOperationError tests whether the error is caused by missing IAM permissions.
+func isUnauthorizedOperationError(err error) bool {
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		return awsErr.Code() == "UnauthorizedOperation"
+	}
+	return false
+}
+
+// mapSecurityGroupRuleInfoByHashCode maps the securityGroup rules by the HashCode of their permission.
+func mapSecurityGroupRuleInfoByHashCode(ruleInfos []SecurityGroupRuleInfo) map[string]SecurityGroupRuleInfo {
+	ruleInfoByHashCode := make(map[string]SecurityGroupRuleInfo, len(ruleInfos))
+	for _, ruleInfo := range ruleInfos {
+		ruleInfoByHashCode[ruleInfo.Permission.HashCode()] = ruleInfo
+	}
+	return ruleInfoByHashCode
+}
+
+// buildIngressPermissionsForAudit builds the ingress permissions reported in mutation diffs.
+func buildIngressPermissionsForAudit(permissions []IPPermissionInfo) map[string]interface{} {
+	hashCodes := make([]string, 0, len(permissions))
+	for _, permission := range permissions {
+		hashCodes = append(hashCodes, permission.HashCode())
+	}
+	return map[string]interface{}{
+		"ingress": hashCodes,
+	}
+}
+
+// partitionIPPermissionInfosByHashCodes partitions permissions into the ones with hashCodes and the others.
+func partitionIPPermissionInfosByHashCodes(permissions []IPPermissionInfo, hashCodes sets.String) ([]IPPermissionInfo, []IPPermissionInfo) {
+	matchedPermissions := make([]IPPermissionInfo, 0, len(permissions))
+	otherPermissions := make([]IPPermissionInfo, 0, len(permissions))
+	for _, permission := range permissions {
+		if hashCodes.Has(permission.HashCode()) {
+			matchedPermissions = append(matchedPermissions, permission)
+		} else {
+			otherPermissions = append(otherPermissions, permission)
+		}
+	}
+	return matchedPermissions, otherPermissions
+}
+
 // diffIPPermissionInfos calculates set_difference as source - target
 func diffIPPermissionInfos(source []IPPermissionInfo, target []IPPermissionInfo) []IPPermissionInfo {
 	sourceByHashCode := make(map[string]IPPermissionInfo, len(source))
@@ -1,10 +1,15 @@
 package networking
 
 import (
+	"context"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/labels"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
 )
 
@@ -215,3 +220,288 @@ func Test_diffIPPermissionInfos(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultSecurityGroupReconciler_reconcileIngressWithSGInfo(t *testing.T) {
+	managedLabels := map[string]string{"owner": "lbc"}
+	ruleTags := map[string]string{"owner": "lbc"}
+	sdkRule := func(ruleID string, cidr string, tags map[string]string) *ec2sdk.SecurityGroupRule {
+		rule := &ec2sdk.SecurityGroupRule{
+			SecurityGroupRuleId: awssdk.String(ruleID),
+			GroupId:             awssdk.String("sg-1"),
+			IsEgress:            awssdk.Bool(false),
+			IpProtocol:          awssdk.String("tcp"),
+			FromPort:            awssdk.Int64(80),
+			ToPort:              awssdk.Int64(80),
+			CidrIpv4:            awssdk.String(cidr),
+		}
+		for key, value := range tags {
+			rule.Tags = append(rule.Tags, &ec2sdk.Tag{Key: awssdk.String(key), Value: awssdk.String(value)})
+		}
+		return rule
+	}
+	type describeSecurityGroupRulesAsListCall struct {
+		resp []*ec2sdk.SecurityGroupRule
+		err  error
+	}
+	tests := []struct {
+		name                                 string
+		sgInfo                               SecurityGroupInfo
+		desiredPermissions                   []IPPermissionInfo
+		reconcileOpts                        SecurityGroupReconcileOptions
+		describeSecurityGroupRulesAsListCall *describeSecurityGroupRulesAsListCall
+		wantRevokeReq                        *ec2sdk.RevokeSecurityGroupIngressInput
+		wantAuthorizeReq                     *ec2sdk.AuthorizeSecurityGroupIngressInput
+		wantCreateTagsReq                    *ec2sdk.CreateTagsInput
+	}{
+		{
+			name: "rule with edited description is revoked by rule tags",
+			sgInfo: SecurityGroupInfo{
+				SecurityGroupID: "sg-1",
+				Ingress: []IPPermissionInfo{
+					NewCIDRIPPermission("tcp", awssdk.Int64(80), awssdk.Int64(80), "10.0.0.0/16", map[string]string{labelKeyRawDescription: "edited"}),
+					NewCIDRIPPermission("tcp", awssdk.Int64(80), awssdk.Int64(80), "10.1.0.0/16", map[string]string{labelKeyRawDescription: "user"}),
+				},
+			},
+			reconcileOpts: SecurityGroupReconcileOptions{
+				PermissionSelector: labels.SelectorFromSet(managedLabels),
+			},
+			describeSecurityGroupRulesAsListCall: &describeSecurityGroupRulesAsListCall{
+				resp: []*ec2sdk.SecurityGroupRule{
+					sdkRule("sgr-1", "10.0.0.0/16", ruleTags),
+					sdkRule("sgr-2", "10.1.0.0/16", nil),
+				},
+			},
+			wantRevokeReq: &ec2sdk.RevokeSecurityGroupIngressInput{
+				GroupId: awssdk.String("sg-1"),
+				IpPermissions: []*ec2sdk.IpPermission{
+					{
+						IpProtocol: awssdk.String("tcp"),
+						FromPort:   awssdk.Int64(80),
+						ToPort:     awssdk.Int64(80),
+						IpRanges:   []*ec2sdk.IpRange{{CidrIp: awssdk.String("10.0.0.0/16"), Description: awssdk.String("edited")}},
+					},
+				},
+			},
+		},
+		{
+			name: "granted rules are tagged and existing managed rules are backfilled",
+			sgInfo: SecurityGroupInfo{
+				SecurityGroupID: "sg-1",
+				Ingress: []IPPermissionInfo{
+					NewCIDRIPPermission("tcp", awssdk.Int64(80), awssdk.Int64(80), "10.0.0.0/16", managedLabels),
+				},
+			},
+			desiredPermissions: []IPPermissionInfo{
+				NewCIDRIPPermission("tcp", awssdk.Int64(80), awssdk.Int64(80), "10.0.0.0/16", managedLabels),
+				NewCIDRIPPermission("tcp", awssdk.Int64(80), awssdk.Int64(80), "10.1.0.0/16", managedLabels),
+			},
+			reconcileOpts: SecurityGroupReconcileOptions{
+				PermissionSelector: labels.SelectorFromSet(managedLabels),
+				RuleTags:           ruleTags,
+			},
+			describeSecurityGroupRulesAsListCall: &describeSecurityGroupRulesAsListCall{
+				resp: []*ec2sdk.SecurityGroupRule{
+					sdkRule("sgr-1", "10.0.0.0/16", nil),
+				},
+			},
+			wantCreateTagsReq: &ec2sdk.CreateTagsInput{
+				Resources: awssdk.StringSlice([]string{"sgr-1"}),
+				Tags:      []*ec2sdk.Tag{{Key: awssdk.String("owner"), Value: awssdk.String("lbc")}},
+			},
+			wantAuthorizeReq: &ec2sdk.AuthorizeSecurityGroupIngressInput{
+				GroupId: awssdk.String("sg-1"),
+				IpPermissions: []*ec2sdk.IpPermission{
+					{
+						IpProtocol: awssdk.String("tcp"),
+						FromPort:   awssdk.Int64(80),
+						ToPort:     awssdk.Int64(80),
+						IpRanges:   []*ec2sdk.IpRange{{CidrIp: awssdk.String("10.1.0.0/16"), Description: awssdk.String("owner=lbc")}},
+					},
+				},
+				TagSpecifications: []*ec2sdk.TagSpecification{
+					{
+						ResourceType: awssdk.String("security-group-rule"),
+						Tags:         []*ec2sdk.Tag{{Key: awssdk.String("owner"), Value: awssdk.String("lbc")}},
+					},
+				},
+			},
+		},
+		{
+			name: "rule tags are ignored without permission to describe rules",
+			sgInfo: SecurityGroupInfo{
+				SecurityGroupID: "sg-1",
+				Ingress: []IPPermissionInfo{
+					NewCIDRIPPermission("tcp", awssdk.Int64(80), awssdk.Int64(80), "10.0.0.0/16", map[string]string{labelKeyRawDescription: "edited"}),
+				},
+			},
+			reconcileOpts: SecurityGroupReconcileOptions{
+				PermissionSelector: labels.SelectorFromSet(managedLabels),
+			},
+			describeSecurityGroupRulesAsListCall: &describeSecurityGroupRulesAsListCall{
+				err: awserr.New("UnauthorizedOperation", "", nil),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ec2Client := mock_services.NewMockEC2(ctrl)
+			if tt.describeSecurityGroupRulesAsListCall != nil {
+				call := tt.describeSecurityGroupRulesAsListCall
+				ec2Client.EXPECT().DescribeSecurityGroupRulesAsList(gomock.Any(), gomock.Any()).Return(call.resp, call.err)
+			}
+			if tt.wantRevokeReq != nil {
+				ec2Client.EXPECT().RevokeSecurityGroupIngressWithContext(gomock.Any(), tt.wantRevokeReq).Return(&ec2sdk.RevokeSecurityGroupIngressOutput{}, nil)
+			}
+			if tt.wantAuthorizeReq != nil {
+				ec2Client.EXPECT().AuthorizeSecurityGroupIngressWithContext(gomock.Any(), tt.wantAuthorizeReq).Return(&ec2sdk.AuthorizeSecurityGroupIngressOutput{}, nil)
+			}
+			if tt.wantCreateTagsReq != nil {
+				ec2Client.EXPECT().CreateTagsWithContext(gomock.Any(), tt.wantCreateTagsReq).Return(&ec2sdk.CreateTagsOutput{}, nil)
+			}
+			sgManager := NewDefaultSecurityGroupManager(ec2Client, &log.NullLogger{})
+			r := NewDefaultSecurityGroupReconciler(sgManager, nil, &log.NullLogger{})
+			err := r.reconcileIngressWithSGInfo(context.Background(), tt.sgInfo, tt.desiredPermissions, tt.reconcileOpts)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// reportedDivergence is a report received by fakeSecurityGroupDivergenceReporter.
+type reportedDivergence struct {
+	sgID             string
+	extraPermissions []IPPermissionInfo
+}
+
+// fakeSecurityGroupDivergenceReporter is a SecurityGroupDivergenceReporter that records the reports.
+type fakeSecurityGroupDivergenceReporter struct {
+	reports []reportedDivergence
+}
+
+func (r *fakeSecurityGroupDivergenceReporter) Report(_ context.Context, sgID string, extraPermissions []IPPermissionInfo) {
+	r.reports = append(r.reports, reportedDivergence{sgID: sgID, extraPermissions: extraPermissions})
+}
+
+func Test_defaultSecurityGroupReconciler_reconcileIngressWithSGInfo_additiveOnly(t *testing.T) {
+	emergencyPermission := NewCIDRIPPermission("tcp", awssdk.Int64(22), awssdk.Int64(22), "192.168.0.0/16", map[string]string{labelKeyRawDescription: "emergency"})
+	desiredPermission := NewCIDRIPPermission("tcp", awssdk.Int64(80), awssdk.Int64(80), "0.0.0.0/0", nil)
+	stalePermission := NewCIDRIPPermission("tcp", awssdk.Int64(8080), awssdk.Int64(8080), "10.0.0.0/16",
+		map[string]string{"elbv2.k8s.aws/targetGroupBinding": "shared"})
+	tests := []struct {
+		name               string
+		sgInfo             SecurityGroupInfo
+		desiredPermissions []IPPermissionInfo
+		reconcileOpts      SecurityGroupReconcileOptions
+		wantAuthorizeReq   *ec2sdk.AuthorizeSecurityGroupIngressInput
+		wantRevokeReq      *ec2sdk.RevokeSecurityGroupIngressInput
+		wantReports        []reportedDivergence
+	}{
+		{
+			name: "extra permissions are reported instead of revoked",
+			sgInfo: SecurityGroupInfo{
+				SecurityGroupID: "sg-1",
+				Ingress:         []IPPermissionInfo{emergencyPermission},
+			},
+			desiredPermissions: []IPPermissionInfo{desiredPermission},
+			reconcileOpts: SecurityGroupReconcileOptions{
+				PermissionSelector: labels.Everything(),
+			},
+			wantAuthorizeReq: &ec2sdk.AuthorizeSecurityGroupIngressInput{
+				GroupId: awssdk.String("sg-1"),
+				IpPermissions: []*ec2sdk.IpPermission{
+					{
+						IpProtocol: awssdk.String("tcp"),
+						FromPort:   awssdk.Int64(80),
+						ToPort:     awssdk.Int64(80),
+						IpRanges:   []*ec2sdk.IpRange{{CidrIp: awssdk.String("0.0.0.0/0"), Description: awssdk.String("")}},
+					},
+				},
+			},
+			wantReports: []reportedDivergence{
+				{
+					sgID:             "sg-1",
+					extraPermissions: []IPPermissionInfo{emergencyPermission},
+				},
+			},
+		},
+		{
+			name: "extra permissions with the controller's labels are revoked",
+			sgInfo: SecurityGroupInfo{
+				SecurityGroupID: "sg-1",
+				Ingress:         []IPPermissionInfo{stalePermission, desiredPermission},
+			},
+			desiredPermissions: []IPPermissionInfo{desiredPermission},
+			reconcileOpts: SecurityGroupReconcileOptions{
+				PermissionSelector: labels.SelectorFromSet(labels.Set{"elbv2.k8s.aws/targetGroupBinding": "shared"}),
+			},
+			wantRevokeReq: &ec2sdk.RevokeSecurityGroupIngressInput{
+				GroupId: awssdk.String("sg-1"),
+				IpPermissions: []*ec2sdk.IpPermission{
+					{
+						IpProtocol: awssdk.String("tcp"),
+						FromPort:   awssdk.Int64(8080),
+						ToPort:     awssdk.Int64(8080),
+						IpRanges:   []*ec2sdk.IpRange{{CidrIp: awssdk.String("10.0.0.0/16"), Description: awssdk.String("elbv2.k8s.aws/targetGroupBinding=shared")}},
+					},
+				},
+			},
+			wantReports: []reportedDivergence{
+				{
+					sgID:             "sg-1",
+					extraPermissions: []IPPermissionInfo{},
+				},
+			},
+		},
+		{
+			name: "report is cleared without extra permissions",
+			sgInfo: SecurityGroupInfo{
+				SecurityGroupID: "sg-1",
+				Ingress:         []IPPermissionInfo{desiredPermission},
+			},
+			desiredPermissions: []IPPermissionInfo{desiredPermission},
+			reconcileOpts: SecurityGroupReconcileOptions{
+				PermissionSelector: labels.Everything(),
+			},
+			wantReports: []reportedDivergence{
+				{
+					sgID:             "sg-1",
+					extraPermissions: []IPPermissionInfo{},
+				},
+			},
+		},
+		{
+			name: "extra permissions aren't reported when only authorizing",
+			sgInfo: SecurityGroupInfo{
+				SecurityGroupID: "sg-1",
+				Ingress:         []IPPermissionInfo{emergencyPermission, desiredPermission},
+			},
+			desiredPermissions: []IPPermissionInfo{desiredPermission},
+			reconcileOpts: SecurityGroupReconcileOptions{
+				PermissionSelector: labels.Everything(),
+				AuthorizeOnly:      true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ec2Client := mock_services.NewMockEC2(ctrl)
+			if tt.wantAuthorizeReq != nil {
+				ec2Client.EXPECT().AuthorizeSecurityGroupIngressWithContext(gomock.Any(), tt.wantAuthorizeReq).Return(&ec2sdk.AuthorizeSecurityGroupIngressOutput{}, nil)
+			}
+			if tt.wantRevokeReq != nil {
+				ec2Client.EXPECT().RevokeSecurityGroupIngressWithContext(gomock.Any(), tt.wantRevokeReq).Return(&ec2sdk.RevokeSecurityGroupIngressOutput{}, nil)
+			}
+			divergenceReporter := &fakeSecurityGroupDivergenceReporter{}
+			sgManager := NewDefaultSecurityGroupManager(ec2Client, &log.NullLogger{})
+			r := NewDefaultSecurityGroupReconciler(sgManager, divergenceReporter, &log.NullLogger{})
+			err := r.reconcileIngressWithSGInfo(context.Background(), tt.sgInfo, tt.desiredPermissions, tt.reconcileOpts)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantReports, divergenceReporter.reports)
+		})
+	}
+}
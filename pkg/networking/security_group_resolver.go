@@ -0,0 +1,207 @@
+package networking
+
+import (
+	"context"
+	"fmt"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
+	"strings"
+	"time"
+)
+
+const (
+	// we cache the securityGroup ID resolved by name by 10 minutes.
+	defaultSGIDByNameCacheTTL = 10 * time.Minute
+	// we cache names that don't resolve to any securityGroup by 1 minute, so that newly created securityGroups are observed soon.
+	defaultSGIDByNameNegativeCacheTTL = 1 * time.Minute
+)
+
+// AmbiguousSecurityGroupNameError is the error when multiple securityGroups have the same name within VPC.
+type AmbiguousSecurityGroupNameError struct {
+	// Name is the name of securityGroups.
+	Name string
+	// VPCID is the ID of VPC where the securityGroups are looked up.
+	VPCID string
+	// SecurityGroupIDs are the IDs of securityGroups with the name.
+	SecurityGroupIDs []string
+}
+
+func (e *AmbiguousSecurityGroupNameError) Error() string {
+	return fmt.Sprintf("multiple securityGroups named %v in vpc %v: %v, specify the securityGroup ID instead",
+		e.Name, e.VPCID, e.SecurityGroupIDs)
+}
+
+// SecurityGroupResolver is responsible for resolve securityGroup IDs.
+type SecurityGroupResolver interface {
+	// ResolveViaNameOrID resolves securityGroup IDs using securityGroup names or IDs, results are in the same order.
+	// securityGroup names are matched by the Name tag within VPC.
+	ResolveViaNameOrID(ctx context.Context, sgNameOrIDs []string) ([]string, error)
+}
+
+// NewDefaultSecurityGroupResolver constructs new defaultSecurityGroupResolver.
+func NewDefaultSecurityGroupResolver(ec2Client services.EC2, vpcID string, logger logr.Logger) *defaultSecurityGroupResolver {
+	return &defaultSecurityGroupResolver{
+		ec2Client:                  ec2Client,
+		vpcID:                      vpcID,
+		logger:                     logger,
+		sgIDByNameCache:            cache.NewExpiring(),
+		sgIDByNameCacheTTL:         defaultSGIDByNameCacheTTL,
+		sgIDByNameNegativeCacheTTL: defaultSGIDByNameNegativeCacheTTL,
+	}
+}
+
+var _ SecurityGroupResolver = &defaultSecurityGroupResolver{}
+
+// default implementation for SecurityGroupResolver.
+type defaultSecurityGroupResolver struct {
+	ec2Client services.EC2
+	vpcID     string
+	logger    logr.Logger
+
+	// sgIDByNameCache caches the securityGroup ID by name, names without securityGroup are cached with empty ID.
+	// concurrent lookups of the same names share a single call to AWS via sgIDByNameFlight.
+	sgIDByNameCache            *cache.Expiring
+	sgIDByNameCacheTTL         time.Duration
+	sgIDByNameNegativeCacheTTL time.Duration
+	sgIDByNameFlight           runtime.SingleFlight
+}
+
+func (r *defaultSecurityGroupResolver) ResolveViaNameOrID(ctx context.Context, sgNameOrIDs []string) ([]string, error) {
+	var sgIDs []string
+	var sgNames []string
+	for _, nameOrID := range sgNameOrIDs {
+		if strings.HasPrefix(nameOrID, "sg-") {
+			sgIDs = append(sgIDs, nameOrID)
+		} else {
+			sgNames = append(sgNames, nameOrID)
+		}
+	}
+	if len(sgIDs) > 0 {
+		if err := r.validateSGIDs(ctx, sgIDs); err != nil {
+			return nil, err
+		}
+	}
+	sgIDByName := make(map[string]string, len(sgNames))
+	if len(sgNames) > 0 {
+		var err error
+		if sgIDByName, err = r.resolveSGIDsByName(ctx, sgNames); err != nil {
+			return nil, err
+		}
+	}
+	resolvedSGIDs := make([]string, 0, len(sgNameOrIDs))
+	for _, nameOrID := range sgNameOrIDs {
+		if sgID, isName := sgIDByName[nameOrID]; isName {
+			resolvedSGIDs = append(resolvedSGIDs, sgID)
+		} else {
+			resolvedSGIDs = append(resolvedSGIDs, nameOrID)
+		}
+	}
+	return resolvedSGIDs, nil
+}
+
+// validateSGIDs checks whether securityGroups with sgIDs exists.
+func (r *defaultSecurityGroupResolver) validateSGIDs(ctx context.Context, sgIDs []string) error {
+	req := &ec2sdk.DescribeSecurityGroupsInput{
+		GroupIds: awssdk.StringSlice(sgIDs),
+	}
+	sgs, err := r.ec2Client.DescribeSecurityGroupsAsList(ctx, req)
+	if err != nil {
+		return err
+	}
+	foundSGIDs := sets.NewString()
+	for _, sg := range sgs {
+		foundSGIDs.Insert(awssdk.StringValue(sg.GroupId))
+	}
+	if missingSGIDs := sets.NewString(sgIDs...).Difference(foundSGIDs); len(missingSGIDs) != 0 {
+		return errors.Errorf("couldn't find securityGroups with IDs: %v", missingSGIDs.List())
+	}
+	return nil
+}
+
+// resolveSGIDsByName resolves the securityGroup ID by name, results are served from cache when possible.
+func (r *defaultSecurityGroupResolver) resolveSGIDsByName(ctx context.Context, sgNames []string) (map[string]string, error) {
+	sgIDByName := make(map[string]string, len(sgNames))
+	unknownSGNames := sets.NewString()
+	for _, sgName := range sgNames {
+		if rawCacheItem, exists := r.sgIDByNameCache.Get(sgName); exists {
+			sgIDByName[sgName] = rawCacheItem.(string)
+		} else {
+			unknownSGNames.Insert(sgName)
+		}
+	}
+	if len(unknownSGNames) > 0 {
+		rawSGIDsByNameFromAWS, err := r.sgIDByNameFlight.Do(strings.Join(unknownSGNames.List(), ","), func() (interface{}, error) {
+			return r.fetchSGIDsByNameFromAWS(ctx, unknownSGNames.List())
+		})
+		if err != nil {
+			return nil, err
+		}
+		sgIDsByNameFromAWS := rawSGIDsByNameFromAWS.(map[string][]string)
+		for _, sgName := range unknownSGNames.List() {
+			sgIDs := sgIDsByNameFromAWS[sgName]
+			switch len(sgIDs) {
+			case 0:
+				r.sgIDByNameCache.Set(sgName, "", r.sgIDByNameNegativeCacheTTL)
+				sgIDByName[sgName] = ""
+			case 1:
+				r.sgIDByNameCache.Set(sgName, sgIDs[0], r.sgIDByNameCacheTTL)
+				sgIDByName[sgName] = sgIDs[0]
+			default:
+				return nil, &AmbiguousSecurityGroupNameError{
+					Name:             sgName,
+					VPCID:            r.vpcID,
+					SecurityGroupIDs: sgIDs,
+				}
+			}
+		}
+	}
+
+	var missingSGNames []string
+	for _, sgName := range sgNames {
+		if len(sgIDByName[sgName]) == 0 {
+			missingSGNames = append(missingSGNames, sgName)
+		}
+	}
+	if len(missingSGNames) != 0 {
+		return nil, errors.Errorf("couldn't find securityGroups with names: %v", missingSGNames)
+	}
+	return sgIDByName, nil
+}
+
+// fetchSGIDsByNameFromAWS returns the IDs of securityGroups by name within VPC.
+func (r *defaultSecurityGroupResolver) fetchSGIDsByNameFromAWS(ctx context.Context, sgNames []string) (map[string][]string, error) {
+	req := &ec2sdk.DescribeSecurityGroupsInput{
+		Filters: []*ec2sdk.Filter{
+			{
+				Name:   awssdk.String("tag:Name"),
+				Values: awssdk.StringSlice(sgNames),
+			},
+			{
+				Name:   awssdk.String("vpc-id"),
+				Values: awssdk.StringSlice([]string{r.vpcID}),
+			},
+		},
+	}
+	sgs, err := r.ec2Client.DescribeSecurityGroupsAsList(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	r.logger.V(1).Info("resolved securityGroups by name", "names", sgNames, "vpcID", r.vpcID, "count", len(sgs))
+	sgIDsByName := make(map[string][]string, len(sgNames))
+	for _, sg := range sgs {
+		for _, tag := range sg.Tags {
+			if awssdk.StringValue(tag.Key) == "Name" {
+				sgName := awssdk.StringValue(tag.Value)
+				sgIDsByName[sgName] = append(sgIDsByName[sgName], awssdk.StringValue(sg.GroupId))
+				break
+			}
+		}
+	}
+	return sgIDsByName, nil
+}
@@ -0,0 +1,177 @@
+package networking
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_defaultSecurityGroupResolver_ResolveViaNameOrID(t *testing.T) {
+	namedSG := func(sgID string, name string) *ec2sdk.SecurityGroup {
+		return &ec2sdk.SecurityGroup{
+			GroupId: awssdk.String(sgID),
+			Tags: []*ec2sdk.Tag{
+				{
+					Key:   awssdk.String("Name"),
+					Value: awssdk.String(name),
+				},
+			},
+		}
+	}
+	byNameReq := func(names ...string) *ec2sdk.DescribeSecurityGroupsInput {
+		return &ec2sdk.DescribeSecurityGroupsInput{
+			Filters: []*ec2sdk.Filter{
+				{
+					Name:   awssdk.String("tag:Name"),
+					Values: awssdk.StringSlice(names),
+				},
+				{
+					Name:   awssdk.String("vpc-id"),
+					Values: awssdk.StringSlice([]string{"vpc-1"}),
+				},
+			},
+		}
+	}
+	type describeSecurityGroupsAsListCall struct {
+		req  *ec2sdk.DescribeSecurityGroupsInput
+		resp []*ec2sdk.SecurityGroup
+		err  error
+	}
+	type resolveCall struct {
+		sgNameOrIDs []string
+		want        []string
+		wantErr     error
+	}
+	tests := []struct {
+		name                              string
+		describeSecurityGroupsAsListCalls []describeSecurityGroupsAsListCall
+		resolveCalls                      []resolveCall
+	}{
+		{
+			name: "resolve names and IDs",
+			describeSecurityGroupsAsListCalls: []describeSecurityGroupsAsListCall{
+				{
+					req: &ec2sdk.DescribeSecurityGroupsInput{
+						GroupIds: awssdk.StringSlice([]string{"sg-1"}),
+					},
+					resp: []*ec2sdk.SecurityGroup{{GroupId: awssdk.String("sg-1")}},
+				},
+				{
+					req:  byNameReq("name-a"),
+					resp: []*ec2sdk.SecurityGroup{namedSG("sg-a", "name-a")},
+				},
+			},
+			resolveCalls: []resolveCall{
+				{
+					sgNameOrIDs: []string{"name-a", "sg-1"},
+					want:        []string{"sg-a", "sg-1"},
+				},
+			},
+		},
+		{
+			name: "resolved names are cached",
+			describeSecurityGroupsAsListCalls: []describeSecurityGroupsAsListCall{
+				{
+					req:  byNameReq("name-a"),
+					resp: []*ec2sdk.SecurityGroup{namedSG("sg-a", "name-a")},
+				},
+				{
+					req:  byNameReq("name-b"),
+					resp: []*ec2sdk.SecurityGroup{namedSG("sg-b", "name-b")},
+				},
+			},
+			resolveCalls: []resolveCall{
+				{
+					sgNameOrIDs: []string{"name-a"},
+					want:        []string{"sg-a"},
+				},
+				{
+					sgNameOrIDs: []string{"name-b", "name-a"},
+					want:        []string{"sg-b", "sg-a"},
+				},
+			},
+		},
+		{
+			name: "names without securityGroup are cached",
+			describeSecurityGroupsAsListCalls: []describeSecurityGroupsAsListCall{
+				{
+					req:  byNameReq("name-a"),
+					resp: nil,
+				},
+			},
+			resolveCalls: []resolveCall{
+				{
+					sgNameOrIDs: []string{"name-a"},
+					wantErr:     errors.New("couldn't find securityGroups with names: [name-a]"),
+				},
+				{
+					sgNameOrIDs: []string{"name-a"},
+					wantErr:     errors.New("couldn't find securityGroups with names: [name-a]"),
+				},
+			},
+		},
+		{
+			name: "multiple securityGroups with same name",
+			describeSecurityGroupsAsListCalls: []describeSecurityGroupsAsListCall{
+				{
+					req:  byNameReq("name-a"),
+					resp: []*ec2sdk.SecurityGroup{namedSG("sg-a1", "name-a"), namedSG("sg-a2", "name-a")},
+				},
+			},
+			resolveCalls: []resolveCall{
+				{
+					sgNameOrIDs: []string{"name-a"},
+					wantErr: &AmbiguousSecurityGroupNameError{
+						Name:             "name-a",
+						VPCID:            "vpc-1",
+						SecurityGroupIDs: []string{"sg-a1", "sg-a2"},
+					},
+				},
+			},
+		},
+		{
+			name: "securityGroup ID not found",
+			describeSecurityGroupsAsListCalls: []describeSecurityGroupsAsListCall{
+				{
+					req: &ec2sdk.DescribeSecurityGroupsInput{
+						GroupIds: awssdk.StringSlice([]string{"sg-1", "sg-2"}),
+					},
+					resp: []*ec2sdk.SecurityGroup{{GroupId: awssdk.String("sg-1")}},
+				},
+			},
+			resolveCalls: []resolveCall{
+				{
+					sgNameOrIDs: []string{"sg-1", "sg-2"},
+					wantErr:     errors.New("couldn't find securityGroups with IDs: [sg-2]"),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ec2Client := mock_services.NewMockEC2(ctrl)
+			for _, call := range tt.describeSecurityGroupsAsListCalls {
+				ec2Client.EXPECT().DescribeSecurityGroupsAsList(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			r := NewDefaultSecurityGroupResolver(ec2Client, "vpc-1", &log.NullLogger{})
+			for _, call := range tt.resolveCalls {
+				got, err := r.ResolveViaNameOrID(context.Background(), call.sgNameOrIDs)
+				if call.wantErr != nil {
+					assert.EqualError(t, err, call.wantErr.Error())
+				} else {
+					assert.NoError(t, err)
+					assert.Equal(t, call.want, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package networking
+
+import (
+	"fmt"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	"hash/fnv"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+	"sort"
+)
+
+// ShardSecurityGroupSpec distributes the inbound permissions of sgSpec across SecurityGroups with at most maxRulesPerSG permissions each,
+// up to maxSGs SecurityGroups. The first SecurityGroup keeps the name of sgSpec, additional ones are suffixed with their index.
+// Permissions are assigned to SecurityGroups by consistent hashing, so that adding or removing a permission doesn't move other
+// permissions between SecurityGroups, unless the SecurityGroup they are assigned to is full.
+func ShardSecurityGroupSpec(sgSpec ec2model.SecurityGroupSpec, maxRulesPerSG int, maxSGs int) ([]ec2model.SecurityGroupSpec, error) {
+	ingressShards, err := shardIPPermissions(sgSpec.Ingress, maxRulesPerSG, maxSGs)
+	if err != nil {
+		return nil, err
+	}
+	shardSGSpecs := make([]ec2model.SecurityGroupSpec, 0, len(ingressShards))
+	for shardIndex, ingress := range ingressShards {
+		shardSGSpec := sgSpec
+		shardSGSpec.Ingress = ingress
+		if shardIndex > 0 {
+			shardSGSpec.GroupName = fmt.Sprintf("%v-%v", sgSpec.GroupName, shardIndex)
+		}
+		shardSGSpecs = append(shardSGSpecs, shardSGSpec)
+	}
+	return shardSGSpecs, nil
+}
+
+// shardIPPermissions distributes permissions into the minimum number of shards with at most maxRulesPerSG permissions.
+// each permission is assigned to the shard its key hashes to, or the next shard with room if that one is full.
+func shardIPPermissions(permissions []ec2model.IPPermission, maxRulesPerSG int, maxSGs int) ([][]ec2model.IPPermission, error) {
+	if len(permissions) <= maxRulesPerSG {
+		return [][]ec2model.IPPermission{permissions}, nil
+	}
+	if len(permissions) > maxRulesPerSG*maxSGs {
+		return nil, errors.Errorf("too many inbound rules for managed SecurityGroups: %v, exceeds %v rules in each of %v SecurityGroups",
+			len(permissions), maxRulesPerSG, maxSGs)
+	}
+	// permissions are sorted so that permissions spilled over from full shards are deterministic.
+	sortedPermissions := make([]ec2model.IPPermission, len(permissions))
+	copy(sortedPermissions, permissions)
+	sort.Slice(sortedPermissions, func(i, j int) bool {
+		return ipPermissionShardKey(sortedPermissions[i]) < ipPermissionShardKey(sortedPermissions[j])
+	})
+	shardCount := (len(permissions) + maxRulesPerSG - 1) / maxRulesPerSG
+	shards := make([][]ec2model.IPPermission, shardCount)
+	for _, permission := range sortedPermissions {
+		shardIndex := jumpHash(hashShardKey(ipPermissionShardKey(permission)), shardCount)
+		for len(shards[shardIndex]) >= maxRulesPerSG {
+			shardIndex = (shardIndex + 1) % shardCount
+		}
+		shards[shardIndex] = append(shards[shardIndex], permission)
+	}
+	return shards, nil
+}
+
+func ipPermissionShardKey(permission ec2model.IPPermission) string {
+	var sources []string
+	for _, ipRange := range permission.IPRanges {
+		sources = append(sources, ipRange.CIDRIP)
+	}
+	for _, ipv6Range := range permission.IPv6Range {
+		sources = append(sources, ipv6Range.CIDRIPv6)
+	}
+	for _, groupPair := range permission.UserIDGroupPairs {
+		sources = append(sources, groupPair.GroupID)
+	}
+	for _, prefixList := range permission.PrefixLists {
+		sources = append(sources, prefixList.PrefixListID)
+	}
+	return fmt.Sprintf("%v/%010d/%010d/%v", permission.IPProtocol,
+		awssdk.Int64Value(permission.FromPort), awssdk.Int64Value(permission.ToPort), sources)
+}
+
+func hashShardKey(key string) uint64 {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(key))
+	return hash.Sum64()
+}
+
+// jumpHash maps key into one of bucketCount buckets with the jump consistent hash algorithm,
+// so that only 1/bucketCount of keys move when the number of buckets grows by one.
+func jumpHash(key uint64, bucketCount int) int {
+	bucket, next := int64(-1), int64(0)
+	for next < int64(bucketCount) {
+		bucket = next
+		key = key*2862933555777941757 + 1
+		next = int64(float64(bucket+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+	return int(bucket)
+}
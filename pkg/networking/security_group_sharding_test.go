@@ -0,0 +1,141 @@
+package networking
+
+import (
+	"errors"
+	"fmt"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+	"testing"
+)
+
+func Test_ShardSecurityGroupSpec(t *testing.T) {
+	tcpPermission := func(port int64, cidr string) ec2model.IPPermission {
+		return ec2model.IPPermission{
+			IPProtocol: "tcp",
+			FromPort:   awssdk.Int64(port),
+			ToPort:     awssdk.Int64(port),
+			IPRanges: []ec2model.IPRange{
+				{
+					CIDRIP: cidr,
+				},
+			},
+		}
+	}
+	type args struct {
+		sgSpec        ec2model.SecurityGroupSpec
+		maxRulesPerSG int
+		maxSGs        int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []ec2model.SecurityGroupSpec
+		wantErr error
+	}{
+		{
+			name: "permissions within rule quota",
+			args: args{
+				sgSpec: ec2model.SecurityGroupSpec{
+					GroupName: "k8s-sg",
+					Ingress:   []ec2model.IPPermission{tcpPermission(443, "10.0.0.0/16"), tcpPermission(80, "10.0.0.0/16")},
+				},
+				maxRulesPerSG: 2,
+				maxSGs:        2,
+			},
+			want: []ec2model.SecurityGroupSpec{
+				{
+					GroupName: "k8s-sg",
+					Ingress:   []ec2model.IPPermission{tcpPermission(443, "10.0.0.0/16"), tcpPermission(80, "10.0.0.0/16")},
+				},
+			},
+		},
+		{
+			name: "permissions exceeding rule quota are sharded",
+			args: args{
+				sgSpec: ec2model.SecurityGroupSpec{
+					GroupName: "k8s-sg",
+					Ingress: []ec2model.IPPermission{
+						tcpPermission(443, "10.0.0.0/16"),
+						tcpPermission(80, "10.1.0.0/16"),
+						tcpPermission(80, "10.0.0.0/16"),
+					},
+				},
+				maxRulesPerSG: 2,
+				maxSGs:        2,
+			},
+			want: []ec2model.SecurityGroupSpec{
+				{
+					GroupName: "k8s-sg",
+					Ingress:   []ec2model.IPPermission{tcpPermission(80, "10.1.0.0/16")},
+				},
+				{
+					GroupName: "k8s-sg-1",
+					Ingress:   []ec2model.IPPermission{tcpPermission(80, "10.0.0.0/16"), tcpPermission(443, "10.0.0.0/16")},
+				},
+			},
+		},
+		{
+			name: "permissions exceeding rule quota of all securityGroups",
+			args: args{
+				sgSpec: ec2model.SecurityGroupSpec{
+					GroupName: "k8s-sg",
+					Ingress: []ec2model.IPPermission{
+						tcpPermission(443, "10.0.0.0/16"),
+						tcpPermission(80, "10.1.0.0/16"),
+						tcpPermission(80, "10.0.0.0/16"),
+					},
+				},
+				maxRulesPerSG: 1,
+				maxSGs:        2,
+			},
+			wantErr: errors.New("too many inbound rules for managed SecurityGroups: 3, exceeds 1 rules in each of 2 SecurityGroups"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ShardSecurityGroupSpec(tt.args.sgSpec, tt.args.maxRulesPerSG, tt.args.maxSGs)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_shardIPPermissions_stableAssignment(t *testing.T) {
+	var permissions []ec2model.IPPermission
+	for i := 0; i < 100; i++ {
+		permissions = append(permissions, ec2model.IPPermission{
+			IPProtocol: "tcp",
+			FromPort:   awssdk.Int64(80),
+			ToPort:     awssdk.Int64(80),
+			IPRanges:   []ec2model.IPRange{{CIDRIP: fmt.Sprintf("10.0.%d.0/24", i)}},
+		})
+	}
+	shardIndexByKey := func(shards [][]ec2model.IPPermission) map[string]int {
+		shardIndexByKey := make(map[string]int)
+		for shardIndex, shard := range shards {
+			for _, permission := range shard {
+				shardIndexByKey[ipPermissionShardKey(permission)] = shardIndex
+			}
+		}
+		return shardIndexByKey
+	}
+
+	shards, err := shardIPPermissions(permissions[:99], 60, 5)
+	assert.NoError(t, err)
+	shardsWithAddedPermission, err := shardIPPermissions(permissions, 60, 5)
+	assert.NoError(t, err)
+
+	// adding a permission doesn't move existing permissions between securityGroups with room.
+	shardIndexByKeyWithAddedPermission := shardIndexByKey(shardsWithAddedPermission)
+	for key, shardIndex := range shardIndexByKey(shards) {
+		assert.Equal(t, shardIndex, shardIndexByKeyWithAddedPermission[key], key)
+	}
+	for _, shard := range shardsWithAddedPermission {
+		assert.True(t, len(shard) <= 60)
+	}
+}
@@ -7,11 +7,14 @@ import (
 	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/cache"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 )
 
 const (
@@ -19,13 +22,38 @@ const (
 	TagKeySubnetPublicELB   = "kubernetes.io/role/elb"
 )
 
-type subnetLocaleType string
+const (
+	// the zone types of AvailabilityZones reported by EC2.
+	zoneTypeLocalZone      = "local-zone"
+	zoneTypeWavelengthZone = "wavelength-zone"
+
+	// zone types never change, so they can be cached for a long time.
+	defaultZoneTypesCacheTTL = 24 * time.Hour
+)
+
+// SubnetLocaleType is the type of location a subnet resides in.
+type SubnetLocaleType string
 
 const (
-	subnetLocaleTypeAvailabilityZone subnetLocaleType = "availabilityZone"
-	subnetLocaleTypeOutpost          subnetLocaleType = "outpost"
+	SubnetLocaleTypeAvailabilityZone SubnetLocaleType = "availabilityZone"
+	SubnetLocaleTypeLocalZone        SubnetLocaleType = "localZone"
+	SubnetLocaleTypeWavelengthZone   SubnetLocaleType = "wavelengthZone"
+	SubnetLocaleTypeOutpost          SubnetLocaleType = "outpost"
 )
 
+// supportedSubnetLocaleTypesByLBType is the subnet locale types supported by each Load Balancer type.
+var supportedSubnetLocaleTypesByLBType = map[elbv2model.LoadBalancerType][]SubnetLocaleType{
+	elbv2model.LoadBalancerTypeApplication: {
+		SubnetLocaleTypeAvailabilityZone,
+		SubnetLocaleTypeLocalZone,
+		SubnetLocaleTypeWavelengthZone,
+		SubnetLocaleTypeOutpost,
+	},
+	elbv2model.LoadBalancerTypeNetwork: {
+		SubnetLocaleTypeAvailabilityZone,
+	},
+}
+
 // options for resolve subnets.
 type SubnetsResolveOptions struct {
 	// The Load Balancer Type.
@@ -34,6 +62,12 @@ type SubnetsResolveOptions struct {
 	// The Load Balancer Scheme.
 	// By default, it's internet-facing.
 	LBScheme elbv2model.LoadBalancerScheme
+	// The required locale type of subnets.
+	// By default, subnets in any locale supported by LBType are allowed.
+	LocaleType SubnetLocaleType
+	// The subnet IDs, or the names or IDs of availability zones, whose subnets are excluded.
+	// By default, no subnets are excluded.
+	ExcludedSubnets []string
 }
 
 // ApplyOptions applies slice of SubnetsResolveOption.
@@ -67,6 +101,20 @@ func WithSubnetsResolveLBScheme(lbScheme elbv2model.LoadBalancerScheme) SubnetsR
 	}
 }
 
+// WithSubnetsResolveLocaleType generates a option that configures LocaleType.
+func WithSubnetsResolveLocaleType(localeType SubnetLocaleType) SubnetsResolveOption {
+	return func(opts *SubnetsResolveOptions) {
+		opts.LocaleType = localeType
+	}
+}
+
+// WithSubnetsResolveExclusions generates a option that configures ExcludedSubnets.
+func WithSubnetsResolveExclusions(excludedSubnets []string) SubnetsResolveOption {
+	return func(opts *SubnetsResolveOptions) {
+		opts.ExcludedSubnets = excludedSubnets
+	}
+}
+
 // SubnetsResolver is responsible for resolve EC2 Subnets for Load Balancers.
 type SubnetsResolver interface {
 	// ResolveViaDiscovery resolve subnets by auto discover matching subnets.
@@ -74,11 +122,18 @@ type SubnetsResolver interface {
 	// Additionally,
 	//   * for internet-facing Load Balancer, "kubernetes.io/role/elb" tag must presents.
 	//   * for internal Load Balancer, "kubernetes.io/role/internal-elb" tag must presents.
+	// Subnets in locales that are not supported by Load Balancer type or don't match the required locale type are ignored, as are excluded subnets.
 	// If multiple subnets are found for specific AZ, one subnet is chosen based on the lexical order of subnetID.
 	ResolveViaDiscovery(ctx context.Context, opts ...SubnetsResolveOption) ([]*ec2sdk.Subnet, error)
 
-	// ResolveViaNameOrIDSlice resolve subnets using subnet name or ID.
+	// ResolveViaNameOrIDSlice resolve subnets using subnet name or ID, it errors if any of the subnets is excluded.
 	ResolveViaNameOrIDSlice(ctx context.Context, subnetNameOrIDs []string, opts ...SubnetsResolveOption) ([]*ec2sdk.Subnet, error)
+
+	// ResolveViaSelector resolve subnets using tag selector.
+	// Candidate includes all subnets within clusterVPC that matches all tags in selector, where a tag matches if its value is any of selector values,
+	// or if the subnet has the tag key when selector values are empty.
+	// Subnets are chosen the same way as ResolveViaDiscovery.
+	ResolveViaSelector(ctx context.Context, selector map[string][]string, opts ...SubnetsResolveOption) ([]*ec2sdk.Subnet, error)
 }
 
 // default implementation for SubnetsResolver.
@@ -87,6 +142,11 @@ type defaultSubnetsResolver struct {
 	vpcID       string
 	clusterName string
 	logger      logr.Logger
+
+	// zoneTypesCache caches the zone type by zone name.
+	zoneTypesCache      *cache.Expiring
+	zoneTypesCacheTTL   time.Duration
+	zoneTypesCacheMutex sync.Mutex
 }
 
 var _ SubnetsResolver = &defaultSubnetsResolver{}
@@ -94,10 +154,12 @@ var _ SubnetsResolver = &defaultSubnetsResolver{}
 // NewDefaultSubnetsResolver constructs new defaultSubnetsResolver.
 func NewDefaultSubnetsResolver(ec2Client services.EC2, vpcID string, clusterName string, logger logr.Logger) *defaultSubnetsResolver {
 	return &defaultSubnetsResolver{
-		ec2Client:   ec2Client,
-		vpcID:       vpcID,
-		clusterName: clusterName,
-		logger:      logger,
+		ec2Client:         ec2Client,
+		vpcID:             vpcID,
+		clusterName:       clusterName,
+		logger:            logger,
+		zoneTypesCache:    cache.NewExpiring(),
+		zoneTypesCacheTTL: defaultZoneTypesCacheTTL,
 	}
 }
 
@@ -113,7 +175,7 @@ func (r *defaultSubnetsResolver) ResolveViaDiscovery(ctx context.Context, opts .
 		subnetRoleTagKey = TagKeySubnetPublicELB
 	}
 	clusterResourceTagKey := fmt.Sprintf("kubernetes.io/cluster/%s", r.clusterName)
-	req := &ec2sdk.DescribeSubnetsInput{Filters: []*ec2sdk.Filter{
+	return r.resolveViaFilters(ctx, []*ec2sdk.Filter{
 		{
 			Name:   awssdk.String("tag:" + clusterResourceTagKey),
 			Values: awssdk.StringSlice([]string{"owned", "shared"}),
@@ -122,16 +184,74 @@ func (r *defaultSubnetsResolver) ResolveViaDiscovery(ctx context.Context, opts .
 			Name:   awssdk.String("tag:" + subnetRoleTagKey),
 			Values: awssdk.StringSlice([]string{"", "1"}),
 		},
-		{
-			Name:   awssdk.String("vpc-id"),
-			Values: awssdk.StringSlice([]string{r.vpcID}),
-		},
-	}}
+	}, resolveOpts)
+}
+
+func (r *defaultSubnetsResolver) ResolveViaSelector(ctx context.Context, selector map[string][]string, opts ...SubnetsResolveOption) ([]*ec2sdk.Subnet, error) {
+	resolveOpts := defaultSubnetsResolveOptions()
+	resolveOpts.ApplyOptions(opts)
+	if len(selector) == 0 {
+		return nil, errors.New("subnet selector must contain at least one tag")
+	}
+
+	tagKeys := make([]string, 0, len(selector))
+	for tagKey := range selector {
+		tagKeys = append(tagKeys, tagKey)
+	}
+	sort.Strings(tagKeys)
+	filters := make([]*ec2sdk.Filter, 0, len(selector))
+	for _, tagKey := range tagKeys {
+		tagValues := selector[tagKey]
+		if len(tagValues) == 0 {
+			filters = append(filters, &ec2sdk.Filter{
+				Name:   awssdk.String("tag-key"),
+				Values: awssdk.StringSlice([]string{tagKey}),
+			})
+		} else {
+			filters = append(filters, &ec2sdk.Filter{
+				Name:   awssdk.String("tag:" + tagKey),
+				Values: awssdk.StringSlice(tagValues),
+			})
+		}
+	}
+	return r.resolveViaFilters(ctx, filters, resolveOpts)
+}
+
+// SplitSubnetTagValues splits the "|" separated values of subnet tag in subnet selector annotations,
+// values are sorted so that they can be compared.
+func SplitSubnetTagValues(rawTagValues string) []string {
+	var tagValues []string
+	for _, tagValue := range strings.Split(rawTagValues, "|") {
+		tagValue = strings.TrimSpace(tagValue)
+		if len(tagValue) == 0 {
+			continue
+		}
+		tagValues = append(tagValues, tagValue)
+	}
+	sort.Strings(tagValues)
+	return tagValues
+}
+
+// resolveViaFilters resolve subnets within clusterVPC that matches filters, and chooses one subnet per AZ.
+func (r *defaultSubnetsResolver) resolveViaFilters(ctx context.Context, filters []*ec2sdk.Filter, resolveOpts SubnetsResolveOptions) ([]*ec2sdk.Subnet, error) {
+	if resolveOpts.LocaleType != "" {
+		if err := r.validateSubnetsLocaleSupport(resolveOpts.LocaleType, resolveOpts); err != nil {
+			return nil, err
+		}
+	}
+
+	req := &ec2sdk.DescribeSubnetsInput{Filters: append(filters, &ec2sdk.Filter{
+		Name:   awssdk.String("vpc-id"),
+		Values: awssdk.StringSlice([]string{r.vpcID}),
+	})}
 
 	subnets, err := r.ec2Client.DescribeSubnetsAsList(ctx, req)
 	if err != nil {
 		return nil, err
 	}
+	zoneTypeByName := r.fetchZoneTypes(ctx, subnets)
+	subnets = r.filterSubnetsByLocale(subnets, zoneTypeByName, resolveOpts)
+	subnets = r.filterSubnetsByExclusions(subnets, resolveOpts)
 	subnetsByAZ := mapSDKSubnetsByAZ(subnets)
 	chosenSubnets := make([]*ec2sdk.Subnet, 0, len(subnetsByAZ))
 	for az, subnets := range subnetsByAZ {
@@ -149,7 +269,7 @@ func (r *defaultSubnetsResolver) ResolveViaDiscovery(ctx context.Context, opts .
 	if len(chosenSubnets) == 0 {
 		return nil, errors.New("unable to discover at least one subnet")
 	}
-	subnetLocale, err := r.validateSubnetsLocaleUniformity(chosenSubnets)
+	subnetLocale, err := r.validateSubnetsLocaleUniformity(chosenSubnets, zoneTypeByName)
 	if err != nil {
 		return nil, err
 	}
@@ -206,16 +326,23 @@ func (r *defaultSubnetsResolver) ResolveViaNameOrIDSlice(ctx context.Context, su
 	if len(resolvedSubnets) != len(subnetNameOrIDs) {
 		return nil, errors.Errorf("couldn't find all subnets, nameOrIDs: %v, found: %v", subnetNameOrIDs, len(resolvedSubnets))
 	}
+	if err := r.validateSubnetsNotExcluded(resolvedSubnets, resolveOpts); err != nil {
+		return nil, err
+	}
 	if len(resolvedSubnets) == 0 {
 		return nil, errors.New("unable to resolve at least one subnet")
 	}
 	if err := r.validateSubnetsAZExclusivity(resolvedSubnets); err != nil {
 		return nil, err
 	}
-	subnetLocale, err := r.validateSubnetsLocaleUniformity(resolvedSubnets)
+	zoneTypeByName := r.fetchZoneTypes(ctx, resolvedSubnets)
+	subnetLocale, err := r.validateSubnetsLocaleUniformity(resolvedSubnets, zoneTypeByName)
 	if err != nil {
 		return nil, err
 	}
+	if err := r.validateSubnetsLocaleSupport(subnetLocale, resolveOpts); err != nil {
+		return nil, err
+	}
 	if err := r.validateSubnetsMinimalCount(resolvedSubnets, subnetLocale, resolveOpts); err != nil {
 		return nil, err
 	}
@@ -241,21 +368,127 @@ func (r *defaultSubnetsResolver) validateSubnetsAZExclusivity(subnets []*ec2sdk.
 
 // validateSDKSubnetsLocaleExclusivity validates all subnets belong to same locale, and returns the same locale.
 // subnets passed-in must be non-empty
-func (r *defaultSubnetsResolver) validateSubnetsLocaleUniformity(subnets []*ec2sdk.Subnet) (subnetLocaleType, error) {
+func (r *defaultSubnetsResolver) validateSubnetsLocaleUniformity(subnets []*ec2sdk.Subnet, zoneTypeByName map[string]string) (SubnetLocaleType, error) {
 	subnetLocales := sets.NewString()
 	for _, subnet := range subnets {
-		subnetLocale := buildSDKSubnetLocaleType(subnet)
+		subnetLocale := buildSDKSubnetLocaleType(subnet, zoneTypeByName)
 		subnetLocales.Insert(string(subnetLocale))
 	}
 	if len(subnetLocales) > 1 {
 		return "", errors.Errorf("subnets in multiple locales: %v", subnetLocales.List())
 	}
 	subnetLocale, _ := subnetLocales.PopAny()
-	return subnetLocaleType(subnetLocale), nil
+	return SubnetLocaleType(subnetLocale), nil
+}
+
+// validateSubnetsLocaleSupport validates subnets locale is supported by Load Balancer type and matches the required locale type.
+func (r *defaultSubnetsResolver) validateSubnetsLocaleSupport(subnetLocale SubnetLocaleType, resolveOpts SubnetsResolveOptions) error {
+	if !isSubnetLocaleTypeSupported(subnetLocale, resolveOpts.LBType) {
+		return errors.Errorf("subnets in %v locale are not supported by %v Load Balancers", subnetLocale, resolveOpts.LBType)
+	}
+	if resolveOpts.LocaleType != "" && subnetLocale != resolveOpts.LocaleType {
+		return errors.Errorf("subnets in %v locale, but %v locale is required", subnetLocale, resolveOpts.LocaleType)
+	}
+	return nil
+}
+
+// filterSubnetsByLocale returns the subnets in locales that are supported by Load Balancer type and match the required locale type.
+func (r *defaultSubnetsResolver) filterSubnetsByLocale(subnets []*ec2sdk.Subnet, zoneTypeByName map[string]string, resolveOpts SubnetsResolveOptions) []*ec2sdk.Subnet {
+	filteredSubnets := make([]*ec2sdk.Subnet, 0, len(subnets))
+	for _, subnet := range subnets {
+		subnetLocale := buildSDKSubnetLocaleType(subnet, zoneTypeByName)
+		if r.validateSubnetsLocaleSupport(subnetLocale, resolveOpts) != nil {
+			r.logger.V(1).Info("ignored subnet in unsupported locale", "subnetID", awssdk.StringValue(subnet.SubnetId),
+				"locale", subnetLocale, "lbType", resolveOpts.LBType)
+			continue
+		}
+		filteredSubnets = append(filteredSubnets, subnet)
+	}
+	return filteredSubnets
+}
+
+// filterSubnetsByExclusions returns the subnets that are not excluded by subnet ID, or by name or ID of their availability zone.
+func (r *defaultSubnetsResolver) filterSubnetsByExclusions(subnets []*ec2sdk.Subnet, resolveOpts SubnetsResolveOptions) []*ec2sdk.Subnet {
+	if len(resolveOpts.ExcludedSubnets) == 0 {
+		return subnets
+	}
+	excludedSubnets := sets.NewString(resolveOpts.ExcludedSubnets...)
+	filteredSubnets := make([]*ec2sdk.Subnet, 0, len(subnets))
+	for _, subnet := range subnets {
+		if isSubnetExcluded(subnet, excludedSubnets) {
+			r.logger.V(1).Info("ignored excluded subnet", "subnetID", awssdk.StringValue(subnet.SubnetId),
+				"availabilityZone", awssdk.StringValue(subnet.AvailabilityZone))
+			continue
+		}
+		filteredSubnets = append(filteredSubnets, subnet)
+	}
+	return filteredSubnets
+}
+
+// validateSubnetsNotExcluded validates none of the explicitly specified subnets are excluded,
+// since silently dropping them would provision the LoadBalancer in fewer subnets than specified.
+func (r *defaultSubnetsResolver) validateSubnetsNotExcluded(subnets []*ec2sdk.Subnet, resolveOpts SubnetsResolveOptions) error {
+	if len(resolveOpts.ExcludedSubnets) == 0 {
+		return nil
+	}
+	excludedSubnets := sets.NewString(resolveOpts.ExcludedSubnets...)
+	var excludedSubnetIDs []string
+	for _, subnet := range subnets {
+		if isSubnetExcluded(subnet, excludedSubnets) {
+			excludedSubnetIDs = append(excludedSubnetIDs, awssdk.StringValue(subnet.SubnetId))
+		}
+	}
+	if len(excludedSubnetIDs) != 0 {
+		return errors.Errorf("subnets %v are both specified and excluded", excludedSubnetIDs)
+	}
+	return nil
+}
+
+// isSubnetExcluded checks whether subnet is excluded by its ID, or by name or ID of its availability zone.
+func isSubnetExcluded(subnet *ec2sdk.Subnet, excludedSubnets sets.String) bool {
+	return excludedSubnets.HasAny(awssdk.StringValue(subnet.SubnetId), awssdk.StringValue(subnet.AvailabilityZone), awssdk.StringValue(subnet.AvailabilityZoneId))
+}
+
+// fetchZoneTypes returns the zone type by zone name for zones of subnets.
+// zones whose type cannot be retrieved are absent, so that they're treated as availability zones,
+// which keeps a missing IAM permission or a transient error from failing subnet resolution.
+func (r *defaultSubnetsResolver) fetchZoneTypes(ctx context.Context, subnets []*ec2sdk.Subnet) map[string]string {
+	r.zoneTypesCacheMutex.Lock()
+	defer r.zoneTypesCacheMutex.Unlock()
+
+	zoneTypeByName := make(map[string]string, len(subnets))
+	unknownZoneNames := sets.NewString()
+	for _, subnet := range subnets {
+		zoneName := awssdk.StringValue(subnet.AvailabilityZone)
+		if rawCacheItem, exists := r.zoneTypesCache.Get(zoneName); exists {
+			zoneTypeByName[zoneName] = rawCacheItem.(string)
+		} else {
+			unknownZoneNames.Insert(zoneName)
+		}
+	}
+	if len(unknownZoneNames) == 0 {
+		return zoneTypeByName
+	}
+	req := &ec2sdk.DescribeAvailabilityZonesInput{
+		AllAvailabilityZones: awssdk.Bool(true),
+		ZoneNames:            awssdk.StringSlice(unknownZoneNames.List()),
+	}
+	resp, err := r.ec2Client.DescribeAvailabilityZonesWithContext(ctx, req)
+	if err != nil {
+		r.logger.Info("unable to retrieve zone types, zones are treated as availability zones", "zones", unknownZoneNames.List(), "error", err)
+		return zoneTypeByName
+	}
+	for _, zone := range resp.AvailabilityZones {
+		zoneName := awssdk.StringValue(zone.ZoneName)
+		zoneType := awssdk.StringValue(zone.ZoneType)
+		zoneTypeByName[zoneName] = zoneType
+		r.zoneTypesCache.Set(zoneName, zoneType, r.zoneTypesCacheTTL)
+	}
+	return zoneTypeByName
 }
 
 // validateSubnetsMinimalCount validates subnets meets minimal count requirement.
-func (r *defaultSubnetsResolver) validateSubnetsMinimalCount(subnets []*ec2sdk.Subnet, subnetLocale subnetLocaleType, resolveOpts SubnetsResolveOptions) error {
+func (r *defaultSubnetsResolver) validateSubnetsMinimalCount(subnets []*ec2sdk.Subnet, subnetLocale SubnetLocaleType, resolveOpts SubnetsResolveOptions) error {
 	minimalCount := r.computeSubnetsMinimalCount(subnetLocale, resolveOpts)
 	if len(subnets) < minimalCount {
 		return errors.Errorf("subnets count less than minimal required count: %v < %v", len(subnets), minimalCount)
@@ -264,9 +497,9 @@ func (r *defaultSubnetsResolver) validateSubnetsMinimalCount(subnets []*ec2sdk.S
 }
 
 // computeSubnetsMinimalCount returns the minimal count requirement for subnets.
-func (r *defaultSubnetsResolver) computeSubnetsMinimalCount(subnetLocale subnetLocaleType, resolveOpts SubnetsResolveOptions) int {
+func (r *defaultSubnetsResolver) computeSubnetsMinimalCount(subnetLocale SubnetLocaleType, resolveOpts SubnetsResolveOptions) int {
 	minimalCount := 1
-	if resolveOpts.LBType == elbv2model.LoadBalancerTypeApplication && subnetLocale == subnetLocaleTypeAvailabilityZone {
+	if resolveOpts.LBType == elbv2model.LoadBalancerTypeApplication && subnetLocale == SubnetLocaleTypeAvailabilityZone {
 		minimalCount = 2
 	}
 	return minimalCount
@@ -282,11 +515,27 @@ func mapSDKSubnetsByAZ(subnets []*ec2sdk.Subnet) map[string][]*ec2sdk.Subnet {
 	return subnetsByAZ
 }
 
-// buildSDKSubnetLocaleType builds the locale type for subnet.
-func buildSDKSubnetLocaleType(subnet *ec2sdk.Subnet) subnetLocaleType {
+// buildSDKSubnetLocaleType builds the locale type for subnet, based on the zone type by zone name.
+func buildSDKSubnetLocaleType(subnet *ec2sdk.Subnet, zoneTypeByName map[string]string) SubnetLocaleType {
 	if subnet.OutpostArn != nil && len(*subnet.OutpostArn) != 0 {
-		return subnetLocaleTypeOutpost
+		return SubnetLocaleTypeOutpost
+	}
+	switch zoneTypeByName[awssdk.StringValue(subnet.AvailabilityZone)] {
+	case zoneTypeLocalZone:
+		return SubnetLocaleTypeLocalZone
+	case zoneTypeWavelengthZone:
+		return SubnetLocaleTypeWavelengthZone
+	default:
+		return SubnetLocaleTypeAvailabilityZone
+	}
+}
+
+// isSubnetLocaleTypeSupported checks whether subnets in locale type are supported by Load Balancer type.
+func isSubnetLocaleTypeSupported(subnetLocale SubnetLocaleType, lbType elbv2model.LoadBalancerType) bool {
+	for _, supportedLocale := range supportedSubnetLocaleTypesByLBType[lbType] {
+		if subnetLocale == supportedLocale {
+			return true
+		}
 	}
-	// TODO: add localZone as well once we have fixed logic to compute whether it's localZone.
-	return subnetLocaleTypeAvailabilityZone
+	return false
 }
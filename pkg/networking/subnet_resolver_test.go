@@ -9,6 +9,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/cache"
 	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -25,6 +26,7 @@ func Test_defaultSubnetsResolver_ResolveViaDiscovery(t *testing.T) {
 		vpcID                      string
 		clusterName                string
 		describeSubnetsAsListCalls []describeSubnetsAsListCall
+		zones                      []*ec2sdk.AvailabilityZone
 	}
 	type args struct {
 		opts []SubnetsResolveOption
@@ -341,7 +343,7 @@ func Test_defaultSubnetsResolver_ResolveViaDiscovery(t *testing.T) {
 			},
 		},
 		{
-			name: "multiple subnet locales",
+			name: "excluded subnets and availability zones are ignored",
 			fields: fields{
 				vpcID:       "vpc-1",
 				clusterName: "kube-cluster",
@@ -354,7 +356,7 @@ func Test_defaultSubnetsResolver_ResolveViaDiscovery(t *testing.T) {
 									Values: awssdk.StringSlice([]string{"owned", "shared"}),
 								},
 								{
-									Name:   awssdk.String("tag:kubernetes.io/role/internal-elb"),
+									Name:   awssdk.String("tag:kubernetes.io/role/elb"),
 									Values: awssdk.StringSlice([]string{"", "1"}),
 								},
 								{
@@ -365,15 +367,34 @@ func Test_defaultSubnetsResolver_ResolveViaDiscovery(t *testing.T) {
 						},
 						output: []*ec2sdk.Subnet{
 							{
-								SubnetId:         awssdk.String("subnet-1"),
-								AvailabilityZone: awssdk.String("us-west-2a"),
-								VpcId:            awssdk.String("vpc-1"),
+								SubnetId:           awssdk.String("subnet-1"),
+								AvailabilityZone:   awssdk.String("us-west-2a"),
+								AvailabilityZoneId: awssdk.String("usw2-az1"),
+								VpcId:              awssdk.String("vpc-1"),
 							},
 							{
-								SubnetId:         awssdk.String("subnet-2"),
-								AvailabilityZone: awssdk.String("us-west-2b"),
-								VpcId:            awssdk.String("vpc-1"),
-								OutpostArn:       awssdk.String("outpost-xxx"),
+								SubnetId:           awssdk.String("subnet-2"),
+								AvailabilityZone:   awssdk.String("us-west-2a"),
+								AvailabilityZoneId: awssdk.String("usw2-az1"),
+								VpcId:              awssdk.String("vpc-1"),
+							},
+							{
+								SubnetId:           awssdk.String("subnet-3"),
+								AvailabilityZone:   awssdk.String("us-west-2b"),
+								AvailabilityZoneId: awssdk.String("usw2-az2"),
+								VpcId:              awssdk.String("vpc-1"),
+							},
+							{
+								SubnetId:           awssdk.String("subnet-4"),
+								AvailabilityZone:   awssdk.String("us-west-2c"),
+								AvailabilityZoneId: awssdk.String("usw2-az3"),
+								VpcId:              awssdk.String("vpc-1"),
+							},
+							{
+								SubnetId:           awssdk.String("subnet-5"),
+								AvailabilityZone:   awssdk.String("us-west-2d"),
+								AvailabilityZoneId: awssdk.String("usw2-az4"),
+								VpcId:              awssdk.String("vpc-1"),
 							},
 						},
 					},
@@ -382,13 +403,27 @@ func Test_defaultSubnetsResolver_ResolveViaDiscovery(t *testing.T) {
 			args: args{
 				opts: []SubnetsResolveOption{
 					WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeApplication),
-					WithSubnetsResolveLBScheme(elbv2model.LoadBalancerSchemeInternal),
+					WithSubnetsResolveLBScheme(elbv2model.LoadBalancerSchemeInternetFacing),
+					WithSubnetsResolveExclusions([]string{"subnet-1", "usw2-az3", "us-west-2d"}),
+				},
+			},
+			want: []*ec2sdk.Subnet{
+				{
+					SubnetId:           awssdk.String("subnet-2"),
+					AvailabilityZone:   awssdk.String("us-west-2a"),
+					AvailabilityZoneId: awssdk.String("usw2-az1"),
+					VpcId:              awssdk.String("vpc-1"),
+				},
+				{
+					SubnetId:           awssdk.String("subnet-3"),
+					AvailabilityZone:   awssdk.String("us-west-2b"),
+					AvailabilityZoneId: awssdk.String("usw2-az2"),
+					VpcId:              awssdk.String("vpc-1"),
 				},
 			},
-			wantErr: errors.New("subnets in multiple locales: [availabilityZone outpost]"),
 		},
 		{
-			name: "describeSubnetsAsList returns error",
+			name: "multiple subnet locales",
 			fields: fields{
 				vpcID:       "vpc-1",
 				clusterName: "kube-cluster",
@@ -410,7 +445,19 @@ func Test_defaultSubnetsResolver_ResolveViaDiscovery(t *testing.T) {
 								},
 							},
 						},
-						err: errors.New("some error"),
+						output: []*ec2sdk.Subnet{
+							{
+								SubnetId:         awssdk.String("subnet-1"),
+								AvailabilityZone: awssdk.String("us-west-2a"),
+								VpcId:            awssdk.String("vpc-1"),
+							},
+							{
+								SubnetId:         awssdk.String("subnet-2"),
+								AvailabilityZone: awssdk.String("us-west-2b"),
+								VpcId:            awssdk.String("vpc-1"),
+								OutpostArn:       awssdk.String("outpost-xxx"),
+							},
+						},
 					},
 				},
 			},
@@ -420,72 +467,30 @@ func Test_defaultSubnetsResolver_ResolveViaDiscovery(t *testing.T) {
 					WithSubnetsResolveLBScheme(elbv2model.LoadBalancerSchemeInternal),
 				},
 			},
-			wantErr: errors.New("some error"),
+			wantErr: errors.New("subnets in multiple locales: [availabilityZone outpost]"),
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ctrl := gomock.NewController(t)
-			defer ctrl.Finish()
-
-			ec2Client := mock_services.NewMockEC2(ctrl)
-			for _, call := range tt.fields.describeSubnetsAsListCalls {
-				ec2Client.EXPECT().DescribeSubnetsAsList(gomock.Any(), call.input).Return(call.output, call.err)
-			}
-
-			r := &defaultSubnetsResolver{
-				ec2Client:   ec2Client,
-				vpcID:       tt.fields.vpcID,
-				clusterName: tt.fields.clusterName,
-				logger:      &log.NullLogger{},
-			}
-
-			got, err := r.ResolveViaDiscovery(context.Background(), tt.args.opts...)
-			if tt.wantErr != nil {
-				assert.EqualError(t, err, tt.wantErr.Error())
-			} else {
-				assert.NoError(t, err)
-				opts := cmpopts.SortSlices(func(lhs *ec2sdk.Subnet, rhs *ec2sdk.Subnet) bool {
-					return awssdk.StringValue(lhs.SubnetId) < awssdk.StringValue(rhs.SubnetId)
-				})
-				assert.True(t, cmp.Equal(tt.want, got, opts), "diff", cmp.Diff(tt.want, got, opts))
-			}
-		})
-	}
-}
-
-func Test_defaultSubnetsResolver_ResolveViaNameOrIDSlice(t *testing.T) {
-	type describeSubnetsAsListCall struct {
-		input  *ec2sdk.DescribeSubnetsInput
-		output []*ec2sdk.Subnet
-		err    error
-	}
-	type fields struct {
-		vpcID                      string
-		clusterName                string
-		describeSubnetsAsListCalls []describeSubnetsAsListCall
-	}
-	type args struct {
-		subnetNameOrIDs []string
-		opts            []SubnetsResolveOption
-	}
-	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		want    []*ec2sdk.Subnet
-		wantErr error
-	}{
 		{
-			name: "ALB with subnetID only",
+			name: "NLB ignores subnets in Local Zones",
 			fields: fields{
 				vpcID:       "vpc-1",
 				clusterName: "kube-cluster",
 				describeSubnetsAsListCalls: []describeSubnetsAsListCall{
 					{
 						input: &ec2sdk.DescribeSubnetsInput{
-							SubnetIds: awssdk.StringSlice([]string{"subnet-1", "subnet-2"}),
+							Filters: []*ec2sdk.Filter{
+								{
+									Name:   awssdk.String("tag:kubernetes.io/cluster/kube-cluster"),
+									Values: awssdk.StringSlice([]string{"owned", "shared"}),
+								},
+								{
+									Name:   awssdk.String("tag:kubernetes.io/role/internal-elb"),
+									Values: awssdk.StringSlice([]string{"", "1"}),
+								},
+								{
+									Name:   awssdk.String("vpc-id"),
+									Values: awssdk.StringSlice([]string{"vpc-1"}),
+								},
+							},
 						},
 						output: []*ec2sdk.Subnet{
 							{
@@ -498,14 +503,32 @@ func Test_defaultSubnetsResolver_ResolveViaNameOrIDSlice(t *testing.T) {
 								AvailabilityZone: awssdk.String("us-west-2b"),
 								VpcId:            awssdk.String("vpc-1"),
 							},
+							{
+								SubnetId:         awssdk.String("subnet-3"),
+								AvailabilityZone: awssdk.String("us-west-2-lax-1a"),
+								VpcId:            awssdk.String("vpc-1"),
+							},
 						},
 					},
 				},
+				zones: []*ec2sdk.AvailabilityZone{
+					{
+						ZoneName: awssdk.String("us-west-2a"),
+						ZoneType: awssdk.String("availability-zone"),
+					},
+					{
+						ZoneName: awssdk.String("us-west-2b"),
+						ZoneType: awssdk.String("availability-zone"),
+					},
+					{
+						ZoneName: awssdk.String("us-west-2-lax-1a"),
+						ZoneType: awssdk.String("local-zone"),
+					},
+				},
 			},
 			args: args{
-				subnetNameOrIDs: []string{"subnet-1", "subnet-2"},
 				opts: []SubnetsResolveOption{
-					WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeApplication),
+					WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeNetwork),
 					WithSubnetsResolveLBScheme(elbv2model.LoadBalancerSchemeInternal),
 				},
 			},
@@ -523,7 +546,7 @@ func Test_defaultSubnetsResolver_ResolveViaNameOrIDSlice(t *testing.T) {
 			},
 		},
 		{
-			name: "ALB with subnet Name only",
+			name: "ALB requires subnets in Local Zones",
 			fields: fields{
 				vpcID:       "vpc-1",
 				clusterName: "kube-cluster",
@@ -532,8 +555,12 @@ func Test_defaultSubnetsResolver_ResolveViaNameOrIDSlice(t *testing.T) {
 						input: &ec2sdk.DescribeSubnetsInput{
 							Filters: []*ec2sdk.Filter{
 								{
-									Name:   awssdk.String("tag:Name"),
-									Values: awssdk.StringSlice([]string{"my-name-1", "my-name-2"}),
+									Name:   awssdk.String("tag:kubernetes.io/cluster/kube-cluster"),
+									Values: awssdk.StringSlice([]string{"owned", "shared"}),
+								},
+								{
+									Name:   awssdk.String("tag:kubernetes.io/role/internal-elb"),
+									Values: awssdk.StringSlice([]string{"", "1"}),
 								},
 								{
 									Name:   awssdk.String("vpc-id"),
@@ -552,54 +579,60 @@ func Test_defaultSubnetsResolver_ResolveViaNameOrIDSlice(t *testing.T) {
 								AvailabilityZone: awssdk.String("us-west-2b"),
 								VpcId:            awssdk.String("vpc-1"),
 							},
+							{
+								SubnetId:         awssdk.String("subnet-3"),
+								AvailabilityZone: awssdk.String("us-west-2-lax-1a"),
+								VpcId:            awssdk.String("vpc-1"),
+							},
 						},
 					},
 				},
+				zones: []*ec2sdk.AvailabilityZone{
+					{
+						ZoneName: awssdk.String("us-west-2a"),
+						ZoneType: awssdk.String("availability-zone"),
+					},
+					{
+						ZoneName: awssdk.String("us-west-2b"),
+						ZoneType: awssdk.String("availability-zone"),
+					},
+					{
+						ZoneName: awssdk.String("us-west-2-lax-1a"),
+						ZoneType: awssdk.String("local-zone"),
+					},
+				},
 			},
 			args: args{
-				subnetNameOrIDs: []string{"my-name-1", "my-name-2"},
 				opts: []SubnetsResolveOption{
 					WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeApplication),
 					WithSubnetsResolveLBScheme(elbv2model.LoadBalancerSchemeInternal),
+					WithSubnetsResolveLocaleType(SubnetLocaleTypeLocalZone),
 				},
 			},
 			want: []*ec2sdk.Subnet{
 				{
-					SubnetId:         awssdk.String("subnet-1"),
-					AvailabilityZone: awssdk.String("us-west-2a"),
-					VpcId:            awssdk.String("vpc-1"),
-				},
-				{
-					SubnetId:         awssdk.String("subnet-2"),
-					AvailabilityZone: awssdk.String("us-west-2b"),
+					SubnetId:         awssdk.String("subnet-3"),
+					AvailabilityZone: awssdk.String("us-west-2-lax-1a"),
 					VpcId:            awssdk.String("vpc-1"),
 				},
 			},
 		},
 		{
-			name: "ALB with subnetID and subnet Name",
+			name: "ALB with subnets in Local Zones forbidden",
 			fields: fields{
 				vpcID:       "vpc-1",
 				clusterName: "kube-cluster",
 				describeSubnetsAsListCalls: []describeSubnetsAsListCall{
-					{
-						input: &ec2sdk.DescribeSubnetsInput{
-							SubnetIds: awssdk.StringSlice([]string{"subnet-1"}),
-						},
-						output: []*ec2sdk.Subnet{
-							{
-								SubnetId:         awssdk.String("subnet-1"),
-								AvailabilityZone: awssdk.String("us-west-2a"),
-								VpcId:            awssdk.String("vpc-1"),
-							},
-						},
-					},
 					{
 						input: &ec2sdk.DescribeSubnetsInput{
 							Filters: []*ec2sdk.Filter{
 								{
-									Name:   awssdk.String("tag:Name"),
-									Values: awssdk.StringSlice([]string{"my-name-2"}),
+									Name:   awssdk.String("tag:kubernetes.io/cluster/kube-cluster"),
+									Values: awssdk.StringSlice([]string{"owned", "shared"}),
+								},
+								{
+									Name:   awssdk.String("tag:kubernetes.io/role/internal-elb"),
+									Values: awssdk.StringSlice([]string{"", "1"}),
 								},
 								{
 									Name:   awssdk.String("vpc-id"),
@@ -608,20 +641,44 @@ func Test_defaultSubnetsResolver_ResolveViaNameOrIDSlice(t *testing.T) {
 							},
 						},
 						output: []*ec2sdk.Subnet{
+							{
+								SubnetId:         awssdk.String("subnet-1"),
+								AvailabilityZone: awssdk.String("us-west-2a"),
+								VpcId:            awssdk.String("vpc-1"),
+							},
 							{
 								SubnetId:         awssdk.String("subnet-2"),
 								AvailabilityZone: awssdk.String("us-west-2b"),
 								VpcId:            awssdk.String("vpc-1"),
 							},
+							{
+								SubnetId:         awssdk.String("subnet-3"),
+								AvailabilityZone: awssdk.String("us-west-2-lax-1a"),
+								VpcId:            awssdk.String("vpc-1"),
+							},
 						},
 					},
 				},
+				zones: []*ec2sdk.AvailabilityZone{
+					{
+						ZoneName: awssdk.String("us-west-2a"),
+						ZoneType: awssdk.String("availability-zone"),
+					},
+					{
+						ZoneName: awssdk.String("us-west-2b"),
+						ZoneType: awssdk.String("availability-zone"),
+					},
+					{
+						ZoneName: awssdk.String("us-west-2-lax-1a"),
+						ZoneType: awssdk.String("local-zone"),
+					},
+				},
 			},
 			args: args{
-				subnetNameOrIDs: []string{"subnet-1", "my-name-2"},
 				opts: []SubnetsResolveOption{
 					WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeApplication),
 					WithSubnetsResolveLBScheme(elbv2model.LoadBalancerSchemeInternal),
+					WithSubnetsResolveLocaleType(SubnetLocaleTypeAvailabilityZone),
 				},
 			},
 			want: []*ec2sdk.Subnet{
@@ -638,7 +695,18 @@ func Test_defaultSubnetsResolver_ResolveViaNameOrIDSlice(t *testing.T) {
 			},
 		},
 		{
-			name: "cannot resolve all subnet names",
+			name: "NLB requires unsupported subnet locale",
+			args: args{
+				opts: []SubnetsResolveOption{
+					WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeNetwork),
+					WithSubnetsResolveLBScheme(elbv2model.LoadBalancerSchemeInternal),
+					WithSubnetsResolveLocaleType(SubnetLocaleTypeOutpost),
+				},
+			},
+			wantErr: errors.New("subnets in outpost locale are not supported by network Load Balancers"),
+		},
+		{
+			name: "describeSubnetsAsList returns error",
 			fields: fields{
 				vpcID:       "vpc-1",
 				clusterName: "kube-cluster",
@@ -647,8 +715,12 @@ func Test_defaultSubnetsResolver_ResolveViaNameOrIDSlice(t *testing.T) {
 						input: &ec2sdk.DescribeSubnetsInput{
 							Filters: []*ec2sdk.Filter{
 								{
-									Name:   awssdk.String("tag:Name"),
-									Values: awssdk.StringSlice([]string{"my-name-1", "my-name-2", "my-name-3"}),
+									Name:   awssdk.String("tag:kubernetes.io/cluster/kube-cluster"),
+									Values: awssdk.StringSlice([]string{"owned", "shared"}),
+								},
+								{
+									Name:   awssdk.String("tag:kubernetes.io/role/internal-elb"),
+									Values: awssdk.StringSlice([]string{"", "1"}),
 								},
 								{
 									Name:   awssdk.String("vpc-id"),
@@ -656,62 +728,349 @@ func Test_defaultSubnetsResolver_ResolveViaNameOrIDSlice(t *testing.T) {
 								},
 							},
 						},
-						output: []*ec2sdk.Subnet{
-							{
-								SubnetId:         awssdk.String("subnet-1"),
-								AvailabilityZone: awssdk.String("us-west-2a"),
-								VpcId:            awssdk.String("vpc-1"),
-							},
-							{
-								SubnetId:         awssdk.String("subnet-2"),
-								AvailabilityZone: awssdk.String("us-west-2b"),
-								VpcId:            awssdk.String("vpc-1"),
-							},
-						},
+						err: errors.New("some error"),
 					},
 				},
 			},
 			args: args{
-				subnetNameOrIDs: []string{"my-name-1", "my-name-2", "my-name-3"},
-				opts: []SubnetsResolveOption{
-					WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeApplication),
-					WithSubnetsResolveLBScheme(elbv2model.LoadBalancerSchemeInternal),
-				},
-			},
-			wantErr: errors.New("couldn't find all subnets, nameOrIDs: [my-name-1 my-name-2 my-name-3], found: 2"),
-		},
-		{
-			name: "empty subnet name or IDs",
-			fields: fields{
-				vpcID:                      "vpc-1",
-				clusterName:                "kube-cluster",
-				describeSubnetsAsListCalls: nil,
-			},
-			args: args{
-				subnetNameOrIDs: nil,
 				opts: []SubnetsResolveOption{
 					WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeApplication),
 					WithSubnetsResolveLBScheme(elbv2model.LoadBalancerSchemeInternal),
 				},
 			},
-			wantErr: errors.New("unable to resolve at least one subnet"),
+			wantErr: errors.New("some error"),
 		},
-		{
-			name: "multiple subnet in same AZ",
-			fields: fields{
-				vpcID:       "vpc-1",
-				clusterName: "kube-cluster",
-				describeSubnetsAsListCalls: []describeSubnetsAsListCall{
-					{
-						input: &ec2sdk.DescribeSubnetsInput{
-							SubnetIds: awssdk.StringSlice([]string{"subnet-1", "subnet-2", "subnet-3"}),
-						},
-						output: []*ec2sdk.Subnet{
-							{
-								SubnetId:         awssdk.String("subnet-1"),
-								AvailabilityZone: awssdk.String("us-west-2a"),
-								VpcId:            awssdk.String("vpc-1"),
-							},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ec2Client := mock_services.NewMockEC2(ctrl)
+			for _, call := range tt.fields.describeSubnetsAsListCalls {
+				ec2Client.EXPECT().DescribeSubnetsAsList(gomock.Any(), call.input).Return(call.output, call.err)
+			}
+			ec2Client.EXPECT().DescribeAvailabilityZonesWithContext(gomock.Any(), gomock.Any()).Return(&ec2sdk.DescribeAvailabilityZonesOutput{
+				AvailabilityZones: tt.fields.zones,
+			}, nil).AnyTimes()
+
+			r := &defaultSubnetsResolver{
+				ec2Client:         ec2Client,
+				vpcID:             tt.fields.vpcID,
+				clusterName:       tt.fields.clusterName,
+				logger:            &log.NullLogger{},
+				zoneTypesCache:    cache.NewExpiring(),
+				zoneTypesCacheTTL: defaultZoneTypesCacheTTL,
+			}
+
+			got, err := r.ResolveViaDiscovery(context.Background(), tt.args.opts...)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				opts := cmpopts.SortSlices(func(lhs *ec2sdk.Subnet, rhs *ec2sdk.Subnet) bool {
+					return awssdk.StringValue(lhs.SubnetId) < awssdk.StringValue(rhs.SubnetId)
+				})
+				assert.True(t, cmp.Equal(tt.want, got, opts), "diff", cmp.Diff(tt.want, got, opts))
+			}
+		})
+	}
+}
+
+func Test_defaultSubnetsResolver_ResolveViaNameOrIDSlice(t *testing.T) {
+	type describeSubnetsAsListCall struct {
+		input  *ec2sdk.DescribeSubnetsInput
+		output []*ec2sdk.Subnet
+		err    error
+	}
+	type fields struct {
+		vpcID                      string
+		clusterName                string
+		describeSubnetsAsListCalls []describeSubnetsAsListCall
+		zones                      []*ec2sdk.AvailabilityZone
+	}
+	type args struct {
+		subnetNameOrIDs []string
+		opts            []SubnetsResolveOption
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []*ec2sdk.Subnet
+		wantErr error
+	}{
+		{
+			name: "ALB with subnetID only",
+			fields: fields{
+				vpcID:       "vpc-1",
+				clusterName: "kube-cluster",
+				describeSubnetsAsListCalls: []describeSubnetsAsListCall{
+					{
+						input: &ec2sdk.DescribeSubnetsInput{
+							SubnetIds: awssdk.StringSlice([]string{"subnet-1", "subnet-2"}),
+						},
+						output: []*ec2sdk.Subnet{
+							{
+								SubnetId:         awssdk.String("subnet-1"),
+								AvailabilityZone: awssdk.String("us-west-2a"),
+								VpcId:            awssdk.String("vpc-1"),
+							},
+							{
+								SubnetId:         awssdk.String("subnet-2"),
+								AvailabilityZone: awssdk.String("us-west-2b"),
+								VpcId:            awssdk.String("vpc-1"),
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				subnetNameOrIDs: []string{"subnet-1", "subnet-2"},
+				opts: []SubnetsResolveOption{
+					WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeApplication),
+					WithSubnetsResolveLBScheme(elbv2model.LoadBalancerSchemeInternal),
+				},
+			},
+			want: []*ec2sdk.Subnet{
+				{
+					SubnetId:         awssdk.String("subnet-1"),
+					AvailabilityZone: awssdk.String("us-west-2a"),
+					VpcId:            awssdk.String("vpc-1"),
+				},
+				{
+					SubnetId:         awssdk.String("subnet-2"),
+					AvailabilityZone: awssdk.String("us-west-2b"),
+					VpcId:            awssdk.String("vpc-1"),
+				},
+			},
+		},
+		{
+			name: "ALB with excluded subnetID",
+			fields: fields{
+				vpcID:       "vpc-1",
+				clusterName: "kube-cluster",
+				describeSubnetsAsListCalls: []describeSubnetsAsListCall{
+					{
+						input: &ec2sdk.DescribeSubnetsInput{
+							SubnetIds: awssdk.StringSlice([]string{"subnet-1", "subnet-2"}),
+						},
+						output: []*ec2sdk.Subnet{
+							{
+								SubnetId:         awssdk.String("subnet-1"),
+								AvailabilityZone: awssdk.String("us-west-2a"),
+								VpcId:            awssdk.String("vpc-1"),
+							},
+							{
+								SubnetId:         awssdk.String("subnet-2"),
+								AvailabilityZone: awssdk.String("us-west-2b"),
+								VpcId:            awssdk.String("vpc-1"),
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				subnetNameOrIDs: []string{"subnet-1", "subnet-2"},
+				opts: []SubnetsResolveOption{
+					WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeApplication),
+					WithSubnetsResolveLBScheme(elbv2model.LoadBalancerSchemeInternal),
+					WithSubnetsResolveExclusions([]string{"us-west-2b"}),
+				},
+			},
+			wantErr: errors.New("subnets [subnet-2] are both specified and excluded"),
+		},
+		{
+			name: "ALB with subnet Name only",
+			fields: fields{
+				vpcID:       "vpc-1",
+				clusterName: "kube-cluster",
+				describeSubnetsAsListCalls: []describeSubnetsAsListCall{
+					{
+						input: &ec2sdk.DescribeSubnetsInput{
+							Filters: []*ec2sdk.Filter{
+								{
+									Name:   awssdk.String("tag:Name"),
+									Values: awssdk.StringSlice([]string{"my-name-1", "my-name-2"}),
+								},
+								{
+									Name:   awssdk.String("vpc-id"),
+									Values: awssdk.StringSlice([]string{"vpc-1"}),
+								},
+							},
+						},
+						output: []*ec2sdk.Subnet{
+							{
+								SubnetId:         awssdk.String("subnet-1"),
+								AvailabilityZone: awssdk.String("us-west-2a"),
+								VpcId:            awssdk.String("vpc-1"),
+							},
+							{
+								SubnetId:         awssdk.String("subnet-2"),
+								AvailabilityZone: awssdk.String("us-west-2b"),
+								VpcId:            awssdk.String("vpc-1"),
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				subnetNameOrIDs: []string{"my-name-1", "my-name-2"},
+				opts: []SubnetsResolveOption{
+					WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeApplication),
+					WithSubnetsResolveLBScheme(elbv2model.LoadBalancerSchemeInternal),
+				},
+			},
+			want: []*ec2sdk.Subnet{
+				{
+					SubnetId:         awssdk.String("subnet-1"),
+					AvailabilityZone: awssdk.String("us-west-2a"),
+					VpcId:            awssdk.String("vpc-1"),
+				},
+				{
+					SubnetId:         awssdk.String("subnet-2"),
+					AvailabilityZone: awssdk.String("us-west-2b"),
+					VpcId:            awssdk.String("vpc-1"),
+				},
+			},
+		},
+		{
+			name: "ALB with subnetID and subnet Name",
+			fields: fields{
+				vpcID:       "vpc-1",
+				clusterName: "kube-cluster",
+				describeSubnetsAsListCalls: []describeSubnetsAsListCall{
+					{
+						input: &ec2sdk.DescribeSubnetsInput{
+							SubnetIds: awssdk.StringSlice([]string{"subnet-1"}),
+						},
+						output: []*ec2sdk.Subnet{
+							{
+								SubnetId:         awssdk.String("subnet-1"),
+								AvailabilityZone: awssdk.String("us-west-2a"),
+								VpcId:            awssdk.String("vpc-1"),
+							},
+						},
+					},
+					{
+						input: &ec2sdk.DescribeSubnetsInput{
+							Filters: []*ec2sdk.Filter{
+								{
+									Name:   awssdk.String("tag:Name"),
+									Values: awssdk.StringSlice([]string{"my-name-2"}),
+								},
+								{
+									Name:   awssdk.String("vpc-id"),
+									Values: awssdk.StringSlice([]string{"vpc-1"}),
+								},
+							},
+						},
+						output: []*ec2sdk.Subnet{
+							{
+								SubnetId:         awssdk.String("subnet-2"),
+								AvailabilityZone: awssdk.String("us-west-2b"),
+								VpcId:            awssdk.String("vpc-1"),
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				subnetNameOrIDs: []string{"subnet-1", "my-name-2"},
+				opts: []SubnetsResolveOption{
+					WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeApplication),
+					WithSubnetsResolveLBScheme(elbv2model.LoadBalancerSchemeInternal),
+				},
+			},
+			want: []*ec2sdk.Subnet{
+				{
+					SubnetId:         awssdk.String("subnet-1"),
+					AvailabilityZone: awssdk.String("us-west-2a"),
+					VpcId:            awssdk.String("vpc-1"),
+				},
+				{
+					SubnetId:         awssdk.String("subnet-2"),
+					AvailabilityZone: awssdk.String("us-west-2b"),
+					VpcId:            awssdk.String("vpc-1"),
+				},
+			},
+		},
+		{
+			name: "cannot resolve all subnet names",
+			fields: fields{
+				vpcID:       "vpc-1",
+				clusterName: "kube-cluster",
+				describeSubnetsAsListCalls: []describeSubnetsAsListCall{
+					{
+						input: &ec2sdk.DescribeSubnetsInput{
+							Filters: []*ec2sdk.Filter{
+								{
+									Name:   awssdk.String("tag:Name"),
+									Values: awssdk.StringSlice([]string{"my-name-1", "my-name-2", "my-name-3"}),
+								},
+								{
+									Name:   awssdk.String("vpc-id"),
+									Values: awssdk.StringSlice([]string{"vpc-1"}),
+								},
+							},
+						},
+						output: []*ec2sdk.Subnet{
+							{
+								SubnetId:         awssdk.String("subnet-1"),
+								AvailabilityZone: awssdk.String("us-west-2a"),
+								VpcId:            awssdk.String("vpc-1"),
+							},
+							{
+								SubnetId:         awssdk.String("subnet-2"),
+								AvailabilityZone: awssdk.String("us-west-2b"),
+								VpcId:            awssdk.String("vpc-1"),
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				subnetNameOrIDs: []string{"my-name-1", "my-name-2", "my-name-3"},
+				opts: []SubnetsResolveOption{
+					WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeApplication),
+					WithSubnetsResolveLBScheme(elbv2model.LoadBalancerSchemeInternal),
+				},
+			},
+			wantErr: errors.New("couldn't find all subnets, nameOrIDs: [my-name-1 my-name-2 my-name-3], found: 2"),
+		},
+		{
+			name: "empty subnet name or IDs",
+			fields: fields{
+				vpcID:                      "vpc-1",
+				clusterName:                "kube-cluster",
+				describeSubnetsAsListCalls: nil,
+			},
+			args: args{
+				subnetNameOrIDs: nil,
+				opts: []SubnetsResolveOption{
+					WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeApplication),
+					WithSubnetsResolveLBScheme(elbv2model.LoadBalancerSchemeInternal),
+				},
+			},
+			wantErr: errors.New("unable to resolve at least one subnet"),
+		},
+		{
+			name: "multiple subnet in same AZ",
+			fields: fields{
+				vpcID:       "vpc-1",
+				clusterName: "kube-cluster",
+				describeSubnetsAsListCalls: []describeSubnetsAsListCall{
+					{
+						input: &ec2sdk.DescribeSubnetsInput{
+							SubnetIds: awssdk.StringSlice([]string{"subnet-1", "subnet-2", "subnet-3"}),
+						},
+						output: []*ec2sdk.Subnet{
+							{
+								SubnetId:         awssdk.String("subnet-1"),
+								AvailabilityZone: awssdk.String("us-west-2a"),
+								VpcId:            awssdk.String("vpc-1"),
+							},
 							{
 								SubnetId:         awssdk.String("subnet-2"),
 								AvailabilityZone: awssdk.String("us-west-2b"),
@@ -733,45 +1092,152 @@ func Test_defaultSubnetsResolver_ResolveViaNameOrIDSlice(t *testing.T) {
 					WithSubnetsResolveLBScheme(elbv2model.LoadBalancerSchemeInternal),
 				},
 			},
-			wantErr: errors.New("multiple subnets in same Availability Zone us-west-2a: [subnet-1 subnet-3]"),
+			wantErr: errors.New("multiple subnets in same Availability Zone us-west-2a: [subnet-1 subnet-3]"),
+		},
+		{
+			name: "multiple subnet locales",
+			fields: fields{
+				vpcID:       "vpc-1",
+				clusterName: "kube-cluster",
+				describeSubnetsAsListCalls: []describeSubnetsAsListCall{
+					{
+						input: &ec2sdk.DescribeSubnetsInput{
+							SubnetIds: awssdk.StringSlice([]string{"subnet-1", "subnet-2"}),
+						},
+						output: []*ec2sdk.Subnet{
+							{
+								SubnetId:         awssdk.String("subnet-1"),
+								AvailabilityZone: awssdk.String("us-west-2a"),
+								VpcId:            awssdk.String("vpc-1"),
+							},
+							{
+								SubnetId:         awssdk.String("subnet-2"),
+								AvailabilityZone: awssdk.String("us-west-2b"),
+								VpcId:            awssdk.String("vpc-1"),
+								OutpostArn:       awssdk.String("outpost-xxx"),
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				subnetNameOrIDs: []string{"subnet-1", "subnet-2"},
+				opts: []SubnetsResolveOption{
+					WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeApplication),
+					WithSubnetsResolveLBScheme(elbv2model.LoadBalancerSchemeInternal),
+				},
+			},
+			wantErr: errors.New("subnets in multiple locales: [availabilityZone outpost]"),
+		},
+		{
+			name: "ALB with one availabilityZone subnet",
+			fields: fields{
+				vpcID:       "vpc-1",
+				clusterName: "kube-cluster",
+				describeSubnetsAsListCalls: []describeSubnetsAsListCall{
+					{
+						input: &ec2sdk.DescribeSubnetsInput{
+							SubnetIds: awssdk.StringSlice([]string{"subnet-1"}),
+						},
+						output: []*ec2sdk.Subnet{
+							{
+								SubnetId:         awssdk.String("subnet-1"),
+								AvailabilityZone: awssdk.String("us-west-2a"),
+								VpcId:            awssdk.String("vpc-1"),
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				subnetNameOrIDs: []string{"subnet-1"},
+				opts: []SubnetsResolveOption{
+					WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeApplication),
+					WithSubnetsResolveLBScheme(elbv2model.LoadBalancerSchemeInternal),
+				},
+			},
+			wantErr: errors.New("subnets count less than minimal required count: 1 < 2"),
+		},
+		{
+			name: "ALB with one Outpost subnet",
+			fields: fields{
+				vpcID:       "vpc-1",
+				clusterName: "kube-cluster",
+				describeSubnetsAsListCalls: []describeSubnetsAsListCall{
+					{
+						input: &ec2sdk.DescribeSubnetsInput{
+							SubnetIds: awssdk.StringSlice([]string{"subnet-1"}),
+						},
+						output: []*ec2sdk.Subnet{
+							{
+								SubnetId:         awssdk.String("subnet-1"),
+								AvailabilityZone: awssdk.String("us-west-2a"),
+								VpcId:            awssdk.String("vpc-1"),
+								OutpostArn:       awssdk.String("outpost-xxx"),
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				subnetNameOrIDs: []string{"subnet-1"},
+				opts: []SubnetsResolveOption{
+					WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeApplication),
+					WithSubnetsResolveLBScheme(elbv2model.LoadBalancerSchemeInternal),
+				},
+			},
+			want: []*ec2sdk.Subnet{
+				{
+					SubnetId:         awssdk.String("subnet-1"),
+					AvailabilityZone: awssdk.String("us-west-2a"),
+					VpcId:            awssdk.String("vpc-1"),
+					OutpostArn:       awssdk.String("outpost-xxx"),
+				},
+			},
 		},
 		{
-			name: "multiple subnet locales",
+			name: "ALB with one Local Zone subnet",
 			fields: fields{
 				vpcID:       "vpc-1",
 				clusterName: "kube-cluster",
 				describeSubnetsAsListCalls: []describeSubnetsAsListCall{
 					{
 						input: &ec2sdk.DescribeSubnetsInput{
-							SubnetIds: awssdk.StringSlice([]string{"subnet-1", "subnet-2"}),
+							SubnetIds: awssdk.StringSlice([]string{"subnet-1"}),
 						},
 						output: []*ec2sdk.Subnet{
 							{
 								SubnetId:         awssdk.String("subnet-1"),
-								AvailabilityZone: awssdk.String("us-west-2a"),
-								VpcId:            awssdk.String("vpc-1"),
-							},
-							{
-								SubnetId:         awssdk.String("subnet-2"),
-								AvailabilityZone: awssdk.String("us-west-2b"),
+								AvailabilityZone: awssdk.String("us-west-2-lax-1a"),
 								VpcId:            awssdk.String("vpc-1"),
-								OutpostArn:       awssdk.String("outpost-xxx"),
 							},
 						},
 					},
 				},
+				zones: []*ec2sdk.AvailabilityZone{
+					{
+						ZoneName: awssdk.String("us-west-2-lax-1a"),
+						ZoneType: awssdk.String("local-zone"),
+					},
+				},
 			},
 			args: args{
-				subnetNameOrIDs: []string{"subnet-1", "subnet-2"},
+				subnetNameOrIDs: []string{"subnet-1"},
 				opts: []SubnetsResolveOption{
 					WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeApplication),
 					WithSubnetsResolveLBScheme(elbv2model.LoadBalancerSchemeInternal),
 				},
 			},
-			wantErr: errors.New("subnets in multiple locales: [availabilityZone outpost]"),
+			want: []*ec2sdk.Subnet{
+				{
+					SubnetId:         awssdk.String("subnet-1"),
+					AvailabilityZone: awssdk.String("us-west-2-lax-1a"),
+					VpcId:            awssdk.String("vpc-1"),
+				},
+			},
 		},
 		{
-			name: "ALB with one availabilityZone subnet",
+			name: "ALB with Local Zone subnet forbidden",
 			fields: fields{
 				vpcID:       "vpc-1",
 				clusterName: "kube-cluster",
@@ -783,24 +1249,31 @@ func Test_defaultSubnetsResolver_ResolveViaNameOrIDSlice(t *testing.T) {
 						output: []*ec2sdk.Subnet{
 							{
 								SubnetId:         awssdk.String("subnet-1"),
-								AvailabilityZone: awssdk.String("us-west-2a"),
+								AvailabilityZone: awssdk.String("us-west-2-lax-1a"),
 								VpcId:            awssdk.String("vpc-1"),
 							},
 						},
 					},
 				},
+				zones: []*ec2sdk.AvailabilityZone{
+					{
+						ZoneName: awssdk.String("us-west-2-lax-1a"),
+						ZoneType: awssdk.String("local-zone"),
+					},
+				},
 			},
 			args: args{
 				subnetNameOrIDs: []string{"subnet-1"},
 				opts: []SubnetsResolveOption{
 					WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeApplication),
 					WithSubnetsResolveLBScheme(elbv2model.LoadBalancerSchemeInternal),
+					WithSubnetsResolveLocaleType(SubnetLocaleTypeAvailabilityZone),
 				},
 			},
-			wantErr: errors.New("subnets count less than minimal required count: 1 < 2"),
+			wantErr: errors.New("subnets in localZone locale, but availabilityZone locale is required"),
 		},
 		{
-			name: "ALB with one Outpost subnet",
+			name: "NLB with one Local Zone subnet",
 			fields: fields{
 				vpcID:       "vpc-1",
 				clusterName: "kube-cluster",
@@ -812,29 +1285,27 @@ func Test_defaultSubnetsResolver_ResolveViaNameOrIDSlice(t *testing.T) {
 						output: []*ec2sdk.Subnet{
 							{
 								SubnetId:         awssdk.String("subnet-1"),
-								AvailabilityZone: awssdk.String("us-west-2a"),
+								AvailabilityZone: awssdk.String("us-west-2-lax-1a"),
 								VpcId:            awssdk.String("vpc-1"),
-								OutpostArn:       awssdk.String("outpost-xxx"),
 							},
 						},
 					},
 				},
+				zones: []*ec2sdk.AvailabilityZone{
+					{
+						ZoneName: awssdk.String("us-west-2-lax-1a"),
+						ZoneType: awssdk.String("local-zone"),
+					},
+				},
 			},
 			args: args{
 				subnetNameOrIDs: []string{"subnet-1"},
 				opts: []SubnetsResolveOption{
-					WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeApplication),
+					WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeNetwork),
 					WithSubnetsResolveLBScheme(elbv2model.LoadBalancerSchemeInternal),
 				},
 			},
-			want: []*ec2sdk.Subnet{
-				{
-					SubnetId:         awssdk.String("subnet-1"),
-					AvailabilityZone: awssdk.String("us-west-2a"),
-					VpcId:            awssdk.String("vpc-1"),
-					OutpostArn:       awssdk.String("outpost-xxx"),
-				},
-			},
+			wantErr: errors.New("subnets in localZone locale are not supported by network Load Balancers"),
 		},
 		{
 			name: "NLB with one availabilityZone subnet",
@@ -882,12 +1353,17 @@ func Test_defaultSubnetsResolver_ResolveViaNameOrIDSlice(t *testing.T) {
 			for _, call := range tt.fields.describeSubnetsAsListCalls {
 				ec2Client.EXPECT().DescribeSubnetsAsList(gomock.Any(), call.input).Return(call.output, call.err)
 			}
+			ec2Client.EXPECT().DescribeAvailabilityZonesWithContext(gomock.Any(), gomock.Any()).Return(&ec2sdk.DescribeAvailabilityZonesOutput{
+				AvailabilityZones: tt.fields.zones,
+			}, nil).AnyTimes()
 
 			r := &defaultSubnetsResolver{
-				ec2Client:   ec2Client,
-				vpcID:       tt.fields.vpcID,
-				clusterName: tt.fields.clusterName,
-				logger:      &log.NullLogger{},
+				ec2Client:         ec2Client,
+				vpcID:             tt.fields.vpcID,
+				clusterName:       tt.fields.clusterName,
+				logger:            &log.NullLogger{},
+				zoneTypesCache:    cache.NewExpiring(),
+				zoneTypesCacheTTL: defaultZoneTypesCacheTTL,
 			}
 			got, err := r.ResolveViaNameOrIDSlice(context.Background(), tt.args.subnetNameOrIDs, tt.args.opts...)
 			if tt.wantErr != nil {
@@ -905,12 +1381,13 @@ func Test_defaultSubnetsResolver_ResolveViaNameOrIDSlice(t *testing.T) {
 
 func Test_buildSDKSubnetLocaleType(t *testing.T) {
 	type args struct {
-		subnet *ec2sdk.Subnet
+		subnet         *ec2sdk.Subnet
+		zoneTypeByName map[string]string
 	}
 	tests := []struct {
 		name string
 		args args
-		want subnetLocaleType
+		want SubnetLocaleType
 	}{
 		{
 			name: "availbilityZone subnet",
@@ -921,7 +1398,7 @@ func Test_buildSDKSubnetLocaleType(t *testing.T) {
 					VpcId:            awssdk.String("vpc-1"),
 				},
 			},
-			want: subnetLocaleTypeAvailabilityZone,
+			want: SubnetLocaleTypeAvailabilityZone,
 		},
 		{
 			name: "outpost subnet",
@@ -933,14 +1410,306 @@ func Test_buildSDKSubnetLocaleType(t *testing.T) {
 					OutpostArn:       awssdk.String("outpost-xxx"),
 				},
 			},
-			want: subnetLocaleTypeOutpost,
+			want: SubnetLocaleTypeOutpost,
+		},
+		{
+			name: "localZone subnet",
+			args: args{
+				subnet: &ec2sdk.Subnet{
+					SubnetId:         awssdk.String("subnet-1"),
+					AvailabilityZone: awssdk.String("us-west-2-lax-1a"),
+					VpcId:            awssdk.String("vpc-1"),
+				},
+				zoneTypeByName: map[string]string{
+					"us-west-2-lax-1a": "local-zone",
+				},
+			},
+			want: SubnetLocaleTypeLocalZone,
+		},
+		{
+			name: "wavelengthZone subnet",
+			args: args{
+				subnet: &ec2sdk.Subnet{
+					SubnetId:         awssdk.String("subnet-1"),
+					AvailabilityZone: awssdk.String("us-west-2-wl1-sea-wlz-1"),
+					VpcId:            awssdk.String("vpc-1"),
+				},
+				zoneTypeByName: map[string]string{
+					"us-west-2-wl1-sea-wlz-1": "wavelength-zone",
+				},
+			},
+			want: SubnetLocaleTypeWavelengthZone,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := buildSDKSubnetLocaleType(tt.args.subnet); got != tt.want {
+			if got := buildSDKSubnetLocaleType(tt.args.subnet, tt.args.zoneTypeByName); got != tt.want {
 				t.Errorf("buildSDKSubnetLocaleType() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func Test_defaultSubnetsResolver_ResolveViaSelector(t *testing.T) {
+	type describeSubnetsAsListCall struct {
+		input  *ec2sdk.DescribeSubnetsInput
+		output []*ec2sdk.Subnet
+		err    error
+	}
+	type fields struct {
+		vpcID                      string
+		clusterName                string
+		describeSubnetsAsListCalls []describeSubnetsAsListCall
+	}
+	type args struct {
+		selector map[string][]string
+		opts     []SubnetsResolveOption
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []*ec2sdk.Subnet
+		wantErr error
+	}{
+		{
+			name: "ALB with tag values and tag keys",
+			fields: fields{
+				vpcID:       "vpc-1",
+				clusterName: "kube-cluster",
+				describeSubnetsAsListCalls: []describeSubnetsAsListCall{
+					{
+						input: &ec2sdk.DescribeSubnetsInput{
+							Filters: []*ec2sdk.Filter{
+								{
+									Name:   awssdk.String("tag-key"),
+									Values: awssdk.StringSlice([]string{"ingress"}),
+								},
+								{
+									Name:   awssdk.String("tag:tenant"),
+									Values: awssdk.StringSlice([]string{"team-a", "team-b"}),
+								},
+								{
+									Name:   awssdk.String("vpc-id"),
+									Values: awssdk.StringSlice([]string{"vpc-1"}),
+								},
+							},
+						},
+						output: []*ec2sdk.Subnet{
+							{
+								SubnetId:         awssdk.String("subnet-1"),
+								AvailabilityZone: awssdk.String("us-west-2a"),
+								VpcId:            awssdk.String("vpc-1"),
+							},
+							{
+								SubnetId:         awssdk.String("subnet-2"),
+								AvailabilityZone: awssdk.String("us-west-2b"),
+								VpcId:            awssdk.String("vpc-1"),
+							},
+							{
+								SubnetId:         awssdk.String("subnet-3"),
+								AvailabilityZone: awssdk.String("us-west-2b"),
+								VpcId:            awssdk.String("vpc-1"),
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				selector: map[string][]string{
+					"tenant":  {"team-a", "team-b"},
+					"ingress": nil,
+				},
+				opts: []SubnetsResolveOption{
+					WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeApplication),
+					WithSubnetsResolveLBScheme(elbv2model.LoadBalancerSchemeInternal),
+				},
+			},
+			want: []*ec2sdk.Subnet{
+				{
+					SubnetId:         awssdk.String("subnet-1"),
+					AvailabilityZone: awssdk.String("us-west-2a"),
+					VpcId:            awssdk.String("vpc-1"),
+				},
+				{
+					SubnetId:         awssdk.String("subnet-2"),
+					AvailabilityZone: awssdk.String("us-west-2b"),
+					VpcId:            awssdk.String("vpc-1"),
+				},
+			},
+		},
+		{
+			name: "ALB with not enough matching subnets",
+			fields: fields{
+				vpcID:       "vpc-1",
+				clusterName: "kube-cluster",
+				describeSubnetsAsListCalls: []describeSubnetsAsListCall{
+					{
+						input: &ec2sdk.DescribeSubnetsInput{
+							Filters: []*ec2sdk.Filter{
+								{
+									Name:   awssdk.String("tag:tenant"),
+									Values: awssdk.StringSlice([]string{"team-a"}),
+								},
+								{
+									Name:   awssdk.String("vpc-id"),
+									Values: awssdk.StringSlice([]string{"vpc-1"}),
+								},
+							},
+						},
+						output: []*ec2sdk.Subnet{
+							{
+								SubnetId:         awssdk.String("subnet-1"),
+								AvailabilityZone: awssdk.String("us-west-2a"),
+								VpcId:            awssdk.String("vpc-1"),
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				selector: map[string][]string{
+					"tenant": {"team-a"},
+				},
+				opts: []SubnetsResolveOption{
+					WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeApplication),
+					WithSubnetsResolveLBScheme(elbv2model.LoadBalancerSchemeInternal),
+				},
+			},
+			wantErr: errors.New("subnets count less than minimal required count: 1 < 2"),
+		},
+		{
+			name: "empty selector",
+			fields: fields{
+				vpcID:       "vpc-1",
+				clusterName: "kube-cluster",
+			},
+			args: args{
+				selector: map[string][]string{},
+			},
+			wantErr: errors.New("subnet selector must contain at least one tag"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ec2Client := mock_services.NewMockEC2(ctrl)
+			for _, call := range tt.fields.describeSubnetsAsListCalls {
+				ec2Client.EXPECT().DescribeSubnetsAsList(gomock.Any(), call.input).Return(call.output, call.err)
+			}
+			ec2Client.EXPECT().DescribeAvailabilityZonesWithContext(gomock.Any(), gomock.Any()).Return(&ec2sdk.DescribeAvailabilityZonesOutput{}, nil).AnyTimes()
+
+			r := NewDefaultSubnetsResolver(ec2Client, tt.fields.vpcID, tt.fields.clusterName, &log.NullLogger{})
+			got, err := r.ResolveViaSelector(context.Background(), tt.args.selector, tt.args.opts...)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				opts := cmpopts.SortSlices(func(lhs *ec2sdk.Subnet, rhs *ec2sdk.Subnet) bool {
+					return awssdk.StringValue(lhs.SubnetId) < awssdk.StringValue(rhs.SubnetId)
+				})
+				assert.True(t, cmp.Equal(tt.want, got, opts), "diff", cmp.Diff(tt.want, got, opts))
+			}
+		})
+	}
+}
+
+func Test_defaultSubnetsResolver_fetchZoneTypes(t *testing.T) {
+	subnets := []*ec2sdk.Subnet{
+		{
+			SubnetId:         awssdk.String("subnet-1"),
+			AvailabilityZone: awssdk.String("us-west-2a"),
+		},
+		{
+			SubnetId:         awssdk.String("subnet-2"),
+			AvailabilityZone: awssdk.String("us-west-2-lax-1a"),
+		},
+	}
+	tests := []struct {
+		name    string
+		zones   []*ec2sdk.AvailabilityZone
+		zoneErr error
+		want    map[string]string
+	}{
+		{
+			name: "zone types retrieved",
+			zones: []*ec2sdk.AvailabilityZone{
+				{
+					ZoneName: awssdk.String("us-west-2a"),
+					ZoneType: awssdk.String("availability-zone"),
+				},
+				{
+					ZoneName: awssdk.String("us-west-2-lax-1a"),
+					ZoneType: awssdk.String("local-zone"),
+				},
+			},
+			want: map[string]string{
+				"us-west-2a":       "availability-zone",
+				"us-west-2-lax-1a": "local-zone",
+			},
+		},
+		{
+			name:    "zone types cannot be retrieved",
+			zoneErr: errors.New("UnauthorizedOperation"),
+			want:    map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ec2Client := mock_services.NewMockEC2(ctrl)
+			ec2Client.EXPECT().DescribeAvailabilityZonesWithContext(gomock.Any(), gomock.Any()).Return(&ec2sdk.DescribeAvailabilityZonesOutput{
+				AvailabilityZones: tt.zones,
+			}, tt.zoneErr)
+
+			r := &defaultSubnetsResolver{
+				ec2Client:         ec2Client,
+				logger:            &log.NullLogger{},
+				zoneTypesCache:    cache.NewExpiring(),
+				zoneTypesCacheTTL: defaultZoneTypesCacheTTL,
+			}
+			got := r.fetchZoneTypes(context.Background(), subnets)
+			assert.Equal(t, tt.want, got)
+			for _, subnet := range subnets {
+				if _, ok := tt.want[awssdk.StringValue(subnet.AvailabilityZone)]; !ok {
+					assert.Equal(t, SubnetLocaleTypeAvailabilityZone, buildSDKSubnetLocaleType(subnet, got))
+				}
+			}
+		})
+	}
+}
+
+func TestSplitSubnetTagValues(t *testing.T) {
+	tests := []struct {
+		name         string
+		rawTagValues string
+		want         []string
+	}{
+		{
+			name:         "single value",
+			rawTagValues: "shared",
+			want:         []string{"shared"},
+		},
+		{
+			name:         "multiple values are trimmed and sorted",
+			rawTagValues: "web | app||",
+			want:         []string{"app", "web"},
+		},
+		{
+			name:         "empty value",
+			rawTagValues: "",
+			want:         nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SplitSubnetTagValues(tt.rawTagValues)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
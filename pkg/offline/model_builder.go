@@ -0,0 +1,193 @@
+package offline
+
+import (
+	"context"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/quota"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/ingress"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/securitygrouppolicy"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/service"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	ResultKindIngressGroup = "IngressGroup"
+	ResultKindService      = "Service"
+)
+
+// NewScheme constructs the scheme of the Kubernetes objects that model building reads.
+func NewScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = elbv2api.AddToScheme(scheme)
+	return scheme
+}
+
+// Config contains the configuration for offline model building.
+type Config struct {
+	// Name of the Kubernetes cluster
+	ClusterName string
+	// ID of the VPC of the cluster
+	VPCID string
+	// Name of the IngressClass that Ingresses are matched by, matches Ingresses without class if empty
+	IngressClass string
+	// IDs of the subnets that are used whenever subnets would be discovered
+	DiscoveredSubnetIDs []string
+	// Default TargetType of TargetGroups for Ingresses
+	DefaultTargetType elbv2model.TargetType
+	// Whether IPAM addon is enabled, which is required by Services with IPAM pool
+	IPAMEnabled bool
+	// Whether VPC endpoint service addon is enabled, which is required by Services with VPC endpoint service
+	VPCEndpointServiceEnabled bool
+}
+
+// Result is the model stack built for an Ingress group or Service group.
+type Result struct {
+	// Kind is the kind of object that stack is built for, IngressGroup or Service.
+	Kind string
+	// ID is the ID of the Ingress group or Service group.
+	ID core.StackID
+	// Stack is the built model stack, nil if model building failed.
+	Stack core.Stack
+	// Events are the events emitted during model building.
+	Events []string
+	// Err is the error of model building.
+	Err error
+}
+
+// ModelBuilder builds the model stacks of Ingress groups and Services without AWS API calls.
+// It's intended for validating Ingresses and Services before they are applied to the cluster, so the results can differ from
+// the controller's for settings that rely on AWS APIs:
+//   - subnet and securityGroup names are used as their IDs as is.
+//   - discovered subnets are the preconfigured ones.
+//   - certificates cannot be discovered.
+type ModelBuilder interface {
+	// Build builds model stacks for the Ingress groups and Services in objects.
+	// objects should contain every object referenced by the Ingresses and Services, like IngressClasses, IngressClassParams, Services and Secrets.
+	Build(ctx context.Context, objects []runtime.Object) ([]Result, error)
+}
+
+// NewDefaultModelBuilder constructs new defaultModelBuilder.
+func NewDefaultModelBuilder(scheme *runtime.Scheme, config Config, logger logr.Logger) *defaultModelBuilder {
+	return &defaultModelBuilder{
+		scheme: scheme,
+		config: config,
+		logger: logger,
+	}
+}
+
+var _ ModelBuilder = &defaultModelBuilder{}
+
+type defaultModelBuilder struct {
+	scheme *runtime.Scheme
+	config Config
+	logger logr.Logger
+}
+
+func (b *defaultModelBuilder) Build(ctx context.Context, objects []runtime.Object) ([]Result, error) {
+	k8sClient := testclient.NewFakeClientWithScheme(b.scheme)
+	for _, obj := range objects {
+		if err := k8sClient.Create(ctx, obj.DeepCopyObject()); err != nil {
+			return nil, errors.Wrap(err, "failed to load object")
+		}
+	}
+	subnetsResolver := newSubnetsResolver(b.config.DiscoveredSubnetIDs)
+	var results []Result
+
+	// models are built with the built-in defaults, since there is no controller to load dynamic config for.
+	dynamicConfigProvider := config.NewStaticDynamicConfigProvider(config.DynamicConfig{})
+	// quotas are assumed to have their default values, since there is no AWS account to retrieve them from.
+	quotaProvider := quota.NewStaticProvider(nil)
+	ingAnnotationParser := annotations.NewSuffixAnnotationParser(annotations.AnnotationPrefixIngress)
+	ingEventRecorder := &eventRecorder{}
+	groupLoader := ingress.NewDefaultGroupLoader(k8sClient, ingEventRecorder, ingAnnotationParser,
+		k8s.NewDefaultNamespaceMatcher(k8sClient, labels.Everything()), b.config.IngressClass)
+	authConfigBuilder := ingress.NewDefaultAuthConfigBuilder(ingAnnotationParser)
+	enhancedBackendBuilder := ingress.NewDefaultEnhancedBackendBuilder(ingAnnotationParser)
+	ingModelBuilder := ingress.NewDefaultModelBuilder(k8sClient, ingEventRecorder,
+		&acmClient{}, ingAnnotationParser,
+		subnetsResolver, &securityGroupResolver{},
+		authConfigBuilder, enhancedBackendBuilder, quotaProvider, dynamicConfigProvider,
+		b.config.VPCID, b.config.ClusterName, "", b.config.DefaultTargetType, b.logger)
+	visitedGroupIDs := make(map[ingress.GroupID]bool)
+	for _, obj := range objects {
+		ing, ok := obj.(*networking.Ingress)
+		if !ok {
+			continue
+		}
+		groupID, err := groupLoader.FindGroupID(ctx, ing)
+		if err != nil {
+			results = append(results, Result{
+				Kind: ResultKindIngressGroup,
+				ID:   core.StackID(k8s.NamespacedName(ing)),
+				Err:  err,
+			})
+			continue
+		}
+		if groupID == nil || visitedGroupIDs[*groupID] {
+			continue
+		}
+		visitedGroupIDs[*groupID] = true
+		result := Result{
+			Kind: ResultKindIngressGroup,
+			ID:   core.StackID(*groupID),
+		}
+		ingGroup, err := groupLoader.Load(ctx, *groupID)
+		if err == nil {
+			result.Stack, _, err = ingModelBuilder.Build(ctx, ingGroup)
+		}
+		result.Err = err
+		result.Events, ingEventRecorder.events = ingEventRecorder.events, nil
+		results = append(results, result)
+	}
+
+	svcAnnotationParser := annotations.NewSuffixAnnotationParser(annotations.AnnotationPrefixService)
+	svcGroupLoader := service.NewDefaultGroupLoader(k8sClient, svcAnnotationParser, k8s.NewDefaultNamespaceMatcher(k8sClient, labels.Everything()))
+	svcEventRecorder := &eventRecorder{}
+	svcModelBuilder := service.NewDefaultModelBuilder(svcAnnotationParser, subnetsResolver,
+		securitygrouppolicy.NewDefaultPermissionBuilder(k8sClient, svcEventRecorder), svcEventRecorder, quotaProvider, dynamicConfigProvider, b.config.ClusterName, b.config.IPAMEnabled, b.config.VPCEndpointServiceEnabled)
+	visitedSvcGroupIDs := make(map[service.GroupID]bool)
+	for _, obj := range objects {
+		svc, ok := obj.(*corev1.Service)
+		if !ok {
+			continue
+		}
+		svcGroupID, err := svcGroupLoader.FindGroupID(ctx, svc)
+		if err != nil {
+			results = append(results, Result{
+				Kind: ResultKindService,
+				ID:   core.StackID(k8s.NamespacedName(svc)),
+				Err:  err,
+			})
+			continue
+		}
+		if svcGroupID == nil || visitedSvcGroupIDs[*svcGroupID] {
+			continue
+		}
+		visitedSvcGroupIDs[*svcGroupID] = true
+		result := Result{
+			Kind: ResultKindService,
+			ID:   core.StackID(*svcGroupID),
+		}
+		svcGroup, err := svcGroupLoader.Load(ctx, *svcGroupID)
+		if err == nil {
+			result.Stack, _, err = svcModelBuilder.Build(ctx, svcGroup)
+		}
+		result.Err = err
+		result.Events, svcEventRecorder.events = svcEventRecorder.events, nil
+		results = append(results, result)
+	}
+	return results, nil
+}
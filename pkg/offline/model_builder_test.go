@@ -0,0 +1,129 @@
+package offline
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"strings"
+	"testing"
+)
+
+func Test_defaultModelBuilder_Build(t *testing.T) {
+	manifests := `
+apiVersion: networking.k8s.io/v1beta1
+kind: Ingress
+metadata:
+  name: ing-a
+  annotations:
+    kubernetes.io/ingress.class: alb
+    alb.ingress.kubernetes.io/group.name: awesome-group
+    alb.ingress.kubernetes.io/subnets: subnet-a, subnet-b
+spec:
+  backend:
+    serviceName: svc
+    servicePort: 80
+---
+apiVersion: networking.k8s.io/v1beta1
+kind: Ingress
+metadata:
+  name: ing-b
+  annotations:
+    kubernetes.io/ingress.class: alb
+    alb.ingress.kubernetes.io/group.name: awesome-group
+spec:
+  rules:
+  - http:
+      paths:
+      - path: /b
+        backend:
+          serviceName: svc
+          servicePort: 80
+---
+apiVersion: networking.k8s.io/v1beta1
+kind: Ingress
+metadata:
+  name: ing-tls
+  annotations:
+    kubernetes.io/ingress.class: alb
+    alb.ingress.kubernetes.io/listen-ports: '[{"HTTPS": 443}]'
+spec:
+  tls:
+  - hosts:
+    - www.example.com
+  backend:
+    serviceName: svc
+    servicePort: 80
+---
+apiVersion: networking.k8s.io/v1beta1
+kind: Ingress
+metadata:
+  name: ing-other-class
+  annotations:
+    kubernetes.io/ingress.class: nginx
+spec:
+  backend:
+    serviceName: svc
+    servicePort: 80
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: svc
+spec:
+  type: NodePort
+  ports:
+  - port: 80
+    targetPort: 8080
+    nodePort: 32768
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: nlb
+  annotations:
+    service.beta.kubernetes.io/aws-load-balancer-type: nlb-ip
+spec:
+  type: LoadBalancer
+  ports:
+  - port: 80
+    targetPort: 8080
+`
+	scheme := NewScheme()
+	objects, err := LoadObjects(scheme, strings.NewReader(manifests))
+	assert.NoError(t, err)
+	b := NewDefaultModelBuilder(scheme, Config{
+		ClusterName:         "cluster",
+		VPCID:               "vpc-1",
+		IngressClass:        "alb",
+		DiscoveredSubnetIDs: []string{"subnet-1", "subnet-2"},
+		DefaultTargetType:   elbv2model.TargetTypeInstance,
+	}, &log.NullLogger{})
+	results, err := b.Build(context.Background(), objects)
+	assert.NoError(t, err)
+
+	assert.Len(t, results, 3)
+	assert.Equal(t, ResultKindIngressGroup, results[0].Kind)
+	assert.Equal(t, core.StackID{Name: "awesome-group"}, results[0].ID)
+	assert.NoError(t, results[0].Err)
+	var resLBs []*elbv2model.LoadBalancer
+	assert.NoError(t, results[0].Stack.ListResources(&resLBs))
+	assert.Len(t, resLBs, 1)
+	assert.Equal(t, []elbv2model.SubnetMapping{{SubnetID: "subnet-a"}, {SubnetID: "subnet-b"}}, resLBs[0].Spec.SubnetMappings)
+	var resRules []*elbv2model.ListenerRule
+	assert.NoError(t, results[0].Stack.ListResources(&resRules))
+	assert.Len(t, resRules, 1)
+
+	assert.Equal(t, ResultKindIngressGroup, results[1].Kind)
+	assert.Equal(t, core.StackID{Namespace: "default", Name: "ing-tls"}, results[1].ID)
+	assert.EqualError(t, results[1].Err, "ingress: default/ing-tls: "+errCertDiscoveryUnsupported.Error())
+
+	assert.Equal(t, ResultKindService, results[2].Kind)
+	assert.Equal(t, core.StackID{Namespace: "default", Name: "nlb"}, results[2].ID)
+	assert.NoError(t, results[2].Err)
+	resLBs = nil
+	assert.NoError(t, results[2].Stack.ListResources(&resLBs))
+	assert.Len(t, resLBs, 1)
+	assert.Equal(t, []elbv2model.SubnetMapping{{SubnetID: "subnet-1"}, {SubnetID: "subnet-2"}}, resLBs[0].Spec.SubnetMappings)
+}
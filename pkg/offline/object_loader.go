@@ -0,0 +1,114 @@
+package offline
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"io"
+	extensions "k8s.io/api/extensions/v1beta1"
+	networking "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const defaultNamespace = "default"
+
+// LoadObjects decodes Kubernetes objects from YAML or JSON documents in reader, with the types registered in scheme.
+// List objects are expanded into their items, and objects without namespace are placed into the default namespace.
+// Ingresses of extensions/v1beta1 are converted into networking.k8s.io/v1beta1.
+func LoadObjects(scheme *runtime.Scheme, reader io.Reader) ([]runtime.Object, error) {
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+	yamlReader := yaml.NewYAMLReader(bufio.NewReader(reader))
+	var objects []runtime.Object
+	for {
+		doc, err := yamlReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		obj, gvk, err := decoder.Decode(doc, nil, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode object")
+		}
+		items := []runtime.Object{obj}
+		if meta.IsListType(obj) {
+			if items, err = meta.ExtractList(obj); err != nil {
+				return nil, errors.Wrapf(err, "failed to extract items of %v", gvk)
+			}
+			for i, item := range items {
+				if unknown, ok := item.(*runtime.Unknown); ok {
+					if items[i], _, err = decoder.Decode(unknown.Raw, nil, nil); err != nil {
+						return nil, errors.Wrapf(err, "failed to decode item of %v", gvk)
+					}
+				}
+			}
+		}
+		for _, item := range items {
+			if extIng, ok := item.(*extensions.Ingress); ok {
+				if item, err = convertExtensionsIngress(extIng); err != nil {
+					return nil, err
+				}
+			}
+			if err := defaultObjectNamespace(scheme, item); err != nil {
+				return nil, err
+			}
+			objects = append(objects, item)
+		}
+	}
+	return objects, nil
+}
+
+// convertExtensionsIngress converts Ingress of extensions/v1beta1 into networking.k8s.io/v1beta1, which is the version that controller reads.
+// both versions share the same schema.
+func convertExtensionsIngress(extIng *extensions.Ingress) (*networking.Ingress, error) {
+	payload, err := json.Marshal(extIng)
+	if err != nil {
+		return nil, err
+	}
+	ing := &networking.Ingress{}
+	if err := json.Unmarshal(payload, ing); err != nil {
+		return nil, err
+	}
+	ing.TypeMeta = metav1.TypeMeta{
+		APIVersion: networking.SchemeGroupVersion.String(),
+		Kind:       "Ingress",
+	}
+	return ing, nil
+}
+
+// defaultObjectNamespace places namespaced object without namespace into the default namespace.
+func defaultObjectNamespace(scheme *runtime.Scheme, obj runtime.Object) error {
+	metaObj, err := meta.Accessor(obj)
+	if err != nil {
+		return err
+	}
+	if metaObj.GetNamespace() != "" || !isNamespaced(scheme, obj) {
+		return nil
+	}
+	metaObj.SetNamespace(defaultNamespace)
+	return nil
+}
+
+// clusterScopedKinds are the cluster scoped kinds that are relevant to model building.
+var clusterScopedKinds = map[string]bool{
+	"Namespace":          true,
+	"IngressClass":       true,
+	"IngressClassParams": true,
+}
+
+func isNamespaced(scheme *runtime.Scheme, obj runtime.Object) bool {
+	gvks, _, err := scheme.ObjectKinds(obj)
+	if err != nil || len(gvks) == 0 {
+		return true
+	}
+	return !clusterScopedKinds[gvks[0].Kind]
+}
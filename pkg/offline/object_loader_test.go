@@ -0,0 +1,90 @@
+package offline
+
+import (
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"strings"
+	"testing"
+)
+
+func Test_LoadObjects(t *testing.T) {
+	tests := []struct {
+		name      string
+		manifests string
+		want      []metav1.Object
+		wantErr   string
+	}{
+		{
+			name: "multiple documents",
+			manifests: `
+apiVersion: v1
+kind: Service
+metadata:
+  name: svc
+---
+---
+apiVersion: networking.k8s.io/v1beta1
+kind: Ingress
+metadata:
+  namespace: ns
+  name: ing
+---
+apiVersion: elbv2.k8s.aws/v1beta1
+kind: IngressClassParams
+metadata:
+  name: params
+`,
+			want: []metav1.Object{
+				&corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "svc"}},
+				&networking.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "ing"}},
+				&elbv2api.IngressClassParams{ObjectMeta: metav1.ObjectMeta{Name: "params"}},
+			},
+		},
+		{
+			name: "list and extensions Ingress",
+			manifests: `
+apiVersion: v1
+kind: List
+items:
+- apiVersion: extensions/v1beta1
+  kind: Ingress
+  metadata:
+    name: ing
+`,
+			want: []metav1.Object{
+				&networking.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "ing"}},
+			},
+		},
+		{
+			name: "unknown kind",
+			manifests: `
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: widget
+`,
+			wantErr: "failed to decode object",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoadObjects(NewScheme(), strings.NewReader(tt.manifests))
+			if tt.wantErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, got, len(tt.want))
+			for i, obj := range got {
+				assert.IsType(t, tt.want[i], obj)
+				metaObj := obj.(metav1.Object)
+				assert.Equal(t, tt.want[i].GetNamespace(), metaObj.GetNamespace())
+				assert.Equal(t, tt.want[i].GetName(), metaObj.GetName())
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package offline
+
+import (
+	"context"
+	"fmt"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	acmsdk "github.com/aws/aws-sdk-go/service/acm"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+)
+
+var errCertDiscoveryUnsupported = errors.New("certificate discovery requires AWS API calls, specify certificate-arn explicitly")
+
+// newSubnetsResolver constructs new subnetsResolver.
+func newSubnetsResolver(discoveredSubnetIDs []string) *subnetsResolver {
+	return &subnetsResolver{
+		discoveredSubnetIDs: discoveredSubnetIDs,
+	}
+}
+
+var _ networking.SubnetsResolver = &subnetsResolver{}
+
+// subnetsResolver resolves subnets without AWS API calls.
+// subnet names or IDs are used as subnet IDs as is, and discovered subnets are the preconfigured ones.
+type subnetsResolver struct {
+	discoveredSubnetIDs []string
+}
+
+func (r *subnetsResolver) ResolveViaDiscovery(_ context.Context, _ ...networking.SubnetsResolveOption) ([]*ec2sdk.Subnet, error) {
+	return r.buildSubnets(r.discoveredSubnetIDs)
+}
+
+func (r *subnetsResolver) ResolveViaNameOrIDSlice(_ context.Context, subnetNameOrIDs []string, _ ...networking.SubnetsResolveOption) ([]*ec2sdk.Subnet, error) {
+	return r.buildSubnets(subnetNameOrIDs)
+}
+
+func (r *subnetsResolver) ResolveViaSelector(_ context.Context, _ map[string][]string, _ ...networking.SubnetsResolveOption) ([]*ec2sdk.Subnet, error) {
+	return r.buildSubnets(r.discoveredSubnetIDs)
+}
+
+func (r *subnetsResolver) buildSubnets(subnetIDs []string) ([]*ec2sdk.Subnet, error) {
+	if len(subnetIDs) == 0 {
+		return nil, errors.New("unable to discover at least one subnet")
+	}
+	subnets := make([]*ec2sdk.Subnet, 0, len(subnetIDs))
+	for _, subnetID := range subnetIDs {
+		subnets = append(subnets, &ec2sdk.Subnet{
+			SubnetId: awssdk.String(subnetID),
+		})
+	}
+	return subnets, nil
+}
+
+var _ networking.SecurityGroupResolver = &securityGroupResolver{}
+
+// securityGroupResolver resolves securityGroups without AWS API calls, securityGroup names or IDs are used as IDs as is.
+type securityGroupResolver struct{}
+
+func (r *securityGroupResolver) ResolveViaNameOrID(_ context.Context, sgNameOrIDs []string) ([]string, error) {
+	return sgNameOrIDs, nil
+}
+
+var _ services.ACM = &acmClient{}
+
+// acmClient fails certificate discovery, since certificates can only be discovered via AWS API calls.
+// other ACM APIs are never called by model building.
+type acmClient struct {
+	services.ACM
+}
+
+func (c *acmClient) ListCertificatesAsList(_ context.Context, _ *acmsdk.ListCertificatesInput) ([]*acmsdk.CertificateSummary, error) {
+	return nil, errCertDiscoveryUnsupported
+}
+
+// eventRecorder records the events emitted by model building.
+type eventRecorder struct {
+	events []string
+}
+
+func (r *eventRecorder) Event(obj runtime.Object, eventType, reason, message string) {
+	objKey := ""
+	if metaObj, err := meta.Accessor(obj); err == nil {
+		objKey = metaObj.GetNamespace() + "/" + metaObj.GetName()
+	}
+	r.events = append(r.events, fmt.Sprintf("%v %v %v: %v", eventType, reason, objKey, message))
+}
+
+func (r *eventRecorder) Eventf(obj runtime.Object, eventType, reason, messageFmt string, args ...interface{}) {
+	r.Event(obj, eventType, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *eventRecorder) AnnotatedEventf(obj runtime.Object, _ map[string]string, eventType, reason, messageFmt string, args ...interface{}) {
+	r.Eventf(obj, eventType, reason, messageFmt, args...)
+}
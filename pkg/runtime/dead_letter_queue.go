@@ -0,0 +1,105 @@
+package runtime
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
+	"sync"
+	"time"
+)
+
+const (
+	metricSubsystemReconcile        = "reconcile"
+	metricDeadLetteredRequestsTotal = "dead_lettered_requests_total"
+	metricLabelController           = "controller"
+	metricLabelTerminalReason       = "reason"
+	overallReconcileRateLimitQPS    = 10
+	overallReconcileRateLimitBurst  = 100
+)
+
+// DeadLetterQueue tracks consecutive terminal failures of reconcile requests, and dead-letters requests once they reached max terminal failures.
+// Dead-lettered requests should no longer be requeued, they're only reconciled again when their objects change or get resynchronized.
+type DeadLetterQueue interface {
+	// ObserveReconcile records the result of reconcile for request of controller.
+	// It returns whether the request is dead-lettered together with the terminal reason.
+	ObserveReconcile(controllerName string, req ctrl.Request, err error) (string, bool)
+
+	// Forget forgets the terminal failures of request of controller, it should be invoked once the object of request is deleted.
+	Forget(controllerName string, req ctrl.Request)
+}
+
+// NewDefaultDeadLetterQueue constructs new defaultDeadLetterQueue that registers metrics to registerer.
+// Requests are never dead-lettered if maxTerminalFailures is zero.
+func NewDefaultDeadLetterQueue(maxTerminalFailures int, registerer prometheus.Registerer) (*defaultDeadLetterQueue, error) {
+	deadLetteredRequestsTotal := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricSubsystemReconcile,
+		Name:      metricDeadLetteredRequestsTotal,
+		Help:      "Total number of reconcile requests dead-lettered after consecutive terminal failures",
+	}, []string{metricLabelController, metricLabelTerminalReason})
+	if err := registerer.Register(deadLetteredRequestsTotal); err != nil {
+		return nil, err
+	}
+	return &defaultDeadLetterQueue{
+		maxTerminalFailures:       maxTerminalFailures,
+		deadLetteredRequestsTotal: deadLetteredRequestsTotal,
+		terminalFailuresByRequest: make(map[string]int),
+	}, nil
+}
+
+var _ DeadLetterQueue = &defaultDeadLetterQueue{}
+
+// default implementation for DeadLetterQueue.
+type defaultDeadLetterQueue struct {
+	maxTerminalFailures       int
+	deadLetteredRequestsTotal *prometheus.CounterVec
+
+	mutex                     sync.Mutex
+	terminalFailuresByRequest map[string]int
+}
+
+func (q *defaultDeadLetterQueue) ObserveReconcile(controllerName string, req ctrl.Request, err error) (string, bool) {
+	if q.maxTerminalFailures <= 0 {
+		return "", false
+	}
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	requestKey := controllerName + "/" + req.String()
+	reason, terminal := ClassifyTerminalError(err)
+	if !terminal {
+		delete(q.terminalFailuresByRequest, requestKey)
+		return "", false
+	}
+	terminalFailures := q.terminalFailuresByRequest[requestKey]
+	if terminalFailures >= q.maxTerminalFailures {
+		return reason, true
+	}
+	terminalFailures++
+	q.terminalFailuresByRequest[requestKey] = terminalFailures
+	if terminalFailures < q.maxTerminalFailures {
+		return "", false
+	}
+	q.deadLetteredRequestsTotal.With(map[string]string{
+		metricLabelController:     controllerName,
+		metricLabelTerminalReason: reason,
+	}).Inc()
+	return reason, true
+}
+
+func (q *defaultDeadLetterQueue) Forget(controllerName string, req ctrl.Request) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	delete(q.terminalFailuresByRequest, controllerName+"/"+req.String())
+}
+
+// NewReconcileRateLimiter constructs the rate limiter for work queue of controllers.
+// Failing requests are retried with exponential backoff from baseDelay up to maxDelay per request, on top of an overall rate limit.
+func NewReconcileRateLimiter(baseDelay time.Duration, maxDelay time.Duration) ratelimiter.RateLimiter {
+	return workqueue.NewMaxOfRateLimiter(
+		workqueue.NewItemExponentialFailureRateLimiter(baseDelay, maxDelay),
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(overallReconcileRateLimitQPS), overallReconcileRateLimitBurst)},
+	)
+}
@@ -0,0 +1,99 @@
+package runtime
+
+import (
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"testing"
+)
+
+func Test_defaultDeadLetterQueue_ObserveReconcile(t *testing.T) {
+	terminalErr := awserr.New("CertificateNotFound", "Certificate 'arn' not found", nil)
+	retryableErr := errors.New("some error")
+	type observeCall struct {
+		err              error
+		wantReason       string
+		wantDeadLettered bool
+	}
+	tests := []struct {
+		name                string
+		maxTerminalFailures int
+		observeCalls        []observeCall
+		wantDeadLettered    float64
+	}{
+		{
+			name:                "dead-letters after max consecutive terminal failures",
+			maxTerminalFailures: 3,
+			observeCalls: []observeCall{
+				{err: terminalErr},
+				{err: terminalErr},
+				{err: terminalErr, wantReason: "CertificateNotFound", wantDeadLettered: true},
+				{err: terminalErr, wantReason: "CertificateNotFound", wantDeadLettered: true},
+			},
+			wantDeadLettered: 1,
+		},
+		{
+			name:                "retryable failures resets terminal failures",
+			maxTerminalFailures: 2,
+			observeCalls: []observeCall{
+				{err: terminalErr},
+				{err: retryableErr},
+				{err: terminalErr},
+				{err: nil},
+				{err: terminalErr},
+			},
+			wantDeadLettered: 0,
+		},
+		{
+			name:                "success resumes dead-lettered request",
+			maxTerminalFailures: 1,
+			observeCalls: []observeCall{
+				{err: terminalErr, wantReason: "CertificateNotFound", wantDeadLettered: true},
+				{err: nil},
+				{err: terminalErr, wantReason: "CertificateNotFound", wantDeadLettered: true},
+			},
+			wantDeadLettered: 2,
+		},
+		{
+			name:                "never dead-letters when disabled",
+			maxTerminalFailures: 0,
+			observeCalls: []observeCall{
+				{err: terminalErr},
+				{err: terminalErr},
+			},
+			wantDeadLettered: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := NewDefaultDeadLetterQueue(tt.maxTerminalFailures, prometheus.NewRegistry())
+			assert.NoError(t, err)
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "awesome-ns", Name: "awesome-svc"}}
+			for _, call := range tt.observeCalls {
+				gotReason, gotDeadLettered := q.ObserveReconcile("service", req, call.err)
+				assert.Equal(t, call.wantReason, gotReason)
+				assert.Equal(t, call.wantDeadLettered, gotDeadLettered)
+			}
+			assert.Equal(t, tt.wantDeadLettered, testutil.ToFloat64(q.deadLetteredRequestsTotal.WithLabelValues("service", "CertificateNotFound")))
+		})
+	}
+}
+
+func Test_defaultDeadLetterQueue_Forget(t *testing.T) {
+	terminalErr := awserr.New("CertificateNotFound", "Certificate 'arn' not found", nil)
+	q, err := NewDefaultDeadLetterQueue(2, prometheus.NewRegistry())
+	assert.NoError(t, err)
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "awesome-ns", Name: "awesome-svc"}}
+	otherReq := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "awesome-ns", Name: "other-svc"}}
+	q.ObserveReconcile("service", req, terminalErr)
+	q.ObserveReconcile("service", otherReq, terminalErr)
+
+	q.Forget("service", req)
+	assert.Equal(t, map[string]int{"service/awesome-ns/other-svc": 1}, q.terminalFailuresByRequest)
+	_, deadLettered := q.ObserveReconcile("service", req, terminalErr)
+	assert.False(t, deadLettered)
+}
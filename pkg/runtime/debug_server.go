@@ -0,0 +1,104 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"time"
+)
+
+const (
+	// the path of reconcile introspection endpoint.
+	debugReconcilesPath = "/debug/reconciles"
+	// the timeout to finish in-flight debug requests on shutdown, profiles are cut short.
+	debugServerShutdownTimeout = 5 * time.Second
+	// the host debug server binds to when bind address doesn't specify one.
+	debugServerDefaultHost = "127.0.0.1"
+)
+
+// NewDebugServer constructs new debugServer that serves pprof and reconcile introspection on bindAddress.
+// The debug endpoints are unauthenticated, so bindAddress without host like ":6060" binds to localhost only.
+func NewDebugServer(bindAddress string, introspector ReconcileIntrospector, logger logr.Logger) *debugServer {
+	return &debugServer{
+		bindAddress:  defaultDebugBindHost(bindAddress),
+		introspector: introspector,
+		logger:       logger,
+	}
+}
+
+var _ manager.Runnable = &debugServer{}
+var _ manager.LeaderElectionRunnable = &debugServer{}
+
+// debugServer serves the debug endpoints of controller, it runs on every replica regardless of leader election.
+type debugServer struct {
+	bindAddress  string
+	introspector ReconcileIntrospector
+	logger       logr.Logger
+}
+
+// Start serves the debug endpoints until stop is closed.
+func (s *debugServer) Start(stop <-chan struct{}) error {
+	listener, err := net.Listen("tcp", s.bindAddress)
+	if err != nil {
+		return errors.Wrapf(err, "failed to listen on debug bind address: %v", s.bindAddress)
+	}
+	server := &http.Server{Handler: s.handler()}
+	go func() {
+		<-stop
+		ctx, cancel := context.WithTimeout(context.Background(), debugServerShutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(ctx); err != nil {
+			s.logger.Error(err, "failed to shutdown debug server")
+		}
+	}()
+	s.logger.Info("serving debug endpoints", "address", listener.Addr().String())
+	if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// defaultDebugBindHost returns bindAddress with debugServerDefaultHost if it doesn't specify a host.
+// invalid addresses are returned as is, so that they fail when debug server starts listening.
+func defaultDebugBindHost(bindAddress string) string {
+	host, port, err := net.SplitHostPort(bindAddress)
+	if err != nil || host != "" {
+		return bindAddress
+	}
+	return net.JoinHostPort(debugServerDefaultHost, port)
+}
+
+func (s *debugServer) NeedLeaderElection() bool {
+	return false
+}
+
+func (s *debugServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.HandleFunc(debugReconcilesPath, s.serveReconciles)
+	return mux
+}
+
+// serveReconciles responds with the state of reconciles for each controller in JSON.
+func (s *debugServer) serveReconciles(w http.ResponseWriter, _ *http.Request) {
+	introspections, err := s.introspector.Introspect()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(introspections); err != nil {
+		s.logger.Error(err, "failed to write reconcile introspection")
+	}
+}
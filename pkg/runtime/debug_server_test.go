@@ -0,0 +1,74 @@
+package runtime
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+	"net/http"
+	"net/http/httptest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+	"time"
+)
+
+func Test_debugServer_handler(t *testing.T) {
+	introspector := NewDefaultReconcileIntrospector(prometheus.NewRegistry())
+	introspector.now = func() time.Time { return time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC) }
+	_ = introspector.StartReconcile("ingress", ctrl.Request{NamespacedName: types.NamespacedName{Name: "awesome-group"}})
+	s := NewDebugServer(":0", introspector, &log.NullLogger{})
+	handler := s.handler()
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/reconciles", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	assert.JSONEq(t, `[
+  {
+    "controller": "ingress",
+    "queueDepth": 0,
+    "inFlightReconciles": [
+      {
+        "request": "/awesome-group",
+        "startTime": "2020-10-01T00:00:00Z",
+        "duration": "0s"
+      }
+    ],
+    "lastErrors": []
+  }
+]`, recorder.Body.String())
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/pprof/", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func Test_defaultDebugBindHost(t *testing.T) {
+	tests := []struct {
+		name        string
+		bindAddress string
+		want        string
+	}{
+		{
+			name:        "port only",
+			bindAddress: ":6060",
+			want:        "127.0.0.1:6060",
+		},
+		{
+			name:        "host and port",
+			bindAddress: "0.0.0.0:6060",
+			want:        "0.0.0.0:6060",
+		},
+		{
+			name:        "invalid address",
+			bindAddress: "6060",
+			want:        "6060",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := defaultDebugBindHost(tt.bindAddress)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package runtime
+
+import (
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"strings"
+)
+
+const (
+	metricReconcileErrorsTotal = "errors_total"
+	metricLabelErrorClass      = "error_class"
+)
+
+// ErrorClass is the class of reconcile errors, errors of different classes need different remediation.
+type ErrorClass string
+
+const (
+	// ErrorClassQuotaExceeded is the class of errors due to exhausted quotas or limits, e.g. LoadBalancers per Region.
+	ErrorClassQuotaExceeded ErrorClass = "QuotaExceeded"
+	// ErrorClassPermissionDenied is the class of errors due to missing IAM permissions of the controller.
+	ErrorClassPermissionDenied ErrorClass = "PermissionDenied"
+	// ErrorClassDependencyViolation is the class of errors due to resources still in use by others, e.g. SecurityGroups referenced by ENIs.
+	ErrorClassDependencyViolation ErrorClass = "DependencyViolation"
+	// ErrorClassInvalidConfig is the class of errors due to invalid configuration of Kubernetes objects.
+	ErrorClassInvalidConfig ErrorClass = "InvalidConfig"
+	// ErrorClassUnknown is the class of errors that aren't classified.
+	ErrorClassUnknown ErrorClass = "Unknown"
+)
+
+// AWS error codes by error class, error codes that are not listed are matched by prefix or suffix in ClassifyError.
+var errorClassByAWSErrorCode = map[string]ErrorClass{
+	"AuthFailure":           ErrorClassPermissionDenied,
+	"UnauthorizedOperation": ErrorClassPermissionDenied,
+
+	"DependencyViolation": ErrorClassDependencyViolation,
+	"InvalidGroup.InUse":  ErrorClassDependencyViolation,
+	"ResourceInUse":       ErrorClassDependencyViolation,
+
+	"CertificateNotFound":         ErrorClassInvalidConfig,
+	"InvalidConfigurationRequest": ErrorClassInvalidConfig,
+	"InvalidSecurityGroup":        ErrorClassInvalidConfig,
+	"InvalidSubnet":               ErrorClassInvalidConfig,
+	"SSLPolicyNotFound":           ErrorClassInvalidConfig,
+	"UnsupportedProtocol":         ErrorClassInvalidConfig,
+	"ValidationError":             ErrorClassInvalidConfig,
+
+	// RequestLimitExceeded is the throttling error of EC2, which resolves by retry.
+	"RequestLimitExceeded": ErrorClassUnknown,
+}
+
+// errorClassifier is implemented by errors that know their class.
+type errorClassifier interface {
+	// ErrorClass returns the class of the error.
+	ErrorClass() ErrorClass
+}
+
+// NewClassifiedError constructs new ClassifiedError to attach the error class to err.
+func NewClassifiedError(class ErrorClass, err error) *ClassifiedError {
+	return &ClassifiedError{
+		class: class,
+		err:   err,
+	}
+}
+
+var _ error = &ClassifiedError{}
+
+// An error of known class, its message is the message of the underlying error.
+type ClassifiedError struct {
+	class ErrorClass
+	err   error
+}
+
+func (e *ClassifiedError) ErrorClass() ErrorClass {
+	return e.class
+}
+
+func (e *ClassifiedError) Unwrap() error {
+	return e.err
+}
+
+func (e *ClassifiedError) Error() string {
+	return e.err.Error()
+}
+
+// ClassifyError returns the class of err, or empty string if err isn't a failure, i.e. it's nil or only requests requeue.
+// Errors that know their class are classified first, then AWS errors are classified by their error code.
+// Other terminal errors are considered InvalidConfig, since they won't resolve until the Kubernetes object is changed.
+func ClassifyError(err error) ErrorClass {
+	if err == nil {
+		return ""
+	}
+	var requeueNeeded *RequeueNeeded
+	var requeueNeededAfter *RequeueNeededAfter
+	if errors.As(err, &requeueNeeded) || errors.As(err, &requeueNeededAfter) {
+		return ""
+	}
+	var classifier errorClassifier
+	if errors.As(err, &classifier) {
+		return classifier.ErrorClass()
+	}
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		return classifyAWSErrorCode(awsErr.Code())
+	}
+	var reasoner terminalReasoner
+	if errors.As(err, &reasoner) {
+		return ErrorClassInvalidConfig
+	}
+	return ErrorClassUnknown
+}
+
+// classifyAWSErrorCode returns the class of AWS error code.
+func classifyAWSErrorCode(code string) ErrorClass {
+	if class, ok := errorClassByAWSErrorCode[code]; ok {
+		return class
+	}
+	switch {
+	case strings.HasPrefix(code, "TooMany"), strings.HasSuffix(code, "LimitExceeded"):
+		return ErrorClassQuotaExceeded
+	case strings.HasPrefix(code, "AccessDenied"):
+		return ErrorClassPermissionDenied
+	case strings.HasPrefix(code, "InvalidParameter"):
+		return ErrorClassInvalidConfig
+	}
+	return ErrorClassUnknown
+}
+
+// ErrorClassCollector counts failed reconciles of controllers by error class.
+type ErrorClassCollector interface {
+	// ObserveReconcile records the result of reconcile of controller, it returns the class of err.
+	ObserveReconcile(controllerName string, err error) ErrorClass
+}
+
+// NewDefaultErrorClassCollector constructs new defaultErrorClassCollector that registers metrics to registerer.
+func NewDefaultErrorClassCollector(registerer prometheus.Registerer) (*defaultErrorClassCollector, error) {
+	reconcileErrorsTotal := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricSubsystemReconcile,
+		Name:      metricReconcileErrorsTotal,
+		Help:      "Total number of failed reconciles by error class",
+	}, []string{metricLabelController, metricLabelErrorClass})
+	if err := registerer.Register(reconcileErrorsTotal); err != nil {
+		return nil, err
+	}
+	return &defaultErrorClassCollector{
+		reconcileErrorsTotal: reconcileErrorsTotal,
+	}, nil
+}
+
+var _ ErrorClassCollector = &defaultErrorClassCollector{}
+
+// default implementation for ErrorClassCollector.
+type defaultErrorClassCollector struct {
+	reconcileErrorsTotal *prometheus.CounterVec
+}
+
+func (c *defaultErrorClassCollector) ObserveReconcile(controllerName string, err error) ErrorClass {
+	class := ClassifyError(err)
+	if class == "" {
+		return ""
+	}
+	c.reconcileErrorsTotal.With(map[string]string{
+		metricLabelController: controllerName,
+		metricLabelErrorClass: string(class),
+	}).Inc()
+	return class
+}
@@ -0,0 +1,110 @@
+package runtime
+
+import (
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want ErrorClass
+	}{
+		{
+			name: "nil error",
+			err:  nil,
+			want: "",
+		},
+		{
+			name: "requeue needed",
+			err:  NewRequeueNeeded("some reason"),
+			want: "",
+		},
+		{
+			name: "requeue needed after wrapped",
+			err:  errors.Wrap(NewRequeueNeededAfter("some reason", time.Second), "some error"),
+			want: "",
+		},
+		{
+			name: "unknown error",
+			err:  errors.New("some error"),
+			want: ErrorClassUnknown,
+		},
+		{
+			name: "classified error wrapped",
+			err:  errors.Wrap(NewClassifiedError(ErrorClassDependencyViolation, errors.New("some error")), "failed to delete securityGroup"),
+			want: ErrorClassDependencyViolation,
+		},
+		{
+			name: "classified error within terminal error",
+			err:  NewTerminalError("MaxTargetsExceeded", NewClassifiedError(ErrorClassQuotaExceeded, errors.New("some error"))),
+			want: ErrorClassQuotaExceeded,
+		},
+		{
+			name: "terminal error",
+			err:  NewTerminalError("InvalidAnnotation", errors.New("some error")),
+			want: ErrorClassInvalidConfig,
+		},
+		{
+			name: "AWS access denied error",
+			err:  errors.Wrap(awserr.New("AccessDenied", "User is not authorized", nil), "failed to create loadBalancer"),
+			want: ErrorClassPermissionDenied,
+		},
+		{
+			name: "AWS unauthorized operation error",
+			err:  awserr.New("UnauthorizedOperation", "You are not authorized to perform this operation", nil),
+			want: ErrorClassPermissionDenied,
+		},
+		{
+			name: "AWS too many error",
+			err:  awserr.New("TooManyLoadBalancers", "Exceeded quota of account", nil),
+			want: ErrorClassQuotaExceeded,
+		},
+		{
+			name: "AWS limit exceeded error",
+			err:  awserr.New("RulesPerSecurityGroupLimitExceeded", "The maximum number of rules per security group has been reached", nil),
+			want: ErrorClassQuotaExceeded,
+		},
+		{
+			name: "AWS throttling error",
+			err:  awserr.New("RequestLimitExceeded", "Request limit exceeded", nil),
+			want: ErrorClassUnknown,
+		},
+		{
+			name: "AWS dependency violation error",
+			err:  awserr.New("DependencyViolation", "resource sg-xxx has a dependent object", nil),
+			want: ErrorClassDependencyViolation,
+		},
+		{
+			name: "AWS invalid parameter error",
+			err:  awserr.New("InvalidParameterValue", "Invalid value for portRange", nil),
+			want: ErrorClassInvalidConfig,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ClassifyError(tt.err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_defaultErrorClassCollector_ObserveReconcile(t *testing.T) {
+	c, err := NewDefaultErrorClassCollector(prometheus.NewRegistry())
+	assert.NoError(t, err)
+
+	assert.Equal(t, ErrorClassPermissionDenied, c.ObserveReconcile("ingress", awserr.New("AccessDenied", "User is not authorized", nil)))
+	assert.Equal(t, ErrorClassPermissionDenied, c.ObserveReconcile("ingress", awserr.New("UnauthorizedOperation", "You are not authorized", nil)))
+	assert.Equal(t, ErrorClassQuotaExceeded, c.ObserveReconcile("service", awserr.New("TooManyLoadBalancers", "Exceeded quota of account", nil)))
+	assert.Equal(t, ErrorClass(""), c.ObserveReconcile("service", nil))
+	assert.Equal(t, ErrorClass(""), c.ObserveReconcile("service", NewRequeueNeeded("some reason")))
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(c.reconcileErrorsTotal.WithLabelValues("ingress", "PermissionDenied")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.reconcileErrorsTotal.WithLabelValues("service", "QuotaExceeded")))
+}
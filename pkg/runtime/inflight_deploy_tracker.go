@@ -0,0 +1,120 @@
+package runtime
+
+import (
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrShuttingDown is returned when deployments are refused because the controller is shutting down.
+var ErrShuttingDown = errors.New("controller is shutting down")
+
+// InFlightDeployTracker tracks in-flight stack deployments of reconcile requests,
+// so that the controller can let them finish instead of abandoning half-deployed stacks upon shutdown.
+type InFlightDeployTracker interface {
+	// TrackDeploy records the deployment for req of controller as in-flight, it returns a func to record the deployment finished.
+	// It returns ErrShuttingDown once draining started, new deployments shouldn't be started then.
+	TrackDeploy(controllerName string, req ctrl.Request) (func(), error)
+
+	// Drain refuses new deployments and waits until in-flight deployments finished or timeout elapsed.
+	// It returns the requests whose deployments are still in-flight by controller name.
+	Drain(timeout time.Duration) map[string][]ctrl.Request
+}
+
+// NewDefaultInFlightDeployTracker constructs new defaultInFlightDeployTracker.
+func NewDefaultInFlightDeployTracker() *defaultInFlightDeployTracker {
+	return &defaultInFlightDeployTracker{
+		inFlightDeploys: make(map[inFlightDeployKey]int),
+		finishedChan:    make(chan struct{}, 1),
+	}
+}
+
+var _ InFlightDeployTracker = &defaultInFlightDeployTracker{}
+
+// inFlightDeployKey identifies the deployments for request of controller.
+type inFlightDeployKey struct {
+	controllerName string
+	req            ctrl.Request
+}
+
+// default implementation for InFlightDeployTracker.
+type defaultInFlightDeployTracker struct {
+	mutex    sync.Mutex
+	draining bool
+	// inFlightDeploys counts the in-flight deployments per request of controller.
+	inFlightDeploys map[inFlightDeployKey]int
+	// finishedChan is signaled whenever a deployment finished.
+	finishedChan chan struct{}
+}
+
+func (t *defaultInFlightDeployTracker) TrackDeploy(controllerName string, req ctrl.Request) (func(), error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.draining {
+		return nil, ErrShuttingDown
+	}
+	key := inFlightDeployKey{controllerName: controllerName, req: req}
+	t.inFlightDeploys[key]++
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			t.finishDeploy(key)
+		})
+	}, nil
+}
+
+func (t *defaultInFlightDeployTracker) Drain(timeout time.Duration) map[string][]ctrl.Request {
+	t.mutex.Lock()
+	t.draining = true
+	t.mutex.Unlock()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		t.mutex.Lock()
+		if len(t.inFlightDeploys) == 0 {
+			t.mutex.Unlock()
+			return nil
+		}
+		t.mutex.Unlock()
+		select {
+		case <-t.finishedChan:
+		case <-timer.C:
+			return t.inFlightRequests()
+		}
+	}
+}
+
+func (t *defaultInFlightDeployTracker) finishDeploy(key inFlightDeployKey) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.inFlightDeploys[key]--
+	if t.inFlightDeploys[key] <= 0 {
+		delete(t.inFlightDeploys, key)
+	}
+	select {
+	case t.finishedChan <- struct{}{}:
+	default:
+	}
+}
+
+// inFlightRequests returns the requests with in-flight deployments by controller name, sorted by request.
+func (t *defaultInFlightDeployTracker) inFlightRequests() map[string][]ctrl.Request {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if len(t.inFlightDeploys) == 0 {
+		return nil
+	}
+	requestsByController := make(map[string][]ctrl.Request)
+	for key := range t.inFlightDeploys {
+		requestsByController[key.controllerName] = append(requestsByController[key.controllerName], key.req)
+	}
+	for _, reqs := range requestsByController {
+		sort.Slice(reqs, func(i, j int) bool {
+			return reqs[i].String() < reqs[j].String()
+		})
+	}
+	return requestsByController
+}
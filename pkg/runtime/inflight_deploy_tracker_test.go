@@ -0,0 +1,54 @@
+package runtime
+
+import (
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"testing"
+	"time"
+)
+
+func Test_defaultInFlightDeployTracker_Drain(t *testing.T) {
+	reqA := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "a"}}
+	reqB := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "b"}}
+
+	t.Run("in-flight deployments finished before timeout", func(t *testing.T) {
+		tracker := NewDefaultInFlightDeployTracker()
+		finishA, err := tracker.TrackDeploy("ingress", reqA)
+		assert.NoError(t, err)
+		finishB, err := tracker.TrackDeploy("service", reqB)
+		assert.NoError(t, err)
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			finishA()
+			// finish funcs are idempotent.
+			finishA()
+			finishB()
+		}()
+		assert.Nil(t, tracker.Drain(time.Minute))
+
+		// new deployments are refused once draining started.
+		_, err = tracker.TrackDeploy("ingress", reqA)
+		assert.Equal(t, ErrShuttingDown, err)
+	})
+
+	t.Run("in-flight deployments interrupted by timeout", func(t *testing.T) {
+		tracker := NewDefaultInFlightDeployTracker()
+		finishA, err := tracker.TrackDeploy("ingress", reqA)
+		assert.NoError(t, err)
+		_, err = tracker.TrackDeploy("ingress", reqB)
+		assert.NoError(t, err)
+		_, err = tracker.TrackDeploy("service", reqA)
+		assert.NoError(t, err)
+		finishA()
+		assert.Equal(t, map[string][]ctrl.Request{
+			"ingress": {reqB},
+			"service": {reqA},
+		}, tracker.Drain(10*time.Millisecond))
+	})
+
+	t.Run("no in-flight deployments", func(t *testing.T) {
+		tracker := NewDefaultInFlightDeployTracker()
+		assert.Nil(t, tracker.Drain(0))
+	})
+}
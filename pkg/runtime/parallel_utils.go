@@ -0,0 +1,152 @@
+package runtime
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"sync"
+)
+
+// ParallelizeUntilError runs fn for each piece in [0, pieces) with at most maxConcurrency pieces running concurrently.
+// Pieces are started in order, no more pieces will be started once any fn returns an error, and the first error is returned.
+func ParallelizeUntilError(ctx context.Context, maxConcurrency int, pieces int, fn func(ctx context.Context, piece int) error) error {
+	if maxConcurrency < 1 {
+		maxConcurrency = 1
+	}
+	if maxConcurrency == 1 {
+		for piece := 0; piece < pieces; piece++ {
+			if err := fn(ctx, piece); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var firstErr error
+	var firstErrOnce sync.Once
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, maxConcurrency)
+	failed := make(chan struct{})
+	for piece := 0; piece < pieces; piece++ {
+		select {
+		case semaphore <- struct{}{}:
+		case <-failed:
+		}
+		select {
+		case <-failed:
+			wg.Wait()
+			return firstErr
+		default:
+		}
+		wg.Add(1)
+		go func(piece int) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			if err := fn(ctx, piece); err != nil {
+				firstErrOnce.Do(func() {
+					firstErr = err
+					close(failed)
+				})
+			}
+		}(piece)
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// TaskGraph is a set of tasks with dependencies between them.
+type TaskGraph struct {
+	taskIDs      []string
+	taskFns      map[string]func(ctx context.Context) error
+	dependencies map[string][]string
+}
+
+// NewTaskGraph constructs new TaskGraph.
+func NewTaskGraph() *TaskGraph {
+	return &TaskGraph{
+		taskFns:      make(map[string]func(ctx context.Context) error),
+		dependencies: make(map[string][]string),
+	}
+}
+
+// AddTask adds a task that only runs after all of its dependencies succeeded.
+func (g *TaskGraph) AddTask(taskID string, fn func(ctx context.Context) error, dependencies ...string) {
+	g.taskIDs = append(g.taskIDs, taskID)
+	g.taskFns[taskID] = fn
+	g.dependencies[taskID] = dependencies
+}
+
+type taskResult struct {
+	taskID string
+	err    error
+}
+
+// Run runs tasks with at most maxConcurrency tasks running concurrently.
+// When multiple tasks are ready, the task added first is started first, so tasks run in the order they are added if maxConcurrency is 1.
+// No more tasks will be started once any task returns an error, and the first error is returned.
+func (g *TaskGraph) Run(ctx context.Context, maxConcurrency int) error {
+	if maxConcurrency < 1 {
+		maxConcurrency = 1
+	}
+	pendingDependencyCount := make(map[string]int, len(g.taskIDs))
+	dependents := make(map[string][]string, len(g.taskIDs))
+	for _, taskID := range g.taskIDs {
+		for _, dependency := range g.dependencies[taskID] {
+			if _, ok := g.taskFns[dependency]; !ok {
+				return errors.Errorf("task %v depends on unknown task %v", taskID, dependency)
+			}
+			pendingDependencyCount[taskID]++
+			dependents[dependency] = append(dependents[dependency], taskID)
+		}
+	}
+
+	started := make(map[string]bool, len(g.taskIDs))
+	results := make(chan taskResult, len(g.taskIDs))
+	running := 0
+	succeeded := 0
+	var firstErr error
+	for {
+		for running < maxConcurrency && firstErr == nil {
+			taskID, ok := g.nextReadyTask(started, pendingDependencyCount)
+			if !ok {
+				break
+			}
+			started[taskID] = true
+			running++
+			go func(taskID string) {
+				results <- taskResult{taskID: taskID, err: g.taskFns[taskID](ctx)}
+			}(taskID)
+		}
+		if running == 0 {
+			break
+		}
+		result := <-results
+		running--
+		if result.err != nil {
+			if firstErr == nil {
+				firstErr = result.err
+			}
+			continue
+		}
+		succeeded++
+		for _, dependent := range dependents[result.taskID] {
+			pendingDependencyCount[dependent]--
+		}
+	}
+	if firstErr != nil {
+		return firstErr
+	}
+	if succeeded != len(g.taskIDs) {
+		return errors.New("tasks contain circular dependencies")
+	}
+	return nil
+}
+
+// nextReadyTask returns the first added task whose dependencies all succeeded.
+func (g *TaskGraph) nextReadyTask(started map[string]bool, pendingDependencyCount map[string]int) (string, bool) {
+	for _, taskID := range g.taskIDs {
+		if !started[taskID] && pendingDependencyCount[taskID] == 0 {
+			return taskID, true
+		}
+	}
+	return "", false
+}
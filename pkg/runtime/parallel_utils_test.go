@@ -0,0 +1,209 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func Test_ParallelizeUntilError(t *testing.T) {
+	tests := []struct {
+		name           string
+		maxConcurrency int
+		pieces         int
+		failedPiece    int
+		wantErr        error
+	}{
+		{
+			name:           "sequentially",
+			maxConcurrency: 1,
+			pieces:         5,
+			failedPiece:    -1,
+		},
+		{
+			name:           "concurrently",
+			maxConcurrency: 3,
+			pieces:         10,
+			failedPiece:    -1,
+		},
+		{
+			name:           "sequentially with error",
+			maxConcurrency: 1,
+			pieces:         5,
+			failedPiece:    2,
+			wantErr:        errors.New("piece failed"),
+		},
+		{
+			name:           "concurrently with error",
+			maxConcurrency: 3,
+			pieces:         10,
+			failedPiece:    4,
+			wantErr:        errors.New("piece failed"),
+		},
+		{
+			name:           "no pieces",
+			maxConcurrency: 3,
+			pieces:         0,
+			failedPiece:    -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var running int64
+			var maxRunning int64
+			var finished int64
+			err := ParallelizeUntilError(context.Background(), tt.maxConcurrency, tt.pieces, func(ctx context.Context, piece int) error {
+				current := atomic.AddInt64(&running, 1)
+				defer atomic.AddInt64(&running, -1)
+				for {
+					observed := atomic.LoadInt64(&maxRunning)
+					if current <= observed || atomic.CompareAndSwapInt64(&maxRunning, observed, current) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+				atomic.AddInt64(&finished, 1)
+				if piece == tt.failedPiece {
+					return errors.New("piece failed")
+				}
+				return nil
+			})
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				// no more pieces should be started after the failed one got observed.
+				assert.True(t, finished < int64(tt.pieces))
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(tt.pieces), finished)
+			}
+			assert.True(t, maxRunning <= int64(tt.maxConcurrency))
+		})
+	}
+}
+
+func TestTaskGraph_Run(t *testing.T) {
+	type task struct {
+		id           string
+		dependencies []string
+		err          error
+	}
+	tests := []struct {
+		name           string
+		tasks          []task
+		maxConcurrency int
+		wantOrder      []string
+		wantErr        error
+	}{
+		{
+			name: "runs in order tasks are added when sequentially",
+			tasks: []task{
+				{id: "SecurityGroup"},
+				{id: "TargetGroup"},
+				{id: "LoadBalancer", dependencies: []string{"SecurityGroup"}},
+				{id: "Listener", dependencies: []string{"LoadBalancer", "TargetGroup"}},
+				{id: "ListenerRule", dependencies: []string{"Listener", "TargetGroup"}},
+				{id: "TargetGroupBinding", dependencies: []string{"TargetGroup", "SecurityGroup"}},
+			},
+			maxConcurrency: 1,
+			wantOrder:      []string{"SecurityGroup", "TargetGroup", "LoadBalancer", "Listener", "ListenerRule", "TargetGroupBinding"},
+		},
+		{
+			name: "runs dependencies first",
+			tasks: []task{
+				{id: "C", dependencies: []string{"B"}},
+				{id: "B", dependencies: []string{"A"}},
+				{id: "A"},
+			},
+			maxConcurrency: 1,
+			wantOrder:      []string{"A", "B", "C"},
+		},
+		{
+			name: "stops when task failed",
+			tasks: []task{
+				{id: "A"},
+				{id: "B", dependencies: []string{"A"}, err: errors.New("task B failed")},
+				{id: "C", dependencies: []string{"B"}},
+				{id: "D", dependencies: []string{"A"}},
+			},
+			maxConcurrency: 1,
+			wantOrder:      []string{"A", "B"},
+			wantErr:        errors.New("task B failed"),
+		},
+		{
+			name: "unknown dependency",
+			tasks: []task{
+				{id: "A", dependencies: []string{"B"}},
+			},
+			maxConcurrency: 1,
+			wantErr:        errors.New("task A depends on unknown task B"),
+		},
+		{
+			name: "circular dependencies",
+			tasks: []task{
+				{id: "A"},
+				{id: "B", dependencies: []string{"C"}},
+				{id: "C", dependencies: []string{"B"}},
+			},
+			maxConcurrency: 2,
+			wantOrder:      []string{"A"},
+			wantErr:        errors.New("tasks contain circular dependencies"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var orderMutex sync.Mutex
+			var gotOrder []string
+			g := NewTaskGraph()
+			for _, task := range tt.tasks {
+				task := task
+				g.AddTask(task.id, func(ctx context.Context) error {
+					orderMutex.Lock()
+					defer orderMutex.Unlock()
+					gotOrder = append(gotOrder, task.id)
+					return task.err
+				}, task.dependencies...)
+			}
+			err := g.Run(context.Background(), tt.maxConcurrency)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantOrder, gotOrder)
+		})
+	}
+}
+
+func TestTaskGraph_Run_concurrently(t *testing.T) {
+	var running int64
+	var maxRunning int64
+	var dependencyFinished int64
+	g := NewTaskGraph()
+	for _, id := range []string{"A", "B", "C"} {
+		g.AddTask(id, func(ctx context.Context) error {
+			current := atomic.AddInt64(&running, 1)
+			defer atomic.AddInt64(&running, -1)
+			for {
+				observed := atomic.LoadInt64(&maxRunning)
+				if current <= observed || atomic.CompareAndSwapInt64(&maxRunning, observed, current) {
+					break
+				}
+			}
+			time.Sleep(50 * time.Millisecond)
+			atomic.AddInt64(&dependencyFinished, 1)
+			return nil
+		})
+	}
+	g.AddTask("D", func(ctx context.Context) error {
+		assert.Equal(t, int64(3), atomic.LoadInt64(&dependencyFinished))
+		return nil
+	}, "A", "B", "C")
+
+	err := g.Run(context.Background(), 3)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), maxRunning)
+}
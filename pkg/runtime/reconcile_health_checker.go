@@ -0,0 +1,237 @@
+package runtime
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"net/http"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sync"
+	"time"
+)
+
+const (
+	// the name of gauge metric controller-runtime reports the depth of controllers' work queue with.
+	workQueueDepthMetricName = ctrlmetrics.WorkQueueSubsystem + "_" + ctrlmetrics.DepthKey
+	// the label of work queue metrics identifies the controller.
+	workQueueNameLabel = "name"
+
+	metricReconcileStalled              = "stalled"
+	metricReconcileNotSyncing           = "not_syncing"
+	metricReconcileLastSuccessTimestamp = "last_success_timestamp_seconds"
+)
+
+// ReconcileHealthChecker checks the health of a controller based on its reconcile queue and reconcile loops,
+// and reports it as metrics. Only a stalled controller fails its healthz check, since restarting the pod doesn't
+// fix reconciles failing on AWS API errors or invalid objects.
+// It runs as a leader election runnable, so that the stall timeout is counted since the controller started as leader.
+type ReconcileHealthChecker interface {
+	manager.Runnable
+	prometheus.Collector
+
+	// ObserveReconcile records the result of a finished reconcile loop.
+	ObserveReconcile(err error)
+
+	// CheckStalled fails when reconcile requests are queued, but no reconcile loop have finished within stall timeout.
+	CheckStalled() error
+
+	// CheckSyncing fails when reconcile requests are queued, but no reconcile loop have succeeded within stall timeout.
+	CheckSyncing() error
+
+	// Healthz fails when the controller is stalled as leader, it always passes on replicas that aren't leader.
+	Healthz(req *http.Request) error
+}
+
+// NewDefaultReconcileHealthChecker constructs new defaultReconcileHealthChecker for controller.
+// The work queue depth of controller is gathered from the metrics of controller-runtime.
+func NewDefaultReconcileHealthChecker(controllerName string, gatherer prometheus.Gatherer, stallTimeout time.Duration) *defaultReconcileHealthChecker {
+	constLabels := prometheus.Labels{metricLabelController: controllerName}
+	return &defaultReconcileHealthChecker{
+		controllerName: controllerName,
+		gatherer:       gatherer,
+		stallTimeout:   stallTimeout,
+		now:            time.Now,
+		startTime:      time.Now(),
+
+		stalledDesc: prometheus.NewDesc(prometheus.BuildFQName("", metricSubsystemReconcile, metricReconcileStalled),
+			"Whether reconcile requests are queued, but no reconcile has finished within the stall timeout", nil, constLabels),
+		notSyncingDesc: prometheus.NewDesc(prometheus.BuildFQName("", metricSubsystemReconcile, metricReconcileNotSyncing),
+			"Whether reconcile requests are queued, but no reconcile has succeeded within the stall timeout", nil, constLabels),
+		lastSuccessTimestampDesc: prometheus.NewDesc(prometheus.BuildFQName("", metricSubsystemReconcile, metricReconcileLastSuccessTimestamp),
+			"Unix time of the last successful reconcile, zero if none succeeded yet", nil, constLabels),
+	}
+}
+
+var _ ReconcileHealthChecker = &defaultReconcileHealthChecker{}
+
+// default implementation for ReconcileHealthChecker.
+type defaultReconcileHealthChecker struct {
+	controllerName string
+	gatherer       prometheus.Gatherer
+	stallTimeout   time.Duration
+	now            func() time.Time
+
+	stalledDesc              *prometheus.Desc
+	notSyncingDesc           *prometheus.Desc
+	lastSuccessTimestampDesc *prometheus.Desc
+
+	mutex              sync.RWMutex
+	started            bool
+	startTime          time.Time
+	lastFinishedTime   time.Time
+	lastSucceededTime  time.Time
+	lastReconcileError error
+}
+
+// Start records the time controller started, it blocks until stop.
+func (c *defaultReconcileHealthChecker) Start(stop <-chan struct{}) error {
+	c.mutex.Lock()
+	c.started = true
+	c.startTime = c.now()
+	c.mutex.Unlock()
+	<-stop
+	return nil
+}
+
+func (c *defaultReconcileHealthChecker) ObserveReconcile(err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	now := c.now()
+	c.lastFinishedTime = now
+	c.lastReconcileError = err
+	if err == nil {
+		c.lastSucceededTime = now
+	}
+}
+
+func (c *defaultReconcileHealthChecker) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.stalledDesc
+	ch <- c.notSyncingDesc
+	ch <- c.lastSuccessTimestampDesc
+}
+
+func (c *defaultReconcileHealthChecker) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(c.stalledDesc, prometheus.GaugeValue, boolGaugeValue(c.CheckStalled() != nil))
+	ch <- prometheus.MustNewConstMetric(c.notSyncingDesc, prometheus.GaugeValue, boolGaugeValue(c.CheckSyncing() != nil))
+	c.mutex.RLock()
+	lastSucceededTime := c.lastSucceededTime
+	c.mutex.RUnlock()
+	lastSuccessTimestamp := float64(0)
+	if !lastSucceededTime.IsZero() {
+		lastSuccessTimestamp = float64(lastSucceededTime.Unix())
+	}
+	ch <- prometheus.MustNewConstMetric(c.lastSuccessTimestampDesc, prometheus.GaugeValue, lastSuccessTimestamp)
+}
+
+func (c *defaultReconcileHealthChecker) CheckStalled() error {
+	depth, err := c.queueDepth()
+	if err != nil {
+		return err
+	}
+	if depth == 0 {
+		return nil
+	}
+
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	if idle := c.now().Sub(latestTime(c.startTime, c.lastFinishedTime)); idle > c.stallTimeout {
+		return errors.Errorf("%v controller is stalled: reconcile queue depth %v, no reconcile finished for %v, last successful sync at %v",
+			c.controllerName, depth, idle.Round(time.Second), formatSyncTime(c.lastSucceededTime))
+	}
+	return nil
+}
+
+func (c *defaultReconcileHealthChecker) CheckSyncing() error {
+	depth, err := c.queueDepth()
+	if err != nil {
+		return err
+	}
+	if depth == 0 {
+		return nil
+	}
+
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	if idle := c.now().Sub(latestTime(c.startTime, c.lastSucceededTime)); idle > c.stallTimeout {
+		if c.lastReconcileError != nil {
+			return errors.Wrapf(c.lastReconcileError, "%v controller is not syncing: reconcile queue depth %v, last successful sync at %v",
+				c.controllerName, depth, formatSyncTime(c.lastSucceededTime))
+		}
+		return errors.Errorf("%v controller is not syncing: reconcile queue depth %v, last successful sync at %v",
+			c.controllerName, depth, formatSyncTime(c.lastSucceededTime))
+	}
+	return nil
+}
+
+func (c *defaultReconcileHealthChecker) Healthz(_ *http.Request) error {
+	c.mutex.RLock()
+	started := c.started
+	c.mutex.RUnlock()
+	if !started {
+		return nil
+	}
+	return c.CheckStalled()
+}
+
+// queueDepth returns the current depth of work queue for controller, it's zero if the controller haven't started.
+func (c *defaultReconcileHealthChecker) queueDepth() (int64, error) {
+	queueDepthByController, err := gatherWorkQueueDepths(c.gatherer)
+	if err != nil {
+		return 0, err
+	}
+	return queueDepthByController[c.controllerName], nil
+}
+
+// gatherWorkQueueDepths returns the current depth of work queue for each started controller.
+func gatherWorkQueueDepths(gatherer prometheus.Gatherer) (map[string]int64, error) {
+	metricFamilies, err := gatherer.Gather()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to gather work queue metrics")
+	}
+	queueDepthByController := make(map[string]int64)
+	for _, metricFamily := range metricFamilies {
+		if metricFamily.GetName() != workQueueDepthMetricName {
+			continue
+		}
+		for _, metric := range metricFamily.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == workQueueNameLabel {
+					queueDepthByController[label.GetValue()] = int64(metric.GetGauge().GetValue())
+				}
+			}
+		}
+	}
+	return queueDepthByController, nil
+}
+
+// AddReconcileHealthChecker adds checker to manager, registers its metrics to registerer and its healthz check named after controller.
+func AddReconcileHealthChecker(mgr manager.Manager, registerer prometheus.Registerer, controllerName string, checker ReconcileHealthChecker) error {
+	if err := mgr.Add(checker); err != nil {
+		return err
+	}
+	if err := registerer.Register(checker); err != nil {
+		return err
+	}
+	return mgr.AddHealthzCheck(controllerName, checker.Healthz)
+}
+
+func boolGaugeValue(value bool) float64 {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+func latestTime(t1 time.Time, t2 time.Time) time.Time {
+	if t1.After(t2) {
+		return t1
+	}
+	return t2
+}
+
+func formatSyncTime(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,123 @@
+package runtime
+
+import (
+	"fmt"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_defaultReconcileHealthChecker(t *testing.T) {
+	startTime := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	type reconcileCall struct {
+		time time.Time
+		err  error
+	}
+	tests := []struct {
+		name           string
+		queueDepth     *float64
+		reconcileCalls []reconcileCall
+		now            time.Time
+		wantStalledErr error
+		wantSyncingErr error
+	}{
+		{
+			name:       "work queue isn't reported before controller start",
+			queueDepth: nil,
+			now:        startTime.Add(30 * time.Minute),
+		},
+		{
+			name:       "work queue is empty",
+			queueDepth: awssdk.Float64(0),
+			now:        startTime.Add(30 * time.Minute),
+		},
+		{
+			name:       "requests queued within stall timeout since start",
+			queueDepth: awssdk.Float64(5),
+			now:        startTime.Add(5 * time.Minute),
+		},
+		{
+			name:           "requests queued without any reconcile finished since start",
+			queueDepth:     awssdk.Float64(5),
+			now:            startTime.Add(30 * time.Minute),
+			wantStalledErr: errors.New("ingress controller is stalled: reconcile queue depth 5, no reconcile finished for 30m0s, last successful sync at never"),
+			wantSyncingErr: errors.New("ingress controller is not syncing: reconcile queue depth 5, last successful sync at never"),
+		},
+		{
+			name:       "requests queued with reconciles keep failing",
+			queueDepth: awssdk.Float64(5),
+			reconcileCalls: []reconcileCall{
+				{time: startTime.Add(1 * time.Minute)},
+				{time: startTime.Add(25 * time.Minute), err: errors.New("some error")},
+			},
+			now:            startTime.Add(30 * time.Minute),
+			wantSyncingErr: errors.New("ingress controller is not syncing: reconcile queue depth 5, last successful sync at 2020-10-01T00:01:00Z: some error"),
+		},
+		{
+			name:       "requests queued with reconciles succeeded recently",
+			queueDepth: awssdk.Float64(5),
+			reconcileCalls: []reconcileCall{
+				{time: startTime.Add(25 * time.Minute)},
+			},
+			now: startTime.Add(30 * time.Minute),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := prometheus.NewRegistry()
+			depth := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Subsystem: "workqueue",
+				Name:      "depth",
+			}, []string{"name"})
+			registry.MustRegister(depth)
+			depth.WithLabelValues("service").Set(100)
+			if tt.queueDepth != nil {
+				depth.WithLabelValues("ingress").Set(*tt.queueDepth)
+			}
+
+			c := NewDefaultReconcileHealthChecker("ingress", registry, 10*time.Minute)
+			c.startTime = startTime
+			for _, call := range tt.reconcileCalls {
+				callTime := call.time
+				c.now = func() time.Time { return callTime }
+				c.ObserveReconcile(call.err)
+			}
+			c.now = func() time.Time { return tt.now }
+
+			stalledErr := c.CheckStalled()
+			if tt.wantStalledErr != nil {
+				assert.EqualError(t, stalledErr, tt.wantStalledErr.Error())
+			} else {
+				assert.NoError(t, stalledErr)
+			}
+			assert.NoError(t, c.Healthz(nil))
+			c.started = true
+			healthzErr := c.Healthz(nil)
+			if tt.wantStalledErr != nil {
+				assert.EqualError(t, healthzErr, tt.wantStalledErr.Error())
+			} else {
+				assert.NoError(t, healthzErr)
+			}
+			syncingErr := c.CheckSyncing()
+			if tt.wantSyncingErr != nil {
+				assert.EqualError(t, syncingErr, tt.wantSyncingErr.Error())
+			} else {
+				assert.NoError(t, syncingErr)
+			}
+			assert.NoError(t, testutil.CollectAndCompare(c, strings.NewReader(fmt.Sprintf(`
+# HELP reconcile_not_syncing Whether reconcile requests are queued, but no reconcile has succeeded within the stall timeout
+# TYPE reconcile_not_syncing gauge
+reconcile_not_syncing{controller="ingress"} %v
+# HELP reconcile_stalled Whether reconcile requests are queued, but no reconcile has finished within the stall timeout
+# TYPE reconcile_stalled gauge
+reconcile_stalled{controller="ingress"} %v
+`, boolGaugeValue(tt.wantSyncingErr != nil), boolGaugeValue(tt.wantStalledErr != nil))),
+				"reconcile_stalled", "reconcile_not_syncing"))
+		})
+	}
+}
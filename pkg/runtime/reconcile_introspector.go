@@ -0,0 +1,182 @@
+package runtime
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ReconcileIntrospector tracks in-flight reconciles and the last error of reconcile requests, to diagnose stuck reconciles.
+type ReconcileIntrospector interface {
+	// StartReconcile records that reconcile for request of controller started.
+	// The returned function must be invoked with the result of reconcile once it finished.
+	StartReconcile(controllerName string, req ctrl.Request) func(err error)
+
+	// Introspect returns the state of reconciles for each controller, ordered by controller name.
+	Introspect() ([]ControllerIntrospection, error)
+}
+
+// ControllerIntrospection is the state of reconciles for a controller.
+type ControllerIntrospection struct {
+	// Controller is the name of controller.
+	Controller string `json:"controller"`
+	// QueueDepth is the number of requests waiting in the work queue of controller.
+	QueueDepth int64 `json:"queueDepth"`
+	// InFlightReconciles are the reconciles that haven't finished, ordered by start time.
+	InFlightReconciles []InFlightReconcile `json:"inFlightReconciles"`
+	// LastErrors are the errors of requests whose last reconcile failed, ordered by request. Requeues aren't considered failures.
+	LastErrors []ReconcileError `json:"lastErrors"`
+}
+
+// InFlightReconcile is a reconcile that hasn't finished.
+type InFlightReconcile struct {
+	// Request is the namespace/name of reconcile request.
+	Request string `json:"request"`
+	// StartTime is the time reconcile started.
+	StartTime time.Time `json:"startTime"`
+	// Duration is how long reconcile has been running, in human-readable format.
+	Duration string `json:"duration"`
+}
+
+// ReconcileError is the error of the last reconcile for a request.
+type ReconcileError struct {
+	// Request is the namespace/name of reconcile request.
+	Request string `json:"request"`
+	// Error is the message of error.
+	Error string `json:"error"`
+	// Time is the time reconcile failed.
+	Time time.Time `json:"time"`
+	// ConsecutiveFailures is the number of reconciles failed since the last successful one.
+	ConsecutiveFailures int `json:"consecutiveFailures"`
+}
+
+// NewDefaultReconcileIntrospector constructs new defaultReconcileIntrospector.
+// The work queue depth of controllers is gathered from the metrics of controller-runtime.
+func NewDefaultReconcileIntrospector(gatherer prometheus.Gatherer) *defaultReconcileIntrospector {
+	return &defaultReconcileIntrospector{
+		gatherer:          gatherer,
+		now:               time.Now,
+		stateByController: make(map[string]*controllerReconcileState),
+		nextReconcileID:   1,
+	}
+}
+
+var _ ReconcileIntrospector = &defaultReconcileIntrospector{}
+
+// default implementation for ReconcileIntrospector.
+type defaultReconcileIntrospector struct {
+	gatherer prometheus.Gatherer
+	now      func() time.Time
+
+	mutex             sync.Mutex
+	stateByController map[string]*controllerReconcileState
+	nextReconcileID   int64
+}
+
+// the state of reconciles for a controller.
+type controllerReconcileState struct {
+	// in-flight reconciles by the id assigned when each reconcile started.
+	inFlightReconciles map[int64]InFlightReconcile
+	lastErrorByRequest map[string]ReconcileError
+}
+
+func (i *defaultReconcileIntrospector) StartReconcile(controllerName string, req ctrl.Request) func(err error) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	state := i.controllerState(controllerName)
+	reconcileID := i.nextReconcileID
+	i.nextReconcileID++
+	state.inFlightReconciles[reconcileID] = InFlightReconcile{
+		Request:   req.String(),
+		StartTime: i.now(),
+	}
+	return func(err error) {
+		i.finishReconcile(controllerName, reconcileID, req, err)
+	}
+}
+
+func (i *defaultReconcileIntrospector) Introspect() ([]ControllerIntrospection, error) {
+	queueDepthByController, err := gatherWorkQueueDepths(i.gatherer)
+	if err != nil {
+		return nil, err
+	}
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	now := i.now()
+	introspectionByController := make(map[string]*ControllerIntrospection)
+	for controllerName, queueDepth := range queueDepthByController {
+		introspectionByController[controllerName] = &ControllerIntrospection{
+			Controller: controllerName,
+			QueueDepth: queueDepth,
+		}
+	}
+	for controllerName, state := range i.stateByController {
+		introspection, exists := introspectionByController[controllerName]
+		if !exists {
+			introspection = &ControllerIntrospection{Controller: controllerName}
+			introspectionByController[controllerName] = introspection
+		}
+		for _, reconcile := range state.inFlightReconciles {
+			reconcile.Duration = now.Sub(reconcile.StartTime).Round(time.Second).String()
+			introspection.InFlightReconciles = append(introspection.InFlightReconciles, reconcile)
+		}
+		sort.Slice(introspection.InFlightReconciles, func(a, b int) bool {
+			return introspection.InFlightReconciles[a].StartTime.Before(introspection.InFlightReconciles[b].StartTime)
+		})
+		for _, reconcileErr := range state.lastErrorByRequest {
+			introspection.LastErrors = append(introspection.LastErrors, reconcileErr)
+		}
+		sort.Slice(introspection.LastErrors, func(a, b int) bool {
+			return introspection.LastErrors[a].Request < introspection.LastErrors[b].Request
+		})
+	}
+
+	introspections := make([]ControllerIntrospection, 0, len(introspectionByController))
+	for _, introspection := range introspectionByController {
+		if introspection.InFlightReconciles == nil {
+			introspection.InFlightReconciles = []InFlightReconcile{}
+		}
+		if introspection.LastErrors == nil {
+			introspection.LastErrors = []ReconcileError{}
+		}
+		introspections = append(introspections, *introspection)
+	}
+	sort.Slice(introspections, func(a, b int) bool {
+		return introspections[a].Controller < introspections[b].Controller
+	})
+	return introspections, nil
+}
+
+func (i *defaultReconcileIntrospector) finishReconcile(controllerName string, reconcileID int64, req ctrl.Request, err error) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	state := i.controllerState(controllerName)
+	delete(state.inFlightReconciles, reconcileID)
+	requestKey := req.String()
+	// requeues aren't failures, e.g. drift sync requeues after every successful reconcile.
+	if ClassifyError(err) == "" {
+		delete(state.lastErrorByRequest, requestKey)
+		return
+	}
+	state.lastErrorByRequest[requestKey] = ReconcileError{
+		Request:             requestKey,
+		Error:               err.Error(),
+		Time:                i.now(),
+		ConsecutiveFailures: state.lastErrorByRequest[requestKey].ConsecutiveFailures + 1,
+	}
+}
+
+func (i *defaultReconcileIntrospector) controllerState(controllerName string) *controllerReconcileState {
+	state, exists := i.stateByController[controllerName]
+	if !exists {
+		state = &controllerReconcileState{
+			inFlightReconciles: make(map[int64]InFlightReconcile),
+			lastErrorByRequest: make(map[string]ReconcileError),
+		}
+		i.stateByController[controllerName] = state
+	}
+	return state
+}
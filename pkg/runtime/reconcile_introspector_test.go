@@ -0,0 +1,96 @@
+package runtime
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"testing"
+	"time"
+)
+
+func Test_defaultReconcileIntrospector_Introspect(t *testing.T) {
+	startTime := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	reqA := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "awesome-ns", Name: "svc-a"}}
+	reqB := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "awesome-ns", Name: "svc-b"}}
+	reqC := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "awesome-ns", Name: "svc-c"}}
+
+	registry := prometheus.NewRegistry()
+	depth := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: "workqueue",
+		Name:      "depth",
+	}, []string{"name"})
+	registry.MustRegister(depth)
+	depth.WithLabelValues("ingress").Set(0)
+	depth.WithLabelValues("service").Set(3)
+
+	i := NewDefaultReconcileIntrospector(registry)
+	i.now = func() time.Time { return startTime }
+	finishA := i.StartReconcile("service", reqA)
+	finishB := i.StartReconcile("service", reqB)
+	finishC := i.StartReconcile("service", reqC)
+	i.now = func() time.Time { return startTime.Add(1 * time.Minute) }
+	finishA(errors.New("some error"))
+	finishB(NewRequeueNeededAfter("forced drift sync", 10*time.Minute))
+	finishA = i.StartReconcile("service", reqA)
+	i.now = func() time.Time { return startTime.Add(2 * time.Minute) }
+	finishA(errors.New("another error"))
+	_ = i.StartReconcile("targetGroupBinding", reqA)
+	i.now = func() time.Time { return startTime.Add(10 * time.Minute) }
+
+	got, err := i.Introspect()
+	assert.NoError(t, err)
+	assert.Equal(t, []ControllerIntrospection{
+		{
+			Controller:         "ingress",
+			QueueDepth:         0,
+			InFlightReconciles: []InFlightReconcile{},
+			LastErrors:         []ReconcileError{},
+		},
+		{
+			Controller: "service",
+			QueueDepth: 3,
+			InFlightReconciles: []InFlightReconcile{
+				{
+					Request:   "awesome-ns/svc-c",
+					StartTime: startTime,
+					Duration:  "10m0s",
+				},
+			},
+			LastErrors: []ReconcileError{
+				{
+					Request:             "awesome-ns/svc-a",
+					Error:               "another error",
+					Time:                startTime.Add(2 * time.Minute),
+					ConsecutiveFailures: 2,
+				},
+			},
+		},
+		{
+			Controller: "targetGroupBinding",
+			QueueDepth: 0,
+			InFlightReconciles: []InFlightReconcile{
+				{
+					Request:   "awesome-ns/svc-a",
+					StartTime: startTime.Add(2 * time.Minute),
+					Duration:  "8m0s",
+				},
+			},
+			LastErrors: []ReconcileError{},
+		},
+	}, got)
+
+	i.now = func() time.Time { return startTime.Add(11 * time.Minute) }
+	finishC(nil)
+	finishA = i.StartReconcile("service", reqA)
+	finishA(nil)
+	got, err = i.Introspect()
+	assert.NoError(t, err)
+	assert.Equal(t, ControllerIntrospection{
+		Controller:         "service",
+		QueueDepth:         3,
+		InFlightReconciles: []InFlightReconcile{},
+		LastErrors:         []ReconcileError{},
+	}, got[1])
+}
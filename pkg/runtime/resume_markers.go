@@ -0,0 +1,205 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"time"
+)
+
+// ResumeMarkerStore persists resume markers for reconcile requests whose deployments were interrupted by shutdown,
+// so that the next leader reconciles them ahead of others.
+type ResumeMarkerStore interface {
+	// Save adds markers for requests by controller name.
+	Save(ctx context.Context, requestsByController map[string][]ctrl.Request) error
+
+	// Take returns the marked requests of controller and removes their markers.
+	Take(ctx context.Context, controllerName string) ([]ctrl.Request, error)
+}
+
+// NewConfigMapResumeMarkerStore constructs new configMapResumeMarkerStore that persists markers into ConfigMap of configMapKey,
+// with one key per controller holding its requests in JSON.
+// markers won't be persisted if configMapKey is empty.
+func NewConfigMapResumeMarkerStore(k8sClient client.Client, apiReader client.Reader, configMapKey types.NamespacedName) *configMapResumeMarkerStore {
+	return &configMapResumeMarkerStore{
+		k8sClient:    k8sClient,
+		apiReader:    apiReader,
+		configMapKey: configMapKey,
+	}
+}
+
+var _ ResumeMarkerStore = &configMapResumeMarkerStore{}
+
+// ConfigMap based implementation for ResumeMarkerStore.
+type configMapResumeMarkerStore struct {
+	k8sClient client.Client
+	// apiReader reads the ConfigMap directly, since the namespace of ConfigMap might not be watched by the manager's cache.
+	apiReader    client.Reader
+	configMapKey types.NamespacedName
+}
+
+func (s *configMapResumeMarkerStore) Save(ctx context.Context, requestsByController map[string][]ctrl.Request) error {
+	if s.configMapKey.Name == "" || len(requestsByController) == 0 {
+		return nil
+	}
+	cm := &corev1.ConfigMap{}
+	if err := s.apiReader.Get(ctx, s.configMapKey, cm); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to get resume markers configMap: %v", s.configMapKey)
+		}
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: s.configMapKey.Namespace,
+				Name:      s.configMapKey.Name,
+			},
+		}
+		if err := s.mergeMarkers(cm, requestsByController); err != nil {
+			return err
+		}
+		if err := s.k8sClient.Create(ctx, cm); err != nil {
+			return errors.Wrapf(err, "failed to create resume markers configMap: %v", s.configMapKey)
+		}
+		return nil
+	}
+	cmOld := cm.DeepCopy()
+	if err := s.mergeMarkers(cm, requestsByController); err != nil {
+		return err
+	}
+	if err := s.k8sClient.Patch(ctx, cm, client.MergeFrom(cmOld)); err != nil {
+		return errors.Wrapf(err, "failed to update resume markers configMap: %v", s.configMapKey)
+	}
+	return nil
+}
+
+func (s *configMapResumeMarkerStore) Take(ctx context.Context, controllerName string) ([]ctrl.Request, error) {
+	if s.configMapKey.Name == "" {
+		return nil, nil
+	}
+	cm := &corev1.ConfigMap{}
+	if err := s.apiReader.Get(ctx, s.configMapKey, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get resume markers configMap: %v", s.configMapKey)
+	}
+	rawMarkers, exists := cm.Data[controllerName]
+	if !exists {
+		return nil, nil
+	}
+	reqs, err := decodeResumeMarkers(rawMarkers)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid resume markers of %v in configMap: %v", controllerName, s.configMapKey)
+	}
+	cmOld := cm.DeepCopy()
+	delete(cm.Data, controllerName)
+	if err := s.k8sClient.Patch(ctx, cm, client.MergeFrom(cmOld)); err != nil {
+		return nil, errors.Wrapf(err, "failed to update resume markers configMap: %v", s.configMapKey)
+	}
+	return reqs, nil
+}
+
+// mergeMarkers adds the markers for requestsByController into cm, keeping the existing markers not yet taken.
+func (s *configMapResumeMarkerStore) mergeMarkers(cm *corev1.ConfigMap, requestsByController map[string][]ctrl.Request) error {
+	if cm.Data == nil {
+		cm.Data = make(map[string]string)
+	}
+	for controllerName, reqs := range requestsByController {
+		var mergedReqs []ctrl.Request
+		if rawMarkers, exists := cm.Data[controllerName]; exists {
+			existingReqs, err := decodeResumeMarkers(rawMarkers)
+			if err != nil {
+				return errors.Wrapf(err, "invalid resume markers of %v in configMap: %v", controllerName, s.configMapKey)
+			}
+			mergedReqs = existingReqs
+		}
+		markedReqs := make(map[ctrl.Request]bool, len(mergedReqs))
+		for _, req := range mergedReqs {
+			markedReqs[req] = true
+		}
+		for _, req := range reqs {
+			if !markedReqs[req] {
+				markedReqs[req] = true
+				mergedReqs = append(mergedReqs, req)
+			}
+		}
+		rawMarkers, err := json.Marshal(mergedReqs)
+		if err != nil {
+			return err
+		}
+		cm.Data[controllerName] = string(rawMarkers)
+	}
+	return nil
+}
+
+func decodeResumeMarkers(rawMarkers string) ([]ctrl.Request, error) {
+	var reqs []ctrl.Request
+	if err := json.Unmarshal([]byte(rawMarkers), &reqs); err != nil {
+		return nil, err
+	}
+	return reqs, nil
+}
+
+// NewResumeSource constructs new resumeSource for controller.
+func NewResumeSource(controllerName string, markerStore ResumeMarkerStore, logger logr.Logger) *resumeSource {
+	return &resumeSource{
+		controllerName: controllerName,
+		markerStore:    markerStore,
+		logger:         logger,
+	}
+}
+
+var _ source.Source = &resumeSource{}
+
+// resumeSource enqueues the requests marked by ResumeMarkerStore once the controller starts, which only happens on the leader.
+// It should be watched ahead of other sources, so that marked requests are reconciled first.
+type resumeSource struct {
+	controllerName string
+	markerStore    ResumeMarkerStore
+	logger         logr.Logger
+}
+
+func (s *resumeSource) Start(_ handler.EventHandler, queue workqueue.RateLimitingInterface, _ ...predicate.Predicate) error {
+	reqs, err := s.markerStore.Take(context.Background(), s.controllerName)
+	if err != nil {
+		// resuming is best effort, the marked requests are reconciled eventually as their objects are listed anyway.
+		s.logger.Error(err, "failed to take resume markers", "controller", s.controllerName)
+		return nil
+	}
+	for _, req := range reqs {
+		s.logger.Info("resuming interrupted deployment", "controller", s.controllerName, "request", req)
+		queue.Add(req)
+	}
+	return nil
+}
+
+// GracefulStopChan returns the stop channel for the manager, which is closed once signalChan is closed and
+// in-flight deployments finished or timeout elapsed. Deployments still in-flight afterwards are saved into markerStore.
+func GracefulStopChan(signalChan <-chan struct{}, deployTracker InFlightDeployTracker, markerStore ResumeMarkerStore,
+	timeout time.Duration, logger logr.Logger) <-chan struct{} {
+	stopChan := make(chan struct{})
+	go func() {
+		defer close(stopChan)
+		<-signalChan
+		logger.Info("draining in-flight deployments", "timeout", timeout)
+		interruptedReqsByController := deployTracker.Drain(timeout)
+		if len(interruptedReqsByController) == 0 {
+			return
+		}
+		logger.Info("interrupting in-flight deployments", "requests", interruptedReqsByController)
+		if err := markerStore.Save(context.Background(), interruptedReqsByController); err != nil {
+			logger.Error(err, "failed to save resume markers")
+		}
+	}()
+	return stopChan
+}
@@ -0,0 +1,109 @@
+package runtime
+
+import (
+	"context"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+	"time"
+)
+
+func Test_configMapResumeMarkerStore(t *testing.T) {
+	reqA := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "a"}}
+	reqB := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "", Name: "b"}}
+	configMapKey := types.NamespacedName{Namespace: "kube-system", Name: "resume-markers"}
+
+	k8sSchema := k8sruntime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+	ctx := context.Background()
+	store := NewConfigMapResumeMarkerStore(k8sClient, k8sClient, configMapKey)
+
+	// markers are merged with the ones not yet taken.
+	assert.NoError(t, store.Save(ctx, map[string][]ctrl.Request{"ingress": {reqA}}))
+	assert.NoError(t, store.Save(ctx, map[string][]ctrl.Request{"ingress": {reqA, reqB}, "service": {reqB}}))
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, k8sClient.Get(ctx, configMapKey, cm))
+	assert.Equal(t, map[string]string{
+		"ingress": `[{"Namespace":"ns","Name":"a"},{"Namespace":"","Name":"b"}]`,
+		"service": `[{"Namespace":"","Name":"b"}]`,
+	}, cm.Data)
+
+	// markers are removed once taken.
+	reqs, err := store.Take(ctx, "ingress")
+	assert.NoError(t, err)
+	assert.Equal(t, []ctrl.Request{reqA, reqB}, reqs)
+	reqs, err = store.Take(ctx, "ingress")
+	assert.NoError(t, err)
+	assert.Nil(t, reqs)
+	reqs, err = store.Take(ctx, "targetGroupBinding")
+	assert.NoError(t, err)
+	assert.Nil(t, reqs)
+
+	// markers aren't persisted without ConfigMap.
+	disabledStore := NewConfigMapResumeMarkerStore(k8sClient, k8sClient, types.NamespacedName{})
+	assert.NoError(t, disabledStore.Save(ctx, map[string][]ctrl.Request{"ingress": {reqA}}))
+	reqs, err = disabledStore.Take(ctx, "service")
+	assert.NoError(t, err)
+	assert.Nil(t, reqs)
+}
+
+func Test_resumeSource_Start(t *testing.T) {
+	reqA := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "a"}}
+	configMapKey := types.NamespacedName{Namespace: "kube-system", Name: "resume-markers"}
+
+	k8sSchema := k8sruntime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+	store := NewConfigMapResumeMarkerStore(k8sClient, k8sClient, configMapKey)
+	assert.NoError(t, store.Save(context.Background(), map[string][]ctrl.Request{"ingress": {reqA}}))
+
+	workQueue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer workQueue.ShutDown()
+	assert.NoError(t, NewResumeSource("ingress", store, &log.NullLogger{}).Start(nil, workQueue))
+	assert.Equal(t, 1, workQueue.Len())
+	item, _ := workQueue.Get()
+	assert.Equal(t, reqA, item)
+	workQueue.Done(item)
+}
+
+func Test_GracefulStopChan(t *testing.T) {
+	reqA := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "a"}}
+	configMapKey := types.NamespacedName{Namespace: "kube-system", Name: "resume-markers"}
+
+	k8sSchema := k8sruntime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+	store := NewConfigMapResumeMarkerStore(k8sClient, k8sClient, configMapKey)
+	tracker := NewDefaultInFlightDeployTracker()
+	_, err := tracker.TrackDeploy("ingress", reqA)
+	assert.NoError(t, err)
+
+	signalChan := make(chan struct{})
+	var logger logr.Logger = &log.NullLogger{}
+	stopChan := GracefulStopChan(signalChan, tracker, store, 10*time.Millisecond, logger)
+	select {
+	case <-stopChan:
+		t.Fatal("stopped before signaled")
+	default:
+	}
+	close(signalChan)
+	select {
+	case <-stopChan:
+	case <-time.After(time.Second):
+		t.Fatal("not stopped after timeout")
+	}
+
+	// deployments still in-flight after timeout are resumed by next leader.
+	reqs, err := store.Take(context.Background(), "ingress")
+	assert.NoError(t, err)
+	assert.Equal(t, []ctrl.Request{reqA}, reqs)
+}
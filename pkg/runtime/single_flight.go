@@ -0,0 +1,44 @@
+package runtime
+
+import "sync"
+
+// SingleFlight deduplicates concurrent calls by key, so that only one call per key is in-flight and
+// concurrent callers share its result. It allows caches to fetch from AWS without holding their mutex
+// across the API call. The zero value is ready to use.
+type SingleFlight struct {
+	mutex sync.Mutex
+	calls map[string]*singleFlightCall
+}
+
+// singleFlightCall is an in-flight or finished call.
+type singleFlightCall struct {
+	done chan struct{}
+	val  interface{}
+	err  error
+}
+
+// Do invokes fn and returns its results, unless a call for key is already in-flight,
+// in which case it waits for that call and returns its results instead.
+func (g *SingleFlight) Do(key string, fn func() (interface{}, error)) (interface{}, error) {
+	g.mutex.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*singleFlightCall)
+	}
+	if call, ok := g.calls[key]; ok {
+		g.mutex.Unlock()
+		<-call.done
+		return call.val, call.err
+	}
+	call := &singleFlightCall{done: make(chan struct{})}
+	g.calls[key] = call
+	g.mutex.Unlock()
+
+	defer func() {
+		g.mutex.Lock()
+		delete(g.calls, key)
+		g.mutex.Unlock()
+		close(call.done)
+	}()
+	call.val, call.err = fn()
+	return call.val, call.err
+}
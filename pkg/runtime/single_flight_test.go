@@ -0,0 +1,55 @@
+package runtime
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSingleFlight_Do(t *testing.T) {
+	t.Run("concurrent calls with same key share result", func(t *testing.T) {
+		var g SingleFlight
+		var invocations int32
+		started := make(chan struct{}, 3)
+		release := make(chan struct{})
+		fn := func() (interface{}, error) {
+			atomic.AddInt32(&invocations, 1)
+			started <- struct{}{}
+			<-release
+			return "value", nil
+		}
+		var wg sync.WaitGroup
+		results := make([]interface{}, 3)
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				results[i], _ = g.Do("key", fn)
+			}(i)
+			if i == 0 {
+				<-started
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+		wg.Wait()
+		assert.Equal(t, []interface{}{"value", "value", "value"}, results)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&invocations))
+	})
+
+	t.Run("calls after the in-flight call finished are invoked again", func(t *testing.T) {
+		var g SingleFlight
+		_, err := g.Do("key", func() (interface{}, error) {
+			return nil, errors.New("some error")
+		})
+		assert.EqualError(t, err, "some error")
+		val, err := g.Do("key", func() (interface{}, error) {
+			return "value", nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "value", val)
+	})
+}
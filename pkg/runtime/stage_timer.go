@@ -0,0 +1,113 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StageTimer measures the duration of stages within a reconcile loop, like model build or AWS resource reconcile.
+// Stages might run concurrently or nested within other stages, so their durations don't necessarily add up to the total.
+type StageTimer struct {
+	now       func() time.Time
+	startTime time.Time
+
+	mutex sync.Mutex
+	// stages contains the timed stages in the order they are first observed.
+	stages    []string
+	durations map[string]time.Duration
+	counts    map[string]int
+}
+
+// NewStageTimer constructs new StageTimer, the total duration is counted since construction.
+func NewStageTimer() *StageTimer {
+	return &StageTimer{
+		now:       time.Now,
+		startTime: time.Now(),
+		durations: make(map[string]time.Duration),
+		counts:    make(map[string]int),
+	}
+}
+
+// ObserveStage records a run of stage with duration, durations of repeated runs are accumulated.
+func (t *StageTimer) ObserveStage(stage string, duration time.Duration) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if _, ok := t.counts[stage]; !ok {
+		t.stages = append(t.stages, stage)
+	}
+	t.durations[stage] += duration
+	t.counts[stage]++
+}
+
+// Elapsed returns the total duration since StageTimer is constructed.
+func (t *StageTimer) Elapsed() time.Duration {
+	return t.now().Sub(t.startTime)
+}
+
+// Breakdown returns the accumulated duration of each stage in the order they are first observed,
+// e.g. "BuildModel=1.2s, ReconcileTags=300ms(x4)".
+func (t *StageTimer) Breakdown() string {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	entries := make([]string, 0, len(t.stages))
+	for _, stage := range t.stages {
+		entry := fmt.Sprintf("%v=%v", stage, t.durations[stage].Round(time.Millisecond))
+		if count := t.counts[stage]; count > 1 {
+			entry = fmt.Sprintf("%v(x%v)", entry, count)
+		}
+		entries = append(entries, entry)
+	}
+	return strings.Join(entries, ", ")
+}
+
+// SlowReconcileMessage returns the message describing the reconcile timed by timer and its stages,
+// if it took longer than threshold. threshold of zero disables the detection.
+func SlowReconcileMessage(timer *StageTimer, threshold time.Duration) (string, bool) {
+	if timer == nil || threshold <= 0 {
+		return "", false
+	}
+	elapsed := timer.Elapsed()
+	if elapsed <= threshold {
+		return "", false
+	}
+	message := fmt.Sprintf("Reconcile took %v, exceeding threshold %v", elapsed.Round(time.Millisecond), threshold)
+	if breakdown := timer.Breakdown(); breakdown != "" {
+		message = fmt.Sprintf("%v: %v", message, breakdown)
+	}
+	return message, true
+}
+
+type stageTimerContextKey struct{}
+
+// ContextWithStageTimer returns a context that stages run within are timed by timer.
+func ContextWithStageTimer(ctx context.Context, timer *StageTimer) context.Context {
+	return context.WithValue(ctx, stageTimerContextKey{}, timer)
+}
+
+// StageTimerFromContext returns the StageTimer within ctx, or nil if there is none.
+func StageTimerFromContext(ctx context.Context) *StageTimer {
+	timer, _ := ctx.Value(stageTimerContextKey{}).(*StageTimer)
+	return timer
+}
+
+// TimeStage runs fn as stage, its duration is observed by the StageTimer within ctx if any.
+func TimeStage(ctx context.Context, stage string, fn func(ctx context.Context) error) error {
+	timer := StageTimerFromContext(ctx)
+	if timer == nil {
+		return fn(ctx)
+	}
+	startTime := timer.now()
+	err := fn(ctx)
+	timer.ObserveStage(stage, timer.now().Sub(startTime))
+	return err
+}
+
+// TimeStageFunc wraps fn so that each of its invocations is timed as stage.
+func TimeStageFunc(stage string, fn func(ctx context.Context) error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		return TimeStage(ctx, stage, fn)
+	}
+}
@@ -0,0 +1,119 @@
+package runtime
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSlowReconcileMessage(t *testing.T) {
+	startTime := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	type stageRun struct {
+		stage    string
+		duration time.Duration
+	}
+	tests := []struct {
+		name        string
+		stageRuns   []stageRun
+		elapsed     time.Duration
+		threshold   time.Duration
+		wantMessage string
+		wantSlow    bool
+	}{
+		{
+			name:      "detection disabled",
+			elapsed:   10 * time.Minute,
+			threshold: 0,
+			wantSlow:  false,
+		},
+		{
+			name:      "reconcile within threshold",
+			stageRuns: []stageRun{{stage: "BuildModel", duration: 2 * time.Second}},
+			elapsed:   30 * time.Second,
+			threshold: 30 * time.Second,
+			wantSlow:  false,
+		},
+		{
+			name: "reconcile exceeds threshold",
+			stageRuns: []stageRun{
+				{stage: "BuildModel", duration: 1200 * time.Millisecond},
+				{stage: "ReconcileTags", duration: 100 * time.Millisecond},
+				{stage: "SynthesizeLoadBalancer", duration: 40 * time.Second},
+				{stage: "ReconcileTags", duration: 200*time.Millisecond + 400*time.Microsecond},
+			},
+			elapsed:     45*time.Second + 300*time.Microsecond,
+			threshold:   30 * time.Second,
+			wantMessage: "Reconcile took 45s, exceeding threshold 30s: BuildModel=1.2s, ReconcileTags=300ms(x2), SynthesizeLoadBalancer=40s",
+			wantSlow:    true,
+		},
+		{
+			name:        "reconcile exceeds threshold without stages",
+			elapsed:     time.Minute,
+			threshold:   30 * time.Second,
+			wantMessage: "Reconcile took 1m0s, exceeding threshold 30s",
+			wantSlow:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timer := NewStageTimer()
+			timer.startTime = startTime
+			timer.now = func() time.Time { return startTime.Add(tt.elapsed) }
+			for _, run := range tt.stageRuns {
+				timer.ObserveStage(run.stage, run.duration)
+			}
+			gotMessage, gotSlow := SlowReconcileMessage(timer, tt.threshold)
+			assert.Equal(t, tt.wantMessage, gotMessage)
+			assert.Equal(t, tt.wantSlow, gotSlow)
+		})
+	}
+}
+
+func TestTimeStage(t *testing.T) {
+	startTime := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		withTimer     bool
+		fnErr         error
+		wantBreakdown string
+	}{
+		{
+			name:          "stage timed by timer within context",
+			withTimer:     true,
+			wantBreakdown: "BuildModel=5s",
+		},
+		{
+			name:          "failed stage timed by timer within context",
+			withTimer:     true,
+			fnErr:         errors.New("some error"),
+			wantBreakdown: "BuildModel=5s",
+		},
+		{
+			name:      "stage runs without timer within context",
+			withTimer: false,
+			fnErr:     errors.New("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := startTime
+			timer := NewStageTimer()
+			timer.now = func() time.Time { return now }
+			ctx := context.Background()
+			if tt.withTimer {
+				ctx = ContextWithStageTimer(ctx, timer)
+			}
+			called := false
+			err := TimeStageFunc("BuildModel", func(ctx context.Context) error {
+				called = true
+				now = now.Add(5 * time.Second)
+				return tt.fnErr
+			})(ctx)
+			assert.True(t, called)
+			assert.Equal(t, tt.fnErr, err)
+			assert.Equal(t, tt.wantBreakdown, timer.Breakdown())
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package runtime
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// the reason of terminal errors that don't report a more specific reason.
+const terminalErrorReasonUnknown = "TerminalError"
+
+// AWS error codes that won't resolve by retry until the Kubernetes object is changed.
+// account-wide errors, like missing IAM permissions or exhausted account quotas, are resolved outside the cluster without changing objects,
+// so they're retried instead, otherwise every object would be dead-lettered until changed.
+var terminalAWSErrorCodes = sets.NewString(
+	"CertificateNotFound",
+	"DuplicateLoadBalancerName",
+	"DuplicateTargetGroupName",
+	"InvalidConfigurationRequest",
+	"InvalidParameterCombination",
+	"InvalidParameterValue",
+	"InvalidSecurityGroup",
+	"InvalidSubnet",
+	"SSLPolicyNotFound",
+	"TooManyCertificates",
+	"TooManyListeners",
+	"TooManyRules",
+	"TooManyTags",
+	"TooManyTargets",
+	"UnsupportedProtocol",
+	"ValidationError",
+)
+
+// terminalReasoner is implemented by errors that won't resolve by retry.
+type terminalReasoner interface {
+	// TerminalReason returns a short CamelCase reason of the terminal error.
+	TerminalReason() string
+}
+
+// NewTerminalError constructs new TerminalError to instruct that the error won't resolve by retry.
+func NewTerminalError(reason string, err error) *TerminalError {
+	return &TerminalError{
+		reason: reason,
+		err:    err,
+	}
+}
+
+var _ error = &TerminalError{}
+
+// An error that won't resolve by retry until the Kubernetes object is changed, e.g. an invalid annotation.
+type TerminalError struct {
+	reason string
+	err    error
+}
+
+func (e *TerminalError) TerminalReason() string {
+	return e.reason
+}
+
+func (e *TerminalError) Unwrap() error {
+	return e.err
+}
+
+func (e *TerminalError) Error() string {
+	return fmt.Sprintf("%v: %v", e.reason, e.err)
+}
+
+// ClassifyTerminalError returns whether err is terminal, i.e. it won't resolve by retry, together with its reason.
+// Errors that are not known to be terminal are considered retryable.
+func ClassifyTerminalError(err error) (string, bool) {
+	if err == nil {
+		return "", false
+	}
+	var reasoner terminalReasoner
+	if errors.As(err, &reasoner) {
+		reason := reasoner.TerminalReason()
+		if reason == "" {
+			reason = terminalErrorReasonUnknown
+		}
+		return reason, true
+	}
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) && terminalAWSErrorCodes.Has(awsErr.Code()) {
+		return awsErr.Code(), true
+	}
+	return "", false
+}
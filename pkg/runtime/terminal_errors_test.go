@@ -0,0 +1,68 @@
+package runtime
+
+import (
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestClassifyTerminalError(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		wantReason   string
+		wantTerminal bool
+	}{
+		{
+			name:         "nil error",
+			err:          nil,
+			wantTerminal: false,
+		},
+		{
+			name:         "unknown error",
+			err:          errors.New("some error"),
+			wantTerminal: false,
+		},
+		{
+			name:         "retryable AWS error",
+			err:          awserr.New("Throttling", "Rate exceeded", nil),
+			wantTerminal: false,
+		},
+		{
+			name:         "account-wide AWS error",
+			err:          awserr.New("AccessDenied", "User is not authorized to perform: elasticloadbalancing:CreateLoadBalancer", nil),
+			wantTerminal: false,
+		},
+		{
+			name:         "terminal AWS error wrapped",
+			err:          errors.Wrap(awserr.New("CertificateNotFound", "Certificate 'arn' not found", nil), "failed to create listener"),
+			wantReason:   "CertificateNotFound",
+			wantTerminal: true,
+		},
+		{
+			name:         "terminal error wrapped",
+			err:          errors.Wrap(NewTerminalError("InvalidAnnotation", errors.New("some error")), "failed to build model"),
+			wantReason:   "InvalidAnnotation",
+			wantTerminal: true,
+		},
+		{
+			name:         "terminal error without reason",
+			err:          NewTerminalError("", errors.New("some error")),
+			wantReason:   "TerminalError",
+			wantTerminal: true,
+		},
+		{
+			name:         "requeue needed",
+			err:          NewRequeueNeeded("some reason"),
+			wantTerminal: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotReason, gotTerminal := ClassifyTerminalError(tt.err)
+			assert.Equal(t, tt.wantReason, gotReason)
+			assert.Equal(t, tt.wantTerminal, gotTerminal)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package runtime
+
+import (
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"sync"
+	"time"
+)
+
+// TimerQueue schedules reconcile requests at points in time, independently of the requeue results of reconciles.
+// It's watched by a controller as a source, requests scheduled before the controller started are enqueued once it starts.
+type TimerQueue interface {
+	source.Source
+
+	// AddAt schedules req to be reconciled at time at, req is reconciled immediately if at has passed.
+	// a request is reconciled at the earliest of its schedules.
+	AddAt(req ctrl.Request, at time.Time)
+}
+
+// NewDefaultTimerQueue constructs new defaultTimerQueue.
+func NewDefaultTimerQueue() *defaultTimerQueue {
+	return &defaultTimerQueue{
+		pendingRequests: make(map[ctrl.Request]time.Time),
+	}
+}
+
+var _ TimerQueue = &defaultTimerQueue{}
+
+// default implementation for TimerQueue, which delegates the timers to the delaying workqueue of controller.
+type defaultTimerQueue struct {
+	mutex sync.Mutex
+	// queue is nil until the controller started.
+	queue           workqueue.RateLimitingInterface
+	pendingRequests map[ctrl.Request]time.Time
+}
+
+func (q *defaultTimerQueue) Start(_ handler.EventHandler, queue workqueue.RateLimitingInterface, _ ...predicate.Predicate) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	q.queue = queue
+	for req, at := range q.pendingRequests {
+		q.queue.AddAfter(req, time.Until(at))
+	}
+	q.pendingRequests = nil
+	return nil
+}
+
+func (q *defaultTimerQueue) AddAt(req ctrl.Request, at time.Time) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if q.queue != nil {
+		q.queue.AddAfter(req, time.Until(at))
+		return
+	}
+	if pendingAt, exists := q.pendingRequests[req]; exists && pendingAt.Before(at) {
+		return
+	}
+	q.pendingRequests[req] = at
+}
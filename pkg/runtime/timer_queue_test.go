@@ -0,0 +1,46 @@
+package runtime
+
+import (
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"testing"
+	"time"
+)
+
+func Test_defaultTimerQueue_AddAt(t *testing.T) {
+	reqA := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "a"}}
+	reqB := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "b"}}
+	reqC := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "c"}}
+
+	q := NewDefaultTimerQueue()
+	now := time.Now()
+	// requests scheduled before start are enqueued once started, at the earliest of their schedules.
+	q.AddAt(reqA, now.Add(time.Hour))
+	q.AddAt(reqA, now.Add(-time.Minute))
+	q.AddAt(reqA, now.Add(time.Hour))
+	q.AddAt(reqB, now.Add(time.Hour))
+	assert.Equal(t, map[ctrl.Request]time.Time{
+		reqA: now.Add(-time.Minute),
+		reqB: now.Add(time.Hour),
+	}, q.pendingRequests)
+
+	workQueue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer workQueue.ShutDown()
+	assert.NoError(t, q.Start(nil, workQueue))
+	assert.Nil(t, q.pendingRequests)
+
+	// requests scheduled after start are enqueued directly.
+	q.AddAt(reqC, now.Add(-time.Minute))
+	assert.Eventually(t, func() bool {
+		return workQueue.Len() == 2
+	}, time.Second, 10*time.Millisecond)
+	var gotReqs []ctrl.Request
+	for i := 0; i < 2; i++ {
+		item, _ := workQueue.Get()
+		gotReqs = append(gotReqs, item.(ctrl.Request))
+		workQueue.Done(item)
+	}
+	assert.ElementsMatch(t, []ctrl.Request{reqA, reqC}, gotReqs)
+}
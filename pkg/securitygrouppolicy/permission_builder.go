@@ -0,0 +1,242 @@
+package securitygrouppolicy
+
+import (
+	"context"
+	"fmt"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	"net"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sort"
+	"strings"
+)
+
+const (
+	// LabelKeyPolicy is the label in rule descriptions that identifies the SecurityGroupPolicy owning the rule.
+	LabelKeyPolicy = "elbv2.k8s.aws/security-group-policy"
+)
+
+// ListenPort is a listener port of LoadBalancer.
+type ListenPort struct {
+	// Protocol is the IP protocol of the listener port, either tcp or udp.
+	Protocol string
+	// Port is the port number of the listener port.
+	Port int64
+}
+
+// PermissionBuilder builds the inbound permissions of managed SecurityGroups from SecurityGroupPolicies.
+type PermissionBuilder interface {
+	// Build builds the inbound permissions from SecurityGroupPolicies targeting any of targets of kind.
+	// listenPorts are the listener ports of the LoadBalancer, which are allowed by rules without explicit port.
+	// invalid SecurityGroupPolicies are skipped with a warning event, so that they don't block other targets sharing the LoadBalancer.
+	Build(ctx context.Context, kind elbv2api.SecurityGroupPolicyTargetKind, targets []types.NamespacedName, listenPorts []ListenPort) ([]ec2model.IPPermission, error)
+
+	// ReportIgnored records a warning event with reason on SecurityGroupPolicies targeting any of targets of kind,
+	// which should be called when the LoadBalancer of targets has no managed SecurityGroup to apply them.
+	ReportIgnored(ctx context.Context, kind elbv2api.SecurityGroupPolicyTargetKind, targets []types.NamespacedName, reason string) error
+}
+
+// NewDefaultPermissionBuilder constructs new defaultPermissionBuilder.
+func NewDefaultPermissionBuilder(k8sClient client.Client, eventRecorder record.EventRecorder) *defaultPermissionBuilder {
+	return &defaultPermissionBuilder{
+		k8sClient:     k8sClient,
+		eventRecorder: eventRecorder,
+	}
+}
+
+var _ PermissionBuilder = &defaultPermissionBuilder{}
+
+// default implementation for PermissionBuilder.
+type defaultPermissionBuilder struct {
+	k8sClient     client.Client
+	eventRecorder record.EventRecorder
+}
+
+func (b *defaultPermissionBuilder) Build(ctx context.Context, kind elbv2api.SecurityGroupPolicyTargetKind, targets []types.NamespacedName, listenPorts []ListenPort) ([]ec2model.IPPermission, error) {
+	policies, err := b.listPolicies(ctx, kind, targets)
+	if err != nil {
+		return nil, err
+	}
+	var permissions []ec2model.IPPermission
+	for _, policy := range policies {
+		if err := ValidatePolicy(policy); err != nil {
+			b.eventRecorder.Event(policy, corev1.EventTypeWarning, k8s.SecurityGroupPolicyEventReasonInvalidPolicy, fmt.Sprintf("Ignored invalid policy due to %v", err))
+			continue
+		}
+		permissions = append(permissions, buildPolicyPermissions(policy, listenPorts)...)
+	}
+	return permissions, nil
+}
+
+func (b *defaultPermissionBuilder) ReportIgnored(ctx context.Context, kind elbv2api.SecurityGroupPolicyTargetKind, targets []types.NamespacedName, reason string) error {
+	policies, err := b.listPolicies(ctx, kind, targets)
+	if err != nil {
+		return err
+	}
+	for _, policy := range policies {
+		b.eventRecorder.Event(policy, corev1.EventTypeWarning, k8s.SecurityGroupPolicyEventReasonPolicyIgnored, fmt.Sprintf("Ignored policy since %v", reason))
+	}
+	return nil
+}
+
+// listPolicies lists the SecurityGroupPolicies targeting any of targets of kind, ordered by namespace and name.
+func (b *defaultPermissionBuilder) listPolicies(ctx context.Context, kind elbv2api.SecurityGroupPolicyTargetKind, targets []types.NamespacedName) ([]*elbv2api.SecurityGroupPolicy, error) {
+	targetNamesByNamespace := make(map[string]sets.String)
+	for _, target := range targets {
+		if _, exists := targetNamesByNamespace[target.Namespace]; !exists {
+			targetNamesByNamespace[target.Namespace] = sets.NewString()
+		}
+		targetNamesByNamespace[target.Namespace].Insert(target.Name)
+	}
+
+	var policies []*elbv2api.SecurityGroupPolicy
+	for _, namespace := range sets.StringKeySet(targetNamesByNamespace).List() {
+		policyList := &elbv2api.SecurityGroupPolicyList{}
+		if err := b.k8sClient.List(ctx, policyList, client.InNamespace(namespace)); err != nil {
+			// there are no policies if SecurityGroupPolicy isn't installed.
+			if meta.IsNoMatchError(err) {
+				return nil, nil
+			}
+			return nil, errors.Wrap(err, "failed to list SecurityGroupPolicies")
+		}
+		sort.Slice(policyList.Items, func(i, j int) bool {
+			return policyList.Items[i].Name < policyList.Items[j].Name
+		})
+		for i := range policyList.Items {
+			policy := &policyList.Items[i]
+			if policy.Spec.TargetRef.Kind != kind || !targetNamesByNamespace[namespace].Has(policy.Spec.TargetRef.Name) {
+				continue
+			}
+			policies = append(policies, policy)
+		}
+	}
+	return policies, nil
+}
+
+// ValidatePolicy checks whether the inbound rules of policy are valid.
+func ValidatePolicy(policy *elbv2api.SecurityGroupPolicy) error {
+	for _, rule := range policy.Spec.Ingress {
+		for _, port := range rule.Ports {
+			if err := validatePort(port); err != nil {
+				return err
+			}
+		}
+		for _, peer := range rule.From {
+			if err := validatePeer(peer); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validatePort(port elbv2api.SecurityGroupPolicyPort) error {
+	if port.EndPort == nil {
+		return nil
+	}
+	if port.Port == nil {
+		return errors.New("endPort requires port to be specified")
+	}
+	if awssdk.Int64Value(port.EndPort) < awssdk.Int64Value(port.Port) {
+		return errors.Errorf("endPort %v must not be less than port %v", awssdk.Int64Value(port.EndPort), awssdk.Int64Value(port.Port))
+	}
+	return nil
+}
+
+func validatePeer(peer elbv2api.SecurityGroupPolicyPeer) error {
+	peerCount := 0
+	if peer.IPBlock != nil {
+		peerCount++
+		if _, _, err := net.ParseCIDR(peer.IPBlock.CIDR); err != nil {
+			return errors.Errorf("invalid CIDR: %v", peer.IPBlock.CIDR)
+		}
+	}
+	if peer.SecurityGroup != nil {
+		peerCount++
+	}
+	if peer.PrefixList != nil {
+		peerCount++
+	}
+	if peerCount != 1 {
+		return errors.New("exactly one of ipBlock, securityGroup and prefixList must be specified for peer")
+	}
+	return nil
+}
+
+// portRange is a range of ports with protocol allowed by inbound permissions.
+type portRange struct {
+	protocol string
+	fromPort int64
+	toPort   int64
+}
+
+// buildPolicyPermissions builds the inbound permissions of a valid policy, the policy is recorded in their description so that rules are traceable.
+func buildPolicyPermissions(policy *elbv2api.SecurityGroupPolicy, listenPorts []ListenPort) []ec2model.IPPermission {
+	description := fmt.Sprintf("%v=%v/%v", LabelKeyPolicy, policy.Namespace, policy.Name)
+	var permissions []ec2model.IPPermission
+	for _, rule := range policy.Spec.Ingress {
+		portRanges := buildPortRanges(rule.Ports, listenPorts)
+		for _, peer := range rule.From {
+			for _, portRange := range portRanges {
+				permissions = append(permissions, buildPeerPermission(peer, portRange, description))
+			}
+		}
+	}
+	return permissions
+}
+
+// buildPortRanges builds the port ranges of ports, ports without explicit port match all listenPorts with same protocol.
+func buildPortRanges(ports []elbv2api.SecurityGroupPolicyPort, listenPorts []ListenPort) []portRange {
+	if len(ports) == 0 {
+		ports = []elbv2api.SecurityGroupPolicyPort{{}}
+	}
+	var portRanges []portRange
+	for _, port := range ports {
+		protocol := ec2model.IPProtocolTCP
+		if port.Protocol != nil && *port.Protocol == elbv2api.NetworkingProtocolUDP {
+			protocol = ec2model.IPProtocolUDP
+		}
+		if port.Port == nil {
+			for _, listenPort := range listenPorts {
+				if listenPort.Protocol == protocol {
+					portRanges = append(portRanges, portRange{protocol: protocol, fromPort: listenPort.Port, toPort: listenPort.Port})
+				}
+			}
+			continue
+		}
+		toPort := awssdk.Int64Value(port.Port)
+		if port.EndPort != nil {
+			toPort = awssdk.Int64Value(port.EndPort)
+		}
+		portRanges = append(portRanges, portRange{protocol: protocol, fromPort: awssdk.Int64Value(port.Port), toPort: toPort})
+	}
+	return portRanges
+}
+
+// buildPeerPermission builds the inbound permission allowing traffic from a valid peer to portRange.
+func buildPeerPermission(peer elbv2api.SecurityGroupPolicyPeer, portRange portRange, description string) ec2model.IPPermission {
+	permission := ec2model.IPPermission{
+		IPProtocol: portRange.protocol,
+		FromPort:   awssdk.Int64(portRange.fromPort),
+		ToPort:     awssdk.Int64(portRange.toPort),
+	}
+	switch {
+	case peer.IPBlock != nil && strings.Contains(peer.IPBlock.CIDR, ":"):
+		permission.IPv6Range = []ec2model.IPv6Range{{CIDRIPv6: peer.IPBlock.CIDR, Description: description}}
+	case peer.IPBlock != nil:
+		permission.IPRanges = []ec2model.IPRange{{CIDRIP: peer.IPBlock.CIDR, Description: description}}
+	case peer.SecurityGroup != nil:
+		permission.UserIDGroupPairs = []ec2model.UserIDGroupPair{{GroupID: peer.SecurityGroup.GroupID, Description: description}}
+	case peer.PrefixList != nil:
+		permission.PrefixLists = []ec2model.PrefixList{{PrefixListID: peer.PrefixList.PrefixListID, Description: description}}
+	}
+	return permission
+}
@@ -0,0 +1,336 @@
+package securitygrouppolicy
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+func Test_defaultPermissionBuilder_Build(t *testing.T) {
+	protocolUDP := elbv2api.NetworkingProtocolUDP
+	policyForIngress := &elbv2api.SecurityGroupPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "policy-a"},
+		Spec: elbv2api.SecurityGroupPolicySpec{
+			TargetRef: elbv2api.SecurityGroupPolicyTargetReference{Kind: elbv2api.SecurityGroupPolicyTargetKindIngress, Name: "ing-1"},
+			Ingress: []elbv2api.SecurityGroupPolicyIngressRule{
+				{
+					From: []elbv2api.SecurityGroupPolicyPeer{
+						{IPBlock: &elbv2api.IPBlock{CIDR: "10.0.0.0/16"}},
+						{PrefixList: &elbv2api.PrefixList{PrefixListID: "pl-abcdefg"}},
+					},
+				},
+			},
+		},
+	}
+	policyForService := &elbv2api.SecurityGroupPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "policy-b"},
+		Spec: elbv2api.SecurityGroupPolicySpec{
+			TargetRef: elbv2api.SecurityGroupPolicyTargetReference{Kind: elbv2api.SecurityGroupPolicyTargetKindService, Name: "ing-1"},
+			Ingress: []elbv2api.SecurityGroupPolicyIngressRule{
+				{
+					From: []elbv2api.SecurityGroupPolicyPeer{
+						{SecurityGroup: &elbv2api.SecurityGroup{GroupID: "sg-abcdefg"}},
+					},
+					Ports: []elbv2api.SecurityGroupPolicyPort{
+						{Protocol: &protocolUDP},
+						{Port: awssdk.Int64(8000), EndPort: awssdk.Int64(8080)},
+					},
+				},
+			},
+		},
+	}
+	policyInOtherNamespace := &elbv2api.SecurityGroupPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "other-ns", Name: "policy-c"},
+		Spec: elbv2api.SecurityGroupPolicySpec{
+			TargetRef: elbv2api.SecurityGroupPolicyTargetReference{Kind: elbv2api.SecurityGroupPolicyTargetKindIngress, Name: "ing-1"},
+			Ingress: []elbv2api.SecurityGroupPolicyIngressRule{
+				{
+					From: []elbv2api.SecurityGroupPolicyPeer{
+						{IPBlock: &elbv2api.IPBlock{CIDR: "2001:db8::/32"}},
+					},
+				},
+			},
+		},
+	}
+	invalidPolicy := &elbv2api.SecurityGroupPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "policy-d"},
+		Spec: elbv2api.SecurityGroupPolicySpec{
+			TargetRef: elbv2api.SecurityGroupPolicyTargetReference{Kind: elbv2api.SecurityGroupPolicyTargetKindIngress, Name: "ing-2"},
+			Ingress: []elbv2api.SecurityGroupPolicyIngressRule{
+				{
+					From: []elbv2api.SecurityGroupPolicyPeer{
+						{
+							IPBlock:       &elbv2api.IPBlock{CIDR: "10.0.0.0/16"},
+							SecurityGroup: &elbv2api.SecurityGroup{GroupID: "sg-abcdefg"},
+						},
+					},
+				},
+			},
+		},
+	}
+	listenPorts := []ListenPort{
+		{Protocol: ec2model.IPProtocolTCP, Port: 80},
+		{Protocol: ec2model.IPProtocolTCP, Port: 443},
+		{Protocol: ec2model.IPProtocolUDP, Port: 53},
+	}
+
+	type args struct {
+		kind    elbv2api.SecurityGroupPolicyTargetKind
+		targets []types.NamespacedName
+	}
+	tests := []struct {
+		name       string
+		args       args
+		want       []ec2model.IPPermission
+		wantEvents []string
+	}{
+		{
+			name: "policies targeting ingresses",
+			args: args{
+				kind: elbv2api.SecurityGroupPolicyTargetKindIngress,
+				targets: []types.NamespacedName{
+					{Namespace: "other-ns", Name: "ing-1"},
+					{Namespace: "awesome-ns", Name: "ing-1"},
+				},
+			},
+			want: []ec2model.IPPermission{
+				{
+					IPProtocol: "tcp",
+					FromPort:   awssdk.Int64(80),
+					ToPort:     awssdk.Int64(80),
+					IPRanges:   []ec2model.IPRange{{CIDRIP: "10.0.0.0/16", Description: "elbv2.k8s.aws/security-group-policy=awesome-ns/policy-a"}},
+				},
+				{
+					IPProtocol: "tcp",
+					FromPort:   awssdk.Int64(443),
+					ToPort:     awssdk.Int64(443),
+					IPRanges:   []ec2model.IPRange{{CIDRIP: "10.0.0.0/16", Description: "elbv2.k8s.aws/security-group-policy=awesome-ns/policy-a"}},
+				},
+				{
+					IPProtocol:  "tcp",
+					FromPort:    awssdk.Int64(80),
+					ToPort:      awssdk.Int64(80),
+					PrefixLists: []ec2model.PrefixList{{PrefixListID: "pl-abcdefg", Description: "elbv2.k8s.aws/security-group-policy=awesome-ns/policy-a"}},
+				},
+				{
+					IPProtocol:  "tcp",
+					FromPort:    awssdk.Int64(443),
+					ToPort:      awssdk.Int64(443),
+					PrefixLists: []ec2model.PrefixList{{PrefixListID: "pl-abcdefg", Description: "elbv2.k8s.aws/security-group-policy=awesome-ns/policy-a"}},
+				},
+				{
+					IPProtocol: "tcp",
+					FromPort:   awssdk.Int64(80),
+					ToPort:     awssdk.Int64(80),
+					IPv6Range:  []ec2model.IPv6Range{{CIDRIPv6: "2001:db8::/32", Description: "elbv2.k8s.aws/security-group-policy=other-ns/policy-c"}},
+				},
+				{
+					IPProtocol: "tcp",
+					FromPort:   awssdk.Int64(443),
+					ToPort:     awssdk.Int64(443),
+					IPv6Range:  []ec2model.IPv6Range{{CIDRIPv6: "2001:db8::/32", Description: "elbv2.k8s.aws/security-group-policy=other-ns/policy-c"}},
+				},
+			},
+		},
+		{
+			name: "policies targeting services",
+			args: args{
+				kind: elbv2api.SecurityGroupPolicyTargetKindService,
+				targets: []types.NamespacedName{
+					{Namespace: "awesome-ns", Name: "ing-1"},
+				},
+			},
+			want: []ec2model.IPPermission{
+				{
+					IPProtocol:       "udp",
+					FromPort:         awssdk.Int64(53),
+					ToPort:           awssdk.Int64(53),
+					UserIDGroupPairs: []ec2model.UserIDGroupPair{{GroupID: "sg-abcdefg", Description: "elbv2.k8s.aws/security-group-policy=awesome-ns/policy-b"}},
+				},
+				{
+					IPProtocol:       "tcp",
+					FromPort:         awssdk.Int64(8000),
+					ToPort:           awssdk.Int64(8080),
+					UserIDGroupPairs: []ec2model.UserIDGroupPair{{GroupID: "sg-abcdefg", Description: "elbv2.k8s.aws/security-group-policy=awesome-ns/policy-b"}},
+				},
+			},
+		},
+		{
+			name: "no policies targeting objects",
+			args: args{
+				kind: elbv2api.SecurityGroupPolicyTargetKindService,
+				targets: []types.NamespacedName{
+					{Namespace: "awesome-ns", Name: "svc-1"},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "invalid policy",
+			args: args{
+				kind: elbv2api.SecurityGroupPolicyTargetKindIngress,
+				targets: []types.NamespacedName{
+					{Namespace: "awesome-ns", Name: "ing-2"},
+				},
+			},
+			want: nil,
+			wantEvents: []string{
+				"Warning InvalidPolicy Ignored invalid policy due to exactly one of ipBlock, securityGroup and prefixList must be specified for peer",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			for _, policy := range []*elbv2api.SecurityGroupPolicy{policyForIngress, policyForService, policyInOtherNamespace, invalidPolicy} {
+				assert.NoError(t, k8sClient.Create(context.Background(), policy.DeepCopy()))
+			}
+
+			eventRecorder := record.NewFakeRecorder(10)
+			b := NewDefaultPermissionBuilder(k8sClient, eventRecorder)
+			got, err := b.Build(context.Background(), tt.args.kind, tt.args.targets, listenPorts)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			close(eventRecorder.Events)
+			var gotEvents []string
+			for event := range eventRecorder.Events {
+				gotEvents = append(gotEvents, event)
+			}
+			assert.Equal(t, tt.wantEvents, gotEvents)
+		})
+	}
+}
+
+func Test_defaultPermissionBuilder_ReportIgnored(t *testing.T) {
+	k8sSchema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	elbv2api.AddToScheme(k8sSchema)
+	k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+	policy := &elbv2api.SecurityGroupPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "policy-a"},
+		Spec: elbv2api.SecurityGroupPolicySpec{
+			TargetRef: elbv2api.SecurityGroupPolicyTargetReference{Kind: elbv2api.SecurityGroupPolicyTargetKindService, Name: "svc-1"},
+		},
+	}
+	assert.NoError(t, k8sClient.Create(context.Background(), policy))
+
+	eventRecorder := record.NewFakeRecorder(10)
+	b := NewDefaultPermissionBuilder(k8sClient, eventRecorder)
+	assert.NoError(t, b.ReportIgnored(context.Background(), elbv2api.SecurityGroupPolicyTargetKindService,
+		[]types.NamespacedName{{Namespace: "awesome-ns", Name: "svc-1"}}, "the NLB has no managed SecurityGroup"))
+	assert.NoError(t, b.ReportIgnored(context.Background(), elbv2api.SecurityGroupPolicyTargetKindIngress,
+		[]types.NamespacedName{{Namespace: "awesome-ns", Name: "svc-1"}}, "the LoadBalancer uses explicit securityGroups"))
+	close(eventRecorder.Events)
+	var gotEvents []string
+	for event := range eventRecorder.Events {
+		gotEvents = append(gotEvents, event)
+	}
+	assert.Equal(t, []string{"Warning PolicyIgnored Ignored policy since the NLB has no managed SecurityGroup"}, gotEvents)
+}
+
+func Test_ValidatePolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    elbv2api.SecurityGroupPolicyIngressRule
+		wantErr error
+	}{
+		{
+			name: "valid rule",
+			rule: elbv2api.SecurityGroupPolicyIngressRule{
+				From:  []elbv2api.SecurityGroupPolicyPeer{{IPBlock: &elbv2api.IPBlock{CIDR: "10.0.0.0/16"}}},
+				Ports: []elbv2api.SecurityGroupPolicyPort{{Port: awssdk.Int64(8000), EndPort: awssdk.Int64(8080)}},
+			},
+		},
+		{
+			name: "endPort without port",
+			rule: elbv2api.SecurityGroupPolicyIngressRule{
+				From:  []elbv2api.SecurityGroupPolicyPeer{{IPBlock: &elbv2api.IPBlock{CIDR: "10.0.0.0/16"}}},
+				Ports: []elbv2api.SecurityGroupPolicyPort{{EndPort: awssdk.Int64(8443)}},
+			},
+			wantErr: errors.New("endPort requires port to be specified"),
+		},
+		{
+			name: "endPort less than port",
+			rule: elbv2api.SecurityGroupPolicyIngressRule{
+				From:  []elbv2api.SecurityGroupPolicyPeer{{IPBlock: &elbv2api.IPBlock{CIDR: "10.0.0.0/16"}}},
+				Ports: []elbv2api.SecurityGroupPolicyPort{{Port: awssdk.Int64(8443), EndPort: awssdk.Int64(8000)}},
+			},
+			wantErr: errors.New("endPort 8000 must not be less than port 8443"),
+		},
+		{
+			name: "invalid CIDR",
+			rule: elbv2api.SecurityGroupPolicyIngressRule{
+				From: []elbv2api.SecurityGroupPolicyPeer{{IPBlock: &elbv2api.IPBlock{CIDR: "10.0.0.0"}}},
+			},
+			wantErr: errors.New("invalid CIDR: 10.0.0.0"),
+		},
+		{
+			name: "peer without source",
+			rule: elbv2api.SecurityGroupPolicyIngressRule{
+				From: []elbv2api.SecurityGroupPolicyPeer{{}},
+			},
+			wantErr: errors.New("exactly one of ipBlock, securityGroup and prefixList must be specified for peer"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &elbv2api.SecurityGroupPolicy{
+				Spec: elbv2api.SecurityGroupPolicySpec{
+					Ingress: []elbv2api.SecurityGroupPolicyIngressRule{tt.rule},
+				},
+			}
+			err := ValidatePolicy(policy)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_buildPortRanges(t *testing.T) {
+	listenPorts := []ListenPort{
+		{Protocol: ec2model.IPProtocolTCP, Port: 80},
+		{Protocol: ec2model.IPProtocolUDP, Port: 53},
+	}
+	tests := []struct {
+		name  string
+		ports []elbv2api.SecurityGroupPolicyPort
+		want  []portRange
+	}{
+		{
+			name: "all tcp listener ports by default",
+			want: []portRange{{protocol: "tcp", fromPort: 80, toPort: 80}},
+		},
+		{
+			name:  "explicit port",
+			ports: []elbv2api.SecurityGroupPolicyPort{{Port: awssdk.Int64(8443)}},
+			want:  []portRange{{protocol: "tcp", fromPort: 8443, toPort: 8443}},
+		},
+		{
+			name:  "port range",
+			ports: []elbv2api.SecurityGroupPolicyPort{{Port: awssdk.Int64(8000), EndPort: awssdk.Int64(8080)}},
+			want:  []portRange{{protocol: "tcp", fromPort: 8000, toPort: 8080}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildPortRanges(tt.ports, listenPorts)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"github.com/pkg/errors"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	zonalshiftmodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/zonalshift"
+	"strconv"
+	"strings"
+)
+
+// NewAnnotationValidator constructs the validator for annotations on Services of load balancer type nlb-ip.
+func NewAnnotationValidator() annotations.Validator {
+	return annotations.NewSuffixAnnotationValidator([]string{annotations.AnnotationPrefixService},
+		map[string]annotations.ValueValidator{
+			annotations.SvcLBSuffixSourceRanges:                  annotations.ValidateStringSlice(annotations.ValidateCIDR),
+			annotations.SvcLBSuffixInternal:                      annotations.ValidateBool,
+			annotations.SvcLBSuffixIPAddressType:                 annotations.ValidateOneOf(string(elbv2model.IPAddressTypeIPV4), string(elbv2model.IPAddressTypeDualStack)),
+			annotations.SvcLBSuffixProxyProtocol:                 annotations.ValidateOneOf("*"),
+			annotations.SvcLBSuffixProxyProtocolTLVs:             annotations.ValidateStringSlice(annotations.ValidateOneOf(supportedProxyProtocolV2TLVs...)),
+			annotations.SvcLBSuffixAccessLogEnabled:              annotations.ValidateBool,
+			annotations.SvcLBSuffixCrossZoneLoadBalancingEnabled: annotations.ValidateBool,
+			annotations.SvcLBSuffixDNSRecordClientRoutingPolicy:  annotations.ValidateOneOf(elbv2model.ClientRoutingPolicyAvailabilityZoneAffinity, elbv2model.ClientRoutingPolicyPartialAvailabilityZoneAffinity, elbv2model.ClientRoutingPolicyAnyAvailabilityZone),
+			annotations.SvcLBSuffixAdditionalTags:                annotations.ValidateStringMap,
+			annotations.SvcLBSuffixHCHealthyThreshold:            annotations.ValidateInt64InRange(2, 10),
+			annotations.SvcLBSuffixHCUnhealthyThreshold:          annotations.ValidateInt64InRange(2, 10),
+			annotations.SvcLBSuffixHCTimeout:                     annotations.ValidateInt64InRange(2, 120),
+			annotations.SvcLBSuffixHCInterval:                    annotations.ValidateInt64InRange(5, 300),
+			annotations.SvcLBSuffixHCProtocol:                    validateHealthCheckProtocol,
+			annotations.SvcLBSuffixHCPort:                        validateHealthCheckPort,
+			annotations.SvcLBSuffixEIPPoolAllocate:               annotations.ValidateBool,
+			annotations.SvcLBSuffixVPCEndpointService:            annotations.ValidateBool,
+			annotations.SvcLBSuffixTargetGroupAttributes:         annotations.ValidateStringMap,
+			annotations.SvcLBSuffixListenerAttributes:            annotations.ValidateStringMapWith(validateListenerAttributes),
+			annotations.SvcLBSuffixTargetGroupCrossZone: func(value string) error {
+				return elbv2model.ValidateTargetGroupCrossZone(value, nil)
+			},
+			annotations.SvcLBSuffixTargetNodeLabels:    annotations.ValidateStringMap,
+			annotations.SvcLBSuffixSubnetTags:          annotations.ValidateStringMap,
+			annotations.SvcLBSuffixALPNPolicy:          annotations.ValidateOneOf(string(elbv2model.ALPNPolicyNone), string(elbv2model.ALPNPolicyHTTP1Only), string(elbv2model.ALPNPolicyHTTP2Only), string(elbv2model.ALPNPolicyHTTP2Optional), string(elbv2model.ALPNPolicyHTTP2Preferred)),
+			annotations.SvcLBSuffixManageSecurityGroup: annotations.ValidateBool,
+			annotations.SvcLBSuffixDriftSyncPeriod:     annotations.ValidateDurationAtLeast(config.MinDriftSyncPeriod),
+			annotations.SvcLBSuffixZonalShiftAwayFrom:  zonalshiftmodel.ValidateZonalShiftAwayFrom,
+			annotations.SvcLBSuffixZonalShiftExpiresIn: zonalshiftmodel.ValidateZonalShiftExpiresIn,
+			annotations.SvcLBSuffixGroupName:           validateGroupName,
+			annotations.SvcLBSuffixTTL:                 annotations.ValidateDurationAtLeast(config.MinResourceTTL),
+			annotations.SvcLBSuffixTTLDeleteObject:     annotations.ValidateBool,
+
+			annotations.SvcLBSuffixVPCEndpointServiceAcceptanceRequired: annotations.ValidateBool,
+
+			annotations.SvcLBSuffixTargetGroupHealthDNSFailoverMinHealthyTargetsCount:                validateTargetGroupHealthRequirementAnnotation(annotations.SvcLBSuffixTargetGroupHealthDNSFailoverMinHealthyTargetsCount),
+			annotations.SvcLBSuffixTargetGroupHealthDNSFailoverMinHealthyTargetsPercentage:           validateTargetGroupHealthRequirementAnnotation(annotations.SvcLBSuffixTargetGroupHealthDNSFailoverMinHealthyTargetsPercentage),
+			annotations.SvcLBSuffixTargetGroupHealthUnhealthyStateRoutingMinHealthyTargetsCount:      validateTargetGroupHealthRequirementAnnotation(annotations.SvcLBSuffixTargetGroupHealthUnhealthyStateRoutingMinHealthyTargetsCount),
+			annotations.SvcLBSuffixTargetGroupHealthUnhealthyStateRoutingMinHealthyTargetsPercentage: validateTargetGroupHealthRequirementAnnotation(annotations.SvcLBSuffixTargetGroupHealthUnhealthyStateRoutingMinHealthyTargetsPercentage),
+		},
+		nil,
+	)
+}
+
+func validateHealthCheckProtocol(value string) error {
+	switch strings.ToUpper(value) {
+	case string(elbv2model.ProtocolTCP), string(elbv2model.ProtocolHTTP), string(elbv2model.ProtocolHTTPS):
+		return nil
+	default:
+		return errors.Errorf("must be one of [%v, %v, %v]", elbv2model.ProtocolTCP, elbv2model.ProtocolHTTP, elbv2model.ProtocolHTTPS)
+	}
+}
+
+func validateHealthCheckPort(value string) error {
+	if value == healthCheckPortTrafficPort {
+		return nil
+	}
+	port, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || port < 1 || port > 65535 {
+		return errors.Errorf("must be %v or an integer within [1, 65535]", healthCheckPortTrafficPort)
+	}
+	return nil
+}
+
+// validateTargetGroupHealthRequirementAnnotation returns ValueValidator for the target group health requirement annotation.
+func validateTargetGroupHealthRequirementAnnotation(annotationSuffix string) annotations.ValueValidator {
+	attrKey := targetGroupHealthAttributeKeyByAnnotation[annotationSuffix]
+	return func(value string) error {
+		return elbv2model.ValidateTargetGroupHealthRequirement(attrKey, value, nil)
+	}
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// GroupID is the unique identifier for a ServiceGroup within cluster.
+type GroupID types.NamespacedName
+
+// IsExplicit tests whether this is an explicit group.
+// Explicit groups are defined by annotation on Service: `aws-load-balancer-group-name`
+func (groupID GroupID) IsExplicit() bool {
+	return groupID.Namespace == ""
+}
+
+// String returns the string representation of a GroupID.
+func (groupID GroupID) String() string {
+	if groupID.IsExplicit() {
+		return groupID.Name
+	}
+	return fmt.Sprintf("%s/%s", groupID.Namespace, groupID.Name)
+}
+
+// NewGroupIDForExplicitGroup generates GroupID for an explicit group.
+func NewGroupIDForExplicitGroup(groupName string) GroupID {
+	return GroupID{
+		Namespace: "",
+		Name:      groupName,
+	}
+}
+
+// NewGroupIDForImplicitGroup generates GroupID for an implicit group.
+func NewGroupIDForImplicitGroup(svcKey types.NamespacedName) GroupID {
+	return GroupID(svcKey)
+}
+
+// EncodeGroupIDToReconcileRequest encodes a GroupID into a controller-runtime reconcile request
+func EncodeGroupIDToReconcileRequest(gID GroupID) ctrl.Request {
+	return ctrl.Request{NamespacedName: types.NamespacedName(gID)}
+}
+
+// DecodeGroupIDFromReconcileRequest decodes a GroupID from a controller-runtime reconcile request
+func DecodeGroupIDFromReconcileRequest(request ctrl.Request) GroupID {
+	return GroupID(request.NamespacedName)
+}
+
+// A Service Group is a group of Services that should be hosted by a single NLB.
+// each member Service defines listeners for distinct ports of that NLB.
+// There are two types of group: explicit and implicit.
+// Explicit groups are defined by annotation on Service: `aws-load-balancer-group-name`
+// Implicit groups are for services without `aws-load-balancer-group-name`, each service become a standalone group of itself.
+type Group struct {
+	ID GroupID
+
+	// Members are Services that is belong to this group.
+	Members []*corev1.Service
+
+	// InactiveMembers are Services that no longer belong to this group, but still hold the finalizers.
+	InactiveMembers []*corev1.Service
+}
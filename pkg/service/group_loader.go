@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"regexp"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sort"
+)
+
+const (
+	maxGroupNameLength    = 63
+	loadBalancerTypeNLBIP = "nlb-ip"
+)
+
+var (
+	// groupName must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character.
+	// groupName must be no more than 63 character.
+	groupNameRegex = regexp.MustCompile("^([a-z0-9][-a-z0-9.]*)?[a-z0-9]$")
+
+	// err represents that service group is invalid.
+	errInvalidServiceGroup = errors.New("invalid service group")
+)
+
+// GroupLoader loads Service groups.
+type GroupLoader interface {
+	// FindGroupID returns the ServiceGroup's ID or nil if it doesn't belong to any group.
+	FindGroupID(ctx context.Context, svc *corev1.Service) (*GroupID, error)
+
+	// Load returns a Service group given groupID.
+	Load(ctx context.Context, groupID GroupID) (Group, error)
+}
+
+// NewDefaultGroupLoader constructs new GroupLoader instance.
+func NewDefaultGroupLoader(client client.Client, annotationParser annotations.Parser, namespaceMatcher k8s.NamespaceMatcher) *defaultGroupLoader {
+	return &defaultGroupLoader{
+		client:           client,
+		annotationParser: annotationParser,
+		namespaceMatcher: namespaceMatcher,
+	}
+}
+
+var _ GroupLoader = (*defaultGroupLoader)(nil)
+
+// default implementation for GroupLoader
+type defaultGroupLoader struct {
+	client           client.Client
+	annotationParser annotations.Parser
+	namespaceMatcher k8s.NamespaceMatcher
+}
+
+func (m *defaultGroupLoader) FindGroupID(ctx context.Context, svc *corev1.Service) (*GroupID, error) {
+	lbType := ""
+	_ = m.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixLoadBalancerType, &lbType, svc.Annotations)
+	if lbType != loadBalancerTypeNLBIP {
+		return nil, nil
+	}
+
+	groupName := ""
+	if exists := m.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixGroupName, &groupName, svc.Annotations); exists {
+		if err := validateGroupName(groupName); err != nil {
+			return nil, fmt.Errorf("%w: %v", errInvalidServiceGroup, err.Error())
+		}
+		groupID := NewGroupIDForExplicitGroup(groupName)
+		return &groupID, nil
+	}
+
+	// explicit ServiceGroups span namespaces and are sharded by their names instead, see NamespaceMatcher.MatchesExplicitGroup.
+	matchesNamespace, err := m.namespaceMatcher.Matches(ctx, svc.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if !matchesNamespace {
+		return nil, nil
+	}
+	groupID := NewGroupIDForImplicitGroup(k8s.NamespacedName(svc))
+	return &groupID, nil
+}
+
+func (m *defaultGroupLoader) Load(ctx context.Context, groupID GroupID) (Group, error) {
+	candidates, err := m.listGroupCandidates(ctx, groupID)
+	if err != nil {
+		return Group{}, err
+	}
+
+	var members []*corev1.Service
+	var inactiveMembers []*corev1.Service
+	finalizer := buildGroupFinalizer(groupID)
+	for _, svc := range candidates {
+		isGroupMember, err := m.isGroupMember(ctx, groupID, svc)
+		if err != nil {
+			return Group{}, errors.Wrapf(err, "service: %v", k8s.NamespacedName(svc))
+		}
+		if isGroupMember {
+			members = append(members, svc)
+		} else if k8s.HasFinalizer(svc, finalizer) {
+			inactiveMembers = append(inactiveMembers, svc)
+		}
+	}
+	sortGroupMembers(members)
+	return Group{
+		ID:              groupID,
+		Members:         members,
+		InactiveMembers: inactiveMembers,
+	}, nil
+}
+
+// listGroupCandidates lists the Services that might be members or inactive members of group.
+// implicit ServiceGroups in namespaces sharded to other controllers have no candidates.
+func (m *defaultGroupLoader) listGroupCandidates(ctx context.Context, groupID GroupID) ([]*corev1.Service, error) {
+	if !groupID.IsExplicit() {
+		matchesNamespace, err := m.namespaceMatcher.Matches(ctx, groupID.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		if !matchesNamespace {
+			return nil, nil
+		}
+		svc := &corev1.Service{}
+		if err := m.client.Get(ctx, types.NamespacedName(groupID), svc); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return []*corev1.Service{svc}, nil
+	}
+
+	svcList := &corev1.ServiceList{}
+	if err := m.client.List(ctx, svcList); err != nil {
+		return nil, err
+	}
+	candidates := make([]*corev1.Service, 0, len(svcList.Items))
+	for index := range svcList.Items {
+		candidates = append(candidates, &svcList.Items[index])
+	}
+	return candidates, nil
+}
+
+// isGroupMember checks whether a service is member of a Service group
+func (m *defaultGroupLoader) isGroupMember(ctx context.Context, groupID GroupID, svc *corev1.Service) (bool, error) {
+	svcGroupID, err := m.FindGroupID(ctx, svc)
+	if err != nil {
+		if errors.Is(err, errInvalidServiceGroup) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if svcGroupID == nil || (*svcGroupID) != groupID {
+		return false, nil
+	}
+
+	return svc.DeletionTimestamp.IsZero(), nil
+}
+
+// sortGroupMembers sorts Services within Service group by lexical order of their full-qualified name.
+// the first member determines the settings of the NLB shared by the group.
+func sortGroupMembers(members []*corev1.Service) {
+	sort.Slice(members, func(i, j int) bool {
+		return k8s.NamespacedName(members[i]).String() < k8s.NamespacedName(members[j]).String()
+	})
+}
+
+// validateGroupName validates whether Service group name is valid
+func validateGroupName(groupName string) error {
+	if !groupNameRegex.MatchString(groupName) {
+		return errors.New("groupName must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character")
+	}
+	if len(groupName) > maxGroupNameLength {
+		return errors.Errorf("groupName must be no more than %v characters", maxGroupNameLength)
+	}
+	return nil
+}
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+	"time"
+)
+
+func Test_defaultGroupLoader_FindGroupID(t *testing.T) {
+	tests := []struct {
+		name    string
+		svc     *corev1.Service
+		want    *GroupID
+		wantErr string
+	}{
+		{
+			name: "service without nlb-ip load balancer type",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "svc-1",
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-group-name": "awesome-group",
+					},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "service without group name",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "svc-1",
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type": "nlb-ip",
+					},
+				},
+			},
+			want: &GroupID{Namespace: "awesome-ns", Name: "svc-1"},
+		},
+		{
+			name: "service with group name",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "svc-1",
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type":       "nlb-ip",
+						"service.beta.kubernetes.io/aws-load-balancer-group-name": "awesome-group",
+					},
+				},
+			},
+			want: &GroupID{Namespace: "", Name: "awesome-group"},
+		},
+		{
+			name: "service with invalid group name",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "svc-1",
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type":       "nlb-ip",
+						"service.beta.kubernetes.io/aws-load-balancer-group-name": "Awesome_Group",
+					},
+				},
+			},
+			wantErr: "invalid service group: groupName must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			annotationParser := annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io")
+			m := NewDefaultGroupLoader(k8sClient, annotationParser, k8s.NewDefaultNamespaceMatcher(k8sClient, labels.Everything()))
+			got, err := m.FindGroupID(context.Background(), tt.svc)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultGroupLoader_Load(t *testing.T) {
+	now := metav1.NewTime(time.Now())
+	svcB := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns-b",
+			Name:      "svc-b",
+			Annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-type":       "nlb-ip",
+				"service.beta.kubernetes.io/aws-load-balancer-group-name": "awesome-group",
+			},
+		},
+	}
+	svcA := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns-a",
+			Name:      "svc-a",
+			Annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-type":       "nlb-ip",
+				"service.beta.kubernetes.io/aws-load-balancer-group-name": "awesome-group",
+			},
+		},
+	}
+	svcLeftGroup := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns-a",
+			Name:      "svc-left-group",
+			Annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-type": "nlb-ip",
+			},
+			Finalizers: []string{"group.service.k8s.aws/awesome-group"},
+		},
+	}
+	svcDeleting := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns-a",
+			Name:      "svc-deleting",
+			Annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-type":       "nlb-ip",
+				"service.beta.kubernetes.io/aws-load-balancer-group-name": "awesome-group",
+			},
+			Finalizers:        []string{"group.service.k8s.aws/awesome-group"},
+			DeletionTimestamp: &now,
+		},
+	}
+	svcOtherGroup := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns-a",
+			Name:      "svc-other-group",
+			Annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-type":       "nlb-ip",
+				"service.beta.kubernetes.io/aws-load-balancer-group-name": "other-group",
+			},
+		},
+	}
+	svcStandalone := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns-a",
+			Name:      "svc-standalone",
+			Annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-type": "nlb-ip",
+			},
+			Finalizers: []string{"service.k8s.aws/resources"},
+		},
+	}
+	svcStandaloneJoinedGroup := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns-a",
+			Name:      "svc-standalone-joined-group",
+			Annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-type":       "nlb-ip",
+				"service.beta.kubernetes.io/aws-load-balancer-group-name": "other-group",
+			},
+			Finalizers: []string{"service.k8s.aws/resources"},
+		},
+	}
+	tests := []struct {
+		name                string
+		groupID             GroupID
+		wantMembers         []types.NamespacedName
+		wantInactiveMembers []types.NamespacedName
+	}{
+		{
+			name:                "explicit group",
+			groupID:             NewGroupIDForExplicitGroup("awesome-group"),
+			wantMembers:         []types.NamespacedName{k8s.NamespacedName(svcA), k8s.NamespacedName(svcB)},
+			wantInactiveMembers: []types.NamespacedName{k8s.NamespacedName(svcDeleting), k8s.NamespacedName(svcLeftGroup)},
+		},
+		{
+			name:        "implicit group",
+			groupID:     NewGroupIDForImplicitGroup(k8s.NamespacedName(svcStandalone)),
+			wantMembers: []types.NamespacedName{k8s.NamespacedName(svcStandalone)},
+		},
+		{
+			name:                "implicit group of service that joined explicit group",
+			groupID:             NewGroupIDForImplicitGroup(k8s.NamespacedName(svcStandaloneJoinedGroup)),
+			wantInactiveMembers: []types.NamespacedName{k8s.NamespacedName(svcStandaloneJoinedGroup)},
+		},
+		{
+			name:    "implicit group of non-existent service",
+			groupID: NewGroupIDForImplicitGroup(types.NamespacedName{Namespace: "ns-a", Name: "svc-non-existent"}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			for _, svc := range []*corev1.Service{svcB, svcA, svcLeftGroup, svcDeleting, svcOtherGroup, svcStandalone, svcStandaloneJoinedGroup} {
+				assert.NoError(t, k8sClient.Create(ctx, svc.DeepCopy()))
+			}
+			annotationParser := annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io")
+			m := NewDefaultGroupLoader(k8sClient, annotationParser, k8s.NewDefaultNamespaceMatcher(k8sClient, labels.Everything()))
+			got, err := m.Load(ctx, tt.groupID)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.groupID, got.ID)
+			var gotMembers, gotInactiveMembers []types.NamespacedName
+			for _, svc := range got.Members {
+				gotMembers = append(gotMembers, k8s.NamespacedName(svc))
+			}
+			for _, svc := range got.InactiveMembers {
+				gotInactiveMembers = append(gotInactiveMembers, k8s.NamespacedName(svc))
+			}
+			assert.Equal(t, tt.wantMembers, gotMembers)
+			assert.ElementsMatch(t, tt.wantInactiveMembers, gotInactiveMembers)
+		})
+	}
+}
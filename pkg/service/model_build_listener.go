@@ -8,23 +8,45 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"strconv"
+	"strings"
 )
 
+const (
+	listenerAttrsTCPPrefix   = "tcp."
+	minTCPIdleTimeoutSeconds = 60
+	maxTCPIdleTimeoutSeconds = 6000
+)
+
+// buildListeners builds the listeners for ports of each member Service, it's an error if members share the same port.
 func (t *defaultModelBuildTask) buildListeners(ctx context.Context) error {
-	cfg := t.buildListenerConfig(ctx)
-	for _, port := range t.service.Spec.Ports {
-		_, err := t.buildListener(ctx, port, cfg)
+	for _, svc := range t.svcGroup.Members {
+		t.service = svc
+		svcKey := k8s.NamespacedName(svc)
+		cfg := t.buildListenerConfig(ctx)
+		ports, protocolByPort, err := mergeServicePorts(svc.Spec.Ports)
 		if err != nil {
-			return err
+			return errors.Wrapf(err, "service: %v", svcKey)
+		}
+		for _, port := range ports {
+			if owner, exists := t.listenerPortOwners[port.Port]; exists && owner != svcKey {
+				return errors.Errorf("conflicting listener port %v on services in group %v: %v | %v",
+					port.Port, t.svcGroup.ID, owner, svcKey)
+			}
+			t.listenerPortOwners[port.Port] = svcKey
+			_, err := t.buildListener(ctx, port, protocolByPort[port.Port], cfg)
+			if err != nil {
+				return err
+			}
 		}
 	}
 	return nil
 }
 
-func (t *defaultModelBuildTask) buildListener(ctx context.Context, port corev1.ServicePort, cfg listenerConfig) (*elbv2model.Listener, error) {
-	lsSpec, err := t.buildListenerSpec(ctx, port, cfg)
+func (t *defaultModelBuildTask) buildListener(ctx context.Context, port corev1.ServicePort, protocol elbv2model.Protocol, cfg listenerConfig) (*elbv2model.Listener, error) {
+	lsSpec, err := t.buildListenerSpec(ctx, port, protocol, cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -33,10 +55,10 @@ func (t *defaultModelBuildTask) buildListener(ctx context.Context, port corev1.S
 	return ls, nil
 }
 
-func (t *defaultModelBuildTask) buildListenerSpec(ctx context.Context, port corev1.ServicePort, cfg listenerConfig) (elbv2model.ListenerSpec, error) {
-	tgProtocol := elbv2model.Protocol(port.Protocol)
-	listenerProtocol := elbv2model.Protocol(port.Protocol)
-	if tgProtocol != elbv2model.ProtocolUDP && len(cfg.certificates) != 0 && (cfg.tlsPortsSet.Len() == 0 ||
+func (t *defaultModelBuildTask) buildListenerSpec(ctx context.Context, port corev1.ServicePort, protocol elbv2model.Protocol, cfg listenerConfig) (elbv2model.ListenerSpec, error) {
+	tgProtocol := protocol
+	listenerProtocol := protocol
+	if tgProtocol != elbv2model.ProtocolUDP && tgProtocol != elbv2model.ProtocolTCP_UDP && len(cfg.certificates) != 0 && (cfg.tlsPortsSet.Len() == 0 ||
 		cfg.tlsPortsSet.Has(port.Name) || cfg.tlsPortsSet.Has(strconv.Itoa(int(port.Port)))) {
 		if cfg.backendProtocol == "ssl" {
 			tgProtocol = elbv2model.ProtocolTLS
@@ -61,15 +83,21 @@ func (t *defaultModelBuildTask) buildListenerSpec(ctx context.Context, port core
 		certificates = cfg.certificates
 	}
 
+	listenerAttributes, err := t.buildListenerAttributes(ctx, listenerProtocol)
+	if err != nil {
+		return elbv2model.ListenerSpec{}, err
+	}
+
 	defaultActions := t.buildListenerDefaultActions(ctx, targetGroup)
 	return elbv2model.ListenerSpec{
-		LoadBalancerARN: t.loadBalancer.LoadBalancerARN(),
-		Port:            int64(port.Port),
-		Protocol:        listenerProtocol,
-		Certificates:    certificates,
-		SSLPolicy:       sslPolicy,
-		ALPNPolicy:      alpnPolicy,
-		DefaultActions:  defaultActions,
+		LoadBalancerARN:    t.loadBalancer.LoadBalancerARN(),
+		Port:               int64(port.Port),
+		Protocol:           listenerProtocol,
+		Certificates:       certificates,
+		SSLPolicy:          sslPolicy,
+		ALPNPolicy:         alpnPolicy,
+		DefaultActions:     defaultActions,
+		ListenerAttributes: listenerAttributes,
 	}, nil
 }
 
@@ -139,6 +167,42 @@ func (t *defaultModelBuildTask) buildListenerALPNPolicy(ctx context.Context, lis
 	}
 }
 
+// buildListenerAttributes builds the listener attributes for listener with listenerProtocol.
+// attributes specific to TCP listeners(tcp.*) are only applied to TCP and TCP_UDP listeners.
+func (t *defaultModelBuildTask) buildListenerAttributes(_ context.Context, listenerProtocol elbv2model.Protocol) ([]elbv2model.ListenerAttribute, error) {
+	var rawAttributes map[string]string
+	if _, err := t.annotationParser.ParseStringMapAnnotation(annotations.SvcLBSuffixListenerAttributes, &rawAttributes, t.service.Annotations); err != nil {
+		return nil, err
+	}
+	if err := validateListenerAttributes(rawAttributes); err != nil {
+		return nil, err
+	}
+	isTCPListener := listenerProtocol == elbv2model.ProtocolTCP || listenerProtocol == elbv2model.ProtocolTCP_UDP
+	var attributes []elbv2model.ListenerAttribute
+	for _, attrKey := range sets.StringKeySet(rawAttributes).List() {
+		if strings.HasPrefix(attrKey, listenerAttrsTCPPrefix) && !isTCPListener {
+			continue
+		}
+		attributes = append(attributes, elbv2model.ListenerAttribute{
+			Key:   attrKey,
+			Value: rawAttributes[attrKey],
+		})
+	}
+	return attributes, nil
+}
+
+// validateListenerAttributes validates the values of well-known listener attributes.
+func validateListenerAttributes(rawAttributes map[string]string) error {
+	if rawIdleTimeout, ok := rawAttributes[elbv2model.ListenerAttributeKeyTCPIdleTimeoutSeconds]; ok {
+		idleTimeout, err := strconv.ParseInt(rawIdleTimeout, 10, 64)
+		if err != nil || idleTimeout < minTCPIdleTimeoutSeconds || idleTimeout > maxTCPIdleTimeoutSeconds {
+			return errors.Errorf("invalid listener attribute %v=%v, must be an integer between %v and %v",
+				elbv2model.ListenerAttributeKeyTCPIdleTimeoutSeconds, rawIdleTimeout, minTCPIdleTimeoutSeconds, maxTCPIdleTimeoutSeconds)
+		}
+	}
+	return nil
+}
+
 type listenerConfig struct {
 	certificates    []elbv2model.Certificate
 	tlsPortsSet     sets.String
@@ -159,3 +223,38 @@ func (t *defaultModelBuildTask) buildListenerConfig(ctx context.Context) listene
 		backendProtocol: backendProtocol,
 	}
 }
+
+// mergeServicePorts merges ServicePorts of mixed-protocol Services, so that each port is served by a single listener.
+// ServicePorts with TCP and UDP protocol on the same port are served by a TCP_UDP listener, they must share the same targetPort and nodePort.
+// it returns the merged ServicePorts in their original order, along with the listener protocol for each port.
+func mergeServicePorts(ports []corev1.ServicePort) ([]corev1.ServicePort, map[int32]elbv2model.Protocol, error) {
+	var mergedPorts []corev1.ServicePort
+	protocolByPort := make(map[int32]elbv2model.Protocol, len(ports))
+	mergedPortByPort := make(map[int32]corev1.ServicePort, len(ports))
+	for _, port := range ports {
+		protocol := elbv2model.Protocol(port.Protocol)
+		existingPort, exists := mergedPortByPort[port.Port]
+		if !exists {
+			mergedPorts = append(mergedPorts, port)
+			mergedPortByPort[port.Port] = port
+			protocolByPort[port.Port] = protocol
+			continue
+		}
+		existingProtocol := protocolByPort[port.Port]
+		if !((existingProtocol == elbv2model.ProtocolTCP && protocol == elbv2model.ProtocolUDP) ||
+			(existingProtocol == elbv2model.ProtocolUDP && protocol == elbv2model.ProtocolTCP)) {
+			return nil, nil, errors.Errorf("unsupported protocols %v and %v on port %v, only TCP and UDP can share the same port",
+				existingProtocol, protocol, port.Port)
+		}
+		if existingPort.TargetPort != port.TargetPort {
+			return nil, nil, errors.Errorf("TCP and UDP protocol on port %v must have the same targetPort, got %v and %v",
+				port.Port, existingPort.TargetPort.String(), port.TargetPort.String())
+		}
+		if existingPort.NodePort != port.NodePort {
+			return nil, nil, errors.Errorf("TCP and UDP protocol on port %v must have the same nodePort, got %v and %v",
+				port.Port, existingPort.NodePort, port.NodePort)
+		}
+		protocolByPort[port.Port] = elbv2model.ProtocolTCP_UDP
+	}
+	return mergedPorts, protocolByPort, nil
+}
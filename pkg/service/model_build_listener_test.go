@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"testing"
@@ -86,3 +87,207 @@ func Test_defaultModelBuilderTask_buildListenerALPNPolicy(t *testing.T) {
 		})
 	}
 }
+
+func Test_mergeServicePorts(t *testing.T) {
+	tests := []struct {
+		name               string
+		ports              []corev1.ServicePort
+		wantPorts          []corev1.ServicePort
+		wantProtocolByPort map[int32]elbv2model.Protocol
+		wantErr            error
+	}{
+		{
+			name: "distinct ports",
+			ports: []corev1.ServicePort{
+				{Name: "http", Port: 80, TargetPort: intstr.FromInt(8080), NodePort: 31080, Protocol: corev1.ProtocolTCP},
+				{Name: "dns", Port: 53, TargetPort: intstr.FromInt(53), NodePort: 31053, Protocol: corev1.ProtocolUDP},
+			},
+			wantPorts: []corev1.ServicePort{
+				{Name: "http", Port: 80, TargetPort: intstr.FromInt(8080), NodePort: 31080, Protocol: corev1.ProtocolTCP},
+				{Name: "dns", Port: 53, TargetPort: intstr.FromInt(53), NodePort: 31053, Protocol: corev1.ProtocolUDP},
+			},
+			wantProtocolByPort: map[int32]elbv2model.Protocol{
+				80: elbv2model.ProtocolTCP,
+				53: elbv2model.ProtocolUDP,
+			},
+		},
+		{
+			name: "TCP and UDP on same port",
+			ports: []corev1.ServicePort{
+				{Name: "dns-udp", Port: 53, TargetPort: intstr.FromInt(53), NodePort: 31053, Protocol: corev1.ProtocolUDP},
+				{Name: "dns-tcp", Port: 53, TargetPort: intstr.FromInt(53), NodePort: 31053, Protocol: corev1.ProtocolTCP},
+			},
+			wantPorts: []corev1.ServicePort{
+				{Name: "dns-udp", Port: 53, TargetPort: intstr.FromInt(53), NodePort: 31053, Protocol: corev1.ProtocolUDP},
+			},
+			wantProtocolByPort: map[int32]elbv2model.Protocol{
+				53: elbv2model.ProtocolTCP_UDP,
+			},
+		},
+		{
+			name: "TCP and UDP on same port with different targetPort",
+			ports: []corev1.ServicePort{
+				{Name: "dns-udp", Port: 53, TargetPort: intstr.FromInt(53), Protocol: corev1.ProtocolUDP},
+				{Name: "dns-tcp", Port: 53, TargetPort: intstr.FromInt(5353), Protocol: corev1.ProtocolTCP},
+			},
+			wantErr: errors.New("TCP and UDP protocol on port 53 must have the same targetPort, got 53 and 5353"),
+		},
+		{
+			name: "TCP and UDP on same port with different nodePort",
+			ports: []corev1.ServicePort{
+				{Name: "dns-udp", Port: 53, TargetPort: intstr.FromInt(53), NodePort: 31053, Protocol: corev1.ProtocolUDP},
+				{Name: "dns-tcp", Port: 53, TargetPort: intstr.FromInt(53), NodePort: 31054, Protocol: corev1.ProtocolTCP},
+			},
+			wantErr: errors.New("TCP and UDP protocol on port 53 must have the same nodePort, got 31053 and 31054"),
+		},
+		{
+			name: "SCTP and TCP on same port",
+			ports: []corev1.ServicePort{
+				{Name: "tcp", Port: 80, TargetPort: intstr.FromInt(80), Protocol: corev1.ProtocolTCP},
+				{Name: "sctp", Port: 80, TargetPort: intstr.FromInt(80), Protocol: corev1.ProtocolSCTP},
+			},
+			wantErr: errors.New("unsupported protocols TCP and SCTP on port 80, only TCP and UDP can share the same port"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotPorts, gotProtocolByPort, err := mergeServicePorts(tt.ports)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantPorts, gotPorts)
+				assert.Equal(t, tt.wantProtocolByPort, gotProtocolByPort)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuilderTask_buildListenerAttributes(t *testing.T) {
+	tests := []struct {
+		name             string
+		svc              *corev1.Service
+		listenerProtocol elbv2model.Protocol
+		want             []elbv2model.ListenerAttribute
+		wantErr          error
+	}{
+		{
+			name:             "Service without annotation",
+			svc:              &corev1.Service{},
+			listenerProtocol: elbv2model.ProtocolTCP,
+		},
+		{
+			name: "Service with tcp idle timeout, TCP listener",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-listener-attributes": "tcp.idle_timeout.seconds=600",
+					},
+				},
+			},
+			listenerProtocol: elbv2model.ProtocolTCP,
+			want: []elbv2model.ListenerAttribute{
+				{
+					Key:   "tcp.idle_timeout.seconds",
+					Value: "600",
+				},
+			},
+		},
+		{
+			name: "Service with tcp idle timeout, TCP_UDP listener",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-listener-attributes": "tcp.idle_timeout.seconds=6000",
+					},
+				},
+			},
+			listenerProtocol: elbv2model.ProtocolTCP_UDP,
+			want: []elbv2model.ListenerAttribute{
+				{
+					Key:   "tcp.idle_timeout.seconds",
+					Value: "6000",
+				},
+			},
+		},
+		{
+			name: "Service with tcp idle timeout, UDP listener",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-listener-attributes": "tcp.idle_timeout.seconds=600,some.attribute=value",
+					},
+				},
+			},
+			listenerProtocol: elbv2model.ProtocolUDP,
+			want: []elbv2model.ListenerAttribute{
+				{
+					Key:   "some.attribute",
+					Value: "value",
+				},
+			},
+		},
+		{
+			name: "Service with multiple attributes, sorted by key",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-listener-attributes": "tcp.idle_timeout.seconds=60,some.attribute=value",
+					},
+				},
+			},
+			listenerProtocol: elbv2model.ProtocolTCP,
+			want: []elbv2model.ListenerAttribute{
+				{
+					Key:   "some.attribute",
+					Value: "value",
+				},
+				{
+					Key:   "tcp.idle_timeout.seconds",
+					Value: "60",
+				},
+			},
+		},
+		{
+			name: "Service with tcp idle timeout out of range",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-listener-attributes": "tcp.idle_timeout.seconds=30",
+					},
+				},
+			},
+			listenerProtocol: elbv2model.ProtocolTCP,
+			wantErr:          errors.New("invalid listener attribute tcp.idle_timeout.seconds=30, must be an integer between 60 and 6000"),
+		},
+		{
+			name: "Service with non-integer tcp idle timeout",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-listener-attributes": "tcp.idle_timeout.seconds=10m",
+					},
+				},
+			},
+			listenerProtocol: elbv2model.ProtocolTCP,
+			wantErr:          errors.New("invalid listener attribute tcp.idle_timeout.seconds=10m, must be an integer between 60 and 6000"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io")
+			builder := &defaultModelBuildTask{
+				annotationParser: parser,
+				service:          tt.svc,
+			}
+			got, err := builder.buildListenerAttributes(context.Background(), tt.listenerProtocol)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
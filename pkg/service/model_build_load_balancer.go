@@ -8,8 +8,12 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"regexp"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
 	"strconv"
@@ -46,7 +50,17 @@ func (t *defaultModelBuildTask) buildLoadBalancerSpec(ctx context.Context, schem
 	if err != nil {
 		return elbv2model.LoadBalancerSpec{}, err
 	}
-	subnetMappings, err := t.buildLoadBalancerSubnetMappings(ctx, t.ec2Subnets)
+	subnetMappings, err := t.buildLoadBalancerSubnetMappings(ctx, scheme, t.ec2Subnets)
+	if err != nil {
+		return elbv2model.LoadBalancerSpec{}, err
+	}
+	var rawSubnetNameOrIDs []string
+	subnetsAutoDiscovered := !t.annotationParser.ParseStringSliceAnnotation(annotations.SvcLBSuffixSubnets, &rawSubnetNameOrIDs, t.service.Annotations)
+	securityGroups, err := t.buildLoadBalancerSecurityGroups(ctx, ipAddressType)
+	if err != nil {
+		return elbv2model.LoadBalancerSpec{}, err
+	}
+	replacement, err := t.buildLoadBalancerReplacement(ctx)
 	if err != nil {
 		return elbv2model.LoadBalancerSpec{}, err
 	}
@@ -57,12 +71,25 @@ func (t *defaultModelBuildTask) buildLoadBalancerSpec(ctx context.Context, schem
 		Scheme:                 &scheme,
 		IPAddressType:          &ipAddressType,
 		SubnetMappings:         subnetMappings,
+		SubnetsAutoDiscovered:  subnetsAutoDiscovered,
+		SecurityGroups:         securityGroups,
 		LoadBalancerAttributes: lbAttributes,
 		Tags:                   tags,
+		Replacement:            replacement,
 	}
 	return spec, nil
 }
 
+// buildLoadBalancerReplacement builds how the LoadBalancer is replaced from Services within ServiceGroup.
+func (t *defaultModelBuildTask) buildLoadBalancerReplacement(_ context.Context) (*elbv2model.LoadBalancerReplacement, error) {
+	memberAnnotations := make([]map[string]string, 0, len(t.svcGroup.Members))
+	for _, svc := range t.svcGroup.Members {
+		memberAnnotations = append(memberAnnotations, svc.Annotations)
+	}
+	return deploy.ResolveLoadBalancerReplacement(t.annotationParser, annotations.SvcLBSuffixReplacementStrategy,
+		annotations.SvcLBSuffixReplacementDrainWindow, memberAnnotations...)
+}
+
 func (t *defaultModelBuildTask) buildLoadBalancerIPAddressType(_ context.Context) (elbv2model.IPAddressType, error) {
 	rawIPAddressType := ""
 	if exists := t.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixIPAddressType, &rawIPAddressType, t.service.Annotations); !exists{
@@ -79,6 +106,20 @@ func (t *defaultModelBuildTask) buildLoadBalancerIPAddressType(_ context.Context
 	}
 }
 
+func (t *defaultModelBuildTask) buildLoadBalancerSecurityGroups(ctx context.Context, ipAddressType elbv2model.IPAddressType) ([]core.StringToken, error) {
+	sgs, err := t.buildManagedSecurityGroups(ctx, ipAddressType)
+	if err != nil {
+		return nil, err
+	}
+	if len(sgs) == 0 {
+		return nil, nil
+	}
+	sgIDTokens := make([]core.StringToken, 0, len(sgs))
+	for _, sg := range sgs {
+		sgIDTokens = append(sgIDTokens, sg.GroupID())
+	}
+	return sgIDTokens, nil
+}
 
 func (t *defaultModelBuildTask) buildLoadBalancerScheme(_ context.Context) (elbv2model.LoadBalancerScheme, error) {
 	internal := false
@@ -97,6 +138,12 @@ func (t *defaultModelBuildTask) buildAdditionalResourceTags(_ context.Context) (
 	if err != nil {
 		return nil, err
 	}
+	// tags from annotations take precedence over the default tags from dynamic config.
+	for tagKey, tagValue := range t.defaultTags {
+		if _, exists := tags[tagKey]; !exists {
+			tags[tagKey] = tagValue
+		}
+	}
 	return tags, nil
 }
 
@@ -104,44 +151,119 @@ func (t *defaultModelBuildTask) buildLoadBalancerTags(ctx context.Context) (map[
 	return t.buildAdditionalResourceTags(ctx)
 }
 
-func (t *defaultModelBuildTask) buildLoadBalancerSubnetMappings(_ context.Context, ec2Subnets []*ec2.Subnet) ([]elbv2model.SubnetMapping, error) {
+func (t *defaultModelBuildTask) buildLoadBalancerSubnetMappings(ctx context.Context, scheme elbv2model.LoadBalancerScheme, ec2Subnets []*ec2.Subnet) ([]elbv2model.SubnetMapping, error) {
 	var eipAllocation []string
 	eipConfigured := t.annotationParser.ParseStringSliceAnnotation(annotations.SvcLBSuffixEIPAllocations, &eipAllocation, t.service.Annotations)
 	if eipConfigured && len(eipAllocation) != len(ec2Subnets) {
 		return []elbv2model.SubnetMapping{}, errors.Errorf("number of EIP allocations (%d) and subnets (%d) must match", len(eipAllocation), len(ec2Subnets))
 	}
+	eipPoolName := ""
+	eipPoolConfigured := t.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixEIPPool, &eipPoolName, t.service.Annotations)
+	if eipPoolConfigured {
+		if eipConfigured {
+			return []elbv2model.SubnetMapping{}, errors.New("EIP allocations and EIP pool cannot be specified together")
+		}
+		if scheme != elbv2model.LoadBalancerSchemeInternetFacing {
+			return []elbv2model.SubnetMapping{}, errors.Errorf("EIP pool is only supported for %v scheme", elbv2model.LoadBalancerSchemeInternetFacing)
+		}
+	}
+	ipamPoolID := ""
+	ipamPoolConfigured := t.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixIPAMPool, &ipamPoolID, t.service.Annotations)
+	if ipamPoolConfigured {
+		if !t.ipamEnabled {
+			return []elbv2model.SubnetMapping{}, errors.New("IPAM pool requires the IPAM addon, which is enabled via --enable-ipam")
+		}
+		if scheme != elbv2model.LoadBalancerSchemeInternal {
+			return []elbv2model.SubnetMapping{}, errors.Errorf("IPAM pool is only supported for %v scheme", elbv2model.LoadBalancerSchemeInternal)
+		}
+	}
 	subnetMappings := make([]elbv2model.SubnetMapping, 0, len(ec2Subnets))
 	for idx, subnet := range ec2Subnets {
 		mapping := elbv2model.SubnetMapping{
 			SubnetID: aws.StringValue(subnet.SubnetId),
 		}
 		if idx < len(eipAllocation) {
-			mapping.AllocationID = aws.String(eipAllocation[idx])
+			mapping.AllocationID = core.LiteralStringToken(eipAllocation[idx])
+		}
+		if eipPoolConfigured {
+			eip, err := t.buildElasticIP(ctx, eipPoolName, mapping.SubnetID)
+			if err != nil {
+				return []elbv2model.SubnetMapping{}, err
+			}
+			mapping.AllocationID = eip.AllocationID()
+		}
+		if ipamPoolConfigured {
+			allocation := t.buildIPAMPoolAllocation(ctx, ipamPoolID, subnet)
+			mapping.PrivateIPv4Address = allocation.PrivateIPv4Address()
 		}
 		subnetMappings = append(subnetMappings, mapping)
 	}
 	return subnetMappings, nil
 }
 
+// buildElasticIP builds the ElasticIP claimed from pool for subnet.
+func (t *defaultModelBuildTask) buildElasticIP(ctx context.Context, poolName string, subnetID string) (*ec2model.ElasticIP, error) {
+	allocateIfPoolEmpty := false
+	if _, err := t.annotationParser.ParseBoolAnnotation(annotations.SvcLBSuffixEIPPoolAllocate, &allocateIfPoolEmpty, t.service.Annotations); err != nil {
+		return nil, err
+	}
+	tags, err := t.buildAdditionalResourceTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resID := fmt.Sprintf("ElasticIP-%v", subnetID)
+	return ec2model.NewElasticIP(t.stack, resID, ec2model.ElasticIPSpec{
+		PoolName:            poolName,
+		AllocateIfPoolEmpty: allocateIfPoolEmpty,
+		Tags:                tags,
+	}), nil
+}
+
+// buildIPAMPoolAllocation builds the IPAMPoolAllocation of the private IPv4 address for subnet.
+func (t *defaultModelBuildTask) buildIPAMPoolAllocation(_ context.Context, ipamPoolID string, subnet *ec2.Subnet) *ec2model.IPAMPoolAllocation {
+	subnetID := aws.StringValue(subnet.SubnetId)
+	resID := fmt.Sprintf("IPAMPoolAllocation-%v", subnetID)
+	return ec2model.NewIPAMPoolAllocation(t.stack, resID, ec2model.IPAMPoolAllocationSpec{
+		IPAMPoolID: ipamPoolID,
+		SubnetCIDR: aws.StringValue(subnet.CidrBlock),
+	})
+}
+
 func (t *defaultModelBuildTask) resolveLoadBalancerSubnets(ctx context.Context, scheme elbv2model.LoadBalancerScheme) ([]*ec2.Subnet, error) {
+	var excludedSubnets []string
+	t.annotationParser.ParseStringSliceAnnotation(annotations.SvcLBSuffixExcludedSubnets, &excludedSubnets, t.service.Annotations)
+	resolveOpts := []networking.SubnetsResolveOption{
+		networking.WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeNetwork),
+		networking.WithSubnetsResolveLBScheme(scheme),
+		networking.WithSubnetsResolveExclusions(excludedSubnets),
+	}
 	var rawSubnetNameOrIDs []string
 	if exists := t.annotationParser.ParseStringSliceAnnotation(annotations.SvcLBSuffixSubnets, &rawSubnetNameOrIDs, t.service.Annotations); exists {
-		return t.subnetsResolver.ResolveViaNameOrIDSlice(ctx, rawSubnetNameOrIDs,
-			networking.WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeNetwork),
-			networking.WithSubnetsResolveLBScheme(scheme),
-		)
+		return t.subnetsResolver.ResolveViaNameOrIDSlice(ctx, rawSubnetNameOrIDs, resolveOpts...)
 	}
-	return t.subnetsResolver.ResolveViaDiscovery(ctx,
-		networking.WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeNetwork),
-		networking.WithSubnetsResolveLBScheme(scheme),
-	)
+	var rawSubnetTags map[string]string
+	exists, err := t.annotationParser.ParseStringMapAnnotation(annotations.SvcLBSuffixSubnetTags, &rawSubnetTags, t.service.Annotations)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		subnetTags := make(map[string][]string, len(rawSubnetTags))
+		for tagKey, rawTagValues := range rawSubnetTags {
+			subnetTags[tagKey] = networking.SplitSubnetTagValues(rawTagValues)
+		}
+		return t.subnetsResolver.ResolveViaSelector(ctx, subnetTags, resolveOpts...)
+	}
+	return t.subnetsResolver.ResolveViaDiscovery(ctx, resolveOpts...)
 }
 
 func (t *defaultModelBuildTask) buildLoadBalancerAttributes(_ context.Context) ([]elbv2model.LoadBalancerAttribute, error) {
 	var attrs []elbv2model.LoadBalancerAttribute
-	accessLogEnabled := t.defaultAccessLogS3Enabled
-	bucketName := t.defaultAccessLogsS3Bucket
-	bucketPrefix := t.defaultAccessLogsS3Prefix
+	accessLogEnabled, err := t.buildDefaultBoolLoadBalancerAttribute(lbAttrsAccessLogsS3Enabled, t.defaultAccessLogS3Enabled)
+	if err != nil {
+		return []elbv2model.LoadBalancerAttribute{}, err
+	}
+	bucketName := t.buildDefaultStringLoadBalancerAttribute(lbAttrsAccessLogsS3Bucket, t.defaultAccessLogsS3Bucket)
+	bucketPrefix := t.buildDefaultStringLoadBalancerAttribute(lbAttrsAccessLogsS3Prefix, t.defaultAccessLogsS3Prefix)
 	if _, err := t.annotationParser.ParseBoolAnnotation(annotations.SvcLBSuffixAccessLogEnabled, &accessLogEnabled, t.service.Annotations); err != nil {
 		return []elbv2model.LoadBalancerAttribute{}, err
 	}
@@ -149,7 +271,10 @@ func (t *defaultModelBuildTask) buildLoadBalancerAttributes(_ context.Context) (
 		t.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixAccessLogS3BucketName, &bucketName, t.service.Annotations)
 		t.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixAccessLogS3BucketPrefix, &bucketPrefix, t.service.Annotations)
 	}
-	crossZoneEnabled := t.defaultLoadBalancingCrossZoneEnabled
+	crossZoneEnabled, err := t.buildDefaultBoolLoadBalancerAttribute(lbAttrsLoadBalancingCrossZoneEnabled, t.defaultLoadBalancingCrossZoneEnabled)
+	if err != nil {
+		return []elbv2model.LoadBalancerAttribute{}, err
+	}
 	if _, err := t.annotationParser.ParseBoolAnnotation(annotations.SvcLBSuffixCrossZoneLoadBalancingEnabled, &crossZoneEnabled, t.service.Annotations); err != nil {
 		return []elbv2model.LoadBalancerAttribute{}, err
 	}
@@ -172,19 +297,69 @@ func (t *defaultModelBuildTask) buildLoadBalancerAttributes(_ context.Context) (
 			Value: strconv.FormatBool(crossZoneEnabled),
 		},
 	}
+	clientRoutingPolicy := ""
+	if exists := t.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixDNSRecordClientRoutingPolicy, &clientRoutingPolicy, t.service.Annotations); exists {
+		attrs = append(attrs, elbv2model.LoadBalancerAttribute{
+			Key:   elbv2model.LoadBalancerAttributeKeyDNSRecordClientRoutingPolicy,
+			Value: clientRoutingPolicy,
+		})
+	}
 
+	// default attributes from dynamic config only apply to attributes that aren't explicitly specified.
+	explicitAttrKeys := sets.NewString()
+	for _, attr := range attrs {
+		explicitAttrKeys.Insert(attr.Key)
+	}
+	for _, attrKey := range sets.StringKeySet(t.defaultLoadBalancerAttributes).List() {
+		if explicitAttrKeys.Has(attrKey) {
+			continue
+		}
+		attrs = append(attrs, elbv2model.LoadBalancerAttribute{
+			Key:   attrKey,
+			Value: t.defaultLoadBalancerAttributes[attrKey],
+		})
+	}
 	return attrs, nil
 }
 
+// buildDefaultBoolLoadBalancerAttribute returns the boolean attribute from the default attributes of dynamic config, or fallback if absent.
+func (t *defaultModelBuildTask) buildDefaultBoolLoadBalancerAttribute(attrKey string, fallback bool) (bool, error) {
+	rawValue, exists := t.defaultLoadBalancerAttributes[attrKey]
+	if !exists {
+		return fallback, nil
+	}
+	value, err := strconv.ParseBool(rawValue)
+	if err != nil {
+		return false, errors.Errorf("invalid default load balancer attribute %v: %v", attrKey, rawValue)
+	}
+	return value, nil
+}
+
+// buildDefaultStringLoadBalancerAttribute returns the attribute from the default attributes of dynamic config, or fallback if absent.
+func (t *defaultModelBuildTask) buildDefaultStringLoadBalancerAttribute(attrKey string, fallback string) string {
+	if value, exists := t.defaultLoadBalancerAttributes[attrKey]; exists {
+		return value
+	}
+	return fallback
+}
+
 var invalidLoadBalancerNamePattern = regexp.MustCompile("[[:^alnum:]]")
 
 func (t *defaultModelBuildTask) buildLoadBalancerName(_ context.Context, scheme elbv2model.LoadBalancerScheme) string {
 	uuidHash := sha256.New()
 	_, _ = uuidHash.Write([]byte(t.clusterName))
-	_, _ = uuidHash.Write([]byte(t.service.UID))
+	if t.svcGroup.ID.IsExplicit() {
+		_, _ = uuidHash.Write([]byte(t.svcGroup.ID.Name))
+	} else {
+		_, _ = uuidHash.Write([]byte(t.service.UID))
+	}
 	_, _ = uuidHash.Write([]byte(scheme))
 	uuid := hex.EncodeToString(uuidHash.Sum(nil))
 
+	if t.svcGroup.ID.IsExplicit() {
+		payload := invalidLoadBalancerNamePattern.ReplaceAllString(t.svcGroup.ID.Name, "")
+		return fmt.Sprintf("k8s-%.17s-%.10s", payload, uuid)
+	}
 	sanitizedNamespace := invalidLoadBalancerNamePattern.ReplaceAllString(t.service.Namespace, "")
 	sanitizedName := invalidLoadBalancerNamePattern.ReplaceAllString(t.service.Name, "")
 	return fmt.Sprintf("k8s-%.8s-%.8s-%.10s", sanitizedNamespace, sanitizedName, uuid)
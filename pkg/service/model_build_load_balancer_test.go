@@ -11,16 +11,19 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	mock_networking "sigs.k8s.io/aws-load-balancer-controller/mocks/networking"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"testing"
 )
 
 func Test_defaultModelBuilderTask_buildLBAttributes(t *testing.T) {
 	tests := []struct {
-		testName  string
-		svc       *corev1.Service
-		wantError bool
-		wantValue []elbv2.LoadBalancerAttribute
+		testName          string
+		svc               *corev1.Service
+		defaultAttributes map[string]string
+		wantError         bool
+		wantValue         []elbv2.LoadBalancerAttribute
 	}{
 		{
 			testName: "Default values",
@@ -84,6 +87,94 @@ func Test_defaultModelBuilderTask_buildLBAttributes(t *testing.T) {
 				},
 			},
 		},
+		{
+			testName: "Client routing policy specified",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type":                             "nlb-ip",
+						"service.beta.kubernetes.io/aws-load-balancer-dns-record-client-routing-policy": "availability_zone_affinity",
+					},
+				},
+			},
+			wantError: false,
+			wantValue: []elbv2.LoadBalancerAttribute{
+				{
+					Key:   lbAttrsAccessLogsS3Enabled,
+					Value: "false",
+				},
+				{
+					Key:   lbAttrsAccessLogsS3Bucket,
+					Value: "",
+				},
+				{
+					Key:   lbAttrsAccessLogsS3Prefix,
+					Value: "",
+				},
+				{
+					Key:   lbAttrsLoadBalancingCrossZoneEnabled,
+					Value: "false",
+				},
+				{
+					Key:   elbv2.LoadBalancerAttributeKeyDNSRecordClientRoutingPolicy,
+					Value: "availability_zone_affinity",
+				},
+			},
+		},
+		{
+			testName: "Default attributes from dynamic config",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type":                              "nlb-ip",
+						"service.beta.kubernetes.io/aws-load-balancer-cross-zone-load-balancing-enabled": "false",
+					},
+				},
+			},
+			defaultAttributes: map[string]string{
+				lbAttrsAccessLogsS3Enabled:           "true",
+				lbAttrsAccessLogsS3Bucket:            "default-bucket",
+				lbAttrsLoadBalancingCrossZoneEnabled: "true",
+				"deletion_protection.enabled":        "true",
+			},
+			wantError: false,
+			wantValue: []elbv2.LoadBalancerAttribute{
+				{
+					Key:   lbAttrsAccessLogsS3Enabled,
+					Value: "true",
+				},
+				{
+					Key:   lbAttrsAccessLogsS3Bucket,
+					Value: "default-bucket",
+				},
+				{
+					Key:   lbAttrsAccessLogsS3Prefix,
+					Value: "",
+				},
+				{
+					Key:   lbAttrsLoadBalancingCrossZoneEnabled,
+					Value: "false",
+				},
+				{
+					Key:   "deletion_protection.enabled",
+					Value: "true",
+				},
+			},
+		},
+		{
+			testName: "Default attributes from dynamic config invalid",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type": "nlb-ip",
+					},
+				},
+			},
+			defaultAttributes: map[string]string{
+				lbAttrsLoadBalancingCrossZoneEnabled: "maybe",
+			},
+			wantError: true,
+		},
 		{
 			testName: "Annotation invalid",
 			svc: &corev1.Service{
@@ -117,6 +208,7 @@ func Test_defaultModelBuilderTask_buildLBAttributes(t *testing.T) {
 				defaultHealthCheckTimeout:            10,
 				defaultHealthCheckHealthyThreshold:   3,
 				defaultHealthCheckUnhealthyThreshold: 3,
+				defaultLoadBalancerAttributes:        tt.defaultAttributes,
 			}
 			lbAttributes, err := builder.buildLoadBalancerAttributes(context.Background())
 			if tt.wantError {
@@ -184,11 +276,11 @@ func Test_defaultModelBuilderTask_buildSubnetMappings(t *testing.T) {
 			want: []elbv2.SubnetMapping{
 				{
 					SubnetID:     "subnet-1",
-					AllocationID: aws.String("eip1"),
+					AllocationID: core.LiteralStringToken("eip1"),
 				},
 				{
 					SubnetID:     "subnet-2",
-					AllocationID: aws.String("eip2"),
+					AllocationID: core.LiteralStringToken("eip2"),
 				},
 			},
 		},
@@ -224,7 +316,7 @@ func Test_defaultModelBuilderTask_buildSubnetMappings(t *testing.T) {
 
 			annotationParser := annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io")
 			builder := &defaultModelBuildTask{service: tt.svc, annotationParser: annotationParser}
-			got, err := builder.buildLoadBalancerSubnetMappings(context.Background(), tt.subnets)
+			got, err := builder.buildLoadBalancerSubnetMappings(context.Background(), elbv2.LoadBalancerSchemeInternetFacing, tt.subnets)
 			if tt.wantErr != nil {
 				assert.EqualError(t, err, tt.wantErr.Error())
 			} else {
@@ -234,6 +326,191 @@ func Test_defaultModelBuilderTask_buildSubnetMappings(t *testing.T) {
 	}
 }
 
+func Test_defaultModelBuildTask_buildLoadBalancerSubnetMappingsWithEIPPool(t *testing.T) {
+	subnets := []*ec2.Subnet{
+		{
+			SubnetId:         aws.String("subnet-1"),
+			AvailabilityZone: aws.String("us-west-2a"),
+			VpcId:            aws.String("vpc-1"),
+		},
+		{
+			SubnetId:         aws.String("subnet-2"),
+			AvailabilityZone: aws.String("us-west-2b"),
+			VpcId:            aws.String("vpc-1"),
+		},
+	}
+	tests := []struct {
+		name           string
+		svcAnnotations map[string]string
+		scheme         elbv2.LoadBalancerScheme
+		wantEIPSpecs   map[string]ec2model.ElasticIPSpec
+		wantErr        error
+	}{
+		{
+			name: "EIP pool",
+			svcAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-eip-pool": "awesome-pool",
+			},
+			scheme: elbv2.LoadBalancerSchemeInternetFacing,
+			wantEIPSpecs: map[string]ec2model.ElasticIPSpec{
+				"ElasticIP-subnet-1": {PoolName: "awesome-pool", Tags: map[string]string{}},
+				"ElasticIP-subnet-2": {PoolName: "awesome-pool", Tags: map[string]string{}},
+			},
+		},
+		{
+			name: "EIP pool with allocation and tags",
+			svcAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-eip-pool":                 "awesome-pool",
+				"service.beta.kubernetes.io/aws-load-balancer-eip-pool-allocate":        "true",
+				"service.beta.kubernetes.io/aws-load-balancer-additional-resource-tags": "team=awesome",
+			},
+			scheme: elbv2.LoadBalancerSchemeInternetFacing,
+			wantEIPSpecs: map[string]ec2model.ElasticIPSpec{
+				"ElasticIP-subnet-1": {PoolName: "awesome-pool", AllocateIfPoolEmpty: true, Tags: map[string]string{"team": "awesome"}},
+				"ElasticIP-subnet-2": {PoolName: "awesome-pool", AllocateIfPoolEmpty: true, Tags: map[string]string{"team": "awesome"}},
+			},
+		},
+		{
+			name: "EIP pool with EIP allocations",
+			svcAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-eip-pool":        "awesome-pool",
+				"service.beta.kubernetes.io/aws-load-balancer-eip-allocations": "eip1, eip2",
+			},
+			scheme:  elbv2.LoadBalancerSchemeInternetFacing,
+			wantErr: errors.New("EIP allocations and EIP pool cannot be specified together"),
+		},
+		{
+			name: "EIP pool with internal scheme",
+			svcAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-eip-pool": "awesome-pool",
+			},
+			scheme:  elbv2.LoadBalancerSchemeInternal,
+			wantErr: errors.New("EIP pool is only supported for internet-facing scheme"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stack := core.NewDefaultStack(core.StackID{Namespace: "awesome-ns", Name: "svc-1"})
+			builder := &defaultModelBuildTask{
+				service: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "awesome-ns",
+						Name:        "svc-1",
+						Annotations: tt.svcAnnotations,
+					},
+				},
+				annotationParser: annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io"),
+				stack:            stack,
+			}
+			got, err := builder.buildLoadBalancerSubnetMappings(context.Background(), tt.scheme, subnets)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			var resEIPs []*ec2model.ElasticIP
+			stack.ListResources(&resEIPs)
+			gotEIPSpecs := make(map[string]ec2model.ElasticIPSpec, len(resEIPs))
+			for _, resEIP := range resEIPs {
+				gotEIPSpecs[resEIP.ID()] = resEIP.Spec
+			}
+			assert.Equal(t, tt.wantEIPSpecs, gotEIPSpecs)
+			for _, mapping := range got {
+				deps := mapping.AllocationID.Dependencies()
+				assert.Len(t, deps, 1)
+				assert.Equal(t, "ElasticIP-"+mapping.SubnetID, deps[0].ID())
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildLoadBalancerSubnetMappingsWithIPAMPool(t *testing.T) {
+	subnets := []*ec2.Subnet{
+		{
+			SubnetId:  aws.String("subnet-1"),
+			CidrBlock: aws.String("192.168.0.0/19"),
+		},
+		{
+			SubnetId:  aws.String("subnet-2"),
+			CidrBlock: aws.String("192.168.32.0/19"),
+		},
+	}
+	tests := []struct {
+		name                string
+		svcAnnotations      map[string]string
+		ipamEnabled         bool
+		scheme              elbv2.LoadBalancerScheme
+		wantAllocationSpecs map[string]ec2model.IPAMPoolAllocationSpec
+		wantErr             error
+	}{
+		{
+			name: "IPAM pool",
+			svcAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-ipam-pool": "ipam-pool-1",
+			},
+			ipamEnabled: true,
+			scheme:      elbv2.LoadBalancerSchemeInternal,
+			wantAllocationSpecs: map[string]ec2model.IPAMPoolAllocationSpec{
+				"IPAMPoolAllocation-subnet-1": {IPAMPoolID: "ipam-pool-1", SubnetCIDR: "192.168.0.0/19"},
+				"IPAMPoolAllocation-subnet-2": {IPAMPoolID: "ipam-pool-1", SubnetCIDR: "192.168.32.0/19"},
+			},
+		},
+		{
+			name: "IPAM pool with internet-facing scheme",
+			svcAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-ipam-pool": "ipam-pool-1",
+			},
+			ipamEnabled: true,
+			scheme:      elbv2.LoadBalancerSchemeInternetFacing,
+			wantErr:     errors.New("IPAM pool is only supported for internal scheme"),
+		},
+		{
+			name: "IPAM pool without IPAM addon",
+			svcAnnotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-ipam-pool": "ipam-pool-1",
+			},
+			scheme:  elbv2.LoadBalancerSchemeInternal,
+			wantErr: errors.New("IPAM pool requires the IPAM addon, which is enabled via --enable-ipam"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stack := core.NewDefaultStack(core.StackID{Namespace: "awesome-ns", Name: "svc-1"})
+			builder := &defaultModelBuildTask{
+				service: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "awesome-ns",
+						Name:        "svc-1",
+						Annotations: tt.svcAnnotations,
+					},
+				},
+				annotationParser: annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io"),
+				stack:            stack,
+				ipamEnabled:      tt.ipamEnabled,
+			}
+			got, err := builder.buildLoadBalancerSubnetMappings(context.Background(), tt.scheme, subnets)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			var resAllocations []*ec2model.IPAMPoolAllocation
+			stack.ListResources(&resAllocations)
+			gotAllocationSpecs := make(map[string]ec2model.IPAMPoolAllocationSpec, len(resAllocations))
+			for _, resAllocation := range resAllocations {
+				gotAllocationSpecs[resAllocation.ID()] = resAllocation.Spec
+			}
+			assert.Equal(t, tt.wantAllocationSpecs, gotAllocationSpecs)
+			for _, mapping := range got {
+				assert.Nil(t, mapping.AllocationID)
+				deps := mapping.PrivateIPv4Address.Dependencies()
+				assert.Len(t, deps, 1)
+				assert.Equal(t, "IPAMPoolAllocation-"+mapping.SubnetID, deps[0].ID())
+			}
+		})
+	}
+}
+
 func Test_defaultModelBuilderTask_resolveLoadBalancerSubnets(t *testing.T) {
 	type resolveSubnetResults struct {
 		subnets []*ec2.Subnet
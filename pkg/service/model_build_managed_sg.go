@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"regexp"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/quota"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/securitygrouppolicy"
+	"strings"
+)
+
+const (
+	resourceIDManagedSecurityGroup = "ManagedLBSecurityGroup"
+)
+
+// buildManagedSecurityGroups builds the managed SecurityGroups for the NLB if it's enabled for the service.
+// inbound rules are sharded into additional SecurityGroups when they exceed the rule quota of a single SecurityGroup,
+// up to the quota of SecurityGroups per network interface. The first SecurityGroup is used as the source of traffic to backends.
+func (t *defaultModelBuildTask) buildManagedSecurityGroups(ctx context.Context, ipAddressType elbv2model.IPAddressType) ([]*ec2model.SecurityGroup, error) {
+	manageSG := false
+	if _, err := t.annotationParser.ParseBoolAnnotation(annotations.SvcLBSuffixManageSecurityGroup, &manageSG, t.service.Annotations); err != nil {
+		return nil, err
+	}
+	// the NLB is kept working without managed SecurityGroup when the feature is disabled, instead of failing every Service that asks for one.
+	if manageSG && !t.featureGates.Enabled(config.FeatureNLBSecurityGroup) {
+		t.eventRecorder.Eventf(t.service, corev1.EventTypeWarning, k8s.ServiceEventReasonIgnoredManagedSecurityGroup,
+			"Ignored managed SecurityGroup since the %v feature is disabled", config.FeatureNLBSecurityGroup)
+		manageSG = false
+	}
+	if !manageSG {
+		if err := t.sgPolicyPermissionBuilder.ReportIgnored(ctx, elbv2api.SecurityGroupPolicyTargetKindService, t.buildSecurityGroupPolicyTargets(),
+			"the NLB has no managed SecurityGroup"); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+	sgSpec, err := t.buildManagedSecurityGroupSpec(ctx, ipAddressType)
+	if err != nil {
+		return nil, err
+	}
+	maxRulesPerSG := t.quotaProvider.GetQuotaValue(ctx, quota.QuotaRulesPerSecurityGroup)
+	maxSGs := t.quotaProvider.GetQuotaValue(ctx, quota.QuotaSecurityGroupsPerNetworkInterface)
+	shardSGSpecs, err := networking.ShardSecurityGroupSpec(sgSpec, int(maxRulesPerSG), int(maxSGs))
+	if err != nil {
+		return nil, err
+	}
+
+	sgs := make([]*ec2model.SecurityGroup, 0, len(shardSGSpecs))
+	for shardIndex, shardSGSpec := range shardSGSpecs {
+		resID := resourceIDManagedSecurityGroup
+		if shardIndex > 0 {
+			resID = fmt.Sprintf("%v-%v", resourceIDManagedSecurityGroup, shardIndex)
+		}
+		sgs = append(sgs, ec2model.NewSecurityGroup(t.stack, resID, shardSGSpec))
+	}
+	t.managedSG = sgs[0]
+	return sgs, nil
+}
+
+func (t *defaultModelBuildTask) buildManagedSecurityGroupSpec(ctx context.Context, ipAddressType elbv2model.IPAddressType) (ec2model.SecurityGroupSpec, error) {
+	name := t.buildManagedSecurityGroupName(ctx)
+	tags, err := t.buildAdditionalResourceTags(ctx)
+	if err != nil {
+		return ec2model.SecurityGroupSpec{}, err
+	}
+	ingressPermissions := t.buildManagedSecurityGroupIngressPermissions(ctx, ipAddressType)
+	policyPermissions, err := t.buildManagedSecurityGroupPolicyPermissions(ctx)
+	if err != nil {
+		return ec2model.SecurityGroupSpec{}, err
+	}
+	ingressPermissions = append(ingressPermissions, policyPermissions...)
+	return ec2model.SecurityGroupSpec{
+		GroupName:   name,
+		Description: "[k8s] Managed SecurityGroup for LoadBalancer",
+		Tags:        tags,
+		Ingress:     ingressPermissions,
+	}, nil
+}
+
+var invalidSecurityGroupNamePtn = regexp.MustCompile("[[:^alnum:]]")
+
+func (t *defaultModelBuildTask) buildManagedSecurityGroupName(_ context.Context) string {
+	uuidHash := sha256.New()
+	_, _ = uuidHash.Write([]byte(t.clusterName))
+	if t.svcGroup.ID.IsExplicit() {
+		_, _ = uuidHash.Write([]byte(t.svcGroup.ID.Name))
+		uuid := hex.EncodeToString(uuidHash.Sum(nil))
+		payload := invalidSecurityGroupNamePtn.ReplaceAllString(t.svcGroup.ID.Name, "")
+		return fmt.Sprintf("k8s-%.17s-%.10s", payload, uuid)
+	}
+	_, _ = uuidHash.Write([]byte(t.service.Namespace))
+	_, _ = uuidHash.Write([]byte(t.service.Name))
+	uuid := hex.EncodeToString(uuidHash.Sum(nil))
+
+	sanitizedNamespace := invalidSecurityGroupNamePtn.ReplaceAllString(t.service.Namespace, "")
+	sanitizedName := invalidSecurityGroupNamePtn.ReplaceAllString(t.service.Name, "")
+	return fmt.Sprintf("k8s-%.8s-%.8s-%.10s", sanitizedNamespace, sanitizedName, uuid)
+}
+
+// buildManagedSecurityGroupIngressPermissions allows traffic from source ranges to the service ports of each member Service.
+// When no source ranges configured, 0.0.0.0/0 is allowed, as well as ::/0 for dualstack NLB.
+func (t *defaultModelBuildTask) buildManagedSecurityGroupIngressPermissions(ctx context.Context, ipAddressType elbv2model.IPAddressType) []ec2model.IPPermission {
+	var permissions []ec2model.IPPermission
+	for _, svc := range t.svcGroup.Members {
+		permissions = append(permissions, t.buildServiceIngressPermissions(ctx, svc, ipAddressType)...)
+	}
+	return permissions
+}
+
+// buildManagedSecurityGroupPolicyPermissions builds the inbound permissions from SecurityGroupPolicies targeting member Services.
+func (t *defaultModelBuildTask) buildManagedSecurityGroupPolicyPermissions(ctx context.Context) ([]ec2model.IPPermission, error) {
+	var listenPorts []securitygrouppolicy.ListenPort
+	for _, svc := range t.svcGroup.Members {
+		for _, port := range svc.Spec.Ports {
+			protocol := ec2model.IPProtocolTCP
+			if port.Protocol == corev1.ProtocolUDP {
+				protocol = ec2model.IPProtocolUDP
+			}
+			listenPorts = append(listenPorts, securitygrouppolicy.ListenPort{Protocol: protocol, Port: int64(port.Port)})
+		}
+	}
+	return t.sgPolicyPermissionBuilder.Build(ctx, elbv2api.SecurityGroupPolicyTargetKindService, t.buildSecurityGroupPolicyTargets(), listenPorts)
+}
+
+// buildSecurityGroupPolicyTargets builds the targets of SecurityGroupPolicies that apply to the NLB, which are member Services.
+func (t *defaultModelBuildTask) buildSecurityGroupPolicyTargets() []types.NamespacedName {
+	targets := make([]types.NamespacedName, 0, len(t.svcGroup.Members))
+	for _, svc := range t.svcGroup.Members {
+		targets = append(targets, k8s.NamespacedName(svc))
+	}
+	return targets
+}
+
+// buildServiceIngressPermissions allows traffic from source ranges of Service to its ports.
+func (t *defaultModelBuildTask) buildServiceIngressPermissions(ctx context.Context, svc *corev1.Service, ipAddressType elbv2model.IPAddressType) []ec2model.IPPermission {
+	var inboundCIDRv4s, inboundCIDRv6s []string
+	sourceRanges := t.buildSourceRanges(ctx, svc)
+	if len(sourceRanges) == 0 {
+		inboundCIDRv4s = append(inboundCIDRv4s, "0.0.0.0/0")
+		inboundCIDRv6s = append(inboundCIDRv6s, "::/0")
+	}
+	for _, cidr := range sourceRanges {
+		if strings.Contains(cidr, ":") {
+			inboundCIDRv6s = append(inboundCIDRv6s, cidr)
+		} else {
+			inboundCIDRv4s = append(inboundCIDRv4s, cidr)
+		}
+	}
+
+	var permissions []ec2model.IPPermission
+	for _, port := range svc.Spec.Ports {
+		ipProtocol := "tcp"
+		if port.Protocol == corev1.ProtocolUDP {
+			ipProtocol = "udp"
+		}
+		for _, cidr := range inboundCIDRv4s {
+			permissions = append(permissions, ec2model.IPPermission{
+				IPProtocol: ipProtocol,
+				FromPort:   awssdk.Int64(int64(port.Port)),
+				ToPort:     awssdk.Int64(int64(port.Port)),
+				IPRanges: []ec2model.IPRange{
+					{
+						CIDRIP: cidr,
+					},
+				},
+			})
+		}
+		if ipAddressType == elbv2model.IPAddressTypeDualStack {
+			for _, cidr := range inboundCIDRv6s {
+				permissions = append(permissions, ec2model.IPPermission{
+					IPProtocol: ipProtocol,
+					FromPort:   awssdk.Int64(int64(port.Port)),
+					ToPort:     awssdk.Int64(int64(port.Port)),
+					IPv6Range: []ec2model.IPv6Range{
+						{
+							CIDRIPv6: cidr,
+						},
+					},
+				})
+			}
+		}
+	}
+	return permissions
+}
+
+// buildSourceRanges builds the CIDRs allowed to access the Service ports of LoadBalancer.
+// spec.loadBalancerSourceRanges takes precedence over the source ranges annotation.
+func (t *defaultModelBuildTask) buildSourceRanges(_ context.Context, svc *corev1.Service) []string {
+	var sourceRanges []string
+	for _, cidr := range svc.Spec.LoadBalancerSourceRanges {
+		sourceRanges = append(sourceRanges, cidr)
+	}
+	if len(sourceRanges) == 0 {
+		t.annotationParser.ParseStringSliceAnnotation(annotations.SvcLBSuffixSourceRanges, &sourceRanges, svc.Annotations)
+	}
+	return sourceRanges
+}
@@ -0,0 +1,498 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/quota"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/securitygrouppolicy"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+func Test_defaultModelBuildTask_buildManagedSecurityGroups(t *testing.T) {
+	var manySourceRanges []string
+	for i := 0; i < 70; i++ {
+		manySourceRanges = append(manySourceRanges, fmt.Sprintf("10.0.%d.0/24", i))
+	}
+	tests := []struct {
+		name             string
+		svc              *corev1.Service
+		sgPolicies       []*elbv2api.SecurityGroupPolicy
+		featureGates     config.FeatureGates
+		wantEvents       []string
+		quotaValues      map[string]int64
+		wantSGNames      []string
+		wantIngressCount []int
+		wantErr          string
+	}{
+		{
+			name: "managed securityGroup not enabled",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "awesome-svc",
+				},
+			},
+		},
+		{
+			name: "managed securityGroup enabled",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "awesome-svc",
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-manage-security-group": "true",
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{
+						{
+							Port:     80,
+							Protocol: corev1.ProtocolTCP,
+						},
+					},
+				},
+			},
+			wantSGNames:      []string{"k8s-awesomen-awesomes-57c0490fc6"},
+			wantIngressCount: []int{1},
+		},
+		{
+			name: "managed securityGroup enabled with SecurityGroupPolicy",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "awesome-svc",
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-manage-security-group": "true",
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{
+						{
+							Port:     80,
+							Protocol: corev1.ProtocolTCP,
+						},
+					},
+				},
+			},
+			sgPolicies: []*elbv2api.SecurityGroupPolicy{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "monitoring",
+					},
+					Spec: elbv2api.SecurityGroupPolicySpec{
+						TargetRef: elbv2api.SecurityGroupPolicyTargetReference{
+							Kind: elbv2api.SecurityGroupPolicyTargetKindService,
+							Name: "awesome-svc",
+						},
+						Ingress: []elbv2api.SecurityGroupPolicyIngressRule{
+							{
+								From: []elbv2api.SecurityGroupPolicyPeer{
+									{SecurityGroup: &elbv2api.SecurityGroup{GroupID: "sg-monitoring"}},
+									{PrefixList: &elbv2api.PrefixList{PrefixListID: "pl-office"}},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantSGNames:      []string{"k8s-awesomen-awesomes-57c0490fc6"},
+			wantIngressCount: []int{3},
+		},
+		{
+			name: "managed securityGroup sharded when exceeding rule quota",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "awesome-svc",
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-manage-security-group": "true",
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{
+						{
+							Port:     80,
+							Protocol: corev1.ProtocolTCP,
+						},
+					},
+					LoadBalancerSourceRanges: manySourceRanges,
+				},
+			},
+			wantSGNames:      []string{"k8s-awesomen-awesomes-57c0490fc6", "k8s-awesomen-awesomes-57c0490fc6-1"},
+			wantIngressCount: []int{40, 30},
+		},
+		{
+			name: "managed securityGroup within adjusted rule quota",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "awesome-svc",
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-manage-security-group": "true",
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{
+						{
+							Port:     80,
+							Protocol: corev1.ProtocolTCP,
+						},
+					},
+					LoadBalancerSourceRanges: manySourceRanges,
+				},
+			},
+			quotaValues:      map[string]int64{"Inbound or outbound rules per security group": 100},
+			wantSGNames:      []string{"k8s-awesomen-awesomes-57c0490fc6"},
+			wantIngressCount: []int{70},
+		},
+		{
+			name: "managed securityGroup exceeding rule quota of all securityGroups",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "awesome-svc",
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-manage-security-group": "true",
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{
+						{
+							Port:     80,
+							Protocol: corev1.ProtocolTCP,
+						},
+						{
+							Port:     81,
+							Protocol: corev1.ProtocolTCP,
+						},
+						{
+							Port:     82,
+							Protocol: corev1.ProtocolTCP,
+						},
+						{
+							Port:     83,
+							Protocol: corev1.ProtocolTCP,
+						},
+						{
+							Port:     84,
+							Protocol: corev1.ProtocolTCP,
+						},
+					},
+					LoadBalancerSourceRanges: manySourceRanges,
+				},
+			},
+			wantErr: "too many inbound rules for managed SecurityGroups: 350, exceeds 60 rules in each of 5 SecurityGroups",
+		},
+		{
+			name: "invalid annotation value",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "awesome-svc",
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-manage-security-group": "yes",
+					},
+				},
+			},
+			wantErr: "failed to parse bool annotation, service.beta.kubernetes.io/aws-load-balancer-manage-security-group: yes: strconv.ParseBool: parsing \"yes\": invalid syntax",
+		},
+		{
+			name: "managed securityGroup enabled while feature disabled",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "awesome-svc",
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-manage-security-group": "true",
+					},
+				},
+			},
+			featureGates: config.FeatureGates{config.FeatureNLBSecurityGroup: false},
+			wantEvents:   []string{"Warning IgnoredManagedSecurityGroup Ignored managed SecurityGroup since the NLBSecurityGroup feature is disabled"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io")
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			for _, policy := range tt.sgPolicies {
+				assert.NoError(t, k8sClient.Create(context.Background(), policy.DeepCopy()))
+			}
+			eventRecorder := record.NewFakeRecorder(10)
+			task := &defaultModelBuildTask{
+				clusterName:               "cluster-name",
+				svcGroup:                  Group{ID: NewGroupIDForImplicitGroup(k8s.NamespacedName(tt.svc)), Members: []*corev1.Service{tt.svc}},
+				service:                   tt.svc,
+				annotationParser:          parser,
+				sgPolicyPermissionBuilder: securitygrouppolicy.NewDefaultPermissionBuilder(k8sClient, record.NewFakeRecorder(10)),
+				eventRecorder:             eventRecorder,
+				quotaProvider:             quota.NewStaticProvider(tt.quotaValues),
+				featureGates:              tt.featureGates,
+				stack:                     core.NewDefaultStack(core.StackID{Namespace: tt.svc.Namespace, Name: tt.svc.Name}),
+			}
+			got, err := task.buildManagedSecurityGroups(context.Background(), elbv2model.IPAddressTypeIPV4)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			close(eventRecorder.Events)
+			var gotEvents []string
+			for event := range eventRecorder.Events {
+				gotEvents = append(gotEvents, event)
+			}
+			assert.Equal(t, tt.wantEvents, gotEvents)
+			if len(tt.wantSGNames) == 0 {
+				assert.Nil(t, got)
+				assert.Nil(t, task.managedSG)
+				return
+			}
+			assert.Equal(t, got[0], task.managedSG)
+			var gotSGNames []string
+			var gotIngressCount []int
+			for _, sg := range got {
+				gotSGNames = append(gotSGNames, sg.Spec.GroupName)
+				gotIngressCount = append(gotIngressCount, len(sg.Spec.Ingress))
+			}
+			assert.Equal(t, tt.wantSGNames, gotSGNames)
+			assert.Equal(t, tt.wantIngressCount, gotIngressCount)
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildManagedSecurityGroupIngressPermissions(t *testing.T) {
+	tcpPermission := func(port int64, cidr string) ec2model.IPPermission {
+		return ec2model.IPPermission{
+			IPProtocol: "tcp",
+			FromPort:   awssdk.Int64(port),
+			ToPort:     awssdk.Int64(port),
+			IPRanges: []ec2model.IPRange{
+				{
+					CIDRIP: cidr,
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name          string
+		svc           *corev1.Service
+		ipAddressType elbv2model.IPAddressType
+		want          []ec2model.IPPermission
+	}{
+		{
+			name: "default source ranges with ipv4 IPAddressType",
+			svc: &corev1.Service{
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{
+						{
+							Port:     80,
+							Protocol: corev1.ProtocolTCP,
+						},
+					},
+				},
+			},
+			ipAddressType: elbv2model.IPAddressTypeIPV4,
+			want:          []ec2model.IPPermission{tcpPermission(80, "0.0.0.0/0")},
+		},
+		{
+			name: "default source ranges with dualstack IPAddressType",
+			svc: &corev1.Service{
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{
+						{
+							Port:     53,
+							Protocol: corev1.ProtocolUDP,
+						},
+					},
+				},
+			},
+			ipAddressType: elbv2model.IPAddressTypeDualStack,
+			want: []ec2model.IPPermission{
+				{
+					IPProtocol: "udp",
+					FromPort:   awssdk.Int64(53),
+					ToPort:     awssdk.Int64(53),
+					IPRanges: []ec2model.IPRange{
+						{
+							CIDRIP: "0.0.0.0/0",
+						},
+					},
+				},
+				{
+					IPProtocol: "udp",
+					FromPort:   awssdk.Int64(53),
+					ToPort:     awssdk.Int64(53),
+					IPv6Range: []ec2model.IPv6Range{
+						{
+							CIDRIPv6: "::/0",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "source ranges from annotation",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/load-balancer-source-ranges": "10.0.0.0/16, 2600:1f14::/56",
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{
+						{
+							Port:     443,
+							Protocol: corev1.ProtocolTCP,
+						},
+					},
+				},
+			},
+			ipAddressType: elbv2model.IPAddressTypeIPV4,
+			want:          []ec2model.IPPermission{tcpPermission(443, "10.0.0.0/16")},
+		},
+		{
+			name: "spec.loadBalancerSourceRanges takes precedence",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/load-balancer-source-ranges": "10.0.0.0/16",
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{
+						{
+							Port:     80,
+							Protocol: corev1.ProtocolTCP,
+						},
+						{
+							Port:     443,
+							Protocol: corev1.ProtocolTCP,
+						},
+					},
+					LoadBalancerSourceRanges: []string{"192.168.0.0/16"},
+				},
+			},
+			ipAddressType: elbv2model.IPAddressTypeIPV4,
+			want: []ec2model.IPPermission{
+				tcpPermission(80, "192.168.0.0/16"),
+				tcpPermission(443, "192.168.0.0/16"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io")
+			task := &defaultModelBuildTask{
+				svcGroup:         Group{ID: NewGroupIDForImplicitGroup(k8s.NamespacedName(tt.svc)), Members: []*corev1.Service{tt.svc}},
+				service:          tt.svc,
+				annotationParser: parser,
+			}
+			got := task.buildManagedSecurityGroupIngressPermissions(context.Background(), tt.ipAddressType)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildTargetGroupBindingNetworking_withManagedSecurityGroup(t *testing.T) {
+	networkingProtocolTCP := elbv2api.NetworkingProtocolTCP
+	networkingProtocolUDP := elbv2api.NetworkingProtocolUDP
+	port80 := intstr.FromInt(80)
+	trafficPort := intstr.FromString("traffic-port")
+	stack := core.NewDefaultStack(core.StackID{Namespace: "awesome-ns", Name: "awesome-svc"})
+	managedSG := ec2model.NewSecurityGroup(stack, resourceIDManagedSecurityGroup, ec2model.SecurityGroupSpec{})
+
+	tests := []struct {
+		name             string
+		svc              *corev1.Service
+		tgPort           intstr.IntOrString
+		hcPort           intstr.IntOrString
+		subnets          []*ec2.Subnet
+		tgProtocol       elbv2model.Protocol
+		preserveClientIP bool
+		want             *elbv2model.TargetGroupBindingNetworking
+	}{
+		{
+			name: "udp-service with managed securityGroup",
+			svc: &corev1.Service{
+				Spec: corev1.ServiceSpec{
+					LoadBalancerSourceRanges: []string{"10.0.0.0/16"},
+				},
+			},
+			tgPort: port80,
+			hcPort: trafficPort,
+			subnets: []*ec2.Subnet{{
+				CidrBlock: awssdk.String("172.16.0.0/19"),
+				SubnetId:  awssdk.String("az-1"),
+			}},
+			tgProtocol: elbv2model.ProtocolUDP,
+			want: &elbv2model.TargetGroupBindingNetworking{
+				Ingress: []elbv2model.NetworkingIngressRule{
+					{
+						From: []elbv2model.NetworkingPeer{
+							{
+								SecurityGroup: &elbv2model.SecurityGroup{
+									GroupID: managedSG.GroupID(),
+								},
+							},
+						},
+						Ports: []elbv2api.NetworkingPort{
+							{
+								Protocol: &networkingProtocolUDP,
+								Port:     &port80,
+							},
+						},
+					},
+					{
+						From: []elbv2model.NetworkingPeer{
+							{
+								SecurityGroup: &elbv2model.SecurityGroup{
+									GroupID: managedSG.GroupID(),
+								},
+							},
+						},
+						Ports: []elbv2api.NetworkingPort{
+							{
+								Protocol: &networkingProtocolTCP,
+								Port:     &port80,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io")
+			builder := &defaultModelBuildTask{service: tt.svc, annotationParser: parser, ec2Subnets: tt.subnets, managedSG: managedSG}
+			got := builder.buildTargetGroupBindingNetworking(context.Background(), tt.tgPort, tt.preserveClientIP, tt.hcPort, tt.tgProtocol, elbv2model.TargetGroupIPAddressTypeIPv4)
+			wantJSON, err := json.Marshal(tt.want)
+			assert.NoError(t, err)
+			gotJSON, err := json.Marshal(got)
+			assert.NoError(t, err)
+			assert.JSONEq(t, string(wantJSON), string(gotJSON))
+		})
+	}
+}
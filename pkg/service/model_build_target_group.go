@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -24,8 +25,16 @@ const (
 	tgAttrsProxyProtocolV2Enabled  = "proxy_protocol_v2.enabled"
 	tgAttrsPreserveClientIPEnabled = "preserve_client_ip.enabled"
 	healthCheckPortTrafficPort     = "traffic-port"
+
+	// proxyProtocolV2TLVVPCEndpointID is the PP2_TYPE_AWS(0xEA) TLV with PP2_SUBTYPE_AWS_VPCE_ID(0x01) subtype,
+	// which NLB includes in proxy protocol v2 header with the ID of VPC endpoint that traffic is received from.
+	proxyProtocolV2TLVVPCEndpointID = "vpce-id"
 )
 
+// supportedProxyProtocolV2TLVs are the proxy protocol v2 TLVs supported by NLB.
+// NLB doesn't support custom TLVs for now.
+var supportedProxyProtocolV2TLVs = []string{proxyProtocolV2TLVVPCEndpointID}
+
 func (t *defaultModelBuildTask) buildTargetGroup(ctx context.Context, port corev1.ServicePort, tgProtocol elbv2model.Protocol) (*elbv2model.TargetGroup, error) {
 	svcPort := intstr.FromInt(int(port.Port))
 	tgResourceID := t.buildTargetGroupResourceID(k8s.NamespacedName(t.service), svcPort)
@@ -52,9 +61,13 @@ func (t *defaultModelBuildTask) buildTargetGroup(ctx context.Context, port corev
 	if err != nil {
 		return nil, err
 	}
+	nodeSelector, err := t.buildTargetGroupBindingNodeSelector(ctx, targetType)
+	if err != nil {
+		return nil, err
+	}
 	targetGroup := elbv2model.NewTargetGroup(t.stack, tgResourceID, tgSpec)
 	t.tgByResID[tgResourceID] = targetGroup
-	_ = t.buildTargetGroupBinding(ctx, targetGroup, preserveClientIP, port, healthCheckConfig)
+	_ = t.buildTargetGroupBinding(ctx, targetGroup, preserveClientIP, port, healthCheckConfig, nodeSelector)
 	return targetGroup, nil
 }
 
@@ -64,11 +77,16 @@ func (t *defaultModelBuildTask) buildTargetGroupSpec(ctx context.Context, tgProt
 	if err != nil {
 		return elbv2model.TargetGroupSpec{}, err
 	}
+	ipAddressType, err := t.buildTargetGroupIPAddressType(ctx, targetType)
+	if err != nil {
+		return elbv2model.TargetGroupSpec{}, err
+	}
 	targetPort := t.buildTargetGroupPort(ctx, targetType, port)
-	tgName := t.buildTargetGroupName(ctx, intstr.FromInt(int(port.Port)), targetPort, targetType, tgProtocol, healthCheckConfig)
+	tgName := t.buildTargetGroupName(ctx, intstr.FromInt(int(port.Port)), targetPort, targetType, ipAddressType, tgProtocol, healthCheckConfig)
 	return elbv2model.TargetGroupSpec{
 		Name:                  tgName,
 		TargetType:            targetType,
+		IPAddressType:         &ipAddressType,
 		Port:                  targetPort,
 		Protocol:              tgProtocol,
 		HealthCheckConfig:     healthCheckConfig,
@@ -120,7 +138,7 @@ func (t *defaultModelBuildTask) buildTargetGroupHealthCheckConfig(ctx context.Co
 var invalidTargetGroupNamePattern = regexp.MustCompile("[[:^alnum:]]")
 
 func (t *defaultModelBuildTask) buildTargetGroupName(_ context.Context, svcPort intstr.IntOrString, tgPort int64,
-	targetType elbv2model.TargetType, tgProtocol elbv2model.Protocol, hc *elbv2model.TargetGroupHealthCheckConfig) string {
+	targetType elbv2model.TargetType, ipAddressType elbv2model.TargetGroupIPAddressType, tgProtocol elbv2model.Protocol, hc *elbv2model.TargetGroupHealthCheckConfig) string {
 	healthCheckProtocol := string(elbv2model.ProtocolTCP)
 	healthCheckInterval := strconv.FormatInt(t.defaultHealthCheckInterval, 10)
 	if hc.Protocol != nil {
@@ -135,6 +153,10 @@ func (t *defaultModelBuildTask) buildTargetGroupName(_ context.Context, svcPort
 	_, _ = uuidHash.Write([]byte(strconv.Itoa(int(tgPort))))
 	_, _ = uuidHash.Write([]byte(svcPort.String()))
 	_, _ = uuidHash.Write([]byte(targetType))
+	// ipv4 is excluded to keep names of existing TargetGroups unchanged.
+	if ipAddressType == elbv2model.TargetGroupIPAddressTypeIPv6 {
+		_, _ = uuidHash.Write([]byte(ipAddressType))
+	}
 	_, _ = uuidHash.Write([]byte(tgProtocol))
 	_, _ = uuidHash.Write([]byte(healthCheckProtocol))
 	_, _ = uuidHash.Write([]byte(healthCheckInterval))
@@ -163,6 +185,31 @@ func (t *defaultModelBuildTask) buildTargetGroupAttributes(_ context.Context) ([
 		}
 		rawAttributes[tgAttrsProxyProtocolV2Enabled] = "true"
 	}
+	var rawProxyV2TLVs []string
+	if exists := t.annotationParser.ParseStringSliceAnnotation(annotations.SvcLBSuffixProxyProtocolTLVs, &rawProxyV2TLVs, t.service.Annotations); exists {
+		if err := validateProxyProtocolV2TLVs(rawProxyV2TLVs); err != nil {
+			return nil, err
+		}
+		// TLVs are only carried by proxy protocol v2 header.
+		rawAttributes[tgAttrsProxyProtocolV2Enabled] = "true"
+	}
+	rawCrossZone := ""
+	if exists := t.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixTargetGroupCrossZone, &rawCrossZone, t.service.Annotations); exists {
+		if err := elbv2model.ValidateTargetGroupCrossZone(rawCrossZone, rawAttributes); err != nil {
+			return nil, err
+		}
+		rawAttributes[elbv2model.TargetGroupAttributeKeyLoadBalancingCrossZoneEnabled] = rawCrossZone
+	}
+	for annotation, attrKey := range targetGroupHealthAttributeKeyByAnnotation {
+		rawValue := ""
+		if exists := t.annotationParser.ParseStringAnnotation(annotation, &rawValue, t.service.Annotations); !exists {
+			continue
+		}
+		if err := elbv2model.ValidateTargetGroupHealthRequirement(attrKey, rawValue, rawAttributes); err != nil {
+			return nil, err
+		}
+		rawAttributes[attrKey] = rawValue
+	}
 	if rawPreserveIPEnabled, ok := rawAttributes[tgAttrsPreserveClientIPEnabled]; ok {
 		_, err := strconv.ParseBool(rawPreserveIPEnabled)
 		if err != nil {
@@ -179,6 +226,31 @@ func (t *defaultModelBuildTask) buildTargetGroupAttributes(_ context.Context) ([
 	return attributes, nil
 }
 
+// validateProxyProtocolV2TLVs validates the proxy protocol v2 TLVs are supported by NLB.
+func validateProxyProtocolV2TLVs(rawTLVs []string) error {
+	for _, rawTLV := range rawTLVs {
+		supported := false
+		for _, supportedTLV := range supportedProxyProtocolV2TLVs {
+			if rawTLV == supportedTLV {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			return errors.Errorf("unsupported proxy protocol v2 TLV %v, NLB only supports %v", rawTLV, supportedProxyProtocolV2TLVs)
+		}
+	}
+	return nil
+}
+
+// targetGroupHealthAttributeKeyByAnnotation maps annotations of target group health requirements to target group attributes.
+var targetGroupHealthAttributeKeyByAnnotation = map[string]string{
+	annotations.SvcLBSuffixTargetGroupHealthDNSFailoverMinHealthyTargetsCount:                elbv2model.TargetGroupAttributeKeyDNSFailoverMinimumHealthyTargetsCount,
+	annotations.SvcLBSuffixTargetGroupHealthDNSFailoverMinHealthyTargetsPercentage:           elbv2model.TargetGroupAttributeKeyDNSFailoverMinimumHealthyTargetsPercentage,
+	annotations.SvcLBSuffixTargetGroupHealthUnhealthyStateRoutingMinHealthyTargetsCount:      elbv2model.TargetGroupAttributeKeyUnhealthyStateRoutingMinimumHealthyTargetsCount,
+	annotations.SvcLBSuffixTargetGroupHealthUnhealthyStateRoutingMinHealthyTargetsPercentage: elbv2model.TargetGroupAttributeKeyUnhealthyStateRoutingMinimumHealthyTargetsPercentage,
+}
+
 func (t *defaultModelBuildTask) buildPreserveClientIPFlag(_ context.Context, targetType elbv2model.TargetType, tgAttrs []elbv2model.TargetGroupAttribute) (bool, error) {
 	for _, attr := range tgAttrs {
 		if attr.Key == tgAttrsPreserveClientIPEnabled {
@@ -284,6 +356,18 @@ func (t *defaultModelBuildTask) buildTargetType(_ context.Context) (elbv2model.T
 	return elbv2model.TargetTypeIP, nil
 }
 
+// buildTargetGroupIPAddressType builds the TargetGroup's IPAddressType.
+// IPv6 TargetGroups are only used for ip TargetType with IPv6 services, which requires a dualstack LoadBalancer.
+func (t *defaultModelBuildTask) buildTargetGroupIPAddressType(_ context.Context, targetType elbv2model.TargetType) (elbv2model.TargetGroupIPAddressType, error) {
+	if targetType != elbv2model.TargetTypeIP || t.service.Spec.IPFamily == nil || (*t.service.Spec.IPFamily) != corev1.IPv6Protocol {
+		return elbv2model.TargetGroupIPAddressTypeIPv4, nil
+	}
+	if t.loadBalancer == nil || t.loadBalancer.Spec.IPAddressType == nil || (*t.loadBalancer.Spec.IPAddressType) != elbv2model.IPAddressTypeDualStack {
+		return "", errors.New("unsupported IPv6 configuration, dualstack LoadBalancer is required")
+	}
+	return elbv2model.TargetGroupIPAddressTypeIPv6, nil
+}
+
 func (t *defaultModelBuildTask) buildTargetGroupResourceID(svcKey types.NamespacedName, port intstr.IntOrString) string {
 	return fmt.Sprintf("%s/%s:%s", svcKey.Namespace, svcKey.Name, port.String())
 }
@@ -293,15 +377,29 @@ func (t *defaultModelBuildTask) buildTargetGroupTags(ctx context.Context) (map[s
 }
 
 func (t *defaultModelBuildTask) buildTargetGroupBinding(ctx context.Context, targetGroup *elbv2model.TargetGroup, preserveClientIP bool,
-	port corev1.ServicePort, hc *elbv2model.TargetGroupHealthCheckConfig) *elbv2model.TargetGroupBindingResource {
-	tgbSpec := t.buildTargetGroupBindingSpec(ctx, targetGroup, preserveClientIP, port, hc)
+	port corev1.ServicePort, hc *elbv2model.TargetGroupHealthCheckConfig, nodeSelector *metav1.LabelSelector) *elbv2model.TargetGroupBindingResource {
+	tgbSpec := t.buildTargetGroupBindingSpec(ctx, targetGroup, preserveClientIP, port, hc, nodeSelector)
 	return elbv2model.NewTargetGroupBindingResource(t.stack, targetGroup.ID(), tgbSpec)
 }
 
 func (t *defaultModelBuildTask) buildTargetGroupBindingSpec(ctx context.Context, targetGroup *elbv2model.TargetGroup, preserveClientIP bool,
-	port corev1.ServicePort, hc *elbv2model.TargetGroupHealthCheckConfig) elbv2model.TargetGroupBindingResourceSpec {
-	tgbNetworking := t.buildTargetGroupBindingNetworking(ctx, port.TargetPort, preserveClientIP, *hc.Port, port.Protocol)
+	port corev1.ServicePort, hc *elbv2model.TargetGroupHealthCheckConfig, nodeSelector *metav1.LabelSelector) elbv2model.TargetGroupBindingResourceSpec {
 	targetType := elbv2api.TargetType(targetGroup.Spec.TargetType)
+	// traffic to instance targets is sent to the nodePort instead of the targetPort.
+	targetPort := port.TargetPort
+	if targetType == elbv2api.TargetTypeInstance {
+		targetPort = intstr.FromInt(int(port.NodePort))
+	}
+	tgIPAddressType := elbv2model.TargetGroupIPAddressTypeIPv4
+	if targetGroup.Spec.IPAddressType != nil {
+		tgIPAddressType = *targetGroup.Spec.IPAddressType
+	}
+	tgbNetworking := t.buildTargetGroupBindingNetworking(ctx, targetPort, preserveClientIP, *hc.Port, targetGroup.Spec.Protocol, tgIPAddressType)
+	var ipAddressType *elbv2api.TargetGroupIPAddressType
+	if targetGroup.Spec.IPAddressType != nil {
+		tgbIPAddressType := elbv2api.TargetGroupIPAddressType(*targetGroup.Spec.IPAddressType)
+		ipAddressType = &tgbIPAddressType
+	}
 	return elbv2model.TargetGroupBindingResourceSpec{
 		Template: elbv2model.TargetGroupBindingTemplate{
 			ObjectMeta: metav1.ObjectMeta{
@@ -315,23 +413,42 @@ func (t *defaultModelBuildTask) buildTargetGroupBindingSpec(ctx context.Context,
 					Name: t.service.Name,
 					Port: intstr.FromInt(int(port.Port)),
 				},
-				Networking: tgbNetworking,
+				IPAddressType: ipAddressType,
+				Networking:    tgbNetworking,
+				NodeSelector:  nodeSelector,
 			},
 		},
 	}
 }
 
-func (t *defaultModelBuildTask) buildPeersFromSourceRanges(_ context.Context) []elbv2model.NetworkingPeer {
-	var sourceRanges []string
-	var peers []elbv2model.NetworkingPeer
-	for _, cidr := range t.service.Spec.LoadBalancerSourceRanges {
-		sourceRanges = append(sourceRanges, cidr)
+// buildTargetGroupBindingNodeSelector constructs the selector of nodes to register as targets from the target-node-labels annotation.
+// it's only applicable to instance TargetType, and nil is returned if the annotation is absent.
+func (t *defaultModelBuildTask) buildTargetGroupBindingNodeSelector(_ context.Context, targetType elbv2model.TargetType) (*metav1.LabelSelector, error) {
+	if targetType != elbv2model.TargetTypeInstance {
+		return nil, nil
+	}
+	var targetNodeLabels map[string]string
+	exists, err := t.annotationParser.ParseStringMapAnnotation(annotations.SvcLBSuffixTargetNodeLabels, &targetNodeLabels, t.service.Annotations)
+	if err != nil {
+		return nil, err
 	}
-	if len(sourceRanges) == 0 {
-		t.annotationParser.ParseStringSliceAnnotation(annotations.SvcLBSuffixSourceRanges, &sourceRanges, t.service.Annotations)
+	if !exists || len(targetNodeLabels) == 0 {
+		return nil, nil
 	}
+	return &metav1.LabelSelector{
+		MatchLabels: targetNodeLabels,
+	}, nil
+}
+
+// buildPeersFromSourceRanges builds the peers from source ranges of Service, all addresses are allowed when no source ranges configured.
+func (t *defaultModelBuildTask) buildPeersFromSourceRanges(ctx context.Context, tgIPAddressType elbv2model.TargetGroupIPAddressType) []elbv2model.NetworkingPeer {
+	var peers []elbv2model.NetworkingPeer
+	sourceRanges := t.buildSourceRanges(ctx, t.service)
 	if len(sourceRanges) == 0 {
 		sourceRanges = append(sourceRanges, "0.0.0.0/0")
+		if tgIPAddressType == elbv2model.TargetGroupIPAddressTypeIPv6 {
+			sourceRanges = append(sourceRanges, "::/0")
+		}
 	}
 	for _, cidr := range sourceRanges {
 		peers = append(peers, elbv2model.NetworkingPeer{
@@ -344,7 +461,7 @@ func (t *defaultModelBuildTask) buildPeersFromSourceRanges(_ context.Context) []
 }
 
 func (t *defaultModelBuildTask) buildTargetGroupBindingNetworking(ctx context.Context, tgPort intstr.IntOrString, preserveClientIP bool,
-	hcPort intstr.IntOrString, tgProtocol corev1.Protocol) *elbv2model.TargetGroupBindingNetworking {
+	hcPort intstr.IntOrString, tgProtocol elbv2model.Protocol, tgIPAddressType elbv2model.TargetGroupIPAddressType) *elbv2model.TargetGroupBindingNetworking {
 	var fromVPC []elbv2model.NetworkingPeer
 	for _, subnet := range t.ec2Subnets {
 		fromVPC = append(fromVPC, elbv2model.NetworkingPeer{
@@ -352,20 +469,53 @@ func (t *defaultModelBuildTask) buildTargetGroupBindingNetworking(ctx context.Co
 				CIDR: aws.StringValue(subnet.CidrBlock),
 			},
 		})
+		// IPv6 targets receive traffic from the IPv6 addresses of the dualstack LoadBalancer in its subnets.
+		if tgIPAddressType == elbv2model.TargetGroupIPAddressTypeIPv6 {
+			for _, ipv6CIDRAssociation := range subnet.Ipv6CidrBlockAssociationSet {
+				if ipv6CIDRAssociation.Ipv6CidrBlockState != nil &&
+					aws.StringValue(ipv6CIDRAssociation.Ipv6CidrBlockState.State) != ec2.SubnetCidrBlockStateCodeAssociated {
+					continue
+				}
+				fromVPC = append(fromVPC, elbv2model.NetworkingPeer{
+					IPBlock: &elbv2api.IPBlock{
+						CIDR: aws.StringValue(ipv6CIDRAssociation.Ipv6CidrBlock),
+					},
+				})
+			}
+		}
 	}
-	networkingProtocol := elbv2api.NetworkingProtocolTCP
-	if tgProtocol == corev1.ProtocolUDP {
-		networkingProtocol = elbv2api.NetworkingProtocolUDP
+	// TCP_UDP targetGroups receive both TCP and UDP traffic on the same port.
+	var networkingProtocols []elbv2api.NetworkingProtocol
+	switch tgProtocol {
+	case elbv2model.ProtocolUDP:
+		networkingProtocols = []elbv2api.NetworkingProtocol{elbv2api.NetworkingProtocolUDP}
+	case elbv2model.ProtocolTCP_UDP:
+		networkingProtocols = []elbv2api.NetworkingProtocol{elbv2api.NetworkingProtocolTCP, elbv2api.NetworkingProtocolUDP}
+	default:
+		networkingProtocols = []elbv2api.NetworkingProtocol{elbv2api.NetworkingProtocolTCP}
 	}
-	trafficPorts := []elbv2api.NetworkingPort{
-		{
+	var trafficPorts []elbv2api.NetworkingPort
+	for i := range networkingProtocols {
+		trafficPorts = append(trafficPorts, elbv2api.NetworkingPort{
 			Port:     &tgPort,
-			Protocol: &networkingProtocol,
-		},
+			Protocol: &networkingProtocols[i],
+		})
 	}
+	hasUDPTraffic := tgProtocol == elbv2model.ProtocolUDP || tgProtocol == elbv2model.ProtocolTCP_UDP
 	trafficSource := fromVPC
-	if networkingProtocol == elbv2api.NetworkingProtocolUDP || preserveClientIP {
-		trafficSource = t.buildPeersFromSourceRanges(ctx)
+	if hasUDPTraffic || preserveClientIP {
+		trafficSource = t.buildPeersFromSourceRanges(ctx, tgIPAddressType)
+	}
+	// when the LoadBalancer has a managed SecurityGroup, backend rules reference it instead of CIDRs.
+	if t.managedSG != nil {
+		fromVPC = []elbv2model.NetworkingPeer{
+			{
+				SecurityGroup: &elbv2model.SecurityGroup{
+					GroupID: t.managedSG.GroupID(),
+				},
+			},
+		}
+		trafficSource = fromVPC
 	}
 	tgbNetworking := &elbv2model.TargetGroupBindingNetworking{
 		Ingress: []elbv2model.NetworkingIngressRule{
@@ -375,7 +525,7 @@ func (t *defaultModelBuildTask) buildTargetGroupBindingNetworking(ctx context.Co
 			},
 		},
 	}
-	if tgProtocol == corev1.ProtocolUDP || (hcPort.String() != healthCheckPortTrafficPort && hcPort.IntValue() != tgPort.IntValue()) {
+	if hasUDPTraffic || (hcPort.String() != healthCheckPortTrafficPort && hcPort.IntValue() != tgPort.IntValue()) {
 		var healthCheckPorts []elbv2api.NetworkingPort
 		networkingProtocolTCP := elbv2api.NetworkingProtocolTCP
 		networkingHealthCheckPort := hcPort
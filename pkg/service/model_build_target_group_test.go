@@ -11,6 +11,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"sort"
 	"testing"
@@ -66,6 +67,34 @@ func Test_defaultModelBuilderTask_targetGroupAttrs(t *testing.T) {
 			},
 			wantError: true,
 		},
+		{
+			testName: "Proxy V2 TLVs",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-target-group-attributes": tgAttrsProxyProtocolV2Enabled + "=false",
+						"service.beta.kubernetes.io/aws-load-balancer-proxy-protocol-tlvs":     "vpce-id",
+					},
+				},
+			},
+			wantValue: []elbv2.TargetGroupAttribute{
+				{
+					Key:   tgAttrsProxyProtocolV2Enabled,
+					Value: "true",
+				},
+			},
+		},
+		{
+			testName: "unsupported Proxy V2 TLV",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-proxy-protocol-tlvs": "vpce-id, 0xE0",
+					},
+				},
+			},
+			wantError: true,
+		},
 		{
 			testName: "target group attributes",
 			svc: &corev1.Service{
@@ -134,6 +163,85 @@ func Test_defaultModelBuilderTask_targetGroupAttrs(t *testing.T) {
 			},
 			wantError: true,
 		},
+		{
+			testName: "target group cross-zone load balancing",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-target-group-cross-zone-load-balancing": "false",
+					},
+				},
+			},
+			wantValue: []elbv2.TargetGroupAttribute{
+				{
+					Key:   tgAttrsProxyProtocolV2Enabled,
+					Value: "false",
+				},
+				{
+					Key:   "load_balancing.cross_zone.enabled",
+					Value: "false",
+				},
+			},
+		},
+		{
+			testName: "target group cross-zone load balancing invalid value",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-target-group-cross-zone-load-balancing": "enabled",
+					},
+				},
+			},
+			wantError: true,
+		},
+		{
+			testName: "target group cross-zone load balancing conflicts with target group attributes",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-target-group-attributes":                "load_balancing.cross_zone.enabled=true",
+						"service.beta.kubernetes.io/aws-load-balancer-target-group-cross-zone-load-balancing": "false",
+					},
+				},
+			},
+			wantError: true,
+		},
+		{
+			testName: "target group health requirements",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-target-group-health-dns-failover-minimum-healthy-targets-count":                 "off",
+						"service.beta.kubernetes.io/aws-load-balancer-target-group-health-unhealthy-state-routing-minimum-healthy-targets-percentage": "30",
+					},
+				},
+			},
+			wantValue: []elbv2.TargetGroupAttribute{
+				{
+					Key:   tgAttrsProxyProtocolV2Enabled,
+					Value: "false",
+				},
+				{
+					Key:   "target_group_health.dns_failover.minimum_healthy_targets.count",
+					Value: "off",
+				},
+				{
+					Key:   "target_group_health.unhealthy_state_routing.minimum_healthy_targets.percentage",
+					Value: "30",
+				},
+			},
+		},
+		{
+			testName: "target group health requirement invalid value",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-target-group-health-unhealthy-state-routing-minimum-healthy-targets-count": "0",
+					},
+				},
+			},
+			wantError: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.testName, func(t *testing.T) {
@@ -288,10 +396,126 @@ func Test_defaultModelBuilderTask_buildTargetGroupBindingNetworking(t *testing.T
 		tgPort           intstr.IntOrString
 		hcPort           intstr.IntOrString
 		subnets          []*ec2.Subnet
-		tgProtocol       corev1.Protocol
+		tgProtocol       elbv2.Protocol
+		tgIPAddressType  elbv2.TargetGroupIPAddressType
 		preserveClientIP bool
 		want             *elbv2.TargetGroupBindingNetworking
 	}{
+		{
+			name:   "ipv6 targetGroup with dualstack subnets",
+			svc:    &corev1.Service{},
+			tgPort: port80,
+			hcPort: trafficPort,
+			subnets: []*ec2.Subnet{{
+				CidrBlock: aws.String("172.16.0.0/19"),
+				SubnetId:  aws.String("az-1"),
+				Ipv6CidrBlockAssociationSet: []*ec2.SubnetIpv6CidrBlockAssociation{
+					{
+						Ipv6CidrBlock: aws.String("2600:1f13:837:8500::/64"),
+						Ipv6CidrBlockState: &ec2.SubnetCidrBlockState{
+							State: aws.String(ec2.SubnetCidrBlockStateCodeAssociated),
+						},
+					},
+					{
+						Ipv6CidrBlock: aws.String("2600:1f13:837:8501::/64"),
+						Ipv6CidrBlockState: &ec2.SubnetCidrBlockState{
+							State: aws.String(ec2.SubnetCidrBlockStateCodeDisassociated),
+						},
+					},
+				},
+			}},
+			tgProtocol:       elbv2.ProtocolTCP,
+			tgIPAddressType:  elbv2.TargetGroupIPAddressTypeIPv6,
+			preserveClientIP: true,
+			want: &elbv2.TargetGroupBindingNetworking{
+				Ingress: []elbv2.NetworkingIngressRule{
+					{
+						From: []elbv2.NetworkingPeer{
+							{
+								IPBlock: &elbv2api.IPBlock{
+									CIDR: "0.0.0.0/0",
+								},
+							},
+							{
+								IPBlock: &elbv2api.IPBlock{
+									CIDR: "::/0",
+								},
+							},
+						},
+						Ports: []elbv2api.NetworkingPort{
+							{
+								Protocol: &networkingProtocolTCP,
+								Port:     &port80,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "ipv6 targetGroup with dualstack subnets and health check port",
+			svc:    &corev1.Service{},
+			tgPort: port80,
+			hcPort: port808,
+			subnets: []*ec2.Subnet{{
+				CidrBlock: aws.String("172.16.0.0/19"),
+				SubnetId:  aws.String("az-1"),
+				Ipv6CidrBlockAssociationSet: []*ec2.SubnetIpv6CidrBlockAssociation{
+					{
+						Ipv6CidrBlock: aws.String("2600:1f13:837:8500::/64"),
+						Ipv6CidrBlockState: &ec2.SubnetCidrBlockState{
+							State: aws.String(ec2.SubnetCidrBlockStateCodeAssociated),
+						},
+					},
+				},
+			}},
+			tgProtocol:      elbv2.ProtocolTCP,
+			tgIPAddressType: elbv2.TargetGroupIPAddressTypeIPv6,
+			want: &elbv2.TargetGroupBindingNetworking{
+				Ingress: []elbv2.NetworkingIngressRule{
+					{
+						From: []elbv2.NetworkingPeer{
+							{
+								IPBlock: &elbv2api.IPBlock{
+									CIDR: "172.16.0.0/19",
+								},
+							},
+							{
+								IPBlock: &elbv2api.IPBlock{
+									CIDR: "2600:1f13:837:8500::/64",
+								},
+							},
+						},
+						Ports: []elbv2api.NetworkingPort{
+							{
+								Protocol: &networkingProtocolTCP,
+								Port:     &port80,
+							},
+						},
+					},
+					{
+						From: []elbv2.NetworkingPeer{
+							{
+								IPBlock: &elbv2api.IPBlock{
+									CIDR: "172.16.0.0/19",
+								},
+							},
+							{
+								IPBlock: &elbv2api.IPBlock{
+									CIDR: "2600:1f13:837:8500::/64",
+								},
+							},
+						},
+						Ports: []elbv2api.NetworkingPort{
+							{
+								Protocol: &networkingProtocolTCP,
+								Port:     &port808,
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "udp-service with source ranges",
 			svc: &corev1.Service{
@@ -305,7 +529,7 @@ func Test_defaultModelBuilderTask_buildTargetGroupBindingNetworking(t *testing.T
 				CidrBlock: aws.String("172.16.0.0/19"),
 				SubnetId:  aws.String("az-1"),
 			}},
-			tgProtocol: corev1.ProtocolUDP,
+			tgProtocol: elbv2.ProtocolUDP,
 			want: &elbv2.TargetGroupBindingNetworking{
 				Ingress: []elbv2.NetworkingIngressRule{
 					{
@@ -361,7 +585,7 @@ func Test_defaultModelBuilderTask_buildTargetGroupBindingNetworking(t *testing.T
 				CidrBlock: aws.String("172.16.0.0/19"),
 				SubnetId:  aws.String("az-1"),
 			}},
-			tgProtocol: corev1.ProtocolUDP,
+			tgProtocol: elbv2.ProtocolUDP,
 			want: &elbv2.TargetGroupBindingNetworking{
 				Ingress: []elbv2.NetworkingIngressRule{
 					{
@@ -411,7 +635,7 @@ func Test_defaultModelBuilderTask_buildTargetGroupBindingNetworking(t *testing.T
 				CidrBlock: aws.String("172.16.0.0/19"),
 				SubnetId:  aws.String("az-1"),
 			}},
-			tgProtocol: corev1.ProtocolUDP,
+			tgProtocol: elbv2.ProtocolUDP,
 			want: &elbv2.TargetGroupBindingNetworking{
 				Ingress: []elbv2.NetworkingIngressRule{
 					{
@@ -462,7 +686,7 @@ func Test_defaultModelBuilderTask_buildTargetGroupBindingNetworking(t *testing.T
 					SubnetId:  aws.String("sn-2"),
 				},
 			},
-			tgProtocol: corev1.ProtocolTCP,
+			tgProtocol: elbv2.ProtocolTCP,
 			want: &elbv2.TargetGroupBindingNetworking{
 				Ingress: []elbv2.NetworkingIngressRule{
 					{
@@ -488,6 +712,59 @@ func Test_defaultModelBuilderTask_buildTargetGroupBindingNetworking(t *testing.T
 				},
 			},
 		},
+		{
+			name: "tcp_udp-service with source ranges",
+			svc: &corev1.Service{
+				Spec: corev1.ServiceSpec{
+					LoadBalancerSourceRanges: []string{"10.0.0.0/16"},
+				},
+			},
+			tgPort: port80,
+			hcPort: trafficPort,
+			subnets: []*ec2.Subnet{{
+				CidrBlock: aws.String("172.16.0.0/19"),
+				SubnetId:  aws.String("az-1"),
+			}},
+			tgProtocol: elbv2.ProtocolTCP_UDP,
+			want: &elbv2.TargetGroupBindingNetworking{
+				Ingress: []elbv2.NetworkingIngressRule{
+					{
+						From: []elbv2.NetworkingPeer{
+							{
+								IPBlock: &elbv2api.IPBlock{
+									CIDR: "10.0.0.0/16",
+								},
+							},
+						},
+						Ports: []elbv2api.NetworkingPort{
+							{
+								Protocol: &networkingProtocolTCP,
+								Port:     &port80,
+							},
+							{
+								Protocol: &networkingProtocolUDP,
+								Port:     &port80,
+							},
+						},
+					},
+					{
+						From: []elbv2.NetworkingPeer{
+							{
+								IPBlock: &elbv2api.IPBlock{
+									CIDR: "172.16.0.0/19",
+								},
+							},
+						},
+						Ports: []elbv2api.NetworkingPort{
+							{
+								Protocol: &networkingProtocolTCP,
+								Port:     &port80,
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name:   "tcp-service with preserveClient IP, traffic-port hc",
 			svc:    &corev1.Service{},
@@ -503,7 +780,7 @@ func Test_defaultModelBuilderTask_buildTargetGroupBindingNetworking(t *testing.T
 					SubnetId:  aws.String("sn-2"),
 				},
 			},
-			tgProtocol:       corev1.ProtocolTCP,
+			tgProtocol:       elbv2.ProtocolTCP,
 			preserveClientIP: true,
 			want: &elbv2.TargetGroupBindingNetworking{
 				Ingress: []elbv2.NetworkingIngressRule{
@@ -540,7 +817,7 @@ func Test_defaultModelBuilderTask_buildTargetGroupBindingNetworking(t *testing.T
 					SubnetId:  aws.String("sn-2"),
 				},
 			},
-			tgProtocol:       corev1.ProtocolTCP,
+			tgProtocol:       elbv2.ProtocolTCP,
 			preserveClientIP: true,
 			want: &elbv2.TargetGroupBindingNetworking{
 				Ingress: []elbv2.NetworkingIngressRule{
@@ -601,7 +878,7 @@ func Test_defaultModelBuilderTask_buildTargetGroupBindingNetworking(t *testing.T
 					SubnetId:  aws.String("sn-2"),
 				},
 			},
-			tgProtocol:       corev1.ProtocolTCP,
+			tgProtocol:       elbv2.ProtocolTCP,
 			preserveClientIP: true,
 			want: &elbv2.TargetGroupBindingNetworking{
 				Ingress: []elbv2.NetworkingIngressRule{
@@ -653,12 +930,82 @@ func Test_defaultModelBuilderTask_buildTargetGroupBindingNetworking(t *testing.T
 		t.Run(tt.name, func(t *testing.T) {
 			parser := annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io")
 			builder := &defaultModelBuildTask{service: tt.svc, annotationParser: parser, ec2Subnets: tt.subnets}
-			got := builder.buildTargetGroupBindingNetworking(context.Background(), tt.tgPort, tt.preserveClientIP, tt.hcPort, tt.tgProtocol)
+			got := builder.buildTargetGroupBindingNetworking(context.Background(), tt.tgPort, tt.preserveClientIP, tt.hcPort, tt.tgProtocol, tt.tgIPAddressType)
 			assert.Equal(t, tt.want, got)
 		})
 	}
 }
 
+func Test_defaultModelBuilderTask_buildTargetGroupBindingSpec_networkingPort(t *testing.T) {
+	networkingProtocolTCP := elbv2api.NetworkingProtocolTCP
+	port8080 := intstr.FromInt(8080)
+	port32080 := intstr.FromInt(32080)
+	svcPort := corev1.ServicePort{
+		Name:       "http",
+		Port:       80,
+		TargetPort: intstr.FromString("http"),
+		NodePort:   32080,
+	}
+	tests := []struct {
+		name       string
+		targetType elbv2.TargetType
+		svcPort    corev1.ServicePort
+		want       []elbv2api.NetworkingPort
+	}{
+		{
+			name:       "ip targets allow traffic to the targetPort",
+			targetType: elbv2.TargetTypeIP,
+			svcPort: corev1.ServicePort{
+				Name:       "http",
+				Port:       80,
+				TargetPort: port8080,
+				NodePort:   32080,
+			},
+			want: []elbv2api.NetworkingPort{
+				{
+					Protocol: &networkingProtocolTCP,
+					Port:     &port8080,
+				},
+			},
+		},
+		{
+			name:       "instance targets allow traffic to the nodePort",
+			targetType: elbv2.TargetTypeInstance,
+			svcPort:    svcPort,
+			want: []elbv2api.NetworkingPort{
+				{
+					Protocol: &networkingProtocolTCP,
+					Port:     &port32080,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "svc-1"},
+			}
+			stack := core.NewDefaultStack(core.StackID{Namespace: "awesome-ns", Name: "svc-1"})
+			tg := elbv2.NewTargetGroup(stack, "awesome-ns/svc-1:80", elbv2.TargetGroupSpec{
+				Name:       "k8s-awesomen-svc1-0123456789",
+				TargetType: tt.targetType,
+				Port:       8080,
+				Protocol:   elbv2.ProtocolTCP,
+			})
+			hcPort := intstr.FromString("traffic-port")
+			builder := &defaultModelBuildTask{
+				service:          svc,
+				stack:            stack,
+				annotationParser: annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io"),
+				ec2Subnets:       []*ec2.Subnet{{CidrBlock: aws.String("172.16.0.0/19")}},
+			}
+			got := builder.buildTargetGroupBindingSpec(context.Background(), tg, false, tt.svcPort,
+				&elbv2.TargetGroupHealthCheckConfig{Port: &hcPort}, nil)
+			assert.Equal(t, tt.want, got.Template.Spec.Networking.Ingress[0].Ports)
+		})
+	}
+}
+
 func Test_defaultModelBuilder_buildPreserveClientIPFlag(t *testing.T) {
 	tests := []struct {
 		testName   string
@@ -752,3 +1099,77 @@ func Test_defaultModelBuilder_buildPreserveClientIPFlag(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultModelBuilderTask_buildTargetGroupBindingNodeSelector(t *testing.T) {
+	tests := []struct {
+		testName   string
+		svc        *corev1.Service
+		targetType elbv2.TargetType
+		want       *metav1.LabelSelector
+		wantErr    bool
+	}{
+		{
+			testName:   "no annotation",
+			svc:        &corev1.Service{},
+			targetType: elbv2.TargetTypeInstance,
+			want:       nil,
+		},
+		{
+			testName: "instance mode with annotation",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-target-node-labels": "node-group=ingress, kubernetes.io/os=linux",
+					},
+				},
+			},
+			targetType: elbv2.TargetTypeInstance,
+			want: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"node-group":       "ingress",
+					"kubernetes.io/os": "linux",
+				},
+			},
+		},
+		{
+			testName: "ip mode with annotation",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-target-node-labels": "node-group=ingress",
+					},
+				},
+			},
+			targetType: elbv2.TargetTypeIP,
+			want:       nil,
+		},
+		{
+			testName: "invalid annotation",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-target-node-labels": "node-group",
+					},
+				},
+			},
+			targetType: elbv2.TargetTypeInstance,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			parser := annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io")
+			builder := &defaultModelBuildTask{
+				service:          tt.svc,
+				annotationParser: parser,
+			}
+			got, err := builder.buildTargetGroupBindingNodeSelector(context.Background(), tt.targetType)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
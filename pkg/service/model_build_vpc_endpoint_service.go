@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+)
+
+func (t *defaultModelBuildTask) buildVPCEndpointService(ctx context.Context, scheme elbv2model.LoadBalancerScheme) (*ec2model.VPCEndpointService, error) {
+	enabled := false
+	if _, err := t.annotationParser.ParseBoolAnnotation(annotations.SvcLBSuffixVPCEndpointService, &enabled, t.service.Annotations); err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return nil, nil
+	}
+	if !t.vpcEndpointServiceEnabled {
+		return nil, errors.New("VPC endpoint service requires the VPC endpoint service addon, which is enabled via --enable-vpc-endpoint-service")
+	}
+	if scheme != elbv2model.LoadBalancerSchemeInternal {
+		return nil, errors.Errorf("VPC endpoint service is only supported for %v scheme", elbv2model.LoadBalancerSchemeInternal)
+	}
+	acceptanceRequired := true
+	if _, err := t.annotationParser.ParseBoolAnnotation(annotations.SvcLBSuffixVPCEndpointServiceAcceptanceRequired, &acceptanceRequired, t.service.Annotations); err != nil {
+		return nil, err
+	}
+	var allowedPrincipals []string
+	_ = t.annotationParser.ParseStringSliceAnnotation(annotations.SvcLBSuffixVPCEndpointServiceAllowedPrincipals, &allowedPrincipals, t.service.Annotations)
+	tags, err := t.buildAdditionalResourceTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	es := ec2model.NewVPCEndpointService(t.stack, resourceIDLoadBalancer, ec2model.VPCEndpointServiceSpec{
+		NetworkLoadBalancerARNs: []core.StringToken{t.loadBalancer.LoadBalancerARN()},
+		AcceptanceRequired:      acceptanceRequired,
+		AllowedPrincipals:       allowedPrincipals,
+		Tags:                    tags,
+	})
+	return es, nil
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"testing"
+)
+
+func Test_defaultModelBuildTask_buildVPCEndpointService(t *testing.T) {
+	tests := []struct {
+		name                      string
+		annotations               map[string]string
+		scheme                    elbv2model.LoadBalancerScheme
+		vpcEndpointServiceEnabled bool
+		wantEnabled               bool
+		wantAcceptanceRequired    bool
+		wantAllowedPrincipals     []string
+		wantTags                  map[string]string
+		wantErr                   error
+	}{
+		{
+			name:        "vpc endpoint service not configured",
+			annotations: map[string]string{},
+			scheme:      elbv2model.LoadBalancerSchemeInternal,
+		},
+		{
+			name: "vpc endpoint service disabled",
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-vpc-endpoint-service": "false",
+			},
+			scheme: elbv2model.LoadBalancerSchemeInternal,
+		},
+		{
+			name: "vpc endpoint service with default settings",
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-vpc-endpoint-service": "true",
+			},
+			scheme:                    elbv2model.LoadBalancerSchemeInternal,
+			vpcEndpointServiceEnabled: true,
+			wantEnabled:               true,
+			wantAcceptanceRequired:    true,
+			wantTags:                  map[string]string{},
+		},
+		{
+			name: "vpc endpoint service configured",
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-vpc-endpoint-service":                     "true",
+				"service.beta.kubernetes.io/aws-load-balancer-vpc-endpoint-service-acceptance-required": "false",
+				"service.beta.kubernetes.io/aws-load-balancer-vpc-endpoint-service-allowed-principals":  "arn:aws:iam::123456789012:root, arn:aws:iam::210987654321:role/consumer",
+				"service.beta.kubernetes.io/aws-load-balancer-additional-resource-tags":                 "team=infra",
+			},
+			scheme:                    elbv2model.LoadBalancerSchemeInternal,
+			vpcEndpointServiceEnabled: true,
+			wantEnabled:               true,
+			wantAcceptanceRequired:    false,
+			wantAllowedPrincipals:     []string{"arn:aws:iam::123456789012:root", "arn:aws:iam::210987654321:role/consumer"},
+			wantTags:                  map[string]string{"team": "infra"},
+		},
+		{
+			name: "vpc endpoint service without addon enabled",
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-vpc-endpoint-service": "true",
+			},
+			scheme:  elbv2model.LoadBalancerSchemeInternal,
+			wantErr: errors.New("VPC endpoint service requires the VPC endpoint service addon, which is enabled via --enable-vpc-endpoint-service"),
+		},
+		{
+			name: "vpc endpoint service for internet-facing load balancer",
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-vpc-endpoint-service": "true",
+			},
+			scheme:                    elbv2model.LoadBalancerSchemeInternetFacing,
+			vpcEndpointServiceEnabled: true,
+			wantErr:                   errors.New("VPC endpoint service is only supported for internal scheme"),
+		},
+		{
+			name: "invalid acceptance required",
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-vpc-endpoint-service":                     "true",
+				"service.beta.kubernetes.io/aws-load-balancer-vpc-endpoint-service-acceptance-required": "maybe",
+			},
+			scheme:                    elbv2model.LoadBalancerSchemeInternal,
+			vpcEndpointServiceEnabled: true,
+			wantErr:                   errors.New("failed to parse bool annotation, service.beta.kubernetes.io/aws-load-balancer-vpc-endpoint-service-acceptance-required: maybe: strconv.ParseBool: parsing \"maybe\": invalid syntax"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stack := core.NewDefaultStack(core.StackID{Namespace: "awesome-ns", Name: "svc-1"})
+			task := &defaultModelBuildTask{
+				annotationParser:          annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io"),
+				vpcEndpointServiceEnabled: tt.vpcEndpointServiceEnabled,
+				service: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "awesome-ns",
+						Name:        "svc-1",
+						Annotations: tt.annotations,
+					},
+				},
+				stack:        stack,
+				loadBalancer: elbv2model.NewLoadBalancer(stack, resourceIDLoadBalancer, elbv2model.LoadBalancerSpec{}),
+			}
+			got, err := task.buildVPCEndpointService(context.Background(), tt.scheme)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			if !tt.wantEnabled {
+				assert.Nil(t, got)
+				return
+			}
+			task.loadBalancer.SetStatus(elbv2model.LoadBalancerStatus{LoadBalancerARN: "lb-arn"})
+			assert.Len(t, got.Spec.NetworkLoadBalancerARNs, 1)
+			lbARN, err := got.Spec.NetworkLoadBalancerARNs[0].Resolve(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, "lb-arn", lbARN)
+			assert.Equal(t, tt.wantAcceptanceRequired, got.Spec.AcceptanceRequired)
+			assert.Equal(t, tt.wantAllowedPrincipals, got.Spec.AllowedPrincipals)
+			assert.Equal(t, tt.wantTags, got.Spec.Tags)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	zonalshiftmodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/zonalshift"
+)
+
+const (
+	defaultZonalShiftExpiresIn = "1h"
+)
+
+func (t *defaultModelBuildTask) buildZonalShift(_ context.Context) (*zonalshiftmodel.ZonalShift, error) {
+	rawAwayFrom := ""
+	if exists := t.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixZonalShiftAwayFrom, &rawAwayFrom, t.service.Annotations); !exists || rawAwayFrom == "" {
+		return nil, nil
+	}
+	rawExpiresIn := defaultZonalShiftExpiresIn
+	_ = t.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixZonalShiftExpiresIn, &rawExpiresIn, t.service.Annotations)
+	rawComment := ""
+	_ = t.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixZonalShiftComment, &rawComment, t.service.Annotations)
+	if err := zonalshiftmodel.ValidateZonalShift(rawAwayFrom, rawExpiresIn); err != nil {
+		return nil, err
+	}
+	zonalShift := zonalshiftmodel.NewZonalShift(t.stack, resourceIDLoadBalancer, zonalshiftmodel.ZonalShiftSpec{
+		ResourceARN: t.loadBalancer.LoadBalancerARN(),
+		AwayFrom:    rawAwayFrom,
+		ExpiresIn:   rawExpiresIn,
+		Comment:     rawComment,
+	})
+	return zonalShift, nil
+}
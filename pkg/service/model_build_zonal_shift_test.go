@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"testing"
+)
+
+func Test_defaultModelBuildTask_buildZonalShift(t *testing.T) {
+	tests := []struct {
+		name          string
+		annotations   map[string]string
+		wantAwayFrom  string
+		wantExpiresIn string
+		wantComment   string
+		wantErr       error
+	}{
+		{
+			name:        "zonal shift not configured",
+			annotations: map[string]string{},
+		},
+		{
+			name: "zonal shift configured with default expiry",
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-zonal-shift-away-from": "usw2-az1",
+			},
+			wantAwayFrom:  "usw2-az1",
+			wantExpiresIn: "1h",
+		},
+		{
+			name: "zonal shift configured",
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-zonal-shift-away-from":  "usw2-az1",
+				"service.beta.kubernetes.io/aws-load-balancer-zonal-shift-expires-in": "90m",
+				"service.beta.kubernetes.io/aws-load-balancer-zonal-shift-comment":    "INC-1234",
+			},
+			wantAwayFrom:  "usw2-az1",
+			wantExpiresIn: "90m",
+			wantComment:   "INC-1234",
+		},
+		{
+			name: "invalid zonal shift away-from",
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-zonal-shift-away-from": "us-west-2a",
+			},
+			wantErr: errors.New("invalid zonal shift away-from us-west-2a, must be an availability zone ID like use1-az1"),
+		},
+		{
+			name: "invalid zonal shift expires-in",
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-zonal-shift-away-from":  "usw2-az1",
+				"service.beta.kubernetes.io/aws-load-balancer-zonal-shift-expires-in": "0m",
+			},
+			wantErr: errors.New("invalid zonal shift expires-in 0m, must be minutes or hours like 30m or 12h"),
+		},
+		{
+			name: "zonal shift expires-in too long",
+			annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-zonal-shift-away-from":  "usw2-az1",
+				"service.beta.kubernetes.io/aws-load-balancer-zonal-shift-expires-in": "4321m",
+			},
+			wantErr: errors.New("invalid zonal shift expires-in 4321m, must be no longer than 72h"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stack := core.NewDefaultStack(core.StackID{Namespace: "awesome-ns", Name: "svc-1"})
+			task := &defaultModelBuildTask{
+				annotationParser: annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io"),
+				service: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "awesome-ns",
+						Name:        "svc-1",
+						Annotations: tt.annotations,
+					},
+				},
+				stack:        stack,
+				loadBalancer: elbv2model.NewLoadBalancer(stack, resourceIDLoadBalancer, elbv2model.LoadBalancerSpec{}),
+			}
+			got, err := task.buildZonalShift(context.Background())
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			if tt.wantAwayFrom == "" {
+				assert.Nil(t, got)
+				return
+			}
+			assert.Equal(t, tt.wantAwayFrom, got.Spec.AwayFrom)
+			assert.Equal(t, tt.wantExpiresIn, got.Spec.ExpiresIn)
+			assert.Equal(t, tt.wantComment, got.Spec.Comment)
+		})
+	}
+}
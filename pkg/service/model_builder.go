@@ -4,47 +4,85 @@ import (
 	"context"
 
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/quota"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	ec2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/ec2"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/securitygrouppolicy"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/tracing"
 )
 
-// ModelBuilder builds the model stack for the service resource.
+// ModelBuilder builds the model stack for the service group.
 type ModelBuilder interface {
-	// Build model stack for service
-	Build(ctx context.Context, service *corev1.Service) (core.Stack, *elbv2model.LoadBalancer, error)
+	// Build model stack for service group
+	Build(ctx context.Context, svcGroup Group) (core.Stack, *elbv2model.LoadBalancer, error)
 }
 
 // NewDefaultModelBuilder construct a new defaultModelBuilder
-func NewDefaultModelBuilder(annotationParser annotations.Parser, subnetsResolver networking.SubnetsResolver, clusterName string) *defaultModelBuilder {
+func NewDefaultModelBuilder(annotationParser annotations.Parser, subnetsResolver networking.SubnetsResolver,
+	sgPolicyPermissionBuilder securitygrouppolicy.PermissionBuilder, eventRecorder record.EventRecorder, quotaProvider quota.Provider, dynamicConfigProvider config.DynamicConfigProvider, clusterName string, ipamEnabled bool, vpcEndpointServiceEnabled bool) *defaultModelBuilder {
 	return &defaultModelBuilder{
-		annotationParser: annotationParser,
-		subnetsResolver:  subnetsResolver,
-		clusterName:      clusterName,
+		annotationParser:          annotationParser,
+		subnetsResolver:           subnetsResolver,
+		sgPolicyPermissionBuilder: sgPolicyPermissionBuilder,
+		eventRecorder:             eventRecorder,
+		quotaProvider:             quotaProvider,
+		dynamicConfigProvider:     dynamicConfigProvider,
+		clusterName:               clusterName,
+		ipamEnabled:               ipamEnabled,
+		vpcEndpointServiceEnabled: vpcEndpointServiceEnabled,
 	}
 }
 
 var _ ModelBuilder = &defaultModelBuilder{}
 
 type defaultModelBuilder struct {
-	annotationParser annotations.Parser
-	subnetsResolver  networking.SubnetsResolver
-	clusterName      string
+	annotationParser          annotations.Parser
+	subnetsResolver           networking.SubnetsResolver
+	sgPolicyPermissionBuilder securitygrouppolicy.PermissionBuilder
+	eventRecorder             record.EventRecorder
+	quotaProvider             quota.Provider
+	dynamicConfigProvider     config.DynamicConfigProvider
+	clusterName               string
+	// IPAM pool allocations are only fulfilled if IPAM addon is enabled.
+	ipamEnabled bool
+	// VPC endpoint services are only provisioned if VPC endpoint service addon is enabled.
+	vpcEndpointServiceEnabled bool
 }
 
-func (b *defaultModelBuilder) Build(ctx context.Context, service *corev1.Service) (core.Stack, *elbv2model.LoadBalancer, error) {
-	stack := core.NewDefaultStack(core.StackID(k8s.NamespacedName(service)))
+func (b *defaultModelBuilder) Build(ctx context.Context, svcGroup Group) (core.Stack, *elbv2model.LoadBalancer, error) {
+	ctx, span := tracing.StartSpan(ctx, "BuildServiceModel", tracing.AttributeStackID.String(svcGroup.ID.String()))
+	stack, lb, err := b.build(ctx, svcGroup)
+	tracing.EndSpan(span, err)
+	return stack, lb, err
+}
+
+func (b *defaultModelBuilder) build(ctx context.Context, svcGroup Group) (core.Stack, *elbv2model.LoadBalancer, error) {
+	stack := core.NewDefaultStack(core.StackID(svcGroup.ID))
+	// the dynamic config is snapshotted per build, so that the stack is built consistently while it's reloaded.
+	dynamicConfig := b.dynamicConfigProvider.Get()
 	task := &defaultModelBuildTask{
-		clusterName:      b.clusterName,
-		annotationParser: b.annotationParser,
-		subnetsResolver:  b.subnetsResolver,
+		clusterName:               b.clusterName,
+		ipamEnabled:               b.ipamEnabled,
+		vpcEndpointServiceEnabled: b.vpcEndpointServiceEnabled,
+		annotationParser:          b.annotationParser,
+		subnetsResolver:           b.subnetsResolver,
+		sgPolicyPermissionBuilder: b.sgPolicyPermissionBuilder,
+		eventRecorder:             b.eventRecorder,
+		quotaProvider:             b.quotaProvider,
 
-		service:   service,
-		stack:     stack,
-		tgByResID: make(map[string]*elbv2model.TargetGroup),
+		svcGroup:           svcGroup,
+		stack:              stack,
+		tgByResID:          make(map[string]*elbv2model.TargetGroup),
+		listenerPortOwners: make(map[int32]types.NamespacedName),
 
 		defaultAccessLogS3Enabled:            false,
 		defaultAccessLogsS3Bucket:            "",
@@ -59,6 +97,9 @@ func (b *defaultModelBuilder) Build(ctx context.Context, service *corev1.Service
 		defaultHealthCheckTimeout:            10,
 		defaultHealthCheckHealthyThreshold:   3,
 		defaultHealthCheckUnhealthyThreshold: 3,
+		defaultTags:                          dynamicConfig.DefaultTags,
+		defaultLoadBalancerAttributes:        dynamicConfig.DefaultNLBAttributes,
+		featureGates:                         dynamicConfig.FeatureGates,
 	}
 	if err := task.run(ctx); err != nil {
 		return nil, nil, err
@@ -67,16 +108,25 @@ func (b *defaultModelBuilder) Build(ctx context.Context, service *corev1.Service
 }
 
 type defaultModelBuildTask struct {
-	clusterName      string
-	annotationParser annotations.Parser
-	subnetsResolver  networking.SubnetsResolver
+	clusterName               string
+	ipamEnabled               bool
+	vpcEndpointServiceEnabled bool
+	annotationParser          annotations.Parser
+	subnetsResolver           networking.SubnetsResolver
+	sgPolicyPermissionBuilder securitygrouppolicy.PermissionBuilder
+	eventRecorder             record.EventRecorder
+	quotaProvider             quota.Provider
 
+	svcGroup Group
+	// service is the member Service being built, it's the first member when building the NLB shared by the group.
 	service *corev1.Service
 
-	stack        core.Stack
-	loadBalancer *elbv2model.LoadBalancer
-	tgByResID    map[string]*elbv2model.TargetGroup
-	ec2Subnets   []*ec2.Subnet
+	stack              core.Stack
+	loadBalancer       *elbv2model.LoadBalancer
+	tgByResID          map[string]*elbv2model.TargetGroup
+	listenerPortOwners map[int32]types.NamespacedName
+	ec2Subnets         []*ec2.Subnet
+	managedSG          *ec2model.SecurityGroup
 
 	defaultAccessLogS3Enabled            bool
 	defaultAccessLogsS3Bucket            string
@@ -91,17 +141,24 @@ type defaultModelBuildTask struct {
 	defaultHealthCheckTimeout            int64
 	defaultHealthCheckHealthyThreshold   int64
 	defaultHealthCheckUnhealthyThreshold int64
+	defaultTags                          map[string]string
+	defaultLoadBalancerAttributes        map[string]string
+	featureGates                         config.FeatureGates
 }
 
 func (t *defaultModelBuildTask) run(ctx context.Context) error {
-	if !t.service.DeletionTimestamp.IsZero() {
+	if len(t.svcGroup.Members) == 0 {
 		return nil
 	}
+	t.service = t.svcGroup.Members[0]
 	err := t.buildModel(ctx)
 	return err
 }
 
 func (t *defaultModelBuildTask) buildModel(ctx context.Context) error {
+	if err := t.validateGroupLoadBalancerAnnotations(ctx); err != nil {
+		return err
+	}
 	scheme, err := t.buildLoadBalancerScheme(ctx)
 	if err != nil {
 		return err
@@ -114,9 +171,63 @@ func (t *defaultModelBuildTask) buildModel(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	_, err = t.buildZonalShift(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = t.buildVPCEndpointService(ctx, scheme)
+	if err != nil {
+		return err
+	}
 	err = t.buildListeners(ctx)
 	if err != nil {
 		return err
 	}
 	return nil
 }
+
+// loadBalancerAnnotationSuffixes are the annotations configuring the NLB, which is shared by members of a Service group.
+var loadBalancerAnnotationSuffixes = []string{
+	annotations.SvcLBSuffixInternal,
+	annotations.SvcLBSuffixIPAddressType,
+	annotations.SvcLBSuffixAdditionalTags,
+	annotations.SvcLBSuffixAccessLogEnabled,
+	annotations.SvcLBSuffixAccessLogS3BucketName,
+	annotations.SvcLBSuffixAccessLogS3BucketPrefix,
+	annotations.SvcLBSuffixCrossZoneLoadBalancingEnabled,
+	annotations.SvcLBSuffixDNSRecordClientRoutingPolicy,
+	annotations.SvcLBSuffixEIPAllocations,
+	annotations.SvcLBSuffixEIPPool,
+	annotations.SvcLBSuffixEIPPoolAllocate,
+	annotations.SvcLBSuffixIPAMPool,
+	annotations.SvcLBSuffixVPCEndpointService,
+	annotations.SvcLBSuffixVPCEndpointServiceAcceptanceRequired,
+	annotations.SvcLBSuffixVPCEndpointServiceAllowedPrincipals,
+	annotations.SvcLBSuffixSubnets,
+	annotations.SvcLBSuffixSubnetTags,
+	annotations.SvcLBSuffixExcludedSubnets,
+	annotations.SvcLBSuffixManageSecurityGroup,
+	annotations.SvcLBSuffixZonalShiftAwayFrom,
+	annotations.SvcLBSuffixZonalShiftExpiresIn,
+	annotations.SvcLBSuffixZonalShiftComment,
+	annotations.SvcLBSuffixReplacementStrategy,
+	annotations.SvcLBSuffixReplacementDrainWindow,
+}
+
+// validateGroupLoadBalancerAnnotations validates that members of Service group agree on the annotations configuring the NLB.
+func (t *defaultModelBuildTask) validateGroupLoadBalancerAnnotations(_ context.Context) error {
+	leader := t.svcGroup.Members[0]
+	for _, suffix := range loadBalancerAnnotationSuffixes {
+		leaderValue := ""
+		leaderExists := t.annotationParser.ParseStringAnnotation(suffix, &leaderValue, leader.Annotations)
+		for _, svc := range t.svcGroup.Members[1:] {
+			value := ""
+			exists := t.annotationParser.ParseStringAnnotation(suffix, &value, svc.Annotations)
+			if exists != leaderExists || value != leaderValue {
+				return errors.Errorf("conflicting %v annotation on services in group %v: %v | %v",
+					suffix, t.svcGroup.ID, k8s.NamespacedName(leader), k8s.NamespacedName(svc))
+			}
+		}
+	}
+	return nil
+}
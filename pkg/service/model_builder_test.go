@@ -11,10 +11,22 @@ import (
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	mock_networking "sigs.k8s.io/aws-load-balancer-controller/mocks/networking"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/quota"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/securitygrouppolicy"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func Test_defaultModelBuilderTask_Build(t *testing.T) {
@@ -133,6 +145,7 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
                    "subnetID":"subnet-1"
                 }
              ],
+             "subnetsAutoDiscovered":true,
              "loadBalancerAttributes":[
                 {
                    "key":"access_logs.s3.enabled",
@@ -159,6 +172,7 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
           "spec":{
              "name":"k8s-default-nlbipsvc-d4818dcd51",
              "targetType":"ip",
+             "ipAddressType":"ipv4",
              "port":80,
              "protocol":"TCP",
              "healthCheckConfig":{
@@ -192,6 +206,7 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
                       "$ref":"#/resources/AWS::ElasticLoadBalancingV2::TargetGroup/default/nlb-ip-svc-tls:80/status/targetGroupARN"
                    },
                    "targetType":"ip",
+                   "ipAddressType":"ipv4",
                    "serviceRef":{
                       "name":"nlb-ip-svc-tls",
                       "port":80
@@ -224,7 +239,7 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
 }
 `,
 			wantNumResources: 4,
-      },
+		},
 		{
 			testName: "Dualstack service",
 			svc: &corev1.Service{
@@ -292,6 +307,7 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
                    "subnetID":"subnet-1"
                 }
              ],
+             "subnetsAutoDiscovered":true,
              "loadBalancerAttributes":[
                 {
                    "key":"access_logs.s3.enabled",
@@ -318,6 +334,7 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
           "spec":{
              "name":"k8s-default-nlbipsvc-d4818dcd51",
              "targetType":"ip",
+             "ipAddressType":"ipv4",
              "port":80,
              "protocol":"TCP",
              "healthCheckConfig":{
@@ -351,6 +368,7 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
                       "$ref":"#/resources/AWS::ElasticLoadBalancingV2::TargetGroup/default/nlb-ip-svc-tls:80/status/targetGroupARN"
                    },
                    "targetType":"ip",
+                   "ipAddressType":"ipv4",
                    "serviceRef":{
                       "name":"nlb-ip-svc-tls",
                       "port":80
@@ -383,7 +401,7 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
 }
 `,
 			wantNumResources: 4,
-		},      
+		},
 		{
 			testName: "Multiple listeners, multiple target groups",
 			svc: &corev1.Service{
@@ -490,6 +508,7 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
                    "subnetID":"subnet-2"
                 }
              ],
+             "subnetsAutoDiscovered":true,
              "loadBalancerAttributes":[
                 {
                    "key":"access_logs.s3.enabled",
@@ -516,6 +535,7 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
           "spec":{
              "name":"k8s-default-nlbipsvc-62f81639fc",
              "targetType":"ip",
+             "ipAddressType":"ipv4",
              "port":80,
              "protocol":"TCP",
              "healthCheckConfig":{
@@ -539,6 +559,7 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
           "spec":{
              "name":"k8s-default-nlbipsvc-3ede6b28b6",
              "targetType":"ip",
+             "ipAddressType":"ipv4",
              "port":80,
              "protocol":"TCP",
              "healthCheckConfig":{
@@ -573,6 +594,7 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
                       "$ref":"#/resources/AWS::ElasticLoadBalancingV2::TargetGroup/default/nlb-ip-svc:80/status/targetGroupARN"
                    },
                    "targetType":"ip",
+                   "ipAddressType":"ipv4",
                    "serviceRef":{
                       "name":"nlb-ip-svc",
                       "port":80
@@ -638,6 +660,7 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
                       "$ref":"#/resources/AWS::ElasticLoadBalancingV2::TargetGroup/default/nlb-ip-svc:83/status/targetGroupARN"
                    },
                    "targetType":"ip",
+                   "ipAddressType":"ipv4",
                    "serviceRef":{
                       "name":"nlb-ip-svc",
                       "port":83
@@ -819,6 +842,7 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
                    "subnetID":"subnet-3"
                 }
              ],
+             "subnetsAutoDiscovered":true,
              "loadBalancerAttributes":[
                 {
                    "key":"access_logs.s3.enabled",
@@ -845,6 +869,7 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
           "spec":{
              "name":"k8s-default-nlbipsvc-62f81639fc",
              "targetType":"ip",
+             "ipAddressType":"ipv4",
              "port":80,
              "protocol":"TCP",
              "healthCheckConfig":{
@@ -868,6 +893,7 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
           "spec":{
              "name":"k8s-default-nlbipsvc-77ea0c7734",
              "targetType":"ip",
+             "ipAddressType":"ipv4",
              "port":8883,
              "protocol":"TCP",
              "healthCheckConfig":{
@@ -902,6 +928,7 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
                       "$ref":"#/resources/AWS::ElasticLoadBalancingV2::TargetGroup/default/nlb-ip-svc-tls:80/status/targetGroupARN"
                    },
                    "targetType":"ip",
+                   "ipAddressType":"ipv4",
                    "serviceRef":{
                       "name":"nlb-ip-svc-tls",
                       "port":80
@@ -952,6 +979,7 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
                       "$ref":"#/resources/AWS::ElasticLoadBalancingV2::TargetGroup/default/nlb-ip-svc-tls:83/status/targetGroupARN"
                    },
                    "targetType":"ip",
+                   "ipAddressType":"ipv4",
                    "serviceRef":{
                       "name":"nlb-ip-svc-tls",
                       "port":83
@@ -1051,9 +1079,20 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
 			}
 
 			annotationParser := annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io")
-			builder := NewDefaultModelBuilder(annotationParser, subnetsResolver, "my-cluster")
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			sgPolicyPermissionBuilder := securitygrouppolicy.NewDefaultPermissionBuilder(k8sClient, record.NewFakeRecorder(10))
+			builder := NewDefaultModelBuilder(annotationParser, subnetsResolver, sgPolicyPermissionBuilder, record.NewFakeRecorder(10), quota.NewStaticProvider(nil), config.NewStaticDynamicConfigProvider(config.DynamicConfig{}), "my-cluster", false, false)
 			ctx := context.Background()
-			stack, _, err := builder.Build(ctx, tt.svc)
+			svcGroup := Group{ID: NewGroupIDForImplicitGroup(k8s.NamespacedName(tt.svc))}
+			if tt.svc.DeletionTimestamp.IsZero() {
+				svcGroup.Members = []*corev1.Service{tt.svc}
+			} else {
+				svcGroup.InactiveMembers = []*corev1.Service{tt.svc}
+			}
+			stack, _, err := builder.Build(ctx, svcGroup)
 			if tt.wantError {
 				assert.Error(t, err)
 			} else {
@@ -1069,3 +1108,129 @@ func Test_defaultModelBuilderTask_Build(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultModelBuilder_Build_serviceGroup(t *testing.T) {
+	buildGroupMember := func(namespace string, name string, port int32, svcAnnotations map[string]string) *corev1.Service {
+		mergedAnnotations := map[string]string{
+			"service.beta.kubernetes.io/aws-load-balancer-type":       "nlb-ip",
+			"service.beta.kubernetes.io/aws-load-balancer-group-name": "awesome-group",
+		}
+		for key, value := range svcAnnotations {
+			mergedAnnotations[key] = value
+		}
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   namespace,
+				Name:        name,
+				UID:         types.UID(name),
+				Annotations: mergedAnnotations,
+			},
+			Spec: corev1.ServiceSpec{
+				Type: corev1.ServiceTypeLoadBalancer,
+				Ports: []corev1.ServicePort{
+					{
+						Port:       port,
+						TargetPort: intstr.FromInt(8080),
+						Protocol:   corev1.ProtocolTCP,
+					},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name              string
+		members           []*corev1.Service
+		wantLBName        string
+		wantListenerPorts []int64
+		wantTGBNamespaces []string
+		wantErr           string
+	}{
+		{
+			name: "members share NLB with distinct ports",
+			members: []*corev1.Service{
+				buildGroupMember("ns-a", "svc-a", 80, nil),
+				buildGroupMember("ns-b", "svc-b", 443, nil),
+			},
+			wantLBName:        "k8s-awesomegroup-f4076d2070",
+			wantListenerPorts: []int64{80, 443},
+			wantTGBNamespaces: []string{"ns-a", "ns-b"},
+		},
+		{
+			name: "members with conflicting listener ports",
+			members: []*corev1.Service{
+				buildGroupMember("ns-a", "svc-a", 80, nil),
+				buildGroupMember("ns-b", "svc-b", 80, nil),
+			},
+			wantErr: "conflicting listener port 80 on services in group awesome-group: ns-a/svc-a | ns-b/svc-b",
+		},
+		{
+			name: "members with conflicting load balancer annotations",
+			members: []*corev1.Service{
+				buildGroupMember("ns-a", "svc-a", 80, nil),
+				buildGroupMember("ns-b", "svc-b", 443, map[string]string{
+					"service.beta.kubernetes.io/aws-load-balancer-internal": "true",
+				}),
+			},
+			wantErr: "conflicting aws-load-balancer-internal annotation on services in group awesome-group: ns-a/svc-a | ns-b/svc-b",
+		},
+		{
+			name: "members with replacement strategy on only one member",
+			members: []*corev1.Service{
+				buildGroupMember("ns-a", "svc-a", 80, nil),
+				buildGroupMember("ns-b", "svc-b", 443, map[string]string{
+					"service.beta.kubernetes.io/aws-load-balancer-replacement-strategy": "delete-create",
+				}),
+			},
+			wantErr: "conflicting aws-load-balancer-replacement-strategy annotation on services in group awesome-group: ns-a/svc-a | ns-b/svc-b",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			subnetsResolver := mock_networking.NewMockSubnetsResolver(ctrl)
+			subnetsResolver.EXPECT().ResolveViaDiscovery(gomock.Any(), gomock.Any()).Return([]*ec2.Subnet{
+				{
+					SubnetId:  aws.String("subnet-1"),
+					CidrBlock: aws.String("192.168.0.0/19"),
+				},
+			}, nil).AnyTimes()
+			annotationParser := annotations.NewSuffixAnnotationParser("service.beta.kubernetes.io")
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			sgPolicyPermissionBuilder := securitygrouppolicy.NewDefaultPermissionBuilder(k8sClient, record.NewFakeRecorder(10))
+			builder := NewDefaultModelBuilder(annotationParser, subnetsResolver, sgPolicyPermissionBuilder, record.NewFakeRecorder(10), quota.NewStaticProvider(nil), config.NewStaticDynamicConfigProvider(config.DynamicConfig{}), "my-cluster", false, false)
+			svcGroup := Group{
+				ID:      NewGroupIDForExplicitGroup("awesome-group"),
+				Members: tt.members,
+			}
+			stack, lb, err := builder.Build(context.Background(), svcGroup)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, core.StackID{Name: "awesome-group"}, stack.StackID())
+			assert.Equal(t, tt.wantLBName, lb.Spec.Name)
+
+			var listeners []*elbv2.Listener
+			assert.NoError(t, stack.ListResources(&listeners))
+			var gotListenerPorts []int64
+			for _, ls := range listeners {
+				gotListenerPorts = append(gotListenerPorts, ls.Spec.Port)
+			}
+			assert.ElementsMatch(t, tt.wantListenerPorts, gotListenerPorts)
+
+			var tgbs []*elbv2.TargetGroupBindingResource
+			assert.NoError(t, stack.ListResources(&tgbs))
+			var gotTGBNamespaces []string
+			for _, tgb := range tgbs {
+				gotTGBNamespaces = append(gotTGBNamespaces, tgb.Spec.Template.Namespace)
+			}
+			assert.ElementsMatch(t, tt.wantTGBNamespaces, gotTGBNamespaces)
+		})
+	}
+}
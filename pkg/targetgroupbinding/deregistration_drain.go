@@ -0,0 +1,68 @@
+package targetgroupbinding
+
+import (
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/backend"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// The deletion of pods can be delayed until their targets finished draining.
+// The deregistration progress is tracked by the reason of pod's targetHealth condition for each TargetGroupBinding:
+//  1. "DeregistrationRequested" is set by pod webhook once the pod is requested to be deleted.
+//  2. "Target.DeregistrationInProgress" is set by controller once pod's targets are deregistered and draining.
+//  3. "TargetDeregistered" is set by controller once pod's targets finished draining.
+const (
+	// PodConditionReasonDeregistrationRequested is the reason of targetHealth condition for pods requested deregistration of their targets.
+	PodConditionReasonDeregistrationRequested = "DeregistrationRequested"
+	// PodConditionReasonDeregistrationInProgress is the reason of targetHealth condition for pods whose targets are draining.
+	PodConditionReasonDeregistrationInProgress = elbv2sdk.TargetHealthReasonEnumTargetDeregistrationInProgress
+	// PodConditionReasonDeregistered is the reason of targetHealth condition for pods whose targets finished draining.
+	PodConditionReasonDeregistered = "TargetDeregistered"
+)
+
+// IsPodDeregistrationRequested checks whether pod requested deregistration of its targets for the targetHealth condition type.
+func IsPodDeregistrationRequested(cond corev1.PodCondition) bool {
+	switch cond.Reason {
+	case PodConditionReasonDeregistrationRequested, PodConditionReasonDeregistrationInProgress, PodConditionReasonDeregistered:
+		return cond.Status != corev1.ConditionTrue
+	}
+	return false
+}
+
+// BuildPodDeregistrationRequestPatch builds the patch to request deregistration of pod's targets for these targetHealth condition types.
+func BuildPodDeregistrationRequestPatch(pod *corev1.Pod, targetHealthCondTypes []corev1.PodConditionType) (client.Patch, error) {
+	now := metav1.Now()
+	conditions := make([]corev1.PodCondition, 0, len(targetHealthCondTypes))
+	for _, condType := range targetHealthCondTypes {
+		conditions = append(conditions, corev1.PodCondition{
+			Type:               condType,
+			Status:             corev1.ConditionFalse,
+			Reason:             PodConditionReasonDeregistrationRequested,
+			Message:            "Target deregistration is requested before pod deletion",
+			LastTransitionTime: now,
+		})
+	}
+	podInfo := k8s.PodInfo{
+		Key: k8s.NamespacedName(pod),
+		UID: pod.UID,
+	}
+	return buildPodConditionPatch(podInfo, conditions...)
+}
+
+// partitionPodEndpointsByDeregistrationStatus partitions pod endpoints into endpoints to register and endpoints requested deregistration.
+func partitionPodEndpointsByDeregistrationStatus(endpoints []backend.PodEndpoint, targetHealthCondType corev1.PodConditionType) ([]backend.PodEndpoint, []backend.PodEndpoint) {
+	var registeringEndpoints []backend.PodEndpoint
+	var deregisteringEndpoints []backend.PodEndpoint
+	for _, endpoint := range endpoints {
+		cond, exists := endpoint.Pod.GetPodCondition(targetHealthCondType)
+		if exists && IsPodDeregistrationRequested(cond) {
+			deregisteringEndpoints = append(deregisteringEndpoints, endpoint)
+		} else {
+			registeringEndpoints = append(registeringEndpoints, endpoint)
+		}
+	}
+	return registeringEndpoints, deregisteringEndpoints
+}
@@ -0,0 +1,50 @@
+package targetgroupbinding
+
+import (
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/backend"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"testing"
+)
+
+func Test_partitionPodEndpointsByDeregistrationStatus(t *testing.T) {
+	buildEndpoint := func(ip string, conditions ...corev1.PodCondition) backend.PodEndpoint {
+		return backend.PodEndpoint{
+			IP:   ip,
+			Port: 8080,
+			Pod:  k8s.PodInfo{Conditions: conditions},
+		}
+	}
+	healthyEndpoint := buildEndpoint("192.168.1.1", corev1.PodCondition{
+		Type:   "target-health.elbv2.k8s.aws/my-tgb",
+		Status: corev1.ConditionTrue,
+	})
+	newEndpoint := buildEndpoint("192.168.1.2")
+	requestedEndpoint := buildEndpoint("192.168.1.3", corev1.PodCondition{
+		Type:   "target-health.elbv2.k8s.aws/my-tgb",
+		Status: corev1.ConditionFalse,
+		Reason: "DeregistrationRequested",
+	})
+	drainingEndpoint := buildEndpoint("192.168.1.4", corev1.PodCondition{
+		Type:   "target-health.elbv2.k8s.aws/my-tgb",
+		Status: corev1.ConditionFalse,
+		Reason: "Target.DeregistrationInProgress",
+	})
+	deregisteredEndpoint := buildEndpoint("192.168.1.5", corev1.PodCondition{
+		Type:   "target-health.elbv2.k8s.aws/my-tgb",
+		Status: corev1.ConditionFalse,
+		Reason: "TargetDeregistered",
+	})
+	requestedByOtherTGBEndpoint := buildEndpoint("192.168.1.6", corev1.PodCondition{
+		Type:   "target-health.elbv2.k8s.aws/other-tgb",
+		Status: corev1.ConditionFalse,
+		Reason: "DeregistrationRequested",
+	})
+
+	gotRegistering, gotDeregistering := partitionPodEndpointsByDeregistrationStatus([]backend.PodEndpoint{
+		healthyEndpoint, newEndpoint, requestedEndpoint, drainingEndpoint, deregisteredEndpoint, requestedByOtherTGBEndpoint,
+	}, "target-health.elbv2.k8s.aws/my-tgb")
+	assert.Equal(t, []backend.PodEndpoint{healthyEndpoint, newEndpoint, requestedByOtherTGBEndpoint}, gotRegistering)
+	assert.Equal(t, []backend.PodEndpoint{requestedEndpoint, drainingEndpoint, deregisteredEndpoint}, gotDeregistering)
+}
@@ -0,0 +1,118 @@
+package targetgroupbinding
+
+import (
+	"context"
+	"fmt"
+	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/cache"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// TTL for fingerprints of synced endpoints, targets are fully re-synchronized once it's expired,
+	// so that changes made to targets outside of the controller are reverted.
+	defaultSyncedEndpointsFingerprintTTL = 5 * time.Minute
+)
+
+// EndpointsFingerprinter tracks fingerprints of the objects that endpoints of TargetGroupBindings are resolved from,
+// so that targets are only resynchronized when the fingerprint changed since last successful sync.
+type EndpointsFingerprinter interface {
+	// ComputePodEndpointsFingerprint computes the fingerprint for pod endpoints of TargetGroupBinding.
+	// it returns empty fingerprint if it cannot be computed, and such fingerprint never matches.
+	ComputePodEndpointsFingerprint(ctx context.Context, tgb *elbv2api.TargetGroupBinding, svcKey types.NamespacedName) string
+
+	// IsSynced checks whether targets of TargetGroupBinding are synced with endpoints of fingerprint.
+	IsSynced(tgbKey types.NamespacedName, fingerprint string) bool
+
+	// MarkSynced records that targets of TargetGroupBinding are synced with endpoints of fingerprint.
+	MarkSynced(tgbKey types.NamespacedName, fingerprint string)
+
+	// Forget forgets the synced fingerprint of TargetGroupBinding.
+	Forget(tgbKey types.NamespacedName)
+}
+
+// NewDefaultEndpointsFingerprinter constructs new defaultEndpointsFingerprinter.
+// draining nodes are part of the fingerprint if excludeDrainingNodes is set, since pods on them are excluded from endpoints.
+func NewDefaultEndpointsFingerprinter(k8sClient client.Client, excludeDrainingNodes bool) *defaultEndpointsFingerprinter {
+	return &defaultEndpointsFingerprinter{
+		k8sClient:            k8sClient,
+		excludeDrainingNodes: excludeDrainingNodes,
+		syncedFingerprints:   cache.NewExpiring(),
+		syncedFingerprintTTL: defaultSyncedEndpointsFingerprintTTL,
+	}
+}
+
+var _ EndpointsFingerprinter = &defaultEndpointsFingerprinter{}
+
+// default implementation for EndpointsFingerprinter.
+// the fingerprint of pod endpoints consists of TargetGroupBinding's generation, and resourceVersions of the Service and its EndpointSlices.
+type defaultEndpointsFingerprinter struct {
+	k8sClient            client.Client
+	excludeDrainingNodes bool
+
+	// syncedFingerprints caches fingerprint of synced endpoints by TargetGroupBinding key.
+	syncedFingerprints   *cache.Expiring
+	syncedFingerprintTTL time.Duration
+}
+
+func (f *defaultEndpointsFingerprinter) ComputePodEndpointsFingerprint(ctx context.Context, tgb *elbv2api.TargetGroupBinding, svcKey types.NamespacedName) string {
+	svc := &corev1.Service{}
+	if err := f.k8sClient.Get(ctx, svcKey, svc); err != nil {
+		return ""
+	}
+	epSliceList := &discovery.EndpointSliceList{}
+	if err := f.k8sClient.List(ctx, epSliceList,
+		client.InNamespace(svcKey.Namespace),
+		client.MatchingLabels{discovery.LabelServiceName: svcKey.Name}); err != nil {
+		return ""
+	}
+	epSliceVersions := make([]string, 0, len(epSliceList.Items))
+	for _, epSlice := range epSliceList.Items {
+		epSliceVersions = append(epSliceVersions, fmt.Sprintf("%v:%v", epSlice.Name, epSlice.ResourceVersion))
+	}
+	sort.Strings(epSliceVersions)
+
+	var drainingNodeNames []string
+	if f.excludeDrainingNodes {
+		nodeList := &corev1.NodeList{}
+		if err := f.k8sClient.List(ctx, nodeList); err != nil {
+			return ""
+		}
+		for i := range nodeList.Items {
+			if k8s.IsNodeDraining(&nodeList.Items[i]) {
+				drainingNodeNames = append(drainingNodeNames, nodeList.Items[i].Name)
+			}
+		}
+		sort.Strings(drainingNodeNames)
+	}
+
+	return fmt.Sprintf("tgb=%v;svc=%v;epSlices=%v;drainingNodes=%v", tgb.Generation, svc.ResourceVersion,
+		strings.Join(epSliceVersions, ","), strings.Join(drainingNodeNames, ","))
+}
+
+func (f *defaultEndpointsFingerprinter) IsSynced(tgbKey types.NamespacedName, fingerprint string) bool {
+	if fingerprint == "" {
+		return false
+	}
+	syncedFingerprint, exists := f.syncedFingerprints.Get(tgbKey.String())
+	return exists && syncedFingerprint.(string) == fingerprint
+}
+
+func (f *defaultEndpointsFingerprinter) MarkSynced(tgbKey types.NamespacedName, fingerprint string) {
+	if fingerprint == "" {
+		f.Forget(tgbKey)
+		return
+	}
+	f.syncedFingerprints.Set(tgbKey.String(), fingerprint, f.syncedFingerprintTTL)
+}
+
+func (f *defaultEndpointsFingerprinter) Forget(tgbKey types.NamespacedName) {
+	f.syncedFingerprints.Delete(tgbKey.String())
+}
@@ -0,0 +1,204 @@
+package targetgroupbinding
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+func Test_defaultEndpointsFingerprinter_ComputePodEndpointsFingerprint(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "svc-1",
+		},
+	}
+	epSliceA := &discovery.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "svc-1-a",
+			Labels: map[string]string{
+				discovery.LabelServiceName: "svc-1",
+			},
+		},
+	}
+	epSliceB := &discovery.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "svc-1-b",
+			Labels: map[string]string{
+				discovery.LabelServiceName: "svc-1",
+			},
+		},
+	}
+	epSliceOfOtherSvc := &discovery.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "svc-2-a",
+			Labels: map[string]string{
+				discovery.LabelServiceName: "svc-2",
+			},
+		},
+	}
+	drainingNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-b",
+		},
+		Spec: corev1.NodeSpec{
+			Unschedulable: true,
+			Taints: []corev1.Taint{
+				{
+					Key:    "aws-node-termination-handler/spot-itn",
+					Effect: corev1.TaintEffectNoSchedule,
+				},
+			},
+		},
+	}
+	schedulableNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-a",
+		},
+	}
+	tgb := &elbv2api.TargetGroupBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  "default",
+			Name:       "tgb-1",
+			Generation: 2,
+		},
+	}
+
+	type env struct {
+		services []*corev1.Service
+		epSlices []*discovery.EndpointSlice
+		nodes    []*corev1.Node
+	}
+	tests := []struct {
+		name                 string
+		env                  env
+		excludeDrainingNodes bool
+		want                 string
+	}{
+		{
+			name: "service with endpointSlices",
+			env: env{
+				services: []*corev1.Service{svc},
+				epSlices: []*discovery.EndpointSlice{epSliceB, epSliceA, epSliceOfOtherSvc},
+				nodes:    []*corev1.Node{schedulableNode, drainingNode},
+			},
+			want: "tgb=2;svc=1;epSlices=svc-1-a:1,svc-1-b:1;drainingNodes=",
+		},
+		{
+			name: "service with endpointSlices and draining nodes",
+			env: env{
+				services: []*corev1.Service{svc},
+				epSlices: []*discovery.EndpointSlice{epSliceA},
+				nodes:    []*corev1.Node{schedulableNode, drainingNode},
+			},
+			excludeDrainingNodes: true,
+			want:                 "tgb=2;svc=1;epSlices=svc-1-a:1;drainingNodes=node-b",
+		},
+		{
+			name: "service not found",
+			env: env{
+				epSlices: []*discovery.EndpointSlice{epSliceA},
+			},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			ctx := context.Background()
+			for _, svc := range tt.env.services {
+				assert.NoError(t, k8sClient.Create(ctx, svc.DeepCopy()))
+			}
+			for _, epSlice := range tt.env.epSlices {
+				assert.NoError(t, k8sClient.Create(ctx, epSlice.DeepCopy()))
+			}
+			for _, node := range tt.env.nodes {
+				assert.NoError(t, k8sClient.Create(ctx, node.DeepCopy()))
+			}
+
+			f := NewDefaultEndpointsFingerprinter(k8sClient, tt.excludeDrainingNodes)
+			got := f.ComputePodEndpointsFingerprint(ctx, tgb, types.NamespacedName{Namespace: "default", Name: "svc-1"})
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_defaultEndpointsFingerprinter_IsSynced(t *testing.T) {
+	tgbKey := types.NamespacedName{Namespace: "default", Name: "tgb-1"}
+	type syncCall struct {
+		fingerprint string
+		forget      bool
+	}
+	tests := []struct {
+		name        string
+		syncCalls   []syncCall
+		fingerprint string
+		want        bool
+	}{
+		{
+			name:        "never synced",
+			fingerprint: "fingerprint-1",
+			want:        false,
+		},
+		{
+			name: "synced with same fingerprint",
+			syncCalls: []syncCall{
+				{fingerprint: "fingerprint-1"},
+			},
+			fingerprint: "fingerprint-1",
+			want:        true,
+		},
+		{
+			name: "synced with different fingerprint",
+			syncCalls: []syncCall{
+				{fingerprint: "fingerprint-1"},
+			},
+			fingerprint: "fingerprint-2",
+			want:        false,
+		},
+		{
+			name: "synced then forgotten",
+			syncCalls: []syncCall{
+				{fingerprint: "fingerprint-1"},
+				{forget: true},
+			},
+			fingerprint: "fingerprint-1",
+			want:        false,
+		},
+		{
+			name: "empty fingerprint never matches",
+			syncCalls: []syncCall{
+				{fingerprint: ""},
+			},
+			fingerprint: "",
+			want:        false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewDefaultEndpointsFingerprinter(nil, false)
+			for _, call := range tt.syncCalls {
+				if call.forget {
+					f.Forget(tgbKey)
+				} else {
+					f.MarkSynced(tgbKey, call.fingerprint)
+				}
+			}
+			got := f.IsSynced(tgbKey, tt.fingerprint)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Collector collects metrics for TargetGroupBindings.
+type Collector interface {
+	// ObserveReadinessGateWaitingPods observes the number of pods waiting on target health for TargetGroupBinding.
+	ObserveReadinessGateWaitingPods(tgbKey types.NamespacedName, waitingPods int)
+
+	// ObserveReadinessGateTimeout observes a targetHealth readiness gate timed out for TargetGroupBinding.
+	ObserveReadinessGateTimeout(tgbKey types.NamespacedName)
+
+	// ObserveDroppedTargets observes the number of targets that are not registered for TargetGroupBinding since TargetGroup reached the max targets.
+	ObserveDroppedTargets(tgbKey types.NamespacedName, droppedTargets int)
+
+	// ObserveTargetHealth observes the number of healthy targets and all targets for TargetGroupBinding.
+	ObserveTargetHealth(tgbKey types.NamespacedName, healthyTargets int, totalTargets int)
+
+	// Forget removes the metrics of TargetGroupBinding, which should be called once TargetGroupBinding is deleted.
+	Forget(tgbKey types.NamespacedName)
+}
+
+// NewCollector constructs new collector that registers metrics to registerer.
+func NewCollector(registerer prometheus.Registerer) (*collector, error) {
+	instruments, err := newInstruments(registerer)
+	if err != nil {
+		return nil, err
+	}
+	return &collector{
+		instruments: instruments,
+	}, nil
+}
+
+var _ Collector = &collector{}
+
+type collector struct {
+	instruments *instruments
+}
+
+func (c *collector) ObserveReadinessGateWaitingPods(tgbKey types.NamespacedName, waitingPods int) {
+	c.instruments.readinessGateWaitingPods.With(labelsForTGB(tgbKey)).Set(float64(waitingPods))
+}
+
+func (c *collector) ObserveReadinessGateTimeout(tgbKey types.NamespacedName) {
+	c.instruments.readinessGateTimeoutsTotal.With(labelsForTGB(tgbKey)).Inc()
+}
+
+func (c *collector) ObserveDroppedTargets(tgbKey types.NamespacedName, droppedTargets int) {
+	c.instruments.droppedTargets.With(labelsForTGB(tgbKey)).Set(float64(droppedTargets))
+}
+
+func (c *collector) ObserveTargetHealth(tgbKey types.NamespacedName, healthyTargets int, totalTargets int) {
+	c.instruments.healthyTargets.With(labelsForTGB(tgbKey)).Set(float64(healthyTargets))
+	c.instruments.targets.With(labelsForTGB(tgbKey)).Set(float64(totalTargets))
+}
+
+func (c *collector) Forget(tgbKey types.NamespacedName) {
+	c.instruments.readinessGateWaitingPods.Delete(labelsForTGB(tgbKey))
+	c.instruments.readinessGateTimeoutsTotal.Delete(labelsForTGB(tgbKey))
+	c.instruments.droppedTargets.Delete(labelsForTGB(tgbKey))
+	c.instruments.healthyTargets.Delete(labelsForTGB(tgbKey))
+	c.instruments.targets.Delete(labelsForTGB(tgbKey))
+}
+
+// NewNoopCollector constructs new Collector that discards all metrics.
+func NewNoopCollector() *noopCollector {
+	return &noopCollector{}
+}
+
+var _ Collector = &noopCollector{}
+
+type noopCollector struct{}
+
+func (c *noopCollector) ObserveReadinessGateWaitingPods(_ types.NamespacedName, _ int) {}
+
+func (c *noopCollector) ObserveReadinessGateTimeout(_ types.NamespacedName) {}
+
+func (c *noopCollector) ObserveDroppedTargets(_ types.NamespacedName, _ int) {}
+
+func (c *noopCollector) ObserveTargetHealth(_ types.NamespacedName, _ int, _ int) {}
+
+func (c *noopCollector) Forget(_ types.NamespacedName) {}
+
+// labelsForTGB returns the metric labels for TargetGroupBinding.
+func labelsForTGB(tgbKey types.NamespacedName) prometheus.Labels {
+	return prometheus.Labels{
+		labelNamespace: tgbKey.Namespace,
+		labelName:      tgbKey.Name,
+	}
+}
@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+	"testing"
+)
+
+func Test_collector(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	collector, err := NewCollector(registry)
+	assert.NoError(t, err)
+
+	tgbKey := types.NamespacedName{Namespace: "awesome-ns", Name: "tgb-1"}
+	collector.ObserveReadinessGateWaitingPods(tgbKey, 3)
+	collector.ObserveReadinessGateWaitingPods(tgbKey, 2)
+	collector.ObserveReadinessGateTimeout(tgbKey)
+	collector.ObserveReadinessGateTimeout(tgbKey)
+	collector.ObserveDroppedTargets(tgbKey, 5)
+	collector.ObserveTargetHealth(tgbKey, 2, 3)
+	assert.Equal(t, float64(2), testutil.ToFloat64(collector.instruments.readinessGateWaitingPods.With(labelsForTGB(tgbKey))))
+	assert.Equal(t, float64(2), testutil.ToFloat64(collector.instruments.readinessGateTimeoutsTotal.With(labelsForTGB(tgbKey))))
+	assert.Equal(t, float64(5), testutil.ToFloat64(collector.instruments.droppedTargets.With(labelsForTGB(tgbKey))))
+	assert.Equal(t, float64(2), testutil.ToFloat64(collector.instruments.healthyTargets.With(labelsForTGB(tgbKey))))
+	assert.Equal(t, float64(3), testutil.ToFloat64(collector.instruments.targets.With(labelsForTGB(tgbKey))))
+
+	collector.Forget(tgbKey)
+	metricFamilies, err := registry.Gather()
+	assert.NoError(t, err)
+	assert.Empty(t, metricFamilies)
+}
@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	metricSubsystemTargetGroupBinding = "targetgroupbinding"
+
+	metricReadinessGateWaitingPods   = "readiness_gate_waiting_pods"
+	metricReadinessGateTimeoutsTotal = "readiness_gate_timeouts_total"
+	metricDroppedTargets             = "dropped_targets"
+	metricHealthyTargets             = "healthy_targets"
+	metricTargets                    = "targets"
+)
+
+const (
+	labelNamespace = "namespace"
+	labelName      = "name"
+)
+
+type instruments struct {
+	readinessGateWaitingPods   *prometheus.GaugeVec
+	readinessGateTimeoutsTotal *prometheus.CounterVec
+	droppedTargets             *prometheus.GaugeVec
+	healthyTargets             *prometheus.GaugeVec
+	targets                    *prometheus.GaugeVec
+}
+
+// newInstruments allocates and register new metrics to registerer
+func newInstruments(registerer prometheus.Registerer) (*instruments, error) {
+	readinessGateWaitingPods := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricSubsystemTargetGroupBinding,
+		Name:      metricReadinessGateWaitingPods,
+		Help:      "Number of pods whose targetHealth readiness gate is waiting on target health",
+	}, []string{labelNamespace, labelName})
+	readinessGateTimeoutsTotal := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricSubsystemTargetGroupBinding,
+		Name:      metricReadinessGateTimeoutsTotal,
+		Help:      "Total number of targetHealth readiness gates timed out waiting on target health",
+	}, []string{labelNamespace, labelName})
+	droppedTargets := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricSubsystemTargetGroupBinding,
+		Name:      metricDroppedTargets,
+		Help:      "Number of endpoints that are not registered as targets since TargetGroup reached the max targets",
+	}, []string{labelNamespace, labelName})
+	healthyTargets := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricSubsystemTargetGroupBinding,
+		Name:      metricHealthyTargets,
+		Help:      "Number of healthy targets in TargetGroup, only reported when target health polling is enabled",
+	}, []string{labelNamespace, labelName})
+	targets := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricSubsystemTargetGroupBinding,
+		Name:      metricTargets,
+		Help:      "Number of targets in TargetGroup excluding targets being deregistered, only reported when target health polling is enabled",
+	}, []string{labelNamespace, labelName})
+
+	if err := registerer.Register(readinessGateWaitingPods); err != nil {
+		return nil, err
+	}
+	if err := registerer.Register(readinessGateTimeoutsTotal); err != nil {
+		return nil, err
+	}
+	if err := registerer.Register(droppedTargets); err != nil {
+		return nil, err
+	}
+	if err := registerer.Register(healthyTargets); err != nil {
+		return nil, err
+	}
+	if err := registerer.Register(targets); err != nil {
+		return nil, err
+	}
+	return &instruments{
+		readinessGateWaitingPods:   readinessGateWaitingPods,
+		readinessGateTimeoutsTotal: readinessGateTimeoutsTotal,
+		droppedTargets:             droppedTargets,
+		healthyTargets:             healthyTargets,
+		targets:                    targets,
+	}, nil
+}
@@ -0,0 +1,283 @@
+package targetgroupbinding
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultInferredNetworkingCacheTTL = 10 * time.Minute
+	healthCheckPortTrafficPort        = "traffic-port"
+)
+
+// NetworkingInferrer infers the networking rules for TargetGroupBindings without spec.networking,
+// from the LoadBalancers that TargetGroup is attached to.
+type NetworkingInferrer interface {
+	// ShouldInfer returns whether networking rules should be inferred for TargetGroupBinding.
+	ShouldInfer(tgb *elbv2api.TargetGroupBinding) bool
+
+	// Infer infers the networking rules for TargetGroupBinding with targets on targetPorts.
+	// LoadBalancers with SecurityGroups are allowed via their SecurityGroups, while LoadBalancers without SecurityGroups are allowed via CIDRs of their subnets.
+	// Only the targetPorts and the health check port of TargetGroup are allowed.
+	// It returns nil if TargetGroup isn't attached to any LoadBalancer yet or there are no targets.
+	Infer(ctx context.Context, tgb *elbv2api.TargetGroupBinding, targetPorts []int64) (*elbv2api.TargetGroupBindingNetworking, error)
+}
+
+// NewDefaultNetworkingInferrer constructs new defaultNetworkingInferrer.
+func NewDefaultNetworkingInferrer(cloud aws.Cloud, enabled bool, logger logr.Logger) *defaultNetworkingInferrer {
+	return &defaultNetworkingInferrer{
+		cloud:       cloud,
+		elbv2Client: cloud.ELBV2(),
+		ec2Client:   cloud.EC2(),
+		enabled:     enabled,
+		logger:      logger,
+
+		assumedRoleClouds:          make(map[string]aws.Cloud),
+		inferredNetworkingCache:    cache.NewExpiring(),
+		inferredNetworkingCacheTTL: defaultInferredNetworkingCacheTTL,
+	}
+}
+
+var _ NetworkingInferrer = &defaultNetworkingInferrer{}
+
+// inferredTargetGroupNetworking is the networking info inferred for a TargetGroup attached to LoadBalancers.
+type inferredTargetGroupNetworking struct {
+	// peers are the SecurityGroups or CIDRs of LoadBalancers.
+	peers []elbv2api.NetworkingPeer
+	// protocol is the protocol of TargetGroup.
+	protocol string
+	// healthCheckPort is the health check port of TargetGroup, it's zero if health checks are sent to the traffic port.
+	healthCheckPort int64
+}
+
+// default implementation for NetworkingInferrer.
+type defaultNetworkingInferrer struct {
+	cloud       aws.Cloud
+	elbv2Client services.ELBV2
+	ec2Client   services.EC2
+	enabled     bool
+	logger      logr.Logger
+
+	// assumedRoleClouds caches the Clouds for TargetGroups in other AWS accounts or regions by IAM role ARN and region.
+	assumedRoleClouds      map[string]aws.Cloud
+	assumedRoleCloudsMutex sync.Mutex
+
+	inferredNetworkingCache      *cache.Expiring
+	inferredNetworkingCacheMutex sync.RWMutex
+	inferredNetworkingCacheTTL   time.Duration
+}
+
+func (i *defaultNetworkingInferrer) ShouldInfer(tgb *elbv2api.TargetGroupBinding) bool {
+	// TargetGroupBindings created for Ingresses and Services leave networking unspecified on purpose,
+	// when the backend SecurityGroup rules are managed by users.
+	return i.enabled && tgb.Spec.Networking == nil &&
+		!tracking.IsStackLabelled(tracking.IngressTagPrefix, tgb.Labels) &&
+		!tracking.IsStackLabelled(tracking.ServiceTagPrefix, tgb.Labels)
+}
+
+func (i *defaultNetworkingInferrer) Infer(ctx context.Context, tgb *elbv2api.TargetGroupBinding, targetPorts []int64) (*elbv2api.TargetGroupBindingNetworking, error) {
+	if len(targetPorts) == 0 {
+		return nil, nil
+	}
+	tgARN := tgb.Spec.TargetGroupARN
+	tgNetworking, exists := i.fetchInferredNetworkingFromCache(tgARN)
+	if !exists {
+		elbv2Client, ec2Client := i.clientsForTGB(tgb)
+		var err error
+		tgNetworking, err = i.inferViaLoadBalancers(ctx, elbv2Client, ec2Client, tgARN)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to infer networking for TargetGroupBinding %v", k8s.NamespacedName(tgb))
+		}
+		// TargetGroups not attached to LoadBalancers yet are not cached, so that rules are reconciled as soon as they're attached.
+		if tgNetworking == nil {
+			return nil, nil
+		}
+		i.saveInferredNetworkingToCache(tgARN, tgNetworking)
+	}
+	return &elbv2api.TargetGroupBindingNetworking{
+		Ingress: []elbv2api.NetworkingIngressRule{
+			{
+				From:  tgNetworking.peers,
+				Ports: buildInferredNetworkingPorts(tgNetworking.protocol, targetPorts, tgNetworking.healthCheckPort),
+			},
+		},
+	}, nil
+}
+
+func (i *defaultNetworkingInferrer) inferViaLoadBalancers(ctx context.Context, elbv2Client services.ELBV2, ec2Client services.EC2, tgARN string) (*inferredTargetGroupNetworking, error) {
+	tgList, err := elbv2Client.DescribeTargetGroupsAsList(ctx, &elbv2sdk.DescribeTargetGroupsInput{
+		TargetGroupArns: awssdk.StringSlice([]string{tgARN}),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(tgList) != 1 {
+		return nil, errors.Errorf("expecting a single targetGroup but got %v", len(tgList))
+	}
+	sdkTG := tgList[0]
+	if len(sdkTG.LoadBalancerArns) == 0 {
+		return nil, nil
+	}
+	lbList, err := elbv2Client.DescribeLoadBalancersAsList(ctx, &elbv2sdk.DescribeLoadBalancersInput{
+		LoadBalancerArns: sdkTG.LoadBalancerArns,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sgIDs := sets.NewString()
+	subnetIDs := sets.NewString()
+	for _, lb := range lbList {
+		if len(lb.SecurityGroups) != 0 {
+			sgIDs.Insert(awssdk.StringValueSlice(lb.SecurityGroups)...)
+			continue
+		}
+		for _, az := range lb.AvailabilityZones {
+			if az.SubnetId != nil {
+				subnetIDs.Insert(awssdk.StringValue(az.SubnetId))
+			}
+		}
+	}
+	var peers []elbv2api.NetworkingPeer
+	for _, sgID := range sgIDs.List() {
+		peers = append(peers, elbv2api.NetworkingPeer{
+			SecurityGroup: &elbv2api.SecurityGroup{GroupID: sgID},
+		})
+	}
+	if subnetIDs.Len() != 0 {
+		cidrs, err := i.fetchSubnetCIDRs(ctx, ec2Client, subnetIDs.List())
+		if err != nil {
+			return nil, err
+		}
+		for _, cidr := range cidrs {
+			peers = append(peers, elbv2api.NetworkingPeer{
+				IPBlock: &elbv2api.IPBlock{CIDR: cidr},
+			})
+		}
+	}
+	if len(peers) == 0 {
+		return nil, nil
+	}
+	var healthCheckPort int64
+	if rawHealthCheckPort := awssdk.StringValue(sdkTG.HealthCheckPort); rawHealthCheckPort != "" && rawHealthCheckPort != healthCheckPortTrafficPort {
+		healthCheckPort, err = strconv.ParseInt(rawHealthCheckPort, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid health check port: %v", rawHealthCheckPort)
+		}
+	}
+	return &inferredTargetGroupNetworking{
+		peers:           peers,
+		protocol:        awssdk.StringValue(sdkTG.Protocol),
+		healthCheckPort: healthCheckPort,
+	}, nil
+}
+
+// fetchSubnetCIDRs returns the IPv4 and IPv6 CIDRs of subnets.
+func (i *defaultNetworkingInferrer) fetchSubnetCIDRs(ctx context.Context, ec2Client services.EC2, subnetIDs []string) ([]string, error) {
+	subnets, err := ec2Client.DescribeSubnetsAsList(ctx, &ec2sdk.DescribeSubnetsInput{
+		SubnetIds: awssdk.StringSlice(subnetIDs),
+	})
+	if err != nil {
+		return nil, err
+	}
+	cidrs := sets.NewString()
+	for _, subnet := range subnets {
+		if subnet.CidrBlock != nil {
+			cidrs.Insert(awssdk.StringValue(subnet.CidrBlock))
+		}
+		for _, ipv6CIDRAssociation := range subnet.Ipv6CidrBlockAssociationSet {
+			if ipv6CIDRAssociation.Ipv6CidrBlock != nil {
+				cidrs.Insert(awssdk.StringValue(ipv6CIDRAssociation.Ipv6CidrBlock))
+			}
+		}
+	}
+	return cidrs.List(), nil
+}
+
+// clientsForTGB returns the ELBV2 and EC2 clients for the AWS account and region of TargetGroupBinding's TargetGroup.
+func (i *defaultNetworkingInferrer) clientsForTGB(tgb *elbv2api.TargetGroupBinding) (services.ELBV2, services.EC2) {
+	cloudKey := buildCustomCloudKeyForTGB(i.cloud, tgb)
+	if cloudKey == "" {
+		return i.elbv2Client, i.ec2Client
+	}
+
+	i.assumedRoleCloudsMutex.Lock()
+	defer i.assumedRoleCloudsMutex.Unlock()
+	roleCloud, ok := i.assumedRoleClouds[cloudKey]
+	if !ok {
+		roleCloud = buildCustomCloudForTGB(i.cloud, tgb)
+		i.assumedRoleClouds[cloudKey] = roleCloud
+	}
+	return roleCloud.ELBV2(), roleCloud.EC2()
+}
+
+func (i *defaultNetworkingInferrer) fetchInferredNetworkingFromCache(tgARN string) (*inferredTargetGroupNetworking, bool) {
+	i.inferredNetworkingCacheMutex.RLock()
+	defer i.inferredNetworkingCacheMutex.RUnlock()
+
+	if rawCacheItem, exists := i.inferredNetworkingCache.Get(tgARN); exists {
+		return rawCacheItem.(*inferredTargetGroupNetworking), true
+	}
+	return nil, false
+}
+
+func (i *defaultNetworkingInferrer) saveInferredNetworkingToCache(tgARN string, networking *inferredTargetGroupNetworking) {
+	i.inferredNetworkingCacheMutex.Lock()
+	defer i.inferredNetworkingCacheMutex.Unlock()
+
+	i.inferredNetworkingCache.Set(tgARN, networking, i.inferredNetworkingCacheTTL)
+}
+
+// buildInferredNetworkingPorts builds the ports of inferred networking rules for TargetGroup with specific protocol,
+// that allow targetPorts and the health check port if it's not the traffic port.
+// TCP is always allowed on targetPorts since health checks for UDP TargetGroups are TCP based.
+func buildInferredNetworkingPorts(tgProtocol string, targetPorts []int64, healthCheckPort int64) []elbv2api.NetworkingPort {
+	protocols := []elbv2api.NetworkingProtocol{elbv2api.NetworkingProtocolTCP}
+	switch tgProtocol {
+	case elbv2sdk.ProtocolEnumUdp, elbv2sdk.ProtocolEnumTcpUdp:
+		protocols = append(protocols, elbv2api.NetworkingProtocolUDP)
+	}
+	sortedTargetPorts := append([]int64(nil), targetPorts...)
+	sort.Slice(sortedTargetPorts, func(i, j int) bool {
+		return sortedTargetPorts[i] < sortedTargetPorts[j]
+	})
+	var ports []elbv2api.NetworkingPort
+	allowedTCPPorts := sets.NewInt64()
+	for index, targetPort := range sortedTargetPorts {
+		if index > 0 && sortedTargetPorts[index-1] == targetPort {
+			continue
+		}
+		for _, protocol := range protocols {
+			ports = append(ports, buildInferredNetworkingPort(protocol, targetPort))
+		}
+		allowedTCPPorts.Insert(targetPort)
+	}
+	if healthCheckPort != 0 && !allowedTCPPorts.Has(healthCheckPort) {
+		ports = append(ports, buildInferredNetworkingPort(elbv2api.NetworkingProtocolTCP, healthCheckPort))
+	}
+	return ports
+}
+
+func buildInferredNetworkingPort(protocol elbv2api.NetworkingProtocol, port int64) elbv2api.NetworkingPort {
+	portValue := intstr.FromInt(int(port))
+	return elbv2api.NetworkingPort{
+		Protocol: &protocol,
+		Port:     &portValue,
+	}
+}
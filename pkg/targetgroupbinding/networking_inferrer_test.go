@@ -0,0 +1,335 @@
+package targetgroupbinding
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+	"time"
+)
+
+func Test_defaultNetworkingInferrer_ShouldInfer(t *testing.T) {
+	tests := []struct {
+		name    string
+		enabled bool
+		tgb     *elbv2api.TargetGroupBinding
+		want    bool
+	}{
+		{
+			name:    "networking omitted",
+			enabled: true,
+			tgb: &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "tgb-1"},
+			},
+			want: true,
+		},
+		{
+			name:    "networking omitted but inference disabled",
+			enabled: false,
+			tgb: &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "tgb-1"},
+			},
+			want: false,
+		},
+		{
+			name:    "networking specified",
+			enabled: true,
+			tgb: &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "tgb-1"},
+				Spec: elbv2api.TargetGroupBindingSpec{
+					Networking: &elbv2api.TargetGroupBindingNetworking{},
+				},
+			},
+			want: false,
+		},
+		{
+			name:    "networking omitted for Service",
+			enabled: true,
+			tgb: &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns-1",
+					Name:      "tgb-1",
+					Labels: map[string]string{
+						"service.k8s.aws/stack-namespace": "ns-1",
+						"service.k8s.aws/stack-name":      "svc-1",
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name:    "networking omitted with labels of users",
+			enabled: true,
+			tgb: &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns-1",
+					Name:      "tgb-1",
+					Labels: map[string]string{
+						"example.com/stack": "prod",
+					},
+				},
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &defaultNetworkingInferrer{enabled: tt.enabled}
+			got := i.ShouldInfer(tt.tgb)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_defaultNetworkingInferrer_Infer(t *testing.T) {
+	type describeTargetGroupsAsListCall struct {
+		req  *elbv2sdk.DescribeTargetGroupsInput
+		resp []*elbv2sdk.TargetGroup
+		err  error
+	}
+	type describeLoadBalancersAsListCall struct {
+		req  *elbv2sdk.DescribeLoadBalancersInput
+		resp []*elbv2sdk.LoadBalancer
+		err  error
+	}
+	type describeSubnetsAsListCall struct {
+		req  *ec2sdk.DescribeSubnetsInput
+		resp []*ec2sdk.Subnet
+		err  error
+	}
+	type fields struct {
+		describeTargetGroupsAsListCalls  []describeTargetGroupsAsListCall
+		describeLoadBalancersAsListCalls []describeLoadBalancersAsListCall
+		describeSubnetsAsListCalls       []describeSubnetsAsListCall
+	}
+	protocolTCP := elbv2api.NetworkingProtocolTCP
+	protocolUDP := elbv2api.NetworkingProtocolUDP
+	port8080 := intstr.FromInt(8080)
+	port8443 := intstr.FromInt(8443)
+	port9000 := intstr.FromInt(9000)
+	tgb := &elbv2api.TargetGroupBinding{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "tgb-1"},
+		Spec: elbv2api.TargetGroupBindingSpec{
+			TargetGroupARN: "tg-1",
+		},
+	}
+	describeTGReq := &elbv2sdk.DescribeTargetGroupsInput{
+		TargetGroupArns: awssdk.StringSlice([]string{"tg-1"}),
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		targetPorts []int64
+		want        *elbv2api.TargetGroupBindingNetworking
+		wantCached  bool
+		wantErr     string
+	}{
+		{
+			name: "LoadBalancer with SecurityGroups",
+			fields: fields{
+				describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+					{
+						req: describeTGReq,
+						resp: []*elbv2sdk.TargetGroup{
+							{
+								TargetGroupArn:   awssdk.String("tg-1"),
+								Protocol:         awssdk.String("HTTP"),
+								HealthCheckPort:  awssdk.String("traffic-port"),
+								LoadBalancerArns: awssdk.StringSlice([]string{"lb-1"}),
+							},
+						},
+					},
+				},
+				describeLoadBalancersAsListCalls: []describeLoadBalancersAsListCall{
+					{
+						req: &elbv2sdk.DescribeLoadBalancersInput{
+							LoadBalancerArns: awssdk.StringSlice([]string{"lb-1"}),
+						},
+						resp: []*elbv2sdk.LoadBalancer{
+							{
+								LoadBalancerArn: awssdk.String("lb-1"),
+								SecurityGroups:  awssdk.StringSlice([]string{"sg-2", "sg-1"}),
+							},
+						},
+					},
+				},
+			},
+			targetPorts: []int64{8443, 8080, 8080},
+			want: &elbv2api.TargetGroupBindingNetworking{
+				Ingress: []elbv2api.NetworkingIngressRule{
+					{
+						From: []elbv2api.NetworkingPeer{
+							{SecurityGroup: &elbv2api.SecurityGroup{GroupID: "sg-1"}},
+							{SecurityGroup: &elbv2api.SecurityGroup{GroupID: "sg-2"}},
+						},
+						Ports: []elbv2api.NetworkingPort{
+							{Protocol: &protocolTCP, Port: &port8080},
+							{Protocol: &protocolTCP, Port: &port8443},
+						},
+					},
+				},
+			},
+			wantCached: true,
+		},
+		{
+			name: "LoadBalancer without SecurityGroups",
+			fields: fields{
+				describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+					{
+						req: describeTGReq,
+						resp: []*elbv2sdk.TargetGroup{
+							{
+								TargetGroupArn:   awssdk.String("tg-1"),
+								Protocol:         awssdk.String("UDP"),
+								HealthCheckPort:  awssdk.String("9000"),
+								LoadBalancerArns: awssdk.StringSlice([]string{"lb-1"}),
+							},
+						},
+					},
+				},
+				describeLoadBalancersAsListCalls: []describeLoadBalancersAsListCall{
+					{
+						req: &elbv2sdk.DescribeLoadBalancersInput{
+							LoadBalancerArns: awssdk.StringSlice([]string{"lb-1"}),
+						},
+						resp: []*elbv2sdk.LoadBalancer{
+							{
+								LoadBalancerArn: awssdk.String("lb-1"),
+								AvailabilityZones: []*elbv2sdk.AvailabilityZone{
+									{SubnetId: awssdk.String("subnet-1")},
+									{SubnetId: awssdk.String("subnet-2")},
+								},
+							},
+						},
+					},
+				},
+				describeSubnetsAsListCalls: []describeSubnetsAsListCall{
+					{
+						req: &ec2sdk.DescribeSubnetsInput{
+							SubnetIds: awssdk.StringSlice([]string{"subnet-1", "subnet-2"}),
+						},
+						resp: []*ec2sdk.Subnet{
+							{
+								SubnetId:  awssdk.String("subnet-1"),
+								CidrBlock: awssdk.String("192.168.0.0/19"),
+								Ipv6CidrBlockAssociationSet: []*ec2sdk.SubnetIpv6CidrBlockAssociation{
+									{Ipv6CidrBlock: awssdk.String("2600:1f14::/64")},
+								},
+							},
+							{
+								SubnetId:  awssdk.String("subnet-2"),
+								CidrBlock: awssdk.String("192.168.32.0/19"),
+							},
+						},
+					},
+				},
+			},
+			targetPorts: []int64{8080},
+			want: &elbv2api.TargetGroupBindingNetworking{
+				Ingress: []elbv2api.NetworkingIngressRule{
+					{
+						From: []elbv2api.NetworkingPeer{
+							{IPBlock: &elbv2api.IPBlock{CIDR: "192.168.0.0/19"}},
+							{IPBlock: &elbv2api.IPBlock{CIDR: "192.168.32.0/19"}},
+							{IPBlock: &elbv2api.IPBlock{CIDR: "2600:1f14::/64"}},
+						},
+						Ports: []elbv2api.NetworkingPort{
+							{Protocol: &protocolTCP, Port: &port8080},
+							{Protocol: &protocolUDP, Port: &port8080},
+							{Protocol: &protocolTCP, Port: &port9000},
+						},
+					},
+				},
+			},
+			wantCached: true,
+		},
+		{
+			name: "TargetGroup not attached to LoadBalancer",
+			fields: fields{
+				describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+					{
+						req: describeTGReq,
+						resp: []*elbv2sdk.TargetGroup{
+							{
+								TargetGroupArn: awssdk.String("tg-1"),
+								Protocol:       awssdk.String("HTTP"),
+							},
+						},
+					},
+				},
+			},
+			targetPorts: []int64{8080},
+			want:        nil,
+			wantCached:  false,
+		},
+		{
+			name:        "no targets",
+			targetPorts: nil,
+			want:        nil,
+		},
+		{
+			name: "TargetGroup not found",
+			fields: fields{
+				describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+					{
+						req:  describeTGReq,
+						resp: nil,
+					},
+				},
+			},
+			targetPorts: []int64{8080},
+			wantErr:     "failed to infer networking for TargetGroupBinding ns-1/tgb-1: expecting a single targetGroup but got 0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			elbv2Client := mock_services.NewMockELBV2(ctrl)
+			for _, call := range tt.fields.describeTargetGroupsAsListCalls {
+				elbv2Client.EXPECT().DescribeTargetGroupsAsList(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			for _, call := range tt.fields.describeLoadBalancersAsListCalls {
+				elbv2Client.EXPECT().DescribeLoadBalancersAsList(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			ec2Client := mock_services.NewMockEC2(ctrl)
+			for _, call := range tt.fields.describeSubnetsAsListCalls {
+				ec2Client.EXPECT().DescribeSubnetsAsList(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			i := &defaultNetworkingInferrer{
+				elbv2Client:                elbv2Client,
+				ec2Client:                  ec2Client,
+				enabled:                    true,
+				logger:                     log.NullLogger{},
+				inferredNetworkingCache:    cache.NewExpiring(),
+				inferredNetworkingCacheTTL: time.Minute,
+			}
+			got, err := i.Infer(context.Background(), tgb, tt.targetPorts)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			// networking inferred from LoadBalancers are cached, while TargetGroups without LoadBalancers are looked up again.
+			_, cached := i.fetchInferredNetworkingFromCache("tg-1")
+			assert.Equal(t, tt.wantCached, cached)
+			if cached {
+				gotFromCache, err := i.Infer(context.Background(), tgb, tt.targetPorts)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, gotFromCache)
+			}
+		})
+	}
+}
@@ -26,6 +26,8 @@ import (
 const (
 	tgbNetworkingIPPermissionLabelKey   = "elbv2.k8s.aws/targetGroupBinding"
 	tgbNetworkingIPPermissionLabelValue = "shared"
+	// the tag key on securityGroup rules for the cluster that granted the rule.
+	tgbNetworkingRuleTagKeyCluster = "elbv2.k8s.aws/cluster"
 )
 
 // NetworkingManager manages the networking for targetGroupBindings.
@@ -42,17 +44,19 @@ type NetworkingManager interface {
 
 // NewDefaultNetworkingManager constructs defaultNetworkingManager.
 func NewDefaultNetworkingManager(k8sClient client.Client, podENIResolver networking.PodENIInfoResolver, nodeENIResolver networking.NodeENIInfoResolver,
-	sgManager networking.SecurityGroupManager, sgReconciler networking.SecurityGroupReconciler, vpcID string, clusterName string, logger logr.Logger) *defaultNetworkingManager {
+	sgManager networking.SecurityGroupManager, sgReconciler networking.SecurityGroupReconciler, networkingInferrer NetworkingInferrer,
+	vpcID string, clusterName string, logger logr.Logger) *defaultNetworkingManager {
 
 	return &defaultNetworkingManager{
-		k8sClient:       k8sClient,
-		podENIResolver:  podENIResolver,
-		nodeENIResolver: nodeENIResolver,
-		sgManager:       sgManager,
-		sgReconciler:    sgReconciler,
-		vpcID:           vpcID,
-		clusterName:     clusterName,
-		logger:          logger,
+		k8sClient:          k8sClient,
+		podENIResolver:     podENIResolver,
+		nodeENIResolver:    nodeENIResolver,
+		sgManager:          sgManager,
+		sgReconciler:       sgReconciler,
+		networkingInferrer: networkingInferrer,
+		vpcID:              vpcID,
+		clusterName:        clusterName,
+		logger:             logger,
 
 		mutex:                         sync.Mutex{},
 		ingressPermissionsPerSGByTGB:  make(map[types.NamespacedName]map[string][]networking.IPPermissionInfo),
@@ -63,14 +67,15 @@ func NewDefaultNetworkingManager(k8sClient client.Client, podENIResolver network
 
 // default implementation for NetworkingManager.
 type defaultNetworkingManager struct {
-	k8sClient       client.Client
-	podENIResolver  networking.PodENIInfoResolver
-	nodeENIResolver networking.NodeENIInfoResolver
-	sgManager       networking.SecurityGroupManager
-	sgReconciler    networking.SecurityGroupReconciler
-	vpcID           string
-	clusterName     string
-	logger          logr.Logger
+	k8sClient          client.Client
+	podENIResolver     networking.PodENIInfoResolver
+	nodeENIResolver    networking.NodeENIInfoResolver
+	sgManager          networking.SecurityGroupManager
+	sgReconciler       networking.SecurityGroupReconciler
+	networkingInferrer NetworkingInferrer
+	vpcID              string
+	clusterName        string
+	logger             logr.Logger
 
 	// mutex will serialize our TargetGroup's networking reconcile requests.
 	mutex sync.Mutex
@@ -87,10 +92,17 @@ type defaultNetworkingManager struct {
 }
 
 func (m *defaultNetworkingManager) ReconcileForPodEndpoints(ctx context.Context, tgb *elbv2api.TargetGroupBinding, endpoints []backend.PodEndpoint) error {
+	targetPorts := make([]int64, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		targetPorts = append(targetPorts, endpoint.Port)
+	}
+	tgbNetworking, err := m.resolveTGBNetworking(ctx, tgb, targetPorts)
+	if err != nil {
+		return err
+	}
 	var ingressPermissionsPerSG map[string][]networking.IPPermissionInfo
-	if tgb.Spec.Networking != nil {
-		var err error
-		ingressPermissionsPerSG, err = m.computeIngressPermissionsPerSGWithPodEndpoints(ctx, *tgb.Spec.Networking, endpoints)
+	if tgbNetworking != nil {
+		ingressPermissionsPerSG, err = m.computeIngressPermissionsPerSGWithPodEndpoints(ctx, *tgbNetworking, endpoints)
 		if err != nil {
 			return err
 		}
@@ -99,10 +111,17 @@ func (m *defaultNetworkingManager) ReconcileForPodEndpoints(ctx context.Context,
 }
 
 func (m *defaultNetworkingManager) ReconcileForNodePortEndpoints(ctx context.Context, tgb *elbv2api.TargetGroupBinding, endpoints []backend.NodePortEndpoint) error {
+	targetPorts := make([]int64, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		targetPorts = append(targetPorts, endpoint.Port)
+	}
+	tgbNetworking, err := m.resolveTGBNetworking(ctx, tgb, targetPorts)
+	if err != nil {
+		return err
+	}
 	var ingressPermissionsPerSG map[string][]networking.IPPermissionInfo
-	if tgb.Spec.Networking != nil {
-		var err error
-		ingressPermissionsPerSG, err = m.computeIngressPermissionsPerSGWithNodePortEndpoints(ctx, *tgb.Spec.Networking, endpoints)
+	if tgbNetworking != nil {
+		ingressPermissionsPerSG, err = m.computeIngressPermissionsPerSGWithNodePortEndpoints(ctx, *tgbNetworking, endpoints)
 		if err != nil {
 			return err
 		}
@@ -114,6 +133,15 @@ func (m *defaultNetworkingManager) Cleanup(ctx context.Context, tgb *elbv2api.Ta
 	return m.reconcileWithIngressPermissionsPerSG(ctx, tgb, nil)
 }
 
+// resolveTGBNetworking resolves the networking rules for TargetGroupBinding.
+// networking rules are inferred from LoadBalancers of the TargetGroup for targetPorts if spec.networking is omitted.
+func (m *defaultNetworkingManager) resolveTGBNetworking(ctx context.Context, tgb *elbv2api.TargetGroupBinding, targetPorts []int64) (*elbv2api.TargetGroupBindingNetworking, error) {
+	if !m.networkingInferrer.ShouldInfer(tgb) {
+		return tgb.Spec.Networking, nil
+	}
+	return m.networkingInferrer.Infer(ctx, tgb, targetPorts)
+}
+
 func (m *defaultNetworkingManager) computeIngressPermissionsPerSGWithPodEndpoints(ctx context.Context, tgbNetworking elbv2api.TargetGroupBindingNetworking, endpoints []backend.PodEndpoint) (map[string][]networking.IPPermissionInfo, error) {
 	pods := make([]k8s.PodInfo, 0, len(endpoints))
 	podByPodKey := make(map[types.NamespacedName]k8s.PodInfo, len(endpoints))
@@ -148,6 +176,11 @@ func (m *defaultNetworkingManager) computeIngressPermissionsPerSGWithPodEndpoint
 }
 
 func (m *defaultNetworkingManager) computeIngressPermissionsPerSGWithNodePortEndpoints(ctx context.Context, tgbNetworking elbv2api.TargetGroupBindingNetworking, endpoints []backend.NodePortEndpoint) (map[string][]networking.IPPermissionInfo, error) {
+	if len(endpoints) == 0 {
+		return map[string][]networking.IPPermissionInfo{}, nil
+	}
+	// all nodePort endpoints of a TargetGroupBinding share the same nodePort.
+	tgbNetworking = resolveNamedPortsToNodePort(tgbNetworking, endpoints[0].Port)
 	nodes := make([]*corev1.Node, 0, len(endpoints))
 	for _, endpoint := range endpoints {
 		nodes = append(nodes, endpoint.Node)
@@ -193,10 +226,15 @@ func (m *defaultNetworkingManager) reconcileWithIngressPermissionsPerSG(ctx cont
 	aggregatedIngressPermissionsPerSG := m.computeAggregatedIngressPermissionsPerSG(ctx)
 
 	permissionSelector := labels.SelectorFromSet(labels.Set{tgbNetworkingIPPermissionLabelKey: tgbNetworkingIPPermissionLabelValue})
+	ruleTags := map[string]string{
+		tgbNetworkingIPPermissionLabelKey: tgbNetworkingIPPermissionLabelValue,
+		tgbNetworkingRuleTagKeyCluster:    m.clusterName,
+	}
 	for sgID, permissions := range aggregatedIngressPermissionsPerSG {
 		if err := m.sgReconciler.ReconcileIngress(ctx, sgID, permissions,
 			networking.WithPermissionSelector(permissionSelector),
-			networking.WithAuthorizeOnly(!computedForAllTGBs)); err != nil {
+			networking.WithAuthorizeOnly(!computedForAllTGBs),
+			networking.WithRuleTags(ruleTags)); err != nil {
 			return err
 		}
 	}
@@ -267,11 +305,11 @@ func (m *defaultNetworkingManager) computeIngressPermissionsForTGBNetworking(ctx
 					Protocol: &protocolTCP,
 					Port:     nil,
 				}
-				permissions, err := m.computePermissionsForPeerPort(ctx, rulePeer, allTCPPort, pods)
+				permissionsForAllTCPPort, err := m.computePermissionsForPeerPort(ctx, rulePeer, allTCPPort, pods)
 				if err != nil {
 					return nil, err
 				}
-				permissions = append(permissions, permissions...)
+				permissions = append(permissions, permissionsForAllTCPPort...)
 			}
 		}
 	}
@@ -369,6 +407,19 @@ func (m *defaultNetworkingManager) computeNumericalPorts(_ context.Context, port
 	return containerPorts.List(), nil
 }
 
+// resolveNamedPortsToNodePort resolves named ports in networking rules to the nodePort, which receives traffic of instance targets.
+func resolveNamedPortsToNodePort(tgbNetworking elbv2api.TargetGroupBindingNetworking, nodePort int64) elbv2api.TargetGroupBindingNetworking {
+	resolvedTGBNetworking := tgbNetworking.DeepCopy()
+	for _, rule := range resolvedTGBNetworking.Ingress {
+		for _, rulePort := range rule.Ports {
+			if rulePort.Port != nil && rulePort.Port.Type == intstr.String {
+				*rulePort.Port = intstr.FromInt(int(nodePort))
+			}
+		}
+	}
+	return *resolvedTGBNetworking
+}
+
 // gcIngressPermissionsFromUnusedEndpointSGs will garbage collect ingress permissions from endpoint SecurityGroups that are no longer used.
 func (m *defaultNetworkingManager) gcIngressPermissionsFromUnusedEndpointSGs(ctx context.Context, ingressPermissionsPerSG map[string][]networking.IPPermissionInfo) error {
 	endpointSGs, err := m.fetchEndpointSGs(ctx)
@@ -393,7 +444,7 @@ func (m *defaultNetworkingManager) gcIngressPermissionsFromUnusedEndpointSGs(ctx
 	return nil
 }
 
-// fetchTGBsWithNetworking returns all targetGroupsBindings with networking rules in cluster.
+// fetchTGBsWithNetworking returns all targetGroupsBindings with networking rules in cluster, including the ones with inferred networking rules.
 func (m *defaultNetworkingManager) fetchTGBsWithNetworking(ctx context.Context) (map[types.NamespacedName]*elbv2api.TargetGroupBinding, error) {
 	tgbList := &elbv2api.TargetGroupBindingList{}
 	if err := m.k8sClient.List(ctx, tgbList); err != nil {
@@ -402,7 +453,7 @@ func (m *defaultNetworkingManager) fetchTGBsWithNetworking(ctx context.Context)
 	tgbWithNetworkingByKey := make(map[types.NamespacedName]*elbv2api.TargetGroupBinding, len(tgbList.Items))
 	for i := range tgbList.Items {
 		tgb := &tgbList.Items[i]
-		if tgb.Spec.Networking != nil {
+		if tgb.Spec.Networking != nil || m.networkingInferrer.ShouldInfer(tgb) {
 			tgbWithNetworkingByKey[k8s.NamespacedName(tgb)] = tgb
 		}
 	}
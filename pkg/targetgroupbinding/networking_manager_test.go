@@ -67,6 +67,41 @@ func Test_defaultNetworkingManager_computeIngressPermissionsForTGBNetworking(t *
 				},
 			},
 		},
+		{
+			name: "with one rule / one peer / no port",
+			args: args{
+				tgbNetworking: elbv2api.TargetGroupBindingNetworking{
+					Ingress: []elbv2api.NetworkingIngressRule{
+						{
+							From: []elbv2api.NetworkingPeer{
+								{
+									SecurityGroup: &elbv2api.SecurityGroup{
+										GroupID: "sg-abcdefg",
+									},
+								},
+							},
+							Ports: nil,
+						},
+					},
+				},
+			},
+			want: []networking.IPPermissionInfo{
+				{
+					Permission: ec2sdk.IpPermission{
+						IpProtocol: awssdk.String("tcp"),
+						FromPort:   awssdk.Int64(0),
+						ToPort:     awssdk.Int64(65535),
+						UserIdGroupPairs: []*ec2sdk.UserIdGroupPair{
+							{
+								Description: awssdk.String("elbv2.k8s.aws/targetGroupBinding=shared"),
+								GroupId:     awssdk.String("sg-abcdefg"),
+							},
+						},
+					},
+					Labels: map[string]string{tgbNetworkingIPPermissionLabelKey: tgbNetworkingIPPermissionLabelValue},
+				},
+			},
+		},
 		{
 			name: "with one rule / multiple peer / multiple port",
 			args: args{
@@ -582,6 +617,76 @@ func Test_defaultNetworkingManager_computeNumericalPorts(t *testing.T) {
 	}
 }
 
+func Test_resolveNamedPortsToNodePort(t *testing.T) {
+	protocolTCP := elbv2api.NetworkingProtocolTCP
+	port8080 := intstr.FromInt(8080)
+	portHTTP := intstr.FromString("http")
+	port32768 := intstr.FromInt(32768)
+	tests := []struct {
+		name          string
+		tgbNetworking elbv2api.TargetGroupBindingNetworking
+		nodePort      int64
+		want          elbv2api.TargetGroupBindingNetworking
+	}{
+		{
+			name: "numerical and unspecified ports are kept as is",
+			tgbNetworking: elbv2api.TargetGroupBindingNetworking{
+				Ingress: []elbv2api.NetworkingIngressRule{
+					{
+						Ports: []elbv2api.NetworkingPort{
+							{Protocol: &protocolTCP, Port: &port8080},
+							{Protocol: &protocolTCP, Port: nil},
+						},
+					},
+				},
+			},
+			nodePort: 32768,
+			want: elbv2api.TargetGroupBindingNetworking{
+				Ingress: []elbv2api.NetworkingIngressRule{
+					{
+						Ports: []elbv2api.NetworkingPort{
+							{Protocol: &protocolTCP, Port: &port8080},
+							{Protocol: &protocolTCP, Port: nil},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "named ports are resolved to nodePort",
+			tgbNetworking: elbv2api.TargetGroupBindingNetworking{
+				Ingress: []elbv2api.NetworkingIngressRule{
+					{
+						Ports: []elbv2api.NetworkingPort{
+							{Protocol: &protocolTCP, Port: &portHTTP},
+							{Protocol: &protocolTCP, Port: &port8080},
+						},
+					},
+				},
+			},
+			nodePort: 32768,
+			want: elbv2api.TargetGroupBindingNetworking{
+				Ingress: []elbv2api.NetworkingIngressRule{
+					{
+						Ports: []elbv2api.NetworkingPort{
+							{Protocol: &protocolTCP, Port: &port32768},
+							{Protocol: &protocolTCP, Port: &port8080},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := tt.tgbNetworking.DeepCopy()
+			got := resolveNamedPortsToNodePort(tt.tgbNetworking, tt.nodePort)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, *original, tt.tgbNetworking)
+		})
+	}
+}
+
 func Test_defaultNetworkingManager_computeAggregatedIngressPermissionsPerSG(t *testing.T) {
 	type fields struct {
 		ingressPermissionsPerSGByTGB map[types.NamespacedName]map[string][]networking.IPPermissionInfo
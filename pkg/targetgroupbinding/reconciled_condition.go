@@ -0,0 +1,103 @@
+package targetgroupbinding
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// the reason of Reconciled condition of TargetGroupBinding once reconcile succeeded, error classes are used as reasons otherwise.
+	tgbConditionReasonReconciled = "Reconciled"
+	// the reason of ReconcilePaused condition of TargetGroupBinding once reconcile is resumed, terminal reasons are used as reasons otherwise.
+	tgbConditionReasonReconcileResumed = "ReconcileResumed"
+	// the reasons of DriftDetected condition of TargetGroupBinding.
+	tgbConditionReasonDriftDetected = "DriftDetected"
+	tgbConditionReasonNoDrift       = "NoDrift"
+)
+
+// UpdateReconciledCondition updates the Reconciled condition of TargetGroupBinding with the class of reconcileErr.
+// the condition is only added once reconcile failed, and is kept as true afterwards.
+func UpdateReconciledCondition(ctx context.Context, k8sClient client.Client, tgb *elbv2api.TargetGroupBinding, errorClass runtime.ErrorClass, reconcileErr error) error {
+	if _, exists := findTargetGroupBindingCondition(tgb, elbv2api.TargetGroupBindingConditionReconciled); !exists && errorClass == "" {
+		return nil
+	}
+
+	newCond := elbv2api.TargetGroupBindingCondition{
+		Type:   elbv2api.TargetGroupBindingConditionReconciled,
+		Status: corev1.ConditionTrue,
+		Reason: tgbConditionReasonReconciled,
+	}
+	if errorClass != "" {
+		newCond.Status = corev1.ConditionFalse
+		newCond.Reason = string(errorClass)
+		newCond.Message = reconcileErr.Error()
+	}
+	tgbOld := tgb.DeepCopy()
+	if !setTargetGroupBindingCondition(tgb, newCond) {
+		return nil
+	}
+	if err := k8sClient.Status().Patch(ctx, tgb, client.MergeFrom(tgbOld)); err != nil {
+		return errors.Wrapf(err, "failed to update targetGroupBinding status: %v", k8s.NamespacedName(tgb))
+	}
+	return nil
+}
+
+// UpdateReconcilePausedCondition updates the ReconcilePaused condition of TargetGroupBinding with the terminal reason of reconcileErr.
+// the condition is only added once reconcile is paused, and is set to false once resumed.
+func UpdateReconcilePausedCondition(ctx context.Context, k8sClient client.Client, tgb *elbv2api.TargetGroupBinding, reason string, reconcileErr error) error {
+	if _, exists := findTargetGroupBindingCondition(tgb, elbv2api.TargetGroupBindingConditionReconcilePaused); !exists && reason == "" {
+		return nil
+	}
+
+	newCond := elbv2api.TargetGroupBindingCondition{
+		Type:   elbv2api.TargetGroupBindingConditionReconcilePaused,
+		Status: corev1.ConditionFalse,
+		Reason: tgbConditionReasonReconcileResumed,
+	}
+	if reason != "" {
+		newCond.Status = corev1.ConditionTrue
+		newCond.Reason = reason
+		newCond.Message = fmt.Sprintf("Paused reconcile until changed due to %v", reconcileErr)
+	}
+	tgbOld := tgb.DeepCopy()
+	if !setTargetGroupBindingCondition(tgb, newCond) {
+		return nil
+	}
+	if err := k8sClient.Status().Patch(ctx, tgb, client.MergeFrom(tgbOld)); err != nil {
+		return errors.Wrapf(err, "failed to update targetGroupBinding status: %v", k8s.NamespacedName(tgb))
+	}
+	return nil
+}
+
+// UpdateDriftDetectedCondition updates the DriftDetected condition of TargetGroupBinding with the drift of AWS resources.
+// the condition is only added once drift is detected, and is set to false once AWS resources match the desired state.
+func UpdateDriftDetectedCondition(ctx context.Context, k8sClient client.Client, tgb *elbv2api.TargetGroupBinding, drift string) error {
+	if _, exists := findTargetGroupBindingCondition(tgb, elbv2api.TargetGroupBindingConditionDriftDetected); !exists && drift == "" {
+		return nil
+	}
+
+	newCond := elbv2api.TargetGroupBindingCondition{
+		Type:   elbv2api.TargetGroupBindingConditionDriftDetected,
+		Status: corev1.ConditionFalse,
+		Reason: tgbConditionReasonNoDrift,
+	}
+	if drift != "" {
+		newCond.Status = corev1.ConditionTrue
+		newCond.Reason = tgbConditionReasonDriftDetected
+		newCond.Message = drift
+	}
+	tgbOld := tgb.DeepCopy()
+	if !setTargetGroupBindingCondition(tgb, newCond) {
+		return nil
+	}
+	if err := k8sClient.Status().Patch(ctx, tgb, client.MergeFrom(tgbOld)); err != nil {
+		return errors.Wrapf(err, "failed to update targetGroupBinding status: %v", k8s.NamespacedName(tgb))
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package targetgroupbinding
+
+import (
+	"context"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+func Test_UpdateDriftDetectedCondition(t *testing.T) {
+	tests := []struct {
+		name           string
+		conditions     []elbv2api.TargetGroupBindingCondition
+		drift          string
+		wantConditions []elbv2api.TargetGroupBindingCondition
+	}{
+		{
+			name:           "no drift and no condition",
+			drift:          "",
+			wantConditions: nil,
+		},
+		{
+			name:  "drift detected",
+			drift: "elasticloadbalancing:RegisterTargets",
+			wantConditions: []elbv2api.TargetGroupBindingCondition{
+				{
+					Type:    elbv2api.TargetGroupBindingConditionDriftDetected,
+					Status:  corev1.ConditionTrue,
+					Reason:  "DriftDetected",
+					Message: "elasticloadbalancing:RegisterTargets",
+				},
+			},
+		},
+		{
+			name: "drift resolved",
+			conditions: []elbv2api.TargetGroupBindingCondition{
+				{
+					Type:    elbv2api.TargetGroupBindingConditionDriftDetected,
+					Status:  corev1.ConditionTrue,
+					Reason:  "DriftDetected",
+					Message: "elasticloadbalancing:RegisterTargets",
+				},
+			},
+			drift: "",
+			wantConditions: []elbv2api.TargetGroupBindingCondition{
+				{
+					Type:   elbv2api.TargetGroupBindingConditionDriftDetected,
+					Status: corev1.ConditionFalse,
+					Reason: "NoDrift",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+
+			ctx := context.Background()
+			tgb := &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "my-tgb",
+				},
+				Status: elbv2api.TargetGroupBindingStatus{
+					Conditions: tt.conditions,
+				},
+			}
+			assert.NoError(t, k8sClient.Create(ctx, tgb))
+
+			err := UpdateDriftDetectedCondition(ctx, k8sClient, tgb, tt.drift)
+			assert.NoError(t, err)
+
+			updatedTGB := &elbv2api.TargetGroupBinding{}
+			assert.NoError(t, k8sClient.Get(ctx, k8s.NamespacedName(tgb), updatedTGB))
+			opts := cmpopts.IgnoreTypes(metav1.Time{})
+			assert.True(t, cmp.Equal(tt.wantConditions, updatedTGB.Status.Conditions, opts),
+				"diff", cmp.Diff(tt.wantConditions, updatedTGB.Status.Conditions, opts))
+		})
+	}
+}
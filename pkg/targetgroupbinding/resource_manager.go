@@ -10,22 +10,45 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1beta1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/cache"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/backend"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/quota"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
+	tgbmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/targetgroupbinding/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sync"
 	"time"
 )
 
-const defaultTargetHealthRequeueDuration = 15 * time.Second
+const (
+	defaultTargetHealthRequeueDuration = 15 * time.Second
+	// the TTL of cached TargetGroup attributes, attributes modified outside of the controller are reconciled once expired.
+	defaultTargetGroupAttributesCacheTTL = 10 * time.Minute
+	// the availabilityZone for IP targets outside the TargetGroup's VPC.
+	targetAvailabilityZoneAll = "all"
+	// the reason of targetHealth condition for pods whose readiness gate timed out waiting on target health.
+	podConditionReasonReadinessGateTimeout = "ReadinessGateTimeout"
+	// the reason of targetHealth condition for pods whose targets are not registered since TargetGroup reached the max targets.
+	podConditionReasonTargetsOverflowed = "TargetsOverflowed"
+	// the reason of targetHealth condition for pods whose targets are held to keep availability zones balanced.
+	podConditionReasonZoneImbalanced = "ZoneImbalanced"
+
+	// the reasons of TargetsOverflowed condition of TargetGroupBinding.
+	tgbConditionReasonMaxTargetsReached = "MaxTargetsReached"
+	tgbConditionReasonTargetsRegistered = "TargetsRegistered"
+)
 
 // ResourceManager manages the TargetGroupBinding resource.
 type ResourceManager interface {
@@ -34,21 +57,40 @@ type ResourceManager interface {
 }
 
 // NewDefaultResourceManager constructs new defaultResourceManager.
-func NewDefaultResourceManager(k8sClient client.Client, elbv2Client services.ELBV2,
+func NewDefaultResourceManager(k8sClient client.Client, cloud aws.Cloud,
 	podInfoRepo k8s.PodInfoRepo, podENIResolver networking.PodENIInfoResolver, nodeENIResolver networking.NodeENIInfoResolver,
 	sgManager networking.SecurityGroupManager, sgReconciler networking.SecurityGroupReconciler,
-	vpcID string, clusterName string, logger logr.Logger) *defaultResourceManager {
-	targetsManager := NewCachedTargetsManager(elbv2Client, logger)
+	vpcID string, clusterName string, readinessGateMaxWait time.Duration, enableNetworkingInference bool, excludedNodeTaintKeys []string,
+	excludeDrainingNodes bool, targetHealthPollPeriod time.Duration, metricsCollector tgbmetrics.Collector, logger logr.Logger) *defaultResourceManager {
+	targetsManager := NewCachedTargetsManager(cloud.ELBV2(), logger)
 	endpointResolver := backend.NewDefaultEndpointResolver(k8sClient, podInfoRepo, logger)
-	networkingManager := NewDefaultNetworkingManager(k8sClient, podENIResolver, nodeENIResolver, sgManager, sgReconciler, vpcID, clusterName, logger)
+	networkingInferrer := NewDefaultNetworkingInferrer(cloud, enableNetworkingInference, logger)
+	networkingManager := NewDefaultNetworkingManager(k8sClient, podENIResolver, nodeENIResolver, sgManager, sgReconciler, networkingInferrer, vpcID, clusterName, logger)
 	return &defaultResourceManager{
-		k8sClient:         k8sClient,
-		targetsManager:    targetsManager,
-		endpointResolver:  endpointResolver,
-		networkingManager: networkingManager,
-		logger:            logger,
+		k8sClient:              k8sClient,
+		cloud:                  cloud,
+		elbv2Client:            cloud.ELBV2(),
+		targetsManager:         targetsManager,
+		endpointResolver:       endpointResolver,
+		networkingManager:      networkingManager,
+		endpointsFingerprinter: NewDefaultEndpointsFingerprinter(k8sClient, excludeDrainingNodes),
+		quotaProvider:          quota.NewDefaultProvider(cloud.ServiceQuotas(), logger),
+		targetHealthReporter:   NewDefaultTargetHealthReporter(k8sClient, cloud, metricsCollector, logger),
+		metricsCollector:       metricsCollector,
+		vpcID:                  vpcID,
+		logger:                 logger,
+
+		assumedRoleTargetsManagers: make(map[string]TargetsManager),
+		assumedRoleQuotaProviders:  make(map[string]quota.Provider),
+
+		targetGroupAttributesCache:    cache.NewExpiring(),
+		targetGroupAttributesCacheTTL: defaultTargetGroupAttributesCacheTTL,
 
 		targetHealthRequeueDuration: defaultTargetHealthRequeueDuration,
+		readinessGateMaxWait:        readinessGateMaxWait,
+		excludedNodeTaintKeys:       excludedNodeTaintKeys,
+		excludeDrainingNodes:        excludeDrainingNodes,
+		targetHealthPollPeriod:      targetHealthPollPeriod,
 	}
 }
 
@@ -57,65 +99,155 @@ var _ ResourceManager = &defaultResourceManager{}
 // default implementation for ResourceManager.
 type defaultResourceManager struct {
 	k8sClient         client.Client
+	cloud             aws.Cloud
+	elbv2Client       services.ELBV2
 	targetsManager    TargetsManager
 	endpointResolver  backend.EndpointResolver
 	networkingManager NetworkingManager
-	logger            logr.Logger
+	// endpointsFingerprinter tracks endpoints that targets are synced with, so that unchanged endpoints are not resynchronized.
+	endpointsFingerprinter EndpointsFingerprinter
+	quotaProvider          quota.Provider
+	targetHealthReporter   TargetHealthReporter
+	metricsCollector       tgbmetrics.Collector
+	// vpcID is the VPC of the controller.
+	vpcID  string
+	logger logr.Logger
+
+	// assumedRoleTargetsManagers caches the TargetsManagers for TargetGroups in other AWS accounts or regions by IAM role ARN and region.
+	assumedRoleTargetsManagers      map[string]TargetsManager
+	assumedRoleTargetsManagersMutex sync.Mutex
+	// assumedRoleQuotaProviders caches the quota Providers for TargetGroups in other AWS accounts or regions by IAM role ARN and region.
+	assumedRoleQuotaProviders      map[string]quota.Provider
+	assumedRoleQuotaProvidersMutex sync.Mutex
+
+	// cache of TargetGroup attributes by targetGroupARN, so that they are not described on every reconcile of TargetGroupBindings with targetGroupAttributes.
+	targetGroupAttributesCache *cache.Expiring
+	// TTL for each TargetGroup's attributes.
+	targetGroupAttributesCacheTTL time.Duration
 
 	targetHealthRequeueDuration time.Duration
+	// readinessGateMaxWait is the max duration to wait on target health before timing out readiness gates, zero means wait forever.
+	readinessGateMaxWait time.Duration
+	// excludedNodeTaintKeys are keys of taints that exclude nodes from being registered for instance TargetType.
+	excludedNodeTaintKeys []string
+	// excludeDrainingNodes instructs to deregister targets on nodes that are drained or tainted to be terminated.
+	excludeDrainingNodes bool
+	// targetHealthPollPeriod is the period to poll target health, target health is only reported when it's positive.
+	targetHealthPollPeriod time.Duration
 }
 
 func (m *defaultResourceManager) Reconcile(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
 	if tgb.Spec.TargetType == nil {
 		return errors.Errorf("targetType is not specified: %v", k8s.NamespacedName(tgb).String())
 	}
-	if *tgb.Spec.TargetType == elbv2api.TargetTypeIP {
-		return m.reconcileWithIPTargetType(ctx, tgb)
+	if *tgb.Spec.TargetType != elbv2api.TargetTypeLambda && tgb.Spec.ServiceRef.Name == "" {
+		return runtime.NewTerminalError("MissingServiceRef", errors.Errorf("serviceRef is required for %v targetType: %v",
+			*tgb.Spec.TargetType, k8s.NamespacedName(tgb).String()))
+	}
+	if err := m.reconcileTargetGroupAttributes(ctx, tgb); err != nil {
+		return m.reportTargetHealth(ctx, tgb, err)
+	}
+	var err error
+	switch *tgb.Spec.TargetType {
+	case elbv2api.TargetTypeIP:
+		err = m.reconcileWithIPTargetType(ctx, tgb)
+	case elbv2api.TargetTypeLambda:
+		err = m.reconcileWithLambdaTargetType(ctx, tgb)
+	default:
+		err = m.reconcileWithInstanceTargetType(ctx, tgb)
 	}
-	return m.reconcileWithInstanceTargetType(ctx, tgb)
+	return m.reportTargetHealth(ctx, tgb, err)
 }
 
 func (m *defaultResourceManager) Cleanup(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
-	if err := m.cleanupTargets(ctx, tgb); err != nil {
-		return err
+	// the Lambda function of lambda TargetType is registered outside the controller, so it's kept as is.
+	if tgb.Spec.TargetType == nil || *tgb.Spec.TargetType != elbv2api.TargetTypeLambda {
+		if err := m.cleanupTargets(ctx, tgb); err != nil {
+			return err
+		}
 	}
 	if err := m.networkingManager.Cleanup(ctx, tgb); err != nil {
 		return err
 	}
+	m.endpointsFingerprinter.Forget(k8s.NamespacedName(tgb))
+	m.targetHealthReporter.Forget(k8s.NamespacedName(tgb))
+	m.metricsCollector.Forget(k8s.NamespacedName(tgb))
 	return nil
 }
 
 func (m *defaultResourceManager) reconcileWithIPTargetType(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
 	svcKey := buildServiceReferenceKey(tgb, tgb.Spec.ServiceRef)
+	tgbKey := k8s.NamespacedName(tgb)
+	// the fingerprint is computed ahead of resolving endpoints, so that changes in between are resynchronized by next reconcile.
+	endpointsFingerprint := m.endpointsFingerprinter.ComputePodEndpointsFingerprint(ctx, tgb, svcKey)
+	if m.endpointsFingerprinter.IsSynced(tgbKey, endpointsFingerprint) {
+		m.logger.V(1).Info("skipping targets sync for unchanged endpoints", "targetGroupBinding", tgbKey)
+		return nil
+	}
+	m.endpointsFingerprinter.Forget(tgbKey)
 
 	targetHealthCondType := BuildTargetHealthPodConditionType(tgb)
 	resolveOpts := []backend.EndpointResolveOption{
 		backend.WithPodReadinessGate(targetHealthCondType),
+		backend.WithExcludeDrainingNodes(m.excludeDrainingNodes),
+	}
+	if tgb.Spec.IPAddressType != nil && (*tgb.Spec.IPAddressType) == elbv2api.TargetGroupIPAddressTypeIPv6 {
+		resolveOpts = append(resolveOpts, backend.WithEndpointAddressType(discovery.AddressTypeIPv6))
 	}
-	endpoints, containsPotentialReadyEndpoints, err := m.endpointResolver.ResolvePodEndpoints(ctx, svcKey, tgb.Spec.ServiceRef.Port, resolveOpts...)
+	resolvedEndpoints, containsPotentialReadyEndpoints, err := m.endpointResolver.ResolvePodEndpoints(ctx, svcKey, tgb.Spec.ServiceRef.Port, resolveOpts...)
 	if err != nil {
 		return err
 	}
+	endpoints, deregisteringEndpoints := partitionPodEndpointsByDeregistrationStatus(resolvedEndpoints, targetHealthCondType)
 
-	tgARN := tgb.Spec.TargetGroupARN
-	targets, err := m.targetsManager.ListTargets(ctx, tgARN)
+	targets, err := m.targetsManagerForTGB(tgb).ListTargets(ctx, tgb.Spec.TargetGroupARN)
 	if err != nil {
 		return err
 	}
 	notDrainingTargets, drainingTargets := partitionTargetsByDrainingStatus(targets)
 	matchedEndpointAndTargets, unmatchedEndpoints, unmatchedTargets := matchPodEndpointWithTargets(endpoints, notDrainingTargets)
 
-	if err := m.networkingManager.ReconcileForPodEndpoints(ctx, tgb, endpoints); err != nil {
+	// deregistering endpoints still receive traffic until their targets finished draining.
+	if err := m.networkingManager.ReconcileForPodEndpoints(ctx, tgb, resolvedEndpoints); err != nil {
 		return err
 	}
-	if err := m.deregisterTargets(ctx, tgARN, unmatchedTargets); err != nil {
+	if err := m.deregisterTargets(ctx, tgb, unmatchedTargets); err != nil {
 		return err
 	}
-	if err := m.registerPodEndpoints(ctx, tgARN, unmatchedEndpoints); err != nil {
+	var zoneImbalancedEndpoints []backend.PodEndpoint
+	if tgb.Spec.ZoneBalancing != nil {
+		registeredEndpoints := make([]backend.PodEndpoint, 0, len(matchedEndpointAndTargets))
+		for _, endpointAndTarget := range matchedEndpointAndTargets {
+			registeredEndpoints = append(registeredEndpoints, endpointAndTarget.endpoint)
+		}
+		unmatchedEndpoints, zoneImbalancedEndpoints = balancePodEndpointsByZone(registeredEndpoints, unmatchedEndpoints, tgb.Spec.ZoneBalancing.MaxSkew)
+		if len(zoneImbalancedEndpoints) != 0 {
+			m.logger.V(1).Info("holding targets to keep availability zones balanced", "targetGroupBinding", tgbKey, "heldTargets", len(zoneImbalancedEndpoints))
+		}
+	}
+	allowedEndpointCount, err := m.reconcileTargetsOverflow(ctx, tgb, len(matchedEndpointAndTargets)+len(drainingTargets), len(unmatchedEndpoints))
+	if err != nil {
+		return err
+	}
+	// unmatchedEndpoints are ordered by their IDs, so that the same endpoints are dropped across reconciles.
+	unmatchedEndpoints, droppedEndpoints := unmatchedEndpoints[:allowedEndpointCount], unmatchedEndpoints[allowedEndpointCount:]
+	if err := m.registerPodEndpoints(ctx, tgb, unmatchedEndpoints); err != nil {
+		return err
+	}
+	if err := m.updateUnregisteredPodCondition(ctx, targetHealthCondType, droppedEndpoints,
+		podConditionReasonTargetsOverflowed, "Target is not registered since TargetGroup reached the max targets"); err != nil {
+		return err
+	}
+	if err := m.updateUnregisteredPodCondition(ctx, targetHealthCondType, zoneImbalancedEndpoints,
+		podConditionReasonZoneImbalanced, "Target is not registered until other availability zones catch up"); err != nil {
 		return err
 	}
 
-	anyPodNeedFurtherProbe, err := m.updateTargetHealthPodCondition(ctx, targetHealthCondType, matchedEndpointAndTargets, unmatchedEndpoints)
+	anyPodDeregistering, err := m.updateDeregistrationPodCondition(ctx, targetHealthCondType, deregisteringEndpoints, targets)
+	if err != nil {
+		return err
+	}
+	anyPodNeedFurtherProbe, err := m.updateTargetHealthPodCondition(ctx, tgb, targetHealthCondType, matchedEndpointAndTargets, unmatchedEndpoints)
 	if err != nil {
 		return err
 	}
@@ -127,52 +259,112 @@ func (m *defaultResourceManager) reconcileWithIPTargetType(ctx context.Context,
 		return runtime.NewRequeueNeeded("monitor targetHealth")
 	}
 
+	if anyPodDeregistering {
+		return runtime.NewRequeueNeededAfter("monitor target deregistration", m.targetHealthRequeueDuration)
+	}
+
+	// held targets can be registered once targets in other availability zones got registered, which doesn't trigger another reconcile.
+	if len(zoneImbalancedEndpoints) != 0 {
+		return runtime.NewRequeueNeededAfter("monitor zone imbalanced targets", m.targetHealthRequeueDuration)
+	}
+
 	if containsPotentialReadyEndpoints {
 		return runtime.NewRequeueNeeded("monitor potential ready endpoints")
 	}
 
 	_ = drainingTargets
+	m.endpointsFingerprinter.MarkSynced(tgbKey, endpointsFingerprint)
 	return nil
 }
 
 func (m *defaultResourceManager) reconcileWithInstanceTargetType(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
+	if m.isTargetGroupInPeeredVPC(tgb) {
+		return errors.Errorf("instance targetType is not supported for TargetGroup in peered VPC: %v", tgb.Spec.VpcID)
+	}
 	svcKey := buildServiceReferenceKey(tgb, tgb.Spec.ServiceRef)
-	nodeSelector := backend.GetTrafficProxyNodeSelector(tgb)
-	resolveOpts := []backend.EndpointResolveOption{backend.WithNodeSelector(nodeSelector)}
+	nodeSelector, err := backend.GetTrafficProxyNodeSelector(tgb)
+	if err != nil {
+		return runtime.NewTerminalError("InvalidNodeSelector", err)
+	}
+	resolveOpts := []backend.EndpointResolveOption{
+		backend.WithNodeSelector(nodeSelector),
+		backend.WithExcludedNodeTaintKeys(m.excludedNodeTaintKeys),
+		backend.WithExcludeDrainingNodes(m.excludeDrainingNodes),
+	}
 	endpoints, err := m.endpointResolver.ResolveNodePortEndpoints(ctx, svcKey, tgb.Spec.ServiceRef.Port, resolveOpts...)
 	if err != nil {
 		return err
 	}
-	tgARN := tgb.Spec.TargetGroupARN
-	targets, err := m.targetsManager.ListTargets(ctx, tgARN)
+	targets, err := m.targetsManagerForTGB(tgb).ListTargets(ctx, tgb.Spec.TargetGroupARN)
 	if err != nil {
 		return err
 	}
 	notDrainingTargets, drainingTargets := partitionTargetsByDrainingStatus(targets)
-	_, unmatchedEndpoints, unmatchedTargets := matchNodePortEndpointWithTargets(endpoints, notDrainingTargets)
+	matchedEndpointAndTargets, unmatchedEndpoints, unmatchedTargets := matchNodePortEndpointWithTargets(endpoints, notDrainingTargets)
 
 	if err := m.networkingManager.ReconcileForNodePortEndpoints(ctx, tgb, endpoints); err != nil {
 		return err
 	}
-	if err := m.deregisterTargets(ctx, tgARN, unmatchedTargets); err != nil {
+	if err := m.deregisterTargets(ctx, tgb, unmatchedTargets); err != nil {
 		return err
 	}
-	if err := m.registerNodePortEndpoints(ctx, tgARN, unmatchedEndpoints); err != nil {
+	allowedEndpointCount, err := m.reconcileTargetsOverflow(ctx, tgb, len(matchedEndpointAndTargets)+len(drainingTargets), len(unmatchedEndpoints))
+	if err != nil {
+		return err
+	}
+	// unmatchedEndpoints are ordered by their IDs, so that the same endpoints are dropped across reconciles.
+	if err := m.registerNodePortEndpoints(ctx, tgb, unmatchedEndpoints[:allowedEndpointCount]); err != nil {
 		return err
 	}
 	_ = drainingTargets
 	return nil
 }
 
+// reconcileWithLambdaTargetType validates the targetType of TargetGroup, no endpoints are registered since its Lambda function is registered outside the controller.
+func (m *defaultResourceManager) reconcileWithLambdaTargetType(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
+	req := &elbv2sdk.DescribeTargetGroupsInput{
+		TargetGroupArns: awssdk.StringSlice([]string{tgb.Spec.TargetGroupARN}),
+	}
+	tgList, err := m.elbv2ClientForTGB(tgb).DescribeTargetGroupsAsList(ctx, req)
+	if err != nil {
+		return err
+	}
+	if len(tgList) != 1 {
+		return errors.Errorf("expecting a single targetGroup but got %v", len(tgList))
+	}
+	if sdkTargetType := awssdk.StringValue(tgList[0].TargetType); sdkTargetType != elbv2sdk.TargetTypeEnumLambda {
+		return runtime.NewTerminalError("InvalidTargetType", errors.Errorf("lambda targetType doesn't match TargetGroup %v with %v targetType",
+			tgb.Spec.TargetGroupARN, sdkTargetType))
+	}
+	return nil
+}
+
+// reportTargetHealth reports target health of TargetGroupBinding once targets are reconciled, if target health polling is enabled.
+// target health is reported while reconcile is requeued to monitor targets as well, so that targets stuck unhealthy are surfaced.
+func (m *defaultResourceManager) reportTargetHealth(ctx context.Context, tgb *elbv2api.TargetGroupBinding, reconcileErr error) error {
+	if m.targetHealthPollPeriod <= 0 {
+		return reconcileErr
+	}
+	var requeueNeeded *runtime.RequeueNeeded
+	var requeueNeededAfter *runtime.RequeueNeededAfter
+	if reconcileErr != nil && !errors.As(reconcileErr, &requeueNeeded) && !errors.As(reconcileErr, &requeueNeededAfter) {
+		return reconcileErr
+	}
+	if err := m.targetHealthReporter.Report(ctx, tgb); err != nil {
+		return err
+	}
+	return reconcileErr
+}
+
 func (m *defaultResourceManager) cleanupTargets(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
-	targets, err := m.targetsManager.ListTargets(ctx, tgb.Spec.TargetGroupARN)
+	targets, err := m.targetsManagerForTGB(tgb).ListTargets(ctx, tgb.Spec.TargetGroupARN)
 	if err != nil {
 		if isELBV2TargetGroupNotFoundError(err) {
 			return nil
 		}
 		return err
 	}
-	if err := m.deregisterTargets(ctx, tgb.Spec.TargetGroupARN, targets); err != nil {
+	if err := m.deregisterTargets(ctx, tgb, targets); err != nil {
 		if isELBV2TargetGroupNotFoundError(err) {
 			return nil
 		}
@@ -183,19 +375,20 @@ func (m *defaultResourceManager) cleanupTargets(ctx context.Context, tgb *elbv2a
 
 // updateTargetHealthPodCondition will updates pod's targetHealth condition for matchedEndpointAndTargets and unmatchedEndpoints.
 // returns whether further probe is needed or not
-func (m *defaultResourceManager) updateTargetHealthPodCondition(ctx context.Context, targetHealthCondType corev1.PodConditionType,
+func (m *defaultResourceManager) updateTargetHealthPodCondition(ctx context.Context, tgb *elbv2api.TargetGroupBinding, targetHealthCondType corev1.PodConditionType,
 	matchedEndpointAndTargets []podEndpointAndTargetPair, unmatchedEndpoints []backend.PodEndpoint) (bool, error) {
-	anyPodNeedFurtherProbe := false
+	tgbKey := k8s.NamespacedName(tgb)
+	waitingPods := 0
 
 	for _, endpointAndTarget := range matchedEndpointAndTargets {
 		pod := endpointAndTarget.endpoint.Pod
 		targetHealth := endpointAndTarget.target.TargetHealth
-		needFurtherProbe, err := m.updateTargetHealthPodConditionForPod(ctx, pod, targetHealth, targetHealthCondType)
+		needFurtherProbe, err := m.updateTargetHealthPodConditionForPod(ctx, tgbKey, pod, targetHealth, targetHealthCondType)
 		if err != nil {
 			return false, err
 		}
 		if needFurtherProbe {
-			anyPodNeedFurtherProbe = true
+			waitingPods++
 		}
 	}
 
@@ -206,20 +399,23 @@ func (m *defaultResourceManager) updateTargetHealthPodCondition(ctx context.Cont
 			Reason:      awssdk.String(elbv2sdk.TargetHealthReasonEnumElbRegistrationInProgress),
 			Description: awssdk.String("Target registration is in progress"),
 		}
-		needFurtherProbe, err := m.updateTargetHealthPodConditionForPod(ctx, pod, targetHealth, targetHealthCondType)
+		needFurtherProbe, err := m.updateTargetHealthPodConditionForPod(ctx, tgbKey, pod, targetHealth, targetHealthCondType)
 		if err != nil {
 			return false, err
 		}
 		if needFurtherProbe {
-			anyPodNeedFurtherProbe = true
+			waitingPods++
 		}
 	}
-	return anyPodNeedFurtherProbe, nil
+	m.metricsCollector.ObserveReadinessGateWaitingPods(tgbKey, waitingPods)
+	return waitingPods > 0, nil
 }
 
 // updateTargetHealthPodConditionForPod updates pod's targetHealth condition for a single pod and its matched target.
 // returns whether further probe is needed or not.
-func (m *defaultResourceManager) updateTargetHealthPodConditionForPod(ctx context.Context, pod k8s.PodInfo,
+// once the pod waited on target health longer than readinessGateMaxWait, its targetHealth condition is kept false
+// with ReadinessGateTimeout reason and further probes are stopped, so that stuck pods are surfaced without reporting them as ready.
+func (m *defaultResourceManager) updateTargetHealthPodConditionForPod(ctx context.Context, tgbKey types.NamespacedName, pod k8s.PodInfo,
 	targetHealth *elbv2sdk.TargetHealth, targetHealthCondType corev1.PodConditionType) (bool, error) {
 	if !pod.HasAnyOfReadinessGates([]corev1.PodConditionType{targetHealthCondType}) {
 		return false, nil
@@ -240,25 +436,84 @@ func (m *defaultResourceManager) updateTargetHealthPodConditionForPod(ctx contex
 	needFurtherProbe := targetHealthCondStatus != corev1.ConditionTrue
 
 	existingTargetHealthCond, exists := pod.GetPodCondition(targetHealthCondType)
+	readinessGateTimedOut := false
+	if needFurtherProbe && m.readinessGateMaxWait > 0 && exists {
+		if existingTargetHealthCond.Status == corev1.ConditionFalse && existingTargetHealthCond.Reason == podConditionReasonReadinessGateTimeout {
+			// the readiness gate stays timed out until target becomes healthy.
+			return false, nil
+		}
+		if existingTargetHealthCond.Status != corev1.ConditionTrue && time.Since(existingTargetHealthCond.LastTransitionTime.Time) >= m.readinessGateMaxWait {
+			targetHealthCondStatus = corev1.ConditionFalse
+			message = fmt.Sprintf("Target didn't become healthy within %v, reason: %v, description: %v",
+				m.readinessGateMaxWait, reason, message)
+			reason = podConditionReasonReadinessGateTimeout
+			needFurtherProbe = false
+			readinessGateTimedOut = true
+		}
+	}
+	patched, err := m.updatePodCondition(ctx, pod, targetHealthCondType, targetHealthCondStatus, reason, message)
+	if err != nil {
+		return false, err
+	}
+	if patched && readinessGateTimedOut {
+		m.logger.Info("targetHealth readiness gate timed out", "pod", pod.Key, "targetGroupBinding", tgbKey)
+		m.metricsCollector.ObserveReadinessGateTimeout(tgbKey)
+	}
+
+	return needFurtherProbe, nil
+}
+
+// updateDeregistrationPodCondition updates pod's targetHealth condition for deregisteringEndpoints with their deregistration progress.
+// returns whether any pod's targets are still draining.
+func (m *defaultResourceManager) updateDeregistrationPodCondition(ctx context.Context, targetHealthCondType corev1.PodConditionType,
+	deregisteringEndpoints []backend.PodEndpoint, targets []TargetInfo) (bool, error) {
+	targetUIDs := sets.NewString()
+	for _, target := range targets {
+		targetUIDs.Insert(UniqueIDForTargetDescription(target.Target))
+	}
+
+	anyPodDeregistering := false
+	for _, endpoint := range deregisteringEndpoints {
+		reason := PodConditionReasonDeregistered
+		message := "Target finished draining"
+		if targetUIDs.Has(fmt.Sprintf("%v:%v", endpoint.IP, endpoint.Port)) {
+			reason = PodConditionReasonDeregistrationInProgress
+			message = "Target deregistration is in progress"
+			anyPodDeregistering = true
+		}
+		if _, err := m.updatePodCondition(ctx, endpoint.Pod, targetHealthCondType, corev1.ConditionFalse, reason, message); err != nil {
+			return false, err
+		}
+	}
+	return anyPodDeregistering, nil
+}
+
+// updatePodCondition updates pod's condition with specific status/reason/message.
+// returns whether the pod is patched.
+func (m *defaultResourceManager) updatePodCondition(ctx context.Context, pod k8s.PodInfo, condType corev1.PodConditionType,
+	status corev1.ConditionStatus, reason string, message string) (bool, error) {
+	existingCond, exists := pod.GetPodCondition(condType)
 	// we skip patch pod if it matches current computed status/reason/message.
 	if exists &&
-		existingTargetHealthCond.Status == targetHealthCondStatus &&
-		existingTargetHealthCond.Reason == reason &&
-		existingTargetHealthCond.Message == message {
-		return needFurtherProbe, nil
+		existingCond.Status == status &&
+		existingCond.Reason == reason &&
+		existingCond.Message == message {
+		return false, nil
 	}
 
-	newTargetHealthCond := corev1.PodCondition{
-		Type:    targetHealthCondType,
-		Status:  targetHealthCondStatus,
+	newCond := corev1.PodCondition{
+		Type:    condType,
+		Status:  status,
 		Reason:  reason,
 		Message: message,
 	}
-	if !exists || existingTargetHealthCond.Status != targetHealthCondStatus {
-		newTargetHealthCond.LastTransitionTime = metav1.Now()
+	if !exists || existingCond.Status != status {
+		newCond.LastTransitionTime = metav1.Now()
+	} else {
+		newCond.LastTransitionTime = existingCond.LastTransitionTime
 	}
 
-	patch, err := buildPodConditionPatch(pod, newTargetHealthCond)
+	patch, err := buildPodConditionPatch(pod, newCond)
 	if err != nil {
 		return false, err
 	}
@@ -275,30 +530,39 @@ func (m *defaultResourceManager) updateTargetHealthPodConditionForPod(ctx contex
 		}
 		return false, err
 	}
-
-	return needFurtherProbe, nil
+	return true, nil
 }
 
-func (m *defaultResourceManager) deregisterTargets(ctx context.Context, tgARN string, targets []TargetInfo) error {
+func (m *defaultResourceManager) deregisterTargets(ctx context.Context, tgb *elbv2api.TargetGroupBinding, targets []TargetInfo) error {
 	sdkTargets := make([]elbv2sdk.TargetDescription, 0, len(targets))
 	for _, target := range targets {
 		sdkTargets = append(sdkTargets, target.Target)
 	}
-	return m.targetsManager.DeregisterTargets(ctx, tgARN, sdkTargets)
+	return runtime.TimeStage(ctx, "DeregisterTargets", func(ctx context.Context) error {
+		return m.targetsManagerForTGB(tgb).DeregisterTargets(ctx, tgb.Spec.TargetGroupARN, sdkTargets)
+	})
 }
 
-func (m *defaultResourceManager) registerPodEndpoints(ctx context.Context, tgARN string, endpoints []backend.PodEndpoint) error {
+func (m *defaultResourceManager) registerPodEndpoints(ctx context.Context, tgb *elbv2api.TargetGroupBinding, endpoints []backend.PodEndpoint) error {
+	// IP targets outside the TargetGroup's VPC must be registered with availabilityZone set to "all".
+	var availabilityZone *string
+	if m.isTargetGroupInPeeredVPC(tgb) {
+		availabilityZone = awssdk.String(targetAvailabilityZoneAll)
+	}
 	sdkTargets := make([]elbv2sdk.TargetDescription, 0, len(endpoints))
 	for _, endpoint := range endpoints {
 		sdkTargets = append(sdkTargets, elbv2sdk.TargetDescription{
-			Id:   awssdk.String(endpoint.IP),
-			Port: awssdk.Int64(endpoint.Port),
+			Id:               awssdk.String(endpoint.IP),
+			Port:             awssdk.Int64(endpoint.Port),
+			AvailabilityZone: availabilityZone,
 		})
 	}
-	return m.targetsManager.RegisterTargets(ctx, tgARN, sdkTargets)
+	return runtime.TimeStage(ctx, "RegisterTargets", func(ctx context.Context) error {
+		return m.targetsManagerForTGB(tgb).RegisterTargets(ctx, tgb.Spec.TargetGroupARN, sdkTargets)
+	})
 }
 
-func (m *defaultResourceManager) registerNodePortEndpoints(ctx context.Context, tgARN string, endpoints []backend.NodePortEndpoint) error {
+func (m *defaultResourceManager) registerNodePortEndpoints(ctx context.Context, tgb *elbv2api.TargetGroupBinding, endpoints []backend.NodePortEndpoint) error {
 	sdkTargets := make([]elbv2sdk.TargetDescription, 0, len(endpoints))
 	for _, endpoint := range endpoints {
 		sdkTargets = append(sdkTargets, elbv2sdk.TargetDescription{
@@ -306,7 +570,229 @@ func (m *defaultResourceManager) registerNodePortEndpoints(ctx context.Context,
 			Port: awssdk.Int64(endpoint.Port),
 		})
 	}
-	return m.targetsManager.RegisterTargets(ctx, tgARN, sdkTargets)
+	return runtime.TimeStage(ctx, "RegisterTargets", func(ctx context.Context) error {
+		return m.targetsManagerForTGB(tgb).RegisterTargets(ctx, tgb.Spec.TargetGroupARN, sdkTargets)
+	})
+}
+
+// updateUnregisteredPodCondition updates pod's targetHealth condition for endpoints whose targets are not registered with reason/message.
+func (m *defaultResourceManager) updateUnregisteredPodCondition(ctx context.Context, targetHealthCondType corev1.PodConditionType,
+	endpoints []backend.PodEndpoint, reason string, message string) error {
+	for _, endpoint := range endpoints {
+		if !endpoint.Pod.HasAnyOfReadinessGates([]corev1.PodConditionType{targetHealthCondType}) {
+			continue
+		}
+		if _, err := m.updatePodCondition(ctx, endpoint.Pod, targetHealthCondType, corev1.ConditionFalse, reason, message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileTargetsOverflow runs the pre-flight check of max targets for TargetGroup before registering new targets.
+// targets already registered are always kept, it returns the number of new targets that can be registered per targetOverflowStrategy.
+// the TargetsOverflowed condition and dropped targets metric of TargetGroupBinding are updated accordingly.
+func (m *defaultResourceManager) reconcileTargetsOverflow(ctx context.Context, tgb *elbv2api.TargetGroupBinding, registeredTargetCount int, newTargetCount int) (int, error) {
+	tgbKey := k8s.NamespacedName(tgb)
+	desiredTargetCount := int64(registeredTargetCount + newTargetCount)
+	maxTargets := m.computeMaxTargets(ctx, tgb, desiredTargetCount)
+	if newTargetCount == 0 || desiredTargetCount <= maxTargets {
+		m.metricsCollector.ObserveDroppedTargets(tgbKey, 0)
+		return newTargetCount, m.updateTargetsOverflowedCondition(ctx, tgb, false, "")
+	}
+
+	var overflowErr error
+	allowedTargetCount := 0
+	if tgb.Spec.TargetOverflowStrategy != nil && *tgb.Spec.TargetOverflowStrategy == elbv2api.TargetOverflowStrategyTruncate {
+		if maxTargets > int64(registeredTargetCount) {
+			allowedTargetCount = int(maxTargets) - registeredTargetCount
+		}
+	} else if tgb.Spec.MaxTargets != nil && desiredTargetCount > *tgb.Spec.MaxTargets {
+		overflowErr = runtime.NewTerminalError("MaxTargetsExceeded", runtime.NewClassifiedError(runtime.ErrorClassQuotaExceeded,
+			errors.Errorf("max targets exceeded for TargetGroup %v: %v desired, limit %v", tgb.Spec.TargetGroupARN, desiredTargetCount, *tgb.Spec.MaxTargets)))
+	} else {
+		overflowErr = quota.CheckQuota(ctx, m.quotaProviderForTGB(tgb), quota.QuotaTargetsPerTargetGroup,
+			desiredTargetCount, fmt.Sprintf("TargetGroup %v", tgb.Spec.TargetGroupARN))
+	}
+
+	droppedTargetCount := newTargetCount - allowedTargetCount
+	m.logger.Info("targets overflowed", "targetGroupBinding", tgbKey,
+		"desiredTargets", desiredTargetCount, "maxTargets", maxTargets, "droppedTargets", droppedTargetCount)
+	m.metricsCollector.ObserveDroppedTargets(tgbKey, droppedTargetCount)
+	message := fmt.Sprintf("%v of %v targets are not registered since TargetGroup reached the max targets %v",
+		droppedTargetCount, desiredTargetCount, maxTargets)
+	if err := m.updateTargetsOverflowedCondition(ctx, tgb, true, message); err != nil {
+		return 0, err
+	}
+	return allowedTargetCount, overflowErr
+}
+
+// computeMaxTargets computes the max targets for TargetGroup of TargetGroupBinding, which is the lower of spec.maxTargets and targets quota.
+// the quota value is only retrieved when desiredTargetCount exceeds its default value, since quotas can only be increased.
+func (m *defaultResourceManager) computeMaxTargets(ctx context.Context, tgb *elbv2api.TargetGroupBinding, desiredTargetCount int64) int64 {
+	maxTargets := quota.QuotaTargetsPerTargetGroup.DefaultValue
+	if desiredTargetCount > maxTargets {
+		maxTargets = m.quotaProviderForTGB(tgb).GetQuotaValue(ctx, quota.QuotaTargetsPerTargetGroup)
+	}
+	if tgb.Spec.MaxTargets != nil && *tgb.Spec.MaxTargets < maxTargets {
+		maxTargets = *tgb.Spec.MaxTargets
+	}
+	return maxTargets
+}
+
+// updateTargetsOverflowedCondition updates the TargetsOverflowed condition of TargetGroupBinding.
+// the condition is only added once targets overflowed, and is kept as false afterwards.
+func (m *defaultResourceManager) updateTargetsOverflowedCondition(ctx context.Context, tgb *elbv2api.TargetGroupBinding, overflowed bool, message string) error {
+	if _, exists := findTargetGroupBindingCondition(tgb, elbv2api.TargetGroupBindingConditionTargetsOverflowed); !exists && !overflowed {
+		return nil
+	}
+
+	newCond := elbv2api.TargetGroupBindingCondition{
+		Type:    elbv2api.TargetGroupBindingConditionTargetsOverflowed,
+		Status:  corev1.ConditionFalse,
+		Reason:  tgbConditionReasonTargetsRegistered,
+		Message: message,
+	}
+	if overflowed {
+		newCond.Status = corev1.ConditionTrue
+		newCond.Reason = tgbConditionReasonMaxTargetsReached
+	}
+	tgbOld := tgb.DeepCopy()
+	if !setTargetGroupBindingCondition(tgb, newCond) {
+		return nil
+	}
+	if err := m.k8sClient.Status().Patch(ctx, tgb, client.MergeFrom(tgbOld)); err != nil {
+		return errors.Wrapf(err, "failed to update targetGroupBinding status: %v", k8s.NamespacedName(tgb))
+	}
+	return nil
+}
+
+// isTargetGroupInPeeredVPC checks whether the TargetGroup of TargetGroupBinding lives in a VPC other than the controller's VPC.
+// TargetGroups in other regions always live in other VPCs.
+func (m *defaultResourceManager) isTargetGroupInPeeredVPC(tgb *elbv2api.TargetGroupBinding) bool {
+	if tgb.Spec.VpcID != "" && tgb.Spec.VpcID != m.vpcID {
+		return true
+	}
+	return targetGroupRegionOverride(m.cloud, tgb) != ""
+}
+
+// reconcileTargetGroupAttributes configures the targetGroupAttributes of TargetGroupBinding on its TargetGroup.
+// attributes that aren't specified are kept as is, since the TargetGroup itself is managed outside of the controller.
+func (m *defaultResourceManager) reconcileTargetGroupAttributes(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
+	if len(tgb.Spec.TargetGroupAttributes) == 0 {
+		return nil
+	}
+	desiredAttrs := make(map[string]string, len(tgb.Spec.TargetGroupAttributes))
+	for _, attr := range tgb.Spec.TargetGroupAttributes {
+		desiredAttrs[attr.Key] = attr.Value
+	}
+	if err := elbv2model.ValidateTargetGroupAttributes(desiredAttrs); err != nil {
+		return runtime.NewTerminalError("InvalidTargetGroupAttributes", err)
+	}
+	elbv2Client := m.elbv2ClientForTGB(tgb)
+	tgARN := tgb.Spec.TargetGroupARN
+	currentAttrs, err := m.fetchTargetGroupAttributes(ctx, elbv2Client, tgARN)
+	if err != nil {
+		return err
+	}
+	attributesToUpdate, err := elbv2model.BuildTargetGroupAttributesToUpdate(desiredAttrs, currentAttrs)
+	if err != nil {
+		return runtime.NewTerminalError("InvalidTargetGroupAttributes", err)
+	}
+	if len(attributesToUpdate) == 0 {
+		return nil
+	}
+	req := &elbv2sdk.ModifyTargetGroupAttributesInput{
+		TargetGroupArn: awssdk.String(tgARN),
+	}
+	for _, attrKey := range sets.StringKeySet(attributesToUpdate).List() {
+		req.Attributes = append(req.Attributes, &elbv2sdk.TargetGroupAttribute{
+			Key:   awssdk.String(attrKey),
+			Value: awssdk.String(attributesToUpdate[attrKey]),
+		})
+	}
+	m.logger.Info("modifying targetGroup attributes",
+		"targetGroupBinding", k8s.NamespacedName(tgb),
+		"arn", tgARN,
+		"change", attributesToUpdate)
+	if _, err := elbv2Client.ModifyTargetGroupAttributesWithContext(ctx, req); err != nil {
+		m.targetGroupAttributesCache.Delete(tgARN)
+		return err
+	}
+	m.logger.Info("modified targetGroup attributes",
+		"targetGroupBinding", k8s.NamespacedName(tgb),
+		"arn", tgARN)
+	updatedAttrs := make(map[string]string, len(currentAttrs)+len(attributesToUpdate))
+	for attrKey, attrValue := range currentAttrs {
+		updatedAttrs[attrKey] = attrValue
+	}
+	for attrKey, attrValue := range attributesToUpdate {
+		updatedAttrs[attrKey] = attrValue
+	}
+	m.targetGroupAttributesCache.Set(tgARN, updatedAttrs, m.targetGroupAttributesCacheTTL)
+	return nil
+}
+
+// fetchTargetGroupAttributes returns the current attributes of TargetGroup, which are cached per targetGroupAttributesCacheTTL.
+func (m *defaultResourceManager) fetchTargetGroupAttributes(ctx context.Context, elbv2Client services.ELBV2, tgARN string) (map[string]string, error) {
+	if rawCacheItem, exists := m.targetGroupAttributesCache.Get(tgARN); exists {
+		return rawCacheItem.(map[string]string), nil
+	}
+	resp, err := elbv2Client.DescribeTargetGroupAttributesWithContext(ctx, &elbv2sdk.DescribeTargetGroupAttributesInput{
+		TargetGroupArn: awssdk.String(tgARN),
+	})
+	if err != nil {
+		return nil, err
+	}
+	currentAttrs := make(map[string]string, len(resp.Attributes))
+	for _, attr := range resp.Attributes {
+		currentAttrs[awssdk.StringValue(attr.Key)] = awssdk.StringValue(attr.Value)
+	}
+	m.targetGroupAttributesCache.Set(tgARN, currentAttrs, m.targetGroupAttributesCacheTTL)
+	return currentAttrs, nil
+}
+
+// elbv2ClientForTGB returns the ELBV2 client for the AWS account and region of TargetGroupBinding's TargetGroup.
+func (m *defaultResourceManager) elbv2ClientForTGB(tgb *elbv2api.TargetGroupBinding) services.ELBV2 {
+	if buildCustomCloudKeyForTGB(m.cloud, tgb) == "" {
+		return m.elbv2Client
+	}
+	return buildCustomCloudForTGB(m.cloud, tgb).ELBV2()
+}
+
+// targetsManagerForTGB returns the TargetsManager that manages targets for TargetGroupBinding.
+// TargetGroupBindings with iamRoleARNToAssume are managed with credentials of that IAM role, and TargetGroups in other regions with clients of that region.
+func (m *defaultResourceManager) targetsManagerForTGB(tgb *elbv2api.TargetGroupBinding) TargetsManager {
+	cloudKey := buildCustomCloudKeyForTGB(m.cloud, tgb)
+	if cloudKey == "" {
+		return m.targetsManager
+	}
+
+	m.assumedRoleTargetsManagersMutex.Lock()
+	defer m.assumedRoleTargetsManagersMutex.Unlock()
+	if targetsManager, ok := m.assumedRoleTargetsManagers[cloudKey]; ok {
+		return targetsManager
+	}
+	targetsManager := NewCachedTargetsManager(buildCustomCloudForTGB(m.cloud, tgb).ELBV2(), m.logger)
+	m.assumedRoleTargetsManagers[cloudKey] = targetsManager
+	return targetsManager
+}
+
+// quotaProviderForTGB returns the quota Provider for the AWS account and region of TargetGroupBinding's TargetGroup.
+func (m *defaultResourceManager) quotaProviderForTGB(tgb *elbv2api.TargetGroupBinding) quota.Provider {
+	cloudKey := buildCustomCloudKeyForTGB(m.cloud, tgb)
+	if cloudKey == "" {
+		return m.quotaProvider
+	}
+
+	m.assumedRoleQuotaProvidersMutex.Lock()
+	defer m.assumedRoleQuotaProvidersMutex.Unlock()
+	if quotaProvider, ok := m.assumedRoleQuotaProviders[cloudKey]; ok {
+		return quotaProvider
+	}
+	quotaProvider := quota.NewDefaultProvider(buildCustomCloudForTGB(m.cloud, tgb).ServiceQuotas(), m.logger)
+	m.assumedRoleQuotaProviders[cloudKey] = quotaProvider
+	return quotaProvider
 }
 
 type podEndpointAndTargetPair struct {
@@ -409,7 +895,7 @@ func matchNodePortEndpointWithTargets(endpoints []backend.NodePortEndpoint, targ
 	return matchedEndpointAndTargets, unmatchedEndpoints, unmatchedTargets
 }
 
-func buildPodConditionPatch(pod k8s.PodInfo, condition corev1.PodCondition) (client.Patch, error) {
+func buildPodConditionPatch(pod k8s.PodInfo, conditions ...corev1.PodCondition) (client.Patch, error) {
 	oldData, err := json.Marshal(corev1.Pod{
 		Status: corev1.PodStatus{
 			Conditions: nil,
@@ -421,7 +907,7 @@ func buildPodConditionPatch(pod k8s.PodInfo, condition corev1.PodCondition) (cli
 	newData, err := json.Marshal(corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{UID: pod.UID}, // only put the uid in the new object to ensure it appears in the patch as a precondition
 		Status: corev1.PodStatus{
-			Conditions: []corev1.PodCondition{condition},
+			Conditions: conditions,
 		},
 	})
 	if err != nil {
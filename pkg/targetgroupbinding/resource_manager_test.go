@@ -4,19 +4,28 @@ import (
 	"context"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/cache"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	mock_services "sigs.k8s.io/aws-load-balancer-controller/mocks/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/backend"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/quota"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/equality"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	tgbmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/targetgroupbinding/metrics"
 	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
+	"time"
 )
 
 func Test_defaultResourceManager_updateTargetHealthPodConditionForPod(t *testing.T) {
@@ -31,12 +40,13 @@ func Test_defaultResourceManager_updateTargetHealthPodConditionForPod(t *testing
 	}
 
 	tests := []struct {
-		name    string
-		env     env
-		args    args
-		want    bool
-		wantPod *corev1.Pod
-		wantErr error
+		name                 string
+		env                  env
+		readinessGateMaxWait time.Duration
+		args                 args
+		want                 bool
+		wantPod              *corev1.Pod
+		wantErr              error
 	}{
 		{
 			name: "pod contains readinessGate and targetHealth is healthy - add pod condition",
@@ -368,6 +378,252 @@ func Test_defaultResourceManager_updateTargetHealthPodConditionForPod(t *testing
 				},
 			},
 		},
+		{
+			name: "pod waited on target health longer than max wait - time out readinessGate",
+			env: env{
+				pods: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "default",
+							Name:      "my-pod",
+							UID:       "my-pod-uuid",
+						},
+						Spec: corev1.PodSpec{
+							ReadinessGates: []corev1.PodReadinessGate{
+								{
+									ConditionType: "target-health.elbv2.k8s.aws/my-tgb",
+								},
+							},
+						},
+						Status: corev1.PodStatus{
+							Conditions: []corev1.PodCondition{
+								{
+									Type:               "target-health.elbv2.k8s.aws/my-tgb",
+									Status:             corev1.ConditionFalse,
+									Reason:             "Target.FailedHealthChecks",
+									LastTransitionTime: metav1.NewTime(time.Now().Add(-10 * time.Minute)),
+								},
+							},
+						},
+					},
+				},
+			},
+			readinessGateMaxWait: 5 * time.Minute,
+			args: args{
+				pod: k8s.PodInfo{
+					Key: types.NamespacedName{Namespace: "default", Name: "my-pod"},
+					UID: "my-pod-uuid",
+					ReadinessGates: []corev1.PodReadinessGate{
+						{
+							ConditionType: "target-health.elbv2.k8s.aws/my-tgb",
+						},
+					},
+					Conditions: []corev1.PodCondition{
+						{
+							Type:               "target-health.elbv2.k8s.aws/my-tgb",
+							Status:             corev1.ConditionFalse,
+							Reason:             "Target.FailedHealthChecks",
+							LastTransitionTime: metav1.NewTime(time.Now().Add(-10 * time.Minute)),
+						},
+					},
+				},
+				targetHealth: &elbv2sdk.TargetHealth{
+					State:       awssdk.String(elbv2sdk.TargetHealthStateEnumUnhealthy),
+					Reason:      awssdk.String(elbv2sdk.TargetHealthReasonEnumTargetFailedHealthChecks),
+					Description: awssdk.String("Health checks failed"),
+				},
+				targetHealthCondType: "target-health.elbv2.k8s.aws/my-tgb",
+			},
+			want: false,
+			wantPod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "my-pod",
+					UID:       "my-pod-uuid",
+				},
+				Spec: corev1.PodSpec{
+					ReadinessGates: []corev1.PodReadinessGate{
+						{
+							ConditionType: "target-health.elbv2.k8s.aws/my-tgb",
+						},
+					},
+				},
+				Status: corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{
+							Type:    "target-health.elbv2.k8s.aws/my-tgb",
+							Status:  corev1.ConditionFalse,
+							Reason:  "ReadinessGateTimeout",
+							Message: "Target didn't become healthy within 5m0s, reason: Target.FailedHealthChecks, description: Health checks failed",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "pod waited on target health within max wait - update pod condition",
+			env: env{
+				pods: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "default",
+							Name:      "my-pod",
+							UID:       "my-pod-uuid",
+						},
+						Spec: corev1.PodSpec{
+							ReadinessGates: []corev1.PodReadinessGate{
+								{
+									ConditionType: "target-health.elbv2.k8s.aws/my-tgb",
+								},
+							},
+						},
+						Status: corev1.PodStatus{
+							Conditions: []corev1.PodCondition{
+								{
+									Type:               "target-health.elbv2.k8s.aws/my-tgb",
+									Status:             corev1.ConditionFalse,
+									Reason:             "Elb.RegistrationInProgress",
+									LastTransitionTime: metav1.NewTime(time.Now().Add(-1 * time.Minute)),
+								},
+							},
+						},
+					},
+				},
+			},
+			readinessGateMaxWait: 5 * time.Minute,
+			args: args{
+				pod: k8s.PodInfo{
+					Key: types.NamespacedName{Namespace: "default", Name: "my-pod"},
+					UID: "my-pod-uuid",
+					ReadinessGates: []corev1.PodReadinessGate{
+						{
+							ConditionType: "target-health.elbv2.k8s.aws/my-tgb",
+						},
+					},
+					Conditions: []corev1.PodCondition{
+						{
+							Type:               "target-health.elbv2.k8s.aws/my-tgb",
+							Status:             corev1.ConditionFalse,
+							Reason:             "Elb.RegistrationInProgress",
+							LastTransitionTime: metav1.NewTime(time.Now().Add(-1 * time.Minute)),
+						},
+					},
+				},
+				targetHealth: &elbv2sdk.TargetHealth{
+					State:       awssdk.String(elbv2sdk.TargetHealthStateEnumUnhealthy),
+					Reason:      awssdk.String(elbv2sdk.TargetHealthReasonEnumTargetFailedHealthChecks),
+					Description: awssdk.String("Health checks failed"),
+				},
+				targetHealthCondType: "target-health.elbv2.k8s.aws/my-tgb",
+			},
+			want: true,
+			wantPod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "my-pod",
+					UID:       "my-pod-uuid",
+				},
+				Spec: corev1.PodSpec{
+					ReadinessGates: []corev1.PodReadinessGate{
+						{
+							ConditionType: "target-health.elbv2.k8s.aws/my-tgb",
+						},
+					},
+				},
+				Status: corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{
+							Type:    "target-health.elbv2.k8s.aws/my-tgb",
+							Status:  corev1.ConditionFalse,
+							Reason:  "Target.FailedHealthChecks",
+							Message: "Health checks failed",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "pod readinessGate already timed out - keep pod condition",
+			env: env{
+				pods: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "default",
+							Name:      "my-pod",
+							UID:       "my-pod-uuid",
+						},
+						Spec: corev1.PodSpec{
+							ReadinessGates: []corev1.PodReadinessGate{
+								{
+									ConditionType: "target-health.elbv2.k8s.aws/my-tgb",
+								},
+							},
+						},
+						Status: corev1.PodStatus{
+							Conditions: []corev1.PodCondition{
+								{
+									Type:               "target-health.elbv2.k8s.aws/my-tgb",
+									Status:             corev1.ConditionFalse,
+									Reason:             "ReadinessGateTimeout",
+									LastTransitionTime: metav1.NewTime(time.Now().Add(-10 * time.Minute)),
+								},
+							},
+						},
+					},
+				},
+			},
+			readinessGateMaxWait: 5 * time.Minute,
+			args: args{
+				pod: k8s.PodInfo{
+					Key: types.NamespacedName{Namespace: "default", Name: "my-pod"},
+					UID: "my-pod-uuid",
+					ReadinessGates: []corev1.PodReadinessGate{
+						{
+							ConditionType: "target-health.elbv2.k8s.aws/my-tgb",
+						},
+					},
+					Conditions: []corev1.PodCondition{
+						{
+							Type:               "target-health.elbv2.k8s.aws/my-tgb",
+							Status:             corev1.ConditionFalse,
+							Reason:             "ReadinessGateTimeout",
+							LastTransitionTime: metav1.NewTime(time.Now().Add(-10 * time.Minute)),
+						},
+					},
+				},
+				targetHealth: &elbv2sdk.TargetHealth{
+					State:       awssdk.String(elbv2sdk.TargetHealthStateEnumUnhealthy),
+					Reason:      awssdk.String(elbv2sdk.TargetHealthReasonEnumTargetFailedHealthChecks),
+					Description: awssdk.String("Health checks failed"),
+				},
+				targetHealthCondType: "target-health.elbv2.k8s.aws/my-tgb",
+			},
+			want: false,
+			wantPod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "my-pod",
+					UID:       "my-pod-uuid",
+				},
+				Spec: corev1.PodSpec{
+					ReadinessGates: []corev1.PodReadinessGate{
+						{
+							ConditionType: "target-health.elbv2.k8s.aws/my-tgb",
+						},
+					},
+				},
+				Status: corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{
+							Type:    "target-health.elbv2.k8s.aws/my-tgb",
+							Status:  corev1.ConditionFalse,
+							Reason:  "ReadinessGateTimeout",
+							Message: "",
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -376,8 +632,10 @@ func Test_defaultResourceManager_updateTargetHealthPodConditionForPod(t *testing
 			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
 
 			m := &defaultResourceManager{
-				k8sClient: k8sClient,
-				logger:    &log.NullLogger{},
+				k8sClient:            k8sClient,
+				metricsCollector:     tgbmetrics.NewNoopCollector(),
+				logger:               &log.NullLogger{},
+				readinessGateMaxWait: tt.readinessGateMaxWait,
 			}
 
 			ctx := context.Background()
@@ -386,7 +644,7 @@ func Test_defaultResourceManager_updateTargetHealthPodConditionForPod(t *testing
 				assert.NoError(t, err)
 			}
 
-			got, err := m.updateTargetHealthPodConditionForPod(context.Background(),
+			got, err := m.updateTargetHealthPodConditionForPod(context.Background(), types.NamespacedName{Namespace: "default", Name: "my-tgb"},
 				tt.args.pod, tt.args.targetHealth, tt.args.targetHealthCondType)
 			if tt.wantErr != nil {
 				assert.EqualError(t, err, tt.wantErr.Error())
@@ -408,6 +666,234 @@ func Test_defaultResourceManager_updateTargetHealthPodConditionForPod(t *testing
 	}
 }
 
+func Test_defaultResourceManager_isTargetGroupInPeeredVPC(t *testing.T) {
+	tests := []struct {
+		name string
+		tgb  *elbv2api.TargetGroupBinding
+		want bool
+	}{
+		{
+			name: "vpcID is not set",
+			tgb: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+				},
+			},
+			want: false,
+		},
+		{
+			name: "vpcID is same as controller's VPC",
+			tgb: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+					VpcID:          "vpc-0123abcd",
+				},
+			},
+			want: false,
+		},
+		{
+			name: "vpcID is different from controller's VPC",
+			tgb: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+					VpcID:          "vpc-4567abcd",
+				},
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &defaultResourceManager{
+				vpcID:  "vpc-0123abcd",
+				logger: &log.NullLogger{},
+			}
+			got := m.isTargetGroupInPeeredVPC(tt.tgb)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_defaultResourceManager_reconcileWithLambdaTargetType(t *testing.T) {
+	type describeTargetGroupsAsListCall struct {
+		resp []*elbv2sdk.TargetGroup
+		err  error
+	}
+	tests := []struct {
+		name                           string
+		describeTargetGroupsAsListCall describeTargetGroupsAsListCall
+		wantErr                        error
+	}{
+		{
+			name: "TargetGroup with lambda targetType",
+			describeTargetGroupsAsListCall: describeTargetGroupsAsListCall{
+				resp: []*elbv2sdk.TargetGroup{
+					{
+						TargetGroupArn: awssdk.String("tg-1"),
+						TargetType:     awssdk.String(elbv2sdk.TargetTypeEnumLambda),
+					},
+				},
+			},
+		},
+		{
+			name: "TargetGroup with ip targetType",
+			describeTargetGroupsAsListCall: describeTargetGroupsAsListCall{
+				resp: []*elbv2sdk.TargetGroup{
+					{
+						TargetGroupArn: awssdk.String("tg-1"),
+						TargetType:     awssdk.String(elbv2sdk.TargetTypeEnumIp),
+					},
+				},
+			},
+			wantErr: errors.New("InvalidTargetType: lambda targetType doesn't match TargetGroup tg-1 with ip targetType"),
+		},
+		{
+			name: "failed to describe TargetGroup",
+			describeTargetGroupsAsListCall: describeTargetGroupsAsListCall{
+				err: errors.New("some error"),
+			},
+			wantErr: errors.New("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			elbv2Client := mock_services.NewMockELBV2(ctrl)
+			elbv2Client.EXPECT().DescribeTargetGroupsAsList(gomock.Any(), &elbv2sdk.DescribeTargetGroupsInput{
+				TargetGroupArns: awssdk.StringSlice([]string{"tg-1"}),
+			}).Return(tt.describeTargetGroupsAsListCall.resp, tt.describeTargetGroupsAsListCall.err)
+
+			lambdaTargetType := elbv2api.TargetTypeLambda
+			tgb := &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+					TargetType:     &lambdaTargetType,
+				},
+			}
+			m := &defaultResourceManager{
+				elbv2Client: elbv2Client,
+				logger:      &log.NullLogger{},
+			}
+			err := m.reconcileWithLambdaTargetType(context.Background(), tgb)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_defaultResourceManager_reconcileTargetGroupAttributes(t *testing.T) {
+	type modifyTargetGroupAttributesWithContextCall struct {
+		req *elbv2sdk.ModifyTargetGroupAttributesInput
+	}
+	tests := []struct {
+		name                                        string
+		targetGroupAttributes                       []elbv2api.TargetGroupAttribute
+		currentAttributes                           []*elbv2sdk.TargetGroupAttribute
+		modifyTargetGroupAttributesWithContextCalls []modifyTargetGroupAttributesWithContextCall
+		wantErr                                     error
+	}{
+		{
+			name: "no targetGroupAttributes",
+		},
+		{
+			name: "switch from lb_cookie to app_cookie stickiness",
+			targetGroupAttributes: []elbv2api.TargetGroupAttribute{
+				{Key: "stickiness.enabled", Value: "true"},
+				{Key: "stickiness.type", Value: "app_cookie"},
+				{Key: "stickiness.app_cookie.cookie_name", Value: "SESSIONID"},
+			},
+			currentAttributes: []*elbv2sdk.TargetGroupAttribute{
+				{Key: awssdk.String("stickiness.enabled"), Value: awssdk.String("true")},
+				{Key: awssdk.String("stickiness.type"), Value: awssdk.String("lb_cookie")},
+				{Key: awssdk.String("stickiness.app_cookie.cookie_name"), Value: awssdk.String("SESSIONID")},
+				{Key: awssdk.String("slow_start.duration_seconds"), Value: awssdk.String("30")},
+			},
+			modifyTargetGroupAttributesWithContextCalls: []modifyTargetGroupAttributesWithContextCall{
+				{
+					req: &elbv2sdk.ModifyTargetGroupAttributesInput{
+						TargetGroupArn: awssdk.String("tg-1"),
+						Attributes: []*elbv2sdk.TargetGroupAttribute{
+							{Key: awssdk.String("stickiness.app_cookie.cookie_name"), Value: awssdk.String("SESSIONID")},
+							{Key: awssdk.String("stickiness.enabled"), Value: awssdk.String("true")},
+							{Key: awssdk.String("stickiness.type"), Value: awssdk.String("app_cookie")},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "attributes already configured",
+			targetGroupAttributes: []elbv2api.TargetGroupAttribute{
+				{Key: "stickiness.enabled", Value: "true"},
+			},
+			currentAttributes: []*elbv2sdk.TargetGroupAttribute{
+				{Key: awssdk.String("stickiness.enabled"), Value: awssdk.String("true")},
+				{Key: awssdk.String("stickiness.type"), Value: awssdk.String("lb_cookie")},
+			},
+		},
+		{
+			name: "app_cookie stickiness without cookie name",
+			targetGroupAttributes: []elbv2api.TargetGroupAttribute{
+				{Key: "stickiness.enabled", Value: "true"},
+				{Key: "stickiness.type", Value: "app_cookie"},
+			},
+			currentAttributes: []*elbv2sdk.TargetGroupAttribute{
+				{Key: awssdk.String("stickiness.enabled"), Value: awssdk.String("false")},
+				{Key: awssdk.String("stickiness.type"), Value: awssdk.String("lb_cookie")},
+			},
+			wantErr: errors.New("InvalidTargetGroupAttributes: app_cookie stickiness requires attribute stickiness.app_cookie.cookie_name"),
+		},
+		{
+			name: "invalid targetGroupAttributes",
+			targetGroupAttributes: []elbv2api.TargetGroupAttribute{
+				{Key: "stickiness.enabled", Value: "yes"},
+			},
+			wantErr: errors.New("InvalidTargetGroupAttributes: invalid target group attribute stickiness.enabled: value yes must be one of true, false"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			elbv2Client := mock_services.NewMockELBV2(ctrl)
+			if len(tt.currentAttributes) != 0 {
+				elbv2Client.EXPECT().DescribeTargetGroupAttributesWithContext(gomock.Any(), &elbv2sdk.DescribeTargetGroupAttributesInput{
+					TargetGroupArn: awssdk.String("tg-1"),
+				}).Return(&elbv2sdk.DescribeTargetGroupAttributesOutput{Attributes: tt.currentAttributes}, nil)
+			}
+			for _, call := range tt.modifyTargetGroupAttributesWithContextCalls {
+				elbv2Client.EXPECT().ModifyTargetGroupAttributesWithContext(gomock.Any(), call.req).Return(&elbv2sdk.ModifyTargetGroupAttributesOutput{}, nil)
+			}
+
+			tgb := &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "tgb-1"},
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN:        "tg-1",
+					TargetGroupAttributes: tt.targetGroupAttributes,
+				},
+			}
+			m := &defaultResourceManager{
+				elbv2Client:                   elbv2Client,
+				logger:                        &log.NullLogger{},
+				targetGroupAttributesCache:    cache.NewExpiring(),
+				targetGroupAttributesCacheTTL: defaultTargetGroupAttributesCacheTTL,
+			}
+			err := m.reconcileTargetGroupAttributes(context.Background(), tgb)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				// attributes are cached, including the modified ones, so that subsequent reconciles take no API calls.
+				assert.NoError(t, m.reconcileTargetGroupAttributes(context.Background(), tgb))
+			}
+		})
+	}
+}
+
 func Test_containsTargetsInInitialState(t *testing.T) {
 	type args struct {
 		matchedEndpointAndTargets []podEndpointAndTargetPair
@@ -500,3 +986,311 @@ func Test_buildPodConditionPatch(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultResourceManager_updateDeregistrationPodCondition(t *testing.T) {
+	requestedCond := corev1.PodCondition{
+		Type:    "target-health.elbv2.k8s.aws/my-tgb",
+		Status:  corev1.ConditionFalse,
+		Reason:  "DeregistrationRequested",
+		Message: "Target deregistration is requested before pod deletion",
+	}
+	buildPod := func(cond corev1.PodCondition) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "my-pod",
+				UID:       "my-pod-uuid",
+			},
+			Spec: corev1.PodSpec{
+				ReadinessGates: []corev1.PodReadinessGate{
+					{
+						ConditionType: "target-health.elbv2.k8s.aws/my-tgb",
+					},
+				},
+			},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{cond},
+			},
+		}
+	}
+	endpoint := backend.PodEndpoint{
+		IP:   "192.168.1.1",
+		Port: 8080,
+		Pod: k8s.PodInfo{
+			Key:        types.NamespacedName{Namespace: "default", Name: "my-pod"},
+			UID:        "my-pod-uuid",
+			Conditions: []corev1.PodCondition{requestedCond},
+		},
+	}
+	tests := []struct {
+		name    string
+		targets []TargetInfo
+		want    bool
+		wantPod *corev1.Pod
+	}{
+		{
+			name: "target is draining",
+			targets: []TargetInfo{
+				{
+					Target: elbv2sdk.TargetDescription{Id: awssdk.String("192.168.1.1"), Port: awssdk.Int64(8080)},
+					TargetHealth: &elbv2sdk.TargetHealth{
+						State:  awssdk.String(elbv2sdk.TargetHealthStateEnumDraining),
+						Reason: awssdk.String(elbv2sdk.TargetHealthReasonEnumTargetDeregistrationInProgress),
+					},
+				},
+			},
+			want: true,
+			wantPod: buildPod(corev1.PodCondition{
+				Type:    "target-health.elbv2.k8s.aws/my-tgb",
+				Status:  corev1.ConditionFalse,
+				Reason:  "Target.DeregistrationInProgress",
+				Message: "Target deregistration is in progress",
+			}),
+		},
+		{
+			name: "target finished draining",
+			targets: []TargetInfo{
+				{
+					Target: elbv2sdk.TargetDescription{Id: awssdk.String("192.168.1.2"), Port: awssdk.Int64(8080)},
+					TargetHealth: &elbv2sdk.TargetHealth{
+						State: awssdk.String(elbv2sdk.TargetHealthStateEnumHealthy),
+					},
+				},
+			},
+			want: false,
+			wantPod: buildPod(corev1.PodCondition{
+				Type:    "target-health.elbv2.k8s.aws/my-tgb",
+				Status:  corev1.ConditionFalse,
+				Reason:  "TargetDeregistered",
+				Message: "Target finished draining",
+			}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+
+			m := &defaultResourceManager{
+				k8sClient:        k8sClient,
+				metricsCollector: tgbmetrics.NewNoopCollector(),
+				logger:           &log.NullLogger{},
+			}
+
+			ctx := context.Background()
+			assert.NoError(t, k8sClient.Create(ctx, buildPod(requestedCond)))
+
+			got, err := m.updateDeregistrationPodCondition(ctx, "target-health.elbv2.k8s.aws/my-tgb",
+				[]backend.PodEndpoint{endpoint}, tt.targets)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			updatedPod := &corev1.Pod{}
+			assert.NoError(t, m.k8sClient.Get(ctx, endpoint.Pod.Key, updatedPod))
+			opts := cmp.Options{
+				equality.IgnoreFakeClientPopulatedFields(),
+				cmpopts.IgnoreTypes(metav1.Time{}),
+			}
+			assert.True(t, cmp.Equal(tt.wantPod, updatedPod, opts), "diff", cmp.Diff(tt.wantPod, updatedPod, opts))
+		})
+	}
+}
+
+// staticQuotaProvider provides quota values from a map, falls back to default values.
+type staticQuotaProvider map[string]int64
+
+func (p staticQuotaProvider) GetQuotaValue(_ context.Context, quota quota.Quota) int64 {
+	if value, ok := p[quota.Name]; ok {
+		return value
+	}
+	return quota.DefaultValue
+}
+
+func Test_defaultResourceManager_reconcileTargetsOverflow(t *testing.T) {
+	truncate := elbv2api.TargetOverflowStrategyTruncate
+	overflowedCond := elbv2api.TargetGroupBindingCondition{
+		Type:   elbv2api.TargetGroupBindingConditionTargetsOverflowed,
+		Status: corev1.ConditionTrue,
+		Reason: "MaxTargetsReached",
+	}
+	type args struct {
+		spec                  elbv2api.TargetGroupBindingSpec
+		conditions            []elbv2api.TargetGroupBindingCondition
+		registeredTargetCount int
+		newTargetCount        int
+	}
+	tests := []struct {
+		name           string
+		quotaProvider  staticQuotaProvider
+		args           args
+		want           int
+		wantConditions []elbv2api.TargetGroupBindingCondition
+		wantErr        error
+	}{
+		{
+			name: "targets within default quota",
+			args: args{
+				registeredTargetCount: 10,
+				newTargetCount:        5,
+			},
+			want: 5,
+		},
+		{
+			name: "targets within maxTargets",
+			args: args{
+				spec:                  elbv2api.TargetGroupBindingSpec{MaxTargets: awssdk.Int64(20)},
+				registeredTargetCount: 10,
+				newTargetCount:        10,
+			},
+			want: 10,
+		},
+		{
+			name: "targets exceeded maxTargets with default Fail strategy",
+			args: args{
+				spec:                  elbv2api.TargetGroupBindingSpec{MaxTargets: awssdk.Int64(12)},
+				registeredTargetCount: 10,
+				newTargetCount:        5,
+			},
+			want: 0,
+			wantConditions: []elbv2api.TargetGroupBindingCondition{
+				{
+					Type:    elbv2api.TargetGroupBindingConditionTargetsOverflowed,
+					Status:  corev1.ConditionTrue,
+					Reason:  "MaxTargetsReached",
+					Message: "5 of 15 targets are not registered since TargetGroup reached the max targets 12",
+				},
+			},
+			wantErr: errors.New("MaxTargetsExceeded: max targets exceeded for TargetGroup my-tg: 15 desired, limit 12"),
+		},
+		{
+			name: "targets exceeded maxTargets with Truncate strategy",
+			args: args{
+				spec:                  elbv2api.TargetGroupBindingSpec{MaxTargets: awssdk.Int64(12), TargetOverflowStrategy: &truncate},
+				registeredTargetCount: 10,
+				newTargetCount:        5,
+			},
+			want: 2,
+			wantConditions: []elbv2api.TargetGroupBindingCondition{
+				{
+					Type:    elbv2api.TargetGroupBindingConditionTargetsOverflowed,
+					Status:  corev1.ConditionTrue,
+					Reason:  "MaxTargetsReached",
+					Message: "3 of 15 targets are not registered since TargetGroup reached the max targets 12",
+				},
+			},
+		},
+		{
+			name: "registered targets exceeded maxTargets with Truncate strategy",
+			args: args{
+				spec:                  elbv2api.TargetGroupBindingSpec{MaxTargets: awssdk.Int64(8), TargetOverflowStrategy: &truncate},
+				registeredTargetCount: 10,
+				newTargetCount:        5,
+			},
+			want: 0,
+			wantConditions: []elbv2api.TargetGroupBindingCondition{
+				{
+					Type:    elbv2api.TargetGroupBindingConditionTargetsOverflowed,
+					Status:  corev1.ConditionTrue,
+					Reason:  "MaxTargetsReached",
+					Message: "5 of 15 targets are not registered since TargetGroup reached the max targets 8",
+				},
+			},
+		},
+		{
+			name:          "targets exceeded quota with Truncate strategy",
+			quotaProvider: staticQuotaProvider{"Targets per Target Group per Region": 1200},
+			args: args{
+				spec:                  elbv2api.TargetGroupBindingSpec{TargetOverflowStrategy: &truncate},
+				registeredTargetCount: 1000,
+				newTargetCount:        300,
+			},
+			want: 200,
+			wantConditions: []elbv2api.TargetGroupBindingCondition{
+				{
+					Type:    elbv2api.TargetGroupBindingConditionTargetsOverflowed,
+					Status:  corev1.ConditionTrue,
+					Reason:  "MaxTargetsReached",
+					Message: "100 of 1300 targets are not registered since TargetGroup reached the max targets 1200",
+				},
+			},
+		},
+		{
+			name: "targets exceeded quota with default Fail strategy",
+			args: args{
+				registeredTargetCount: 1000,
+				newTargetCount:        1,
+			},
+			want: 0,
+			wantConditions: []elbv2api.TargetGroupBindingCondition{
+				{
+					Type:    elbv2api.TargetGroupBindingConditionTargetsOverflowed,
+					Status:  corev1.ConditionTrue,
+					Reason:  "MaxTargetsReached",
+					Message: "1 of 1001 targets are not registered since TargetGroup reached the max targets 1000",
+				},
+			},
+			wantErr: errors.New("quota \"Targets per Target Group per Region\" exceeded for TargetGroup my-tg: 1001 desired, limit 1000"),
+		},
+		{
+			name: "targets no longer overflowed",
+			args: args{
+				spec:                  elbv2api.TargetGroupBindingSpec{MaxTargets: awssdk.Int64(20)},
+				conditions:            []elbv2api.TargetGroupBindingCondition{overflowedCond},
+				registeredTargetCount: 10,
+				newTargetCount:        5,
+			},
+			want: 5,
+			wantConditions: []elbv2api.TargetGroupBindingCondition{
+				{
+					Type:   elbv2api.TargetGroupBindingConditionTargetsOverflowed,
+					Status: corev1.ConditionFalse,
+					Reason: "TargetsRegistered",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+
+			m := &defaultResourceManager{
+				k8sClient:        k8sClient,
+				quotaProvider:    tt.quotaProvider,
+				metricsCollector: tgbmetrics.NewNoopCollector(),
+				logger:           &log.NullLogger{},
+			}
+
+			ctx := context.Background()
+			tgb := &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "my-tgb",
+				},
+				Spec: tt.args.spec,
+				Status: elbv2api.TargetGroupBindingStatus{
+					Conditions: tt.args.conditions,
+				},
+			}
+			tgb.Spec.TargetGroupARN = "my-tg"
+			assert.NoError(t, k8sClient.Create(ctx, tgb))
+
+			got, err := m.reconcileTargetsOverflow(ctx, tgb, tt.args.registeredTargetCount, tt.args.newTargetCount)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+
+			updatedTGB := &elbv2api.TargetGroupBinding{}
+			assert.NoError(t, k8sClient.Get(ctx, k8s.NamespacedName(tgb), updatedTGB))
+			opts := cmpopts.IgnoreTypes(metav1.Time{})
+			assert.True(t, cmp.Equal(tt.wantConditions, updatedTGB.Status.Conditions, opts),
+				"diff", cmp.Diff(tt.wantConditions, updatedTGB.Status.Conditions, opts))
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package targetgroupbinding
+
+import (
+	"context"
+	"fmt"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	tgbmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/targetgroupbinding/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sync"
+	"time"
+)
+
+const (
+	// target health is reported at most once per this interval for each TargetGroupBinding,
+	// since reconciles are requeued rapidly while monitoring targets.
+	defaultTargetHealthMinReportInterval = 15 * time.Second
+
+	// the reasons of TargetsHealthy condition of TargetGroupBinding.
+	tgbConditionReasonAllTargetsHealthy = "AllTargetsHealthy"
+	tgbConditionReasonTargetsUnhealthy  = "TargetsUnhealthy"
+	tgbConditionReasonNoTargets         = "NoTargets"
+)
+
+// TargetHealthReporter reports the aggregate target health of TargetGroupBindings.
+type TargetHealthReporter interface {
+	// Report reports the aggregate target health of TargetGroupBinding via its status and metrics.
+	Report(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error
+
+	// Forget removes the target health reported for TargetGroupBinding, which should be called once TargetGroupBinding is deleted.
+	Forget(tgbKey types.NamespacedName)
+}
+
+// NewDefaultTargetHealthReporter constructs new defaultTargetHealthReporter.
+func NewDefaultTargetHealthReporter(k8sClient client.Client, cloud aws.Cloud, metricsCollector tgbmetrics.Collector, logger logr.Logger) *defaultTargetHealthReporter {
+	return &defaultTargetHealthReporter{
+		k8sClient:         k8sClient,
+		cloud:             cloud,
+		metricsCollector:  metricsCollector,
+		logger:            logger,
+		minReportInterval: defaultTargetHealthMinReportInterval,
+		lastReportTimes:   make(map[types.NamespacedName]time.Time),
+	}
+}
+
+var _ TargetHealthReporter = &defaultTargetHealthReporter{}
+
+// default implementation for TargetHealthReporter.
+// target health is described from AWS on each report, bypassing the targets cache of TargetsManager.
+type defaultTargetHealthReporter struct {
+	k8sClient        client.Client
+	cloud            aws.Cloud
+	metricsCollector tgbmetrics.Collector
+	logger           logr.Logger
+
+	minReportInterval time.Duration
+	// lastReportTimes tracks the time target health was last reported for TargetGroupBindings.
+	lastReportTimes      map[types.NamespacedName]time.Time
+	lastReportTimesMutex sync.Mutex
+}
+
+func (r *defaultTargetHealthReporter) Report(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
+	tgbKey := k8s.NamespacedName(tgb)
+	if r.isReportedRecently(tgbKey) {
+		return nil
+	}
+	targets, err := r.describeTargetHealth(ctx, tgb)
+	if err != nil {
+		return err
+	}
+	summary := summarizeTargetHealth(targets)
+	r.metricsCollector.ObserveTargetHealth(tgbKey, int(summary.HealthyTargets), int(summary.TotalTargets))
+	if err := r.updateTargetHealthStatus(ctx, tgb, summary); err != nil {
+		return err
+	}
+
+	r.lastReportTimesMutex.Lock()
+	defer r.lastReportTimesMutex.Unlock()
+	r.lastReportTimes[tgbKey] = time.Now()
+	return nil
+}
+
+func (r *defaultTargetHealthReporter) Forget(tgbKey types.NamespacedName) {
+	r.lastReportTimesMutex.Lock()
+	defer r.lastReportTimesMutex.Unlock()
+	delete(r.lastReportTimes, tgbKey)
+}
+
+func (r *defaultTargetHealthReporter) isReportedRecently(tgbKey types.NamespacedName) bool {
+	r.lastReportTimesMutex.Lock()
+	defer r.lastReportTimesMutex.Unlock()
+	lastReportTime, ok := r.lastReportTimes[tgbKey]
+	return ok && time.Since(lastReportTime) < r.minReportInterval
+}
+
+// describeTargetHealth describes the targets of TargetGroup for TargetGroupBinding,
+// TargetGroupBindings with iamRoleARNToAssume are described with credentials of that IAM role, and TargetGroups in other regions with clients of that region.
+func (r *defaultTargetHealthReporter) describeTargetHealth(ctx context.Context, tgb *elbv2api.TargetGroupBinding) ([]TargetInfo, error) {
+	elbv2Client := buildCustomCloudForTGB(r.cloud, tgb).ELBV2()
+	req := &elbv2sdk.DescribeTargetHealthInput{
+		TargetGroupArn: awssdk.String(tgb.Spec.TargetGroupARN),
+	}
+	resp, err := elbv2Client.DescribeTargetHealthWithContext(ctx, req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe target health: %v", tgb.Spec.TargetGroupARN)
+	}
+	targets := make([]TargetInfo, 0, len(resp.TargetHealthDescriptions))
+	for _, elem := range resp.TargetHealthDescriptions {
+		targets = append(targets, TargetInfo{
+			Target:       *elem.Target,
+			TargetHealth: elem.TargetHealth,
+		})
+	}
+	return targets, nil
+}
+
+// updateTargetHealthStatus updates the targetHealth and TargetsHealthy condition of TargetGroupBinding for summary.
+func (r *defaultTargetHealthReporter) updateTargetHealthStatus(ctx context.Context, tgb *elbv2api.TargetGroupBinding, summary elbv2api.TargetHealthSummary) error {
+	newCond := elbv2api.TargetGroupBindingCondition{
+		Type:    elbv2api.TargetGroupBindingConditionTargetsHealthy,
+		Status:  corev1.ConditionFalse,
+		Reason:  tgbConditionReasonTargetsUnhealthy,
+		Message: fmt.Sprintf("%v of %v targets are healthy", summary.HealthyTargets, summary.TotalTargets),
+	}
+	if summary.TotalTargets == 0 {
+		newCond.Reason = tgbConditionReasonNoTargets
+		newCond.Message = "TargetGroup has no targets"
+	} else if summary.HealthyTargets == summary.TotalTargets {
+		newCond.Status = corev1.ConditionTrue
+		newCond.Reason = tgbConditionReasonAllTargetsHealthy
+	}
+
+	tgbOld := tgb.DeepCopy()
+	condChanged := setTargetGroupBindingCondition(tgb, newCond)
+	if !condChanged && equality.Semantic.DeepEqual(tgb.Status.TargetHealth, &summary) {
+		return nil
+	}
+	tgb.Status.TargetHealth = &summary
+	if err := r.k8sClient.Status().Patch(ctx, tgb, client.MergeFrom(tgbOld)); err != nil {
+		return errors.Wrapf(err, "failed to update targetGroupBinding status: %v", k8s.NamespacedName(tgb))
+	}
+	return nil
+}
+
+// summarizeTargetHealth summarizes the health of targets, targets being deregistered are excluded.
+func summarizeTargetHealth(targets []TargetInfo) elbv2api.TargetHealthSummary {
+	var summary elbv2api.TargetHealthSummary
+	for _, target := range targets {
+		if target.IsDraining() {
+			continue
+		}
+		summary.TotalTargets++
+		if target.IsHealthy() {
+			summary.HealthyTargets++
+		}
+	}
+	return summary
+}
@@ -0,0 +1,219 @@
+package targetgroupbinding
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	tgbmetrics "sigs.k8s.io/aws-load-balancer-controller/pkg/targetgroupbinding/metrics"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+	"time"
+)
+
+func Test_summarizeTargetHealth(t *testing.T) {
+	buildTarget := func(id string, state string) TargetInfo {
+		return TargetInfo{
+			Target:       elbv2sdk.TargetDescription{Id: awssdk.String(id)},
+			TargetHealth: &elbv2sdk.TargetHealth{State: awssdk.String(state)},
+		}
+	}
+	tests := []struct {
+		name    string
+		targets []TargetInfo
+		want    elbv2api.TargetHealthSummary
+	}{
+		{
+			name:    "no targets",
+			targets: nil,
+			want:    elbv2api.TargetHealthSummary{},
+		},
+		{
+			name: "all targets healthy",
+			targets: []TargetInfo{
+				buildTarget("i-1", elbv2sdk.TargetHealthStateEnumHealthy),
+				buildTarget("i-2", elbv2sdk.TargetHealthStateEnumHealthy),
+			},
+			want: elbv2api.TargetHealthSummary{HealthyTargets: 2, TotalTargets: 2},
+		},
+		{
+			name: "targets in other states are not healthy",
+			targets: []TargetInfo{
+				buildTarget("i-1", elbv2sdk.TargetHealthStateEnumHealthy),
+				buildTarget("i-2", elbv2sdk.TargetHealthStateEnumUnhealthy),
+				buildTarget("i-3", elbv2sdk.TargetHealthStateEnumInitial),
+				{Target: elbv2sdk.TargetDescription{Id: awssdk.String("i-4")}},
+			},
+			want: elbv2api.TargetHealthSummary{HealthyTargets: 1, TotalTargets: 4},
+		},
+		{
+			name: "draining targets are excluded",
+			targets: []TargetInfo{
+				buildTarget("i-1", elbv2sdk.TargetHealthStateEnumHealthy),
+				buildTarget("i-2", elbv2sdk.TargetHealthStateEnumDraining),
+			},
+			want: elbv2api.TargetHealthSummary{HealthyTargets: 1, TotalTargets: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := summarizeTargetHealth(tt.targets)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_defaultTargetHealthReporter_updateTargetHealthStatus(t *testing.T) {
+	type args struct {
+		conditions []elbv2api.TargetGroupBindingCondition
+		summary    elbv2api.TargetHealthSummary
+	}
+	tests := []struct {
+		name           string
+		args           args
+		wantConditions []elbv2api.TargetGroupBindingCondition
+	}{
+		{
+			name: "all targets healthy",
+			args: args{
+				summary: elbv2api.TargetHealthSummary{HealthyTargets: 3, TotalTargets: 3},
+			},
+			wantConditions: []elbv2api.TargetGroupBindingCondition{
+				{
+					Type:    elbv2api.TargetGroupBindingConditionTargetsHealthy,
+					Status:  corev1.ConditionTrue,
+					Reason:  "AllTargetsHealthy",
+					Message: "3 of 3 targets are healthy",
+				},
+			},
+		},
+		{
+			name: "some targets unhealthy",
+			args: args{
+				conditions: []elbv2api.TargetGroupBindingCondition{
+					{
+						Type:    elbv2api.TargetGroupBindingConditionTargetsHealthy,
+						Status:  corev1.ConditionTrue,
+						Reason:  "AllTargetsHealthy",
+						Message: "3 of 3 targets are healthy",
+					},
+				},
+				summary: elbv2api.TargetHealthSummary{HealthyTargets: 0, TotalTargets: 3},
+			},
+			wantConditions: []elbv2api.TargetGroupBindingCondition{
+				{
+					Type:    elbv2api.TargetGroupBindingConditionTargetsHealthy,
+					Status:  corev1.ConditionFalse,
+					Reason:  "TargetsUnhealthy",
+					Message: "0 of 3 targets are healthy",
+				},
+			},
+		},
+		{
+			name: "no targets",
+			args: args{
+				conditions: []elbv2api.TargetGroupBindingCondition{
+					{
+						Type:   elbv2api.TargetGroupBindingConditionTargetsOverflowed,
+						Status: corev1.ConditionFalse,
+						Reason: "TargetsRegistered",
+					},
+				},
+				summary: elbv2api.TargetHealthSummary{},
+			},
+			wantConditions: []elbv2api.TargetGroupBindingCondition{
+				{
+					Type:   elbv2api.TargetGroupBindingConditionTargetsOverflowed,
+					Status: corev1.ConditionFalse,
+					Reason: "TargetsRegistered",
+				},
+				{
+					Type:    elbv2api.TargetGroupBindingConditionTargetsHealthy,
+					Status:  corev1.ConditionFalse,
+					Reason:  "NoTargets",
+					Message: "TargetGroup has no targets",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+
+			r := NewDefaultTargetHealthReporter(k8sClient, nil, tgbmetrics.NewNoopCollector(), &log.NullLogger{})
+
+			ctx := context.Background()
+			tgb := &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "my-tgb",
+				},
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "my-tg",
+				},
+				Status: elbv2api.TargetGroupBindingStatus{
+					Conditions: tt.args.conditions,
+				},
+			}
+			assert.NoError(t, k8sClient.Create(ctx, tgb))
+
+			err := r.updateTargetHealthStatus(ctx, tgb, tt.args.summary)
+			assert.NoError(t, err)
+
+			updatedTGB := &elbv2api.TargetGroupBinding{}
+			assert.NoError(t, k8sClient.Get(ctx, k8s.NamespacedName(tgb), updatedTGB))
+			assert.Equal(t, &tt.args.summary, updatedTGB.Status.TargetHealth)
+			opts := cmpopts.IgnoreTypes(metav1.Time{})
+			assert.True(t, cmp.Equal(tt.wantConditions, updatedTGB.Status.Conditions, opts),
+				"diff", cmp.Diff(tt.wantConditions, updatedTGB.Status.Conditions, opts))
+		})
+	}
+}
+
+func Test_defaultTargetHealthReporter_isReportedRecently(t *testing.T) {
+	tgbKey := types.NamespacedName{Namespace: "default", Name: "my-tgb"}
+	tests := []struct {
+		name            string
+		lastReportTimes map[types.NamespacedName]time.Time
+		want            bool
+	}{
+		{
+			name:            "never reported",
+			lastReportTimes: map[types.NamespacedName]time.Time{},
+			want:            false,
+		},
+		{
+			name:            "reported within min interval",
+			lastReportTimes: map[types.NamespacedName]time.Time{tgbKey: time.Now().Add(-5 * time.Second)},
+			want:            true,
+		},
+		{
+			name:            "reported before min interval",
+			lastReportTimes: map[types.NamespacedName]time.Time{tgbKey: time.Now().Add(-1 * time.Minute)},
+			want:            false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &defaultTargetHealthReporter{
+				minReportInterval: 15 * time.Second,
+				lastReportTimes:   tt.lastReportTimes,
+			}
+			got := r.isReportedRecently(tgbKey)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -12,9 +12,10 @@ import (
 )
 
 const (
-	defaultTargetsCacheTTL            = 5 * time.Minute
-	defaultRegisterTargetsChunkSize   = 200
-	defaultDeregisterTargetsChunkSize = 200
+	defaultTargetsCacheTTL = 5 * time.Minute
+	// targets are registered and deregistered in chunks within the limit of 100 targets per ELBV2 API call.
+	defaultRegisterTargetsChunkSize   = 100
+	defaultDeregisterTargetsChunkSize = 100
 )
 
 // TargetsManager is an abstraction around ELBV2's targets API.
@@ -2,10 +2,13 @@ package targetgroupbinding
 
 import (
 	"fmt"
+	"github.com/aws/aws-sdk-go/aws/arn"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws"
 )
 
 const (
@@ -35,3 +38,69 @@ func buildServiceReferenceKey(tgb *elbv2api.TargetGroupBinding, svcRef elbv2api.
 		Name:      svcRef.Name,
 	}
 }
+
+// findTargetGroupBindingCondition returns the condition of condType on TargetGroupBinding.
+func findTargetGroupBindingCondition(tgb *elbv2api.TargetGroupBinding, condType elbv2api.TargetGroupBindingConditionType) (elbv2api.TargetGroupBindingCondition, bool) {
+	for _, cond := range tgb.Status.Conditions {
+		if cond.Type == condType {
+			return cond, true
+		}
+	}
+	return elbv2api.TargetGroupBindingCondition{}, false
+}
+
+// setTargetGroupBindingCondition sets newCond on TargetGroupBinding, its lastTransitionTime is only updated when status changes.
+// returns whether the conditions of TargetGroupBinding are changed.
+func setTargetGroupBindingCondition(tgb *elbv2api.TargetGroupBinding, newCond elbv2api.TargetGroupBindingCondition) bool {
+	for i, existingCond := range tgb.Status.Conditions {
+		if existingCond.Type != newCond.Type {
+			continue
+		}
+		if existingCond.Status == newCond.Status && existingCond.Reason == newCond.Reason && existingCond.Message == newCond.Message {
+			return false
+		}
+		newCond.LastTransitionTime = existingCond.LastTransitionTime
+		if existingCond.Status != newCond.Status {
+			newCond.LastTransitionTime = metav1.Now()
+		}
+		tgb.Status.Conditions[i] = newCond
+		return true
+	}
+	newCond.LastTransitionTime = metav1.Now()
+	tgb.Status.Conditions = append(tgb.Status.Conditions, newCond)
+	return true
+}
+
+// targetGroupRegionOverride returns the region of TargetGroupBinding's TargetGroup if it's other than the controller's region.
+// it returns empty string if the TargetGroup lives in controller's region, or its ARN is unparsable.
+func targetGroupRegionOverride(cloud aws.Cloud, tgb *elbv2api.TargetGroupBinding) string {
+	parsedARN, err := arn.Parse(tgb.Spec.TargetGroupARN)
+	if err != nil || parsedARN.Region == "" || parsedARN.Region == cloud.Region() {
+		return ""
+	}
+	return parsedARN.Region
+}
+
+// buildCustomCloudKeyForTGB builds the key that identifies the IAM role and region to manage TargetGroupBinding's TargetGroup with.
+// it returns empty string if the TargetGroup should be managed with the controller's own Cloud.
+func buildCustomCloudKeyForTGB(cloud aws.Cloud, tgb *elbv2api.TargetGroupBinding) string {
+	roleARN := tgb.Spec.IAMRoleARNToAssume
+	region := targetGroupRegionOverride(cloud, tgb)
+	if roleARN == "" && region == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s@%s", roleARN, region)
+}
+
+// buildCustomCloudForTGB builds the Cloud for the AWS account and region of TargetGroupBinding's TargetGroup.
+// TargetGroupBindings with iamRoleARNToAssume use credentials of that IAM role, and TargetGroups in other regions use clients of that region.
+func buildCustomCloudForTGB(cloud aws.Cloud, tgb *elbv2api.TargetGroupBinding) aws.Cloud {
+	tgbCloud := cloud
+	if tgb.Spec.IAMRoleARNToAssume != "" {
+		tgbCloud = tgbCloud.AssumeRole(tgb.Spec.IAMRoleARNToAssume)
+	}
+	if region := targetGroupRegionOverride(cloud, tgb); region != "" {
+		tgbCloud = tgbCloud.ForRegion(region, tgb.Spec.VpcID)
+	}
+	return tgbCloud
+}
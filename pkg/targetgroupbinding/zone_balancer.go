@@ -0,0 +1,60 @@
+package targetgroupbinding
+
+import (
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/backend"
+)
+
+// balancePodEndpointsByZone partitions new endpoints into the ones that can be registered without skewing availability zones beyond maxSkew,
+// and the ones that must wait until other availability zones catch up.
+// each availability zone is allowed up to maxSkew more targets than the availability zone with the fewest potential targets,
+// i.e. its registered targets plus all its new endpoints. registered targets are always kept, and endpoints of unknown zone are never held.
+// the order of newEndpoints is preserved, so that the same endpoints are held across reconciles.
+func balancePodEndpointsByZone(registeredEndpoints []backend.PodEndpoint, newEndpoints []backend.PodEndpoint, maxSkew int64) ([]backend.PodEndpoint, []backend.PodEndpoint) {
+	registeredCountByZone := make(map[string]int64)
+	for _, endpoint := range registeredEndpoints {
+		if endpoint.Zone != "" {
+			registeredCountByZone[endpoint.Zone]++
+		}
+	}
+	newCountByZone := make(map[string]int64)
+	for _, endpoint := range newEndpoints {
+		if endpoint.Zone != "" {
+			newCountByZone[endpoint.Zone]++
+		}
+	}
+	if len(newCountByZone) == 0 {
+		return newEndpoints, nil
+	}
+
+	minPotentialCount := int64(-1)
+	for zone, registeredCount := range registeredCountByZone {
+		if potentialCount := registeredCount + newCountByZone[zone]; minPotentialCount == -1 || potentialCount < minPotentialCount {
+			minPotentialCount = potentialCount
+		}
+	}
+	for zone, newCount := range newCountByZone {
+		if potentialCount := registeredCountByZone[zone] + newCount; minPotentialCount == -1 || potentialCount < minPotentialCount {
+			minPotentialCount = potentialCount
+		}
+	}
+
+	allowedCountByZone := make(map[string]int64, len(newCountByZone))
+	for zone := range newCountByZone {
+		allowedCountByZone[zone] = minPotentialCount + maxSkew - registeredCountByZone[zone]
+	}
+	var allowedEndpoints []backend.PodEndpoint
+	var heldEndpoints []backend.PodEndpoint
+	for _, endpoint := range newEndpoints {
+		if endpoint.Zone == "" {
+			allowedEndpoints = append(allowedEndpoints, endpoint)
+			continue
+		}
+		if allowedCountByZone[endpoint.Zone] > 0 {
+			allowedCountByZone[endpoint.Zone]--
+			allowedEndpoints = append(allowedEndpoints, endpoint)
+		} else {
+			heldEndpoints = append(heldEndpoints, endpoint)
+		}
+	}
+	return allowedEndpoints, heldEndpoints
+}
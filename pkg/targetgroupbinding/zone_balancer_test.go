@@ -0,0 +1,94 @@
+package targetgroupbinding
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/backend"
+	"testing"
+)
+
+func Test_balancePodEndpointsByZone(t *testing.T) {
+	endpointA1 := backend.PodEndpoint{IP: "192.168.1.1", Port: 8080, Zone: "us-west-2a"}
+	endpointA2 := backend.PodEndpoint{IP: "192.168.1.2", Port: 8080, Zone: "us-west-2a"}
+	endpointA3 := backend.PodEndpoint{IP: "192.168.1.3", Port: 8080, Zone: "us-west-2a"}
+	endpointA4 := backend.PodEndpoint{IP: "192.168.1.4", Port: 8080, Zone: "us-west-2a"}
+	endpointB1 := backend.PodEndpoint{IP: "192.168.2.1", Port: 8080, Zone: "us-west-2b"}
+	endpointB2 := backend.PodEndpoint{IP: "192.168.2.2", Port: 8080, Zone: "us-west-2b"}
+	endpointUnknownZone := backend.PodEndpoint{IP: "192.168.3.1", Port: 8080}
+	type args struct {
+		registeredEndpoints []backend.PodEndpoint
+		newEndpoints        []backend.PodEndpoint
+		maxSkew             int64
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantAllowed []backend.PodEndpoint
+		wantHeld    []backend.PodEndpoint
+	}{
+		{
+			name: "no new endpoints",
+			args: args{
+				registeredEndpoints: []backend.PodEndpoint{endpointA1, endpointB1},
+				maxSkew:             1,
+			},
+			wantAllowed: nil,
+			wantHeld:    nil,
+		},
+		{
+			name: "new endpoints are balanced",
+			args: args{
+				registeredEndpoints: []backend.PodEndpoint{endpointA1, endpointB1},
+				newEndpoints:        []backend.PodEndpoint{endpointA2, endpointB2},
+				maxSkew:             0,
+			},
+			wantAllowed: []backend.PodEndpoint{endpointA2, endpointB2},
+			wantHeld:    nil,
+		},
+		{
+			name: "new endpoints skewed beyond maxSkew are held",
+			args: args{
+				registeredEndpoints: []backend.PodEndpoint{endpointA1, endpointB1},
+				newEndpoints:        []backend.PodEndpoint{endpointA2, endpointA3, endpointA4},
+				maxSkew:             1,
+			},
+			wantAllowed: []backend.PodEndpoint{endpointA2},
+			wantHeld:    []backend.PodEndpoint{endpointA3, endpointA4},
+		},
+		{
+			name: "new endpoints of the short availability zone are allowed",
+			args: args{
+				registeredEndpoints: []backend.PodEndpoint{endpointA1, endpointA2, endpointA3},
+				newEndpoints:        []backend.PodEndpoint{endpointA4, endpointB1, endpointB2},
+				maxSkew:             1,
+			},
+			wantAllowed: []backend.PodEndpoint{endpointB1, endpointB2},
+			wantHeld:    []backend.PodEndpoint{endpointA4},
+		},
+		{
+			name: "new endpoints of single availability zone",
+			args: args{
+				newEndpoints: []backend.PodEndpoint{endpointA1, endpointA2},
+				maxSkew:      0,
+			},
+			wantAllowed: []backend.PodEndpoint{endpointA1, endpointA2},
+			wantHeld:    nil,
+		},
+		{
+			name: "new endpoints of unknown zone are never held",
+			args: args{
+				registeredEndpoints: []backend.PodEndpoint{endpointA1, endpointA2, endpointB1},
+				newEndpoints:        []backend.PodEndpoint{endpointA3, endpointUnknownZone},
+				maxSkew:             1,
+			},
+			wantAllowed: []backend.PodEndpoint{endpointUnknownZone},
+			wantHeld:    []backend.PodEndpoint{endpointA3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotAllowed, gotHeld := balancePodEndpointsByZone(tt.args.registeredEndpoints, tt.args.newEndpoints, tt.args.maxSkew)
+			assert.Equal(t, tt.wantAllowed, gotAllowed)
+			assert.Equal(t, tt.wantHeld, gotHeld)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package tracing
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"net/url"
+)
+
+const (
+	flagTracingOTLPEndpoint   = "tracing-otlp-endpoint"
+	flagTracingSampleRatio    = "tracing-sample-ratio"
+	defaultTracingSampleRatio = 1.0
+)
+
+// Config contains the configurations for tracing reconciles with OpenTelemetry
+type Config struct {
+	// URL of the OTLP/HTTP endpoint that spans are exported to, like http://otel-collector:4318.
+	// Tracing is disabled if it's empty.
+	OTLPEndpoint string
+
+	// Ratio of reconciles that are sampled into traces.
+	SampleRatio float64
+}
+
+// BindFlags binds the command line flags to the fields in the config object
+func (cfg *Config) BindFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&cfg.OTLPEndpoint, flagTracingOTLPEndpoint, "",
+		"URL of the OTLP/HTTP endpoint that OpenTelemetry spans of reconciles, model builds, deployments and AWS API calls are exported to, disabled if empty")
+	fs.Float64Var(&cfg.SampleRatio, flagTracingSampleRatio, defaultTracingSampleRatio,
+		"Ratio between 0 and 1 of reconciles that are sampled into traces")
+}
+
+// Validate the tracing configuration
+func (cfg *Config) Validate() error {
+	if cfg.SampleRatio < 0 || cfg.SampleRatio > 1 {
+		return errors.Errorf("%v must be between 0 and 1", flagTracingSampleRatio)
+	}
+	if len(cfg.OTLPEndpoint) == 0 {
+		return nil
+	}
+	endpointURL, err := url.Parse(cfg.OTLPEndpoint)
+	if err != nil {
+		return errors.Wrapf(err, "invalid %v", flagTracingOTLPEndpoint)
+	}
+	if (endpointURL.Scheme != "http" && endpointURL.Scheme != "https") || len(endpointURL.Host) == 0 {
+		return errors.Errorf("invalid %v: %v, must be a http or https URL", flagTracingOTLPEndpoint, cfg.OTLPEndpoint)
+	}
+	return nil
+}
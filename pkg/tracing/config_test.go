@@ -0,0 +1,47 @@
+package tracing
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr string
+	}{
+		{
+			name: "tracing disabled",
+			cfg:  Config{SampleRatio: 1},
+		},
+		{
+			name: "http endpoint",
+			cfg:  Config{OTLPEndpoint: "http://otel-collector:4318", SampleRatio: 0.1},
+		},
+		{
+			name: "https endpoint with path",
+			cfg:  Config{OTLPEndpoint: "https://otel.example.com/v1/traces", SampleRatio: 0},
+		},
+		{
+			name:    "endpoint without scheme",
+			cfg:     Config{OTLPEndpoint: "otel-collector:4318", SampleRatio: 1},
+			wantErr: "invalid tracing-otlp-endpoint: otel-collector:4318, must be a http or https URL",
+		},
+		{
+			name:    "sample ratio exceeds 1",
+			cfg:     Config{SampleRatio: 1.5},
+			wantErr: "tracing-sample-ratio must be between 0 and 1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
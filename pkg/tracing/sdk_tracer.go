@@ -0,0 +1,74 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	sdkHandlerStartAPICallSpan = "startAPICallSpan"
+	sdkHandlerEndAPICallSpan   = "endAPICallSpan"
+
+	attributeAWSRegion     = attribute.Key("aws.region")
+	attributeAWSRequestID  = attribute.Key("aws.request_id")
+	attributeAWSRetryCount = attribute.Key("aws.retry_count")
+)
+
+// apiCallSpanContextKey is the context key of span for an AWS API call.
+type apiCallSpanContextKey struct{}
+
+// NewSDKTracer constructs new sdkTracer.
+func NewSDKTracer() *sdkTracer {
+	return &sdkTracer{}
+}
+
+// sdkTracer traces each AWS API call with a span, which covers all retries of the call.
+type sdkTracer struct{}
+
+// InjectHandlers injects the SDK handlers that trace AWS API calls.
+func (t *sdkTracer) InjectHandlers(handlers *request.Handlers) {
+	handlers.Build.PushFrontNamed(request.NamedHandler{
+		Name: sdkHandlerStartAPICallSpan,
+		Fn:   t.startAPICallSpan,
+	})
+	handlers.Complete.PushBackNamed(request.NamedHandler{
+		Name: sdkHandlerEndAPICallSpan,
+		Fn:   t.endAPICallSpan,
+	})
+}
+
+func (t *sdkTracer) startAPICallSpan(r *request.Request) {
+	if r.Operation == nil {
+		return
+	}
+	service := r.ClientInfo.ServiceID
+	operation := r.Operation.Name
+	ctx, span := StartSpan(r.Context(), fmt.Sprintf("%v.%v", service, operation),
+		semconv.RPCSystemKey.String("aws-api"),
+		semconv.RPCServiceKey.String(service),
+		semconv.RPCMethodKey.String(operation),
+		attributeAWSRegion.String(aws.StringValue(r.Config.Region)),
+	)
+	r.SetContext(context.WithValue(ctx, apiCallSpanContextKey{}, span))
+}
+
+func (t *sdkTracer) endAPICallSpan(r *request.Request) {
+	// the span isn't started if the request failed before build, like on invalid parameters.
+	span, ok := r.Context().Value(apiCallSpanContextKey{}).(trace.Span)
+	if !ok {
+		return
+	}
+	span.SetAttributes(
+		attributeAWSRequestID.String(r.RequestID),
+		attributeAWSRetryCount.Int(r.RetryCount),
+	)
+	if r.HTTPResponse != nil {
+		span.SetAttributes(semconv.HTTPStatusCodeKey.Int(r.HTTPResponse.StatusCode))
+	}
+	EndSpan(span, r.Error)
+}
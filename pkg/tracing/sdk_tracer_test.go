@@ -0,0 +1,108 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"net/http"
+	"testing"
+)
+
+func Test_sdkTracer_InjectHandlers(t *testing.T) {
+	tests := []struct {
+		name           string
+		operation      string
+		err            error
+		wantSpanName   string
+		wantStatusCode codes.Code
+		wantAttributes []attribute.KeyValue
+	}{
+		{
+			name:           "successful call",
+			operation:      "CreateLoadBalancer",
+			wantSpanName:   "Elastic Load Balancing v2.CreateLoadBalancer",
+			wantStatusCode: codes.Unset,
+			wantAttributes: []attribute.KeyValue{
+				attribute.String("rpc.system", "aws-api"),
+				attribute.String("rpc.service", "Elastic Load Balancing v2"),
+				attribute.String("rpc.method", "CreateLoadBalancer"),
+				attribute.String("aws.region", "us-west-2"),
+				attribute.String("aws.request_id", "request-id"),
+				attribute.Int("aws.retry_count", 2),
+				attribute.Int("http.status_code", 200),
+			},
+		},
+		{
+			name:           "failed call",
+			operation:      "DeleteLoadBalancer",
+			err:            awserr.New("LoadBalancerNotFound", "load balancer not found", errors.New("not found")),
+			wantSpanName:   "Elastic Load Balancing v2.DeleteLoadBalancer",
+			wantStatusCode: codes.Error,
+			wantAttributes: []attribute.KeyValue{
+				attribute.String("rpc.system", "aws-api"),
+				attribute.String("rpc.service", "Elastic Load Balancing v2"),
+				attribute.String("rpc.method", "DeleteLoadBalancer"),
+				attribute.String("aws.region", "us-west-2"),
+				attribute.String("aws.request_id", "request-id"),
+				attribute.Int("aws.retry_count", 2),
+				attribute.Int("http.status_code", 200),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spanRecorder := tracetest.NewSpanRecorder()
+			otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder)))
+			defer otel.SetTracerProvider(sdktrace.NewTracerProvider())
+
+			ctx, parentSpan := StartSpan(context.Background(), "ReconcileIngressGroup")
+			handlers := request.Handlers{}
+			NewSDKTracer().InjectHandlers(&handlers)
+			req := request.New(aws.Config{Region: aws.String("us-west-2")}, metadata.ClientInfo{ServiceID: "Elastic Load Balancing v2"}, handlers,
+				nil, &request.Operation{Name: tt.operation}, nil, nil)
+			req.SetContext(ctx)
+			handlers.Build.Run(req)
+			req.RequestID = "request-id"
+			req.RetryCount = 2
+			req.HTTPResponse = &http.Response{StatusCode: 200}
+			req.Error = tt.err
+			handlers.Complete.Run(req)
+			parentSpan.End()
+
+			spans := spanRecorder.Ended()
+			assert.Len(t, spans, 2)
+			apiCallSpan := spans[0]
+			assert.Equal(t, tt.wantSpanName, apiCallSpan.Name())
+			assert.Equal(t, parentSpan.SpanContext().SpanID(), apiCallSpan.Parent().SpanID())
+			assert.Equal(t, tt.wantStatusCode, apiCallSpan.Status().Code)
+			assert.Equal(t, tt.wantAttributes, apiCallSpan.Attributes())
+		})
+	}
+}
+
+func Test_sdkTracer_InjectHandlers_callFailedBeforeBuild(t *testing.T) {
+	spanRecorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder)))
+	defer otel.SetTracerProvider(sdktrace.NewTracerProvider())
+
+	ctx, parentSpan := StartSpan(context.Background(), "ReconcileService")
+	handlers := request.Handlers{}
+	NewSDKTracer().InjectHandlers(&handlers)
+	req := request.New(aws.Config{Region: aws.String("us-west-2")}, metadata.ClientInfo{ServiceID: "EC2"}, handlers,
+		nil, &request.Operation{Name: "CreateSecurityGroup"}, nil, nil)
+	req.SetContext(ctx)
+	req.Error = errors.New("invalid parameters")
+	handlers.Complete.Run(req)
+
+	assert.Empty(t, spanRecorder.Ended())
+	assert.True(t, parentSpan.IsRecording())
+}
@@ -0,0 +1,88 @@
+package tracing
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+	"net/url"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/version"
+)
+
+const (
+	instrumentationName = "sigs.k8s.io/aws-load-balancer-controller"
+	serviceName         = "aws-load-balancer-controller"
+
+	// AttributeReconcileRequest is the attribute of reconcile request in the form of namespace/name.
+	AttributeReconcileRequest = attribute.Key("k8s.reconcile_request")
+	// AttributeStackID is the attribute of ID of the resource stack built and deployed.
+	AttributeStackID = attribute.Key("elbv2.k8s.aws.stack_id")
+)
+
+// ShutdownFunc flushes the pending spans and stops exporting spans.
+type ShutdownFunc func(ctx context.Context) error
+
+// Setup installs the global TracerProvider that exports spans according to cfg.
+// Spans are no-ops if tracing is disabled.
+func Setup(ctx context.Context, cfg Config) (ShutdownFunc, error) {
+	if len(cfg.OTLPEndpoint) == 0 {
+		return func(ctx context.Context) error { return nil }, nil
+	}
+	endpointURL, err := url.Parse(cfg.OTLPEndpoint)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid OTLP endpoint: %v", cfg.OTLPEndpoint)
+	}
+	exporterOpts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(endpointURL.Host)}
+	if endpointURL.Scheme == "http" {
+		exporterOpts = append(exporterOpts, otlptracehttp.WithInsecure())
+	}
+	if len(endpointURL.Path) != 0 && endpointURL.Path != "/" {
+		exporterOpts = append(exporterOpts, otlptracehttp.WithURLPath(endpointURL.Path))
+	}
+	exporter, err := otlptracehttp.New(ctx, exporterOpts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize OTLP exporter")
+	}
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL,
+			semconv.ServiceNameKey.String(serviceName),
+			semconv.ServiceVersionKey.String(version.GitVersion),
+		)),
+	)
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return tracerProvider.Shutdown, nil
+}
+
+// StartSpan starts a span as child of the span within ctx, the returned context contains the new span.
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// EndSpan ends the span, and records err on it if not nil.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// TraceFunc wraps fn so that each of its invocations is traced by a span named name.
+func TraceFunc(name string, fn func(ctx context.Context) error, attrs ...attribute.KeyValue) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		ctx, span := StartSpan(ctx, name, attrs...)
+		err := fn(ctx)
+		EndSpan(span, err)
+		return err
+	}
+}
@@ -0,0 +1,55 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"testing"
+)
+
+func TestTraceFunc(t *testing.T) {
+	tests := []struct {
+		name              string
+		fnErr             error
+		wantStatusCode    codes.Code
+		wantStatusMessage string
+	}{
+		{
+			name:           "fn succeeded",
+			wantStatusCode: codes.Unset,
+		},
+		{
+			name:              "fn failed",
+			fnErr:             errors.New("failed to create listener"),
+			wantStatusCode:    codes.Error,
+			wantStatusMessage: "failed to create listener",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spanRecorder := tracetest.NewSpanRecorder()
+			otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder)))
+			defer otel.SetTracerProvider(sdktrace.NewTracerProvider())
+
+			var fnSpanContext trace.SpanContext
+			fn := TraceFunc("SynthesizeListener", func(ctx context.Context) error {
+				fnSpanContext = trace.SpanContextFromContext(ctx)
+				return tt.fnErr
+			})
+			err := fn(context.Background())
+			assert.Equal(t, tt.fnErr, err)
+
+			spans := spanRecorder.Ended()
+			assert.Len(t, spans, 1)
+			assert.Equal(t, "SynthesizeListener", spans[0].Name())
+			assert.Equal(t, fnSpanContext, spans[0].SpanContext())
+			assert.Equal(t, tt.wantStatusCode, spans[0].Status().Code)
+			assert.Equal(t, tt.wantStatusMessage, spans[0].Status().Description)
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/inject"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/targetgroupbinding"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/webhook"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"strings"
+	"time"
+)
+
+const (
+	apiPathValidatePod = "/validate-v1-pod"
+)
+
+// NewPodValidator returns a validator for Pod.
+func NewPodValidator(k8sClient client.Client, config inject.Config, logger logr.Logger) *podValidator {
+	return &podValidator{
+		k8sClient: k8sClient,
+		config:    config,
+		logger:    logger,
+	}
+}
+
+var _ webhook.Validator = &podValidator{}
+
+type podValidator struct {
+	k8sClient client.Client
+	config    inject.Config
+	logger    logr.Logger
+}
+
+func (v *podValidator) Prototype(_ admission.Request) (runtime.Object, error) {
+	return &corev1.Pod{}, nil
+}
+
+func (v *podValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+func (v *podValidator) ValidateUpdate(ctx context.Context, obj runtime.Object, oldObj runtime.Object) error {
+	return nil
+}
+
+// ValidateDelete delays the deletion of pods until their targets finished draining.
+// the pod is requested to deregister its targets upon first deletion attempt, and its deletion is denied until
+// controller reports its targets as deregistered for each TargetGroupBinding, or PodDeregistrationDrainMaxWait elapsed.
+// pods on draining nodes are deleted right away, since their node is going away and delaying them only holds up the node drain.
+func (v *podValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	if !v.config.EnablePodDeregistrationDrain {
+		return nil
+	}
+	pod := obj.(*corev1.Pod)
+	req := webhook.ContextGetAdmissionRequest(ctx)
+	if isForceDeletion(req) || !isPodServingTraffic(pod) {
+		return nil
+	}
+	targetHealthCondTypes, err := v.computeTargetHealthConditionTypes(ctx, pod)
+	if err != nil {
+		return err
+	}
+	if len(targetHealthCondTypes) == 0 {
+		return nil
+	}
+	nodeDraining, err := v.isPodNodeDraining(ctx, pod)
+	if err != nil {
+		return err
+	}
+	if nodeDraining {
+		v.logger.Info("skipped target deregistration drain on draining node", "pod", k8s.NamespacedName(pod), "node", pod.Spec.NodeName)
+		return nil
+	}
+
+	var deregisteringTGBNames []string
+	var unrequestedCondTypes []corev1.PodConditionType
+	for _, condType := range targetHealthCondTypes {
+		cond := k8s.GetPodCondition(pod, condType)
+		if cond == nil || !targetgroupbinding.IsPodDeregistrationRequested(*cond) {
+			unrequestedCondTypes = append(unrequestedCondTypes, condType)
+		} else if cond.Reason == targetgroupbinding.PodConditionReasonDeregistered {
+			continue
+		} else if v.config.PodDeregistrationDrainMaxWait > 0 && time.Since(cond.LastTransitionTime.Time) >= v.config.PodDeregistrationDrainMaxWait {
+			v.logger.Info("target deregistration timed out", "pod", k8s.NamespacedName(pod), "conditionType", condType)
+			continue
+		}
+		deregisteringTGBNames = append(deregisteringTGBNames, tgbNameForTargetHealthConditionType(condType))
+	}
+	if len(deregisteringTGBNames) == 0 {
+		return nil
+	}
+
+	if len(unrequestedCondTypes) != 0 && !isDryRun(req) {
+		if err := v.requestDeregistration(ctx, pod, unrequestedCondTypes); err != nil {
+			return err
+		}
+	}
+	return errors.Errorf("pod %v is deregistering from TargetGroupBindings %v, deletion is delayed until targets finished draining",
+		k8s.NamespacedName(pod).String(), strings.Join(deregisteringTGBNames, ","))
+}
+
+// computeTargetHealthConditionTypes computes the targetHealth condition types of pod for existing TargetGroupBindings with ip targetType.
+func (v *podValidator) computeTargetHealthConditionTypes(ctx context.Context, pod *corev1.Pod) ([]corev1.PodConditionType, error) {
+	var targetHealthCondTypes []corev1.PodConditionType
+	for _, rg := range pod.Spec.ReadinessGates {
+		if !strings.HasPrefix(string(rg.ConditionType), targetgroupbinding.TargetHealthPodConditionTypePrefix+"/") {
+			continue
+		}
+		tgbKey := types.NamespacedName{Namespace: pod.Namespace, Name: tgbNameForTargetHealthConditionType(rg.ConditionType)}
+		tgb := &elbv2api.TargetGroupBinding{}
+		if err := v.k8sClient.Get(ctx, tgbKey, tgb); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, errors.Wrap(err, "unable to determine targetHealth conditions")
+		}
+		if tgb.DeletionTimestamp != nil || tgb.Spec.TargetType == nil || (*tgb.Spec.TargetType) != elbv2api.TargetTypeIP {
+			continue
+		}
+		targetHealthCondTypes = append(targetHealthCondTypes, rg.ConditionType)
+	}
+	return targetHealthCondTypes, nil
+}
+
+// isPodNodeDraining checks whether the node of pod is draining.
+func (v *podValidator) isPodNodeDraining(ctx context.Context, pod *corev1.Pod) (bool, error) {
+	if pod.Spec.NodeName == "" {
+		return false, nil
+	}
+	node := &corev1.Node{}
+	if err := v.k8sClient.Get(ctx, types.NamespacedName{Name: pod.Spec.NodeName}, node); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "unable to determine node draining status")
+	}
+	return k8s.IsNodeDraining(node), nil
+}
+
+// requestDeregistration requests deregistration of pod's targets for these targetHealth condition types.
+func (v *podValidator) requestDeregistration(ctx context.Context, pod *corev1.Pod, targetHealthCondTypes []corev1.PodConditionType) error {
+	patch, err := targetgroupbinding.BuildPodDeregistrationRequestPatch(pod, targetHealthCondTypes)
+	if err != nil {
+		return err
+	}
+	k8sPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+			UID:       pod.UID,
+		},
+	}
+	if err := v.k8sClient.Status().Patch(ctx, k8sPod, patch); err != nil {
+		return errors.Wrap(err, "unable to request target deregistration")
+	}
+	v.logger.Info("requested target deregistration", "pod", k8s.NamespacedName(pod), "conditionTypes", targetHealthCondTypes)
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-v1-pod,mutating=false,failurePolicy=ignore,groups="",resources=pods,verbs=delete,versions=v1,name=vpod.elbv2.k8s.aws,sideEffects=NoneOnDryRun,webhookVersions=v1beta1
+
+func (v *podValidator) SetupWithManager(mgr ctrl.Manager) {
+	mgr.GetWebhookServer().Register(apiPathValidatePod, webhook.ValidatingWebhookForValidator(v))
+}
+
+// isPodServingTraffic checks whether pod might be serving traffic as targets.
+func isPodServingTraffic(pod *corev1.Pod) bool {
+	if pod.DeletionTimestamp != nil || pod.Status.PodIP == "" {
+		return false
+	}
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return false
+	}
+	return k8s.IsPodContainersReady(pod)
+}
+
+// isForceDeletion checks whether the deletion request is a force deletion with zero grace period.
+func isForceDeletion(req *admission.Request) bool {
+	if req == nil || len(req.Options.Raw) == 0 {
+		return false
+	}
+	deleteOptions := metav1.DeleteOptions{}
+	if err := json.Unmarshal(req.Options.Raw, &deleteOptions); err != nil {
+		return false
+	}
+	return deleteOptions.GracePeriodSeconds != nil && *deleteOptions.GracePeriodSeconds == 0
+}
+
+func isDryRun(req *admission.Request) bool {
+	return req != nil && req.DryRun != nil && *req.DryRun
+}
+
+func tgbNameForTargetHealthConditionType(condType corev1.PodConditionType) string {
+	return strings.TrimPrefix(string(condType), targetgroupbinding.TargetHealthPodConditionTypePrefix+"/")
+}
@@ -0,0 +1,214 @@
+package core
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/inject"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/webhook"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"testing"
+	"time"
+)
+
+func Test_podValidator_ValidateDelete(t *testing.T) {
+	ipTargetType := elbv2api.TargetTypeIP
+	instanceTargetType := elbv2api.TargetTypeInstance
+	tgbIP := &elbv2api.TargetGroupBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "tgb-ip",
+		},
+		Spec: elbv2api.TargetGroupBindingSpec{
+			TargetGroupARN: "tg-1",
+			TargetType:     &ipTargetType,
+		},
+	}
+	tgbInstance := &elbv2api.TargetGroupBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "tgb-instance",
+		},
+		Spec: elbv2api.TargetGroupBindingSpec{
+			TargetGroupARN: "tg-2",
+			TargetType:     &instanceTargetType,
+		},
+	}
+	containersReadyCond := corev1.PodCondition{
+		Type:   corev1.ContainersReady,
+		Status: corev1.ConditionTrue,
+	}
+	buildPod := func(readinessGates []corev1.PodConditionType, conditions ...corev1.PodCondition) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "awesome-ns",
+				Name:      "pod-1",
+				UID:       "pod-uuid",
+			},
+			Status: corev1.PodStatus{
+				PodIP:      "192.168.1.1",
+				Phase:      corev1.PodRunning,
+				Conditions: conditions,
+			},
+		}
+		for _, condType := range readinessGates {
+			pod.Spec.ReadinessGates = append(pod.Spec.ReadinessGates, corev1.PodReadinessGate{ConditionType: condType})
+		}
+		return pod
+	}
+	readinessGates := []corev1.PodConditionType{
+		"target-health.elbv2.k8s.aws/tgb-ip",
+		"target-health.elbv2.k8s.aws/tgb-instance",
+		"target-health.elbv2.k8s.aws/tgb-not-exists",
+	}
+	healthyCond := corev1.PodCondition{
+		Type:   "target-health.elbv2.k8s.aws/tgb-ip",
+		Status: corev1.ConditionTrue,
+	}
+	buildDeregistrationCond := func(reason string, lastTransitionTime time.Time) corev1.PodCondition {
+		return corev1.PodCondition{
+			Type:               "target-health.elbv2.k8s.aws/tgb-ip",
+			Status:             corev1.ConditionFalse,
+			Reason:             reason,
+			LastTransitionTime: metav1.NewTime(lastTransitionTime),
+		}
+	}
+	drainingNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "draining-node",
+		},
+		Spec: corev1.NodeSpec{
+			Taints: []corev1.Taint{
+				{
+					Key:    "aws-node-termination-handler/spot-itn",
+					Effect: corev1.TaintEffectNoSchedule,
+				},
+			},
+		},
+	}
+	onNode := func(pod *corev1.Pod, nodeName string) *corev1.Pod {
+		pod.Spec.NodeName = nodeName
+		return pod
+	}
+	deregistrationErr := errors.New("pod awesome-ns/pod-1 is deregistering from TargetGroupBindings tgb-ip, deletion is delayed until targets finished draining")
+
+	tests := []struct {
+		name          string
+		config        inject.Config
+		pod           *corev1.Pod
+		req           admissionv1beta1.AdmissionRequest
+		wantErr       error
+		wantPodReason string
+	}{
+		{
+			name:   "deregistration drain disabled",
+			config: inject.Config{EnablePodDeregistrationDrain: false},
+			pod:    buildPod(readinessGates, containersReadyCond, healthyCond),
+		},
+		{
+			name:   "pod without targetHealth readiness gates",
+			config: inject.Config{EnablePodDeregistrationDrain: true},
+			pod:    buildPod([]corev1.PodConditionType{"target-health.elbv2.k8s.aws/tgb-instance"}, containersReadyCond),
+		},
+		{
+			name:   "pod with containers not ready",
+			config: inject.Config{EnablePodDeregistrationDrain: true},
+			pod:    buildPod(readinessGates, healthyCond),
+		},
+		{
+			name:          "first deletion attempt requests deregistration",
+			config:        inject.Config{EnablePodDeregistrationDrain: true, PodDeregistrationDrainMaxWait: 10 * time.Minute},
+			pod:           buildPod(readinessGates, containersReadyCond, healthyCond),
+			wantErr:       deregistrationErr,
+			wantPodReason: "DeregistrationRequested",
+		},
+		{
+			name:    "first deletion attempt with dryRun",
+			config:  inject.Config{EnablePodDeregistrationDrain: true, PodDeregistrationDrainMaxWait: 10 * time.Minute},
+			pod:     buildPod(readinessGates, containersReadyCond, healthyCond),
+			req:     admissionv1beta1.AdmissionRequest{DryRun: awssdk.Bool(true)},
+			wantErr: deregistrationErr,
+		},
+		{
+			name:   "force deletion",
+			config: inject.Config{EnablePodDeregistrationDrain: true, PodDeregistrationDrainMaxWait: 10 * time.Minute},
+			pod:    buildPod(readinessGates, containersReadyCond, healthyCond),
+			req: admissionv1beta1.AdmissionRequest{
+				Options: runtime.RawExtension{Raw: []byte(`{"kind":"DeleteOptions","apiVersion":"meta.k8s.io/v1","gracePeriodSeconds":0}`)},
+			},
+		},
+		{
+			name:          "targets are draining",
+			config:        inject.Config{EnablePodDeregistrationDrain: true, PodDeregistrationDrainMaxWait: 10 * time.Minute},
+			pod:           buildPod(readinessGates, containersReadyCond, buildDeregistrationCond("Target.DeregistrationInProgress", time.Now())),
+			wantErr:       deregistrationErr,
+			wantPodReason: "Target.DeregistrationInProgress",
+		},
+		{
+			name:          "targets are draining longer than max wait",
+			config:        inject.Config{EnablePodDeregistrationDrain: true, PodDeregistrationDrainMaxWait: 10 * time.Minute},
+			pod:           buildPod(readinessGates, containersReadyCond, buildDeregistrationCond("Target.DeregistrationInProgress", time.Now().Add(-11*time.Minute))),
+			wantPodReason: "Target.DeregistrationInProgress",
+		},
+		{
+			name:          "targets are draining on draining node",
+			config:        inject.Config{EnablePodDeregistrationDrain: true, PodDeregistrationDrainMaxWait: 10 * time.Minute},
+			pod:           onNode(buildPod(readinessGates, containersReadyCond, buildDeregistrationCond("Target.DeregistrationInProgress", time.Now())), "draining-node"),
+			wantPodReason: "Target.DeregistrationInProgress",
+		},
+		{
+			name:          "targets are draining on node that isn't draining",
+			config:        inject.Config{EnablePodDeregistrationDrain: true, PodDeregistrationDrainMaxWait: 10 * time.Minute},
+			pod:           onNode(buildPod(readinessGates, containersReadyCond, buildDeregistrationCond("Target.DeregistrationInProgress", time.Now())), "node-not-exists"),
+			wantErr:       deregistrationErr,
+			wantPodReason: "Target.DeregistrationInProgress",
+		},
+		{
+			name:          "targets are deregistered",
+			config:        inject.Config{EnablePodDeregistrationDrain: true, PodDeregistrationDrainMaxWait: 10 * time.Minute},
+			pod:           buildPod(readinessGates, containersReadyCond, buildDeregistrationCond("TargetDeregistered", time.Now())),
+			wantPodReason: "TargetDeregistered",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			ctx := context.Background()
+			assert.NoError(t, k8sClient.Create(ctx, tgbIP.DeepCopy()))
+			assert.NoError(t, k8sClient.Create(ctx, tgbInstance.DeepCopy()))
+			assert.NoError(t, k8sClient.Create(ctx, tt.pod.DeepCopy()))
+			assert.NoError(t, k8sClient.Create(ctx, drainingNode.DeepCopy()))
+
+			v := NewPodValidator(k8sClient, tt.config, &log.NullLogger{})
+			ctx = webhook.ContextWithAdmissionRequest(ctx, admission.Request{AdmissionRequest: tt.req})
+			err := v.ValidateDelete(ctx, tt.pod.DeepCopy())
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			updatedPod := &corev1.Pod{}
+			assert.NoError(t, k8sClient.Get(ctx, k8s.NamespacedName(tt.pod), updatedPod))
+			var gotPodReason string
+			if cond := k8s.GetPodCondition(updatedPod, "target-health.elbv2.k8s.aws/tgb-ip"); cond != nil {
+				gotPodReason = cond.Reason
+			}
+			assert.Equal(t, tt.wantPodReason, gotPodReason)
+			assert.Nil(t, k8s.GetPodCondition(updatedPod, "target-health.elbv2.k8s.aws/tgb-instance"))
+		})
+	}
+}
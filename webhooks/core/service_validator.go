@@ -0,0 +1,163 @@
+package core
+
+import (
+	"context"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/quota"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/ingress"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/service"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/webhook"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	apiPathValidateService = "/validate-v1-service"
+	// the load balancer type of Services managed by controller.
+	loadBalancerTypeNLBIP = "nlb-ip"
+)
+
+// NewServiceValidator returns a validator for Service.
+func NewServiceValidator(k8sClient client.Client, logger logr.Logger) *serviceValidator {
+	annotationParser := annotations.NewSuffixAnnotationParser(annotations.AnnotationPrefixService)
+	// Services are grouped regardless of how namespaces are sharded between controllers.
+	groupLoader := service.NewDefaultGroupLoader(k8sClient, annotationParser, k8s.NewDefaultNamespaceMatcher(k8sClient, labels.Everything()))
+	return &serviceValidator{
+		k8sClient:                  k8sClient,
+		annotationParser:           annotationParser,
+		groupLoader:                groupLoader,
+		serviceAnnotationValidator: service.NewAnnotationValidator(),
+		ingressAnnotationValidator: ingress.NewAnnotationValidator(),
+		logger:                     logger,
+	}
+}
+
+var _ webhook.Validator = &serviceValidator{}
+
+type serviceValidator struct {
+	k8sClient                  client.Client
+	annotationParser           annotations.Parser
+	groupLoader                service.GroupLoader
+	serviceAnnotationValidator annotations.Validator
+	ingressAnnotationValidator annotations.Validator
+	logger                     logr.Logger
+}
+
+func (v *serviceValidator) Prototype(_ admission.Request) (runtime.Object, error) {
+	return &corev1.Service{}, nil
+}
+
+func (v *serviceValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	svc := obj.(*corev1.Service)
+	if err := v.checkAnnotations(svc, svc.Annotations); err != nil {
+		return err
+	}
+	return v.checkNamespaceQuota(ctx, svc)
+}
+
+func (v *serviceValidator) ValidateUpdate(ctx context.Context, obj runtime.Object, oldObj runtime.Object) error {
+	svc := obj.(*corev1.Service)
+	oldSvc := oldObj.(*corev1.Service)
+	// Service being deleted only gets its finalizers removed, which shouldn't be blocked.
+	if !svc.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	if err := v.checkAnnotations(svc, annotations.ChangedAnnotations(svc.Annotations, oldSvc.Annotations)); err != nil {
+		return err
+	}
+	return v.checkNamespaceQuotaOnGroupChange(ctx, svc, oldSvc)
+}
+
+func (v *serviceValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+// checkAnnotations will check the annotations to validate on Service have values of their expected formats.
+// Ingress annotations are checked on any Service, since they're only meaningful to backend services of Ingresses,
+// while Service annotations are only checked on Services managed by controller, since other load balancer implementations share them.
+func (v *serviceValidator) checkAnnotations(svc *corev1.Service, annotationsToValidate map[string]string) error {
+	fldPath := field.NewPath("metadata", "annotations")
+	allErrs := v.ingressAnnotationValidator.Validate(annotationsToValidate, fldPath)
+	if v.isServiceManaged(svc) {
+		allErrs = append(allErrs, v.serviceAnnotationValidator.Validate(annotationsToValidate, fldPath)...)
+	}
+	if len(allErrs) != 0 {
+		return apierrors.NewInvalid(corev1.SchemeGroupVersion.WithKind("Service").GroupKind(), svc.Name, allErrs)
+	}
+	return nil
+}
+
+// checkNamespaceQuota checks the NLBs in namespace of Service don't exceed the limit of ResourceQuotaPolicies.
+// only Services joining a ServiceGroup without other members in namespace are checked, since the NLB of that ServiceGroup becomes charged to namespace.
+func (v *serviceValidator) checkNamespaceQuota(ctx context.Context, svc *corev1.Service) error {
+	groupID, err := v.groupLoader.FindGroupID(ctx, svc)
+	if err != nil || groupID == nil {
+		return nil
+	}
+	// Objects in namespace are only listed if namespace has a limit on NLBs, which is resolved from cache.
+	limits, err := quota.ResolveNamespaceLimits(ctx, v.k8sClient, svc.Namespace)
+	if err != nil {
+		return err
+	}
+	if _, limited := limits[quota.NamespaceResourceNetworkLoadBalancers]; !limited {
+		return nil
+	}
+	svcList := &corev1.ServiceList{}
+	if err := v.k8sClient.List(ctx, svcList, client.InNamespace(svc.Namespace)); err != nil {
+		return err
+	}
+	namespaceGroupIDs := sets.NewString()
+	for i := range svcList.Items {
+		member := &svcList.Items[i]
+		if member.Name == svc.Name || !member.DeletionTimestamp.IsZero() {
+			continue
+		}
+		memberGroupID, err := v.groupLoader.FindGroupID(ctx, member)
+		if err != nil || memberGroupID == nil {
+			continue
+		}
+		namespaceGroupIDs.Insert(memberGroupID.String())
+	}
+	if namespaceGroupIDs.Has(groupID.String()) {
+		return nil
+	}
+	return limits.Check(svc.Namespace, quota.NamespaceResourceNetworkLoadBalancers, int64(namespaceGroupIDs.Len()+1))
+}
+
+// checkNamespaceQuotaOnGroupChange checks the namespace quota of Service if it moves to another ServiceGroup,
+// so that Services in namespaces already beyond their limit can still be updated.
+func (v *serviceValidator) checkNamespaceQuotaOnGroupChange(ctx context.Context, svc *corev1.Service, oldSvc *corev1.Service) error {
+	groupID, err := v.groupLoader.FindGroupID(ctx, svc)
+	if err != nil {
+		return nil
+	}
+	oldGroupID, err := v.groupLoader.FindGroupID(ctx, oldSvc)
+	if err != nil {
+		return nil
+	}
+	if groupID == nil || (oldGroupID != nil && *oldGroupID == *groupID) {
+		return nil
+	}
+	return v.checkNamespaceQuota(ctx, svc)
+}
+
+func (v *serviceValidator) isServiceManaged(svc *corev1.Service) bool {
+	lbType := ""
+	_ = v.annotationParser.ParseStringAnnotation(annotations.SvcLBSuffixLoadBalancerType, &lbType, svc.Annotations)
+	return lbType == loadBalancerTypeNLBIP
+}
+
+// +kubebuilder:webhook:path=/validate-v1-service,mutating=false,failurePolicy=ignore,groups="",resources=services,verbs=create;update,versions=v1,name=vservice.elbv2.k8s.aws,sideEffects=None,webhookVersions=v1beta1
+
+func (v *serviceValidator) SetupWithManager(mgr ctrl.Manager) {
+	mgr.GetWebhookServer().Register(apiPathValidateService, webhook.ValidatingWebhookForValidator(v))
+}
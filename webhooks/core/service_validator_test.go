@@ -0,0 +1,221 @@
+package core
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_serviceValidator_ValidateCreate(t *testing.T) {
+	nlbQuotaPolicy := &elbv2api.ResourceQuotaPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenants"},
+		Spec: elbv2api.ResourceQuotaPolicySpec{
+			Hard: elbv2api.ResourceQuotaLimits{
+				NetworkLoadBalancers: awssdk.Int64(1),
+			},
+		},
+	}
+	existingNLBSvc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "existing-svc",
+			Annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-type":       "nlb-ip",
+				"service.beta.kubernetes.io/aws-load-balancer-group-name": "awesome-group",
+			},
+		},
+	}
+	tests := []struct {
+		name         string
+		policies     []*elbv2api.ResourceQuotaPolicy
+		existingSvcs []*corev1.Service
+		svc          *corev1.Service
+		wantErr      error
+	}{
+		{
+			name: "managed service with valid annotations",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "awesome-svc",
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type":                     "nlb-ip",
+						"service.beta.kubernetes.io/load-balancer-source-ranges":                "10.0.0.0/16, 2001:db8::/32",
+						"service.beta.kubernetes.io/aws-load-balancer-additional-resource-tags": "env=dev,team=awesome",
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-protocol":     "http",
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-port":         "traffic-port",
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-interval":     "10",
+						"service.beta.kubernetes.io/aws-load-balancer-target-group-attributes":  "deregistration_delay.timeout_seconds=120",
+						"alb.ingress.kubernetes.io/healthcheck-path":                            "/healthz",
+					},
+				},
+			},
+		},
+		{
+			name: "managed service with invalid annotations",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "awesome-svc",
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type":                     "nlb-ip",
+						"service.beta.kubernetes.io/load-balancer-source-ranges":                "10.0.0.0/33",
+						"service.beta.kubernetes.io/aws-load-balancer-additional-resource-tags": "env",
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-port":         "health",
+					},
+				},
+			},
+			wantErr: errors.New(`Service "awesome-svc" is invalid: [` +
+				`metadata.annotations[service.beta.kubernetes.io/aws-load-balancer-additional-resource-tags]: Invalid value: "env": must be comma separated key=value pairs: expect key=value pair: env, ` +
+				`metadata.annotations[service.beta.kubernetes.io/aws-load-balancer-healthcheck-port]: Invalid value: "health": must be traffic-port or an integer within [1, 65535], ` +
+				`metadata.annotations[service.beta.kubernetes.io/load-balancer-source-ranges]: Invalid value: "10.0.0.0/33": item 0 "10.0.0.0/33" must be a valid IPv4 or IPv6 CIDR like 10.0.0.0/16]`),
+		},
+		{
+			name: "unmanaged service with service annotations of other implementations",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "awesome-svc",
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type":             "nlb",
+						"service.beta.kubernetes.io/aws-load-balancer-healthcheck-port": "health",
+					},
+				},
+			},
+		},
+		{
+			name: "unmanaged service with invalid ingress annotations",
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "awesome-svc",
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/healthcheck-interval-seconds": "1",
+					},
+				},
+			},
+			wantErr: errors.New(`Service "awesome-svc" is invalid: metadata.annotations[alb.ingress.kubernetes.io/healthcheck-interval-seconds]: Invalid value: "1": must be an integer within [5, 300]`),
+		},
+		{
+			name:         "managed service joining existing group within namespace quota",
+			policies:     []*elbv2api.ResourceQuotaPolicy{nlbQuotaPolicy},
+			existingSvcs: []*corev1.Service{existingNLBSvc},
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "awesome-svc",
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type":       "nlb-ip",
+						"service.beta.kubernetes.io/aws-load-balancer-group-name": "awesome-group",
+					},
+				},
+			},
+		},
+		{
+			name:         "managed service exceeding namespace quota",
+			policies:     []*elbv2api.ResourceQuotaPolicy{nlbQuotaPolicy},
+			existingSvcs: []*corev1.Service{existingNLBSvc},
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "awesome-svc",
+					Annotations: map[string]string{
+						"service.beta.kubernetes.io/aws-load-balancer-type": "nlb-ip",
+					},
+				},
+			},
+			wantErr: errors.New("ResourceQuotaPolicy tenants exceeded for networkLoadBalancers in namespace awesome-ns: 2 desired, limit 1"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			assert.NoError(t, k8sClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "awesome-ns"}}))
+			for _, policy := range tt.policies {
+				assert.NoError(t, k8sClient.Create(ctx, policy.DeepCopy()))
+			}
+			for _, svc := range tt.existingSvcs {
+				assert.NoError(t, k8sClient.Create(ctx, svc.DeepCopy()))
+			}
+			v := NewServiceValidator(k8sClient, &log.NullLogger{})
+			err := v.ValidateCreate(ctx, tt.svc)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_serviceValidator_ValidateUpdate(t *testing.T) {
+	buildService := func(annotations map[string]string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "awesome-ns",
+				Name:        "awesome-svc",
+				Annotations: annotations,
+			},
+		}
+	}
+	tests := []struct {
+		name    string
+		svc     *corev1.Service
+		oldSvc  *corev1.Service
+		wantErr error
+	}{
+		{
+			name: "invalid annotation unchanged",
+			svc: buildService(map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-type":                     "nlb-ip",
+				"service.beta.kubernetes.io/aws-load-balancer-additional-resource-tags": "env",
+				"service.beta.kubernetes.io/aws-load-balancer-healthcheck-interval":     "10",
+			}),
+			oldSvc: buildService(map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-type":                     "nlb-ip",
+				"service.beta.kubernetes.io/aws-load-balancer-additional-resource-tags": "env",
+			}),
+		},
+		{
+			name: "invalid annotation changed",
+			svc: buildService(map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-type":                     "nlb-ip",
+				"service.beta.kubernetes.io/aws-load-balancer-additional-resource-tags": "team",
+			}),
+			oldSvc: buildService(map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-type":                     "nlb-ip",
+				"service.beta.kubernetes.io/aws-load-balancer-additional-resource-tags": "env",
+			}),
+			wantErr: errors.New(`Service "awesome-svc" is invalid: metadata.annotations[service.beta.kubernetes.io/aws-load-balancer-additional-resource-tags]: Invalid value: "team": must be comma separated key=value pairs: expect key=value pair: team`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			v := NewServiceValidator(k8sClient, &log.NullLogger{})
+			err := v.ValidateUpdate(ctx, tt.svc, tt.oldSvc)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
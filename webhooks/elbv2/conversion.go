@@ -0,0 +1,15 @@
+package elbv2
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+)
+
+const apiPathConvert = "/convert"
+
+// SetupConversionWithManager registers the conversion webhook for CRDs with multiple served versions.
+// TargetGroupBinding is converted between v1alpha1, v1beta1 and v1beta2 with v1beta1 as the hub,
+// and admission webhooks match v1beta2 requests by their equivalent v1beta1 objects.
+func SetupConversionWithManager(mgr ctrl.Manager) {
+	mgr.GetWebhookServer().Register(apiPathConvert, &conversion.Webhook{})
+}
@@ -0,0 +1,130 @@
+package elbv2
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	networking "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/webhook"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	apiPathValidateELBv2IngressClassParams = "/validate-elbv2-k8s-aws-v1beta1-ingressclassparams"
+	// the Kind of IngressClassParams referenced by IngressClass's parameters.
+	ingressClassParamsKind = "IngressClassParams"
+)
+
+// NewIngressClassParamsValidator returns a validator for IngressClassParams CRD.
+func NewIngressClassParamsValidator(k8sClient client.Client, logger logr.Logger) *ingressClassParamsValidator {
+	return &ingressClassParamsValidator{
+		k8sClient: k8sClient,
+		logger:    logger,
+	}
+}
+
+var _ webhook.Validator = &ingressClassParamsValidator{}
+
+type ingressClassParamsValidator struct {
+	k8sClient client.Client
+	logger    logr.Logger
+}
+
+func (v *ingressClassParamsValidator) Prototype(_ admission.Request) (runtime.Object, error) {
+	return &elbv2api.IngressClassParams{}, nil
+}
+
+func (v *ingressClassParamsValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	ingClassParams := obj.(*elbv2api.IngressClassParams)
+	if err := v.checkFeatureGates(ingClassParams); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *ingressClassParamsValidator) ValidateUpdate(ctx context.Context, obj runtime.Object, oldObj runtime.Object) error {
+	ingClassParams := obj.(*elbv2api.IngressClassParams)
+	oldIngClassParams := oldObj.(*elbv2api.IngressClassParams)
+	if err := v.checkFeatureGates(ingClassParams); err != nil {
+		return err
+	}
+	if err := v.checkRegionChange(ctx, ingClassParams, oldIngClassParams); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *ingressClassParamsValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+// checkFeatureGates checks the featureGates only contain known features that can be overridden per IngressClass.
+func (v *ingressClassParamsValidator) checkFeatureGates(ingClassParams *elbv2api.IngressClassParams) error {
+	if _, err := config.BuildIngressClassFeatureGates(ingClassParams.Spec.FeatureGates); err != nil {
+		return errors.Wrap(err, "invalid featureGates")
+	}
+	return nil
+}
+
+// checkRegionChange checks the region and vpcID aren't changed while IngressClassParams is used by any Ingress,
+// since the AWS resources provisioned in the previous region are only cleaned up in the region of IngressClassParams.
+func (v *ingressClassParamsValidator) checkRegionChange(ctx context.Context, ingClassParams *elbv2api.IngressClassParams, oldIngClassParams *elbv2api.IngressClassParams) error {
+	if awssdk.StringValue(ingClassParams.Spec.Region) == awssdk.StringValue(oldIngClassParams.Spec.Region) &&
+		awssdk.StringValue(ingClassParams.Spec.VpcID) == awssdk.StringValue(oldIngClassParams.Spec.VpcID) {
+		return nil
+	}
+	ing, err := v.findIngressUsingParams(ctx, ingClassParams.Name)
+	if err != nil {
+		return err
+	}
+	if ing != nil {
+		return errors.Errorf("IngressClassParams region and vpcID cannot be changed while used by Ingress %v", k8s.NamespacedName(ing))
+	}
+	return nil
+}
+
+// findIngressUsingParams returns an Ingress that belongs to IngressClass with IngressClassParams of paramsName, it returns nil if there is none.
+// Ingresses being deleted are considered as well, since their AWS resources are yet to be cleaned up.
+func (v *ingressClassParamsValidator) findIngressUsingParams(ctx context.Context, paramsName string) (*networking.Ingress, error) {
+	ingClassList := &networking.IngressClassList{}
+	if err := v.k8sClient.List(ctx, ingClassList); err != nil {
+		return nil, err
+	}
+	ingClassNames := sets.NewString()
+	for _, ingClass := range ingClassList.Items {
+		params := ingClass.Spec.Parameters
+		if params == nil || params.APIGroup == nil || *params.APIGroup != elbv2api.GroupVersion.Group ||
+			params.Kind != ingressClassParamsKind || params.Name != paramsName {
+			continue
+		}
+		ingClassNames.Insert(ingClass.Name)
+	}
+	if len(ingClassNames) == 0 {
+		return nil, nil
+	}
+	ingList := &networking.IngressList{}
+	if err := v.k8sClient.List(ctx, ingList); err != nil {
+		return nil, err
+	}
+	for i := range ingList.Items {
+		ing := &ingList.Items[i]
+		if ing.Spec.IngressClassName != nil && ingClassNames.Has(*ing.Spec.IngressClassName) {
+			return ing, nil
+		}
+	}
+	return nil, nil
+}
+
+// +kubebuilder:webhook:path=/validate-elbv2-k8s-aws-v1beta1-ingressclassparams,mutating=false,failurePolicy=fail,groups=elbv2.k8s.aws,resources=ingressclassparams,verbs=create;update,versions=v1beta1,name=vingressclassparams.elbv2.k8s.aws,sideEffects=None,webhookVersions=v1beta1
+
+func (v *ingressClassParamsValidator) SetupWithManager(mgr ctrl.Manager) {
+	mgr.GetWebhookServer().Register(apiPathValidateELBv2IngressClassParams, webhook.ValidatingWebhookForValidator(v))
+}
@@ -0,0 +1,150 @@
+package elbv2
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_ingressClassParamsValidator_ValidateCreate(t *testing.T) {
+	tests := []struct {
+		name         string
+		featureGates map[string]bool
+		wantErr      string
+	}{
+		{
+			name: "no featureGates",
+		},
+		{
+			name:         "featureGates that apply per IngressClass",
+			featureGates: map[string]bool{"RuleSplitting": false},
+		},
+		{
+			name:         "unknown feature",
+			featureGates: map[string]bool{"RuleSpliting": false},
+			wantErr:      "invalid featureGates: unknown feature: RuleSpliting",
+		},
+		{
+			name:         "feature that doesn't apply per IngressClass",
+			featureGates: map[string]bool{"NLBSecurityGroup": false},
+			wantErr:      "invalid featureGates: feature cannot be overridden per IngressClass: NLBSecurityGroup",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := &elbv2api.IngressClassParams{
+				ObjectMeta: metav1.ObjectMeta{Name: "awesome-params"},
+				Spec: elbv2api.IngressClassParamsSpec{
+					FeatureGates: tt.featureGates,
+				},
+			}
+			v := NewIngressClassParamsValidator(nil, &log.NullLogger{})
+			err := v.ValidateCreate(context.Background(), params)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_ingressClassParamsValidator_ValidateUpdate(t *testing.T) {
+	ingClass := &networking.IngressClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "alb-eu"},
+		Spec: networking.IngressClassSpec{
+			Controller: "ingress.k8s.aws/alb",
+			Parameters: &corev1.TypedLocalObjectReference{
+				APIGroup: awssdk.String("elbv2.k8s.aws"),
+				Kind:     "IngressClassParams",
+				Name:     "params-eu",
+			},
+		},
+	}
+	ing := &networking.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "awesome-ing"},
+		Spec: networking.IngressSpec{
+			IngressClassName: awssdk.String("alb-eu"),
+		},
+	}
+	oldParams := &elbv2api.IngressClassParams{
+		ObjectMeta: metav1.ObjectMeta{Name: "params-eu"},
+		Spec: elbv2api.IngressClassParamsSpec{
+			Region: awssdk.String("eu-west-1"),
+			VpcID:  awssdk.String("vpc-0123456789abcdef0"),
+		},
+	}
+	tests := []struct {
+		name       string
+		ingClasses []*networking.IngressClass
+		ings       []*networking.Ingress
+		params     *elbv2api.IngressClassParams
+		wantErr    string
+	}{
+		{
+			name:       "region unchanged",
+			ingClasses: []*networking.IngressClass{ingClass},
+			ings:       []*networking.Ingress{ing},
+			params: &elbv2api.IngressClassParams{
+				ObjectMeta: metav1.ObjectMeta{Name: "params-eu"},
+				Spec: elbv2api.IngressClassParamsSpec{
+					Region: awssdk.String("eu-west-1"),
+					VpcID:  awssdk.String("vpc-0123456789abcdef0"),
+					Subnets: &elbv2api.SubnetSelector{
+						Tags: map[string][]string{"tier": {"public"}},
+					},
+				},
+			},
+		},
+		{
+			name:       "region changed while used by Ingress",
+			ingClasses: []*networking.IngressClass{ingClass},
+			ings:       []*networking.Ingress{ing},
+			params: &elbv2api.IngressClassParams{
+				ObjectMeta: metav1.ObjectMeta{Name: "params-eu"},
+				Spec: elbv2api.IngressClassParamsSpec{
+					Region: awssdk.String("eu-central-1"),
+					VpcID:  awssdk.String("vpc-0123456789abcdef1"),
+				},
+			},
+			wantErr: "IngressClassParams region and vpcID cannot be changed while used by Ingress awesome-ns/awesome-ing",
+		},
+		{
+			name:       "region changed without Ingress",
+			ingClasses: []*networking.IngressClass{ingClass},
+			params: &elbv2api.IngressClassParams{
+				ObjectMeta: metav1.ObjectMeta{Name: "params-eu"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			for _, ingClass := range tt.ingClasses {
+				assert.NoError(t, k8sClient.Create(context.Background(), ingClass.DeepCopy()))
+			}
+			for _, ing := range tt.ings {
+				assert.NoError(t, k8sClient.Create(context.Background(), ing.DeepCopy()))
+			}
+			v := NewIngressClassParamsValidator(k8sClient, &log.NullLogger{})
+			err := v.ValidateUpdate(context.Background(), tt.params, oldParams)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
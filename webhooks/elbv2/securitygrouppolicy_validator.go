@@ -0,0 +1,51 @@
+package elbv2
+
+import (
+	"context"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/securitygrouppolicy"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/webhook"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const apiPathValidateELBv2SecurityGroupPolicy = "/validate-elbv2-k8s-aws-v1beta1-securitygrouppolicy"
+
+// NewSecurityGroupPolicyValidator returns a validator for SecurityGroupPolicy CRD.
+func NewSecurityGroupPolicyValidator(logger logr.Logger) *securityGroupPolicyValidator {
+	return &securityGroupPolicyValidator{
+		logger: logger,
+	}
+}
+
+var _ webhook.Validator = &securityGroupPolicyValidator{}
+
+type securityGroupPolicyValidator struct {
+	logger logr.Logger
+}
+
+func (v *securityGroupPolicyValidator) Prototype(_ admission.Request) (runtime.Object, error) {
+	return &elbv2api.SecurityGroupPolicy{}, nil
+}
+
+func (v *securityGroupPolicyValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	policy := obj.(*elbv2api.SecurityGroupPolicy)
+	return securitygrouppolicy.ValidatePolicy(policy)
+}
+
+func (v *securityGroupPolicyValidator) ValidateUpdate(ctx context.Context, obj runtime.Object, oldObj runtime.Object) error {
+	policy := obj.(*elbv2api.SecurityGroupPolicy)
+	return securitygrouppolicy.ValidatePolicy(policy)
+}
+
+func (v *securityGroupPolicyValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-elbv2-k8s-aws-v1beta1-securitygrouppolicy,mutating=false,failurePolicy=fail,groups=elbv2.k8s.aws,resources=securitygrouppolicies,verbs=create;update,versions=v1beta1,name=vsecuritygrouppolicy.elbv2.k8s.aws,sideEffects=None,webhookVersions=v1beta1
+
+func (v *securityGroupPolicyValidator) SetupWithManager(mgr ctrl.Manager) {
+	mgr.GetWebhookServer().Register(apiPathValidateELBv2SecurityGroupPolicy, webhook.ValidatingWebhookForValidator(v))
+}
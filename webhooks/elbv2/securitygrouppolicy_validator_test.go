@@ -0,0 +1,56 @@
+package elbv2
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_securityGroupPolicyValidator_ValidateCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		peer    elbv2api.SecurityGroupPolicyPeer
+		wantErr string
+	}{
+		{
+			name: "valid policy",
+			peer: elbv2api.SecurityGroupPolicyPeer{IPBlock: &elbv2api.IPBlock{CIDR: "10.0.0.0/16"}},
+		},
+		{
+			name:    "invalid CIDR",
+			peer:    elbv2api.SecurityGroupPolicyPeer{IPBlock: &elbv2api.IPBlock{CIDR: "10.0.0.300/16"}},
+			wantErr: "invalid CIDR: 10.0.0.300/16",
+		},
+		{
+			name: "multiple sources in peer",
+			peer: elbv2api.SecurityGroupPolicyPeer{
+				IPBlock:    &elbv2api.IPBlock{CIDR: "10.0.0.0/16"},
+				PrefixList: &elbv2api.PrefixList{PrefixListID: "pl-abcdefg"},
+			},
+			wantErr: "exactly one of ipBlock, securityGroup and prefixList must be specified for peer",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &elbv2api.SecurityGroupPolicy{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "policy-a"},
+				Spec: elbv2api.SecurityGroupPolicySpec{
+					TargetRef: elbv2api.SecurityGroupPolicyTargetReference{Kind: elbv2api.SecurityGroupPolicyTargetKindIngress, Name: "ing-1"},
+					Ingress: []elbv2api.SecurityGroupPolicyIngressRule{
+						{From: []elbv2api.SecurityGroupPolicyPeer{tt.peer}},
+					},
+				},
+			}
+			v := NewSecurityGroupPolicyValidator(&log.NullLogger{})
+			err := v.ValidateCreate(context.Background(), policy)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -40,6 +40,9 @@ func (m *targetGroupBindingMutator) MutateCreate(ctx context.Context, obj runtim
 	if err := m.defaultingTargetType(ctx, tgb); err != nil {
 		return nil, err
 	}
+	if err := m.defaultingIPAddressType(ctx, tgb); err != nil {
+		return nil, err
+	}
 	return tgb, nil
 }
 
@@ -62,6 +65,8 @@ func (m *targetGroupBindingMutator) defaultingTargetType(ctx context.Context, tg
 		targetType = elbv2api.TargetTypeInstance
 	case elbv2sdk.TargetTypeEnumIp:
 		targetType = elbv2api.TargetTypeIP
+	case elbv2sdk.TargetTypeEnumLambda:
+		targetType = elbv2api.TargetTypeLambda
 	default:
 		return errors.Errorf("unsupported TargetType: %v", sdkTargetType)
 	}
@@ -70,20 +75,65 @@ func (m *targetGroupBindingMutator) defaultingTargetType(ctx context.Context, tg
 	return nil
 }
 
+// defaultingIPAddressType infers the IPAddressType for ip TargetType, since it decides the family of pod addresses to register.
+func (m *targetGroupBindingMutator) defaultingIPAddressType(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
+	if tgb.Spec.IPAddressType != nil {
+		return nil
+	}
+	if tgb.Spec.TargetType == nil || (*tgb.Spec.TargetType) != elbv2api.TargetTypeIP {
+		return nil
+	}
+	tgARN := tgb.Spec.TargetGroupARN
+	sdkIPAddressType, err := m.obtainSDKIPAddressTypeFromAWS(ctx, tgARN)
+	if err != nil {
+		return errors.Wrap(err, "couldn't determine IPAddressType")
+	}
+	var ipAddressType elbv2api.TargetGroupIPAddressType
+	switch sdkIPAddressType {
+	case "", elbv2sdk.TargetGroupIpAddressTypeEnumIpv4:
+		ipAddressType = elbv2api.TargetGroupIPAddressTypeIPv4
+	case elbv2sdk.TargetGroupIpAddressTypeEnumIpv6:
+		ipAddressType = elbv2api.TargetGroupIPAddressTypeIPv6
+	default:
+		return errors.Errorf("unsupported IPAddressType: %v", sdkIPAddressType)
+	}
+
+	tgb.Spec.IPAddressType = &ipAddressType
+	return nil
+}
+
 func (m *targetGroupBindingMutator) obtainSDKTargetTypeFromAWS(ctx context.Context, tgARN string) (string, error) {
+	tg, err := m.describeSDKTargetGroup(ctx, tgARN)
+	if err != nil {
+		return "", err
+	}
+	return awssdk.StringValue(tg.TargetType), nil
+}
+
+func (m *targetGroupBindingMutator) obtainSDKIPAddressTypeFromAWS(ctx context.Context, tgARN string) (string, error) {
+	tg, err := m.describeSDKTargetGroup(ctx, tgARN)
+	if err != nil {
+		return "", err
+	}
+	return awssdk.StringValue(tg.IpAddressType), nil
+}
+
+func (m *targetGroupBindingMutator) describeSDKTargetGroup(ctx context.Context, tgARN string) (*elbv2sdk.TargetGroup, error) {
 	req := &elbv2sdk.DescribeTargetGroupsInput{
 		TargetGroupArns: awssdk.StringSlice([]string{tgARN}),
 	}
 	tgList, err := m.elbv2Client.DescribeTargetGroupsAsList(ctx, req)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	if len(tgList) != 1 {
-		return "", errors.Errorf("expecting a single targetGroup but got %v", len(tgList))
+		return nil, errors.Errorf("expecting a single targetGroup but got %v", len(tgList))
 	}
-	return awssdk.StringValue(tgList[0].TargetType), nil
+	return tgList[0], nil
 }
 
+// the webhook is registered with matchPolicy Equivalent by config/webhook/targetgroupbinding_mutator_patch.yaml,
+// so that TargetGroupBindings written as elbv2.k8s.aws/v1beta2 are defaulted as well.
 // +kubebuilder:webhook:path=/mutate-elbv2-k8s-aws-v1beta1-targetgroupbinding,mutating=true,failurePolicy=fail,groups=elbv2.k8s.aws,resources=targetgroupbindings,verbs=create;update,versions=v1beta1,name=mtargetgroupbinding.elbv2.k8s.aws,sideEffects=None,webhookVersions=v1beta1
 
 func (m *targetGroupBindingMutator) SetupWithManager(mgr ctrl.Manager) {
@@ -26,6 +26,9 @@ func Test_targetGroupBindingMutator_MutateCreate(t *testing.T) {
 
 	instanceTargetType := elbv2api.TargetTypeInstance
 	ipTargetType := elbv2api.TargetTypeIP
+	lambdaTargetType := elbv2api.TargetTypeLambda
+	ipv4AddressType := elbv2api.TargetGroupIPAddressTypeIPv4
+	ipv6AddressType := elbv2api.TargetGroupIPAddressTypeIPv6
 	type args struct {
 		obj *elbv2api.TargetGroupBinding
 	}
@@ -103,6 +106,17 @@ func Test_targetGroupBindingMutator_MutateCreate(t *testing.T) {
 							},
 						},
 					},
+					{
+						req: &elbv2sdk.DescribeTargetGroupsInput{
+							TargetGroupArns: awssdk.StringSlice([]string{"tg-1"}),
+						},
+						resp: []*elbv2sdk.TargetGroup{
+							{
+								TargetGroupArn: awssdk.String("tg-1"),
+								TargetType:     awssdk.String("ip"),
+							},
+						},
+					},
 				},
 			},
 			args: args{
@@ -117,6 +131,63 @@ func Test_targetGroupBindingMutator_MutateCreate(t *testing.T) {
 				Spec: elbv2api.TargetGroupBindingSpec{
 					TargetGroupARN: "tg-1",
 					TargetType:     &ipTargetType,
+					IPAddressType:  &ipv4AddressType,
+				},
+			},
+		},
+		{
+			name: "targetGroupBinding with IPAddressType absent will be defaulted via AWS API - ipv6",
+			fields: fields{
+				describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupsInput{
+							TargetGroupArns: awssdk.StringSlice([]string{"tg-1"}),
+						},
+						resp: []*elbv2sdk.TargetGroup{
+							{
+								TargetGroupArn: awssdk.String("tg-1"),
+								TargetType:     awssdk.String("ip"),
+								IpAddressType:  awssdk.String("ipv6"),
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				obj: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "tg-1",
+						TargetType:     &ipTargetType,
+					},
+				},
+			},
+			want: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+					TargetType:     &ipTargetType,
+					IPAddressType:  &ipv6AddressType,
+				},
+			},
+		},
+		{
+			name: "targetGroupBinding with IPAddressType already set",
+			fields: fields{
+				describeTargetGroupsAsListCalls: nil,
+			},
+			args: args{
+				obj: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "tg-1",
+						TargetType:     &ipTargetType,
+						IPAddressType:  &ipv6AddressType,
+					},
+				},
+			},
+			want: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+					TargetType:     &ipTargetType,
+					IPAddressType:  &ipv6AddressType,
 				},
 			},
 		},
@@ -145,7 +216,39 @@ func Test_targetGroupBindingMutator_MutateCreate(t *testing.T) {
 					},
 				},
 			},
-			wantErr: errors.New("unsupported TargetType: lambda"),
+			want: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+					TargetType:     &lambdaTargetType,
+				},
+			},
+		},
+		{
+			name: "targetGroupBinding with TargetType absent will be defaulted via AWS API - alb",
+			fields: fields{
+				describeTargetGroupsAsListCalls: []describeTargetGroupsAsListCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupsInput{
+							TargetGroupArns: awssdk.StringSlice([]string{"tg-1"}),
+						},
+						resp: []*elbv2sdk.TargetGroup{
+							{
+								TargetGroupArn: awssdk.String("tg-1"),
+								TargetType:     awssdk.String("alb"),
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				obj: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "tg-1",
+						TargetType:     nil,
+					},
+				},
+			},
+			wantErr: errors.New("unsupported TargetType: alb"),
 		},
 	}
 	for _, tt := range tests {
@@ -4,27 +4,45 @@ import (
 	"context"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	networking "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"regexp"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/webhook"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 	"strings"
 )
 
 const apiPathValidateELBv2TargetGroupBinding = "/validate-elbv2-k8s-aws-v1beta1-targetgroupbinding"
 
-// NewTargetGroupBindingMutator returns a mutator for TargetGroupBinding CRD.
-func NewTargetGroupBindingValidator(logger logr.Logger) *targetGroupBindingValidator {
+var vpcIDPattern = regexp.MustCompile("^vpc-[0-9a-f]{8}(?:[0-9a-f]{9})?$")
+
+var iamRoleARNPattern = regexp.MustCompile(`^arn:aws[a-z-]*:iam::\d{12}:role/[\w+=,.@/-]+$`)
+
+// NewTargetGroupBindingValidator returns a validator for TargetGroupBinding CRD.
+// IAM roles to assume are only allowed if listed in allowedIAMRoleARNs or configured on IngressClasses by cluster administrators.
+func NewTargetGroupBindingValidator(k8sClient client.Client, allowedIAMRoleARNs []string, logger logr.Logger) *targetGroupBindingValidator {
 	return &targetGroupBindingValidator{
-		logger: logger,
+		k8sClient:          k8sClient,
+		annotationParser:   annotations.NewSuffixAnnotationParser(annotations.AnnotationPrefixIngress),
+		allowedIAMRoleARNs: sets.NewString(allowedIAMRoleARNs...),
+		logger:             logger,
 	}
 }
 
 var _ webhook.Validator = &targetGroupBindingValidator{}
 
 type targetGroupBindingValidator struct {
-	logger logr.Logger
+	k8sClient          client.Client
+	annotationParser   annotations.Parser
+	allowedIAMRoleARNs sets.String
+	logger             logr.Logger
 }
 
 func (v *targetGroupBindingValidator) Prototype(_ admission.Request) (runtime.Object, error) {
@@ -36,6 +54,18 @@ func (v *targetGroupBindingValidator) ValidateCreate(ctx context.Context, obj ru
 	if err := v.checkRequiredFields(tgb); err != nil {
 		return err
 	}
+	if err := v.checkVpcID(tgb); err != nil {
+		return err
+	}
+	if err := v.checkNodeSelector(tgb); err != nil {
+		return err
+	}
+	if err := v.checkTargetGroupAttributes(tgb); err != nil {
+		return err
+	}
+	if err := v.checkIAMRoleARNToAssume(ctx, tgb); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -48,6 +78,15 @@ func (v *targetGroupBindingValidator) ValidateUpdate(ctx context.Context, obj ru
 	if err := v.checkImmutableFields(tgb, oldTgb); err != nil {
 		return err
 	}
+	if err := v.checkVpcID(tgb); err != nil {
+		return err
+	}
+	if err := v.checkNodeSelector(tgb); err != nil {
+		return err
+	}
+	if err := v.checkTargetGroupAttributes(tgb); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -62,6 +101,10 @@ func (v *targetGroupBindingValidator) checkRequiredFields(tgb *elbv2api.TargetGr
 	if tgb.Spec.TargetType == nil {
 		absentRequiredFields = append(absentRequiredFields, "spec.targetType")
 	}
+	// serviceRef is only optional for lambda TargetType, whose Lambda function is registered outside the controller.
+	if tgb.Spec.TargetType != nil && *tgb.Spec.TargetType != elbv2api.TargetTypeLambda && tgb.Spec.ServiceRef.Name == "" {
+		absentRequiredFields = append(absentRequiredFields, "spec.serviceRef")
+	}
 	if len(absentRequiredFields) != 0 {
 		return errors.Errorf("%s must specify these fields: %s", "TargetGroupBinding", strings.Join(absentRequiredFields, ","))
 	}
@@ -80,6 +123,16 @@ func (v *targetGroupBindingValidator) checkImmutableFields(tgb *elbv2api.TargetG
 	if tgb.Spec.TargetType != nil && oldTGB.Spec.TargetType != nil && (*tgb.Spec.TargetType) != (*oldTGB.Spec.TargetType) {
 		changedImmutableFields = append(changedImmutableFields, "spec.targetType")
 	}
+	// ipAddressType defaults to ipv4, TargetGroupBindings created before ipAddressType was introduced leave it unset.
+	if resolveIPAddressType(tgb) != resolveIPAddressType(oldTGB) {
+		changedImmutableFields = append(changedImmutableFields, "spec.ipAddressType")
+	}
+	if tgb.Spec.VpcID != oldTGB.Spec.VpcID {
+		changedImmutableFields = append(changedImmutableFields, "spec.vpcID")
+	}
+	if tgb.Spec.IAMRoleARNToAssume != oldTGB.Spec.IAMRoleARNToAssume {
+		changedImmutableFields = append(changedImmutableFields, "spec.iamRoleARNToAssume")
+	}
 
 	if len(changedImmutableFields) != 0 {
 		return errors.Errorf("%s update may not change these fields: %s", "TargetGroupBinding", strings.Join(changedImmutableFields, ","))
@@ -87,6 +140,80 @@ func (v *targetGroupBindingValidator) checkImmutableFields(tgb *elbv2api.TargetG
 	return nil
 }
 
+// checkIAMRoleARNToAssume will check the IAM role to assume is a valid IAM role ARN allowed by cluster administrators.
+// the controller assumes the IAM role with its own credentials, so that users cannot be allowed to pick arbitrary IAM roles.
+func (v *targetGroupBindingValidator) checkIAMRoleARNToAssume(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
+	roleARN := tgb.Spec.IAMRoleARNToAssume
+	if roleARN == "" {
+		return nil
+	}
+	if !iamRoleARNPattern.MatchString(roleARN) {
+		return errors.Errorf("invalid IAM role ARN %v for spec.iamRoleARNToAssume", roleARN)
+	}
+	if v.allowedIAMRoleARNs.Has(roleARN) {
+		return nil
+	}
+	ingClassList := &networking.IngressClassList{}
+	if err := v.k8sClient.List(ctx, ingClassList); err != nil {
+		return errors.Wrap(err, "failed to list IngressClasses")
+	}
+	for _, ingClass := range ingClassList.Items {
+		ingClassRoleARN := ""
+		_ = v.annotationParser.ParseStringAnnotation(annotations.IngressClassSuffixIAMRoleARN, &ingClassRoleARN, ingClass.Annotations)
+		if ingClassRoleARN == roleARN {
+			return nil
+		}
+	}
+	return errors.Errorf("IAM role %v for spec.iamRoleARNToAssume is not allowed, it must be allowed via --targetgroupbinding-allowed-iam-role-arns or configured on an IngressClass", roleARN)
+}
+
+// resolveIPAddressType returns the ipAddressType of tgb, which defaults to ipv4.
+func resolveIPAddressType(tgb *elbv2api.TargetGroupBinding) elbv2api.TargetGroupIPAddressType {
+	if tgb.Spec.IPAddressType == nil {
+		return elbv2api.TargetGroupIPAddressTypeIPv4
+	}
+	return *tgb.Spec.IPAddressType
+}
+
+// checkVpcID will check the vpcID is in valid format.
+func (v *targetGroupBindingValidator) checkVpcID(tgb *elbv2api.TargetGroupBinding) error {
+	if tgb.Spec.VpcID == "" {
+		return nil
+	}
+	if !vpcIDPattern.MatchString(tgb.Spec.VpcID) {
+		return errors.Errorf("%s has invalid spec.vpcID: %s", "TargetGroupBinding", tgb.Spec.VpcID)
+	}
+	return nil
+}
+
+// checkNodeSelector will check the nodeSelector is a valid label selector.
+func (v *targetGroupBindingValidator) checkNodeSelector(tgb *elbv2api.TargetGroupBinding) error {
+	if tgb.Spec.NodeSelector == nil {
+		return nil
+	}
+	if _, err := metav1.LabelSelectorAsSelector(tgb.Spec.NodeSelector); err != nil {
+		return errors.Errorf("%s has invalid spec.nodeSelector: %v", "TargetGroupBinding", err)
+	}
+	return nil
+}
+
+// checkTargetGroupAttributes will check the targetGroupAttributes are supported attributes with valid values, and each is specified only once.
+func (v *targetGroupBindingValidator) checkTargetGroupAttributes(tgb *elbv2api.TargetGroupBinding) error {
+	attrs := make(map[string]string, len(tgb.Spec.TargetGroupAttributes))
+	for _, attr := range tgb.Spec.TargetGroupAttributes {
+		if _, exists := attrs[attr.Key]; exists {
+			return errors.Errorf("%s has duplicate spec.targetGroupAttributes: %s", "TargetGroupBinding", attr.Key)
+		}
+		attrs[attr.Key] = attr.Value
+	}
+	if err := elbv2model.ValidateTargetGroupAttributes(attrs); err != nil {
+		return errors.Errorf("%s has invalid spec.targetGroupAttributes: %v", "TargetGroupBinding", err)
+	}
+	return nil
+}
+
+// the webhook is registered with matchPolicy Equivalent by config/webhook/targetgroupbinding_validator_patch.yaml,
+// so that TargetGroupBindings written as elbv2.k8s.aws/v1beta2 are validated as well.
 // +kubebuilder:webhook:path=/validate-elbv2-k8s-aws-v1beta1-targetgroupbinding,mutating=false,failurePolicy=fail,groups=elbv2.k8s.aws,resources=targetgroupbindings,verbs=create;update,versions=v1beta1,name=vtargetgroupbinding.elbv2.k8s.aws,sideEffects=None,webhookVersions=v1beta1
 
 func (v *targetGroupBindingValidator) SetupWithManager(mgr ctrl.Manager) {
@@ -4,7 +4,13 @@ import (
 	"context"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	networking "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
 )
@@ -38,6 +44,7 @@ func Test_targetGroupBindingValidator_ValidateCreate(t *testing.T) {
 					Spec: elbv2api.TargetGroupBindingSpec{
 						TargetGroupARN: "tg-2",
 						TargetType:     &instanceTargetType,
+						ServiceRef:     elbv2api.ServiceReference{Name: "svc-1", Port: intstr.FromInt(80)},
 					},
 				},
 			},
@@ -95,6 +102,7 @@ func Test_targetGroupBindingValidator_ValidateUpdate(t *testing.T) {
 					Spec: elbv2api.TargetGroupBindingSpec{
 						TargetGroupARN: "tg-2",
 						TargetType:     &instanceTargetType,
+						ServiceRef:     elbv2api.ServiceReference{Name: "svc-1", Port: intstr.FromInt(80)},
 					},
 				},
 				oldObj: &elbv2api.TargetGroupBinding{
@@ -127,6 +135,7 @@ func Test_targetGroupBindingValidator_checkRequiredFields(t *testing.T) {
 		tgb *elbv2api.TargetGroupBinding
 	}
 	instanceTargetType := elbv2api.TargetTypeInstance
+	lambdaTargetType := elbv2api.TargetTypeLambda
 	tests := []struct {
 		name    string
 		args    args
@@ -145,12 +154,37 @@ func Test_targetGroupBindingValidator_checkRequiredFields(t *testing.T) {
 			wantErr: errors.New("TargetGroupBinding must specify these fields: spec.targetType"),
 		},
 		{
-			name: "targetType is set",
+			name: "targetType and serviceRef are set",
 			args: args{
 				tgb: &elbv2api.TargetGroupBinding{
 					Spec: elbv2api.TargetGroupBindingSpec{
 						TargetGroupARN: "tg-2",
 						TargetType:     &instanceTargetType,
+						ServiceRef:     elbv2api.ServiceReference{Name: "svc-1", Port: intstr.FromInt(80)},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "serviceRef is not set for instance targetType",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "tg-2",
+						TargetType:     &instanceTargetType,
+					},
+				},
+			},
+			wantErr: errors.New("TargetGroupBinding must specify these fields: spec.serviceRef"),
+		},
+		{
+			name: "serviceRef is not set for lambda targetType",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "tg-2",
+						TargetType:     &lambdaTargetType,
 					},
 				},
 			},
@@ -179,6 +213,8 @@ func Test_targetGroupBindingValidator_checkImmutableFields(t *testing.T) {
 	}
 	instanceTargetType := elbv2api.TargetTypeInstance
 	ipTargetType := elbv2api.TargetTypeIP
+	ipv4AddressType := elbv2api.TargetGroupIPAddressTypeIPv4
+	ipv6AddressType := elbv2api.TargetGroupIPAddressTypeIPv6
 	tests := []struct {
 		name    string
 		args    args
@@ -274,6 +310,103 @@ func Test_targetGroupBindingValidator_checkImmutableFields(t *testing.T) {
 			},
 			wantErr: errors.New("TargetGroupBinding update may not change these fields: spec.targetGroupARN,spec.targetType"),
 		},
+		{
+			name: "vpcID is changed",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "tg-1",
+						TargetType:     &ipTargetType,
+						VpcID:          "vpc-0123456789abcdef0",
+					},
+				},
+				oldTGB: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "tg-1",
+						TargetType:     &ipTargetType,
+					},
+				},
+			},
+			wantErr: errors.New("TargetGroupBinding update may not change these fields: spec.vpcID"),
+		},
+		{
+			name: "ipAddressType is changed",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "tg-1",
+						TargetType:     &ipTargetType,
+						IPAddressType:  &ipv6AddressType,
+					},
+				},
+				oldTGB: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "tg-1",
+						TargetType:     &ipTargetType,
+						IPAddressType:  &ipv4AddressType,
+					},
+				},
+			},
+			wantErr: errors.New("TargetGroupBinding update may not change these fields: spec.ipAddressType"),
+		},
+		{
+			name: "ipAddressType ipv6 is removed",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "tg-1",
+						TargetType:     &ipTargetType,
+					},
+				},
+				oldTGB: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "tg-1",
+						TargetType:     &ipTargetType,
+						IPAddressType:  &ipv6AddressType,
+					},
+				},
+			},
+			wantErr: errors.New("TargetGroupBinding update may not change these fields: spec.ipAddressType"),
+		},
+		{
+			name: "ipAddressType ipv4 is set on TargetGroupBinding without ipAddressType",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "tg-1",
+						TargetType:     &ipTargetType,
+						IPAddressType:  &ipv4AddressType,
+					},
+				},
+				oldTGB: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN: "tg-1",
+						TargetType:     &ipTargetType,
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "iamRoleARNToAssume is changed",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN:     "tg-1",
+						TargetType:         &ipTargetType,
+						IAMRoleARNToAssume: "arn:aws:iam::123456789012:role/role-2",
+					},
+				},
+				oldTGB: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetGroupARN:     "tg-1",
+						TargetType:         &ipTargetType,
+						IAMRoleARNToAssume: "arn:aws:iam::123456789012:role/role-1",
+					},
+				},
+			},
+			wantErr: errors.New("TargetGroupBinding update may not change these fields: spec.iamRoleARNToAssume"),
+		},
 		{
 			name: "both targetGroupARN and targetType are not changed",
 			args: args{
@@ -307,3 +440,280 @@ func Test_targetGroupBindingValidator_checkImmutableFields(t *testing.T) {
 		})
 	}
 }
+
+func Test_targetGroupBindingValidator_checkVpcID(t *testing.T) {
+	tests := []struct {
+		name    string
+		tgb     *elbv2api.TargetGroupBinding
+		wantErr error
+	}{
+		{
+			name: "vpcID is not set",
+			tgb: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "vpcID with short format",
+			tgb: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+					VpcID:          "vpc-0123abcd",
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "vpcID with long format",
+			tgb: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+					VpcID:          "vpc-0123456789abcdef0",
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "vpcID with invalid format",
+			tgb: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+					VpcID:          "vpc-xyz",
+				},
+			},
+			wantErr: errors.New("TargetGroupBinding has invalid spec.vpcID: vpc-xyz"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &targetGroupBindingValidator{
+				logger: &log.NullLogger{},
+			}
+			err := v.checkVpcID(tt.tgb)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_targetGroupBindingValidator_checkNodeSelector(t *testing.T) {
+	tests := []struct {
+		name    string
+		tgb     *elbv2api.TargetGroupBinding
+		wantErr error
+	}{
+		{
+			name: "nodeSelector is not set",
+			tgb: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "nodeSelector is valid",
+			tgb: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+					NodeSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"node-group": "ingress",
+						},
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{
+								Key:      "topology.kubernetes.io/zone",
+								Operator: metav1.LabelSelectorOpIn,
+								Values:   []string{"us-west-2a", "us-west-2b"},
+							},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "nodeSelector with invalid operator",
+			tgb: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+					NodeSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{
+								Key:      "node-group",
+								Operator: "Equals",
+								Values:   []string{"ingress"},
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("TargetGroupBinding has invalid spec.nodeSelector: \"Equals\" is not a valid pod selector operator"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &targetGroupBindingValidator{
+				logger: &log.NullLogger{},
+			}
+			err := v.checkNodeSelector(tt.tgb)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_targetGroupBindingValidator_checkTargetGroupAttributes(t *testing.T) {
+	tests := []struct {
+		name    string
+		tgb     *elbv2api.TargetGroupBinding
+		wantErr error
+	}{
+		{
+			name: "targetGroupAttributes is not set",
+			tgb: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "targetGroupAttributes are valid",
+			tgb: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+					TargetGroupAttributes: []elbv2api.TargetGroupAttribute{
+						{Key: "stickiness.enabled", Value: "true"},
+						{Key: "stickiness.type", Value: "app_cookie"},
+						{Key: "stickiness.app_cookie.cookie_name", Value: "SESSIONID"},
+						{Key: "deregistration_delay.timeout_seconds", Value: "30"},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "targetGroupAttributes with unsupported key",
+			tgb: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+					TargetGroupAttributes: []elbv2api.TargetGroupAttribute{
+						{Key: "stickiness.enable", Value: "true"},
+					},
+				},
+			},
+			wantErr: errors.New("TargetGroupBinding has invalid spec.targetGroupAttributes: unsupported target group attribute stickiness.enable"),
+		},
+		{
+			name: "targetGroupAttributes with invalid value",
+			tgb: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+					TargetGroupAttributes: []elbv2api.TargetGroupAttribute{
+						{Key: "stickiness.type", Value: "cookie"},
+					},
+				},
+			},
+			wantErr: errors.New("TargetGroupBinding has invalid spec.targetGroupAttributes: invalid target group attribute stickiness.type: value cookie must be one of lb_cookie, app_cookie, source_ip, source_ip_dest_ip, source_ip_dest_ip_proto"),
+		},
+		{
+			name: "targetGroupAttributes with duplicate key",
+			tgb: &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "tg-1",
+					TargetGroupAttributes: []elbv2api.TargetGroupAttribute{
+						{Key: "stickiness.enabled", Value: "true"},
+						{Key: "stickiness.enabled", Value: "false"},
+					},
+				},
+			},
+			wantErr: errors.New("TargetGroupBinding has duplicate spec.targetGroupAttributes: stickiness.enabled"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &targetGroupBindingValidator{
+				logger: &log.NullLogger{},
+			}
+			err := v.checkTargetGroupAttributes(tt.tgb)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_targetGroupBindingValidator_checkIAMRoleARNToAssume(t *testing.T) {
+	ingClass := &networking.IngressClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "alb",
+			Annotations: map[string]string{
+				"alb.ingress.kubernetes.io/iam-role-arn": "arn:aws:iam::123456789012:role/ingress-class-role",
+			},
+		},
+	}
+	tests := []struct {
+		name               string
+		allowedIAMRoleARNs []string
+		iamRoleARNToAssume string
+		wantErr            error
+	}{
+		{
+			name:               "iamRoleARNToAssume is not set",
+			iamRoleARNToAssume: "",
+		},
+		{
+			name:               "iamRoleARNToAssume is allowed by flag",
+			allowedIAMRoleARNs: []string{"arn:aws:iam::123456789012:role/allowed-role"},
+			iamRoleARNToAssume: "arn:aws:iam::123456789012:role/allowed-role",
+		},
+		{
+			name:               "iamRoleARNToAssume is configured on IngressClass",
+			iamRoleARNToAssume: "arn:aws:iam::123456789012:role/ingress-class-role",
+		},
+		{
+			name:               "iamRoleARNToAssume is not allowed",
+			allowedIAMRoleARNs: []string{"arn:aws:iam::123456789012:role/allowed-role"},
+			iamRoleARNToAssume: "arn:aws:iam::123456789012:role/other-role",
+			wantErr:            errors.New("IAM role arn:aws:iam::123456789012:role/other-role for spec.iamRoleARNToAssume is not allowed, it must be allowed via --targetgroupbinding-allowed-iam-role-arns or configured on an IngressClass"),
+		},
+		{
+			name:               "iamRoleARNToAssume is not an IAM role ARN",
+			allowedIAMRoleARNs: []string{"arn:aws:iam::123456789012:user/someone"},
+			iamRoleARNToAssume: "arn:aws:iam::123456789012:user/someone",
+			wantErr:            errors.New("invalid IAM role ARN arn:aws:iam::123456789012:user/someone for spec.iamRoleARNToAssume"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			assert.NoError(t, k8sClient.Create(context.Background(), ingClass.DeepCopy()))
+			v := NewTargetGroupBindingValidator(k8sClient, tt.allowedIAMRoleARNs, &log.NullLogger{})
+			tgb := &elbv2api.TargetGroupBinding{
+				Spec: elbv2api.TargetGroupBindingSpec{
+					IAMRoleARNToAssume: tt.iamRoleARNToAssume,
+				},
+			}
+			err := v.checkIAMRoleARNToAssume(context.Background(), tgb)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,306 @@
+package networking
+
+import (
+	"context"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/quota"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/ingress"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/webhook"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const apiPathValidateNetworkingIngress = "/validate-networking-v1beta1-ingress"
+
+// NewIngressValidator returns a validator for Ingress API.
+func NewIngressValidator(k8sClient client.Client, ingConfig config.IngressConfig, eventRecorder record.EventRecorder, logger logr.Logger) *ingressValidator {
+	annotationParser := annotations.NewSuffixAnnotationParser(annotations.AnnotationPrefixIngress)
+	// group membership is validated against Ingresses in all namespaces, regardless of how they're sharded between controllers.
+	groupLoader := ingress.NewDefaultGroupLoader(k8sClient, eventRecorder, annotationParser,
+		k8s.NewDefaultNamespaceMatcher(k8sClient, labels.Everything()), ingConfig.IngressClass)
+	classParamsLoader := ingress.NewDefaultClassParamsLoader(k8sClient)
+	fargateDetector := k8s.NewDefaultFargateDetector(k8sClient)
+	return &ingressValidator{
+		k8sClient:                k8sClient,
+		annotationValidator:      ingress.NewAnnotationValidator(),
+		enhancedBackendBuilder:   ingress.NewDefaultEnhancedBackendBuilder(annotationParser),
+		groupMembershipValidator: ingress.NewDefaultGroupMembershipValidator(k8sClient, groupLoader, annotationParser),
+		groupLoader:              groupLoader,
+		targetTypeResolver: ingress.NewDefaultTargetTypeResolver(annotationParser, classParamsLoader,
+			fargateDetector, elbv2model.TargetType(ingConfig.DefaultTargetType)),
+		fargateDetector: fargateDetector,
+		logger:          logger,
+	}
+}
+
+var _ webhook.Validator = &ingressValidator{}
+
+type ingressValidator struct {
+	k8sClient                client.Client
+	annotationValidator      annotations.Validator
+	enhancedBackendBuilder   ingress.EnhancedBackendBuilder
+	groupMembershipValidator ingress.GroupMembershipValidator
+	groupLoader              ingress.GroupLoader
+	targetTypeResolver       ingress.TargetTypeResolver
+	fargateDetector          k8s.FargateDetector
+	logger                   logr.Logger
+}
+
+func (v *ingressValidator) Prototype(_ admission.Request) (runtime.Object, error) {
+	return &networking.Ingress{}, nil
+}
+
+func (v *ingressValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	ing := obj.(*networking.Ingress)
+	if managed, err := v.isManagedIngress(ctx, ing); err != nil || !managed {
+		return err
+	}
+	if err := v.checkAnnotations(ing, ing.Annotations); err != nil {
+		return err
+	}
+	if err := v.checkRuleHosts(ing); err != nil {
+		return err
+	}
+	if err := v.checkBackendAnnotations(ctx, ing); err != nil {
+		return err
+	}
+	if err := v.checkTargetTypes(ctx, ing); err != nil {
+		return err
+	}
+	if err := v.groupMembershipValidator.Validate(ctx, ing); err != nil {
+		return err
+	}
+	if err := v.checkNamespaceQuota(ctx, ing); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *ingressValidator) ValidateUpdate(ctx context.Context, obj runtime.Object, oldObj runtime.Object) error {
+	ing := obj.(*networking.Ingress)
+	oldIng := oldObj.(*networking.Ingress)
+	// Ingress being deleted only gets its finalizers removed, which shouldn't be blocked.
+	if !ing.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	if managed, err := v.isManagedIngress(ctx, ing); err != nil || !managed {
+		return err
+	}
+	if err := v.checkAnnotations(ing, annotations.ChangedAnnotations(ing.Annotations, oldIng.Annotations)); err != nil {
+		return err
+	}
+	if err := v.checkRuleHosts(ing); err != nil {
+		return err
+	}
+	if err := v.checkBackendAnnotations(ctx, ing); err != nil {
+		return err
+	}
+	if err := v.checkTargetTypes(ctx, ing); err != nil {
+		return err
+	}
+	if err := v.groupMembershipValidator.Validate(ctx, ing); err != nil {
+		return err
+	}
+	if err := v.checkNamespaceQuotaOnGroupChange(ctx, ing, oldIng); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *ingressValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+// isManagedIngress tests whether Ingress is handled by this controller, via either IngressClass or the ingress.class annotation.
+// Ingresses of other controllers are left alone, since they'd fail validation of annotations and backends that only this controller understands.
+func (v *ingressValidator) isManagedIngress(ctx context.Context, ing *networking.Ingress) (bool, error) {
+	groupID, err := v.groupLoader.FindGroupID(ctx, ing)
+	if err != nil {
+		// the IngressClass may not be created yet, it's reported during reconcile instead.
+		if ingress.IsInvalidIngressClassError(err) {
+			return false, nil
+		}
+		// Ingress with invalid group name is handled by this controller, which is reported by group membership validation.
+		return true, nil
+	}
+	return groupID != nil, nil
+}
+
+// checkNamespaceQuota checks the ALBs in namespace of Ingress don't exceed the limit of ResourceQuotaPolicies.
+// only Ingresses joining an IngressGroup without other members in namespace are checked, since the ALB of that IngressGroup becomes charged to namespace.
+func (v *ingressValidator) checkNamespaceQuota(ctx context.Context, ing *networking.Ingress) error {
+	groupID, err := v.groupLoader.FindGroupID(ctx, ing)
+	if err != nil || groupID == nil {
+		return nil
+	}
+	// Objects in namespace are only listed if namespace has a limit on ALBs, which is resolved from cache.
+	limits, err := quota.ResolveNamespaceLimits(ctx, v.k8sClient, ing.Namespace)
+	if err != nil {
+		return err
+	}
+	if _, limited := limits[quota.NamespaceResourceApplicationLoadBalancers]; !limited {
+		return nil
+	}
+	ingList := &networking.IngressList{}
+	if err := v.k8sClient.List(ctx, ingList, client.InNamespace(ing.Namespace)); err != nil {
+		return err
+	}
+	namespaceGroupIDs := sets.NewString()
+	for i := range ingList.Items {
+		member := &ingList.Items[i]
+		if member.Name == ing.Name || !member.DeletionTimestamp.IsZero() {
+			continue
+		}
+		memberGroupID, err := v.groupLoader.FindGroupID(ctx, member)
+		if err != nil || memberGroupID == nil {
+			continue
+		}
+		namespaceGroupIDs.Insert(memberGroupID.String())
+	}
+	if namespaceGroupIDs.Has(groupID.String()) {
+		return nil
+	}
+	return limits.Check(ing.Namespace, quota.NamespaceResourceApplicationLoadBalancers, int64(namespaceGroupIDs.Len()+1))
+}
+
+// checkNamespaceQuotaOnGroupChange checks the namespace quota of Ingress if it moves to another IngressGroup,
+// so that Ingresses in namespaces already beyond their limit can still be updated.
+func (v *ingressValidator) checkNamespaceQuotaOnGroupChange(ctx context.Context, ing *networking.Ingress, oldIng *networking.Ingress) error {
+	// Ingress being deleted releases its ALB, which shouldn't be blocked by namespace quota.
+	if !ing.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	groupID, err := v.groupLoader.FindGroupID(ctx, ing)
+	if err != nil {
+		return nil
+	}
+	oldGroupID, err := v.groupLoader.FindGroupID(ctx, oldIng)
+	if err != nil {
+		return nil
+	}
+	if groupID == nil || (oldGroupID != nil && *oldGroupID == *groupID) {
+		return nil
+	}
+	return v.checkNamespaceQuota(ctx, ing)
+}
+
+// checkAnnotations will check the annotations to validate on Ingress have values of their expected formats.
+func (v *ingressValidator) checkAnnotations(ing *networking.Ingress, annotationsToValidate map[string]string) error {
+	allErrs := v.annotationValidator.Validate(annotationsToValidate, field.NewPath("metadata", "annotations"))
+	if len(allErrs) != 0 {
+		return apierrors.NewInvalid(networking.SchemeGroupVersion.WithKind("Ingress").GroupKind(), ing.Name, allErrs)
+	}
+	return nil
+}
+
+// checkRuleHosts will check the hosts of Ingress rules are supported by ALB host-header conditions.
+func (v *ingressValidator) checkRuleHosts(ing *networking.Ingress) error {
+	for index, rule := range ing.Spec.Rules {
+		if len(rule.Host) == 0 {
+			continue
+		}
+		if err := ingress.ValidateHostPattern(rule.Host); err != nil {
+			return errors.Wrapf(err, "invalid host for rule %v", index)
+		}
+	}
+	return nil
+}
+
+// checkBackendAnnotations will check the actions and conditions annotations used by Ingress backends are valid.
+func (v *ingressValidator) checkBackendAnnotations(ctx context.Context, ing *networking.Ingress) error {
+	for _, backend := range listIngressBackends(ing) {
+		if _, err := v.enhancedBackendBuilder.Build(ctx, ing, backend); err != nil {
+			return errors.Wrapf(err, "invalid configuration for backend %v", backend.ServiceName)
+		}
+	}
+	return nil
+}
+
+// checkTargetTypes will check the backend Services of Ingress don't use instance targetType on Fargate,
+// either because they select only Fargate pods or because there are no nodes other than Fargate nodes in cluster.
+func (v *ingressValidator) checkTargetTypes(ctx context.Context, ing *networking.Ingress) error {
+	svcNames := sets.NewString()
+	for _, backend := range listIngressBackends(ing) {
+		enhancedBackend, err := v.enhancedBackendBuilder.Build(ctx, ing, backend)
+		if err != nil {
+			return err
+		}
+		if enhancedBackend.Action.ForwardConfig == nil {
+			continue
+		}
+		for _, tgTuple := range enhancedBackend.Action.ForwardConfig.TargetGroups {
+			if tgTuple.ServiceName != nil {
+				svcNames.Insert(*tgTuple.ServiceName)
+			}
+		}
+	}
+	// whether cluster is Fargate-only is only detected for backends with instance targetType, since it takes listing all nodes.
+	var fargateOnlyCluster *bool
+	for _, svcName := range svcNames.List() {
+		svc := &corev1.Service{}
+		if err := v.k8sClient.Get(ctx, types.NamespacedName{Namespace: ing.Namespace, Name: svcName}, svc); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+			svc = nil
+		}
+		targetType, err := v.targetTypeResolver.Resolve(ctx, ing, svc)
+		if err != nil {
+			return errors.Wrapf(err, "invalid targetType for backend %v", svcName)
+		}
+		if targetType != elbv2model.TargetTypeInstance {
+			continue
+		}
+		if fargateOnlyCluster == nil {
+			fargateOnly, err := v.fargateDetector.IsFargateOnlyCluster(ctx)
+			if err != nil {
+				return err
+			}
+			fargateOnlyCluster = &fargateOnly
+		}
+		if *fargateOnlyCluster {
+			return errors.Errorf("instance targetType is unsupported for backend %v on Fargate-only cluster, use ip targetType instead", svcName)
+		}
+	}
+	return nil
+}
+
+// listIngressBackends returns the backends of Ingress, including the default backend.
+func listIngressBackends(ing *networking.Ingress) []networking.IngressBackend {
+	var backends []networking.IngressBackend
+	if ing.Spec.Backend != nil {
+		backends = append(backends, *ing.Spec.Backend)
+	}
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			backends = append(backends, path.Backend)
+		}
+	}
+	return backends
+}
+
+// the webhook is registered with matchPolicy Equivalent by config/webhook/ingress_validator_patch.yaml,
+// so that Ingresses written as networking.k8s.io/v1 are validated as well.
+// +kubebuilder:webhook:path=/validate-networking-v1beta1-ingress,mutating=false,failurePolicy=fail,groups=networking.k8s.io,resources=ingresses,verbs=create;update,versions=v1beta1,name=vingress.elbv2.k8s.aws,sideEffects=None,webhookVersions=v1beta1
+
+func (v *ingressValidator) SetupWithManager(mgr ctrl.Manager) {
+	mgr.GetWebhookServer().Register(apiPathValidateNetworkingIngress, webhook.ValidatingWebhookForValidator(v))
+}
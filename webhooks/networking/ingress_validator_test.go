@@ -0,0 +1,496 @@
+package networking
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1beta1"
+	networking "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/ingress"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_ingressValidator_ValidateCreate(t *testing.T) {
+	type env struct {
+		nodes    []*corev1.Node
+		svcs     []*corev1.Service
+		epSlices []*discovery.EndpointSlice
+		ings     []*networking.Ingress
+		policies []*elbv2api.ResourceQuotaPolicy
+	}
+	type args struct {
+		obj *networking.Ingress
+	}
+	fargateNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "fargate-ip-192-168-1-1",
+			Labels: map[string]string{
+				"kubernetes.io/hostname":         "fargate-ip-192-168-1-1",
+				"eks.amazonaws.com/compute-type": "fargate",
+			},
+		},
+	}
+	ec2Node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "ip-192-168-1-2",
+		},
+	}
+	ingWithDefaultBackend := &networking.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "my-ns",
+			Name:      "my-ing",
+		},
+		Spec: networking.IngressSpec{
+			Backend: &networking.IngressBackend{
+				ServiceName: "svc-1",
+				ServicePort: intstr.FromInt(80),
+			},
+		},
+	}
+	albQuotaPolicy := &elbv2api.ResourceQuotaPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenants"},
+		Spec: elbv2api.ResourceQuotaPolicySpec{
+			Hard: elbv2api.ResourceQuotaLimits{
+				ApplicationLoadBalancers: awssdk.Int64(1),
+			},
+		},
+	}
+	existingGroupIng := &networking.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "my-ns",
+			Name:      "existing-ing",
+			Annotations: map[string]string{
+				"alb.ingress.kubernetes.io/group.name": "awesome-group",
+			},
+		},
+		Spec: networking.IngressSpec{
+			Backend: &networking.IngressBackend{
+				ServiceName: "svc-1",
+				ServicePort: intstr.FromInt(80),
+			},
+		},
+	}
+	tests := []struct {
+		name    string
+		env     env
+		args    args
+		wantErr error
+	}{
+		{
+			name: "ingress with valid actions",
+			args: args{
+				obj: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "my-ns",
+						Name:      "my-ing",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/actions.redirect-to-www": `{"type":"redirect","redirectConfig":{"host":"www.#{host}","statusCode":"HTTP_301"}}`,
+						},
+					},
+					Spec: networking.IngressSpec{
+						Backend: &networking.IngressBackend{
+							ServiceName: "redirect-to-www",
+							ServicePort: intstr.FromString("use-annotation"),
+						},
+						Rules: []networking.IngressRule{
+							{
+								IngressRuleValue: networking.IngressRuleValue{
+									HTTP: &networking.HTTPIngressRuleValue{
+										Paths: []networking.HTTPIngressPath{
+											{
+												Path: "/app",
+												Backend: networking.IngressBackend{
+													ServiceName: "svc-1",
+													ServicePort: intstr.FromInt(80),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "ingress with invalid actions",
+			args: args{
+				obj: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "my-ns",
+						Name:      "my-ing",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/actions.redirect-to-www": `{"type":"redirect","redirectConfig":{"host":"#{hostname}","statusCode":"HTTP_301"}}`,
+						},
+					},
+					Spec: networking.IngressSpec{
+						Rules: []networking.IngressRule{
+							{
+								IngressRuleValue: networking.IngressRuleValue{
+									HTTP: &networking.HTTPIngressRuleValue{
+										Paths: []networking.HTTPIngressPath{
+											{
+												Path: "/app",
+												Backend: networking.IngressBackend{
+													ServiceName: "redirect-to-www",
+													ServicePort: intstr.FromString("use-annotation"),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New(`Ingress.networking.k8s.io "my-ing" is invalid: metadata.annotations[alb.ingress.kubernetes.io/actions.redirect-to-www]: Invalid value: "{\"type\":\"redirect\",\"redirectConfig\":{\"host\":\"#{hostname}\",\"statusCode\":\"HTTP_301\"}}": invalid RedirectConfig: unsupported variable #{hostname} in redirect template: #{hostname}`),
+		},
+		{
+			name: "ingress with malformed annotations",
+			args: args{
+				obj: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "my-ns",
+						Name:      "my-ing",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/actions.response-503": `{"type":"fixed-response","fixedResponseConfig":{"statusCode":"503"`,
+							"alb.ingress.kubernetes.io/inbound-cidrs":        "10.0.0.0/16, 192.168.0.0",
+							"alb.ingress.kubernetes.io/tags":                 "env=dev,team",
+							"alb.ingress.kubernetes.io/scheme":               "internet-facing",
+							"alb.ingress.kubernetes.io/unknown-annotation":   "whatever",
+						},
+					},
+					Spec: networking.IngressSpec{
+						Backend: &networking.IngressBackend{
+							ServiceName: "svc-1",
+							ServicePort: intstr.FromInt(80),
+						},
+					},
+				},
+			},
+			wantErr: errors.New(`Ingress.networking.k8s.io "my-ing" is invalid: [` +
+				`metadata.annotations[alb.ingress.kubernetes.io/actions.response-503]: Invalid value: "{\"type\":\"fixed-response\",\"fixedResponseConfig\":{\"statusCode\":\"503\"": must be valid json: unexpected end of JSON input at offset 66, ` +
+				`metadata.annotations[alb.ingress.kubernetes.io/inbound-cidrs]: Invalid value: "10.0.0.0/16, 192.168.0.0": item 1 "192.168.0.0" must be a valid IPv4 or IPv6 CIDR like 10.0.0.0/16, ` +
+				`metadata.annotations[alb.ingress.kubernetes.io/tags]: Invalid value: "env=dev,team": must be comma separated key=value pairs: expect key=value pair: team]`),
+		},
+		{
+			name: "ingress of other controller with malformed annotations",
+			args: args{
+				obj: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "my-ns",
+						Name:      "my-ing",
+						Annotations: map[string]string{
+							"kubernetes.io/ingress.class":      "nginx",
+							"alb.ingress.kubernetes.io/scheme": "internet-facing",
+							"alb.ingress.kubernetes.io/tags":   "env=dev,team",
+						},
+					},
+					Spec: networking.IngressSpec{
+						Backend: &networking.IngressBackend{
+							ServiceName: "svc-1",
+							ServicePort: intstr.FromInt(80),
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "ingress with missing actions",
+			args: args{
+				obj: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "my-ns",
+						Name:      "my-ing",
+					},
+					Spec: networking.IngressSpec{
+						Backend: &networking.IngressBackend{
+							ServiceName: "response-503",
+							ServicePort: intstr.FromString("use-annotation"),
+						},
+					},
+				},
+			},
+			wantErr: errors.New("invalid configuration for backend response-503: missing actions.response-503 configuration"),
+		},
+		{
+			name: "ingress with regular expression host",
+			args: args{
+				obj: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "my-ns",
+						Name:      "my-ing",
+					},
+					Spec: networking.IngressSpec{
+						Rules: []networking.IngressRule{
+							{
+								Host: "(app|api).example.com",
+								IngressRuleValue: networking.IngressRuleValue{
+									HTTP: &networking.HTTPIngressRuleValue{
+										Paths: []networking.HTTPIngressPath{
+											{
+												Path: "/app",
+												Backend: networking.IngressBackend{
+													ServiceName: "svc-1",
+													ServicePort: intstr.FromInt(80),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("invalid host for rule 0: host (app|api).example.com looks like a regular expression, which is not supported, use * and ? wildcards instead"),
+		},
+		{
+			name: "ingress with invalid host-header conditions",
+			args: args{
+				obj: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "my-ns",
+						Name:      "my-ing",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/conditions.svc-1": `[{"field":"host-header","hostHeaderConfig":{"values":["app.example.com","api_v2.example.com"]}}]`,
+						},
+					},
+					Spec: networking.IngressSpec{
+						Rules: []networking.IngressRule{
+							{
+								Host: "*.example.com",
+								IngressRuleValue: networking.IngressRuleValue{
+									HTTP: &networking.HTTPIngressRuleValue{
+										Paths: []networking.HTTPIngressPath{
+											{
+												Path: "/app",
+												Backend: networking.IngressBackend{
+													ServiceName: "svc-1",
+													ServicePort: intstr.FromInt(80),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New(`Ingress.networking.k8s.io "my-ing" is invalid: metadata.annotations[alb.ingress.kubernetes.io/conditions.svc-1]: Invalid value: "[{\"field\":\"host-header\",\"hostHeaderConfig\":{\"values\":[\"app.example.com\",\"api_v2.example.com\"]}}]": invalid condition 0: invalid hostHeaderConfig: host api_v2.example.com must only contain A-Z, a-z, 0-9, -, ., * and ?`),
+		},
+		{
+			name: "ingress with invalid header name in listener attributes",
+			args: args{
+				obj: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "my-ns",
+						Name:      "my-ing",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/listener-attributes.HTTPS-443": "routing.http.request.x_amzn_tls_version.header_name=X:TLS",
+						},
+					},
+				},
+			},
+			wantErr: errors.New(`Ingress.networking.k8s.io "my-ing" is invalid: metadata.annotations[alb.ingress.kubernetes.io/listener-attributes.HTTPS-443]: Invalid value: "routing.http.request.x_amzn_tls_version.header_name=X:TLS": invalid listener attribute routing.http.request.x_amzn_tls_version.header_name=X:TLS, header name must be 1-40 alphanumeric characters, hyphens or underscores`),
+		},
+		{
+			name: "ingress with instance targetType on Fargate-only cluster",
+			env: env{
+				nodes: []*corev1.Node{fargateNode},
+			},
+			args: args{
+				obj: ingWithDefaultBackend,
+			},
+			wantErr: errors.New("instance targetType is unsupported for backend svc-1 on Fargate-only cluster, use ip targetType instead"),
+		},
+		{
+			name: "ingress with ip targetType from Service annotation on Fargate-only cluster",
+			env: env{
+				nodes: []*corev1.Node{fargateNode},
+				svcs: []*corev1.Service{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "my-ns",
+							Name:      "svc-1",
+							Annotations: map[string]string{
+								"alb.ingress.kubernetes.io/target-type": "ip",
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				obj: ingWithDefaultBackend,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "ingress with instance targetType from Service annotation for Service that selects only Fargate pods",
+			env: env{
+				nodes: []*corev1.Node{fargateNode, ec2Node},
+				svcs: []*corev1.Service{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "my-ns",
+							Name:      "svc-1",
+							Annotations: map[string]string{
+								"alb.ingress.kubernetes.io/target-type": "instance",
+							},
+						},
+					},
+				},
+				epSlices: []*discovery.EndpointSlice{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "my-ns",
+							Name:      "svc-1-abcde",
+							Labels: map[string]string{
+								"kubernetes.io/service-name": "svc-1",
+							},
+						},
+						AddressType: discovery.AddressTypeIPv4,
+						Endpoints: []discovery.Endpoint{
+							{
+								Addresses: []string{"192.168.1.1"},
+								TargetRef: &corev1.ObjectReference{Kind: "Pod", Namespace: "my-ns", Name: "pod-1"},
+								Topology: map[string]string{
+									"kubernetes.io/hostname": "fargate-ip-192-168-1-1",
+								},
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				obj: ingWithDefaultBackend,
+			},
+			wantErr: errors.New("invalid targetType for backend svc-1: instance targetType is unsupported for service my-ns/svc-1 that selects only Fargate pods, use ip targetType instead"),
+		},
+		{
+			name: "ingress with instance targetType on cluster with EC2 nodes",
+			env: env{
+				nodes: []*corev1.Node{fargateNode, ec2Node},
+			},
+			args: args{
+				obj: ingWithDefaultBackend,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "ingress joining existing IngressGroup within namespace quota",
+			env: env{
+				nodes:    []*corev1.Node{ec2Node},
+				ings:     []*networking.Ingress{existingGroupIng},
+				policies: []*elbv2api.ResourceQuotaPolicy{albQuotaPolicy},
+			},
+			args: args{
+				obj: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "my-ns",
+						Name:      "my-ing",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/group.name": "awesome-group",
+						},
+					},
+					Spec: networking.IngressSpec{
+						Rules: []networking.IngressRule{
+							{
+								IngressRuleValue: networking.IngressRuleValue{
+									HTTP: &networking.HTTPIngressRuleValue{
+										Paths: []networking.HTTPIngressPath{
+											{
+												Path: "/app",
+												Backend: networking.IngressBackend{
+													ServiceName: "svc-1",
+													ServicePort: intstr.FromInt(80),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "ingress exceeding namespace quota",
+			env: env{
+				nodes:    []*corev1.Node{ec2Node},
+				ings:     []*networking.Ingress{existingGroupIng},
+				policies: []*elbv2api.ResourceQuotaPolicy{albQuotaPolicy},
+			},
+			args: args{
+				obj: ingWithDefaultBackend,
+			},
+			wantErr: errors.New("ResourceQuotaPolicy tenants exceeded for applicationLoadBalancers in namespace my-ns: 2 desired, limit 1"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			annotationParser := annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			for _, node := range tt.env.nodes {
+				assert.NoError(t, k8sClient.Create(ctx, node.DeepCopy()))
+			}
+			for _, svc := range tt.env.svcs {
+				assert.NoError(t, k8sClient.Create(ctx, svc.DeepCopy()))
+			}
+			for _, epSlice := range tt.env.epSlices {
+				assert.NoError(t, k8sClient.Create(ctx, epSlice.DeepCopy()))
+			}
+			for _, ing := range tt.env.ings {
+				assert.NoError(t, k8sClient.Create(ctx, ing.DeepCopy()))
+			}
+			for _, policy := range tt.env.policies {
+				assert.NoError(t, k8sClient.Create(ctx, policy.DeepCopy()))
+			}
+			assert.NoError(t, k8sClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "my-ns"}}))
+			groupLoader := ingress.NewDefaultGroupLoader(k8sClient, record.NewFakeRecorder(10), annotationParser, k8s.NewDefaultNamespaceMatcher(k8sClient, labels.Everything()), "")
+			fargateDetector := k8s.NewDefaultFargateDetector(k8sClient)
+			v := &ingressValidator{
+				k8sClient:                k8sClient,
+				annotationValidator:      ingress.NewAnnotationValidator(),
+				enhancedBackendBuilder:   ingress.NewDefaultEnhancedBackendBuilder(annotationParser),
+				groupMembershipValidator: ingress.NewDefaultGroupMembershipValidator(k8sClient, groupLoader, annotationParser),
+				groupLoader:              groupLoader,
+				targetTypeResolver: ingress.NewDefaultTargetTypeResolver(annotationParser, ingress.NewDefaultClassParamsLoader(k8sClient),
+					fargateDetector, elbv2model.TargetTypeInstance),
+				fargateDetector: fargateDetector,
+				logger:          &log.NullLogger{},
+			}
+			err := v.ValidateCreate(ctx, tt.args.obj)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}